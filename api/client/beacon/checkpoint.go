@@ -0,0 +1,148 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/container/trie"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrInvalidStateRoot is returned when a downloaded state does not match the state root of its block.
+var ErrInvalidStateRoot = errors.New("state root does not match the state root of the block")
+
+// OriginData is a finalized block along with its post state, downloaded from a beacon node to start
+// syncing from instead of from genesis, and the deposit tree snapshot of the beacon node if it serves one.
+type OriginData struct {
+	State           state.BeaconState
+	Block           block.SignedBeaconBlock
+	StateBytes      []byte
+	BlockBytes      []byte
+	DepositSnapshot *trie.DepositTreeSnapshot
+}
+
+// DownloadFinalizedData downloads the finalized state of the beacon node and the block it was last
+// updated by, and verifies that the state root of the block matches the state. If the finalized state
+// was advanced over empty slots following the block, the post state of the block is downloaded instead.
+func DownloadFinalizedData(ctx context.Context, client *Client) (*OriginData, error) {
+	sb, err := client.GetState(ctx, IdFinalized)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not download finalized state")
+	}
+	st, err := unmarshalState(sb)
+	if err != nil {
+		return nil, err
+	}
+	stateRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute finalized state root")
+	}
+	header := st.LatestBlockHeader()
+	if bytesutil.ToBytes32(header.StateRoot) == [32]byte{} {
+		header.StateRoot = stateRoot[:]
+	}
+	blockRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute finalized block root")
+	}
+
+	bb, err := client.GetBlock(ctx, IdFromRoot(blockRoot))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not download finalized block %#x", blockRoot)
+	}
+	cf, err := detect.FromState(sb)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := cf.UnmarshalBeaconBlock(bb)
+	if err != nil {
+		return nil, err
+	}
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute downloaded block root")
+	}
+	if root != blockRoot {
+		return nil, fmt.Errorf("downloaded block root %#x does not match the requested root %#x", root, blockRoot)
+	}
+
+	blockStateRoot := bytesutil.ToBytes32(blk.Block().StateRoot())
+	if blockStateRoot != stateRoot {
+		// The state was advanced over empty slots, download the post state of the block.
+		sb, err = client.GetState(ctx, IdFromRoot(blockStateRoot))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not download state %#x", blockStateRoot)
+		}
+		st, err = unmarshalState(sb)
+		if err != nil {
+			return nil, err
+		}
+		stateRoot, err = st.HashTreeRoot(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute state root")
+		}
+		if stateRoot != blockStateRoot {
+			return nil, errors.Wrapf(ErrInvalidStateRoot, "state root %#x, block state root %#x", stateRoot, blockStateRoot)
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"slot":      blk.Block().Slot(),
+		"blockRoot": fmt.Sprintf("%#x", blockRoot),
+		"stateRoot": fmt.Sprintf("%#x", stateRoot),
+		"fork":      version.String(st.Version()),
+	}).Info("Downloaded checkpoint sync state and block")
+
+	od := &OriginData{
+		State:      st,
+		Block:      blk,
+		StateBytes: sb,
+		BlockBytes: bb,
+	}
+	od.DepositSnapshot, err = downloadDepositSnapshot(ctx, client, st)
+	if err != nil {
+		log.WithError(err).Warn("Could not download deposit snapshot, deposits will be processed from the deposit contract deployment")
+	}
+	return od, nil
+}
+
+// Downloads the deposit tree snapshot of the beacon node, if it matches the eth1 data of the state.
+func downloadDepositSnapshot(ctx context.Context, client *Client, st state.BeaconState) (*trie.DepositTreeSnapshot, error) {
+	enc, err := client.GetDepositSnapshot(ctx)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &trie.DepositTreeSnapshot{}
+	if err := snapshot.UnmarshalSSZ(enc); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal deposit snapshot")
+	}
+	eth1Data := st.Eth1Data()
+	if snapshot.DepositCount != eth1Data.DepositCount || snapshot.DepositRoot != bytesutil.ToBytes32(eth1Data.DepositRoot) {
+		return nil, fmt.Errorf(
+			"deposit snapshot of %d deposits with root %#x does not match the eth1 data of the state",
+			snapshot.DepositCount,
+			snapshot.DepositRoot,
+		)
+	}
+	return snapshot, nil
+}
+
+func unmarshalState(sb []byte) (state.BeaconState, error) {
+	cf, err := detect.FromState(sb)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect fork of downloaded state")
+	}
+	st, err := cf.UnmarshalBeaconState(sb)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal downloaded state")
+	}
+	return st, nil
+}
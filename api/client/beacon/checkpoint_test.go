@@ -0,0 +1,142 @@
+package beacon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/container/trie"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+// Serves SSZ encoded values by request path.
+func testServer(t *testing.T, values map[string][]byte) *Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/octet-stream", r.Header.Get("Accept"))
+		v, ok := values[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write(v)
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+	c, err := NewClient(srv.URL)
+	require.NoError(t, err)
+	return c
+}
+
+// Returns a block and its post state, with the given eth1 data.
+func testBlockAndState(t *testing.T, eth1Data *ethpb.Eth1Data) (*ethpb.SignedBeaconBlock, state.BeaconState) {
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetEth1Data(eth1Data))
+	require.NoError(t, st.SetFork(&ethpb.Fork{
+		PreviousVersion: params.BeaconConfig().GenesisForkVersion,
+		CurrentVersion:  params.BeaconConfig().GenesisForkVersion,
+	}))
+	require.NoError(t, st.SetSlot(5))
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 5
+	blk.Block.ParentRoot = []byte{'a', 31: 0}
+	bodyRoot, err := blk.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestBlockHeader(&ethpb.BeaconBlockHeader{
+		Slot:       blk.Block.Slot,
+		ParentRoot: blk.Block.ParentRoot,
+		StateRoot:  make([]byte, 32),
+		BodyRoot:   bodyRoot[:],
+	}))
+	stateRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	blk.Block.StateRoot = stateRoot[:]
+	return blk, st
+}
+
+func TestDownloadFinalizedData(t *testing.T) {
+	ctx := context.Background()
+	blk, st := testBlockAndState(t, &ethpb.Eth1Data{DepositRoot: make([]byte, 32), BlockHash: make([]byte, 32)})
+	blockRoot, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	sb, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	bb, err := blk.MarshalSSZ()
+	require.NoError(t, err)
+
+	client := testServer(t, map[string][]byte{
+		"/eth/v2/debug/beacon/states/finalized":                  sb,
+		"/eth/v2/beacon/blocks/" + string(IdFromRoot(blockRoot)): bb,
+	})
+	od, err := DownloadFinalizedData(ctx, client)
+	require.NoError(t, err)
+	assert.DeepEqual(t, sb, od.StateBytes)
+	assert.DeepEqual(t, bb, od.BlockBytes)
+	assert.Equal(t, blk.Block.Slot, od.Block.Block().Slot())
+	assert.Equal(t, (*trie.DepositTreeSnapshot)(nil), od.DepositSnapshot)
+}
+
+func TestDownloadFinalizedData_AdvancedState(t *testing.T) {
+	ctx := context.Background()
+	items := [][]byte{{'a'}, {'b'}}
+	depositTrie, err := trie.GenerateTrieFromItems(items, params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	depositRoot := depositTrie.HashTreeRoot()
+
+	blk, st := testBlockAndState(t, &ethpb.Eth1Data{DepositRoot: depositRoot[:], DepositCount: 2, BlockHash: make([]byte, 32)})
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	blockRoot, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	// The finalized state was advanced over two empty slots.
+	advanced := st.Copy()
+	header := advanced.LatestBlockHeader()
+	header.StateRoot = stateRoot[:]
+	require.NoError(t, advanced.SetLatestBlockHeader(header))
+	require.NoError(t, advanced.SetSlot(7))
+
+	sb, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	advancedBytes, err := advanced.MarshalSSZ()
+	require.NoError(t, err)
+	bb, err := blk.MarshalSSZ()
+	require.NoError(t, err)
+	snapshot, err := depositTrie.Snapshot(2, [32]byte{}, 10)
+	require.NoError(t, err)
+	snapshotBytes, err := snapshot.MarshalSSZ()
+	require.NoError(t, err)
+
+	values := map[string][]byte{
+		"/eth/v2/debug/beacon/states/finalized":                        advancedBytes,
+		"/eth/v2/debug/beacon/states/" + string(IdFromRoot(stateRoot)): sb,
+		"/eth/v2/beacon/blocks/" + string(IdFromRoot(blockRoot)):       bb,
+		"/eth/v1/beacon/deposit_snapshot":                              snapshotBytes,
+	}
+	od, err := DownloadFinalizedData(ctx, testServer(t, values))
+	require.NoError(t, err)
+	assert.DeepEqual(t, sb, od.StateBytes)
+	assert.Equal(t, blk.Block.Slot, od.State.Slot())
+	require.NotNil(t, od.DepositSnapshot)
+	assert.Equal(t, depositRoot, od.DepositSnapshot.DepositRoot)
+
+	// The post state of the block must match its state root.
+	values["/eth/v2/debug/beacon/states/"+string(IdFromRoot(stateRoot))] = advancedBytes
+	_, err = DownloadFinalizedData(ctx, testServer(t, values))
+	require.ErrorIs(t, err, ErrInvalidStateRoot)
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient("localhost:3500")
+	require.ErrorContains(t, "invalid beacon node url", err)
+	c, err := NewClient("http://localhost:3500/prefix")
+	require.NoError(t, err)
+	assert.Equal(t, true, strings.HasSuffix(c.baseURL.Path, "/prefix"))
+}
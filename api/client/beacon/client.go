@@ -0,0 +1,124 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+)
+
+const (
+	getSignedBlockPath     = "/eth/v2/beacon/blocks"
+	getStatePath           = "/eth/v2/debug/beacon/states"
+	getDepositSnapshotPath = "/eth/v1/beacon/deposit_snapshot"
+)
+
+// Default timeout of requests to the beacon node. States are several hundred megabytes on mainnet.
+const defaultRequestTimeout = 10 * time.Minute
+
+// ErrNotFound is returned when the beacon node does not have the requested value.
+var ErrNotFound = errors.New("not found")
+
+// StateOrBlockId identifies a state or block in the Beacon API: "head", "genesis", "finalized",
+// "justified", a slot or a 0x prefixed hex encoded root.
+type StateOrBlockId string
+
+const (
+	IdFinalized StateOrBlockId = "finalized"
+	IdGenesis   StateOrBlockId = "genesis"
+	IdHead      StateOrBlockId = "head"
+)
+
+// IdFromRoot encodes a root as a StateOrBlockId.
+func IdFromRoot(r [32]byte) StateOrBlockId {
+	return StateOrBlockId(fmt.Sprintf("%#x", r))
+}
+
+// ClientOpt is a functional option for the Client type.
+type ClientOpt func(*Client)
+
+// WithTimeout sets the timeout of the requests to the beacon node.
+func WithTimeout(timeout time.Duration) ClientOpt {
+	return func(c *Client) {
+		c.hc.Timeout = timeout
+	}
+}
+
+// Client retrieves SSZ encoded values from a beacon node over the standard Beacon API.
+type Client struct {
+	hc      *http.Client
+	baseURL *url.URL
+}
+
+// NewClient creates a client for the beacon node serving the Beacon API at the given host, which
+// may include a scheme, a port and a path prefix.
+func NewClient(host string, opts ...ClientOpt) (*Client, error) {
+	u, err := url.ParseRequestURI(host)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid beacon node url %q", host)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid beacon node url %q, the scheme must be http or https", host)
+	}
+	c := &Client{
+		hc:      &http.Client{Timeout: defaultRequestTimeout},
+		baseURL: u,
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c, nil
+}
+
+// GetState retrieves the SSZ encoded BeaconState identified by the given id.
+func (c *Client) GetState(ctx context.Context, id StateOrBlockId) ([]byte, error) {
+	return c.getSSZ(ctx, path.Join(getStatePath, string(id)))
+}
+
+// GetBlock retrieves the SSZ encoded SignedBeaconBlock identified by the given id.
+func (c *Client) GetBlock(ctx context.Context, id StateOrBlockId) ([]byte, error) {
+	return c.getSSZ(ctx, path.Join(getSignedBlockPath, string(id)))
+}
+
+// GetDepositSnapshot retrieves the SSZ encoded EIP-4881 snapshot of the finalized deposit tree.
+func (c *Client) GetDepositSnapshot(ctx context.Context) ([]byte, error) {
+	return c.getSSZ(ctx, getDepositSnapshotPath)
+}
+
+func (c *Client) getSSZ(ctx context.Context, p string) ([]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon.Client.getSSZ")
+	defer span.End()
+	u := *c.baseURL
+	u.Path = path.Join(c.baseURL.Path, p)
+	span.AddAttributes(trace.StringAttribute("path", p))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not request %s", p)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errors.Wrapf(ErrNotFound, "%s", p)
+	case resp.StatusCode != http.StatusOK:
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("request to %s failed with status %d: %s", p, resp.StatusCode, body)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
@@ -7,6 +7,10 @@ import (
 	"github.com/gorilla/mux"
 )
 
+// InternalAuthHeader marks requests proxied by the API middleware to grpc-gateway. These requests
+// were already authenticated and rate limited when they first reached the gateway.
+const InternalAuthHeader = "X-Prysm-Gateway-Auth"
+
 // ApiProxyMiddleware is a proxy between an Ethereum consensus API HTTP client and grpc-gateway.
 // The purpose of the proxy is to handle HTTP requests and gRPC responses in such a way that:
 //   - Ethereum consensus API requests can be handled by grpc-gateway correctly
@@ -166,6 +170,20 @@ func (m *ApiProxyMiddleware) WithMiddleware(path string) http.HandlerFunc {
 			}
 		}
 
+		if !GrpcResponseIsEmpty(respJson) {
+			if HandleConditionalGet(req, grpcResp, respJson, w) {
+				if errJson := Cleanup(grpcResp.Body); errJson != nil {
+					WriteError(w, errJson, nil)
+				}
+				return
+			}
+			respJson, errJson = CompressResponseBody(req, respJson, w)
+			if errJson != nil {
+				WriteError(w, errJson, nil)
+				return
+			}
+		}
+
 		if errJson := WriteMiddlewareResponseHeadersAndBody(grpcResp, respJson, w); errJson != nil {
 			WriteError(w, errJson, nil)
 			return
@@ -165,10 +165,13 @@ func SerializeMiddlewareResponseIntoJson(responseContainer interface{}) (jsonRes
 func WriteMiddlewareResponseHeadersAndBody(grpcResp *http.Response, responseJson []byte, w http.ResponseWriter) ErrorJson {
 	var statusCodeHeader string
 	for h, vs := range grpcResp.Header {
-		// We don't want to expose any gRPC metadata in the HTTP response, so we skip forwarding metadata headers.
+		// We don't want to expose any gRPC metadata in the HTTP response, so we skip forwarding metadata headers,
+		// except for the execution optimistic flag which is part of the API.
 		if strings.HasPrefix(h, "Grpc-Metadata") {
 			if h == "Grpc-Metadata-"+grpc.HttpCodeMetadataKey {
 				statusCodeHeader = vs[0]
+			} else if h == "Grpc-Metadata-"+grpc.ExecutionOptimisticMetadataKey {
+				w.Header().Set(grpc.ExecutionOptimisticMetadataKey, vs[0])
 			}
 		} else {
 			for _, v := range vs {
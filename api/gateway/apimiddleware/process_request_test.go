@@ -298,6 +298,25 @@ func TestWriteMiddlewareResponseHeadersAndBody(t *testing.T) {
 		assert.Equal(t, 204, writer.Code)
 	})
 
+	t.Run("GET_execution_optimistic", func(t *testing.T) {
+		response := &http.Response{
+			Header: http.Header{
+				"Grpc-Metadata-" + grpc.ExecutionOptimisticMetadataKey: []string{"true"},
+				"Grpc-Metadata-Other": []string{"other"},
+			},
+			StatusCode: 200,
+		}
+		container := defaultResponseContainer()
+		responseJson, err := json.Marshal(container)
+		require.NoError(t, err)
+		writer := httptest.NewRecorder()
+
+		errJson := WriteMiddlewareResponseHeadersAndBody(response, responseJson, writer)
+		require.Equal(t, true, errJson == nil)
+		assert.Equal(t, "true", writer.Header().Get(grpc.ExecutionOptimisticMetadataKey))
+		assert.Equal(t, "", writer.Header().Get("Grpc-Metadata-Other"))
+	})
+
 	t.Run("GET_invalid_status_code", func(t *testing.T) {
 		response := &http.Response{
 			Header: http.Header{},
@@ -0,0 +1,110 @@
+package apimiddleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/prysmaticlabs/prysm/api/grpc"
+)
+
+// minGzipSize is the size of the smallest response body compressed with gzip. Compressing smaller
+// bodies costs more than it saves.
+const minGzipSize = 1024
+
+// HandleConditionalGet sets the ETag header of a successful response to a GET request. When the ETag
+// matches the If-None-Match header of the request, it writes a 304 Not Modified response without a body
+// and returns true.
+func HandleConditionalGet(req *http.Request, grpcResp *http.Response, responseBody []byte, w http.ResponseWriter) (handled bool) {
+	if req.Method != http.MethodGet || grpcResp.StatusCode != http.StatusOK {
+		return false
+	}
+	if _, ok := grpcResp.Header["Grpc-Metadata-"+grpc.HttpCodeMetadataKey]; ok {
+		// The status code was overridden by the server, so the response is not a plain success.
+		return false
+	}
+	etag := responseETag(responseBody)
+	w.Header().Set("ETag", etag)
+	if !etagMatches(req.Header.Values("If-None-Match"), etag) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// CompressResponseBody compresses a large response body with gzip when the request accepts it, setting
+// the Content-Encoding header of the response. The body is returned unchanged otherwise.
+func CompressResponseBody(req *http.Request, responseBody []byte, w http.ResponseWriter) ([]byte, ErrorJson) {
+	if len(responseBody) < minGzipSize {
+		return responseBody, nil
+	}
+	w.Header().Add("Vary", "Accept-Encoding")
+	if !gzipAccepted(req) {
+		return responseBody, nil
+	}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(responseBody); err != nil {
+		return nil, InternalServerErrorWithMessage(err, "could not compress response body")
+	}
+	if err := gz.Close(); err != nil {
+		return nil, InternalServerErrorWithMessage(err, "could not compress response body")
+	}
+	w.Header().Set("Content-Encoding", "gzip")
+	return buf.Bytes(), nil
+}
+
+// responseETag returns a weak entity tag of the response body. The tag is weak because the body might
+// be sent with different content encodings.
+func responseETag(responseBody []byte) string {
+	h := sha256.Sum256(responseBody)
+	return `W/"` + hex.EncodeToString(h[:16]) + `"`
+}
+
+// etagMatches compares the entity tags of If-None-Match headers with the given tag, using the weak
+// comparison required for If-None-Match.
+func etagMatches(ifNoneMatch []string, etag string) bool {
+	for _, header := range ifNoneMatch {
+		for _, tag := range strings.Split(header, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// gzipAccepted returns true if the Accept-Encoding headers of the request accept gzip with a non-zero
+// quality value.
+func gzipAccepted(req *http.Request) bool {
+	for _, header := range req.Header.Values("Accept-Encoding") {
+		for _, coding := range strings.Split(header, ",") {
+			parts := strings.Split(coding, ";")
+			name := strings.ToLower(strings.TrimSpace(parts[0]))
+			if name != "gzip" && name != "*" {
+				continue
+			}
+			quality := 1.0
+			for _, param := range parts[1:] {
+				param = strings.TrimSpace(param)
+				if strings.HasPrefix(param, "q=") {
+					q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+					if err != nil {
+						quality = 0
+					} else {
+						quality = q
+					}
+				}
+			}
+			if quality > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
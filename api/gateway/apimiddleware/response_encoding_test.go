@@ -0,0 +1,99 @@
+package apimiddleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/api/grpc"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestHandleConditionalGet(t *testing.T) {
+	body := []byte(`{"data":"foo"}`)
+	okResp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+
+	t.Run("no_if_none_match", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "http://foo.example", nil)
+		writer := httptest.NewRecorder()
+		assert.Equal(t, false, HandleConditionalGet(req, okResp, body, writer))
+		assert.Equal(t, responseETag(body), writer.Header().Get("ETag"))
+	})
+
+	t.Run("matching_etag", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "http://foo.example", nil)
+		req.Header.Set("If-None-Match", `"foo", `+responseETag(body))
+		writer := httptest.NewRecorder()
+		assert.Equal(t, true, HandleConditionalGet(req, okResp, body, writer))
+		assert.Equal(t, http.StatusNotModified, writer.Code)
+		assert.Equal(t, 0, writer.Body.Len())
+	})
+
+	t.Run("different_etag", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "http://foo.example", nil)
+		req.Header.Set("If-None-Match", responseETag([]byte("bar")))
+		writer := httptest.NewRecorder()
+		assert.Equal(t, false, HandleConditionalGet(req, okResp, body, writer))
+	})
+
+	t.Run("not_a_plain_success", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "http://foo.example", nil)
+		req.Header.Set("If-None-Match", "*")
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Grpc-Metadata-" + grpc.HttpCodeMetadataKey: []string{"206"}},
+		}
+		writer := httptest.NewRecorder()
+		assert.Equal(t, false, HandleConditionalGet(req, resp, body, writer))
+		assert.Equal(t, "", writer.Header().Get("ETag"))
+
+		req = httptest.NewRequest("POST", "http://foo.example", nil)
+		req.Header.Set("If-None-Match", "*")
+		assert.Equal(t, false, HandleConditionalGet(req, okResp, body, httptest.NewRecorder()))
+	})
+}
+
+func TestCompressResponseBody(t *testing.T) {
+	body := bytes.Repeat([]byte("foo"), minGzipSize)
+
+	t.Run("gzip_accepted", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "http://foo.example", nil)
+		req.Header.Set("Accept-Encoding", "deflate, gzip;q=0.5")
+		writer := httptest.NewRecorder()
+		compressed, errJson := CompressResponseBody(req, body, writer)
+		require.Equal(t, true, errJson == nil)
+		assert.Equal(t, "gzip", writer.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", writer.Header().Get("Vary"))
+		assert.Equal(t, true, len(compressed) < len(body))
+		r, err := gzip.NewReader(bytes.NewReader(compressed))
+		require.NoError(t, err)
+		decompressed, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		assert.DeepEqual(t, body, decompressed)
+	})
+
+	t.Run("gzip_not_accepted", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "http://foo.example", nil)
+		req.Header.Set("Accept-Encoding", "gzip;q=0")
+		writer := httptest.NewRecorder()
+		resp, errJson := CompressResponseBody(req, body, writer)
+		require.Equal(t, true, errJson == nil)
+		assert.Equal(t, "", writer.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", writer.Header().Get("Vary"))
+		assert.DeepEqual(t, body, resp)
+	})
+
+	t.Run("small_body", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "http://foo.example", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		writer := httptest.NewRecorder()
+		resp, errJson := CompressResponseBody(req, []byte("foo"), writer)
+		require.Equal(t, true, errJson == nil)
+		assert.Equal(t, "", writer.Header().Get("Content-Encoding"))
+		assert.DeepEqual(t, []byte("foo"), resp)
+	})
+}
@@ -0,0 +1,237 @@
+package gateway
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kevinms/leakybucket-go"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/api/gateway/apimiddleware"
+	"gopkg.in/yaml.v2"
+)
+
+// authConfig lists the bearer tokens accepted by the gateway. It is loaded from a YAML file.
+type authConfig struct {
+	Tokens []*tokenConfig `yaml:"tokens"`
+}
+
+// tokenConfig configures a bearer token. A token without routes can access every route.
+// Otherwise, it can only access the routes starting with one of the configured prefixes.
+type tokenConfig struct {
+	Name      string         `yaml:"name"`
+	Token     string         `yaml:"token"`
+	RateLimit *rateLimit     `yaml:"rate_limit"`
+	Routes    []*routeConfig `yaml:"routes"`
+}
+
+// routeConfig configures the routes starting with the prefix for a token, with an optional quota
+// applied on top of the rate limit of the token.
+type routeConfig struct {
+	Prefix    string     `yaml:"prefix"`
+	RateLimit *rateLimit `yaml:"rate_limit"`
+}
+
+// rateLimit is the number of requests allowed per second, along with the allowed burst of requests.
+type rateLimit struct {
+	PerSecond float64 `yaml:"per_second"`
+	Burst     int64   `yaml:"burst"`
+}
+
+// loadAuthConfig reads the gateway authentication config from a YAML file.
+func loadAuthConfig(path string) (*authConfig, error) {
+	enc, err := ioutil.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read gateway auth config")
+	}
+	cfg := &authConfig{}
+	if err := yaml.UnmarshalStrict(enc, cfg); err != nil {
+		return nil, errors.Wrap(err, "could not decode gateway auth config")
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid gateway auth config")
+	}
+	return cfg, nil
+}
+
+func (c *authConfig) validate() error {
+	if len(c.Tokens) == 0 {
+		return errors.New("no tokens configured")
+	}
+	tokens := make(map[string]bool, len(c.Tokens))
+	for i, t := range c.Tokens {
+		if t.Token == "" {
+			return fmt.Errorf("token %d is empty", i)
+		}
+		if tokens[t.Token] {
+			return fmt.Errorf("token %d is configured more than once", i)
+		}
+		tokens[t.Token] = true
+		if err := t.RateLimit.validate(); err != nil {
+			return errors.Wrapf(err, "token %d", i)
+		}
+		for _, r := range t.Routes {
+			if !strings.HasPrefix(r.Prefix, "/") {
+				return fmt.Errorf("route prefix %q of token %d must start with /", r.Prefix, i)
+			}
+			if err := r.RateLimit.validate(); err != nil {
+				return errors.Wrapf(err, "route %s of token %d", r.Prefix, i)
+			}
+		}
+	}
+	return nil
+}
+
+func (l *rateLimit) validate() error {
+	if l == nil {
+		return nil
+	}
+	if l.PerSecond <= 0 || l.Burst <= 0 {
+		return errors.New("rate limit must allow a positive number of requests per second and burst")
+	}
+	return nil
+}
+
+// authenticator checks the bearer token of the gateway requests, and enforces the rate limit of the
+// token and the quotas of its routes.
+type authenticator struct {
+	// tokens are keyed by the hash of the token, so that looking up a token does not leak its value
+	// through timing.
+	tokens         map[[32]byte]*tokenLimits
+	internalSecret string
+	lock           sync.Mutex
+}
+
+type tokenLimits struct {
+	name    string
+	limiter *leakybucket.LeakyBucket
+	routes  []*routeLimits
+}
+
+type routeLimits struct {
+	prefix  string
+	limiter *leakybucket.LeakyBucket
+}
+
+func newAuthenticator(cfg *authConfig) (*authenticator, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.Wrap(err, "could not generate internal secret")
+	}
+	a := &authenticator{
+		tokens:         make(map[[32]byte]*tokenLimits, len(cfg.Tokens)),
+		internalSecret: hex.EncodeToString(secret),
+	}
+	for _, t := range cfg.Tokens {
+		limits := &tokenLimits{
+			name:    t.Name,
+			limiter: newLimiter(t.RateLimit),
+			routes:  make([]*routeLimits, len(t.Routes)),
+		}
+		for i, r := range t.Routes {
+			limits.routes[i] = &routeLimits{prefix: r.Prefix, limiter: newLimiter(r.RateLimit)}
+		}
+		// Match the most specific route first.
+		sort.SliceStable(limits.routes, func(i, j int) bool {
+			return len(limits.routes[i].prefix) > len(limits.routes[j].prefix)
+		})
+		a.tokens[sha256.Sum256([]byte(t.Token))] = limits
+	}
+	return a, nil
+}
+
+func newLimiter(l *rateLimit) *leakybucket.LeakyBucket {
+	if l == nil {
+		return nil
+	}
+	return leakybucket.NewLeakyBucket(l.PerSecond, l.Burst)
+}
+
+// middleware rejects requests without a valid bearer token, requests to routes the token cannot
+// access and requests exceeding the limits of the token.
+func (a *authenticator) middleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if secret := r.Header.Get(apimiddleware.InternalAuthHeader); secret != "" {
+			if subtle.ConstantTimeCompare([]byte(secret), []byte(a.internalSecret)) != 1 {
+				writeAuthError(w, "Invalid internal request", http.StatusUnauthorized)
+				return
+			}
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		header := r.Header.Get("Authorization")
+		token := strings.TrimPrefix(header, "Bearer ")
+		limits, ok := a.tokens[sha256.Sum256([]byte(token))]
+		if token == header || !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeAuthError(w, "Missing or invalid bearer token", http.StatusUnauthorized)
+			return
+		}
+		route, ok := limits.route(r.URL.Path)
+		if !ok {
+			writeAuthError(w, "Token is not allowed to access this route", http.StatusForbidden)
+			return
+		}
+		if !a.allow(limits, route) {
+			log.WithField("token", limits.name).WithField("path", r.URL.Path).Debug("Gateway request rate limited")
+			writeAuthError(w, "Too many requests", http.StatusTooManyRequests)
+			return
+		}
+
+		// Requests proxied by the API middleware hit the gateway again, and must not be counted twice.
+		r.Header.Del("Authorization")
+		r.Header.Set(apimiddleware.InternalAuthHeader, a.internalSecret)
+		h.ServeHTTP(w, r)
+	})
+}
+
+// route returns the most specific configured route matching the path. A token without routes
+// matches every path.
+func (l *tokenLimits) route(path string) (*routeLimits, bool) {
+	if len(l.routes) == 0 {
+		return nil, true
+	}
+	for _, r := range l.routes {
+		if strings.HasPrefix(path, r.prefix) {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// allow counts a request against the rate limit of the token and the quota of the route, unless
+// either of them is exhausted.
+func (a *authenticator) allow(l *tokenLimits, r *routeLimits) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var routeLimiter *leakybucket.LeakyBucket
+	if r != nil {
+		routeLimiter = r.limiter
+	}
+	if l.limiter != nil && l.limiter.Remaining() < 1 {
+		return false
+	}
+	if routeLimiter != nil && routeLimiter.Remaining() < 1 {
+		return false
+	}
+	if l.limiter != nil {
+		l.limiter.Add(1)
+	}
+	if routeLimiter != nil {
+		routeLimiter.Add(1)
+	}
+	return true
+}
+
+func writeAuthError(w http.ResponseWriter, msg string, code int) {
+	apimiddleware.WriteError(w, &apimiddleware.DefaultErrorJson{Message: msg, Code: code}, nil)
+}
@@ -0,0 +1,172 @@
+package gateway
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/api/gateway/apimiddleware"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestLoadAuthConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+tokens:
+  - name: public
+    token: public-token
+    rate_limit:
+      per_second: 10
+      burst: 20
+    routes:
+      - prefix: /eth/v1/beacon/
+        rate_limit:
+          per_second: 1
+          burst: 2
+      - prefix: /eth/v1/node/
+  - name: admin
+    token: admin-token
+`), 0600))
+
+	cfg, err := loadAuthConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(cfg.Tokens))
+	public := cfg.Tokens[0]
+	assert.Equal(t, "public", public.Name)
+	assert.Equal(t, "public-token", public.Token)
+	require.NotNil(t, public.RateLimit)
+	assert.Equal(t, 10.0, public.RateLimit.PerSecond)
+	assert.Equal(t, int64(20), public.RateLimit.Burst)
+	require.Equal(t, 2, len(public.Routes))
+	assert.Equal(t, "/eth/v1/beacon/", public.Routes[0].Prefix)
+	assert.Equal(t, int64(2), public.Routes[0].RateLimit.Burst)
+	assert.Equal(t, (*rateLimit)(nil), public.Routes[1].RateLimit)
+	assert.Equal(t, (*rateLimit)(nil), cfg.Tokens[1].RateLimit)
+	assert.Equal(t, 0, len(cfg.Tokens[1].Routes))
+}
+
+func TestLoadAuthConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		errMsg string
+	}{
+		{
+			name:   "no tokens",
+			config: "tokens: []",
+			errMsg: "no tokens configured",
+		},
+		{
+			name:   "empty token",
+			config: "tokens: [{name: foo}]",
+			errMsg: "token 0 is empty",
+		},
+		{
+			name:   "duplicate token",
+			config: "tokens: [{token: foo}, {token: foo}]",
+			errMsg: "token 1 is configured more than once",
+		},
+		{
+			name:   "invalid rate limit",
+			config: "tokens: [{token: foo, rate_limit: {per_second: 1}}]",
+			errMsg: "rate limit must allow a positive number of requests per second and burst",
+		},
+		{
+			name:   "relative route",
+			config: "tokens: [{token: foo, routes: [{prefix: eth/v1/}]}]",
+			errMsg: "route prefix \"eth/v1/\" of token 0 must start with /",
+		},
+		{
+			name:   "unknown field",
+			config: "tokens: [{token: foo, quota: 1}]",
+			errMsg: "could not decode gateway auth config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "auth.yaml")
+			require.NoError(t, ioutil.WriteFile(path, []byte(tt.config), 0600))
+			_, err := loadAuthConfig(path)
+			assert.ErrorContains(t, tt.errMsg, err)
+		})
+	}
+}
+
+func TestAuthenticator_Middleware(t *testing.T) {
+	a, err := newAuthenticator(&authConfig{
+		Tokens: []*tokenConfig{
+			{
+				Name:      "public",
+				Token:     "public-token",
+				RateLimit: &rateLimit{PerSecond: 0.001, Burst: 3},
+				Routes: []*routeConfig{
+					{Prefix: "/eth/v1/beacon/", RateLimit: &rateLimit{PerSecond: 0.001, Burst: 1}},
+					{Prefix: "/eth/v1/beacon/genesis"},
+				},
+			},
+			{
+				Name:  "admin",
+				Token: "admin-token",
+			},
+		},
+	})
+	require.NoError(t, err)
+	var forwarded *http.Request
+	handler := a.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r
+	}))
+	serve := func(path, authorization string) *httptest.ResponseRecorder {
+		forwarded = nil
+		req := httptest.NewRequest(http.MethodGet, "http://foo.example"+path, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		writer := httptest.NewRecorder()
+		handler.ServeHTTP(writer, req)
+		return writer
+	}
+
+	t.Run("missing token", func(t *testing.T) {
+		writer := serve("/eth/v1/node/version", "")
+		assert.Equal(t, http.StatusUnauthorized, writer.Code)
+		assert.Equal(t, "Bearer", writer.Header().Get("WWW-Authenticate"))
+		assert.Equal(t, (*http.Request)(nil), forwarded)
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve("/eth/v1/node/version", "Bearer foo").Code)
+		assert.Equal(t, http.StatusUnauthorized, serve("/eth/v1/node/version", "admin-token").Code)
+	})
+	t.Run("route not allowed", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, serve("/eth/v1/node/version", "Bearer public-token").Code)
+	})
+	t.Run("rate limits", func(t *testing.T) {
+		// The genesis route has no quota, but counts against the rate limit of the token.
+		assert.Equal(t, http.StatusOK, serve("/eth/v1/beacon/genesis", "Bearer public-token").Code)
+		require.NotNil(t, forwarded)
+		assert.Equal(t, "", forwarded.Header.Get("Authorization"))
+		assert.Equal(t, a.internalSecret, forwarded.Header.Get(apimiddleware.InternalAuthHeader))
+		// The quota of the beacon routes is exhausted after a single request.
+		assert.Equal(t, http.StatusOK, serve("/eth/v1/beacon/headers", "Bearer public-token").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serve("/eth/v1/beacon/headers", "Bearer public-token").Code)
+		assert.Equal(t, http.StatusOK, serve("/eth/v1/beacon/genesis", "Bearer public-token").Code)
+		// The rate limit of the token is exhausted.
+		assert.Equal(t, http.StatusTooManyRequests, serve("/eth/v1/beacon/genesis", "Bearer public-token").Code)
+		// Other tokens are not affected.
+		assert.Equal(t, http.StatusOK, serve("/eth/v1/beacon/headers", "Bearer admin-token").Code)
+	})
+	t.Run("internal request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "http://foo.example/internal/eth/v1/node/version", nil)
+		req.Header.Set(apimiddleware.InternalAuthHeader, a.internalSecret)
+		writer := httptest.NewRecorder()
+		handler.ServeHTTP(writer, req)
+		assert.Equal(t, http.StatusOK, writer.Code)
+
+		req.Header.Set(apimiddleware.InternalAuthHeader, "foo")
+		writer = httptest.NewRecorder()
+		handler.ServeHTTP(writer, req)
+		assert.Equal(t, http.StatusUnauthorized, writer.Code)
+	})
+}
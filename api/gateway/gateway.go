@@ -14,6 +14,7 @@ import (
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/api/gateway/apimiddleware"
+	grpcutil "github.com/prysmaticlabs/prysm/api/grpc"
 	"github.com/prysmaticlabs/prysm/runtime"
 	"github.com/rs/cors"
 	"google.golang.org/grpc"
@@ -45,6 +46,8 @@ type MuxHandler func(
 type config struct {
 	maxCallRecvMsgSize           uint64
 	remoteCert                   string
+	remoteClientCert             string
+	remoteClientKey              string
 	gatewayAddr                  string
 	remoteAddr                   string
 	allowedOrigins               []string
@@ -52,6 +55,7 @@ type config struct {
 	muxHandler                   MuxHandler
 	pbHandlers                   []*PbMux
 	router                       *mux.Router
+	auth                         *authenticator
 }
 
 // Gateway is the gRPC gateway to serve HTTP JSON traffic as a proxy and forward it to the gRPC server.
@@ -107,7 +111,11 @@ func (g *Gateway) Start() {
 		}
 	}
 
-	corsMux := g.corsMiddleware(g.cfg.router)
+	var handler http.Handler = g.cfg.router
+	if g.cfg.auth != nil {
+		handler = g.cfg.auth.middleware(handler)
+	}
+	corsMux := g.corsMiddleware(handler)
 
 	if g.cfg.apiMiddlewareEndpointFactory != nil && !g.cfg.apiMiddlewareEndpointFactory.IsNil() {
 		g.registerApiMiddleware()
@@ -209,7 +217,13 @@ func (g *Gateway) dial(ctx context.Context, network, addr string) (*grpc.ClientC
 // "addr" must be a valid TCP address with a port number.
 func (g *Gateway) dialTCP(ctx context.Context, addr string) (*grpc.ClientConn, error) {
 	security := grpc.WithInsecure()
-	if len(g.cfg.remoteCert) > 0 {
+	if len(g.cfg.remoteClientCert) > 0 {
+		creds, err := grpcutil.NewClientMutualTLSFromFile(g.cfg.remoteCert, g.cfg.remoteClientCert, g.cfg.remoteClientKey)
+		if err != nil {
+			return nil, err
+		}
+		security = grpc.WithTransportCredentials(creds)
+	} else if len(g.cfg.remoteCert) > 0 {
 		creds, err := credentials.NewClientTLSFromFile(g.cfg.remoteCert, "")
 		if err != nil {
 			return nil, err
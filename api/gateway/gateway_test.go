@@ -36,6 +36,8 @@ func (*mockEndpointFactory) IsNil() bool {
 func TestGateway_Customized(t *testing.T) {
 	r := mux.NewRouter()
 	cert := "cert"
+	clientCert := "client_cert"
+	clientKey := "client_key"
 	origins := []string{"origin"}
 	size := uint64(100)
 	endpointFactory := &mockEndpointFactory{}
@@ -43,6 +45,7 @@ func TestGateway_Customized(t *testing.T) {
 	opts := []Option{
 		WithRouter(r),
 		WithRemoteCert(cert),
+		WithRemoteClientCert(clientCert, clientKey),
 		WithAllowedOrigins(origins),
 		WithMaxCallRecvMsgSize(size),
 		WithApiMiddleware(endpointFactory),
@@ -60,6 +63,8 @@ func TestGateway_Customized(t *testing.T) {
 
 	assert.Equal(t, r, g.cfg.router)
 	assert.Equal(t, cert, g.cfg.remoteCert)
+	assert.Equal(t, clientCert, g.cfg.remoteClientCert)
+	assert.Equal(t, clientKey, g.cfg.remoteClientKey)
 	require.Equal(t, 1, len(g.cfg.allowedOrigins))
 	assert.Equal(t, origins[0], g.cfg.allowedOrigins[0])
 	assert.Equal(t, size, g.cfg.maxCallRecvMsgSize)
@@ -64,6 +64,16 @@ func WithRemoteCert(cert string) Option {
 	}
 }
 
+// WithRemoteClientCert allows the gateway to present a client certificate to the gRPC server,
+// which is required when the server verifies the certificates of its clients.
+func WithRemoteClientCert(cert, key string) Option {
+	return func(g *Gateway) error {
+		g.cfg.remoteClientCert = cert
+		g.cfg.remoteClientKey = key
+		return nil
+	}
+}
+
 // WithMaxCallRecvMsgSize allows specifying the maximum allowed gRPC message size.
 func WithMaxCallRecvMsgSize(size uint64) Option {
 	return func(g *Gateway) error {
@@ -79,3 +89,16 @@ func WithApiMiddleware(endpointFactory apimiddleware.EndpointFactory) Option {
 		return nil
 	}
 }
+
+// WithAuthConfig requires the gateway requests to be authenticated with one of the bearer tokens
+// listed in the YAML file at the given path, enforcing the rate limits and route quotas of the token.
+func WithAuthConfig(path string) Option {
+	return func(g *Gateway) error {
+		cfg, err := loadAuthConfig(path)
+		if err != nil {
+			return err
+		}
+		g.cfg.auth, err = newAuthenticator(cfg)
+		return err
+	}
+}
@@ -94,3 +94,12 @@ func AppendCustomErrorHeader(ctx context.Context, errorData interface{}) error {
 	}
 	return nil
 }
+
+// AppendExecutionOptimisticHeader sets an ExecutionOptimisticMetadataKey gRPC header on the passed in
+// context, flagging the response as served from an optimistically imported block.
+func AppendExecutionOptimisticHeader(ctx context.Context) error {
+	if err := grpc.SetHeader(ctx, metadata.Pairs(ExecutionOptimisticMetadataKey, "true")); err != nil {
+		return fmt.Errorf("could not set execution optimistic header: %w", err)
+	}
+	return nil
+}
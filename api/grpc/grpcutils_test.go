@@ -76,3 +76,13 @@ func TestAppendCustomErrorHeader(t *testing.T) {
 	assert.Equal(t, string(expected), value[0])
 
 }
+
+func TestAppendExecutionOptimisticHeader(t *testing.T) {
+	stream := &runtime.ServerTransportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	require.NoError(t, AppendExecutionOptimisticHeader(ctx))
+	// The stream used in test setup sets the metadata key in lowercase.
+	value, ok := stream.Header()[strings.ToLower(ExecutionOptimisticMetadataKey)]
+	require.Equal(t, true, ok, "Failed to retrieve execution optimistic metadata value")
+	assert.Equal(t, "true", value[0])
+}
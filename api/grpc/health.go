@@ -0,0 +1,19 @@
+package grpc
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// NewHealthServer registers a grpc.health.v1 health service on the given server, so that tools such as
+// grpcurl, load balancers and Kubernetes probes can health check it without a custom client. Every service
+// registered on the server before this call, as well as the server as a whole, is reported as serving.
+func NewHealthServer(s *grpc.Server) *health.Server {
+	hs := health.NewServer()
+	for service := range s.GetServiceInfo() {
+		hs.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(s, hs)
+	return hs
+}
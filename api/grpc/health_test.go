@@ -0,0 +1,33 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func TestNewHealthServer(t *testing.T) {
+	s := grpc.NewServer()
+	reflection.Register(s)
+	hs := NewHealthServer(s)
+
+	for _, service := range []string{"", "grpc.reflection.v1alpha.ServerReflection"} {
+		resp, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	}
+	_, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "foo"})
+	assert.ErrorContains(t, "unknown service", err)
+	_, ok := s.GetServiceInfo()[healthpb.Health_ServiceDesc.ServiceName]
+	assert.Equal(t, true, ok)
+
+	hs.Shutdown()
+	resp, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+}
@@ -6,3 +6,7 @@ const CustomErrorMetadataKey = "Custom-Error"
 
 // HttpCodeMetadataKey is the key to use when setting custom HTTP status codes in gRPC metadata.
 const HttpCodeMetadataKey = "X-Http-Code"
+
+// ExecutionOptimisticMetadataKey is the key of the gRPC header set on responses served from a block
+// imported optimistically, whose execution payload was not yet validated by the execution node.
+const ExecutionOptimisticMetadataKey = "Eth-Execution-Optimistic"
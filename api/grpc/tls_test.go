@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	caCert, caKey := writeCertificate(t, dir, "ca", nil, nil)
+	writeCertificate(t, dir, "server", caCert, caKey)
+	writeCertificate(t, dir, "client", caCert, caKey)
+	otherCACert, otherCAKey := writeCertificate(t, dir, "other-ca", nil, nil)
+	writeCertificate(t, dir, "other-client", otherCACert, otherCAKey)
+	path := func(name string) string {
+		return filepath.Join(dir, name)
+	}
+
+	serverCreds, err := NewServerMutualTLSFromFile(path("server.crt"), path("server.key"), path("ca.crt"))
+	require.NoError(t, err)
+	s := grpc.NewServer(grpc.Creds(serverCreds))
+	NewHealthServer(s)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	defer s.Stop()
+
+	check := func(creds credentials.TransportCredentials) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn, err := grpc.DialContext(ctx, lis.Addr().String(), grpc.WithTransportCredentials(creds))
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, conn.Close())
+		}()
+		_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		return err
+	}
+
+	t.Run("trusted client", func(t *testing.T) {
+		creds, err := NewClientMutualTLSFromFile(path("ca.crt"), path("client.crt"), path("client.key"))
+		require.NoError(t, err)
+		assert.NoError(t, check(creds))
+	})
+	t.Run("untrusted client", func(t *testing.T) {
+		creds, err := NewClientMutualTLSFromFile(path("ca.crt"), path("other-client.crt"), path("other-client.key"))
+		require.NoError(t, err)
+		assert.NotNil(t, check(creds))
+	})
+	t.Run("no client certificate", func(t *testing.T) {
+		creds, err := credentials.NewClientTLSFromFile(path("ca.crt"), "")
+		require.NoError(t, err)
+		assert.NotNil(t, check(creds))
+	})
+	t.Run("invalid files", func(t *testing.T) {
+		_, err := NewServerMutualTLSFromFile(path("server.crt"), path("server.key"), path("server.key"))
+		assert.ErrorContains(t, "no valid certificates found", err)
+		_, err = NewClientMutualTLSFromFile(path("ca.crt"), path("client.crt"), path("server.key"))
+		assert.ErrorContains(t, "could not load client certificate", err)
+	})
+}
+
+// writeCertificate writes a certificate for 127.0.0.1 and its key to <name>.crt and <name>.key in dir.
+// The certificate is signed by the given parent, or is a self-signed certificate authority if parent is nil.
+func TestVerifyClientCertUsage(t *testing.T) {
+	dir := t.TempDir()
+	caCert, caKey := writeCertificate(t, dir, "ca", nil, nil)
+	writeCertificate(t, dir, "client", caCert, caKey)
+	writeCertificate(t, dir, "server", caCert, caKey, x509.ExtKeyUsageServerAuth)
+
+	require.NoError(t, VerifyClientCertUsage(filepath.Join(dir, "client.crt")))
+	require.NoError(t, VerifyClientCertUsage(filepath.Join(dir, "ca.crt")))
+	err := VerifyClientCertUsage(filepath.Join(dir, "server.crt"))
+	assert.ErrorContains(t, "does not allow client authentication", err)
+	err = VerifyClientCertUsage(filepath.Join(dir, "server.key"))
+	assert.ErrorContains(t, "no valid certificate found", err)
+}
+
+func writeCertificate(
+	t *testing.T, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, usages ...x509.ExtKeyUsage,
+) (*x509.Certificate, *ecdsa.PrivateKey) {
+	if len(usages) == 0 {
+		usages = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	} else {
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		template.ExtKeyUsage = usages
+		template.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name+".crt"), certPEM, 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name+".key"), keyPEM, 0600))
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
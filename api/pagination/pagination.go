@@ -2,6 +2,8 @@
 package pagination
 
 import (
+	"encoding/base64"
+	"encoding/binary"
 	"fmt"
 	"strconv"
 
@@ -41,3 +43,28 @@ func StartAndEndPage(pageToken string, pageSize, totalSize int) (int, int, strin
 
 	return start, end, nextPageToken, nil
 }
+
+// EncodeCursor returns an opaque page token pointing at the given position of a listing. Unlike the
+// page tokens of StartAndEndPage, a cursor keeps pointing at the same item when items are added to
+// or filtered out of the listing.
+func EncodeCursor(position uint64) string {
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, position)
+	return base64.RawURLEncoding.EncodeToString(enc)
+}
+
+// DecodeCursor returns the position of a listing the page token returned by EncodeCursor points at.
+// An empty page token points at the start of the listing.
+func DecodeCursor(pageToken string) (uint64, error) {
+	if pageToken == "" {
+		return 0, nil
+	}
+	enc, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not decode page token")
+	}
+	if len(enc) != 8 {
+		return 0, fmt.Errorf("page token has length %d, expected 8", len(enc))
+	}
+	return binary.BigEndian.Uint64(enc), nil
+}
@@ -85,3 +85,20 @@ func TestStartAndEndPage_ExceedsMaxPage(t *testing.T) {
 	_, _, _, err := pagination.StartAndEndPage("", 0, 0)
 	assert.ErrorContains(t, wanted, err)
 }
+
+func TestCursor(t *testing.T) {
+	for _, want := range []uint64{0, 1, 12345, 1<<64 - 1} {
+		position, err := pagination.DecodeCursor(pagination.EncodeCursor(want))
+		require.NoError(t, err)
+		assert.Equal(t, want, position)
+	}
+
+	position, err := pagination.DecodeCursor("")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), position)
+
+	_, err = pagination.DecodeCursor("not a cursor!")
+	assert.ErrorContains(t, "could not decode page token", err)
+	_, err = pagination.DecodeCursor("AQI")
+	assert.ErrorContains(t, "page token has length 2, expected 8", err)
+}
@@ -11,8 +11,11 @@ import (
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/time/slots"
 	"go.opencensus.io/trace"
 )
 
@@ -54,6 +57,8 @@ type HeadFetcher interface {
 	HeadValidatorIndexToPublicKey(ctx context.Context, index types.ValidatorIndex) ([fieldparams.BLSPubkeyLength]byte, error)
 	ProtoArrayStore() *protoarray.Store
 	ChainHeads() ([][32]byte, []types.Slot)
+	ForkChoiceDump(ctx context.Context) (*ethpbv1.ForkChoiceDump, error)
+	ProposerHead(ctx context.Context, slot types.Slot) ([]byte, error)
 	IsOptimistic(ctx context.Context) (bool, error)
 	IsOptimisticForRoot(ctx context.Context, root [32]byte, slot types.Slot) (bool, error)
 	HeadSyncCommitteeFetcher
@@ -71,6 +76,12 @@ type CanonicalFetcher interface {
 	VerifyBlkDescendant(ctx context.Context, blockRoot [32]byte) error
 }
 
+// LightClientFetcher retrieves the latest light client updates produced by the node.
+type LightClientFetcher interface {
+	LightClientFinalityUpdate() *ethpbv2.LightClientFinalityUpdate
+	LightClientOptimisticUpdate() *ethpbv2.LightClientOptimisticUpdate
+}
+
 // FinalizationFetcher defines a common interface for methods in blockchain service which
 // directly retrieves finalization and justification related data.
 type FinalizationFetcher interface {
@@ -237,9 +248,14 @@ func (s *Service) HeadETH1Data() *ethpb.Eth1Data {
 	return s.head.state.Eth1Data()
 }
 
-// ProtoArrayStore returns the proto array store object.
+// ProtoArrayStore returns the proto array store object, or nil if the fork choice store
+// is not the proto array one.
 func (s *Service) ProtoArrayStore() *protoarray.Store {
-	return s.cfg.ForkChoiceStore.Store()
+	fc, ok := s.cfg.ForkChoiceStore.(*protoarray.ForkChoice)
+	if !ok {
+		return nil
+	}
+	return fc.Store()
 }
 
 // GenesisTime returns the genesis time of beacon chain.
@@ -287,23 +303,30 @@ func (s *Service) IsCanonical(ctx context.Context, blockRoot [32]byte) (bool, er
 // ChainHeads returns all possible chain heads (leaves of fork choice tree).
 // Heads roots and heads slots are returned.
 func (s *Service) ChainHeads() ([][32]byte, []types.Slot) {
-	nodes := s.ProtoArrayStore().Nodes()
-
-	// Deliberate choice to not preallocate space for below.
-	// Heads cant be more than 2-3 in the worst case where pre-allocation will be 64 to begin with.
-	headsRoots := make([][32]byte, 0)
-	headsSlots := make([]types.Slot, 0)
-
-	nonExistentNode := ^uint64(0)
-	for _, node := range nodes {
-		// Possible heads have no children.
-		if node.BestDescendant() == nonExistentNode && node.BestChild() == nonExistentNode {
-			headsRoots = append(headsRoots, node.Root())
-			headsSlots = append(headsSlots, node.Slot())
-		}
-	}
+	return s.cfg.ForkChoiceStore.Tips()
+}
 
-	return headsRoots, headsSlots
+// ForkChoiceDump returns all the nodes of the fork choice store along with the current head,
+// the proposer boosted root and the justified and finalized checkpoints.
+func (s *Service) ForkChoiceDump(ctx context.Context) (*ethpbv1.ForkChoiceDump, error) {
+	nodes, err := s.cfg.ForkChoiceStore.ForkChoiceDump(ctx)
+	if err != nil {
+		return nil, err
+	}
+	headRoot, err := s.HeadRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	justified := s.CurrentJustifiedCheckpt()
+	finalized := s.FinalizedCheckpt()
+	boostRoot := s.cfg.ForkChoiceStore.ProposerBoost()
+	return &ethpbv1.ForkChoiceDump{
+		JustifiedCheckpoint: &ethpbv1.Checkpoint{Epoch: justified.Epoch, Root: justified.Root},
+		FinalizedCheckpoint: &ethpbv1.Checkpoint{Epoch: finalized.Epoch, Root: finalized.Root},
+		HeadRoot:            headRoot,
+		ProposerBoostRoot:   boostRoot[:],
+		Nodes:               nodes,
+	}, nil
 }
 
 // HeadPublicKeyToValidatorIndex returns the validator index of the `pubkey` in current head state.
@@ -343,6 +366,30 @@ func (s *Service) IsOptimisticForRoot(ctx context.Context, root [32]byte, slot t
 	return s.cfg.ForkChoiceStore.Optimistic(ctx, root, slot)
 }
 
+// ProposerHead returns the root of the block that a proposal for the given slot should be built
+// on. This is the current head, unless the head arrived late and is weak enough for fork choice
+// to reorg it out, in which case it's the parent of the head. The head is only reorged when the
+// proposal is requested early enough in the slot for the new block to be timely.
+func (s *Service) ProposerHead(ctx context.Context, slot types.Slot) ([]byte, error) {
+	headRoot, err := s.HeadRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slotStart, err := slots.ToTime(uint64(s.genesisTime.Unix()), slot)
+	if err != nil {
+		return nil, err
+	}
+	cutoff := time.Duration(params.BeaconConfig().SecondsPerSlot/params.BeaconConfig().IntervalsPerSlot/2) * time.Second
+	if time.Since(slotStart) > cutoff {
+		return headRoot, nil
+	}
+	root, err := s.cfg.ForkChoiceStore.ProposerHead(ctx, bytesutil.ToBytes32(headRoot), slot)
+	if err != nil {
+		return nil, err
+	}
+	return root[:], nil
+}
+
 // SetGenesisTime sets the genesis time of beacon chain.
 func (s *Service) SetGenesisTime(t time.Time) {
 	s.genesisTime = t
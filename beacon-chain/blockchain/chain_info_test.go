@@ -6,6 +6,7 @@ import (
 	"time"
 
 	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain/store"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
@@ -298,6 +299,57 @@ func TestService_ChainHeads(t *testing.T) {
 	require.DeepEqual(t, []types.Slot{102, 103, 104}, slots)
 }
 
+func TestService_ForkChoiceDump(t *testing.T) {
+	ctx := context.Background()
+	justified := &ethpb.Checkpoint{Epoch: 2, Root: bytesutil.PadTo([]byte{'a'}, 32)}
+	finalized := &ethpb.Checkpoint{Epoch: 1, Root: bytesutil.PadTo([]byte{'b'}, 32)}
+	c := &Service{
+		cfg:   &config{ForkChoiceStore: protoarray.New(0, 0, [32]byte{})},
+		store: store.New(justified, finalized),
+		head:  &head{root: [32]byte{'c'}},
+	}
+	require.NoError(t, c.cfg.ForkChoiceStore.ProcessBlock(ctx, 100, [32]byte{'a'}, [32]byte{}, [32]byte{}, 0, 0))
+	require.NoError(t, c.cfg.ForkChoiceStore.ProcessBlock(ctx, 101, [32]byte{'c'}, [32]byte{'a'}, [32]byte{}, 0, 0))
+
+	dump, err := c.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(dump.Nodes))
+	assert.DeepEqual(t, [][]byte{bytesutil.PadTo([]byte{'a'}, 32), bytesutil.PadTo([]byte{'c'}, 32)}, [][]byte{dump.Nodes[0].Root, dump.Nodes[1].Root})
+	assert.DeepEqual(t, bytesutil.PadTo([]byte{'c'}, 32), dump.HeadRoot)
+	assert.Equal(t, justified.Epoch, dump.JustifiedCheckpoint.Epoch)
+	assert.DeepEqual(t, justified.Root, dump.JustifiedCheckpoint.Root)
+	assert.Equal(t, finalized.Epoch, dump.FinalizedCheckpoint.Epoch)
+	assert.DeepEqual(t, finalized.Root, dump.FinalizedCheckpoint.Root)
+	assert.DeepEqual(t, params.BeaconConfig().ZeroHash[:], dump.ProposerBoostRoot)
+}
+
+func TestService_ProposerHead(t *testing.T) {
+	ctx := context.Background()
+	c := &Service{
+		cfg:  &config{ForkChoiceStore: protoarray.New(0, 0, [32]byte{})},
+		head: &head{root: [32]byte{'c'}},
+	}
+	require.NoError(t, c.cfg.ForkChoiceStore.ProcessBlock(ctx, 100, [32]byte{'a'}, [32]byte{}, [32]byte{}, 0, 0))
+	require.NoError(t, c.cfg.ForkChoiceStore.ProcessBlock(ctx, 101, [32]byte{'c'}, [32]byte{'a'}, [32]byte{}, 0, 0))
+
+	// The proposal is requested on time, fork choice keeps the head as it's too far from finalization.
+	c.genesisTime = time.Now().Add(-time.Duration(102*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	root, err := c.ProposerHead(ctx, 102)
+	require.NoError(t, err)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte{'c'}, 32), root)
+
+	// An unknown head is reported by fork choice.
+	c.head = &head{root: [32]byte{'d'}}
+	_, err = c.ProposerHead(ctx, 102)
+	require.ErrorContains(t, "node index is invalid", err)
+
+	// The proposal is requested too late in the slot, fork choice is not consulted.
+	c.genesisTime = time.Now().Add(-time.Duration(102*params.BeaconConfig().SecondsPerSlot+3) * time.Second)
+	root, err = c.ProposerHead(ctx, 102)
+	require.NoError(t, err)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte{'d'}, 32), root)
+}
+
 func TestService_HeadPublicKeyToValidatorIndex(t *testing.T) {
 	s, _ := util.DeterministicGenesisState(t, 10)
 	c := &Service{}
@@ -9,4 +9,7 @@ var (
 	errNilBestJustifiedInStore = errors.New("nil best justified checkpoint returned from store")
 	// errNilFinalizedInStore is returned when a nil finalized checkpt is returned from store.
 	errNilFinalizedInStore = errors.New("nil finalized checkpoint returned from store")
+	// errNotOptimisticCandidate is returned when the execution node is syncing and the block
+	// cannot be imported optimistically.
+	errNotOptimisticCandidate = errors.New("block is not a candidate for optimistic sync")
 )
@@ -0,0 +1,126 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// notifyNewPayload sends the execution payload of a block to the execution node for validation.
+// It returns true if the execution node could not validate the payload yet, because it is still
+// syncing, in which case the block is imported optimistically if it is a candidate for optimistic
+// sync. Blocks with an invalid payload are rejected.
+func (s *Service) notifyNewPayload(ctx context.Context, blk block.BeaconBlock) (bool, error) {
+	ctx, span := trace.StartSpan(ctx, "blockChain.notifyNewPayload")
+	defer span.End()
+
+	if s.cfg.ExecutionEngineCaller == nil || blk.Version() < version.Bellatrix {
+		return false, nil
+	}
+	// Blocks prior to the merge transition have an empty payload, which the execution node does not validate.
+	isExecutionBlock, err := blocks.ExecutionBlock(blk.Body())
+	if err != nil {
+		return false, errors.Wrap(err, "could not determine if block is an execution block")
+	}
+	if !isExecutionBlock {
+		return false, nil
+	}
+	payload, err := blk.Body().ExecutionPayload()
+	if err != nil {
+		return false, errors.Wrap(err, "could not get execution payload")
+	}
+	payloadStatus, err := s.cfg.ExecutionEngineCaller.NewPayload(ctx, payload)
+	if err != nil {
+		return false, errors.Wrap(err, "could not validate execution payload")
+	}
+	switch err := engine.PayloadStatusError(payloadStatus); {
+	case err == nil:
+		return false, nil
+	case errors.Is(err, engine.ErrAcceptedSyncingPayloadStatus):
+		candidate, err := s.optimisticCandidateBlock(ctx, blk)
+		if err != nil {
+			return false, errors.Wrap(err, "could not check if block is an optimistic candidate")
+		}
+		if !candidate {
+			return false, errors.Wrapf(errNotOptimisticCandidate, "slot %d", blk.Slot())
+		}
+		optimisticBlockCount.Inc()
+		log.WithFields(logrus.Fields{
+			"slot":        blk.Slot(),
+			"payloadHash": fmt.Sprintf("%#x", payload.BlockHash),
+		}).Debug("Execution node is syncing, importing block optimistically")
+		return true, nil
+	default:
+		var invalidErr *engine.InvalidPayloadError
+		if errors.As(err, &invalidErr) {
+			parentRoot := bytesutil.ToBytes32(blk.ParentRoot())
+			if invErr := s.invalidateOptimisticAncestors(ctx, parentRoot, invalidErr.LatestValidHash); invErr != nil {
+				log.WithError(invErr).Warn("Could not invalidate optimistic ancestors of invalid block")
+			}
+		}
+		return false, errors.Wrap(err, "could not validate execution payload")
+	}
+}
+
+// invalidateOptimisticAncestors removes from fork choice the optimistically imported ancestors of
+// a block with an invalid payload, back to the block whose payload has the latest valid hash
+// returned by the execution node. Nothing is removed if the execution node did not return it.
+func (s *Service) invalidateOptimisticAncestors(ctx context.Context, parentRoot [32]byte, latestValidHash []byte) error {
+	if len(latestValidHash) == 0 || bytes.Equal(latestValidHash, params.BeaconConfig().ZeroHash[:]) {
+		return nil
+	}
+	// Ancestors are collected from the parent up, and removed in that order so each is a tip of
+	// the fork choice store once its descendants are gone.
+	var invalidRoots [][32]byte
+	root := parentRoot
+	for {
+		blk, err := s.cfg.BeaconDB.Block(ctx, root)
+		if err != nil {
+			return errors.Wrapf(err, "could not get block %#x", root)
+		}
+		if (blk == nil || blk.IsNil()) && s.hasInitSyncBlock(root) {
+			blk = s.getInitSyncBlock(root)
+		}
+		if blk == nil || blk.IsNil() || blk.Version() < version.Bellatrix {
+			break
+		}
+		optimistic, err := s.cfg.ForkChoiceStore.Optimistic(ctx, root, blk.Block().Slot())
+		if err != nil {
+			return errors.Wrapf(err, "could not check if block %#x is optimistic", root)
+		}
+		if !optimistic {
+			break
+		}
+		payload, err := blk.Block().Body().ExecutionPayload()
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(payload.BlockHash, latestValidHash) {
+			break
+		}
+		invalidRoots = append(invalidRoots, root)
+		root = bytesutil.ToBytes32(blk.Block().ParentRoot())
+	}
+	for _, r := range invalidRoots {
+		if err := s.cfg.ForkChoiceStore.UpdateSyncedTipsWithInvalidRoot(ctx, r); err != nil {
+			return errors.Wrapf(err, "could not invalidate block %#x", r)
+		}
+	}
+	if len(invalidRoots) > 0 {
+		log.WithFields(logrus.Fields{
+			"count":           len(invalidRoots),
+			"latestValidHash": fmt.Sprintf("%#x", latestValidHash),
+		}).Warn("Removed optimistic blocks descending from an invalid payload from fork choice")
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+	mockEngine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func executionBlock(t *testing.T, slot types.Slot) block.BeaconBlock {
+	blk := util.NewBeaconBlockBellatrix()
+	blk.Block.Slot = slot
+	blk.Block.Body.ExecutionPayload.BlockHash = bytesutil.PadTo([]byte{'a'}, fieldparams.RootLength)
+	wr, err := wrapper.WrappedBellatrixBeaconBlock(blk.Block)
+	require.NoError(t, err)
+	return wr
+}
+
+func Test_NotifyNewPayload(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MainnetConfig())
+	params.BeaconConfig().SafeSlotsToImportOptimistically = 128
+
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	// The justified block predates the merge transition, only deep blocks are optimistic candidates.
+	justified := util.NewBeaconBlockBellatrix()
+	justified.Block.Slot = 32
+	wsb, err := wrapper.WrappedBellatrixSignedBeaconBlock(justified)
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wsb))
+	justifiedRoot, err := justified.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	errUnavailable := errors.New("connection refused")
+	preMerge, err := wrapper.WrappedBellatrixBeaconBlock(util.NewBeaconBlockBellatrix().Block)
+	require.NoError(t, err)
+	altair, err := wrapper.WrappedAltairBeaconBlock(util.NewBeaconBlockAltair().Block)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		blk            block.BeaconBlock
+		noEngine       bool
+		status         *pb.PayloadStatus
+		engineErr      error
+		wantCalls      int
+		wantOptimistic bool
+		wantErr        error
+	}{
+		{
+			name:     "no execution engine",
+			blk:      executionBlock(t, 300),
+			noEngine: true,
+		},
+		{
+			name: "altair block",
+			blk:  altair,
+		},
+		{
+			name: "empty payload",
+			blk:  preMerge,
+		},
+		{
+			name:      "valid payload",
+			blk:       executionBlock(t, 300),
+			status:    &pb.PayloadStatus{Status: pb.PayloadStatus_VALID},
+			wantCalls: 1,
+		},
+		{
+			name:           "syncing, deep block",
+			blk:            executionBlock(t, 1),
+			status:         &pb.PayloadStatus{Status: pb.PayloadStatus_SYNCING},
+			wantCalls:      1,
+			wantOptimistic: true,
+		},
+		{
+			name:           "accepted, deep block",
+			blk:            executionBlock(t, 1),
+			status:         &pb.PayloadStatus{Status: pb.PayloadStatus_ACCEPTED},
+			wantCalls:      1,
+			wantOptimistic: true,
+		},
+		{
+			name:      "syncing, shallow block",
+			blk:       executionBlock(t, 300),
+			status:    &pb.PayloadStatus{Status: pb.PayloadStatus_SYNCING},
+			wantCalls: 1,
+			wantErr:   errNotOptimisticCandidate,
+		},
+		{
+			name:      "invalid payload",
+			blk:       executionBlock(t, 300),
+			status:    &pb.PayloadStatus{Status: pb.PayloadStatus_INVALID},
+			wantCalls: 1,
+			wantErr:   engine.ErrInvalidPayloadStatus,
+		},
+		{
+			name:      "execution node error",
+			blk:       executionBlock(t, 300),
+			engineErr: errUnavailable,
+			wantCalls: 1,
+			wantErr:   errUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockEngine.EngineClient{PayloadStatus: tt.status, Err: tt.engineErr}
+			opts := []Option{
+				WithDatabase(beaconDB),
+				WithStateGen(stategen.New(beaconDB)),
+				WithForkChoiceStore(protoarray.New(0, 0, [32]byte{'a'})),
+			}
+			if !tt.noEngine {
+				opts = append(opts, WithExecutionEngineCaller(client))
+			}
+			service, err := NewService(ctx, opts...)
+			require.NoError(t, err)
+			service.genesisTime = time.Now().Add(-time.Second * time.Duration(params.BeaconConfig().SecondsPerSlot) * 200)
+			service.store.SetJustifiedCheckpt(&ethpb.Checkpoint{Root: justifiedRoot[:], Epoch: 1})
+
+			optimistic, err := service.notifyNewPayload(ctx, tt.blk)
+			if tt.wantErr != nil {
+				require.Equal(t, true, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantOptimistic, optimistic)
+			require.Equal(t, tt.wantCalls, client.Calls(engine.NewPayloadMethod))
+		})
+	}
+}
+
+func Test_NotifyNewPayload_InvalidatesOptimisticAncestors(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	genesisRoot := [32]byte{'g'}
+	fcs := protoarray.New(0, 0, genesisRoot)
+	require.NoError(t, fcs.ProcessBlock(ctx, 0, genesisRoot, [32]byte{}, [32]byte{}, 0, 0))
+	require.NoError(t, fcs.SetSyncedTips(map[[32]byte]types.Slot{genesisRoot: 0}))
+
+	// Saves an optimistically imported block with the given payload hash.
+	saveBlock := func(slot types.Slot, parentRoot [32]byte, payloadHash byte) [32]byte {
+		blk := util.NewBeaconBlockBellatrix()
+		blk.Block.Slot = slot
+		blk.Block.ParentRoot = parentRoot[:]
+		blk.Block.Body.ExecutionPayload.BlockHash = bytesutil.PadTo([]byte{payloadHash}, fieldparams.RootLength)
+		wsb, err := wrapper.WrappedBellatrixSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wsb))
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, fcs.ProcessBlock(ctx, slot, root, parentRoot, [32]byte{}, 0, 0))
+		return root
+	}
+	validRoot := saveBlock(1, genesisRoot, 'a')
+	invalidRoot := saveBlock(2, validRoot, 'b')
+
+	client := &mockEngine.EngineClient{PayloadStatus: &pb.PayloadStatus{
+		Status:          pb.PayloadStatus_INVALID,
+		LatestValidHash: bytesutil.PadTo([]byte{'a'}, fieldparams.RootLength),
+	}}
+	service, err := NewService(
+		ctx,
+		WithDatabase(beaconDB),
+		WithStateGen(stategen.New(beaconDB)),
+		WithForkChoiceStore(fcs),
+		WithExecutionEngineCaller(client),
+	)
+	require.NoError(t, err)
+
+	blk := util.NewBeaconBlockBellatrix()
+	blk.Block.Slot = 3
+	blk.Block.ParentRoot = invalidRoot[:]
+	blk.Block.Body.ExecutionPayload.BlockHash = bytesutil.PadTo([]byte{'c'}, fieldparams.RootLength)
+	wr, err := wrapper.WrappedBellatrixBeaconBlock(blk.Block)
+	require.NoError(t, err)
+	_, err = service.notifyNewPayload(ctx, wr)
+	require.Equal(t, true, errors.Is(err, engine.ErrInvalidPayloadStatus))
+
+	// The parent descends from the latest valid payload, so its own payload is invalid as well.
+	require.Equal(t, false, fcs.HasNode(invalidRoot))
+	require.Equal(t, true, fcs.HasNode(validRoot))
+}
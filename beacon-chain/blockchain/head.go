@@ -10,7 +10,9 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
-	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	coreTime "github.com/prysmaticlabs/prysm/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
@@ -77,7 +79,7 @@ func (s *Service) updateHead(ctx context.Context, balances []uint64) error {
 		if err != nil {
 			return err
 		}
-		s.cfg.ForkChoiceStore = protoarray.New(j.Epoch, f.Epoch, bytesutil.ToBytes32(f.Root))
+		s.cfg.ForkChoiceStore = forkchoice.New(j.Epoch, f.Epoch, bytesutil.ToBytes32(f.Root))
 		if err := s.insertBlockToForkChoiceStore(ctx, jb.Block(), headStartRoot, f, j); err != nil {
 			return err
 		}
@@ -89,7 +91,13 @@ func (s *Service) updateHead(ctx context.Context, balances []uint64) error {
 	}
 
 	// Save head to the local service cache.
-	return s.saveHead(ctx, headRoot)
+	if err := s.saveHead(ctx, headRoot); err != nil {
+		return err
+	}
+	if features.Get().EnableHeadInvariantChecks {
+		s.checkHeadInvariants(ctx, balances)
+	}
+	return nil
 }
 
 // This saves head info to the local service cache, it also saves the
@@ -138,29 +146,35 @@ func (s *Service) saveHead(ctx context.Context, headRoot [32]byte) error {
 	oldStateRoot := s.headBlock().Block().StateRoot()
 	newStateRoot := newHeadBlock.Block().StateRoot()
 	if bytesutil.ToBytes32(newHeadBlock.Block().ParentRoot()) != bytesutil.ToBytes32(r) {
-		log.WithFields(logrus.Fields{
-			"newSlot": fmt.Sprintf("%d", newHeadSlot),
-			"oldSlot": fmt.Sprintf("%d", headSlot),
-		}).Debug("Chain reorg occurred")
-		absoluteSlotDifference := slots.AbsoluteValueSlotDifference(newHeadSlot, headSlot)
-		s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
-			Type: statefeed.Reorg,
-			Data: &ethpbv1.EventChainReorg{
-				Slot:         newHeadSlot,
-				Depth:        absoluteSlotDifference,
-				OldHeadBlock: oldHeadRoot[:],
-				NewHeadBlock: headRoot[:],
-				OldHeadState: oldStateRoot,
-				NewHeadState: newStateRoot,
-				Epoch:        slots.ToEpoch(newHeadSlot),
-			},
-		})
-
-		if err := s.saveOrphanedAtts(ctx, bytesutil.ToBytes32(r)); err != nil {
-			return err
-		}
+		isReorg, depth, commonAncestorRoot := s.reorgInfo(ctx, oldHeadRoot, headSlot, headRoot, newHeadSlot)
+		if isReorg {
+			log.WithFields(logrus.Fields{
+				"newSlot":        fmt.Sprintf("%d", newHeadSlot),
+				"oldSlot":        fmt.Sprintf("%d", headSlot),
+				"depth":          depth,
+				"commonAncestor": fmt.Sprintf("%#x", bytesutil.Trunc(commonAncestorRoot)),
+			}).Debug("Chain reorg occurred")
+			s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+				Type: statefeed.Reorg,
+				Data: &ethpbv1.EventChainReorg{
+					Slot:                newHeadSlot,
+					Depth:               depth,
+					OldHeadBlock:        oldHeadRoot[:],
+					NewHeadBlock:        headRoot[:],
+					OldHeadState:        oldStateRoot,
+					NewHeadState:        newStateRoot,
+					Epoch:               slots.ToEpoch(newHeadSlot),
+					CommonAncestorBlock: commonAncestorRoot,
+				},
+			})
+
+			if err := s.saveOrphanedAtts(ctx, bytesutil.ToBytes32(r)); err != nil {
+				return err
+			}
 
-		reorgCount.Inc()
+			reorgCount.Inc()
+			reorgDepth.Observe(float64(depth))
+		}
 	}
 
 	// Cache the new head info.
@@ -178,10 +192,81 @@ func (s *Service) saveHead(ctx context.Context, headRoot [32]byte) error {
 			log.WithError(err).Error("Could not notify event feed of new chain head")
 		}
 	}()
+	go func() {
+		if err := s.updateProposerIndicesCache(s.ctx, newHeadState); err != nil {
+			log.WithError(err).Debug("Could not update proposer indices cache")
+		}
+	}()
 
 	return nil
 }
 
+// reorgInfo determines whether switching from the old head to the new head reorgs blocks out of the
+// canonical chain, the depth of the reorg in slots from the old head and the root of the common
+// ancestor of both heads. When fork choice cannot find the common ancestor, any head that is not a
+// child of the old head is considered a reorg whose depth is the slot difference of both heads.
+func (s *Service) reorgInfo(
+	ctx context.Context, oldHeadRoot [32]byte, oldHeadSlot types.Slot, newHeadRoot [32]byte, newHeadSlot types.Slot,
+) (bool, uint64, []byte) {
+	ancestorRoot, ancestorSlot, err := s.cfg.ForkChoiceStore.CommonAncestor(ctx, oldHeadRoot, newHeadRoot)
+	if err != nil {
+		log.WithError(err).Debug("Could not find common ancestor of old and new head")
+		return true, slots.AbsoluteValueSlotDifference(newHeadSlot, oldHeadSlot), nil
+	}
+	if ancestorRoot == oldHeadRoot {
+		// The new head descends from the old head, no block left the canonical chain.
+		return false, 0, nil
+	}
+	return true, uint64(oldHeadSlot - ancestorSlot), ancestorRoot[:]
+}
+
+// updateProposerIndicesCache caches the proposer indices of the current epoch of the head state and,
+// at the last slot of an epoch, of the next epoch, so that proposer duties and gossip block validation
+// on the new head do not sample them on demand.
+func (s *Service) updateProposerIndicesCache(ctx context.Context, headState state.BeaconState) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.updateProposerIndicesCache")
+	defer span.End()
+
+	if err := helpers.UpdateProposerIndicesInCache(ctx, headState); err != nil {
+		return err
+	}
+	if !slots.IsEpochEnd(headState.Slot()) {
+		return nil
+	}
+	return updateNextEpochProposerIndices(ctx, headState)
+}
+
+// updateNextEpochProposerIndices caches the proposer indices of the epoch following the one of the
+// given state, which is not modified. The indices are keyed by the root of the state at the last
+// slot of its epoch, which the state is advanced to when that slot is empty, and are only computed
+// when they are not cached yet.
+func updateNextEpochProposerIndices(ctx context.Context, st state.BeaconState) error {
+	epochEnd, err := slots.EpochEnd(coreTime.CurrentEpoch(st))
+	if err != nil {
+		return err
+	}
+	copied := st.Copy()
+	if copied.Slot() < epochEnd {
+		copied, err = transition.ProcessSlots(ctx, copied, epochEnd)
+		if err != nil {
+			return err
+		}
+	}
+	root, err := copied.HashTreeRoot(ctx)
+	if err != nil {
+		return err
+	}
+	cached, err := helpers.HasProposerIndicesInCache(root)
+	if err != nil || cached {
+		return err
+	}
+	copied, err = transition.ProcessSlots(ctx, copied, epochEnd+1)
+	if err != nil {
+		return err
+	}
+	return helpers.UpdateProposerIndicesInCache(ctx, copied)
+}
+
 // This gets called to update canonical root mapping. It does not save head block
 // root in DB. With the inception of initial-sync-cache-state flag, it uses finalized
 // check point as anchors to resume sync therefore head is no longer needed to be saved on per slot basis.
@@ -0,0 +1,220 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/io/file"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	prysmTime "github.com/prysmaticlabs/prysm/time"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// headInvariantSnapshotInterval is the minimum time between two head invariant snapshots, so that
+// a violation persisting over many head updates does not write the head state on each of them.
+const headInvariantSnapshotInterval = 10 * time.Minute
+
+// headInvariantSnapshot is the diagnostic snapshot written when a head invariant is violated.
+type headInvariantSnapshot struct {
+	HeadSlot   uint64                    `json:"head_slot"`
+	HeadRoot   string                    `json:"head_root"`
+	Justified  *ethpb.Checkpoint         `json:"justified"`
+	Finalized  *ethpb.Checkpoint         `json:"finalized"`
+	Violations []string                  `json:"violations"`
+	ForkChoice []*ethpbv1.ForkChoiceNode `json:"fork_choice"`
+}
+
+// checkHeadInvariants re-validates the invariants that must hold after a head update, and writes
+// a diagnostic snapshot of the head and fork choice store to the temp directory when one of them
+// does not, at most once per headInvariantSnapshotInterval. It is a debug aid meant to catch
+// consistency bugs on devnets and in soak tests.
+func (s *Service) checkHeadInvariants(ctx context.Context, balances []uint64) {
+	ctx, span := trace.StartSpan(ctx, "blockChain.checkHeadInvariants")
+	defer span.End()
+
+	r, err := s.HeadRoot(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get head root to check head invariants")
+		return
+	}
+	headRoot := bytesutil.ToBytes32(r)
+	violations, nodes, err := s.headInvariantViolations(ctx, headRoot, balances)
+	if err != nil {
+		log.WithError(err).Error("Could not check head invariants")
+		return
+	}
+	if len(violations) == 0 {
+		return
+	}
+	headInvariantViolationCount.Add(float64(len(violations)))
+	for _, v := range violations {
+		log.WithFields(logrus.Fields{
+			"headSlot": s.HeadSlot(),
+			"headRoot": fmt.Sprintf("%#x", bytesutil.Trunc(headRoot[:])),
+		}).Errorf("Head invariant violated: %s", v)
+	}
+	if !s.headInvariantSnapshotDue() {
+		log.Debug("Skipped head invariant snapshot, one was written recently")
+		return
+	}
+	fp, err := s.writeHeadInvariantSnapshot(ctx, headRoot, violations, nodes)
+	if err != nil {
+		log.WithError(err).Error("Could not write head invariant snapshot")
+		return
+	}
+	log.Warnf("Wrote head invariant snapshot to %s", fp)
+}
+
+// headInvariantViolations returns a description of every violated head invariant, along with the
+// fork choice nodes they were checked against. The invariants are:
+//   1. The finalized epoch is not past the justified epoch, both in the store and in the head state.
+//   2. Fork choice tracks the same justified and finalized epochs as the store.
+//   3. The head state does not finalize past the store.
+//   4. The finalized block is an ancestor of the head block.
+//   5. Every fork choice node weighs at least as much as its children, and no more than the justified
+//      balances plus the proposer boost, which is at most the weight of a committee.
+func (s *Service) headInvariantViolations(ctx context.Context, headRoot [32]byte, balances []uint64) ([]string, []*ethpbv1.ForkChoiceNode, error) {
+	f := s.store.FinalizedCheckpt()
+	if f == nil {
+		return nil, nil, errNilFinalizedInStore
+	}
+	j := s.store.JustifiedCheckpt()
+	if j == nil {
+		return nil, nil, errNilJustifiedInStore
+	}
+	headState, err := s.HeadState(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if headState == nil || headState.IsNil() {
+		return nil, nil, errors.New("nil head state")
+	}
+	fc := s.cfg.ForkChoiceStore
+
+	var violations []string
+	if f.Epoch > j.Epoch {
+		violations = append(violations, fmt.Sprintf("store finalized epoch %d is past justified epoch %d", f.Epoch, j.Epoch))
+	}
+	if fc.JustifiedEpoch() != j.Epoch {
+		violations = append(violations, fmt.Sprintf("fork choice justified epoch %d differs from store justified epoch %d", fc.JustifiedEpoch(), j.Epoch))
+	}
+	if fc.FinalizedEpoch() != f.Epoch {
+		violations = append(violations, fmt.Sprintf("fork choice finalized epoch %d differs from store finalized epoch %d", fc.FinalizedEpoch(), f.Epoch))
+	}
+	headFinalized := headState.FinalizedCheckpoint()
+	headJustified := headState.CurrentJustifiedCheckpoint()
+	if headFinalized.Epoch > headJustified.Epoch {
+		violations = append(violations, fmt.Sprintf("head state finalized epoch %d is past its justified epoch %d", headFinalized.Epoch, headJustified.Epoch))
+	}
+	if headFinalized.Epoch > f.Epoch {
+		violations = append(violations, fmt.Sprintf("head state finalized epoch %d is past store finalized epoch %d", headFinalized.Epoch, f.Epoch))
+	}
+
+	finalizedRoot := bytesutil.ToBytes32(f.Root)
+	if finalizedRoot == params.BeaconConfig().ZeroHash {
+		finalizedRoot = s.originBlockRoot
+	}
+	ancestorRoot, _, err := fc.CommonAncestor(ctx, headRoot, finalizedRoot)
+	if err != nil {
+		violations = append(violations, fmt.Sprintf("could not find common ancestor of head and finalized block %#x: %v", finalizedRoot, err))
+	} else if ancestorRoot != finalizedRoot {
+		violations = append(violations, fmt.Sprintf("finalized block %#x is not an ancestor of head, common ancestor is %#x", finalizedRoot, ancestorRoot))
+	}
+
+	nodes, err := fc.ForkChoiceDump(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not dump fork choice")
+	}
+	var totalBalance uint64
+	for _, b := range balances {
+		totalBalance += b
+	}
+	maxWeight := totalBalance + totalBalance/uint64(params.BeaconConfig().SlotsPerEpoch)
+	childrenWeight := make(map[[32]byte]uint64, len(nodes))
+	for _, n := range nodes {
+		childrenWeight[bytesutil.ToBytes32(n.ParentRoot)] += n.Weight
+	}
+	for _, n := range nodes {
+		if n.Weight > maxWeight {
+			violations = append(violations, fmt.Sprintf("node %#x weight %d is above the maximum weight %d", n.Root, n.Weight, maxWeight))
+		}
+		if w := childrenWeight[bytesutil.ToBytes32(n.Root)]; n.Weight < w {
+			violations = append(violations, fmt.Sprintf("node %#x weight %d is below the weight %d of its children", n.Root, n.Weight, w))
+		}
+	}
+	return violations, nodes, nil
+}
+
+// headInvariantSnapshotDue returns true if no head invariant snapshot was written in the last
+// headInvariantSnapshotInterval, in which case the current time is recorded as the time of the last one.
+func (s *Service) headInvariantSnapshotDue() bool {
+	s.headSnapshotLock.Lock()
+	defer s.headSnapshotLock.Unlock()
+	now := prysmTime.Now()
+	if !s.lastHeadSnapshot.IsZero() && now.Sub(s.lastHeadSnapshot) < headInvariantSnapshotInterval {
+		return false
+	}
+	s.lastHeadSnapshot = now
+	return true
+}
+
+// writeHeadInvariantSnapshot writes the head block and state, along with the violations and the
+// fork choice nodes as JSON, to a new directory in the temp directory and returns its path.
+func (s *Service) writeHeadInvariantSnapshot(
+	ctx context.Context, headRoot [32]byte, violations []string, nodes []*ethpbv1.ForkChoiceNode,
+) (string, error) {
+	headSlot := s.HeadSlot()
+	dir := path.Join(os.TempDir(), fmt.Sprintf("head_invariants_%d_%#x", headSlot, bytesutil.Trunc(headRoot[:])))
+	if err := file.MkdirAll(dir); err != nil {
+		return "", err
+	}
+
+	enc, err := json.MarshalIndent(&headInvariantSnapshot{
+		HeadSlot:   uint64(headSlot),
+		HeadRoot:   fmt.Sprintf("%#x", headRoot),
+		Justified:  s.store.JustifiedCheckpt(),
+		Finalized:  s.store.FinalizedCheckpt(),
+		Violations: violations,
+		ForkChoice: nodes,
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := file.WriteFile(path.Join(dir, "snapshot.json"), enc); err != nil {
+		return "", err
+	}
+
+	headBlock, err := s.HeadBlock(ctx)
+	if err != nil {
+		return "", err
+	}
+	enc, err = headBlock.MarshalSSZ()
+	if err != nil {
+		return "", err
+	}
+	if err := file.WriteFile(path.Join(dir, "head_block.ssz"), enc); err != nil {
+		return "", err
+	}
+
+	headState, err := s.HeadState(ctx)
+	if err != nil {
+		return "", err
+	}
+	enc, err = headState.MarshalSSZ()
+	if err != nil {
+		return "", err
+	}
+	if err := file.WriteFile(path.Join(dir, "head_state.ssz"), enc); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
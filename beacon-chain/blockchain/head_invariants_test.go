@@ -0,0 +1,102 @@
+package blockchain
+
+import (
+	"context"
+	"path"
+	"strings"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain/store"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestService_headInvariantViolations(t *testing.T) {
+	ctx := context.Background()
+	headState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	balances := []uint64{10, 10}
+
+	// This builds the following tree, with both validators voting for b:
+	//
+	//	0 -- a -- b
+	//	      \
+	//	       -- c
+	setupService := func(t *testing.T, justified, finalized *ethpb.Checkpoint) *Service {
+		fc := protoarray.New(0, 0, [32]byte{'a'})
+		require.NoError(t, fc.ProcessBlock(ctx, 1, [32]byte{'a'}, [32]byte{}, [32]byte{}, 0, 0))
+		require.NoError(t, fc.ProcessBlock(ctx, 2, [32]byte{'b'}, [32]byte{'a'}, [32]byte{}, 0, 0))
+		require.NoError(t, fc.ProcessBlock(ctx, 2, [32]byte{'c'}, [32]byte{'a'}, [32]byte{}, 0, 0))
+		fc.ProcessAttestation(ctx, []uint64{0, 1}, [32]byte{'b'}, 0)
+		headRoot, err := fc.Head(ctx, 0, [32]byte{'a'}, balances, 0)
+		require.NoError(t, err)
+		require.Equal(t, [32]byte{'b'}, headRoot)
+		return &Service{
+			cfg:   &config{ForkChoiceStore: fc},
+			store: store.New(justified, finalized),
+			head: &head{
+				slot:  2,
+				root:  headRoot,
+				block: wrapper.WrappedPhase0SignedBeaconBlock(util.NewBeaconBlock()),
+				state: headState,
+			},
+		}
+	}
+	checkpoint := func(epoch types.Epoch, root byte) *ethpb.Checkpoint {
+		return &ethpb.Checkpoint{Epoch: epoch, Root: bytesutil.PadTo([]byte{root}, 32)}
+	}
+
+	t.Run("consistent", func(t *testing.T) {
+		s := setupService(t, checkpoint(0, 'a'), checkpoint(0, 'a'))
+		violations, nodes, err := s.headInvariantViolations(ctx, [32]byte{'b'}, balances)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(violations))
+		assert.Equal(t, 3, len(nodes))
+	})
+	t.Run("finalized block is not an ancestor of head", func(t *testing.T) {
+		s := setupService(t, checkpoint(0, 'a'), checkpoint(0, 'c'))
+		violations, _, err := s.headInvariantViolations(ctx, [32]byte{'b'}, balances)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(violations))
+		assert.Equal(t, true, strings.Contains(violations[0], "is not an ancestor of head"), violations[0])
+	})
+	t.Run("finalized past justified", func(t *testing.T) {
+		s := setupService(t, checkpoint(0, 'a'), checkpoint(1, 'a'))
+		violations, _, err := s.headInvariantViolations(ctx, [32]byte{'b'}, balances)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(violations))
+		assert.Equal(t, true, strings.Contains(violations[0], "store finalized epoch 1 is past justified epoch 0"), violations[0])
+		assert.Equal(t, true, strings.Contains(violations[1], "fork choice finalized epoch 0 differs from store finalized epoch 1"), violations[1])
+	})
+	t.Run("weights above balances", func(t *testing.T) {
+		s := setupService(t, checkpoint(0, 'a'), checkpoint(0, 'a'))
+		violations, _, err := s.headInvariantViolations(ctx, [32]byte{'b'}, []uint64{1})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(violations))
+		assert.Equal(t, true, strings.Contains(violations[0], "weight 20 is above the maximum weight 1"), violations[0])
+		assert.Equal(t, true, strings.Contains(violations[1], "weight 20 is above the maximum weight 1"), violations[1])
+	})
+	t.Run("snapshot", func(t *testing.T) {
+		t.Setenv("TMPDIR", t.TempDir())
+		s := setupService(t, checkpoint(0, 'a'), checkpoint(0, 'a'))
+		dir, err := s.writeHeadInvariantSnapshot(ctx, [32]byte{'b'}, []string{"violation"}, nil)
+		require.NoError(t, err)
+		for _, name := range []string{"snapshot.json", "head_block.ssz", "head_state.ssz"} {
+			assert.Equal(t, true, file.FileExists(path.Join(dir, name)), name)
+		}
+	})
+	t.Run("snapshots are rate limited", func(t *testing.T) {
+		s := setupService(t, checkpoint(0, 'a'), checkpoint(0, 'a'))
+		assert.Equal(t, true, s.headInvariantSnapshotDue())
+		assert.Equal(t, false, s.headInvariantSnapshotDue())
+		s.lastHeadSnapshot = s.lastHeadSnapshot.Add(-headInvariantSnapshotInterval)
+		assert.Equal(t, true, s.headInvariantSnapshotDue())
+	})
+}
@@ -8,9 +8,12 @@ import (
 
 	types "github.com/prysmaticlabs/eth2-types"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
@@ -120,6 +123,35 @@ func TestSaveHead_Different_Reorg(t *testing.T) {
 	require.LogsContain(t, hook, "Chain reorg occurred")
 }
 
+func TestService_reorgInfo(t *testing.T) {
+	ctx := context.Background()
+	service := &Service{cfg: &config{ForkChoiceStore: protoarray.New(0, 0, [32]byte{})}}
+	// This builds the following tree:
+	//
+	//	0 -- a -- b -- d
+	//	      \
+	//	       ------ c
+	require.NoError(t, service.cfg.ForkChoiceStore.ProcessBlock(ctx, 1, [32]byte{'a'}, [32]byte{}, [32]byte{}, 0, 0))
+	require.NoError(t, service.cfg.ForkChoiceStore.ProcessBlock(ctx, 2, [32]byte{'b'}, [32]byte{'a'}, [32]byte{}, 0, 0))
+	require.NoError(t, service.cfg.ForkChoiceStore.ProcessBlock(ctx, 3, [32]byte{'c'}, [32]byte{'a'}, [32]byte{}, 0, 0))
+	require.NoError(t, service.cfg.ForkChoiceStore.ProcessBlock(ctx, 4, [32]byte{'d'}, [32]byte{'b'}, [32]byte{}, 0, 0))
+
+	isReorg, depth, ancestor := service.reorgInfo(ctx, [32]byte{'d'}, 4, [32]byte{'c'}, 3)
+	assert.Equal(t, true, isReorg)
+	assert.Equal(t, uint64(3), depth)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte{'a'}, 32), ancestor)
+
+	// A descendant of the old head which is not its child is not a reorg.
+	isReorg, _, _ = service.reorgInfo(ctx, [32]byte{'a'}, 1, [32]byte{'d'}, 4)
+	assert.Equal(t, false, isReorg)
+
+	// Without a common ancestor the slot difference of both heads is used.
+	isReorg, depth, ancestor = service.reorgInfo(ctx, [32]byte{'e'}, 7, [32]byte{'c'}, 3)
+	assert.Equal(t, true, isReorg)
+	assert.Equal(t, uint64(4), depth)
+	assert.DeepEqual(t, []byte(nil), ancestor)
+}
+
 func TestCacheJustifiedStateBalances_CanCache(t *testing.T) {
 	beaconDB := testDB.SetupDB(t)
 	service := setupBeaconChain(t, beaconDB)
@@ -134,6 +166,27 @@ func TestCacheJustifiedStateBalances_CanCache(t *testing.T) {
 	require.DeepEqual(t, balances, state.Balances(), "Incorrect justified balances")
 }
 
+func TestUpdateProposerIndicesCache_NextEpoch(t *testing.T) {
+	ctx := context.Background()
+	service := setupBeaconChain(t, testDB.SetupDB(t))
+	helpers.ClearCache()
+
+	// The head state is at the last slot of epoch 2.
+	st, _ := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, st.SetSlot(3*params.BeaconConfig().SlotsPerEpoch-1))
+	root, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	cached, err := helpers.HasProposerIndicesInCache(root)
+	require.NoError(t, err)
+	require.Equal(t, false, cached)
+
+	require.NoError(t, service.updateProposerIndicesCache(ctx, st))
+	cached, err = helpers.HasProposerIndicesInCache(root)
+	require.NoError(t, err)
+	assert.Equal(t, true, cached, "Proposer indices of the next epoch are not cached")
+	assert.Equal(t, 3*params.BeaconConfig().SlotsPerEpoch-1, st.Slot(), "Head state was advanced")
+}
+
 func TestUpdateHead_MissingJustifiedRoot(t *testing.T) {
 	beaconDB := testDB.SetupDB(t)
 	service := setupBeaconChain(t, beaconDB)
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/emicklei/dot"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/config/params"
 )
 
@@ -45,7 +46,15 @@ func (s *Service) TreeHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	nodes := s.cfg.ForkChoiceStore.Nodes()
+	// The tree is rendered from the indices of the proto array nodes.
+	fc, ok := s.cfg.ForkChoiceStore.(*protoarray.ForkChoice)
+	if !ok {
+		if _, err := w.Write([]byte("Unavailable with this fork choice store")); err != nil {
+			log.WithError(err).Error("Failed to render p2p info page")
+		}
+		return
+	}
+	nodes := fc.Nodes()
 
 	graph := dot.NewGraph(dot.Directed)
 	graph.Attr("rankdir", "RL")
@@ -0,0 +1,375 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/proto/migration"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// syncCommitteeBranchDepth is the depth of the Merkle proof of a sync committee in an Altair beacon state.
+	syncCommitteeBranchDepth = 5
+	// finalityBranchDepth is the depth of the Merkle proof of the finalized root in an Altair beacon state.
+	finalityBranchDepth = 6
+	// lightClientQueueSize is the number of imported blocks which can wait for their light client update to be
+	// created, beyond which the updates of the newly imported blocks are skipped.
+	lightClientQueueSize = 64
+)
+
+// LightClientFinalityUpdate returns the latest light client finality update produced by the node, or nil.
+func (s *Service) LightClientFinalityUpdate() *ethpbv2.LightClientFinalityUpdate {
+	s.lightClientLock.RLock()
+	defer s.lightClientLock.RUnlock()
+	if s.lightClientFinality == nil {
+		return nil
+	}
+	return proto.Clone(s.lightClientFinality).(*ethpbv2.LightClientFinalityUpdate)
+}
+
+// LightClientOptimisticUpdate returns the latest light client optimistic update produced by the node, or nil.
+func (s *Service) LightClientOptimisticUpdate() *ethpbv2.LightClientOptimisticUpdate {
+	s.lightClientLock.RLock()
+	defer s.lightClientLock.RUnlock()
+	if s.lightClientOptimistic == nil {
+		return nil
+	}
+	return proto.Clone(s.lightClientOptimistic).(*ethpbv2.LightClientOptimisticUpdate)
+}
+
+// enqueueLightClientBlock queues the imported block for its light client update to be created in the background.
+// The block is skipped when the queue is full, as the updates of the next blocks supersede its own.
+func (s *Service) enqueueLightClientBlock(signed block.SignedBeaconBlock) {
+	select {
+	case s.lightClientBlocks <- signed:
+	default:
+		log.WithField("slot", signed.Block().Slot()).Debug("Light client update queue is full, skipping block")
+	}
+}
+
+// processLightClientBlocks creates the light client updates of the queued blocks one at a time, until the
+// service is stopped.
+func (s *Service) processLightClientBlocks() {
+	for {
+		select {
+		case signed := <-s.lightClientBlocks:
+			if err := s.processLightClientUpdates(s.ctx, signed); err != nil {
+				log.WithError(err).Debug("Could not process light client updates")
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// processLightClientUpdates creates the light client update signed by the sync aggregate of the block. The update
+// is saved when it is the best one of its sync committee period, and the finality and optimistic updates derived
+// from it are kept and gossiped when they are newer than the latest ones.
+func (s *Service) processLightClientUpdates(ctx context.Context, signed block.SignedBeaconBlock) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.processLightClientUpdates")
+	defer span.End()
+
+	update, err := s.createLightClientUpdate(ctx, signed)
+	if err != nil {
+		return errors.Wrap(err, "could not create light client update")
+	}
+	if update == nil {
+		return nil
+	}
+	if err := s.saveBestLightClientUpdate(ctx, update); err != nil {
+		return errors.Wrap(err, "could not save light client update")
+	}
+	msgs := s.updateLatestLightClientUpdates(update)
+
+	// Only updates of the current slot are gossiped, not those of blocks synced from the past.
+	if len(msgs) == 0 || update.SignatureSlot != s.CurrentSlot() {
+		return nil
+	}
+	// Updates are gossiped once a third of the signature slot has passed, leaving its block time to propagate.
+	cfg := params.BeaconConfig()
+	at := slots.StartTime(uint64(s.genesisTime.Unix()), update.SignatureSlot).
+		Add(time.Duration(cfg.SecondsPerSlot/cfg.IntervalsPerSlot) * time.Second)
+	select {
+	case <-time.After(time.Until(at)):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	for _, msg := range msgs {
+		if err := s.cfg.P2p.Broadcast(ctx, msg); err != nil {
+			log.WithError(err).Debug("Could not broadcast light client update")
+		}
+	}
+	return nil
+}
+
+// createLightClientUpdate creates the light client update of the parent block attested by the sync aggregate
+// of the block, following create_light_client_update of the light client spec. It returns nil if the block
+// has no sync aggregate or too few sync committee participants.
+func (s *Service) createLightClientUpdate(ctx context.Context, signed block.SignedBeaconBlock) (*ethpbv2.LightClientUpdate, error) {
+	if signed.Version() == version.Phase0 {
+		return nil, nil
+	}
+	b := signed.Block()
+	syncAggregate, err := b.Body().SyncAggregate()
+	if err != nil {
+		return nil, err
+	}
+	if syncAggregate.SyncCommitteeBits.Count() < params.BeaconConfig().MinSyncCommitteeParticipants {
+		return nil, nil
+	}
+
+	attestedRoot := bytesutil.ToBytes32(b.ParentRoot())
+	attestedBlock, err := s.cfg.BeaconDB.Block(ctx, attestedRoot)
+	if err != nil {
+		return nil, err
+	}
+	if attestedBlock == nil || attestedBlock.IsNil() {
+		return nil, errors.Errorf("attested block %#x not found", attestedRoot)
+	}
+	// The parent of the first Altair block has no sync committees to prove.
+	if attestedBlock.Version() == version.Phase0 {
+		return nil, nil
+	}
+	attestedState, err := s.cfg.StateGen.StateByRoot(ctx, attestedRoot)
+	if err != nil {
+		return nil, err
+	}
+	attestedHeader, err := lightClientHeader(attestedBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &ethpbv2.LightClientUpdate{
+		AttestedHeader:          attestedHeader,
+		NextSyncCommittee:       emptySyncCommittee(),
+		NextSyncCommitteeBranch: emptyBranch(syncCommitteeBranchDepth),
+		FinalizedHeader:         emptyHeader(),
+		FinalityBranch:          emptyBranch(finalityBranchDepth),
+		SyncAggregate: &ethpbv1.SyncAggregate{
+			SyncCommitteeBits:      syncAggregate.SyncCommitteeBits,
+			SyncCommitteeSignature: syncAggregate.SyncCommitteeSignature,
+		},
+		SignatureSlot: b.Slot(),
+	}
+
+	// The next sync committee is only relevant when it is signed for within the attested period.
+	if syncCommitteePeriodAtSlot(attestedHeader.Slot) == syncCommitteePeriodAtSlot(b.Slot()) {
+		committee, err := attestedState.NextSyncCommittee()
+		if err != nil {
+			return nil, err
+		}
+		branch, err := attestedState.NextSyncCommitteeProof(ctx)
+		if err != nil {
+			return nil, err
+		}
+		update.NextSyncCommittee = &ethpbv2.SyncCommittee{
+			Pubkeys:         committee.Pubkeys,
+			AggregatePubkey: committee.AggregatePubkey,
+		}
+		update.NextSyncCommitteeBranch = branch
+	}
+
+	// Finality is proven whenever possible. The finalized block may be missing, e.g. on a checkpoint synced node.
+	finalizedRoot := bytesutil.ToBytes32(attestedState.FinalizedCheckpoint().Root)
+	if finalizedRoot != params.BeaconConfig().ZeroHash {
+		finalizedBlock, err := s.cfg.BeaconDB.Block(ctx, finalizedRoot)
+		if err != nil {
+			return nil, err
+		}
+		if finalizedBlock == nil || finalizedBlock.IsNil() {
+			return update, nil
+		}
+		update.FinalizedHeader, err = lightClientHeader(finalizedBlock)
+		if err != nil {
+			return nil, err
+		}
+	}
+	update.FinalityBranch, err = attestedState.FinalizedRootProof(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return update, nil
+}
+
+// saveBestLightClientUpdate saves the update as the best one of the sync committee period of its attested header,
+// unless the saved update is better.
+func (s *Service) saveBestLightClientUpdate(ctx context.Context, update *ethpbv2.LightClientUpdate) error {
+	s.lightClientLock.Lock()
+	defer s.lightClientLock.Unlock()
+
+	period := syncCommitteePeriodAtSlot(update.AttestedHeader.Slot)
+	best, err := s.cfg.BeaconDB.LightClientUpdate(ctx, period)
+	if err != nil {
+		return err
+	}
+	if best != nil && !isBetterLightClientUpdate(update, best) {
+		return nil
+	}
+	return s.cfg.BeaconDB.SaveLightClientUpdate(ctx, period, update)
+}
+
+// updateLatestLightClientUpdates keeps the finality and optimistic updates derived from the update when they are
+// newer than the latest ones, following the gossip forwarding rules. It returns the updates which were kept.
+func (s *Service) updateLatestLightClientUpdates(update *ethpbv2.LightClientUpdate) []proto.Message {
+	s.lightClientLock.Lock()
+	defer s.lightClientLock.Unlock()
+
+	var kept []proto.Message
+	if hasFinalizedHeader(update) {
+		latest := s.lightClientFinality
+		if latest == nil ||
+			update.FinalizedHeader.Slot > latest.FinalizedHeader.Slot ||
+			(update.FinalizedHeader.Slot == latest.FinalizedHeader.Slot &&
+				hasSupermajority(update.SyncAggregate) && !hasSupermajority(latest.SyncAggregate)) {
+			s.lightClientFinality = &ethpbv2.LightClientFinalityUpdate{
+				AttestedHeader:  update.AttestedHeader,
+				FinalizedHeader: update.FinalizedHeader,
+				FinalityBranch:  update.FinalityBranch,
+				SyncAggregate:   update.SyncAggregate,
+				SignatureSlot:   update.SignatureSlot,
+			}
+			kept = append(kept, s.lightClientFinality)
+		}
+	}
+	if s.lightClientOptimistic == nil || update.AttestedHeader.Slot > s.lightClientOptimistic.AttestedHeader.Slot {
+		s.lightClientOptimistic = &ethpbv2.LightClientOptimisticUpdate{
+			AttestedHeader: update.AttestedHeader,
+			SyncAggregate:  update.SyncAggregate,
+			SignatureSlot:  update.SignatureSlot,
+		}
+		kept = append(kept, s.lightClientOptimistic)
+	}
+	return kept
+}
+
+// isBetterLightClientUpdate returns true if the new update is better than the old one, following
+// is_better_update of the light client spec.
+func isBetterLightClientUpdate(newUpdate, oldUpdate *ethpbv2.LightClientUpdate) bool {
+	// Compare supermajority (> 2/3) sync committee participation.
+	newParticipants := newUpdate.SyncAggregate.SyncCommitteeBits.Count()
+	oldParticipants := oldUpdate.SyncAggregate.SyncCommitteeBits.Count()
+	newHasSupermajority := hasSupermajority(newUpdate.SyncAggregate)
+	oldHasSupermajority := hasSupermajority(oldUpdate.SyncAggregate)
+	if newHasSupermajority != oldHasSupermajority {
+		return newHasSupermajority
+	}
+	if !newHasSupermajority && newParticipants != oldParticipants {
+		return newParticipants > oldParticipants
+	}
+
+	// Compare presence of the relevant sync committee.
+	newHasSyncCommittee := isSyncCommitteeUpdate(newUpdate) &&
+		syncCommitteePeriodAtSlot(newUpdate.AttestedHeader.Slot) == syncCommitteePeriodAtSlot(newUpdate.SignatureSlot)
+	oldHasSyncCommittee := isSyncCommitteeUpdate(oldUpdate) &&
+		syncCommitteePeriodAtSlot(oldUpdate.AttestedHeader.Slot) == syncCommitteePeriodAtSlot(oldUpdate.SignatureSlot)
+	if newHasSyncCommittee != oldHasSyncCommittee {
+		return newHasSyncCommittee
+	}
+
+	// Compare indication of any finality.
+	newHasFinality := isFinalityUpdate(newUpdate)
+	oldHasFinality := isFinalityUpdate(oldUpdate)
+	if newHasFinality != oldHasFinality {
+		return newHasFinality
+	}
+
+	// Compare sync committee finality.
+	if newHasFinality {
+		newHasSyncCommitteeFinality :=
+			syncCommitteePeriodAtSlot(newUpdate.FinalizedHeader.Slot) == syncCommitteePeriodAtSlot(newUpdate.AttestedHeader.Slot)
+		oldHasSyncCommitteeFinality :=
+			syncCommitteePeriodAtSlot(oldUpdate.FinalizedHeader.Slot) == syncCommitteePeriodAtSlot(oldUpdate.AttestedHeader.Slot)
+		if newHasSyncCommitteeFinality != oldHasSyncCommitteeFinality {
+			return newHasSyncCommitteeFinality
+		}
+	}
+
+	// Tiebreaker 1: sync committee participation beyond supermajority.
+	if newParticipants != oldParticipants {
+		return newParticipants > oldParticipants
+	}
+	// Tiebreaker 2: prefer older data, which makes fewer changes to the best update.
+	if newUpdate.AttestedHeader.Slot != oldUpdate.AttestedHeader.Slot {
+		return newUpdate.AttestedHeader.Slot < oldUpdate.AttestedHeader.Slot
+	}
+	return newUpdate.SignatureSlot < oldUpdate.SignatureSlot
+}
+
+func hasSupermajority(syncAggregate *ethpbv1.SyncAggregate) bool {
+	return syncAggregate.SyncCommitteeBits.Count()*3 >= syncAggregate.SyncCommitteeBits.Len()*2
+}
+
+func isSyncCommitteeUpdate(update *ethpbv2.LightClientUpdate) bool {
+	return !isEmptyBranch(update.NextSyncCommitteeBranch)
+}
+
+func isFinalityUpdate(update *ethpbv2.LightClientUpdate) bool {
+	return !isEmptyBranch(update.FinalityBranch)
+}
+
+// hasFinalizedHeader returns true if the update proves the finality of a block header, rather
+// than the empty finalized root of a chain which did not finalize yet.
+func hasFinalizedHeader(update *ethpbv2.LightClientUpdate) bool {
+	return isFinalityUpdate(update) && !bytes.Equal(update.FinalizedHeader.BodyRoot, params.BeaconConfig().ZeroHash[:])
+}
+
+func isEmptyBranch(branch [][]byte) bool {
+	for _, node := range branch {
+		if !bytes.Equal(node, params.BeaconConfig().ZeroHash[:]) {
+			return false
+		}
+	}
+	return true
+}
+
+func syncCommitteePeriodAtSlot(slot types.Slot) uint64 {
+	return slots.SyncCommitteePeriod(slots.ToEpoch(slot))
+}
+
+func lightClientHeader(blk block.SignedBeaconBlock) (*ethpbv1.BeaconBlockHeader, error) {
+	header, err := migration.BlockIfaceToV1BlockHeader(blk)
+	if err != nil {
+		return nil, err
+	}
+	return header.Message, nil
+}
+
+func emptyHeader() *ethpbv1.BeaconBlockHeader {
+	return &ethpbv1.BeaconBlockHeader{
+		ParentRoot: make([]byte, fieldparams.RootLength),
+		StateRoot:  make([]byte, fieldparams.RootLength),
+		BodyRoot:   make([]byte, fieldparams.RootLength),
+	}
+}
+
+func emptySyncCommittee() *ethpbv2.SyncCommittee {
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	return &ethpbv2.SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+	}
+}
+
+func emptyBranch(depth int) [][]byte {
+	branch := make([][]byte, depth)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return branch
+}
@@ -0,0 +1,260 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/config/params"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+// setupLightClientChain saves a finalized block, and an attested block whose state finalized it, returning
+// the attested state and a block at the given slot signing the attested block with the given participation.
+func setupLightClientChain(t *testing.T, service *Service, slot types.Slot, participants uint64) (state.BeaconState, block.SignedBeaconBlock) {
+	ctx := context.Background()
+
+	finalized := util.NewBeaconBlockAltair()
+	finalized.Block.Slot = 1
+	wsb, err := wrapper.WrappedAltairSignedBeaconBlock(finalized)
+	require.NoError(t, err)
+	require.NoError(t, service.cfg.BeaconDB.SaveBlock(ctx, wsb))
+	finalizedRoot, err := finalized.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, st.SetSlot(slot-1))
+	require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]}))
+	attested := util.NewBeaconBlockAltair()
+	attested.Block.Slot = slot - 1
+	wsb, err = wrapper.WrappedAltairSignedBeaconBlock(attested)
+	require.NoError(t, err)
+	require.NoError(t, service.cfg.BeaconDB.SaveBlock(ctx, wsb))
+	attestedRoot, err := attested.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, service.cfg.BeaconDB.SaveState(ctx, st, attestedRoot))
+
+	signing := util.NewBeaconBlockAltair()
+	signing.Block.Slot = slot
+	signing.Block.ParentRoot = attestedRoot[:]
+	bits := bitfield.NewBitvector512()
+	for i := uint64(0); i < participants; i++ {
+		bits.SetBitAt(i, true)
+	}
+	signing.Block.Body.SyncAggregate.SyncCommitteeBits = bits
+	wsb, err = wrapper.WrappedAltairSignedBeaconBlock(signing)
+	require.NoError(t, err)
+	return st, wsb
+}
+
+func TestService_CreateLightClientUpdate(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service, err := NewService(ctx, WithDatabase(beaconDB), WithStateGen(stategen.New(beaconDB)))
+	require.NoError(t, err)
+
+	st, signing := setupLightClientChain(t, service, 100, 512)
+	update, err := service.createLightClientUpdate(ctx, signing)
+	require.NoError(t, err)
+	require.NotNil(t, update)
+
+	assert.Equal(t, types.Slot(99), update.AttestedHeader.Slot)
+	assert.Equal(t, types.Slot(100), update.SignatureSlot)
+	assert.Equal(t, types.Slot(1), update.FinalizedHeader.Slot)
+	committee, err := st.NextSyncCommittee()
+	require.NoError(t, err)
+	assert.DeepEqual(t, committee.Pubkeys, update.NextSyncCommittee.Pubkeys)
+	branch, err := st.NextSyncCommitteeProof(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, branch, update.NextSyncCommitteeBranch)
+	branch, err = st.FinalizedRootProof(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, branch, update.FinalityBranch)
+	assert.Equal(t, true, isSyncCommitteeUpdate(update))
+	assert.Equal(t, true, hasFinalizedHeader(update))
+
+	// The update must be serializable for the database and gossip.
+	_, err = update.MarshalSSZ()
+	require.NoError(t, err)
+}
+
+func TestService_CreateLightClientUpdate_NextPeriodSignature(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service, err := NewService(ctx, WithDatabase(beaconDB), WithStateGen(stategen.New(beaconDB)))
+	require.NoError(t, err)
+
+	periodSlots := types.Slot(params.BeaconConfig().EpochsPerSyncCommitteePeriod) * params.BeaconConfig().SlotsPerEpoch
+	_, signing := setupLightClientChain(t, service, periodSlots, 512)
+	update, err := service.createLightClientUpdate(ctx, signing)
+	require.NoError(t, err)
+	require.NotNil(t, update)
+	assert.Equal(t, false, isSyncCommitteeUpdate(update))
+	assert.DeepEqual(t, emptySyncCommittee(), update.NextSyncCommittee)
+	assert.Equal(t, true, isFinalityUpdate(update))
+}
+
+func TestService_CreateLightClientUpdate_TooFewParticipants(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service, err := NewService(ctx, WithDatabase(beaconDB), WithStateGen(stategen.New(beaconDB)))
+	require.NoError(t, err)
+
+	_, signing := setupLightClientChain(t, service, 100, 0)
+	update, err := service.createLightClientUpdate(ctx, signing)
+	require.NoError(t, err)
+	assert.Equal(t, (*ethpbv2.LightClientUpdate)(nil), update)
+
+	phase0, err := wrapper.WrappedSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	update, err = service.createLightClientUpdate(ctx, phase0)
+	require.NoError(t, err)
+	assert.Equal(t, (*ethpbv2.LightClientUpdate)(nil), update)
+}
+
+func TestService_ProcessLightClientUpdates(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service, err := NewService(ctx, WithDatabase(beaconDB), WithStateGen(stategen.New(beaconDB)))
+	require.NoError(t, err)
+	service.SetGenesisTime(time.Now())
+
+	_, signing := setupLightClientChain(t, service, 100, 400)
+	require.NoError(t, service.processLightClientUpdates(ctx, signing))
+	best, err := beaconDB.LightClientUpdate(ctx, 0)
+	require.NoError(t, err)
+	require.NotNil(t, best)
+	assert.Equal(t, uint64(400), best.SyncAggregate.SyncCommitteeBits.Count())
+	require.NotNil(t, service.LightClientFinalityUpdate())
+	assert.Equal(t, types.Slot(1), service.LightClientFinalityUpdate().FinalizedHeader.Slot)
+	require.NotNil(t, service.LightClientOptimisticUpdate())
+	assert.Equal(t, types.Slot(99), service.LightClientOptimisticUpdate().AttestedHeader.Slot)
+
+	// A later update with less participation is not the best update of the period, but it is the latest one.
+	_, signing = setupLightClientChain(t, service, 101, 300)
+	require.NoError(t, service.processLightClientUpdates(ctx, signing))
+	best, err = beaconDB.LightClientUpdate(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(400), best.SyncAggregate.SyncCommitteeBits.Count())
+	assert.Equal(t, types.Slot(100), service.LightClientOptimisticUpdate().AttestedHeader.Slot)
+	// The finalized header did not change, so the latest finality update is kept.
+	assert.Equal(t, types.Slot(100), service.LightClientFinalityUpdate().SignatureSlot)
+}
+
+func TestService_ProcessLightClientBlocks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconDB := testDB.SetupDB(t)
+	service, err := NewService(ctx, WithDatabase(beaconDB), WithStateGen(stategen.New(beaconDB)))
+	require.NoError(t, err)
+	service.SetGenesisTime(time.Now())
+
+	_, signing := setupLightClientChain(t, service, 100, 400)
+	go service.processLightClientBlocks()
+	service.enqueueLightClientBlock(signing)
+	for service.LightClientOptimisticUpdate() == nil {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, types.Slot(99), service.LightClientOptimisticUpdate().AttestedHeader.Slot)
+}
+
+func TestService_EnqueueLightClientBlock_QueueFull(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	service, err := NewService(context.Background(), WithDatabase(beaconDB), WithStateGen(stategen.New(beaconDB)))
+	require.NoError(t, err)
+	wsb, err := wrapper.WrappedAltairSignedBeaconBlock(util.NewBeaconBlockAltair())
+	require.NoError(t, err)
+	for i := 0; i < lightClientQueueSize+1; i++ {
+		service.enqueueLightClientBlock(wsb)
+	}
+	assert.Equal(t, lightClientQueueSize, len(service.lightClientBlocks))
+}
+
+func TestIsBetterLightClientUpdate(t *testing.T) {
+	update := func(participants uint64, attestedSlot, signatureSlot types.Slot, syncCommittee, finality bool) *ethpbv2.LightClientUpdate {
+		bits := bitfield.NewBitvector512()
+		for i := uint64(0); i < participants; i++ {
+			bits.SetBitAt(i, true)
+		}
+		u := &ethpbv2.LightClientUpdate{
+			AttestedHeader:          &ethpbv1.BeaconBlockHeader{Slot: attestedSlot},
+			NextSyncCommitteeBranch: emptyBranch(syncCommitteeBranchDepth),
+			FinalizedHeader:         &ethpbv1.BeaconBlockHeader{Slot: attestedSlot - 64},
+			FinalityBranch:          emptyBranch(finalityBranchDepth),
+			SyncAggregate:           &ethpbv1.SyncAggregate{SyncCommitteeBits: bits},
+			SignatureSlot:           signatureSlot,
+		}
+		if syncCommittee {
+			u.NextSyncCommitteeBranch[0] = []byte{'a'}
+		}
+		if finality {
+			u.FinalityBranch[0] = []byte{'a'}
+		}
+		return u
+	}
+	tests := []struct {
+		name     string
+		new, old *ethpbv2.LightClientUpdate
+		want     bool
+	}{
+		{
+			name: "supermajority wins",
+			new:  update(342, 100, 101, false, false),
+			old:  update(341, 100, 101, true, true),
+			want: true,
+		},
+		{
+			name: "more participants without supermajority",
+			new:  update(300, 100, 101, false, false),
+			old:  update(200, 100, 101, true, true),
+			want: true,
+		},
+		{
+			name: "relevant sync committee",
+			new:  update(400, 100, 101, true, false),
+			old:  update(500, 100, 101, false, true),
+			want: true,
+		},
+		{
+			name: "finality",
+			new:  update(400, 100, 101, true, true),
+			old:  update(500, 100, 101, true, false),
+			want: true,
+		},
+		{
+			name: "participation beyond supermajority",
+			new:  update(400, 100, 101, true, true),
+			old:  update(500, 100, 101, true, true),
+			want: false,
+		},
+		{
+			name: "older attested header",
+			new:  update(500, 99, 101, true, true),
+			old:  update(500, 100, 101, true, true),
+			want: true,
+		},
+		{
+			name: "older signature slot",
+			new:  update(500, 100, 102, true, true),
+			old:  update(500, 100, 101, true, true),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isBetterLightClientUpdate(tt.new, tt.old))
+		})
+	}
+}
@@ -111,6 +111,21 @@ var (
 		Name: "beacon_reorgs_total",
 		Help: "Count the number of times beacon chain has a reorg",
 	})
+	reorgDepth = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "beacon_reorg_depth_slots",
+			Help:    "The number of slots between the old head and the common ancestor of a reorg",
+			Buckets: []float64{1, 2, 3, 4, 6, 8, 16, 32, 64},
+		},
+	)
+	deepPreStateCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_deep_pre_state_regenerations_total",
+		Help: "Count the number of times the pre state of a block had to be regenerated from an older saved state",
+	})
+	headInvariantViolationCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_head_invariant_violations_total",
+		Help: "Count the number of head invariant violations found with --enable-head-invariant-checks",
+	})
 	saveOrphanedAttCount = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "saved_orphaned_att_total",
 		Help: "Count the number of times an orphaned attestation is saved",
@@ -138,6 +153,10 @@ var (
 		Name: "state_balance_cache_miss",
 		Help: "Count the number of state balance cache hits.",
 	})
+	optimisticBlockCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "optimistic_block_count",
+		Help: "Count the number of blocks imported optimistically while the execution node is syncing.",
+	})
 )
 
 // reportSlotMetrics reports slot related metrics.
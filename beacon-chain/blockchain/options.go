@@ -1,7 +1,9 @@
 package blockchain
 
 import (
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/async/event"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
@@ -11,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -26,6 +29,17 @@ func WithMaxGoroutines(x int) Option {
 	}
 }
 
+// WithCheckpointStateCacheSize to bound the number of checkpoint states cached.
+func WithCheckpointStateCacheSize(size int) Option {
+	return func(s *Service) error {
+		if size <= 0 {
+			return errors.Errorf("checkpoint state cache size must be positive, got %d", size)
+		}
+		s.checkpointStateCache = cache.NewCheckpointStateCache(size)
+		return nil
+	}
+}
+
 // WithWeakSubjectivityCheckpoint for checkpoint sync.
 func WithWeakSubjectivityCheckpoint(c *ethpb.Checkpoint) Option {
 	return func(s *Service) error {
@@ -144,3 +158,19 @@ func WithFinalizedStateAtStartUp(st state.BeaconState) Option {
 		return nil
 	}
 }
+
+// WithExecutionEngineCaller to validate the execution payloads of blocks with the execution node.
+func WithExecutionEngineCaller(c engine.EngineCaller) Option {
+	return func(s *Service) error {
+		s.cfg.ExecutionEngineCaller = c
+		return nil
+	}
+}
+
+// WithLivenessCache to track the validators seen proposing or attesting for the validator liveness API.
+func WithLivenessCache(c *cache.LivenessCache) Option {
+	return func(s *Service) error {
+		s.cfg.LivenessCache = c
+		return nil
+	}
+}
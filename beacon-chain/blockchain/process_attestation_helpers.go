@@ -93,3 +93,11 @@ func (s *Service) verifyBeaconBlock(ctx context.Context, data *ethpb.Attestation
 	}
 	return nil
 }
+
+// markLive records the given validators as live in the given epoch for the validator liveness API.
+func (s *Service) markLive(epoch types.Epoch, indices []uint64) {
+	if s.cfg.LivenessCache == nil {
+		return
+	}
+	s.cfg.LivenessCache.MarkLive(epoch, indices)
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
@@ -136,6 +138,7 @@ func TestStore_OnAttestation_Ok(t *testing.T) {
 		WithDatabase(beaconDB),
 		WithStateGen(stategen.New(beaconDB)),
 		WithForkChoiceStore(fcs),
+		WithLivenessCache(cache.NewLivenessCache()),
 	}
 	service, err := NewService(ctx, opts...)
 	require.NoError(t, err)
@@ -151,6 +154,12 @@ func TestStore_OnAttestation_Ok(t *testing.T) {
 	require.NoError(t, service.cfg.BeaconDB.SaveState(ctx, copied, tRoot))
 	require.NoError(t, service.cfg.ForkChoiceStore.ProcessBlock(ctx, 0, tRoot, tRoot, tRoot, 1, 1))
 	require.NoError(t, service.OnAttestation(ctx, att[0]))
+
+	committee, err := helpers.BeaconCommitteeFromState(ctx, genesisState, att[0].Data.Slot, att[0].Data.CommitteeIndex)
+	require.NoError(t, err)
+	for i, idx := range committee {
+		assert.Equal(t, att[0].AggregationBits.BitAt(uint64(i)), service.cfg.LivenessCache.IsLive(0, idx))
+	}
 }
 
 func TestStore_SaveCheckpointState(t *testing.T) {
@@ -3,9 +3,11 @@ package blockchain
 import (
 	"context"
 	"fmt"
+	"runtime"
 	"time"
 
 	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
@@ -102,6 +104,10 @@ func (s *Service) onBlock(ctx context.Context, signed block.SignedBeaconBlock, b
 	if err != nil {
 		return err
 	}
+	optimistic, err := s.notifyNewPayload(ctx, b)
+	if err != nil {
+		return err
+	}
 
 	// We add a proposer score boost to fork choice for the block root if applicable, right after
 	// running a successful state transition for the block.
@@ -114,6 +120,12 @@ func (s *Service) onBlock(ctx context.Context, signed block.SignedBeaconBlock, b
 	if err := s.savePostStateInfo(ctx, blockRoot, signed, postState, false /* reg sync */); err != nil {
 		return err
 	}
+	// A block whose payload was validated also validates its optimistically imported ancestors.
+	if !optimistic {
+		if err := s.cfg.ForkChoiceStore.UpdateSyncedTipsWithValidRoot(ctx, blockRoot); err != nil {
+			return errors.Wrap(err, "could not update synced tips")
+		}
+	}
 
 	// If slasher is configured, forward the attestations in the block via
 	// an event feed for processing.
@@ -142,6 +154,11 @@ func (s *Service) onBlock(ctx context.Context, signed block.SignedBeaconBlock, b
 		}()
 	}
 
+	if features.Get().EnableLightClient {
+		// Light client updates are created in the background, as the block does not depend on them.
+		s.enqueueLightClientBlock(signed)
+	}
+
 	// Update justified check point.
 	justified := s.store.JustifiedCheckpt()
 	if justified == nil {
@@ -250,8 +267,11 @@ func (s *Service) onBlock(ctx context.Context, signed block.SignedBeaconBlock, b
 	return s.handleEpochBoundary(ctx, postState)
 }
 
+// onBlockBatch runs the state transition of a linear batch of blocks and verifies their signatures as
+// a batch. The proposer signatures of the blocks flagged in proposerVerified, which may be nil, were
+// already verified by the caller and are not verified again.
 func (s *Service) onBlockBatch(ctx context.Context, blks []block.SignedBeaconBlock,
-	blockRoots [][32]byte) ([]*ethpb.Checkpoint, []*ethpb.Checkpoint, error) {
+	blockRoots [][32]byte, proposerVerified []bool) ([]*ethpb.Checkpoint, []*ethpb.Checkpoint, error) {
 	ctx, span := trace.StartSpan(ctx, "blockChain.onBlockBatch")
 	defer span.End()
 
@@ -277,36 +297,34 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []block.SignedBeaconBlo
 
 	jCheckpoints := make([]*ethpb.Checkpoint, len(blks))
 	fCheckpoints := make([]*ethpb.Checkpoint, len(blks))
-	sigSet := &bls.SignatureBatch{
-		Signatures: [][]byte{},
-		PublicKeys: []bls.PublicKey{},
-		Messages:   [][32]byte{},
-	}
+	// The signatures of each block are verified while the state transition of the next blocks is computed.
+	verifier := newSignatureVerifier(runtime.GOMAXPROCS(0))
 	var set *bls.SignatureBatch
 	boundaries := make(map[[32]byte]state.BeaconState)
 	for i, b := range blks {
 		set, preState, err = transition.ExecuteStateTransitionNoVerifyAnySig(ctx, preState, b)
 		if err != nil {
+			verifier.abort()
 			return nil, nil, err
 		}
+		if i < len(proposerVerified) && proposerVerified[i] {
+			set = withoutProposerSignature(set)
+		}
+		verifier.add(set)
 		// Save potential boundary states.
 		if slots.IsEpochStart(preState.Slot()) {
 			boundaries[blockRoots[i]] = preState.Copy()
 			if err := s.handleEpochBoundary(ctx, preState); err != nil {
+				verifier.abort()
 				return nil, nil, errors.Wrap(err, "could not handle epoch boundary state")
 			}
 		}
 		jCheckpoints[i] = preState.CurrentJustifiedCheckpoint()
 		fCheckpoints[i] = preState.FinalizedCheckpoint()
-		sigSet.Join(set)
 	}
-	verify, err := sigSet.Verify()
-	if err != nil {
+	if err := verifier.wait(); err != nil {
 		return nil, nil, err
 	}
-	if !verify {
-		return nil, nil, errors.New("batch block signature verification failed")
-	}
 	for r, st := range boundaries {
 		if err := s.cfg.StateGen.SaveState(ctx, r, st); err != nil {
 			return nil, nil, err
@@ -330,10 +348,19 @@ func (s *Service) handleBlockAfterBatchVerify(ctx context.Context, signed block.
 	blockRoot [32]byte, fCheckpoint, jCheckpoint *ethpb.Checkpoint) error {
 	b := signed.Block()
 
+	optimistic, err := s.notifyNewPayload(ctx, b)
+	if err != nil {
+		return err
+	}
 	s.saveInitSyncBlock(blockRoot, signed)
 	if err := s.insertBlockToForkChoiceStore(ctx, b, blockRoot, fCheckpoint, jCheckpoint); err != nil {
 		return err
 	}
+	if !optimistic {
+		if err := s.cfg.ForkChoiceStore.UpdateSyncedTipsWithValidRoot(ctx, blockRoot); err != nil {
+			return errors.Wrap(err, "could not update synced tips")
+		}
+	}
 	if err := s.saveSyncedTipsDB(ctx); err != nil {
 		return errors.Wrap(err, "could not save synced tips")
 	}
@@ -388,12 +415,7 @@ func (s *Service) handleEpochBoundary(ctx context.Context, postState state.Beaco
 		if err := helpers.UpdateCommitteeCache(postState, coreTime.NextEpoch(postState)); err != nil {
 			return err
 		}
-		copied := postState.Copy()
-		copied, err := transition.ProcessSlots(ctx, copied, copied.Slot()+1)
-		if err != nil {
-			return err
-		}
-		if err := helpers.UpdateProposerIndicesInCache(ctx, copied); err != nil {
+		if err := updateNextEpochProposerIndices(ctx, postState); err != nil {
 			return err
 		}
 	} else if postState.Slot() >= s.nextEpochBoundarySlot {
@@ -419,6 +441,34 @@ func (s *Service) handleEpochBoundary(ctx context.Context, postState state.Beaco
 	return nil
 }
 
+// precomputeCommittees caches the committees of the given epoch and of the next one at the start of
+// the epoch, before its first block is processed. Their seeds and active validators are fixed by the
+// head state of the previous epoch, so that attester duty requests and gossip validation at the epoch
+// boundary find the committees cached instead of all shuffling them on demand. The proposer indices
+// of the epoch are cached too, as they are not cached along with the head when the last slot of the
+// previous epoch is empty.
+func (s *Service) precomputeCommittees(epoch types.Epoch) {
+	s.headLock.RLock()
+	if !s.hasHeadState() {
+		s.headLock.RUnlock()
+		return
+	}
+	headState := s.head.state
+	s.headLock.RUnlock()
+
+	// The seed of the next epoch is not known from a head state further behind, and the committees
+	// are updated along with the head state once it reaches the epoch.
+	if coreTime.CurrentEpoch(headState)+1 != epoch {
+		return
+	}
+	if err := helpers.UpdateCommitteeCache(headState, epoch); err != nil {
+		log.WithError(err).Debug("Could not precompute committees")
+	}
+	if err := updateNextEpochProposerIndices(s.ctx, headState); err != nil {
+		log.WithError(err).Debug("Could not precompute proposer indices")
+	}
+}
+
 // This feeds in the block and block's attestations to fork choice store. It's allows fork choice store
 // to gain information on the most current chain.
 func (s *Service) insertBlockAndAttestationsToForkChoiceStore(ctx context.Context, blk block.BeaconBlock, root [32]byte,
@@ -431,6 +481,7 @@ func (s *Service) insertBlockAndAttestationsToForkChoiceStore(ctx context.Contex
 	if err := s.insertBlockToForkChoiceStore(ctx, blk, root, fCheckpoint, jCheckpoint); err != nil {
 		return err
 	}
+	s.markLive(slots.ToEpoch(blk.Slot()), []uint64{uint64(blk.ProposerIndex())})
 	// Feed in block's attestations to fork choice store.
 	for _, a := range blk.Body().Attestations() {
 		committee, err := helpers.BeaconCommitteeFromState(ctx, st, a.Data.Slot, a.Data.CommitteeIndex)
@@ -442,6 +493,7 @@ func (s *Service) insertBlockAndAttestationsToForkChoiceStore(ctx context.Contex
 			return err
 		}
 		s.cfg.ForkChoiceStore.ProcessAttestation(ctx, indices, bytesutil.ToBytes32(a.Data.BeaconBlockRoot), a.Data.Target.Epoch)
+		s.markLive(a.Data.Target.Epoch, indices)
 	}
 	return nil
 }
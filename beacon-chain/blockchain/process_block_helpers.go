@@ -15,6 +15,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
 	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
 
@@ -35,6 +36,10 @@ func (s *Service) getBlockPreState(ctx context.Context, b block.BeaconBlock) (st
 		return nil, err
 	}
 
+	if err := s.logDeepPreState(ctx, b); err != nil {
+		return nil, err
+	}
+
 	preState, err := s.cfg.StateGen.StateByRoot(ctx, bytesutil.ToBytes32(b.ParentRoot()))
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not get pre state for slot %d", b.Slot())
@@ -86,6 +91,30 @@ func (s *Service) verifyBlkPreState(ctx context.Context, b block.BeaconBlock) er
 	return nil
 }
 
+// logDeepPreState logs when the pre state of an incoming block is neither cached nor saved in DB.
+// This happens when the block builds on a fork which branched off deeper than the hot state cache,
+// and its pre state has to be regenerated by replaying blocks from an older saved state.
+func (s *Service) logDeepPreState(ctx context.Context, b block.BeaconBlock) error {
+	parentRoot := bytesutil.ToBytes32(b.ParentRoot())
+	if parentRoot == params.BeaconConfig().ZeroHash {
+		return nil
+	}
+	has, err := s.cfg.StateGen.HasState(ctx, parentRoot)
+	if err != nil {
+		return err
+	}
+	if has {
+		return nil
+	}
+	deepPreStateCount.Inc()
+	log.WithFields(logrus.Fields{
+		"slot":       b.Slot(),
+		"headSlot":   s.HeadSlot(),
+		"parentRoot": fmt.Sprintf("%#x", bytesutil.Trunc(parentRoot[:])),
+	}).Info("Regenerating pre state of a block from a deep fork")
+	return nil
+}
+
 // VerifyBlkDescendant validates input block root is a descendant of the
 // current finalized block root.
 func (s *Service) VerifyBlkDescendant(ctx context.Context, root [32]byte) error {
@@ -251,9 +280,40 @@ func (s *Service) updateFinalized(ctx context.Context, cp *ethpb.Checkpoint) err
 		return errors.Wrap(err, "could not migrate to cold")
 	}
 
+	// Index the public keys of the finalized validators, which the validator lookups use instead of
+	// scanning a state. The finalized state was just cached by the migration to cold.
+	fState, err := s.cfg.StateGen.StateByRoot(ctx, fRoot)
+	if err != nil {
+		log.WithError(err).Error("Could not get finalized state to index its validators")
+		return nil
+	}
+	s.indexFinalizedValidators(fState)
+
 	return nil
 }
 
+// indexFinalizedValidators indexes the validators of the finalized state in the background, off the
+// block processing path. The indexing is skipped while the one of a previous finalized state is
+// running, as the next finalized state indexes the validators left.
+func (s *Service) indexFinalizedValidators(st state.ReadOnlyBeaconState) {
+	s.validatorIndicesLock.Lock()
+	defer s.validatorIndicesLock.Unlock()
+	if s.indexingValidators {
+		return
+	}
+	s.indexingValidators = true
+	go func() {
+		defer func() {
+			s.validatorIndicesLock.Lock()
+			s.indexingValidators = false
+			s.validatorIndicesLock.Unlock()
+		}()
+		if err := s.cfg.BeaconDB.SaveValidatorIndices(s.ctx, st); err != nil {
+			log.WithError(err).Error("Could not index finalized validators")
+		}
+	}()
+}
+
 // ancestor returns the block root of an ancestry block from the input block root.
 //
 // Spec pseudocode definition:
@@ -9,10 +9,13 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	types "github.com/prysmaticlabs/eth2-types"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
@@ -31,6 +34,7 @@ import (
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
 	prysmTime "github.com/prysmaticlabs/prysm/time"
+	logTest "github.com/sirupsen/logrus/hooks/test"
 )
 
 func TestStore_OnBlock(t *testing.T) {
@@ -178,7 +182,7 @@ func TestStore_OnBlockBatch(t *testing.T) {
 	rBlock.Block.ParentRoot = gRoot[:]
 	require.NoError(t, beaconDB.SaveBlock(context.Background(), blks[0]))
 	require.NoError(t, service.cfg.StateGen.SaveState(ctx, blkRoots[0], firstState))
-	_, _, err = service.onBlockBatch(ctx, blks[1:], blkRoots[1:])
+	_, _, err = service.onBlockBatch(ctx, blks[1:], blkRoots[1:], nil)
 	require.NoError(t, err)
 }
 
@@ -310,6 +314,35 @@ func TestCachedPreState_CanGetFromDB(t *testing.T) {
 	require.NoError(t, service.verifyBlkPreState(ctx, wrapper.WrappedPhase0SignedBeaconBlock(b).Block()))
 }
 
+func TestLogDeepPreState(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service, err := NewService(ctx, WithDatabase(beaconDB), WithStateGen(stategen.New(beaconDB)))
+	require.NoError(t, err)
+
+	// Blocks building on genesis are not logged.
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 1
+	require.NoError(t, service.logDeepPreState(ctx, wrapper.WrappedPhase0BeaconBlock(b.Block)))
+	require.LogsDoNotContain(t, hook, "Regenerating pre state")
+
+	// The pre state is cached.
+	parentRoot := [32]byte{'a'}
+	b.Block.ParentRoot = parentRoot[:]
+	st, err := v1.InitializeFromProto(&ethpb.BeaconState{Slot: 1})
+	require.NoError(t, err)
+	require.NoError(t, service.cfg.StateGen.SaveState(ctx, parentRoot, st))
+	require.NoError(t, service.logDeepPreState(ctx, wrapper.WrappedPhase0BeaconBlock(b.Block)))
+	require.LogsDoNotContain(t, hook, "Regenerating pre state")
+
+	// The pre state has to be regenerated.
+	otherRoot := [32]byte{'b'}
+	b.Block.ParentRoot = otherRoot[:]
+	require.NoError(t, service.logDeepPreState(ctx, wrapper.WrappedPhase0BeaconBlock(b.Block)))
+	require.LogsContain(t, hook, "Regenerating pre state")
+}
+
 func TestUpdateJustified_CouldUpdateBest(t *testing.T) {
 	ctx := context.Background()
 	beaconDB := testDB.SetupDB(t)
@@ -382,7 +415,7 @@ func TestFillForkChoiceMissingBlocks_CanSave(t *testing.T) {
 	require.NoError(t, err)
 
 	// 5 nodes from the block tree 1. B0 - B3 - B4 - B6 - B8
-	assert.Equal(t, 5, len(service.cfg.ForkChoiceStore.Nodes()), "Miss match nodes")
+	assert.Equal(t, 5, service.cfg.ForkChoiceStore.NodeCount(), "Miss match nodes")
 	assert.Equal(t, true, service.cfg.ForkChoiceStore.HasNode(bytesutil.ToBytes32(roots[4])), "Didn't save node")
 	assert.Equal(t, true, service.cfg.ForkChoiceStore.HasNode(bytesutil.ToBytes32(roots[6])), "Didn't save node")
 	assert.Equal(t, true, service.cfg.ForkChoiceStore.HasNode(bytesutil.ToBytes32(roots[8])), "Didn't save node")
@@ -423,7 +456,7 @@ func TestFillForkChoiceMissingBlocks_RootsMatch(t *testing.T) {
 	require.NoError(t, err)
 
 	// 5 nodes from the block tree 1. B0 - B3 - B4 - B6 - B8
-	assert.Equal(t, 5, len(service.cfg.ForkChoiceStore.Nodes()), "Miss match nodes")
+	assert.Equal(t, 5, service.cfg.ForkChoiceStore.NodeCount(), "Miss match nodes")
 	// Ensure all roots and their respective blocks exist.
 	wantedRoots := [][]byte{roots[0], roots[3], roots[4], roots[6], roots[8]}
 	for i, rt := range wantedRoots {
@@ -479,7 +512,7 @@ func TestFillForkChoiceMissingBlocks_FilterFinalized(t *testing.T) {
 	require.NoError(t, err)
 
 	// There should be 2 nodes, block 65 and block 64.
-	assert.Equal(t, 2, len(service.cfg.ForkChoiceStore.Nodes()), "Miss match nodes")
+	assert.Equal(t, 2, service.cfg.ForkChoiceStore.NodeCount(), "Miss match nodes")
 
 	// Block with slot 63 should be in fork choice because it's less than finalized epoch 1.
 	assert.Equal(t, true, service.cfg.ForkChoiceStore.HasNode(r63), "Didn't save node")
@@ -867,6 +900,55 @@ func TestHandleEpochBoundary_UpdateFirstSlot(t *testing.T) {
 	require.Equal(t, 3*params.BeaconConfig().SlotsPerEpoch, service.nextEpochBoundarySlot)
 }
 
+func TestPrecomputeCommittees(t *testing.T) {
+	ctx := context.Background()
+	opts := testServiceOptsNoDB()
+	service, err := NewService(ctx, opts...)
+	require.NoError(t, err)
+	helpers.ClearCache()
+
+	s, _ := util.DeterministicGenesisState(t, 1024)
+	require.NoError(t, s.SetSlot(params.BeaconConfig().SlotsPerEpoch-1))
+	service.head = &head{state: s}
+	misses := func(epoch types.Epoch) float64 {
+		before := testutil.ToFloat64(cache.CommitteeCacheMiss)
+		_, err := helpers.BeaconCommitteeFromState(ctx, s, params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epoch)), 0)
+		require.NoError(t, err)
+		return testutil.ToFloat64(cache.CommitteeCacheMiss) - before
+	}
+
+	// The head state is too far behind to know the seed of epoch 3.
+	service.precomputeCommittees(3)
+	assert.NotEqual(t, float64(0), misses(3))
+
+	service.precomputeCommittees(1)
+	assert.Equal(t, float64(0), misses(1))
+	assert.Equal(t, float64(0), misses(2))
+}
+
+func TestPrecomputeCommittees_EmptyLastSlot(t *testing.T) {
+	ctx := context.Background()
+	opts := testServiceOptsNoDB()
+	service, err := NewService(ctx, opts...)
+	require.NoError(t, err)
+	helpers.ClearCache()
+
+	// The last block of epoch 1 is before its last slot.
+	s, _ := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, s.SetSlot(2*params.BeaconConfig().SlotsPerEpoch-3))
+	service.head = &head{state: s}
+	epochEnd, err := transition.ProcessSlots(ctx, s.Copy(), 2*params.BeaconConfig().SlotsPerEpoch-1)
+	require.NoError(t, err)
+	root, err := epochEnd.HashTreeRoot(ctx)
+	require.NoError(t, err)
+
+	service.precomputeCommittees(2)
+	cached, err := helpers.HasProposerIndicesInCache(root)
+	require.NoError(t, err)
+	assert.Equal(t, true, cached, "Proposer indices of epoch 2 are not cached")
+	assert.Equal(t, 2*params.BeaconConfig().SlotsPerEpoch-3, s.Slot(), "Head state was advanced")
+}
+
 func TestOnBlock_CanFinalize(t *testing.T) {
 	ctx := context.Background()
 	beaconDB := testDB.SetupDB(t)
@@ -1042,3 +1124,24 @@ func TestService_saveSyncedTipsDB(t *testing.T) {
 	require.Equal(t, 1, len(savedTips))
 	require.Equal(t, types.Slot(100), savedTips[r100])
 }
+
+func TestService_IndexFinalizedValidators(t *testing.T) {
+	ctx := context.Background()
+	opts := testServiceOptsWithDB(t)
+	service, err := NewService(ctx, opts...)
+	require.NoError(t, err)
+	st, _ := util.DeterministicGenesisState(t, 8)
+	pubkey := st.PubkeyAtIndex(5)
+
+	service.indexFinalizedValidators(st)
+	// Wait for the indexing to complete in the background.
+	for indexing := true; indexing; {
+		time.Sleep(10 * time.Millisecond)
+		service.validatorIndicesLock.Lock()
+		indexing = service.indexingValidators
+		service.validatorIndicesLock.Unlock()
+	}
+	indices, err := service.cfg.BeaconDB.ValidatorIndices(ctx, [][fieldparams.BLSPubkeyLength]byte{pubkey})
+	require.NoError(t, err)
+	assert.Equal(t, types.ValidatorIndex(5), indices[pubkey])
+}
@@ -143,6 +143,9 @@ func (s *Service) spawnProcessAttestationsRoutine(stateFeed *event.Feed) {
 					log.WithError(err).Error("Could not process new slot")
 					return
 				}
+				if slots.IsEpochStart(s.CurrentSlot()) {
+					go s.precomputeCommittees(slots.ToEpoch(s.CurrentSlot()))
+				}
 
 				// Continue when there's no fork choice attestation, there's nothing to process and update head.
 				// This covers the condition when the node is still initial syncing to the head of the chain.
@@ -8,6 +8,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
 	"github.com/prysmaticlabs/prysm/time"
 	"github.com/prysmaticlabs/prysm/time/slots"
@@ -21,7 +22,9 @@ var epochsSinceFinalitySaveHotStateDB = types.Epoch(100)
 type BlockReceiver interface {
 	ReceiveBlock(ctx context.Context, block block.SignedBeaconBlock, blockRoot [32]byte) error
 	ReceiveBlockBatch(ctx context.Context, blocks []block.SignedBeaconBlock, blkRoots [][32]byte) error
+	ReceiveBlockBatchWithVerifiedProposers(ctx context.Context, blocks []block.SignedBeaconBlock, blkRoots [][32]byte, proposerVerified []bool) error
 	HasInitSyncBlock(root [32]byte) bool
+	ReceiveProposerEquivocation(ctx context.Context, slashing *ethpb.ProposerSlashing) error
 }
 
 // ReceiveBlock is a function that defines the the operations (minus pubsub)
@@ -73,11 +76,20 @@ func (s *Service) ReceiveBlock(ctx context.Context, block block.SignedBeaconBloc
 // the state, performing batch verification of all collected signatures and then performing the appropriate
 // actions for a block post-transition.
 func (s *Service) ReceiveBlockBatch(ctx context.Context, blocks []block.SignedBeaconBlock, blkRoots [][32]byte) error {
+	return s.ReceiveBlockBatchWithVerifiedProposers(ctx, blocks, blkRoots, nil)
+}
+
+// ReceiveBlockBatchWithVerifiedProposers processes a block batch like ReceiveBlockBatch, except that the
+// proposer signatures of the blocks flagged in proposerVerified were already verified by the caller, and
+// are not verified again.
+func (s *Service) ReceiveBlockBatchWithVerifiedProposers(
+	ctx context.Context, blocks []block.SignedBeaconBlock, blkRoots [][32]byte, proposerVerified []bool,
+) error {
 	ctx, span := trace.StartSpan(ctx, "blockChain.ReceiveBlockBatch")
 	defer span.End()
 
 	// Apply state transition on the incoming newly received blockCopy without verifying its BLS contents.
-	fCheckpoints, jCheckpoints, err := s.onBlockBatch(ctx, blocks, blkRoots)
+	fCheckpoints, jCheckpoints, err := s.onBlockBatch(ctx, blocks, blkRoots, proposerVerified)
 	if err != nil {
 		err := errors.Wrap(err, "could not process block in batch")
 		tracing.AnnotateError(span, err)
@@ -112,6 +124,10 @@ func (s *Service) ReceiveBlockBatch(ctx context.Context, blocks []block.SignedBe
 	if err := s.cfg.BeaconDB.SaveBlocks(ctx, s.getInitSyncBlocks()); err != nil {
 		return err
 	}
+	// Now that the batch is saved, a node restarted during initial sync may resume from it.
+	if err := s.cfg.BeaconDB.SaveInitialSyncProgressRoot(ctx, blkRoots[len(blkRoots)-1]); err != nil {
+		return err
+	}
 	finalized := s.store.FinalizedCheckpt()
 	if finalized == nil {
 		return errNilFinalizedInStore
@@ -131,6 +147,27 @@ func (s *Service) HasInitSyncBlock(root [32]byte) bool {
 	return s.hasInitSyncBlock(root)
 }
 
+// ReceiveProposerEquivocation lets fork choice know about the two conflicting blocks of
+// a proposer equivocation, so that neither of them is treated as a timely block of its slot.
+func (s *Service) ReceiveProposerEquivocation(ctx context.Context, slashing *ethpb.ProposerSlashing) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.ReceiveProposerEquivocation")
+	defer span.End()
+
+	if slashing == nil || slashing.Header_1 == nil || slashing.Header_1.Header == nil ||
+		slashing.Header_2 == nil || slashing.Header_2.Header == nil {
+		return errors.New("nil proposer slashing")
+	}
+	root1, err := slashing.Header_1.Header.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not hash first header")
+	}
+	root2, err := slashing.Header_2.Header.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not hash second header")
+	}
+	return s.cfg.ForkChoiceStore.MarkEquivocatingBlocks(ctx, [][32]byte{root1, root2})
+}
+
 func (s *Service) handlePostBlockOperations(b block.BeaconBlock) error {
 	// Delete the processed block attestations from attestation pool.
 	if err := s.deletePoolAtts(b.Body().Attestations()); err != nil {
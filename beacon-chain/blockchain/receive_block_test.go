@@ -192,7 +192,7 @@ func TestService_ReceiveBlockUpdateHead(t *testing.T) {
 		t.Errorf("Received %d state notifications, expected at least 1", recvd)
 	}
 	// Verify fork choice has processed the block. (Genesis block and the new block)
-	assert.Equal(t, 2, len(s.cfg.ForkChoiceStore.Nodes()))
+	assert.Equal(t, 2, s.cfg.ForkChoiceStore.NodeCount())
 }
 
 func TestService_ReceiveBlockBatch(t *testing.T) {
@@ -288,6 +288,38 @@ func TestService_HasInitSyncBlock(t *testing.T) {
 	}
 }
 
+func TestService_ReceiveProposerEquivocation(t *testing.T) {
+	ctx := context.Background()
+	fc := protoarray.New(0, 0, [32]byte{})
+	opts := append(testServiceOptsNoDB(), WithForkChoiceStore(fc))
+	s, err := NewService(ctx, opts...)
+	require.NoError(t, err)
+
+	header1 := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{Slot: 1, ProposerIndex: 1, BodyRoot: bytesutil.PadTo([]byte("a"), 32)},
+	})
+	header2 := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{Slot: 1, ProposerIndex: 1, BodyRoot: bytesutil.PadTo([]byte("b"), 32)},
+	})
+	root1, err := header1.Header.HashTreeRoot()
+	require.NoError(t, err)
+	root2, err := header2.Header.HashTreeRoot()
+	require.NoError(t, err)
+
+	// Genesis set to 1 slot ago, so that blocks from slot 1 are timely.
+	genesis := time.Now().Add(-time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	require.NoError(t, fc.BoostProposerRoot(ctx, 1, root1, genesis))
+	require.NoError(t, fc.ProcessBlock(ctx, 1, root1, [32]byte{}, [32]byte{}, 0, 0))
+	require.Equal(t, root1, fc.ProposerBoost())
+
+	require.NoError(t, s.ReceiveProposerEquivocation(ctx, &ethpb.ProposerSlashing{Header_1: header1, Header_2: header2}))
+	require.Equal(t, [32]byte{}, fc.ProposerBoost())
+	require.NoError(t, fc.BoostProposerRoot(ctx, 1, root2, genesis))
+	require.Equal(t, [32]byte{}, fc.ProposerBoost())
+
+	require.ErrorContains(t, "nil proposer slashing", s.ReceiveProposerEquivocation(ctx, &ethpb.ProposerSlashing{Header_1: header1}))
+}
+
 func TestCheckSaveHotStateDB_Enabling(t *testing.T) {
 	opts := testServiceOptsWithDB(t)
 	hook := logTest.NewGlobal()
@@ -21,17 +21,19 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	f "github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
-	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
 	prysmTime "github.com/prysmaticlabs/prysm/time"
@@ -63,6 +65,14 @@ type Service struct {
 	justifiedBalances     *stateBalanceCache
 	wsVerifier            *WeakSubjectivityVerifier
 	store                 *store.Store
+	lightClientLock       sync.RWMutex
+	lightClientFinality   *ethpbv2.LightClientFinalityUpdate
+	lightClientOptimistic *ethpbv2.LightClientOptimisticUpdate
+	lightClientBlocks     chan block.SignedBeaconBlock
+	validatorIndicesLock  sync.Mutex
+	indexingValidators    bool
+	headSnapshotLock      sync.Mutex
+	lastHeadSnapshot      time.Time
 }
 
 // config options for the service.
@@ -83,6 +93,8 @@ type config struct {
 	SlasherAttestationsFeed *event.Feed
 	WeakSubjectivityCheckpt *ethpb.Checkpoint
 	FinalizedStateAtStartUp state.BeaconState
+	ExecutionEngineCaller   engine.EngineCaller
+	LivenessCache           *cache.LivenessCache
 }
 
 // NewService instantiates a new block service instance that will
@@ -93,8 +105,9 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		ctx:                  ctx,
 		cancel:               cancel,
 		boundaryRoots:        [][32]byte{},
-		checkpointStateCache: cache.NewCheckpointStateCache(),
+		checkpointStateCache: cache.NewCheckpointStateCache(cache.DefaultCheckpointStateCacheSize),
 		initSyncBlocks:       make(map[[32]byte]block.SignedBeaconBlock),
+		lightClientBlocks:    make(chan block.SignedBeaconBlock, lightClientQueueSize),
 		cfg:                  &config{},
 		store:                &store.Store{},
 	}
@@ -119,6 +132,9 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 
 // Start a blockchain service's main event loop.
 func (s *Service) Start() {
+	if features.Get().EnableLightClient {
+		go s.processLightClientBlocks()
+	}
 	saved := s.cfg.FinalizedStateAtStartUp
 
 	if saved != nil && !saved.IsNil() {
@@ -184,8 +200,7 @@ func (s *Service) startFromSavedState(saved state.BeaconState) error {
 	}
 	s.store = store.New(justified, finalized)
 
-	store := protoarray.New(justified.Epoch, finalized.Epoch, bytesutil.ToBytes32(finalized.Root))
-	s.cfg.ForkChoiceStore = store
+	s.cfg.ForkChoiceStore = f.New(justified.Epoch, finalized.Epoch, bytesutil.ToBytes32(finalized.Root))
 
 	if err := s.loadSyncedTips(originRoot, saved.Slot()); err != nil {
 		return err
@@ -309,6 +324,10 @@ func (s *Service) initializeHeadFromDB(ctx context.Context) error {
 		}
 	}
 
+	if s.resumeInitialSyncHead(ctx, finalizedState) {
+		return nil
+	}
+
 	finalizedBlock, err := s.cfg.BeaconDB.Block(ctx, finalizedRoot)
 	if err != nil {
 		return errors.Wrap(err, "could not get finalized block from db")
@@ -322,6 +341,39 @@ func (s *Service) initializeHeadFromDB(ctx context.Context) error {
 	return nil
 }
 
+// resumeInitialSyncHead sets the head to the last batch processed by initial sync before the node
+// was restarted, if that batch is beyond the finalized state. The head state is regenerated by
+// replaying the saved blocks, so that these blocks are neither requested nor verified again.
+func (s *Service) resumeInitialSyncHead(ctx context.Context, finalizedState state.BeaconState) bool {
+	if finalizedState == nil || finalizedState.IsNil() {
+		return false
+	}
+	root, err := s.cfg.BeaconDB.InitialSyncProgressRoot(ctx)
+	if err != nil {
+		return false
+	}
+	blk, err := s.cfg.BeaconDB.Block(ctx, root)
+	if err != nil || blk == nil || blk.IsNil() || blk.Block().Slot() <= finalizedState.Slot() {
+		return false
+	}
+	// The progress of an earlier initial sync is outdated once the node has saved a later head.
+	headBlock, err := s.cfg.BeaconDB.HeadBlock(ctx)
+	if err == nil && headBlock != nil && !headBlock.IsNil() && headBlock.Block().Slot() > blk.Block().Slot() {
+		return false
+	}
+	log.WithFields(logrus.Fields{
+		"finalizedSlot": finalizedState.Slot(),
+		"slot":          blk.Block().Slot(),
+	}).Info("Resuming initial sync from the last processed batch, regenerating its state")
+	st, err := s.cfg.StateGen.StateByRoot(ctx, root)
+	if err != nil {
+		log.WithError(err).Warn("Could not regenerate the state of the last processed batch, resetting head from the checkpoint")
+		return false
+	}
+	s.setHead(root, blk, st)
+	return true
+}
+
 func (s *Service) startFromPOWChain() error {
 	log.Info("Waiting to reach the validator deposit threshold to start the beacon chain...")
 	if s.cfg.ChainStartFetcher == nil {
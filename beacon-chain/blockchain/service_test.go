@@ -493,6 +493,72 @@ func TestChainService_InitializeChainInfo_HeadSync(t *testing.T) {
 	assert.LogsDoNotContain(t, hook, "resetting head from the checkpoint ('--head-sync' flag is ignored)")
 }
 
+func TestChainService_InitializeChainInfo_ResumeInitialSync(t *testing.T) {
+	hook := logTest.NewGlobal()
+	finalizedSlot := params.BeaconConfig().SlotsPerEpoch*2 + 1
+	beaconDB := testDB.SetupDB(t)
+	ctx := context.Background()
+
+	genesisBlock := util.NewBeaconBlock()
+	genesisRoot, err := genesisBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(genesisBlock)))
+
+	finalizedBlock := util.NewBeaconBlock()
+	finalizedBlock.Block.Slot = finalizedSlot
+	finalizedBlock.Block.ParentRoot = genesisRoot[:]
+	finalizedRoot, err := finalizedBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(finalizedBlock)))
+	finalizedState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, finalizedState.SetSlot(finalizedSlot))
+	require.NoError(t, beaconDB.SaveState(ctx, finalizedState, genesisRoot))
+	require.NoError(t, beaconDB.SaveState(ctx, finalizedState, finalizedRoot))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{
+		Epoch: slots.ToEpoch(finalizedSlot),
+		Root:  finalizedRoot[:],
+	}))
+
+	// Last block processed by initial sync before the restart.
+	progressBlock := util.NewBeaconBlock()
+	progressBlock.Block.Slot = finalizedSlot + params.BeaconConfig().SlotsPerEpoch*3
+	progressBlock.Block.ParentRoot = finalizedRoot[:]
+	progressRoot, err := progressBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(progressBlock)))
+	progressState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, progressState.SetSlot(progressBlock.Block.Slot))
+	require.NoError(t, beaconDB.SaveState(ctx, progressState, progressRoot))
+	require.NoError(t, beaconDB.SaveInitialSyncProgressRoot(ctx, progressRoot))
+
+	attSrv, err := attestations.NewService(ctx, &attestations.Config{})
+	require.NoError(t, err)
+	c, err := NewService(ctx, WithDatabase(beaconDB), WithStateGen(stategen.New(beaconDB)), WithAttestationService(attSrv), WithStateNotifier(&mock.MockStateNotifier{}), WithFinalizedStateAtStartUp(finalizedState))
+	require.NoError(t, err)
+	require.NoError(t, c.startFromSavedState(finalizedState))
+	assert.DeepEqual(t, progressBlock, c.head.block.Proto())
+	assert.Equal(t, progressBlock.Block.Slot, c.HeadSlot())
+	assert.LogsContain(t, hook, "Resuming initial sync from the last processed batch")
+
+	// A head saved after the initial sync progress supersedes it.
+	headBlock := util.NewBeaconBlock()
+	headBlock.Block.Slot = progressBlock.Block.Slot + 1
+	headBlock.Block.ParentRoot = progressRoot[:]
+	headRoot, err := headBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(headBlock)))
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: headBlock.Block.Slot, Root: headRoot[:]}))
+	require.NoError(t, beaconDB.SaveHeadBlockRoot(ctx, headRoot))
+
+	hook.Reset()
+	require.NoError(t, c.initializeHeadFromDB(ctx))
+	assert.DeepEqual(t, finalizedBlock, c.head.block.Proto())
+	assert.LogsDoNotContain(t, hook, "Resuming initial sync from the last processed batch")
+}
+
 func TestChainService_SaveHeadNoDB(t *testing.T) {
 	beaconDB := testDB.SetupDB(t)
 	ctx := context.Background()
@@ -0,0 +1,103 @@
+package blockchain
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+)
+
+var errBatchSignatureVerification = errors.New("batch block signature verification failed")
+
+// signatureVerifier verifies signature batches on a pool of workers. The signatures of the blocks
+// of a batch are added as soon as their state transition has been computed, so that they are
+// verified while the state transition of the following blocks is computed.
+type signatureVerifier struct {
+	sets    chan *bls.SignatureBatch
+	wg      sync.WaitGroup
+	lock    sync.Mutex
+	err     error
+	aborted bool
+}
+
+func newSignatureVerifier(workers int) *signatureVerifier {
+	if workers < 1 {
+		workers = 1
+	}
+	v := &signatureVerifier{
+		sets: make(chan *bls.SignatureBatch, workers),
+	}
+	v.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go v.run()
+	}
+	return v
+}
+
+func (v *signatureVerifier) run() {
+	defer v.wg.Done()
+	for set := range v.sets {
+		if v.done() {
+			continue
+		}
+		verified, err := set.Verify()
+		if err == nil && !verified {
+			err = errBatchSignatureVerification
+		}
+		if err != nil {
+			v.lock.Lock()
+			if v.err == nil {
+				v.err = err
+			}
+			v.lock.Unlock()
+		}
+	}
+}
+
+// done returns whether the remaining signatures need not be verified, because a signature
+// was found to be invalid or because the batch was aborted.
+func (v *signatureVerifier) done() bool {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.err != nil || v.aborted
+}
+
+// add a signature batch to be verified.
+func (v *signatureVerifier) add(set *bls.SignatureBatch) {
+	if len(set.Signatures) == 0 {
+		return
+	}
+	v.sets <- set
+}
+
+// wait for all the added signatures to be verified, and return an error if any is invalid.
+// The verifier cannot be used afterwards.
+func (v *signatureVerifier) wait() error {
+	close(v.sets)
+	v.wg.Wait()
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.err
+}
+
+// abort the verification of the signatures not verified yet, and release the workers.
+func (v *signatureVerifier) abort() {
+	v.lock.Lock()
+	v.aborted = true
+	v.lock.Unlock()
+	close(v.sets)
+	v.wg.Wait()
+}
+
+// withoutProposerSignature removes the proposer signature from the signature batch of a block returned
+// by the state transition, in which it comes first.
+func withoutProposerSignature(set *bls.SignatureBatch) *bls.SignatureBatch {
+	if len(set.Signatures) == 0 {
+		return set
+	}
+	return &bls.SignatureBatch{
+		Signatures: set.Signatures[1:],
+		PublicKeys: set.PublicKeys[1:],
+		Messages:   set.Messages[1:],
+	}
+}
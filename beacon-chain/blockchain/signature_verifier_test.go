@@ -0,0 +1,55 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func signatureSet(t *testing.T, valid bool) *bls.SignatureBatch {
+	key, err := bls.RandKey()
+	require.NoError(t, err)
+	msg := [32]byte{'a'}
+	sig := key.Sign(msg[:])
+	if !valid {
+		msg[0] = 'b'
+	}
+	return &bls.SignatureBatch{
+		Signatures: [][]byte{sig.Marshal()},
+		PublicKeys: []bls.PublicKey{key.PublicKey()},
+		Messages:   [][32]byte{msg},
+	}
+}
+
+func TestSignatureVerifier(t *testing.T) {
+	v := newSignatureVerifier(2)
+	for i := 0; i < 5; i++ {
+		v.add(signatureSet(t, true))
+	}
+	v.add(bls.NewSet())
+	require.NoError(t, v.wait())
+
+	v = newSignatureVerifier(2)
+	for i := 0; i < 5; i++ {
+		v.add(signatureSet(t, i != 3))
+	}
+	require.ErrorIs(t, v.wait(), errBatchSignatureVerification)
+
+	v = newSignatureVerifier(0)
+	v.add(signatureSet(t, false))
+	v.abort()
+}
+
+func TestWithoutProposerSignature(t *testing.T) {
+	// An invalid proposer signature followed by a valid randao reveal.
+	set := signatureSet(t, false).Join(signatureSet(t, true))
+	verified, err := set.Verify()
+	require.NoError(t, err)
+	require.Equal(t, false, verified)
+
+	verified, err = withoutProposerSignature(set).Verify()
+	require.NoError(t, err)
+	require.Equal(t, true, verified)
+	require.Equal(t, 0, len(withoutProposerSignature(bls.NewSet()).Signatures))
+}
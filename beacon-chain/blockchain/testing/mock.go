@@ -23,6 +23,8 @@ import (
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
 	"github.com/sirupsen/logrus"
@@ -60,6 +62,12 @@ type ChainService struct {
 	PublicKey                   [fieldparams.BLSPubkeyLength]byte
 	SyncCommitteePubkeys        [][]byte
 	InitSyncBlockRoots          map[[32]byte]bool
+	Optimistic                  bool
+	ForkChoiceNodes             []*ethpbv1.ForkChoiceNode
+	ProposerEquivocations       []*ethpb.ProposerSlashing
+	FinalityUpdate              *ethpbv2.LightClientFinalityUpdate
+	OptimisticUpdate            *ethpbv2.LightClientOptimisticUpdate
+	ProposerHeadRoot            []byte
 }
 
 // StateNotifier mocks the same method in the chain service.
@@ -184,6 +192,13 @@ func (s *ChainService) ReceiveBlockInitialSync(ctx context.Context, block block.
 	return nil
 }
 
+// ReceiveBlockBatchWithVerifiedProposers processes blocks in batches from initial-sync.
+func (s *ChainService) ReceiveBlockBatchWithVerifiedProposers(
+	ctx context.Context, blks []block.SignedBeaconBlock, roots [][32]byte, _ []bool,
+) error {
+	return s.ReceiveBlockBatch(ctx, blks, roots)
+}
+
 // ReceiveBlockBatch processes blocks in batches from initial-sync.
 func (s *ChainService) ReceiveBlockBatch(ctx context.Context, blks []block.SignedBeaconBlock, _ [][32]byte) error {
 	if s.State == nil {
@@ -370,6 +385,22 @@ func (s *ChainService) HasInitSyncBlock(rt [32]byte) bool {
 	return s.InitSyncBlockRoots[rt]
 }
 
+// ReceiveProposerEquivocation mocks the same method in the chain service.
+func (s *ChainService) ReceiveProposerEquivocation(_ context.Context, slashing *ethpb.ProposerSlashing) error {
+	s.ProposerEquivocations = append(s.ProposerEquivocations, slashing)
+	return nil
+}
+
+// LightClientFinalityUpdate mocks the same method in the chain service.
+func (s *ChainService) LightClientFinalityUpdate() *ethpbv2.LightClientFinalityUpdate {
+	return s.FinalityUpdate
+}
+
+// LightClientOptimisticUpdate mocks the same method in the chain service.
+func (s *ChainService) LightClientOptimisticUpdate() *ethpbv2.LightClientOptimisticUpdate {
+	return s.OptimisticUpdate
+}
+
 // HeadGenesisValidatorsRoot mocks HeadGenesisValidatorsRoot method in chain service.
 func (_ *ChainService) HeadGenesisValidatorsRoot() [32]byte {
 	return [32]byte{}
@@ -405,6 +436,30 @@ func (_ *ChainService) ChainHeads() ([][32]byte, []types.Slot) {
 		[]types.Slot{0, 1}
 }
 
+// ProposerHead mocks the same method in the chain service.
+func (s *ChainService) ProposerHead(ctx context.Context, _ types.Slot) ([]byte, error) {
+	if s.ProposerHeadRoot != nil {
+		return s.ProposerHeadRoot, nil
+	}
+	return s.HeadRoot(ctx)
+}
+
+// ForkChoiceDump mocks the same method in the chain service.
+func (s *ChainService) ForkChoiceDump(_ context.Context) (*ethpbv1.ForkChoiceDump, error) {
+	dump := &ethpbv1.ForkChoiceDump{
+		HeadRoot:          s.Root,
+		ProposerBoostRoot: params.BeaconConfig().ZeroHash[:],
+		Nodes:             s.ForkChoiceNodes,
+	}
+	if s.CurrentJustifiedCheckPoint != nil {
+		dump.JustifiedCheckpoint = &ethpbv1.Checkpoint{Epoch: s.CurrentJustifiedCheckPoint.Epoch, Root: s.CurrentJustifiedCheckPoint.Root}
+	}
+	if s.FinalizedCheckPoint != nil {
+		dump.FinalizedCheckpoint = &ethpbv1.Checkpoint{Epoch: s.FinalizedCheckPoint.Epoch, Root: s.FinalizedCheckPoint.Root}
+	}
+	return dump, nil
+}
+
 // HeadPublicKeyToValidatorIndex mocks HeadPublicKeyToValidatorIndex and always return 0 and true.
 func (_ *ChainService) HeadPublicKeyToValidatorIndex(_ [fieldparams.BLSPubkeyLength]byte) (types.ValidatorIndex, bool) {
 	return 0, true
@@ -442,10 +497,10 @@ func (s *ChainService) HeadSyncContributionProofDomain(_ context.Context, _ type
 
 // IsOptimistic mocks the same method in the chain service.
 func (s *ChainService) IsOptimistic(_ context.Context) (bool, error) {
-	return false, nil
+	return s.Optimistic, nil
 }
 
 // IsOptimisticForRoot mocks the same method in the chain service.
 func (s *ChainService) IsOptimisticForRoot(_ context.Context, _ [32]byte, _ types.Slot) (bool, error) {
-	return false, nil
+	return s.Optimistic, nil
 }
@@ -4,20 +4,22 @@ import (
 	"sync"
 
 	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
-	"github.com/prysmaticlabs/prysm/crypto/hash"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 )
 
-var (
-	// maxCheckpointStateSize defines the max number of entries check point to state cache can contain.
-	// Choosing 10 to account for multiple forks, this allows 5 forks per epoch boundary with 2 epochs
-	// window to accept attestation based on latest spec.
-	maxCheckpointStateSize = 10
+// DefaultCheckpointStateCacheSize defines the default max number of entries check point to state cache
+// can contain. Choosing 10 to account for multiple forks, this allows 5 forks per epoch boundary with 2
+// epochs window to accept attestation based on latest spec.
+const DefaultCheckpointStateCacheSize = 10
 
+var (
 	// Metrics.
 	checkpointStateMiss = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "check_point_state_cache_miss",
@@ -27,18 +29,29 @@ var (
 		Name: "check_point_state_cache_hit",
 		Help: "The number of check point state requests that are present in the cache.",
 	})
+	checkpointStateEviction = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "check_point_state_cache_eviction",
+		Help: "The number of check point states evicted from the cache to stay within its size.",
+	})
 )
 
+// checkpointKey is the root of a checkpoint followed by its epoch, as the same block root may be
+// the checkpoint of several epochs when their start slots are skipped.
+type checkpointKey [fieldparams.RootLength + 8]byte
+
 // CheckpointStateCache is a struct with 1 queue for looking up state by checkpoint.
 type CheckpointStateCache struct {
 	cache *lru.Cache
 	lock  sync.RWMutex
 }
 
-// NewCheckpointStateCache creates a new checkpoint state cache for storing/accessing processed state.
-func NewCheckpointStateCache() *CheckpointStateCache {
+// NewCheckpointStateCache creates a new checkpoint state cache for storing/accessing processed state,
+// which holds up to the given number of states. The least recently used state is evicted when full.
+func NewCheckpointStateCache(size int) *CheckpointStateCache {
 	return &CheckpointStateCache{
-		cache: lruwrpr.New(maxCheckpointStateSize),
+		cache: lruwrpr.NewWithEvict(size, func(_ interface{}, _ interface{}) {
+			checkpointStateEviction.Inc()
+		}),
 	}
 }
 
@@ -47,12 +60,12 @@ func NewCheckpointStateCache() *CheckpointStateCache {
 func (c *CheckpointStateCache) StateByCheckpoint(cp *ethpb.Checkpoint) (state.BeaconState, error) {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
-	h, err := hash.HashProto(cp)
+	k, err := keyOfCheckpoint(cp)
 	if err != nil {
 		return nil, err
 	}
 
-	item, exists := c.cache.Get(h)
+	item, exists := c.cache.Get(k)
 
 	if exists && item != nil {
 		checkpointStateHit.Inc()
@@ -65,14 +78,24 @@ func (c *CheckpointStateCache) StateByCheckpoint(cp *ethpb.Checkpoint) (state.Be
 }
 
 // AddCheckpointState adds CheckpointState object to the cache. This method also trims the least
-// recently added CheckpointState object if the cache size has ready the max cache size limit.
+// recently used CheckpointState object if the cache size has ready the max cache size limit.
 func (c *CheckpointStateCache) AddCheckpointState(cp *ethpb.Checkpoint, s state.ReadOnlyBeaconState) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	h, err := hash.HashProto(cp)
+	k, err := keyOfCheckpoint(cp)
 	if err != nil {
 		return err
 	}
-	c.cache.Add(h, s)
+	c.cache.Add(k, s)
 	return nil
 }
+
+func keyOfCheckpoint(cp *ethpb.Checkpoint) (checkpointKey, error) {
+	var k checkpointKey
+	if cp == nil || len(cp.Root) != fieldparams.RootLength {
+		return k, errors.New("invalid checkpoint")
+	}
+	copy(k[:], cp.Root)
+	copy(k[fieldparams.RootLength:], bytesutil.Bytes8(uint64(cp.Epoch)))
+	return k, nil
+}
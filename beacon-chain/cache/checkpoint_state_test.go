@@ -15,7 +15,7 @@ import (
 )
 
 func TestCheckpointStateCache_StateByCheckpoint(t *testing.T) {
-	cache := NewCheckpointStateCache()
+	cache := NewCheckpointStateCache(DefaultCheckpointStateCacheSize)
 
 	cp1 := &ethpb.Checkpoint{Epoch: 1, Root: bytesutil.PadTo([]byte{'A'}, 32)}
 	st, err := v1.InitializeFromProto(&ethpb.BeaconState{
@@ -58,16 +58,42 @@ func TestCheckpointStateCache_StateByCheckpoint(t *testing.T) {
 }
 
 func TestCheckpointStateCache_MaxSize(t *testing.T) {
-	c := NewCheckpointStateCache()
+	c := NewCheckpointStateCache(DefaultCheckpointStateCacheSize)
 	st, err := v1.InitializeFromProto(&ethpb.BeaconState{
 		Slot: 0,
 	})
 	require.NoError(t, err)
 
-	for i := uint64(0); i < uint64(maxCheckpointStateSize+100); i++ {
+	for i := uint64(0); i < uint64(DefaultCheckpointStateCacheSize+100); i++ {
 		require.NoError(t, st.SetSlot(types.Slot(i)))
 		require.NoError(t, c.AddCheckpointState(&ethpb.Checkpoint{Epoch: types.Epoch(i), Root: make([]byte, 32)}, st))
 	}
 
-	assert.Equal(t, maxCheckpointStateSize, len(c.cache.Keys()))
+	assert.Equal(t, DefaultCheckpointStateCacheSize, len(c.cache.Keys()))
+}
+
+func TestCheckpointStateCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewCheckpointStateCache(2)
+	st, err := v1.InitializeFromProto(&ethpb.BeaconState{})
+	require.NoError(t, err)
+	cp1 := &ethpb.Checkpoint{Epoch: 1, Root: bytesutil.PadTo([]byte{'A'}, 32)}
+	cp2 := &ethpb.Checkpoint{Epoch: 2, Root: bytesutil.PadTo([]byte{'A'}, 32)}
+	cp3 := &ethpb.Checkpoint{Epoch: 2, Root: bytesutil.PadTo([]byte{'B'}, 32)}
+	require.NoError(t, c.AddCheckpointState(cp1, st))
+	require.NoError(t, c.AddCheckpointState(cp2, st))
+
+	// The same root at another epoch is another checkpoint.
+	s, err := c.StateByCheckpoint(cp1)
+	require.NoError(t, err)
+	assert.NotNil(t, s)
+	require.NoError(t, c.AddCheckpointState(cp3, st))
+	s, err = c.StateByCheckpoint(cp2)
+	require.NoError(t, err)
+	assert.Equal(t, state.BeaconState(nil), s, "Expected least recently used state to be evicted")
+	s, err = c.StateByCheckpoint(cp1)
+	require.NoError(t, err)
+	assert.NotNil(t, s)
+
+	_, err = c.StateByCheckpoint(&ethpb.Checkpoint{Root: []byte{'A'}})
+	require.ErrorContains(t, "invalid checkpoint", err)
 }
@@ -162,6 +162,30 @@ func (dc *DepositCache) InsertFinalizedDeposits(ctx context.Context, eth1Deposit
 	}
 }
 
+// InsertFinalizedDepositsSnapshot rebuilds the finalized deposits trie from a deposit tree snapshot, for
+// nodes which did not process the finalized deposits themselves, such as checkpoint synced ones. Deposits
+// with an index greater than the ones of the snapshot keep being finalized by InsertFinalizedDeposits.
+func (dc *DepositCache) InsertFinalizedDepositsSnapshot(ctx context.Context, snapshot *trie.DepositTreeSnapshot) error {
+	_, span := trace.StartSpan(ctx, "DepositsCache.InsertFinalizedDepositsSnapshot")
+	defer span.End()
+	dc.depositsLock.Lock()
+	defer dc.depositsLock.Unlock()
+
+	merkleTrieIndex := int64(snapshot.DepositCount) - 1
+	if merkleTrieIndex <= dc.finalizedDeposits.MerkleTrieIndex {
+		return nil
+	}
+	depositTrie, err := trie.TrieFromSnapshot(snapshot, params.BeaconConfig().DepositContractTreeDepth)
+	if err != nil {
+		return errors.Wrap(err, "could not rebuild deposit trie from snapshot")
+	}
+	dc.finalizedDeposits = &FinalizedDeposits{
+		Deposits:        depositTrie,
+		MerkleTrieIndex: merkleTrieIndex,
+	}
+	return nil
+}
+
 // AllDepositContainers returns all historical deposit containers.
 func (dc *DepositCache) AllDepositContainers(ctx context.Context) []*ethpb.DepositContainer {
 	_, span := trace.StartSpan(ctx, "DepositsCache.AllDepositContainers")
@@ -206,7 +230,9 @@ func (dc *DepositCache) DepositsNumberAndRootAtHeight(ctx context.Context, block
 	if heightIdx == 0 {
 		return 0, [32]byte{}
 	}
-	return uint64(heightIdx), bytesutil.ToBytes32(dc.deposits[heightIdx-1].DepositRoot)
+	// Deposits may not start at index 0 when they were processed on top of a deposit tree snapshot.
+	last := dc.deposits[heightIdx-1]
+	return uint64(last.Index + 1), bytesutil.ToBytes32(last.DepositRoot)
 }
 
 // DepositByPubkey looks through historical deposits and finds one which contains
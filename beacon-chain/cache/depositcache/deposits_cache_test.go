@@ -491,6 +491,49 @@ func TestFinalizedDeposits_UtilizesPreviouslyCachedDeposits(t *testing.T) {
 	assert.Equal(t, trie.HashTreeRoot(), cachedDeposits.Deposits.HashTreeRoot())
 }
 
+func TestFinalizedDeposits_FromSnapshot(t *testing.T) {
+	dc, err := New()
+	require.NoError(t, err)
+
+	var ctrs []*ethpb.DepositContainer
+	var deps [][]byte
+	for i := 0; i < 4; i++ {
+		ctr := &ethpb.DepositContainer{
+			Deposit: &ethpb.Deposit{
+				Data: &ethpb.Deposit_Data{
+					PublicKey:             bytesutil.PadTo([]byte{byte(i)}, 48),
+					WithdrawalCredentials: make([]byte, 32),
+					Signature:             make([]byte, 96),
+				},
+			},
+			Index: int64(i),
+		}
+		hash, err := ctr.Deposit.Data.HashTreeRoot()
+		require.NoError(t, err)
+		ctrs = append(ctrs, ctr)
+		deps = append(deps, hash[:])
+	}
+	fullTrie, err := trie.GenerateTrieFromItems(deps, params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	snapshot, err := fullTrie.Snapshot(2, [32]byte{}, 0)
+	require.NoError(t, err)
+
+	// Only the deposits following the snapshot are known to the cache.
+	dc.deposits = ctrs[2:]
+	require.NoError(t, dc.InsertFinalizedDepositsSnapshot(context.Background(), snapshot))
+	assert.Equal(t, int64(1), dc.FinalizedDeposits(context.Background()).MerkleTrieIndex)
+	assert.Equal(t, snapshot.DepositRoot, dc.FinalizedDeposits(context.Background()).Deposits.HashTreeRoot())
+
+	dc.InsertFinalizedDeposits(context.Background(), 3)
+	cachedDeposits := dc.FinalizedDeposits(context.Background())
+	assert.Equal(t, int64(3), cachedDeposits.MerkleTrieIndex)
+	assert.Equal(t, fullTrie.HashTreeRoot(), cachedDeposits.Deposits.HashTreeRoot())
+
+	// Older snapshots do not override the finalized deposits.
+	require.NoError(t, dc.InsertFinalizedDepositsSnapshot(context.Background(), snapshot))
+	assert.Equal(t, int64(3), dc.FinalizedDeposits(context.Background()).MerkleTrieIndex)
+}
+
 func TestFinalizedDeposits_InitializedCorrectly(t *testing.T) {
 	dc, err := New()
 	require.NoError(t, err)
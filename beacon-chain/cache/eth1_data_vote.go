@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// Eth1DataVoteCacheMiss tracks the number of eth1 data vote requests that aren't present in the cache.
+	Eth1DataVoteCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "eth1_data_vote_cache_miss",
+		Help: "The number of eth1 data vote requests that aren't present in the cache.",
+	})
+	// Eth1DataVoteCacheHit tracks the number of eth1 data vote requests that are in the cache.
+	Eth1DataVoteCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "eth1_data_vote_cache_hit",
+		Help: "The number of eth1 data vote requests that are present in the cache.",
+	})
+)
+
+type eth1DataVote struct {
+	headEth1Data *ethpb.Eth1Data
+	vote         *ethpb.Eth1Data
+}
+
+// Eth1DataVoteCache stores the eth1 data votes computed for block proposals, keyed by the start time
+// of their eth1 voting period. All proposals of a voting period vote for the same eth1 block, so the
+// vote is only computed once per period, unless the eth1 data of the head state changes within the
+// period. Votes of periods prior to the previous one are pruned as newer ones are stored.
+type Eth1DataVoteCache struct {
+	votes map[uint64]eth1DataVote
+	lock  sync.RWMutex
+}
+
+// NewEth1DataVoteCache creates a new eth1 data vote cache.
+func NewEth1DataVoteCache() *Eth1DataVoteCache {
+	return &Eth1DataVoteCache{votes: make(map[uint64]eth1DataVote)}
+}
+
+// Vote returns a copy of the eth1 data vote computed for the voting period starting at the given
+// time against the given eth1 data of the head state.
+func (c *Eth1DataVoteCache) Vote(votingPeriodStart uint64, headEth1Data *ethpb.Eth1Data) (*ethpb.Eth1Data, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	v, ok := c.votes[votingPeriodStart]
+	if !ok || !proto.Equal(v.headEth1Data, headEth1Data) {
+		Eth1DataVoteCacheMiss.Inc()
+		return nil, false
+	}
+	Eth1DataVoteCacheHit.Inc()
+	return ethpb.CopyETH1Data(v.vote), true
+}
+
+// SetVote stores the eth1 data vote computed for the voting period starting at the given time against
+// the given eth1 data of the head state, and prunes the votes of periods prior to the previous one.
+func (c *Eth1DataVoteCache) SetVote(votingPeriodStart uint64, headEth1Data, vote *ethpb.Eth1Data) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.votes[votingPeriodStart] = eth1DataVote{
+		headEth1Data: ethpb.CopyETH1Data(headEth1Data),
+		vote:         ethpb.CopyETH1Data(vote),
+	}
+	// Keep the vote of the previous period, which may still be requested
+	// for proposals at the boundary of the voting periods.
+	latest := uint64(0)
+	for start := range c.votes {
+		if start > latest {
+			latest = start
+		}
+	}
+	var previous uint64
+	for start := range c.votes {
+		if start < latest && start > previous {
+			previous = start
+		}
+	}
+	for start := range c.votes {
+		if start < previous {
+			delete(c.votes, start)
+		}
+	}
+}
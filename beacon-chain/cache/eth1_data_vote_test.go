@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestEth1DataVoteCache_RoundTrip(t *testing.T) {
+	c := NewEth1DataVoteCache()
+	head := &ethpb.Eth1Data{DepositCount: 1, BlockHash: []byte{'a'}}
+	vote := &ethpb.Eth1Data{DepositCount: 2, BlockHash: []byte{'b'}}
+
+	_, ok := c.Vote(100, head)
+	require.Equal(t, false, ok)
+
+	c.SetVote(100, head, vote)
+	cached, ok := c.Vote(100, head)
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, vote, cached)
+
+	// Returned votes are copies.
+	cached.DepositCount = 3
+	cached, ok = c.Vote(100, head)
+	require.Equal(t, true, ok)
+	assert.Equal(t, uint64(2), cached.DepositCount)
+
+	// Votes are invalidated by a change of the eth1 data of the head state.
+	_, ok = c.Vote(100, &ethpb.Eth1Data{DepositCount: 2, BlockHash: []byte{'b'}})
+	require.Equal(t, false, ok)
+	_, ok = c.Vote(200, head)
+	require.Equal(t, false, ok)
+}
+
+func TestEth1DataVoteCache_Prunes(t *testing.T) {
+	c := NewEth1DataVoteCache()
+	head := &ethpb.Eth1Data{}
+	c.SetVote(100, head, &ethpb.Eth1Data{DepositCount: 1})
+	c.SetVote(200, head, &ethpb.Eth1Data{DepositCount: 2})
+	_, ok := c.Vote(100, head)
+	require.Equal(t, true, ok, "Vote of the previous period should be kept")
+
+	c.SetVote(300, head, &ethpb.Eth1Data{DepositCount: 3})
+	_, ok = c.Vote(100, head)
+	require.Equal(t, false, ok)
+	_, ok = c.Vote(200, head)
+	require.Equal(t, true, ok)
+	_, ok = c.Vote(300, head)
+	require.Equal(t, true, ok)
+}
@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"sync"
+
+	types "github.com/prysmaticlabs/eth2-types"
+)
+
+// LivenessCache tracks the validators seen performing their duties in recent epochs, by proposing
+// a block or by attesting, in the blocks and gossip attestations processed by the node. Only the
+// most recent epoch seen and the previous one are kept, as older epochs can no longer be queried.
+type LivenessCache struct {
+	live        map[types.Epoch]map[types.ValidatorIndex]bool
+	latestEpoch types.Epoch
+	lock        sync.RWMutex
+}
+
+// NewLivenessCache creates a new validator liveness cache.
+func NewLivenessCache() *LivenessCache {
+	return &LivenessCache{live: make(map[types.Epoch]map[types.ValidatorIndex]bool)}
+}
+
+// MarkLive records the given validators as live in the given epoch. Epochs prior to the previous
+// epoch of the latest epoch seen are ignored, and pruned as newer epochs are seen.
+func (c *LivenessCache) MarkLive(epoch types.Epoch, indices []uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if epoch+1 < c.latestEpoch {
+		return
+	}
+	if epoch > c.latestEpoch {
+		c.latestEpoch = epoch
+		for e := range c.live {
+			if e+1 < epoch {
+				delete(c.live, e)
+			}
+		}
+	}
+	live, ok := c.live[epoch]
+	if !ok {
+		live = make(map[types.ValidatorIndex]bool)
+		c.live[epoch] = live
+	}
+	for _, i := range indices {
+		live[types.ValidatorIndex(i)] = true
+	}
+}
+
+// IsLive returns true if the validator was seen performing its duties in the given epoch.
+func (c *LivenessCache) IsLive(epoch types.Epoch, index types.ValidatorIndex) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.live[epoch][index]
+}
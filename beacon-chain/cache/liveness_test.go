@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestLivenessCache_MarkLive(t *testing.T) {
+	c := NewLivenessCache()
+	require.Equal(t, false, c.IsLive(1, 1))
+
+	c.MarkLive(1, []uint64{1, 2})
+	require.Equal(t, true, c.IsLive(1, 1))
+	require.Equal(t, true, c.IsLive(1, 2))
+	require.Equal(t, false, c.IsLive(1, 3))
+	require.Equal(t, false, c.IsLive(0, 1))
+
+	// The previous epoch of the latest epoch is kept.
+	c.MarkLive(2, []uint64{3})
+	c.MarkLive(1, []uint64{3})
+	require.Equal(t, true, c.IsLive(1, 1))
+	require.Equal(t, true, c.IsLive(1, 3))
+	require.Equal(t, true, c.IsLive(2, 3))
+
+	// Older epochs are pruned and ignored.
+	c.MarkLive(3, []uint64{4})
+	require.Equal(t, false, c.IsLive(1, 1))
+	c.MarkLive(1, []uint64{5})
+	require.Equal(t, false, c.IsLive(1, 5))
+	require.Equal(t, true, c.IsLive(2, 3))
+	require.Equal(t, true, c.IsLive(3, 4))
+}
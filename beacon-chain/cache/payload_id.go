@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	types "github.com/prysmaticlabs/eth2-types"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+)
+
+var (
+	// PayloadIDCacheMiss tracks the number of payload ID requests that aren't present in the cache.
+	PayloadIDCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "payload_id_cache_miss",
+		Help: "The number of payload ID requests that aren't present in the cache.",
+	})
+	// PayloadIDCacheHit tracks the number of payload ID requests that are in the cache.
+	PayloadIDCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "payload_id_cache_hit",
+		Help: "The number of payload ID requests that are present in the cache.",
+	})
+)
+
+// PayloadID is the identifier returned by the execution node for a payload it builds.
+type PayloadID = [8]byte
+
+// payloadIDKey identifies the payload built for a proposal on top of a head block.
+type payloadIDKey struct {
+	slot         types.Slot
+	headRoot     [32]byte
+	feeRecipient [fieldparams.FeeRecipientLength]byte
+}
+
+// PayloadIDCache stores the payload IDs returned by forkchoice updates with payload attributes, so the
+// proposer of a slot can retrieve the payload being built without re-issuing a forkchoice update at
+// proposal time. Payload IDs of past slots are pruned as newer ones are stored.
+type PayloadIDCache struct {
+	ids  map[payloadIDKey]PayloadID
+	lock sync.RWMutex
+}
+
+// NewPayloadIDCache creates a new payload ID cache.
+func NewPayloadIDCache() *PayloadIDCache {
+	return &PayloadIDCache{ids: make(map[payloadIDKey]PayloadID)}
+}
+
+// PayloadID returns the ID of the payload built for the given slot, head block root and fee recipient.
+func (c *PayloadIDCache) PayloadID(
+	slot types.Slot, headRoot [32]byte, feeRecipient [fieldparams.FeeRecipientLength]byte,
+) (PayloadID, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	id, ok := c.ids[payloadIDKey{slot: slot, headRoot: headRoot, feeRecipient: feeRecipient}]
+	if ok {
+		PayloadIDCacheHit.Inc()
+	} else {
+		PayloadIDCacheMiss.Inc()
+	}
+	return id, ok
+}
+
+// SetPayloadID stores the ID of the payload built for the given slot, head block root and fee recipient,
+// and prunes the payload IDs of slots prior to the previous one, which can no longer be proposed.
+func (c *PayloadIDCache) SetPayloadID(
+	slot types.Slot, headRoot [32]byte, feeRecipient [fieldparams.FeeRecipientLength]byte, id PayloadID,
+) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.ids[payloadIDKey{slot: slot, headRoot: headRoot, feeRecipient: feeRecipient}] = id
+	for k := range c.ids {
+		if k.slot+1 < slot {
+			delete(c.ids, k)
+		}
+	}
+}
+
+// Prune removes the payload IDs of slots prior to the given slot.
+func (c *PayloadIDCache) Prune(slot types.Slot) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for k := range c.ids {
+		if k.slot < slot {
+			delete(c.ids, k)
+		}
+	}
+}
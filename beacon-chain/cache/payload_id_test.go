@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestPayloadIDCache_RoundTrip(t *testing.T) {
+	c := NewPayloadIDCache()
+	root := [32]byte{'a'}
+	recipient := [20]byte{'b'}
+
+	_, ok := c.PayloadID(1, root, recipient)
+	require.Equal(t, false, ok)
+
+	c.SetPayloadID(1, root, recipient, PayloadID{1})
+	id, ok := c.PayloadID(1, root, recipient)
+	require.Equal(t, true, ok)
+	require.Equal(t, PayloadID{1}, id)
+
+	// Payloads are distinguished by slot, head root and fee recipient.
+	_, ok = c.PayloadID(2, root, recipient)
+	require.Equal(t, false, ok)
+	_, ok = c.PayloadID(1, [32]byte{'c'}, recipient)
+	require.Equal(t, false, ok)
+	_, ok = c.PayloadID(1, root, [20]byte{'d'})
+	require.Equal(t, false, ok)
+
+	// A newer payload ID for the same key overrides the previous one.
+	c.SetPayloadID(1, root, recipient, PayloadID{2})
+	id, ok = c.PayloadID(1, root, recipient)
+	require.Equal(t, true, ok)
+	require.Equal(t, PayloadID{2}, id)
+}
+
+func TestPayloadIDCache_Prune(t *testing.T) {
+	c := NewPayloadIDCache()
+	root := [32]byte{'a'}
+	recipient := [20]byte{'b'}
+	for slot := types.Slot(1); slot <= 3; slot++ {
+		c.SetPayloadID(slot, root, recipient, PayloadID{byte(slot)})
+	}
+	// Storing the payload ID of slot 3 pruned that of slot 1.
+	_, ok := c.PayloadID(1, root, recipient)
+	require.Equal(t, false, ok)
+	_, ok = c.PayloadID(2, root, recipient)
+	require.Equal(t, true, ok)
+
+	c.Prune(3)
+	_, ok = c.PayloadID(2, root, recipient)
+	require.Equal(t, false, ok)
+	_, ok = c.PayloadID(3, root, recipient)
+	require.Equal(t, true, ok)
+}
@@ -76,6 +76,17 @@ func ProcessInactivityScores(
 	ctx context.Context,
 	beaconState state.BeaconState,
 	vals []*precompute.Validator,
+) (state.BeaconState, []*precompute.Validator, error) {
+	return processInactivityScores(ctx, beaconState, vals, 1)
+}
+
+// processInactivityScores updates the inactivity scores of the validators with the given number of
+// parallel workers. The score of a validator only depends on its own precomputed records.
+func processInactivityScores(
+	ctx context.Context,
+	beaconState state.BeaconState,
+	vals []*precompute.Validator,
+	workers int,
 ) (state.BeaconState, []*precompute.Validator, error) {
 	_, span := trace.StartSpan(ctx, "altair.ProcessInactivityScores")
 	defer span.End()
@@ -94,32 +105,39 @@ func ProcessInactivityScores(
 	recoveryRate := cfg.InactivityScoreRecoveryRate
 	prevEpoch := time.PrevEpoch(beaconState)
 	finalizedEpoch := beaconState.FinalizedCheckpointEpoch()
-	for i, v := range vals {
-		if !precompute.EligibleForRewards(v) {
-			continue
-		}
-
-		if v.IsPrevEpochTargetAttester && !v.IsSlashed {
-			// Decrease inactivity score when validator gets target correct.
-			if v.InactivityScore > 0 {
-				v.InactivityScore -= 1
+	if err := helpers.ForEachValidatorChunk(len(vals), workers, func(start, end int) error {
+		var err error
+		for i := start; i < end; i++ {
+			v := vals[i]
+			if !precompute.EligibleForRewards(v) {
+				continue
 			}
-		} else {
-			v.InactivityScore, err = math.Add64(v.InactivityScore, bias)
-			if err != nil {
-				return nil, nil, err
+
+			if v.IsPrevEpochTargetAttester && !v.IsSlashed {
+				// Decrease inactivity score when validator gets target correct.
+				if v.InactivityScore > 0 {
+					v.InactivityScore -= 1
+				}
+			} else {
+				v.InactivityScore, err = math.Add64(v.InactivityScore, bias)
+				if err != nil {
+					return err
+				}
 			}
-		}
 
-		if !helpers.IsInInactivityLeak(prevEpoch, finalizedEpoch) {
-			score := recoveryRate
-			// Prevents underflow below 0.
-			if score > v.InactivityScore {
-				score = v.InactivityScore
+			if !helpers.IsInInactivityLeak(prevEpoch, finalizedEpoch) {
+				score := recoveryRate
+				// Prevents underflow below 0.
+				if score > v.InactivityScore {
+					score = v.InactivityScore
+				}
+				v.InactivityScore -= score
 			}
-			v.InactivityScore -= score
+			inactivityScores[i] = v.InactivityScore
 		}
-		inactivityScores[i] = v.InactivityScore
+		return nil
+	}); err != nil {
+		return nil, nil, err
 	}
 
 	if err := beaconState.SetInactivityScores(inactivityScores); err != nil {
@@ -212,6 +230,18 @@ func ProcessRewardsAndPenaltiesPrecompute(
 	beaconState state.BeaconStateAltair,
 	bal *precompute.Balance,
 	vals []*precompute.Validator,
+) (state.BeaconStateAltair, error) {
+	return ProcessRewardsAndPenaltiesParallel(beaconState, bal, vals, 1)
+}
+
+// ProcessRewardsAndPenaltiesParallel is ProcessRewardsAndPenaltiesPrecompute with the rewards and
+// penalties of the validators computed and applied by the given number of parallel workers. The
+// rewards of a validator only depend on its own precomputed records and the total balances.
+func ProcessRewardsAndPenaltiesParallel(
+	beaconState state.BeaconStateAltair,
+	bal *precompute.Balance,
+	vals []*precompute.Validator,
+	workers int,
 ) (state.BeaconStateAltair, error) {
 	// Don't process rewards and penalties in genesis epoch.
 	cfg := params.BeaconConfig()
@@ -225,24 +255,30 @@ func ProcessRewardsAndPenaltiesPrecompute(
 		return beaconState, errors.New("validator registries not the same length as state's validator registries")
 	}
 
-	attsRewards, attsPenalties, err := AttestationsDelta(beaconState, bal, vals)
+	attsRewards, attsPenalties, err := attestationsDelta(beaconState, bal, vals, workers)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get attestation delta")
 	}
 
 	balances := beaconState.Balances()
-	for i := 0; i < numOfVals; i++ {
-		vals[i].BeforeEpochTransitionBalance = balances[i]
+	if err := helpers.ForEachValidatorChunk(numOfVals, workers, func(start, end int) error {
+		var err error
+		for i := start; i < end; i++ {
+			vals[i].BeforeEpochTransitionBalance = balances[i]
+
+			// Compute the post balance of the validator after accounting for the
+			// attester and proposer rewards and penalties.
+			balances[i], err = helpers.IncreaseBalanceWithVal(balances[i], attsRewards[i])
+			if err != nil {
+				return err
+			}
+			balances[i] = helpers.DecreaseBalanceWithVal(balances[i], attsPenalties[i])
 
-		// Compute the post balance of the validator after accounting for the
-		// attester and proposer rewards and penalties.
-		balances[i], err = helpers.IncreaseBalanceWithVal(balances[i], attsRewards[i])
-		if err != nil {
-			return nil, err
+			vals[i].AfterEpochTransitionBalance = balances[i]
 		}
-		balances[i] = helpers.DecreaseBalanceWithVal(balances[i], attsPenalties[i])
-
-		vals[i].AfterEpochTransitionBalance = balances[i]
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 
 	if err := beaconState.SetBalances(balances); err != nil {
@@ -255,20 +291,76 @@ func ProcessRewardsAndPenaltiesPrecompute(
 // AttestationsDelta computes and returns the rewards and penalties differences for individual validators based on the
 // voting records.
 func AttestationsDelta(beaconState state.BeaconState, bal *precompute.Balance, vals []*precompute.Validator) (rewards, penalties []uint64, err error) {
+	return attestationsDelta(beaconState, bal, vals, 1)
+}
+
+// attestationsDelta computes the rewards and penalties of the validators with the given number of
+// parallel workers.
+func attestationsDelta(beaconState state.BeaconState, bal *precompute.Balance, vals []*precompute.Validator, workers int) (rewards, penalties []uint64, err error) {
 	numOfVals := beaconState.NumValidators()
 	rewards = make([]uint64, numOfVals)
 	penalties = make([]uint64, numOfVals)
 
+	baseRewardMultiplier, inactivityDenominator, leak, err := attestationsDeltaParams(beaconState, bal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := helpers.ForEachValidatorChunk(len(vals), workers, func(start, end int) error {
+		var err error
+		for i := start; i < end; i++ {
+			rewards[i], penalties[i], err = attestationDelta(bal, vals[i], baseRewardMultiplier, inactivityDenominator, leak)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return rewards, penalties, nil
+}
+
+// AttestationDeltaDetail is the attestation reward and penalty of a validator broken down by voting duty.
+type AttestationDeltaDetail struct {
+	SourceReward      uint64
+	SourcePenalty     uint64
+	TargetReward      uint64
+	TargetPenalty     uint64
+	HeadReward        uint64
+	InactivityPenalty uint64
+}
+
+// AttestationsDeltaDetails computes the attestation rewards and penalties of the given validators broken down by
+// voting duty. The validators need not be the validators of the state, so that the rewards of a hypothetical
+// validator with a given voting record can be computed as well.
+func AttestationsDeltaDetails(beaconState state.BeaconState, bal *precompute.Balance, vals []*precompute.Validator) ([]*AttestationDeltaDetail, error) {
+	baseRewardMultiplier, inactivityDenominator, leak, err := attestationsDeltaParams(beaconState, bal)
+	if err != nil {
+		return nil, err
+	}
+	details := make([]*AttestationDeltaDetail, len(vals))
+	for i, val := range vals {
+		details[i], err = attestationDeltaDetail(bal, val, baseRewardMultiplier, inactivityDenominator, leak)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return details, nil
+}
+
+// attestationsDeltaParams returns the parameters shared by the attestation deltas of all validators of the state.
+func attestationsDeltaParams(beaconState state.BeaconState, bal *precompute.Balance) (baseRewardMultiplier, inactivityDenominator uint64, leak bool, err error) {
 	cfg := params.BeaconConfig()
 	prevEpoch := time.PrevEpoch(beaconState)
 	finalizedEpoch := beaconState.FinalizedCheckpointEpoch()
 	increment := cfg.EffectiveBalanceIncrement
 	factor := cfg.BaseRewardFactor
-	baseRewardMultiplier := increment * factor / math.IntegerSquareRoot(bal.ActiveCurrentEpoch)
-	leak := helpers.IsInInactivityLeak(prevEpoch, finalizedEpoch)
+	baseRewardMultiplier = increment * factor / math.IntegerSquareRoot(bal.ActiveCurrentEpoch)
+	leak = helpers.IsInInactivityLeak(prevEpoch, finalizedEpoch)
 
 	// Modified in Altair and Bellatrix.
-	var inactivityDenominator uint64
 	bias := cfg.InactivityScoreBias
 	switch beaconState.Version() {
 	case version.Altair:
@@ -276,17 +368,9 @@ func AttestationsDelta(beaconState state.BeaconState, bal *precompute.Balance, v
 	case version.Bellatrix:
 		inactivityDenominator = bias * cfg.InactivityPenaltyQuotientBellatrix
 	default:
-		return nil, nil, errors.Errorf("invalid state type version: %T", beaconState.Version())
+		return 0, 0, false, errors.Errorf("invalid state type version: %T", beaconState.Version())
 	}
-
-	for i, v := range vals {
-		rewards[i], penalties[i], err = attestationDelta(bal, v, baseRewardMultiplier, inactivityDenominator, leak)
-		if err != nil {
-			return nil, nil, err
-		}
-	}
-
-	return rewards, penalties, nil
+	return baseRewardMultiplier, inactivityDenominator, leak, nil
 }
 
 func attestationDelta(
@@ -294,10 +378,25 @@ func attestationDelta(
 	val *precompute.Validator,
 	baseRewardMultiplier, inactivityDenominator uint64,
 	inactivityLeak bool) (reward, penalty uint64, err error) {
+	d, err := attestationDeltaDetail(bal, val, baseRewardMultiplier, inactivityDenominator, inactivityLeak)
+	if err != nil {
+		return 0, 0, err
+	}
+	reward = d.SourceReward + d.TargetReward + d.HeadReward
+	penalty = d.SourcePenalty + d.TargetPenalty + d.InactivityPenalty
+	return reward, penalty, nil
+}
+
+func attestationDeltaDetail(
+	bal *precompute.Balance,
+	val *precompute.Validator,
+	baseRewardMultiplier, inactivityDenominator uint64,
+	inactivityLeak bool) (*AttestationDeltaDetail, error) {
+	d := &AttestationDeltaDetail{}
 	eligible := val.IsActivePrevEpoch || (val.IsSlashed && !val.IsWithdrawableCurrentEpoch)
 	// Per spec `ActiveCurrentEpoch` can't be 0 to process attestation delta.
 	if !eligible || bal.ActiveCurrentEpoch == 0 {
-		return 0, 0, nil
+		return d, nil
 	}
 
 	cfg := params.BeaconConfig()
@@ -310,32 +409,31 @@ func attestationDelta(
 	srcWeight := cfg.TimelySourceWeight
 	tgtWeight := cfg.TimelyTargetWeight
 	headWeight := cfg.TimelyHeadWeight
-	reward, penalty = uint64(0), uint64(0)
 	// Process source reward / penalty
 	if val.IsPrevEpochSourceAttester && !val.IsSlashed {
 		if !inactivityLeak {
 			n := baseReward * srcWeight * (bal.PrevEpochAttested / increment)
-			reward += n / (activeIncrement * weightDenominator)
+			d.SourceReward = n / (activeIncrement * weightDenominator)
 		}
 	} else {
-		penalty += baseReward * srcWeight / weightDenominator
+		d.SourcePenalty = baseReward * srcWeight / weightDenominator
 	}
 
 	// Process target reward / penalty
 	if val.IsPrevEpochTargetAttester && !val.IsSlashed {
 		if !inactivityLeak {
 			n := baseReward * tgtWeight * (bal.PrevEpochTargetAttested / increment)
-			reward += n / (activeIncrement * weightDenominator)
+			d.TargetReward = n / (activeIncrement * weightDenominator)
 		}
 	} else {
-		penalty += baseReward * tgtWeight / weightDenominator
+		d.TargetPenalty = baseReward * tgtWeight / weightDenominator
 	}
 
 	// Process head reward / penalty
 	if val.IsPrevEpochHeadAttester && !val.IsSlashed {
 		if !inactivityLeak {
 			n := baseReward * headWeight * (bal.PrevEpochHeadAttested / increment)
-			reward += n / (activeIncrement * weightDenominator)
+			d.HeadReward = n / (activeIncrement * weightDenominator)
 		}
 	}
 
@@ -344,10 +442,10 @@ func attestationDelta(
 	if !val.IsPrevEpochTargetAttester || val.IsSlashed {
 		n, err := math.Mul64(effectiveBalance, val.InactivityScore)
 		if err != nil {
-			return 0, 0, err
+			return nil, err
 		}
-		penalty += n / inactivityDenominator
+		d.InactivityPenalty = n / inactivityDenominator
 	}
 
-	return reward, penalty, nil
+	return d, nil
 }
@@ -269,6 +269,41 @@ func TestAttestationsDeltaBellatrix(t *testing.T) {
 	require.DeepEqual(t, want, penalties)
 }
 
+func TestAttestationsDeltaDetails(t *testing.T) {
+	s, err := testState()
+	require.NoError(t, err)
+	validators, balance, err := InitializePrecomputeValidators(context.Background(), s)
+	require.NoError(t, err)
+	validators, balance, err = ProcessEpochParticipation(context.Background(), s, balance, validators)
+	require.NoError(t, err)
+	rewards, penalties, err := AttestationsDelta(s, balance, validators)
+	require.NoError(t, err)
+	details, err := AttestationsDeltaDetails(s, balance, validators)
+	require.NoError(t, err)
+	require.Equal(t, len(validators), len(details))
+
+	// The details should add up to the attestation deltas.
+	for i, d := range details {
+		require.Equal(t, rewards[i], d.SourceReward+d.TargetReward+d.HeadReward)
+		require.Equal(t, penalties[i], d.SourcePenalty+d.TargetPenalty+d.InactivityPenalty)
+	}
+
+	// A validator that attested perfectly receives every reward and no penalty.
+	ideal := &precompute.Validator{
+		IsActivePrevEpoch:            true,
+		CurrentEpochEffectiveBalance: params.BeaconConfig().MaxEffectiveBalance,
+		IsPrevEpochSourceAttester:    true,
+		IsPrevEpochTargetAttester:    true,
+		IsPrevEpochHeadAttester:      true,
+	}
+	details, err = AttestationsDeltaDetails(s, balance, []*precompute.Validator{ideal})
+	require.NoError(t, err)
+	require.Equal(t, true, details[0].SourceReward > 0)
+	require.Equal(t, true, details[0].TargetReward > details[0].SourceReward)
+	require.Equal(t, true, details[0].HeadReward > 0)
+	require.Equal(t, uint64(0), details[0].SourcePenalty+details[0].TargetPenalty+details[0].InactivityPenalty)
+}
+
 func TestProcessRewardsAndPenaltiesPrecompute_Ok(t *testing.T) {
 	s, err := testState()
 	require.NoError(t, err)
@@ -347,6 +382,46 @@ func TestProcessInactivityScores_CanProcessInactivityLeak(t *testing.T) {
 	require.Equal(t, defaultScore-1, inactivityScores[3])
 }
 
+func TestProcessInactivityScores_Parallel(t *testing.T) {
+	numVals := 5000
+	base := &ethpb.BeaconStateAltair{
+		Slot:                       params.BeaconConfig().SlotsPerEpoch * types.Slot(params.BeaconConfig().MinEpochsToInactivityPenalty+2),
+		Validators:                 make([]*ethpb.Validator, numVals),
+		Balances:                   make([]uint64, numVals),
+		InactivityScores:           make([]uint64, numVals),
+		CurrentEpochParticipation:  make([]byte, numVals),
+		PreviousEpochParticipation: make([]byte, numVals),
+	}
+	for i := 0; i < numVals; i++ {
+		base.Validators[i] = &ethpb.Validator{EffectiveBalance: params.BeaconConfig().MaxEffectiveBalance, ExitEpoch: params.BeaconConfig().FarFutureEpoch}
+		base.InactivityScores[i] = uint64(i % 7)
+		if i%3 == 0 {
+			flag, err := AddValidatorFlag(0, params.BeaconConfig().TimelyTargetFlagIndex)
+			require.NoError(t, err)
+			base.PreviousEpochParticipation[i] = flag
+		}
+	}
+	s, err := stateAltair.InitializeFromProto(base)
+	require.NoError(t, err)
+
+	process := func(st state.BeaconState, workers int) []uint64 {
+		validators, balance, err := InitializePrecomputeValidators(context.Background(), st)
+		require.NoError(t, err)
+		validators, _, err = ProcessEpochParticipation(context.Background(), st, balance, validators)
+		require.NoError(t, err)
+		st, _, err = processInactivityScores(context.Background(), st, validators, workers)
+		require.NoError(t, err)
+		scores, err := st.InactivityScores()
+		require.NoError(t, err)
+		return scores
+	}
+	want := process(s.Copy(), 1)
+	got := process(s.Copy(), 4)
+	require.DeepEqual(t, want, got)
+	require.Equal(t, uint64(0), got[0])
+	require.Equal(t, 1+params.BeaconConfig().InactivityScoreBias, got[1])
+}
+
 func TestProcessInactivityScores_GenesisEpoch(t *testing.T) {
 	s, err := testState()
 	require.NoError(t, err)
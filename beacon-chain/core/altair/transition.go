@@ -30,6 +30,13 @@ import (
 //    process_participation_flag_updates(state)  # [New in Altair]
 //    process_sync_committee_updates(state)  # [New in Altair]
 func ProcessEpoch(ctx context.Context, state state.BeaconState) (state.BeaconStateAltair, error) {
+	return ProcessEpochParallel(ctx, state, 1)
+}
+
+// ProcessEpochParallel is ProcessEpoch with the inactivity score updates, the rewards and penalties
+// and the effective balance updates of the validators, which do not depend on each other, processed
+// by the given number of parallel workers over disjoint validator index ranges.
+func ProcessEpochParallel(ctx context.Context, state state.BeaconState, workers int) (state.BeaconStateAltair, error) {
 	ctx, span := trace.StartSpan(ctx, "altair.ProcessEpoch")
 	defer span.End()
 
@@ -53,13 +60,13 @@ func ProcessEpoch(ctx context.Context, state state.BeaconState) (state.BeaconSta
 	}
 
 	// New in Altair.
-	state, vp, err = ProcessInactivityScores(ctx, state, vp)
+	state, vp, err = processInactivityScores(ctx, state, vp, workers)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process inactivity updates")
 	}
 
 	// New in Altair.
-	state, err = ProcessRewardsAndPenaltiesPrecompute(state, bp, vp)
+	state, err = ProcessRewardsAndPenaltiesParallel(state, bp, vp, workers)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process rewards and penalties")
 	}
@@ -90,7 +97,11 @@ func ProcessEpoch(ctx context.Context, state state.BeaconState) (state.BeaconSta
 	if err != nil {
 		return nil, err
 	}
-	state, err = e.ProcessEffectiveBalanceUpdates(state)
+	if workers > 1 {
+		state, err = e.ProcessEffectiveBalanceUpdatesParallel(state, workers)
+	} else {
+		state, err = e.ProcessEffectiveBalanceUpdates(state)
+	}
 	if err != nil {
 		return nil, err
 	}
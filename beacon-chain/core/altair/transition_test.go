@@ -73,3 +73,25 @@ func TestProcessEpoch_CanProcessBellatrix(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, params.BeaconConfig().SyncCommitteeSize, uint64(len(sc.Pubkeys)))
 }
+
+func TestProcessEpochParallel_MatchesProcessEpoch(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateAltair(t, params.BeaconConfig().MaxValidatorsPerCommittee)
+	require.NoError(t, st.SetSlot(10*params.BeaconConfig().SlotsPerEpoch))
+	// Balances far enough from the effective balances to update them.
+	balances := st.Balances()
+	for i := range balances {
+		balances[i] -= uint64(i%4) * params.BeaconConfig().EffectiveBalanceIncrement
+	}
+	require.NoError(t, st.SetBalances(balances))
+
+	want, err := altair.ProcessEpoch(ctx, st.Copy())
+	require.NoError(t, err)
+	got, err := altair.ProcessEpochParallel(ctx, st.Copy(), 4)
+	require.NoError(t, err)
+	wantRoot, err := want.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	gotRoot, err := got.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, wantRoot, gotRoot)
+}
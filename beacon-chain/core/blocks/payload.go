@@ -213,6 +213,47 @@ func PayloadToHeader(payload *enginev1.ExecutionPayload) (*ethpb.ExecutionPayloa
 	}, nil
 }
 
+// PayloadFromHeaderAndBody reconstructs the full execution payload committed to by `header`
+// from the payload body returned by the execution node. This allows recovering full blocks
+// from header-only storage without requesting them again from peers.
+func PayloadFromHeaderAndBody(
+	header *ethpb.ExecutionPayloadHeader, body *enginev1.ExecutionPayloadBodyV1,
+) (*enginev1.ExecutionPayload, error) {
+	if header == nil || body == nil {
+		return nil, errors.New("nil execution payload header or body")
+	}
+	txRoot, err := ssz.TransactionsRoot(body.Transactions)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(txRoot[:], header.TransactionsRoot) {
+		return nil, errors.Errorf(
+			"transactions root %#x of payload body does not match header transactions root %#x",
+			txRoot, header.TransactionsRoot,
+		)
+	}
+	transactions := make([][]byte, len(body.Transactions))
+	for i, tx := range body.Transactions {
+		transactions[i] = bytesutil.SafeCopyBytes(tx)
+	}
+	return &enginev1.ExecutionPayload{
+		ParentHash:    bytesutil.SafeCopyBytes(header.ParentHash),
+		FeeRecipient:  bytesutil.SafeCopyBytes(header.FeeRecipient),
+		StateRoot:     bytesutil.SafeCopyBytes(header.StateRoot),
+		ReceiptsRoot:  bytesutil.SafeCopyBytes(header.ReceiptRoot),
+		LogsBloom:     bytesutil.SafeCopyBytes(header.LogsBloom),
+		Random:        bytesutil.SafeCopyBytes(header.Random),
+		BlockNumber:   header.BlockNumber,
+		GasLimit:      header.GasLimit,
+		GasUsed:       header.GasUsed,
+		Timestamp:     header.Timestamp,
+		ExtraData:     bytesutil.SafeCopyBytes(header.ExtraData),
+		BaseFeePerGas: bytesutil.SafeCopyBytes(header.BaseFeePerGas),
+		BlockHash:     bytesutil.SafeCopyBytes(header.BlockHash),
+		Transactions:  transactions,
+	}, nil
+}
+
 func isEmptyPayload(p *enginev1.ExecutionPayload) bool {
 	if !bytes.Equal(p.ParentHash, make([]byte, fieldparams.RootLength)) {
 		return false
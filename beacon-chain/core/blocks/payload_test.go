@@ -645,6 +645,24 @@ func Test_PayloadToHeader(t *testing.T) {
 	require.Equal(t, h.Timestamp, uint64(0))
 }
 
+func Test_PayloadFromHeaderAndBody(t *testing.T) {
+	p := emptyPayload()
+	p.BlockNumber = 10
+	p.BlockHash = bytesutil.PadTo([]byte("hash"), fieldparams.RootLength)
+	p.Transactions = [][]byte{[]byte("tx1"), []byte("tx2")}
+	h, err := blocks.PayloadToHeader(p)
+	require.NoError(t, err)
+
+	got, err := blocks.PayloadFromHeaderAndBody(h, &enginev1.ExecutionPayloadBodyV1{Transactions: p.Transactions})
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, p, got)
+
+	_, err = blocks.PayloadFromHeaderAndBody(h, &enginev1.ExecutionPayloadBodyV1{Transactions: [][]byte{[]byte("tx1")}})
+	require.ErrorContains(t, "does not match header transactions root", err)
+	_, err = blocks.PayloadFromHeaderAndBody(h, nil)
+	require.ErrorContains(t, "nil execution payload header or body", err)
+}
+
 func BenchmarkBellatrixComplete(b *testing.B) {
 	st, _ := util.DeterministicGenesisStateBellatrix(b, 1)
 	require.NoError(b, st.SetLatestExecutionPayloadHeader(emptyPayloadHeader()))
@@ -116,6 +116,26 @@ func VerifyBlockSignatureUsingCurrentFork(beaconState state.ReadOnlyBeaconState,
 	return signing.VerifyBlockSigningRoot(proposerPubKey, blk.Signature(), domain, blk.Block().HashTreeRoot)
 }
 
+// BlockSignatureBatchUsingCurrentFork retrieves the proposer signature batch of a beacon block. Like
+// VerifyBlockSignatureUsingCurrentFork, the fork data is retrieved via the block's epoch instead of the state.
+func BlockSignatureBatchUsingCurrentFork(beaconState state.ReadOnlyBeaconState, blk block.SignedBeaconBlock) (*bls.SignatureBatch, error) {
+	currentEpoch := slots.ToEpoch(blk.Block().Slot())
+	fork, err := forks.Fork(currentEpoch)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := signing.Domain(fork, currentEpoch, params.BeaconConfig().DomainBeaconProposer, beaconState.GenesisValidatorsRoot())
+	if err != nil {
+		return nil, err
+	}
+	proposer, err := beaconState.ValidatorAtIndex(blk.Block().ProposerIndex())
+	if err != nil {
+		return nil, err
+	}
+	proposerPubKey := proposer.PublicKey
+	return signing.BlockSignatureBatch(proposerPubKey, blk.Signature(), domain, blk.Block().HashTreeRoot)
+}
+
 // BlockSignatureBatch retrieves the block signature batch from the provided block and its corresponding state.
 func BlockSignatureBatch(beaconState state.ReadOnlyBeaconState,
 	proposerIndex types.ValidatorIndex,
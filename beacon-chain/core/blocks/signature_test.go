@@ -87,3 +87,41 @@ func TestVerifyBlockSignatureUsingCurrentFork(t *testing.T) {
 	require.NoError(t, err)
 	assert.NoError(t, blocks.VerifyBlockSignatureUsingCurrentFork(bState, wsb))
 }
+
+func TestBlockSignatureBatchUsingCurrentFork(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	bCfg := params.BeaconConfig()
+	bCfg.AltairForkEpoch = 100
+	bCfg.ForkVersionSchedule[bytesutil.ToBytes4(bCfg.AltairForkVersion)] = 100
+	params.OverrideBeaconConfig(bCfg)
+	bState, keys := util.DeterministicGenesisState(t, 100)
+	altairBlk := util.NewBeaconBlockAltair()
+	altairBlk.Block.ProposerIndex = 0
+	altairBlk.Block.Slot = params.BeaconConfig().SlotsPerEpoch * 100
+	fData := &ethpb.Fork{
+		Epoch:           100,
+		CurrentVersion:  params.BeaconConfig().AltairForkVersion,
+		PreviousVersion: params.BeaconConfig().GenesisForkVersion,
+	}
+	domain, err := signing.Domain(fData, 100, params.BeaconConfig().DomainBeaconProposer, bState.GenesisValidatorsRoot())
+	require.NoError(t, err)
+	rt, err := signing.ComputeSigningRoot(altairBlk.Block, domain)
+	require.NoError(t, err)
+	altairBlk.Signature = keys[0].Sign(rt[:]).Marshal()
+	wsb, err := wrapper.WrappedAltairSignedBeaconBlock(altairBlk)
+	require.NoError(t, err)
+	set, err := blocks.BlockSignatureBatchUsingCurrentFork(bState, wsb)
+	require.NoError(t, err)
+	verified, err := set.Verify()
+	require.NoError(t, err)
+	assert.Equal(t, true, verified, "Block signature batch did not verify")
+
+	altairBlk.Signature = keys[1].Sign(rt[:]).Marshal()
+	wsb, err = wrapper.WrappedAltairSignedBeaconBlock(altairBlk)
+	require.NoError(t, err)
+	set, err = blocks.BlockSignatureBatchUsingCurrentFork(bState, wsb)
+	require.NoError(t, err)
+	verified, err = set.Verify()
+	require.NoError(t, err)
+	assert.Equal(t, false, verified, "Block signature batch with the wrong signer verified")
+}
@@ -243,12 +243,7 @@ func ProcessEth1DataReset(state state.BeaconState) (state.BeaconState, error) {
 //        ):
 //            validator.effective_balance = min(balance - balance % EFFECTIVE_BALANCE_INCREMENT, MAX_EFFECTIVE_BALANCE)
 func ProcessEffectiveBalanceUpdates(state state.BeaconState) (state.BeaconState, error) {
-	effBalanceInc := params.BeaconConfig().EffectiveBalanceIncrement
-	maxEffBalance := params.BeaconConfig().MaxEffectiveBalance
-	hysteresisInc := effBalanceInc / params.BeaconConfig().HysteresisQuotient
-	downwardThreshold := hysteresisInc * params.BeaconConfig().HysteresisDownwardMultiplier
-	upwardThreshold := hysteresisInc * params.BeaconConfig().HysteresisUpwardMultiplier
-
+	update := effectiveBalanceUpdater()
 	bals := state.Balances()
 	// Update effective balances with hysteresis.
 	validatorFunc := func(idx int, val *ethpb.Validator) (bool, *ethpb.Validator, error) {
@@ -258,49 +253,85 @@ func ProcessEffectiveBalanceUpdates(state state.BeaconState) (state.BeaconState,
 		if idx >= len(bals) {
 			return false, nil, fmt.Errorf("validator index exceeds validator length in state %d >= %d", idx, len(state.Balances()))
 		}
-		balance := bals[idx]
+		effectiveBal, ok := update(bals[idx], val.EffectiveBalance)
+		if !ok {
+			return false, val, nil
+		}
+		newVal := ethpb.CopyValidator(val)
+		newVal.EffectiveBalance = effectiveBal
+		return true, newVal, nil
+	}
 
-		if balance+downwardThreshold < val.EffectiveBalance || val.EffectiveBalance+upwardThreshold < balance {
-			newVal := ethpb.CopyValidator(val)
-			newVal.EffectiveBalance = maxEffBalance
-			if newVal.EffectiveBalance > balance-balance%effBalanceInc {
-				newVal.EffectiveBalance = balance - balance%effBalanceInc
-			}
-			return true, newVal, nil
+	if err := state.ApplyToEveryValidator(validatorFunc); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// effectiveBalanceUpdater returns a function applying the hysteresis of the effective balance updates
+// to a validator, which returns its new effective balance and whether the validator is updated. With
+// the optimized balance update, the validators whose effective balance does not change are not.
+func effectiveBalanceUpdater() func(balance, effectiveBalance uint64) (uint64, bool) {
+	effBalanceInc := params.BeaconConfig().EffectiveBalanceIncrement
+	maxEffBalance := params.BeaconConfig().MaxEffectiveBalance
+	hysteresisInc := effBalanceInc / params.BeaconConfig().HysteresisQuotient
+	downwardThreshold := hysteresisInc * params.BeaconConfig().HysteresisDownwardMultiplier
+	upwardThreshold := hysteresisInc * params.BeaconConfig().HysteresisUpwardMultiplier
+	optimized := features.Get().EnableOptimizedBalanceUpdate
+
+	return func(balance, effectiveBalance uint64) (uint64, bool) {
+		if balance+downwardThreshold >= effectiveBalance && effectiveBalance+upwardThreshold >= balance {
+			return effectiveBalance, false
 		}
-		return false, val, nil
+		effectiveBal := maxEffBalance
+		if effectiveBal > balance-balance%effBalanceInc {
+			effectiveBal = balance - balance%effBalanceInc
+		}
+		if optimized && effectiveBal == effectiveBalance {
+			return effectiveBalance, false
+		}
+		return effectiveBal, true
 	}
+}
 
-	if features.Get().EnableOptimizedBalanceUpdate {
-		validatorFunc = func(idx int, val *ethpb.Validator) (bool, *ethpb.Validator, error) {
-			if val == nil {
-				return false, nil, fmt.Errorf("validator %d is nil in state", idx)
-			}
-			if idx >= len(bals) {
-				return false, nil, fmt.Errorf("validator index exceeds validator length in state %d >= %d", idx, len(state.Balances()))
+// ProcessEffectiveBalanceUpdatesParallel is ProcessEffectiveBalanceUpdates with the effective
+// balances of the validators computed by the given number of parallel workers.
+func ProcessEffectiveBalanceUpdatesParallel(state state.BeaconState, workers int) (state.BeaconState, error) {
+	update := effectiveBalanceUpdater()
+	bals := state.Balances()
+	numVals := state.NumValidators()
+	if numVals > len(bals) {
+		return nil, fmt.Errorf("validator index exceeds validator length in state %d >= %d", numVals-1, len(bals))
+	}
+	effBals := make([]uint64, numVals)
+	changed := make([]bool, numVals)
+	if err := helpers.ForEachValidatorChunk(numVals, workers, func(start, end int) error {
+		for i := start; i < end; i++ {
+			val, err := state.ValidatorAtIndexReadOnly(types.ValidatorIndex(i))
+			if err != nil {
+				return err
 			}
-			balance := bals[idx]
-
-			if balance+downwardThreshold < val.EffectiveBalance || val.EffectiveBalance+upwardThreshold < balance {
-				effectiveBal := maxEffBalance
-				if effectiveBal > balance-balance%effBalanceInc {
-					effectiveBal = balance - balance%effBalanceInc
-				}
-				if effectiveBal != val.EffectiveBalance {
-					newVal := ethpb.CopyValidator(val)
-					newVal.EffectiveBalance = effectiveBal
-					return true, newVal, nil
-				}
-				return false, val, nil
+			if val == nil || val.IsNil() {
+				return fmt.Errorf("validator %d is nil in state", i)
 			}
-			return false, val, nil
+			effBals[i], changed[i] = update(bals[i], val.EffectiveBalance())
 		}
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 
-	if err := state.ApplyToEveryValidator(validatorFunc); err != nil {
+	if err := state.ApplyToEveryValidator(func(idx int, val *ethpb.Validator) (bool, *ethpb.Validator, error) {
+		if idx >= numVals || !changed[idx] {
+			return false, val, nil
+		}
+		newVal := ethpb.CopyValidator(val)
+		newVal.EffectiveBalance = effBals[idx]
+		return true, newVal, nil
+	}); err != nil {
 		return nil, err
 	}
-
 	return state, nil
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	"github.com/prysmaticlabs/prysm/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -280,6 +281,36 @@ func TestProcessFinalUpdates_CanProcess(t *testing.T) {
 	assert.NotNil(t, currAtt, "Nil value stored in current epoch attestations instead of empty slice")
 }
 
+func TestProcessEffectiveBalanceUpdatesParallel(t *testing.T) {
+	cfg := params.BeaconConfig()
+	numVals := 3000
+	base := &ethpb.BeaconState{
+		Validators: make([]*ethpb.Validator, numVals),
+		Balances:   make([]uint64, numVals),
+	}
+	for i := 0; i < numVals; i++ {
+		base.Validators[i] = &ethpb.Validator{EffectiveBalance: cfg.MaxEffectiveBalance}
+		// Balances at, within and below the hysteresis of the effective balance.
+		base.Balances[i] = cfg.MaxEffectiveBalance - uint64(i%3)*cfg.EffectiveBalanceIncrement/2 + uint64(i%5)
+	}
+	st, err := v1.InitializeFromProto(base)
+	require.NoError(t, err)
+
+	for _, optimized := range []bool{false, true} {
+		t.Run(fmt.Sprintf("optimized balance update %t", optimized), func(t *testing.T) {
+			resetCfg := features.InitWithReset(&features.Flags{EnableOptimizedBalanceUpdate: optimized})
+			defer resetCfg()
+			want, err := epoch.ProcessEffectiveBalanceUpdates(st.Copy())
+			require.NoError(t, err)
+			got, err := epoch.ProcessEffectiveBalanceUpdatesParallel(st.Copy(), 4)
+			require.NoError(t, err)
+			assert.DeepEqual(t, want.Validators(), got.Validators())
+			assert.Equal(t, cfg.MaxEffectiveBalance, got.Validators()[0].EffectiveBalance)
+			assert.Equal(t, cfg.MaxEffectiveBalance-cfg.EffectiveBalanceIncrement, got.Validators()[1].EffectiveBalance)
+		})
+	}
+}
+
 func TestProcessRegistryUpdates_NoRotation(t *testing.T) {
 	base := &ethpb.BeaconState{
 		Slot: 5 * params.BeaconConfig().SlotsPerEpoch,
@@ -4,6 +4,7 @@
 package state
 
 import (
+	"math/big"
 	"time"
 
 	types "github.com/prysmaticlabs/eth2-types"
@@ -26,6 +27,18 @@ const (
 	FinalizedCheckpoint
 	// NewHead of the chain event.
 	NewHead
+	// ExecutionConnectionChanged is sent when the beacon node loses or regains
+	// connectivity to its execution node.
+	ExecutionConnectionChanged
+	// TerminalBlockReached is sent when the terminal proof-of-work block of the execution
+	// chain is found, or when it changes after an execution chain reorganization.
+	TerminalBlockReached
+	// TransitionConfigurationChanged is sent when the merge transition configuration of the
+	// execution node starts or stops matching the configuration of the beacon node.
+	TransitionConfigurationChanged
+	// PayloadAttributes is sent on every head update and when the execution node starts building
+	// the payload of an upcoming proposal, with the attributes of that payload.
+	PayloadAttributes
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -59,3 +72,34 @@ type InitializedData struct {
 	// GenesisValidatorsRoot represents state.validators.HashTreeRoot().
 	GenesisValidatorsRoot []byte
 }
+
+// ExecutionConnectionChangedData is the data sent with ExecutionConnectionChanged events.
+type ExecutionConnectionChangedData struct {
+	// Endpoint of the execution node, with any credentials redacted.
+	Endpoint string
+	// Connected is true if the execution node is reachable.
+	Connected bool
+	// Error which caused the connection to be lost, if any.
+	Error error
+}
+
+// TerminalBlockReachedData is the data sent with TerminalBlockReached events.
+type TerminalBlockReachedData struct {
+	// Hash of the terminal proof-of-work block.
+	Hash [32]byte
+	// Number of the terminal proof-of-work block.
+	Number uint64
+	// TotalDifficulty of the execution chain at the terminal proof-of-work block.
+	TotalDifficulty *big.Int
+}
+
+// TransitionConfigurationChangedData is the data sent with TransitionConfigurationChanged events.
+type TransitionConfigurationChangedData struct {
+	// Endpoint of the execution node, with any credentials redacted.
+	Endpoint string
+	// Matching is true if the execution node is configured with the same terminal
+	// total difficulty and terminal block hash as the beacon node.
+	Matching bool
+	// Error describing the mismatch, if any.
+	Error error
+}
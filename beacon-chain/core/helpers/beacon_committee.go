@@ -158,39 +158,14 @@ func CommitteeAssignments(
 	state state.BeaconState,
 	epoch types.Epoch,
 ) (map[types.ValidatorIndex]*CommitteeAssignmentContainer, map[types.ValidatorIndex][]types.Slot, error) {
-	nextEpoch := time.NextEpoch(state)
-	if epoch > nextEpoch {
-		return nil, nil, fmt.Errorf(
-			"epoch %d can't be greater than next epoch %d",
-			epoch,
-			nextEpoch,
-		)
+	proposerIndexToSlots, err := ProposerAssignments(ctx, state, epoch)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	// We determine the slots in which proposers are supposed to act.
-	// Some validators may need to propose multiple times per epoch, so
-	// we use a map of proposer idx -> []slot to keep track of this possibility.
 	startSlot, err := slots.EpochStart(epoch)
 	if err != nil {
 		return nil, nil, err
 	}
-	proposerIndexToSlots := make(map[types.ValidatorIndex][]types.Slot, params.BeaconConfig().SlotsPerEpoch)
-	// Proposal epochs do not have a look ahead, so we skip them over here.
-	validProposalEpoch := epoch < nextEpoch
-	for slot := startSlot; slot < startSlot+params.BeaconConfig().SlotsPerEpoch && validProposalEpoch; slot++ {
-		// Skip proposer assignment for genesis slot.
-		if slot == 0 {
-			continue
-		}
-		if err := state.SetSlot(slot); err != nil {
-			return nil, nil, err
-		}
-		i, err := BeaconProposerIndex(ctx, state)
-		if err != nil {
-			return nil, nil, errors.Wrapf(err, "could not check proposer at slot %d", state.Slot())
-		}
-		proposerIndexToSlots[i] = append(proposerIndexToSlots[i], slot)
-	}
 
 	activeValidatorIndices, err := ActiveValidatorIndices(ctx, state, epoch)
 	if err != nil {
@@ -225,6 +200,53 @@ func CommitteeAssignments(
 	return validatorIndexToCommittee, proposerIndexToSlots, nil
 }
 
+// ProposerAssignments returns the slots in which each validator proposes during the given epoch,
+// without computing the committees of the epoch. The given state is advanced to every slot of the
+// epoch. The proposer indices are read from the proposer indices cache when the epoch is cached
+// under the roots of the state, and computed and cached otherwise.
+func ProposerAssignments(ctx context.Context, state state.BeaconState, epoch types.Epoch) (map[types.ValidatorIndex][]types.Slot, error) {
+	nextEpoch := time.NextEpoch(state)
+	if epoch > nextEpoch {
+		return nil, fmt.Errorf(
+			"epoch %d can't be greater than next epoch %d",
+			epoch,
+			nextEpoch,
+		)
+	}
+
+	// We determine the slots in which proposers are supposed to act.
+	// Some validators may need to propose multiple times per epoch, so
+	// we use a map of proposer idx -> []slot to keep track of this possibility.
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+	proposerIndexToSlots := make(map[types.ValidatorIndex][]types.Slot, params.BeaconConfig().SlotsPerEpoch)
+	// Proposal epochs do not have a look ahead, so we skip them over here.
+	validProposalEpoch := epoch < nextEpoch
+	for slot := startSlot; slot < startSlot+params.BeaconConfig().SlotsPerEpoch && validProposalEpoch; slot++ {
+		// Skip proposer assignment for genesis slot.
+		if slot == 0 {
+			continue
+		}
+		if err := state.SetSlot(slot); err != nil {
+			return nil, err
+		}
+		i, err := BeaconProposerIndex(ctx, state)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not check proposer at slot %d", state.Slot())
+		}
+		proposerIndexToSlots[i] = append(proposerIndexToSlots[i], slot)
+	}
+	return proposerIndexToSlots, nil
+}
+
+// HasProposerIndicesInCache returns whether the proposer indices of an epoch are cached, given the
+// state root at the last slot of the previous epoch which keys them.
+func HasProposerIndicesInCache(root [32]byte) (bool, error) {
+	return proposerIndicesCache.HasProposerIndices(root)
+}
+
 // VerifyBitfieldLength verifies that a bitfield length matches the given committee size.
 func VerifyBitfieldLength(bf bitfield.Bitfield, committeeSize uint64) error {
 	if bf.Len() != committeeSize {
@@ -277,15 +299,17 @@ func ShuffledIndices(s state.ReadOnlyBeaconState, epoch types.Epoch) ([]types.Va
 }
 
 // UpdateCommitteeCache gets called at the beginning of every epoch to cache the committee shuffled indices
-// list with committee index and epoch number. It caches the shuffled indices for current epoch and next epoch.
+// list with committee index and epoch number. It caches the shuffled indices for current epoch and next epoch,
+// the seed of the next epoch being known from the start of the current one.
 func UpdateCommitteeCache(state state.ReadOnlyBeaconState, epoch types.Epoch) error {
 	for _, e := range []types.Epoch{epoch, epoch + 1} {
 		seed, err := Seed(state, e, params.BeaconConfig().DomainBeaconAttester)
 		if err != nil {
 			return err
 		}
+		// The next epoch may not be cached yet when the current one is.
 		if committeeCache.HasEntry(string(seed[:])) {
-			return nil
+			continue
 		}
 
 		shuffledIndices, err := ShuffledIndices(state, e)
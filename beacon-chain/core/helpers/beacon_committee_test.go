@@ -272,6 +272,38 @@ func TestCommitteeAssignments_EverySlotHasMin1Proposer(t *testing.T) {
 	}
 }
 
+func TestProposerAssignments(t *testing.T) {
+	validators := make([]*ethpb.Validator, 4*params.BeaconConfig().SlotsPerEpoch)
+	for i := 0; i < len(validators); i++ {
+		validators[i] = &ethpb.Validator{
+			ExitEpoch:        params.BeaconConfig().FarFutureEpoch,
+			EffectiveBalance: params.BeaconConfig().MaxEffectiveBalance,
+		}
+	}
+	state, err := v1.InitializeFromProto(&ethpb.BeaconState{
+		Validators:  validators,
+		Slot:        2 * params.BeaconConfig().SlotsPerEpoch, // epoch 2
+		RandaoMixes: make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector),
+	})
+	require.NoError(t, err)
+	ClearCache()
+
+	ctx := context.Background()
+	proposerIndexToSlots, err := ProposerAssignments(ctx, state.Copy(), 2)
+	require.NoError(t, err)
+	_, want, err := CommitteeAssignments(ctx, state.Copy(), 2)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, proposerIndexToSlots)
+
+	// The proposers of the next epoch are not known yet.
+	proposerIndexToSlots, err = ProposerAssignments(ctx, state.Copy(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(proposerIndexToSlots))
+
+	_, err = ProposerAssignments(ctx, state.Copy(), 4)
+	assert.ErrorContains(t, "can't be greater than next epoch", err)
+}
+
 func TestVerifyAttestationBitfieldLengths_OK(t *testing.T) {
 	validators := make([]*ethpb.Validator, 2*params.BeaconConfig().SlotsPerEpoch)
 	activeRoots := make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector)
@@ -398,6 +430,29 @@ func TestUpdateCommitteeCache_CanUpdate(t *testing.T) {
 	assert.Equal(t, params.BeaconConfig().TargetCommitteeSize, uint64(len(indices)), "Did not save correct indices lengths")
 }
 
+func TestUpdateCommitteeCache_CachesNextEpoch(t *testing.T) {
+	ClearCache()
+	validators := make([]*ethpb.Validator, params.BeaconConfig().MinGenesisActiveValidatorCount)
+	for i := range validators {
+		validators[i] = &ethpb.Validator{
+			ExitEpoch:        params.BeaconConfig().FarFutureEpoch,
+			EffectiveBalance: 1,
+		}
+	}
+	state, err := v1.InitializeFromProto(&ethpb.BeaconState{
+		Validators:  validators,
+		RandaoMixes: make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector),
+	})
+	require.NoError(t, err)
+	require.NoError(t, UpdateCommitteeCache(state, 0))
+
+	// The committees of epoch 1 are already cached, those of epoch 2 are not.
+	require.NoError(t, UpdateCommitteeCache(state, 1))
+	seed, err := Seed(state, 2, params.BeaconConfig().DomainBeaconAttester)
+	require.NoError(t, err)
+	assert.Equal(t, true, committeeCache.HasEntry(string(seed[:])), "Next epoch committees not cached")
+}
+
 func BenchmarkComputeCommittee300000_WithPreCache(b *testing.B) {
 	validators := make([]*ethpb.Validator, 300000)
 	for i := 0; i < len(validators); i++ {
@@ -47,6 +47,24 @@ func BlockRootAtSlot(state state.ReadOnlyBeaconState, slot types.Slot) ([]byte,
 	return state.BlockRootAtIndex(uint64(slot % params.BeaconConfig().SlotsPerHistoricalRoot))
 }
 
+// DutyDependentRoot returns the root of the last block before the given epoch, which the proposer
+// duties of the epoch and the attester duties of the next epoch depend on. The duties of the genesis
+// epoch depend on the genesis block.
+//
+// Spec pseudocode definition:
+//  get_block_root_at_slot(state, max(compute_start_slot_at_epoch(epoch), 1) - 1)
+func DutyDependentRoot(state state.ReadOnlyBeaconState, epoch types.Epoch) ([]byte, error) {
+	var slot types.Slot
+	if epoch > 0 {
+		epochStartSlot, err := slots.EpochStart(epoch)
+		if err != nil {
+			return nil, err
+		}
+		slot = epochStartSlot - 1
+	}
+	return BlockRootAtSlot(state, slot)
+}
+
 // StateRootAtSlot returns the cached state root at that particular slot. If no state
 // root has been cached it will return a zero-hash.
 func StateRootAtSlot(state state.ReadOnlyBeaconState, slot types.Slot) ([]byte, error) {
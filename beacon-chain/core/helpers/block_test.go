@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
 	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
@@ -117,3 +118,24 @@ func TestBlockRootAtSlot_OutOfBounds(t *testing.T) {
 		assert.ErrorContains(t, tt.expectedErr, err)
 	}
 }
+
+func TestDutyDependentRoot(t *testing.T) {
+	var blockRoots [][]byte
+	for i := uint64(0); i < uint64(params.BeaconConfig().SlotsPerHistoricalRoot); i++ {
+		blockRoots = append(blockRoots, []byte{byte(i)})
+	}
+	s, err := v1.InitializeFromProto(&ethpb.BeaconState{
+		BlockRoots: blockRoots,
+		Slot:       3 * params.BeaconConfig().SlotsPerEpoch,
+	})
+	require.NoError(t, err)
+
+	root, err := helpers.DutyDependentRoot(s, 0)
+	require.NoError(t, err)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte{0}, 32), root)
+	root, err = helpers.DutyDependentRoot(s, 3)
+	require.NoError(t, err)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte{byte(3*params.BeaconConfig().SlotsPerEpoch - 1)}, 32), root)
+	_, err = helpers.DutyDependentRoot(s, 4)
+	assert.ErrorContains(t, "out of bounds", err)
+}
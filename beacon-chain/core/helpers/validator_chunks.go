@@ -0,0 +1,35 @@
+package helpers
+
+import "golang.org/x/sync/errgroup"
+
+// minValidatorsPerWorker is the smallest number of validators processed by a worker, below which
+// the overhead of a goroutine outweighs the work.
+const minValidatorsPerWorker = 1024
+
+// ForEachValidatorChunk splits the validator indices below numVals into contiguous chunks, at most
+// one per worker, and calls f with the bounds of each chunk in parallel. The chunks are disjoint, so
+// f may write to the entries of slices indexed by validator without locking. It returns the first
+// error returned by f.
+func ForEachValidatorChunk(numVals, workers int, f func(start, end int) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+	size := (numVals + workers - 1) / workers
+	if size < minValidatorsPerWorker {
+		size = minValidatorsPerWorker
+	}
+	if size >= numVals {
+		return f(0, numVals)
+	}
+	var eg errgroup.Group
+	for start := 0; start < numVals; start += size {
+		start, end := start, start+size
+		if end > numVals {
+			end = numVals
+		}
+		eg.Go(func() error {
+			return f(start, end)
+		})
+	}
+	return eg.Wait()
+}
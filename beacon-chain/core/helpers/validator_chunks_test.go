@@ -0,0 +1,57 @@
+package helpers
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestForEachValidatorChunk(t *testing.T) {
+	tests := []struct {
+		name    string
+		numVals int
+		workers int
+		chunks  int
+	}{
+		{name: "no validators", numVals: 0, workers: 4, chunks: 1},
+		{name: "single worker", numVals: 10 * minValidatorsPerWorker, workers: 1, chunks: 1},
+		{name: "small registry", numVals: minValidatorsPerWorker, workers: 4, chunks: 1},
+		{name: "even split", numVals: 4 * minValidatorsPerWorker, workers: 4, chunks: 4},
+		{name: "uneven split", numVals: 4*minValidatorsPerWorker + 1, workers: 4, chunks: 4},
+		{name: "minimum chunk size", numVals: 2*minValidatorsPerWorker + 1, workers: 8, chunks: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := make([]int, tt.numVals)
+			var mu sync.Mutex
+			chunks := 0
+			require.NoError(t, ForEachValidatorChunk(tt.numVals, tt.workers, func(start, end int) error {
+				for i := start; i < end; i++ {
+					seen[i]++
+				}
+				mu.Lock()
+				chunks++
+				mu.Unlock()
+				return nil
+			}))
+			assert.Equal(t, tt.chunks, chunks)
+			for i, n := range seen {
+				assert.Equal(t, 1, n, "validator %d", i)
+			}
+		})
+	}
+}
+
+func TestForEachValidatorChunk_Error(t *testing.T) {
+	wanted := errors.New("chunk failed")
+	err := ForEachValidatorChunk(4*minValidatorsPerWorker, 4, func(start, _ int) error {
+		if start > 0 {
+			return wanted
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, wanted)
+}
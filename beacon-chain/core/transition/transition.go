@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"runtime"
 
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
@@ -26,6 +27,10 @@ import (
 	"go.opencensus.io/trace"
 )
 
+// epochProcessingWorkers is the number of parallel workers processing the validators at epoch
+// boundaries. Small validator sets are processed by a single worker regardless.
+var epochProcessingWorkers = runtime.GOMAXPROCS(0)
+
 // ExecuteStateTransition defines the procedure for a state transition function.
 //
 // Note: This method differs from the spec pseudocode as it uses a batch signature verification.
@@ -256,7 +261,7 @@ func ProcessSlots(ctx context.Context, state state.BeaconState, slot types.Slot)
 					return nil, errors.Wrap(err, "could not process epoch with optimizations")
 				}
 			case version.Altair, version.Bellatrix:
-				state, err = altair.ProcessEpoch(ctx, state)
+				state, err = altair.ProcessEpochParallel(ctx, state, epochProcessingWorkers)
 				if err != nil {
 					tracing.AnnotateError(span, err)
 					return nil, errors.Wrap(err, "could not process epoch")
@@ -23,6 +23,18 @@ type HeadAccessDatabase = iface.HeadAccessDatabase
 // not be used often. Prefer a more restrictive interface in this package.
 type Database = iface.Database
 
+// PruneStats reports the objects deleted when pruning the database.
+type PruneStats = iface.PruneStats
+
+// IntegrityReport reports the inconsistencies found when verifying the integrity of the database.
+type IntegrityReport = iface.IntegrityReport
+
+// DiskUsage reports the disk space used by the database.
+type DiskUsage = iface.DiskUsage
+
+// PayloadBodiesFetcher fetches the execution payloads of the blocks saved blinded.
+type PayloadBodiesFetcher = iface.PayloadBodiesFetcher
+
 // SlasherDatabase defines necessary methods for Prysm's slasher implementation.
 type SlasherDatabase = iface.SlasherDatabase
 
@@ -0,0 +1,154 @@
+// Package backend defines the narrow key-value interface the beacon chain database is built upon,
+// with transactions over named buckets of sorted keys, and its bolt and pebble implementations.
+package backend
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prysmaticlabs/prysm/io/file"
+)
+
+// Kind of key-value backend.
+type Kind string
+
+const (
+	// Bolt is a single file B+tree store with a single writer, the default backend.
+	Bolt Kind = "bolt"
+	// Pebble is a log-structured merge tree store, better suited to write heavy archival workloads.
+	Pebble Kind = "pebble"
+)
+
+var (
+	// ErrTxNotWritable is returned when writing in a read-only transaction.
+	ErrTxNotWritable = errors.New("tx not writable")
+	// ErrBucketNotFound is returned when deleting a bucket which does not exist.
+	ErrBucketNotFound = errors.New("bucket not found")
+	// ErrReadOnly is returned when writing to a database opened read-only.
+	ErrReadOnly = errors.New("database is open read-only")
+)
+
+// DB is a key-value database of named buckets.
+type DB interface {
+	// View runs the function in a read-only transaction over a consistent view of the database.
+	View(fn func(tx Tx) error) error
+	// Update runs the function in a read-write transaction, committed if the function returns no
+	// error. Read-write transactions are serialized.
+	Update(fn func(tx Tx) error) error
+	// Kind of the backend.
+	Kind() Kind
+	// Collector of the prometheus metrics of the backend.
+	Collector(blockedBuckets ...[]byte) prometheus.Collector
+	// Stats of the disk space used by the database.
+	Stats() (*Stats, error)
+	// Compact rewrites the database to reclaim the disk space of deleted data, without closing it.
+	Compact(ctx context.Context) error
+	Close() error
+}
+
+// Tx is a database transaction.
+type Tx interface {
+	// Bucket returns the bucket with the given name, or nil if it does not exist.
+	Bucket(name []byte) Bucket
+	CreateBucketIfNotExists(name []byte) (Bucket, error)
+	DeleteBucket(name []byte) error
+	// ForEach calls the function for each bucket of the database.
+	ForEach(fn func(name []byte, b Bucket) error) error
+}
+
+// Bucket is a collection of key-value pairs sorted by key. Keys and values returned by a bucket
+// are only valid for the life of the transaction.
+type Bucket interface {
+	// Get returns the value of the key, or nil if it does not exist.
+	Get(key []byte) []byte
+	Put(key, value []byte) error
+	Delete(key []byte) error
+	// ForEach calls the function for each key-value pair of the bucket, in key order.
+	ForEach(fn func(k, v []byte) error) error
+	Cursor() Cursor
+}
+
+// Cursor iterates over the key-value pairs of a bucket in key order. All its methods return a nil
+// key once the iteration is over.
+type Cursor interface {
+	First() (key, value []byte)
+	Last() (key, value []byte)
+	Next() (key, value []byte)
+	Prev() (key, value []byte)
+	// Seek moves the cursor to the first key greater or equal to the given key.
+	Seek(seek []byte) (key, value []byte)
+}
+
+// Stats of the disk space used by a database.
+type Stats struct {
+	// Size of the database on disk, in bytes.
+	Size int64
+	// Bytes on disk which hold no live data, and are reclaimed by compaction.
+	Free int64
+}
+
+// Options to open a database.
+type Options struct {
+	// Initial size of the bolt mmap.
+	InitialMMapSize int
+	// Open an existing database without writing to it. Read-write transactions and compactions
+	// fail with ErrReadOnly, and a bolt database may be opened by several read-only processes.
+	ReadOnly bool
+}
+
+// ParseKind returns the backend kind of the given name, bolt if empty.
+func ParseKind(name string) (Kind, error) {
+	if name == "" {
+		return Bolt, nil
+	}
+	switch Kind(name) {
+	case Bolt, Pebble:
+		return Kind(name), nil
+	default:
+		return "", errors.Errorf("unknown database backend %q, expected %s or %s", name, Bolt, Pebble)
+	}
+}
+
+// Path of a database of the given kind and file name in the given directory. Pebble databases are
+// directories, named after the file name with a .pebble extension instead.
+func Path(kind Kind, dir, fileName string) string {
+	if kind == Pebble {
+		return filepath.Join(dir, trimExt(fileName)+".pebble")
+	}
+	return filepath.Join(dir, fileName)
+}
+
+// Exists returns whether a database of the given kind and file name exists in the given directory.
+func Exists(kind Kind, dir, fileName string) bool {
+	p := Path(kind, dir, fileName)
+	if kind == Pebble {
+		exists, err := file.HasDir(p)
+		return err == nil && exists
+	}
+	return file.FileExists(p)
+}
+
+// Open the database of the given kind and file name in the given directory, creating it if needed
+// unless it is opened read-only.
+func Open(kind Kind, dir, fileName string, opts *Options) (DB, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if opts.ReadOnly && !Exists(kind, dir, fileName) {
+		return nil, errors.Errorf("no %s database in %s to open read-only", kind, dir)
+	}
+	switch kind {
+	case Bolt:
+		return openBolt(Path(kind, dir, fileName), opts)
+	case Pebble:
+		return openPebble(Path(kind, dir, fileName), opts)
+	default:
+		return nil, errors.Errorf("unknown database backend %q", kind)
+	}
+}
+
+func trimExt(fileName string) string {
+	return fileName[:len(fileName)-len(filepath.Ext(fileName))]
+}
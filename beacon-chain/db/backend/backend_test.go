@@ -0,0 +1,351 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func setupDB(t *testing.T, kind Kind) DB {
+	db, err := Open(kind, t.TempDir(), "test.db", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	return db
+}
+
+func TestDB_Buckets(t *testing.T) {
+	for _, kind := range []Kind{Bolt, Pebble} {
+		t.Run(string(kind), func(t *testing.T) {
+			db := setupDB(t, kind)
+			require.NoError(t, db.Update(func(tx Tx) error {
+				for _, name := range []string{"a", "ab", "b"} {
+					bkt, err := tx.CreateBucketIfNotExists([]byte(name))
+					if err != nil {
+						return err
+					}
+					if err := bkt.Put([]byte("key"), []byte(name)); err != nil {
+						return err
+					}
+				}
+				return nil
+			}))
+			require.NoError(t, db.View(func(tx Tx) error {
+				assert.Equal(t, nil, tx.Bucket([]byte("c")))
+				// Buckets whose name is a prefix of another one do not share keys.
+				assert.DeepEqual(t, []byte("a"), tx.Bucket([]byte("a")).Get([]byte("key")))
+				assert.DeepEqual(t, []byte("ab"), tx.Bucket([]byte("ab")).Get([]byte("key")))
+				var names []string
+				require.NoError(t, tx.ForEach(func(name []byte, _ Bucket) error {
+					names = append(names, string(name))
+					return nil
+				}))
+				assert.DeepEqual(t, []string{"a", "ab", "b"}, names)
+				return nil
+			}))
+
+			require.NoError(t, db.Update(func(tx Tx) error {
+				return tx.DeleteBucket([]byte("ab"))
+			}))
+			require.NoError(t, db.Update(func(tx Tx) error {
+				assert.Equal(t, nil, tx.Bucket([]byte("ab")))
+				assert.Equal(t, true, errors.Is(tx.DeleteBucket([]byte("ab")), ErrBucketNotFound))
+				bkt, err := tx.CreateBucketIfNotExists([]byte("ab"))
+				require.NoError(t, err)
+				assert.Equal(t, true, bkt.Get([]byte("key")) == nil)
+				return nil
+			}))
+		})
+	}
+}
+
+func TestDB_Transactions(t *testing.T) {
+	for _, kind := range []Kind{Bolt, Pebble} {
+		t.Run(string(kind), func(t *testing.T) {
+			db := setupDB(t, kind)
+			require.NoError(t, db.Update(func(tx Tx) error {
+				bkt, err := tx.CreateBucketIfNotExists([]byte("bucket"))
+				if err != nil {
+					return err
+				}
+				if err := bkt.Put([]byte("key"), []byte("value")); err != nil {
+					return err
+				}
+				// Writes are visible within the transaction.
+				assert.DeepEqual(t, []byte("value"), bkt.Get([]byte("key")))
+				return nil
+			}))
+
+			errRollback := errors.New("rollback")
+			err := db.Update(func(tx Tx) error {
+				bkt := tx.Bucket([]byte("bucket"))
+				require.NoError(t, bkt.Put([]byte("key"), []byte("other")))
+				require.NoError(t, bkt.Delete([]byte("key")))
+				return errRollback
+			})
+			assert.Equal(t, true, errors.Is(err, errRollback))
+
+			require.NoError(t, db.View(func(tx Tx) error {
+				bkt := tx.Bucket([]byte("bucket"))
+				assert.DeepEqual(t, []byte("value"), bkt.Get([]byte("key")))
+				assert.Equal(t, true, errors.Is(bkt.Put([]byte("key"), []byte("other")), ErrTxNotWritable))
+				assert.Equal(t, true, errors.Is(bkt.Delete([]byte("key")), ErrTxNotWritable))
+				return nil
+			}))
+		})
+	}
+}
+
+func TestDB_Cursor(t *testing.T) {
+	for _, kind := range []Kind{Bolt, Pebble} {
+		t.Run(string(kind), func(t *testing.T) {
+			db := setupDB(t, kind)
+			require.NoError(t, db.Update(func(tx Tx) error {
+				bkt, err := tx.CreateBucketIfNotExists([]byte("bucket"))
+				if err != nil {
+					return err
+				}
+				for _, k := range []byte{5, 1, 3} {
+					if err := bkt.Put([]byte{k}, []byte{k * 10}); err != nil {
+						return err
+					}
+				}
+				other, err := tx.CreateBucketIfNotExists([]byte("other"))
+				if err != nil {
+					return err
+				}
+				return other.Put([]byte{2}, []byte{2})
+			}))
+			require.NoError(t, db.View(func(tx Tx) error {
+				c := tx.Bucket([]byte("bucket")).Cursor()
+				var keys []byte
+				for k, v := c.First(); k != nil; k, v = c.Next() {
+					assert.DeepEqual(t, []byte{k[0] * 10}, v)
+					keys = append(keys, k[0])
+				}
+				assert.DeepEqual(t, []byte{1, 3, 5}, keys)
+
+				k, _ := c.Last()
+				assert.DeepEqual(t, []byte{5}, k)
+				k, _ = c.Prev()
+				assert.DeepEqual(t, []byte{3}, k)
+				k, _ = c.Seek([]byte{2})
+				assert.DeepEqual(t, []byte{3}, k)
+				k, _ = c.Seek([]byte{6})
+				assert.Equal(t, true, k == nil)
+
+				var count int
+				require.NoError(t, tx.Bucket([]byte("bucket")).ForEach(func(_, _ []byte) error {
+					count++
+					return nil
+				}))
+				assert.Equal(t, 3, count)
+				return nil
+			}))
+		})
+	}
+}
+
+func TestCopy(t *testing.T) {
+	for _, kinds := range [][2]Kind{{Bolt, Pebble}, {Pebble, Bolt}} {
+		t.Run(fmt.Sprintf("%s to %s", kinds[0], kinds[1]), func(t *testing.T) {
+			src, dst := setupDB(t, kinds[0]), setupDB(t, kinds[1])
+			require.NoError(t, src.Update(func(tx Tx) error {
+				for _, name := range []string{"blocks", "states", "empty"} {
+					bkt, err := tx.CreateBucketIfNotExists([]byte(name))
+					if err != nil {
+						return err
+					}
+					if name == "empty" {
+						continue
+					}
+					for i := 0; i < 100; i++ {
+						if err := bkt.Put([]byte(fmt.Sprintf("%s-%03d", name, i)), []byte{byte(i)}); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			}))
+
+			copied, err := Copy(context.Background(), src, dst)
+			require.NoError(t, err)
+			assert.Equal(t, 200, copied)
+			require.NoError(t, dst.View(func(tx Tx) error {
+				require.NotNil(t, tx.Bucket([]byte("empty")))
+				for _, name := range []string{"blocks", "states"} {
+					bkt := tx.Bucket([]byte(name))
+					require.NotNil(t, bkt)
+					for i := 0; i < 100; i++ {
+						assert.DeepEqual(t, []byte{byte(i)}, bkt.Get([]byte(fmt.Sprintf("%s-%03d", name, i))))
+					}
+				}
+				return nil
+			}))
+		})
+	}
+}
+
+func TestDB_Compact(t *testing.T) {
+	for _, kind := range []Kind{Bolt, Pebble} {
+		t.Run(string(kind), func(t *testing.T) {
+			db := setupDB(t, kind)
+			value := make([]byte, 4096)
+			put := func(from, to int) {
+				require.NoError(t, db.Update(func(tx Tx) error {
+					bkt, err := tx.CreateBucketIfNotExists([]byte("bucket"))
+					if err != nil {
+						return err
+					}
+					for i := from; i < to; i++ {
+						if err := bkt.Put([]byte(fmt.Sprintf("key-%04d", i)), value); err != nil {
+							return err
+						}
+					}
+					return nil
+				}))
+			}
+			put(0, 1000)
+			require.NoError(t, db.Update(func(tx Tx) error {
+				for i := 10; i < 1000; i++ {
+					if err := tx.Bucket([]byte("bucket")).Delete([]byte(fmt.Sprintf("key-%04d", i))); err != nil {
+						return err
+					}
+				}
+				return nil
+			}))
+			before, err := db.Stats()
+			require.NoError(t, err)
+			if kind == Bolt {
+				assert.Equal(t, true, before.Free > 0)
+			}
+
+			require.NoError(t, db.Compact(context.Background()))
+			after, err := db.Stats()
+			require.NoError(t, err)
+			if kind == Bolt {
+				assert.Equal(t, true, after.Size < before.Size, "size %d after compaction, %d before", after.Size, before.Size)
+			}
+			// The database is still open and writable.
+			put(1000, 1001)
+			require.NoError(t, db.View(func(tx Tx) error {
+				bkt := tx.Bucket([]byte("bucket"))
+				for _, i := range []int{0, 9, 1000} {
+					assert.DeepEqual(t, value, bkt.Get([]byte(fmt.Sprintf("key-%04d", i))))
+				}
+				assert.Equal(t, true, bkt.Get([]byte("key-0010")) == nil)
+				return nil
+			}))
+		})
+	}
+}
+
+func TestBoltDB_Compact_JournaledWrites(t *testing.T) {
+	// The memory map is large enough for the database file not to be remapped while the copied view
+	// is open, as remapping waits for the read-only transactions.
+	kv, err := Open(Bolt, t.TempDir(), "test.db", &Options{InitialMMapSize: 1 << 24})
+	require.NoError(t, err)
+	db, ok := kv.(*boltDB)
+	require.Equal(t, true, ok)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	put := func(bucket, key string) error {
+		return db.Update(func(tx Tx) error {
+			bkt, err := tx.CreateBucketIfNotExists([]byte(bucket))
+			if err != nil {
+				return err
+			}
+			return bkt.Put([]byte(key), []byte(key))
+		})
+	}
+	require.NoError(t, put("a", "copied"))
+	require.NoError(t, put("a", "deleted"))
+
+	src, srcTx, err := db.startJournal()
+	require.NoError(t, err)
+	_, _, err = db.startJournal()
+	require.ErrorContains(t, "compaction already in progress", err)
+	// The writes committed during the copy are replayed on the copy, the failed ones are not.
+	require.NoError(t, put("a", "journaled"))
+	require.NoError(t, put("b", "journaled"))
+	require.NoError(t, db.Update(func(tx Tx) error {
+		return tx.Bucket([]byte("a")).Delete([]byte("deleted"))
+	}))
+	require.ErrorContains(t, "failed", db.Update(func(tx Tx) error {
+		if err := tx.Bucket([]byte("a")).Put([]byte("failed"), []byte("failed")); err != nil {
+			return err
+		}
+		return errors.New("failed")
+	}))
+	tmp := db.datafile + ".compact"
+	dst, err := db.copyTo(context.Background(), srcTx, tmp)
+	require.NoError(t, err)
+	require.NoError(t, db.replaceBy(dst, tmp))
+	require.NoError(t, src.Close())
+
+	require.NoError(t, db.View(func(tx Tx) error {
+		a, b := tx.Bucket([]byte("a")), tx.Bucket([]byte("b"))
+		require.NotNil(t, b)
+		assert.DeepEqual(t, []byte("copied"), a.Get([]byte("copied")))
+		assert.DeepEqual(t, []byte("journaled"), a.Get([]byte("journaled")))
+		assert.DeepEqual(t, []byte("journaled"), b.Get([]byte("journaled")))
+		assert.Equal(t, true, a.Get([]byte("deleted")) == nil)
+		assert.Equal(t, true, a.Get([]byte("failed")) == nil)
+		return nil
+	}))
+	assert.Equal(t, true, db.journal == nil)
+}
+
+func TestOpen_ReadOnly(t *testing.T) {
+	for _, kind := range []Kind{Bolt, Pebble} {
+		t.Run(string(kind), func(t *testing.T) {
+			dir := t.TempDir()
+			_, err := Open(kind, dir, "test.db", &Options{ReadOnly: true})
+			require.ErrorContains(t, "to open read-only", err)
+
+			db, err := Open(kind, dir, "test.db", nil)
+			require.NoError(t, err)
+			require.NoError(t, db.Update(func(tx Tx) error {
+				bkt, err := tx.CreateBucketIfNotExists([]byte("a"))
+				if err != nil {
+					return err
+				}
+				return bkt.Put([]byte("key"), []byte("value"))
+			}))
+			require.NoError(t, db.Close())
+
+			db, err = Open(kind, dir, "test.db", &Options{ReadOnly: true})
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, db.Close())
+			}()
+			require.NoError(t, db.View(func(tx Tx) error {
+				assert.DeepEqual(t, []byte("value"), tx.Bucket([]byte("a")).Get([]byte("key")))
+				return nil
+			}))
+			err = db.Update(func(tx Tx) error {
+				t.Fatal("Read-write transaction ran on a read-only database")
+				return nil
+			})
+			assert.Equal(t, true, errors.Is(err, ErrReadOnly))
+			assert.Equal(t, true, errors.Is(db.Compact(context.Background()), ErrReadOnly))
+		})
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	kind, err := ParseKind("")
+	require.NoError(t, err)
+	assert.Equal(t, Bolt, kind)
+	kind, err = ParseKind("pebble")
+	require.NoError(t, err)
+	assert.Equal(t, Pebble, kind)
+	_, err = ParseKind("leveldb")
+	assert.ErrorContains(t, "unknown database backend", err)
+}
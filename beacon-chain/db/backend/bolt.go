@@ -0,0 +1,398 @@
+package backend
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	prombolt "github.com/prysmaticlabs/prombbolt"
+	"github.com/prysmaticlabs/prysm/config/params"
+	bolt "go.etcd.io/bbolt"
+)
+
+const boltAllocSize = 8 * 1024 * 1024
+
+// ErrTimeout is returned when the lock of a bolt database could not be obtained.
+var ErrTimeout = bolt.ErrTimeout
+
+type boltDB struct {
+	datafile string
+	opts     *Options
+	// Serializes the read-write transactions with the start and the end of a compaction.
+	writeLock sync.Mutex
+	// The writes committed while the database is copied by a compaction, which are replayed on the
+	// compacted copy. Nil when no compaction is in progress. Guarded by writeLock.
+	journal []*boltWrite
+	dbLock  sync.RWMutex
+	db      *bolt.DB
+}
+
+type boltWriteOp byte
+
+const (
+	createBucketOp boltWriteOp = iota
+	deleteBucketOp
+	putOp
+	deleteOp
+)
+
+// boltWrite is a write of a read-write transaction, recorded while the database is compacted.
+type boltWrite struct {
+	op     boltWriteOp
+	bucket []byte
+	key    []byte
+	value  []byte
+}
+
+// apply the write to the transaction.
+func (w *boltWrite) apply(tx *bolt.Tx) error {
+	switch w.op {
+	case createBucketOp:
+		_, err := tx.CreateBucketIfNotExists(w.bucket)
+		return err
+	case deleteBucketOp:
+		if err := tx.DeleteBucket(w.bucket); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+			return err
+		}
+		return nil
+	}
+	bkt := tx.Bucket(w.bucket)
+	if bkt == nil {
+		return errors.Errorf("bucket %s not found", w.bucket)
+	}
+	if w.op == putOp {
+		return bkt.Put(w.key, w.value)
+	}
+	return bkt.Delete(w.key)
+}
+
+func openBolt(datafile string, opts *Options) (*boltDB, error) {
+	db, err := openBoltFile(datafile, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &boltDB{datafile: datafile, opts: opts, db: db}, nil
+}
+
+func openBoltFile(datafile string, opts *Options) (*bolt.DB, error) {
+	db, err := bolt.Open(
+		datafile,
+		params.BeaconIoConfig().ReadWritePermissions,
+		&bolt.Options{
+			Timeout:         1 * time.Second,
+			InitialMmapSize: opts.InitialMMapSize,
+			ReadOnly:        opts.ReadOnly,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	db.AllocSize = boltAllocSize
+	return db, nil
+}
+
+// current returns the open bolt database, which is replaced when compacted.
+func (b *boltDB) current() *bolt.DB {
+	b.dbLock.RLock()
+	defer b.dbLock.RUnlock()
+	return b.db
+}
+
+func (b *boltDB) View(fn func(tx Tx) error) error {
+	for {
+		db := b.current()
+		err := db.View(func(tx *bolt.Tx) error {
+			return fn(&boltTx{tx: tx})
+		})
+		// The database was closed after being replaced by its compacted copy before the
+		// transaction began, which is retried on the copy.
+		if errors.Is(err, bolt.ErrDatabaseNotOpen) && db != b.current() {
+			continue
+		}
+		return err
+	}
+}
+
+func (b *boltDB) Update(fn func(tx Tx) error) error {
+	if b.opts.ReadOnly {
+		return ErrReadOnly
+	}
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+	var writes *[]*boltWrite
+	if b.journal != nil {
+		writes = &[]*boltWrite{}
+	}
+	err := b.current().Update(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx, writes: writes})
+	})
+	if err == nil && writes != nil {
+		b.journal = append(b.journal, *writes...)
+	}
+	return err
+}
+
+func (*boltDB) Kind() Kind {
+	return Bolt
+}
+
+func (b *boltDB) Collector(blockedBuckets ...[]byte) prometheus.Collector {
+	return &boltCollector{b: b, blockedBuckets: blockedBuckets}
+}
+
+func (b *boltDB) Stats() (*Stats, error) {
+	db := b.current()
+	info, err := os.Stat(b.datafile)
+	if err != nil {
+		return nil, err
+	}
+	st := db.Stats()
+	return &Stats{
+		Size: info.Size(),
+		Free: int64(st.FreePageN+st.PendingPageN) * int64(db.Info().PageSize),
+	}, nil
+}
+
+// Compact copies the database into a new file, which then replaces the database file, so it needs
+// free disk space for the size of the data. Transactions carry on during the copy, which is made
+// from a consistent view of the database. The writes committed meanwhile are replayed on the copy
+// before it replaces the database, while the read-write transactions wait. Writes growing the
+// database file past its memory map still wait for the copy, as bolt remaps the file once no
+// read-only transaction is open.
+func (b *boltDB) Compact(ctx context.Context) error {
+	if b.opts.ReadOnly {
+		return ErrReadOnly
+	}
+	old, err := b.replaceByCompactedCopy(ctx)
+	if err != nil {
+		return err
+	}
+	// Closing waits for the read-only transactions still open on the old database, which may take
+	// long for a backup, without holding back the read-write transactions.
+	return old.Close()
+}
+
+func (b *boltDB) replaceByCompactedCopy(ctx context.Context) (*bolt.DB, error) {
+	src, srcTx, err := b.startJournal()
+	if err != nil {
+		return nil, err
+	}
+	tmp := b.datafile + ".compact"
+	dst, err := b.copyTo(ctx, srcTx, tmp)
+	if err != nil {
+		b.writeLock.Lock()
+		b.journal = nil
+		b.writeLock.Unlock()
+		return nil, err
+	}
+	if err := b.replaceBy(dst, tmp); err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+// replaceBy replays the journal on the copy of the database at the given path, which then
+// replaces the database.
+func (b *boltDB) replaceBy(dst *bolt.DB, path string) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+	journal := b.journal
+	b.journal = nil
+	if err := dst.Update(func(tx *bolt.Tx) error {
+		for _, w := range journal {
+			if err := w.apply(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		discardBoltFile(dst, path)
+		return errors.Wrap(err, "could not replay the writes committed during the copy")
+	}
+	if err := dst.Sync(); err != nil {
+		discardBoltFile(dst, path)
+		return err
+	}
+	// The compacted database stays open through the rename, so it never has to be reopened.
+	if err := os.Rename(path, b.datafile); err != nil {
+		discardBoltFile(dst, path)
+		return errors.Wrap(err, "could not replace database file")
+	}
+	b.dbLock.Lock()
+	b.db = dst
+	b.dbLock.Unlock()
+	return nil
+}
+
+// copyTo copies the read-only transaction into a new database at the given path, and closes the
+// transaction.
+func (b *boltDB) copyTo(ctx context.Context, srcTx *bolt.Tx, path string) (*bolt.DB, error) {
+	defer func() {
+		if err := srcTx.Rollback(); err != nil {
+			log.WithError(err).Error("Could not close read-only transaction")
+		}
+	}()
+	if err := os.RemoveAll(path); err != nil {
+		return nil, err
+	}
+	dst, err := openBoltFile(path, b.opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create compacted database")
+	}
+	if _, err := copyTx(ctx, &boltTx{tx: srcTx}, &boltDB{db: dst, opts: b.opts}); err != nil {
+		discardBoltFile(dst, path)
+		return nil, errors.Wrap(err, "could not copy database")
+	}
+	return dst, nil
+}
+
+func discardBoltFile(db *bolt.DB, path string) {
+	if err := db.Close(); err != nil {
+		log.WithError(err).Error("Could not close compacted database")
+	}
+	if err := os.RemoveAll(path); err != nil {
+		log.WithError(err).Error("Could not remove compacted database")
+	}
+}
+
+// startJournal begins the read-only transaction which a compaction copies, along with the journal
+// of the writes committed after it.
+func (b *boltDB) startJournal() (*bolt.DB, *bolt.Tx, error) {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+	if b.journal != nil {
+		return nil, nil, errors.New("compaction already in progress")
+	}
+	src := b.current()
+	tx, err := src.Begin(false)
+	if err != nil {
+		return nil, nil, err
+	}
+	b.journal = make([]*boltWrite, 0)
+	return src, tx, nil
+}
+
+func (b *boltDB) Close() error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+	return b.current().Close()
+}
+
+// boltCollector collects the metrics of the current bolt database, which is replaced when compacted.
+type boltCollector struct {
+	b              *boltDB
+	blockedBuckets [][]byte
+}
+
+func (c *boltCollector) Describe(ch chan<- *prometheus.Desc) {
+	prombolt.New("boltDB", c.b.current(), c.blockedBuckets...).Describe(ch)
+}
+
+func (c *boltCollector) Collect(ch chan<- prometheus.Metric) {
+	prombolt.New("boltDB", c.b.current(), c.blockedBuckets...).Collect(ch)
+}
+
+// boltTx implements io.WriterTo, to write a consistent copy of the database file.
+type boltTx struct {
+	tx *bolt.Tx
+	// The writes of the transaction are recorded while the database is compacted, if set.
+	writes *[]*boltWrite
+}
+
+var _ io.WriterTo = (*boltTx)(nil)
+
+func (t *boltTx) Bucket(name []byte) Bucket {
+	bkt := t.tx.Bucket(name)
+	if bkt == nil {
+		return nil
+	}
+	return t.bucket(name, bkt)
+}
+
+func (t *boltTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	bkt, err := t.tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, err
+	}
+	t.record(&boltWrite{op: createBucketOp, bucket: name})
+	return t.bucket(name, bkt), nil
+}
+
+func (t *boltTx) DeleteBucket(name []byte) error {
+	err := t.tx.DeleteBucket(name)
+	if errors.Is(err, bolt.ErrBucketNotFound) {
+		return ErrBucketNotFound
+	}
+	if err == nil {
+		t.record(&boltWrite{op: deleteBucketOp, bucket: name})
+	}
+	return err
+}
+
+func (t *boltTx) ForEach(fn func(name []byte, b Bucket) error) error {
+	return t.tx.ForEach(func(name []byte, bkt *bolt.Bucket) error {
+		return fn(name, t.bucket(name, bkt))
+	})
+}
+
+func (t *boltTx) WriteTo(w io.Writer) (int64, error) {
+	return t.tx.WriteTo(w)
+}
+
+func (t *boltTx) bucket(name []byte, bkt *bolt.Bucket) *boltBucket {
+	return &boltBucket{bkt: bkt, name: name, tx: t}
+}
+
+// record the write if the writes of the transaction are recorded, copying its bytes which are
+// only valid for the life of the transaction.
+func (t *boltTx) record(w *boltWrite) {
+	if t.writes == nil {
+		return
+	}
+	w.bucket, w.key, w.value = copyBytes(w.bucket), copyBytes(w.key), copyBytes(w.value)
+	*t.writes = append(*t.writes, w)
+}
+
+type boltBucket struct {
+	bkt  *bolt.Bucket
+	name []byte
+	tx   *boltTx
+}
+
+func (b *boltBucket) Get(key []byte) []byte {
+	return b.bkt.Get(key)
+}
+
+func (b *boltBucket) Put(key, value []byte) error {
+	err := b.bkt.Put(key, value)
+	if errors.Is(err, bolt.ErrTxNotWritable) {
+		return ErrTxNotWritable
+	}
+	if err == nil {
+		b.tx.record(&boltWrite{op: putOp, bucket: b.name, key: key, value: value})
+	}
+	return err
+}
+
+func (b *boltBucket) Delete(key []byte) error {
+	err := b.bkt.Delete(key)
+	if errors.Is(err, bolt.ErrTxNotWritable) {
+		return ErrTxNotWritable
+	}
+	if err == nil {
+		b.tx.record(&boltWrite{op: deleteOp, bucket: b.name, key: key})
+	}
+	return err
+}
+
+func (b *boltBucket) ForEach(fn func(k, v []byte) error) error {
+	return b.bkt.ForEach(fn)
+}
+
+func (b *boltBucket) Cursor() Cursor {
+	return b.bkt.Cursor()
+}
@@ -0,0 +1,82 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// Size of the key-value pairs written per transaction of a copy.
+const copyBatchBytes = 64 * 1024 * 1024
+
+type kvPair struct {
+	key, value []byte
+}
+
+// Copy all the buckets of the source database into the destination database, which may be of a
+// different kind, over a consistent view of the source. The destination is written in batches,
+// so it is left partially copied if the copy fails. Returns the number of keys copied.
+func Copy(ctx context.Context, src, dst DB) (int, error) {
+	copied := 0
+	err := src.View(func(srcTx Tx) error {
+		var err error
+		copied, err = copyTx(ctx, srcTx, dst)
+		return err
+	})
+	return copied, err
+}
+
+// copyTx copies all the buckets of the source transaction into the destination database.
+func copyTx(ctx context.Context, srcTx Tx, dst DB) (int, error) {
+	copied := 0
+	err := srcTx.ForEach(func(name []byte, b Bucket) error {
+		bucket := copyBytes(name)
+		if err := dst.Update(func(tx Tx) error {
+			_, err := tx.CreateBucketIfNotExists(bucket)
+			return err
+		}); err != nil {
+			return errors.Wrapf(err, "could not create bucket %s", bucket)
+		}
+		batch := make([]kvPair, 0)
+		size := 0
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			err := dst.Update(func(tx Tx) error {
+				bkt := tx.Bucket(bucket)
+				for _, p := range batch {
+					if err := bkt.Put(p.key, p.value); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return errors.Wrapf(err, "could not write to bucket %s", bucket)
+			}
+			copied += len(batch)
+			batch, size = batch[:0], 0
+			return nil
+		}
+		if err := b.ForEach(func(k, v []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			batch = append(batch, kvPair{key: copyBytes(k), value: copyBytes(v)})
+			size += len(k) + len(v)
+			if size < copyBatchBytes {
+				return nil
+			}
+			return flush()
+		}); err != nil {
+			return err
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		log.WithField("bucket", string(bucket)).Debug("Copied bucket")
+		return nil
+	})
+	return copied, err
+}
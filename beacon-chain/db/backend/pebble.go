@@ -0,0 +1,339 @@
+package backend
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Pebble has a single sorted key space, in which buckets are emulated with key prefixes:
+// the key of a bucket is the length of its name followed by its name and the keys of
+// the bucket are prefixed with it, while bucket names are registered under a zero prefix.
+const bucketRegistryPrefix = byte(0)
+
+type pebbleDB struct {
+	db       *pebble.DB
+	readOnly bool
+	// Serializes the read-write transactions, as bolt does, so that read-modify-write
+	// sequences within a transaction do not race.
+	writeLock sync.Mutex
+}
+
+func openPebble(dir string, opts *Options) (*pebbleDB, error) {
+	db, err := pebble.Open(dir, &pebble.Options{ReadOnly: opts.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	return &pebbleDB{db: db, readOnly: opts.ReadOnly}, nil
+}
+
+func (p *pebbleDB) View(fn func(tx Tx) error) error {
+	snap := p.db.NewSnapshot()
+	defer func() {
+		if err := snap.Close(); err != nil {
+			log.WithError(err).Error("Could not close pebble snapshot")
+		}
+	}()
+	tx := &pebbleTx{reader: snap}
+	err := fn(tx)
+	return tx.close(err)
+}
+
+func (p *pebbleDB) Update(fn func(tx Tx) error) error {
+	if p.readOnly {
+		return ErrReadOnly
+	}
+	p.writeLock.Lock()
+	defer p.writeLock.Unlock()
+	batch := p.db.NewIndexedBatch()
+	defer func() {
+		if err := batch.Close(); err != nil {
+			log.WithError(err).Error("Could not close pebble batch")
+		}
+	}()
+	tx := &pebbleTx{reader: batch, batch: batch}
+	if err := tx.close(fn(tx)); err != nil {
+		return err
+	}
+	return batch.Commit(pebble.Sync)
+}
+
+func (*pebbleDB) Kind() Kind {
+	return Pebble
+}
+
+func (p *pebbleDB) Collector(_ ...[]byte) prometheus.Collector {
+	return &pebbleCollector{db: p.db}
+}
+
+func (p *pebbleDB) Stats() (*Stats, error) {
+	m := p.db.Metrics()
+	return &Stats{
+		Size: int64(m.DiskSpaceUsage()),
+		Free: int64(m.Table.ObsoleteSize + m.Table.ZombieSize),
+	}, nil
+}
+
+// Compact runs a manual compaction of the whole key space, which drops deleted keys and
+// overwritten values. Transactions carry on during the compaction.
+func (p *pebbleDB) Compact(_ context.Context) error {
+	if p.readOnly {
+		return ErrReadOnly
+	}
+	return p.db.Compact([]byte{0}, []byte{0xff})
+}
+
+func (p *pebbleDB) Close() error {
+	return p.db.Close()
+}
+
+// pebbleReader is implemented by pebble snapshots and indexed batches.
+type pebbleReader interface {
+	Get(key []byte) ([]byte, io.Closer, error)
+	NewIter(o *pebble.IterOptions) *pebble.Iterator
+}
+
+type pebbleTx struct {
+	reader pebbleReader
+	// Nil in read-only transactions.
+	batch *pebble.Batch
+	iters []*pebble.Iterator
+	// First read error, as reads do not return errors.
+	err error
+}
+
+// close the iterators of the transaction, returning the first error of the transaction.
+func (t *pebbleTx) close(err error) error {
+	for _, it := range t.iters {
+		if closeErr := it.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	t.iters = nil
+	if err == nil {
+		err = t.err
+	}
+	return err
+}
+
+func (t *pebbleTx) get(key []byte) []byte {
+	v, closer, err := t.reader.Get(key)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		if t.err == nil {
+			t.err = err
+		}
+		return nil
+	}
+	value := make([]byte, len(v))
+	copy(value, v)
+	if err := closer.Close(); err != nil && t.err == nil {
+		t.err = err
+	}
+	return value
+}
+
+func (t *pebbleTx) iter(lower, upper []byte) *pebble.Iterator {
+	it := t.reader.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: upper})
+	t.iters = append(t.iters, it)
+	return it
+}
+
+func (t *pebbleTx) Bucket(name []byte) Bucket {
+	if t.get(registryKey(name)) == nil {
+		return nil
+	}
+	return &pebbleBucket{tx: t, prefix: bucketPrefix(name)}
+}
+
+func (t *pebbleTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	if t.batch == nil {
+		return nil, ErrTxNotWritable
+	}
+	if len(name) == 0 || len(name) > 255 {
+		return nil, errors.Errorf("invalid bucket name length %d", len(name))
+	}
+	if err := t.batch.Set(registryKey(name), []byte{}, nil); err != nil {
+		return nil, err
+	}
+	return &pebbleBucket{tx: t, prefix: bucketPrefix(name)}, nil
+}
+
+func (t *pebbleTx) DeleteBucket(name []byte) error {
+	if t.batch == nil {
+		return ErrTxNotWritable
+	}
+	if t.Bucket(name) == nil {
+		return ErrBucketNotFound
+	}
+	if err := t.batch.Delete(registryKey(name), nil); err != nil {
+		return err
+	}
+	prefix := bucketPrefix(name)
+	return t.batch.DeleteRange(prefix, prefixEnd(prefix), nil)
+}
+
+func (t *pebbleTx) ForEach(fn func(name []byte, b Bucket) error) error {
+	it := t.iter([]byte{bucketRegistryPrefix}, []byte{bucketRegistryPrefix + 1})
+	for valid := it.First(); valid; valid = it.Next() {
+		name := copyBytes(it.Key()[1:])
+		if err := fn(name, &pebbleBucket{tx: t, prefix: bucketPrefix(name)}); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+type pebbleBucket struct {
+	tx     *pebbleTx
+	prefix []byte
+}
+
+func (b *pebbleBucket) key(k []byte) []byte {
+	key := make([]byte, len(b.prefix)+len(k))
+	copy(key, b.prefix)
+	copy(key[len(b.prefix):], k)
+	return key
+}
+
+func (b *pebbleBucket) Get(key []byte) []byte {
+	return b.tx.get(b.key(key))
+}
+
+func (b *pebbleBucket) Put(key, value []byte) error {
+	if b.tx.batch == nil {
+		return ErrTxNotWritable
+	}
+	if len(key) == 0 {
+		return errors.New("key required")
+	}
+	return b.tx.batch.Set(b.key(key), value, nil)
+}
+
+func (b *pebbleBucket) Delete(key []byte) error {
+	if b.tx.batch == nil {
+		return ErrTxNotWritable
+	}
+	return b.tx.batch.Delete(b.key(key), nil)
+}
+
+func (b *pebbleBucket) ForEach(fn func(k, v []byte) error) error {
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *pebbleBucket) Cursor() Cursor {
+	return &pebbleCursor{it: b.tx.iter(b.prefix, prefixEnd(b.prefix)), prefix: b.prefix}
+}
+
+type pebbleCursor struct {
+	it     *pebble.Iterator
+	prefix []byte
+}
+
+// entry returns copies of the key, stripped of the bucket prefix, and value at the iterator
+// position, since they are only valid until the iterator moves.
+func (c *pebbleCursor) entry(valid bool) ([]byte, []byte) {
+	if !valid {
+		return nil, nil
+	}
+	return copyBytes(c.it.Key()[len(c.prefix):]), copyBytes(c.it.Value())
+}
+
+func (c *pebbleCursor) First() ([]byte, []byte) {
+	return c.entry(c.it.First())
+}
+
+func (c *pebbleCursor) Last() ([]byte, []byte) {
+	return c.entry(c.it.Last())
+}
+
+func (c *pebbleCursor) Next() ([]byte, []byte) {
+	return c.entry(c.it.Next())
+}
+
+func (c *pebbleCursor) Prev() ([]byte, []byte) {
+	return c.entry(c.it.Prev())
+}
+
+func (c *pebbleCursor) Seek(seek []byte) ([]byte, []byte) {
+	key := make([]byte, len(c.prefix)+len(seek))
+	copy(key, c.prefix)
+	copy(key[len(c.prefix):], seek)
+	return c.entry(c.it.SeekGE(key))
+}
+
+func registryKey(name []byte) []byte {
+	return append([]byte{bucketRegistryPrefix}, name...)
+}
+
+func bucketPrefix(name []byte) []byte {
+	return append([]byte{byte(len(name))}, name...)
+}
+
+// prefixEnd returns the smallest key greater than all the keys with the given prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := copyBytes(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	// The prefix only has 0xff bytes, there is no upper bound.
+	return nil
+}
+
+func copyBytes(b []byte) []byte {
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
+
+var (
+	pebbleDiskUsageDesc = prometheus.NewDesc("pebble_disk_usage_bytes",
+		"Disk space used by the pebble database", nil, nil)
+	pebbleReadAmpDesc = prometheus.NewDesc("pebble_read_amplification",
+		"Number of sorted runs read from for a key lookup", nil, nil)
+	pebbleMemTableSizeDesc = prometheus.NewDesc("pebble_memtable_size_bytes",
+		"Size of the pebble memtables", nil, nil)
+	pebbleCompactionsDesc = prometheus.NewDesc("pebble_compactions_total",
+		"Number of pebble compactions", nil, nil)
+	pebbleFlushesDesc = prometheus.NewDesc("pebble_flushes_total",
+		"Number of pebble memtable flushes", nil, nil)
+)
+
+// pebbleCollector is a prometheus.Collector for pebble database metrics.
+type pebbleCollector struct {
+	db *pebble.DB
+}
+
+// Describe implements the prometheus.Collector interface.
+func (*pebbleCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pebbleDiskUsageDesc
+	ch <- pebbleReadAmpDesc
+	ch <- pebbleMemTableSizeDesc
+	ch <- pebbleCompactionsDesc
+	ch <- pebbleFlushesDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *pebbleCollector) Collect(ch chan<- prometheus.Metric) {
+	m := c.db.Metrics()
+	ch <- prometheus.MustNewConstMetric(pebbleDiskUsageDesc, prometheus.GaugeValue, float64(m.DiskSpaceUsage()))
+	ch <- prometheus.MustNewConstMetric(pebbleReadAmpDesc, prometheus.GaugeValue, float64(m.ReadAmp()))
+	ch <- prometheus.MustNewConstMetric(pebbleMemTableSizeDesc, prometheus.GaugeValue, float64(m.MemTable.Size))
+	ch <- prometheus.MustNewConstMetric(pebbleCompactionsDesc, prometheus.CounterValue, float64(m.Compact.Count))
+	ch <- prometheus.MustNewConstMetric(pebbleFlushesDesc, prometheus.CounterValue, float64(m.Flush.Count))
+}
@@ -0,0 +1,5 @@
+package compactor
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "compactor")
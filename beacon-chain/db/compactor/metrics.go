@@ -0,0 +1,29 @@
+package compactor
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	diskSizeBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacondb_disk_size_bytes",
+		Help: "Size of the beacon database on disk",
+	})
+	freeBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacondb_free_bytes",
+		Help: "Bytes of the beacon database on disk which hold no live data, reclaimed by compaction",
+	})
+	compactionsCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "compactor_compactions_count",
+		Help: "Number of compactions of the beacon database",
+	})
+	reclaimedBytesCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "compactor_reclaimed_bytes_count",
+		Help: "Number of bytes of disk space reclaimed by compacting the beacon database",
+	})
+	lastCompactionDuration = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "compactor_last_compaction_duration_seconds",
+		Help: "Duration of the last compaction of the beacon database",
+	})
+)
@@ -0,0 +1,171 @@
+// Package compactor rewrites the beacon database to reclaim the disk space of deleted data, on a
+// schedule or when triggered through the monitoring endpoint, and reports its disk usage.
+package compactor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/async"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/runtime"
+	"github.com/sirupsen/logrus"
+)
+
+var _ runtime.Service = (*Service)(nil)
+
+var errCompactionInProgress = errors.New("compaction already in progress")
+
+// Config to set up the compactor service.
+type Config struct {
+	DB db.Database
+	// Period of the scheduled compactions, none are scheduled if zero.
+	Interval time.Duration
+	// Minimum ratio of free bytes to the size of the database for a scheduled compaction to run.
+	MinFreeRatio float64
+}
+
+// Service compacts the database periodically, whenever enough of it is free, or on demand.
+type Service struct {
+	cfg        *Config
+	ctx        context.Context
+	cancel     context.CancelFunc
+	compacting int32
+}
+
+// NewService configures the compactor service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start the compactor service.
+func (s *Service) Start() {
+	s.updateDiskUsage()
+	period := time.Duration(params.BeaconConfig().SecondsPerSlot*uint64(params.BeaconConfig().SlotsPerEpoch)) * time.Second
+	async.RunEvery(s.ctx, period, s.updateDiskUsage)
+	if s.cfg.Interval == 0 {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"interval":     s.cfg.Interval,
+		"minFreeRatio": s.cfg.MinFreeRatio,
+	}).Info("Scheduling database compactions")
+	async.RunEvery(s.ctx, s.cfg.Interval, s.run)
+}
+
+// Stop the compactor service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the compactor service.
+func (s *Service) Status() error {
+	return nil
+}
+
+// CompactHandler compacts the database when receiving a POST request, and replies with the
+// number of bytes reclaimed.
+func (s *Service) CompactHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	log.Debug("Compacting database from HTTP webhook")
+	reclaimed, err := s.compact(r.Context())
+	if errors.Is(err, errCompactionInProgress) {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("Could not compact database")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "Reclaimed %d bytes", reclaimed); err != nil {
+		log.WithError(err).Error("Failed to write response")
+	}
+}
+
+func (s *Service) run() {
+	usage, err := s.cfg.DB.DiskUsage()
+	if err != nil {
+		log.WithError(err).Error("Could not get database disk usage")
+		return
+	}
+	if usage.Size == 0 || float64(usage.Free)/float64(usage.Size) < s.cfg.MinFreeRatio {
+		log.WithFields(logrus.Fields{
+			"size": usage.Size,
+			"free": usage.Free,
+		}).Debug("Skipping database compaction")
+		return
+	}
+	if _, err := s.compact(s.ctx); err != nil {
+		if errors.Is(s.ctx.Err(), context.Canceled) {
+			return
+		}
+		log.WithError(err).Error("Could not compact database")
+	}
+}
+
+// compact the database, returning the number of bytes reclaimed.
+func (s *Service) compact(ctx context.Context) (int64, error) {
+	if !atomic.CompareAndSwapInt32(&s.compacting, 0, 1) {
+		return 0, errCompactionInProgress
+	}
+	defer atomic.StoreInt32(&s.compacting, 0)
+
+	before, err := s.cfg.DB.DiskUsage()
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get database disk usage")
+	}
+	log.WithFields(logrus.Fields{
+		"size": before.Size,
+		"free": before.Free,
+	}).Info("Compacting database")
+	start := time.Now()
+	if err := s.cfg.DB.Compact(ctx); err != nil {
+		return 0, err
+	}
+	duration := time.Since(start)
+	after, err := s.cfg.DB.DiskUsage()
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get database disk usage")
+	}
+	reclaimed := before.Size - after.Size
+	if reclaimed < 0 {
+		reclaimed = 0
+	}
+	compactionsCount.Inc()
+	reclaimedBytesCount.Add(float64(reclaimed))
+	lastCompactionDuration.Set(duration.Seconds())
+	diskSizeBytes.Set(float64(after.Size))
+	freeBytes.Set(float64(after.Free))
+	log.WithFields(logrus.Fields{
+		"size":      after.Size,
+		"reclaimed": reclaimed,
+		"duration":  duration,
+	}).Info("Compacted database")
+	return reclaimed, nil
+}
+
+func (s *Service) updateDiskUsage() {
+	usage, err := s.cfg.DB.DiskUsage()
+	if err != nil {
+		log.WithError(err).Error("Could not get database disk usage")
+		return
+	}
+	diskSizeBytes.Set(float64(usage.Size))
+	freeBytes.Set(float64(usage.Free))
+}
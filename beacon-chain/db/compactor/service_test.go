@@ -0,0 +1,69 @@
+package compactor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestService_Compact(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+
+	var roots [][32]byte
+	for slot := types.Slot(1); slot <= 512; slot++ {
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = slot
+		blk.Block.Body.Graffiti = bytesutil.PadTo([]byte{byte(slot), byte(slot >> 8)}, 32)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(blk)))
+		r, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		roots = append(roots, r)
+	}
+	for _, r := range roots[1:] {
+		require.NoError(t, beaconDB.DeleteBlock(ctx, r))
+	}
+	usage, err := beaconDB.DiskUsage()
+	require.NoError(t, err)
+	require.Equal(t, true, usage.Free > 0)
+
+	t.Run("below minimum free ratio", func(t *testing.T) {
+		s := NewService(ctx, &Config{DB: beaconDB, MinFreeRatio: 1})
+		s.run()
+		after, err := beaconDB.DiskUsage()
+		require.NoError(t, err)
+		assert.Equal(t, usage.Size, after.Size)
+	})
+	t.Run("in progress", func(t *testing.T) {
+		s := NewService(ctx, &Config{DB: beaconDB})
+		s.compacting = 1
+		rec := httptest.NewRecorder()
+		s.CompactHandler(rec, httptest.NewRequest(http.MethodPost, "/db/compact", nil))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+	t.Run("webhook", func(t *testing.T) {
+		s := NewService(ctx, &Config{DB: beaconDB})
+		rec := httptest.NewRecorder()
+		s.CompactHandler(rec, httptest.NewRequest(http.MethodGet, "/db/compact", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+		rec = httptest.NewRecorder()
+		s.CompactHandler(rec, httptest.NewRequest(http.MethodPost, "/db/compact", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, true, strings.HasPrefix(rec.Body.String(), "Reclaimed"))
+		after, err := beaconDB.DiskUsage()
+		require.NoError(t, err)
+		assert.Equal(t, true, after.Size < usage.Size, "size %d after compaction, %d before", after.Size, usage.Size)
+		assert.Equal(t, true, beaconDB.HasBlock(ctx, roots[0]))
+	})
+}
@@ -5,6 +5,7 @@ package db
 import (
 	"context"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
 )
 
@@ -15,7 +16,7 @@ func NewDB(ctx context.Context, dirPath string, config *kv.Config) (Database, er
 
 // NewDBFilename uses the KVStoreDatafilePath so that if this layer of
 // indirection between db.NewDB->kv.NewKVStore ever changes, it will be easy to remember
-// to also change this filename indirection at the same time.
-func NewDBFilename(dirPath string) string {
-	return kv.KVStoreDatafilePath(dirPath)
+// to also change this filename indirection at the same time. Pebble databases are directories.
+func NewDBFilename(dirPath string, kind backend.Kind) string {
+	return kv.KVStoreDatafilePath(dirPath, kind)
 }
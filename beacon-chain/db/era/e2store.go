@@ -0,0 +1,90 @@
+package era
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+)
+
+// An e2store file is a sequence of records, each made of an 8 bytes header followed by the data of
+// the record: the type of the record on 2 bytes, the length of the data as a little endian uint32,
+// then 2 reserved zero bytes.
+const headerLength = 8
+
+var (
+	versionType   = [2]byte{0x65, 0x32}
+	blockType     = [2]byte{0x01, 0x00}
+	stateType     = [2]byte{0x02, 0x00}
+	slotIndexType = [2]byte{0x69, 0x32}
+)
+
+type e2sWriter struct {
+	w   io.Writer
+	pos int64
+}
+
+// write a record, returning its offset in the file.
+func (w *e2sWriter) write(typ [2]byte, data []byte) (int64, error) {
+	if uint64(len(data)) > uint64(^uint32(0)) {
+		return 0, errors.Errorf("record of %d bytes is too large", len(data))
+	}
+	header := make([]byte, headerLength)
+	copy(header, typ[:])
+	binary.LittleEndian.PutUint32(header[2:], uint32(len(data)))
+	offset := w.pos
+	if _, err := w.w.Write(header); err != nil {
+		return 0, err
+	}
+	if _, err := w.w.Write(data); err != nil {
+		return 0, err
+	}
+	w.pos += int64(headerLength + len(data))
+	return offset, nil
+}
+
+// writeCompressed writes a record of snappy framed data.
+func (w *e2sWriter) writeCompressed(typ [2]byte, data []byte) (int64, error) {
+	buf := new(bytes.Buffer)
+	sw := snappy.NewBufferedWriter(buf)
+	if _, err := sw.Write(data); err != nil {
+		return 0, err
+	}
+	if err := sw.Close(); err != nil {
+		return 0, err
+	}
+	return w.write(typ, buf.Bytes())
+}
+
+// readRecord reads the record at the given offset of the file.
+func readRecord(r io.ReaderAt, offset int64) ([2]byte, []byte, error) {
+	var typ [2]byte
+	header := make([]byte, headerLength)
+	if _, err := r.ReadAt(header, offset); err != nil {
+		return typ, nil, errors.Wrapf(err, "could not read record header at offset %d", offset)
+	}
+	copy(typ[:], header)
+	if header[6] != 0 || header[7] != 0 {
+		return typ, nil, errors.Errorf("invalid record header at offset %d", offset)
+	}
+	data := make([]byte, binary.LittleEndian.Uint32(header[2:]))
+	if _, err := r.ReadAt(data, offset+headerLength); err != nil {
+		return typ, nil, errors.Wrapf(err, "could not read record at offset %d", offset)
+	}
+	return typ, data, nil
+}
+
+// readCompressed reads a record of snappy framed data of the given type.
+func readCompressed(r io.ReaderAt, offset int64, want [2]byte) ([]byte, error) {
+	typ, data, err := readRecord(r, offset)
+	if err != nil {
+		return nil, err
+	}
+	if typ != want {
+		return nil, errors.Errorf("record at offset %d is of type %#x, expected %#x", offset, typ, want)
+	}
+	return ioutil.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+}
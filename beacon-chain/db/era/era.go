@@ -0,0 +1,240 @@
+// Package era reads and writes era files, which hold the finalized history of the beacon chain
+// one era of SLOTS_PER_HISTORICAL_ROOT slots at a time, so that it can be archived and seeded
+// from flat files instead of being requested from peers.
+//
+// The era file of era N is an e2store file holding the blocks of the slots of era N-1, followed by
+// the state at the first slot of era N, whose block roots commit to those blocks. The blocks and
+// the state are snappy framed SSZ. The file ends with the index of the offsets of the blocks by
+// slot, then the index of the offset of the state.
+package era
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+)
+
+// FileName of the era file of the given era, named after the network, the era and the first bytes
+// of the last historical root of the state of the era, or the genesis validators root for era 0.
+func FileName(era uint64, st state.ReadOnlyBeaconState) string {
+	root := st.GenesisValidatorsRoot()
+	if roots := st.HistoricalRoots(); era > 0 && uint64(len(roots)) >= era {
+		root = roots[era-1]
+	}
+	return fmt.Sprintf("%s-%05d-%x.era", params.BeaconConfig().ConfigName, era, root[:4])
+}
+
+// Writer writes the blocks of an era, then the state at its end, to an era file.
+type Writer struct {
+	e2s       *e2sWriter
+	era       uint64
+	startSlot types.Slot
+	// Offsets of the records of the blocks by slot, zero for empty slots.
+	blockOffsets []int64
+	lastSlot     types.Slot
+	hasBlock     bool
+}
+
+// NewWriter starts the era file of the given era.
+func NewWriter(w io.Writer, era uint64) (*Writer, error) {
+	e2s := &e2sWriter{w: w}
+	if _, err := e2s.write(versionType, nil); err != nil {
+		return nil, err
+	}
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	ew := &Writer{e2s: e2s, era: era}
+	if era > 0 {
+		ew.startSlot = perEra.Mul(era - 1)
+		ew.blockOffsets = make([]int64, perEra)
+	}
+	return ew, nil
+}
+
+// WriteBlock writes the next block of the era, the blocks being written by increasing slot.
+func (w *Writer) WriteBlock(blk block.SignedBeaconBlock) error {
+	slot := blk.Block().Slot()
+	if slot < w.startSlot || uint64(slot-w.startSlot) >= uint64(len(w.blockOffsets)) {
+		return errors.Errorf("block at slot %d is not part of era %d", slot, w.era)
+	}
+	if w.hasBlock && slot <= w.lastSlot {
+		return errors.Errorf("block at slot %d written after block at slot %d", slot, w.lastSlot)
+	}
+	enc, err := blk.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	offset, err := w.e2s.writeCompressed(blockType, enc)
+	if err != nil {
+		return err
+	}
+	w.blockOffsets[slot-w.startSlot] = offset
+	w.lastSlot = slot
+	w.hasBlock = true
+	return nil
+}
+
+// Finish the era file with the state at the first slot of the next era, and the indices.
+func (w *Writer) Finish(st state.ReadOnlyBeaconState) error {
+	if want := params.BeaconConfig().SlotsPerHistoricalRoot.Mul(w.era); st.Slot() != want {
+		return errors.Errorf("state of era %d is at slot %d, expected %d", w.era, st.Slot(), want)
+	}
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	stateOffset, err := w.e2s.writeCompressed(stateType, enc)
+	if err != nil {
+		return err
+	}
+	if w.era > 0 {
+		if err := w.writeSlotIndex(w.startSlot, w.blockOffsets); err != nil {
+			return err
+		}
+	}
+	return w.writeSlotIndex(st.Slot(), []int64{stateOffset})
+}
+
+// writeSlotIndex writes the index of the records of a range of slots: the starting slot, the
+// offsets of the records relative to the index, or zero for empty slots, then the count of slots.
+func (w *Writer) writeSlotIndex(start types.Slot, offsets []int64) error {
+	data := make([]byte, 16+8*len(offsets))
+	binary.LittleEndian.PutUint64(data, uint64(start))
+	for i, offset := range offsets {
+		if offset != 0 {
+			binary.LittleEndian.PutUint64(data[8+8*i:], uint64(offset-w.e2s.pos))
+		}
+	}
+	binary.LittleEndian.PutUint64(data[8+8*len(offsets):], uint64(len(offsets)))
+	_, err := w.e2s.write(slotIndexType, data)
+	return err
+}
+
+// Reader reads the blocks and the state of an era file.
+type Reader struct {
+	r   io.ReaderAt
+	era uint64
+	// Offsets of the records of the blocks by slot from the start slot, zero for empty slots.
+	startSlot    types.Slot
+	blockOffsets []int64
+	stateOffset  int64
+	unmarshaler  *detect.VersionedUnmarshaler
+}
+
+// NewReader reads the indices of an era file of the given size.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	stateSlot, stateOffsets, stateIndexOffset, err := readSlotIndex(r, size)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read state index")
+	}
+	if len(stateOffsets) != 1 || stateOffsets[0] == 0 {
+		return nil, errors.New("invalid state index")
+	}
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	if stateSlot%perEra != 0 {
+		return nil, errors.Errorf("state at slot %d is not at the start of an era", stateSlot)
+	}
+	er := &Reader{
+		r:           r,
+		era:         uint64(stateSlot / perEra),
+		stateOffset: stateOffsets[0],
+	}
+	if er.era > 0 {
+		er.startSlot, er.blockOffsets, _, err = readSlotIndex(r, stateIndexOffset)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read block index")
+		}
+		if er.startSlot != stateSlot-perEra || uint64(len(er.blockOffsets)) != uint64(perEra) {
+			return nil, errors.New("invalid block index")
+		}
+	}
+	enc, err := readCompressed(r, er.stateOffset, stateType)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read state")
+	}
+	if er.unmarshaler, err = detect.FromState(enc); err != nil {
+		return nil, errors.Wrap(err, "could not detect fork of state")
+	}
+	return er, nil
+}
+
+// Era of the file.
+func (r *Reader) Era() uint64 {
+	return r.era
+}
+
+// StartSlot of the blocks of the file.
+func (r *Reader) StartSlot() types.Slot {
+	return r.startSlot
+}
+
+// State at the first slot of the era.
+func (r *Reader) State() (state.BeaconState, error) {
+	enc, err := readCompressed(r.r, r.stateOffset, stateType)
+	if err != nil {
+		return nil, err
+	}
+	return r.unmarshaler.UnmarshalBeaconState(enc)
+}
+
+// Block at the given slot of the previous era, nil if the slot is empty.
+func (r *Reader) Block(slot types.Slot) (block.SignedBeaconBlock, error) {
+	if slot < r.startSlot || uint64(slot-r.startSlot) >= uint64(len(r.blockOffsets)) {
+		return nil, errors.Errorf("slot %d is not part of era %d", slot, r.era)
+	}
+	offset := r.blockOffsets[slot-r.startSlot]
+	if offset == 0 {
+		return nil, nil
+	}
+	enc, err := readCompressed(r.r, offset, blockType)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := r.unmarshaler.UnmarshalBeaconBlock(enc)
+	if err != nil {
+		return nil, err
+	}
+	if blk.Block().Slot() != slot {
+		return nil, errors.Errorf("block indexed at slot %d is at slot %d", slot, blk.Block().Slot())
+	}
+	return blk, nil
+}
+
+// readSlotIndex reads the slot index record ending at the given offset, returning its starting
+// slot, the absolute offsets of its records and its own offset.
+func readSlotIndex(r io.ReaderAt, end int64) (types.Slot, []int64, int64, error) {
+	if end < headerLength+24 {
+		return 0, nil, 0, errors.New("file too short")
+	}
+	buf := make([]byte, 8)
+	if _, err := r.ReadAt(buf, end-8); err != nil {
+		return 0, nil, 0, err
+	}
+	count := binary.LittleEndian.Uint64(buf)
+	length := 16 + 8*count
+	if count > uint64(end) || int64(length)+headerLength > end {
+		return 0, nil, 0, errors.Errorf("invalid slot index count %d", count)
+	}
+	indexOffset := end - int64(length) - headerLength
+	typ, data, err := readRecord(r, indexOffset)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	if typ != slotIndexType || uint64(len(data)) != length {
+		return 0, nil, 0, errors.New("invalid slot index record")
+	}
+	start := types.Slot(binary.LittleEndian.Uint64(data))
+	offsets := make([]int64, count)
+	for i := range offsets {
+		if rel := int64(binary.LittleEndian.Uint64(data[8+8*i:])); rel != 0 {
+			offsets[i] = indexOffset + rel
+		}
+	}
+	return start, offsets, indexOffset, nil
+}
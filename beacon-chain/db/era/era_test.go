@@ -0,0 +1,234 @@
+package era
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+type chain struct {
+	blocks map[types.Slot]block.SignedBeaconBlock
+	roots  map[types.Slot][32]byte
+	// States at the start of the eras, saved at the roots of the blocks at their slot.
+	states map[types.Slot]state.BeaconState
+}
+
+// makeChain saves a chain of blocks at the given increasing slots from genesis, the last of which
+// starts an era. The blocks starting the eras commit to states whose block roots are the
+// roots of the chain, but are otherwise not valid state transitions.
+func makeChain(t *testing.T, beaconDB db.Database, slots ...types.Slot) *chain {
+	ctx := context.Background()
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	c := &chain{
+		blocks: make(map[types.Slot]block.SignedBeaconBlock),
+		roots:  make(map[types.Slot][32]byte),
+		states: make(map[types.Slot]state.BeaconState),
+	}
+	genesis, _ := util.DeterministicGenesisState(t, 16)
+	blockRoots := make([][]byte, perEra)
+	var parentRoot [32]byte
+	last := slots[len(slots)-1]
+	next := 0
+	for slot := types.Slot(0); slot <= last; slot++ {
+		if slot > 0 {
+			blockRoots[(slot-1)%perEra] = bytesutil.SafeCopyBytes(parentRoot[:])
+		}
+		if slot != slots[next] {
+			continue
+		}
+		next++
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = bytesutil.SafeCopyBytes(parentRoot[:])
+		b.Block.Body.Graffiti = bytes.Repeat([]byte{byte(slot)}, 32)
+		var st state.BeaconState
+		if slot%perEra == 0 {
+			st = genesis.Copy()
+			require.NoError(t, st.SetSlot(slot))
+			roots := make([][]byte, perEra)
+			for i := range roots {
+				roots[i] = bytesutil.PadTo(bytesutil.SafeCopyBytes(blockRoots[i]), 32)
+			}
+			require.NoError(t, st.SetBlockRoots(roots))
+			bodyRoot, err := b.Block.Body.HashTreeRoot()
+			require.NoError(t, err)
+			require.NoError(t, st.SetLatestBlockHeader(&ethpb.BeaconBlockHeader{
+				Slot:       slot,
+				ParentRoot: bytesutil.SafeCopyBytes(parentRoot[:]),
+				StateRoot:  make([]byte, 32),
+				BodyRoot:   bodyRoot[:],
+			}))
+			stateRoot, err := st.HashTreeRoot(ctx)
+			require.NoError(t, err)
+			b.Block.StateRoot = stateRoot[:]
+		}
+		blk, err := wrapper.WrappedSignedBeaconBlock(b)
+		require.NoError(t, err)
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, blk))
+		if st != nil {
+			require.NoError(t, beaconDB.SaveState(ctx, st, root))
+			require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: root[:]}))
+			c.states[slot] = st
+		}
+		if slot == 0 {
+			require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, root))
+		}
+		c.blocks[slot] = blk
+		c.roots[slot] = root
+		parentRoot = root
+	}
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{
+		Epoch: types.Epoch(last / params.BeaconConfig().SlotsPerEpoch),
+		Root:  parentRoot[:],
+	}))
+	return c
+}
+
+func openReader(t *testing.T, path string) *Reader {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, f.Close())
+	})
+	info, err := f.Stat()
+	require.NoError(t, err)
+	r, err := NewReader(f, info.Size())
+	require.NoError(t, err)
+	return r
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	// Only one database can be open at once, so the source database is closed once exported.
+	src, err := kv.NewKVStore(ctx, t.TempDir(), &kv.Config{})
+	require.NoError(t, err)
+	c := makeChain(t, src, 0, 1, 2, 100, perEra-2, perEra, perEra+2, perEra+3, 2*perEra-1, 2*perEra)
+
+	dir := filepath.Join(t.TempDir(), "era")
+	_, err = Export(ctx, src, dir, 0, 3)
+	require.ErrorContains(t, "is not finalized", err)
+	paths, err := Export(ctx, src, dir, 0, 0)
+	require.NoError(t, err)
+	_, err = Import(ctx, src, dir)
+	require.ErrorIs(t, err, errNoOrigin)
+	require.NoError(t, src.Close())
+	require.Equal(t, 3, len(paths))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(entries), "temporary files left in the era directory")
+
+	for era, path := range paths {
+		assert.Equal(t, true, bytes.HasPrefix([]byte(filepath.Base(path)), []byte("mainnet-0000")), path)
+		r := openReader(t, path)
+		require.Equal(t, uint64(era), r.Era())
+		st, err := r.State()
+		require.NoError(t, err)
+		assert.Equal(t, perEra.Mul(uint64(era)), st.Slot())
+		if era == 0 {
+			continue
+		}
+		require.DeepSSZEqual(t, c.states[st.Slot()].InnerStateUnsafe(), st.InnerStateUnsafe())
+		for slot := r.StartSlot(); slot < st.Slot(); slot++ {
+			blk, err := r.Block(slot)
+			require.NoError(t, err)
+			want, ok := c.blocks[slot]
+			if !ok {
+				assert.Equal(t, nil, blk, "block at empty slot %d", slot)
+				continue
+			}
+			require.NotNil(t, blk, "block at slot %d", slot)
+			assert.DeepEqual(t, want.Proto(), blk.Proto())
+		}
+		_, err = r.Block(st.Slot())
+		require.ErrorContains(t, "is not part of era", err)
+	}
+
+	t.Run("checkpoint synced node", func(t *testing.T) {
+		dst := dbtest.SetupDB(t)
+		originSlot := 2 * perEra
+		stateEnc, err := c.states[originSlot].MarshalSSZ()
+		require.NoError(t, err)
+		blockEnc, err := c.blocks[originSlot].MarshalSSZ()
+		require.NoError(t, err)
+		require.NoError(t, dst.SaveOrigin(ctx, bytes.NewReader(stateEnc), bytes.NewReader(blockEnc)))
+
+		count, err := Import(ctx, dst, dir)
+		require.NoError(t, err)
+		assert.Equal(t, len(c.blocks)-1, count)
+		for slot, root := range c.roots {
+			blk, err := dst.Block(ctx, root)
+			require.NoError(t, err)
+			require.NotNil(t, blk, "block at slot %d", slot)
+			assert.DeepEqual(t, c.blocks[slot].Proto(), blk.Proto())
+		}
+		backfilled, err := dst.BackfillBlockRoot(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, c.roots[0], backfilled)
+		assert.Equal(t, true, dst.HasState(ctx, c.roots[perEra]))
+		genesis, err := dst.GenesisBlock(ctx)
+		require.NoError(t, err)
+		assert.DeepEqual(t, c.blocks[0].Proto(), genesis.Proto())
+
+		count, err = Import(ctx, dst, dir)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+	t.Run("missing era", func(t *testing.T) {
+		dst := dbtest.SetupDB(t)
+		originSlot := 2 * perEra
+		stateEnc, err := c.states[originSlot].MarshalSSZ()
+		require.NoError(t, err)
+		blockEnc, err := c.blocks[originSlot].MarshalSSZ()
+		require.NoError(t, err)
+		require.NoError(t, dst.SaveOrigin(ctx, bytes.NewReader(stateEnc), bytes.NewReader(blockEnc)))
+
+		gapDir := t.TempDir()
+		for _, path := range paths[:2] {
+			enc, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(gapDir, filepath.Base(path)), enc, 0600))
+		}
+		_, err = Import(ctx, dst, gapDir)
+		require.ErrorContains(t, "missing era files", err)
+	})
+}
+
+func TestWriter_Errors(t *testing.T) {
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	w, err := NewWriter(new(bytes.Buffer), 2)
+	require.NoError(t, err)
+
+	newBlock := func(slot types.Slot) block.SignedBeaconBlock {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		blk, err := wrapper.WrappedSignedBeaconBlock(b)
+		require.NoError(t, err)
+		return blk
+	}
+	require.ErrorContains(t, "is not part of era 2", w.WriteBlock(newBlock(perEra-1)))
+	require.ErrorContains(t, "is not part of era 2", w.WriteBlock(newBlock(2*perEra)))
+	require.NoError(t, w.WriteBlock(newBlock(perEra+1)))
+	require.ErrorContains(t, "written after block", w.WriteBlock(newBlock(perEra+1)))
+
+	st, _ := util.DeterministicGenesisState(t, 16)
+	require.ErrorContains(t, "expected 16384", w.Finish(st))
+}
@@ -0,0 +1,159 @@
+package era
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/sirupsen/logrus"
+)
+
+// Export writes the era files of the eras from `from` to `to` included to the given directory,
+// returning the paths of the files. Only the eras whose state is finalized can be exported, and a
+// `to` of zero exports up to the last one of them.
+func Export(ctx context.Context, beaconDB db.NoHeadAccessDatabase, dir string, from, to uint64) ([]string, error) {
+	cp, err := beaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	last := uint64(params.BeaconConfig().SlotsPerEpoch.Mul(uint64(cp.Epoch)) / perEra)
+	if to == 0 {
+		to = last
+	}
+	if to > last {
+		return nil, errors.Errorf("era %d is not finalized, the last finalized era is %d", to, last)
+	}
+	if from > to {
+		return nil, errors.Errorf("no eras to export from era %d to era %d", from, to)
+	}
+	if err := file.MkdirAll(dir); err != nil {
+		return nil, errors.Wrap(err, "could not create era directory")
+	}
+
+	gen := stategen.New(beaconDB)
+	paths := make([]string, 0, to-from+1)
+	for era := from; era <= to; era++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		path, count, err := exportEra(ctx, beaconDB, gen, dir, era)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not export era %d", era)
+		}
+		log.WithFields(logrus.Fields{
+			"era":    era,
+			"blocks": count,
+			"path":   path,
+		}).Info("Exported era")
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// exportEra writes the era file of the given era, returning its path and the number of blocks.
+func exportEra(ctx context.Context, beaconDB db.NoHeadAccessDatabase, gen *stategen.State, dir string, era uint64) (string, int, error) {
+	st, err := eraState(ctx, beaconDB, gen, params.BeaconConfig().SlotsPerHistoricalRoot.Mul(era))
+	if err != nil {
+		return "", 0, errors.Wrap(err, "could not get state")
+	}
+	if st == nil || st.IsNil() {
+		return "", 0, errors.New("state not found")
+	}
+
+	// The file is named after its state, so it is written to a temporary file first.
+	f, err := ioutil.TempFile(dir, "export-*.era.tmp")
+	if err != nil {
+		return "", 0, err
+	}
+	tmp := f.Name()
+	defer func() {
+		if tmp == "" {
+			return
+		}
+		if err := f.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+			log.WithError(err).Error("Could not close temporary era file")
+		}
+		if err := os.Remove(tmp); err != nil {
+			log.WithError(err).Error("Could not remove temporary era file")
+		}
+	}()
+	count, err := writeEra(ctx, beaconDB, f, era, st)
+	if err != nil {
+		return "", 0, err
+	}
+	if err := f.Close(); err != nil {
+		return "", 0, err
+	}
+	path := filepath.Join(dir, FileName(era, st))
+	if err := os.Rename(tmp, path); err != nil {
+		return "", 0, err
+	}
+	tmp = ""
+	return path, count, nil
+}
+
+// eraState returns the finalized state at the given slot, after the block at that slot if any.
+func eraState(ctx context.Context, beaconDB db.NoHeadAccessDatabase, gen *stategen.State, slot types.Slot) (state.BeaconState, error) {
+	_, roots, err := beaconDB.BlockRootsBySlot(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	for _, root := range roots {
+		if beaconDB.IsFinalizedBlock(ctx, root) {
+			return gen.StateByRoot(ctx, root)
+		}
+	}
+	return gen.StateBySlot(ctx, slot)
+}
+
+// writeEra writes the blocks of the era committed to by the state, then the state, returning the
+// number of blocks.
+func writeEra(ctx context.Context, beaconDB db.NoHeadAccessDatabase, f io.Writer, era uint64, st state.ReadOnlyBeaconState) (int, error) {
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	w, err := NewWriter(f, era)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	if era > 0 {
+		// The block roots of the state are the roots of the blocks of the previous era, the
+		// root of the latest block being repeated over empty slots.
+		roots := st.BlockRoots()
+		var prev []byte
+		for slot := perEra.Mul(era - 1); slot < perEra.Mul(era); slot++ {
+			root := roots[slot%perEra]
+			if bytes.Equal(root, prev) {
+				continue
+			}
+			prev = root
+			blk, err := beaconDB.Block(ctx, bytesutil.ToBytes32(root))
+			if err != nil {
+				return 0, errors.Wrapf(err, "could not get block at slot %d", slot)
+			}
+			if blk == nil || blk.IsNil() {
+				return 0, errors.Errorf("block %#x at slot %d is not in the database, export from a later era", root, slot)
+			}
+			// The first slots of the era may be empty, repeating a block of the era before.
+			if blk.Block().Slot() != slot {
+				continue
+			}
+			if err := w.WriteBlock(blk); err != nil {
+				return 0, err
+			}
+			count++
+		}
+	}
+	return count, w.Finish(st)
+}
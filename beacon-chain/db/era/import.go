@@ -0,0 +1,224 @@
+package era
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/sirupsen/logrus"
+)
+
+// Number of blocks saved to the database at once.
+const importBatchSize = 256
+
+var errNoOrigin = errors.New("era files can only be imported by a node started from a checkpoint")
+
+// Import fills in the history below the origin block of a node started from a checkpoint with the
+// blocks of the era files of the given directory, instead of backfilling them from peers. The
+// blocks are trusted as they form a chain of block roots ending at the parent of the lowest block
+// known to the node, and the lowest block imported is recorded as backfilled so that the backfill
+// service resumes below it. The states of the era files are saved when a block known to the node
+// commits to them. It returns the number of blocks imported.
+func Import(ctx context.Context, beaconDB db.NoHeadAccessDatabase, dir string) (int, error) {
+	lowestRoot, err := beaconDB.BackfillBlockRoot(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		lowestRoot, err = beaconDB.OriginBlockRoot(ctx)
+		if errors.Is(err, db.ErrNotFound) {
+			return 0, errNoOrigin
+		}
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get lowest block root")
+	}
+	lowest, err := beaconDB.Block(ctx, lowestRoot)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get lowest block")
+	}
+	if lowest == nil || lowest.IsNil() {
+		return 0, errors.Errorf("lowest block %#x not found", lowestRoot)
+	}
+
+	files, err := eraFiles(dir)
+	if err != nil {
+		return 0, err
+	}
+	imp := &importer{
+		db:         beaconDB,
+		parentRoot: bytesutil.ToBytes32(lowest.Block().ParentRoot()),
+		cursor:     lowest.Block().Slot(),
+	}
+	// The eras are imported from the highest one down, following the parent roots.
+	for i := len(files) - 1; i >= 0 && !imp.done(ctx); i-- {
+		if err := imp.importFile(ctx, files[i]); err != nil {
+			return imp.count, errors.Wrapf(err, "could not import %s", files[i])
+		}
+	}
+	if !imp.done(ctx) {
+		log.WithField("lowestSlot", imp.cursor).Warn("Era files do not reach genesis, the remaining blocks are backfilled from peers")
+	}
+	return imp.count, nil
+}
+
+// eraFiles lists the era files of the current network in the directory, sorted by era.
+func eraFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read era directory")
+	}
+	prefix := params.BeaconConfig().ConfigName + "-"
+	files := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), prefix) && strings.HasSuffix(e.Name(), ".era") {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	// The era number is zero padded, so the names sort by era.
+	sort.Strings(files)
+	return files, nil
+}
+
+type importer struct {
+	db db.NoHeadAccessDatabase
+	// Root of the parent of the lowest block imported, or known to the node.
+	parentRoot [32]byte
+	// Blocks below this slot have not been imported yet.
+	cursor types.Slot
+	count  int
+}
+
+// done returns whether the blocks imported reach genesis or blocks already known to the node.
+func (imp *importer) done(ctx context.Context) bool {
+	return imp.parentRoot == params.BeaconConfig().ZeroHash || imp.db.HasBlock(ctx, imp.parentRoot)
+}
+
+func (imp *importer) importFile(ctx context.Context, path string) error {
+	f, err := os.Open(path) // #nosec G304 -- the path is from the directory given by the operator.
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close era file")
+		}
+	}()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	r, err := NewReader(f, info.Size())
+	if err != nil {
+		return err
+	}
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	end := perEra.Mul(r.Era())
+	if r.Era() == 0 || r.StartSlot() >= imp.cursor {
+		return imp.saveState(ctx, r)
+	}
+	if end < imp.cursor {
+		return errors.Errorf("missing era files from era %d to slot %d", r.Era()+1, imp.cursor)
+	}
+
+	batch := make([]block.SignedBeaconBlock, 0, importBatchSize)
+	for slot := imp.cursor; slot > r.StartSlot() && !imp.done(ctx); slot-- {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		blk, err := r.Block(slot - 1)
+		if err != nil {
+			return err
+		}
+		if blk == nil {
+			continue
+		}
+		root, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not compute block root")
+		}
+		if root != imp.parentRoot {
+			return errors.Errorf("block at slot %d has root %#x, expected %#x", slot-1, root, imp.parentRoot)
+		}
+		batch = append(batch, blk)
+		imp.parentRoot = bytesutil.ToBytes32(blk.Block().ParentRoot())
+		if len(batch) == importBatchSize {
+			if err := imp.saveBatch(ctx, batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := imp.saveBatch(ctx, batch); err != nil {
+		return err
+	}
+	imp.cursor = r.StartSlot()
+	log.WithFields(logrus.Fields{
+		"era":         r.Era(),
+		"totalBlocks": imp.count,
+	}).Info("Imported era")
+	return imp.saveState(ctx, r)
+}
+
+// saveBatch saves the blocks, sorted by decreasing slot, and records the lowest one as backfilled.
+func (imp *importer) saveBatch(ctx context.Context, batch []block.SignedBeaconBlock) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	blks := make([]block.SignedBeaconBlock, len(batch))
+	for i, blk := range batch {
+		blks[len(batch)-1-i] = blk
+	}
+	if err := imp.db.SaveBlocks(ctx, blks); err != nil {
+		return errors.Wrap(err, "could not save blocks")
+	}
+	root, err := blks[0].Block().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute block root")
+	}
+	if err := imp.db.SaveBackfillBlockRoot(ctx, root); err != nil {
+		return errors.Wrap(err, "could not save backfill block root")
+	}
+	if blks[0].Block().Slot() == 0 {
+		if err := imp.db.SaveGenesisBlockRoot(ctx, root); err != nil {
+			return errors.Wrap(err, "could not save genesis block root")
+		}
+	}
+	imp.count += len(blks)
+	return nil
+}
+
+// saveState saves the state of the era file if the block at its slot is known to the node and
+// commits to it.
+func (imp *importer) saveState(ctx context.Context, r *Reader) error {
+	st, err := r.State()
+	if err != nil {
+		return errors.Wrap(err, "could not read state")
+	}
+	header := st.LatestBlockHeader()
+	if header.Slot != st.Slot() {
+		return nil
+	}
+	stateRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not compute state root")
+	}
+	header.StateRoot = stateRoot[:]
+	root, err := header.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute block root")
+	}
+	if !imp.db.HasBlock(ctx, root) || imp.db.HasState(ctx, root) {
+		return nil
+	}
+	if err := imp.db.SaveState(ctx, st, root); err != nil {
+		return errors.Wrap(err, "could not save state")
+	}
+	return imp.db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: st.Slot(), Root: root[:]})
+}
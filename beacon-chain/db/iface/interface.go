@@ -12,7 +12,11 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/container/trie"
 	"github.com/prysmaticlabs/prysm/monitoring/backup"
+	enginev1 "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
 )
@@ -38,6 +42,8 @@ type ReadOnlyDatabase interface {
 	StateSummary(ctx context.Context, blockRoot [32]byte) (*ethpb.StateSummary, error)
 	HasStateSummary(ctx context.Context, blockRoot [32]byte) bool
 	HighestSlotStatesBelow(ctx context.Context, slot types.Slot) ([]state.ReadOnlyBeaconState, error)
+	// Finalized validator indices by public key.
+	ValidatorIndices(ctx context.Context, pubkeys [][fieldparams.BLSPubkeyLength]byte) (map[[fieldparams.BLSPubkeyLength]byte]types.ValidatorIndex, error)
 	// Checkpoint operations.
 	JustifiedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
 	FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
@@ -45,13 +51,22 @@ type ReadOnlyDatabase interface {
 	HasArchivedPoint(ctx context.Context, slot types.Slot) bool
 	LastArchivedRoot(ctx context.Context) [32]byte
 	LastArchivedSlot(ctx context.Context) (types.Slot, error)
+	SlotsPerArchivedPoint(ctx context.Context) (types.Slot, error)
 	// Deposit contract related handlers.
 	DepositContractAddress(ctx context.Context) ([]byte, error)
 	// Powchain operations.
 	PowchainData(ctx context.Context) (*ethpb.ETH1ChainData, error)
+	DepositSnapshot(ctx context.Context) (*trie.DepositTreeSnapshot, error)
+	// Light client operations.
+	LightClientUpdate(ctx context.Context, period uint64) (*ethpbv2.LightClientUpdate, error)
+	LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) ([]*ethpbv2.LightClientUpdate, error)
 
 	// origin checkpoint sync support
 	OriginBlockRoot(ctx context.Context) ([32]byte, error)
+	BackfillBlockRoot(ctx context.Context) ([32]byte, error)
+	// Initial sync progress.
+	InitialSyncProgressRoot(ctx context.Context) ([32]byte, error)
+	InitialSyncPendingBlocks(ctx context.Context) ([]block.SignedBeaconBlock, error)
 }
 
 // NoHeadAccessDatabase defines a struct without access to chain head data.
@@ -63,14 +78,21 @@ type NoHeadAccessDatabase interface {
 	SaveBlock(ctx context.Context, block block.SignedBeaconBlock) error
 	SaveBlocks(ctx context.Context, blocks []block.SignedBeaconBlock) error
 	SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte) error
+	SaveBackfillBlockRoot(ctx context.Context, blockRoot [32]byte) error
+	SaveInitialSyncProgressRoot(ctx context.Context, blockRoot [32]byte) error
+	SaveInitialSyncPendingBlocks(ctx context.Context, blocks []block.SignedBeaconBlock) error
+	DeleteInitialSyncPendingBlocks(ctx context.Context, slot types.Slot) error
 	UpdateValidatedTips(ctx context.Context, newVals map[[32]byte]types.Slot) error
 	// State related methods.
 	SaveState(ctx context.Context, state state.ReadOnlyBeaconState, blockRoot [32]byte) error
 	SaveStates(ctx context.Context, states []state.ReadOnlyBeaconState, blockRoots [][32]byte) error
+	SaveStateDiff(ctx context.Context, state state.ReadOnlyBeaconState, blockRoot, baseRoot [32]byte) error
 	DeleteState(ctx context.Context, blockRoot [32]byte) error
 	DeleteStates(ctx context.Context, blockRoots [][32]byte) error
 	SaveStateSummary(ctx context.Context, summary *ethpb.StateSummary) error
 	SaveStateSummaries(ctx context.Context, summaries []*ethpb.StateSummary) error
+	SaveSlotsPerArchivedPoint(ctx context.Context, slots types.Slot) error
+	SaveValidatorIndices(ctx context.Context, state state.ReadOnlyBeaconState) error
 	// Checkpoint operations.
 	SaveJustifiedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
 	SaveFinalizedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
@@ -78,10 +100,59 @@ type NoHeadAccessDatabase interface {
 	SaveDepositContractAddress(ctx context.Context, addr common.Address) error
 	// Powchain operations.
 	SavePowchainData(ctx context.Context, data *ethpb.ETH1ChainData) error
+	SaveDepositSnapshot(ctx context.Context, snapshot *trie.DepositTreeSnapshot) error
+	// Light client operations.
+	SaveLightClientUpdate(ctx context.Context, period uint64, update *ethpbv2.LightClientUpdate) error
 	// Run any required database migrations.
 	RunMigrations(ctx context.Context) error
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint types.Slot) error
+	PruneFinalized(ctx context.Context, beforeSlot types.Slot, dryRun bool) (*PruneStats, error)
+	VerifyIntegrity(ctx context.Context, repair bool) (*IntegrityReport, error)
+}
+
+// PruneStats reports the objects deleted when pruning the database, or the
+// objects which would be deleted in a dry run.
+type PruneStats struct {
+	Blocks       int
+	States       int
+	Attestations int
+	// Bytes is the total size of the deleted keys and values.
+	Bytes uint64
+}
+
+// IntegrityReport reports the inconsistencies found when verifying the integrity of the database.
+type IntegrityReport struct {
+	// Numbers of blocks and states checked.
+	Blocks int
+	States int
+	// Roots of the blocks which could not be decoded.
+	CorruptBlocks [][32]byte
+	// Roots of the blocks whose parent block is not saved, other than the genesis, origin and
+	// backfill blocks.
+	MissingParents [][32]byte
+	// Roots of the genesis, origin and checkpoint blocks whose saved state could not be read, or
+	// does not have the state root of the block.
+	StateRootMismatches [][32]byte
+	// Numbers of states, and of state summaries and index entries, of blocks which are not saved.
+	OrphanedStates  int
+	OrphanedEntries int
+	// Whether the orphaned states and entries were deleted.
+	Repaired bool
+}
+
+// DiskUsage reports the disk space used by the database.
+type DiskUsage struct {
+	// Size of the database on disk, in bytes.
+	Size int64
+	// Bytes on disk which hold no live data, and are reclaimed by compaction.
+	Free int64
+}
+
+// PayloadBodiesFetcher fetches execution payload bodies from an execution node, to reconstruct
+// the execution payloads of the blocks saved blinded.
+type PayloadBodiesFetcher interface {
+	GetPayloadBodiesByHash(ctx context.Context, hashes []common.Hash) ([]*enginev1.ExecutionPayloadBodyV1, error)
 }
 
 // HeadAccessDatabase defines a struct with access to reading chain head data.
@@ -153,8 +224,15 @@ type SlasherDatabase interface {
 type Database interface {
 	io.Closer
 	backup.BackupExporter
+	backup.BackupStreamer
 	HeadAccessDatabase
 
 	DatabasePath() string
 	ClearDB() error
+	// DiskUsage reports the disk space used by the database.
+	DiskUsage() (*DiskUsage, error)
+	// Compact rewrites the database to reclaim the disk space of deleted data, without closing it.
+	Compact(ctx context.Context) error
+	// SetPayloadBodiesFetcher sets the fetcher of the execution payloads of blinded blocks.
+	SetPayloadBodiesFetcher(fetcher PayloadBodiesFetcher)
 }
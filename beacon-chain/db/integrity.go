@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// VerifyDatabase verifies the integrity of the beacon chain database of the data directory, deleting
+// the orphaned entries found with the repair flag. It fails if the database holds inconsistencies
+// which are left.
+func VerifyDatabase(cliCtx *cli.Context) error {
+	if cliCtx.Bool(cmd.DBRepairFlag.Name) && cliCtx.Bool(cmd.DBReadOnlyFlag.Name) {
+		return errors.Errorf("cannot repair a database opened with --%s", cmd.DBReadOnlyFlag.Name)
+	}
+	store, err := openExisting(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer closeStore(store)
+	report, err := VerifyIntegrity(cliCtx.Context, store, cliCtx.Bool(cmd.DBRepairFlag.Name))
+	if err != nil {
+		return err
+	}
+	if len(report.CorruptBlocks)+len(report.MissingParents)+len(report.StateRootMismatches) > 0 {
+		return errors.New("the database is inconsistent, the affected blocks need to be synced again")
+	}
+	if !report.Repaired && report.OrphanedStates+report.OrphanedEntries > 0 {
+		return errors.Errorf("the database holds orphaned entries, which --%s deletes", cmd.DBRepairFlag.Name)
+	}
+	return nil
+}
+
+// VerifyIntegrity verifies the integrity of the beacon chain database, deleting the orphaned entries
+// found with repair, and logs the inconsistencies found.
+func VerifyIntegrity(ctx context.Context, d NoHeadAccessDatabase, repair bool) (*IntegrityReport, error) {
+	log.Info("Verifying the integrity of the database")
+	report, err := d.VerifyIntegrity(ctx, repair)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not verify the integrity of the database")
+	}
+	for _, root := range report.CorruptBlocks {
+		log.WithField("root", fmt.Sprintf("%#x", root)).Error("Could not decode block")
+	}
+	for _, root := range report.MissingParents {
+		log.WithField("root", fmt.Sprintf("%#x", root)).Error("Parent of block is missing")
+	}
+	for _, root := range report.StateRootMismatches {
+		log.WithField("root", fmt.Sprintf("%#x", root)).Error("Saved state does not have the state root of its block")
+	}
+	fields := logrus.Fields{
+		"blocks":          report.Blocks,
+		"states":          report.States,
+		"orphanedStates":  report.OrphanedStates,
+		"orphanedEntries": report.OrphanedEntries,
+	}
+	switch {
+	case report.Repaired:
+		log.WithFields(fields).Warn("Deleted orphaned entries from the database")
+	case report.OrphanedStates+report.OrphanedEntries > 0:
+		log.WithFields(fields).Warn("Found orphaned entries in the database")
+	default:
+		log.WithFields(fields).Info("Verified the integrity of the database")
+	}
+	return report, nil
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"flag"
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/urfave/cli/v2"
+)
+
+func TestVerifyDatabase(t *testing.T) {
+	logHook := logTest.NewGlobal()
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	store, err := kv.NewKVStore(ctx, path.Join(dataDir, kv.BeaconNodeDbDirName), &kv.Config{})
+	require.NoError(t, err)
+	// A state summary of a block which is not saved.
+	require.NoError(t, store.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 1, Root: bytesutil.PadTo([]byte{'a'}, 32)}))
+	require.NoError(t, store.Close())
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, dataDir, "")
+	set.Bool(cmd.DBRepairFlag.Name, false, "")
+	set.Bool(cmd.DBReadOnlyFlag.Name, true, "")
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	require.ErrorContains(t, "orphaned entries", VerifyDatabase(cliCtx))
+	assert.LogsContain(t, logHook, "Found orphaned entries in the database")
+
+	// A database opened read-only cannot be repaired.
+	require.NoError(t, set.Set(cmd.DBRepairFlag.Name, "true"))
+	require.ErrorContains(t, "cannot repair", VerifyDatabase(cliCtx))
+	require.NoError(t, set.Set(cmd.DBReadOnlyFlag.Name, "false"))
+	require.NoError(t, VerifyDatabase(cliCtx))
+	assert.LogsContain(t, logHook, "Deleted orphaned entries from the database")
+	require.NoError(t, VerifyDatabase(cliCtx))
+	assert.LogsContain(t, logHook, "Verified the integrity of the database")
+}
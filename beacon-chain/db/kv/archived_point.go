@@ -4,8 +4,8 @@ import (
 	"context"
 
 	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -14,7 +14,7 @@ func (s *Store) LastArchivedSlot(ctx context.Context) (types.Slot, error) {
 	_, span := trace.StartSpan(ctx, "BeaconDB.LastArchivedSlot")
 	defer span.End()
 	var index types.Slot
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(stateSlotIndicesBucket)
 		b, _ := bkt.Cursor().Last()
 		index = bytesutil.BytesToSlotBigEndian(b)
@@ -30,7 +30,7 @@ func (s *Store) LastArchivedRoot(ctx context.Context) [32]byte {
 	defer span.End()
 
 	var blockRoot []byte
-	if err := s.db.View(func(tx *bolt.Tx) error {
+	if err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(stateSlotIndicesBucket)
 		_, blockRoot = bkt.Cursor().Last()
 		return nil
@@ -48,7 +48,7 @@ func (s *Store) ArchivedPointRoot(ctx context.Context, slot types.Slot) [32]byte
 	defer span.End()
 
 	var blockRoot []byte
-	if err := s.db.View(func(tx *bolt.Tx) error {
+	if err := s.db.View(func(tx backend.Tx) error {
 		bucket := tx.Bucket(stateSlotIndicesBucket)
 		blockRoot = bucket.Get(bytesutil.SlotToBytesBigEndian(slot))
 		return nil
@@ -64,7 +64,7 @@ func (s *Store) HasArchivedPoint(ctx context.Context, slot types.Slot) bool {
 	_, span := trace.StartSpan(ctx, "BeaconDB.HasArchivedPoint")
 	defer span.End()
 	var exists bool
-	if err := s.db.View(func(tx *bolt.Tx) error {
+	if err := s.db.View(func(tx backend.Tx) error {
 		iBucket := tx.Bucket(stateSlotIndicesBucket)
 		exists = iBucket.Get(bytesutil.SlotToBytesBigEndian(slot)) != nil
 		return nil
@@ -73,3 +73,29 @@ func (s *Store) HasArchivedPoint(ctx context.Context, slot types.Slot) bool {
 	}
 	return exists
 }
+
+// SlotsPerArchivedPoint returns the number of slots between the archived points saved in the DB,
+// or zero if it was never recorded.
+func (s *Store) SlotsPerArchivedPoint(ctx context.Context) (types.Slot, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SlotsPerArchivedPoint")
+	defer span.End()
+	var slots types.Slot
+	err := s.db.View(func(tx backend.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(slotsPerArchivedPointKey)
+		if enc == nil {
+			return nil
+		}
+		slots = bytesutil.BytesToSlotBigEndian(enc)
+		return nil
+	})
+	return slots, err
+}
+
+// SaveSlotsPerArchivedPoint records the number of slots between the archived points saved in the DB.
+func (s *Store) SaveSlotsPerArchivedPoint(ctx context.Context, slots types.Slot) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveSlotsPerArchivedPoint")
+	defer span.End()
+	return s.db.Update(func(tx backend.Tx) error {
+		return tx.Bucket(chainMetadataBucket).Put(slotsPerArchivedPointKey, bytesutil.SlotToBytesBigEndian(slots))
+	})
+}
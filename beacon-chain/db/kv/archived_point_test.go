@@ -49,3 +49,21 @@ func TestLastArchivedPoint_CanRetrieve(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, types.Slot(3), i, "Did not get correct index")
 }
+
+func TestSlotsPerArchivedPoint_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	slots, err := db.SlotsPerArchivedPoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(0), slots, "Should not have been saved")
+
+	require.NoError(t, db.SaveSlotsPerArchivedPoint(ctx, 2048))
+	slots, err = db.SlotsPerArchivedPoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(2048), slots)
+
+	require.NoError(t, db.SaveSlotsPerArchivedPoint(ctx, 8192))
+	slots, err = db.SlotsPerArchivedPoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(8192), slots)
+}
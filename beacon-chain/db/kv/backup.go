@@ -3,18 +3,25 @@ package kv
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"path"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
-	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
 	"github.com/prysmaticlabs/prysm/io/file"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
 const backupsDirectoryName = "backups"
 
-// Backup the database to the datadir backup directory.
+// The number of keys copied per transaction of a backup.
+const backupBatchSize = 1000
+
+// Backup the database to the datadir backup directory. Backups are written with the same backend
+// as the database, so a pebble database is backed up as a pebble directory.
 // Example for backup at slot 345: $DATADIR/backups/prysm_beacondb_at_slot_0000345.backup
 func (s *Store) Backup(ctx context.Context, outputDir string, permissionOverride bool) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.Backup")
@@ -41,19 +48,13 @@ func (s *Store) Backup(ctx context.Context, outputDir string, permissionOverride
 	if err := file.HandleBackupDir(backupsDir, permissionOverride); err != nil {
 		return err
 	}
-	backupPath := path.Join(backupsDir, fmt.Sprintf("prysm_beacondb_at_slot_%07d.backup", head.Block().Slot()))
-	log.WithField("backup", backupPath).Info("Writing backup database.")
+	backupName := fmt.Sprintf("prysm_beacondb_at_slot_%07d.backup", head.Block().Slot())
+	log.WithField("backup", backend.Path(s.db.Kind(), backupsDir, backupName)).Info("Writing backup database.")
 
-	copyDB, err := bolt.Open(
-		backupPath,
-		params.BeaconIoConfig().ReadWritePermissions,
-		&bolt.Options{NoSync: true, Timeout: params.BeaconIoConfig().BoltTimeout, FreelistType: bolt.FreelistMapType},
-	)
+	copyDB, err := backend.Open(s.db.Kind(), backupsDir, backupName, nil)
 	if err != nil {
 		return err
 	}
-	copyDB.AllocSize = boltAllocSize
-
 	defer func() {
 		if err := copyDB.Close(); err != nil {
 			log.WithError(err).Error("Failed to close backup database")
@@ -63,8 +64,8 @@ func (s *Store) Backup(ctx context.Context, outputDir string, permissionOverride
 	// bucket to use less memory usage when backing up.
 	var bucketKeys [][]byte
 	bucketMap := make(map[string][][]byte)
-	err = s.db.View(func(tx *bolt.Tx) error {
-		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+	err = s.db.View(func(tx backend.Tx) error {
+		return tx.ForEach(func(name []byte, b backend.Bucket) error {
 			newName := make([]byte, len(name))
 			copy(newName, name)
 			bucketKeys = append(bucketKeys, newName)
@@ -94,16 +95,28 @@ func (s *Store) Backup(ctx context.Context, outputDir string, permissionOverride
 	// handle those well.
 	for _, k := range bucketKeys {
 		log.Debugf("Copying bucket %s\n", k)
+		if err := copyDB.Update(func(tx backend.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(k)
+			return err
+		}); err != nil {
+			return err
+		}
 		innerKeys := bucketMap[string(k)]
-		for _, ik := range innerKeys {
-			err = s.db.View(func(tx *bolt.Tx) error {
+		for i := 0; i < len(innerKeys); i += backupBatchSize {
+			batch := innerKeys[i:]
+			if len(batch) > backupBatchSize {
+				batch = batch[:backupBatchSize]
+			}
+			err = s.db.View(func(tx backend.Tx) error {
 				bkt := tx.Bucket(k)
-				return copyDB.Update(func(tx2 *bolt.Tx) error {
-					b2, err := tx2.CreateBucketIfNotExists(k)
-					if err != nil {
-						return err
+				return copyDB.Update(func(tx2 backend.Tx) error {
+					b2 := tx2.Bucket(k)
+					for _, ik := range batch {
+						if err := b2.Put(ik, bkt.Get(ik)); err != nil {
+							return err
+						}
 					}
-					return b2.Put(ik, bkt.Get(ik))
+					return nil
 				})
 			})
 			if err != nil {
@@ -111,8 +124,47 @@ func (s *Store) Backup(ctx context.Context, outputDir string, permissionOverride
 			}
 		}
 	}
-	// Re-enable sync to allow bolt to fsync
-	// again.
-	copyDB.NoSync = false
 	return nil
 }
+
+// BackupTo writes a consistent snapshot of the database to the given writer, as a bolt database
+// file, and returns the number of bytes written. Writes to the database are not blocked meanwhile.
+// Only databases with the bolt backend can be streamed.
+func (s *Store) BackupTo(ctx context.Context, w io.Writer) (int64, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.BackupTo")
+	defer span.End()
+
+	if s.db.Kind() != backend.Bolt {
+		return 0, errors.Errorf("streaming backups are not supported by the %s backend", s.db.Kind())
+	}
+
+	// The snapshot is written to a temporary file first, so that the read transaction is not held
+	// for as long as the writer takes to consume it, which would block the growth of the database file.
+	f, err := ioutil.TempFile(s.databasePath, "backup-*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close temporary backup file")
+		}
+		if err := os.Remove(f.Name()); err != nil {
+			log.WithError(err).Error("Could not remove temporary backup file")
+		}
+	}()
+	err = s.db.View(func(tx backend.Tx) error {
+		wt, ok := tx.(io.WriterTo)
+		if !ok {
+			return errors.Errorf("streaming backups are not supported by the %s backend", s.db.Kind())
+		}
+		_, err := wt.WriteTo(f)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return io.Copy(w, f)
+}
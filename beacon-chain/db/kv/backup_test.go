@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
@@ -51,6 +52,44 @@ func TestStore_Backup(t *testing.T) {
 	require.Equal(t, true, backedDB.HasState(ctx, root))
 }
 
+func TestStore_Backup_Pebble(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewKVStore(ctx, t.TempDir(), &Config{Backend: backend.Pebble})
+	require.NoError(t, err, "Failed to instantiate DB")
+
+	head := util.NewBeaconBlock()
+	head.Block.Slot = 5000
+	require.NoError(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(head)))
+	root, err := head.Block.HashTreeRoot()
+	require.NoError(t, err)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, root))
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, root))
+
+	require.NoError(t, db.Backup(ctx, "", false))
+	_, err = db.BackupTo(ctx, ioutil.Discard)
+	require.ErrorContains(t, "not supported by the pebble backend", err)
+
+	// The backup is a pebble database, and no bolt file is written.
+	backupsPath := filepath.Join(db.databasePath, backupsDirectoryName)
+	backupName := "prysm_beacondb_at_slot_0005000.backup"
+	require.Equal(t, true, backend.Exists(backend.Pebble, backupsPath, backupName))
+	require.Equal(t, false, backend.Exists(backend.Bolt, backupsPath, backupName))
+	require.NoError(t, db.Close(), "Failed to close database")
+
+	require.NoError(t, os.Rename(
+		backend.Path(backend.Pebble, backupsPath, backupName),
+		KVStoreDatafilePath(backupsPath, backend.Pebble),
+	))
+	backedDB, err := NewKVStore(ctx, backupsPath, &Config{Backend: backend.Pebble})
+	require.NoError(t, err, "Failed to instantiate DB")
+	t.Cleanup(func() {
+		require.NoError(t, backedDB.Close(), "Failed to close database")
+	})
+	require.Equal(t, true, backedDB.HasState(ctx, root))
+}
+
 func TestStore_BackupMultipleBuckets(t *testing.T) {
 	db, err := NewKVStore(context.Background(), t.TempDir(), &Config{})
 	require.NoError(t, err, "Failed to instantiate DB")
@@ -105,3 +144,33 @@ func TestStore_BackupMultipleBuckets(t *testing.T) {
 		require.Equal(t, nState.Slot(), i)
 	}
 }
+
+func TestStore_BackupTo(t *testing.T) {
+	db, err := NewKVStore(context.Background(), t.TempDir(), &Config{})
+	require.NoError(t, err, "Failed to instantiate DB")
+	ctx := context.Background()
+
+	head := util.NewBeaconBlock()
+	head.Block.Slot = 5000
+	require.NoError(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(head)))
+	root, err := head.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	backupsPath := t.TempDir()
+	f, err := os.Create(filepath.Join(backupsPath, DatabaseFileName))
+	require.NoError(t, err)
+	n, err := db.BackupTo(ctx, f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	info, err := os.Stat(filepath.Join(backupsPath, DatabaseFileName))
+	require.NoError(t, err)
+	require.Equal(t, info.Size(), n)
+	require.NoError(t, db.Close(), "Failed to close database")
+
+	backedDB, err := NewKVStore(ctx, backupsPath, &Config{})
+	require.NoError(t, err, "Failed to instantiate DB")
+	t.Cleanup(func() {
+		require.NoError(t, backedDB.Close(), "Failed to close database")
+	})
+	require.Equal(t, true, backedDB.HasBlock(ctx, root))
+}
@@ -0,0 +1,151 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// Number of execution payload bodies requested at once from the execution node.
+const payloadBodiesBatchSize = 32
+
+// SetPayloadBodiesFetcher sets the fetcher of the execution payload bodies from the execution node,
+// which reconstructs the execution payloads of the blocks saved blinded when they are read.
+func (s *Store) SetPayloadBodiesFetcher(fetcher iface.PayloadBodiesFetcher) {
+	s.payloadBodiesLock.Lock()
+	defer s.payloadBodiesLock.Unlock()
+	s.payloadBodies = fetcher
+}
+
+func (s *Store) payloadBodiesFetcher() iface.PayloadBodiesFetcher {
+	s.payloadBodiesLock.RLock()
+	defer s.payloadBodiesLock.RUnlock()
+	return s.payloadBodies
+}
+
+// marshalBlindedBlock returns the encoding of the block without the transactions of its execution
+// payload, which the execution node keeps. It returns false if the block has no transactions to
+// drop, in which case it is saved in full.
+func marshalBlindedBlock(blk block.SignedBeaconBlock) ([]byte, bool, error) {
+	if blk.Version() != version.Bellatrix {
+		return nil, false, nil
+	}
+	pb, err := blk.PbBellatrixBlock()
+	if err != nil {
+		return nil, false, err
+	}
+	if pb.Block == nil || pb.Block.Body == nil || pb.Block.Body.ExecutionPayload == nil ||
+		len(pb.Block.Body.ExecutionPayload.Transactions) == 0 {
+		return nil, false, nil
+	}
+	blinded, ok := proto.Clone(pb).(*ethpb.SignedBeaconBlockBellatrix)
+	if !ok {
+		return nil, false, errors.New("could not clone block")
+	}
+	blinded.Block.Body.ExecutionPayload.Transactions = nil
+	obj, err := blinded.MarshalSSZ()
+	if err != nil {
+		return nil, false, err
+	}
+	return snappy.Encode(nil, append(blindedBellatrixKey, obj...)), true, nil
+}
+
+// blindBlock replaces the saved block of the given root by its blinded encoding.
+func blindBlock(tx backend.Tx, root []byte, blk block.SignedBeaconBlock) error {
+	enc, ok, err := marshalBlindedBlock(blk)
+	if err != nil || !ok {
+		return err
+	}
+	return tx.Bucket(blocksBucket).Put(root, enc)
+}
+
+// decodeBlocks decodes the encoded blocks of the given roots, reconstructing the execution payloads
+// of the blocks saved blinded with the transactions fetched from the execution node. The blocks whose
+// transactions the execution node cannot provide, such as when it is unreachable, are returned in
+// their blinded form, without transactions, so that reading blocks does not depend on the execution
+// node. It must not be called within a database transaction, as it may wait for the execution node.
+func (s *Store) decodeBlocks(ctx context.Context, roots [][32]byte, encs [][]byte) ([]block.SignedBeaconBlock, error) {
+	blks := make([]block.SignedBeaconBlock, len(encs))
+	blinded := make([]int, 0)
+	for i, enc := range encs {
+		blk, isBlinded, err := unmarshalBlock(ctx, enc)
+		if err != nil {
+			return nil, err
+		}
+		blks[i] = blk
+		if isBlinded {
+			blinded = append(blinded, i)
+		}
+	}
+	for start := 0; start < len(blinded); start += payloadBodiesBatchSize {
+		end := start + payloadBodiesBatchSize
+		if end > len(blinded) {
+			end = len(blinded)
+		}
+		if err := s.unblindBlocks(ctx, blks, roots, blinded[start:end]); err != nil {
+			return nil, err
+		}
+	}
+	return blks, nil
+}
+
+// unblindBlocks reconstructs the execution payloads of the blinded blocks at the given indices. The
+// blocks are left blinded, and not cached, if the execution node cannot provide their transactions.
+func (s *Store) unblindBlocks(ctx context.Context, blks []block.SignedBeaconBlock, roots [][32]byte, indices []int) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.unblindBlocks")
+	defer span.End()
+	fetcher := s.payloadBodiesFetcher()
+	if fetcher == nil {
+		log.WithField("blocks", len(indices)).Debug("No execution node to reconstruct the execution payloads of blinded blocks")
+		return nil
+	}
+	payloads := make([]*ethpb.SignedBeaconBlockBellatrix, len(indices))
+	hashes := make([]common.Hash, len(indices))
+	for i, idx := range indices {
+		pb, err := blks[idx].PbBellatrixBlock()
+		if err != nil {
+			return err
+		}
+		payloads[i] = pb
+		hashes[i] = common.BytesToHash(pb.Block.Body.ExecutionPayload.BlockHash)
+	}
+	bodies, err := fetcher.GetPayloadBodiesByHash(ctx, hashes)
+	if err != nil {
+		log.WithError(err).WithField("blocks", len(indices)).Warn("Could not get execution payload bodies, returning blinded blocks")
+		return nil
+	}
+	if len(bodies) != len(hashes) {
+		return errors.Errorf("got %d execution payload bodies, expected %d", len(bodies), len(hashes))
+	}
+	for i, idx := range indices {
+		if bodies[i] == nil {
+			log.WithFields(logrus.Fields{
+				"blockRoot":   fmt.Sprintf("%#x", roots[idx]),
+				"payloadHash": fmt.Sprintf("%#x", hashes[i]),
+			}).Warn("Execution payload is not available from the execution node, returning blinded block")
+			continue
+		}
+		payloads[i].Block.Body.ExecutionPayload.Transactions = bodies[i].Transactions
+		// The block root commits to the transactions, which checks the reconstructed payload.
+		root, err := blks[idx].Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		if root != roots[idx] {
+			return errors.Errorf("reconstructed block has root %#x, expected %#x", root, roots[idx])
+		}
+		s.blockCache.Set(string(roots[idx][:]), blks[idx], int64(blks[idx].SizeSSZ()))
+	}
+	return nil
+}
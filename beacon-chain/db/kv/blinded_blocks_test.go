@@ -0,0 +1,195 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/snappy"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+type mockPayloadBodiesFetcher struct {
+	bodies   map[common.Hash]*enginev1.ExecutionPayloadBodyV1
+	err      error
+	requests int
+}
+
+func (m *mockPayloadBodiesFetcher) GetPayloadBodiesByHash(_ context.Context, hashes []common.Hash) ([]*enginev1.ExecutionPayloadBodyV1, error) {
+	m.requests++
+	if m.err != nil {
+		return nil, m.err
+	}
+	bodies := make([]*enginev1.ExecutionPayloadBodyV1, len(hashes))
+	for i, h := range hashes {
+		bodies[i] = m.bodies[h]
+	}
+	return bodies, nil
+}
+
+// makeBellatrixBlocks returns a chain of bellatrix blocks with transactions from the given slot, and
+// a fetcher of their execution payload bodies.
+func makeBellatrixBlocks(t *testing.T, start, num uint64, parentRoot [32]byte) ([]block.SignedBeaconBlock, *mockPayloadBodiesFetcher) {
+	fetcher := &mockPayloadBodiesFetcher{bodies: make(map[common.Hash]*enginev1.ExecutionPayloadBodyV1)}
+	blks := make([]block.SignedBeaconBlock, num)
+	for i := range blks {
+		b := util.NewBeaconBlockBellatrix()
+		b.Block.Slot = types.Slot(start + uint64(i))
+		b.Block.ParentRoot = bytesutil.SafeCopyBytes(parentRoot[:])
+		payload := b.Block.Body.ExecutionPayload
+		payload.BlockHash = bytesutil.PadTo(append(bytesutil.Bytes8(start+uint64(i)), parentRoot[:8]...), 32)
+		payload.Transactions = [][]byte{make([]byte, 512), bytesutil.Bytes8(start + uint64(i))}
+		fetcher.bodies[common.BytesToHash(payload.BlockHash)] = &enginev1.ExecutionPayloadBodyV1{
+			Transactions: [][]byte{make([]byte, 512), bytesutil.Bytes8(start + uint64(i))},
+		}
+		blk, err := wrapper.WrappedBellatrixSignedBeaconBlock(b)
+		require.NoError(t, err)
+		blks[i] = blk
+		parentRoot, err = b.Block.HashTreeRoot()
+		require.NoError(t, err)
+	}
+	return blks, fetcher
+}
+
+func isBlindedInDB(t *testing.T, db *Store, root [32]byte) bool {
+	var blinded bool
+	require.NoError(t, db.db.View(func(tx backend.Tx) error {
+		enc, err := snappy.Decode(nil, tx.Bucket(blocksBucket).Get(root[:]))
+		require.NoError(t, err)
+		blinded = hasBlindedBellatrixKey(enc)
+		return nil
+	}))
+	return blinded
+}
+
+func TestStore_BlindedBlock(t *testing.T) {
+	ctx := context.Background()
+	blks, fetcher := makeBellatrixBlocks(t, 1, 1, genesisBlockRoot)
+	root, err := blks[0].Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	setup := func(t *testing.T) *Store {
+		db := setupDB(t)
+		require.NoError(t, db.SaveBlock(ctx, blks[0]))
+		require.NoError(t, db.db.Update(func(tx backend.Tx) error {
+			return blindBlock(tx, root[:], blks[0])
+		}))
+		// Drop the block saved in full from the cache.
+		db.blockCache.Del(string(root[:]))
+		db.blockCache.Wait()
+		return db
+	}
+
+	t.Run("reconstructed", func(t *testing.T) {
+		db := setup(t)
+		db.SetPayloadBodiesFetcher(fetcher)
+		assert.Equal(t, true, isBlindedInDB(t, db, root))
+		blk, err := db.Block(ctx, root)
+		require.NoError(t, err)
+		assert.DeepEqual(t, blks[0].Proto(), blk.Proto())
+		assert.Equal(t, 1, fetcher.requests)
+	})
+	// The block is returned blinded when its transactions cannot be fetched, and reconstructed once
+	// they can.
+	for name, unavailable := range map[string]*mockPayloadBodiesFetcher{
+		"no execution node":     nil,
+		"unreachable":           {err: errors.New("connection refused")},
+		"payload not available": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			db := setup(t)
+			if unavailable != nil {
+				db.SetPayloadBodiesFetcher(unavailable)
+			}
+			blk, err := db.Block(ctx, root)
+			require.NoError(t, err)
+			assert.Equal(t, blks[0].Block().Slot(), blk.Block().Slot())
+			payload, err := blk.Block().Body().ExecutionPayload()
+			require.NoError(t, err)
+			assert.Equal(t, 0, len(payload.Transactions))
+
+			db.SetPayloadBodiesFetcher(fetcher)
+			blk, err = db.Block(ctx, root)
+			require.NoError(t, err)
+			assert.DeepEqual(t, blks[0].Proto(), blk.Proto())
+		})
+	}
+	t.Run("wrong transactions", func(t *testing.T) {
+		db := setup(t)
+		wrong := &mockPayloadBodiesFetcher{bodies: make(map[common.Hash]*enginev1.ExecutionPayloadBodyV1)}
+		for h := range fetcher.bodies {
+			wrong.bodies[h] = &enginev1.ExecutionPayloadBodyV1{Transactions: [][]byte{{'a'}}}
+		}
+		db.SetPayloadBodiesFetcher(wrong)
+		_, err := db.Block(ctx, root)
+		require.ErrorContains(t, "reconstructed block has root", err)
+	})
+}
+
+func TestStore_BlindedBlocks_Finalized(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	db.blindedBlocks = true
+	ctx := context.Background()
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+
+	blks, fetcher := makeBellatrixBlocks(t, 1, slotsPerEpoch*2, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i, blk := range blks {
+		root, err := blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+		roots[i] = root
+		assert.Equal(t, false, isBlindedInDB(t, db, roots[i]))
+	}
+
+	// The finalized checkpoint is the block at the start slot of epoch 1.
+	cpRoot := roots[slotsPerEpoch-1]
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: types.Slot(slotsPerEpoch), Root: cpRoot[:]}))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: cpRoot[:]}))
+	for i := range blks {
+		assert.Equal(t, uint64(i) < slotsPerEpoch, isBlindedInDB(t, db, roots[i]), "block at index %d", i)
+	}
+
+	// Blocks backfilled below the finalized checkpoint are saved blinded.
+	forks, forkFetcher := makeBellatrixBlocks(t, 2, 1, [32]byte{'f'})
+	forkRoot, err := forks[0].Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlocks(ctx, forks))
+	assert.Equal(t, true, isBlindedInDB(t, db, forkRoot))
+	for h, body := range forkFetcher.bodies {
+		fetcher.bodies[h] = body
+	}
+
+	db.blockCache.Clear()
+	db.SetPayloadBodiesFetcher(fetcher)
+	got, gotRoots, err := db.Blocks(ctx, filters.NewFilter().SetStartSlot(1).SetEndSlot(types.Slot(slotsPerEpoch*2)))
+	require.NoError(t, err)
+	require.Equal(t, len(blks)+1, len(got))
+	for i, blk := range got {
+		want := blks[0]
+		for j, root := range roots {
+			if root == gotRoots[i] {
+				want = blks[j]
+			}
+		}
+		if gotRoots[i] == forkRoot {
+			want = forks[0]
+		}
+		assert.DeepEqual(t, want.Proto(), blk.Proto())
+	}
+	// The blinded blocks are reconstructed in batches.
+	assert.Equal(t, int(slotsPerEpoch+1+payloadBodiesBatchSize-1)/payloadBodiesBatchSize, fetcher.requests)
+}
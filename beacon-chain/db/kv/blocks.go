@@ -8,6 +8,7 @@ import (
 	"github.com/golang/snappy"
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/container/slice"
@@ -17,7 +18,6 @@ import (
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
 	"github.com/prysmaticlabs/prysm/runtime/version"
 	"github.com/prysmaticlabs/prysm/time/slots"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -32,18 +32,19 @@ func (s *Store) Block(ctx context.Context, blockRoot [32]byte) (block.SignedBeac
 	if v, ok := s.blockCache.Get(string(blockRoot[:])); v != nil && ok {
 		return v.(block.SignedBeaconBlock), nil
 	}
-	var blk block.SignedBeaconBlock
-	err := s.db.View(func(tx *bolt.Tx) error {
+	var enc []byte
+	if err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(blocksBucket)
-		enc := bkt.Get(blockRoot[:])
-		if enc == nil {
-			return nil
-		}
-		var err error
-		blk, err = unmarshalBlock(ctx, enc)
-		return err
-	})
-	return blk, err
+		enc = bytesutil.SafeCopyBytes(bkt.Get(blockRoot[:]))
+		return nil
+	}); err != nil || enc == nil {
+		return nil, err
+	}
+	blks, err := s.decodeBlocks(ctx, [][32]byte{blockRoot}, [][]byte{enc})
+	if err != nil {
+		return nil, err
+	}
+	return blks[0], nil
 }
 
 // OriginBlockRoot returns the value written to the db in SaveOriginBlockRoot
@@ -55,7 +56,7 @@ func (s *Store) OriginBlockRoot(ctx context.Context) ([32]byte, error) {
 	defer span.End()
 
 	var root [32]byte
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(blocksBucket)
 		rootSlice := bkt.Get(originBlockRootKey)
 		if rootSlice == nil {
@@ -68,36 +69,80 @@ func (s *Store) OriginBlockRoot(ctx context.Context) ([32]byte, error) {
 	return root, err
 }
 
+// BackfillBlockRoot returns the value written to the db in SaveBackfillBlockRoot.
+// This is the root of the lowest block of the chain history that has been filled in
+// below the origin block by the backfill service.
+func (s *Store) BackfillBlockRoot(ctx context.Context) ([32]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.BackfillBlockRoot")
+	defer span.End()
+
+	var root [32]byte
+	err := s.db.View(func(tx backend.Tx) error {
+		bkt := tx.Bucket(blocksBucket)
+		rootSlice := bkt.Get(backfillBlockRootKey)
+		if rootSlice == nil {
+			return ErrNotFoundBackfillBlockRoot
+		}
+		copy(root[:], rootSlice)
+		return nil
+	})
+
+	return root, err
+}
+
+// InitialSyncProgressRoot returns the value written to the db in SaveInitialSyncProgressRoot.
+// This is the root of the last block of the last batch verified and processed by initial sync.
+func (s *Store) InitialSyncProgressRoot(ctx context.Context) ([32]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.InitialSyncProgressRoot")
+	defer span.End()
+
+	var root [32]byte
+	err := s.db.View(func(tx backend.Tx) error {
+		bkt := tx.Bucket(blocksBucket)
+		rootSlice := bkt.Get(initialSyncProgressRootKey)
+		if rootSlice == nil {
+			return ErrNotFoundInitialSyncProgressRoot
+		}
+		copy(root[:], rootSlice)
+		return nil
+	})
+
+	return root, err
+}
+
 // HeadBlock returns the latest canonical block in the Ethereum Beacon Chain.
 func (s *Store) HeadBlock(ctx context.Context) (block.SignedBeaconBlock, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HeadBlock")
 	defer span.End()
-	var headBlock block.SignedBeaconBlock
-	err := s.db.View(func(tx *bolt.Tx) error {
+	var headRoot [32]byte
+	var enc []byte
+	if err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(blocksBucket)
-		headRoot := bkt.Get(headBlockRootKey)
-		if headRoot == nil {
+		root := bkt.Get(headBlockRootKey)
+		if root == nil {
 			return nil
 		}
-		enc := bkt.Get(headRoot)
-		if enc == nil {
-			return nil
-		}
-		var err error
-		headBlock, err = unmarshalBlock(ctx, enc)
-		return err
-	})
-	return headBlock, err
+		headRoot = bytesutil.ToBytes32(root)
+		enc = bytesutil.SafeCopyBytes(bkt.Get(root))
+		return nil
+	}); err != nil || enc == nil {
+		return nil, err
+	}
+	blks, err := s.decodeBlocks(ctx, [][32]byte{headRoot}, [][]byte{enc})
+	if err != nil {
+		return nil, err
+	}
+	return blks[0], nil
 }
 
 // Blocks retrieves a list of beacon blocks and its respective roots by filter criteria.
 func (s *Store) Blocks(ctx context.Context, f *filters.QueryFilter) ([]block.SignedBeaconBlock, [][32]byte, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.Blocks")
 	defer span.End()
-	blocks := make([]block.SignedBeaconBlock, 0)
 	blockRoots := make([][32]byte, 0)
+	encs := make([][]byte, 0)
 
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(blocksBucket)
 
 		keys, err := blockRootsByFilter(ctx, tx, f)
@@ -106,17 +151,19 @@ func (s *Store) Blocks(ctx context.Context, f *filters.QueryFilter) ([]block.Sig
 		}
 
 		for i := 0; i < len(keys); i++ {
-			encoded := bkt.Get(keys[i])
-			blk, err := unmarshalBlock(ctx, encoded)
-			if err != nil {
-				return err
-			}
-			blocks = append(blocks, blk)
+			encs = append(encs, bytesutil.SafeCopyBytes(bkt.Get(keys[i])))
 			blockRoots = append(blockRoots, bytesutil.ToBytes32(keys[i]))
 		}
 		return nil
 	})
-	return blocks, blockRoots, err
+	if err != nil {
+		return nil, nil, err
+	}
+	blocks, err := s.decodeBlocks(ctx, blockRoots, encs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return blocks, blockRoots, nil
 }
 
 // BlockRoots retrieves a list of beacon block roots by filter criteria. If the caller
@@ -128,7 +175,7 @@ func (s *Store) BlockRoots(ctx context.Context, f *filters.QueryFilter) ([][32]b
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.BlockRoots")
 	defer span.End()
 	blockRoots := make([][32]byte, 0)
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		keys, err := blockRootsByFilter(ctx, tx, f)
 		if err != nil {
 			return err
@@ -153,7 +200,7 @@ func (s *Store) HasBlock(ctx context.Context, blockRoot [32]byte) bool {
 		return true
 	}
 	exists := false
-	if err := s.db.View(func(tx *bolt.Tx) error {
+	if err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(blocksBucket)
 		exists = bkt.Get(blockRoot[:]) != nil
 		return nil
@@ -167,23 +214,27 @@ func (s *Store) HasBlock(ctx context.Context, blockRoot [32]byte) bool {
 func (s *Store) BlocksBySlot(ctx context.Context, slot types.Slot) (bool, []block.SignedBeaconBlock, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.BlocksBySlot")
 	defer span.End()
-	blocks := make([]block.SignedBeaconBlock, 0)
+	blockRoots := make([][32]byte, 0)
+	encs := make([][]byte, 0)
 
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(blocksBucket)
 
 		keys := blockRootsBySlot(ctx, tx, slot)
 		for i := 0; i < len(keys); i++ {
-			encoded := bkt.Get(keys[i])
-			blk, err := unmarshalBlock(ctx, encoded)
-			if err != nil {
-				return err
-			}
-			blocks = append(blocks, blk)
+			encs = append(encs, bytesutil.SafeCopyBytes(bkt.Get(keys[i])))
+			blockRoots = append(blockRoots, bytesutil.ToBytes32(keys[i]))
 		}
 		return nil
 	})
-	return len(blocks) > 0, blocks, err
+	if err != nil {
+		return false, nil, err
+	}
+	blocks, err := s.decodeBlocks(ctx, blockRoots, encs)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(blocks) > 0, blocks, nil
 }
 
 // BlockRootsBySlot retrieves a list of beacon block roots by slot
@@ -191,7 +242,7 @@ func (s *Store) BlockRootsBySlot(ctx context.Context, slot types.Slot) (bool, []
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.BlockRootsBySlot")
 	defer span.End()
 	blockRoots := make([][32]byte, 0)
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		keys := blockRootsBySlot(ctx, tx, slot)
 		for i := 0; i < len(keys); i++ {
 			blockRoots = append(blockRoots, bytesutil.ToBytes32(keys[i]))
@@ -215,7 +266,7 @@ func (s *Store) DeleteBlock(ctx context.Context, root [32]byte) error {
 		return errDeleteFinalized
 	}
 
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx backend.Tx) error {
 		bkt := tx.Bucket(finalizedBlockRootsIndexBucket)
 		if b := bkt.Get(root[:]); b != nil {
 			return errDeleteFinalized
@@ -256,21 +307,44 @@ func (s *Store) SaveBlocks(ctx context.Context, blocks []block.SignedBeaconBlock
 	blockRoots := make([][]byte, len(blocks))
 	encodedBlocks := make([][]byte, len(blocks))
 	indicesForBlocks := make([]map[string][]byte, len(blocks))
-	for i, blk := range blocks {
-		blockRoot, err := blk.Block().HashTreeRoot()
+	// Blocks backfilled below the finalized checkpoint are saved blinded right away, as they are
+	// not walked when the finalized checkpoint is updated.
+	var finalizedSlot types.Slot
+	if s.blindedBlocks {
+		cp, err := s.FinalizedCheckpoint(ctx)
 		if err != nil {
 			return err
 		}
-		enc, err := marshalBlock(ctx, blk)
+		finalizedSlot, err = slots.EpochStart(cp.Epoch)
 		if err != nil {
 			return err
 		}
+	}
+	for i, blk := range blocks {
+		blockRoot, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		var enc []byte
+		blinded := false
+		if blk.Block().Slot() < finalizedSlot {
+			enc, blinded, err = marshalBlindedBlock(blk)
+			if err != nil {
+				return err
+			}
+		}
+		if !blinded {
+			enc, err = marshalBlock(ctx, blk)
+			if err != nil {
+				return err
+			}
+		}
 		blockRoots[i] = blockRoot[:]
 		encodedBlocks[i] = enc
 		indicesByBucket := createBlockIndicesFromBlock(ctx, blk.Block())
 		indicesForBlocks[i] = indicesByBucket
 	}
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx backend.Tx) error {
 		bkt := tx.Bucket(blocksBucket)
 		for i, blk := range blocks {
 			if existingBlock := bkt.Get(blockRoots[i]); existingBlock != nil {
@@ -292,7 +366,7 @@ func (s *Store) SaveBlocks(ctx context.Context, blocks []block.SignedBeaconBlock
 func (s *Store) SaveHeadBlockRoot(ctx context.Context, blockRoot [32]byte) error {
 	_, span := trace.StartSpan(ctx, "BeaconDB.SaveHeadBlockRoot")
 	defer span.End()
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx backend.Tx) error {
 		hasStateSummary := s.hasStateSummaryBytes(tx, blockRoot)
 		hasStateInDB := tx.Bucket(stateBucket).Get(blockRoot[:]) != nil
 		if !(hasStateInDB || hasStateSummary) {
@@ -308,26 +382,29 @@ func (s *Store) SaveHeadBlockRoot(ctx context.Context, blockRoot [32]byte) error
 func (s *Store) GenesisBlock(ctx context.Context) (block.SignedBeaconBlock, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.GenesisBlock")
 	defer span.End()
-	var blk block.SignedBeaconBlock
-	err := s.db.View(func(tx *bolt.Tx) error {
+	var genesisRoot [32]byte
+	var enc []byte
+	if err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(blocksBucket)
 		root := bkt.Get(genesisBlockRootKey)
-		enc := bkt.Get(root)
-		if enc == nil {
-			return nil
-		}
-		var err error
-		blk, err = unmarshalBlock(ctx, enc)
-		return err
-	})
-	return blk, err
+		genesisRoot = bytesutil.ToBytes32(root)
+		enc = bytesutil.SafeCopyBytes(bkt.Get(root))
+		return nil
+	}); err != nil || enc == nil {
+		return nil, err
+	}
+	blks, err := s.decodeBlocks(ctx, [][32]byte{genesisRoot}, [][]byte{enc})
+	if err != nil {
+		return nil, err
+	}
+	return blks[0], nil
 }
 
 // SaveGenesisBlockRoot to the db.
 func (s *Store) SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte) error {
 	_, span := trace.StartSpan(ctx, "BeaconDB.SaveGenesisBlockRoot")
 	defer span.End()
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx backend.Tx) error {
 		bucket := tx.Bucket(blocksBucket)
 		return bucket.Put(genesisBlockRootKey, blockRoot[:])
 	})
@@ -340,19 +417,43 @@ func (s *Store) SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte) er
 func (s *Store) SaveOriginBlockRoot(ctx context.Context, blockRoot [32]byte) error {
 	_, span := trace.StartSpan(ctx, "BeaconDB.SaveOriginBlockRoot")
 	defer span.End()
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx backend.Tx) error {
 		bucket := tx.Bucket(blocksBucket)
 		return bucket.Put(originBlockRootKey, blockRoot[:])
 	})
 }
 
+// SaveBackfillBlockRoot is used to keep track of the progress of backfilling the chain history
+// below the origin block. It should be the root of the lowest block saved by backfill, so that
+// backfill can resume from there after a restart.
+func (s *Store) SaveBackfillBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveBackfillBlockRoot")
+	defer span.End()
+	return s.db.Update(func(tx backend.Tx) error {
+		bucket := tx.Bucket(blocksBucket)
+		return bucket.Put(backfillBlockRootKey, blockRoot[:])
+	})
+}
+
+// SaveInitialSyncProgressRoot is used to keep track of the progress of initial sync. It should be
+// the root of the last block of a batch which has been verified, processed and saved, so that a
+// node restarted in the middle of initial sync can resume from there.
+func (s *Store) SaveInitialSyncProgressRoot(ctx context.Context, blockRoot [32]byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveInitialSyncProgressRoot")
+	defer span.End()
+	return s.db.Update(func(tx backend.Tx) error {
+		bucket := tx.Bucket(blocksBucket)
+		return bucket.Put(initialSyncProgressRootKey, blockRoot[:])
+	})
+}
+
 // HighestSlotBlocksBelow returns the block with the highest slot below the input slot from the db.
 func (s *Store) HighestSlotBlocksBelow(ctx context.Context, slot types.Slot) ([]block.SignedBeaconBlock, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HighestSlotBlocksBelow")
 	defer span.End()
 
 	var best []byte
-	if err := s.db.View(func(tx *bolt.Tx) error {
+	if err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(blockSlotIndicesBucket)
 		// Iterate through the index, which is in byte sorted order.
 		c := bkt.Cursor()
@@ -393,7 +494,7 @@ func (s *Store) HighestSlotBlocksBelow(ctx context.Context, slot types.Slot) ([]
 }
 
 // blockRootsByFilter retrieves the block roots given the filter criteria.
-func blockRootsByFilter(ctx context.Context, tx *bolt.Tx, f *filters.QueryFilter) ([][]byte, error) {
+func blockRootsByFilter(ctx context.Context, tx backend.Tx, f *filters.QueryFilter) ([][]byte, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.blockRootsByFilter")
 	defer span.End()
 
@@ -454,7 +555,7 @@ func blockRootsByFilter(ctx context.Context, tx *bolt.Tx, f *filters.QueryFilter
 // However, if step is one, the implemented logic won’t skip half of the slots in the range.
 func blockRootsBySlotRange(
 	ctx context.Context,
-	bkt *bolt.Bucket,
+	bkt backend.Bucket,
 	startSlotEncoded, endSlotEncoded, startEpochEncoded, endEpochEncoded, slotStepEncoded interface{},
 ) ([][]byte, error) {
 	_, span := trace.StartSpan(ctx, "BeaconDB.blockRootsBySlotRange")
@@ -521,7 +622,7 @@ func blockRootsBySlotRange(
 }
 
 // blockRootsBySlot retrieves the block roots by slot
-func blockRootsBySlot(ctx context.Context, tx *bolt.Tx, slot types.Slot) [][]byte {
+func blockRootsBySlot(ctx context.Context, tx backend.Tx, slot types.Slot) [][]byte {
 	_, span := trace.StartSpan(ctx, "BeaconDB.blockRootsBySlot")
 	defer span.End()
 
@@ -596,11 +697,13 @@ func createBlockIndicesFromFilters(ctx context.Context, f *filters.QueryFilter)
 }
 
 // unmarshal block from marshaled proto beacon block bytes to versioned beacon block struct type.
-func unmarshalBlock(_ context.Context, enc []byte) (block.SignedBeaconBlock, error) {
+// It also returns whether the block was saved blinded, in which case the transactions of its
+// execution payload are missing.
+func unmarshalBlock(_ context.Context, enc []byte) (block.SignedBeaconBlock, bool, error) {
 	var err error
 	enc, err = snappy.Decode(nil, enc)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	switch {
 	case hasAltairKey(enc):
@@ -608,24 +711,34 @@ func unmarshalBlock(_ context.Context, enc []byte) (block.SignedBeaconBlock, err
 		rawBlock := &ethpb.SignedBeaconBlockAltair{}
 		err := rawBlock.UnmarshalSSZ(enc[len(altairKey):])
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		return wrapper.WrappedAltairSignedBeaconBlock(rawBlock)
+		blk, err := wrapper.WrappedAltairSignedBeaconBlock(rawBlock)
+		return blk, false, err
 	case hasBellatrixKey(enc):
 		rawBlock := &ethpb.SignedBeaconBlockBellatrix{}
 		err := rawBlock.UnmarshalSSZ(enc[len(bellatrixKey):])
 		if err != nil {
-			return nil, err
+			return nil, false, err
+		}
+		blk, err := wrapper.WrappedBellatrixSignedBeaconBlock(rawBlock)
+		return blk, false, err
+	case hasBlindedBellatrixKey(enc):
+		rawBlock := &ethpb.SignedBeaconBlockBellatrix{}
+		err := rawBlock.UnmarshalSSZ(enc[len(blindedBellatrixKey):])
+		if err != nil {
+			return nil, false, err
 		}
-		return wrapper.WrappedBellatrixSignedBeaconBlock(rawBlock)
+		blk, err := wrapper.WrappedBellatrixSignedBeaconBlock(rawBlock)
+		return blk, true, err
 	default:
 		// Marshal block bytes to phase 0 beacon block.
 		rawBlock := &ethpb.SignedBeaconBlock{}
 		err = rawBlock.UnmarshalSSZ(enc)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		return wrapper.WrappedPhase0SignedBeaconBlock(rawBlock), nil
+		return wrapper.WrappedPhase0SignedBeaconBlock(rawBlock), false, nil
 	}
 }
 
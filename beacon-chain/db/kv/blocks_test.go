@@ -216,6 +216,32 @@ func TestStore_GenesisBlock(t *testing.T) {
 	assert.Equal(t, true, proto.Equal(genesisBlock, retrievedBlock.Proto()), "Wanted: %v, received: %v", genesisBlock, retrievedBlock)
 }
 
+func TestStore_BackfillBlockRoot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	_, err := db.BackfillBlockRoot(ctx)
+	require.ErrorIs(t, err, ErrNotFoundBackfillBlockRoot)
+
+	root := bytesutil.ToBytes32([]byte{'a'})
+	require.NoError(t, db.SaveBackfillBlockRoot(ctx, root))
+	got, err := db.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, root, got)
+}
+
+func TestStore_InitialSyncProgressRoot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	_, err := db.InitialSyncProgressRoot(ctx)
+	require.ErrorIs(t, err, ErrNotFoundInitialSyncProgressRoot)
+
+	root := bytesutil.ToBytes32([]byte{'a'})
+	require.NoError(t, db.SaveInitialSyncProgressRoot(ctx, root))
+	got, err := db.InitialSyncProgressRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, root, got)
+}
+
 func TestStore_BlocksCRUD_NoCache(t *testing.T) {
 	for _, tt := range blockTests {
 		t.Run(tt.name, func(t *testing.T) {
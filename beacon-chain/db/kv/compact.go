@@ -0,0 +1,29 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"go.opencensus.io/trace"
+)
+
+// DefaultCompactionMinFreeRatio is the default minimum ratio of free space to the size of the database
+// for a scheduled compaction to run.
+const DefaultCompactionMinFreeRatio = 0.25
+
+// DiskUsage reports the disk space used by the database, and how much of it compaction reclaims.
+func (s *Store) DiskUsage() (*iface.DiskUsage, error) {
+	st, err := s.db.Stats()
+	if err != nil {
+		return nil, err
+	}
+	return &iface.DiskUsage{Size: st.Size, Free: st.Free}, nil
+}
+
+// Compact rewrites the database to reclaim the disk space of deleted data, such as pruned blocks
+// and states. The database stays open, and transactions carry on during the compaction.
+func (s *Store) Compact(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.Compact")
+	defer span.End()
+	return s.db.Compact(ctx)
+}
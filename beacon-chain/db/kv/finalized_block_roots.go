@@ -5,12 +5,12 @@ import (
 	"context"
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -37,7 +37,7 @@ var containerFinalizedButNotCanonical = []byte("recent block needs reindexing to
 //
 // This method ensures that all blocks from the current finalized epoch are considered "final" while
 // maintaining only canonical and finalized blocks older than the current finalized epoch.
-func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, checkpoint *ethpb.Checkpoint) error {
+func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx backend.Tx, checkpoint *ethpb.Checkpoint) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.updateFinalizedBlockRoots")
 	defer span.End()
 
@@ -46,6 +46,7 @@ func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 	root := checkpoint.Root
 	var previousRoot []byte
 	genesisRoot := tx.Bucket(blocksBucket).Get(genesisBlockRootKey)
+	originRoot := tx.Bucket(blocksBucket).Get(originBlockRootKey)
 
 	// De-index recent finalized block roots, to be re-indexed.
 	previousFinalizedCheckpoint := &ethpb.Checkpoint{}
@@ -72,21 +73,33 @@ func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 	}
 
 	// Walk up the ancestry chain until we reach a block root present in the finalized block roots
-	// index bucket or genesis block root.
+	// index bucket, the genesis block root or the origin block root.
 	for {
 		if bytes.Equal(root, genesisRoot) {
 			break
 		}
 
-		signedBlock, err := s.Block(ctx, bytesutil.ToBytes32(root))
-		if err != nil {
-			tracing.AnnotateError(span, err)
-			return err
+		// The block is read within the transaction, without reconstructing the payload of a blinded
+		// block, as only its parent root is needed.
+		var signedBlock block.SignedBeaconBlock
+		var blinded bool
+		if enc := tx.Bucket(blocksBucket).Get(root); enc != nil {
+			signedBlock, blinded, err = unmarshalBlock(ctx, enc)
+			if err != nil {
+				tracing.AnnotateError(span, err)
+				return err
+			}
 		}
 		if err := helpers.BeaconBlockIsNil(signedBlock); err != nil {
 			tracing.AnnotateError(span, err)
 			return err
 		}
+		if s.blindedBlocks && !blinded {
+			if err := blindBlock(tx, root, signedBlock); err != nil {
+				tracing.AnnotateError(span, err)
+				return err
+			}
+		}
 		block := signedBlock.Block()
 
 		container := &ethpb.FinalizedBlockRootContainer{
@@ -123,6 +136,10 @@ func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 			}
 			break
 		}
+		// The ancestors of the checkpoint sync origin block may not be in the database.
+		if originRoot != nil && bytes.Equal(root, originRoot) {
+			break
+		}
 		previousRoot = root
 		root = block.ParentRoot()
 	}
@@ -163,7 +180,7 @@ func (s *Store) IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool {
 	defer span.End()
 
 	var exists bool
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		exists = tx.Bucket(finalizedBlockRootsIndexBucket).Get(blockRoot[:]) != nil
 		// Check genesis block root.
 		if !exists {
@@ -185,8 +202,9 @@ func (s *Store) FinalizedChildBlock(ctx context.Context, blockRoot [32]byte) (bl
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.FinalizedChildBlock")
 	defer span.End()
 
-	var blk block.SignedBeaconBlock
-	err := s.db.View(func(tx *bolt.Tx) error {
+	var childRoot [32]byte
+	var enc []byte
+	err := s.db.View(func(tx backend.Tx) error {
 		blkBytes := tx.Bucket(finalizedBlockRootsIndexBucket).Get(blockRoot[:])
 		if blkBytes == nil {
 			return nil
@@ -199,14 +217,18 @@ func (s *Store) FinalizedChildBlock(ctx context.Context, blockRoot [32]byte) (bl
 			tracing.AnnotateError(span, err)
 			return err
 		}
-		enc := tx.Bucket(blocksBucket).Get(ctr.ChildRoot)
-		if enc == nil {
-			return nil
-		}
-		var err error
-		blk, err = unmarshalBlock(ctx, enc)
-		return err
+		childRoot = bytesutil.ToBytes32(ctr.ChildRoot)
+		enc = bytesutil.SafeCopyBytes(tx.Bucket(blocksBucket).Get(ctr.ChildRoot))
+		return nil
 	})
-	tracing.AnnotateError(span, err)
-	return blk, err
+	if err != nil || enc == nil {
+		tracing.AnnotateError(span, err)
+		return nil, err
+	}
+	blks, err := s.decodeBlocks(ctx, [][32]byte{childRoot}, [][]byte{enc})
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return nil, err
+	}
+	return blks[0], nil
 }
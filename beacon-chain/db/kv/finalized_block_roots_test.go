@@ -68,6 +68,34 @@ func TestStore_IsFinalizedBlockGenesis(t *testing.T) {
 	assert.Equal(t, true, db.IsFinalizedBlock(ctx, root), "Finalized genesis block doesn't exist in db")
 }
 
+func TestStore_IsFinalizedBlock_OriginWithoutHistory(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	ctx := context.Background()
+
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+
+	// Only the blocks from the checkpoint sync origin onwards are in the database.
+	blks := makeBlocks(t, 0, slotsPerEpoch*3, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks[slotsPerEpoch:]))
+	originRoot, err := blks[slotsPerEpoch].Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveOriginBlockRoot(ctx, originRoot))
+
+	root, err := blks[slotsPerEpoch*2].Block().HashTreeRoot()
+	require.NoError(t, err)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, root))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: root[:]}))
+
+	for i := slotsPerEpoch; i <= slotsPerEpoch*2; i++ {
+		root, err := blks[i].Block().HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, true, db.IsFinalizedBlock(ctx, root), "Block at index %d was not considered finalized in the index", i)
+	}
+}
+
 // This test scenario is to test a specific edge case where the finalized block root is not part of
 // the finalized and canonical chain.
 //
@@ -0,0 +1,85 @@
+package kv
+
+import (
+	"context"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"go.opencensus.io/trace"
+)
+
+// SaveInitialSyncPendingBlocks saves blocks fetched by initial sync which are waiting to be processed,
+// so that a node restarted in the middle of initial sync does not request them again. They are keyed
+// by slot and root, and are not indexed nor visible to the other block getters.
+func (s *Store) SaveInitialSyncPendingBlocks(ctx context.Context, blocks []block.SignedBeaconBlock) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveInitialSyncPendingBlocks")
+	defer span.End()
+
+	keys := make([][]byte, len(blocks))
+	encodedBlocks := make([][]byte, len(blocks))
+	for i, blk := range blocks {
+		blockRoot, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		enc, err := marshalBlock(ctx, blk)
+		if err != nil {
+			return err
+		}
+		keys[i] = append(bytesutil.SlotToBytesBigEndian(blk.Block().Slot()), blockRoot[:]...)
+		encodedBlocks[i] = enc
+	}
+	return s.db.Update(func(tx backend.Tx) error {
+		bkt := tx.Bucket(initialSyncPendingBlocksBucket)
+		for i := range keys {
+			if err := bkt.Put(keys[i], encodedBlocks[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// InitialSyncPendingBlocks returns the blocks saved in SaveInitialSyncPendingBlocks, in increasing
+// slot order.
+func (s *Store) InitialSyncPendingBlocks(ctx context.Context) ([]block.SignedBeaconBlock, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.InitialSyncPendingBlocks")
+	defer span.End()
+
+	blocks := make([]block.SignedBeaconBlock, 0)
+	err := s.db.View(func(tx backend.Tx) error {
+		return tx.Bucket(initialSyncPendingBlocksBucket).ForEach(func(_, v []byte) error {
+			blk, _, err := unmarshalBlock(ctx, v)
+			if err != nil {
+				return err
+			}
+			blocks = append(blocks, blk)
+			return nil
+		})
+	})
+	return blocks, err
+}
+
+// DeleteInitialSyncPendingBlocks deletes the pending blocks of initial sync up to the given slot,
+// once they have been processed.
+func (s *Store) DeleteInitialSyncPendingBlocks(ctx context.Context, slot types.Slot) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteInitialSyncPendingBlocks")
+	defer span.End()
+
+	return s.db.Update(func(tx backend.Tx) error {
+		bkt := tx.Bucket(initialSyncPendingBlocksBucket)
+		keys := make([][]byte, 0)
+		c := bkt.Cursor()
+		for k, _ := c.First(); k != nil && bytesutil.BytesToSlotBigEndian(k[:8]) <= slot; k, _ = c.Next() {
+			keys = append(keys, k)
+		}
+		for _, k := range keys {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,51 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestStore_InitialSyncPendingBlocks(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	blks, err := db.InitialSyncPendingBlocks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(blks))
+
+	saved := make([]block.SignedBeaconBlock, 0)
+	for _, slot := range []types.Slot{3, 1, 2, 256} {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		saved = append(saved, wrapper.WrappedPhase0SignedBeaconBlock(b))
+	}
+	require.NoError(t, db.SaveInitialSyncPendingBlocks(ctx, saved))
+	blks, err = db.InitialSyncPendingBlocks(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(saved), len(blks))
+	for i, slot := range []types.Slot{1, 2, 3, 256} {
+		assert.Equal(t, slot, blks[i].Block().Slot())
+	}
+	// Pending blocks are not saved as regular blocks.
+	root, err := saved[0].Block().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, false, db.HasBlock(ctx, root))
+
+	require.NoError(t, db.DeleteInitialSyncPendingBlocks(ctx, 2))
+	blks, err = db.InitialSyncPendingBlocks(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(blks))
+	assert.Equal(t, types.Slot(3), blks[0].Block().Slot())
+	assert.Equal(t, types.Slot(256), blks[1].Block().Slot())
+
+	require.NoError(t, db.DeleteInitialSyncPendingBlocks(ctx, 256))
+	blks, err = db.InitialSyncPendingBlocks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(blks))
+}
@@ -0,0 +1,304 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"go.opencensus.io/trace"
+)
+
+// An orphaned entry, either the value at a key of a bucket or, in an index bucket, one of the roots
+// of the values at a key.
+type orphanedEntry struct {
+	bucket []byte
+	key    []byte
+	root   []byte
+}
+
+// The block of the genesis, origin or a checkpoint, whose state root is checked against its state.
+type checkpointBlock struct {
+	root      [32]byte
+	slot      types.Slot
+	stateRoot [32]byte
+}
+
+// VerifyIntegrity walks the blocks and states of the database, checking that the parent of every
+// block is saved, except below the genesis, origin and backfill blocks and the pruning cutoff, and
+// that the states saved at the genesis, origin, justified and finalized blocks have the state roots
+// of the blocks. The states, state summaries and index entries of blocks which are not saved are
+// reported as orphaned, and deleted with repair. Blocks and states which are inconsistent are only
+// reported, they need to be synced again.
+func (s *Store) VerifyIntegrity(ctx context.Context, repair bool) (*iface.IntegrityReport, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.VerifyIntegrity")
+	defer span.End()
+
+	if repair && s.readOnly {
+		return nil, backend.ErrReadOnly
+	}
+	// The cached state summaries are checked along with the saved ones. Nothing is cached in a
+	// database opened read-only.
+	if !s.readOnly {
+		if err := s.saveCachedStateSummariesDB(ctx); err != nil {
+			return nil, err
+		}
+	}
+	report := &iface.IntegrityReport{}
+	var checkpoints []*checkpointBlock
+	var states []*pruneEntry
+	var orphans []*orphanedEntry
+	err := s.db.View(func(tx backend.Tx) error {
+		roots, err := checkpointRoots(ctx, tx)
+		if err != nil {
+			return err
+		}
+		checkpoints, err = verifyBlocks(ctx, tx, roots, report)
+		if err != nil {
+			return err
+		}
+		states, orphans = orphanedEntries(tx, report)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range checkpoints {
+		ok, err := s.hasStateRoot(ctx, c)
+		if err != nil {
+			log.WithError(err).WithField("root", fmt.Sprintf("%#x", c.root)).Error("Could not read checkpoint state")
+		}
+		if !ok {
+			report.StateRootMismatches = append(report.StateRootMismatches, c.root)
+		}
+	}
+	report.OrphanedStates, report.OrphanedEntries = len(states), len(orphans)
+	if !repair || len(states)+len(orphans) == 0 {
+		return report, nil
+	}
+
+	if err := s.deleteInBatches(states, func(tx backend.Tx, e *pruneEntry) error {
+		if err := deleteStateDiff(ctx, tx, e.root); err != nil {
+			return err
+		}
+		for _, bkt := range [][]byte{stateBucket, stateDiffBucket, blockRootValidatorHashesBucket} {
+			if err := tx.Bucket(bkt).Delete(e.root[:]); err != nil {
+				return err
+			}
+		}
+		if e.indexKey == nil {
+			return nil
+		}
+		return deleteValueForIndices(ctx, map[string][]byte{string(stateSlotIndicesBucket): e.indexKey}, e.root[:], tx)
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not delete orphaned states")
+	}
+	for start := 0; start < len(orphans); start += pruneBatchSize {
+		end := start + pruneBatchSize
+		if end > len(orphans) {
+			end = len(orphans)
+		}
+		if err := s.db.Update(func(tx backend.Tx) error {
+			for _, e := range orphans[start:end] {
+				if e.root == nil {
+					if err := tx.Bucket(e.bucket).Delete(e.key); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := deleteValueForIndices(ctx, map[string][]byte{string(e.bucket): e.key}, e.root, tx); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return nil, errors.Wrap(err, "could not delete orphaned entries")
+		}
+	}
+	report.Repaired = true
+	return report, nil
+}
+
+// checkpointRoots returns the roots of the genesis, origin, justified and finalized blocks.
+func checkpointRoots(ctx context.Context, tx backend.Tx) (map[[32]byte]bool, error) {
+	roots := make(map[[32]byte]bool)
+	blkBkt := tx.Bucket(blocksBucket)
+	for _, key := range [][]byte{genesisBlockRootKey, originBlockRootKey} {
+		if root := blkBkt.Get(key); root != nil {
+			roots[bytesutil.ToBytes32(root)] = true
+		}
+	}
+	chkBkt := tx.Bucket(checkpointBucket)
+	for _, key := range [][]byte{finalizedCheckpointKey, justifiedCheckpointKey} {
+		enc := chkBkt.Get(key)
+		if enc == nil {
+			continue
+		}
+		checkpoint := &ethpb.Checkpoint{}
+		if err := decode(ctx, enc, checkpoint); err != nil {
+			return nil, err
+		}
+		roots[bytesutil.ToBytes32(checkpoint.Root)] = true
+	}
+	return roots, nil
+}
+
+// verifyBlocks checks that every block can be decoded and that its parent is saved, and returns the
+// checkpoint blocks of the given roots which have a saved state.
+func verifyBlocks(ctx context.Context, tx backend.Tx, roots map[[32]byte]bool, report *iface.IntegrityReport) ([]*checkpointBlock, error) {
+	blkBkt := tx.Bucket(blocksBucket)
+	// The chain may start above genesis with a checkpoint sync, at the origin or backfilled blocks.
+	unparented := make(map[[32]byte]bool)
+	for _, key := range [][]byte{genesisBlockRootKey, originBlockRootKey, backfillBlockRootKey} {
+		if root := blkBkt.Get(key); root != nil {
+			unparented[bytesutil.ToBytes32(root)] = true
+		}
+	}
+	// The parents of the blocks kept below the pruning cutoff, and of the first block above it, are
+	// pruned.
+	var unparentedUpTo types.Slot
+	if enc := tx.Bucket(chainMetadataBucket).Get(prunedSlotKey); enc != nil {
+		unparentedUpTo = bytesutil.BytesToSlotBigEndian(enc)
+		if k, _ := tx.Bucket(blockSlotIndicesBucket).Cursor().Seek(enc); k != nil {
+			unparentedUpTo = bytesutil.BytesToSlotBigEndian(k)
+		}
+	}
+	checkpoints := make([]*checkpointBlock, 0, len(roots))
+	err := blkBkt.ForEach(func(k, v []byte) error {
+		// The bucket also holds the genesis, origin, backfill and head block roots.
+		if len(k) != 32 {
+			return nil
+		}
+		report.Blocks++
+		root := bytesutil.ToBytes32(k)
+		blk, _, err := unmarshalBlock(ctx, v)
+		if err != nil || blk.IsNil() {
+			report.CorruptBlocks = append(report.CorruptBlocks, root)
+			return nil
+		}
+		if blk.Block().Slot() > unparentedUpTo && !unparented[root] && blkBkt.Get(blk.Block().ParentRoot()) == nil {
+			report.MissingParents = append(report.MissingParents, root)
+		}
+		if roots[root] && (tx.Bucket(stateBucket).Get(k) != nil || tx.Bucket(stateDiffBucket).Get(k) != nil) {
+			checkpoints = append(checkpoints, &checkpointBlock{
+				root:      root,
+				slot:      blk.Block().Slot(),
+				stateRoot: bytesutil.ToBytes32(blk.Block().StateRoot()),
+			})
+		}
+		return nil
+	})
+	return checkpoints, err
+}
+
+// orphanedEntries returns the states, and the state summaries and index entries, of the blocks
+// which are not saved, along with the index entries of the states and state diffs which are not
+// saved. The states which the diffs of saved blocks are based on are kept.
+func orphanedEntries(tx backend.Tx, report *iface.IntegrityReport) ([]*pruneEntry, []*orphanedEntry) {
+	blkBkt, stateBkt, diffBkt := tx.Bucket(blocksBucket), tx.Bucket(stateBucket), tx.Bucket(stateDiffBucket)
+	hasState := func(root []byte) bool {
+		return stateBkt.Get(root) != nil || diffBkt.Get(root) != nil
+	}
+
+	states := make([]*pruneEntry, 0)
+	for _, bkt := range []backend.Bucket{stateBkt, diffBkt} {
+		_ = bkt.ForEach(func(k, _ []byte) error {
+			report.States++
+			if blkBkt.Get(k) == nil {
+				states = append(states, &pruneEntry{root: bytesutil.ToBytes32(k)})
+			}
+			return nil
+		})
+	}
+	states = keepStateDiffBases(tx, states)
+	orphanedStates := make(map[[32]byte]*pruneEntry, len(states))
+	for _, e := range states {
+		orphanedStates[e.root] = e
+	}
+
+	orphans := make([]*orphanedEntry, 0)
+	// The state summaries and the finalized index are keyed by block root, the validator hashes by
+	// the block root of their state.
+	keyed := []struct {
+		bucket []byte
+		saved  func(root []byte) bool
+	}{
+		{stateSummaryBucket, func(root []byte) bool { return blkBkt.Get(root) != nil }},
+		{finalizedBlockRootsIndexBucket, func(root []byte) bool { return blkBkt.Get(root) != nil }},
+		{blockRootValidatorHashesBucket, func(root []byte) bool {
+			_, orphaned := orphanedStates[bytesutil.ToBytes32(root)]
+			return orphaned || hasState(root)
+		}},
+	}
+	for _, b := range keyed {
+		_ = tx.Bucket(b.bucket).ForEach(func(k, _ []byte) error {
+			// The finalized index also holds the previous finalized checkpoint.
+			if len(k) == 32 && !b.saved(k) {
+				orphans = append(orphans, &orphanedEntry{bucket: b.bucket, key: bytesutil.SafeCopyBytes(k)})
+			}
+			return nil
+		})
+	}
+
+	// The slot and parent root indices hold the roots of the blocks at each key, the state slot
+	// index the roots of the states.
+	indices := []struct {
+		bucket []byte
+		saved  func(root []byte) bool
+	}{
+		{blockSlotIndicesBucket, func(root []byte) bool { return blkBkt.Get(root) != nil }},
+		{blockParentRootIndicesBucket, func(root []byte) bool { return blkBkt.Get(root) != nil }},
+		{stateSlotIndicesBucket, hasState},
+		{stateDiffBaseRootIndicesBucket, func(root []byte) bool { return diffBkt.Get(root) != nil }},
+	}
+	for _, b := range indices {
+		_ = tx.Bucket(b.bucket).ForEach(func(k, v []byte) error {
+			for i := 0; i+32 <= len(v); i += 32 {
+				root := v[i : i+32]
+				if e, ok := orphanedStates[bytesutil.ToBytes32(root)]; ok && bytes.Equal(b.bucket, stateSlotIndicesBucket) {
+					// Deleted along with the orphaned state.
+					e.indexKey = bytesutil.SafeCopyBytes(k)
+					continue
+				}
+				if _, ok := orphanedStates[bytesutil.ToBytes32(root)]; ok && bytes.Equal(b.bucket, stateDiffBaseRootIndicesBucket) {
+					// Deleted along with the orphaned state diff.
+					continue
+				}
+				if !b.saved(root) {
+					orphans = append(orphans, &orphanedEntry{
+						bucket: b.bucket,
+						key:    bytesutil.SafeCopyBytes(k),
+						root:   bytesutil.SafeCopyBytes(root),
+					})
+				}
+			}
+			return nil
+		})
+	}
+	return states, orphans
+}
+
+// hasStateRoot returns whether the saved state of the checkpoint block has the state root of the
+// block. States advanced past the slot of the block are not checked.
+func (s *Store) hasStateRoot(ctx context.Context, c *checkpointBlock) (bool, error) {
+	st, err := s.State(ctx, c.root)
+	if err != nil {
+		return false, err
+	}
+	if st == nil || st.IsNil() {
+		return false, errors.New("nil state")
+	}
+	if st.Slot() != c.slot {
+		return true, nil
+	}
+	root, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return false, err
+	}
+	return root == c.stateRoot, nil
+}
@@ -0,0 +1,90 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestStore_VerifyIntegrity(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	// A genesis block and state with matching state roots.
+	genesisState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	stateRoot, err := genesisState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := util.NewBeaconBlock()
+	genesis.Block.StateRoot = stateRoot[:]
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(genesis)))
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisRoot))
+	require.NoError(t, db.SaveState(ctx, genesisState, genesisRoot))
+
+	blks := makeBlocks(t, 0, 4, genesisRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i, blk := range blks {
+		roots[i], err = blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+	}
+	// The finalized state does not have the state root of its block.
+	finalizedState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, finalizedState.SetSlot(2))
+	require.NoError(t, db.SaveState(ctx, finalizedState, roots[1]))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Root: roots[1][:]}))
+
+	// A block whose parent is missing.
+	unparented := makeBlocks(t, 10, 1, [32]byte{'x'})
+	require.NoError(t, db.SaveBlocks(ctx, unparented))
+	unparentedRoot, err := unparented[0].Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	// A state and a summary without blocks, and the slot and parent indices of a deleted block.
+	orphanedState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, orphanedState.SetSlot(3))
+	require.NoError(t, db.SaveState(ctx, orphanedState, [32]byte{'o'}))
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 5, Root: bytesutil.PadTo([]byte{'s'}, 32)}))
+	require.NoError(t, db.db.Update(func(tx backend.Tx) error {
+		return tx.Bucket(blocksBucket).Delete(roots[3][:])
+	}))
+
+	report, err := db.VerifyIntegrity(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, 5, report.Blocks)
+	assert.Equal(t, 3, report.States)
+	assert.Equal(t, 0, len(report.CorruptBlocks))
+	assert.DeepEqual(t, [][32]byte{unparentedRoot}, report.MissingParents)
+	assert.DeepEqual(t, [][32]byte{roots[1]}, report.StateRootMismatches)
+	assert.Equal(t, 1, report.OrphanedStates)
+	assert.Equal(t, 4, report.OrphanedEntries)
+	assert.Equal(t, false, report.Repaired)
+	assert.Equal(t, true, db.HasState(ctx, [32]byte{'o'}))
+
+	report, err = db.VerifyIntegrity(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, true, report.Repaired)
+	assert.Equal(t, false, db.HasState(ctx, [32]byte{'o'}))
+	assert.Equal(t, false, db.HasStateSummary(ctx, [32]byte{'s'}))
+
+	// Only the inconsistencies which cannot be repaired are left.
+	report, err = db.VerifyIntegrity(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.States)
+	assert.Equal(t, 0, report.OrphanedStates)
+	assert.Equal(t, 0, report.OrphanedEntries)
+	assert.Equal(t, false, report.Repaired)
+	assert.DeepEqual(t, [][32]byte{unparentedRoot}, report.MissingParents)
+	assert.DeepEqual(t, [][32]byte{roots[1]}, report.StateRootMismatches)
+}
@@ -16,3 +16,10 @@ func hasBellatrixKey(enc []byte) bool {
 	}
 	return bytes.Equal(enc[:len(bellatrixKey)], bellatrixKey)
 }
+
+func hasBlindedBellatrixKey(enc []byte) bool {
+	if len(blindedBellatrixKey) >= len(enc) {
+		return false
+	}
+	return bytes.Equal(enc[:len(blindedBellatrixKey)], blindedBellatrixKey)
+}
@@ -1,22 +1,20 @@
-// Package kv defines a bolt-db, key-value store implementation
-// of the Database interface defined by a Prysm beacon node.
+// Package kv defines a key-value store implementation of the Database
+// interface defined by a Prysm beacon node, backed by bolt or pebble.
 package kv
 
 import (
 	"context"
 	"os"
-	"path"
+	"sync"
 	"time"
 
 	"github.com/dgraph-io/ristretto"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	prombolt "github.com/prysmaticlabs/prombbolt"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
-	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/io/file"
-	bolt "go.etcd.io/bbolt"
 )
 
 var _ iface.Database = (*Store)(nil)
@@ -31,8 +29,6 @@ const (
 	BeaconNodeDbDirName = "beaconchaindata"
 	// DatabaseFileName is the name of the beacon node database.
 	DatabaseFileName = "beaconchain.db"
-
-	boltAllocSize = 8 * 1024 * 1024
 	// The size of hash length in bytes
 	hashLength = 32
 )
@@ -68,27 +64,38 @@ var blockedBuckets = [][]byte{
 	finalizedBlockRootsIndexBucket,
 }
 
-// Config for the bolt db kv store.
+// Config for the kv store.
 type Config struct {
 	InitialMMapSize int
+	// Key-value backend of the store, bolt by default.
+	Backend backend.Kind
+	// Save finalized blocks without the transactions of their execution payload, which are
+	// fetched from the execution node when the blocks are read.
+	BlindedBlocks bool
+	// Open an existing database without writing to it, all writes failing with backend.ErrReadOnly.
+	ReadOnly bool
 }
 
 // Store defines an implementation of the Prysm Database interface
-// using BoltDB as the underlying persistent kv-store for Ethereum Beacon Nodes.
+// using BoltDB or Pebble as the underlying persistent kv-store for Ethereum Beacon Nodes.
 type Store struct {
-	db                  *bolt.DB
+	db                  backend.DB
 	databasePath        string
 	blockCache          *ristretto.Cache
 	validatorEntryCache *ristretto.Cache
 	stateSummaryCache   *stateSummaryCache
+	blindedBlocks       bool
+	readOnly            bool
+	payloadBodies       iface.PayloadBodiesFetcher
+	payloadBodiesLock   sync.RWMutex
 	ctx                 context.Context
 }
 
 // KVStoreDatafilePath is the canonical construction of a full
-// database file path from the directory path, so that code outside
-// this package can find the full path in a consistent way.
-func KVStoreDatafilePath(dirPath string) string {
-	return path.Join(dirPath, DatabaseFileName)
+// database file path from the directory path and backend, so that code
+// outside this package can find the full path in a consistent way.
+func KVStoreDatafilePath(dirPath string, kind backend.Kind) string {
+	return backend.Path(kind, dirPath, DatabaseFileName)
 }
 
 // NewKVStore initializes a new boltDB key-value store at the directory
@@ -99,32 +106,44 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 	if err != nil {
 		return nil, err
 	}
-	if !hasDir {
+	if !hasDir && !config.ReadOnly {
 		if err := file.MkdirAll(dirPath); err != nil {
 			return nil, err
 		}
 	}
-	datafile := KVStoreDatafilePath(dirPath)
+	kind := config.Backend
+	if kind == "" {
+		kind = backend.Bolt
+	}
+	for _, other := range []backend.Kind{backend.Bolt, backend.Pebble} {
+		if other == kind || !backend.Exists(other, dirPath, DatabaseFileName) {
+			continue
+		}
+		if !backend.Exists(kind, dirPath, DatabaseFileName) {
+			return nil, errors.Errorf("found a %s database in %s, run with the %s backend or migrate the database to the %s backend", other, dirPath, other, kind)
+		}
+		log.Warnf("Found an unused %s database at %s, it can be removed", other, KVStoreDatafilePath(dirPath, other))
+	}
+	datafile := KVStoreDatafilePath(dirPath, kind)
 	start := time.Now()
-	log.Infof("Opening Bolt DB at %s", datafile)
-	boltDB, err := bolt.Open(
-		datafile,
-		params.BeaconIoConfig().ReadWritePermissions,
-		&bolt.Options{
-			Timeout:         1 * time.Second,
-			InitialMmapSize: config.InitialMMapSize,
-		},
-	)
+	if config.ReadOnly {
+		log.Infof("Opening %s DB at %s read-only", kind, datafile)
+	} else {
+		log.Infof("Opening %s DB at %s", kind, datafile)
+	}
+	kvDB, err := backend.Open(kind, dirPath, DatabaseFileName, &backend.Options{
+		InitialMMapSize: config.InitialMMapSize,
+		ReadOnly:        config.ReadOnly,
+	})
 	if err != nil {
-		log.WithField("elapsed", time.Since(start)).Error("Failed to open Bolt DB")
-		if errors.Is(err, bolt.ErrTimeout) {
+		log.WithField("elapsed", time.Since(start)).Errorf("Failed to open %s DB", kind)
+		if errors.Is(err, backend.ErrTimeout) {
 			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
 		}
 		return nil, err
 	}
-	log.WithField("elapsed", time.Since(start)).Info("Opened Bolt DB")
+	log.WithField("elapsed", time.Since(start)).Infof("Opened %s DB", kind)
 
-	boltDB.AllocSize = boltAllocSize
 	start = time.Now()
 	log.Infof("Creating block cache...")
 	blockCache, err := ristretto.NewCache(&ristretto.Config{
@@ -152,76 +171,101 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 	log.WithField("elapsed", time.Since(start)).Info("Created validator cache")
 
 	kv := &Store{
-		db:                  boltDB,
+		db:                  kvDB,
 		databasePath:        dirPath,
 		blockCache:          blockCache,
 		validatorEntryCache: validatorCache,
 		stateSummaryCache:   newStateSummaryCache(),
+		blindedBlocks:       config.BlindedBlocks,
+		readOnly:            config.ReadOnly,
 		ctx:                 ctx,
 	}
+	if config.ReadOnly {
+		if err := kv.db.View(func(tx backend.Tx) error {
+			return hasBuckets(tx, storeBuckets...)
+		}); err != nil {
+			if closeErr := kv.db.Close(); closeErr != nil {
+				log.WithError(closeErr).Error("Could not close database")
+			}
+			return nil, err
+		}
+		return kv, prometheus.Register(createCollector(kv.db))
+	}
 	start = time.Now()
 	log.Infof("Updating DB and creating buckets...")
-	if err := kv.db.Update(func(tx *bolt.Tx) error {
-		return createBuckets(
-			tx,
-			attestationsBucket,
-			blocksBucket,
-			stateBucket,
-			proposerSlashingsBucket,
-			attesterSlashingsBucket,
-			voluntaryExitsBucket,
-			chainMetadataBucket,
-			checkpointBucket,
-			powchainBucket,
-			stateSummaryBucket,
-			stateValidatorsBucket,
-			validatedTips,
-			// Indices buckets.
-			attestationHeadBlockRootBucket,
-			attestationSourceRootIndicesBucket,
-			attestationSourceEpochIndicesBucket,
-			attestationTargetRootIndicesBucket,
-			attestationTargetEpochIndicesBucket,
-			blockSlotIndicesBucket,
-			stateSlotIndicesBucket,
-			blockParentRootIndicesBucket,
-			finalizedBlockRootsIndexBucket,
-			blockRootValidatorHashesBucket,
-			// State management service bucket.
-			newStateServiceCompatibleBucket,
-			// Migrations
-			migrationsBucket,
-		)
+	if err := kv.db.Update(func(tx backend.Tx) error {
+		return createBuckets(tx, storeBuckets...)
 	}); err != nil {
 		log.WithField("elapsed", time.Since(start)).Error("Failed to update db and create buckets")
 		return nil, err
 	}
 	log.WithField("elapsed", time.Since(start)).Info("Updated db and created buckets")
 
-	err = prometheus.Register(createBoltCollector(kv.db))
+	err = prometheus.Register(createCollector(kv.db))
 
 	return kv, err
 }
 
+// storeBuckets are the buckets of the store, created when it is opened.
+var storeBuckets = [][]byte{
+	attestationsBucket,
+	blocksBucket,
+	stateBucket,
+	proposerSlashingsBucket,
+	attesterSlashingsBucket,
+	voluntaryExitsBucket,
+	chainMetadataBucket,
+	checkpointBucket,
+	powchainBucket,
+	stateSummaryBucket,
+	stateValidatorsBucket,
+	stateDiffBucket,
+	validatedTips,
+	lightClientUpdates,
+	initialSyncPendingBlocksBucket,
+	// Indices buckets.
+	attestationHeadBlockRootBucket,
+	attestationSourceRootIndicesBucket,
+	attestationSourceEpochIndicesBucket,
+	attestationTargetRootIndicesBucket,
+	attestationTargetEpochIndicesBucket,
+	blockSlotIndicesBucket,
+	stateSlotIndicesBucket,
+	stateDiffBaseRootIndicesBucket,
+	blockParentRootIndicesBucket,
+	finalizedBlockRootsIndexBucket,
+	blockRootValidatorHashesBucket,
+	validatorIndicesBucket,
+	// State management service bucket.
+	newStateServiceCompatibleBucket,
+	// Migrations
+	migrationsBucket,
+}
+
 // ClearDB removes the previously stored database in the data directory.
 func (s *Store) ClearDB() error {
+	if s.readOnly {
+		return backend.ErrReadOnly
+	}
 	if _, err := os.Stat(s.databasePath); os.IsNotExist(err) {
 		return nil
 	}
-	prometheus.Unregister(createBoltCollector(s.db))
-	if err := os.Remove(path.Join(s.databasePath, DatabaseFileName)); err != nil {
+	prometheus.Unregister(createCollector(s.db))
+	if err := os.RemoveAll(KVStoreDatafilePath(s.databasePath, s.db.Kind())); err != nil {
 		return errors.Wrap(err, "could not remove database file")
 	}
 	return nil
 }
 
-// Close closes the underlying BoltDB database.
+// Close closes the underlying database.
 func (s *Store) Close() error {
-	prometheus.Unregister(createBoltCollector(s.db))
+	prometheus.Unregister(createCollector(s.db))
 
 	// Before DB closes, we should dump the cached state summary objects to DB.
-	if err := s.saveCachedStateSummariesDB(s.ctx); err != nil {
-		return err
+	if !s.readOnly {
+		if err := s.saveCachedStateSummariesDB(s.ctx); err != nil {
+			return err
+		}
 	}
 
 	return s.db.Close()
@@ -232,7 +276,18 @@ func (s *Store) DatabasePath() string {
 	return s.databasePath
 }
 
-func createBuckets(tx *bolt.Tx, buckets ...[]byte) error {
+// hasBuckets returns an error if one of the buckets does not exist, in a database which was not
+// opened read-write by this version of the store.
+func hasBuckets(tx backend.Tx, buckets ...[]byte) error {
+	for _, bucket := range buckets {
+		if tx.Bucket(bucket) == nil {
+			return errors.Errorf("database has no %s bucket, open it read-write once to create it", bucket)
+		}
+	}
+	return nil
+}
+
+func createBuckets(tx backend.Tx, buckets ...[]byte) error {
 	for _, bucket := range buckets {
 		if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
 			return err
@@ -241,7 +296,7 @@ func createBuckets(tx *bolt.Tx, buckets ...[]byte) error {
 	return nil
 }
 
-// createBoltCollector returns a prometheus collector specifically configured for boltdb.
-func createBoltCollector(db *bolt.DB) prometheus.Collector {
-	return prombolt.New("boltDB", db, blockedBuckets...)
+// createCollector returns a prometheus collector specifically configured for the database backend.
+func createCollector(db backend.DB) prometheus.Collector {
+	return db.Collector(blockedBuckets...)
 }
@@ -4,7 +4,12 @@ import (
 	"context"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
 )
 
 // setupDB instantiates and returns a Store instance.
@@ -16,3 +21,55 @@ func setupDB(t testing.TB) *Store {
 	})
 	return db
 }
+
+func TestNewKVStore_Backend(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db, err := NewKVStore(ctx, dir, &Config{Backend: backend.Pebble})
+	require.NoError(t, err)
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 10
+	require.NoError(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(blk)))
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	assert.Equal(t, true, backend.Exists(backend.Pebble, dir, DatabaseFileName))
+	assert.Equal(t, false, backend.Exists(backend.Bolt, dir, DatabaseFileName))
+
+	_, err = NewKVStore(ctx, dir, &Config{})
+	assert.ErrorContains(t, "found a pebble database", err)
+
+	db, err = NewKVStore(ctx, dir, &Config{Backend: backend.Pebble})
+	require.NoError(t, err)
+	assert.Equal(t, true, db.HasBlock(ctx, root))
+	require.NoError(t, db.Close())
+	require.NoError(t, db.ClearDB())
+	assert.Equal(t, false, backend.Exists(backend.Pebble, dir, DatabaseFileName))
+}
+
+func TestNewKVStore_ReadOnly(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	_, err := NewKVStore(ctx, dir, &Config{ReadOnly: true})
+	require.ErrorContains(t, "to open read-only", err)
+
+	db, err := NewKVStore(ctx, dir, &Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.RunMigrations(ctx))
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 10
+	require.NoError(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(blk)))
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = NewKVStore(ctx, dir, &Config{ReadOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, true, db.HasBlock(ctx, root))
+	require.NoError(t, db.RunMigrations(ctx))
+	require.ErrorIs(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(util.NewBeaconBlock())), backend.ErrReadOnly)
+	require.ErrorIs(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Root: root[:]}), backend.ErrReadOnly)
+	require.ErrorIs(t, db.ClearDB(), backend.ErrReadOnly)
+	require.NoError(t, db.Close())
+	assert.Equal(t, true, backend.Exists(backend.Bolt, dir, DatabaseFileName))
+}
@@ -0,0 +1,72 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"go.opencensus.io/trace"
+)
+
+// LightClientUpdate returns the best light client update saved for the given sync committee period,
+// or nil if there is none.
+func (s *Store) LightClientUpdate(ctx context.Context, period uint64) (*ethpbv2.LightClientUpdate, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdate")
+	defer span.End()
+
+	var update *ethpbv2.LightClientUpdate
+	err := s.db.View(func(tx backend.Tx) error {
+		enc := tx.Bucket(lightClientUpdates).Get(bytesutil.Uint64ToBytesBigEndian(period))
+		if enc == nil {
+			return nil
+		}
+		update = &ethpbv2.LightClientUpdate{}
+		return decode(ctx, enc, update)
+	})
+	return update, err
+}
+
+// LightClientUpdates returns the best light client updates saved for the consecutive sync committee periods
+// from startPeriod to endPeriod inclusive. The returned updates stop at the first period without an update.
+func (s *Store) LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) ([]*ethpbv2.LightClientUpdate, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdates")
+	defer span.End()
+
+	var updates []*ethpbv2.LightClientUpdate
+	err := s.db.View(func(tx backend.Tx) error {
+		c := tx.Bucket(lightClientUpdates).Cursor()
+		period := startPeriod
+		for k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(startPeriod)); k != nil && period <= endPeriod; k, v = c.Next() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if bytesutil.BytesToUint64BigEndian(k) != period {
+				break
+			}
+			update := &ethpbv2.LightClientUpdate{}
+			if err := decode(ctx, v, update); err != nil {
+				return err
+			}
+			updates = append(updates, update)
+			period++
+		}
+		return nil
+	})
+	return updates, err
+}
+
+// SaveLightClientUpdate saves the best light client update for the given sync committee period,
+// replacing any update previously saved for it.
+func (s *Store) SaveLightClientUpdate(ctx context.Context, period uint64, update *ethpbv2.LightClientUpdate) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveLightClientUpdate")
+	defer span.End()
+
+	enc, err := encode(ctx, update)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx backend.Tx) error {
+		return tx.Bucket(lightClientUpdates).Put(bytesutil.Uint64ToBytesBigEndian(period), enc)
+	})
+}
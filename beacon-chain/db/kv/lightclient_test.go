@@ -0,0 +1,63 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestStore_LightClientUpdate_CanSaveRetrieve(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	update, err := db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, (*ethpbv2.LightClientUpdate)(nil), update)
+
+	want := &ethpbv2.LightClientUpdate{SignatureSlot: 100}
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 1, want))
+	update, err = db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(100), update.SignatureSlot)
+
+	// A better update for the same period replaces the saved one.
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 1, &ethpbv2.LightClientUpdate{SignatureSlot: 101}))
+	update, err = db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(101), update.SignatureSlot)
+}
+
+func TestStore_LightClientUpdates_Consecutive(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	for _, period := range []uint64{1, 2, 3, 5} {
+		require.NoError(t, db.SaveLightClientUpdate(ctx, period, &ethpbv2.LightClientUpdate{SignatureSlot: types.Slot(period)}))
+	}
+
+	tests := []struct {
+		name       string
+		start, end uint64
+		want       []types.Slot
+	}{
+		{name: "all consecutive", start: 1, end: 3, want: []types.Slot{1, 2, 3}},
+		{name: "stops at end", start: 2, end: 2, want: []types.Slot{2}},
+		{name: "stops at gap", start: 2, end: 10, want: []types.Slot{2, 3}},
+		{name: "missing start", start: 4, end: 5, want: nil},
+		{name: "after last", start: 6, end: 10, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates, err := db.LightClientUpdates(ctx, tt.start, tt.end)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.want), len(updates))
+			for i, u := range updates {
+				assert.Equal(t, tt.want[i], u.SignatureSlot)
+			}
+		})
+	}
+}
@@ -1,27 +1,289 @@
 package kv
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 
-	bolt "go.etcd.io/bbolt"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/sirupsen/logrus"
 )
 
 var migrationCompleted = []byte("done")
 
-type migration func(context.Context, *bolt.DB) error
+var errDryRun = errors.New("dry run")
 
-var migrations = []migration{
-	migrateArchivedIndex,
-	migrateBlockSlotIndex,
-	migrateStateValidators,
+// migration of the database schema. Migrations are applied once, in the order of their versions, and
+// recorded as applied in the migrations bucket.
+type migration struct {
+	// Version of the schema once the migration is applied.
+	version uint64
+	// Key recording the migration as applied in the migrations bucket, also naming the migration.
+	key []byte
+	// Description of the migration, as reported to operators.
+	description string
+	// enabled returns whether the migration is to be applied, nil if it always is.
+	enabled func() bool
+	// up applies the migration, in the transaction recording it as applied.
+	up func(context.Context, backend.Tx) error
+	// down reverts the migration, in the transaction recording it as reverted. It is nil if the
+	// migration cannot be rolled back.
+	down func(context.Context, backend.Tx) error
 }
 
-// RunMigrations defined in the migrations array.
+var migrations = []*migration{
+	{
+		version:     1,
+		key:         migrationArchivedIndex0Key,
+		description: "index the archived states by slot and delete the archived roots bucket",
+		up:          migrateArchivedIndex,
+	},
+	{
+		version:     2,
+		key:         migrationBlockSlotIndex0Key,
+		description: "index the blocks by big endian slot instead of decimal string",
+		up:          migrateBlockSlotIndex,
+		down:        rollbackBlockSlotIndex,
+	},
+	{
+		version:     3,
+		key:         migrationStateValidatorsKey,
+		description: "move the validators of the saved states to a bucket shared between states",
+		enabled: func() bool {
+			return features.Get().EnableHistoricalSpaceRepresentation
+		},
+		up: migrateStateValidators,
+	},
+}
+
+// MigrationStatus reports whether a migration of the database schema is applied.
+type MigrationStatus struct {
+	Version     uint64
+	Name        string
+	Description string
+	Applied     bool
+	// Enabled is false for the migrations not applied with the current feature flags.
+	Enabled bool
+	// Reversible migrations can be rolled back.
+	Reversible bool
+	// Writes is the number of keys and buckets the migration writes, as found by a dry run.
+	Writes int
+}
+
+func (m *migration) status(applied bool) *MigrationStatus {
+	return &MigrationStatus{
+		Version:     m.version,
+		Name:        string(m.key),
+		Description: m.description,
+		Applied:     applied,
+		Enabled:     m.enabled == nil || m.enabled(),
+		Reversible:  m.down != nil,
+	}
+}
+
+// Migrations reports the status of the migrations of the database schema, by version.
+func (s *Store) Migrations(_ context.Context) ([]*MigrationStatus, error) {
+	statuses := make([]*MigrationStatus, 0, len(migrations))
+	err := s.db.View(func(tx backend.Tx) error {
+		mb := tx.Bucket(migrationsBucket)
+		for _, m := range migrations {
+			statuses = append(statuses, m.status(bytes.Equal(mb.Get(m.key), migrationCompleted)))
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// RunMigrations applies the pending migrations of the database schema, or checks that none is
+// pending in a database opened read-only.
 func (s *Store) RunMigrations(ctx context.Context) error {
-	for _, m := range migrations {
-		if err := m(ctx, s.db); err != nil {
-			return err
+	if s.readOnly {
+		return s.checkMigrationsApplied(ctx)
+	}
+	_, err := runMigrations(ctx, s.db, migrations, false)
+	return err
+}
+
+// checkMigrationsApplied returns an error if an enabled migration is pending, which cannot be applied
+// to a database opened read-only.
+func (s *Store) checkMigrationsApplied(ctx context.Context) error {
+	statuses, err := s.Migrations(ctx)
+	if err != nil {
+		return err
+	}
+	for _, st := range statuses {
+		if st.Enabled && !st.Applied {
+			return errors.Errorf("migration to %s is pending, open the database read-write to apply it", st.Description)
 		}
 	}
 	return nil
 }
+
+// DryRunMigrations runs the pending migrations of the database schema in transactions which are
+// rolled back, reporting the migrations which would be applied and the writes they would make.
+// Each migration runs against the current database, without the changes of the migrations before.
+func (s *Store) DryRunMigrations(ctx context.Context) ([]*MigrationStatus, error) {
+	return runMigrations(ctx, s.db, migrations, true)
+}
+
+// RollbackMigrations reverts the applied migrations above the given schema version, from the
+// latest one. It fails without reverting anything if one of them cannot be rolled back.
+func (s *Store) RollbackMigrations(ctx context.Context, version uint64) ([]*MigrationStatus, error) {
+	return rollbackMigrations(ctx, s.db, migrations, version)
+}
+
+func runMigrations(ctx context.Context, db backend.DB, ms []*migration, dryRun bool) ([]*MigrationStatus, error) {
+	applied := make([]*MigrationStatus, 0)
+	for _, m := range ms {
+		isApplied, err := isMigrationApplied(db, m)
+		if err != nil {
+			return nil, err
+		}
+		if isApplied {
+			if m.enabled != nil && !m.enabled() {
+				log.WithField("migration", string(m.key)).Warnf("Migration to %s was applied before, the database keeps its schema", m.description)
+			}
+			continue
+		}
+		if m.enabled != nil && !m.enabled() {
+			continue
+		}
+		status := m.status(false)
+		if dryRun {
+			writes, err := dryRunMigration(ctx, db, m)
+			if err != nil {
+				return nil, errors.Wrapf(err, "dry run of migration %s failed", m.key)
+			}
+			status.Writes = writes
+			applied = append(applied, status)
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"migration": string(m.key),
+			"version":   m.version,
+		}).Infof("Migrating database to %s", m.description)
+		if err := db.Update(func(tx backend.Tx) error {
+			if err := m.up(ctx, tx); err != nil {
+				return err
+			}
+			return tx.Bucket(migrationsBucket).Put(m.key, migrationCompleted)
+		}); err != nil {
+			return nil, errors.Wrapf(err, "could not apply migration %s", m.key)
+		}
+		status.Applied = true
+		applied = append(applied, status)
+	}
+	return applied, nil
+}
+
+func rollbackMigrations(ctx context.Context, db backend.DB, ms []*migration, version uint64) ([]*MigrationStatus, error) {
+	toRevert := make([]*migration, 0)
+	for i := len(ms) - 1; i >= 0 && ms[i].version > version; i-- {
+		isApplied, err := isMigrationApplied(db, ms[i])
+		if err != nil {
+			return nil, err
+		}
+		if !isApplied {
+			continue
+		}
+		if ms[i].down == nil {
+			return nil, fmt.Errorf("migration %s to version %d cannot be rolled back", ms[i].key, ms[i].version)
+		}
+		toRevert = append(toRevert, ms[i])
+	}
+	reverted := make([]*MigrationStatus, 0, len(toRevert))
+	for _, m := range toRevert {
+		log.WithFields(logrus.Fields{
+			"migration": string(m.key),
+			"version":   m.version,
+		}).Info("Rolling back database migration")
+		if err := db.Update(func(tx backend.Tx) error {
+			if err := m.down(ctx, tx); err != nil {
+				return err
+			}
+			return tx.Bucket(migrationsBucket).Delete(m.key)
+		}); err != nil {
+			return nil, errors.Wrapf(err, "could not roll back migration %s", m.key)
+		}
+		reverted = append(reverted, m.status(false))
+	}
+	return reverted, nil
+}
+
+func isMigrationApplied(db backend.DB, m *migration) (bool, error) {
+	var applied bool
+	err := db.View(func(tx backend.Tx) error {
+		applied = bytes.Equal(tx.Bucket(migrationsBucket).Get(m.key), migrationCompleted)
+		return nil
+	})
+	return applied, err
+}
+
+// dryRunMigration applies the migration in a transaction which is rolled back, returning the number
+// of writes it makes.
+func dryRunMigration(ctx context.Context, db backend.DB, m *migration) (int, error) {
+	writes := 0
+	err := db.Update(func(tx backend.Tx) error {
+		if err := m.up(ctx, &dryRunTx{Tx: tx, writes: &writes}); err != nil {
+			return err
+		}
+		return errDryRun
+	})
+	if errors.Is(err, errDryRun) {
+		return writes, nil
+	}
+	return 0, err
+}
+
+// dryRunTx counts the writes of a migration.
+type dryRunTx struct {
+	backend.Tx
+	writes *int
+}
+
+func (t *dryRunTx) Bucket(name []byte) backend.Bucket {
+	b := t.Tx.Bucket(name)
+	if b == nil {
+		return nil
+	}
+	return &dryRunBucket{Bucket: b, writes: t.writes}
+}
+
+func (t *dryRunTx) CreateBucketIfNotExists(name []byte) (backend.Bucket, error) {
+	if t.Tx.Bucket(name) == nil {
+		*t.writes++
+	}
+	b, err := t.Tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, err
+	}
+	return &dryRunBucket{Bucket: b, writes: t.writes}, nil
+}
+
+func (t *dryRunTx) DeleteBucket(name []byte) error {
+	*t.writes++
+	return t.Tx.DeleteBucket(name)
+}
+
+func (t *dryRunTx) ForEach(fn func(name []byte, b backend.Bucket) error) error {
+	return t.Tx.ForEach(func(name []byte, b backend.Bucket) error {
+		return fn(name, &dryRunBucket{Bucket: b, writes: t.writes})
+	})
+}
+
+type dryRunBucket struct {
+	backend.Bucket
+	writes *int
+}
+
+func (b *dryRunBucket) Put(key, value []byte) error {
+	*b.writes++
+	return b.Bucket.Put(key, value)
+}
+
+func (b *dryRunBucket) Delete(key []byte) error {
+	*b.writes++
+	return b.Bucket.Delete(key)
+}
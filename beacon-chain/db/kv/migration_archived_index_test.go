@@ -4,22 +4,22 @@ import (
 	"context"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/util"
-	"go.etcd.io/bbolt"
 )
 
 func Test_migrateArchivedIndex(t *testing.T) {
 	tests := []struct {
 		name  string
-		setup func(t *testing.T, db *bbolt.DB)
-		eval  func(t *testing.T, db *bbolt.DB)
+		setup func(t *testing.T, db backend.DB)
+		eval  func(t *testing.T, db backend.DB)
 	}{
 		{
 			name: "only runs once",
-			setup: func(t *testing.T, db *bbolt.DB) {
-				err := db.Update(func(tx *bbolt.Tx) error {
+			setup: func(t *testing.T, db backend.DB) {
+				err := db.Update(func(tx backend.Tx) error {
 					_, err := tx.CreateBucketIfNotExists(archivedRootBucket)
 					assert.NoError(t, err)
 					if err := tx.Bucket(archivedRootBucket).Put(bytesutil.Uint64ToBytesLittleEndian(2048), []byte("foo")); err != nil {
@@ -29,8 +29,8 @@ func Test_migrateArchivedIndex(t *testing.T) {
 				})
 				assert.NoError(t, err)
 			},
-			eval: func(t *testing.T, db *bbolt.DB) {
-				err := db.View(func(tx *bbolt.Tx) error {
+			eval: func(t *testing.T, db backend.DB) {
+				err := db.View(func(tx backend.Tx) error {
 					v := tx.Bucket(archivedRootBucket).Get(bytesutil.Uint64ToBytesLittleEndian(2048))
 					assert.DeepEqual(t, []byte("foo"), v, "Did not receive correct data for key 2048")
 					return nil
@@ -40,8 +40,8 @@ func Test_migrateArchivedIndex(t *testing.T) {
 		},
 		{
 			name: "migrates and deletes entries",
-			setup: func(t *testing.T, db *bbolt.DB) {
-				err := db.Update(func(tx *bbolt.Tx) error {
+			setup: func(t *testing.T, db backend.DB) {
+				err := db.Update(func(tx backend.Tx) error {
 					_, err := tx.CreateBucketIfNotExists(archivedRootBucket)
 					assert.NoError(t, err)
 					_, err = tx.CreateBucketIfNotExists(slotsHasObjectBucket)
@@ -59,8 +59,8 @@ func Test_migrateArchivedIndex(t *testing.T) {
 				})
 				assert.NoError(t, err)
 			},
-			eval: func(t *testing.T, db *bbolt.DB) {
-				err := db.View(func(tx *bbolt.Tx) error {
+			eval: func(t *testing.T, db backend.DB) {
+				err := db.View(func(tx backend.Tx) error {
 					k := uint64(2048)
 					v := tx.Bucket(stateSlotIndicesBucket).Get(bytesutil.Uint64ToBytesBigEndian(k))
 					assert.DeepEqual(t, []byte("foo"), v, "Did not receive correct data for key %d", k)
@@ -71,8 +71,8 @@ func Test_migrateArchivedIndex(t *testing.T) {
 		},
 		{
 			name: "deletes old buckets",
-			setup: func(t *testing.T, db *bbolt.DB) {
-				err := db.Update(func(tx *bbolt.Tx) error {
+			setup: func(t *testing.T, db backend.DB) {
+				err := db.Update(func(tx backend.Tx) error {
 					_, err := tx.CreateBucketIfNotExists(archivedRootBucket)
 					assert.NoError(t, err)
 					_, err = tx.CreateBucketIfNotExists(slotsHasObjectBucket)
@@ -81,10 +81,10 @@ func Test_migrateArchivedIndex(t *testing.T) {
 				})
 				assert.NoError(t, err)
 			},
-			eval: func(t *testing.T, db *bbolt.DB) {
-				err := db.View(func(tx *bbolt.Tx) error {
-					assert.Equal(t, (*bbolt.Bucket)(nil), tx.Bucket(slotsHasObjectBucket), "Expected %v to be deleted", savedStateSlotsKey)
-					assert.Equal(t, (*bbolt.Bucket)(nil), tx.Bucket(archivedRootBucket), "Expected %v to be deleted", savedStateSlotsKey)
+			eval: func(t *testing.T, db backend.DB) {
+				err := db.View(func(tx backend.Tx) error {
+					assert.Equal(t, (backend.Bucket)(nil), tx.Bucket(slotsHasObjectBucket), "Expected %v to be deleted", savedStateSlotsKey)
+					assert.Equal(t, (backend.Bucket)(nil), tx.Bucket(archivedRootBucket), "Expected %v to be deleted", savedStateSlotsKey)
 					return nil
 				})
 				assert.NoError(t, err)
@@ -95,7 +95,7 @@ func Test_migrateArchivedIndex(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			db := setupDB(t).db
 			tt.setup(t, db)
-			assert.NoError(t, migrateArchivedIndex(context.Background(), db), "migrateArchivedIndex(tx) error")
+			assert.NoError(t, runMigration(db, migrationArchivedIndex0Key), "migrateArchivedIndex(tx) error")
 			tt.eval(t, db)
 		})
 	}
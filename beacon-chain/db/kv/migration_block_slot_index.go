@@ -1,50 +1,75 @@
 package kv
 
 import (
-	"bytes"
 	"context"
+	"fmt"
 	"strconv"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
-	bolt "go.etcd.io/bbolt"
 )
 
 var migrationBlockSlotIndex0Key = []byte("block_slot_index_0")
 
-func migrateBlockSlotIndex(ctx context.Context, db *bolt.DB) error {
-	if updateErr := db.Update(func(tx *bolt.Tx) error {
-		mb := tx.Bucket(migrationsBucket)
-		if b := mb.Get(migrationBlockSlotIndex0Key); bytes.Equal(b, migrationCompleted) {
-			return nil // Migration already completed.
+// migrateBlockSlotIndex converts the indices from strings to big endian integers.
+func migrateBlockSlotIndex(ctx context.Context, tx backend.Tx) error {
+	bkt := tx.Bucket(blockSlotIndicesBucket)
+	var keys, values [][]byte
+	if err := bkt.ForEach(func(k, v []byte) error {
+		keys = append(keys, bytesutil.SafeCopyBytes(k))
+		values = append(values, bytesutil.SafeCopyBytes(v))
+		return nil
+	}); err != nil {
+		return err
+	}
+	for i, k := range keys {
+		// check if context is cancelled in between
+		if ctx.Err() != nil {
+			return ctx.Err()
 		}
-
-		bkt := tx.Bucket(blockSlotIndicesBucket)
-
-		// Convert indices from strings to big endian integers.
-		if err := bkt.ForEach(func(k, v []byte) error {
-			key, err := strconv.ParseUint(string(k), 10, 64)
-			if err != nil {
-				return err
-			}
-			if err = bkt.Delete(k); err != nil {
-				return err
-			}
-			if err = bkt.Put(bytesutil.Uint64ToBytesBigEndian(key), v); err != nil {
-				return err
+		slot, err := strconv.ParseUint(string(k), 10, 64)
+		if err != nil {
+			// Skip the keys which are already big endian integers.
+			if len(k) == 8 {
+				continue
 			}
-			// check if context is cancelled in between
-			if ctx.Err() != nil {
-				return ctx.Err()
-			}
-			return nil
-		}); err != nil {
+			return fmt.Errorf("could not migrate block slot index key %#x: %v", k, err)
+		}
+		if err := bkt.Delete(k); err != nil {
 			return err
 		}
+		if err := bkt.Put(bytesutil.Uint64ToBytesBigEndian(slot), values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-		return mb.Put(migrationBlockSlotIndex0Key, migrationCompleted)
-	}); updateErr != nil {
-		log.WithError(updateErr).Errorf("could not migrate bucket: %s", blockSlotIndicesBucket)
-		return updateErr
+// rollbackBlockSlotIndex converts the indices back from big endian integers to strings.
+func rollbackBlockSlotIndex(ctx context.Context, tx backend.Tx) error {
+	bkt := tx.Bucket(blockSlotIndicesBucket)
+	var keys, values [][]byte
+	if err := bkt.ForEach(func(k, v []byte) error {
+		if len(k) != 8 {
+			return fmt.Errorf("block slot index key %#x is not a big endian integer", k)
+		}
+		keys = append(keys, bytesutil.SafeCopyBytes(k))
+		values = append(values, bytesutil.SafeCopyBytes(v))
+		return nil
+	}); err != nil {
+		return err
+	}
+	for i, k := range keys {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := bkt.Delete(k); err != nil {
+			return err
+		}
+		slot := bytesutil.BytesToUint64BigEndian(k)
+		if err := bkt.Put([]byte(strconv.FormatUint(slot, 10)), values[i]); err != nil {
+			return err
+		}
 	}
 	return nil
 }
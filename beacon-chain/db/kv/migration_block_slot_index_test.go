@@ -1,24 +1,23 @@
 package kv
 
 import (
-	"context"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/testing/assert"
-	"go.etcd.io/bbolt"
 )
 
 func Test_migrateBlockSlotIndex(t *testing.T) {
 	tests := []struct {
 		name  string
-		setup func(t *testing.T, db *bbolt.DB)
-		eval  func(t *testing.T, db *bbolt.DB)
+		setup func(t *testing.T, db backend.DB)
+		eval  func(t *testing.T, db backend.DB)
 	}{
 		{
 			name: "only runs once",
-			setup: func(t *testing.T, db *bbolt.DB) {
-				err := db.Update(func(tx *bbolt.Tx) error {
+			setup: func(t *testing.T, db backend.DB) {
+				err := db.Update(func(tx backend.Tx) error {
 					if err := tx.Bucket(blockSlotIndicesBucket).Put([]byte("2048"), []byte("foo")); err != nil {
 						return err
 					}
@@ -26,8 +25,8 @@ func Test_migrateBlockSlotIndex(t *testing.T) {
 				})
 				assert.NoError(t, err)
 			},
-			eval: func(t *testing.T, db *bbolt.DB) {
-				err := db.View(func(tx *bbolt.Tx) error {
+			eval: func(t *testing.T, db backend.DB) {
+				err := db.View(func(tx backend.Tx) error {
 					v := tx.Bucket(blockSlotIndicesBucket).Get([]byte("2048"))
 					assert.DeepEqual(t, []byte("foo"), v, "Did not receive correct data for key 2048")
 					return nil
@@ -37,14 +36,14 @@ func Test_migrateBlockSlotIndex(t *testing.T) {
 		},
 		{
 			name: "migrates and deletes entries",
-			setup: func(t *testing.T, db *bbolt.DB) {
-				err := db.Update(func(tx *bbolt.Tx) error {
+			setup: func(t *testing.T, db backend.DB) {
+				err := db.Update(func(tx backend.Tx) error {
 					return tx.Bucket(blockSlotIndicesBucket).Put([]byte("2048"), []byte("foo"))
 				})
 				assert.NoError(t, err)
 			},
-			eval: func(t *testing.T, db *bbolt.DB) {
-				err := db.View(func(tx *bbolt.Tx) error {
+			eval: func(t *testing.T, db backend.DB) {
+				err := db.View(func(tx backend.Tx) error {
 					k := uint64(2048)
 					v := tx.Bucket(blockSlotIndicesBucket).Get(bytesutil.Uint64ToBytesBigEndian(k))
 					assert.DeepEqual(t, []byte("foo"), v, "Did not receive correct data for key %d", k)
@@ -53,12 +52,34 @@ func Test_migrateBlockSlotIndex(t *testing.T) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			name: "skips the entries already migrated",
+			setup: func(t *testing.T, db backend.DB) {
+				err := db.Update(func(tx backend.Tx) error {
+					bkt := tx.Bucket(blockSlotIndicesBucket)
+					if err := bkt.Put(bytesutil.Uint64ToBytesBigEndian(1024), []byte("bar")); err != nil {
+						return err
+					}
+					return bkt.Put([]byte("2048"), []byte("foo"))
+				})
+				assert.NoError(t, err)
+			},
+			eval: func(t *testing.T, db backend.DB) {
+				err := db.View(func(tx backend.Tx) error {
+					bkt := tx.Bucket(blockSlotIndicesBucket)
+					assert.DeepEqual(t, []byte("bar"), bkt.Get(bytesutil.Uint64ToBytesBigEndian(1024)))
+					assert.DeepEqual(t, []byte("foo"), bkt.Get(bytesutil.Uint64ToBytesBigEndian(2048)))
+					return nil
+				})
+				assert.NoError(t, err)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			db := setupDB(t).db
 			tt.setup(t, db)
-			assert.NoError(t, migrateBlockSlotIndex(context.Background(), db), "migrateBlockSlotIndex(tx) error")
+			assert.NoError(t, runMigration(db, migrationBlockSlotIndex0Key), "migrateBlockSlotIndex(tx) error")
 			tt.eval(t, db)
 		})
 	}
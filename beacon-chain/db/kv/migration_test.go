@@ -0,0 +1,116 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+// runMigration applies the migration of the given key, unless it is recorded as applied.
+func runMigration(db backend.DB, key []byte) error {
+	for _, m := range migrations {
+		if bytes.Equal(m.key, key) {
+			_, err := runMigrations(context.Background(), db, []*migration{m}, false)
+			return err
+		}
+	}
+	return nil
+}
+
+func TestStore_Migrations(t *testing.T) {
+	ctx := context.Background()
+	resetCfg := features.InitWithReset(&features.Flags{EnableHistoricalSpaceRepresentation: false})
+	defer resetCfg()
+	db := setupDB(t)
+	require.NoError(t, db.db.Update(func(tx backend.Tx) error {
+		return tx.Bucket(blockSlotIndicesBucket).Put([]byte("2048"), []byte("foo"))
+	}))
+
+	statuses, err := db.Migrations(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(migrations), len(statuses))
+	for i, st := range statuses {
+		assert.Equal(t, uint64(i+1), st.Version)
+		assert.Equal(t, false, st.Applied)
+	}
+	assert.Equal(t, false, statuses[2].Enabled)
+	assert.Equal(t, true, statuses[1].Reversible)
+
+	// A dry run reports the writes of the pending migrations without applying them.
+	pending, err := db.DryRunMigrations(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(pending))
+	assert.Equal(t, string(migrationBlockSlotIndex0Key), pending[1].Name)
+	assert.Equal(t, 2, pending[1].Writes)
+	statuses, err = db.Migrations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, false, statuses[0].Applied)
+	require.NoError(t, db.db.View(func(tx backend.Tx) error {
+		assert.DeepEqual(t, []byte("foo"), tx.Bucket(blockSlotIndicesBucket).Get([]byte("2048")))
+		return nil
+	}))
+
+	require.NoError(t, db.RunMigrations(ctx))
+	statuses, err = db.Migrations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, statuses[0].Applied)
+	assert.Equal(t, true, statuses[1].Applied)
+	assert.Equal(t, false, statuses[2].Applied)
+	require.NoError(t, db.db.View(func(tx backend.Tx) error {
+		assert.DeepEqual(t, []byte("foo"), tx.Bucket(blockSlotIndicesBucket).Get(bytesutil.Uint64ToBytesBigEndian(2048)))
+		return nil
+	}))
+	pending, err = db.DryRunMigrations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(pending))
+
+	// The archived index migration cannot be rolled back.
+	_, err = db.RollbackMigrations(ctx, 0)
+	require.ErrorContains(t, "cannot be rolled back", err)
+	statuses, err = db.Migrations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, statuses[1].Applied)
+
+	reverted, err := db.RollbackMigrations(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(reverted))
+	assert.Equal(t, uint64(2), reverted[0].Version)
+	statuses, err = db.Migrations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, statuses[0].Applied)
+	assert.Equal(t, false, statuses[1].Applied)
+	require.NoError(t, db.db.View(func(tx backend.Tx) error {
+		assert.DeepEqual(t, []byte("foo"), tx.Bucket(blockSlotIndicesBucket).Get([]byte("2048")))
+		return nil
+	}))
+}
+
+func TestStore_RunMigrations_FailedMigrationIsNotRecorded(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	m := &migration{
+		version: 1,
+		key:     []byte("failing_migration"),
+		up: func(_ context.Context, tx backend.Tx) error {
+			if err := tx.Bucket(blockSlotIndicesBucket).Put([]byte("2048"), []byte("foo")); err != nil {
+				return err
+			}
+			return errors.New("failed")
+		},
+	}
+	_, err := runMigrations(ctx, db.db, []*migration{m}, false)
+	require.ErrorContains(t, "failed", err)
+	require.NoError(t, db.db.View(func(tx backend.Tx) error {
+		assert.Equal(t, 0, len(tx.Bucket(migrationsBucket).Get(m.key)))
+		assert.Equal(t, 0, len(tx.Bucket(blockSlotIndicesBucket).Get([]byte("2048"))))
+		return nil
+	}))
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/container/trie"
 	v2 "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
 )
 
 func TestStore_SavePowchainData(t *testing.T) {
@@ -33,3 +36,25 @@ func TestStore_SavePowchainData(t *testing.T) {
 		})
 	}
 }
+
+func TestStore_DepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+	store := setupDB(t)
+
+	snapshot, err := store.DepositSnapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, (*trie.DepositTreeSnapshot)(nil), snapshot)
+	require.ErrorContains(t, "cannot save nil deposit snapshot", store.SaveDepositSnapshot(ctx, nil))
+
+	want := &trie.DepositTreeSnapshot{
+		Finalized:            [][32]byte{{'a'}},
+		DepositRoot:          [32]byte{'b'},
+		DepositCount:         1,
+		ExecutionBlockHash:   [32]byte{'c'},
+		ExecutionBlockHeight: 10,
+	}
+	require.NoError(t, store.SaveDepositSnapshot(ctx, want))
+	snapshot, err = store.DepositSnapshot(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, snapshot)
+}
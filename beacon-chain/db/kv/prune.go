@@ -0,0 +1,323 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// Number of objects collected and deleted per transaction when pruning, so that the database
+// is not locked for the whole pruning.
+const pruneBatchSize = 256
+
+// An object to prune, identified by its root and the key of its slot or epoch index.
+type pruneEntry struct {
+	root     [32]byte
+	indexKey []byte
+}
+
+// A kind of objects to prune, which are stored under their root in the given buckets and indexed
+// by slot or epoch in the index bucket.
+type pruneKind struct {
+	index   []byte
+	buckets [][]byte
+	// collect returns the objects to prune from an index entry, and whether the iteration over
+	// the index is done.
+	collect func(tx backend.Tx, k, v []byte) (entries []*pruneEntry, done bool)
+	// delete deletes what is not stored under the root of an object, if set.
+	delete func(tx backend.Tx, e *pruneEntry) error
+}
+
+// PruneFinalized deletes the blocks and states below the given slot, which must not be above the
+// finalized checkpoint, along with the archived attestations targeting an epoch below it. The
+// genesis, origin, backfill, finalized, justified and head blocks and states are kept, as well as
+// the first finalized block of each era of SLOTS_PER_HISTORICAL_ROOT slots and its state, and the
+// states which the kept state diffs are based on. The last state saved at or below the given slot
+// and the blocks above it are kept too, so that the states above the given slot can still be
+// replayed from it.
+// In a dry run nothing is deleted, the returned stats report what would be.
+func (s *Store) PruneFinalized(ctx context.Context, beforeSlot types.Slot, dryRun bool) (*iface.PruneStats, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PruneFinalized")
+	defer span.End()
+
+	f, err := s.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	finalizedSlot, err := slots.EpochStart(f.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	if beforeSlot > finalizedSlot {
+		return nil, errors.Errorf("cannot prune above the finalized slot %d", finalizedSlot)
+	}
+
+	var protected map[[32]byte]bool
+	var cutoff types.Slot
+	if err := s.db.View(func(tx backend.Tx) error {
+		protected, err = protectedRoots(ctx, tx)
+		cutoff = replayBaseSlot(tx, beforeSlot)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	// The blocks below the cutoff are deleted, except a few whose parents are then missing. The
+	// cutoff is recorded before deleting them, so that the integrity check expects it.
+	if !dryRun {
+		if err := s.db.Update(func(tx backend.Tx) error {
+			bkt := tx.Bucket(chainMetadataBucket)
+			if enc := bkt.Get(prunedSlotKey); enc != nil && bytesutil.BytesToSlotBigEndian(enc) >= cutoff {
+				return nil
+			}
+			return bkt.Put(prunedSlotKey, bytesutil.SlotToBytesBigEndian(cutoff))
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	stats := &iface.PruneStats{}
+	slotsPerEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	keptEras := make(map[types.Slot]bool)
+	kept := make(map[[32]byte]bool)
+	blocks := &pruneKind{
+		index:   blockSlotIndicesBucket,
+		buckets: [][]byte{blocksBucket, blockParentRootIndicesBucket, finalizedBlockRootsIndexBucket, stateSummaryBucket},
+		collect: func(tx backend.Tx, k, v []byte) ([]*pruneEntry, bool) {
+			slot := bytesutil.BytesToSlotBigEndian(k)
+			if slot >= cutoff {
+				return nil, true
+			}
+			era := slot / slotsPerEra
+			entries := make([]*pruneEntry, 0)
+			for i := 0; i+32 <= len(v); i += 32 {
+				root := bytesutil.ToBytes32(v[i : i+32])
+				if !keptEras[era] && tx.Bucket(finalizedBlockRootsIndexBucket).Get(root[:]) != nil {
+					keptEras[era] = true
+					kept[root] = true
+					continue
+				}
+				if protected[root] {
+					continue
+				}
+				entries = append(entries, &pruneEntry{root: root, indexKey: bytesutil.SafeCopyBytes(k)})
+			}
+			return entries, false
+		},
+		delete: func(_ backend.Tx, e *pruneEntry) error {
+			s.blockCache.Del(string(e.root[:]))
+			return nil
+		},
+	}
+	if stats.Blocks, err = s.prune(ctx, blocks, dryRun, stats); err != nil {
+		return nil, errors.Wrap(err, "could not prune blocks")
+	}
+
+	// The state diffs are pruned along with the states, so a state diff is kept if its state is.
+	isKept := func(root [32]byte, slot types.Slot) bool {
+		return slot >= cutoff || protected[root] || kept[root]
+	}
+	states := &pruneKind{
+		index:   stateSlotIndicesBucket,
+		buckets: [][]byte{stateBucket, stateDiffBucket, blockRootValidatorHashesBucket},
+		collect: func(tx backend.Tx, k, v []byte) ([]*pruneEntry, bool) {
+			if bytesutil.BytesToSlotBigEndian(k) >= cutoff {
+				return nil, true
+			}
+			entries := make([]*pruneEntry, 0)
+			for i := 0; i+32 <= len(v); i += 32 {
+				root := bytesutil.ToBytes32(v[i : i+32])
+				if protected[root] || kept[root] || hasKeptStateDiffsBasedOn(tx, root, isKept) {
+					continue
+				}
+				entries = append(entries, &pruneEntry{root: root, indexKey: bytesutil.SafeCopyBytes(k)})
+			}
+			return entries, false
+		},
+		delete: func(tx backend.Tx, e *pruneEntry) error {
+			return deleteStateDiff(ctx, tx, e.root)
+		},
+	}
+	if stats.States, err = s.prune(ctx, states, dryRun, stats); err != nil {
+		return nil, errors.Wrap(err, "could not prune states")
+	}
+
+	// Attestations are no longer archived, but databases of previous versions may still hold them,
+	// indexed by their little endian target epoch.
+	beforeEpoch := slots.ToEpoch(beforeSlot)
+	atts := &pruneKind{
+		index:   attestationTargetEpochIndicesBucket,
+		buckets: [][]byte{attestationsBucket},
+		collect: func(_ backend.Tx, k, v []byte) ([]*pruneEntry, bool) {
+			if len(k) != 8 || types.Epoch(bytesutil.FromBytes8(k)) >= beforeEpoch {
+				return nil, false
+			}
+			entries := make([]*pruneEntry, 0)
+			for i := 0; i+32 <= len(v); i += 32 {
+				entries = append(entries, &pruneEntry{root: bytesutil.ToBytes32(v[i : i+32]), indexKey: bytesutil.SafeCopyBytes(k)})
+			}
+			return entries, false
+		},
+	}
+	if stats.Attestations, err = s.prune(ctx, atts, dryRun, stats); err != nil {
+		return nil, errors.Wrap(err, "could not prune attestations")
+	}
+	return stats, nil
+}
+
+// prune iterates over the index of the given kind of objects, collecting and deleting the objects
+// to prune in batches of pruneBatchSize. It returns the number of pruned objects, and adds their
+// size to the stats.
+func (s *Store) prune(ctx context.Context, kind *pruneKind, dryRun bool, stats *iface.PruneStats) (int, error) {
+	count := 0
+	var next []byte
+	for {
+		var batch []*pruneEntry
+		if err := s.db.View(func(tx backend.Tx) error {
+			c := tx.Bucket(kind.index).Cursor()
+			k, v := c.First()
+			if next != nil {
+				k, v = c.Seek(next)
+			}
+			for ; k != nil && len(batch) < pruneBatchSize; k, v = c.Next() {
+				entries, done := kind.collect(tx, k, v)
+				if done {
+					k = nil
+					break
+				}
+				batch = append(batch, entries...)
+			}
+			next = bytesutil.SafeCopyBytes(k)
+			for _, e := range batch {
+				for _, bkt := range kind.buckets {
+					stats.Bytes += entrySize(tx.Bucket(bkt), e.root[:])
+				}
+			}
+			return nil
+		}); err != nil {
+			return 0, err
+		}
+		count += len(batch)
+		if !dryRun && len(batch) > 0 {
+			if err := s.db.Update(func(tx backend.Tx) error {
+				for _, e := range batch {
+					if kind.delete != nil {
+						if err := kind.delete(tx, e); err != nil {
+							return err
+						}
+					}
+					for _, bkt := range kind.buckets {
+						if err := tx.Bucket(bkt).Delete(e.root[:]); err != nil {
+							return err
+						}
+					}
+					if err := deleteValueForIndices(ctx, map[string][]byte{string(kind.index): e.indexKey}, e.root[:], tx); err != nil {
+						return err
+					}
+				}
+				return nil
+			}); err != nil {
+				return 0, err
+			}
+		}
+		if next == nil {
+			return count, nil
+		}
+	}
+}
+
+// deleteInBatches deletes the given entries in transactions of pruneBatchSize entries.
+func (s *Store) deleteInBatches(entries []*pruneEntry, del func(tx backend.Tx, e *pruneEntry) error) error {
+	for start := 0; start < len(entries); start += pruneBatchSize {
+		end := start + pruneBatchSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+		if err := s.db.Update(func(tx backend.Tx) error {
+			for _, e := range entries[start:end] {
+				if err := del(tx, e); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protectedRoots returns the roots of the blocks and states which are never pruned.
+func protectedRoots(ctx context.Context, tx backend.Tx) (map[[32]byte]bool, error) {
+	protected := make(map[[32]byte]bool)
+	blkBkt := tx.Bucket(blocksBucket)
+	for _, key := range [][]byte{genesisBlockRootKey, originBlockRootKey, backfillBlockRootKey, headBlockRootKey} {
+		if root := blkBkt.Get(key); root != nil {
+			protected[bytesutil.ToBytes32(root)] = true
+		}
+	}
+	chkBkt := tx.Bucket(checkpointBucket)
+	for _, key := range [][]byte{finalizedCheckpointKey, justifiedCheckpointKey} {
+		enc := chkBkt.Get(key)
+		if enc == nil {
+			continue
+		}
+		checkpoint := &ethpb.Checkpoint{}
+		if err := decode(ctx, enc, checkpoint); err != nil {
+			return nil, err
+		}
+		protected[bytesutil.ToBytes32(checkpoint.Root)] = true
+	}
+	return protected, nil
+}
+
+// replayBaseSlot returns the slot of the last state saved at or below the given slot, which the
+// states above it are replayed from.
+func replayBaseSlot(tx backend.Tx, slot types.Slot) types.Slot {
+	c := tx.Bucket(stateSlotIndicesBucket).Cursor()
+	k, _ := c.Seek(bytesutil.SlotToBytesBigEndian(slot + 1))
+	if k == nil {
+		k, _ = c.Last()
+	} else {
+		k, _ = c.Prev()
+	}
+	if k == nil {
+		return 0
+	}
+	return bytesutil.BytesToSlotBigEndian(k)
+}
+
+// keepStateDiffBases removes from the states to prune the base states of the state diffs which
+// are kept.
+func keepStateDiffBases(tx backend.Tx, states []*pruneEntry) []*pruneEntry {
+	pruned := make(map[[32]byte]bool, len(states))
+	for _, e := range states {
+		pruned[e.root] = true
+	}
+	isKept := func(root [32]byte, _ types.Slot) bool {
+		return !pruned[root]
+	}
+	kept := make([]*pruneEntry, 0, len(states))
+	for _, e := range states {
+		if !hasKeptStateDiffsBasedOn(tx, e.root, isKept) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// entrySize returns the size of the key and value stored at the given key.
+func entrySize(bkt backend.Bucket, key []byte) uint64 {
+	v := bkt.Get(key)
+	if v == nil {
+		return 0
+	}
+	return uint64(len(key) + len(v))
+}
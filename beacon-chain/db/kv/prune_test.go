@@ -0,0 +1,189 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestStore_PruneFinalized(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+
+	db := setupDB(t)
+	ctx := context.Background()
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	blks := makeBlocks(t, 0, slotsPerEpoch*5, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i, blk := range blks {
+		r, err := blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+		roots[i] = r
+	}
+
+	// Save a state every 8 slots and at the finalized checkpoint.
+	for i := 0; i < len(blks); i += 8 {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(types.Slot(i)))
+		require.NoError(t, db.SaveState(ctx, st, roots[i]))
+	}
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, roots[8]))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 4, Root: roots[4*slotsPerEpoch][:]}))
+
+	// Archived attestations targeting epochs 1 and 5.
+	oldAtt, newAtt := [32]byte{'o', 'l', 'd'}, [32]byte{'n', 'e', 'w'}
+	require.NoError(t, db.db.Update(func(tx backend.Tx) error {
+		attBkt, indexBkt := tx.Bucket(attestationsBucket), tx.Bucket(attestationTargetEpochIndicesBucket)
+		if err := attBkt.Put(oldAtt[:], []byte{1, 2, 3}); err != nil {
+			return err
+		}
+		if err := attBkt.Put(newAtt[:], []byte{1, 2, 3}); err != nil {
+			return err
+		}
+		if err := indexBkt.Put(bytesutil.Bytes8(1), oldAtt[:]); err != nil {
+			return err
+		}
+		return indexBkt.Put(bytesutil.Bytes8(5), newAtt[:])
+	}))
+
+	// Eras of 2 epochs, set once the states are saved since it changes their ssz encoding.
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	cfg.SlotsPerHistoricalRoot = 2 * cfg.SlotsPerEpoch
+	params.OverrideBeaconConfig(cfg)
+
+	_, err := db.PruneFinalized(ctx, types.Slot(5*slotsPerEpoch), false)
+	require.ErrorContains(t, "cannot prune above the finalized slot", err)
+
+	// Blocks are at slots 1 to 160. The first block of each era, at slots 1 and 64, and the head
+	// block at slot 9 are kept below slot 96.
+	beforeSlot := types.Slot(3 * slotsPerEpoch)
+	dryRun, err := db.PruneFinalized(ctx, beforeSlot, true)
+	require.NoError(t, err)
+	assert.Equal(t, 92, dryRun.Blocks)
+	assert.Equal(t, 10, dryRun.States)
+	assert.Equal(t, 1, dryRun.Attestations)
+	assert.NotEqual(t, uint64(0), dryRun.Bytes)
+	assert.Equal(t, true, db.HasBlock(ctx, roots[1]))
+	assert.Equal(t, true, db.HasState(ctx, roots[16]))
+
+	stats, err := db.PruneFinalized(ctx, beforeSlot, false)
+	require.NoError(t, err)
+	assert.DeepEqual(t, dryRun, stats)
+	for i, r := range roots {
+		slot := types.Slot(i + 1)
+		kept := slot == 1 || slot == 9 || slot == 64 || slot >= beforeSlot
+		assert.Equal(t, kept, db.HasBlock(ctx, r), "Unexpected block at slot %d", slot)
+		if i%8 == 0 {
+			assert.Equal(t, kept, db.HasState(ctx, r), "Unexpected state of block at slot %d", slot)
+		}
+	}
+	blkRoots, err := db.BlockRoots(ctx, filters.NewFilter().SetStartSlot(0).SetEndSlot(beforeSlot-1))
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][32]byte{roots[0], roots[8], roots[63]}, blkRoots)
+	require.NoError(t, db.db.View(func(tx backend.Tx) error {
+		assert.Equal(t, true, tx.Bucket(attestationsBucket).Get(oldAtt[:]) == nil)
+		assert.Equal(t, false, tx.Bucket(attestationsBucket).Get(newAtt[:]) == nil)
+		assert.Equal(t, true, tx.Bucket(attestationTargetEpochIndicesBucket).Get(bytesutil.Bytes8(1)) == nil)
+		return nil
+	}))
+
+	stats, err = db.PruneFinalized(ctx, beforeSlot, false)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &iface.PruneStats{}, stats)
+}
+
+func TestStore_PruneFinalized_KeepsReplayBase(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+
+	db := setupDB(t)
+	ctx := context.Background()
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	blks := makeBlocks(t, 0, slotsPerEpoch*5, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i, blk := range blks {
+		r, err := blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+		roots[i] = r
+	}
+	for i := 0; i < len(blks); i += 8 {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(types.Slot(i)))
+		require.NoError(t, db.SaveState(ctx, st, roots[i]))
+	}
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 4, Root: roots[4*slotsPerEpoch][:]}))
+
+	// The states above slot 100 are replayed from the state at slot 96, which is kept along with
+	// the blocks above it.
+	_, err := db.PruneFinalized(ctx, 100, false)
+	require.NoError(t, err)
+	assert.Equal(t, true, db.HasState(ctx, roots[96]))
+	assert.Equal(t, false, db.HasState(ctx, roots[88]))
+	for i := 95; i < 100; i++ {
+		assert.Equal(t, true, db.HasBlock(ctx, roots[i]), "Missing block at slot %d", i+1)
+	}
+	assert.Equal(t, false, db.HasBlock(ctx, roots[93]))
+
+	// The missing parents of the blocks kept at the cutoff are not reported by the integrity check,
+	// unlike those of the blocks above it.
+	unparented := makeBlocks(t, 150, 1, [32]byte{'x'})
+	require.NoError(t, db.SaveBlocks(ctx, unparented))
+	unparentedRoot, err := unparented[0].Block().HashTreeRoot()
+	require.NoError(t, err)
+	report, err := db.VerifyIntegrity(ctx, false)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][32]byte{unparentedRoot}, report.MissingParents)
+}
+
+func TestStore_PruneFinalized_KeepsStateDiffBases(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 128
+	blk.Block.ParentRoot = genesisBlockRoot[:]
+	require.NoError(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(blk)))
+	finalizedRoot, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 128, Root: finalizedRoot[:]}))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 4, Root: finalizedRoot[:]}))
+
+	// Two snapshots, with a diff each, of which only the second one is above the pruned slot.
+	roots := map[types.Slot][32]byte{8: {'a'}, 16: {'b'}, 40: {'c'}, 100: {'d'}}
+	for _, slot := range []types.Slot{8, 40} {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, db.SaveState(ctx, st, roots[slot]))
+	}
+	for slot, base := range map[types.Slot]types.Slot{16: 8, 100: 40} {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, db.SaveStateDiff(ctx, st, roots[slot], roots[base]))
+	}
+
+	stats, err := db.PruneFinalized(ctx, 64, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.States)
+	for slot, kept := range map[types.Slot]bool{8: false, 16: false, 40: true, 100: true} {
+		assert.Equal(t, kept, db.HasState(ctx, roots[slot]), "state at slot %d", slot)
+	}
+	st, err := db.State(ctx, roots[100])
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(100), st.Slot())
+}
@@ -18,7 +18,12 @@ var (
 	checkpointBucket        = []byte("check-point")
 	powchainBucket          = []byte("powchain")
 	stateValidatorsBucket   = []byte("state-validators")
+	stateDiffBucket         = []byte("state-diffs")
 	validatedTips           = []byte("validated-synced-tips")
+	lightClientUpdates      = []byte("light-client-updates")
+
+	// Blocks fetched by initial sync which are not processed yet.
+	initialSyncPendingBlocksBucket = []byte("initial-sync-pending-blocks")
 
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
@@ -29,6 +34,7 @@ var (
 	blockParentRootIndicesBucket        = []byte("block-parent-root-indices")
 	blockSlotIndicesBucket              = []byte("block-slot-indices")
 	stateSlotIndicesBucket              = []byte("state-slot-indices")
+	stateDiffBaseRootIndicesBucket      = []byte("state-diff-base-root-indices")
 	attestationHeadBlockRootBucket      = []byte("attestation-head-block-root-indices")
 	attestationSourceRootIndicesBucket  = []byte("attestation-source-root-indices")
 	attestationSourceEpochIndicesBucket = []byte("attestation-source-epoch-indices")
@@ -36,6 +42,7 @@ var (
 	attestationTargetEpochIndicesBucket = []byte("attestation-target-epoch-indices")
 	finalizedBlockRootsIndexBucket      = []byte("finalized-block-roots-index")
 	blockRootValidatorHashesBucket      = []byte("block-root-validator-hashes")
+	validatorIndicesBucket              = []byte("validator-indices")
 
 	// Specific item keys.
 	headBlockRootKey          = []byte("head-root")
@@ -44,13 +51,23 @@ var (
 	justifiedCheckpointKey    = []byte("justified-checkpoint")
 	finalizedCheckpointKey    = []byte("finalized-checkpoint")
 	powchainDataKey           = []byte("powchain-data")
+	depositSnapshotKey        = []byte("deposit-snapshot")
+	validatorIndicesCountKey  = []byte("validator-indices-count")
+	slotsPerArchivedPointKey  = []byte("slots-per-archived-point")
+	prunedSlotKey             = []byte("pruned-slot")
 
 	// Below keys are used to identify objects are to be fork compatible.
 	// Objects that are only compatible with specific forks should be prefixed with such keys.
 	altairKey    = []byte("altair")
 	bellatrixKey = []byte("merge")
+	// Bellatrix blocks saved without the transactions of their execution payload.
+	blindedBellatrixKey = []byte("blinded-merge")
 	// block root included in the beacon state used by weak subjectivity initial sync
 	originBlockRootKey = []byte("origin-block-root")
+	// block root of the lowest block filled in by backfill below the origin block
+	backfillBlockRootKey = []byte("backfill-block-root")
+	// block root of the last block of the last batch processed by initial sync
+	initialSyncProgressRootKey = []byte("initial-sync-progress-root")
 
 	// Deprecated: This index key was migrated in PR 6461. Do not use, except for migrations.
 	lastArchivedIndexKey = []byte("last-archived")
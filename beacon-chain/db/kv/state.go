@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/genesis"
 	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
@@ -20,7 +21,6 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
 	"github.com/prysmaticlabs/prysm/time/slots"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -35,7 +35,7 @@ func (s *Store) State(ctx context.Context, blockRoot [32]byte) (state.BeaconStat
 	}
 
 	if len(enc) == 0 {
-		return nil, nil
+		return s.stateFromDiff(ctx, blockRoot)
 	}
 	// get the validator entries of the state
 	valEntries, valErr := s.validatorEntries(ctx, blockRoot)
@@ -62,7 +62,7 @@ func (s *Store) GenesisState(ctx context.Context) (state.BeaconState, error) {
 	}
 
 	var st state.BeaconState
-	err = s.db.View(func(tx *bolt.Tx) error {
+	err = s.db.View(func(tx backend.Tx) error {
 		// Retrieve genesis block's signing root from blocks bucket,
 		// to look up what the genesis state is.
 		bucket := tx.Bucket(blocksBucket)
@@ -122,7 +122,7 @@ func (s *Store) SaveStates(ctx context.Context, states []state.ReadOnlyBeaconSta
 		multipleEncs[i] = stateBytes
 	}
 
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx backend.Tx) error {
 		bucket := tx.Bucket(stateBucket)
 		for i, rt := range blockRoots {
 			indicesByBucket := createStateIndicesFromStateSlot(ctx, states[i].Slot())
@@ -187,7 +187,7 @@ func (s *Store) SaveStatesEfficient(ctx context.Context, states []state.ReadOnly
 		validatorKeys[i] = snappy.Encode(nil, hashes)
 	}
 
-	if err := s.db.Update(func(tx *bolt.Tx) error {
+	if err := s.db.Update(func(tx backend.Tx) error {
 		bucket := tx.Bucket(stateBucket)
 		valIdxBkt := tx.Bucket(blockRootValidatorHashesBucket)
 		for i, rt := range blockRoots {
@@ -307,10 +307,10 @@ func (s *Store) HasState(ctx context.Context, blockRoot [32]byte) bool {
 	_, span := trace.StartSpan(ctx, "BeaconDB.HasState")
 	defer span.End()
 	hasState := false
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(stateBucket)
 		stBytes := bkt.Get(blockRoot[:])
-		if len(stBytes) > 0 {
+		if len(stBytes) > 0 || tx.Bucket(stateDiffBucket).Get(blockRoot[:]) != nil {
 			hasState = true
 		}
 		return nil
@@ -326,7 +326,7 @@ func (s *Store) DeleteState(ctx context.Context, blockRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeleteState")
 	defer span.End()
 
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx backend.Tx) error {
 		bkt := tx.Bucket(blocksBucket)
 		genesisBlockRoot := bkt.Get(genesisBlockRootKey)
 
@@ -356,6 +356,14 @@ func (s *Store) DeleteState(ctx context.Context, blockRoot [32]byte) error {
 			return errors.Wrap(err, "could not delete root for DB indices")
 		}
 
+		// A state saved as a diff has no full state nor validator entries.
+		if tx.Bucket(stateDiffBucket).Get(blockRoot[:]) != nil {
+			return deleteStateDiff(ctx, tx, blockRoot)
+		}
+		if hasStateDiffsBasedOn(tx, blockRoot) {
+			return errors.New("cannot delete a state which state diffs are based on")
+		}
+
 		ok, err := s.isStateValidatorMigrationOver()
 		if err != nil {
 			return err
@@ -512,7 +520,7 @@ func (s *Store) validatorEntries(ctx context.Context, blockRoot [32]byte) ([]*et
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.validatorEntries")
 	defer span.End()
 	var validatorEntries []*ethpb.Validator
-	err = s.db.View(func(tx *bolt.Tx) error {
+	err = s.db.View(func(tx backend.Tx) error {
 		// get the validator keys from the index bucket
 		idxBkt := tx.Bucket(blockRootValidatorHashesBucket)
 		valKey := idxBkt.Get(blockRoot[:])
@@ -572,7 +580,7 @@ func (s *Store) stateBytes(ctx context.Context, blockRoot [32]byte) ([]byte, err
 	_, span := trace.StartSpan(ctx, "BeaconDB.stateBytes")
 	defer span.End()
 	var dst []byte
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(stateBucket)
 		stBytes := bkt.Get(blockRoot[:])
 		if len(stBytes) == 0 {
@@ -590,7 +598,7 @@ func (s *Store) stateBytes(ctx context.Context, blockRoot [32]byte) ([]byte, err
 }
 
 // slotByBlockRoot retrieves the corresponding slot of the input block root.
-func (s *Store) slotByBlockRoot(ctx context.Context, tx *bolt.Tx, blockRoot []byte) (types.Slot, error) {
+func (s *Store) slotByBlockRoot(ctx context.Context, tx backend.Tx, blockRoot []byte) (types.Slot, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.slotByBlockRoot")
 	defer span.End()
 
@@ -607,6 +615,11 @@ func (s *Store) slotByBlockRoot(ctx context.Context, tx *bolt.Tx, blockRoot []by
 			bkt = tx.Bucket(stateBucket)
 			enc = bkt.Get(blockRoot)
 			if enc == nil {
+				// The slot of a state diff is saved along with it.
+				diff := tx.Bucket(stateDiffBucket).Get(blockRoot)
+				if len(diff) >= stateDiffHeaderLength {
+					return bytesutil.BytesToSlotBigEndian(diff[32:stateDiffHeaderLength]), nil
+				}
 				return 0, errors.New("state enc can't be nil")
 			}
 			// no need to construct the validator entries as it is not used here.
@@ -645,7 +658,7 @@ func (s *Store) HighestSlotStatesBelow(ctx context.Context, slot types.Slot) ([]
 	defer span.End()
 
 	var best []byte
-	if err := s.db.View(func(tx *bolt.Tx) error {
+	if err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(stateSlotIndicesBucket)
 		c := bkt.Cursor()
 		for s, root := c.First(); s != nil; s, root = c.Next() {
@@ -728,7 +741,7 @@ func (s *Store) CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint ty
 	}
 	deletedRoots := make([][32]byte, 0)
 
-	err = s.db.View(func(tx *bolt.Tx) error {
+	err = s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(stateSlotIndicesBucket)
 		return bkt.ForEach(func(k, v []byte) error {
 			if ctx.Err() != nil {
@@ -773,7 +786,7 @@ func (s *Store) isStateValidatorMigrationOver() (bool, error) {
 	// if the flag is not enabled, but the migration is over, then
 	// follow the new code path as if the flag is enabled.
 	returnFlag := false
-	if err := s.db.View(func(tx *bolt.Tx) error {
+	if err := s.db.View(func(tx backend.Tx) error {
 		mb := tx.Bucket(migrationsBucket)
 		b := mb.Get(migrationStateValidatorsKey)
 		returnFlag = bytes.Equal(b, migrationCompleted)
@@ -0,0 +1,475 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	v2 "github.com/prysmaticlabs/prysm/beacon-chain/state/v2"
+	v3 "github.com/prysmaticlabs/prysm/beacon-chain/state/v3"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// A state diff stores a state as the fields which differ from those of a base state saved in full,
+// so that the cold states in between two full snapshots only take a fraction of the space of a full
+// state. Lists, such as the validator registry, the balances or the participation flags, are stored
+// as their changed elements whenever that is smaller than the full list.
+//
+// A diff is saved after the block root of its base state and its slot, so that they can be read
+// without decoding the diff, and is indexed by the block root of its base state. It is encoded as a
+// sequence of operations on the fields of the base state, each made of the field number and the
+// operation followed by its arguments.
+const (
+	// The field is replaced by the value of the field in the encoded message which follows.
+	diffFieldSet byte = iota
+	// The field is cleared.
+	diffFieldClear
+	// The list is resized to the following length, then the following indexed elements are replaced.
+	diffListPatch
+)
+
+// Length of the base block root and slot saved before a state diff.
+const stateDiffHeaderLength = 40
+
+var errStateDiffForkMismatch = errors.New("state and base state are of different forks")
+
+// SaveStateDiff stores the state of the given block root as a diff against the state of the base
+// block root, which must be saved in full. The state is saved in full instead if it is not of the
+// same fork as the base state.
+func (s *Store) SaveStateDiff(ctx context.Context, st state.ReadOnlyBeaconState, blockRoot, baseRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveStateDiff")
+	defer span.End()
+	if st == nil || st.IsNil() {
+		return errors.New("nil state")
+	}
+	base, err := s.fullState(ctx, baseRoot)
+	if err != nil {
+		return err
+	}
+	if base == nil || base.IsNil() {
+		return errors.Errorf("no full state saved for base block root %#x", baseRoot)
+	}
+	baseProto, ok := base.InnerStateUnsafe().(proto.Message)
+	if !ok {
+		return errors.New("non valid inner base state")
+	}
+	stProto, ok := st.InnerStateUnsafe().(proto.Message)
+	if !ok {
+		return errors.New("non valid inner state")
+	}
+	diff, err := computeStateDiff(baseProto, stProto)
+	if errors.Is(err, errStateDiffForkMismatch) {
+		return s.SaveState(ctx, st, blockRoot)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not compute state diff")
+	}
+	enc := append(baseRoot[:], bytesutil.SlotToBytesBigEndian(st.Slot())...)
+	enc = append(enc, snappy.Encode(nil, diff)...)
+
+	return s.db.Update(func(tx backend.Tx) error {
+		indicesByBucket := createStateIndicesFromStateSlot(ctx, st.Slot())
+		indicesByBucket[string(stateDiffBaseRootIndicesBucket)] = baseRoot[:]
+		if err := deleteStateDiff(ctx, tx, blockRoot); err != nil {
+			return err
+		}
+		if err := updateValueForIndices(ctx, indicesByBucket, blockRoot[:], tx); err != nil {
+			return errors.Wrap(err, "could not update DB indices")
+		}
+		return tx.Bucket(stateDiffBucket).Put(blockRoot[:], enc)
+	})
+}
+
+// fullState returns the state of the given block root if it is saved in full.
+func (s *Store) fullState(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+	enc, err := s.stateBytes(ctx, blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	if len(enc) == 0 {
+		return nil, nil
+	}
+	valEntries, err := s.validatorEntries(ctx, blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	return s.unmarshalState(ctx, enc, valEntries)
+}
+
+// stateFromDiff reconstructs the state of the given block root from its diff and base state.
+// It returns nil if there is no diff saved for the block root.
+func (s *Store) stateFromDiff(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.stateFromDiff")
+	defer span.End()
+	var enc []byte
+	if err := s.db.View(func(tx backend.Tx) error {
+		enc = bytesutil.SafeCopyBytes(tx.Bucket(stateDiffBucket).Get(blockRoot[:]))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if enc == nil {
+		return nil, nil
+	}
+	if len(enc) < stateDiffHeaderLength {
+		return nil, errors.Errorf("invalid state diff length %d", len(enc))
+	}
+	baseRoot := bytesutil.ToBytes32(enc[:32])
+	diff, err := snappy.Decode(nil, enc[stateDiffHeaderLength:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress state diff")
+	}
+	base, err := s.fullState(ctx, baseRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get base state of state diff")
+	}
+	if base == nil || base.IsNil() {
+		return nil, errors.Errorf("base state %#x of state diff not found", baseRoot)
+	}
+	switch inner := base.CloneInnerState().(type) {
+	case *ethpb.BeaconState:
+		if err := applyStateDiff(inner, diff); err != nil {
+			return nil, err
+		}
+		return v1.InitializeFromProtoUnsafe(inner)
+	case *ethpb.BeaconStateAltair:
+		if err := applyStateDiff(inner, diff); err != nil {
+			return nil, err
+		}
+		return v2.InitializeFromProtoUnsafe(inner)
+	case *ethpb.BeaconStateBellatrix:
+		if err := applyStateDiff(inner, diff); err != nil {
+			return nil, err
+		}
+		return v3.InitializeFromProtoUnsafe(inner)
+	default:
+		return nil, errors.New("invalid inner base state")
+	}
+}
+
+// deleteStateDiff deletes the state diff of the given block root, if any, along with its entry in
+// the index of the state diffs by base root.
+func deleteStateDiff(ctx context.Context, tx backend.Tx, blockRoot [32]byte) error {
+	bkt := tx.Bucket(stateDiffBucket)
+	enc := bkt.Get(blockRoot[:])
+	if len(enc) < stateDiffHeaderLength {
+		return nil
+	}
+	indices := map[string][]byte{string(stateDiffBaseRootIndicesBucket): bytesutil.SafeCopyBytes(enc[:32])}
+	if err := deleteValueForIndices(ctx, indices, blockRoot[:], tx); err != nil {
+		return errors.Wrap(err, "could not delete root for DB indices")
+	}
+	return bkt.Delete(blockRoot[:])
+}
+
+// hasStateDiffsBasedOn returns whether any state diff is based on the state of the given block root.
+func hasStateDiffsBasedOn(tx backend.Tx, blockRoot [32]byte) bool {
+	return len(tx.Bucket(stateDiffBaseRootIndicesBucket).Get(blockRoot[:])) > 0
+}
+
+// hasKeptStateDiffsBasedOn returns whether any state diff based on the state of the given block root
+// is kept, according to its block root and slot.
+func hasKeptStateDiffsBasedOn(tx backend.Tx, blockRoot [32]byte, isKept func(root [32]byte, slot types.Slot) bool) bool {
+	roots := tx.Bucket(stateDiffBaseRootIndicesBucket).Get(blockRoot[:])
+	diffBkt := tx.Bucket(stateDiffBucket)
+	for i := 0; i+32 <= len(roots); i += 32 {
+		root := bytesutil.ToBytes32(roots[i : i+32])
+		enc := diffBkt.Get(root[:])
+		if len(enc) < stateDiffHeaderLength {
+			continue
+		}
+		if isKept(root, bytesutil.BytesToSlotBigEndian(enc[32:stateDiffHeaderLength])) {
+			return true
+		}
+	}
+	return false
+}
+
+// computeStateDiff returns the diff of the target state against the base state.
+func computeStateDiff(base, target proto.Message) ([]byte, error) {
+	b, t := base.ProtoReflect(), target.ProtoReflect()
+	if b.Descriptor().FullName() != t.Descriptor().FullName() {
+		return nil, errStateDiffForkMismatch
+	}
+	buf := new(bytes.Buffer)
+	fields := t.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !t.Has(fd) {
+			if b.Has(fd) {
+				writeUvarint(buf, uint64(fd.Number()))
+				buf.WriteByte(diffFieldClear)
+			}
+			continue
+		}
+		if b.Has(fd) && fieldsEqual(fd, b.Get(fd), t.Get(fd)) {
+			continue
+		}
+		single := t.New()
+		single.Set(fd, t.Get(fd))
+		full, err := proto.Marshal(single.Interface())
+		if err != nil {
+			return nil, err
+		}
+		patch, err := listPatch(fd, b.Get(fd), t.Get(fd))
+		if err != nil {
+			return nil, err
+		}
+		writeUvarint(buf, uint64(fd.Number()))
+		if patch != nil && len(patch) < len(full) {
+			buf.WriteByte(diffListPatch)
+			buf.Write(patch)
+			continue
+		}
+		buf.WriteByte(diffFieldSet)
+		writeUvarint(buf, uint64(len(full)))
+		buf.Write(full)
+	}
+	return buf.Bytes(), nil
+}
+
+// applyStateDiff applies the diff to the base state, which is modified in place.
+func applyStateDiff(base proto.Message, diff []byte) error {
+	m := base.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	r := bytes.NewReader(diff)
+	for r.Len() > 0 {
+		num, err := binary.ReadUvarint(r)
+		if err != nil {
+			return errors.Wrap(err, "could not read field number")
+		}
+		fd := fields.ByNumber(protoreflect.FieldNumber(num))
+		if fd == nil {
+			return errors.Errorf("unknown field number %d in state diff", num)
+		}
+		op, err := r.ReadByte()
+		if err != nil {
+			return errors.Wrap(err, "could not read operation")
+		}
+		switch op {
+		case diffFieldClear:
+			m.Clear(fd)
+		case diffFieldSet:
+			enc, err := readBytes(r)
+			if err != nil {
+				return err
+			}
+			single := m.New()
+			if err := proto.Unmarshal(enc, single.Interface()); err != nil {
+				return errors.Wrapf(err, "could not unmarshal field %s", fd.Name())
+			}
+			m.Set(fd, single.Get(fd))
+		case diffListPatch:
+			if err := applyListPatch(m, fd, r); err != nil {
+				return errors.Wrapf(err, "could not patch field %s", fd.Name())
+			}
+		default:
+			return errors.Errorf("unknown state diff operation %d", op)
+		}
+	}
+	return nil
+}
+
+// listPatch returns the patch from the base list to the target list, or nil if the field cannot be
+// patched. Bytes fields are patched as lists of bytes.
+func listPatch(fd protoreflect.FieldDescriptor, base, target protoreflect.Value) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if !fd.IsList() {
+		if fd.Kind() != protoreflect.BytesKind {
+			return nil, nil
+		}
+		b, t := base.Bytes(), target.Bytes()
+		changed := make([]int, 0)
+		for i := range t {
+			if i >= len(b) || b[i] != t[i] {
+				changed = append(changed, i)
+			}
+		}
+		writeUvarint(buf, uint64(len(t)))
+		writeUvarint(buf, uint64(len(changed)))
+		for _, i := range changed {
+			writeUvarint(buf, uint64(i))
+			buf.WriteByte(t[i])
+		}
+		return buf.Bytes(), nil
+	}
+	switch fd.Kind() {
+	case protoreflect.Uint64Kind, protoreflect.BytesKind, protoreflect.MessageKind:
+	default:
+		return nil, nil
+	}
+	b, t := base.List(), target.List()
+	changed := make([]int, 0)
+	for i := 0; i < t.Len(); i++ {
+		if i >= b.Len() || !valuesEqual(fd, b.Get(i), t.Get(i)) {
+			changed = append(changed, i)
+		}
+	}
+	writeUvarint(buf, uint64(t.Len()))
+	writeUvarint(buf, uint64(len(changed)))
+	for _, i := range changed {
+		writeUvarint(buf, uint64(i))
+		v := t.Get(i)
+		switch fd.Kind() {
+		case protoreflect.Uint64Kind:
+			writeUvarint(buf, v.Uint())
+		case protoreflect.BytesKind:
+			writeUvarint(buf, uint64(len(v.Bytes())))
+			buf.Write(v.Bytes())
+		case protoreflect.MessageKind:
+			enc, err := proto.Marshal(v.Message().Interface())
+			if err != nil {
+				return nil, err
+			}
+			writeUvarint(buf, uint64(len(enc)))
+			buf.Write(enc)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func applyListPatch(m protoreflect.Message, fd protoreflect.FieldDescriptor, r *bytes.Reader) error {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return err
+	}
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return err
+	}
+	if !fd.IsList() {
+		old := m.Get(fd).Bytes()
+		if length > uint64(len(old))+count {
+			return errors.Errorf("invalid patched length %d", length)
+		}
+		patched := make([]byte, length)
+		copy(patched, old)
+		for j := uint64(0); j < count; j++ {
+			i, err := binary.ReadUvarint(r)
+			if err != nil {
+				return err
+			}
+			if i >= length {
+				return errors.Errorf("patched index %d out of range", i)
+			}
+			if patched[i], err = r.ReadByte(); err != nil {
+				return err
+			}
+		}
+		m.Set(fd, protoreflect.ValueOfBytes(patched))
+		return nil
+	}
+
+	l := m.Mutable(fd).List()
+	if length > uint64(l.Len())+count {
+		return errors.Errorf("invalid patched length %d", length)
+	}
+	if uint64(l.Len()) > length {
+		l.Truncate(int(length))
+	}
+	for j := uint64(0); j < count; j++ {
+		i, err := binary.ReadUvarint(r)
+		if err != nil {
+			return err
+		}
+		var v protoreflect.Value
+		switch fd.Kind() {
+		case protoreflect.Uint64Kind:
+			u, err := binary.ReadUvarint(r)
+			if err != nil {
+				return err
+			}
+			v = protoreflect.ValueOfUint64(u)
+		case protoreflect.BytesKind:
+			b, err := readBytes(r)
+			if err != nil {
+				return err
+			}
+			v = protoreflect.ValueOfBytes(b)
+		case protoreflect.MessageKind:
+			enc, err := readBytes(r)
+			if err != nil {
+				return err
+			}
+			v = l.NewElement()
+			if err := proto.Unmarshal(enc, v.Message().Interface()); err != nil {
+				return err
+			}
+		default:
+			return errors.Errorf("list of kind %s cannot be patched", fd.Kind())
+		}
+		switch {
+		case i < uint64(l.Len()):
+			l.Set(int(i), v)
+		case i == uint64(l.Len()) && i < length:
+			l.Append(v)
+		default:
+			return errors.Errorf("patched index %d out of range", i)
+		}
+	}
+	if uint64(l.Len()) != length {
+		return errors.Errorf("patched list has length %d, expected %d", l.Len(), length)
+	}
+	return nil
+}
+
+// fieldsEqual returns whether the values of the field in two messages are equal.
+func fieldsEqual(fd protoreflect.FieldDescriptor, a, b protoreflect.Value) bool {
+	if !fd.IsList() {
+		return valuesEqual(fd, a, b)
+	}
+	la, lb := a.List(), b.List()
+	if la.Len() != lb.Len() {
+		return false
+	}
+	for i := 0; i < la.Len(); i++ {
+		if !valuesEqual(fd, la.Get(i), lb.Get(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// valuesEqual returns whether two singular values of the kind of the field are equal.
+func valuesEqual(fd protoreflect.FieldDescriptor, a, b protoreflect.Value) bool {
+	switch fd.Kind() {
+	case protoreflect.BytesKind:
+		return bytes.Equal(a.Bytes(), b.Bytes())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return proto.Equal(a.Message().Interface(), b.Message().Interface())
+	default:
+		return a.Interface() == b.Interface()
+	}
+}
+
+func writeUvarint(buf *bytes.Buffer, x uint64) {
+	var enc [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(enc[:], x)
+	buf.Write(enc[:n])
+}
+
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(r.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
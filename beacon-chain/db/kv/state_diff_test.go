@@ -0,0 +1,117 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/config/features"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestStore_SaveStateDiff(t *testing.T) {
+	for _, historical := range []bool{false, true} {
+		t.Run("historical state representation "+map[bool]string{false: "disabled", true: "enabled"}[historical], func(t *testing.T) {
+			resetCfg := features.InitWithReset(&features.Flags{EnableHistoricalSpaceRepresentation: historical})
+			defer resetCfg()
+			db := setupDB(t)
+			ctx := context.Background()
+
+			base, _ := util.DeterministicGenesisStateAltair(t, 64)
+			baseRoot := [32]byte{'a'}
+			require.NoError(t, db.SaveState(ctx, base, baseRoot))
+
+			st := base.Copy()
+			require.NoError(t, st.SetSlot(2048))
+			require.NoError(t, st.UpdateBalancesAtIndex(3, 31e9))
+			v, err := st.ValidatorAtIndex(5)
+			require.NoError(t, err)
+			v.EffectiveBalance = 31e9
+			v.Slashed = true
+			require.NoError(t, st.UpdateValidatorAtIndex(5, v))
+			require.NoError(t, st.AppendValidator(&ethpb.Validator{PublicKey: make([]byte, 48), WithdrawalCredentials: make([]byte, 32)}))
+			require.NoError(t, st.AppendBalance(32e9))
+			require.NoError(t, st.AppendInactivityScore(0))
+			require.NoError(t, st.AppendCurrentParticipationBits(7))
+			require.NoError(t, st.AppendPreviousParticipationBits(0))
+			require.NoError(t, st.UpdateRandaoMixesAtIndex(1, make([]byte, 32)))
+			root := [32]byte{'b'}
+			require.NoError(t, db.SaveStateDiff(ctx, st, root, baseRoot))
+			assert.Equal(t, true, db.HasState(ctx, root))
+
+			var diffSize, fullSize int
+			require.NoError(t, db.db.View(func(tx backend.Tx) error {
+				assert.Equal(t, 0, len(tx.Bucket(stateBucket).Get(root[:])))
+				diffSize = len(tx.Bucket(stateDiffBucket).Get(root[:]))
+				fullSize = len(tx.Bucket(stateBucket).Get(baseRoot[:]))
+				assert.DeepEqual(t, root[:], tx.Bucket(stateDiffBaseRootIndicesBucket).Get(baseRoot[:]))
+				return nil
+			}))
+			assert.Equal(t, true, diffSize > 0 && diffSize*10 < fullSize, "diff of %d bytes for a full state of %d bytes", diffSize, fullSize)
+
+			saved, err := db.State(ctx, root)
+			require.NoError(t, err)
+			require.DeepSSZEqual(t, st.InnerStateUnsafe(), saved.InnerStateUnsafe())
+			highest, err := db.HighestSlotStatesBelow(ctx, 2049)
+			require.NoError(t, err)
+			require.Equal(t, 1, len(highest))
+			assert.Equal(t, st.Slot(), highest[0].Slot())
+
+			require.ErrorContains(t, "state diffs are based on", db.DeleteState(ctx, baseRoot))
+			require.NoError(t, db.DeleteState(ctx, root))
+			assert.Equal(t, false, db.HasState(ctx, root))
+			require.NoError(t, db.db.View(func(tx backend.Tx) error {
+				assert.Equal(t, 0, len(tx.Bucket(stateDiffBaseRootIndicesBucket).Get(baseRoot[:])))
+				return nil
+			}))
+			require.NoError(t, db.DeleteState(ctx, baseRoot))
+			assert.Equal(t, false, db.HasState(ctx, baseRoot))
+		})
+	}
+}
+
+func TestStore_SaveStateDiff_DifferentFork(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	base, _ := util.DeterministicGenesisState(t, 16)
+	baseRoot := [32]byte{'a'}
+	require.NoError(t, db.SaveState(ctx, base, baseRoot))
+
+	st, _ := util.DeterministicGenesisStateAltair(t, 16)
+	root := [32]byte{'b'}
+	require.NoError(t, db.SaveStateDiff(ctx, st, root, baseRoot))
+	require.NoError(t, db.db.View(func(tx backend.Tx) error {
+		assert.Equal(t, true, len(tx.Bucket(stateBucket).Get(root[:])) > 0)
+		assert.Equal(t, 0, len(tx.Bucket(stateDiffBucket).Get(root[:])))
+		return nil
+	}))
+	saved, err := db.State(ctx, root)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, st.InnerStateUnsafe(), saved.InnerStateUnsafe())
+}
+
+func TestStore_SaveStateDiff_NoBaseState(t *testing.T) {
+	db := setupDB(t)
+	st, _ := util.DeterministicGenesisState(t, 16)
+	require.ErrorContains(t, "no full state saved", db.SaveStateDiff(context.Background(), st, [32]byte{'b'}, [32]byte{'a'}))
+}
+
+func TestComputeStateDiff_ClearedFields(t *testing.T) {
+	base, _ := util.DeterministicGenesisState(t, 16)
+	require.NoError(t, base.AppendEth1DataVotes(&ethpb.Eth1Data{DepositRoot: make([]byte, 32), BlockHash: make([]byte, 32)}))
+	require.NoError(t, base.AppendCurrentEpochAttestations(&ethpb.PendingAttestation{}))
+	target := base.Copy()
+	require.NoError(t, target.SetEth1DataVotes(nil))
+	require.NoError(t, target.RotateAttestations())
+
+	diff, err := computeStateDiff(base.InnerStateUnsafe().(proto.Message), target.InnerStateUnsafe().(proto.Message))
+	require.NoError(t, err)
+	patched := base.CloneInnerState().(*ethpb.BeaconState)
+	require.NoError(t, applyStateDiff(patched, diff))
+	require.DeepSSZEqual(t, target.InnerStateUnsafe(), patched)
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 
 	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -15,7 +15,7 @@ func (s *Store) ValidatedTips(ctx context.Context) (map[[32]byte]types.Slot, err
 	defer span.End()
 
 	valTips := make(map[[32]byte]types.Slot, 1)
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(validatedTips)
 
 		c := bkt.Cursor()
@@ -42,7 +42,7 @@ func (s *Store) UpdateValidatedTips(ctx context.Context, newVals map[[32]byte]ty
 		return err
 	}
 
-	updateErr := s.db.Update(func(tx *bolt.Tx) error {
+	updateErr := s.db.Update(func(tx backend.Tx) error {
 		bkt := tx.Bucket(validatedTips)
 
 		// Delete keys that are present and not in the new set.
@@ -0,0 +1,86 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"go.opencensus.io/trace"
+)
+
+// validatorIndicesBatchSize is the number of validators indexed per transaction, so that indexing
+// the whole registry does not hold the write lock of the database for long.
+var validatorIndicesBatchSize = uint64(10000)
+
+// SaveValidatorIndices indexes by public key the validators of the state which are not indexed yet.
+// The state is expected to be finalized: validator indices never change once assigned, so the
+// indices of the validators of a finalized state are final. The validators are indexed in batches,
+// each recording the count of indexed validators, so that an interrupted indexing resumes where it
+// stopped.
+func (s *Store) SaveValidatorIndices(ctx context.Context, st state.ReadOnlyBeaconState) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveValidatorIndices")
+	defer span.End()
+	if st == nil || st.IsNil() {
+		return errors.New("nil state")
+	}
+	num := uint64(st.NumValidators())
+	for {
+		done := false
+		if err := s.db.Update(func(tx backend.Tx) error {
+			count := uint64(0)
+			if enc := tx.Bucket(chainMetadataBucket).Get(validatorIndicesCountKey); enc != nil {
+				count = bytesutil.BytesToUint64BigEndian(enc)
+			}
+			if num <= count {
+				done = true
+				return nil
+			}
+			end := count + validatorIndicesBatchSize
+			if end > num {
+				end = num
+			}
+			bkt := tx.Bucket(validatorIndicesBucket)
+			for i := count; i < end; i++ {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				pubkey := st.PubkeyAtIndex(types.ValidatorIndex(i))
+				// A public key keeps the index it was first assigned.
+				if bkt.Get(pubkey[:]) != nil {
+					continue
+				}
+				if err := bkt.Put(pubkey[:], bytesutil.Uint64ToBytesBigEndian(i)); err != nil {
+					return err
+				}
+			}
+			return tx.Bucket(chainMetadataBucket).Put(validatorIndicesCountKey, bytesutil.Uint64ToBytesBigEndian(end))
+		}); err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// ValidatorIndices returns the indices of the validators of the given public keys which are indexed.
+// The public keys of validators which are not finalized yet are omitted.
+func (s *Store) ValidatorIndices(ctx context.Context, pubkeys [][fieldparams.BLSPubkeyLength]byte) (map[[fieldparams.BLSPubkeyLength]byte]types.ValidatorIndex, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ValidatorIndices")
+	defer span.End()
+	indices := make(map[[fieldparams.BLSPubkeyLength]byte]types.ValidatorIndex, len(pubkeys))
+	err := s.db.View(func(tx backend.Tx) error {
+		bkt := tx.Bucket(validatorIndicesBucket)
+		for _, pubkey := range pubkeys {
+			if enc := bkt.Get(pubkey[:]); enc != nil {
+				indices[pubkey] = types.ValidatorIndex(bytesutil.BytesToUint64BigEndian(enc))
+			}
+		}
+		return nil
+	})
+	return indices, err
+}
@@ -0,0 +1,58 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestStore_ValidatorIndices(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisState(t, 8)
+	pubkeys := make([][fieldparams.BLSPubkeyLength]byte, 0)
+	for i := types.ValidatorIndex(0); i < 8; i++ {
+		pubkeys = append(pubkeys, st.PubkeyAtIndex(i))
+	}
+	unknown := [fieldparams.BLSPubkeyLength]byte{'u'}
+
+	indices, err := db.ValidatorIndices(ctx, pubkeys)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(indices))
+
+	// The validators are indexed over several transactions.
+	batchSize := validatorIndicesBatchSize
+	validatorIndicesBatchSize = 3
+	defer func() {
+		validatorIndicesBatchSize = batchSize
+	}()
+	require.NoError(t, db.SaveValidatorIndices(ctx, st))
+	indices, err = db.ValidatorIndices(ctx, append(pubkeys, unknown))
+	require.NoError(t, err)
+	require.Equal(t, len(pubkeys), len(indices))
+	for i, pubkey := range pubkeys {
+		assert.Equal(t, types.ValidatorIndex(i), indices[pubkey])
+	}
+
+	// Only the validators added since the last finalized state are indexed, and a public key keeps
+	// the index it was first assigned.
+	next := st.Copy()
+	require.NoError(t, next.AppendValidator(&ethpb.Validator{PublicKey: unknown[:], WithdrawalCredentials: make([]byte, 32)}))
+	require.NoError(t, next.AppendValidator(&ethpb.Validator{PublicKey: pubkeys[3][:], WithdrawalCredentials: make([]byte, 32)}))
+	require.NoError(t, db.SaveValidatorIndices(ctx, next))
+	indices, err = db.ValidatorIndices(ctx, [][fieldparams.BLSPubkeyLength]byte{unknown, pubkeys[3]})
+	require.NoError(t, err)
+	assert.Equal(t, types.ValidatorIndex(8), indices[unknown])
+	assert.Equal(t, types.ValidatorIndex(3), indices[pubkeys[3]])
+	// Saving an older state is a no-op.
+	require.NoError(t, db.SaveValidatorIndices(ctx, st))
+	indices, err = db.ValidatorIndices(ctx, [][fieldparams.BLSPubkeyLength]byte{unknown})
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(indices))
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"os"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// MigrateBackend converts the beacon chain database of the data directory to the backend set
+// with the db-backend flag, from the other backend. The source database is left untouched.
+func MigrateBackend(cliCtx *cli.Context) error {
+	target, err := backend.ParseKind(cliCtx.String(cmd.DBBackendFlag.Name))
+	if err != nil {
+		return err
+	}
+	source := backend.Bolt
+	if target == backend.Bolt {
+		source = backend.Pebble
+	}
+	dir := path.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	if !backend.Exists(source, dir, kv.DatabaseFileName) {
+		return errors.Errorf("no %s database found in %s", source, dir)
+	}
+	if backend.Exists(target, dir, kv.DatabaseFileName) {
+		return errors.Errorf("a %s database already exists in %s", target, dir)
+	}
+
+	src, err := backend.Open(source, dir, kv.DatabaseFileName, nil)
+	if err != nil {
+		return errors.Wrapf(err, "could not open %s database", source)
+	}
+	defer func() {
+		if err := src.Close(); err != nil {
+			log.WithError(err).Error("Could not close source database")
+		}
+	}()
+	dst, err := backend.Open(target, dir, kv.DatabaseFileName, nil)
+	if err != nil {
+		return errors.Wrapf(err, "could not create %s database", target)
+	}
+
+	log.WithFields(logrus.Fields{
+		"from": source,
+		"to":   target,
+	}).Info("Migrating database, this may take a while")
+	start := time.Now()
+	copied, err := backend.Copy(cliCtx.Context, src, dst)
+	if closeErr := dst.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Do not leave a partial database behind, which would be opened by the beacon node.
+		if rmErr := os.RemoveAll(kv.KVStoreDatafilePath(dir, target)); rmErr != nil {
+			log.WithError(rmErr).Error("Could not remove partially migrated database")
+		}
+		return errors.Wrap(err, "could not migrate database")
+	}
+	log.WithFields(logrus.Fields{
+		"keys":    copied,
+		"elapsed": time.Since(start),
+	}).Info("Migrated database")
+	log.Infof("Start the beacon node with --%s=%s. The %s database at %s can be removed once the node runs fine",
+		cmd.DBBackendFlag.Name, target, source, kv.KVStoreDatafilePath(dir, source))
+	return nil
+}
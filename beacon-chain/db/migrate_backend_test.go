@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"flag"
+	"path"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/urfave/cli/v2"
+)
+
+func TestMigrateBackend(t *testing.T) {
+	logHook := logTest.NewGlobal()
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	dbDir := path.Join(dataDir, kv.BeaconNodeDbDirName)
+
+	boltDB, err := kv.NewKVStore(ctx, dbDir, &kv.Config{})
+	require.NoError(t, err)
+	head := util.NewBeaconBlock()
+	head.Block.Slot = 5000
+	require.NoError(t, boltDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(head)))
+	root, err := head.Block.HashTreeRoot()
+	require.NoError(t, err)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, boltDB.SaveState(ctx, st, root))
+	require.NoError(t, boltDB.SaveHeadBlockRoot(ctx, root))
+	require.NoError(t, boltDB.Close())
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, dataDir, "")
+	set.String(cmd.DBBackendFlag.Name, string(backend.Pebble), "")
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	require.NoError(t, MigrateBackend(cliCtx))
+	assert.LogsContain(t, logHook, "Migrated database")
+	// The source database is kept.
+	assert.Equal(t, true, backend.Exists(backend.Bolt, dbDir, kv.DatabaseFileName))
+	// The target database exists already.
+	assert.ErrorContains(t, "a pebble database already exists", MigrateBackend(cliCtx))
+
+	pebbleDB, err := kv.NewKVStore(ctx, dbDir, &kv.Config{Backend: backend.Pebble})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, pebbleDB.Close())
+	}()
+	headBlock, err := pebbleDB.HeadBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(5000), headBlock.Block().Slot(), "Migrated database has incorrect data")
+	assert.Equal(t, true, pebbleDB.HasState(ctx, root))
+}
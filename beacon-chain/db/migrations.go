@@ -0,0 +1,106 @@
+package db
+
+import (
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// ListMigrations logs the status of the schema migrations of the beacon chain database.
+func ListMigrations(cliCtx *cli.Context) error {
+	store, err := openExisting(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer closeStore(store)
+	statuses, err := store.Migrations(cliCtx.Context)
+	if err != nil {
+		return err
+	}
+	for _, st := range statuses {
+		logMigration(st).Info(st.Description)
+	}
+	return nil
+}
+
+// ApplyMigrations applies the pending schema migrations of the beacon chain database, which the
+// beacon node otherwise does when it starts. With the dry run flag, the migrations are run in
+// transactions which are rolled back, reporting the number of writes they would make.
+func ApplyMigrations(cliCtx *cli.Context) error {
+	store, err := openExisting(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer closeStore(store)
+	if !cliCtx.Bool(cmd.DBMigrationDryRunFlag.Name) {
+		return store.RunMigrations(cliCtx.Context)
+	}
+	pending, err := store.DryRunMigrations(cliCtx.Context)
+	if err != nil {
+		return err
+	}
+	for _, st := range pending {
+		logMigration(st).WithField("writes", st.Writes).Info("Migration would be applied")
+	}
+	if len(pending) == 0 {
+		log.Info("No pending migrations")
+	}
+	return nil
+}
+
+// RollbackMigrations reverts the schema migrations of the beacon chain database above the target
+// version, to downgrade the beacon node to a version expecting the older schema.
+func RollbackMigrations(cliCtx *cli.Context) error {
+	if !cliCtx.IsSet(cmd.DBMigrationTargetVersionFlag.Name) {
+		return errors.Errorf("--%s is required", cmd.DBMigrationTargetVersionFlag.Name)
+	}
+	store, err := openExisting(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer closeStore(store)
+	reverted, err := store.RollbackMigrations(cliCtx.Context, cliCtx.Uint64(cmd.DBMigrationTargetVersionFlag.Name))
+	if err != nil {
+		return err
+	}
+	for _, st := range reverted {
+		logMigration(st).Info("Rolled back migration")
+	}
+	return nil
+}
+
+func openExisting(cliCtx *cli.Context) (*kv.Store, error) {
+	// Some migrations only apply with the feature flags enabling them.
+	features.ConfigureBeaconChain(cliCtx)
+	kind, err := backend.ParseKind(cliCtx.String(cmd.DBBackendFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	dir := path.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	if !backend.Exists(kind, dir, kv.DatabaseFileName) {
+		return nil, errors.Errorf("no %s database found in %s", kind, dir)
+	}
+	return kv.NewKVStore(cliCtx.Context, dir, &kv.Config{Backend: kind, ReadOnly: cliCtx.Bool(cmd.DBReadOnlyFlag.Name)})
+}
+
+func closeStore(store *kv.Store) {
+	if err := store.Close(); err != nil {
+		log.WithError(err).Error("Could not close database")
+	}
+}
+
+func logMigration(st *kv.MigrationStatus) *logrus.Entry {
+	return log.WithFields(logrus.Fields{
+		"version":    st.Version,
+		"name":       st.Name,
+		"applied":    st.Applied,
+		"enabled":    st.Enabled,
+		"reversible": st.Reversible,
+	})
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"flag"
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/urfave/cli/v2"
+)
+
+func TestMigrations(t *testing.T) {
+	logHook := logTest.NewGlobal()
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	store, err := kv.NewKVStore(ctx, path.Join(dataDir, kv.BeaconNodeDbDirName), &kv.Config{})
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, dataDir, "")
+	set.Bool(cmd.DBMigrationDryRunFlag.Name, true, "")
+	set.Uint64(cmd.DBMigrationTargetVersionFlag.Name, 1, "")
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	require.NoError(t, ApplyMigrations(cliCtx))
+	assert.LogsContain(t, logHook, "Migration would be applied")
+	require.NoError(t, ListMigrations(cliCtx))
+	assert.LogsContain(t, logHook, "applied=false")
+
+	require.NoError(t, set.Set(cmd.DBMigrationDryRunFlag.Name, "false"))
+	require.NoError(t, ApplyMigrations(cliCtx))
+	logHook.Reset()
+	require.NoError(t, ApplyMigrations(cliCtx))
+	assert.LogsDoNotContain(t, logHook, "Migrating database")
+
+	require.ErrorContains(t, "--target-version is required", RollbackMigrations(cli.NewContext(&app, flag.NewFlagSet("test", 0), nil)))
+	require.NoError(t, set.Set(cmd.DBMigrationTargetVersionFlag.Name, "1"))
+	require.NoError(t, RollbackMigrations(cliCtx))
+	assert.LogsContain(t, logHook, "Rolled back migration")
+}
@@ -0,0 +1,25 @@
+package pruner
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	prunedBlocksCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pruner_blocks_count",
+		Help: "Number of finalized blocks pruned from the database",
+	})
+	prunedStatesCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pruner_states_count",
+		Help: "Number of finalized states pruned from the database",
+	})
+	prunedAttestationsCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pruner_attestations_count",
+		Help: "Number of archived attestations pruned from the database",
+	})
+	prunedBytesCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pruner_freed_bytes_count",
+		Help: "Number of bytes of keys and values pruned from the database",
+	})
+)
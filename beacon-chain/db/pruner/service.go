@@ -0,0 +1,120 @@
+// Package pruner deletes finalized blocks, states and archived attestations older than a
+// retention period from the beacon database, so that its size stays bounded.
+package pruner
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/async"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/runtime"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var _ runtime.Service = (*Service)(nil)
+
+// Config to set up the pruner service.
+type Config struct {
+	DB db.NoHeadAccessDatabase
+	// Number of finalized epochs, counted back from the finalized checkpoint, to keep.
+	RetentionEpochs types.Epoch
+	// Only report what would be pruned.
+	DryRun bool
+}
+
+// Service prunes the database once per epoch, below the finalized checkpoint minus the
+// configured retention.
+type Service struct {
+	cfg        *Config
+	ctx        context.Context
+	cancel     context.CancelFunc
+	prunedSlot types.Slot
+}
+
+// NewService configures the pruner service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start the pruner service.
+func (s *Service) Start() {
+	log.WithFields(logrus.Fields{
+		"retentionEpochs": s.cfg.RetentionEpochs,
+		"dryRun":          s.cfg.DryRun,
+	}).Info("Pruning finalized data from the database")
+	s.run()
+	period := time.Duration(params.BeaconConfig().SecondsPerSlot*uint64(params.BeaconConfig().SlotsPerEpoch)) * time.Second
+	async.RunEvery(s.ctx, period, s.run)
+}
+
+// Stop the pruner service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the pruner service.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	if err := s.prune(); err != nil {
+		if errors.Is(s.ctx.Err(), context.Canceled) {
+			return
+		}
+		log.WithError(err).Error("Could not prune database")
+	}
+}
+
+func (s *Service) prune() error {
+	f, err := s.cfg.DB.FinalizedCheckpoint(s.ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	if f.Epoch <= s.cfg.RetentionEpochs {
+		return nil
+	}
+	cutoff, err := slots.EpochStart(f.Epoch - s.cfg.RetentionEpochs)
+	if err != nil {
+		return err
+	}
+	if cutoff <= s.prunedSlot {
+		return nil
+	}
+	start := time.Now()
+	stats, err := s.cfg.DB.PruneFinalized(s.ctx, cutoff, s.cfg.DryRun)
+	if err != nil {
+		return err
+	}
+	s.prunedSlot = cutoff
+
+	fields := logrus.Fields{
+		"beforeSlot":   cutoff,
+		"blocks":       stats.Blocks,
+		"states":       stats.States,
+		"attestations": stats.Attestations,
+		"bytes":        stats.Bytes,
+		"duration":     time.Since(start),
+	}
+	if s.cfg.DryRun {
+		log.WithFields(fields).Info("Pruning dry run")
+		return nil
+	}
+	prunedBlocksCount.Add(float64(stats.Blocks))
+	prunedStatesCount.Add(float64(stats.States))
+	prunedAttestationsCount.Add(float64(stats.Attestations))
+	prunedBytesCount.Add(float64(stats.Bytes))
+	log.WithFields(fields).Info("Pruned finalized data")
+	return nil
+}
@@ -0,0 +1,67 @@
+package pruner
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestService_Prune(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	var roots [][32]byte
+	parentRoot := [32]byte{}
+	for slot := types.Slot(1); slot <= 4*slotsPerEpoch; slot++ {
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = slot
+		blk.Block.ParentRoot = bytesutil.SafeCopyBytes(parentRoot[:])
+		require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(blk)))
+		r, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		roots = append(roots, r)
+		parentRoot = r
+	}
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, roots[0]))
+	finalizedRoot := roots[3*slotsPerEpoch-1]
+	// The states at the epoch boundaries, which the states above them are replayed from.
+	for _, slot := range []types.Slot{2 * slotsPerEpoch, 3 * slotsPerEpoch} {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, beaconDB.SaveState(ctx, st, roots[slot-1]))
+	}
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: finalizedRoot[:]}))
+
+	t.Run("retention above finalized epoch", func(t *testing.T) {
+		s := NewService(ctx, &Config{DB: beaconDB, RetentionEpochs: 3})
+		require.NoError(t, s.prune())
+		assert.Equal(t, types.Slot(0), s.prunedSlot)
+	})
+	t.Run("dry run", func(t *testing.T) {
+		s := NewService(ctx, &Config{DB: beaconDB, RetentionEpochs: 1, DryRun: true})
+		require.NoError(t, s.prune())
+		assert.Equal(t, 2*slotsPerEpoch, s.prunedSlot)
+		assert.Equal(t, true, beaconDB.HasBlock(ctx, roots[1]))
+	})
+	t.Run("prune", func(t *testing.T) {
+		s := NewService(ctx, &Config{DB: beaconDB, RetentionEpochs: 1})
+		require.NoError(t, s.prune())
+		assert.Equal(t, 2*slotsPerEpoch, s.prunedSlot)
+		// The genesis block and the first finalized block of the era are kept.
+		assert.Equal(t, true, beaconDB.HasBlock(ctx, roots[0]))
+		assert.Equal(t, true, beaconDB.HasBlock(ctx, roots[1]))
+		assert.Equal(t, false, beaconDB.HasBlock(ctx, roots[2]))
+		assert.Equal(t, true, beaconDB.HasBlock(ctx, roots[2*slotsPerEpoch-1]))
+	})
+}
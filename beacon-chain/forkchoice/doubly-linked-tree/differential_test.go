@@ -0,0 +1,160 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+// This applies the same random sequence of blocks, votes, balance changes, proposer boosts
+// and prunes to the doubly linked tree and to the proto array fork choice stores, and
+// checks that both stores agree on the head and the canonical chain after every step.
+func TestForkChoice_MatchesProtoArray(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		testMatchesProtoArray(t, seed)
+	}
+}
+
+func testMatchesProtoArray(t *testing.T, seed int64) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(seed))
+	zeroHash := params.BeaconConfig().ZeroHash
+
+	f := New(0, 0)
+	p := protoarray.New(0, 0, zeroHash)
+	require.NoError(t, f.ProcessBlock(ctx, 0, zeroHash, zeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, p.ProcessBlock(ctx, 0, zeroHash, zeroHash, [32]byte{}, 0, 0))
+
+	balances := make([]uint64, 128)
+	for i := range balances {
+		balances[i] = uint64(r.Intn(32) + 1)
+	}
+	roots := [][32]byte{zeroHash}
+	slotByRoot := map[[32]byte]types.Slot{zeroHash: 0}
+	justifiedRoot := zeroHash
+	headRoot := zeroHash
+	targetEpoch := types.Epoch(0)
+	blockCount := uint64(0)
+
+	for step := 0; step < 300; step++ {
+		switch op := r.Intn(10); {
+		case op < 4:
+			blockCount++
+			parent := roots[r.Intn(len(roots))]
+			slot := slotByRoot[parent] + types.Slot(r.Intn(3)+1)
+			root := indexToHash(blockCount)
+			require.NoError(t, f.ProcessBlock(ctx, slot, root, parent, [32]byte{}, 0, 0))
+			require.NoError(t, p.ProcessBlock(ctx, slot, root, parent, [32]byte{}, 0, 0))
+			roots = append(roots, root)
+			slotByRoot[root] = slot
+			if r.Intn(3) == 0 {
+				secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+				genesis := time.Now().Add(-time.Duration(uint64(slot)*secondsPerSlot) * time.Second)
+				require.NoError(t, f.BoostProposerRoot(ctx, slot, root, genesis))
+				require.NoError(t, p.BoostProposerRoot(ctx, slot, root, genesis))
+			}
+		case op < 7:
+			targetEpoch++
+			root := roots[r.Intn(len(roots))]
+			indices := make([]uint64, 0)
+			for i := range balances {
+				if r.Intn(4) == 0 {
+					indices = append(indices, uint64(i))
+				}
+			}
+			f.ProcessAttestation(ctx, indices, root, targetEpoch)
+			p.ProcessAttestation(ctx, indices, root, targetEpoch)
+		case op < 8:
+			balances = append([]uint64{}, balances...)
+			balances[r.Intn(len(balances))] = uint64(r.Intn(32) + 1)
+		case op < 9:
+			require.NoError(t, f.ResetBoostedProposerRoot(ctx))
+			require.NoError(t, p.ResetBoostedProposerRoot(ctx))
+		default:
+			// Finalize an ancestor of the head, the pruned blocks are no longer used.
+			slot := slotByRoot[justifiedRoot] + (slotByRoot[headRoot]-slotByRoot[justifiedRoot])/2
+			ancestor, err := f.AncestorRoot(ctx, headRoot, slot)
+			require.NoError(t, err)
+			justifiedRoot = bytesutil.ToBytes32(ancestor)
+			require.NoError(t, f.Prune(ctx, justifiedRoot))
+			require.NoError(t, p.Prune(ctx, justifiedRoot))
+			kept := make([][32]byte, 0, len(roots))
+			for _, root := range roots {
+				if f.HasNode(root) {
+					kept = append(kept, root)
+				}
+			}
+			roots = kept
+		}
+
+		// A head computation cancelled part way must leave the pending weight changes to the next one.
+		if r.Intn(4) == 0 {
+			cancelCtx := &cancelAfterContext{Context: ctx, after: r.Intn(4)}
+			_, _ = f.Head(cancelCtx, 0, justifiedRoot, balances, 0)
+		}
+
+		var err error
+		headRoot, err = f.Head(ctx, 0, justifiedRoot, balances, 0)
+		require.NoError(t, err)
+		want, err := p.Head(ctx, 0, justifiedRoot, balances, 0)
+		require.NoError(t, err)
+		require.Equal(t, want, headRoot, "seed %d, step %d: heads differ", seed, step)
+		assert.Equal(t, p.ProposerBoost(), f.ProposerBoost())
+
+		for _, root := range roots {
+			require.Equal(t, true, p.HasNode(root))
+			if root != zeroHash {
+				assert.Equal(t, p.Node(root).Weight(), f.store.nodeByRoot[root].weight, "seed %d, step %d: weights differ", seed, step)
+			}
+			assert.Equal(t, p.IsCanonical(root), f.IsCanonical(root), "seed %d, step %d: canonical status differs", seed, step)
+		}
+		root := roots[r.Intn(len(roots))]
+		slot := types.Slot(r.Intn(int(slotByRoot[root]) + 1))
+		if slot < slotByRoot[justifiedRoot] {
+			slot = slotByRoot[justifiedRoot]
+		}
+		got, err := f.AncestorRoot(ctx, root, slot)
+		require.NoError(t, err)
+		wantAncestor, err := p.AncestorRoot(ctx, root, slot)
+		require.NoError(t, err)
+		require.DeepEqual(t, wantAncestor, got, "seed %d, step %d: ancestors differ", seed, step)
+
+		wantTips := make(map[[32]byte]types.Slot)
+		tipRoots, tipSlots := p.Tips()
+		for i := range tipRoots {
+			if f.HasNode(tipRoots[i]) {
+				wantTips[tipRoots[i]] = tipSlots[i]
+			}
+		}
+		gotTips := make(map[[32]byte]types.Slot)
+		tipRoots, tipSlots = f.Tips()
+		for i := range tipRoots {
+			gotTips[tipRoots[i]] = tipSlots[i]
+		}
+		require.DeepEqual(t, wantTips, gotTips, "seed %d, step %d: tips differ", seed, step)
+	}
+}
+
+// cancelAfterContext reports the context as cancelled once its error has been checked more than
+// the given number of times, to cancel a call part way.
+type cancelAfterContext struct {
+	context.Context
+	after int
+	calls int
+}
+
+func (c *cancelAfterContext) Err() error {
+	c.calls++
+	if c.calls > c.after {
+		return context.Canceled
+	}
+	return c.Context.Err()
+}
@@ -0,0 +1,9 @@
+/*
+Package doublylinkedtree implements fork choice as a tree of block nodes, where every node links
+to its parent and to its children. When head is requested, the balance changes of the voted
+nodes are applied to the weights and the best descendants of these nodes and of their ancestors,
+the rest of the tree is left untouched, and head is the best descendant of the justified node.
+Pruning upon finalization drops the subtrees which do not descend from
+the finalized node without moving the remaining nodes, unlike the array based proto array store.
+*/
+package doublylinkedtree
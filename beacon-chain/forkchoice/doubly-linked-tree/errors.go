@@ -0,0 +1,11 @@
+package doublylinkedtree
+
+import "errors"
+
+var errUnknownFinalizedRoot = errors.New("unknown finalized root")
+var errUnknownJustifiedRoot = errors.New("unknown justified root")
+var errInvalidNodeRoot = errors.New("node root is invalid")
+var errInvalidParentRoot = errors.New("parent root is not in fork choice store")
+var errNotLeafNode = errors.New("node is not a leaf of the fork choice store")
+var errInvalidSyncedTips = errors.New("invalid synced tips")
+var errUnknownCommonAncestor = errors.New("unknown common ancestor")
@@ -0,0 +1,121 @@
+package doublylinkedtree
+
+import (
+	"bytes"
+	"context"
+
+	types "github.com/prysmaticlabs/eth2-types"
+)
+
+// Slot of the fork choice node.
+func (n *Node) Slot() types.Slot {
+	return n.slot
+}
+
+// Root of the fork choice node.
+func (n *Node) Root() [32]byte {
+	return n.root
+}
+
+// Parent of the fork choice node.
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// JustifiedEpoch of the fork choice node.
+func (n *Node) JustifiedEpoch() types.Epoch {
+	return n.justifiedEpoch
+}
+
+// FinalizedEpoch of the fork choice node.
+func (n *Node) FinalizedEpoch() types.Epoch {
+	return n.finalizedEpoch
+}
+
+// Weight of the fork choice node.
+func (n *Node) Weight() uint64 {
+	return n.weight
+}
+
+// BestDescendant of the fork choice node.
+func (n *Node) BestDescendant() *Node {
+	return n.bestDescendant
+}
+
+// Graffiti of the fork choice node.
+func (n *Node) Graffiti() [32]byte {
+	return n.graffiti
+}
+
+// updateBestDescendant recomputes the best descendant of the node and of all its
+// descendants, the children being updated before their parent.
+func (n *Node) updateBestDescendant(ctx context.Context, justifiedEpoch, finalizedEpoch types.Epoch) error {
+	for _, child := range n.children {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := child.updateBestDescendant(ctx, justifiedEpoch, finalizedEpoch); err != nil {
+			return err
+		}
+	}
+	n.chooseBestDescendant(justifiedEpoch, finalizedEpoch)
+	return nil
+}
+
+// chooseBestDescendant sets the best descendant of the node from the best descendants of
+// its children, which are assumed to be up to date. The best child is the heaviest child
+// leading to a viable head, ties are broken by the highest root.
+func (n *Node) chooseBestDescendant(justifiedEpoch, finalizedEpoch types.Epoch) {
+	var bestChild *Node
+	for _, child := range n.children {
+		if !child.leadsToViableHead(justifiedEpoch, finalizedEpoch) {
+			continue
+		}
+		if bestChild == nil || child.weight > bestChild.weight ||
+			(child.weight == bestChild.weight && bytes.Compare(child.root[:], bestChild.root[:]) > 0) {
+			bestChild = child
+		}
+	}
+	switch {
+	case bestChild == nil:
+		n.bestDescendant = nil
+	case bestChild.bestDescendant == nil:
+		n.bestDescendant = bestChild
+	default:
+		n.bestDescendant = bestChild.bestDescendant
+	}
+}
+
+// bestChild returns the child of the node leading to its best descendant, or nil if
+// the node has no best descendant.
+func (n *Node) bestChild() *Node {
+	if n.bestDescendant == nil {
+		return nil
+	}
+	for _, child := range n.children {
+		if child == n.bestDescendant || child.bestDescendant == n.bestDescendant {
+			return child
+		}
+	}
+	return nil
+}
+
+// leadsToViableHead returns true if the node or the best descendant of the node is viable for head.
+func (n *Node) leadsToViableHead(justifiedEpoch, finalizedEpoch types.Epoch) bool {
+	if n.bestDescendant != nil && n.bestDescendant.viableForHead(justifiedEpoch, finalizedEpoch) {
+		return true
+	}
+	return n.viableForHead(justifiedEpoch, finalizedEpoch)
+}
+
+// viableForHead returns true if the node is viable to head.
+// Any node with diff finalized or justified epoch than the ones in fork choice store
+// should not be viable to head.
+func (n *Node) viableForHead(justifiedEpoch, finalizedEpoch types.Epoch) bool {
+	// `n` is viable if its justified epoch and finalized epoch are the same as the one in `Store`.
+	// It's also viable if we are in genesis epoch.
+	justified := justifiedEpoch == n.justifiedEpoch || justifiedEpoch == 0
+	finalized := finalizedEpoch == n.finalizedEpoch || finalizedEpoch == 0
+
+	return justified && finalized
+}
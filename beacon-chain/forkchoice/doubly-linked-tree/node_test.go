@@ -0,0 +1,177 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestNode_Getters(t *testing.T) {
+	slot := types.Slot(100)
+	root := [32]byte{'a'}
+	parent := &Node{}
+	jEpoch := types.Epoch(20)
+	fEpoch := types.Epoch(30)
+	weight := uint64(10000)
+	bestDescendant := &Node{}
+	graffiti := [32]byte{'b'}
+	n := &Node{
+		slot:           slot,
+		root:           root,
+		parent:         parent,
+		justifiedEpoch: jEpoch,
+		finalizedEpoch: fEpoch,
+		weight:         weight,
+		bestDescendant: bestDescendant,
+		graffiti:       graffiti,
+	}
+
+	require.Equal(t, slot, n.Slot())
+	require.Equal(t, root, n.Root())
+	require.Equal(t, parent, n.Parent())
+	require.Equal(t, jEpoch, n.JustifiedEpoch())
+	require.Equal(t, fEpoch, n.FinalizedEpoch())
+	require.Equal(t, weight, n.Weight())
+	require.Equal(t, bestDescendant, n.BestDescendant())
+	require.Equal(t, graffiti, n.Graffiti())
+}
+
+func TestStore_ApplyWeightChanges(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(2), indexToHash(1), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(3), indexToHash(1), [32]byte{}, 0, 0))
+
+	s := f.store
+	s.setBalance(s.nodeByRoot[indexToHash(1)], 10)
+	s.setBalance(s.nodeByRoot[indexToHash(2)], 20)
+	s.setBalance(s.nodeByRoot[indexToHash(3)], 30)
+	changed, err := s.applyWeightChanges(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(changed))
+
+	assert.Equal(t, uint64(0), s.nodeByRoot[params.BeaconConfig().ZeroHash].weight, "Genesis alias should not be weighed")
+	assert.Equal(t, uint64(60), s.nodeByRoot[indexToHash(1)].weight)
+	assert.Equal(t, uint64(20), s.nodeByRoot[indexToHash(2)].weight)
+	assert.Equal(t, uint64(30), s.nodeByRoot[indexToHash(3)].weight)
+
+	// Only the changes since the last update are applied, the unchanged nodes are not returned.
+	s.setBalance(s.nodeByRoot[indexToHash(2)], 5)
+	s.setBalance(s.nodeByRoot[indexToHash(3)], 40)
+	s.setBalance(s.nodeByRoot[indexToHash(3)], 30)
+	changed, err = s.applyWeightChanges(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(changed))
+	assert.Equal(t, s.nodeByRoot[indexToHash(2)], changed[0])
+	assert.Equal(t, uint64(45), s.nodeByRoot[indexToHash(1)].weight)
+	assert.Equal(t, uint64(5), s.nodeByRoot[indexToHash(2)].weight)
+	assert.Equal(t, uint64(30), s.nodeByRoot[indexToHash(3)].weight)
+}
+
+func TestStore_UpdateBestDescendants(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(2), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	require.NoError(t, f.ProcessBlock(ctx, 3, indexToHash(3), indexToHash(1), [32]byte{}, 1, 1))
+	require.NoError(t, f.ProcessBlock(ctx, 4, indexToHash(4), indexToHash(3), [32]byte{}, 1, 1))
+
+	// The insertions update the best descendants of all the ancestors.
+	s := f.store
+	assert.Equal(t, s.nodeByRoot[indexToHash(4)], s.nodeByRoot[indexToHash(1)].bestDescendant)
+
+	s.setBalance(s.nodeByRoot[indexToHash(4)], 5)
+	changed, err := s.applyWeightChanges(ctx)
+	require.NoError(t, err)
+	s.updateBestDescendants(changed)
+	assert.Equal(t, s.nodeByRoot[indexToHash(4)], s.treeRootNode.bestDescendant)
+
+	s.setBalance(s.nodeByRoot[indexToHash(2)], 10)
+	changed, err = s.applyWeightChanges(ctx)
+	require.NoError(t, err)
+	s.updateBestDescendants(changed)
+	assert.Equal(t, s.nodeByRoot[indexToHash(2)], s.treeRootNode.bestDescendant)
+
+	s.setBalance(s.nodeByRoot[indexToHash(4)], 20)
+	changed, err = s.applyWeightChanges(ctx)
+	require.NoError(t, err)
+	s.updateBestDescendants(changed)
+	assert.Equal(t, s.nodeByRoot[indexToHash(4)], s.treeRootNode.bestDescendant)
+	assert.Equal(t, s.nodeByRoot[indexToHash(1)], s.treeRootNode.bestChild())
+}
+
+func TestNode_UpdateBestDescendant_HeaviestChild(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(2), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	require.NoError(t, f.ProcessBlock(ctx, 3, indexToHash(3), indexToHash(1), [32]byte{}, 1, 1))
+
+	s := f.store
+	s.nodeByRoot[indexToHash(1)].weight = 20
+	s.nodeByRoot[indexToHash(2)].weight = 10
+	require.NoError(t, s.treeRootNode.updateBestDescendant(ctx, 1, 1))
+	assert.Equal(t, s.nodeByRoot[indexToHash(3)], s.treeRootNode.bestDescendant)
+
+	s.nodeByRoot[indexToHash(2)].weight = 30
+	require.NoError(t, s.treeRootNode.updateBestDescendant(ctx, 1, 1))
+	assert.Equal(t, s.nodeByRoot[indexToHash(2)], s.treeRootNode.bestDescendant)
+	assert.Equal(t, s.nodeByRoot[indexToHash(2)], s.treeRootNode.bestChild())
+}
+
+func TestNode_UpdateBestDescendant_NonViableChild(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(2), params.BeaconConfig().ZeroHash, [32]byte{}, 2, 1))
+
+	s := f.store
+	s.nodeByRoot[indexToHash(2)].weight = 100
+	require.NoError(t, s.treeRootNode.updateBestDescendant(ctx, 1, 1))
+	assert.Equal(t, s.nodeByRoot[indexToHash(1)], s.treeRootNode.bestDescendant)
+
+	require.NoError(t, s.treeRootNode.updateBestDescendant(ctx, 2, 1))
+	assert.Equal(t, s.nodeByRoot[indexToHash(2)], s.treeRootNode.bestDescendant)
+}
+
+func TestNode_UpdateBestDescendant_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := setup(0, 0)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	cancel()
+	err := f.store.treeRootNode.updateBestDescendant(ctx, 0, 0)
+	require.ErrorContains(t, "context canceled", err)
+}
+
+func TestNode_ViableForHead(t *testing.T) {
+	tests := []struct {
+		n    *Node
+		j    types.Epoch
+		f    types.Epoch
+		want bool
+	}{
+		{&Node{}, 0, 0, true},
+		{&Node{}, 1, 0, false},
+		{&Node{}, 0, 1, false},
+		{&Node{finalizedEpoch: 1, justifiedEpoch: 1}, 1, 1, true},
+		{&Node{finalizedEpoch: 1, justifiedEpoch: 1}, 2, 2, false},
+		{&Node{finalizedEpoch: 3, justifiedEpoch: 4}, 4, 3, true},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, tc.n.viableForHead(tc.j, tc.f))
+	}
+}
+
+func TestNode_LeadsToViableHead(t *testing.T) {
+	viable := &Node{justifiedEpoch: 1, finalizedEpoch: 1}
+	n := &Node{bestDescendant: viable}
+	assert.Equal(t, true, n.leadsToViableHead(1, 1))
+	n.bestDescendant = nil
+	assert.Equal(t, false, n.leadsToViableHead(1, 1))
+	assert.Equal(t, true, viable.leadsToViableHead(1, 1))
+}
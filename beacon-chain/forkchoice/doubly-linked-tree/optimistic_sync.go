@@ -0,0 +1,257 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"fmt"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+)
+
+// This returns the minimum and maximum slot of the synced_tips tree
+func (f *ForkChoice) boundarySyncedTips() (types.Slot, types.Slot) {
+	f.syncedTips.RLock()
+	defer f.syncedTips.RUnlock()
+
+	min := params.BeaconConfig().FarFutureSlot
+	max := types.Slot(0)
+	for _, slot := range f.syncedTips.validatedTips {
+		if slot > max {
+			max = slot
+		}
+		if slot < min {
+			min = slot
+		}
+	}
+	return min, max
+}
+
+// isSyncedTip returns true if the root is one of the synced tips.
+func (f *ForkChoice) isSyncedTip(root [32]byte) bool {
+	f.syncedTips.RLock()
+	defer f.syncedTips.RUnlock()
+	_, ok := f.syncedTips.validatedTips[root]
+	return ok
+}
+
+// Optimistic returns true if this node is optimistically synced
+// A optimistically synced block is synced as usual, but its
+// execution payload is not validated, while the EL is still syncing.
+// WARNING: this function does not check if slot corresponds to the
+//          block with the given root. An incorrect response may be
+//          returned when requesting earlier than finalized epoch due
+//          to pruning of non-canonical branches. A requests for a
+//          combination root/slot of an available block is guaranteed
+//          to yield the correct result. The caller is responsible for
+//          checking the block's availability. A consensus bug could be
+//          a cause of getting this wrong, so think twice before passing
+//          a wrong pair.
+func (f *ForkChoice) Optimistic(ctx context.Context, root [32]byte, slot types.Slot) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	// If the node is a synced tip, then it's fully validated
+	if f.isSyncedTip(root) {
+		return false, nil
+	}
+
+	// If the slot is higher than the max synced tip, it's optimistic
+	min, max := f.boundarySyncedTips()
+	if slot > max {
+		return true, nil
+	}
+
+	// If the slot is lower than the min synced tip, it's fully validated
+	if slot <= min {
+		return false, nil
+	}
+
+	// If we reached this point then the block has to be in the Fork Choice
+	// Store!
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+	node, ok := f.store.nodeByRoot[root]
+	if !ok || node == nil {
+		// This should not happen
+		return false, fmt.Errorf("invalid root, slot combination, got %#x, %d",
+			bytesutil.Trunc(root[:]), slot)
+	}
+
+	// Follow the best children until reaching a synced tip, or a leaf
+	// of the Fork Choice tree which is optimistic.
+	for {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		node = node.bestChild()
+		if node == nil {
+			return true, nil
+		}
+		if f.isSyncedTip(node.root) {
+			return false, nil
+		}
+		if node.slot > max {
+			return true, nil
+		}
+	}
+}
+
+// This function returns the sync tip node that's ancestor to the input node.
+// In the event of none, nil is returned.
+// This internal method assumes the caller holds a lock on syncedTips and s.nodesLock
+func (s *Store) findSyncedTip(ctx context.Context, node *Node, syncedTips *optimisticStore) (*Node, error) {
+	for ; node != nil; node = node.parent {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if _, ok := syncedTips.validatedTips[node.root]; ok {
+			return node, nil
+		}
+	}
+	return nil, nil
+}
+
+// UpdateSyncedTipsWithValidRoot is called with the root of a block that was returned as
+// VALID by the EL. This routine recomputes and updates the synced_tips map to
+// account for this new tip.
+func (f *ForkChoice) UpdateSyncedTipsWithValidRoot(ctx context.Context, root [32]byte) error {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+	// We can only update if given root is in Fork Choice
+	node, ok := f.store.nodeByRoot[root]
+	if !ok || node == nil {
+		return errInvalidNodeRoot
+	}
+
+	// We can only update if root is a leaf in Fork Choice
+	if node.bestDescendant != nil {
+		return errNotLeafNode
+	}
+
+	// Stop early if the root is part of validated tips
+	f.syncedTips.Lock()
+	defer f.syncedTips.Unlock()
+	_, ok = f.syncedTips.validatedTips[root]
+	if ok {
+		return nil
+	}
+
+	// Cache root and slot to validated tips
+	newTips := make(map[[32]byte]types.Slot)
+	newValidSlot := node.slot
+	newTips[root] = newValidSlot
+
+	// Compute the full valid path from the given node to its previous synced tip
+	// This path will now consist of fully validated blocks. Notice that
+	// the previous tip may have been outside the Fork Choice store.
+	// In this case, only one block can be in syncedTips as the whole
+	// Fork Choice would be a descendant of this block.
+	validPath := map[*Node]bool{node: true}
+	for n := node.parent; n != nil; n = n.parent {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if _, ok := f.syncedTips.validatedTips[n.root]; ok {
+			break
+		}
+		validPath[n] = true
+	}
+
+	// For each leaf, recompute the new tip.
+	for _, leaf := range f.store.leaves() {
+		for n := leaf; n != nil; n = n.parent {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// Stop if we reached the previous tip or the valid path
+			_, ok := f.syncedTips.validatedTips[n.root]
+			if ok || validPath[n] {
+				newTips[n.root] = n.slot
+				break
+			}
+		}
+	}
+
+	f.syncedTips.validatedTips = newTips
+	lastSyncedTipSlot.Set(float64(newValidSlot))
+	syncedTipsCount.Set(float64(len(newTips)))
+	return nil
+}
+
+// UpdateSyncedTipsWithInvalidRoot updates the synced_tips map when the block with the given root becomes INVALID.
+// The invalid node is removed from the store.
+func (f *ForkChoice) UpdateSyncedTipsWithInvalidRoot(ctx context.Context, root [32]byte) error {
+	f.store.nodesLock.Lock()
+	defer f.store.nodesLock.Unlock()
+	node, ok := f.store.nodeByRoot[root]
+	if !ok || node == nil {
+		return errInvalidNodeRoot
+	}
+	// We only support changing status for the tips in Fork Choice store.
+	if len(node.children) != 0 {
+		return errNotLeafNode
+	}
+	parent := node.parent
+	// This should not happen
+	if parent == nil {
+		return errInvalidParentRoot
+	}
+
+	// Update the weights of the ancestors subtracting the INVALID node's weight
+	for n := parent; n != nil; n = n.parent {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if n.weight < node.weight {
+			n.weight = 0
+		} else {
+			n.weight -= node.weight
+		}
+	}
+
+	// Delete the invalid node.
+	for i, child := range parent.children {
+		if child == node {
+			parent.children = append(parent.children[:i], parent.children[i+1:]...)
+			break
+		}
+	}
+	delete(f.store.nodeByRoot, root)
+	delete(f.store.canonicalNodes, root)
+	if f.store.headNode == node {
+		f.store.headNode = parent
+	}
+	nodeCount.Set(float64(len(f.store.nodeByRoot)))
+
+	// The best descendants of the ancestors may have been the invalid node.
+	for n := parent; n != nil; n = n.parent {
+		n.chooseBestDescendant(f.store.justifiedEpoch, f.store.finalizedEpoch)
+	}
+
+	// Return early if the parent is not a synced_tip.
+	f.syncedTips.Lock()
+	defer f.syncedTips.Unlock()
+	_, ok = f.syncedTips.validatedTips[parent.root]
+	if !ok {
+		return nil
+	}
+
+	for _, leaf := range f.store.leaves() {
+		for n := leaf; n != nil; n = n.parent {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// Return early if the parent is still a synced tip
+			if n == parent {
+				return nil
+			}
+			if _, ok := f.syncedTips.validatedTips[n.root]; ok {
+				break
+			}
+		}
+	}
+	delete(f.syncedTips.validatedTips, parent.root)
+	syncedTipsCount.Set(float64(len(f.syncedTips.validatedTips)))
+	return nil
+}
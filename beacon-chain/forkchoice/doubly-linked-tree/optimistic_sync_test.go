@@ -0,0 +1,223 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+// We test the algorithm to check the optimistic status of a node. The
+// status for this test is the following branching diagram
+//
+//                       -- E -- F
+//                      /
+//                  -- C -- D
+//                 /
+// 0 -- 1 -- A -- B      -- J -- K
+//                 \    /
+//                  -- G -- H -- I
+//
+// Here nodes 0, 1, A, B, C, D are fully validated and nodes
+// E, F, G, H, J, K are optimistic.
+// Synced Tips are nodes B, C, D
+// nodes 0 and 1 are outside the Fork Choice Store.
+
+func setupOptimisticTree(t *testing.T) *ForkChoice {
+	ctx := context.Background()
+	f := New(1, 1)
+	blocks := []struct {
+		slot   types.Slot
+		root   string
+		parent string
+	}{
+		{100, "A", ""},
+		{101, "B", "A"},
+		{102, "C", "B"},
+		{103, "D", "C"},
+		{103, "E", "C"},
+		{104, "F", "E"},
+		{102, "G", "B"},
+		{103, "H", "G"},
+		{104, "I", "H"},
+		{103, "J", "G"},
+		{104, "K", "J"},
+	}
+	for _, b := range blocks {
+		parent := params.BeaconConfig().ZeroHash
+		if b.parent != "" {
+			parent = optimisticRoot(b.parent)
+		}
+		require.NoError(t, f.ProcessBlock(ctx, b.slot, optimisticRoot(b.root), parent, [32]byte{}, 1, 1))
+	}
+	return f
+}
+
+func optimisticRoot(name string) [32]byte {
+	return bytesutil.ToBytes32([]byte("hello" + name))
+}
+
+func TestOptimistic(t *testing.T) {
+	ctx := context.Background()
+	f := setupOptimisticTree(t)
+	root0 := bytesutil.ToBytes32([]byte("hello0"))
+	root1 := bytesutil.ToBytes32([]byte("hello1"))
+
+	// Make D the best descendant of C, and I the best descendant of G.
+	f.ProcessAttestation(ctx, []uint64{0}, optimisticRoot("D"), 1)
+	f.ProcessAttestation(ctx, []uint64{1}, optimisticRoot("I"), 1)
+	_, err := f.Head(ctx, 1, optimisticRoot("A"), []uint64{10, 10}, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, f.SetSyncedTips(map[[32]byte]types.Slot{
+		optimisticRoot("B"): 101,
+		optimisticRoot("C"): 102,
+		optimisticRoot("D"): 103,
+	}))
+
+	tests := []struct {
+		root [32]byte
+		slot types.Slot
+		want bool
+	}{
+		{root0, 98, false},
+		{root1, 99, false},
+		{optimisticRoot("A"), 100, false},
+		{optimisticRoot("B"), 101, false},
+		{optimisticRoot("C"), 102, false},
+		{optimisticRoot("D"), 103, false},
+		{optimisticRoot("E"), 103, true},
+		{optimisticRoot("F"), 104, true},
+		{optimisticRoot("G"), 102, true},
+		{optimisticRoot("H"), 103, true},
+		{optimisticRoot("I"), 104, true},
+		{optimisticRoot("J"), 103, true},
+		{optimisticRoot("K"), 104, true},
+	}
+	for _, tc := range tests {
+		got, err := f.Optimistic(ctx, tc.root, tc.slot)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got, "wrong optimistic status for slot %d", tc.slot)
+	}
+
+	_, err = f.Optimistic(ctx, bytesutil.ToBytes32([]byte("unknown")), 102)
+	require.ErrorContains(t, "invalid root, slot combination", err)
+}
+
+func TestUpdateSyncTipsWithValidRoots(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unknown root", func(t *testing.T) {
+		f := setupOptimisticTree(t)
+		err := f.UpdateSyncedTipsWithValidRoot(ctx, bytesutil.ToBytes32([]byte("unknown")))
+		require.ErrorIs(t, err, errInvalidNodeRoot)
+	})
+	t.Run("not a leaf", func(t *testing.T) {
+		f := setupOptimisticTree(t)
+		err := f.UpdateSyncedTipsWithValidRoot(ctx, optimisticRoot("G"))
+		require.ErrorIs(t, err, errNotLeafNode)
+	})
+	t.Run("validates the path to the previous tip", func(t *testing.T) {
+		f := setupOptimisticTree(t)
+		require.NoError(t, f.SetSyncedTips(map[[32]byte]types.Slot{
+			optimisticRoot("B"): 101,
+			optimisticRoot("C"): 102,
+			optimisticRoot("D"): 103,
+		}))
+		require.NoError(t, f.UpdateSyncedTipsWithValidRoot(ctx, optimisticRoot("I")))
+		assert.DeepEqual(t, map[[32]byte]types.Slot{
+			optimisticRoot("C"): 102,
+			optimisticRoot("D"): 103,
+			optimisticRoot("G"): 102,
+			optimisticRoot("I"): 104,
+		}, f.SyncedTips())
+	})
+	t.Run("already a synced tip", func(t *testing.T) {
+		f := setupOptimisticTree(t)
+		tips := map[[32]byte]types.Slot{
+			optimisticRoot("B"): 101,
+			optimisticRoot("D"): 103,
+		}
+		require.NoError(t, f.SetSyncedTips(tips))
+		require.NoError(t, f.UpdateSyncedTipsWithValidRoot(ctx, optimisticRoot("D")))
+		assert.DeepEqual(t, tips, f.SyncedTips())
+	})
+}
+
+func TestUpdateSyncTipsWithInvalidRoot(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unknown root", func(t *testing.T) {
+		f := setupOptimisticTree(t)
+		err := f.UpdateSyncedTipsWithInvalidRoot(ctx, bytesutil.ToBytes32([]byte("unknown")))
+		require.ErrorIs(t, err, errInvalidNodeRoot)
+	})
+	t.Run("not a leaf", func(t *testing.T) {
+		f := setupOptimisticTree(t)
+		err := f.UpdateSyncedTipsWithInvalidRoot(ctx, optimisticRoot("E"))
+		require.ErrorIs(t, err, errNotLeafNode)
+	})
+	t.Run("removes the node and keeps the parent tip", func(t *testing.T) {
+		f := setupOptimisticTree(t)
+		require.NoError(t, f.SetSyncedTips(map[[32]byte]types.Slot{
+			optimisticRoot("B"): 101,
+			optimisticRoot("C"): 102,
+		}))
+		f.ProcessAttestation(ctx, []uint64{0}, optimisticRoot("F"), 1)
+		_, err := f.Head(ctx, 1, optimisticRoot("A"), []uint64{10}, 1)
+		require.NoError(t, err)
+
+		require.NoError(t, f.UpdateSyncedTipsWithInvalidRoot(ctx, optimisticRoot("F")))
+		assert.Equal(t, false, f.HasNode(optimisticRoot("F")))
+		assert.Equal(t, uint64(0), f.store.nodeByRoot[optimisticRoot("A")].weight)
+		assert.Equal(t, 2, len(f.SyncedTips()))
+	})
+	t.Run("removes the parent tip", func(t *testing.T) {
+		f := setupOptimisticTree(t)
+		require.NoError(t, f.SetSyncedTips(map[[32]byte]types.Slot{
+			optimisticRoot("B"): 101,
+			optimisticRoot("C"): 102,
+			optimisticRoot("E"): 103,
+		}))
+		require.NoError(t, f.UpdateSyncedTipsWithInvalidRoot(ctx, optimisticRoot("D")))
+		assert.DeepEqual(t, map[[32]byte]types.Slot{
+			optimisticRoot("B"): 101,
+			optimisticRoot("E"): 103,
+		}, f.SyncedTips())
+	})
+}
+
+func TestFindSyncedTip(t *testing.T) {
+	ctx := context.Background()
+	f := setupOptimisticTree(t)
+	require.NoError(t, f.SetSyncedTips(map[[32]byte]types.Slot{
+		optimisticRoot("B"): 101,
+		optimisticRoot("C"): 102,
+	}))
+
+	tests := []struct {
+		node string
+		tip  string
+	}{
+		{"A", ""},
+		{"B", "B"},
+		{"D", "C"},
+		{"F", "C"},
+		{"K", "B"},
+	}
+	f.syncedTips.RLock()
+	defer f.syncedTips.RUnlock()
+	for _, tc := range tests {
+		tip, err := f.store.findSyncedTip(ctx, f.store.nodeByRoot[optimisticRoot(tc.node)], f.syncedTips)
+		require.NoError(t, err)
+		if tc.tip == "" {
+			assert.Equal(t, (*Node)(nil), tip)
+			continue
+		}
+		assert.Equal(t, optimisticRoot(tc.tip), tip.root)
+	}
+}
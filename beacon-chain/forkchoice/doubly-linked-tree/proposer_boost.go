@@ -0,0 +1,211 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+// BoostProposerRoot sets the block root which should be boosted during
+// the LMD fork choice algorithm calculations. This is meant to reward timely,
+// proposed blocks which occur before a cutoff interval set to
+// SECONDS_PER_SLOT // INTERVALS_PER_SLOT.
+//
+//  time_into_slot = (store.time - store.genesis_time) % SECONDS_PER_SLOT
+//  is_before_attesting_interval = time_into_slot < SECONDS_PER_SLOT // INTERVALS_PER_SLOT
+//  if get_current_slot(store) == block.slot and is_before_attesting_interval:
+//      store.proposer_boost_root = hash_tree_root(block)
+func (f *ForkChoice) BoostProposerRoot(_ context.Context, blockSlot types.Slot, blockRoot [32]byte, genesisTime time.Time) error {
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	timeIntoSlot := uint64(time.Since(genesisTime).Seconds()) % secondsPerSlot
+	isBeforeAttestingInterval := timeIntoSlot < secondsPerSlot/params.BeaconConfig().IntervalsPerSlot
+	currentSlot := slots.SinceGenesis(genesisTime)
+
+	// Only update the boosted proposer root to the incoming block root
+	// if the block is for the current, clock-based slot and the block was timely.
+	if currentSlot == blockSlot && isBeforeAttestingInterval {
+		f.store.proposerBoostLock.Lock()
+		// Blocks of a proposer that equivocated in this slot are never boosted.
+		if !f.store.equivocatingRoots[blockRoot] {
+			f.store.proposerBoostRoot = blockRoot
+		}
+		f.store.proposerBoostLock.Unlock()
+	}
+	return nil
+}
+
+// ResetBoostedProposerRoot sets the value of the proposer boosted root to zeros
+// and forgets the equivocating blocks of the previous slot.
+func (f *ForkChoice) ResetBoostedProposerRoot(_ context.Context) error {
+	f.store.proposerBoostLock.Lock()
+	f.store.proposerBoostRoot = [32]byte{}
+	f.store.equivocatingRoots = nil
+	f.store.proposerBoostLock.Unlock()
+	return nil
+}
+
+// MarkEquivocatingBlocks records the given roots as blocks of a proposer that equivocated
+// in the current slot. None of them can be boosted for the rest of the slot, the boost is
+// removed if one of them already received it, and they are no longer considered timely so
+// that a proposer may reorg them out.
+func (f *ForkChoice) MarkEquivocatingBlocks(_ context.Context, roots [][32]byte) error {
+	f.store.nodesLock.Lock()
+	defer f.store.nodesLock.Unlock()
+	f.store.proposerBoostLock.Lock()
+	defer f.store.proposerBoostLock.Unlock()
+
+	if f.store.equivocatingRoots == nil {
+		f.store.equivocatingRoots = make(map[[32]byte]bool, len(roots))
+	}
+	for _, root := range roots {
+		f.store.equivocatingRoots[root] = true
+		if f.store.proposerBoostRoot == root {
+			f.store.proposerBoostRoot = [32]byte{}
+		}
+		if node, ok := f.store.nodeByRoot[root]; ok {
+			node.timely = false
+		}
+	}
+	return nil
+}
+
+// ProposerHead returns the root of the block that the proposer of the given slot should
+// build on. This is the given head, unless the head is a weak block that arrived after the
+// attestation deadline of its slot, in which case the proposer builds on its parent instead
+// and reorgs the late block out. All the following conditions must hold for the reorg:
+//
+//  head_late = not is_timely(head)
+//  shuffling_stable = slot % SLOTS_PER_EPOCH != 0
+//  ffg_competitive = head.justified_epoch == parent.justified_epoch
+//  finalization_ok = compute_epoch_at_slot(slot) - store.finalized_epoch <= REORG_MAX_EPOCHS_SINCE_FINALIZATION
+//  single_slot_reorg = parent.slot + 1 == head.slot and head.slot + 1 == slot
+//  head_weak = get_weight(head) < committee_weight * REORG_WEIGHT_THRESHOLD // 100
+//  parent_strong = get_weight(parent) > committee_weight * REORG_PARENT_WEIGHT_THRESHOLD // 100
+func (f *ForkChoice) ProposerHead(ctx context.Context, headRoot [32]byte, slot types.Slot) ([32]byte, error) {
+	if ctx.Err() != nil {
+		return [32]byte{}, ctx.Err()
+	}
+	f.votesLock.RLock()
+	balances := f.balances
+	f.votesLock.RUnlock()
+
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	head, ok := f.store.nodeByRoot[headRoot]
+	if !ok || head == nil {
+		return [32]byte{}, errInvalidNodeRoot
+	}
+	parent := head.parent
+	if head.timely || parent == nil {
+		return headRoot, nil
+	}
+	if !canReorgLateBlock(slot, head.slot, parent.slot, head.justifiedEpoch, parent.justifiedEpoch, f.store.finalizedEpoch) {
+		return headRoot, nil
+	}
+	committeeWeight, err := computeCommitteeWeight(balances)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if head.weight >= committeeWeight*params.BeaconConfig().ReorgWeightThreshold/100 {
+		return headRoot, nil
+	}
+	if parent.weight <= committeeWeight*params.BeaconConfig().ReorgParentWeightThreshold/100 {
+		return headRoot, nil
+	}
+	return parent.root, nil
+}
+
+// canReorgLateBlock checks the conditions of a late block reorg which do not depend on the
+// weights: the shuffling is stable, the reorg doesn't change the justified checkpoint, the chain
+// is finalizing and only the single block of the previous slot is reorged.
+func canReorgLateBlock(slot, headSlot, parentSlot types.Slot, headJustified, parentJustified, finalizedEpoch types.Epoch) bool {
+	if slots.IsEpochStart(slot) {
+		return false
+	}
+	if headJustified != parentJustified {
+		return false
+	}
+	if slots.ToEpoch(slot) > finalizedEpoch+params.BeaconConfig().ReorgMaxEpochsSinceFinalization {
+		return false
+	}
+	return parentSlot+1 == headSlot && headSlot+1 == slot
+}
+
+// Given a list of validator balances, we compute the proposer boost score
+// that should be given to a proposer based on their committee weight, derived from
+// the total active balances, the size of a committee, and a boost score constant.
+// IMPORTANT: The caller MUST pass in a list of validator balances where balances > 0 refer to active
+// validators while balances == 0 are for inactive validators.
+func computeProposerBoostScore(validatorBalances []uint64) (score uint64, err error) {
+	committeeWeight, err := computeCommitteeWeight(validatorBalances)
+	if err != nil {
+		return 0, err
+	}
+	score = (committeeWeight * params.BeaconConfig().ProposerScoreBoost) / 100
+	return
+}
+
+// Given a list of validator balances, we compute the weight of a committee, derived from
+// the total active balances and the size of a committee. The same constraints as in
+// computeProposerBoostScore apply to the list of balances.
+func computeCommitteeWeight(validatorBalances []uint64) (committeeWeight uint64, err error) {
+	totalActiveBalance := uint64(0)
+	numActive := uint64(0)
+	for _, balance := range validatorBalances {
+		// We only consider balances > 0. The input slice should be constructed
+		// as balance > 0 for all active validators and 0 for inactive ones.
+		if balance == 0 {
+			continue
+		}
+		totalActiveBalance += balance
+		numActive += 1
+	}
+	if numActive == 0 {
+		// Should never happen.
+		err = errors.New("no active validators")
+		return
+	}
+	avgBalance := totalActiveBalance / numActive
+	committeeSize := numActive / uint64(params.BeaconConfig().SlotsPerEpoch)
+	committeeWeight = committeeSize * avgBalance
+	return
+}
+
+// applyProposerBoostScore removes the proposer boost score from the balance of the previously
+// boosted node and adds the score computed from the input balances to the currently boosted node.
+// This internal method assumes that the caller holds a lock in s.nodesLock.
+func (s *Store) applyProposerBoostScore(newBalances []uint64) error {
+	s.proposerBoostLock.Lock()
+	defer s.proposerBoostLock.Unlock()
+
+	proposerScore := uint64(0)
+	var err error
+	if s.previousProposerBoostRoot != params.BeaconConfig().ZeroHash {
+		if previousNode, ok := s.nodeByRoot[s.previousProposerBoostRoot]; ok {
+			if previousNode.balance < s.previousProposerBoostScore {
+				s.setBalance(previousNode, 0)
+			} else {
+				s.setBalance(previousNode, previousNode.balance-s.previousProposerBoostScore)
+			}
+		}
+	}
+	if s.proposerBoostRoot != params.BeaconConfig().ZeroHash {
+		if currentNode, ok := s.nodeByRoot[s.proposerBoostRoot]; ok {
+			proposerScore, err = computeProposerBoostScore(newBalances)
+			if err != nil {
+				return err
+			}
+			s.setBalance(currentNode, currentNode.balance+proposerScore)
+		}
+	}
+
+	// Set the previous boosted root and score.
+	s.previousProposerBoostRoot = s.proposerBoostRoot
+	s.previousProposerBoostScore = proposerScore
+	return nil
+}
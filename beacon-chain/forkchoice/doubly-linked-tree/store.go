@@ -0,0 +1,643 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	"go.opencensus.io/trace"
+)
+
+// New initializes a new fork choice store.
+func New(justifiedEpoch, finalizedEpoch types.Epoch) *ForkChoice {
+	s := &Store{
+		justifiedEpoch: justifiedEpoch,
+		finalizedEpoch: finalizedEpoch,
+		nodeByRoot:     make(map[[fieldparams.RootLength]byte]*Node),
+		canonicalNodes: make(map[[fieldparams.RootLength]byte]bool),
+	}
+
+	b := make([]uint64, 0)
+	v := make([]Vote, 0)
+	st := &optimisticStore{
+		validatedTips: make(map[[32]byte]types.Slot),
+	}
+	return &ForkChoice{store: s, balances: b, votes: v, syncedTips: st}
+}
+
+// SetSyncedTips sets the synced and validated tips from the passed map
+func (f *ForkChoice) SetSyncedTips(tips map[[32]byte]types.Slot) error {
+	if len(tips) == 0 {
+		return errInvalidSyncedTips
+	}
+	newTips := make(map[[32]byte]types.Slot, len(tips))
+	for k, v := range tips {
+		newTips[k] = v
+	}
+	f.syncedTips.Lock()
+	defer f.syncedTips.Unlock()
+	f.syncedTips.validatedTips = newTips
+	return nil
+}
+
+// SyncedTips returns the synced and validated tips from the fork choice store.
+func (f *ForkChoice) SyncedTips() map[[32]byte]types.Slot {
+	f.syncedTips.RLock()
+	defer f.syncedTips.RUnlock()
+
+	m := make(map[[32]byte]types.Slot)
+	for k, v := range f.syncedTips.validatedTips {
+		m[k] = v
+	}
+	return m
+}
+
+// Head returns the head root from fork choice store.
+// It firsts updates the balances of the voted nodes then the weights and the best descendants of
+// these nodes and of their ancestors only, head is the best descendant of the justified node.
+// The best descendants of the whole tree are only recomputed when the justified or finalized
+// epoch changes, as it changes which nodes are viable for head.
+func (f *ForkChoice) Head(
+	ctx context.Context,
+	justifiedEpoch types.Epoch,
+	justifiedRoot [32]byte,
+	justifiedStateBalances []uint64,
+	finalizedEpoch types.Epoch,
+) ([32]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.Head")
+	defer span.End()
+	f.votesLock.Lock()
+	defer f.votesLock.Unlock()
+
+	calledHeadCount.Inc()
+
+	f.store.nodesLock.Lock()
+	defer f.store.nodesLock.Unlock()
+	f.updateBalances(justifiedStateBalances)
+	if err := f.store.applyProposerBoostScore(justifiedStateBalances); err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not apply proposer boost score")
+	}
+
+	changed, err := f.store.applyWeightChanges(ctx)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not apply weight changes")
+	}
+
+	// Update the justified / finalized epochs in store if necessary.
+	if f.store.justifiedEpoch != justifiedEpoch || f.store.finalizedEpoch != finalizedEpoch {
+		f.store.justifiedEpoch = justifiedEpoch
+		f.store.finalizedEpoch = finalizedEpoch
+		if f.store.treeRootNode != nil {
+			if err := f.store.treeRootNode.updateBestDescendant(ctx, justifiedEpoch, finalizedEpoch); err != nil {
+				return [32]byte{}, errors.Wrap(err, "could not update best descendants")
+			}
+		}
+	} else {
+		f.store.updateBestDescendants(changed)
+	}
+	return f.store.head(ctx, justifiedRoot)
+}
+
+// updateBalances updates the balances of the nodes voted for, from the validators' votes
+// and balances. Votes for blocks which are not in the store are ignored.
+// This internal method assumes that the caller holds a lock in f.votesLock and f.store.nodesLock.
+func (f *ForkChoice) updateBalances(newBalances []uint64) {
+	zeroHash := params.BeaconConfig().ZeroHash
+	for index, vote := range f.votes {
+		// Skip if validator has never voted for current root and next root (i.e. if the
+		// votes are zero hash aka genesis block), there's nothing to compute.
+		if vote.currentRoot == zeroHash && vote.nextRoot == zeroHash {
+			continue
+		}
+
+		// If the validator index did not exist in `oldBalance` or `newBalance` list above, the balance is just 0.
+		oldBalance := uint64(0)
+		newBalance := uint64(0)
+		if index < len(f.balances) {
+			oldBalance = f.balances[index]
+		}
+		if index < len(newBalances) {
+			newBalance = newBalances[index]
+		}
+
+		// Update the balances only if the validator's balance or vote has changed.
+		// There is no need to adjust the balance of the zero hash, it is an alias to the genesis block.
+		if vote.currentRoot != vote.nextRoot || oldBalance != newBalance {
+			if nextNode, ok := f.store.nodeByRoot[vote.nextRoot]; ok && vote.nextRoot != zeroHash {
+				f.store.setBalance(nextNode, nextNode.balance+newBalance)
+			}
+			if currentNode, ok := f.store.nodeByRoot[vote.currentRoot]; ok && vote.currentRoot != zeroHash {
+				// A node's balance can not be negative.
+				if currentNode.balance < oldBalance {
+					f.store.setBalance(currentNode, 0)
+				} else {
+					f.store.setBalance(currentNode, currentNode.balance-oldBalance)
+				}
+			}
+		}
+
+		// Rotate the validator vote.
+		f.votes[index].currentRoot = vote.nextRoot
+	}
+	f.balances = newBalances
+}
+
+// setBalance sets the balance of the node, the weights of the node and of its ancestors are
+// updated from the change on the next head computation.
+// This internal method assumes that the caller holds a lock in s.nodesLock.
+func (s *Store) setBalance(n *Node, balance uint64) {
+	if s.changedBalances == nil {
+		s.changedBalances = make(map[*Node]uint64)
+	}
+	if _, ok := s.changedBalances[n]; !ok {
+		s.changedBalances[n] = n.balance
+	}
+	n.balance = balance
+}
+
+// applyWeightChanges adds the balance changes of the nodes since the weights were last updated
+// to the weights of these nodes and of their ancestors. It returns the nodes whose balance changed.
+// The context is only checked before any weight is changed: the pending changes are consumed
+// by the update, so it always runs to completion once started.
+// This internal method assumes that the caller holds a lock in s.nodesLock.
+func (s *Store) applyWeightChanges(ctx context.Context) ([]*Node, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	zeroHash := params.BeaconConfig().ZeroHash
+	changed := make([]*Node, 0, len(s.changedBalances))
+	for node, previousBalance := range s.changedBalances {
+		if node.balance == previousBalance {
+			continue
+		}
+		changed = append(changed, node)
+		for n := node; n != nil; n = n.parent {
+			// There is no need to weigh the zero hash, it is an alias to the genesis block.
+			if n.root == zeroHash {
+				continue
+			}
+			// A node's weight can not be negative.
+			switch {
+			case node.balance > previousBalance:
+				n.weight += node.balance - previousBalance
+			case n.weight < previousBalance-node.balance:
+				n.weight = 0
+			default:
+				n.weight -= previousBalance - node.balance
+			}
+		}
+	}
+	s.changedBalances = nil
+	return changed, nil
+}
+
+// updateBestDescendants recomputes the best descendants of the ancestors of the given nodes,
+// whose weights changed. Each ancestor is last updated after all of its changed descendants.
+// It runs to completion as the changed nodes are not tracked anymore once their weights are applied.
+// This internal method assumes that the caller holds a lock in s.nodesLock.
+func (s *Store) updateBestDescendants(changed []*Node) {
+	for _, node := range changed {
+		for n := node.parent; n != nil; n = n.parent {
+			n.chooseBestDescendant(s.justifiedEpoch, s.finalizedEpoch)
+		}
+	}
+}
+
+// ProcessAttestation processes attestation for vote accounting, it iterates around validator indices
+// and update their votes accordingly.
+func (f *ForkChoice) ProcessAttestation(ctx context.Context, validatorIndices []uint64, blockRoot [32]byte, targetEpoch types.Epoch) {
+	_, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.ProcessAttestation")
+	defer span.End()
+	f.votesLock.Lock()
+	defer f.votesLock.Unlock()
+
+	for _, index := range validatorIndices {
+		// Validator indices will grow the vote cache.
+		for index >= uint64(len(f.votes)) {
+			f.votes = append(f.votes, Vote{currentRoot: params.BeaconConfig().ZeroHash, nextRoot: params.BeaconConfig().ZeroHash})
+		}
+
+		// Newly allocated vote if the root fields are untouched.
+		newVote := f.votes[index].nextRoot == params.BeaconConfig().ZeroHash &&
+			f.votes[index].currentRoot == params.BeaconConfig().ZeroHash
+
+		// Vote gets updated if it's newly allocated or high target epoch.
+		if newVote || targetEpoch > f.votes[index].nextEpoch {
+			f.votes[index].nextEpoch = targetEpoch
+			f.votes[index].nextRoot = blockRoot
+		}
+	}
+
+	processedAttestationCount.Inc()
+}
+
+// ProcessBlock processes a new block by inserting it to the fork choice store.
+func (f *ForkChoice) ProcessBlock(
+	ctx context.Context,
+	slot types.Slot,
+	blockRoot, parentRoot, graffiti [32]byte,
+	justifiedEpoch, finalizedEpoch types.Epoch,
+) error {
+	ctx, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.ProcessBlock")
+	defer span.End()
+
+	return f.store.insert(ctx, slot, blockRoot, parentRoot, graffiti, justifiedEpoch, finalizedEpoch)
+}
+
+// Prune prunes the fork choice store with the new finalized root. Every node which does not
+// descend from the finalized node is removed, the finalized node becomes the root of the tree.
+func (f *ForkChoice) Prune(ctx context.Context, finalizedRoot [32]byte) error {
+	return f.store.prune(ctx, finalizedRoot, f.syncedTips)
+}
+
+// HasNode returns true if the node exists in fork choice store,
+// false else wise.
+func (f *ForkChoice) HasNode(root [32]byte) bool {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	_, ok := f.store.nodeByRoot[root]
+	return ok
+}
+
+// HasParent returns true if the node parent exists in fork choice store,
+// false else wise.
+func (f *ForkChoice) HasParent(root [32]byte) bool {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	node, ok := f.store.nodeByRoot[root]
+	if !ok || node == nil {
+		return false
+	}
+	return node.parent != nil
+}
+
+// IsCanonical returns true if the given root is part of the canonical chain.
+func (f *ForkChoice) IsCanonical(root [32]byte) bool {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	return f.store.canonicalNodes[root]
+}
+
+// AncestorRoot returns the ancestor root of input block root at a given slot.
+func (f *ForkChoice) AncestorRoot(ctx context.Context, root [32]byte, slot types.Slot) ([]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.AncestorRoot")
+	defer span.End()
+
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	node, ok := f.store.nodeByRoot[root]
+	if !ok || node == nil {
+		return nil, errors.New("node does not exist")
+	}
+	for node.slot > slot {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		node = node.parent
+		if node == nil {
+			return nil, errors.New("ancestor is not in fork choice store")
+		}
+	}
+	ancestorRoot := node.root
+	return ancestorRoot[:], nil
+}
+
+// CommonAncestor returns the root and slot of the most recent common ancestor of the two given
+// roots. An error is returned if any of the roots is not in the store or if their common ancestor
+// has already been pruned.
+func (f *ForkChoice) CommonAncestor(ctx context.Context, r1 [32]byte, r2 [32]byte) ([32]byte, types.Slot, error) {
+	ctx, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.CommonAncestor")
+	defer span.End()
+
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	n1, ok := f.store.nodeByRoot[r1]
+	if !ok || n1 == nil {
+		return [32]byte{}, 0, errInvalidNodeRoot
+	}
+	n2, ok := f.store.nodeByRoot[r2]
+	if !ok || n2 == nil {
+		return [32]byte{}, 0, errInvalidNodeRoot
+	}
+	for n1 != n2 {
+		if ctx.Err() != nil {
+			return [32]byte{}, 0, ctx.Err()
+		}
+		if n1.slot > n2.slot {
+			n1 = n1.parent
+		} else {
+			n2 = n2.parent
+		}
+		if n1 == nil || n2 == nil {
+			return [32]byte{}, 0, errUnknownCommonAncestor
+		}
+	}
+	return n1.root, n1.slot, nil
+}
+
+// NodeCount returns the number of nodes in the fork choice store.
+func (f *ForkChoice) NodeCount() int {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+	return len(f.store.nodeByRoot)
+}
+
+// Tips returns the roots and slots of the nodes without a best descendant, which are
+// the possible heads of the chain.
+func (f *ForkChoice) Tips() ([][32]byte, []types.Slot) {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	leaves := f.store.leaves()
+	roots := make([][32]byte, len(leaves))
+	slots := make([]types.Slot, len(leaves))
+	for i, node := range leaves {
+		roots[i] = node.root
+		slots[i] = node.slot
+	}
+	return roots, slots
+}
+
+// JustifiedEpoch of fork choice store.
+func (f *ForkChoice) JustifiedEpoch() types.Epoch {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+	return f.store.justifiedEpoch
+}
+
+// FinalizedEpoch of fork choice store.
+func (f *ForkChoice) FinalizedEpoch() types.Epoch {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+	return f.store.finalizedEpoch
+}
+
+// ProposerBoost of fork choice store.
+func (f *ForkChoice) ProposerBoost() [fieldparams.RootLength]byte {
+	f.store.proposerBoostLock.RLock()
+	defer f.store.proposerBoostLock.RUnlock()
+	return f.store.proposerBoostRoot
+}
+
+// ForkChoiceDump returns every node of the fork choice store, parents before their children,
+// along with its weight and optimistic status.
+func (f *ForkChoice) ForkChoiceDump(ctx context.Context) ([]*ethpbv1.ForkChoiceNode, error) {
+	f.store.nodesLock.RLock()
+	nodes := make([]*ethpbv1.ForkChoiceNode, 0, len(f.store.nodeByRoot))
+	if f.store.treeRootNode != nil {
+		stack := []*Node{f.store.treeRootNode}
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			root := node.root
+			parentRoot := params.BeaconConfig().ZeroHash
+			if node.parent != nil {
+				parentRoot = node.parent.root
+			}
+			nodes = append(nodes, &ethpbv1.ForkChoiceNode{
+				Slot:           node.slot,
+				Root:           root[:],
+				ParentRoot:     parentRoot[:],
+				JustifiedEpoch: node.justifiedEpoch,
+				FinalizedEpoch: node.finalizedEpoch,
+				Weight:         node.weight,
+			})
+			for i := len(node.children) - 1; i >= 0; i-- {
+				stack = append(stack, node.children[i])
+			}
+		}
+	}
+	f.store.nodesLock.RUnlock()
+
+	// Optimistic takes the locks on its own.
+	for _, node := range nodes {
+		optimistic, err := f.Optimistic(ctx, bytesutil.ToBytes32(node.Root), node.Slot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get optimistic status of root %#x", node.Root)
+		}
+		node.Optimistic = optimistic
+	}
+	return nodes, nil
+}
+
+// head returns the best descendant of the justified node, or the justified node itself
+// if none of its descendants is viable for head.
+// This internal method assumes that the caller holds a lock in s.nodesLock.
+func (s *Store) head(ctx context.Context, justifiedRoot [32]byte) ([32]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.head")
+	defer span.End()
+
+	justifiedNode, ok := s.nodeByRoot[justifiedRoot]
+	if !ok || justifiedNode == nil {
+		return [32]byte{}, errUnknownJustifiedRoot
+	}
+
+	bestNode := justifiedNode.bestDescendant
+	// If the justified node doesn't have a best descendant,
+	// the best node is itself.
+	if bestNode == nil {
+		bestNode = justifiedNode
+	}
+
+	if !bestNode.viableForHead(s.justifiedEpoch, s.finalizedEpoch) {
+		return [32]byte{}, fmt.Errorf("head at slot %d with weight %d is not eligible, finalizedEpoch %d != %d, justifiedEpoch %d != %d",
+			bestNode.slot, bestNode.weight/10e9, bestNode.finalizedEpoch, s.finalizedEpoch, bestNode.justifiedEpoch, s.justifiedEpoch)
+	}
+
+	if bestNode != s.headNode {
+		// Update canonical mapping given the head node.
+		if err := s.updateCanonicalNodes(ctx, bestNode); err != nil {
+			return [32]byte{}, err
+		}
+		s.headNode = bestNode
+		headChangesCount.Inc()
+		headSlotNumber.Set(float64(bestNode.slot))
+	}
+
+	return bestNode.root, nil
+}
+
+// updateCanonicalNodes updates the canonical nodes mapping from the previous head to the input head.
+// Only the nodes between the heads and the node where their chains join are updated.
+// This internal method assumes that the caller holds a lock in s.nodesLock.
+func (s *Store) updateCanonicalNodes(ctx context.Context, head *Node) error {
+	_, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.updateCanonicalNodes")
+	defer span.End()
+
+	// Walk up from the new head until a node of the previous canonical chain.
+	var newCanonicalNodes []*Node
+	node := head
+	for node != nil && !s.canonicalNodes[node.root] {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		newCanonicalNodes = append(newCanonicalNodes, node)
+		node = node.parent
+	}
+
+	// The nodes of the previous canonical chain after the joining node are no longer canonical.
+	if node == nil {
+		s.canonicalNodes = make(map[[fieldparams.RootLength]byte]bool)
+	} else {
+		for n := s.headNode; n != nil && n != node; n = n.parent {
+			delete(s.canonicalNodes, n.root)
+		}
+	}
+
+	for _, n := range newCanonicalNodes {
+		s.canonicalNodes[n.root] = true
+	}
+	return nil
+}
+
+// insert registers a new block node to the fork choice store as a child of its parent node.
+// It then updates the best descendants of its ancestors, up to the first one whose best
+// descendant does not change.
+func (s *Store) insert(ctx context.Context,
+	slot types.Slot,
+	root, parentRoot, graffiti [32]byte,
+	justifiedEpoch, finalizedEpoch types.Epoch) error {
+	_, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.insert")
+	defer span.End()
+
+	s.nodesLock.Lock()
+	defer s.nodesLock.Unlock()
+
+	// Return if the block has been inserted into Store before.
+	if _, ok := s.nodeByRoot[root]; ok {
+		return nil
+	}
+
+	// The proposer boost is only given to timely blocks, right before they are inserted.
+	s.proposerBoostLock.RLock()
+	timely := root == s.proposerBoostRoot
+	s.proposerBoostLock.RUnlock()
+
+	parent := s.nodeByRoot[parentRoot]
+	n := &Node{
+		slot:           slot,
+		root:           root,
+		graffiti:       graffiti,
+		parent:         parent,
+		justifiedEpoch: justifiedEpoch,
+		finalizedEpoch: finalizedEpoch,
+		timely:         timely,
+	}
+
+	if parent == nil {
+		// Only the first block inserted, the finalized or genesis block, can be the root of the tree.
+		if s.treeRootNode != nil {
+			return errInvalidParentRoot
+		}
+		s.treeRootNode = n
+	} else {
+		parent.children = append(parent.children, n)
+		for a := parent; a != nil; a = a.parent {
+			bestDescendant := a.bestDescendant
+			a.chooseBestDescendant(s.justifiedEpoch, s.finalizedEpoch)
+			if a.bestDescendant == bestDescendant {
+				break
+			}
+		}
+	}
+	s.nodeByRoot[root] = n
+
+	// Update metrics.
+	processedBlockCount.Inc()
+	nodeCount.Set(float64(len(s.nodeByRoot)))
+
+	return nil
+}
+
+// prune prunes the store with the new finalized root. The ancestors of the finalized node
+// and every node which does not descend from it are removed from the store.
+func (s *Store) prune(ctx context.Context, finalizedRoot [32]byte, syncedTips *optimisticStore) error {
+	_, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.prune")
+	defer span.End()
+
+	s.nodesLock.Lock()
+	defer s.nodesLock.Unlock()
+
+	// The node would have seen finalized root or else it
+	// wouldn't be able to prune it.
+	finalizedNode, ok := s.nodeByRoot[finalizedRoot]
+	if !ok || finalizedNode == nil {
+		return errUnknownFinalizedRoot
+	}
+	if finalizedNode == s.treeRootNode {
+		return nil
+	}
+
+	syncedTips.Lock()
+	defer syncedTips.Unlock()
+
+	// The synced tip of the finalized branch is kept, the other synced tips of the pruned
+	// nodes are removed.
+	finalizedTip, err := s.findSyncedTip(ctx, finalizedNode, syncedTips)
+	if err != nil {
+		return err
+	}
+	child := finalizedNode
+	for parent := finalizedNode.parent; parent != nil; parent = parent.parent {
+		for _, sibling := range parent.children {
+			if sibling != child {
+				s.removeSubtree(sibling, syncedTips, finalizedTip)
+			}
+		}
+		s.removeNode(parent, syncedTips, finalizedTip)
+		child = parent
+	}
+	finalizedNode.parent = nil
+	s.treeRootNode = finalizedNode
+
+	prunedCount.Inc()
+	nodeCount.Set(float64(len(s.nodeByRoot)))
+	syncedTipsCount.Set(float64(len(syncedTips.validatedTips)))
+	return nil
+}
+
+// removeSubtree removes the node and all its descendants from the store.
+// This internal method assumes that the caller holds a lock on syncedTips and s.nodesLock.
+func (s *Store) removeSubtree(node *Node, syncedTips *optimisticStore, keptTip *Node) {
+	stack := []*Node{node}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = append(stack[:len(stack)-1], n.children...)
+		s.removeNode(n, syncedTips, keptTip)
+	}
+}
+
+// removeNode removes a single node from the indices of the store, the node's links are left as is.
+// This internal method assumes that the caller holds a lock on syncedTips and s.nodesLock.
+func (s *Store) removeNode(node *Node, syncedTips *optimisticStore, keptTip *Node) {
+	delete(s.nodeByRoot, node.root)
+	delete(s.canonicalNodes, node.root)
+	if node == s.headNode {
+		s.headNode = nil
+	}
+	if node != keptTip {
+		delete(syncedTips.validatedTips, node.root)
+	}
+}
+
+// leaves returns the list of nodes without a best descendant.
+// This internal method assumes that the caller holds a lock in s.nodesLock.
+func (s *Store) leaves() []*Node {
+	var leaves []*Node
+	for _, node := range s.nodeByRoot {
+		if node.bestDescendant == nil {
+			leaves = append(leaves, node)
+		}
+	}
+	return leaves
+}
@@ -0,0 +1,376 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/crypto/hash"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestStore_JustifiedEpoch(t *testing.T) {
+	j := types.Epoch(100)
+	f := New(j, 0)
+	require.Equal(t, j, f.JustifiedEpoch())
+}
+
+func TestStore_FinalizedEpoch(t *testing.T) {
+	fe := types.Epoch(50)
+	f := New(0, fe)
+	require.Equal(t, fe, f.FinalizedEpoch())
+}
+
+func TestForkChoice_HasNode(t *testing.T) {
+	f := setup(0, 0)
+	require.NoError(t, f.ProcessBlock(context.Background(), 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	require.Equal(t, true, f.HasNode(indexToHash(1)))
+	require.Equal(t, false, f.HasNode(indexToHash(2)))
+	require.Equal(t, 2, f.NodeCount())
+}
+
+func TestStore_Head_UnknownJustifiedRoot(t *testing.T) {
+	f := setup(0, 0)
+	_, err := f.Head(context.Background(), 0, [32]byte{'a'}, []uint64{}, 0)
+	assert.ErrorContains(t, errUnknownJustifiedRoot.Error(), err)
+}
+
+func TestStore_Head_Itself(t *testing.T) {
+	f := setup(0, 0)
+	require.NoError(t, f.ProcessBlock(context.Background(), 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+
+	// Since the justified node does not have a best descendant, the best node is itself.
+	h, err := f.Head(context.Background(), 0, indexToHash(1), []uint64{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(1), h)
+}
+
+func TestStore_Head_BestDescendant(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(2), indexToHash(1), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 3, indexToHash(3), indexToHash(1), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 4, indexToHash(4), indexToHash(2), [32]byte{}, 0, 0))
+
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(3), 1)
+	h, err := f.Head(ctx, 0, params.BeaconConfig().ZeroHash, []uint64{1}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(3), h)
+}
+
+func TestStore_Head_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := setup(0, 0)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	cancel()
+	_, err := f.Head(ctx, 0, params.BeaconConfig().ZeroHash, []uint64{}, 0)
+	require.ErrorContains(t, "context canceled", err)
+}
+
+func TestStore_Insert_UnknownParent(t *testing.T) {
+	f := setup(0, 0)
+	err := f.ProcessBlock(context.Background(), 100, [32]byte{'A'}, [32]byte{'B'}, [32]byte{}, 1, 1)
+	assert.ErrorContains(t, errInvalidParentRoot.Error(), err)
+	assert.Equal(t, 1, f.NodeCount())
+}
+
+func TestStore_Insert_KnownParent(t *testing.T) {
+	f := setup(0, 0)
+	require.NoError(t, f.ProcessBlock(context.Background(), 100, [32]byte{'A'}, params.BeaconConfig().ZeroHash, [32]byte{}, 1, 1))
+
+	assert.Equal(t, 2, f.NodeCount())
+	n := f.store.nodeByRoot[[32]byte{'A'}]
+	assert.Equal(t, types.Slot(100), n.slot)
+	assert.Equal(t, types.Epoch(1), n.justifiedEpoch)
+	assert.Equal(t, types.Epoch(1), n.finalizedEpoch)
+	assert.Equal(t, f.store.treeRootNode, n.parent)
+	assert.Equal(t, true, f.HasParent([32]byte{'A'}))
+	assert.Equal(t, false, f.HasParent(params.BeaconConfig().ZeroHash))
+}
+
+func TestStore_Insert_Twice(t *testing.T) {
+	f := setup(0, 0)
+	require.NoError(t, f.ProcessBlock(context.Background(), 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(context.Background(), 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	assert.Equal(t, 2, f.NodeCount())
+	assert.Equal(t, 1, len(f.store.treeRootNode.children))
+}
+
+func TestStore_UpdateBalances_VotesForUnknownBlock(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(1), 1)
+	f.ProcessAttestation(ctx, []uint64{1}, indexToHash(2), 1)
+	_, err := f.Head(ctx, 0, params.BeaconConfig().ZeroHash, []uint64{10, 20}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), f.store.nodeByRoot[indexToHash(1)].weight)
+
+	// Moving the vote away from the block removes its balance.
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(2), 2)
+	_, err = f.Head(ctx, 0, params.BeaconConfig().ZeroHash, []uint64{10, 20}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), f.store.nodeByRoot[indexToHash(1)].weight)
+}
+
+// This builds the following tree:
+//
+//	0 -- 1 -- 2 -- 3 -- 4
+//	      \         \
+//	       5         6 -- 7
+func setupPruneTree(t *testing.T) *ForkChoice {
+	ctx := context.Background()
+	f := setup(0, 0)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(2), indexToHash(1), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 3, indexToHash(3), indexToHash(2), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 4, indexToHash(4), indexToHash(3), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(5), indexToHash(1), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 4, indexToHash(6), indexToHash(3), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 5, indexToHash(7), indexToHash(6), [32]byte{}, 0, 0))
+	return f
+}
+
+func TestStore_Prune_UnknownFinalizedRoot(t *testing.T) {
+	f := setupPruneTree(t)
+	err := f.Prune(context.Background(), indexToHash(100))
+	assert.ErrorContains(t, errUnknownFinalizedRoot.Error(), err)
+}
+
+func TestStore_Prune_Root(t *testing.T) {
+	f := setupPruneTree(t)
+	require.NoError(t, f.Prune(context.Background(), params.BeaconConfig().ZeroHash))
+	assert.Equal(t, 8, f.NodeCount())
+}
+
+func TestStore_Prune_RemovesAncestorsAndSiblings(t *testing.T) {
+	ctx := context.Background()
+	f := setupPruneTree(t)
+	require.NoError(t, f.Prune(ctx, indexToHash(2)))
+	assert.Equal(t, 5, f.NodeCount())
+	for _, i := range []uint64{2, 3, 4, 6, 7} {
+		assert.Equal(t, true, f.HasNode(indexToHash(i)))
+	}
+	assert.Equal(t, false, f.HasNode(params.BeaconConfig().ZeroHash))
+	assert.Equal(t, false, f.HasNode(indexToHash(1)))
+	assert.Equal(t, false, f.HasNode(indexToHash(5)))
+	assert.Equal(t, false, f.HasParent(indexToHash(2)))
+	assert.Equal(t, f.store.nodeByRoot[indexToHash(2)], f.store.treeRootNode)
+
+	require.NoError(t, f.Prune(ctx, indexToHash(6)))
+	assert.Equal(t, 2, f.NodeCount())
+	assert.Equal(t, true, f.HasNode(indexToHash(6)))
+	assert.Equal(t, true, f.HasNode(indexToHash(7)))
+
+	h, err := f.Head(ctx, 0, indexToHash(6), []uint64{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(7), h)
+}
+
+func TestStore_PruneSyncedTips(t *testing.T) {
+	ctx := context.Background()
+	f := setupPruneTree(t)
+	require.NoError(t, f.SetSyncedTips(map[[32]byte]types.Slot{
+		indexToHash(1): 1,
+		indexToHash(5): 2,
+	}))
+	require.NoError(t, f.Prune(ctx, indexToHash(3)))
+
+	// The synced tip of the finalized branch is kept.
+	tips := f.SyncedTips()
+	assert.Equal(t, 1, len(tips))
+	_, ok := tips[indexToHash(1)]
+	assert.Equal(t, true, ok)
+}
+
+func TestStore_SetSyncedTips(t *testing.T) {
+	f := setup(1, 1)
+	tips := make(map[[32]byte]types.Slot)
+	require.ErrorIs(t, errInvalidSyncedTips, f.SetSyncedTips(tips))
+	tips[bytesutil.ToBytes32([]byte("a"))] = 1
+	tips[bytesutil.ToBytes32([]byte("b"))] = 2
+	require.NoError(t, f.SetSyncedTips(tips))
+	f.syncedTips.RLock()
+	defer f.syncedTips.RUnlock()
+	require.Equal(t, 2, len(f.syncedTips.validatedTips))
+	require.Equal(t, types.Slot(1), f.syncedTips.validatedTips[bytesutil.ToBytes32([]byte("a"))])
+	require.Equal(t, types.Slot(2), f.syncedTips.validatedTips[bytesutil.ToBytes32([]byte("b"))])
+}
+
+func TestStore_AncestorRoot(t *testing.T) {
+	ctx := context.Background()
+	f := setupPruneTree(t)
+
+	r, err := f.AncestorRoot(ctx, indexToHash(7), 3)
+	require.NoError(t, err)
+	assert.DeepEqual(t, indexToHash(3), bytesutil.ToBytes32(r))
+
+	// Slots without blocks return the closest ancestor below them.
+	r, err = f.AncestorRoot(ctx, indexToHash(5), 1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, indexToHash(1), bytesutil.ToBytes32(r))
+
+	r, err = f.AncestorRoot(ctx, indexToHash(4), 10)
+	require.NoError(t, err)
+	assert.DeepEqual(t, indexToHash(4), bytesutil.ToBytes32(r))
+
+	_, err = f.AncestorRoot(ctx, indexToHash(100), 1)
+	assert.ErrorContains(t, "node does not exist", err)
+}
+
+func TestStore_AncestorRootOutOfBound(t *testing.T) {
+	ctx := context.Background()
+	f := setupPruneTree(t)
+	require.NoError(t, f.Prune(ctx, indexToHash(3)))
+	_, err := f.AncestorRoot(ctx, indexToHash(7), 1)
+	assert.ErrorContains(t, "ancestor is not in fork choice store", err)
+}
+
+func TestStore_CommonAncestor(t *testing.T) {
+	ctx := context.Background()
+	// This builds the following tree:
+	//
+	//	0 -- 1 -- 2 -- 3 -- 4
+	//	      \         \
+	//	       5         6 -- 7
+	f := setup(0, 0)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(2), indexToHash(1), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 3, indexToHash(3), indexToHash(2), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 4, indexToHash(4), indexToHash(3), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(5), indexToHash(1), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 4, indexToHash(6), indexToHash(3), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 5, indexToHash(7), indexToHash(6), [32]byte{}, 0, 0))
+
+	tests := []struct {
+		r1       [32]byte
+		r2       [32]byte
+		wantRoot [32]byte
+		wantSlot types.Slot
+	}{
+		{indexToHash(4), indexToHash(7), indexToHash(3), 3},
+		{indexToHash(7), indexToHash(5), indexToHash(1), 1},
+		{indexToHash(2), indexToHash(7), indexToHash(2), 2},
+		{indexToHash(4), indexToHash(4), indexToHash(4), 4},
+		{indexToHash(5), params.BeaconConfig().ZeroHash, params.BeaconConfig().ZeroHash, 0},
+	}
+	for _, tc := range tests {
+		root, slot, err := f.CommonAncestor(ctx, tc.r1, tc.r2)
+		require.NoError(t, err)
+		assert.Equal(t, tc.wantRoot, root)
+		assert.Equal(t, tc.wantSlot, slot)
+	}
+
+	_, _, err := f.CommonAncestor(ctx, indexToHash(4), indexToHash(100))
+	require.ErrorIs(t, err, errInvalidNodeRoot)
+}
+
+func TestStore_Tips(t *testing.T) {
+	ctx := context.Background()
+	f := setupPruneTree(t)
+	_, err := f.Head(ctx, 0, params.BeaconConfig().ZeroHash, []uint64{}, 0)
+	require.NoError(t, err)
+
+	roots, slots := f.Tips()
+	require.Equal(t, 3, len(roots))
+	tips := make(map[[32]byte]types.Slot)
+	for i := range roots {
+		tips[roots[i]] = slots[i]
+	}
+	assert.Equal(t, types.Slot(4), tips[indexToHash(4)])
+	assert.Equal(t, types.Slot(2), tips[indexToHash(5)])
+	assert.Equal(t, types.Slot(5), tips[indexToHash(7)])
+}
+
+func TestStore_UpdateCanonicalNodes(t *testing.T) {
+	ctx := context.Background()
+	f := setupPruneTree(t)
+
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(4), 1)
+	h, err := f.Head(ctx, 0, params.BeaconConfig().ZeroHash, []uint64{1}, 0)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(4), h)
+	for _, i := range []uint64{1, 2, 3, 4} {
+		assert.Equal(t, true, f.IsCanonical(indexToHash(i)))
+	}
+	for _, i := range []uint64{5, 6, 7} {
+		assert.Equal(t, false, f.IsCanonical(indexToHash(i)))
+	}
+
+	// Moving the vote reorgs the head to 7.
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(7), 2)
+	h, err = f.Head(ctx, 0, params.BeaconConfig().ZeroHash, []uint64{1}, 0)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(7), h)
+	for _, i := range []uint64{1, 2, 3, 6, 7} {
+		assert.Equal(t, true, f.IsCanonical(indexToHash(i)))
+	}
+	for _, i := range []uint64{4, 5} {
+		assert.Equal(t, false, f.IsCanonical(indexToHash(i)))
+	}
+}
+
+func TestStore_UpdateCanonicalNodes_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := setupPruneTree(t)
+	cancel()
+	err := f.store.updateCanonicalNodes(ctx, f.store.nodeByRoot[indexToHash(7)])
+	require.ErrorContains(t, "context canceled", err)
+}
+
+func TestForkChoice_ProposerBoost(t *testing.T) {
+	f := setup(0, 0)
+	require.Equal(t, [32]byte{}, f.ProposerBoost())
+	f.store.proposerBoostRoot = indexToHash(1)
+	require.Equal(t, indexToHash(1), f.ProposerBoost())
+}
+
+func TestForkChoice_ForkChoiceDump(t *testing.T) {
+	ctx := context.Background()
+	f := setupPruneTree(t)
+	require.NoError(t, f.SetSyncedTips(map[[32]byte]types.Slot{indexToHash(3): 3}))
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(7), 1)
+	_, err := f.Head(ctx, 0, params.BeaconConfig().ZeroHash, []uint64{10}, 0)
+	require.NoError(t, err)
+
+	nodes, err := f.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	require.Equal(t, f.NodeCount(), len(nodes))
+	assert.DeepEqual(t, params.BeaconConfig().ZeroHash[:], nodes[0].Root)
+
+	wantParents := map[uint64]uint64{2: 1, 3: 2, 4: 3, 5: 1, 6: 3, 7: 6}
+	wantWeights := map[uint64]uint64{1: 10, 2: 10, 3: 10, 4: 0, 5: 0, 6: 10, 7: 10}
+	wantOptimistic := map[uint64]bool{4: true, 6: true, 7: true}
+	dumped := map[[32]byte]bool{params.BeaconConfig().ZeroHash: true}
+	for _, n := range nodes[1:] {
+		require.Equal(t, true, dumped[bytesutil.ToBytes32(n.ParentRoot)], "Parent not dumped before its child")
+		dumped[bytesutil.ToBytes32(n.Root)] = true
+	}
+	for i := uint64(1); i <= 7; i++ {
+		root := indexToHash(i)
+		var node *ethpbv1.ForkChoiceNode
+		for _, n := range nodes {
+			if bytesutil.ToBytes32(n.Root) == root {
+				node = n
+			}
+		}
+		require.NotNil(t, node)
+		if parent, ok := wantParents[i]; ok {
+			assert.DeepEqual(t, indexToHash(parent), bytesutil.ToBytes32(node.ParentRoot), "Wrong parent for node %d", i)
+		}
+		assert.Equal(t, wantWeights[i], node.Weight, "Wrong weight for node %d", i)
+		assert.Equal(t, wantOptimistic[i], node.Optimistic, "Wrong optimistic status for node %d", i)
+	}
+}
+
+func indexToHash(i uint64) [32]byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], i)
+	return hash.Hash(b[:])
+}
@@ -0,0 +1,63 @@
+package doublylinkedtree
+
+import (
+	"sync"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+)
+
+// ForkChoice defines the overall fork choice store which includes all block nodes, validator's latest votes and balances.
+type ForkChoice struct {
+	store      *Store
+	votes      []Vote // tracks individual validator's last vote.
+	votesLock  sync.RWMutex
+	balances   []uint64 // tracks individual validator's last justified balances.
+	syncedTips *optimisticStore
+}
+
+// Store defines the fork choice store which includes block nodes and the last view of checkpoint information.
+type Store struct {
+	justifiedEpoch             types.Epoch                            // latest justified epoch in store.
+	finalizedEpoch             types.Epoch                            // latest finalized epoch in store.
+	proposerBoostRoot          [fieldparams.RootLength]byte           // latest block root that was boosted after being received in a timely manner.
+	previousProposerBoostRoot  [fieldparams.RootLength]byte           // previous block root that was boosted after being received in a timely manner.
+	previousProposerBoostScore uint64                                 // previous proposer boosted root score.
+	equivocatingRoots          map[[fieldparams.RootLength]byte]bool  // block roots of proposers that equivocated in the current slot.
+	treeRootNode               *Node                                  // the root node of the tree, the finalized node once pruned.
+	headNode                   *Node                                  // last head computed by the store.
+	nodeByRoot                 map[[fieldparams.RootLength]byte]*Node // nodes indexed by their block root.
+	canonicalNodes             map[[fieldparams.RootLength]byte]bool  // the canonical block nodes.
+	changedBalances            map[*Node]uint64                       // balances before their change of the nodes whose balance changed since the weights were last updated.
+	nodesLock                  sync.RWMutex
+	proposerBoostLock          sync.RWMutex
+}
+
+// Node defines the individual block which includes its block parent, children and how much weight accounted for it.
+type Node struct {
+	slot           types.Slot                   // slot of the block converted to the node.
+	root           [fieldparams.RootLength]byte // root of the block converted to the node.
+	parent         *Node                        // parent of this node, nil for the tree root.
+	children       []*Node                      // the nodes of the blocks built on this node.
+	justifiedEpoch types.Epoch                  // justifiedEpoch of this node.
+	finalizedEpoch types.Epoch                  // finalizedEpoch of this node.
+	balance        uint64                       // the balance of the validators voting for this node.
+	weight         uint64                       // weight of this node, its balance plus the weight of its children.
+	bestDescendant *Node                        // bestDescendant of this node, nil if no descendant leads to a viable head.
+	graffiti       [fieldparams.RootLength]byte // graffiti of the block node.
+	timely         bool                         // timely is true if the block arrived before the attestation deadline of its slot.
+}
+
+// optimisticStore defines a structure that tracks the tips of the fully
+// validated blocks tree.
+type optimisticStore struct {
+	validatedTips map[[32]byte]types.Slot
+	sync.RWMutex
+}
+
+// Vote defines an individual validator's vote.
+type Vote struct {
+	currentRoot [fieldparams.RootLength]byte // current voting root.
+	nextRoot    [fieldparams.RootLength]byte // next voting root.
+	nextEpoch   types.Epoch                  // epoch of next voting period.
+}
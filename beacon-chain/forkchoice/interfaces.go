@@ -5,7 +5,8 @@ import (
 	"time"
 
 	types "github.com/prysmaticlabs/eth2-types"
-	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 )
 
 // ForkChoicer represents the full fork choice interface composed of all the sub-interfaces.
@@ -44,17 +45,23 @@ type Pruner interface {
 type ProposerBooster interface {
 	BoostProposerRoot(ctx context.Context, blockSlot types.Slot, blockRoot [32]byte, genesisTime time.Time) error
 	ResetBoostedProposerRoot(ctx context.Context) error
+	ProposerHead(ctx context.Context, headRoot [32]byte, slot types.Slot) ([32]byte, error)
+	MarkEquivocatingBlocks(ctx context.Context, roots [][32]byte) error
 }
 
 // Getter returns fork choice related information.
 type Getter interface {
-	Nodes() []*protoarray.Node
-	Node([32]byte) *protoarray.Node
 	HasNode([32]byte) bool
-	Store() *protoarray.Store
+	NodeCount() int
 	HasParent(root [32]byte) bool
 	AncestorRoot(ctx context.Context, root [32]byte, slot types.Slot) ([]byte, error)
+	CommonAncestor(ctx context.Context, r1 [32]byte, r2 [32]byte) ([32]byte, types.Slot, error)
 	IsCanonical(root [32]byte) bool
+	Tips() ([][32]byte, []types.Slot)
+	JustifiedEpoch() types.Epoch
+	FinalizedEpoch() types.Epoch
+	ProposerBoost() [fieldparams.RootLength]byte
+	ForkChoiceDump(ctx context.Context) ([]*ethpbv1.ForkChoiceNode, error)
 }
 
 // SyncTipper returns sync tips related information.
@@ -0,0 +1,22 @@
+package forkchoice
+
+import (
+	types "github.com/prysmaticlabs/eth2-types"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/config/features"
+)
+
+var (
+	_ = ForkChoicer(&protoarray.ForkChoice{})
+	_ = ForkChoicer(&doublylinkedtree.ForkChoice{})
+)
+
+// New returns the fork choice store selected by the feature flags, the proto array
+// store unless the doubly linked tree store is enabled.
+func New(justifiedEpoch, finalizedEpoch types.Epoch, finalizedRoot [32]byte) ForkChoicer {
+	if features.Get().EnableForkChoiceDoublyLinkedTree {
+		return doublylinkedtree.New(justifiedEpoch, finalizedEpoch)
+	}
+	return protoarray.New(justifiedEpoch, finalizedEpoch, finalizedRoot)
+}
@@ -12,3 +12,4 @@ var errInvalidParentDelta = errors.New("parent delta is invalid")
 var errInvalidNodeDelta = errors.New("node delta is invalid")
 var errInvalidDeltaLength = errors.New("delta length is invalid")
 var errInvalidSyncedTips = errors.New("invalid synced tips")
+var errUnknownCommonAncestor = errors.New("unknown common ancestor")
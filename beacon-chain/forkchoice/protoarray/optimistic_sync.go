@@ -46,10 +46,10 @@ func (f *ForkChoice) Optimistic(ctx context.Context, root [32]byte, slot types.S
 	// If the node is a synced tip, then it's fully validated
 	f.syncedTips.RLock()
 	_, ok := f.syncedTips.validatedTips[root]
+	f.syncedTips.RUnlock()
 	if ok {
 		return false, nil
 	}
-	f.syncedTips.RUnlock()
 
 	// If the slot is higher than the max synced tip, it's optimistic
 	min, max := f.boundarySyncedTips()
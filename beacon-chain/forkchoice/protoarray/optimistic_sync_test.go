@@ -200,6 +200,9 @@ func TestOptimistic(t *testing.T) {
 	op, err = f.Optimistic(ctx, nodeK.root, nodeK.slot)
 	require.NoError(t, err)
 	require.Equal(t, op, true)
+
+	// The synced tips must not remain locked after checking a synced tip.
+	require.NoError(t, f.SetSyncedTips(tips))
 }
 
 // This tests the algorithm to update syncedTips
@@ -29,26 +29,135 @@ func (f *ForkChoice) BoostProposerRoot(_ context.Context, blockSlot types.Slot,
 	// if the block is for the current, clock-based slot and the block was timely.
 	if currentSlot == blockSlot && isBeforeAttestingInterval {
 		f.store.proposerBoostLock.Lock()
-		f.store.proposerBoostRoot = blockRoot
+		// Blocks of a proposer that equivocated in this slot are never boosted.
+		if !f.store.equivocatingRoots[blockRoot] {
+			f.store.proposerBoostRoot = blockRoot
+		}
 		f.store.proposerBoostLock.Unlock()
 	}
 	return nil
 }
 
-// ResetBoostedProposerRoot sets the value of the proposer boosted root to zeros.
+// ResetBoostedProposerRoot sets the value of the proposer boosted root to zeros
+// and forgets the equivocating blocks of the previous slot.
 func (f *ForkChoice) ResetBoostedProposerRoot(_ context.Context) error {
 	f.store.proposerBoostLock.Lock()
 	f.store.proposerBoostRoot = [32]byte{}
+	f.store.equivocatingRoots = nil
 	f.store.proposerBoostLock.Unlock()
 	return nil
 }
 
+// MarkEquivocatingBlocks records the given roots as blocks of a proposer that equivocated
+// in the current slot. None of them can be boosted for the rest of the slot, the boost is
+// removed if one of them already received it, and they are no longer considered timely so
+// that a proposer may reorg them out.
+func (f *ForkChoice) MarkEquivocatingBlocks(_ context.Context, roots [][32]byte) error {
+	f.store.nodesLock.Lock()
+	defer f.store.nodesLock.Unlock()
+	f.store.proposerBoostLock.Lock()
+	defer f.store.proposerBoostLock.Unlock()
+
+	if f.store.equivocatingRoots == nil {
+		f.store.equivocatingRoots = make(map[[32]byte]bool, len(roots))
+	}
+	for _, root := range roots {
+		f.store.equivocatingRoots[root] = true
+		if f.store.proposerBoostRoot == root {
+			f.store.proposerBoostRoot = [32]byte{}
+		}
+		if index, ok := f.store.nodesIndices[root]; ok && index < uint64(len(f.store.nodes)) {
+			f.store.nodes[index].timely = false
+		}
+	}
+	return nil
+}
+
+// ProposerHead returns the root of the block that the proposer of the given slot should
+// build on. This is the given head, unless the head is a weak block that arrived after the
+// attestation deadline of its slot, in which case the proposer builds on its parent instead
+// and reorgs the late block out. All the following conditions must hold for the reorg:
+//
+//  head_late = not is_timely(head)
+//  shuffling_stable = slot % SLOTS_PER_EPOCH != 0
+//  ffg_competitive = head.justified_epoch == parent.justified_epoch
+//  finalization_ok = compute_epoch_at_slot(slot) - store.finalized_epoch <= REORG_MAX_EPOCHS_SINCE_FINALIZATION
+//  single_slot_reorg = parent.slot + 1 == head.slot and head.slot + 1 == slot
+//  head_weak = get_weight(head) < committee_weight * REORG_WEIGHT_THRESHOLD // 100
+//  parent_strong = get_weight(parent) > committee_weight * REORG_PARENT_WEIGHT_THRESHOLD // 100
+func (f *ForkChoice) ProposerHead(ctx context.Context, headRoot [32]byte, slot types.Slot) ([32]byte, error) {
+	if ctx.Err() != nil {
+		return [32]byte{}, ctx.Err()
+	}
+	f.votesLock.RLock()
+	balances := f.balances
+	f.votesLock.RUnlock()
+
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	index, ok := f.store.nodesIndices[headRoot]
+	if !ok || index >= uint64(len(f.store.nodes)) {
+		return [32]byte{}, errInvalidNodeIndex
+	}
+	head := f.store.nodes[index]
+	if head.timely || head.parent == NonExistentNode {
+		return headRoot, nil
+	}
+	if head.parent >= uint64(len(f.store.nodes)) {
+		return [32]byte{}, errInvalidNodeIndex
+	}
+	parent := f.store.nodes[head.parent]
+	if !canReorgLateBlock(slot, head.slot, parent.slot, head.justifiedEpoch, parent.justifiedEpoch, f.store.finalizedEpoch) {
+		return headRoot, nil
+	}
+	committeeWeight, err := computeCommitteeWeight(balances)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if head.weight >= committeeWeight*params.BeaconConfig().ReorgWeightThreshold/100 {
+		return headRoot, nil
+	}
+	if parent.weight <= committeeWeight*params.BeaconConfig().ReorgParentWeightThreshold/100 {
+		return headRoot, nil
+	}
+	return parent.root, nil
+}
+
+// canReorgLateBlock checks the conditions of a late block reorg which do not depend on the
+// weights: the shuffling is stable, the reorg doesn't change the justified checkpoint, the chain
+// is finalizing and only the single block of the previous slot is reorged.
+func canReorgLateBlock(slot, headSlot, parentSlot types.Slot, headJustified, parentJustified, finalizedEpoch types.Epoch) bool {
+	if slots.IsEpochStart(slot) {
+		return false
+	}
+	if headJustified != parentJustified {
+		return false
+	}
+	if slots.ToEpoch(slot) > finalizedEpoch+params.BeaconConfig().ReorgMaxEpochsSinceFinalization {
+		return false
+	}
+	return parentSlot+1 == headSlot && headSlot+1 == slot
+}
+
 // Given a list of validator balances, we compute the proposer boost score
 // that should be given to a proposer based on their committee weight, derived from
 // the total active balances, the size of a committee, and a boost score constant.
 // IMPORTANT: The caller MUST pass in a list of validator balances where balances > 0 refer to active
 // validators while balances == 0 are for inactive validators.
 func computeProposerBoostScore(validatorBalances []uint64) (score uint64, err error) {
+	committeeWeight, err := computeCommitteeWeight(validatorBalances)
+	if err != nil {
+		return 0, err
+	}
+	score = (committeeWeight * params.BeaconConfig().ProposerScoreBoost) / 100
+	return
+}
+
+// Given a list of validator balances, we compute the weight of a committee, derived from
+// the total active balances and the size of a committee. The same constraints as in
+// computeProposerBoostScore apply to the list of balances.
+func computeCommitteeWeight(validatorBalances []uint64) (committeeWeight uint64, err error) {
 	totalActiveBalance := uint64(0)
 	numActive := uint64(0)
 	for _, balance := range validatorBalances {
@@ -67,7 +176,6 @@ func computeProposerBoostScore(validatorBalances []uint64) (score uint64, err er
 	}
 	avgBalance := totalActiveBalance / numActive
 	committeeSize := numActive / uint64(params.BeaconConfig().SlotsPerEpoch)
-	committeeWeight := committeeSize * avgBalance
-	score = (committeeWeight * params.BeaconConfig().ProposerScoreBoost) / 100
+	committeeWeight = committeeSize * avgBalance
 	return
 }
@@ -467,6 +467,37 @@ func TestForkChoice_BoostProposerRoot(t *testing.T) {
 	})
 }
 
+func TestForkChoice_MarkEquivocatingBlocks(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	cfg.SecondsPerSlot = 6
+	cfg.IntervalsPerSlot = 3
+	params.OverrideBeaconConfig(cfg)
+	ctx := context.Background()
+	// Genesis set to 1 slot ago, so that blocks from slot 1 are timely.
+	genesis := time.Now().Add(-time.Duration(cfg.SecondsPerSlot) * time.Second)
+
+	f := setup(0, 0)
+	require.NoError(t, f.BoostProposerRoot(ctx, 1, indexToHash(1), genesis))
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(2), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	require.Equal(t, indexToHash(1), f.store.proposerBoostRoot)
+	require.Equal(t, true, f.store.nodes[f.store.nodesIndices[indexToHash(1)]].timely)
+
+	require.NoError(t, f.MarkEquivocatingBlocks(ctx, [][32]byte{indexToHash(1), indexToHash(2)}))
+	require.Equal(t, [32]byte{}, f.store.proposerBoostRoot)
+	require.Equal(t, false, f.store.nodes[f.store.nodesIndices[indexToHash(1)]].timely)
+
+	// Equivocating blocks can't be boosted for the rest of the slot.
+	require.NoError(t, f.BoostProposerRoot(ctx, 1, indexToHash(2), genesis))
+	require.Equal(t, [32]byte{}, f.store.proposerBoostRoot)
+
+	require.NoError(t, f.ResetBoostedProposerRoot(ctx))
+	require.Equal(t, 0, len(f.store.equivocatingRoots))
+	require.NoError(t, f.BoostProposerRoot(ctx, 1, indexToHash(2), genesis))
+	require.Equal(t, indexToHash(2), f.store.proposerBoostRoot)
+}
+
 func TestForkChoice_computeProposerBoostScore(t *testing.T) {
 	t.Run("nil justified balances throws error", func(t *testing.T) {
 		_, err := computeProposerBoostScore(nil)
@@ -487,3 +518,104 @@ func TestForkChoice_computeProposerBoostScore(t *testing.T) {
 		require.Equal(t, uint64(14), score)
 	})
 }
+
+func TestForkChoice_ProposerHead(t *testing.T) {
+	ctx := context.Background()
+	balances := make([]uint64, 64) // 64 active validators.
+	for i := 0; i < len(balances); i++ {
+		balances[i] = 10
+	}
+	// The committee weight is 20, so a weak head has a weight below 20 * 20 / 100 = 4 and
+	// a strong parent has a weight above 20 * 160 / 100 = 32.
+	setupTree := func(t *testing.T) *ForkChoice {
+		f := setup(0, 0)
+		require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+		require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(2), indexToHash(1), [32]byte{}, 0, 0))
+		f.balances = balances
+		f.store.nodes[f.store.nodesIndices[indexToHash(1)]].weight = 40
+		f.store.nodes[f.store.nodesIndices[indexToHash(2)]].weight = 3
+		return f
+	}
+	tests := []struct {
+		name   string
+		slot   types.Slot
+		update func(f *ForkChoice)
+		want   [32]byte
+	}{
+		{
+			name: "late and weak head is reorged",
+			slot: 3,
+			want: indexToHash(1),
+		},
+		{
+			name:   "timely head",
+			slot:   3,
+			update: func(f *ForkChoice) { f.store.nodes[f.store.nodesIndices[indexToHash(2)]].timely = true },
+			want:   indexToHash(2),
+		},
+		{
+			name:   "strong head",
+			slot:   3,
+			update: func(f *ForkChoice) { f.store.nodes[f.store.nodesIndices[indexToHash(2)]].weight = 4 },
+			want:   indexToHash(2),
+		},
+		{
+			name:   "weak parent",
+			slot:   3,
+			update: func(f *ForkChoice) { f.store.nodes[f.store.nodesIndices[indexToHash(1)]].weight = 32 },
+			want:   indexToHash(2),
+		},
+		{
+			name:   "different justification",
+			slot:   3,
+			update: func(f *ForkChoice) { f.store.nodes[f.store.nodesIndices[indexToHash(2)]].justifiedEpoch = 1 },
+			want:   indexToHash(2),
+		},
+		{
+			name: "skipped slot",
+			slot: 4,
+			want: indexToHash(2),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := setupTree(t)
+			if tc.update != nil {
+				tc.update(f)
+			}
+			got, err := f.ProposerHead(ctx, indexToHash(2), tc.slot)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+	t.Run("unknown head", func(t *testing.T) {
+		f := setupTree(t)
+		_, err := f.ProposerHead(ctx, indexToHash(3), 3)
+		require.ErrorIs(t, err, errInvalidNodeIndex)
+	})
+}
+
+func TestForkChoice_canReorgLateBlock(t *testing.T) {
+	spe := params.BeaconConfig().SlotsPerEpoch
+	tests := []struct {
+		name            string
+		slot            types.Slot
+		headSlot        types.Slot
+		parentSlot      types.Slot
+		headJustified   types.Epoch
+		parentJustified types.Epoch
+		finalized       types.Epoch
+		want            bool
+	}{
+		{"single slot reorg", 3, 2, 1, 0, 0, 0, true},
+		{"epoch start", spe, spe - 1, spe - 2, 0, 0, 0, false},
+		{"justification changes", 3, 2, 1, 1, 0, 0, false},
+		{"not finalizing", 3*spe + 3, 3*spe + 2, 3*spe + 1, 0, 0, 0, false},
+		{"skipped slot before head", 3, 2, 0, 0, 0, 0, false},
+		{"skipped slot after head", 4, 2, 1, 0, 0, 0, false},
+	}
+	for _, tc := range tests {
+		got := canReorgLateBlock(tc.slot, tc.headSlot, tc.parentSlot, tc.headJustified, tc.parentJustified, tc.finalized)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	"go.opencensus.io/trace"
 )
 
@@ -242,6 +244,116 @@ func (f *ForkChoice) AncestorRoot(ctx context.Context, root [32]byte, slot types
 	return f.store.nodes[i].root[:], nil
 }
 
+// CommonAncestor returns the root and slot of the most recent common ancestor of the two given
+// roots. An error is returned if any of the roots is not in the store or if their common ancestor
+// has already been pruned.
+func (f *ForkChoice) CommonAncestor(ctx context.Context, r1 [32]byte, r2 [32]byte) ([32]byte, types.Slot, error) {
+	ctx, span := trace.StartSpan(ctx, "protoArray.CommonAncestor")
+	defer span.End()
+
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	i1, ok := f.store.nodesIndices[r1]
+	if !ok || i1 >= uint64(len(f.store.nodes)) {
+		return [32]byte{}, 0, errInvalidNodeIndex
+	}
+	i2, ok := f.store.nodesIndices[r2]
+	if !ok || i2 >= uint64(len(f.store.nodes)) {
+		return [32]byte{}, 0, errInvalidNodeIndex
+	}
+	for i1 != i2 {
+		if ctx.Err() != nil {
+			return [32]byte{}, 0, ctx.Err()
+		}
+		// Parents are always inserted before their children, so the node with the
+		// highest index can't be an ancestor of the other one.
+		if i1 > i2 {
+			i1 = f.store.nodes[i1].parent
+		} else {
+			i2 = f.store.nodes[i2].parent
+		}
+		if i1 >= uint64(len(f.store.nodes)) || i2 >= uint64(len(f.store.nodes)) {
+			return [32]byte{}, 0, errUnknownCommonAncestor
+		}
+	}
+	return f.store.nodes[i1].root, f.store.nodes[i1].slot, nil
+}
+
+// NodeCount returns the number of nodes in the fork choice store.
+func (f *ForkChoice) NodeCount() int {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+	return len(f.store.nodes)
+}
+
+// Tips returns the roots and slots of the nodes without a best child, which are
+// the possible heads of the chain.
+func (f *ForkChoice) Tips() ([][32]byte, []types.Slot) {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	// Deliberate choice to not preallocate space for below.
+	// Heads cant be more than 2-3 in the worst case where pre-allocation will be 64 to begin with.
+	roots := make([][32]byte, 0)
+	slots := make([]types.Slot, 0)
+	for _, node := range f.store.nodes {
+		if node.bestChild == NonExistentNode && node.bestDescendant == NonExistentNode {
+			roots = append(roots, node.root)
+			slots = append(slots, node.slot)
+		}
+	}
+	return roots, slots
+}
+
+// JustifiedEpoch of fork choice store.
+func (f *ForkChoice) JustifiedEpoch() types.Epoch {
+	return f.store.JustifiedEpoch()
+}
+
+// FinalizedEpoch of fork choice store.
+func (f *ForkChoice) FinalizedEpoch() types.Epoch {
+	return f.store.FinalizedEpoch()
+}
+
+// ProposerBoost of fork choice store.
+func (f *ForkChoice) ProposerBoost() [fieldparams.RootLength]byte {
+	return f.store.ProposerBoost()
+}
+
+// ForkChoiceDump returns every node of the fork choice store, in insertion order, along with
+// its weight and optimistic status.
+func (f *ForkChoice) ForkChoiceDump(ctx context.Context) ([]*ethpbv1.ForkChoiceNode, error) {
+	f.store.nodesLock.RLock()
+	nodes := make([]*ethpbv1.ForkChoiceNode, len(f.store.nodes))
+	for i, node := range f.store.nodes {
+		root := node.root
+		parentRoot := params.BeaconConfig().ZeroHash
+		if node.parent != NonExistentNode {
+			parentRoot = f.store.nodes[node.parent].root
+		}
+		nodes[i] = &ethpbv1.ForkChoiceNode{
+			Slot:           node.slot,
+			Root:           root[:],
+			ParentRoot:     parentRoot[:],
+			JustifiedEpoch: node.justifiedEpoch,
+			FinalizedEpoch: node.finalizedEpoch,
+			Weight:         node.weight,
+		}
+	}
+	f.store.nodesLock.RUnlock()
+
+	// Optimistic takes the locks on its own.
+	for _, node := range nodes {
+		optimistic, err := f.Optimistic(ctx, bytesutil.ToBytes32(node.Root), node.Slot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get optimistic status of root %#x", node.Root)
+		}
+		node.Optimistic = optimistic
+	}
+	return nodes, nil
+}
+
 // PruneThreshold of fork choice store.
 func (s *Store) PruneThreshold() uint64 {
 	return s.pruneThreshold
@@ -393,6 +505,11 @@ func (s *Store) insert(ctx context.Context,
 		parentIndex = NonExistentNode
 	}
 
+	// The proposer boost is only given to timely blocks, right before they are inserted.
+	s.proposerBoostLock.RLock()
+	timely := root == s.proposerBoostRoot
+	s.proposerBoostLock.RUnlock()
+
 	n := &Node{
 		slot:           slot,
 		root:           root,
@@ -403,6 +520,7 @@ func (s *Store) insert(ctx context.Context,
 		bestChild:      NonExistentNode,
 		bestDescendant: NonExistentNode,
 		weight:         0,
+		timely:         timely,
 	}
 
 	s.nodesIndices[root] = index
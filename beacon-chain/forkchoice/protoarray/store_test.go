@@ -705,6 +705,45 @@ func TestStore_AncestorRootOutOfBound(t *testing.T) {
 	require.ErrorContains(t, "node index out of range", err)
 }
 
+func TestStore_CommonAncestor(t *testing.T) {
+	ctx := context.Background()
+	// This builds the following tree:
+	//
+	//	0 -- 1 -- 2 -- 3 -- 4
+	//	      \         \
+	//	       5         6 -- 7
+	f := setup(0, 0)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(2), indexToHash(1), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 3, indexToHash(3), indexToHash(2), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 4, indexToHash(4), indexToHash(3), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(5), indexToHash(1), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 4, indexToHash(6), indexToHash(3), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 5, indexToHash(7), indexToHash(6), [32]byte{}, 0, 0))
+
+	tests := []struct {
+		r1       [32]byte
+		r2       [32]byte
+		wantRoot [32]byte
+		wantSlot types.Slot
+	}{
+		{indexToHash(4), indexToHash(7), indexToHash(3), 3},
+		{indexToHash(7), indexToHash(5), indexToHash(1), 1},
+		{indexToHash(2), indexToHash(7), indexToHash(2), 2},
+		{indexToHash(4), indexToHash(4), indexToHash(4), 4},
+		{indexToHash(5), params.BeaconConfig().ZeroHash, params.BeaconConfig().ZeroHash, 0},
+	}
+	for _, tc := range tests {
+		root, slot, err := f.CommonAncestor(ctx, tc.r1, tc.r2)
+		require.NoError(t, err)
+		assert.Equal(t, tc.wantRoot, root)
+		assert.Equal(t, tc.wantSlot, slot)
+	}
+
+	_, _, err := f.CommonAncestor(ctx, indexToHash(4), indexToHash(100))
+	require.ErrorIs(t, err, errInvalidNodeIndex)
+}
+
 func TestStore_UpdateCanonicalNodes_WholeList(t *testing.T) {
 	ctx := context.Background()
 	f := &ForkChoice{store: &Store{}}
@@ -789,3 +828,36 @@ func TestStore_UpdateCanonicalNodes_RemoveOldCanonical(t *testing.T) {
 	_, ok := f.store.canonicalNodes[[32]byte{'c'}]
 	require.Equal(t, false, ok)
 }
+
+func TestForkChoice_ForkChoiceDump(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(2), indexToHash(1), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 3, indexToHash(3), indexToHash(2), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 4, indexToHash(4), indexToHash(3), [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 4, indexToHash(5), indexToHash(3), [32]byte{}, 0, 0))
+	require.NoError(t, f.SetSyncedTips(map[[32]byte]types.Slot{indexToHash(3): 3}))
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(5), 1)
+	_, err := f.Head(ctx, 0, params.BeaconConfig().ZeroHash, []uint64{10}, 0)
+	require.NoError(t, err)
+
+	nodes, err := f.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 6, len(nodes))
+	assert.DeepEqual(t, params.BeaconConfig().ZeroHash[:], nodes[0].Root)
+	assert.DeepEqual(t, params.BeaconConfig().ZeroHash[:], nodes[0].ParentRoot)
+	for i, n := range nodes[1:] {
+		root := indexToHash(uint64(i + 1))
+		assert.DeepEqual(t, root[:], n.Root)
+		assert.Equal(t, f.store.nodes[i+1].slot, n.Slot)
+	}
+	assert.DeepEqual(t, indexToHash(3), bytesutil.ToBytes32(nodes[5].ParentRoot))
+
+	wantWeights := []uint64{0, 10, 10, 10, 0, 10}
+	wantOptimistic := []bool{false, false, false, false, true, true}
+	for i, n := range nodes {
+		assert.Equal(t, wantWeights[i], n.Weight, "Wrong weight for node %d", i)
+		assert.Equal(t, wantOptimistic[i], n.Optimistic, "Wrong optimistic status for node %d", i)
+	}
+}
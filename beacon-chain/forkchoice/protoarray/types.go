@@ -25,6 +25,7 @@ type Store struct {
 	proposerBoostRoot          [fieldparams.RootLength]byte            // latest block root that was boosted after being received in a timely manner.
 	previousProposerBoostRoot  [fieldparams.RootLength]byte            // previous block root that was boosted after being received in a timely manner.
 	previousProposerBoostScore uint64                                  // previous proposer boosted root score.
+	equivocatingRoots          map[[fieldparams.RootLength]byte]bool   // block roots of proposers that equivocated in the current slot.
 	nodes                      []*Node                                 // list of block nodes, each node is a representation of one block.
 	nodesIndices               map[[fieldparams.RootLength]byte]uint64 // the root of block node and the nodes index in the list.
 	canonicalNodes             map[[fieldparams.RootLength]byte]bool   // the canonical block nodes.
@@ -44,6 +45,7 @@ type Node struct {
 	bestChild      uint64                       // bestChild index of this node.
 	bestDescendant uint64                       // bestDescendant of this node.
 	graffiti       [fieldparams.RootLength]byte // graffiti of the block node.
+	timely         bool                         // timely is true if the block arrived before the attestation deadline of its slot.
 }
 
 // optimisticStore defines a structure that tracks the tips of the fully
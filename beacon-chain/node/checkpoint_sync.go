@@ -0,0 +1,55 @@
+package node
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/sirupsen/logrus"
+)
+
+// checkpointSync downloads the finalized state and block of the beacon node serving the Beacon API at
+// the given URL, and saves them as the origin of the chain to sync from instead of genesis. This is
+// skipped if the database already contains blocks beyond genesis.
+func (b *BeaconNode) checkpointSync(ctx context.Context, url string) error {
+	_, err := b.db.OriginBlockRoot(ctx)
+	if err == nil {
+		log.Info("Database was already initialized from a checkpoint, skipping checkpoint sync")
+		return nil
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return errors.Wrap(err, "could not retrieve checkpoint sync origin from db")
+	}
+	head, err := b.db.HeadBlock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve head block from db")
+	}
+	if head != nil && !head.IsNil() && head.Block().Slot() > 0 {
+		log.WithField("headSlot", head.Block().Slot()).Warn("Database is not empty, skipping checkpoint sync")
+		return nil
+	}
+
+	client, err := beacon.NewClient(url)
+	if err != nil {
+		return err
+	}
+	od, err := beacon.DownloadFinalizedData(ctx, client)
+	if err != nil {
+		return errors.Wrap(err, "could not download checkpoint sync data")
+	}
+	if err := b.db.SaveOrigin(ctx, bytes.NewReader(od.StateBytes), bytes.NewReader(od.BlockBytes)); err != nil {
+		return errors.Wrap(err, "could not save checkpoint sync origin")
+	}
+	if od.DepositSnapshot != nil {
+		if err := b.db.SaveDepositSnapshot(ctx, od.DepositSnapshot); err != nil {
+			return errors.Wrap(err, "could not save deposit snapshot")
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"slot":            od.Block.Block().Slot(),
+		"depositSnapshot": od.DepositSnapshot != nil,
+	}).Info("Initialized database from checkpoint")
+	return nil
+}
@@ -1,12 +1,17 @@
 package node
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/cmd"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/config/params"
 	tracing2 "github.com/prysmaticlabs/prysm/monitoring/tracing"
+	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
 
@@ -53,12 +58,17 @@ func configureSafeSlotsToImportOptimistically(cliCtx *cli.Context) {
 	}
 }
 
-func configureSlotsPerArchivedPoint(cliCtx *cli.Context) {
+func configureSlotsPerArchivedPoint(cliCtx *cli.Context) error {
 	if cliCtx.IsSet(flags.SlotsPerArchivedPoint.Name) {
+		slots := cliCtx.Int(flags.SlotsPerArchivedPoint.Name)
+		if slots <= 0 {
+			return errors.Errorf("--%s must be positive, got %d", flags.SlotsPerArchivedPoint.Name, slots)
+		}
 		c := params.BeaconConfig()
-		c.SlotsPerArchivedPoint = types.Slot(cliCtx.Int(flags.SlotsPerArchivedPoint.Name))
+		c.SlotsPerArchivedPoint = types.Slot(slots)
 		params.OverrideBeaconConfig(c)
 	}
+	return nil
 }
 
 func configureEth1Config(cliCtx *cli.Context) {
@@ -112,3 +122,41 @@ func configureExecutionSetting(cliCtx *cli.Context) {
 		params.OverrideBeaconConfig(c)
 	}
 }
+
+func configureTerminalBlockOverrides(cliCtx *cli.Context) error {
+	if cliCtx.IsSet(flags.TerminalTotalDifficultyOverride.Name) {
+		ttd := cliCtx.String(flags.TerminalTotalDifficultyOverride.Name)
+		if _, ok := new(big.Int).SetString(ttd, 10); !ok {
+			return errors.Errorf("invalid terminal total difficulty %q, must be a decimal number", ttd)
+		}
+		c := params.BeaconConfig()
+		c.TerminalTotalDifficulty = ttd
+		params.OverrideBeaconConfig(c)
+		log.WithField("terminalTotalDifficulty", ttd).Warn("Overriding terminal total difficulty")
+	}
+	hashIsSet := cliCtx.IsSet(flags.TerminalBlockHashOverride.Name)
+	epochIsSet := cliCtx.IsSet(flags.TerminalBlockHashActivationEpochOverride.Name)
+	if hashIsSet != epochIsSet {
+		return errors.Errorf(
+			"--%s and --%s must be set together",
+			flags.TerminalBlockHashOverride.Name,
+			flags.TerminalBlockHashActivationEpochOverride.Name,
+		)
+	}
+	if hashIsSet {
+		enc := cliCtx.String(flags.TerminalBlockHashOverride.Name)
+		hash, err := hexutil.Decode(enc)
+		if err != nil || len(hash) != common.HashLength {
+			return errors.Errorf("invalid terminal block hash %q, must be a 32 byte hex string", enc)
+		}
+		c := params.BeaconConfig()
+		c.TerminalBlockHash = common.BytesToHash(hash)
+		c.TerminalBlockHashActivationEpoch = types.Epoch(cliCtx.Uint64(flags.TerminalBlockHashActivationEpochOverride.Name))
+		params.OverrideBeaconConfig(c)
+		log.WithFields(logrus.Fields{
+			"terminalBlockHash":                c.TerminalBlockHash.Hex(),
+			"terminalBlockHashActivationEpoch": c.TerminalBlockHashActivationEpoch,
+		}).Warn("Overriding terminal block hash")
+	}
+	return nil
+}
@@ -60,9 +60,12 @@ func TestConfigureSlotsPerArchivedPoint(t *testing.T) {
 	require.NoError(t, set.Set(flags.SlotsPerArchivedPoint.Name, strconv.Itoa(100)))
 	cliCtx := cli.NewContext(&app, set, nil)
 
-	configureSlotsPerArchivedPoint(cliCtx)
+	require.NoError(t, configureSlotsPerArchivedPoint(cliCtx))
 
 	assert.Equal(t, types.Slot(100), params.BeaconConfig().SlotsPerArchivedPoint)
+
+	require.NoError(t, set.Set(flags.SlotsPerArchivedPoint.Name, "0"))
+	require.ErrorContains(t, "must be positive", configureSlotsPerArchivedPoint(cliCtx))
 }
 
 func TestConfigureProofOfWork(t *testing.T) {
@@ -187,3 +190,33 @@ func TestConfigureInterop(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigureTerminalBlockOverrides(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	newContext := func(ttd, hash string, epoch uint64) *cli.Context {
+		set := flag.NewFlagSet("test", 0)
+		set.String(flags.TerminalTotalDifficultyOverride.Name, "", "")
+		set.String(flags.TerminalBlockHashOverride.Name, "", "")
+		set.Uint64(flags.TerminalBlockHashActivationEpochOverride.Name, 0, "")
+		if ttd != "" {
+			require.NoError(t, set.Set(flags.TerminalTotalDifficultyOverride.Name, ttd))
+		}
+		if hash != "" {
+			require.NoError(t, set.Set(flags.TerminalBlockHashOverride.Name, hash))
+		}
+		if epoch != 0 {
+			require.NoError(t, set.Set(flags.TerminalBlockHashActivationEpochOverride.Name, strconv.FormatUint(epoch, 10)))
+		}
+		return cli.NewContext(&cli.App{}, set, nil)
+	}
+	hash := common.HexToHash("0x01")
+
+	require.ErrorContains(t, "invalid terminal total difficulty", configureTerminalBlockOverrides(newContext("0x10", "", 0)))
+	require.ErrorContains(t, "must be set together", configureTerminalBlockOverrides(newContext("", hash.Hex(), 0)))
+	require.ErrorContains(t, "invalid terminal block hash", configureTerminalBlockOverrides(newContext("", "0x01", 10)))
+
+	require.NoError(t, configureTerminalBlockOverrides(newContext("100", hash.Hex(), 10)))
+	assert.Equal(t, "100", params.BeaconConfig().TerminalTotalDifficulty)
+	assert.Equal(t, hash, params.BeaconConfig().TerminalBlockHash)
+	assert.Equal(t, types.Epoch(10), params.BeaconConfig().TerminalBlockHashActivationEpoch)
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -16,18 +17,23 @@ import (
 	"syscall"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	apigateway "github.com/prysmaticlabs/prysm/api/gateway"
+	grpcutil "github.com/prysmaticlabs/prysm/api/grpc"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/compactor"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/pruner"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/slasherkv"
 	interopcoldstart "github.com/prysmaticlabs/prysm/beacon-chain/deterministic-genesis"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
-	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/beacon-chain/gateway"
 	"github.com/prysmaticlabs/prysm/beacon-chain/monitor"
 	"github.com/prysmaticlabs/prysm/beacon-chain/node/registration"
@@ -37,12 +43,19 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/powchain/preparation"
+	"github.com/prysmaticlabs/prysm/beacon-chain/powchain/terminal"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/blockfetcher"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/graphql"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/statefetcher"
 	"github.com/prysmaticlabs/prysm/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	regularsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/backfill"
 	initialsync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync"
 	"github.com/prysmaticlabs/prysm/cmd"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
@@ -99,6 +112,8 @@ type BeaconNode struct {
 	slasherBlockHeadersFeed *event.Feed
 	slasherAttestationsFeed *event.Feed
 	finalizedStateAtStartUp state.BeaconState
+	payloadIDCache          *cache.PayloadIDCache
+	livenessCache           *cache.LivenessCache
 	serviceFlagOpts         *serviceFlagOpts
 }
 
@@ -111,14 +126,21 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 	prereqs.WarnIfPlatformNotSupported(cliCtx.Context)
 	features.ConfigureBeaconChain(cliCtx)
 	cmd.ConfigureBeaconChain(cliCtx)
-	flags.ConfigureGlobalFlags(cliCtx)
+	if err := flags.ConfigureGlobalFlags(cliCtx); err != nil {
+		return nil, err
+	}
 	configureChainConfig(cliCtx)
 	configureHistoricalSlasher(cliCtx)
 	configureSafeSlotsToImportOptimistically(cliCtx)
-	configureSlotsPerArchivedPoint(cliCtx)
+	if err := configureSlotsPerArchivedPoint(cliCtx); err != nil {
+		return nil, err
+	}
 	configureEth1Config(cliCtx)
 	configureNetwork(cliCtx)
 	configureInteropConfig(cliCtx)
+	if err := configureTerminalBlockOverrides(cliCtx); err != nil {
+		return nil, err
+	}
 
 	// Initializes any forks here.
 	params.BeaconConfig().InitializeForkSchedule()
@@ -141,6 +163,8 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		syncCommitteePool:       synccommittee.NewPool(),
 		slasherBlockHeadersFeed: new(event.Feed),
 		slasherAttestationsFeed: new(event.Feed),
+		payloadIDCache:          cache.NewPayloadIDCache(),
+		livenessCache:           cache.NewLivenessCache(),
 		serviceFlagOpts:         &serviceFlagOpts{},
 	}
 
@@ -167,6 +191,9 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 	if err := beacon.startStateGen(); err != nil {
 		return nil, err
 	}
+	if err := verifyWeakSubjectivityPeriod(beacon.ctx, cliCtx, beacon.finalizedStateAtStartUp); err != nil {
+		return nil, err
+	}
 
 	log.Debugln("Registering P2P Service")
 	if err := beacon.registerP2P(cliCtx); err != nil {
@@ -196,6 +223,16 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Terminal Block Service")
+	if err := beacon.registerTerminalBlockService(); err != nil {
+		return nil, err
+	}
+
+	log.Debugln("Registering Payload Preparation Service")
+	if err := beacon.registerPayloadPreparationService(); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Registering Intial Sync Service")
 	if err := beacon.registerInitialSyncService(); err != nil {
 		return nil, err
@@ -206,6 +243,21 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Backfill Service")
+	if err := beacon.registerBackfillService(); err != nil {
+		return nil, err
+	}
+
+	log.Debugln("Registering Pruner Service")
+	if err := beacon.registerPrunerService(); err != nil {
+		return nil, err
+	}
+
+	log.Debugln("Registering Compactor Service")
+	if err := beacon.registerCompactorService(); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Registering Slasher Service")
 	if err := beacon.registerSlasherService(); err != nil {
 		return nil, err
@@ -236,7 +288,7 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 	// db.DatabasePath is the path to the containing directory
 	// db.NewDBFilename expands that to the canonical full path using
 	// the same constuction as NewDB()
-	c, err := newBeaconNodePromCollector(db.NewDBFilename(beacon.db.DatabasePath()))
+	c, err := newBeaconNodePromCollector(db.NewDBFilename(beacon.db.DatabasePath(), backend.Kind(cliCtx.String(cmd.DBBackendFlag.Name))))
 	if err != nil {
 		return nil, err
 	}
@@ -310,7 +362,7 @@ func (b *BeaconNode) Close() {
 }
 
 func (b *BeaconNode) startForkChoice() {
-	f := protoarray.New(0, 0, params.BeaconConfig().ZeroHash)
+	f := forkchoice.New(0, 0, params.BeaconConfig().ZeroHash)
 	b.forkChoiceStore = f
 }
 
@@ -322,9 +374,16 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 
 	log.WithField("database-path", dbPath).Info("Checking DB")
 
-	d, err := db.NewDB(b.ctx, dbPath, &kv.Config{
+	dbBackend, err := backend.ParseKind(cliCtx.String(cmd.DBBackendFlag.Name))
+	if err != nil {
+		return err
+	}
+	dbConfig := &kv.Config{
 		InitialMMapSize: cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
-	})
+		Backend:         dbBackend,
+		BlindedBlocks:   cliCtx.Bool(flags.BlindedBlockStorageFlag.Name),
+	}
+	d, err := db.NewDB(b.ctx, dbPath, dbConfig)
 	if err != nil {
 		return err
 	}
@@ -346,9 +405,7 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 		if err := d.ClearDB(); err != nil {
 			return errors.Wrap(err, "could not clear database")
 		}
-		d, err = db.NewDB(b.ctx, dbPath, &kv.Config{
-			InitialMMapSize: cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
-		})
+		d, err = db.NewDB(b.ctx, dbPath, dbConfig)
 		if err != nil {
 			return errors.Wrap(err, "could not create new database")
 		}
@@ -357,6 +414,11 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 	if err := d.RunMigrations(b.ctx); err != nil {
 		return err
 	}
+	if cliCtx.Bool(flags.VerifyDBFlag.Name) {
+		if _, err := db.VerifyIntegrity(b.ctx, d, true); err != nil {
+			return err
+		}
+	}
 
 	b.db = d
 
@@ -389,6 +451,11 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 	if err := b.db.EnsureEmbeddedGenesis(b.ctx); err != nil {
 		return err
 	}
+	if cliCtx.IsSet(flags.CheckpointSyncURLFlag.Name) {
+		if err := b.checkpointSync(b.ctx, cliCtx.String(flags.CheckpointSyncURLFlag.Name)); err != nil {
+			return errors.Wrap(err, "could not checkpoint sync")
+		}
+	}
 	knownContract, err := b.db.DepositContractAddress(b.ctx)
 	if err != nil {
 		return err
@@ -457,7 +524,22 @@ func (b *BeaconNode) startSlasherDB(cliCtx *cli.Context) error {
 }
 
 func (b *BeaconNode) startStateGen() error {
-	b.stateGen = stategen.New(b.db)
+	var opts []stategen.StateGenOption
+	if b.cliCtx.IsSet(flags.HotStateCacheSize.Name) {
+		size := b.cliCtx.Int(flags.HotStateCacheSize.Name)
+		if size <= 0 {
+			return errors.Errorf("--%s must be positive, got %d", flags.HotStateCacheSize.Name, size)
+		}
+		opts = append(opts, stategen.WithHotStateCacheSize(size))
+	}
+	if b.cliCtx.IsSet(flags.SaveHotStateInterval.Name) {
+		interval := b.cliCtx.Uint64(flags.SaveHotStateInterval.Name)
+		if interval == 0 {
+			return errors.Errorf("--%s must be positive", flags.SaveHotStateInterval.Name)
+		}
+		opts = append(opts, stategen.WithHotStateDBInterval(types.Slot(interval)))
+	}
+	b.stateGen = stategen.New(b.db, opts...)
 
 	cp, err := b.db.FinalizedCheckpoint(b.ctx)
 	if err != nil {
@@ -491,27 +573,50 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	addressFamily := cliCtx.String(cmd.P2PPreferredAddressFamily.Name)
+	if addressFamily != "" && addressFamily != "ipv4" && addressFamily != "ipv6" {
+		return errors.Errorf("invalid preferred address family %q, expected ipv4 or ipv6", addressFamily)
+	}
 
+	// A zero inbound ratio leaves no room for inbound peers, which is the no-inbound mode, whereas a zero
+	// ratio in the p2p config stands for the default ratio.
+	inboundRatio := cliCtx.Float64(cmd.P2PInboundRatio.Name)
+	noInbound := cliCtx.Bool(cmd.P2PNoInbound.Name) || inboundRatio == 0
 	svc, err := p2p.NewService(b.ctx, &p2p.Config{
-		NoDiscovery:       cliCtx.Bool(cmd.NoDiscovery.Name),
-		StaticPeers:       slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.StaticPeers.Name)),
-		BootstrapNodeAddr: bootstrapNodeAddrs,
-		RelayNodeAddr:     cliCtx.String(cmd.RelayNode.Name),
-		DataDir:           dataDir,
-		LocalIP:           cliCtx.String(cmd.P2PIP.Name),
-		HostAddress:       cliCtx.String(cmd.P2PHost.Name),
-		HostDNS:           cliCtx.String(cmd.P2PHostDNS.Name),
-		PrivateKey:        cliCtx.String(cmd.P2PPrivKey.Name),
-		MetaDataDir:       cliCtx.String(cmd.P2PMetadata.Name),
-		TCPPort:           cliCtx.Uint(cmd.P2PTCPPort.Name),
-		UDPPort:           cliCtx.Uint(cmd.P2PUDPPort.Name),
-		MaxPeers:          cliCtx.Uint(cmd.P2PMaxPeers.Name),
-		AllowListCIDR:     cliCtx.String(cmd.P2PAllowList.Name),
-		DenyListCIDR:      slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PDenyList.Name)),
-		EnableUPnP:        cliCtx.Bool(cmd.EnableUPnPFlag.Name),
-		DisableDiscv5:     cliCtx.Bool(flags.DisableDiscv5.Name),
-		StateNotifier:     b,
-		DB:                b.db,
+		NoDiscovery:             cliCtx.Bool(cmd.NoDiscovery.Name),
+		StaticPeers:             slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.StaticPeers.Name)),
+		BootstrapNodeAddr:       bootstrapNodeAddrs,
+		RelayNodeAddr:           cliCtx.String(cmd.RelayNode.Name),
+		DataDir:                 dataDir,
+		LocalIP:                 cliCtx.String(cmd.P2PIP.Name),
+		HostAddress:             cliCtx.String(cmd.P2PHost.Name),
+		HostDNS:                 cliCtx.String(cmd.P2PHostDNS.Name),
+		ENREntries:              cliCtx.StringSlice(cmd.P2PENREntries.Name),
+		LocalIPv6:               cliCtx.String(cmd.P2PIPv6.Name),
+		HostAddressIPv6:         cliCtx.String(cmd.P2PHostIPv6.Name),
+		PreferIPv6:              addressFamily == "ipv6",
+		PrivateKey:              cliCtx.String(cmd.P2PPrivKey.Name),
+		MetaDataDir:             cliCtx.String(cmd.P2PMetadata.Name),
+		TCPPort:                 cliCtx.Uint(cmd.P2PTCPPort.Name),
+		UDPPort:                 cliCtx.Uint(cmd.P2PUDPPort.Name),
+		MaxPeers:                cliCtx.Uint(cmd.P2PMaxPeers.Name),
+		InboundRatio:            inboundRatio,
+		NoInbound:               noInbound,
+		MaxMessageSize:          cliCtx.Uint64(cmd.P2PMaxMessageSize.Name),
+		AllowListCIDR:           cliCtx.String(cmd.P2PAllowList.Name),
+		DenyListCIDR:            slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PDenyList.Name)),
+		EnableUPnP:              cliCtx.Bool(cmd.EnableUPnPFlag.Name),
+		DisableDiscv5:           cliCtx.Bool(flags.DisableDiscv5.Name),
+		GossipScoringConfigFile: cliCtx.String(flags.GossipScoringConfig.Name),
+		StateNotifier:           b,
+		DB:                      b.db,
+		ResourceLimits: p2p.ResourceLimits{
+			MaxConnsPerPeer:       cliCtx.Int(cmd.P2PMaxConnsPerPeer.Name),
+			MaxStreamsPerPeer:     cliCtx.Int(cmd.P2PMaxStreamsPerPeer.Name),
+			MaxStreamsPerProtocol: cliCtx.Int(cmd.P2PMaxStreamsPerProtocol.Name),
+			MaxMemoryPerPeer:      cliCtx.Uint64(cmd.P2PMaxMemoryPerPeer.Name),
+			MaxMemoryPerProtocol:  cliCtx.Uint64(cmd.P2PMaxMemoryPerProtocol.Name),
+		},
 	})
 	if err != nil {
 		return err
@@ -564,7 +669,12 @@ func (b *BeaconNode) registerBlockchainService() error {
 		blockchain.WithStateGen(b.stateGen),
 		blockchain.WithSlasherAttestationsFeed(b.slasherAttestationsFeed),
 		blockchain.WithFinalizedStateAtStartUp(b.finalizedStateAtStartUp),
+		blockchain.WithLivenessCache(b.livenessCache),
 	)
+	// Execution payloads are only validated when connected to an execution node.
+	if client := web3Service.EngineAPIClient(); client != nil {
+		opts = append(opts, blockchain.WithExecutionEngineCaller(client))
+	}
 	blockchainService, err := blockchain.NewService(b.ctx, opts...)
 	if err != nil {
 		return errors.Wrap(err, "could not register blockchain service")
@@ -600,10 +710,81 @@ func (b *BeaconNode) registerPOWChainService() error {
 	if err != nil {
 		return errors.Wrap(err, "could not register proof-of-work chain web3Service")
 	}
+	// Blinded blocks are read back with the execution payload bodies of the execution node.
+	if client := web3Service.EngineAPIClient(); client != nil {
+		b.db.SetPayloadBodiesFetcher(client)
+	} else if b.cliCtx.Bool(flags.BlindedBlockStorageFlag.Name) {
+		return errors.Errorf("--%s requires an execution node to reconstruct blinded blocks", flags.BlindedBlockStorageFlag.Name)
+	}
 
 	return b.services.RegisterService(web3Service)
 }
 
+func (b *BeaconNode) registerTerminalBlockService() error {
+	var web3Service *powchain.Service
+	if err := b.services.FetchService(&web3Service); err != nil {
+		return err
+	}
+	// The terminal block can only be searched when connected to an execution node.
+	client := web3Service.EngineAPIClient()
+	if client == nil {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	svc, err := terminal.NewService(
+		b.ctx,
+		terminal.WithEngineCaller(client),
+		terminal.WithHeadFetcher(chainService),
+		terminal.WithStateNotifier(b),
+		terminal.WithTimeFetcher(chainService),
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not register terminal block service")
+	}
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerPayloadPreparationService() error {
+	var web3Service *powchain.Service
+	if err := b.services.FetchService(&web3Service); err != nil {
+		return err
+	}
+	// Payloads can only be prepared when connected to an execution node.
+	client := web3Service.EngineAPIClient()
+	if client == nil {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	// Proposals of untracked validators are still prepared on demand by the validator RPC server.
+	var tracked []types.ValidatorIndex
+	for _, idx := range b.cliCtx.IntSlice(flags.PayloadPreparationIndicesFlag.Name) {
+		tracked = append(tracked, types.ValidatorIndex(idx))
+	}
+	opts := []preparation.Option{
+		preparation.WithEngineCaller(client),
+		preparation.WithChainInfoFetcher(chainService),
+		preparation.WithDatabase(b.db),
+		preparation.WithPayloadIDCache(b.payloadIDCache),
+		preparation.WithTrackedValidators(tracked),
+		preparation.WithFeeRecipient(params.BeaconConfig().FeeRecipient),
+		preparation.WithStateNotifier(b),
+	}
+	if b.cliCtx.IsSet(flags.PayloadPreparationLeadTimeFlag.Name) {
+		opts = append(opts, preparation.WithLeadTime(b.cliCtx.Duration(flags.PayloadPreparationLeadTimeFlag.Name)))
+	}
+	svc, err := preparation.NewService(b.ctx, opts...)
+	if err != nil {
+		return errors.Wrap(err, "could not register payload preparation service")
+	}
+	return b.services.RegisterService(svc)
+}
+
 func (b *BeaconNode) registerSyncService() error {
 	var web3Service *powchain.Service
 	if err := b.services.FetchService(&web3Service); err != nil {
@@ -657,6 +838,56 @@ func (b *BeaconNode) registerInitialSyncService() error {
 	return b.services.RegisterService(is)
 }
 
+func (b *BeaconNode) registerBackfillService() error {
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+
+	// Backfilled blocks saved blinded are only kept once the execution node can reconstruct them.
+	var payloadBodies backfill.PayloadBodiesFetcher
+	if b.cliCtx.Bool(flags.BlindedBlockStorageFlag.Name) {
+		var web3Service *powchain.Service
+		if err := b.services.FetchService(&web3Service); err != nil {
+			return err
+		}
+		if client := web3Service.EngineAPIClient(); client != nil {
+			payloadBodies = client
+		}
+	}
+
+	svc := backfill.NewService(b.ctx, &backfill.Config{
+		P2P:             b.fetchP2P(),
+		DB:              b.db,
+		Chain:           chainService,
+		RetentionEpochs: types.Epoch(b.cliCtx.Uint64(flags.BackfillRetentionEpochsFlag.Name)),
+		PayloadBodies:   payloadBodies,
+	})
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerPrunerService() error {
+	retention := b.cliCtx.Uint64(flags.PruneRetentionEpochsFlag.Name)
+	if retention == 0 {
+		return nil
+	}
+	svc := pruner.NewService(b.ctx, &pruner.Config{
+		DB:              b.db,
+		RetentionEpochs: types.Epoch(retention),
+		DryRun:          b.cliCtx.Bool(flags.PruneDryRunFlag.Name),
+	})
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerCompactorService() error {
+	svc := compactor.NewService(b.ctx, &compactor.Config{
+		DB:           b.db,
+		Interval:     b.cliCtx.Duration(flags.DBCompactionIntervalFlag.Name),
+		MinFreeRatio: b.cliCtx.Float64(flags.DBCompactionMinFreeRatioFlag.Name),
+	})
+	return b.services.RegisterService(svc)
+}
+
 func (b *BeaconNode) registerSlasherService() error {
 	if !features.Get().EnableSlasher {
 		return nil
@@ -703,6 +934,13 @@ func (b *BeaconNode) registerRPCService() error {
 		return err
 	}
 
+	// The backfill service is not registered when the database is read-only.
+	var backfillChecker backfill.Checker
+	var backfillService *backfill.Service
+	if err := b.services.FetchService(&backfillService); err == nil {
+		backfillChecker = backfillService
+	}
+
 	var slasherService *slasher.Service
 	if features.Get().EnableSlasher {
 		if err := b.services.FetchService(&slasherService); err != nil {
@@ -726,12 +964,32 @@ func (b *BeaconNode) registerRPCService() error {
 		chainStartFetcher = web3Service
 	}
 
+	// Blinded blocks submitted to the beacon API are unblinded with the payloads of the execution node,
+	// which also builds the payloads of proposed blocks.
+	var payloadBodiesFetcher db.PayloadBodiesFetcher
+	var engineCaller engine.EngineCaller
+	var engineLatencyFetcher engine.LatencyFetcher
+	var engineConnChecker engine.ConnectionChecker
+	var payloadPreparer preparation.PayloadPreparer
+	if client := web3Service.EngineAPIClient(); client != nil {
+		payloadBodiesFetcher = client
+		engineCaller = client
+		engineLatencyFetcher = client
+		engineConnChecker = client
+		var preparationService *preparation.Service
+		if err := b.services.FetchService(&preparationService); err != nil {
+			return err
+		}
+		payloadPreparer = preparationService
+	}
+
 	host := b.cliCtx.String(flags.RPCHost.Name)
 	port := b.cliCtx.String(flags.RPCPort.Name)
 	beaconMonitoringHost := b.cliCtx.String(cmd.MonitoringHostFlag.Name)
 	beaconMonitoringPort := b.cliCtx.Int(flags.MonitoringPortFlag.Name)
 	cert := b.cliCtx.String(flags.CertFlag.Name)
 	key := b.cliCtx.String(flags.KeyFlag.Name)
+	clientCA := b.cliCtx.String(flags.ClientCAFlag.Name)
 	mockEth1DataVotes := b.cliCtx.Bool(flags.InteropMockEth1DataVotesFlag.Name)
 
 	maxMsgSize := b.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name)
@@ -740,7 +998,10 @@ func (b *BeaconNode) registerRPCService() error {
 		maxMsgSize = int(math.Max(float64(maxMsgSize), debugGrpcMaxMsgSize))
 	}
 
-	p2pService := b.fetchP2P()
+	var p2pService *p2p.Service
+	if err := b.services.FetchService(&p2pService); err != nil {
+		return err
+	}
 	rpcService := rpc.NewService(b.ctx, &rpc.Config{
 		Host:                    host,
 		Port:                    port,
@@ -748,16 +1009,20 @@ func (b *BeaconNode) registerRPCService() error {
 		BeaconMonitoringPort:    beaconMonitoringPort,
 		CertFlag:                cert,
 		KeyFlag:                 key,
+		ClientCAFlag:            clientCA,
 		BeaconDB:                b.db,
 		Broadcaster:             p2pService,
 		PeersFetcher:            p2pService,
 		PeerManager:             p2pService,
+		StaticPeerManager:       p2pService,
+		BanManager:              p2pService,
 		MetadataProvider:        p2pService,
 		ChainInfoFetcher:        chainService,
 		HeadFetcher:             chainService,
 		CanonicalFetcher:        chainService,
 		ForkFetcher:             chainService,
 		FinalizationFetcher:     chainService,
+		LightClientFetcher:      chainService,
 		BlockReceiver:           chainService,
 		AttestationReceiver:     chainService,
 		GenesisTimeFetcher:      chainService,
@@ -769,17 +1034,30 @@ func (b *BeaconNode) registerRPCService() error {
 		SyncCommitteeObjectPool: b.syncCommitteePool,
 		POWChainService:         web3Service,
 		POWChainInfoFetcher:     web3Service,
+		PayloadBodiesFetcher:    payloadBodiesFetcher,
 		ChainStartFetcher:       chainStartFetcher,
 		MockEth1Votes:           mockEth1DataVotes,
 		SyncService:             syncService,
+		BackfillChecker:         backfillChecker,
 		DepositFetcher:          depositFetcher,
 		PendingDepositFetcher:   b.depositCache,
 		BlockNotifier:           b,
 		StateNotifier:           b,
 		OperationNotifier:       b,
 		StateGen:                b.stateGen,
+		LivenessCache:           b.livenessCache,
+		ExecutionEngineCaller:   engineCaller,
+		EngineLatencyFetcher:    engineLatencyFetcher,
+		EngineConnChecker:       engineConnChecker,
+		PayloadIDCache:          b.payloadIDCache,
+		PayloadPreparer:         payloadPreparer,
 		EnableDebugRPCEndpoints: enableDebugRPCEndpoints,
+		EnablePeerManagement:    b.cliCtx.Bool(flags.EnablePeerManagementRPCEndpoints.Name),
 		MaxMsgSize:              maxMsgSize,
+		MaxSendMsgSize:          b.cliCtx.Int(cmd.GrpcMaxCallSendMsgSizeFlag.Name),
+		KeepaliveTime:           b.cliCtx.Duration(cmd.GrpcKeepaliveTimeFlag.Name),
+		KeepaliveTimeout:        b.cliCtx.Duration(cmd.GrpcKeepaliveTimeoutFlag.Name),
+		KeepaliveMinTime:        b.cliCtx.Duration(flags.GRPCKeepaliveMinTimeFlag.Name),
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -805,11 +1083,31 @@ func (b *BeaconNode) registerPrometheusService(cliCtx *cli.Context) error {
 				Path:    "/db/backup",
 				Handler: backup.BackupHandler(b.db, cliCtx.String(cmd.BackupWebhookOutputDir.Name)),
 			},
+			prometheus.Handler{
+				Path:    "/db/backup/stream",
+				Handler: backup.StreamBackupHandler(b.db, "prysm_beacondb"),
+			},
 		)
 	}
 
+	if cliCtx.Bool(flags.EnableDBCompactionWebhookFlag.Name) {
+		var compactorService *compactor.Service
+		if err := b.services.FetchService(&compactorService); err != nil {
+			panic(err)
+		}
+		additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/db/compact", Handler: compactorService.CompactHandler})
+	}
+
 	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/tree", Handler: c.TreeHandler})
 
+	var terminalService *terminal.Service
+	if err := b.services.FetchService(&terminalService); err == nil {
+		additionalHandlers = append(additionalHandlers, prometheus.Handler{
+			Path:    "/merge/terminal-block",
+			Handler: terminalService.TerminalBlockHandler,
+		})
+	}
+
 	service := prometheus.NewService(
 		fmt.Sprintf("%s:%d", b.cliCtx.String(cmd.MonitoringHostFlag.Name), b.cliCtx.Int(flags.MonitoringPortFlag.Name)),
 		b.services,
@@ -856,9 +1154,54 @@ func (b *BeaconNode) registerGRPCGateway() error {
 		apigateway.WithMaxCallRecvMsgSize(maxCallSize),
 		apigateway.WithAllowedOrigins(allowedOrigins),
 	}
+	if b.cliCtx.String(flags.ClientCAFlag.Name) != "" {
+		// The gRPC server requires a client certificate, so the gateway presents its own certificate, or the
+		// certificate of the node when none is set.
+		clientCert, clientKey := selfCert, b.cliCtx.String(flags.KeyFlag.Name)
+		if b.cliCtx.IsSet(flags.GRPCGatewayClientCertFlag.Name) {
+			clientCert = b.cliCtx.String(flags.GRPCGatewayClientCertFlag.Name)
+			clientKey = b.cliCtx.String(flags.GRPCGatewayClientKeyFlag.Name)
+		}
+		if err := grpcutil.VerifyClientCertUsage(clientCert); err != nil {
+			return errors.Wrapf(err, "gRPC gateway cannot authenticate to the gRPC server, set the --%s and --%s flags",
+				flags.GRPCGatewayClientCertFlag.Name, flags.GRPCGatewayClientKeyFlag.Name)
+		}
+		opts = append(opts, apigateway.WithRemoteClientCert(clientCert, clientKey))
+	}
+	if authConfig := b.cliCtx.String(flags.GRPCGatewayAuthConfig.Name); authConfig != "" {
+		opts = append(opts, apigateway.WithAuthConfig(authConfig))
+	}
+	// Endpoints without a gRPC counterpart are served directly by the gateway router.
+	router := mux.NewRouter()
 	if flags.EnableHTTPEthAPI(httpModules) {
+		router.HandleFunc(apimiddleware.DepositSnapshotPath, apimiddleware.DepositSnapshotHandler(b.db)).Methods(http.MethodGet)
 		opts = append(opts, apigateway.WithApiMiddleware(&apimiddleware.BeaconEndpointFactory{}))
 	}
+	if b.cliCtx.Bool(flags.EnableGraphQL.Name) {
+		var chainService *blockchain.Service
+		if err := b.services.FetchService(&chainService); err != nil {
+			return err
+		}
+		graphQLServer := &graphql.Server{
+			ChainInfoFetcher: chainService,
+			BlockFetcher: &blockfetcher.BlockProvider{
+				BeaconDB:         b.db,
+				ChainInfoFetcher: chainService,
+			},
+			StateFetcher: &statefetcher.StateProvider{
+				BeaconDB:           b.db,
+				ChainInfoFetcher:   chainService,
+				GenesisTimeFetcher: chainService,
+				StateGenService:    b.stateGen,
+			},
+		}
+		graphQLHandler, err := graphQLServer.Handler()
+		if err != nil {
+			return err
+		}
+		router.Handle(graphql.Path, graphQLHandler).Methods(http.MethodPost)
+	}
+	opts = append(opts, apigateway.WithRouter(router))
 	g, err := apigateway.New(b.ctx, opts...)
 	if err != nil {
 		return err
@@ -901,9 +1244,12 @@ func (b *BeaconNode) registerValidatorMonitorService() error {
 	if cliSlice == nil {
 		return nil
 	}
-	tracked := make([]types.ValidatorIndex, len(cliSlice))
-	for i := range tracked {
-		tracked[i] = types.ValidatorIndex(cliSlice[i])
+	// Proposals of untracked validators are still prepared on demand by the validator RPC server.
+	var tracked []types.ValidatorIndex
+	if cmd.ValidatorMonitorIndicesFlag.Value != nil {
+		for _, idx := range cmd.ValidatorMonitorIndicesFlag.Value.Value() {
+			tracked = append(tracked, types.ValidatorIndex(idx))
+		}
 	}
 
 	var chainService *blockchain.Service
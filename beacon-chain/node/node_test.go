@@ -9,6 +9,7 @@ import (
 
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/urfave/cli/v2"
@@ -30,6 +31,7 @@ func TestNodeClose_OK(t *testing.T) {
 	set.String("p2p-encoding", "ssz", "p2p encoding scheme")
 	set.Bool("demo-config", true, "demo configuration")
 	set.String("deposit-contract", "0x0000000000000000000000000000000000000000", "deposit contract address")
+	set.Bool(flags.IgnoreWeakSubjectivityPeriod.Name, true, "start from the stale mainnet genesis state")
 
 	context := cli.NewContext(&app, set, nil)
 
@@ -52,6 +54,7 @@ func TestClearDB(t *testing.T) {
 	set := flag.NewFlagSet("test", 0)
 	set.String("datadir", tmp, "node data directory")
 	set.Bool(cmd.ForceClearDB.Name, true, "force clear db")
+	set.Bool(flags.IgnoreWeakSubjectivityPeriod.Name, true, "start from the stale mainnet genesis state")
 
 	context := cli.NewContext(&app, set, nil)
 	_, err := New(context)
@@ -3,6 +3,7 @@ package node
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -53,7 +54,24 @@ func (bc *bcnodeCollector) getCurrentDbBytes() (float64, error) {
 	if err != nil {
 		return 0, fmt.Errorf("could not collect database file size for prometheus, path=%s, err=%s", bc.dbPath, err)
 	}
-	return float64(fs.Size()), nil
+	if !fs.IsDir() {
+		return float64(fs.Size()), nil
+	}
+	// Pebble databases are directories of files.
+	var size int64
+	err = filepath.Walk(bc.dbPath, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not collect database size for prometheus, path=%s, err=%s", bc.dbPath, err)
+	}
+	return float64(size), nil
 }
 
 func (bc *bcnodeCollector) unregister() {
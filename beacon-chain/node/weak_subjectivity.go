@@ -0,0 +1,59 @@
+package node
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var errStateOutsideWeakSubjectivityPeriod = errors.New("finalized state is older than the weak subjectivity period")
+
+// verifyWeakSubjectivityPeriod refuses to sync from a finalized state older than the weak subjectivity
+// period computed from its validator set, as the chain built on top of such a state may be the result
+// of a long range attack. Syncing from a stale state is only allowed with a more recent weak subjectivity
+// checkpoint which is itself within the weak subjectivity period, and which the synced chain is then
+// required to pass through, or when explicitly overridden.
+func verifyWeakSubjectivityPeriod(ctx context.Context, cliCtx *cli.Context, st state.BeaconState) error {
+	if st == nil || st.IsNil() {
+		return nil
+	}
+	wsPeriod, err := helpers.ComputeWeakSubjectivityPeriod(ctx, st)
+	if err != nil {
+		return errors.Wrap(err, "could not compute weak subjectivity period")
+	}
+	stateEpoch := slots.ToEpoch(st.Slot())
+	currentEpoch := slots.ToEpoch(slots.CurrentSlot(st.GenesisTime()))
+	log.WithFields(logrus.Fields{
+		"stateEpoch":             stateEpoch,
+		"weakSubjectivityPeriod": wsPeriod,
+	}).Info("Computed weak subjectivity period of finalized state")
+	if currentEpoch <= stateEpoch+wsPeriod {
+		return nil
+	}
+
+	wsCheckpt, err := helpers.ParseWeakSubjectivityInputString(cliCtx.String(flags.WeakSubjectivityCheckpt.Name))
+	if err != nil {
+		return err
+	}
+	if wsCheckpt != nil && wsCheckpt.Epoch > stateEpoch && currentEpoch <= wsCheckpt.Epoch+wsPeriod {
+		log.WithField("checkpointEpoch", wsCheckpt.Epoch).Info(
+			"Finalized state is older than the weak subjectivity period, syncing through the weak subjectivity checkpoint")
+		return nil
+	}
+	if cliCtx.Bool(flags.IgnoreWeakSubjectivityPeriod.Name) {
+		log.WithFields(logrus.Fields{
+			"stateEpoch":   stateEpoch,
+			"currentEpoch": currentEpoch,
+		}).Warn("Finalized state is older than the weak subjectivity period, syncing from it anyway")
+		return nil
+	}
+	return errors.Wrapf(errStateOutsideWeakSubjectivityPeriod,
+		"state epoch %d, current epoch %d, weak subjectivity period %d epochs: provide a more recent --%s or override with --%s",
+		stateEpoch, currentEpoch, wsPeriod, flags.WeakSubjectivityCheckpt.Name, flags.IgnoreWeakSubjectivityPeriod.Name)
+}
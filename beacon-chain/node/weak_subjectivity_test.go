@@ -0,0 +1,70 @@
+package node
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"github.com/urfave/cli/v2"
+)
+
+func TestVerifyWeakSubjectivityPeriod(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisState(t, 64)
+	wsPeriod, err := helpers.ComputeWeakSubjectivityPeriod(ctx, st)
+	require.NoError(t, err)
+	epochDuration := time.Duration(uint64(params.BeaconConfig().SlotsPerEpoch)*params.BeaconConfig().SecondsPerSlot) * time.Second
+	staleGenesis := uint64(time.Now().Add(-time.Duration(wsPeriod+2) * epochDuration).Unix())
+
+	newCliCtx := func(t *testing.T, wsCheckpoint string, ignore bool) *cli.Context {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.String(flags.WeakSubjectivityCheckpt.Name, "", "")
+		set.Bool(flags.IgnoreWeakSubjectivityPeriod.Name, false, "")
+		require.NoError(t, set.Set(flags.WeakSubjectivityCheckpt.Name, wsCheckpoint))
+		require.NoError(t, set.Set(flags.IgnoreWeakSubjectivityPeriod.Name, fmt.Sprintf("%t", ignore)))
+		return cli.NewContext(&app, set, nil)
+	}
+
+	t.Run("no state", func(t *testing.T) {
+		require.NoError(t, verifyWeakSubjectivityPeriod(ctx, newCliCtx(t, "", false), nil))
+	})
+	t.Run("within weak subjectivity period", func(t *testing.T) {
+		require.NoError(t, st.SetGenesisTime(uint64(time.Now().Unix())))
+		require.NoError(t, verifyWeakSubjectivityPeriod(ctx, newCliCtx(t, "", false), st))
+	})
+	t.Run("stale state", func(t *testing.T) {
+		require.NoError(t, st.SetGenesisTime(staleGenesis))
+		err := verifyWeakSubjectivityPeriod(ctx, newCliCtx(t, "", false), st)
+		require.ErrorIs(t, err, errStateOutsideWeakSubjectivityPeriod)
+	})
+	t.Run("stale state with older checkpoint", func(t *testing.T) {
+		require.NoError(t, st.SetGenesisTime(staleGenesis))
+		wsCheckpoint := fmt.Sprintf("%#x:0", params.BeaconConfig().ZeroHash)
+		err := verifyWeakSubjectivityPeriod(ctx, newCliCtx(t, wsCheckpoint, false), st)
+		require.ErrorIs(t, err, errStateOutsideWeakSubjectivityPeriod)
+	})
+	t.Run("stale state with stale checkpoint", func(t *testing.T) {
+		require.NoError(t, st.SetGenesisTime(staleGenesis))
+		// The checkpoint is more recent than the state, but also older than the weak subjectivity period.
+		wsCheckpoint := fmt.Sprintf("%#x:1", params.BeaconConfig().ZeroHash)
+		err := verifyWeakSubjectivityPeriod(ctx, newCliCtx(t, wsCheckpoint, false), st)
+		require.ErrorIs(t, err, errStateOutsideWeakSubjectivityPeriod)
+	})
+	t.Run("stale state with recent checkpoint", func(t *testing.T) {
+		require.NoError(t, st.SetGenesisTime(staleGenesis))
+		wsCheckpoint := fmt.Sprintf("%#x:%d", params.BeaconConfig().ZeroHash, wsPeriod)
+		require.NoError(t, verifyWeakSubjectivityPeriod(ctx, newCliCtx(t, wsCheckpoint, false), st))
+	})
+	t.Run("stale state with override", func(t *testing.T) {
+		require.NoError(t, st.SetGenesisTime(staleGenesis))
+		require.NoError(t, verifyWeakSubjectivityPeriod(ctx, newCliCtx(t, "", true), st))
+	})
+}
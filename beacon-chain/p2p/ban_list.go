@@ -0,0 +1,256 @@
+package p2p
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"path"
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/io/file"
+)
+
+const banListPath = "banlist.json"
+
+// banList holds the peers and ip ranges banned by the operator. Unlike bad peers, which are
+// forgotten on restart, bans are persisted to the data directory until they are lifted.
+type banList struct {
+	lock     sync.RWMutex
+	path     string
+	peers    map[peer.ID]bool
+	networks map[string]*net.IPNet
+}
+
+// persistedBanList is the on-disk representation of the ban list.
+type persistedBanList struct {
+	Peers    []string `json:"peers"`
+	Networks []string `json:"networks"`
+}
+
+// loadBanList reads the ban list from the data directory. The ban list is kept in memory
+// only when no data directory is given.
+func loadBanList(dataDir string) (*banList, error) {
+	b := &banList{
+		peers:    make(map[peer.ID]bool),
+		networks: make(map[string]*net.IPNet),
+	}
+	if dataDir == "" {
+		return b, nil
+	}
+	b.path = path.Join(dataDir, banListPath)
+	if !file.FileExists(b.path) {
+		return b, nil
+	}
+	enc, err := ioutil.ReadFile(b.path) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read ban list")
+	}
+	persisted := &persistedBanList{}
+	if err := json.Unmarshal(enc, persisted); err != nil {
+		return nil, errors.Wrap(err, "could not decode ban list")
+	}
+	for _, id := range persisted.Peers {
+		pid, err := peer.Decode(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid banned peer %s", id)
+		}
+		b.peers[pid] = true
+	}
+	for _, cidr := range persisted.Networks {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid banned network %s", cidr)
+		}
+		b.networks[ipNet.String()] = ipNet
+	}
+	return b, nil
+}
+
+func (b *banList) banPeer(pid peer.ID) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.peers[pid] = true
+	return b.save()
+}
+
+// unbanPeer lifts the ban of a peer, returning false if the peer is not banned.
+func (b *banList) unbanPeer(pid peer.ID) (bool, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if !b.peers[pid] {
+		return false, nil
+	}
+	delete(b.peers, pid)
+	return true, b.save()
+}
+
+// banNetwork bans an ip range, returning it in its canonical form.
+func (b *banList) banNetwork(cidr string) (*net.IPNet, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid network")
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.networks[ipNet.String()] = ipNet
+	return ipNet, b.save()
+}
+
+// unbanNetwork lifts the ban of an ip range, returning false if the range is not banned.
+func (b *banList) unbanNetwork(cidr string) (bool, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid network")
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if _, ok := b.networks[ipNet.String()]; !ok {
+		return false, nil
+	}
+	delete(b.networks, ipNet.String())
+	return true, b.save()
+}
+
+func (b *banList) isPeerBanned(pid peer.ID) bool {
+	if b == nil {
+		return false
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.peers[pid]
+}
+
+func (b *banList) isIPBanned(ip net.IP) bool {
+	if b == nil {
+		return false
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	for _, ipNet := range b.networks {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAddrBanned checks if the ip of the multiaddr is within a banned ip range.
+func (b *banList) isAddrBanned(addr multiaddr.Multiaddr) bool {
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return false
+	}
+	return b.isIPBanned(ip)
+}
+
+func (b *banList) bannedPeers() []peer.ID {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	pids := make([]peer.ID, 0, len(b.peers))
+	for pid := range b.peers {
+		pids = append(pids, pid)
+	}
+	return pids
+}
+
+func (b *banList) bannedNetworks() []string {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	cidrs := make([]string, 0, len(b.networks))
+	for cidr := range b.networks {
+		cidrs = append(cidrs, cidr)
+	}
+	sort.Strings(cidrs)
+	return cidrs
+}
+
+// save writes the ban list to the data directory. It must be called with the lock held.
+func (b *banList) save() error {
+	if b.path == "" {
+		return nil
+	}
+	persisted := &persistedBanList{
+		Peers:    make([]string, 0, len(b.peers)),
+		Networks: make([]string, 0, len(b.networks)),
+	}
+	for pid := range b.peers {
+		persisted.Peers = append(persisted.Peers, pid.String())
+	}
+	for cidr := range b.networks {
+		persisted.Networks = append(persisted.Networks, cidr)
+	}
+	sort.Strings(persisted.Peers)
+	sort.Strings(persisted.Networks)
+	enc, err := json.Marshal(persisted)
+	if err != nil {
+		return errors.Wrap(err, "could not encode ban list")
+	}
+	if err := file.WriteFile(b.path, enc); err != nil {
+		return errors.Wrap(err, "could not write ban list")
+	}
+	return nil
+}
+
+// BanPeer bans a peer, disconnecting from it. The ban persists across restarts until it is lifted.
+func (s *Service) BanPeer(pid peer.ID) error {
+	if err := s.banList.banPeer(pid); err != nil {
+		return err
+	}
+	s.disconnectBanned(func(p peer.ID, _ multiaddr.Multiaddr) bool { return p == pid })
+	return nil
+}
+
+// UnbanPeer lifts the ban of a peer, returning false if the peer is not banned.
+func (s *Service) UnbanPeer(pid peer.ID) (bool, error) {
+	return s.banList.unbanPeer(pid)
+}
+
+// BannedPeers returns the banned peers.
+func (s *Service) BannedPeers() []peer.ID {
+	return s.banList.bannedPeers()
+}
+
+// BanNetwork bans the ip range of the cidr, disconnecting from the peers within it. It returns
+// the canonical form of the banned range.
+func (s *Service) BanNetwork(cidr string) (string, error) {
+	ipNet, err := s.banList.banNetwork(cidr)
+	if err != nil {
+		return "", err
+	}
+	s.disconnectBanned(func(_ peer.ID, addr multiaddr.Multiaddr) bool {
+		ip, err := manet.ToIP(addr)
+		return err == nil && ipNet.Contains(ip)
+	})
+	return ipNet.String(), nil
+}
+
+// UnbanNetwork lifts the ban of an ip range, returning false if the range is not banned.
+func (s *Service) UnbanNetwork(cidr string) (bool, error) {
+	return s.banList.unbanNetwork(cidr)
+}
+
+// BannedNetworks returns the banned ip ranges.
+func (s *Service) BannedNetworks() []string {
+	return s.banList.bannedNetworks()
+}
+
+// disconnectBanned closes the connections with the peers matching the ban.
+func (s *Service) disconnectBanned(banned func(peer.ID, multiaddr.Multiaddr) bool) {
+	if s.host == nil {
+		return
+	}
+	for _, conn := range s.host.Network().Conns() {
+		pid := conn.RemotePeer()
+		if !banned(pid, conn.RemoteMultiaddr()) {
+			continue
+		}
+		if err := s.host.Network().ClosePeer(pid); err != nil {
+			log.WithField("peer", pid).WithError(err).Debug("Could not disconnect from banned peer")
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestBanList_SaveLoad(t *testing.T) {
+	dataDir := t.TempDir()
+	b, err := loadBanList(dataDir)
+	require.NoError(t, err)
+	pid := newTestPeerID(t)
+	require.NoError(t, b.banPeer(pid))
+	ipNet, err := b.banNetwork("10.1.2.3/16")
+	require.NoError(t, err)
+	assert.Equal(t, "10.1.0.0/16", ipNet.String())
+	_, err = b.banNetwork("10.1.2.3")
+	assert.ErrorContains(t, "invalid network", err)
+
+	loaded, err := loadBanList(dataDir)
+	require.NoError(t, err)
+	assert.Equal(t, true, loaded.isPeerBanned(pid))
+	assert.Equal(t, true, loaded.isIPBanned(net.ParseIP("10.1.200.1")))
+	assert.Equal(t, false, loaded.isIPBanned(net.ParseIP("10.2.0.1")))
+	assert.DeepEqual(t, []string{"10.1.0.0/16"}, loaded.bannedNetworks())
+
+	ok, err := loaded.unbanPeer(pid)
+	require.NoError(t, err)
+	assert.Equal(t, true, ok)
+	ok, err = loaded.unbanNetwork("10.1.0.0/16")
+	require.NoError(t, err)
+	assert.Equal(t, true, ok)
+	ok, err = loaded.unbanNetwork("10.1.0.0/16")
+	require.NoError(t, err)
+	assert.Equal(t, false, ok)
+
+	loaded, err = loadBanList(dataDir)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(loaded.bannedPeers()))
+	assert.Equal(t, 0, len(loaded.bannedNetworks()))
+}
+
+func TestService_InterceptBannedPeersAndNetworks(t *testing.T) {
+	b, err := loadBanList("")
+	require.NoError(t, err)
+	s := &Service{
+		cfg:     &Config{},
+		banList: b,
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    20,
+			ScorerParams: &scorers.Config{},
+		}),
+	}
+	s.addrFilter, err = configureFilter(&Config{})
+	require.NoError(t, err)
+
+	pid := newTestPeerID(t)
+	addr, err := ma.NewMultiaddr("/ip4/192.168.10.4/tcp/13000")
+	require.NoError(t, err)
+	assert.Equal(t, true, s.InterceptPeerDial(pid))
+	assert.Equal(t, true, s.InterceptAddrDial(pid, addr))
+
+	require.NoError(t, s.BanPeer(pid))
+	assert.Equal(t, false, s.InterceptPeerDial(pid))
+	assert.Equal(t, false, s.InterceptSecured(0, pid, &maEndpoints{raddr: addr}))
+
+	other := newTestPeerID(t)
+	cidr, err := s.BanNetwork("192.168.10.0/24")
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.10.0/24", cidr)
+	assert.Equal(t, false, s.InterceptAddrDial(other, addr))
+	assert.Equal(t, false, s.InterceptAccept(&maEndpoints{raddr: addr}))
+
+	ok, err := s.UnbanNetwork(cidr)
+	require.NoError(t, err)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, true, s.InterceptAddrDial(other, addr))
+}
@@ -210,12 +210,16 @@ func (s *Service) broadcastObject(ctx context.Context, obj ssz.Marshaler, topic
 		return err
 	}
 
+	topic += s.Encoding().ProtocolSuffix()
+	uncompressedLen, compressedLen := int64(obj.SizeSSZ()), int64(buf.Len())
+	publishedMessageSizeHistogram.WithLabelValues(topic, "uncompressed").Observe(float64(uncompressedLen))
+	publishedMessageSizeHistogram.WithLabelValues(topic, "compressed").Observe(float64(compressedLen))
+
 	if span.IsRecordingEvents() {
 		id := hash.FastSum64(buf.Bytes())
-		messageLen := int64(buf.Len())
-		span.AddMessageSendEvent(int64(id), messageLen /*uncompressed*/, messageLen /*compressed*/)
+		span.AddMessageSendEvent(int64(id), uncompressedLen, compressedLen)
 	}
-	if err := s.PublishToTopic(ctx, topic+s.Encoding().ProtocolSuffix(), buf.Bytes()); err != nil {
+	if err := s.PublishToTopic(ctx, topic, buf.Bytes()); err != nil {
 		err := errors.Wrap(err, "could not publish message")
 		tracing.AnnotateError(span, err)
 		return err
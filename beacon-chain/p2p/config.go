@@ -8,24 +8,33 @@ import (
 // Config for the p2p service. These parameters are set from application level flags
 // to initialize the p2p service.
 type Config struct {
-	NoDiscovery         bool
-	EnableUPnP          bool
-	DisableDiscv5       bool
-	StaticPeers         []string
-	BootstrapNodeAddr   []string
-	Discv5BootStrapAddr []string
-	RelayNodeAddr       string
-	LocalIP             string
-	HostAddress         string
-	HostDNS             string
-	PrivateKey          string
-	DataDir             string
-	MetaDataDir         string
-	TCPPort             uint
-	UDPPort             uint
-	MaxPeers            uint
-	AllowListCIDR       string
-	DenyListCIDR        []string
-	StateNotifier       statefeed.Notifier
-	DB                  db.ReadOnlyDatabase
+	NoDiscovery             bool
+	EnableUPnP              bool
+	DisableDiscv5           bool
+	StaticPeers             []string
+	BootstrapNodeAddr       []string
+	Discv5BootStrapAddr     []string
+	RelayNodeAddr           string
+	LocalIP                 string
+	HostAddress             string
+	HostDNS                 string
+	ENREntries              []string
+	LocalIPv6               string
+	HostAddressIPv6         string
+	PreferIPv6              bool
+	GossipScoringConfigFile string
+	ResourceLimits          ResourceLimits
+	PrivateKey              string
+	DataDir                 string
+	MetaDataDir             string
+	TCPPort                 uint
+	UDPPort                 uint
+	MaxPeers                uint
+	InboundRatio            float64
+	NoInbound               bool
+	MaxMessageSize          uint64
+	AllowListCIDR           string
+	DenyListCIDR            []string
+	StateNotifier           statefeed.Notifier
+	DB                      db.ReadOnlyDatabase
 }
@@ -25,8 +25,8 @@ const (
 )
 
 // InterceptPeerDial tests whether we're permitted to Dial the specified peer.
-func (_ *Service) InterceptPeerDial(_ peer.ID) (allow bool) {
-	return true
+func (s *Service) InterceptPeerDial(pid peer.ID) (allow bool) {
+	return !s.banList.isPeerBanned(pid)
 }
 
 // InterceptAddrDial tests whether we're permitted to dial the specified
@@ -36,11 +36,24 @@ func (s *Service) InterceptAddrDial(pid peer.ID, m multiaddr.Multiaddr) (allow b
 	if s.peers.IsBad(pid) {
 		return false
 	}
+	if s.banList.isAddrBanned(m) {
+		return false
+	}
 	return filterConnections(s.addrFilter, m)
 }
 
 // InterceptAccept checks whether the incidental inbound connection is allowed.
 func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
+	if s.cfg.NoInbound {
+		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
+			"reason": "inbound connections disabled"}).Trace("Not accepting inbound dial")
+		return false
+	}
+	if s.banList.isAddrBanned(n.RemoteMultiaddr()) {
+		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
+			"reason": "banned ip address"}).Trace("Not accepting inbound dial")
+		return false
+	}
 	if !s.validateDial(n.RemoteMultiaddr()) {
 		// Allow other go-routines to run in the event
 		// we receive a large amount of junk connections.
@@ -59,7 +72,17 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 
 // InterceptSecured tests whether a given connection, now authenticated,
 // is allowed.
-func (_ *Service) InterceptSecured(_ network.Direction, _ peer.ID, _ network.ConnMultiaddrs) (allow bool) {
+func (s *Service) InterceptSecured(_ network.Direction, pid peer.ID, n network.ConnMultiaddrs) (allow bool) {
+	if s.banList.isPeerBanned(pid) {
+		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
+			"reason": "banned peer"}).Trace("Not accepting connection")
+		return false
+	}
+	if s.isAtConnLimit(pid) {
+		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
+			"reason": "at connection limit of peer"}).Trace("Not accepting connection")
+		return false
+	}
 	return true
 }
 
@@ -132,6 +132,26 @@ func TestService_RejectInboundPeersBeyondLimit(t *testing.T) {
 	}
 }
 
+func TestService_RejectInboundPeersWhenDisabled(t *testing.T) {
+	s := &Service{
+		ipLimiter: leakybucket.NewCollector(ipLimit, ipBurst, false),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    20,
+			NoInbound:    true,
+			ScorerParams: &scorers.Config{},
+		}),
+		host: mockp2p.NewTestP2P(t).BHost,
+		cfg:  &Config{MaxPeers: 20, NoInbound: true},
+	}
+	var err error
+	s.addrFilter, err = configureFilter(&Config{})
+	require.NoError(t, err)
+	multiAddress, err := ma.NewMultiaddr("/ip4/212.67.10.122/tcp/3000")
+	require.NoError(t, err)
+	assert.Equal(t, false, s.InterceptAccept(&maEndpoints{raddr: multiAddress}), "Inbound dial accepted")
+	assert.Equal(t, true, s.InterceptAddrDial(newTestPeerID(t), multiAddress), "Outbound dial rejected")
+}
+
 func TestPeer_BelowMaxLimit(t *testing.T) {
 	// create host and remote peer
 	ipAddr, pkey := createAddrAndPrivKey(t)
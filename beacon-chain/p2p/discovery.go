@@ -109,7 +109,7 @@ func (s *Service) listenForNewNodes() {
 			break
 		}
 		node := iterator.Node()
-		peerInfo, _, err := convertToAddrInfo(node)
+		peerInfo, _, err := convertToAddrInfo(node, s.cfg.PreferIPv6)
 		if err != nil {
 			log.WithError(err).Error("Could not convert to peer info")
 			continue
@@ -149,6 +149,11 @@ func (s *Service) createListener(
 		}
 		bindIP = ipAddr
 	}
+	// A dual-stack node binds the unspecified IPv6 address, which receives
+	// discovery packets of both address families.
+	if s.dualStack() {
+		bindIP = net.IPv6zero
+	}
 	udpAddr := &net.UDPAddr{
 		IP:   bindIP,
 		Port: int(s.cfg.UDPPort),
@@ -179,6 +184,22 @@ func (s *Service) createListener(
 			localNode.SetStaticIP(hostIP)
 		}
 	}
+	if s.cfg.LocalIPv6 != "" {
+		localIP6 := net.ParseIP(s.cfg.LocalIPv6)
+		if localIP6 == nil || localIP6.To4() != nil {
+			return nil, errors.New("invalid local ipv6 provided")
+		}
+		localNode.SetFallbackIP(localIP6)
+	}
+	if s.cfg.HostAddressIPv6 != "" {
+		hostIP6 := net.ParseIP(s.cfg.HostAddressIPv6)
+		if hostIP6 == nil || hostIP6.To4() != nil {
+			log.Errorf("Invalid host ipv6 address given: %s", s.cfg.HostAddressIPv6)
+		} else {
+			localNode.SetFallbackIP(hostIP6)
+			localNode.SetStaticIP(hostIP6)
+		}
+	}
 	if s.cfg.HostDNS != "" {
 		host := s.cfg.HostDNS
 		ips, err := net.LookupIP(host)
@@ -236,7 +257,14 @@ func (s *Service) createLocalNode(
 		return nil, errors.Wrap(err, "could not add eth2 fork version entry to enr")
 	}
 	localNode = initializeAttSubnets(localNode)
-	return initializeSyncCommSubnets(localNode), nil
+	localNode = initializeSyncCommSubnets(localNode)
+	if err := checkENRSize(localNode.Node().Record(), s.enrEntries, privKey); err != nil {
+		return nil, err
+	}
+	for _, entry := range s.enrEntries {
+		localNode.Set(entry)
+	}
+	return localNode, nil
 }
 
 func (s *Service) startDiscoveryV5(
@@ -258,7 +286,7 @@ func (s *Service) startDiscoveryV5(
 // 1) The local node is still actively looking for peers to
 //    connect to.
 // 2) Peer has a valid IP and TCP port set in their enr.
-// 3) Peer hasn't been marked as 'bad'
+// 3) Peer hasn't been marked as 'bad' or banned
 // 4) Peer is not currently active or connected.
 // 5) Peer is ready to receive incoming connections.
 // 6) Peer's fork digest in their ENR matches that of
@@ -273,13 +301,10 @@ func (s *Service) filterPeer(node *enode.Node) bool {
 		return false
 	}
 	// do not dial nodes with their tcp ports not set
-	if err := node.Record().Load(enr.WithEntry("tcp", new(enr.TCP))); err != nil {
-		if !enr.IsNotFound(err) {
-			log.WithError(err).Debug("Could not retrieve tcp port")
-		}
+	if _, tcpPort := nodeTCPEndpoint(node, s.cfg.PreferIPv6); tcpPort == 0 {
 		return false
 	}
-	peerData, multiAddr, err := convertToAddrInfo(node)
+	peerData, multiAddr, err := convertToAddrInfo(node, s.cfg.PreferIPv6)
 	if err != nil {
 		log.WithError(err).Debug("Could not convert to peer data")
 		return false
@@ -287,6 +312,9 @@ func (s *Service) filterPeer(node *enode.Node) bool {
 	if s.peers.IsBad(peerData.ID) {
 		return false
 	}
+	if s.banList.isPeerBanned(peerData.ID) || s.banList.isIPBanned(node.IP()) {
+		return false
+	}
 	if s.peers.IsActive(peerData.ID) {
 		return false
 	}
@@ -377,8 +405,10 @@ func convertToMultiAddr(nodes []*enode.Node) []ma.Multiaddr {
 	return multiAddrs
 }
 
-func convertToAddrInfo(node *enode.Node) (*peer.AddrInfo, ma.Multiaddr, error) {
-	multiAddr, err := convertToSingleMultiAddr(node)
+// convertToAddrInfo converts a node into the address info to dial it with, using the
+// preferred address family for nodes advertising both IPv4 and IPv6 addresses.
+func convertToAddrInfo(node *enode.Node, preferIPv6 bool) (*peer.AddrInfo, ma.Multiaddr, error) {
+	multiAddr, err := convertToPreferredMultiAddr(node, preferIPv6)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -390,13 +420,48 @@ func convertToAddrInfo(node *enode.Node) (*peer.AddrInfo, ma.Multiaddr, error) {
 }
 
 func convertToSingleMultiAddr(node *enode.Node) (ma.Multiaddr, error) {
+	return convertToPreferredMultiAddr(node, false /* preferIPv6 */)
+}
+
+func convertToPreferredMultiAddr(node *enode.Node, preferIPv6 bool) (ma.Multiaddr, error) {
 	pubkey := node.Pubkey()
 	assertedKey := convertToInterfacePubkey(pubkey)
 	id, err := peer.IDFromPublicKey(assertedKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get peer id")
 	}
-	return multiAddressBuilderWithID(node.IP().String(), "tcp", uint(node.TCP()), id)
+	ip, tcpPort := nodeTCPEndpoint(node, preferIPv6)
+	return multiAddressBuilderWithID(ip.String(), "tcp", tcpPort, id)
+}
+
+// nodeTCPEndpoint returns the ip address and tcp port a node is dialed on. Nodes advertising
+// both address families are dialed on the preferred one. The IPv6 tcp port defaults to the
+// IPv4 one, as nodes only advertise it when the two differ.
+func nodeTCPEndpoint(node *enode.Node, preferIPv6 bool) (net.IP, uint) {
+	var ip4 enr.IPv4
+	var ip6 enr.IPv6
+	var tcp4 enr.TCP
+	var tcp6 enr.TCP6
+	hasIP4 := node.Load(&ip4) == nil
+	hasIP6 := node.Load(&ip6) == nil
+	if err := node.Load(&tcp4); err != nil && !enr.IsNotFound(err) {
+		log.WithError(err).Debug("Could not retrieve tcp port")
+	}
+	if err := node.Load(&tcp6); err != nil {
+		tcp6 = enr.TCP6(tcp4)
+	}
+	if hasIP6 && (preferIPv6 || !hasIP4) {
+		return net.IP(ip6), uint(tcp6)
+	}
+	if hasIP4 {
+		return net.IP(ip4), uint(tcp4)
+	}
+	return nil, 0
+}
+
+// dualStack states if the node listens on both IPv4 and IPv6.
+func (s *Service) dualStack() bool {
+	return s.cfg.LocalIPv6 != "" || s.cfg.HostAddressIPv6 != ""
 }
 
 func convertToUdpMultiAddr(node *enode.Node) ([]ma.Multiaddr, error) {
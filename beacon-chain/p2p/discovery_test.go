@@ -134,6 +134,26 @@ func TestStartDiscV5_DiscoverAllPeers(t *testing.T) {
 	}
 }
 
+func TestCreateListener_DualStack(t *testing.T) {
+	ipAddr, pkey := createAddrAndPrivKey(t)
+	hostIP6 := net.ParseIP("2001:db8::1")
+	s := &Service{
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
+		cfg:                   &Config{UDPPort: 1025, TCPPort: 13000, HostAddressIPv6: hostIP6.String()},
+	}
+	listener, err := s.createListener(ipAddr, pkey)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	var ip4 enr.IPv4
+	var ip6 enr.IPv6
+	require.NoError(t, listener.Self().Load(&ip4))
+	require.NoError(t, listener.Self().Load(&ip6))
+	assert.Equal(t, true, net.IP(ip4).Equal(ipAddr))
+	assert.Equal(t, true, net.IP(ip6).Equal(hostIP6))
+}
+
 func TestMultiAddrsConversion_InvalidIPAddr(t *testing.T) {
 	addr := net.ParseIP("invalidIP")
 	_, pkey := createAddrAndPrivKey(t)
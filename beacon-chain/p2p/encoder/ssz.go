@@ -19,6 +19,9 @@ var _ NetworkEncoding = (*SszNetworkEncoder)(nil)
 var MaxGossipSize = params.BeaconNetworkConfig().GossipMaxSize // 1 Mib.
 var MaxChunkSize = params.BeaconNetworkConfig().MaxChunkSize   // 1 Mib.
 
+// maxMessageSize caps MaxGossipSize and MaxChunkSize when it is set.
+var maxMessageSize uint64
+
 // This pool defines the sync pool for our buffered snappy writers, so that they
 // can be constantly reused.
 var bufWriterPool = new(sync.Pool)
@@ -27,6 +30,10 @@ var bufWriterPool = new(sync.Pool)
 // can be constantly reused.
 var bufReaderPool = new(sync.Pool)
 
+// This pool defines the sync pool for the byte buffers used to compress and
+// decompress messages, so that they can be constantly reused.
+var bufPool = new(sync.Pool)
+
 // SszNetworkEncoder supports p2p networking encoding using SimpleSerialize
 // with snappy compression (if enabled).
 type SszNetworkEncoder struct{}
@@ -46,8 +53,9 @@ func (_ SszNetworkEncoder) EncodeGossip(w io.Writer, msg fastssz.Marshaler) (int
 	if uint64(len(b)) > MaxGossipSize {
 		return 0, errors.Errorf("gossip message exceeds max gossip size: %d bytes > %d bytes", len(b), MaxGossipSize)
 	}
-	b = snappy.Encode(nil /*dst*/, b)
-	return w.Write(b)
+	dst := getBuffer(snappy.MaxEncodedLen(len(b)))
+	defer putBuffer(dst)
+	return w.Write(snappy.Encode(*dst, b))
 }
 
 // EncodeWithMaxLength the proto message to the io.Writer. This encoding prefixes the byte slice with a protobuf varint
@@ -81,7 +89,14 @@ func doDecode(b []byte, to fastssz.Unmarshaler) error {
 
 // DecodeGossip decodes the bytes to the protobuf gossip message provided.
 func (_ SszNetworkEncoder) DecodeGossip(b []byte, to fastssz.Unmarshaler) error {
-	b, err := DecodeSnappy(b, MaxGossipSize)
+	size, err := decodedLen(b, MaxGossipSize)
+	if err != nil {
+		return err
+	}
+	// The decoded message is copied when unmarshalled, so that its buffer can be reused.
+	dst := getBuffer(size)
+	defer putBuffer(dst)
+	b, err = snappy.Decode(*dst, b)
 	if err != nil {
 		return err
 	}
@@ -90,20 +105,29 @@ func (_ SszNetworkEncoder) DecodeGossip(b []byte, to fastssz.Unmarshaler) error
 
 // DecodeSnappy decodes a snappy compressed message.
 func DecodeSnappy(msg []byte, maxSize uint64) ([]byte, error) {
-	size, err := snappy.DecodedLen(msg)
-	if err != nil {
+	if _, err := decodedLen(msg, maxSize); err != nil {
 		return nil, err
 	}
-	if uint64(size) > maxSize {
-		return nil, errors.Errorf("snappy message exceeds max size: %d bytes > %d bytes", size, maxSize)
-	}
-	msg, err = snappy.Decode(nil /*dst*/, msg)
+	msg, err := snappy.Decode(nil /*dst*/, msg)
 	if err != nil {
 		return nil, err
 	}
 	return msg, nil
 }
 
+// Returns the decoded length of a snappy compressed message, checking that
+// it does not exceed the provided max size.
+func decodedLen(msg []byte, maxSize uint64) (int, error) {
+	size, err := snappy.DecodedLen(msg)
+	if err != nil {
+		return 0, err
+	}
+	if uint64(size) > maxSize {
+		return 0, errors.Errorf("snappy message exceeds max size: %d bytes > %d bytes", size, maxSize)
+	}
+	return size, nil
+}
+
 // DecodeWithMaxLength the bytes from io.Reader to the protobuf message provided.
 // This checks that the decoded message isn't larger than the provided max limit.
 func (e SszNetworkEncoder) DecodeWithMaxLength(r io.Reader, to fastssz.Unmarshaler) error {
@@ -126,15 +150,16 @@ func (e SszNetworkEncoder) DecodeWithMaxLength(r io.Reader, to fastssz.Unmarshal
 	r = newBufferedReader(limitedRdr)
 	defer bufReaderPool.Put(r)
 
-	buf := make([]byte, msgLen)
+	buf := getBuffer(int(msgLen))
+	defer putBuffer(buf)
 	// Returns an error if less than msgLen bytes
 	// are read. This ensures we read exactly the
 	// required amount.
-	_, err = io.ReadFull(r, buf)
+	_, err = io.ReadFull(r, *buf)
 	if err != nil {
 		return err
 	}
-	return doDecode(buf, to)
+	return doDecode(*buf, to)
 }
 
 // ProtocolSuffix returns the appropriate suffix for protocol IDs.
@@ -201,12 +226,46 @@ func newBufferedWriter(w io.Writer) *snappy.Writer {
 	return bufW
 }
 
-// SetMaxGossipSizeForBellatrix sets the MaxGossipSize to 10Mb.
+// Returns a buffer of the given length from our sync pool, the buffer
+// is allocated when none of the pooled buffers are large enough.
+func getBuffer(length int) *[]byte {
+	if rawBuf, ok := bufPool.Get().(*[]byte); ok && cap(*rawBuf) >= length {
+		*rawBuf = (*rawBuf)[:length]
+		return rawBuf
+	}
+	buf := make([]byte, length)
+	return &buf
+}
+
+// Returns a buffer to our sync pool.
+func putBuffer(buf *[]byte) {
+	bufPool.Put(buf)
+}
+
+// SetMaxGossipSizeForBellatrix sets the MaxGossipSize to 10Mb, or to the
+// max message size if it is lower.
 func SetMaxGossipSizeForBellatrix() {
-	MaxGossipSize = params.BeaconNetworkConfig().GossipMaxSizeBellatrix
+	MaxGossipSize = capMessageSize(params.BeaconNetworkConfig().GossipMaxSizeBellatrix)
 }
 
-// SetMaxChunkSizeForBellatrix sets the MaxChunkSize to 10Mb.
+// SetMaxChunkSizeForBellatrix sets the MaxChunkSize to 10Mb, or to the
+// max message size if it is lower.
 func SetMaxChunkSizeForBellatrix() {
-	MaxChunkSize = params.BeaconNetworkConfig().MaxChunkSizeBellatrix
+	MaxChunkSize = capMessageSize(params.BeaconNetworkConfig().MaxChunkSizeBellatrix)
+}
+
+// SetMaxMessageSize caps the MaxGossipSize and the MaxChunkSize to the given size,
+// messages above it are rejected. A size of 0 removes the cap, the sizes of the
+// network configuration are then enforced.
+func SetMaxMessageSize(size uint64) {
+	maxMessageSize = size
+	MaxGossipSize = capMessageSize(params.BeaconNetworkConfig().GossipMaxSize)
+	MaxChunkSize = capMessageSize(params.BeaconNetworkConfig().MaxChunkSize)
+}
+
+func capMessageSize(size uint64) uint64 {
+	if maxMessageSize != 0 && maxMessageSize < size {
+		return maxMessageSize
+	}
+	return size
 }
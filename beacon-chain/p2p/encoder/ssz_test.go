@@ -105,6 +105,47 @@ func TestSszNetworkEncoder_DecodeWithMaxLength(t *testing.T) {
 	assert.ErrorContains(t, wanted, err)
 }
 
+func TestSszNetworkEncoder_DecodeGossipReusesBuffers(t *testing.T) {
+	e := &encoder.SszNetworkEncoder{}
+	first := &ethpb.Checkpoint{Epoch: 1, Root: bytes.Repeat([]byte{'A'}, 32)}
+	second := &ethpb.Checkpoint{Epoch: 2, Root: bytes.Repeat([]byte{'B'}, 32)}
+	decoded := make([]*ethpb.Checkpoint, 0, 2)
+	for _, msg := range []*ethpb.Checkpoint{first, second} {
+		buf := new(bytes.Buffer)
+		_, err := e.EncodeGossip(buf, msg)
+		require.NoError(t, err)
+		cp := &ethpb.Checkpoint{}
+		require.NoError(t, e.DecodeGossip(buf.Bytes(), cp))
+		decoded = append(decoded, cp)
+	}
+	// Decoded messages must not share the pooled buffers.
+	assert.DeepEqual(t, first, decoded[0])
+	assert.DeepEqual(t, second, decoded[1])
+}
+
+func TestSetMaxMessageSize(t *testing.T) {
+	defer encoder.SetMaxMessageSize(0)
+	e := &encoder.SszNetworkEncoder{}
+	encoder.SetMaxMessageSize(16)
+	assert.Equal(t, uint64(16), encoder.MaxGossipSize)
+	assert.Equal(t, uint64(16), encoder.MaxChunkSize)
+	encoder.SetMaxGossipSizeForBellatrix()
+	encoder.SetMaxChunkSizeForBellatrix()
+	assert.Equal(t, uint64(16), encoder.MaxGossipSize)
+	assert.Equal(t, uint64(16), encoder.MaxChunkSize)
+
+	buf := new(bytes.Buffer)
+	_, err := e.EncodeGossip(buf, &ethpb.Fork{PreviousVersion: []byte("fooo"), CurrentVersion: []byte("barr")})
+	require.NoError(t, err)
+	_, err = e.EncodeGossip(new(bytes.Buffer), &ethpb.Checkpoint{Root: make([]byte, 32)})
+	assert.ErrorContains(t, "gossip message exceeds max gossip size", err)
+
+	// Sizes above the network limits are ignored.
+	encoder.SetMaxMessageSize(math.MaxUint64)
+	assert.Equal(t, params.BeaconNetworkConfig().GossipMaxSize, encoder.MaxGossipSize)
+	assert.Equal(t, params.BeaconNetworkConfig().MaxChunkSize, encoder.MaxChunkSize)
+}
+
 func TestSszNetworkEncoder_DecodeWithMultipleFrames(t *testing.T) {
 	buf := new(bytes.Buffer)
 	st, _ := util.DeterministicGenesisState(t, 100)
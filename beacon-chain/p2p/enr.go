@@ -0,0 +1,173 @@
+package p2p
+
+import (
+	"crypto/ecdsa"
+	"net"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+)
+
+// ENRInfo is the decoded content of an ENR, with the Ethereum consensus entries decoded into
+// their fields. Entries unknown to the node are kept as their raw values in Entries.
+type ENRInfo struct {
+	Record          string
+	Seq             uint64
+	NodeID          string
+	IP              net.IP
+	IPv6            net.IP
+	TCP             uint16
+	UDP             uint16
+	TCP6            uint16
+	UDP6            uint16
+	ForkDigest      []byte
+	NextForkVersion []byte
+	NextForkEpoch   types.Epoch
+	AttSubnets      []uint64
+	SyncSubnets     []uint64
+	Entries         map[string][]byte
+}
+
+// reservedENRKeys are the keys of the ENR entries set by the node, which cannot be
+// overridden by custom entries.
+func reservedENRKeys() map[string]bool {
+	return map[string]bool{
+		"id":                  true,
+		"secp256k1":           true,
+		"ip":                  true,
+		"ip6":                 true,
+		"tcp":                 true,
+		"tcp6":                true,
+		"udp":                 true,
+		"udp6":                true,
+		eth2ENRKey:            true,
+		attSubnetEnrKey:       true,
+		syncCommsSubnetEnrKey: true,
+	}
+}
+
+// parseENREntries parses the custom ENR entries given as key=value. Values starting with 0x
+// are decoded as hex, other values are used as is.
+func parseENREntries(entries []string) ([]enr.Entry, error) {
+	reserved := reservedENRKeys()
+	parsed := make([]enr.Entry, 0, len(entries))
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("invalid enr entry %q, expected key=value", entry)
+		}
+		key, value := parts[0], []byte(parts[1])
+		if reserved[key] {
+			return nil, errors.Errorf("enr entry %s is reserved", key)
+		}
+		if strings.HasPrefix(parts[1], "0x") {
+			var err error
+			value, err = hexutil.Decode(parts[1])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid hex value of enr entry %s", key)
+			}
+		}
+		if len(value) > enr.SizeLimit {
+			return nil, errors.Errorf("enr entry %s is larger than the enr size limit of %d bytes", key, enr.SizeLimit)
+		}
+		parsed = append(parsed, enr.WithEntry(key, value))
+	}
+	return parsed, nil
+}
+
+// checkENRSize returns an error if the record with the given entries set is larger than the
+// size limit of an ENR, as the local node cannot sign such a record.
+func checkENRSize(record *enr.Record, entries []enr.Entry, privKey *ecdsa.PrivateKey) error {
+	enc, err := rlp.EncodeToBytes(record)
+	if err != nil {
+		return errors.Wrap(err, "could not encode enr")
+	}
+	// The entries are set on a copy, so that the record of the local node is left untouched.
+	var r enr.Record
+	if err := rlp.DecodeBytes(enc, &r); err != nil {
+		return errors.Wrap(err, "could not decode enr")
+	}
+	for _, entry := range entries {
+		r.Set(entry)
+	}
+	if err := enode.SignV4(&r, privKey); err != nil {
+		return errors.Wrap(err, "custom enr entries do not fit in the enr")
+	}
+	return nil
+}
+
+// DecodeENR decodes the entries of an ENR.
+func DecodeENR(record *enr.Record) (*ENRInfo, error) {
+	node, err := enode.New(enode.ValidSchemes, record)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid enr")
+	}
+	info := &ENRInfo{
+		Record:  node.String(),
+		Seq:     record.Seq(),
+		NodeID:  node.ID().String(),
+		Entries: make(map[string][]byte),
+	}
+	// Optional entries are left empty when they are missing.
+	var ip4 enr.IPv4
+	if record.Load(&ip4) == nil {
+		info.IP = net.IP(ip4)
+	}
+	var ip6 enr.IPv6
+	if record.Load(&ip6) == nil {
+		info.IPv6 = net.IP(ip6)
+	}
+	var tcp enr.TCP
+	if record.Load(&tcp) == nil {
+		info.TCP = uint16(tcp)
+	}
+	var udp enr.UDP
+	if record.Load(&udp) == nil {
+		info.UDP = uint16(udp)
+	}
+	var tcp6 enr.TCP6
+	if record.Load(&tcp6) == nil {
+		info.TCP6 = uint16(tcp6)
+	}
+	var udp6 enr.UDP6
+	if record.Load(&udp6) == nil {
+		info.UDP6 = uint16(udp6)
+	}
+	if fork, err := forkEntry(record); err == nil {
+		info.ForkDigest = fork.CurrentForkDigest
+		info.NextForkVersion = fork.NextForkVersion
+		info.NextForkEpoch = fork.NextForkEpoch
+	}
+	if subnets, err := attSubnets(record); err == nil {
+		info.AttSubnets = subnets
+	}
+	if subnets, err := syncSubnets(record); err == nil {
+		info.SyncSubnets = subnets
+	}
+
+	reserved := reservedENRKeys()
+	// The elements are the sequence number followed by the sorted key/value pairs.
+	elements := record.AppendElements(nil)
+	for i := 1; i+1 < len(elements); i += 2 {
+		key, ok := elements[i].(string)
+		if !ok || reserved[key] {
+			continue
+		}
+		raw, ok := elements[i+1].(rlp.RawValue)
+		if !ok {
+			continue
+		}
+		// Custom entries are byte strings, other values are kept RLP encoded.
+		var value []byte
+		if err := rlp.DecodeBytes(raw, &value); err != nil {
+			value = raw
+		}
+		info.Entries[key] = value
+	}
+	return info, nil
+}
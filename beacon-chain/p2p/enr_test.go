@@ -0,0 +1,89 @@
+package p2p
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestParseENREntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		wantErr string
+	}{
+		{name: "text and hex values", entries: []string{"client=prysm", "role=0x01ff"}},
+		{name: "value with equal sign", entries: []string{"note=a=b"}},
+		{name: "missing value", entries: []string{"client"}, wantErr: "expected key=value"},
+		{name: "missing key", entries: []string{"=prysm"}, wantErr: "expected key=value"},
+		{name: "reserved key", entries: []string{"tcp=0x01"}, wantErr: "enr entry tcp is reserved"},
+		{name: "reserved eth2 key", entries: []string{eth2ENRKey + "=0x01"}, wantErr: "is reserved"},
+		{name: "invalid hex", entries: []string{"role=0xzz"}, wantErr: "invalid hex value"},
+		{name: "value over the size limit", entries: []string{"note=" + strings.Repeat("a", enr.SizeLimit+1)}, wantErr: "larger than the enr size limit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := parseENREntries(tt.entries)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.entries), len(entries))
+		})
+	}
+}
+
+func TestDecodeENR(t *testing.T) {
+	entries, err := parseENREntries([]string{"client=prysm", "role=0x01ff"})
+	require.NoError(t, err)
+	pkey, err := privKey(&Config{DataDir: t.TempDir()})
+	require.NoError(t, err)
+	s := &Service{
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
+		cfg:                   &Config{},
+		enrEntries:            entries,
+	}
+	localNode, err := s.createLocalNode(pkey, net.ParseIP("10.0.0.1"), 9000, 13000)
+	require.NoError(t, err)
+	bitV := bitfield.NewBitvector64()
+	bitV.SetBitAt(3, true)
+	bitV.SetBitAt(40, true)
+	localNode.Set(enr.WithEntry(attSubnetEnrKey, &bitV))
+
+	info, err := DecodeENR(localNode.Node().Record())
+	require.NoError(t, err)
+	assert.Equal(t, localNode.Node().String(), info.Record)
+	assert.Equal(t, localNode.Node().ID().String(), info.NodeID)
+	assert.Equal(t, "10.0.0.1", info.IP.String())
+	assert.Equal(t, uint16(9000), info.UDP)
+	assert.Equal(t, uint16(13000), info.TCP)
+	assert.Equal(t, 4, len(info.ForkDigest))
+	assert.DeepEqual(t, []uint64{3, 40}, info.AttSubnets)
+	assert.Equal(t, 0, len(info.SyncSubnets))
+	assert.DeepEqual(t, map[string][]byte{"client": []byte("prysm"), "role": {0x01, 0xff}}, info.Entries)
+}
+
+func TestCreateLocalNode_ENREntriesOverSizeLimit(t *testing.T) {
+	// Each entry is within the size limit, but together they do not fit in the enr.
+	entries, err := parseENREntries([]string{"note=" + strings.Repeat("a", 150), "other=" + strings.Repeat("b", 150)})
+	require.NoError(t, err)
+	pkey, err := privKey(&Config{DataDir: t.TempDir()})
+	require.NoError(t, err)
+	s := &Service{
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
+		cfg:                   &Config{},
+		enrEntries:            entries,
+	}
+	_, err = s.createLocalNode(pkey, net.ParseIP("10.0.0.1"), 9000, 13000)
+	assert.ErrorContains(t, "custom enr entries do not fit in the enr", err)
+}
@@ -0,0 +1,165 @@
+package p2p
+
+import (
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// gossipMessageNames are the names of the gossip messages whose topic scoring can be configured.
+var gossipMessageNames = map[string]bool{
+	GossipAttestationMessage:          true,
+	GossipSyncCommitteeMessage:        true,
+	GossipBlockMessage:                true,
+	GossipExitMessage:                 true,
+	GossipProposerSlashingMessage:     true,
+	GossipAttesterSlashingMessage:     true,
+	GossipAggregateAndProofMessage:    true,
+	GossipContributionAndProofMessage: true,
+}
+
+// subnetSuffix matches the subnet id at the end of the name of a subnet topic.
+var subnetSuffix = regexp.MustCompile(`_[0-9]+$`)
+
+// gossipScoringConfig overrides the default gossipsub peer scoring parameters. It is loaded
+// from a YAML file, and only the parameters set in the file are overridden. Topic parameters
+// are keyed by the gossip message name, without the subnet id, e.g. beacon_attestation.
+type gossipScoringConfig struct {
+	Thresholds struct {
+		Gossip             *float64 `yaml:"gossip_threshold"`
+		Publish            *float64 `yaml:"publish_threshold"`
+		Graylist           *float64 `yaml:"graylist_threshold"`
+		AcceptPX           *float64 `yaml:"accept_px_threshold"`
+		OpportunisticGraft *float64 `yaml:"opportunistic_graft_threshold"`
+	} `yaml:"thresholds"`
+	Peer struct {
+		TopicScoreCap               *float64       `yaml:"topic_score_cap"`
+		AppSpecificWeight           *float64       `yaml:"app_specific_weight"`
+		IPColocationFactorWeight    *float64       `yaml:"ip_colocation_factor_weight"`
+		IPColocationFactorThreshold *int           `yaml:"ip_colocation_factor_threshold"`
+		BehaviourPenaltyWeight      *float64       `yaml:"behaviour_penalty_weight"`
+		BehaviourPenaltyThreshold   *float64       `yaml:"behaviour_penalty_threshold"`
+		BehaviourPenaltyDecay       *time.Duration `yaml:"behaviour_penalty_decay"`
+		DecayInterval               *time.Duration `yaml:"decay_interval"`
+		RetainScore                 *time.Duration `yaml:"retain_score"`
+	} `yaml:"peer"`
+	Topics map[string]*topicScoringConfig `yaml:"topics"`
+}
+
+// topicScoringConfig overrides the scoring parameters of a gossip topic. Decays are given as
+// the duration over which a counter decays to zero.
+type topicScoringConfig struct {
+	TopicWeight                    *float64       `yaml:"topic_weight"`
+	TimeInMeshWeight               *float64       `yaml:"time_in_mesh_weight"`
+	FirstMessageDeliveriesWeight   *float64       `yaml:"first_message_deliveries_weight"`
+	FirstMessageDeliveriesDecay    *time.Duration `yaml:"first_message_deliveries_decay"`
+	FirstMessageDeliveriesCap      *float64       `yaml:"first_message_deliveries_cap"`
+	MeshMessageDeliveriesWeight    *float64       `yaml:"mesh_message_deliveries_weight"`
+	MeshMessageDeliveriesDecay     *time.Duration `yaml:"mesh_message_deliveries_decay"`
+	MeshMessageDeliveriesThreshold *float64       `yaml:"mesh_message_deliveries_threshold"`
+	MeshFailurePenaltyWeight       *float64       `yaml:"mesh_failure_penalty_weight"`
+	MeshFailurePenaltyDecay        *time.Duration `yaml:"mesh_failure_penalty_decay"`
+	InvalidMessageDeliveriesWeight *float64       `yaml:"invalid_message_deliveries_weight"`
+	InvalidMessageDeliveriesDecay  *time.Duration `yaml:"invalid_message_deliveries_decay"`
+}
+
+// loadGossipScoringConfig reads the gossip scoring parameter overrides from a YAML file.
+func loadGossipScoringConfig(path string) (*gossipScoringConfig, error) {
+	enc, err := ioutil.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read gossip scoring config")
+	}
+	cfg := &gossipScoringConfig{}
+	if err := yaml.UnmarshalStrict(enc, cfg); err != nil {
+		return nil, errors.Wrap(err, "could not decode gossip scoring config")
+	}
+	for name := range cfg.Topics {
+		if !gossipMessageNames[name] {
+			return nil, errors.Errorf("unknown gossip topic %s in gossip scoring config", name)
+		}
+	}
+	return cfg, nil
+}
+
+// applyPeerScoreParams overrides the peer scoring parameters and thresholds set in the config.
+func (c *gossipScoringConfig) applyPeerScoreParams(p *pubsub.PeerScoreParams, t *pubsub.PeerScoreThresholds) {
+	if c == nil {
+		return
+	}
+	setFloat(&t.GossipThreshold, c.Thresholds.Gossip)
+	setFloat(&t.PublishThreshold, c.Thresholds.Publish)
+	setFloat(&t.GraylistThreshold, c.Thresholds.Graylist)
+	setFloat(&t.AcceptPXThreshold, c.Thresholds.AcceptPX)
+	setFloat(&t.OpportunisticGraftThreshold, c.Thresholds.OpportunisticGraft)
+
+	setFloat(&p.TopicScoreCap, c.Peer.TopicScoreCap)
+	setFloat(&p.AppSpecificWeight, c.Peer.AppSpecificWeight)
+	setFloat(&p.IPColocationFactorWeight, c.Peer.IPColocationFactorWeight)
+	if c.Peer.IPColocationFactorThreshold != nil {
+		p.IPColocationFactorThreshold = *c.Peer.IPColocationFactorThreshold
+	}
+	setFloat(&p.BehaviourPenaltyWeight, c.Peer.BehaviourPenaltyWeight)
+	setFloat(&p.BehaviourPenaltyThreshold, c.Peer.BehaviourPenaltyThreshold)
+	if c.Peer.DecayInterval != nil {
+		p.DecayInterval = *c.Peer.DecayInterval
+	}
+	if c.Peer.RetainScore != nil {
+		p.RetainScore = *c.Peer.RetainScore
+	}
+	// Decays are computed last, as they depend on the decay interval.
+	setDecay(&p.BehaviourPenaltyDecay, c.Peer.BehaviourPenaltyDecay, p.DecayInterval)
+}
+
+// applyTopicScoreParams overrides the scoring parameters of the topic set in the config.
+func (c *gossipScoringConfig) applyTopicScoreParams(topic string, p *pubsub.TopicScoreParams) {
+	if c == nil || p == nil {
+		return
+	}
+	tc, ok := c.Topics[gossipTopicName(topic)]
+	if !ok || tc == nil {
+		return
+	}
+	decayInterval := oneSlotDuration()
+	if c.Peer.DecayInterval != nil {
+		decayInterval = *c.Peer.DecayInterval
+	}
+	setFloat(&p.TopicWeight, tc.TopicWeight)
+	setFloat(&p.TimeInMeshWeight, tc.TimeInMeshWeight)
+	setFloat(&p.FirstMessageDeliveriesWeight, tc.FirstMessageDeliveriesWeight)
+	setDecay(&p.FirstMessageDeliveriesDecay, tc.FirstMessageDeliveriesDecay, decayInterval)
+	setFloat(&p.FirstMessageDeliveriesCap, tc.FirstMessageDeliveriesCap)
+	setFloat(&p.MeshMessageDeliveriesWeight, tc.MeshMessageDeliveriesWeight)
+	setDecay(&p.MeshMessageDeliveriesDecay, tc.MeshMessageDeliveriesDecay, decayInterval)
+	setFloat(&p.MeshMessageDeliveriesThreshold, tc.MeshMessageDeliveriesThreshold)
+	setFloat(&p.MeshFailurePenaltyWeight, tc.MeshFailurePenaltyWeight)
+	setDecay(&p.MeshFailurePenaltyDecay, tc.MeshFailurePenaltyDecay, decayInterval)
+	setFloat(&p.InvalidMessageDeliveriesWeight, tc.InvalidMessageDeliveriesWeight)
+	setDecay(&p.InvalidMessageDeliveriesDecay, tc.InvalidMessageDeliveriesDecay, decayInterval)
+}
+
+// gossipTopicName returns the gossip message name of a topic of the form
+// /eth2/{fork-digest}/{name}/{encoding}, with the subnet id of subnet topics removed.
+func gossipTopicName(topic string) string {
+	parts := strings.Split(topic, "/")
+	if len(parts) < 4 {
+		return ""
+	}
+	return subnetSuffix.ReplaceAllString(parts[3], "")
+}
+
+func setFloat(dst, val *float64) {
+	if val != nil {
+		*dst = *val
+	}
+}
+
+func setDecay(dst *float64, decayPeriod *time.Duration, decayInterval time.Duration) {
+	if decayPeriod != nil {
+		*dst = pubsub.ScoreParameterDecayWithBase(*decayPeriod, decayInterval, decayToZero)
+	}
+}
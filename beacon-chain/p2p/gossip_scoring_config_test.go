@@ -0,0 +1,87 @@
+package p2p
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestLoadGossipScoringConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scoring.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+thresholds:
+  gossip_threshold: -1000
+  graylist_threshold: -5000
+peer:
+  behaviour_penalty_weight: -10
+  decay_interval: 6s
+  behaviour_penalty_decay: 60s
+topics:
+  beacon_attestation:
+    topic_weight: 0.1
+    invalid_message_deliveries_decay: 120s
+`), params.BeaconIoConfig().ReadWritePermissions))
+	cfg, err := loadGossipScoringConfig(path)
+	require.NoError(t, err)
+
+	scoreParams, thresholds := peerScoringParams()
+	defaultPublishThreshold := thresholds.PublishThreshold
+	cfg.applyPeerScoreParams(scoreParams, thresholds)
+	assert.Equal(t, float64(-1000), thresholds.GossipThreshold)
+	assert.Equal(t, float64(-5000), thresholds.GraylistThreshold)
+	assert.Equal(t, defaultPublishThreshold, thresholds.PublishThreshold)
+	assert.Equal(t, float64(-10), scoreParams.BehaviourPenaltyWeight)
+	assert.Equal(t, 6*time.Second, scoreParams.DecayInterval)
+	// Decaying to zero over 10 decay intervals.
+	assert.Equal(t, true, scoreParams.BehaviourPenaltyDecay > 0.6 && scoreParams.BehaviourPenaltyDecay < 0.7)
+
+	subnetParams := defaultBlockTopicParams()
+	blockParams := defaultBlockTopicParams()
+	cfg.applyTopicScoreParams(fmt.Sprintf(AttestationSubnetTopicFormat, [4]byte{}, 3)+"/ssz_snappy", subnetParams)
+	cfg.applyTopicScoreParams(fmt.Sprintf(BlockSubnetTopicFormat, [4]byte{})+"/ssz_snappy", blockParams)
+	assert.Equal(t, 0.1, subnetParams.TopicWeight)
+	assert.Equal(t, true, subnetParams.InvalidMessageDeliveriesDecay > 0.7 && subnetParams.InvalidMessageDeliveriesDecay < 0.8)
+	assert.DeepEqual(t, defaultBlockTopicParams(), blockParams)
+
+	// Applying a nil config keeps the defaults.
+	var noCfg *gossipScoringConfig
+	scoreParams, thresholds = peerScoringParams()
+	noCfg.applyPeerScoreParams(scoreParams, thresholds)
+	assert.Equal(t, float64(-4000), thresholds.GossipThreshold)
+}
+
+func TestLoadGossipScoringConfig_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	unknownTopic := filepath.Join(dir, "topic.yaml")
+	require.NoError(t, ioutil.WriteFile(unknownTopic, []byte("topics:\n  beacon_blocks:\n    topic_weight: 1\n"), params.BeaconIoConfig().ReadWritePermissions))
+	_, err := loadGossipScoringConfig(unknownTopic)
+	assert.ErrorContains(t, "unknown gossip topic beacon_blocks", err)
+
+	unknownField := filepath.Join(dir, "field.yaml")
+	require.NoError(t, ioutil.WriteFile(unknownField, []byte("peer:\n  topic_cap: 1\n"), params.BeaconIoConfig().ReadWritePermissions))
+	_, err = loadGossipScoringConfig(unknownField)
+	assert.ErrorContains(t, "could not decode gossip scoring config", err)
+}
+
+func TestGossipTopicName(t *testing.T) {
+	digest := [4]byte{'a', 'b', 'c', 'd'}
+	tests := []struct {
+		topic string
+		want  string
+	}{
+		{topic: fmt.Sprintf(BlockSubnetTopicFormat, digest) + "/ssz_snappy", want: GossipBlockMessage},
+		{topic: fmt.Sprintf(AttestationSubnetTopicFormat, digest, 63) + "/ssz_snappy", want: GossipAttestationMessage},
+		{topic: fmt.Sprintf(SyncCommitteeSubnetTopicFormat, digest, 2) + "/ssz_snappy", want: GossipSyncCommitteeMessage},
+		{topic: fmt.Sprintf(SyncContributionAndProofSubnetTopicFormat, digest) + "/ssz_snappy", want: GossipContributionAndProofMessage},
+		{topic: "invalid", want: ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, gossipTopicName(tt.topic))
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/multiformats/go-multiaddr"
+	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -78,9 +79,30 @@ type PeerManager interface {
 	DiscoveryAddresses() ([]multiaddr.Multiaddr, error)
 	RefreshENR()
 	FindPeersWithSubnet(ctx context.Context, topic string, subIndex uint64, threshold int) (bool, error)
+	FindAggregatorSubnetPeers(subnet uint64, slot types.Slot)
 	AddPingMethod(reqFunc func(ctx context.Context, id peer.ID) error)
 }
 
+// StaticPeerManager manages the static and trusted peers of the node at runtime.
+type StaticPeerManager interface {
+	AddStaticPeer(addr string) (peer.AddrInfo, error)
+	RemoveStaticPeer(pid peer.ID) bool
+	StaticPeers() []peer.AddrInfo
+	AddTrustedPeer(pid peer.ID)
+	RemoveTrustedPeer(pid peer.ID)
+	TrustedPeers() []peer.ID
+}
+
+// BanManager manages the banned peers and ip ranges of the node. Bans persist across restarts.
+type BanManager interface {
+	BanPeer(pid peer.ID) error
+	UnbanPeer(pid peer.ID) (bool, error)
+	BannedPeers() []peer.ID
+	BanNetwork(cidr string) (string, error)
+	UnbanNetwork(cidr string) (bool, error)
+	BannedNetworks() []string
+}
+
 // Sender abstracts the sending functionality from libp2p.
 type Sender interface {
 	Send(context.Context, interface{}, string, peer.ID) (network.Stream, error)
@@ -43,6 +43,27 @@ var (
 		Name: "p2p_sync_committee_subnet_attempted_broadcasts",
 		Help: "The number of sync committee that were attempted to be broadcast.",
 	})
+	aggregatorSubnetSearchAttempts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "p2p_aggregator_subnet_search_attempts",
+		Help: "The number of searches for peers on the attestation subnet of an aggregator duty, " +
+			"triggered when the duty is known and no peer is on the subnet.",
+	})
+	savedAggregatorSubnetSearches = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "p2p_aggregator_subnet_search_successes",
+		Help: "The number of searches for peers on the attestation subnet of an aggregator duty " +
+			"that found a peer before the duty slot ended.",
+	})
+	resourceLimitHitsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_resource_limit_hits_total",
+		Help: "The number of connections and streams rejected for exceeding a resource limit.",
+	},
+		[]string{"limit"})
+	publishedMessageSizeHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "p2p_message_published_size_bytes",
+		Help:    "The size of the published gossip messages, before and after their compression.",
+		Buckets: prometheus.ExponentialBuckets(64, 4, 9),
+	},
+		[]string{"topic", "encoding"})
 )
 
 func (s *Service) updateMetrics() {
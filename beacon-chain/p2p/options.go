@@ -30,6 +30,18 @@ func (s *Service) buildOptions(ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 			log.Fatalf("Failed to p2p listen: %v", err)
 		}
 	}
+	listenAddrs := []ma.Multiaddr{listen}
+	// Listen on both address families when a local IPv6 address is given.
+	if cfg.LocalIPv6 != "" {
+		if ip6 := net.ParseIP(cfg.LocalIPv6); ip6 == nil || ip6.To4() != nil {
+			log.Fatalf("Invalid local ipv6 provided: %s", cfg.LocalIPv6)
+		}
+		listen6, err := multiAddressBuilder(cfg.LocalIPv6, cfg.TCPPort)
+		if err != nil {
+			log.Fatalf("Failed to p2p listen: %v", err)
+		}
+		listenAddrs = append(listenAddrs, listen6)
+	}
 	ifaceKey := convertToInterfacePrivkey(priKey)
 	id, err := peer.IDFromPublicKey(ifaceKey.GetPublic())
 	if err != nil {
@@ -39,7 +51,7 @@ func (s *Service) buildOptions(ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 
 	options := []libp2p.Option{
 		privKeyOption(priKey),
-		libp2p.ListenAddrs(listen),
+		libp2p.ListenAddrs(listenAddrs...),
 		libp2p.UserAgent(version.BuildData()),
 		libp2p.ConnectionGater(s),
 		libp2p.Transport(tcp.NewTCPTransport),
@@ -56,13 +68,18 @@ func (s *Service) buildOptions(ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 		// Disable relay if it has not been set.
 		options = append(options, libp2p.DisableRelay())
 	}
-	if cfg.HostAddress != "" {
+	if cfg.HostAddress != "" || cfg.HostAddressIPv6 != "" {
 		options = append(options, libp2p.AddrsFactory(func(addrs []ma.Multiaddr) []ma.Multiaddr {
-			external, err := multiAddressBuilder(cfg.HostAddress, cfg.TCPPort)
-			if err != nil {
-				log.WithError(err).Error("Unable to create external multiaddress")
-			} else {
-				addrs = append(addrs, external)
+			for _, hostAddr := range []string{cfg.HostAddress, cfg.HostAddressIPv6} {
+				if hostAddr == "" {
+					continue
+				}
+				external, err := multiAddressBuilder(hostAddr, cfg.TCPPort)
+				if err != nil {
+					log.WithError(err).Error("Unable to create external multiaddress")
+				} else {
+					addrs = append(addrs, external)
+				}
 			}
 			return addrs
 		}))
@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
@@ -70,3 +71,34 @@ func TestIPV6Support(t *testing.T) {
 		t.Error("Multiaddress did not have ipv6 protocol")
 	}
 }
+
+func TestNodeTCPEndpoint_PreferredAddressFamily(t *testing.T) {
+	key, err := gethCrypto.GenerateKey()
+	require.NoError(t, err)
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	lNode := enode.NewLocalNode(db, key)
+	ip4 := net.ParseIP("192.168.0.1")
+	ip6 := net.ParseIP("2001:db8::1")
+
+	lNode.Set(enr.IPv6(ip6))
+	lNode.Set(enr.TCP(13000))
+	// A node only advertising an IPv6 address is dialed on it, with the IPv4 tcp port.
+	ip, port := nodeTCPEndpoint(lNode.Node(), false)
+	assert.Equal(t, true, ip.Equal(ip6))
+	assert.Equal(t, uint(13000), port)
+
+	lNode.Set(enr.IPv4(ip4))
+	lNode.Set(enr.TCP6(13001))
+	ip, port = nodeTCPEndpoint(lNode.Node(), false)
+	assert.Equal(t, true, ip.Equal(ip4))
+	assert.Equal(t, uint(13000), port)
+	ip, port = nodeTCPEndpoint(lNode.Node(), true)
+	assert.Equal(t, true, ip.Equal(ip6))
+	assert.Equal(t, uint(13001), port)
+
+	addr, err := convertToPreferredMultiAddr(lNode.Node(), true)
+	require.NoError(t, err)
+	_, err = addr.ValueForProtocol(ma.P_IP6)
+	assert.NoError(t, err)
+}
@@ -56,10 +56,20 @@ type PeerData struct {
 	ChainState                *ethpb.Status
 	ChainStateLastUpdated     time.Time
 	ChainStateValidationError error
+	// Req/resp data.
+	RequestsReceived uint64
+	RequestsSent     uint64
+	LastSeen         time.Time
 	// Scorers internal data.
-	BadResponses         int
-	ProcessedBlocks      uint64
-	BlockProviderUpdated time.Time
+	BadResponses          int
+	ProcessedBlocks       uint64
+	BlockProviderUpdated  time.Time
+	EmptyResponses        int
+	InvalidBatches        int
+	BlockThroughput       float64
+	BlockProviderCooldown time.Time
+	// Score saved before the last restart, used until the peer connects again.
+	RestoredScore float64
 	// Gossip Scoring data.
 	TopicScores      map[string]*ethpb.TopicScoreSnapshot
 	GossipScore      float64
@@ -32,6 +32,25 @@ const (
 	// opportunity to provide blocks (their score gets boosted, up until they are selected for
 	// fetching).
 	DefaultBlockProviderStalePeerRefreshInterval = 5 * time.Minute
+	// DefaultBlockProviderEmptyResponseWeight is a default penalty of an empty blocks by range response.
+	DefaultBlockProviderEmptyResponseWeight = float64(0.05)
+	// DefaultBlockProviderInvalidBatchWeight is a default penalty of a batch of blocks that failed processing.
+	DefaultBlockProviderInvalidBatchWeight = float64(0.2)
+	// DefaultBlockProviderThroughputWeight defines which share of the maximum score is awarded to peers
+	// serving blocks at the throughput cap.
+	DefaultBlockProviderThroughputWeight = float64(0.2)
+	// DefaultBlockProviderThroughputCap defines the throughput (in blocks per second) above which
+	// peers are not rewarded any further.
+	DefaultBlockProviderThroughputCap = float64(256)
+	// DefaultBlockProviderCooldownThreshold defines how many empty responses and invalid batches are
+	// tolerated before a peer is temporarily avoided.
+	DefaultBlockProviderCooldownThreshold = 3
+	// DefaultBlockProviderCooldownPeriod defines for how long peers are avoided once they have reached
+	// the cooldown threshold.
+	DefaultBlockProviderCooldownPeriod = 2 * time.Minute
+	// blockProviderThroughputSmoothing is the weight of the latest measurement in the throughput
+	// moving average.
+	blockProviderThroughputSmoothing = 0.3
 )
 
 // BlockProviderScorer represents block provider scoring service.
@@ -59,6 +78,20 @@ type BlockProviderScorerConfig struct {
 	// StalePeerRefreshInterval is an interval at which peers should be given an opportunity
 	// to provide blocks (scores are boosted to max up until such peers are selected).
 	StalePeerRefreshInterval time.Duration
+	// EmptyResponseWeight defines a penalty for a single empty blocks by range response.
+	EmptyResponseWeight float64
+	// InvalidBatchWeight defines a penalty for a single batch of blocks that failed processing.
+	InvalidBatchWeight float64
+	// ThroughputWeight defines which share of the maximum score is awarded for throughput. Peers
+	// serving blocks at ThroughputCap are awarded the full share.
+	ThroughputWeight float64
+	// ThroughputCap defines the throughput (in blocks per second) above which peers are not rewarded.
+	ThroughputCap float64
+	// CooldownThreshold defines how many empty responses and invalid batches (not yet decayed) are
+	// tolerated, before a peer is avoided for CooldownPeriod.
+	CooldownThreshold int
+	// CooldownPeriod defines for how long peers are avoided when fetching blocks.
+	CooldownPeriod time.Duration
 }
 
 // newBlockProviderScorer creates block provider scoring service.
@@ -85,6 +118,24 @@ func newBlockProviderScorer(store *peerdata.Store, config *BlockProviderScorerCo
 	if scorer.config.StalePeerRefreshInterval == 0 {
 		scorer.config.StalePeerRefreshInterval = DefaultBlockProviderStalePeerRefreshInterval
 	}
+	if scorer.config.EmptyResponseWeight == 0.0 {
+		scorer.config.EmptyResponseWeight = DefaultBlockProviderEmptyResponseWeight
+	}
+	if scorer.config.InvalidBatchWeight == 0.0 {
+		scorer.config.InvalidBatchWeight = DefaultBlockProviderInvalidBatchWeight
+	}
+	if scorer.config.ThroughputWeight == 0.0 {
+		scorer.config.ThroughputWeight = DefaultBlockProviderThroughputWeight
+	}
+	if scorer.config.ThroughputCap == 0.0 {
+		scorer.config.ThroughputCap = DefaultBlockProviderThroughputCap
+	}
+	if scorer.config.CooldownThreshold == 0 {
+		scorer.config.CooldownThreshold = DefaultBlockProviderCooldownThreshold
+	}
+	if scorer.config.CooldownPeriod == 0 {
+		scorer.config.CooldownPeriod = DefaultBlockProviderCooldownPeriod
+	}
 	batchSize := uint64(flags.Get().BlockBatchLimit)
 	scorer.maxScore = 1.0
 	if batchSize > 0 {
@@ -106,6 +157,9 @@ func (s *BlockProviderScorer) Score(pid peer.ID) float64 {
 func (s *BlockProviderScorer) score(pid peer.ID) float64 {
 	score := float64(0)
 	peerData, ok := s.store.PeerData(pid)
+	if s.isCoolingDown(pid) {
+		return 0
+	}
 	// Boost score of new peers or peers that haven't been accessed for too long.
 	if !ok || time.Since(peerData.BlockProviderUpdated) >= s.config.StalePeerRefreshInterval {
 		return s.maxScore
@@ -115,6 +169,14 @@ func (s *BlockProviderScorer) score(pid peer.ID) float64 {
 		processedBatches := float64(peerData.ProcessedBlocks / batchSize)
 		score += processedBatches * s.config.ProcessedBatchWeight
 	}
+	if peerData.BlockThroughput > 0 {
+		throughput := math.Min(peerData.BlockThroughput/s.config.ThroughputCap, 1.0)
+		score += throughput * s.config.ThroughputWeight * s.maxScore
+		score = math.Min(score, s.maxScore)
+	}
+	score -= float64(peerData.EmptyResponses) * s.config.EmptyResponseWeight
+	score -= float64(peerData.InvalidBatches) * s.config.InvalidBatchWeight
+	score = math.Max(score, 0)
 	return math.Round(score*ScoreRoundingFactor) / ScoreRoundingFactor
 }
 
@@ -141,6 +203,96 @@ func (s *BlockProviderScorer) IncrementProcessedBlocks(pid peer.ID, cnt uint64)
 	}
 }
 
+// IncrementEmptyResponses increments the number of empty blocks by range responses of a peer.
+func (s *BlockProviderScorer) IncrementEmptyResponses(pid peer.ID) {
+	s.store.Lock()
+	defer s.store.Unlock()
+
+	peerData := s.store.PeerDataGetOrCreate(pid)
+	peerData.EmptyResponses++
+	s.updateCooldown(peerData)
+}
+
+// IncrementInvalidBatches increments the number of batches of blocks from a peer that failed processing.
+func (s *BlockProviderScorer) IncrementInvalidBatches(pid peer.ID) {
+	s.store.Lock()
+	defer s.store.Unlock()
+
+	peerData := s.store.PeerDataGetOrCreate(pid)
+	peerData.InvalidBatches++
+	s.updateCooldown(peerData)
+}
+
+// updateCooldown starts (or extends) the cooldown of a peer, once it has reached the cooldown threshold.
+func (s *BlockProviderScorer) updateCooldown(peerData *peerdata.PeerData) {
+	if peerData.EmptyResponses+peerData.InvalidBatches >= s.config.CooldownThreshold {
+		peerData.BlockProviderCooldown = prysmTime.Now().Add(s.config.CooldownPeriod)
+	}
+}
+
+// UpdateThroughput records that a peer served a number of blocks in a given time.
+func (s *BlockProviderScorer) UpdateThroughput(pid peer.ID, blocks int, elapsed time.Duration) {
+	if blocks <= 0 || elapsed <= 0 {
+		return
+	}
+	s.store.Lock()
+	defer s.store.Unlock()
+
+	peerData := s.store.PeerDataGetOrCreate(pid)
+	throughput := float64(blocks) / elapsed.Seconds()
+	if peerData.BlockThroughput == 0 {
+		peerData.BlockThroughput = throughput
+		return
+	}
+	peerData.BlockThroughput += blockProviderThroughputSmoothing * (throughput - peerData.BlockThroughput)
+}
+
+// EmptyResponses returns the number of empty blocks by range responses of a peer (not yet decayed).
+func (s *BlockProviderScorer) EmptyResponses(pid peer.ID) int {
+	s.store.RLock()
+	defer s.store.RUnlock()
+	if peerData, ok := s.store.PeerData(pid); ok {
+		return peerData.EmptyResponses
+	}
+	return 0
+}
+
+// InvalidBatches returns the number of batches of blocks from a peer that failed processing (not yet decayed).
+func (s *BlockProviderScorer) InvalidBatches(pid peer.ID) int {
+	s.store.RLock()
+	defer s.store.RUnlock()
+	if peerData, ok := s.store.PeerData(pid); ok {
+		return peerData.InvalidBatches
+	}
+	return 0
+}
+
+// Throughput returns the average throughput of a peer, in blocks per second.
+func (s *BlockProviderScorer) Throughput(pid peer.ID) float64 {
+	s.store.RLock()
+	defer s.store.RUnlock()
+	if peerData, ok := s.store.PeerData(pid); ok {
+		return peerData.BlockThroughput
+	}
+	return 0
+}
+
+// IsCoolingDown states whether a peer is temporarily avoided when fetching blocks.
+func (s *BlockProviderScorer) IsCoolingDown(pid peer.ID) bool {
+	s.store.RLock()
+	defer s.store.RUnlock()
+	return s.isCoolingDown(pid)
+}
+
+// isCoolingDown is a lock-free version of IsCoolingDown.
+func (s *BlockProviderScorer) isCoolingDown(pid peer.ID) bool {
+	peerData, ok := s.store.PeerData(pid)
+	if !ok {
+		return false
+	}
+	return prysmTime.Now().Before(peerData.BlockProviderCooldown)
+}
+
 // Touch updates last access time for a given peer. This allows to detect peers that are
 // stale and boost their scores to increase chances in block fetching participation.
 func (s *BlockProviderScorer) Touch(pid peer.ID, t ...time.Time) {
@@ -201,6 +353,12 @@ func (s *BlockProviderScorer) Decay() {
 		} else {
 			peerData.ProcessedBlocks = 0
 		}
+		if peerData.EmptyResponses > 0 {
+			peerData.EmptyResponses--
+		}
+		if peerData.InvalidBatches > 0 {
+			peerData.InvalidBatches--
+		}
 	}
 }
 
@@ -277,9 +435,13 @@ func (s *BlockProviderScorer) mapScoresAndPeers(
 	scores := make(map[peer.ID]float64, len(pids))
 	peers := make([]peer.ID, len(pids))
 	for i, pid := range pids {
-		if scoreFn != nil {
+		switch {
+		case s.isCoolingDown(pid):
+			// Peers cooling down are only used once all other peers have been tried.
+			scores[pid] = 0
+		case scoreFn != nil:
 			scores[pid] = scoreFn(pid, s.score(pid))
-		} else {
+		default:
 			scores[pid] = s.score(pid)
 		}
 		peers[i] = pid
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strconv"
 	"testing"
+	gotime "time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
@@ -116,6 +117,46 @@ func TestScorers_BlockProvider_Score(t *testing.T) {
 				assert.Equal(t, 1.0, scorer.Score("peer1"))
 			},
 		},
+		{
+			name: "throughput reward",
+			update: func(scorer *scorers.BlockProviderScorer) {
+				scorer.IncrementProcessedBlocks("peer1", batchSize*2)
+				scorer.UpdateThroughput("peer1", int(scorer.Params().ThroughputCap/2), 1*gotime.Second)
+			},
+			check: func(scorer *scorers.BlockProviderScorer) {
+				batchWeight := scorer.Params().ProcessedBatchWeight
+				reward := scorer.Params().ThroughputWeight * scorer.MaxScore() / 2
+				assert.Equal(t, roundScore(batchWeight*2+reward), scorer.Score("peer1"), "Unexpected score")
+			},
+		},
+		{
+			name: "empty responses and invalid batches penalty",
+			update: func(scorer *scorers.BlockProviderScorer) {
+				scorer.IncrementProcessedBlocks("peer1", batchSize*5)
+				scorer.IncrementEmptyResponses("peer1")
+				scorer.IncrementInvalidBatches("peer1")
+			},
+			check: func(scorer *scorers.BlockProviderScorer) {
+				batchWeight := scorer.Params().ProcessedBatchWeight
+				penalty := scorer.Params().EmptyResponseWeight + scorer.Params().InvalidBatchWeight
+				assert.Equal(t, roundScore(batchWeight*5-penalty), scorer.Score("peer1"), "Unexpected score")
+			},
+		},
+		{
+			name: "cooling down peer",
+			update: func(scorer *scorers.BlockProviderScorer) {
+				scorer.IncrementProcessedBlocks("peer1", scorer.Params().ProcessedBlocksCap)
+				for i := 0; i < scorer.Params().CooldownThreshold; i++ {
+					scorer.IncrementEmptyResponses("peer1")
+				}
+				// Stale peers are not boosted while cooling down.
+				scorer.Touch("peer1", time.Now().Add(-1*scorer.Params().StalePeerRefreshInterval))
+			},
+			check: func(scorer *scorers.BlockProviderScorer) {
+				assert.Equal(t, true, scorer.IsCoolingDown("peer1"))
+				assert.Equal(t, 0.0, scorer.Score("peer1"), "Unexpected score")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -149,6 +190,50 @@ func TestScorers_BlockProvider_GettersSetters(t *testing.T) {
 	assert.Equal(t, uint64(64), scorer.ProcessedBlocks("peer1"))
 }
 
+func TestScorers_BlockProvider_ResponseQuality(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peerStatuses := peers.NewStatus(ctx, &peers.StatusConfig{
+		ScorerParams: &scorers.Config{
+			BlockProviderScorerConfig: &scorers.BlockProviderScorerConfig{
+				CooldownThreshold: 2,
+			},
+		},
+	})
+	scorer := peerStatuses.Scorers().BlockProviderScorer()
+
+	assert.Equal(t, 0, scorer.EmptyResponses("peer1"), "Unexpected count for unregistered peer")
+	assert.Equal(t, 0, scorer.InvalidBatches("peer1"), "Unexpected count for unregistered peer")
+	assert.Equal(t, 0.0, scorer.Throughput("peer1"), "Unexpected throughput for unregistered peer")
+	assert.Equal(t, false, scorer.IsCoolingDown("peer1"))
+
+	// Throughput is a moving average.
+	scorer.UpdateThroughput("peer1", 100, gotime.Second)
+	assert.Equal(t, 100.0, scorer.Throughput("peer1"))
+	scorer.UpdateThroughput("peer1", 200, gotime.Second)
+	assert.Equal(t, 130.0, scorer.Throughput("peer1"))
+	scorer.UpdateThroughput("peer1", 0, gotime.Second)
+	assert.Equal(t, 130.0, scorer.Throughput("peer1"))
+
+	scorer.IncrementEmptyResponses("peer1")
+	assert.Equal(t, 1, scorer.EmptyResponses("peer1"))
+	assert.Equal(t, false, scorer.IsCoolingDown("peer1"))
+	scorer.IncrementInvalidBatches("peer1")
+	assert.Equal(t, 1, scorer.InvalidBatches("peer1"))
+	assert.Equal(t, true, scorer.IsCoolingDown("peer1"))
+
+	// Peers cooling down are sorted last, whatever their score.
+	scorer.IncrementProcessedBlocks("peer1", scorer.Params().ProcessedBlocksCap)
+	scorer.IncrementProcessedBlocks("peer2", uint64(flags.Get().BlockBatchLimit))
+	assert.DeepEqual(t, []peer.ID{"peer2", "peer1"}, scorer.Sorted([]peer.ID{"peer1", "peer2"}, nil))
+
+	// Counters decay along with processed blocks.
+	scorer.Decay()
+	assert.Equal(t, 0, scorer.EmptyResponses("peer1"))
+	assert.Equal(t, 0, scorer.InvalidBatches("peer1"))
+}
+
 func TestScorers_BlockProvider_WeightSorted(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
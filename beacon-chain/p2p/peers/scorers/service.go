@@ -113,9 +113,13 @@ func (s *Service) Score(pid peer.ID) float64 {
 // ScoreNoLock is a lock-free version of Score.
 func (s *Service) ScoreNoLock(pid peer.ID) float64 {
 	score := float64(0)
-	if _, ok := s.store.PeerData(pid); !ok {
+	peerData, ok := s.store.PeerData(pid)
+	if !ok {
 		return 0
 	}
+	if peerData.RestoredScore != 0 {
+		return peerData.RestoredScore
+	}
 	score += s.scorers.badResponsesScorer.score(pid) * s.scorerWeight(s.scorers.badResponsesScorer)
 	score += s.scorers.blockProviderScorer.score(pid) * s.scorerWeight(s.scorers.blockProviderScorer)
 	score += s.scorers.peerStatusScorer.score(pid) * s.scorerWeight(s.scorers.peerStatusScorer)
@@ -123,6 +127,16 @@ func (s *Service) ScoreNoLock(pid peer.ID) float64 {
 	return math.Round(score*ScoreRoundingFactor) / ScoreRoundingFactor
 }
 
+// RestoreScore sets the score a peer had before a restart. The peer keeps this score until it
+// connects again, from which point it is scored on its new behaviour.
+func (s *Service) RestoreScore(pid peer.ID, score float64) {
+	s.store.Lock()
+	defer s.store.Unlock()
+	if peerData, ok := s.store.PeerData(pid); ok {
+		peerData.RestoredScore = score
+	}
+}
+
 // IsBadPeer traverses all the scorers to see if any of them classifies peer as bad.
 func (s *Service) IsBadPeer(pid peer.ID) bool {
 	s.store.RLock()
@@ -37,6 +37,7 @@ import (
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/cmd"
 	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/crypto/rand"
@@ -64,8 +65,8 @@ const (
 	// Additional buffer beyond current peer limit, from which we can store the relevant peer statuses.
 	maxLimitBuffer = 150
 
-	// InboundRatio is the proportion of our connected peer limit at which we will allow inbound peers.
-	InboundRatio = float64(0.8)
+	// InboundRatio is the default proportion of our connected peer limit at which we will allow inbound peers.
+	InboundRatio = float64(cmd.DefaultP2PInboundRatio)
 
 	// MinBackOffDuration minimum amount (in milliseconds) to wait before peer is re-dialed.
 	// When node and peer are dialing each other simultaneously connection may fail. In order, to break
@@ -81,13 +82,22 @@ type Status struct {
 	scorers   *scorers.Service
 	store     *peerdata.Store
 	ipTracker map[string]uint64
+	trusted   map[peer.ID]bool
 	rand      *rand.Rand
+	// inboundRatio is the proportion of the connected peer limit at which we will allow inbound peers.
+	inboundRatio float64
+	noInbound    bool
 }
 
 // StatusConfig represents peer status service params.
 type StatusConfig struct {
 	// PeerLimit specifies maximum amount of concurrent peers that are expected to be connect to the node.
 	PeerLimit int
+	// InboundRatio is the proportion of the peer limit which can be taken by inbound peers,
+	// InboundRatio is used when it is zero. Inbound peers are disallowed with NoInbound.
+	InboundRatio float64
+	// NoInbound disallows inbound peers, all of them are pruned.
+	NoInbound bool
 	// ScorerParams holds peer scorer configuration params.
 	ScorerParams *scorers.Config
 }
@@ -97,14 +107,24 @@ func NewStatus(ctx context.Context, config *StatusConfig) *Status {
 	store := peerdata.NewStore(ctx, &peerdata.StoreConfig{
 		MaxPeers: maxLimitBuffer + config.PeerLimit,
 	})
+	inboundRatio := config.InboundRatio
+	if inboundRatio == 0 {
+		inboundRatio = InboundRatio
+	}
+	if config.NoInbound {
+		inboundRatio = 0
+	}
 	return &Status{
 		ctx:       ctx,
 		store:     store,
 		scorers:   scorers.NewService(ctx, store, config.ScorerParams),
 		ipTracker: map[string]uint64{},
+		trusted:   map[peer.ID]bool{},
 		// Random generator used to calculate dial backoff period.
 		// It is ok to use deterministic generator, no need for true entropy.
-		rand: rand.NewDeterministicGenerator(),
+		rand:         rand.NewDeterministicGenerator(),
+		inboundRatio: inboundRatio,
+		noInbound:    config.NoInbound,
 	}
 }
 
@@ -218,8 +238,7 @@ func (p *Status) IsAboveInboundLimit() bool {
 			totalInbound += 1
 		}
 	}
-	inboundLimit := int(float64(p.ConnectedPeerLimit()) * InboundRatio)
-	return totalInbound > inboundLimit
+	return totalInbound > p.inboundLimit()
 }
 
 // InboundLimit returns the current inbound
@@ -227,7 +246,13 @@ func (p *Status) IsAboveInboundLimit() bool {
 func (p *Status) InboundLimit() int {
 	p.store.RLock()
 	defer p.store.RUnlock()
-	return int(float64(p.ConnectedPeerLimit()) * InboundRatio)
+	return p.inboundLimit()
+}
+
+// this method assumes the store lock is acquired before
+// executing the method.
+func (p *Status) inboundLimit() int {
+	return int(float64(p.ConnectedPeerLimit()) * p.inboundRatio)
 }
 
 // SetMetadata sets the metadata of the given remote peer.
@@ -268,9 +293,44 @@ func (p *Status) CommitteeIndices(pid peer.ID) ([]uint64, error) {
 	return nil, peerdata.ErrPeerUnknown
 }
 
+// SyncCommitteeIndices retrieves the sync committee subnets the peer is subscribed to,
+// as advertised in its altair metadata.
+func (p *Status) SyncCommitteeIndices(pid peer.ID) ([]uint64, error) {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	if peerData, ok := p.store.PeerData(pid); ok {
+		if peerData.MetaData == nil || peerData.MetaData.IsNil() || peerData.MetaData.SyncnetsBitfield() == nil {
+			return []uint64{}, nil
+		}
+		return indicesFromBitfield(peerData.MetaData.SyncnetsBitfield()), nil
+	}
+	return nil, peerdata.ErrPeerUnknown
+}
+
 // SubscribedToSubnet retrieves the peers subscribed to the given
 // committee subnet.
 func (p *Status) SubscribedToSubnet(index uint64) []peer.ID {
+	return p.subscribedToSubnet(index, func(md metadata.Metadata) bitfield.Bitfield {
+		if md.AttnetsBitfield() == nil {
+			return nil
+		}
+		return md.AttnetsBitfield()
+	})
+}
+
+// SubscribedToSyncSubnet retrieves the peers subscribed to the given
+// sync committee subnet.
+func (p *Status) SubscribedToSyncSubnet(index uint64) []peer.ID {
+	return p.subscribedToSubnet(index, func(md metadata.Metadata) bitfield.Bitfield {
+		if md.SyncnetsBitfield() == nil {
+			return nil
+		}
+		return md.SyncnetsBitfield()
+	})
+}
+
+func (p *Status) subscribedToSubnet(index uint64, subnets func(metadata.Metadata) bitfield.Bitfield) []peer.ID {
 	p.store.RLock()
 	defer p.store.RUnlock()
 
@@ -278,14 +338,12 @@ func (p *Status) SubscribedToSubnet(index uint64) []peer.ID {
 	for pid, peerData := range p.store.Peers() {
 		// look at active peers
 		connectedStatus := peerData.ConnState == PeerConnecting || peerData.ConnState == PeerConnected
-		if connectedStatus && peerData.MetaData != nil && !peerData.MetaData.IsNil() && peerData.MetaData.AttnetsBitfield() != nil {
-			indices := indicesFromBitfield(peerData.MetaData.AttnetsBitfield())
-			for _, idx := range indices {
-				if idx == index {
-					peers = append(peers, pid)
-					break
-				}
-			}
+		if !connectedStatus || peerData.MetaData == nil || peerData.MetaData.IsNil() {
+			continue
+		}
+		bitV := subnets(peerData.MetaData)
+		if bitV != nil && bitV.BitAt(index) {
+			peers = append(peers, pid)
 		}
 	}
 	return peers
@@ -298,6 +356,11 @@ func (p *Status) SetConnectionState(pid peer.ID, state peerdata.PeerConnectionSt
 
 	peerData := p.store.PeerDataGetOrCreate(pid)
 	peerData.ConnState = state
+	if state == PeerConnected {
+		peerData.LastSeen = prysmTime.Now()
+		// The peer is scored on its new behaviour from now on.
+		peerData.RestoredScore = 0
+	}
 }
 
 // ConnectionState gets the connection state of the given remote peer.
@@ -324,6 +387,50 @@ func (p *Status) ChainStateLastUpdated(pid peer.ID) (time.Time, error) {
 	return prysmTime.Now(), peerdata.ErrPeerUnknown
 }
 
+// IncrementRequestsReceived records a req/resp request received from the given remote peer.
+func (p *Status) IncrementRequestsReceived(pid peer.ID) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	peerData := p.store.PeerDataGetOrCreate(pid)
+	peerData.RequestsReceived++
+	peerData.LastSeen = prysmTime.Now()
+}
+
+// IncrementRequestsSent records a req/resp request sent to the given remote peer.
+func (p *Status) IncrementRequestsSent(pid peer.ID) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	peerData := p.store.PeerDataGetOrCreate(pid)
+	peerData.RequestsSent++
+	peerData.LastSeen = prysmTime.Now()
+}
+
+// RequestCounts gets the number of req/resp requests received from and sent to the given
+// remote peer. This will error if the peer does not exist.
+func (p *Status) RequestCounts(pid peer.ID) (received, sent uint64, err error) {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	if peerData, ok := p.store.PeerData(pid); ok {
+		return peerData.RequestsReceived, peerData.RequestsSent, nil
+	}
+	return 0, 0, peerdata.ErrPeerUnknown
+}
+
+// LastSeen gets the last time the given remote peer connected, or exchanged a req/resp
+// request with the node. This will error if the peer does not exist.
+func (p *Status) LastSeen(pid peer.ID) (time.Time, error) {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	if peerData, ok := p.store.PeerData(pid); ok {
+		return peerData.LastSeen, nil
+	}
+	return time.Time{}, peerdata.ErrPeerUnknown
+}
+
 // IsBad states if the peer is to be considered bad (by *any* of the registered scorers).
 // If the peer is unknown this will return `false`, which makes using this function easier than returning an error.
 func (p *Status) IsBad(pid peer.ID) bool {
@@ -334,9 +441,42 @@ func (p *Status) IsBad(pid peer.ID) bool {
 
 // isBad is the lock-free version of IsBad.
 func (p *Status) isBad(pid peer.ID) bool {
+	if p.trusted[pid] {
+		return false
+	}
 	return p.isfromBadIP(pid) || p.scorers.IsBadPeerNoLock(pid)
 }
 
+// SetTrusted marks a peer as trusted or not. Trusted peers are never considered bad, and are
+// never pruned.
+func (p *Status) SetTrusted(pid peer.ID, trusted bool) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	if trusted {
+		p.trusted[pid] = true
+		return
+	}
+	delete(p.trusted, pid)
+}
+
+// IsTrusted states if the peer is trusted.
+func (p *Status) IsTrusted(pid peer.ID) bool {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.trusted[pid]
+}
+
+// Trusted returns the peers which are trusted.
+func (p *Status) Trusted() []peer.ID {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	peers := make([]peer.ID, 0, len(p.trusted))
+	for pid := range p.trusted {
+		peers = append(peers, pid)
+	}
+	return peers
+}
+
 // NextValidTime gets the earliest possible time it is to contact/dial
 // a peer again. This is used to back-off from peers in the event
 // they are 'full' or have banned us.
@@ -562,7 +702,7 @@ func (p *Status) Prune() {
 	peersToPrune := make([]*peerResp, 0)
 	// Select disconnected peers with a smaller bad response count.
 	for pid, peerData := range p.store.Peers() {
-		if peerData.ConnState == PeerDisconnected && notBadPeer(pid) {
+		if peerData.ConnState == PeerDisconnected && notBadPeer(pid) && !p.trusted[pid] {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:   pid,
 				score: p.Scorers().ScoreNoLock(pid),
@@ -610,7 +750,7 @@ func (p *Status) deprecatedPrune() {
 	peersToPrune := make([]*peerResp, 0)
 	// Select disconnected peers with a smaller bad response count.
 	for pid, peerData := range p.store.Peers() {
-		if peerData.ConnState == PeerDisconnected && notBadPeer(peerData) {
+		if peerData.ConnState == PeerDisconnected && notBadPeer(peerData) && !p.trusted[pid] {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:     pid,
 				badResp: peerData.BadResponses,
@@ -753,8 +893,8 @@ func (p *Status) PeersToPrune() []peer.ID {
 	activePeers := p.Active()
 	numInboundPeers := len(p.InboundConnected())
 	// Exit early if we are still below our max
-	// limit.
-	if len(activePeers) <= int(connLimit) {
+	// limit, unless inbound peers are disallowed.
+	if len(activePeers) <= int(connLimit) && (!p.noInbound || numInboundPeers == 0) {
 		return []peer.ID{}
 	}
 	p.store.Lock()
@@ -768,7 +908,7 @@ func (p *Status) PeersToPrune() []peer.ID {
 	// Select connected and inbound peers to prune.
 	for pid, peerData := range p.store.Peers() {
 		if peerData.ConnState == PeerConnected &&
-			peerData.Direction == network.DirInbound {
+			peerData.Direction == network.DirInbound && !p.trusted[pid] {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:   pid,
 				score: p.scorers.ScoreNoLock(pid),
@@ -785,6 +925,9 @@ func (p *Status) PeersToPrune() []peer.ID {
 	// Determine amount of peers to prune using our
 	// max connection limit.
 	amountToPrune := len(activePeers) - int(connLimit)
+	if amountToPrune < 0 {
+		amountToPrune = 0
+	}
 
 	// Also check for inbound peers above our limit.
 	excessInbound := 0
@@ -814,8 +957,8 @@ func (p *Status) deprecatedPeersToPrune() []peer.ID {
 	activePeers := p.Active()
 	numInboundPeers := len(p.InboundConnected())
 	// Exit early if we are still below our max
-	// limit.
-	if len(activePeers) <= int(connLimit) {
+	// limit, unless inbound peers are disallowed.
+	if len(activePeers) <= int(connLimit) && (!p.noInbound || numInboundPeers == 0) {
 		return []peer.ID{}
 	}
 	p.store.Lock()
@@ -829,7 +972,7 @@ func (p *Status) deprecatedPeersToPrune() []peer.ID {
 	// Select connected and inbound peers to prune.
 	for pid, peerData := range p.store.Peers() {
 		if peerData.ConnState == PeerConnected &&
-			peerData.Direction == network.DirInbound {
+			peerData.Direction == network.DirInbound && !p.trusted[pid] {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:     pid,
 				badResp: peerData.BadResponses,
@@ -846,6 +989,9 @@ func (p *Status) deprecatedPeersToPrune() []peer.ID {
 	// Determine amount of peers to prune using our
 	// max connection limit.
 	amountToPrune := len(activePeers) - int(connLimit)
+	if amountToPrune < 0 {
+		amountToPrune = 0
+	}
 	// Also check for inbound peers above our limit.
 	excessInbound := 0
 	if numInboundPeers > inBoundLimit {
@@ -970,9 +1116,9 @@ func sameIP(firstAddr, secondAddr ma.Multiaddr) bool {
 	return firstIP.Equal(secondIP)
 }
 
-func indicesFromBitfield(bitV bitfield.Bitvector64) []uint64 {
+func indicesFromBitfield(bitV bitfield.Bitfield) []uint64 {
 	committeeIdxs := make([]uint64, 0, bitV.Count())
-	for i := uint64(0); i < 64; i++ {
+	for i := uint64(0); i < bitV.Len(); i++ {
 		if bitV.BitAt(i) {
 			committeeIdxs = append(committeeIdxs, i)
 		}
@@ -245,6 +245,42 @@ func TestPeerSubscribedToSubnet(t *testing.T) {
 	assert.Equal(t, expectedPeer, ps[0])
 }
 
+func TestPeerSubscribedToSyncSubnet(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+
+	altairPeer := addPeer(t, p, peers.PeerConnected)
+	phase0Peer := addPeer(t, p, peers.PeerConnected)
+	addPeer(t, p, peers.PeerConnected)
+	bitV := bitfield.Bitvector4{byte(0x00)}
+	bitV.SetBitAt(1, true)
+	bitV.SetBitAt(3, true)
+	p.SetMetadata(altairPeer, wrapper.WrappedMetadataV1(&pb.MetaDataV1{
+		SeqNumber: 2,
+		Attnets:   bitfield.NewBitvector64(),
+		Syncnets:  bitV,
+	}))
+	p.SetMetadata(phase0Peer, wrapper.WrappedMetadataV0(&pb.MetaDataV0{
+		SeqNumber: 2,
+		Attnets:   bitfield.NewBitvector64(),
+	}))
+
+	indices, err := p.SyncCommitteeIndices(altairPeer)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []uint64{1, 3}, indices)
+	indices, err = p.SyncCommitteeIndices(phase0Peer)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(indices))
+
+	ps := p.SubscribedToSyncSubnet(1)
+	require.Equal(t, 1, len(ps), "Unexpected num of peers")
+	assert.Equal(t, altairPeer, ps[0])
+	assert.Equal(t, 0, len(p.SubscribedToSyncSubnet(2)), "Unexpected num of peers")
+	assert.Equal(t, 0, len(p.SubscribedToSubnet(1)), "Unexpected num of peers")
+}
+
 func TestPeerImplicitAdd(t *testing.T) {
 	maxBadResponses := 2
 	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
@@ -548,6 +584,63 @@ func TestPrune(t *testing.T) {
 	assert.ErrorContains(t, "peer unknown", err)
 }
 
+func TestTrustedPeers(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold: 1,
+			},
+		},
+	})
+
+	for i := 0; i < p.MaxPeerLimit()+100; i++ {
+		_ = addPeer(t, p, peers.PeerConnected)
+	}
+	trusted := addPeer(t, p, peers.PeerDisconnected)
+	p.SetTrusted(trusted, true)
+	assert.Equal(t, true, p.IsTrusted(trusted))
+	assert.DeepEqual(t, []peer.ID{trusted}, p.Trusted())
+
+	// Trusted peers are never bad.
+	p.Scorers().BadResponsesScorer().Increment(trusted)
+	assert.Equal(t, false, p.IsBad(trusted))
+
+	// Trusted peers are never pruned.
+	p.Prune()
+	_, err := p.ConnectionState(trusted)
+	require.NoError(t, err)
+
+	p.SetTrusted(trusted, false)
+	assert.Equal(t, false, p.IsTrusted(trusted))
+	assert.Equal(t, true, p.IsBad(trusted))
+}
+
+func TestPeerRequestCounts(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	id := addPeer(t, p, peers.PeerDisconnected)
+	lastSeen, err := p.LastSeen(id)
+	require.NoError(t, err)
+	assert.Equal(t, true, lastSeen.IsZero())
+
+	p.IncrementRequestsReceived(id)
+	p.IncrementRequestsSent(id)
+	p.IncrementRequestsSent(id)
+	received, sent, err := p.RequestCounts(id)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), received)
+	assert.Equal(t, uint64(2), sent)
+	lastSeen, err = p.LastSeen(id)
+	require.NoError(t, err)
+	assert.Equal(t, false, lastSeen.IsZero())
+
+	_, _, err = p.RequestCounts("unknown")
+	assert.ErrorContains(t, peerdata.ErrPeerUnknown.Error(), err)
+}
+
 func TestPeerIPTracker(t *testing.T) {
 	resetCfg := features.InitWithReset(&features.Flags{
 		EnablePeerScorer: false,
@@ -755,6 +848,54 @@ func TestPrunePeers(t *testing.T) {
 	}
 }
 
+func TestPrunePeers_InboundRatio(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		InboundRatio: 0.5,
+		ScorerParams: &scorers.Config{},
+	})
+	assert.Equal(t, 15, p.InboundLimit())
+	for i := 0; i < 10; i++ {
+		createPeer(t, p, nil, network.DirOutbound, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+	}
+	for i := 0; i < 18; i++ {
+		createPeer(t, p, nil, network.DirInbound, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+	}
+	// Below the peer limit, excess inbound peers are kept.
+	assert.Equal(t, true, p.IsAboveInboundLimit())
+	assert.Equal(t, 0, len(p.PeersToPrune()))
+
+	for i := 0; i < 4; i++ {
+		createPeer(t, p, nil, network.DirOutbound, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+	}
+	// Above the peer limit, inbound peers are pruned down to the inbound limit.
+	assert.Equal(t, 3, len(p.PeersToPrune()))
+}
+
+func TestPrunePeers_NoInbound(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		InboundRatio: 0.5,
+		NoInbound:    true,
+		ScorerParams: &scorers.Config{},
+	})
+	assert.Equal(t, 0, p.InboundLimit())
+	for i := 0; i < 10; i++ {
+		createPeer(t, p, nil, network.DirOutbound, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+	}
+	assert.Equal(t, 0, len(p.PeersToPrune()))
+	for i := 0; i < 3; i++ {
+		createPeer(t, p, nil, network.DirInbound, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+	}
+	peersToPrune := p.PeersToPrune()
+	assert.Equal(t, 3, len(peersToPrune))
+	for _, pid := range peersToPrune {
+		dir, err := p.Direction(pid)
+		require.NoError(t, err)
+		assert.Equal(t, network.DirInbound, dir)
+	}
+}
+
 func TestStatus_BestPeer(t *testing.T) {
 	type peerConfig struct {
 		headSlot       types.Slot
@@ -0,0 +1,137 @@
+package p2p
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/io/file"
+)
+
+const peerStorePath = "peerstore.json"
+
+// maxPersistedPeers is the number of best scored peers saved to the data directory on shutdown.
+const maxPersistedPeers = 200
+
+// persistedPeer is a known peer saved across restarts.
+type persistedPeer struct {
+	ID      string  `json:"id"`
+	Address string  `json:"address"`
+	ENR     string  `json:"enr,omitempty"`
+	Score   float64 `json:"score"`
+}
+
+// savePeerStore saves the addresses, ENRs and scores of the best known peers to the data
+// directory, so that they can be dialed right away after a restart. Bad peers are not saved.
+func (s *Service) savePeerStore() error {
+	if s.cfg.DataDir == "" {
+		return nil
+	}
+	scorer := s.peers.Scorers()
+	var known []*persistedPeer
+	for _, pid := range s.peers.All() {
+		if s.peers.IsBad(pid) {
+			continue
+		}
+		addr, err := s.peers.Address(pid)
+		if err != nil || addr == nil {
+			continue
+		}
+		p := &persistedPeer{ID: pid.String(), Address: addr.String(), Score: scorer.Score(pid)}
+		if record, err := s.peers.ENR(pid); err == nil && record != nil {
+			if p.ENR, err = SerializeENR(record); err != nil {
+				continue
+			}
+		}
+		known = append(known, p)
+	}
+	sort.SliceStable(known, func(i, j int) bool {
+		return known[i].Score > known[j].Score
+	})
+	if len(known) > maxPersistedPeers {
+		known = known[:maxPersistedPeers]
+	}
+	enc, err := json.Marshal(known)
+	if err != nil {
+		return errors.Wrap(err, "could not encode peer store")
+	}
+	if err := file.WriteFile(path.Join(s.cfg.DataDir, peerStorePath), enc); err != nil {
+		return errors.Wrap(err, "could not write peer store")
+	}
+	log.WithField("peers", len(known)).Debug("Saved peer store")
+	return nil
+}
+
+// loadPeerStore adds the peers saved by savePeerStore to the peer status tracker along with their
+// scores, and returns their addresses ordered from the best to the worst scored peer.
+func (s *Service) loadPeerStore() ([]ma.Multiaddr, error) {
+	if s.cfg.DataDir == "" {
+		return nil, nil
+	}
+	storePath := path.Join(s.cfg.DataDir, peerStorePath)
+	if !file.FileExists(storePath) {
+		return nil, nil
+	}
+	enc, err := ioutil.ReadFile(storePath) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read peer store")
+	}
+	var known []*persistedPeer
+	if err := json.Unmarshal(enc, &known); err != nil {
+		return nil, errors.Wrap(err, "could not decode peer store")
+	}
+	addrs := make([]ma.Multiaddr, 0, len(known))
+	for _, p := range known {
+		pid, err := peer.Decode(p.ID)
+		if err != nil {
+			log.WithError(err).Debug("Skipping invalid peer in peer store")
+			continue
+		}
+		addr, err := ma.NewMultiaddr(p.Address)
+		if err != nil {
+			log.WithError(err).Debug("Skipping invalid peer address in peer store")
+			continue
+		}
+		// Addresses of peers found through discovery already carry the peer id.
+		if transport, _ := peer.SplitAddr(addr); transport != nil {
+			addr = transport
+		}
+		var record *enr.Record
+		if p.ENR != "" {
+			record, err = deserializeENR(p.ENR)
+			if err != nil {
+				log.WithError(err).Debug("Skipping invalid peer record in peer store")
+				continue
+			}
+		}
+		s.peers.Add(record, pid, addr, network.DirUnknown)
+		s.peers.Scorers().RestoreScore(pid, p.Score)
+		peerAddr, err := ma.NewMultiaddr("/p2p/" + pid.String())
+		if err != nil {
+			continue
+		}
+		addrs = append(addrs, addr.Encapsulate(peerAddr))
+	}
+	return addrs, nil
+}
+
+// deserializeENR is the inverse of SerializeENR.
+func deserializeENR(enrString string) (*enr.Record, error) {
+	enc, err := base64.URLEncoding.DecodeString(enrString)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode ENR string")
+	}
+	record := &enr.Record{}
+	if err := rlp.DecodeBytes(enc, record); err != nil {
+		return nil, errors.Wrap(err, "could not decode ENR record")
+	}
+	return record, nil
+}
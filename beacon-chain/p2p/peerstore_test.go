@@ -0,0 +1,111 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestService_PeerStore_SaveLoad(t *testing.T) {
+	dataDir := t.TempDir()
+	newService := func() *Service {
+		return &Service{
+			cfg: &Config{DataDir: dataDir},
+			peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+				PeerLimit: 30,
+				ScorerParams: &scorers.Config{
+					BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+						Threshold: 1,
+					},
+				},
+			}),
+		}
+	}
+
+	s := newService()
+	// A peer found through discovery, with an ENR and a peer id in its address.
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	record := enode.NewLocalNode(db, key).Node().Record()
+	discovered := newTestPeerID(t)
+	discoveredAddr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/10.0.0.1/tcp/13000/p2p/%s", discovered))
+	require.NoError(t, err)
+	s.peers.Add(record, discovered, discoveredAddr, network.DirOutbound)
+	s.peers.Scorers().GossipScorer().SetGossipData(discovered, 10, 0, nil)
+	discoveredScore := s.peers.Scorers().Score(discovered)
+	require.Equal(t, true, discoveredScore > 0)
+	// An inbound peer without an ENR.
+	inbound := newTestPeerID(t)
+	inboundAddr, err := ma.NewMultiaddr("/ip4/10.0.0.2/tcp/13000")
+	require.NoError(t, err)
+	s.peers.Add(nil, inbound, inboundAddr, network.DirInbound)
+	// A bad peer, which is not saved.
+	bad := newTestPeerID(t)
+	badAddr, err := ma.NewMultiaddr("/ip4/10.0.0.3/tcp/13000")
+	require.NoError(t, err)
+	s.peers.Add(nil, bad, badAddr, network.DirInbound)
+	s.peers.Scorers().BadResponsesScorer().Increment(bad)
+	require.Equal(t, true, s.peers.IsBad(bad))
+
+	require.NoError(t, s.savePeerStore())
+
+	restarted := newService()
+	addrs, err := restarted.loadPeerStore()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(addrs))
+	infos, err := peer.AddrInfosFromP2pAddrs(addrs...)
+	require.NoError(t, err)
+	loaded := make(map[peer.ID]bool)
+	for _, info := range infos {
+		loaded[info.ID] = true
+	}
+	assert.Equal(t, true, loaded[discovered])
+	assert.Equal(t, true, loaded[inbound])
+	assert.Equal(t, false, loaded[bad])
+
+	gotRecord, err := restarted.peers.ENR(discovered)
+	require.NoError(t, err)
+	require.NotNil(t, gotRecord)
+	assert.Equal(t, record.Seq(), gotRecord.Seq())
+	gotAddr, err := restarted.peers.Address(inbound)
+	require.NoError(t, err)
+	assert.Equal(t, inboundAddr.String(), gotAddr.String())
+
+	// Scores are restored until the peers connect again.
+	assert.Equal(t, discoveredScore, restarted.peers.Scorers().Score(discovered))
+	restarted.peers.SetConnectionState(discovered, peers.PeerConnected)
+	assert.Equal(t, float64(0), restarted.peers.Scorers().Score(discovered))
+}
+
+func TestService_PeerStore_NoDataDir(t *testing.T) {
+	s := &Service{
+		cfg:   &Config{},
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{ScorerParams: &scorers.Config{}}),
+	}
+	require.NoError(t, s.savePeerStore())
+	addrs, err := s.loadPeerStore()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(addrs))
+}
+
+func newTestPeerID(t *testing.T) peer.ID {
+	priv, _, err := libp2pcrypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPrivateKey(priv)
+	require.NoError(t, err)
+	return pid
+}
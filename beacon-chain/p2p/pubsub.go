@@ -109,6 +109,7 @@ func (s *Service) SubscribeToTopic(topic string, opts ...pubsub.SubOpt) (*pubsub
 		return nil, err
 	}
 
+	s.scoringConfig.applyTopicScoreParams(topic, scoringParams)
 	if scoringParams != nil {
 		if err := topicHandle.SetScoreParams(scoringParams); err != nil {
 			return nil, err
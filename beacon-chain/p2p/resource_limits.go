@@ -0,0 +1,131 @@
+package p2p
+
+import (
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	"github.com/sirupsen/logrus"
+)
+
+// Labels of the resource limits hit metric.
+const (
+	connsPerPeerLimit       = "conns_per_peer"
+	streamsPerPeerLimit     = "streams_per_peer"
+	streamsPerProtocolLimit = "streams_per_protocol"
+	memoryPerPeerLimit      = "memory_per_peer"
+	memoryPerProtocolLimit  = "memory_per_protocol"
+)
+
+// ResourceLimits bounds the connections, inbound req/resp streams and the memory of these streams
+// the node accepts, so that a peer cannot exhaust the node's streams or memory. A zero limit disables
+// the corresponding bound.
+type ResourceLimits struct {
+	MaxConnsPerPeer       int
+	MaxStreamsPerPeer     int
+	MaxStreamsPerProtocol int
+	// The memory limits are in bytes. Each stream reserves the max size of a req/resp chunk,
+	// which is the most it can allocate when decoding a message.
+	MaxMemoryPerPeer     uint64
+	MaxMemoryPerProtocol uint64
+}
+
+// streamUsage is the number of active inbound streams and the memory they reserve.
+type streamUsage struct {
+	streams int
+	memory  uint64
+}
+
+// streamLimiter tracks the active inbound streams and their memory per peer and per protocol.
+type streamLimiter struct {
+	lock        sync.Mutex
+	limits      ResourceLimits
+	perPeer     map[peer.ID]streamUsage
+	perProtocol map[protocol.ID]streamUsage
+}
+
+func newStreamLimiter(limits ResourceLimits) *streamLimiter {
+	return &streamLimiter{
+		limits:      limits,
+		perPeer:     make(map[peer.ID]streamUsage),
+		perProtocol: make(map[protocol.ID]streamUsage),
+	}
+}
+
+// acquire reserves a stream of the protocol using the given memory for the peer, returning
+// the name of the limit reached if the stream is not allowed.
+func (l *streamLimiter) acquire(pid peer.ID, proto protocol.ID, memory uint64) (string, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	peerUsage, protoUsage := l.perPeer[pid], l.perProtocol[proto]
+	switch {
+	case l.limits.MaxStreamsPerPeer > 0 && peerUsage.streams >= l.limits.MaxStreamsPerPeer:
+		return streamsPerPeerLimit, false
+	case l.limits.MaxStreamsPerProtocol > 0 && protoUsage.streams >= l.limits.MaxStreamsPerProtocol:
+		return streamsPerProtocolLimit, false
+	case l.limits.MaxMemoryPerPeer > 0 && peerUsage.memory+memory > l.limits.MaxMemoryPerPeer:
+		return memoryPerPeerLimit, false
+	case l.limits.MaxMemoryPerProtocol > 0 && protoUsage.memory+memory > l.limits.MaxMemoryPerProtocol:
+		return memoryPerProtocolLimit, false
+	}
+	l.perPeer[pid] = streamUsage{streams: peerUsage.streams + 1, memory: peerUsage.memory + memory}
+	l.perProtocol[proto] = streamUsage{streams: protoUsage.streams + 1, memory: protoUsage.memory + memory}
+	return "", true
+}
+
+// release frees a stream reserved with acquire, with the same memory.
+func (l *streamLimiter) release(pid peer.ID, proto protocol.ID, memory uint64) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if u := l.perPeer[pid]; u.streams <= 1 {
+		delete(l.perPeer, pid)
+	} else {
+		l.perPeer[pid] = streamUsage{streams: u.streams - 1, memory: u.memory - memory}
+	}
+	if u := l.perProtocol[proto]; u.streams <= 1 {
+		delete(l.perProtocol, proto)
+	} else {
+		l.perProtocol[proto] = streamUsage{streams: u.streams - 1, memory: u.memory - memory}
+	}
+}
+
+// limitStreams wraps a stream handler, resetting the inbound streams above the stream or memory limits.
+func (s *Service) limitStreams(proto protocol.ID, handler network.StreamHandler) network.StreamHandler {
+	if s.streamLimiter == nil {
+		return handler
+	}
+	return func(stream network.Stream) {
+		pid := stream.Conn().RemotePeer()
+		// The chunk size grows at the Bellatrix fork, the reserved memory is released as is.
+		memory := encoder.MaxChunkSize
+		limit, ok := s.streamLimiter.acquire(pid, proto, memory)
+		if !ok {
+			resourceLimitHitsCounter.WithLabelValues(limit).Inc()
+			log.WithFields(logrus.Fields{
+				"peer":     pid,
+				"protocol": proto,
+				"limit":    limit,
+			}).Debug("Resetting stream above resource limit")
+			_err := stream.Reset()
+			_ = _err
+			return
+		}
+		defer s.streamLimiter.release(pid, proto, memory)
+		handler(stream)
+	}
+}
+
+// isAtConnLimit checks if the node already has the maximum number of connections with a peer.
+func (s *Service) isAtConnLimit(pid peer.ID) bool {
+	maxConns := s.cfg.ResourceLimits.MaxConnsPerPeer
+	if maxConns <= 0 || s.host == nil {
+		return false
+	}
+	if len(s.host.Network().ConnsToPeer(pid)) < maxConns {
+		return false
+	}
+	resourceLimitHitsCounter.WithLabelValues(connsPerPeerLimit).Inc()
+	return true
+}
@@ -0,0 +1,80 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+)
+
+func TestStreamLimiter(t *testing.T) {
+	l := newStreamLimiter(ResourceLimits{MaxStreamsPerPeer: 2, MaxStreamsPerProtocol: 3})
+	p1 := newTestPeerID(t)
+	p2 := newTestPeerID(t)
+	status := protocol.ID(RPCStatusTopicV1)
+	ping := protocol.ID(RPCPingTopicV1)
+
+	_, ok := l.acquire(p1, status, 0)
+	assert.Equal(t, true, ok)
+	_, ok = l.acquire(p1, ping, 0)
+	assert.Equal(t, true, ok)
+	limit, ok := l.acquire(p1, ping, 0)
+	assert.Equal(t, false, ok)
+	assert.Equal(t, streamsPerPeerLimit, limit)
+
+	_, ok = l.acquire(p2, status, 0)
+	assert.Equal(t, true, ok)
+	_, ok = l.acquire(p2, status, 0)
+	assert.Equal(t, true, ok)
+	p3 := newTestPeerID(t)
+	limit, ok = l.acquire(p3, status, 0)
+	assert.Equal(t, false, ok)
+	assert.Equal(t, streamsPerProtocolLimit, limit)
+
+	// Released streams free both the peer and the protocol slots.
+	l.release(p1, status, 0)
+	_, ok = l.acquire(p3, status, 0)
+	assert.Equal(t, true, ok)
+	l.release(p1, ping, 0)
+	assert.Equal(t, 0, l.perPeer[p1].streams)
+	_, ok = l.perPeer[p1]
+	assert.Equal(t, false, ok)
+}
+
+func TestStreamLimiter_Memory(t *testing.T) {
+	l := newStreamLimiter(ResourceLimits{MaxMemoryPerPeer: 100, MaxMemoryPerProtocol: 150})
+	p1 := newTestPeerID(t)
+	p2 := newTestPeerID(t)
+	status := protocol.ID(RPCStatusTopicV1)
+	ping := protocol.ID(RPCPingTopicV1)
+
+	_, ok := l.acquire(p1, status, 60)
+	assert.Equal(t, true, ok)
+	limit, ok := l.acquire(p1, ping, 60)
+	assert.Equal(t, false, ok)
+	assert.Equal(t, memoryPerPeerLimit, limit)
+	_, ok = l.acquire(p1, ping, 40)
+	assert.Equal(t, true, ok)
+
+	_, ok = l.acquire(p2, status, 60)
+	assert.Equal(t, true, ok)
+	limit, ok = l.acquire(p2, status, 60)
+	assert.Equal(t, false, ok)
+	assert.Equal(t, memoryPerProtocolLimit, limit)
+
+	// Released streams free the memory they reserved.
+	l.release(p1, status, 60)
+	assert.Equal(t, uint64(40), l.perPeer[p1].memory)
+	assert.Equal(t, uint64(60), l.perProtocol[status].memory)
+	_, ok = l.acquire(p2, status, 60)
+	assert.Equal(t, true, ok)
+}
+
+func TestStreamLimiter_NoLimits(t *testing.T) {
+	l := newStreamLimiter(ResourceLimits{})
+	pid := newTestPeerID(t)
+	for i := 0; i < 1000; i++ {
+		_, ok := l.acquire(pid, protocol.ID(RPCStatusTopicV1), 1<<20)
+		assert.Equal(t, true, ok)
+	}
+}
@@ -41,6 +41,7 @@ func (s *Service) Send(ctx context.Context, message interface{}, baseTopic strin
 		tracing.AnnotateError(span, err)
 		return nil, err
 	}
+	s.peers.IncrementRequestsSent(pid)
 	// do not encode anything if we are sending a metadata request
 	if baseTopic != RPCMetaDataTopicV1 && baseTopic != RPCMetaDataTopicV2 {
 		castedMsg, ok := message.(ssz.Marshaler)
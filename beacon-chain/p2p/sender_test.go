@@ -22,8 +22,9 @@ func TestService_Send(t *testing.T) {
 	p1.Connect(p2)
 
 	svc := &Service{
-		host: p1.BHost,
-		cfg:  &Config{},
+		host:  p1.BHost,
+		cfg:   &Config{},
+		peers: p1.Peers(),
 	}
 
 	msg := &ethpb.Fork{
@@ -66,6 +66,7 @@ type Service struct {
 	cancel                context.CancelFunc
 	cfg                   *Config
 	peers                 *peers.Status
+	staticPeers           staticPeers
 	addrFilter            *multiaddr.Filters
 	ipLimiter             *leakybucket.Collector
 	privKey               *ecdsa.PrivateKey
@@ -84,12 +85,19 @@ type Service struct {
 	genesisTime           time.Time
 	genesisValidatorsRoot []byte
 	activeValidatorCount  uint64
+	scoringConfig         *gossipScoringConfig
+	streamLimiter         *streamLimiter
+	banList               *banList
+	enrEntries            []enr.Entry
 }
 
 // NewService initializes a new p2p service compatible with shared.Service interface. No
 // connections are made until the Start function is called during the service registry startup.
 func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 	var err error
+	if cfg.InboundRatio < 0 || cfg.InboundRatio > 1 {
+		return nil, errors.Errorf("invalid inbound ratio %v, expected a value between 0 and 1", cfg.InboundRatio)
+	}
 	ctx, cancel := context.WithCancel(ctx)
 	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop().
 
@@ -123,7 +131,28 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		log.WithError(err).Error("Failed to create address filter")
 		return nil, err
 	}
+	if s.cfg.MaxMessageSize != 0 {
+		encoder.SetMaxMessageSize(s.cfg.MaxMessageSize)
+	}
 	s.ipLimiter = leakybucket.NewCollector(ipLimit, ipBurst, true /* deleteEmptyBuckets */)
+	s.streamLimiter = newStreamLimiter(s.cfg.ResourceLimits)
+	s.banList, err = loadBanList(s.cfg.DataDir)
+	if err != nil {
+		log.WithError(err).Error("Failed to load ban list")
+		return nil, err
+	}
+	s.enrEntries, err = parseENREntries(s.cfg.ENREntries)
+	if err != nil {
+		log.WithError(err).Error("Failed to parse custom ENR entries")
+		return nil, err
+	}
+	if s.cfg.GossipScoringConfigFile != "" {
+		s.scoringConfig, err = loadGossipScoringConfig(s.cfg.GossipScoringConfigFile)
+		if err != nil {
+			log.WithError(err).Error("Failed to load gossip scoring config")
+			return nil, err
+		}
+	}
 
 	opts := s.buildOptions(ipAddr, s.privKey)
 	h, err := libp2p.New(opts...)
@@ -134,6 +163,8 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 
 	s.host = h
 	s.host.RemoveStreamHandler(identify.IDDelta)
+	scoreParams, scoreThresholds := peerScoringParams()
+	s.scoringConfig.applyPeerScoreParams(scoreParams, scoreThresholds)
 	// Gossipsub registration is done before we add in any new peers
 	// due to libp2p's gossipsub implementation not taking into
 	// account previously added peers when creating the gossipsub
@@ -147,7 +178,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		pubsub.WithSubscriptionFilter(s),
 		pubsub.WithPeerOutboundQueueSize(pubsubQueueSize),
 		pubsub.WithValidateQueueSize(pubsubQueueSize),
-		pubsub.WithPeerScore(peerScoringParams()),
+		pubsub.WithPeerScore(scoreParams, scoreThresholds),
 		pubsub.WithPeerScoreInspect(s.peerInspector, time.Minute),
 		pubsub.WithGossipSubParams(pubsubGossipParam()),
 	}
@@ -165,7 +196,9 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 	s.pubsub = gs
 
 	s.peers = peers.NewStatus(ctx, &peers.StatusConfig{
-		PeerLimit: int(s.cfg.MaxPeers),
+		PeerLimit:    int(s.cfg.MaxPeers),
+		InboundRatio: s.cfg.InboundRatio,
+		NoInbound:    s.cfg.NoInbound,
 		ScorerParams: &scorers.Config{
 			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
 				Threshold:     maxBadResponses,
@@ -228,8 +261,27 @@ func (s *Service) Start() {
 		if err != nil {
 			log.Errorf("Could not connect to static peer: %v", err)
 		}
+		infos, err := peer.AddrInfosFromP2pAddrs(addrs...)
+		if err != nil {
+			log.WithError(err).Error("Could not get static peer address info")
+		}
+		for _, info := range infos {
+			s.staticPeers.add(info)
+		}
 		s.connectWithAllPeers(addrs)
 	}
+	// Reconnect to the peers known before the last restart.
+	knownAddrs, err := s.loadPeerStore()
+	if err != nil {
+		log.WithError(err).Error("Could not load peer store")
+	}
+	if len(knownAddrs) > int(s.cfg.MaxPeers) {
+		knownAddrs = knownAddrs[:s.cfg.MaxPeers]
+	}
+	if len(knownAddrs) > 0 {
+		log.WithField("peers", len(knownAddrs)).Info("Reconnecting to peers from the peer store")
+		s.connectWithAllPeers(knownAddrs)
+	}
 	// Initialize metadata according to the
 	// current epoch.
 	s.RefreshENR()
@@ -242,6 +294,7 @@ func (s *Service) Start() {
 	async.RunEvery(s.ctx, params.BeaconNetworkConfig().TtfbTimeout, func() {
 		ensurePeerConnections(s.ctx, s.host, peersToWatch...)
 	})
+	async.RunEvery(s.ctx, params.BeaconNetworkConfig().TtfbTimeout, s.ensureStaticPeerConnections)
 	async.RunEvery(s.ctx, 30*time.Minute, s.Peers().Prune)
 	async.RunEvery(s.ctx, params.BeaconNetworkConfig().RespTimeout, s.updateMetrics)
 	async.RunEvery(s.ctx, refreshRate, func() {
@@ -265,6 +318,11 @@ func (s *Service) Start() {
 		logExternalIPAddr(s.host.ID(), p2pHostAddress, p2pTCPPort)
 		verifyConnectivity(p2pHostAddress, p2pTCPPort, "tcp")
 	}
+	p2pHostAddressIPv6 := s.cfg.HostAddressIPv6
+	if p2pHostAddressIPv6 != "" {
+		logExternalIPAddr(s.host.ID(), p2pHostAddressIPv6, p2pTCPPort)
+		verifyConnectivity(p2pHostAddressIPv6, p2pTCPPort, "tcp")
+	}
 
 	p2pHostDNS := s.cfg.HostDNS
 	if p2pHostDNS != "" {
@@ -276,6 +334,11 @@ func (s *Service) Start() {
 // Stop the p2p service and terminate all peer connections.
 func (s *Service) Stop() error {
 	defer s.cancel()
+	if s.started {
+		if err := s.savePeerStore(); err != nil {
+			log.WithError(err).Error("Could not save peer store")
+		}
+	}
 	s.started = false
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
@@ -325,7 +388,7 @@ func (s *Service) Host() host.Host {
 // SetStreamHandler sets the protocol handler on the p2p host multiplexer.
 // This method is a pass through to libp2pcore.Host.SetStreamHandler.
 func (s *Service) SetStreamHandler(topic string, handler network.StreamHandler) {
-	s.host.SetStreamHandler(protocol.ID(topic), handler)
+	s.host.SetStreamHandler(protocol.ID(topic), s.limitStreams(protocol.ID(topic), handler))
 }
 
 // PeerID returns the Peer ID of the local peer.
@@ -0,0 +1,100 @@
+package p2p
+
+import (
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+)
+
+// staticPeers are the peers the node stays connected with, reconnecting to them whenever their
+// connection drops. They are configured with the peer flag and managed at runtime by the node API.
+type staticPeers struct {
+	lock  sync.RWMutex
+	peers map[peer.ID]peer.AddrInfo
+}
+
+func (sp *staticPeers) add(info peer.AddrInfo) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	if sp.peers == nil {
+		sp.peers = make(map[peer.ID]peer.AddrInfo)
+	}
+	sp.peers[info.ID] = info
+}
+
+func (sp *staticPeers) remove(pid peer.ID) bool {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	_, ok := sp.peers[pid]
+	delete(sp.peers, pid)
+	return ok
+}
+
+func (sp *staticPeers) all() []peer.AddrInfo {
+	sp.lock.RLock()
+	defer sp.lock.RUnlock()
+	infos := make([]peer.AddrInfo, 0, len(sp.peers))
+	for _, info := range sp.peers {
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// AddStaticPeer adds a static peer from its multiaddr or enr, and dials it.
+func (s *Service) AddStaticPeer(addr string) (peer.AddrInfo, error) {
+	addrs, err := peersFromStringAddrs([]string{addr})
+	if err != nil {
+		return peer.AddrInfo{}, err
+	}
+	infos, err := peer.AddrInfosFromP2pAddrs(addrs...)
+	if err != nil {
+		return peer.AddrInfo{}, errors.Wrap(err, "could not get peer address info")
+	}
+	if len(infos) != 1 {
+		return peer.AddrInfo{}, errors.New("address must contain the peer id")
+	}
+	s.staticPeers.add(infos[0])
+	s.connectWithAllPeers(addrs)
+	return infos[0], nil
+}
+
+// RemoveStaticPeer removes a static peer, returning false if the peer is not static. The node
+// stays connected with the peer until it is pruned.
+func (s *Service) RemoveStaticPeer(pid peer.ID) bool {
+	return s.staticPeers.remove(pid)
+}
+
+// StaticPeers returns the static peers.
+func (s *Service) StaticPeers() []peer.AddrInfo {
+	return s.staticPeers.all()
+}
+
+// AddTrustedPeer marks a peer as trusted, which exempts it from peer scoring and pruning.
+func (s *Service) AddTrustedPeer(pid peer.ID) {
+	s.peers.SetTrusted(pid, true)
+}
+
+// RemoveTrustedPeer removes the trusted mark of a peer.
+func (s *Service) RemoveTrustedPeer(pid peer.ID) {
+	s.peers.SetTrusted(pid, false)
+}
+
+// TrustedPeers returns the trusted peers.
+func (s *Service) TrustedPeers() []peer.ID {
+	return s.peers.Trusted()
+}
+
+// ensureStaticPeerConnections reconnects to the static peers the node is not connected with.
+func (s *Service) ensureStaticPeerConnections() {
+	for _, info := range s.staticPeers.all() {
+		if len(s.host.Network().ConnsToPeer(info.ID)) > 0 {
+			continue
+		}
+		go func(info peer.AddrInfo) {
+			if err := connectWithTimeout(s.ctx, s.host, &info); err != nil {
+				log.WithField("peer", info.ID).WithError(err).Debug("Failed to reconnect to static peer")
+			}
+		}(info)
+	}
+}
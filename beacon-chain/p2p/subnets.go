@@ -4,14 +4,18 @@ import (
 	"context"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	mathutil "github.com/prysmaticlabs/prysm/math"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 
 	"github.com/prysmaticlabs/prysm/config/params"
@@ -69,7 +73,7 @@ func (s *Service) FindPeersWithSubnet(ctx context.Context, topic string,
 		}
 		nodes := enode.ReadNodes(iterator, int(params.BeaconNetworkConfig().MinimumPeersInSubnetSearch))
 		for _, node := range nodes {
-			info, _, err := convertToAddrInfo(node)
+			info, _, err := convertToAddrInfo(node, s.cfg.PreferIPv6)
 			if err != nil {
 				continue
 			}
@@ -88,6 +92,53 @@ func (s *Service) FindPeersWithSubnet(ctx context.Context, topic string,
 	return true, nil
 }
 
+// FindAggregatorSubnetPeers searches the network for peers on the attestation subnet of an
+// aggregator duty if the node has none, so that the aggregator receives the attestations of its
+// committee. The search is filtered by the attestation subnet bitfield of the discovered ENRs,
+// and runs in the background until the end of the duty slot.
+func (s *Service) FindAggregatorSubnetPeers(subnet uint64, slot types.Slot) {
+	forkDigest, err := s.currentForkDigest()
+	if err != nil {
+		log.WithError(err).Debug("Could not search for aggregator subnet peers")
+		return
+	}
+	deadline := slots.StartTime(uint64(s.genesisTime.Unix()), slot+1)
+	if time.Now().After(deadline) {
+		return
+	}
+	topic := attestationToTopic(subnet, forkDigest)
+	s.subnetLocker(subnet).RLock()
+	hasPeer := s.hasPeerWithSubnet(topic)
+	s.subnetLocker(subnet).RUnlock()
+	if hasPeer {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithDeadline(s.ctx, deadline)
+		defer cancel()
+		s.subnetLocker(subnet).Lock()
+		defer s.subnetLocker(subnet).Unlock()
+		// Another search may have found a peer while waiting for the lock.
+		if s.hasPeerWithSubnet(topic) {
+			return
+		}
+		aggregatorSubnetSearchAttempts.Inc()
+		log.WithFields(logrus.Fields{
+			"subnet": subnet,
+			"slot":   slot,
+		}).Debug("No peers found on aggregator subnet, searching network")
+		ok, err := s.FindPeersWithSubnet(ctx, topic, subnet, 1)
+		if err != nil {
+			log.WithError(err).WithField("subnet", subnet).Debug("Could not find aggregator subnet peers")
+			return
+		}
+		if ok {
+			savedAggregatorSubnetSearches.Inc()
+		}
+	}()
+}
+
 // returns a method with filters peers specifically for a particular attestation subnet.
 func (s *Service) filterPeerForAttSubnet(index uint64) func(node *enode.Node) bool {
 	return func(node *enode.Node) bool {
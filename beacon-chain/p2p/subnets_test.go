@@ -4,19 +4,25 @@ import (
 	"context"
 	"crypto/rand"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prysmaticlabs/go-bitfield"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/config/params"
 	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
 	"github.com/prysmaticlabs/prysm/testing/assert"
@@ -490,3 +496,50 @@ func Test_SyncSubnets(t *testing.T) {
 		})
 	}
 }
+
+func TestFindAggregatorSubnetPeers(t *testing.T) {
+	gFlags := new(flags.GlobalFlags)
+	gFlags.MinimumPeersPerSubnet = 1
+	flags.Init(gFlags)
+	defer flags.Init(new(flags.GlobalFlags))
+
+	h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, h.Close())
+	}()
+	ps, err := pubsub.NewGossipSub(context.Background(), h)
+	require.NoError(t, err)
+	s := &Service{
+		ctx:         context.Background(),
+		cfg:         &Config{},
+		host:        h,
+		pubsub:      ps,
+		subnetsLock: make(map[uint64]*sync.RWMutex),
+	}
+
+	// No search is made before the chain is initialized.
+	attempts := testutil.ToFloat64(aggregatorSubnetSearchAttempts)
+	s.FindAggregatorSubnetPeers(1, 0)
+	assert.Equal(t, attempts, testutil.ToFloat64(aggregatorSubnetSearchAttempts))
+
+	s.genesisTime = time.Now().Add(-10 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	s.genesisValidatorsRoot = make([]byte, 32)
+	// No search is made for a duty slot that has already ended.
+	s.FindAggregatorSubnetPeers(1, 5)
+	assert.Equal(t, attempts, testutil.ToFloat64(aggregatorSubnetSearchAttempts))
+
+	// A search is made for an upcoming duty without peers on its subnet.
+	s.FindAggregatorSubnetPeers(1, 20)
+	require.NoError(t, waitForCount(func() float64 { return testutil.ToFloat64(aggregatorSubnetSearchAttempts) }, attempts+1))
+}
+
+func waitForCount(count func() float64, want float64) error {
+	for i := 0; i < 100; i++ {
+		if count() == want {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return errors.Errorf("count is %f, wanted %f", count(), want)
+}
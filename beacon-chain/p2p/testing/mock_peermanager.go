@@ -8,6 +8,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/multiformats/go-multiaddr"
+	types "github.com/prysmaticlabs/eth2-types"
 )
 
 // MockPeerManager is mock of the PeerManager interface.
@@ -55,5 +56,8 @@ func (_ MockPeerManager) FindPeersWithSubnet(_ context.Context, _ string, _ uint
 	return true, nil
 }
 
+// FindAggregatorSubnetPeers .
+func (_ MockPeerManager) FindAggregatorSubnetPeers(_ uint64, _ types.Slot) {}
+
 // AddPingMethod .
 func (_ MockPeerManager) AddPingMethod(_ func(ctx context.Context, id peer.ID) error) {}
@@ -21,6 +21,7 @@ import (
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	swarmt "github.com/libp2p/go-libp2p-swarm/testing"
 	"github.com/multiformats/go-multiaddr"
+	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
@@ -311,6 +312,7 @@ func (p *TestP2P) Send(ctx context.Context, msg interface{}, topic string, pid p
 	if err != nil {
 		return nil, err
 	}
+	p.peers.IncrementRequestsSent(pid)
 
 	if topic != metatadataV1Topic && topic != metatadataV2Topic {
 		castedMsg, ok := msg.(ssz.Marshaler)
@@ -353,6 +355,9 @@ func (_ *TestP2P) FindPeersWithSubnet(_ context.Context, _ string, _ uint64, _ i
 	return false, nil
 }
 
+// FindAggregatorSubnetPeers mocks the p2p func.
+func (_ *TestP2P) FindAggregatorSubnetPeers(_ uint64, _ types.Slot) {}
+
 // RefreshENR mocks the p2p func.
 func (_ *TestP2P) RefreshENR() {}
 
@@ -0,0 +1,109 @@
+package powchain
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/container/trie"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// Initializes the deposit trie from the persisted deposit tree snapshot if the trie does not contain
+// the deposits of the snapshot yet, as is the case for checkpoint synced nodes, so that deposit logs
+// are processed from the execution block of the snapshot instead of from the deployment of the
+// deposit contract. The finalized deposits cache is always rebuilt from the snapshot, as the deposit
+// containers of such nodes do not include the deposits of the snapshot.
+func (s *Service) initializeFromDepositSnapshot(ctx context.Context, snapshot *trie.DepositTreeSnapshot) error {
+	if uint64(s.depositTrie.NumOfItems()) < snapshot.DepositCount {
+		depositTrie, err := trie.TrieFromSnapshot(snapshot, params.BeaconConfig().DepositContractTreeDepth)
+		if err != nil {
+			return err
+		}
+		s.depositTrie = depositTrie
+		s.lastReceivedMerkleIndex = int64(snapshot.DepositCount) - 1
+		if s.latestEth1Data.LastRequestedBlock < snapshot.ExecutionBlockHeight {
+			s.latestEth1Data.LastRequestedBlock = snapshot.ExecutionBlockHeight
+		}
+		log.WithFields(logrus.Fields{
+			"depositCount": snapshot.DepositCount,
+			"eth1Block":    snapshot.ExecutionBlockHeight,
+		}).Info("Initialized deposit trie from deposit snapshot")
+	}
+	return s.cfg.depositCache.InsertFinalizedDepositsSnapshot(ctx, snapshot)
+}
+
+// Persists a snapshot of the deposit trie up to the deposits of the latest finalized state, so that
+// the node, or nodes checkpoint syncing from it, can resume processing deposit logs from the finalized
+// execution block.
+func (s *Service) updateDepositSnapshot(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "powchain.updateDepositSnapshot")
+	defer span.End()
+
+	if !s.chainStartData.Chainstarted || s.cfg.stateGen == nil {
+		return nil
+	}
+	c, err := s.cfg.beaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	fRoot := bytesutil.ToBytes32(c.Root)
+	if fRoot == params.BeaconConfig().ZeroHash || fRoot == s.lastSnapshotRoot {
+		return nil
+	}
+	fState, err := s.cfg.stateGen.StateByRoot(ctx, fRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve finalized state")
+	}
+	if fState == nil || fState.IsNil() {
+		return errors.Errorf("finalized state with root %#x is nil", fRoot)
+	}
+	eth1Data := fState.Eth1Data()
+	if eth1Data == nil || eth1Data.DepositCount == 0 {
+		s.lastSnapshotRoot = fRoot
+		return nil
+	}
+	blockHash := bytesutil.ToBytes32(eth1Data.BlockHash)
+	current, err := s.cfg.beaconDB.DepositSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	if current != nil && (current.DepositCount > eth1Data.DepositCount || current.ExecutionBlockHash == blockHash) {
+		s.lastSnapshotRoot = fRoot
+		return nil
+	}
+	// Retry once the deposit logs up to the finalized deposits have been processed.
+	if uint64(s.depositTrie.NumOfItems()) < eth1Data.DepositCount {
+		return nil
+	}
+	exists, height, err := s.BlockExists(ctx, common.Hash(blockHash))
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve finalized eth1 block")
+	}
+	if !exists {
+		return errors.Errorf("finalized eth1 block %#x not found", blockHash)
+	}
+	snapshot, err := s.depositTrie.Snapshot(eth1Data.DepositCount, blockHash, height.Uint64())
+	if err != nil {
+		return err
+	}
+	if snapshot.DepositRoot != bytesutil.ToBytes32(eth1Data.DepositRoot) {
+		return errors.Errorf(
+			"deposit snapshot root %#x does not match the finalized deposit root %#x",
+			snapshot.DepositRoot,
+			eth1Data.DepositRoot,
+		)
+	}
+	if err := s.cfg.beaconDB.SaveDepositSnapshot(ctx, snapshot); err != nil {
+		return errors.Wrap(err, "could not save deposit snapshot")
+	}
+	s.lastSnapshotRoot = fRoot
+	log.WithFields(logrus.Fields{
+		"depositCount": snapshot.DepositCount,
+		"eth1Block":    snapshot.ExecutionBlockHeight,
+	}).Debug("Saved deposit snapshot")
+	return nil
+}
@@ -0,0 +1,149 @@
+package powchain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/container/trie"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func depositSnapshotTestItems() [][]byte {
+	return [][]byte{{'a'}, {'b'}, {'c'}}
+}
+
+// Saves a block to use as the finalized checkpoint and returns its root.
+func saveFinalizedTestBlock(t *testing.T, ctx context.Context, s *Service) [32]byte {
+	blk := util.NewBeaconBlock()
+	require.NoError(t, s.cfg.beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(blk)))
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, s.cfg.beaconDB.SaveGenesisBlockRoot(ctx, root))
+	return root
+}
+
+func TestService_InitializeFromDepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbutil.SetupDB(t)
+	depositCache, err := depositcache.New()
+	require.NoError(t, err)
+	s, err := NewService(ctx,
+		WithHttpEndpoints([]string{endpoint}),
+		WithDatabase(beaconDB),
+		WithDepositCache(depositCache),
+	)
+	require.NoError(t, err)
+
+	full, err := trie.GenerateTrieFromItems(depositSnapshotTestItems(), params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	snapshot, err := full.Snapshot(2, [32]byte{'a'}, 50)
+	require.NoError(t, err)
+
+	require.NoError(t, s.initializeFromDepositSnapshot(ctx, snapshot))
+	assert.Equal(t, snapshot.DepositRoot, s.depositTrie.HashTreeRoot())
+	assert.Equal(t, 2, s.depositTrie.NumOfItems())
+	assert.Equal(t, int64(1), s.lastReceivedMerkleIndex)
+	assert.Equal(t, uint64(50), s.latestEth1Data.LastRequestedBlock)
+	assert.Equal(t, int64(1), depositCache.FinalizedDeposits(ctx).MerkleTrieIndex)
+
+	// New deposits are appended on top of the snapshot.
+	require.NoError(t, s.depositTrie.Insert([]byte{'c'}, 2))
+	assert.Equal(t, full.HashTreeRoot(), s.depositTrie.HashTreeRoot())
+
+	// A deposit trie already containing the snapshot deposits is kept.
+	require.NoError(t, s.initializeFromDepositSnapshot(ctx, snapshot))
+	assert.Equal(t, 3, s.depositTrie.NumOfItems())
+}
+
+func TestService_UpdateDepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbutil.SetupDB(t)
+	depositCache, err := depositcache.New()
+	require.NoError(t, err)
+	stateGen := stategen.New(beaconDB)
+	s, err := NewService(ctx,
+		WithHttpEndpoints([]string{endpoint}),
+		WithDatabase(beaconDB),
+		WithDepositCache(depositCache),
+		WithStateGen(stateGen),
+	)
+	require.NoError(t, err)
+	s.chainStartData.Chainstarted = true
+	for i, item := range depositSnapshotTestItems() {
+		require.NoError(t, s.depositTrie.Insert(item, i))
+	}
+
+	header := &gethTypes.Header{Number: big.NewInt(100)}
+	require.NoError(t, s.headerCache.AddHeader(header))
+	finalized, err := trie.GenerateTrieFromItems(depositSnapshotTestItems()[:2], params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	depositRoot := finalized.HashTreeRoot()
+
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetEth1Data(&ethpb.Eth1Data{
+		DepositRoot:  depositRoot[:],
+		DepositCount: 2,
+		BlockHash:    header.Hash().Bytes(),
+	}))
+	root := saveFinalizedTestBlock(t, ctx, s)
+	require.NoError(t, stateGen.SaveState(ctx, root, st))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Root: root[:]}))
+
+	require.NoError(t, s.updateDepositSnapshot(ctx))
+	snapshot, err := beaconDB.DepositSnapshot(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	assert.Equal(t, uint64(2), snapshot.DepositCount)
+	assert.Equal(t, depositRoot, snapshot.DepositRoot)
+	assert.Equal(t, [32]byte(header.Hash()), snapshot.ExecutionBlockHash)
+	assert.Equal(t, uint64(100), snapshot.ExecutionBlockHeight)
+	assert.Equal(t, root, s.lastSnapshotRoot)
+}
+
+func TestService_UpdateDepositSnapshot_RootMismatch(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbutil.SetupDB(t)
+	depositCache, err := depositcache.New()
+	require.NoError(t, err)
+	stateGen := stategen.New(beaconDB)
+	s, err := NewService(ctx,
+		WithHttpEndpoints([]string{endpoint}),
+		WithDatabase(beaconDB),
+		WithDepositCache(depositCache),
+		WithStateGen(stateGen),
+	)
+	require.NoError(t, err)
+	s.chainStartData.Chainstarted = true
+	for i, item := range depositSnapshotTestItems() {
+		require.NoError(t, s.depositTrie.Insert(item, i))
+	}
+
+	header := &gethTypes.Header{Number: big.NewInt(100)}
+	require.NoError(t, s.headerCache.AddHeader(header))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetEth1Data(&ethpb.Eth1Data{
+		DepositRoot:  make([]byte, 32),
+		DepositCount: 2,
+		BlockHash:    header.Hash().Bytes(),
+	}))
+	root := saveFinalizedTestBlock(t, ctx, s)
+	require.NoError(t, stateGen.SaveState(ctx, root, st))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Root: root[:]}))
+
+	require.ErrorContains(t, "does not match the finalized deposit root", s.updateDepositSnapshot(ctx))
+	snapshot, err := beaconDB.DepositSnapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, (*trie.DepositTreeSnapshot)(nil), snapshot)
+}
@@ -0,0 +1,122 @@
+package powchain
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/network"
+)
+
+const (
+	// Weight of the outcome of the latest request in the error rate of an endpoint.
+	endpointErrorRateWeight = 0.2
+	// Error rate from which an endpoint is considered unhealthy.
+	maxEndpointErrorRate = 0.5
+	// Number of blocks the head of an endpoint may lag behind the highest head seen on any endpoint
+	// before it is considered unhealthy. Idle endpoints are only probed every endpointProbePeriod,
+	// so their heads are expected to lag a few blocks behind the head of the current endpoint.
+	maxEndpointHeadLag = 10
+)
+
+// Interval at which the heads of the endpoints not currently in use are probed.
+var endpointProbePeriod = time.Minute
+
+type endpointStats struct {
+	errorRate float64
+	head      uint64
+	probed    time.Time
+}
+
+// endpointHealth scores the eth1 endpoints on the exponentially weighted rate of their failed
+// requests and on how far their heads lag behind the highest head seen on any endpoint.
+type endpointHealth struct {
+	lock        sync.RWMutex
+	stats       map[string]*endpointStats
+	highestHead uint64
+}
+
+func newEndpointHealth() *endpointHealth {
+	return &endpointHealth{
+		stats: make(map[string]*endpointStats),
+	}
+}
+
+// Must be called with the lock held.
+func (h *endpointHealth) endpointStats(endpoint network.Endpoint) *endpointStats {
+	st, ok := h.stats[endpoint.Url]
+	if !ok {
+		st = &endpointStats{}
+		h.stats[endpoint.Url] = st
+	}
+	return st
+}
+
+// recordSuccess of a request to the endpoint, along with its head if known.
+func (h *endpointHealth) recordSuccess(endpoint network.Endpoint, head uint64) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	st := h.endpointStats(endpoint)
+	st.errorRate *= 1 - endpointErrorRateWeight
+	if head > 0 {
+		st.head = head
+		if head > h.highestHead {
+			h.highestHead = head
+		}
+	}
+}
+
+// recordFailure of a request to the endpoint.
+func (h *endpointHealth) recordFailure(endpoint network.Endpoint) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	st := h.endpointStats(endpoint)
+	st.errorRate = st.errorRate*(1-endpointErrorRateWeight) + endpointErrorRateWeight
+}
+
+// markProbed records the time the endpoint was probed, and returns whether it was due for a probe.
+func (h *endpointHealth) markProbed(endpoint network.Endpoint, now time.Time) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	st := h.endpointStats(endpoint)
+	if now.Sub(st.probed) < endpointProbePeriod {
+		return false
+	}
+	st.probed = now
+	return true
+}
+
+// score of the endpoint, lower is better. Endpoints scoring 1 or more are unhealthy.
+func (h *endpointHealth) score(endpoint network.Endpoint) float64 {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	st, ok := h.stats[endpoint.Url]
+	if !ok {
+		return 0
+	}
+	score := st.errorRate / maxEndpointErrorRate
+	if st.head > 0 && h.highestHead > st.head {
+		score += float64(h.highestHead-st.head) / maxEndpointHeadLag
+	}
+	return score
+}
+
+func (h *endpointHealth) healthy(endpoint network.Endpoint) bool {
+	return h.score(endpoint) < 1
+}
+
+// preferred endpoint to use: the first healthy endpoint in the configured order, so that the
+// primary endpoint is used whenever it is healthy, or the best scoring endpoint if none is.
+func (h *endpointHealth) preferred(endpoints []network.Endpoint) network.Endpoint {
+	best := endpoints[0]
+	bestScore := h.score(best)
+	for _, endpoint := range endpoints {
+		score := h.score(endpoint)
+		if score < 1 {
+			return endpoint
+		}
+		if score < bestScore {
+			best, bestScore = endpoint, score
+		}
+	}
+	return best
+}
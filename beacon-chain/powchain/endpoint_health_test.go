@@ -0,0 +1,88 @@
+package powchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/contracts/deposit/mock"
+	"github.com/prysmaticlabs/prysm/network"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestEndpointHealth_Score(t *testing.T) {
+	h := newEndpointHealth()
+	a := network.Endpoint{Url: "A"}
+	b := network.Endpoint{Url: "B"}
+	assert.Equal(t, 0.0, h.score(a))
+
+	h.recordFailure(a)
+	h.recordFailure(a)
+	assert.Equal(t, true, h.healthy(a))
+	h.recordFailure(a)
+	h.recordFailure(a)
+	assert.Equal(t, false, h.healthy(a), "Endpoint failing most requests should be unhealthy")
+	for i := 0; i < 3; i++ {
+		h.recordSuccess(a, 0)
+	}
+	assert.Equal(t, true, h.healthy(a), "Endpoint should recover after successful requests")
+
+	h.recordSuccess(a, 100)
+	h.recordSuccess(b, 100+maxEndpointHeadLag/4)
+	assert.Equal(t, true, h.healthy(a))
+	h.recordSuccess(b, 100+maxEndpointHeadLag)
+	assert.Equal(t, false, h.healthy(a), "Endpoint lagging behind should be unhealthy")
+	assert.Equal(t, true, h.healthy(b))
+}
+
+func TestEndpointHealth_Preferred(t *testing.T) {
+	h := newEndpointHealth()
+	endpoints := []network.Endpoint{{Url: "A"}, {Url: "B"}, {Url: "C"}}
+	assert.Equal(t, "A", h.preferred(endpoints).Url)
+
+	for i := 0; i < 5; i++ {
+		h.recordFailure(endpoints[0])
+	}
+	assert.Equal(t, "B", h.preferred(endpoints).Url)
+
+	for i := 0; i < 5; i++ {
+		h.recordFailure(endpoints[1])
+		h.recordFailure(endpoints[2])
+	}
+	h.recordFailure(endpoints[0])
+	h.recordFailure(endpoints[1])
+	assert.Equal(t, "C", h.preferred(endpoints).Url, "Best scoring endpoint should be preferred if none is healthy")
+}
+
+func TestEndpointHealth_MarkProbed(t *testing.T) {
+	h := newEndpointHealth()
+	a := network.Endpoint{Url: "A"}
+	now := time.Now()
+	assert.Equal(t, true, h.markProbed(a, now))
+	assert.Equal(t, false, h.markProbed(a, now.Add(endpointProbePeriod/2)))
+	assert.Equal(t, true, h.markProbed(a, now.Add(endpointProbePeriod)))
+}
+
+func TestService_FallbackToHealthiestEndpoint(t *testing.T) {
+	testAcc, err := mock.Setup()
+	require.NoError(t, err, "Unable to set up simulated backend")
+	s, err := NewService(context.Background(),
+		WithHttpEndpoints([]string{"A", "B", "C"}),
+		WithDepositContractAddress(testAcc.ContractAddr),
+		WithDatabase(dbutil.SetupDB(t)),
+	)
+	require.NoError(t, err)
+
+	// B keeps failing, so falling back from A skips it.
+	for i := 0; i < 4; i++ {
+		s.endpointHealth.recordFailure(s.cfg.httpEndpoints[1])
+	}
+	s.fallbackToNextEndpoint()
+	assert.Equal(t, "C", s.cfg.currHttpEndpoint.Url)
+	assert.Equal(t, false, s.endpointHealth.score(s.cfg.httpEndpoints[0]) == 0, "Failure of previous endpoint was not recorded")
+
+	s.fallbackToNextEndpoint()
+	assert.Equal(t, "A", s.cfg.currHttpEndpoint.Url)
+}
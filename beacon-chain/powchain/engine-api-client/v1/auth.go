@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+)
+
+// jwtTransport is an http.RoundTripper that authenticates every outgoing request
+// to the engine API with a freshly signed HS256 token, as required by
+// https://github.com/ethereum/execution-apis/blob/main/src/engine/authentication.md.
+type jwtTransport struct {
+	underlyingTransport http.RoundTripper
+	jwtSecret           []byte
+}
+
+// RoundTrip signs a new token with an up-to-date "iat" claim and attaches it
+// to the request as a bearer authorization header. Execution clients reject
+// tokens whose "iat" claim drifts too far from their local clock, so tokens
+// cannot be reused across requests.
+func (t *jwtTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	authHeader, err := jwtAuthorizationHeader(t.jwtSecret)
+	if err != nil {
+		return nil, err
+	}
+	// A round tripper must not modify the caller's request.
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", authHeader)
+	return t.underlyingTransport.RoundTrip(req)
+}
+
+// Returns a bearer authorization header value containing a token signed
+// with the given secret, issued at the current time.
+func jwtAuthorizationHeader(secret []byte) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iat": time.Now().Unix(),
+	})
+	tokenString, err := token.SignedString(secret)
+	if err != nil {
+		return "", errors.Wrap(err, "could not produce signed JWT token")
+	}
+	return "Bearer " + tokenString, nil
+}
+
+// headerTransport is an http.RoundTripper which attaches a static set of
+// HTTP headers to every outgoing request.
+type headerTransport struct {
+	underlyingTransport http.RoundTripper
+	headers             map[string]string
+}
+
+// RoundTrip attaches the configured headers to a copy of the request.
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	return t.underlyingTransport.RoundTrip(req)
+}
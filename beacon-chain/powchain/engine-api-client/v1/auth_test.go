@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestJWTTransport_RoundTrip(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		require.Equal(t, true, strings.HasPrefix(authHeader, "Bearer "))
+		token, err := jwt.Parse(strings.TrimPrefix(authHeader, "Bearer "), func(token *jwt.Token) (interface{}, error) {
+			_, ok := token.Method.(*jwt.SigningMethodHMAC)
+			require.Equal(t, true, ok)
+			return secret, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, true, token.Valid)
+		claims, ok := token.Claims.(jwt.MapClaims)
+		require.Equal(t, true, ok)
+		_, ok = claims["iat"]
+		require.Equal(t, true, ok)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{
+		Transport: &jwtTransport{
+			underlyingTransport: http.DefaultTransport,
+			jwtSecret:           secret,
+		},
+	}
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	// The original request must not be mutated by the transport.
+	require.Equal(t, "", req.Header.Get("Authorization"))
+}
+
+func TestJWTTransport_WrongSecret(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := jwt.Parse(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), func(token *jwt.Token) (interface{}, error) {
+			return []byte("wrong secret"), nil
+		})
+		require.ErrorContains(t, "signature is invalid", err)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{
+		Transport: &jwtTransport{
+			underlyingTransport: http.DefaultTransport,
+			jwtSecret:           []byte("secret"),
+		},
+	}
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestNew_HTTPWithJWTAndHeaders(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	want, ok := fixtures()["ExecutionBlock"].(*pb.ExecutionBlock)
+	require.Equal(t, true, ok)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "bar", r.Header.Get("X-Foo"))
+		require.Equal(t, true, strings.HasPrefix(r.Header.Get("Authorization"), "Bearer "))
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  want,
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	client, err := New(
+		context.Background(),
+		srv.URL,
+		WithJWTSecret(secret),
+		WithHeaders(map[string]string{"X-Foo": "bar"}),
+	)
+	require.NoError(t, err)
+	resp, err := client.LatestExecutionBlock(context.Background())
+	require.NoError(t, err)
+	require.DeepEqual(t, want, resp)
+}
+
+func TestNew_HTTPSWithTLSConfig(t *testing.T) {
+	want, ok := fixtures()["ExecutionBlock"].(*pb.ExecutionBlock)
+	require.Equal(t, true, ok)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  want,
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	// The certificate of the test server is not trusted by default.
+	client, err := New(context.Background(), srv.URL, WithHealthCheckInterval(0))
+	require.NoError(t, err)
+	_, err = client.LatestExecutionBlock(context.Background())
+	require.ErrorContains(t, "certificate", err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	client, err = New(
+		context.Background(),
+		srv.URL,
+		WithHealthCheckInterval(0),
+		WithTLSConfig(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}),
+	)
+	require.NoError(t, err)
+	resp, err := client.LatestExecutionBlock(context.Background())
+	require.NoError(t, err)
+	require.DeepEqual(t, want, resp)
+}
+
+func TestWithJWTSecret_Empty(t *testing.T) {
+	_, err := New(context.Background(), "http://localhost:8545", WithJWTSecret(nil))
+	require.ErrorContains(t, "empty JWT secret", err)
+}
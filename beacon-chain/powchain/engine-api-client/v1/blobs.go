@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"crypto/sha256"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// VersionedHashVersionKzg is the version byte of versioned hashes of KZG commitments.
+const VersionedHashVersionKzg = 0x01
+
+// KzgCommitmentToVersionedHash computes the versioned hash of a blob KZG commitment,
+// as expected by the engine_newPayloadV3 method.
+//
+// Spec code:
+// def kzg_commitment_to_versioned_hash(kzg_commitment: KZGCommitment) -> VersionedHash:
+//    return VERSIONED_HASH_VERSION_KZG + hash(kzg_commitment)[1:]
+func KzgCommitmentToVersionedHash(commitment []byte) common.Hash {
+	h := sha256.Sum256(commitment)
+	h[0] = VersionedHashVersionKzg
+	return h
+}
+
+// KzgCommitmentsToVersionedHashes computes the versioned hashes of the given
+// blob KZG commitments, in order.
+func KzgCommitmentsToVersionedHashes(commitments [][]byte) []common.Hash {
+	hashes := make([]common.Hash, len(commitments))
+	for i, c := range commitments {
+		hashes[i] = KzgCommitmentToVersionedHash(c)
+	}
+	return hashes
+}
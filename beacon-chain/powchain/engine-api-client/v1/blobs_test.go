@@ -0,0 +1,22 @@
+package v1
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestKzgCommitmentToVersionedHash(t *testing.T) {
+	commitment := make([]byte, 48)
+	commitment[0] = 0xc0
+	digest := sha256.Sum256(commitment)
+
+	hash := KzgCommitmentToVersionedHash(commitment)
+	require.Equal(t, byte(VersionedHashVersionKzg), hash[0])
+	require.DeepEqual(t, digest[1:], hash[1:])
+
+	hashes := KzgCommitmentsToVersionedHashes([][]byte{commitment, commitment})
+	require.Equal(t, 2, len(hashes))
+	require.Equal(t, hash, hashes[1])
+}
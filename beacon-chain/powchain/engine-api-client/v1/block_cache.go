@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/common"
+	lru "github.com/hashicorp/golang-lru"
+	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultBlockCacheSize is the default number of execution blocks cached by hash.
+const DefaultBlockCacheSize = 256
+
+// blockCache is a bounded LRU cache of execution blocks keyed by block hash. Blocks are
+// immutable once known to the execution node, so cached blocks never need to be invalidated.
+type blockCache struct {
+	cache *lru.Cache
+}
+
+func newBlockCache(size int) *blockCache {
+	if size <= 0 {
+		return nil
+	}
+	return &blockCache{cache: lruwrpr.New(size)}
+}
+
+// Returns a copy of the cached block with the given hash, if any.
+func (b *blockCache) get(hash common.Hash) (*pb.ExecutionBlock, bool) {
+	if b == nil {
+		return nil, false
+	}
+	item, ok := b.cache.Get(hash)
+	if !ok {
+		blockCacheMiss.Inc()
+		return nil, false
+	}
+	blk, ok := item.(*pb.ExecutionBlock)
+	if !ok {
+		blockCacheMiss.Inc()
+		return nil, false
+	}
+	blockCacheHit.Inc()
+	return proto.Clone(blk).(*pb.ExecutionBlock), true
+}
+
+// Caches a copy of a block fetched by the given hash. Responses for blocks unknown
+// to the execution node, which do not match the requested hash, are not cached.
+func (b *blockCache) add(hash common.Hash, blk *pb.ExecutionBlock) {
+	if b == nil || blk == nil || !bytes.Equal(blk.Hash, hash[:]) {
+		return
+	}
+	b.cache.Add(hash, proto.Clone(blk))
+}
@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+// Returns a server responding to block lookups by hash or by number, single or batched, with the
+// fixture execution block carrying the requested hash, or the requested number as its hash.
+// The number of looked up blocks is tracked in numLookups.
+func newBlockByHashTestServer(t *testing.T, numLookups *int32) *httptest.Server {
+	lookup := func(params json.RawMessage) (interface{}, *jsonError) {
+		atomic.AddInt32(numLookups, 1)
+		var args []interface{}
+		require.NoError(t, json.Unmarshal(params, &args))
+		hash, ok := args[0].(string)
+		require.Equal(t, true, ok)
+		blk, ok := fixtures()["ExecutionBlock"].(*pb.ExecutionBlock)
+		require.Equal(t, true, ok)
+		blk.Hash = common.HexToHash(hash).Bytes()
+		return blk, nil
+	}
+	return newJSONRPCServer(t, jsonRPCHandlers{
+		ExecutionBlockByHashMethod:   lookup,
+		ExecutionBlockByNumberMethod: lookup,
+	})
+}
+
+func TestClient_CachesExecutionBlocksByHash(t *testing.T) {
+	ctx := context.Background()
+	numLookups := int32(0)
+	srv := newBlockByHashTestServer(t, &numLookups)
+	defer srv.Close()
+	client, err := New(ctx, srv.URL, WithHealthCheckInterval(0))
+	require.NoError(t, err)
+	defer client.Close()
+
+	foo := common.BytesToHash([]byte("foo"))
+	hits := testutil.ToFloat64(blockCacheHit)
+	blk, err := client.ExecutionBlockByHash(ctx, foo)
+	require.NoError(t, err)
+	require.DeepEqual(t, foo.Bytes(), blk.Hash)
+	// Modifying a returned block must not affect the cache.
+	blk.Hash = []byte("modified")
+	blk, err = client.ExecutionBlockByHash(ctx, foo)
+	require.NoError(t, err)
+	require.DeepEqual(t, foo.Bytes(), blk.Hash)
+	require.Equal(t, int32(1), atomic.LoadInt32(&numLookups))
+	require.Equal(t, hits+1, testutil.ToFloat64(blockCacheHit))
+
+	// Blocks fetched by number in batches are cached by hash.
+	blks, err := client.ExecutionBlocksByNumbers(ctx, []uint64{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(blks))
+	require.Equal(t, int32(3), atomic.LoadInt32(&numLookups))
+	_, err = client.ExecutionBlockByHash(ctx, common.BytesToHash(blks[1].Hash))
+	require.NoError(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&numLookups))
+}
+
+func TestClient_BlockCacheDisabled(t *testing.T) {
+	ctx := context.Background()
+	numLookups := int32(0)
+	srv := newBlockByHashTestServer(t, &numLookups)
+	defer srv.Close()
+	client, err := New(ctx, srv.URL, WithHealthCheckInterval(0), WithBlockCacheSize(0))
+	require.NoError(t, err)
+	defer client.Close()
+
+	for i := 0; i < 2; i++ {
+		_, err = client.ExecutionBlockByHash(ctx, common.BytesToHash([]byte("foo")))
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(&numLookups))
+}
+
+func TestBlockCache_IgnoresUnknownBlocks(t *testing.T) {
+	cache := newBlockCache(2)
+	hash := common.BytesToHash([]byte("foo"))
+	cache.add(hash, &pb.ExecutionBlock{})
+	_, ok := cache.get(hash)
+	require.Equal(t, false, ok)
+}
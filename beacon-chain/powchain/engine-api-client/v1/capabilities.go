@@ -0,0 +1,136 @@
+package v1
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Deadline for exchanging capabilities with the execution node.
+var capabilitiesTimeout = healthCheckTimeout
+
+// Engine API methods supported by the client, advertised to the execution node.
+var supportedCapabilities = []string{
+	NewPayloadMethod,
+	NewPayloadMethodV2,
+	NewPayloadMethodV3,
+	ForkchoiceUpdatedMethod,
+	ForkchoiceUpdatedMethodV2,
+	GetPayloadMethod,
+	GetPayloadMethodV2,
+	GetPayloadMethodV3,
+	GetPayloadBodiesByHashMethod,
+	GetPayloadBodiesByRangeMethod,
+}
+
+// Exchanges the lists of supported engine API methods with the active execution endpoint, and
+// records the methods it supports. Execution nodes which do not implement the capability exchange
+// are assumed to support every method, as are endpoints which could not be reached.
+func (c *Client) exchangeCapabilities(ctx context.Context) {
+	conn, refresh := c.startCapabilitiesRefresh()
+	if conn == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, capabilitiesTimeout)
+	defer cancel()
+	// The exchange bypasses the circuit breaker and failover, as it is retried whenever the
+	// active endpoint changes or reconnects.
+	var result []string
+	err := handleRPCError(conn.rpc.CallContext(ctx, &result, ExchangeCapabilitiesMethod, supportedCapabilities))
+	if errors.Is(err, ErrMethodNotFound) {
+		log.Debug("Execution node does not support exchanging capabilities, assuming it supports every engine API method")
+		c.setCapabilities(conn, refresh, nil)
+		return
+	}
+	if err != nil {
+		log.WithError(err).Warn("Could not exchange capabilities with execution node")
+		return
+	}
+	capabilities := make(map[string]bool, len(result))
+	for _, method := range result {
+		capabilities[method] = true
+	}
+	var unsupported []string
+	for _, method := range supportedCapabilities {
+		if !capabilities[method] {
+			unsupported = append(unsupported, method)
+		}
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		log.WithFields(logrus.Fields{
+			"endpoint":           redactURL(conn.url),
+			"unsupportedMethods": strings.Join(unsupported, ","),
+		}).Warn("Execution node does not support every engine API method, calls to these methods will fail")
+	}
+	c.setCapabilities(conn, refresh, capabilities)
+}
+
+// Returns the active endpoint and the number of the capability exchange starting with it.
+func (c *Client) startCapabilitiesRefresh() (*endpointConn, uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.endpoints) == 0 {
+		return nil, 0
+	}
+	conn := c.endpoints[c.activeIdx]
+	conn.capabilitiesRefresh++
+	return conn, conn.capabilitiesRefresh
+}
+
+// Swaps in the capabilities of an endpoint once an exchange completes. The endpoint keeps its
+// previous capabilities while the exchange is in progress, and results of exchanges superseded
+// by a later one are discarded.
+func (c *Client) setCapabilities(conn *endpointConn, refresh uint64, capabilities map[string]bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if conn.capabilitiesRefresh != refresh {
+		return
+	}
+	conn.capabilities = capabilities
+}
+
+// Exchanges capabilities again in the background, after the active endpoint changed or
+// reconnected, as it may be running a different version of the execution node.
+func (c *Client) refreshCapabilities() {
+	go c.exchangeCapabilities(context.Background())
+}
+
+// SupportsMethod returns false if the execution node advertised that it does not support
+// the given engine API method. Methods are assumed to be supported until capabilities were
+// exchanged with the execution node.
+func (c *Client) SupportsMethod(method string) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if len(c.endpoints) == 0 || !isAdvertisedMethod(method) {
+		return true
+	}
+	// Capabilities are tracked per endpoint, so those of a previously active endpoint never
+	// gate calls to the current one.
+	capabilities := c.endpoints[c.activeIdx].capabilities
+	if capabilities == nil {
+		return true
+	}
+	return capabilities[method]
+}
+
+// Returns an error for calls of engine API methods the execution node does not support, rather
+// than sending requests bound to fail with a method not found error.
+func (c *Client) checkCapability(method string) error {
+	if !c.SupportsMethod(method) {
+		return errors.Wrapf(ErrUnsupportedMethod, "%s", method)
+	}
+	return nil
+}
+
+func isAdvertisedMethod(method string) bool {
+	for _, m := range supportedCapabilities {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
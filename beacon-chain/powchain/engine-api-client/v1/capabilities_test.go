@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+// Returns a server advertising the given capabilities, or not implementing the capability
+// exchange if capabilities is nil, and counting the requests of every other method.
+func newCapabilitiesTestServer(t *testing.T, capabilities []string, otherRequests *int32) *httptest.Server {
+	return newJSONRPCServer(t, jsonRPCHandlers{
+		ExchangeCapabilitiesMethod: func(json.RawMessage) (interface{}, *jsonError) {
+			if capabilities == nil {
+				return nil, errJSONMethodNotFound
+			}
+			return capabilities, nil
+		},
+		anyMethod: func(json.RawMessage) (interface{}, *jsonError) {
+			atomic.AddInt32(otherRequests, 1)
+			return nil, nil
+		},
+	})
+}
+
+// Returns true for capability exchange requests, which test servers counting requests ignore.
+func isCapabilitiesRequest(t *testing.T, r *http.Request) bool {
+	body, err := ioutil.ReadAll(r.Body)
+	require.NoError(t, err)
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return bytes.Contains(body, []byte(ExchangeCapabilitiesMethod))
+}
+
+func TestClient_ExchangeCapabilities(t *testing.T) {
+	ctx := context.Background()
+	otherRequests := int32(0)
+	srv := newCapabilitiesTestServer(t, []string{NewPayloadMethod, ForkchoiceUpdatedMethod, GetPayloadMethod}, &otherRequests)
+	defer srv.Close()
+
+	client, err := New(ctx, srv.URL, WithHealthCheckInterval(0))
+	require.NoError(t, err)
+	defer client.Close()
+
+	require.Equal(t, true, client.SupportsMethod(NewPayloadMethod))
+	require.Equal(t, false, client.SupportsMethod(GetPayloadMethodV3))
+	// Methods outside of the engine API are never gated.
+	require.Equal(t, true, client.SupportsMethod(ExecutionBlockByHashMethod))
+
+	// Calls of unsupported methods fail without being sent to the execution node.
+	_, err = client.GetPayloadV3(ctx, [8]byte{})
+	require.Equal(t, true, errors.Is(err, ErrUnsupportedMethod))
+	require.Equal(t, int32(0), atomic.LoadInt32(&otherRequests))
+
+	_, err = client.GetPayload(ctx, [8]byte{})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&otherRequests))
+}
+
+func TestClient_ExchangeCapabilitiesNotImplemented(t *testing.T) {
+	otherRequests := int32(0)
+	srv := newCapabilitiesTestServer(t, nil, &otherRequests)
+	defer srv.Close()
+
+	client, err := New(context.Background(), srv.URL, WithHealthCheckInterval(0))
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Execution nodes not implementing the capability exchange are assumed to support every method.
+	for _, method := range supportedCapabilities {
+		require.Equal(t, true, client.SupportsMethod(method))
+	}
+}
+
+func TestClient_CapabilitiesPerEndpoint(t *testing.T) {
+	otherRequests := int32(0)
+	primary := newCapabilitiesTestServer(t, []string{NewPayloadMethod, ForkchoiceUpdatedMethod, GetPayloadMethod}, &otherRequests)
+	defer primary.Close()
+	var fallbackCapabilities []string
+	for _, method := range supportedCapabilities {
+		if method != GetPayloadBodiesByRangeMethod {
+			fallbackCapabilities = append(fallbackCapabilities, method)
+		}
+	}
+	fallback := newCapabilitiesTestServer(t, fallbackCapabilities, &otherRequests)
+	defer fallback.Close()
+
+	client, err := New(context.Background(), primary.URL, WithFallbackEndpoints([]string{fallback.URL}), WithHealthCheckInterval(0))
+	require.NoError(t, err)
+	defer client.Close()
+	require.Equal(t, false, client.SupportsMethod(GetPayloadMethodV3))
+
+	// Once the fallback becomes active, the capabilities of the primary no longer apply, while
+	// those of the fallback are unknown until exchanged.
+	client.lock.Lock()
+	client.activeIdx = 1
+	client.rpc = client.endpoints[1].rpc
+	client.lock.Unlock()
+	require.Equal(t, true, client.SupportsMethod(GetPayloadMethodV3))
+	require.Equal(t, true, client.SupportsMethod(GetPayloadBodiesByRangeMethod))
+
+	client.exchangeCapabilities(context.Background())
+	require.Equal(t, true, client.SupportsMethod(GetPayloadMethodV3))
+	require.Equal(t, false, client.SupportsMethod(GetPayloadBodiesByRangeMethod))
+}
+
+func TestClient_SupersededCapabilitiesDiscarded(t *testing.T) {
+	otherRequests := int32(0)
+	srv := newCapabilitiesTestServer(t, supportedCapabilities, &otherRequests)
+	defer srv.Close()
+
+	client, err := New(context.Background(), srv.URL, WithHealthCheckInterval(0))
+	require.NoError(t, err)
+	defer client.Close()
+
+	conn, first := client.startCapabilitiesRefresh()
+	_, second := client.startCapabilitiesRefresh()
+	client.setCapabilities(conn, first, map[string]bool{})
+	require.Equal(t, true, client.SupportsMethod(GetPayloadMethodV3))
+	client.setCapabilities(conn, second, map[string]bool{})
+	require.Equal(t, false, client.SupportsMethod(GetPayloadMethodV3))
+}
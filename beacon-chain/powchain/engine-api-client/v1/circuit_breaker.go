@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultCircuitBreakerThreshold is the default number of consecutive failed requests
+	// after which requests to the execution node are short-circuited.
+	DefaultCircuitBreakerThreshold = 5
+	// DefaultCircuitBreakerCooldown is the default period during which requests are
+	// short-circuited once the circuit breaker has tripped.
+	DefaultCircuitBreakerCooldown = 30 * time.Second
+)
+
+// circuitBreaker short-circuits requests to an execution node which failed a number of
+// consecutive requests, so that callers fail fast instead of blocking on a dead connection
+// until their deadline. Once the cooldown elapses, requests are let through again and the
+// breaker closes as soon as one of them succeeds, or trips again if it fails.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	lock      sync.Mutex
+	failures  int
+	open      bool
+	openUntil time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// Returns ErrCircuitOpen if requests are currently being short-circuited.
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.open && time.Now().Before(b.openUntil) {
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+// Records the outcome of a request, returning whether the breaker tripped or closed as a result.
+func (b *circuitBreaker) record(failed bool) (tripped, closed bool) {
+	if b == nil {
+		return false, false
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if !failed {
+		b.failures = 0
+		closed = b.open
+		b.open = false
+		return false, closed
+	}
+	b.failures++
+	if b.failures < b.threshold {
+		return false, false
+	}
+	b.openUntil = time.Now().Add(b.cooldown)
+	tripped = !b.open
+	b.open = true
+	return tripped, false
+}
+
+// Determines whether a request failed because the execution node could not be reached. Errors
+// returned by the JSON-RPC server itself show the node is up, and errors caused by the caller's
+// context being canceled say nothing about the node.
+func isNodeFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	_, isRPCError := err.(rpc.Error)
+	return !isRPCError
+}
+
+// Updates the circuit breaker with the outcome of a request, marking the execution
+// node offline when the breaker trips and online again when it closes.
+func (c *Client) recordRequestOutcome(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	tripped, closed := c.breaker.record(isNodeFailure(ctx, err))
+	switch {
+	case tripped:
+		circuitBreakerOpenGauge.Set(1)
+		log.WithError(err).WithField("cooldown", c.cfg.circuitBreakerCooldown).Error(
+			"Execution node failed too many consecutive requests, short-circuiting engine API requests",
+		)
+		c.updateConnectionStatus(errors.Wrap(ErrCircuitOpen, err.Error()))
+	case closed:
+		circuitBreakerOpenGauge.Set(0)
+		log.Info("Execution node is responding again, no longer short-circuiting engine API requests")
+		c.updateConnectionStatus(nil)
+	}
+}
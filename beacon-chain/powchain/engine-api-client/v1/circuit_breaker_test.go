@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/async/event"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := newCircuitBreaker(2, time.Hour)
+	require.NoError(t, b.allow())
+
+	tripped, closed := b.record(true)
+	require.Equal(t, false, tripped)
+	require.Equal(t, false, closed)
+	require.NoError(t, b.allow())
+
+	// A success resets the count of consecutive failures.
+	b.record(false)
+	b.record(true)
+	require.NoError(t, b.allow())
+
+	tripped, _ = b.record(true)
+	require.Equal(t, true, tripped)
+	require.ErrorIs(t, b.allow(), ErrCircuitOpen)
+
+	// Once the cooldown has elapsed, requests are let through and a single failure trips the breaker again.
+	b.openUntil = time.Now()
+	require.NoError(t, b.allow())
+	tripped, _ = b.record(true)
+	require.Equal(t, false, tripped)
+	require.ErrorIs(t, b.allow(), ErrCircuitOpen)
+
+	b.openUntil = time.Now()
+	_, closed = b.record(false)
+	require.Equal(t, true, closed)
+	require.NoError(t, b.allow())
+
+	require.Equal(t, true, newCircuitBreaker(0, time.Hour) == nil)
+}
+
+func TestClient_CircuitBreakerShortCircuitsRequests(t *testing.T) {
+	ctx := context.Background()
+	down := int32(1)
+	numRequests := int32(0)
+	want, ok := fixtures()["ExecutionBlock"].(*pb.ExecutionBlock)
+	require.Equal(t, true, ok)
+	srv := newFailoverTestServer(t, want, &down)
+	defer srv.Close()
+	handler := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isCapabilitiesRequest(t, r) {
+			atomic.AddInt32(&numRequests, 1)
+		}
+		handler.ServeHTTP(w, r)
+	})
+
+	notifier := &mockStateNotifier{feed: new(event.Feed)}
+	events := make(chan *feed.Event, 10)
+	sub := notifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+
+	client, err := New(
+		ctx,
+		srv.URL,
+		WithHealthCheckInterval(0),
+		WithCircuitBreaker(2, 50*time.Millisecond),
+		WithStateNotifier(notifier),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	for i := 0; i < 2; i++ {
+		_, err = client.LatestExecutionBlock(ctx)
+		require.ErrorContains(t, "503", err)
+	}
+	ev := receiveConnectionEvent(t, events)
+	require.Equal(t, false, ev.Connected)
+	require.ErrorIs(t, ev.Error, ErrCircuitOpen)
+	require.Equal(t, false, client.IsConnected())
+
+	// Requests fail fast without reaching the execution node.
+	_, err = client.LatestExecutionBlock(ctx)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	require.ErrorIs(t, err, ErrTransport)
+	require.Equal(t, int32(2), atomic.LoadInt32(&numRequests))
+
+	// After the cooldown, a successful request closes the breaker.
+	atomic.StoreInt32(&down, 0)
+	time.Sleep(60 * time.Millisecond)
+	resp, err := client.LatestExecutionBlock(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, want, resp)
+	ev = receiveConnectionEvent(t, events)
+	require.Equal(t, true, ev.Connected)
+	require.Equal(t, true, client.IsConnected())
+}
+
+func TestClient_CircuitBreakerIgnoresJSONRPCErrors(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	client, err := New(ctx, srv.URL, WithHealthCheckInterval(0), WithCircuitBreaker(1, time.Hour))
+	require.NoError(t, err)
+	defer client.Close()
+	for i := 0; i < 3; i++ {
+		_, err = client.GetPayload(ctx, [8]byte{1})
+		require.ErrorIs(t, err, ErrMethodNotFound)
+	}
+}
@@ -5,13 +5,22 @@ package v1
 
 import (
 	"context"
-	"net/url"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/monitoring/tracing"
 	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	"go.opencensus.io/trace"
 )
 
 const (
@@ -21,11 +30,38 @@ const (
 	ForkchoiceUpdatedMethod = "engine_forkchoiceUpdatedV1"
 	// GetPayloadMethod v1 request string for JSON-RPC.
 	GetPayloadMethod = "engine_getPayloadV1"
+	// NewPayloadMethodV2 v2 request string for JSON-RPC.
+	NewPayloadMethodV2 = "engine_newPayloadV2"
+	// ForkchoiceUpdatedMethodV2 v2 request string for JSON-RPC.
+	ForkchoiceUpdatedMethodV2 = "engine_forkchoiceUpdatedV2"
+	// GetPayloadMethodV2 v2 request string for JSON-RPC.
+	GetPayloadMethodV2 = "engine_getPayloadV2"
+	// NewPayloadMethodV3 v3 request string for JSON-RPC.
+	NewPayloadMethodV3 = "engine_newPayloadV3"
+	// GetPayloadMethodV3 v3 request string for JSON-RPC.
+	GetPayloadMethodV3 = "engine_getPayloadV3"
+	// GetPayloadBodiesByHashMethod v1 request string for JSON-RPC.
+	GetPayloadBodiesByHashMethod = "engine_getPayloadBodiesByHashV1"
+	// GetPayloadBodiesByRangeMethod v1 request string for JSON-RPC.
+	GetPayloadBodiesByRangeMethod = "engine_getPayloadBodiesByRangeV1"
+	// MaxPayloadBodiesRequest is the maximum number of payload bodies
+	// which may be requested from the execution node at once.
+	MaxPayloadBodiesRequest = 1024
 	// ExecutionBlockByHashMethod request string for JSON-RPC.
 	ExecutionBlockByHashMethod = "eth_getBlockByHash"
 	// ExecutionBlockByNumberMethod request string for JSON-RPC.
 	ExecutionBlockByNumberMethod = "eth_getBlockByNumber"
-	// DefaultTimeout for HTTP.
+	// LogsMethod request string for JSON-RPC.
+	LogsMethod = "eth_getLogs"
+	// ChainIDMethod request string for JSON-RPC.
+	ChainIDMethod = "eth_chainId"
+	// ExchangeCapabilitiesMethod request string for JSON-RPC.
+	ExchangeCapabilitiesMethod = "engine_exchangeCapabilities"
+	// ExchangeTransitionConfigurationMethod v1 request string for JSON-RPC.
+	ExchangeTransitionConfigurationMethod = "engine_exchangeTransitionConfigurationV1"
+	// SyncingMethod request string for JSON-RPC.
+	SyncingMethod = "eth_syncing"
+	// DefaultTimeout for JSON-RPC requests without a method specific timeout.
 	DefaultTimeout = time.Second * 5
 )
 
@@ -44,121 +80,543 @@ type EngineCaller interface {
 		ctx context.Context, state *pb.ForkchoiceState, attrs *pb.PayloadAttributes,
 	) (*ForkchoiceUpdatedResponse, error)
 	GetPayload(ctx context.Context, payloadId [8]byte) (*pb.ExecutionPayload, error)
+	NewPayloadV2(ctx context.Context, payload *pb.ExecutionPayloadCapella) (*pb.PayloadStatus, error)
+	ForkchoiceUpdatedV2(
+		ctx context.Context, state *pb.ForkchoiceState, attrs *pb.PayloadAttributesV2,
+	) (*ForkchoiceUpdatedResponse, error)
+	GetPayloadV2(ctx context.Context, payloadId [8]byte) (*pb.ExecutionPayloadCapellaWithValue, error)
+	NewPayloadV3(
+		ctx context.Context, payload *pb.ExecutionPayloadDeneb, versionedHashes []common.Hash, parentBlockRoot common.Hash,
+	) (*pb.PayloadStatus, error)
+	GetPayloadV3(ctx context.Context, payloadId [8]byte) (*pb.ExecutionPayloadDenebWithValueAndBlobsBundle, error)
+	NewPayloadForSlot(
+		ctx context.Context, slot types.Slot, payload *pb.ExecutionPayloadCapella,
+	) (*pb.PayloadStatus, error)
+	ForkchoiceUpdatedForSlot(
+		ctx context.Context, slot types.Slot, state *pb.ForkchoiceState, attrs *pb.PayloadAttributesV2,
+	) (*ForkchoiceUpdatedResponse, error)
+	GetPayloadForSlot(ctx context.Context, slot types.Slot, payloadId [8]byte) (*pb.ExecutionPayloadCapella, error)
+	GetPayloadBodiesByHash(ctx context.Context, hashes []common.Hash) ([]*pb.ExecutionPayloadBodyV1, error)
+	GetPayloadBodiesByRange(ctx context.Context, start, count uint64) ([]*pb.ExecutionPayloadBodyV1, error)
 	LatestExecutionBlock(ctx context.Context) (*pb.ExecutionBlock, error)
 	ExecutionBlockByHash(ctx context.Context, hash common.Hash) (*pb.ExecutionBlock, error)
+	ExecutionBlocksByNumbers(ctx context.Context, numbers []uint64) ([]*pb.ExecutionBlock, error)
 }
 
 // Client defines a new engine API client for the Prysm consensus node
 // to interact with an Ethereum execution node.
 type Client struct {
-	cfg *config
-	rpc *rpc.Client
+	cfg                *config
+	rpc                *rpc.Client
+	eth                *rpc.Client
+	endpoints          []*endpointConn
+	activeIdx          int
+	breaker            *circuitBreaker
+	blocks             *blockCache
+	latency            *latencyTracker
+	limiter            *rateLimiter
+	checkingPrimary    bool
+	connected          bool
+	transitionMismatch bool
+	cancel             context.CancelFunc
+	lock               sync.RWMutex
 }
 
 // New returns a ready, engine API client from an endpoint and configuration options.
-// Only http(s) and ipc (inter-process communication) URL schemes are supported.
+// Only http(s), ws(s) and ipc (inter-process communication) URL schemes are supported.
+// If fallback endpoints are configured, the first healthy endpoint in order of
+// priority is selected as the active one. Unless disabled, the endpoints are then
+// health checked in the background until the context is canceled or Close is called.
 func New(ctx context.Context, endpoint string, opts ...Option) (*Client, error) {
-	u, err := url.Parse(endpoint)
-	if err != nil {
-		return nil, err
-	}
 	c := &Client{
 		cfg: defaultConfig(),
 	}
-	switch u.Scheme {
-	case "http", "https":
-		c.rpc, err = rpc.DialHTTPWithClient(endpoint, c.cfg.httpClient)
-	case "":
-		c.rpc, err = rpc.DialIPC(ctx, endpoint)
-	default:
-		return nil, errors.Wrapf(ErrUnsupportedScheme, "%q", u.Scheme)
-	}
-	if err != nil {
-		return nil, err
-	}
 	for _, opt := range opts {
 		if err := opt(c); err != nil {
 			return nil, err
 		}
 	}
+	urls := append([]string{endpoint}, c.cfg.fallbackEndpoints...)
+	c.endpoints = make([]*endpointConn, len(urls))
+	for i, u := range urls {
+		client, err := c.dial(ctx, u)
+		if err != nil {
+			for _, e := range c.endpoints[:i] {
+				e.rpc.Close()
+			}
+			return nil, errors.Wrapf(err, "could not dial execution endpoint %s", redactURL(u))
+		}
+		c.endpoints[i] = &endpointConn{url: u, rpc: client}
+		if err := c.checkNetwork(ctx, u, client); err != nil {
+			for _, e := range c.endpoints[:i+1] {
+				e.rpc.Close()
+			}
+			return nil, err
+		}
+	}
+	if c.cfg.ethEndpoint != "" {
+		eth, err := dialEthEndpoint(ctx, c.cfg.ethEndpoint)
+		if err == nil {
+			err = c.checkNetwork(ctx, c.cfg.ethEndpoint, eth)
+		}
+		if err != nil {
+			for _, e := range c.endpoints {
+				e.rpc.Close()
+			}
+			return nil, err
+		}
+		c.eth = eth
+	}
+	c.rpc = c.endpoints[0].rpc
+	c.breaker = newCircuitBreaker(c.cfg.circuitBreakerThreshold, c.cfg.circuitBreakerCooldown)
+	c.blocks = newBlockCache(c.cfg.blockCacheSize)
+	c.latency = newLatencyTracker(c.cfg.slowCallThreshold)
+	c.limiter = newRateLimiter(c.cfg.rateLimit)
+	if len(c.endpoints) > 1 {
+		for i, e := range c.endpoints {
+			if err := checkEndpointHealth(e.rpc); err != nil {
+				log.WithError(err).WithField("endpoint", redactURL(e.url)).Warn("Execution endpoint is not healthy")
+				e.lastFailure = time.Now()
+				continue
+			}
+			c.activeIdx = i
+			c.rpc = e.rpc
+			break
+		}
+	}
+	updateActiveEndpointMetric(c.endpoints, c.activeIdx)
+	c.connected = true
+	connectedGauge.Set(1)
+	c.exchangeCapabilities(ctx)
+	ctx, c.cancel = context.WithCancel(ctx)
+	if c.cfg.healthCheckInterval > 0 {
+		go c.superviseConnections(ctx)
+	}
 	return c, nil
 }
 
+// Close stops the background health checks and closes the connections to all execution endpoints.
+func (c *Client) Close() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	for _, e := range c.endpoints {
+		e.rpc.Close()
+	}
+	if c.eth != nil {
+		c.eth.Close()
+	}
+}
+
+// Wraps the transport of the configured HTTP client with any custom TLS configuration,
+// custom headers and JWT authentication, if specified. The configured client itself is left untouched.
+func (c *Client) authenticatedHTTPClient() *http.Client {
+	if len(c.cfg.jwtSecret) == 0 && len(c.cfg.headers) == 0 && c.cfg.tlsConfig == nil {
+		return c.cfg.httpClient
+	}
+	transport := c.cfg.httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if c.cfg.tlsConfig != nil {
+		if t, ok := transport.(*http.Transport); ok {
+			t = t.Clone()
+			t.TLSClientConfig = c.cfg.tlsConfig
+			transport = t
+		} else {
+			log.Warn("Custom HTTP transport in use, ignoring TLS configuration of execution endpoint")
+		}
+	}
+	if len(c.cfg.headers) > 0 {
+		transport = &headerTransport{
+			underlyingTransport: transport,
+			headers:             c.cfg.headers,
+		}
+	}
+	if len(c.cfg.jwtSecret) > 0 {
+		transport = &jwtTransport{
+			underlyingTransport: transport,
+			jwtSecret:           c.cfg.jwtSecret,
+		}
+	}
+	client := *c.cfg.httpClient
+	client.Transport = transport
+	return &client
+}
+
 // NewPayload calls the engine_newPayloadV1 method via JSON-RPC.
 func (c *Client) NewPayload(ctx context.Context, payload *pb.ExecutionPayload) (*pb.PayloadStatus, error) {
+	if payload == nil {
+		return nil, ErrNilPayload
+	}
+	ctx, span := startSpan(ctx, "NewPayload", NewPayloadMethod)
+	defer span.End()
+	annotatePayload(span, payload.BlockHash, payload.BlockNumber)
 	result := &pb.PayloadStatus{}
-	err := c.rpc.CallContext(ctx, result, NewPayloadMethod, payload)
-	return result, handleRPCError(err)
+	if err := handleRPCError(c.call(ctx, result, NewPayloadMethod, payload)); err != nil {
+		tracing.AnnotateError(span, err)
+		return result, err
+	}
+	annotatePayloadStatus(span, result)
+	return result, nil
 }
 
 // ForkchoiceUpdated calls the engine_forkchoiceUpdatedV1 method via JSON-RPC.
 func (c *Client) ForkchoiceUpdated(
 	ctx context.Context, state *pb.ForkchoiceState, attrs *pb.PayloadAttributes,
 ) (*ForkchoiceUpdatedResponse, error) {
+	ctx, span := startSpan(ctx, "ForkchoiceUpdated", ForkchoiceUpdatedMethod)
+	defer span.End()
+	annotateForkchoiceState(span, state, attrs != nil)
 	result := &ForkchoiceUpdatedResponse{}
-	err := c.rpc.CallContext(ctx, result, ForkchoiceUpdatedMethod, state, attrs)
-	return result, handleRPCError(err)
+	if err := handleRPCError(c.call(ctx, result, ForkchoiceUpdatedMethod, state, attrs)); err != nil {
+		tracing.AnnotateError(span, err)
+		return result, err
+	}
+	annotatePayloadStatus(span, result.Status)
+	return result, nil
 }
 
 // GetPayload calls the engine_getPayloadV1 method via JSON-RPC.
 func (c *Client) GetPayload(ctx context.Context, payloadId [8]byte) (*pb.ExecutionPayload, error) {
+	ctx, span := startSpan(ctx, "GetPayload", GetPayloadMethod)
+	defer span.End()
+	span.AddAttributes(trace.StringAttribute("payloadId", fmt.Sprintf("%#x", payloadId)))
 	result := &pb.ExecutionPayload{}
-	err := c.rpc.CallContext(ctx, result, GetPayloadMethod, pb.PayloadIDBytes(payloadId))
-	return result, handleRPCError(err)
+	if err := handleRPCError(c.call(ctx, result, GetPayloadMethod, pb.PayloadIDBytes(payloadId))); err != nil {
+		tracing.AnnotateError(span, err)
+		return result, err
+	}
+	annotatePayload(span, result.BlockHash, result.BlockNumber)
+	return result, nil
+}
+
+// NewPayloadV2 calls the engine_newPayloadV2 method via JSON-RPC.
+func (c *Client) NewPayloadV2(ctx context.Context, payload *pb.ExecutionPayloadCapella) (*pb.PayloadStatus, error) {
+	if payload == nil {
+		return nil, ErrNilPayload
+	}
+	ctx, span := startSpan(ctx, "NewPayloadV2", NewPayloadMethodV2)
+	defer span.End()
+	annotatePayload(span, payload.BlockHash, payload.BlockNumber)
+	result := &pb.PayloadStatus{}
+	if err := handleRPCError(c.call(ctx, result, NewPayloadMethodV2, payload)); err != nil {
+		tracing.AnnotateError(span, err)
+		return result, err
+	}
+	annotatePayloadStatus(span, result)
+	return result, nil
+}
+
+// ForkchoiceUpdatedV2 calls the engine_forkchoiceUpdatedV2 method via JSON-RPC.
+func (c *Client) ForkchoiceUpdatedV2(
+	ctx context.Context, state *pb.ForkchoiceState, attrs *pb.PayloadAttributesV2,
+) (*ForkchoiceUpdatedResponse, error) {
+	ctx, span := startSpan(ctx, "ForkchoiceUpdatedV2", ForkchoiceUpdatedMethodV2)
+	defer span.End()
+	annotateForkchoiceState(span, state, attrs != nil)
+	result := &ForkchoiceUpdatedResponse{}
+	if err := handleRPCError(c.call(ctx, result, ForkchoiceUpdatedMethodV2, state, attrs)); err != nil {
+		tracing.AnnotateError(span, err)
+		return result, err
+	}
+	annotatePayloadStatus(span, result.Status)
+	return result, nil
+}
+
+// GetPayloadV2 calls the engine_getPayloadV2 method via JSON-RPC.
+func (c *Client) GetPayloadV2(ctx context.Context, payloadId [8]byte) (*pb.ExecutionPayloadCapellaWithValue, error) {
+	ctx, span := startSpan(ctx, "GetPayloadV2", GetPayloadMethodV2)
+	defer span.End()
+	span.AddAttributes(trace.StringAttribute("payloadId", fmt.Sprintf("%#x", payloadId)))
+	result := &pb.ExecutionPayloadCapellaWithValue{}
+	if err := handleRPCError(c.call(ctx, result, GetPayloadMethodV2, pb.PayloadIDBytes(payloadId))); err != nil {
+		tracing.AnnotateError(span, err)
+		return result, err
+	}
+	if result.Payload != nil {
+		annotatePayload(span, result.Payload.BlockHash, result.Payload.BlockNumber)
+	}
+	return result, nil
+}
+
+// NewPayloadV3 calls the engine_newPayloadV3 method via JSON-RPC. The versioned hashes are those
+// of the KZG commitments of the blobs in the beacon block, in order, which the execution node
+// checks against the blob transactions of the payload.
+func (c *Client) NewPayloadV3(
+	ctx context.Context, payload *pb.ExecutionPayloadDeneb, versionedHashes []common.Hash, parentBlockRoot common.Hash,
+) (*pb.PayloadStatus, error) {
+	if payload == nil {
+		return nil, ErrNilPayload
+	}
+	if versionedHashes == nil {
+		// The versioned hashes must be sent as an empty list rather than null.
+		versionedHashes = make([]common.Hash, 0)
+	}
+	ctx, span := startSpan(ctx, "NewPayloadV3", NewPayloadMethodV3)
+	defer span.End()
+	annotatePayload(span, payload.BlockHash, payload.BlockNumber)
+	span.AddAttributes(trace.Int64Attribute("numBlobs", int64(len(versionedHashes))))
+	result := &pb.PayloadStatus{}
+	if err := handleRPCError(c.call(ctx, result, NewPayloadMethodV3, payload, versionedHashes, parentBlockRoot)); err != nil {
+		tracing.AnnotateError(span, err)
+		return result, err
+	}
+	annotatePayloadStatus(span, result)
+	return result, nil
+}
+
+// GetPayloadV3 calls the engine_getPayloadV3 method via JSON-RPC, which returns the built
+// payload along with the bundle of blobs of its blob transactions.
+func (c *Client) GetPayloadV3(ctx context.Context, payloadId [8]byte) (*pb.ExecutionPayloadDenebWithValueAndBlobsBundle, error) {
+	ctx, span := startSpan(ctx, "GetPayloadV3", GetPayloadMethodV3)
+	defer span.End()
+	span.AddAttributes(trace.StringAttribute("payloadId", fmt.Sprintf("%#x", payloadId)))
+	result := &pb.ExecutionPayloadDenebWithValueAndBlobsBundle{}
+	if err := handleRPCError(c.call(ctx, result, GetPayloadMethodV3, pb.PayloadIDBytes(payloadId))); err != nil {
+		tracing.AnnotateError(span, err)
+		return result, err
+	}
+	if result.Payload != nil {
+		annotatePayload(span, result.Payload.BlockHash, result.Payload.BlockNumber)
+	}
+	return result, nil
+}
+
+// GetPayloadBodiesByHash calls the engine_getPayloadBodiesByHashV1 method via JSON-RPC.
+// The returned bodies are in the order of the given hashes, with a nil entry for every
+// block which is unknown to, or has been pruned by, the execution node.
+func (c *Client) GetPayloadBodiesByHash(ctx context.Context, hashes []common.Hash) ([]*pb.ExecutionPayloadBodyV1, error) {
+	if len(hashes) > MaxPayloadBodiesRequest {
+		return nil, errors.Errorf("requested %d payload bodies, at most %d are allowed", len(hashes), MaxPayloadBodiesRequest)
+	}
+	ctx, span := startSpan(ctx, "GetPayloadBodiesByHash", GetPayloadBodiesByHashMethod)
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("count", int64(len(hashes))))
+	result := make([]*pb.ExecutionPayloadBodyV1, 0, len(hashes))
+	err := handleRPCError(c.call(ctx, &result, GetPayloadBodiesByHashMethod, hashes))
+	tracing.AnnotateError(span, err)
+	return result, err
+}
+
+// GetPayloadBodiesByRange calls the engine_getPayloadBodiesByRangeV1 method via JSON-RPC,
+// requesting the bodies of count consecutive blocks starting at block number start. The
+// execution node omits trailing blocks beyond its latest known block, and sets a nil entry
+// for every other block it does not have.
+func (c *Client) GetPayloadBodiesByRange(ctx context.Context, start, count uint64) ([]*pb.ExecutionPayloadBodyV1, error) {
+	if start == 0 || count == 0 {
+		return nil, errors.New("start and count must be greater than zero")
+	}
+	if count > MaxPayloadBodiesRequest {
+		return nil, errors.Errorf("requested %d payload bodies, at most %d are allowed", count, MaxPayloadBodiesRequest)
+	}
+	ctx, span := startSpan(ctx, "GetPayloadBodiesByRange", GetPayloadBodiesByRangeMethod)
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("start", int64(start)), trace.Int64Attribute("count", int64(count)))
+	result := make([]*pb.ExecutionPayloadBodyV1, 0, count)
+	err := c.call(ctx, &result, GetPayloadBodiesByRangeMethod, hexutil.Uint64(start), hexutil.Uint64(count))
+	err = handleRPCError(err)
+	tracing.AnnotateError(span, err)
+	return result, err
 }
 
 // LatestExecutionBlock fetches the latest execution engine block by calling
 // eth_blockByNumber via JSON-RPC.
 func (c *Client) LatestExecutionBlock(ctx context.Context) (*pb.ExecutionBlock, error) {
+	ctx, span := startSpan(ctx, "LatestExecutionBlock", ExecutionBlockByNumberMethod)
+	defer span.End()
 	result := &pb.ExecutionBlock{}
-	err := c.rpc.CallContext(
+	err := c.call(
 		ctx,
 		result,
 		ExecutionBlockByNumberMethod,
 		"latest",
 		false, /* no full transaction objects */
 	)
-	return result, handleRPCError(err)
+	if err = handleRPCError(err); err != nil {
+		tracing.AnnotateError(span, err)
+		return result, err
+	}
+	annotateExecutionBlock(span, result)
+	return result, nil
 }
 
 // ExecutionBlockByHash fetches an execution engine block by hash by calling
-// eth_blockByHash via JSON-RPC.
+// eth_blockByHash via JSON-RPC. Blocks are served from the client's cache when possible.
 func (c *Client) ExecutionBlockByHash(ctx context.Context, hash common.Hash) (*pb.ExecutionBlock, error) {
+	ctx, span := startSpan(ctx, "ExecutionBlockByHash", ExecutionBlockByHashMethod)
+	defer span.End()
+	span.AddAttributes(trace.StringAttribute("blockHash", hash.Hex()))
+	if blk, ok := c.blocks.get(hash); ok {
+		span.AddAttributes(trace.BoolAttribute("cached", true))
+		return blk, nil
+	}
 	result := &pb.ExecutionBlock{}
-	err := c.rpc.CallContext(ctx, result, ExecutionBlockByHashMethod, hash, false /* no full transaction objects */)
-	return result, handleRPCError(err)
+	err := c.call(ctx, result, ExecutionBlockByHashMethod, hash, false /* no full transaction objects */)
+	if err != nil {
+		err = handleRPCError(err)
+		tracing.AnnotateError(span, err)
+		return result, err
+	}
+	annotateExecutionBlock(span, result)
+	c.blocks.add(hash, result)
+	return result, nil
+}
+
+// FilterLogs calls the eth_getLogs method via JSON-RPC, returning the logs matching the given query.
+// The query is sent to the dedicated eth endpoint, if configured.
+func (c *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]gethTypes.Log, error) {
+	ctx, span := startSpan(ctx, "FilterLogs", LogsMethod)
+	defer span.End()
+	arg, err := filterArg(q)
+	if err != nil {
+		return nil, err
+	}
+	var result []gethTypes.Log
+	if err := handleRPCError(c.call(ctx, &result, LogsMethod, arg)); err != nil {
+		tracing.AnnotateError(span, err)
+		return nil, err
+	}
+	span.AddAttributes(trace.Int64Attribute("count", int64(len(result))))
+	return result, nil
+}
+
+// Converts a log filter query to the parameter of the eth_getLogs method.
+func filterArg(q ethereum.FilterQuery) (interface{}, error) {
+	arg := map[string]interface{}{
+		"address": q.Addresses,
+		"topics":  q.Topics,
+	}
+	if q.BlockHash != nil {
+		if q.FromBlock != nil || q.ToBlock != nil {
+			return nil, errors.New("cannot specify both a block hash and a block range")
+		}
+		arg["blockHash"] = *q.BlockHash
+		return arg, nil
+	}
+	arg["fromBlock"] = blockNumberArg(q.FromBlock)
+	arg["toBlock"] = blockNumberArg(q.ToBlock)
+	return arg, nil
+}
+
+func blockNumberArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	return hexutil.EncodeBig(number)
+}
+
+// ExecutionBlocksByNumbers fetches a batch of execution engine blocks by number by calling
+// eth_getBlockByNumber for every number within a single JSON-RPC batch request.
+// The fetched blocks are cached by hash for subsequent lookups by hash.
+func (c *Client) ExecutionBlocksByNumbers(ctx context.Context, numbers []uint64) ([]*pb.ExecutionBlock, error) {
+	ctx, span := startSpan(ctx, "ExecutionBlocksByNumbers", ExecutionBlockByNumberMethod)
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("count", int64(len(numbers))))
+	results := make([]*pb.ExecutionBlock, len(numbers))
+	batch := make([]rpc.BatchElem, len(numbers))
+	for i, number := range numbers {
+		results[i] = &pb.ExecutionBlock{}
+		batch[i] = rpc.BatchElem{
+			Method: ExecutionBlockByNumberMethod,
+			Args:   []interface{}{hexutil.EncodeUint64(number), false /* no full transaction objects */},
+			Result: results[i],
+		}
+	}
+	if err := c.BatchCall(ctx, batch); err != nil {
+		err = handleRPCError(err)
+		tracing.AnnotateError(span, err)
+		return nil, err
+	}
+	for i, e := range batch {
+		if e.Error != nil {
+			err := errors.Wrapf(handleRPCError(e.Error), "could not fetch execution block %d", numbers[i])
+			tracing.AnnotateError(span, err)
+			return nil, err
+		}
+		c.blocks.add(common.BytesToHash(results[i].Hash), results[i])
+	}
+	return results, nil
+}
+
+// BatchCall sends multiple JSON-RPC requests to the execution node in a single round trip.
+// An error is returned only if the batch as a whole could not be sent, errors of individual
+// requests are set in the Error field of the corresponding batch element.
+func (c *Client) BatchCall(ctx context.Context, batch []rpc.BatchElem) (err error) {
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := c.checkCapability(batch[0].Method); err != nil {
+		return err
+	}
+	if isRateLimitedMethod(batch[0].Method) {
+		if err := c.limiter.wait(ctx, len(batch)); err != nil {
+			return err
+		}
+	}
+	ctx, span := startSpan(ctx, "BatchCall", batch[0].Method)
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("batchSize", int64(len(batch))))
+	start := time.Now()
+	defer func() {
+		tracing.AnnotateError(span, err)
+		for _, e := range batch {
+			elemErr := err
+			if elemErr == nil {
+				elemErr = e.Error
+			}
+			observeRequest(e.Method, start, elemErr)
+			c.latency.observe(e.Method, time.Since(start))
+			c.logDebug(e.Method, e.Args, e.Result, elemErr)
+		}
+	}()
+	return c.executeRead(ctx, batch[0].Method, func(ctx context.Context, client *rpc.Client) error {
+		return client.BatchCallContext(ctx, batch)
+	})
 }
 
 // Handles errors received from the RPC server according to the specification.
+// JSON-RPC level errors are returned as an *EngineError wrapping the sentinel error of
+// their code, and other errors as a *TransportError.
 func handleRPCError(err error) error {
 	if err == nil {
 		return nil
 	}
+	if _, ok := err.(*TimeoutError); ok {
+		return err
+	}
 	e, ok := err.(rpc.Error)
 	if !ok {
-		return errors.Wrap(err, "got an unexpected error")
+		return &TransportError{Err: err}
+	}
+	engineErr := &EngineError{
+		Code:    e.ErrorCode(),
+		Message: e.Error(),
+	}
+	if errWithData, ok := err.(rpc.DataError); ok {
+		engineErr.Data = errWithData.ErrorData()
 	}
 	switch e.ErrorCode() {
 	case -32700:
-		return ErrParse
+		engineErr.err = ErrParse
 	case -32600:
-		return ErrInvalidRequest
+		engineErr.err = ErrInvalidRequest
 	case -32601:
-		return ErrMethodNotFound
+		engineErr.err = ErrMethodNotFound
 	case -32602:
-		return ErrInvalidParams
+		engineErr.err = ErrInvalidParams
 	case -32603:
-		return ErrInternal
+		engineErr.err = ErrInternal
 	case -32001:
-		return ErrUnknownPayload
+		engineErr.err = ErrUnknownPayload
 	case -32000:
 		// Only -32000 status codes are data errors in the RPC specification.
-		errWithData, ok := err.(rpc.DataError)
-		if !ok {
+		if engineErr.Data == nil {
 			return errors.Wrap(err, "got an unexpected error")
 		}
-		return errors.Wrapf(ErrServer, "%v", errWithData.ErrorData())
-	default:
-		return err
+		engineErr.err = ErrServer
 	}
+	return engineErr
 }
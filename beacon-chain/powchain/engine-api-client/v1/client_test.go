@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -12,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
@@ -57,6 +59,84 @@ func TestClient_IPC(t *testing.T) {
 		require.NoError(t, err)
 		require.DeepEqual(t, want, resp)
 	})
+	t.Run(GetPayloadMethodV2, func(t *testing.T) {
+		want, ok := fix["ExecutionPayloadCapella"].(*pb.ExecutionPayloadCapellaWithValue)
+		require.Equal(t, true, ok)
+		resp, err := client.GetPayloadV2(ctx, [8]byte{1})
+		require.NoError(t, err)
+		require.DeepEqual(t, want.Payload, resp.Payload)
+		require.DeepEqual(t, want.BlockValue, resp.BlockValue)
+	})
+	t.Run(ForkchoiceUpdatedMethodV2, func(t *testing.T) {
+		want, ok := fix["ForkchoiceUpdatedResponse"].(*ForkchoiceUpdatedResponse)
+		require.Equal(t, true, ok)
+		resp, err := client.ForkchoiceUpdatedV2(ctx, &pb.ForkchoiceState{}, &pb.PayloadAttributesV2{})
+		require.NoError(t, err)
+		require.DeepEqual(t, want.Status, resp.Status)
+		require.DeepEqual(t, want.PayloadId, resp.PayloadId)
+	})
+	t.Run(NewPayloadMethodV2, func(t *testing.T) {
+		want, ok := fix["PayloadStatus"].(*pb.PayloadStatus)
+		require.Equal(t, true, ok)
+		req, ok := fix["ExecutionPayloadCapella"].(*pb.ExecutionPayloadCapellaWithValue)
+		require.Equal(t, true, ok)
+		resp, err := client.NewPayloadV2(ctx, req.Payload)
+		require.NoError(t, err)
+		require.DeepEqual(t, want, resp)
+	})
+	t.Run(GetPayloadMethodV3, func(t *testing.T) {
+		want, ok := fix["ExecutionPayloadDeneb"].(*pb.ExecutionPayloadDenebWithValueAndBlobsBundle)
+		require.Equal(t, true, ok)
+		resp, err := client.GetPayloadV3(ctx, [8]byte{1})
+		require.NoError(t, err)
+		require.DeepEqual(t, want.Payload, resp.Payload)
+		require.DeepEqual(t, want.BlobsBundle, resp.BlobsBundle)
+		require.DeepEqual(t, want.BlockValue, resp.BlockValue)
+		require.Equal(t, true, resp.ShouldOverrideBuilder)
+	})
+	t.Run(NewPayloadMethodV3, func(t *testing.T) {
+		want, ok := fix["PayloadStatus"].(*pb.PayloadStatus)
+		require.Equal(t, true, ok)
+		req, ok := fix["ExecutionPayloadDeneb"].(*pb.ExecutionPayloadDenebWithValueAndBlobsBundle)
+		require.Equal(t, true, ok)
+		hashes := KzgCommitmentsToVersionedHashes(req.BlobsBundle.KzgCommitments)
+		resp, err := client.NewPayloadV3(ctx, req.Payload, hashes, common.BytesToHash([]byte("root")))
+		require.NoError(t, err)
+		require.DeepEqual(t, want, resp)
+
+		resp, err = client.NewPayloadV3(ctx, req.Payload, nil, common.BytesToHash([]byte("root")))
+		require.NoError(t, err)
+		require.Equal(t, pb.PayloadStatus_INVALID, resp.Status)
+	})
+	t.Run(GetPayloadBodiesByHashMethod, func(t *testing.T) {
+		want, ok := fix["ExecutionPayloadBody"].(*pb.ExecutionPayloadBodyV1)
+		require.Equal(t, true, ok)
+		hashes := []common.Hash{common.BytesToHash([]byte("foo")), {}, common.BytesToHash([]byte("bar"))}
+		resp, err := client.GetPayloadBodiesByHash(ctx, hashes)
+		require.NoError(t, err)
+		require.Equal(t, len(hashes), len(resp))
+		require.DeepEqual(t, want, resp[0])
+		require.Equal(t, true, resp[1] == nil)
+		require.DeepEqual(t, want, resp[2])
+
+		_, err = client.GetPayloadBodiesByHash(ctx, make([]common.Hash, MaxPayloadBodiesRequest+1))
+		require.ErrorContains(t, "at most", err)
+	})
+	t.Run(GetPayloadBodiesByRangeMethod, func(t *testing.T) {
+		want, ok := fix["ExecutionPayloadBody"].(*pb.ExecutionPayloadBodyV1)
+		require.Equal(t, true, ok)
+		resp, err := client.GetPayloadBodiesByRange(ctx, 1, 3)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(resp))
+		for _, body := range resp {
+			require.DeepEqual(t, want, body)
+		}
+
+		_, err = client.GetPayloadBodiesByRange(ctx, 0, 3)
+		require.ErrorContains(t, "greater than zero", err)
+		_, err = client.GetPayloadBodiesByRange(ctx, 1, MaxPayloadBodiesRequest+1)
+		require.ErrorContains(t, "at most", err)
+	})
 	t.Run(ExecutionBlockByNumberMethod, func(t *testing.T) {
 		want, ok := fix["ExecutionBlock"].(*pb.ExecutionBlock)
 		require.Equal(t, true, ok)
@@ -72,6 +152,61 @@ func TestClient_IPC(t *testing.T) {
 		require.NoError(t, err)
 		require.DeepEqual(t, want, resp)
 	})
+	t.Run(ExecutionBlockByNumberMethod+" batch", func(t *testing.T) {
+		want, ok := fix["ExecutionBlock"].(*pb.ExecutionBlock)
+		require.Equal(t, true, ok)
+		args := []uint64{1, 2}
+		resp, err := client.ExecutionBlocksByNumbers(ctx, args)
+		require.NoError(t, err)
+		require.Equal(t, len(args), len(resp))
+		for _, blk := range resp {
+			require.DeepEqual(t, want, blk)
+		}
+	})
+}
+
+func TestClient_HTTP_ExecutionBlocksByNumbers(t *testing.T) {
+	ctx := context.Background()
+	want, ok := fixtures()["ExecutionBlock"].(*pb.ExecutionBlock)
+	require.Equal(t, true, ok)
+	numbers := []uint64{1, 2}
+	numRequests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+		w.Header().Set("Content-Type", "application/json")
+		defer func() {
+			require.NoError(t, r.Body.Close())
+		}()
+		var reqs []map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&reqs))
+		// We expect all lookups to be sent within a single batch request.
+		require.Equal(t, len(numbers), len(reqs))
+		resps := make([]map[string]interface{}, len(reqs))
+		for i, req := range reqs {
+			require.Equal(t, ExecutionBlockByNumberMethod, req["method"])
+			resps[i] = map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      req["id"],
+				"result":  want,
+			}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resps))
+	}))
+	defer srv.Close()
+
+	rpcClient, err := rpc.DialHTTP(srv.URL)
+	require.NoError(t, err)
+	defer rpcClient.Close()
+	client := &Client{}
+	client.rpc = rpcClient
+
+	resp, err := client.ExecutionBlocksByNumbers(ctx, numbers)
+	require.NoError(t, err)
+	require.Equal(t, 1, numRequests)
+	require.Equal(t, len(numbers), len(resp))
+	for _, blk := range resp {
+		require.DeepEqual(t, want, blk)
+	}
 }
 
 func TestClient_HTTP(t *testing.T) {
@@ -393,6 +528,73 @@ func newTestIPCServer(t *testing.T) *rpc.Server {
 	return server
 }
 
+// jsonError is the error object of a JSON-RPC response.
+type jsonError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// errJSONMethodNotFound is the JSON-RPC error of the methods a test server does not serve.
+var errJSONMethodNotFound = &jsonError{Code: -32601, Message: "method not found"}
+
+// anyMethod is the key of the handler serving the methods which have no handler of their own.
+const anyMethod = "*"
+
+// jsonRPCHandlers maps JSON-RPC methods to the result or the error of their requests.
+type jsonRPCHandlers map[string]func(params json.RawMessage) (interface{}, *jsonError)
+
+// Returns a handler serving single or batched JSON-RPC requests with the handler of their method,
+// or the anyMethod handler. Requests of other methods get a method not found error.
+func jsonRPCHandler(t *testing.T, handlers jsonRPCHandlers) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		respond := func(msg json.RawMessage) map[string]interface{} {
+			req := struct {
+				ID     json.RawMessage `json:"id"`
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}{}
+			require.NoError(t, json.Unmarshal(msg, &req))
+			resp := map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+			}
+			handler, ok := handlers[req.Method]
+			if !ok {
+				handler, ok = handlers[anyMethod]
+			}
+			if !ok {
+				resp["error"] = errJSONMethodNotFound
+				return resp
+			}
+			if result, jsonErr := handler(req.Params); jsonErr != nil {
+				resp["error"] = jsonErr
+			} else {
+				resp["result"] = result
+			}
+			return resp
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+			var msgs []json.RawMessage
+			require.NoError(t, json.Unmarshal(body, &msgs))
+			resps := make([]map[string]interface{}, len(msgs))
+			for i, msg := range msgs {
+				resps[i] = respond(msg)
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(resps))
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(respond(body)))
+	})
+}
+
+// Returns a server serving JSON-RPC requests with the given handlers, see jsonRPCHandler.
+func newJSONRPCServer(t *testing.T, handlers jsonRPCHandlers) *httptest.Server {
+	return httptest.NewServer(jsonRPCHandler(t, handlers))
+}
+
 func fixtures() map[string]interface{} {
 	foo := bytesutil.ToBytes32([]byte("foo"))
 	bar := bytesutil.PadTo([]byte("bar"), 20)
@@ -454,9 +656,54 @@ func fixtures() map[string]interface{} {
 		Status:    status,
 		PayloadId: &id,
 	}
+	executionPayloadCapellaFixture := pb.ExecutionPayloadCapellaFromV1(executionPayloadFixture)
+	executionPayloadCapellaFixture.Withdrawals = []*pb.Withdrawal{{
+		Index:          1,
+		ValidatorIndex: 2,
+		Address:        bar,
+		Amount:         3,
+	}}
+	executionPayloadCapellaWithValue := &pb.ExecutionPayloadCapellaWithValue{
+		Payload:    executionPayloadCapellaFixture,
+		BlockValue: big.NewInt(100).Bytes(),
+	}
+	executionPayloadDenebWithBlobs := &pb.ExecutionPayloadDenebWithValueAndBlobsBundle{
+		Payload: &pb.ExecutionPayloadDeneb{
+			ParentHash:    foo[:],
+			FeeRecipient:  bar,
+			StateRoot:     foo[:],
+			ReceiptsRoot:  foo[:],
+			LogsBloom:     baz,
+			PrevRandao:    foo[:],
+			BlockNumber:   1,
+			GasLimit:      1,
+			GasUsed:       1,
+			Timestamp:     1,
+			ExtraData:     foo[:],
+			BaseFeePerGas: bytesutil.PadTo(baseFeePerGas.Bytes(), fieldparams.RootLength),
+			BlockHash:     foo[:],
+			Transactions:  [][]byte{foo[:]},
+			Withdrawals:   executionPayloadCapellaFixture.Withdrawals,
+			BlobGasUsed:   2,
+			ExcessBlobGas: 3,
+		},
+		BlockValue: big.NewInt(100).Bytes(),
+		BlobsBundle: &pb.BlobsBundle{
+			KzgCommitments: [][]byte{bytesutil.PadTo([]byte("commitment"), fieldparams.BLSPubkeyLength)},
+			Proofs:         [][]byte{bytesutil.PadTo([]byte("proof"), fieldparams.BLSPubkeyLength)},
+			Blobs:          [][]byte{bytesutil.PadTo([]byte("blob"), 1024)},
+		},
+		ShouldOverrideBuilder: true,
+	}
+	payloadBody := &pb.ExecutionPayloadBodyV1{
+		Transactions: [][]byte{foo[:]},
+	}
 	return map[string]interface{}{
 		"ExecutionBlock":            executionBlock,
 		"ExecutionPayload":          executionPayloadFixture,
+		"ExecutionPayloadBody":      payloadBody,
+		"ExecutionPayloadCapella":   executionPayloadCapellaWithValue,
+		"ExecutionPayloadDeneb":     executionPayloadDenebWithBlobs,
 		"PayloadStatus":             status,
 		"ForkchoiceUpdatedResponse": forkChoiceResp,
 	}
@@ -499,6 +746,109 @@ func (*testEngineService) GetPayloadV1(
 	return item
 }
 
+func (*testEngineService) GetPayloadV2(
+	_ context.Context, _ pb.PayloadIDBytes,
+) *pb.ExecutionPayloadCapellaWithValue {
+	fix := fixtures()
+	item, ok := fix["ExecutionPayloadCapella"].(*pb.ExecutionPayloadCapellaWithValue)
+	if !ok {
+		panic("not found")
+	}
+	return item
+}
+
+func (*testEngineService) ForkchoiceUpdatedV2(
+	_ context.Context, _ *pb.ForkchoiceState, _ *pb.PayloadAttributesV2,
+) *ForkchoiceUpdatedResponse {
+	fix := fixtures()
+	item, ok := fix["ForkchoiceUpdatedResponse"].(*ForkchoiceUpdatedResponse)
+	if !ok {
+		panic("not found")
+	}
+	return item
+}
+
+func (*testEngineService) NewPayloadV2(
+	_ context.Context, _ *pb.ExecutionPayloadCapella,
+) *pb.PayloadStatus {
+	fix := fixtures()
+	item, ok := fix["PayloadStatus"].(*pb.PayloadStatus)
+	if !ok {
+		panic("not found")
+	}
+	return item
+}
+
+func (*testEngineService) GetPayloadV3(
+	_ context.Context, _ pb.PayloadIDBytes,
+) *pb.ExecutionPayloadDenebWithValueAndBlobsBundle {
+	fix := fixtures()
+	item, ok := fix["ExecutionPayloadDeneb"].(*pb.ExecutionPayloadDenebWithValueAndBlobsBundle)
+	if !ok {
+		panic("not found")
+	}
+	return item
+}
+
+// Reports the payload as invalid unless the versioned hashes match the fixture's blobs bundle.
+func (*testEngineService) NewPayloadV3(
+	_ context.Context, _ *pb.ExecutionPayloadDeneb, versionedHashes []common.Hash, _ common.Hash,
+) *pb.PayloadStatus {
+	fix := fixtures()
+	item, ok := fix["PayloadStatus"].(*pb.PayloadStatus)
+	if !ok {
+		panic("not found")
+	}
+	bundle, ok := fix["ExecutionPayloadDeneb"].(*pb.ExecutionPayloadDenebWithValueAndBlobsBundle)
+	if !ok {
+		panic("not found")
+	}
+	want := KzgCommitmentsToVersionedHashes(bundle.BlobsBundle.KzgCommitments)
+	if len(want) != len(versionedHashes) {
+		return &pb.PayloadStatus{Status: pb.PayloadStatus_INVALID}
+	}
+	for i := range want {
+		if want[i] != versionedHashes[i] {
+			return &pb.PayloadStatus{Status: pb.PayloadStatus_INVALID}
+		}
+	}
+	return item
+}
+
+// Returns a payload body for every requested hash, except for the zero hash
+// which stands in for a block unknown to the execution node.
+func (*testEngineService) GetPayloadBodiesByHashV1(
+	_ context.Context, hashes []common.Hash,
+) []*pb.ExecutionPayloadBodyV1 {
+	fix := fixtures()
+	item, ok := fix["ExecutionPayloadBody"].(*pb.ExecutionPayloadBodyV1)
+	if !ok {
+		panic("not found")
+	}
+	bodies := make([]*pb.ExecutionPayloadBodyV1, len(hashes))
+	for i, h := range hashes {
+		if h != (common.Hash{}) {
+			bodies[i] = item
+		}
+	}
+	return bodies
+}
+
+func (*testEngineService) GetPayloadBodiesByRangeV1(
+	_ context.Context, _, count hexutil.Uint64,
+) []*pb.ExecutionPayloadBodyV1 {
+	fix := fixtures()
+	item, ok := fix["ExecutionPayloadBody"].(*pb.ExecutionPayloadBodyV1)
+	if !ok {
+		panic("not found")
+	}
+	bodies := make([]*pb.ExecutionPayloadBodyV1, count)
+	for i := range bodies {
+		bodies[i] = item
+	}
+	return bodies
+}
+
 func (*testEngineService) ForkchoiceUpdatedV1(
 	_ context.Context, _ *pb.ForkchoiceState, _ *pb.PayloadAttributes,
 ) *ForkchoiceUpdatedResponse {
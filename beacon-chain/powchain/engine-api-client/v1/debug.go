@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultDebugMaxTransactions is the default number of transactions of a payload which
+// are logged in debug mode, as payloads may contain thousands of them.
+const DefaultDebugMaxTransactions = 4
+
+// Logs the JSON-RPC request and response of an engine API call when debug mode is enabled.
+func (c *Client) logDebug(method string, args []interface{}, result interface{}, err error) {
+	if c.cfg == nil || !c.cfg.debug {
+		return
+	}
+	fields := logrus.Fields{
+		"method":  method,
+		"request": debugJSON(args, c.cfg.debugMaxTransactions),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	} else {
+		fields["response"] = debugJSON(result, c.cfg.debugMaxTransactions)
+	}
+	log.WithFields(fields).Info("Engine API call")
+}
+
+// Encodes a value as JSON, with lists of transactions truncated to at most maxTxs entries.
+// A zero maxTxs disables the truncation.
+func debugJSON(v interface{}, maxTxs int) string {
+	enc, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("could not encode as JSON: %v", err)
+	}
+	if maxTxs <= 0 {
+		return string(enc)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(enc, &decoded); err != nil {
+		return string(enc)
+	}
+	truncated, err := json.Marshal(truncateTransactions(decoded, maxTxs))
+	if err != nil {
+		return string(enc)
+	}
+	return string(truncated)
+}
+
+// Replaces the transactions beyond the first maxTxs of every "transactions" list in a
+// decoded JSON value with a note of how many were left out.
+func truncateTransactions(v interface{}, maxTxs int) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if txs, ok := item.([]interface{}); ok && k == "transactions" && len(txs) > maxTxs {
+				omitted := len(txs) - maxTxs
+				val[k] = append(txs[:maxTxs:maxTxs], fmt.Sprintf("... %d more transactions", omitted))
+				continue
+			}
+			val[k] = truncateTransactions(item, maxTxs)
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = truncateTransactions(item, maxTxs)
+		}
+		return val
+	default:
+		return v
+	}
+}
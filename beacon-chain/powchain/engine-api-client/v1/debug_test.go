@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func Test_debugJSON(t *testing.T) {
+	v := map[string]interface{}{
+		"blockNumber":  "0x1",
+		"transactions": []string{"0x01", "0x02", "0x03"},
+		"nested": []interface{}{
+			map[string]interface{}{"transactions": []string{"0x04", "0x05"}},
+		},
+	}
+	require.Equal(
+		t,
+		`{"blockNumber":"0x1","nested":[{"transactions":["0x04","... 1 more transactions"]}],"transactions":["0x01","... 2 more transactions"]}`,
+		debugJSON(v, 1),
+	)
+	require.Equal(
+		t,
+		`{"blockNumber":"0x1","nested":[{"transactions":["0x04","0x05"]}],"transactions":["0x01","0x02","0x03"]}`,
+		debugJSON(v, 0),
+	)
+}
+
+func TestClient_DebugLogging(t *testing.T) {
+	hook := logTest.NewGlobal()
+	server := newTestIPCServer(t)
+	defer server.Stop()
+	rpcClient := rpc.DialInProc(server)
+	defer rpcClient.Close()
+	client := &Client{cfg: defaultConfig(), rpc: rpcClient}
+	ctx := context.Background()
+
+	_, err := client.LatestExecutionBlock(ctx)
+	require.NoError(t, err)
+	require.LogsDoNotContain(t, hook, "Engine API call")
+
+	require.NoError(t, WithDebugLogging(2)(client))
+	payload, ok := fixtures()["ExecutionPayload"].(*pb.ExecutionPayload)
+	require.Equal(t, true, ok)
+	payload.Transactions = [][]byte{{0x01}, {0x02}, {0x03}}
+	_, err = client.NewPayload(ctx, payload)
+	require.NoError(t, err)
+	require.LogsContain(t, hook, "Engine API call")
+	require.LogsContain(t, hook, NewPayloadMethod)
+	require.LogsContain(t, hook, `\"transactions\":[\"0x01\",\"0x02\",\"... 1 more transactions\"]`)
+	require.LogsContain(t, hook, "response")
+}
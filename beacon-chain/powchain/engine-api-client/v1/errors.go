@@ -1,6 +1,11 @@
 package v1
 
-import "github.com/pkg/errors"
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+)
 
 var (
 	// ErrParse corresponds to JSON-RPC code -32700.
@@ -17,6 +22,136 @@ var (
 	ErrServer = errors.New("client error while processing request")
 	// ErrUnknownPayload corresponds to JSON-RPC code -32001.
 	ErrUnknownPayload = errors.New("payload does not exist or is not available")
+	// ErrNilPayload is returned for calls made with a nil execution payload.
+	ErrNilPayload = errors.New("nil execution payload")
+	// ErrTimeout is matched by errors returned for requests exceeding their configured deadline.
+	ErrTimeout = errors.New("engine API request timed out")
+	// ErrTransport is matched by errors returned when the execution node could not be reached,
+	// or did not respond with a valid JSON-RPC response, including timed out requests.
+	ErrTransport = errors.New("could not communicate with execution node")
+	// ErrInvalidPayloadStatus is matched by errors returned for payloads deemed invalid by the execution node.
+	ErrInvalidPayloadStatus = errors.New("payload is invalid")
+	// ErrAcceptedSyncingPayloadStatus is returned for payloads the execution node could not yet
+	// validate, as it is syncing or the payload is not part of its canonical chain.
+	ErrAcceptedSyncingPayloadStatus = errors.New("payload is accepted or syncing")
+	// ErrUnknownPayloadStatus is returned for payload statuses unknown to the client.
+	ErrUnknownPayloadStatus = errors.New("unknown payload status")
+	// ErrCircuitOpen is returned for requests short-circuited because the execution node
+	// failed too many consecutive requests.
+	ErrCircuitOpen = errors.New("execution node is offline, request was short-circuited")
+	// ErrChainIDMismatch is returned when an execution endpoint is on another chain than the beacon node.
+	ErrChainIDMismatch = errors.New("execution node is on a different chain than the beacon node")
+	// ErrTransitionConfigurationMismatch is returned when the execution node is configured with another
+	// terminal total difficulty or terminal block hash than the beacon node.
+	ErrTransitionConfigurationMismatch = errors.New("execution node transition configuration does not match the beacon node")
+	// ErrUnsupportedMethod is returned for calls of engine API methods the execution node
+	// advertised it does not support.
+	ErrUnsupportedMethod = errors.New("engine API method is not supported by the execution node")
 	// ErrUnsupportedScheme for unsupported URL schemes.
-	ErrUnsupportedScheme = errors.New("unsupported url scheme, only http(s) and ipc are supported")
+	ErrUnsupportedScheme = errors.New("unsupported url scheme, only http(s), ws(s) and ipc are supported")
 )
+
+// EngineError is a JSON-RPC level error returned by the execution node. It wraps the sentinel
+// error corresponding to its code, if any, such that it can be matched with errors.Is.
+type EngineError struct {
+	Code    int
+	Message string
+	// Data is the optional data payload of the error, as sent by the execution node.
+	Data interface{}
+	err  error
+}
+
+// Error satisfies the error interface.
+func (e *EngineError) Error() string {
+	msg := e.Message
+	if e.err != nil {
+		msg = e.err.Error()
+	}
+	if e.Data != nil {
+		return fmt.Sprintf("%s: %v", msg, e.Data)
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error corresponding to the error code.
+func (e *EngineError) Unwrap() error {
+	return e.err
+}
+
+// ErrorCode returns the JSON-RPC error code.
+func (e *EngineError) ErrorCode() int {
+	return e.Code
+}
+
+// ErrorData returns the data payload of the error.
+func (e *EngineError) ErrorData() interface{} {
+	return e.Data
+}
+
+// TransportError is returned when a request could not be sent to the execution
+// node or its response could not be read.
+type TransportError struct {
+	Err error
+}
+
+// Error satisfies the error interface.
+func (e *TransportError) Error() string {
+	return "got an unexpected error: " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// Is allows matching a TransportError against ErrTransport with errors.Is.
+func (e *TransportError) Is(target error) bool {
+	return target == ErrTransport
+}
+
+// InvalidPayloadError is returned for payloads deemed invalid by the execution node.
+type InvalidPayloadError struct {
+	Status pb.PayloadStatus_Status
+	// LatestValidHash is the hash of the most recent valid ancestor of the invalid payload,
+	// nil if the execution node could not determine it.
+	LatestValidHash []byte
+	ValidationError string
+}
+
+// Error satisfies the error interface.
+func (e *InvalidPayloadError) Error() string {
+	msg := fmt.Sprintf("%s: status %s, latest valid hash %#x", ErrInvalidPayloadStatus, e.Status, e.LatestValidHash)
+	if e.ValidationError != "" {
+		msg += ": " + e.ValidationError
+	}
+	return msg
+}
+
+// Is allows matching an InvalidPayloadError against ErrInvalidPayloadStatus with errors.Is.
+func (e *InvalidPayloadError) Is(target error) bool {
+	return target == ErrInvalidPayloadStatus
+}
+
+// PayloadStatusError converts a payload status returned by the execution node into an error,
+// allowing callers to distinguish invalid payloads from those the execution node could not
+// validate yet. It returns nil for valid payloads, ErrAcceptedSyncingPayloadStatus for accepted
+// or syncing payloads and an *InvalidPayloadError for invalid ones.
+func PayloadStatusError(status *pb.PayloadStatus) error {
+	if status == nil {
+		return errors.New("nil payload status")
+	}
+	switch status.Status {
+	case pb.PayloadStatus_VALID:
+		return nil
+	case pb.PayloadStatus_SYNCING, pb.PayloadStatus_ACCEPTED:
+		return ErrAcceptedSyncingPayloadStatus
+	case pb.PayloadStatus_INVALID, pb.PayloadStatus_INVALID_BLOCK_HASH, pb.PayloadStatus_INVALID_TERMINAL_BLOCK:
+		return &InvalidPayloadError{
+			Status:          status.Status,
+			LatestValidHash: status.LatestValidHash,
+			ValidationError: status.ValidationError,
+		}
+	default:
+		return errors.Wrapf(ErrUnknownPayloadStatus, "%s", status.Status)
+	}
+}
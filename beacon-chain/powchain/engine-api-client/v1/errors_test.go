@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestHandleRPCError_Typed(t *testing.T) {
+	err := handleRPCError(&customError{code: -32601})
+	require.ErrorIs(t, err, ErrMethodNotFound)
+	var engineErr *EngineError
+	require.Equal(t, true, errors.As(err, &engineErr))
+	require.Equal(t, -32601, engineErr.ErrorCode())
+
+	data := map[string]interface{}{"err": "invalid payload"}
+	err = handleRPCError(&dataError{code: -32000, data: data})
+	require.ErrorIs(t, err, ErrServer)
+	require.Equal(t, true, errors.As(err, &engineErr))
+	require.DeepEqual(t, data, engineErr.ErrorData())
+
+	// Codes outside of the specification are still surfaced with their code.
+	err = handleRPCError(&customError{code: -38001})
+	require.Equal(t, true, errors.As(err, &engineErr))
+	require.Equal(t, -38001, engineErr.Code)
+
+	err = handleRPCError(errors.New("connection refused"))
+	require.ErrorIs(t, err, ErrTransport)
+	require.ErrorContains(t, "connection refused", err)
+
+	err = handleRPCError(&TimeoutError{Method: NewPayloadMethod})
+	require.ErrorIs(t, err, ErrTimeout)
+	require.ErrorIs(t, err, ErrTransport)
+}
+
+func TestPayloadStatusError(t *testing.T) {
+	lvh := []byte{'a'}
+	require.NoError(t, PayloadStatusError(&pb.PayloadStatus{Status: pb.PayloadStatus_VALID}))
+	require.ErrorIs(t, PayloadStatusError(&pb.PayloadStatus{Status: pb.PayloadStatus_SYNCING}), ErrAcceptedSyncingPayloadStatus)
+	require.ErrorIs(t, PayloadStatusError(&pb.PayloadStatus{Status: pb.PayloadStatus_ACCEPTED}), ErrAcceptedSyncingPayloadStatus)
+	require.ErrorContains(t, "nil payload status", PayloadStatusError(nil))
+	require.ErrorIs(t, PayloadStatusError(&pb.PayloadStatus{Status: 100}), ErrUnknownPayloadStatus)
+
+	for _, status := range []pb.PayloadStatus_Status{
+		pb.PayloadStatus_INVALID, pb.PayloadStatus_INVALID_BLOCK_HASH, pb.PayloadStatus_INVALID_TERMINAL_BLOCK,
+	} {
+		err := PayloadStatusError(&pb.PayloadStatus{
+			Status:          status,
+			LatestValidHash: lvh,
+			ValidationError: "bad block",
+		})
+		require.ErrorIs(t, err, ErrInvalidPayloadStatus)
+		var invalidErr *InvalidPayloadError
+		require.Equal(t, true, errors.As(err, &invalidErr))
+		require.DeepEqual(t, lvh, invalidErr.LatestValidHash)
+		require.Equal(t, status, invalidErr.Status)
+		require.ErrorContains(t, "bad block", err)
+	}
+}
@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// Returns true for the read-only eth namespace methods which may be served by a separate
+// endpoint, such as a hosted provider, rather than the authenticated engine endpoint.
+func isEthReadMethod(method string) bool {
+	switch method {
+	case ExecutionBlockByHashMethod, ExecutionBlockByNumberMethod, LogsMethod:
+		return true
+	default:
+		return false
+	}
+}
+
+// Dials the endpoint serving read-only eth namespace requests. As it may be operated by a third
+// party, neither the JWT secret nor the custom headers of the engine endpoint are sent to it.
+func dialEthEndpoint(ctx context.Context, rawURL string) (*rpc.Client, error) {
+	client, err := rpc.DialContext(ctx, rawURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not dial eth endpoint %s", redactURL(rawURL))
+	}
+	return client, nil
+}
+
+// Executes a read-only eth namespace request against the dedicated eth endpoint, if configured.
+// If that endpoint cannot be reached, the request is sent to the engine endpoint instead.
+func (c *Client) executeRead(ctx context.Context, method string, request func(context.Context, *rpc.Client) error) error {
+	if c.eth == nil || !isEthReadMethod(method) {
+		return c.execute(ctx, method, request)
+	}
+	err := c.withTimeout(ctx, method, c.eth, request)
+	if _, isRPCError := err.(rpc.Error); err == nil || isRPCError || ctx.Err() != nil {
+		return err
+	}
+	log.WithError(err).WithField("method", method).Debug("Eth endpoint request failed, sending it to the execution endpoint")
+	return c.execute(ctx, method, request)
+}
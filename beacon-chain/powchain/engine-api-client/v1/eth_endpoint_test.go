@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestClient_EthEndpointServesBlockLookups(t *testing.T) {
+	ctx := context.Background()
+	engineLookups := int32(0)
+	engineSrv := newBlockByHashTestServer(t, &engineLookups)
+	defer engineSrv.Close()
+	ethLookups := int32(0)
+	ethSrv := newBlockByHashTestServer(t, &ethLookups)
+	defer ethSrv.Close()
+	authorized := int32(0)
+	handler := ethSrv.Config.Handler
+	ethSrv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			atomic.AddInt32(&authorized, 1)
+		}
+		handler.ServeHTTP(w, r)
+	})
+
+	client, err := New(
+		ctx,
+		engineSrv.URL,
+		WithHealthCheckInterval(0),
+		WithBlockCacheSize(0),
+		WithJWTSecret([]byte("secret")),
+		WithEthEndpoint(ethSrv.URL),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	foo := common.BytesToHash([]byte("foo"))
+	blk, err := client.ExecutionBlockByHash(ctx, foo)
+	require.NoError(t, err)
+	require.DeepEqual(t, foo.Bytes(), blk.Hash)
+	_, err = client.ExecutionBlocksByNumbers(ctx, []uint64{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&ethLookups))
+	require.Equal(t, int32(0), atomic.LoadInt32(&engineLookups))
+	// The JWT secret of the engine endpoint is not sent to the eth endpoint.
+	require.Equal(t, int32(0), atomic.LoadInt32(&authorized))
+
+	// Lookups fall back to the engine endpoint when the eth endpoint is unavailable.
+	ethSrv.Close()
+	blk, err = client.ExecutionBlockByHash(ctx, foo)
+	require.NoError(t, err)
+	require.DeepEqual(t, foo.Bytes(), blk.Hash)
+	require.Equal(t, int32(1), atomic.LoadInt32(&engineLookups))
+}
+
+func TestClient_EthEndpointServesLogs(t *testing.T) {
+	ctx := context.Background()
+	newServer := func(numRequests *int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if isCapabilitiesRequest(t, r) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			atomic.AddInt32(numRequests, 1)
+			var req map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, LogsMethod, req["method"])
+			params, ok := req["params"].([]interface{})
+			require.Equal(t, true, ok)
+			arg, ok := params[0].(map[string]interface{})
+			require.Equal(t, true, ok)
+			require.Equal(t, "0x1", arg["fromBlock"])
+			require.Equal(t, "latest", arg["toBlock"])
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      req["id"],
+				"result":  []interface{}{},
+			}))
+		}))
+	}
+	engineRequests := int32(0)
+	engineSrv := newServer(&engineRequests)
+	defer engineSrv.Close()
+	ethRequests := int32(0)
+	ethSrv := newServer(&ethRequests)
+	defer ethSrv.Close()
+
+	client, err := New(ctx, engineSrv.URL, WithHealthCheckInterval(0), WithEthEndpoint(ethSrv.URL))
+	require.NoError(t, err)
+	defer client.Close()
+
+	logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(1)})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(logs))
+	require.Equal(t, int32(1), atomic.LoadInt32(&ethRequests))
+	require.Equal(t, int32(0), atomic.LoadInt32(&engineRequests))
+}
@@ -0,0 +1,227 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// How long to wait before probing a failed primary endpoint again.
+	primaryRecheckInterval = 30 * time.Second
+	// Deadline for a single health check call against an endpoint.
+	healthCheckTimeout = 2 * time.Second
+)
+
+// endpointConn is a dialed connection to a single execution node.
+type endpointConn struct {
+	url         string
+	rpc         *rpc.Client
+	lastFailure time.Time
+	// Engine API methods advertised by the execution node, nil until capabilities were exchanged.
+	capabilities map[string]bool
+	// Incremented whenever a capability exchange with the endpoint starts.
+	capabilitiesRefresh uint64
+}
+
+// Dials an engine API endpoint, supporting http(s), ws(s) and ipc URL schemes.
+func (c *Client) dial(ctx context.Context, rawURL string) (*rpc.Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return rpc.DialHTTPWithClient(rawURL, c.authenticatedHTTPClient())
+	case "ws", "wss":
+		return c.dialWebsocket(ctx, rawURL)
+	case "":
+		return rpc.DialIPC(ctx, rawURL)
+	default:
+		return nil, errors.Wrapf(ErrUnsupportedScheme, "%q", u.Scheme)
+	}
+}
+
+// Returns the RPC connection of the currently active endpoint.
+func (c *Client) activeRPC() *rpc.Client {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.rpc
+}
+
+// CurrentEndpoint returns the URL of the execution endpoint currently in use,
+// with any user credentials redacted.
+func (c *Client) CurrentEndpoint() string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if len(c.endpoints) == 0 {
+		return ""
+	}
+	return redactURL(c.endpoints[c.activeIdx].url)
+}
+
+// Sends a JSON-RPC request to the active endpoint, failing over to other endpoints if needed.
+func (c *Client) call(ctx context.Context, result interface{}, method string, args ...interface{}) (err error) {
+	if err := c.checkCapability(method); err != nil {
+		return err
+	}
+	if isRateLimitedMethod(method) {
+		if err := c.limiter.wait(ctx, 1); err != nil {
+			return err
+		}
+	}
+	start := time.Now()
+	defer func() {
+		observeRequest(method, start, err)
+		c.latency.observe(method, time.Since(start))
+		c.logDebug(method, args, result, err)
+	}()
+	return c.executeRead(ctx, method, func(ctx context.Context, client *rpc.Client) error {
+		return client.CallContext(ctx, result, method, args...)
+	})
+}
+
+// Executes a request against the active endpoint, enforcing the deadline configured for the
+// given method. If the request fails due to a transport level error, such as a timeout or a
+// refused connection, the request is retried against the remaining configured endpoints in
+// order of priority and the first one to respond becomes the active endpoint.
+func (c *Client) execute(ctx context.Context, method string, request func(context.Context, *rpc.Client) error) (err error) {
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	defer func() {
+		c.recordRequestOutcome(ctx, err)
+	}()
+	c.maybeRecoverPrimary()
+	err = c.withTimeout(ctx, method, c.activeRPC(), request)
+	if !c.shouldFailover(ctx, err) {
+		return err
+	}
+	c.lock.RLock()
+	failedIdx := c.activeIdx
+	candidates := make([]int, 0, len(c.endpoints))
+	for i := range c.endpoints {
+		if i != failedIdx {
+			candidates = append(candidates, i)
+		}
+	}
+	c.lock.RUnlock()
+	c.markFailed(failedIdx)
+
+	for _, i := range candidates {
+		log.WithError(err).WithField("endpoint", redactURL(c.endpoints[i].url)).Warn(
+			"Engine API request failed, failing over to next execution endpoint",
+		)
+		err = c.withTimeout(ctx, method, c.endpointRPC(i), request)
+		if !c.shouldFailover(ctx, err) {
+			c.setActive(i)
+			return err
+		}
+		c.markFailed(i)
+	}
+	return err
+}
+
+// Determines whether an error returned by an endpoint is a transport failure which warrants
+// trying another endpoint. Errors returned by the JSON-RPC server itself are passed through, as
+// are errors caused by the caller's context being canceled or timing out.
+func (c *Client) shouldFailover(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	c.lock.RLock()
+	numEndpoints := len(c.endpoints)
+	c.lock.RUnlock()
+	if numEndpoints < 2 {
+		return false
+	}
+	_, isRPCError := err.(rpc.Error)
+	return !isRPCError
+}
+
+func (c *Client) markFailed(idx int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.endpoints[idx].lastFailure = time.Now()
+}
+
+func (c *Client) setActive(idx int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.activeIdx == idx {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"previous": redactURL(c.endpoints[c.activeIdx].url),
+		"current":  redactURL(c.endpoints[idx].url),
+	}).Info("Switched active execution endpoint")
+	c.activeIdx = idx
+	c.rpc = c.endpoints[idx].rpc
+	endpointFailoverCount.Inc()
+	updateActiveEndpointMetric(c.endpoints, idx)
+	c.refreshCapabilities()
+}
+
+// If a fallback endpoint is in use and the primary endpoint has not failed recently,
+// the primary endpoint is health checked in the background and reinstated if it responds.
+func (c *Client) maybeRecoverPrimary() {
+	// Most requests are served by the primary endpoint, so the check is done under
+	// a read lock first to avoid serializing every request on the write lock.
+	c.lock.RLock()
+	check := c.shouldRecoverPrimary()
+	c.lock.RUnlock()
+	if !check {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.shouldRecoverPrimary() {
+		return
+	}
+	c.checkingPrimary = true
+	go func() {
+		defer func() {
+			c.lock.Lock()
+			c.checkingPrimary = false
+			c.lock.Unlock()
+		}()
+		if err := checkEndpointHealth(c.endpointRPC(0)); err != nil {
+			log.WithError(err).Debug("Primary execution endpoint is still unavailable")
+			c.markFailed(0)
+			return
+		}
+		c.setActive(0)
+	}()
+}
+
+// Reports whether the primary endpoint should be health checked. The caller must hold the lock.
+func (c *Client) shouldRecoverPrimary() bool {
+	return c.activeIdx != 0 && !c.checkingPrimary && time.Since(c.endpoints[0].lastFailure) >= primaryRecheckInterval
+}
+
+// Checks whether an endpoint is reachable by requesting its chain ID. Any
+// JSON-RPC response, including an error object, shows the endpoint is reachable.
+func checkEndpointHealth(client *rpc.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	var result json.RawMessage
+	err := client.CallContext(ctx, &result, ChainIDMethod)
+	if _, isRPCError := err.(rpc.Error); isRPCError {
+		return nil
+	}
+	return err
+}
+
+// Strips user credentials and query parameters from URLs so they are safe to log.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return u.Scheme + "://" + u.Host + u.Path
+}
@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultSlowCallThreshold is the latency above which newPayload and forkchoiceUpdated
+	// calls are reported as slow, as they delay the import and proposal of blocks.
+	DefaultSlowCallThreshold = 2 * time.Second
+	// Number of most recent calls of every method the latency percentiles are computed over.
+	latencyWindowSize = 256
+)
+
+// LatencyStats summarizes the latency of the most recent calls of an engine API method.
+type LatencyStats struct {
+	Samples int
+	P95     time.Duration
+	Max     time.Duration
+}
+
+// LatencyFetcher retrieves the latency of the most recent engine API calls.
+type LatencyFetcher interface {
+	LatencyStats() map[string]LatencyStats
+}
+
+// latencyTracker keeps a rolling window of the latencies of the calls of every
+// engine API method, and reports calls which are slow enough to cause late blocks.
+// The full latency distribution of every method is exported by the
+// engine_api_request_latency_seconds histogram.
+type latencyTracker struct {
+	threshold time.Duration
+	lock      sync.Mutex
+	windows   map[string]*latencyWindow
+}
+
+// latencyWindow is a ring buffer of the most recent latencies of a method.
+type latencyWindow struct {
+	samples []time.Duration
+	next    int
+}
+
+func newLatencyTracker(threshold time.Duration) *latencyTracker {
+	return &latencyTracker{
+		threshold: threshold,
+		windows:   make(map[string]*latencyWindow),
+	}
+}
+
+// Records the latency of a call, logging a warning if a call of a block processing
+// method exceeds the slow call threshold.
+func (t *latencyTracker) observe(method string, latency time.Duration) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	w, ok := t.windows[method]
+	if !ok {
+		w = &latencyWindow{samples: make([]time.Duration, 0, latencyWindowSize)}
+		t.windows[method] = w
+	}
+	if len(w.samples) < latencyWindowSize {
+		w.samples = append(w.samples, latency)
+	} else {
+		w.samples[w.next] = latency
+	}
+	w.next = (w.next + 1) % latencyWindowSize
+	t.lock.Unlock()
+
+	if t.threshold <= 0 || latency <= t.threshold || !isBlockProcessingMethod(method) {
+		return
+	}
+	slowRequestCount.WithLabelValues(method).Inc()
+	log.WithFields(logrus.Fields{
+		"method":    method,
+		"latency":   latency,
+		"threshold": t.threshold,
+	}).Warn("Slow engine API call, the execution node may be causing late blocks")
+}
+
+// Returns the latency statistics of every method called so far.
+func (t *latencyTracker) stats() map[string]LatencyStats {
+	res := make(map[string]LatencyStats)
+	if t == nil {
+		return res
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for method, w := range t.windows {
+		sorted := make([]time.Duration, len(w.samples))
+		copy(sorted, w.samples)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		// Nearest-rank percentile.
+		rank := (len(sorted)*95 + 99) / 100
+		res[method] = LatencyStats{
+			Samples: len(sorted),
+			P95:     sorted[rank-1],
+			Max:     sorted[len(sorted)-1],
+		}
+	}
+	return res
+}
+
+// Returns true for the methods on the critical path of importing and proposing blocks.
+func isBlockProcessingMethod(method string) bool {
+	return strings.HasPrefix(method, "engine_newPayload") || strings.HasPrefix(method, "engine_forkchoiceUpdated")
+}
+
+// LatencyStats returns the latency statistics of the most recent calls of every engine API
+// method called so far, by JSON-RPC method.
+func (c *Client) LatencyStats() map[string]LatencyStats {
+	return c.latency.stats()
+}
@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestLatencyTracker_Stats(t *testing.T) {
+	tracker := newLatencyTracker(0)
+	for i := 1; i <= 100; i++ {
+		tracker.observe(GetPayloadMethod, time.Duration(i)*time.Millisecond)
+	}
+	stats := tracker.stats()
+	require.Equal(t, 1, len(stats))
+	require.Equal(t, LatencyStats{Samples: 100, P95: 95 * time.Millisecond, Max: 100 * time.Millisecond}, stats[GetPayloadMethod])
+
+	// Only the most recent calls are taken into account.
+	for i := 0; i < latencyWindowSize; i++ {
+		tracker.observe(GetPayloadMethod, time.Millisecond)
+	}
+	require.Equal(t, LatencyStats{Samples: latencyWindowSize, P95: time.Millisecond, Max: time.Millisecond}, tracker.stats()[GetPayloadMethod])
+}
+
+func TestLatencyTracker_ReportsSlowCalls(t *testing.T) {
+	hook := logTest.NewGlobal()
+	tracker := newLatencyTracker(time.Second)
+
+	tracker.observe(GetPayloadMethod, 2*time.Second)
+	tracker.observe(NewPayloadMethodV2, 500*time.Millisecond)
+	require.LogsDoNotContain(t, hook, "Slow engine API call")
+
+	before := testutil.ToFloat64(slowRequestCount.WithLabelValues(NewPayloadMethodV2))
+	tracker.observe(NewPayloadMethodV2, 2*time.Second)
+	require.LogsContain(t, hook, "Slow engine API call")
+	require.Equal(t, before+1, testutil.ToFloat64(slowRequestCount.WithLabelValues(NewPayloadMethodV2)))
+}
@@ -0,0 +1,5 @@
+package v1
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "engine-api-client")
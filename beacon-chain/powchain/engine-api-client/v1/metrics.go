@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	activeEndpointGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "engine_api_active_endpoint",
+		Help: "Set to 1 for the execution endpoint currently used by the engine API client, 0 otherwise",
+	}, []string{"endpoint"})
+	connectedGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "engine_api_connected",
+		Help: "Boolean indicating whether the active execution endpoint passed its most recent health check",
+	})
+	endpointFailoverCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "engine_api_endpoint_failovers_total",
+		Help: "The number of times the engine API client switched its active execution endpoint",
+	})
+	transitionMismatchGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "engine_api_transition_configuration_mismatch",
+		Help: "Boolean indicating whether the transition configuration of the execution node differs from the beacon node",
+	})
+	circuitBreakerOpenGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "engine_api_circuit_breaker_open",
+		Help: "Boolean indicating whether engine API requests are short-circuited after consecutive failures",
+	})
+	blockCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "engine_api_block_cache_hit",
+		Help: "The number of execution blocks fetched by hash which were present in the cache",
+	})
+	blockCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "engine_api_block_cache_miss",
+		Help: "The number of execution blocks fetched by hash which were not present in the cache",
+	})
+	requestCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "engine_api_requests_total",
+		Help: "The number of JSON-RPC requests sent to the execution node, by method",
+	}, []string{"method"})
+	requestErrorCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "engine_api_request_errors_total",
+		Help: "The number of failed JSON-RPC requests sent to the execution node, by method and error code. " +
+			"Failures which are not JSON-RPC errors are labeled as timeout, circuit_open or transport errors",
+	}, []string{"method", "code"})
+	slowRequestCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "engine_api_slow_requests_total",
+		Help: "The number of newPayload and forkchoiceUpdated requests exceeding the slow call threshold, by method",
+	}, []string{"method"})
+	rateLimitedRequestCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "engine_api_rate_limited_requests_total",
+		Help: "The number of non-critical requests delayed by the engine API client's rate limiter",
+	})
+	requestLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "engine_api_request_latency_seconds",
+		Help:    "Latency of JSON-RPC requests sent to the execution node, by method",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2, 4, 8, 16},
+	}, []string{"method"})
+)
+
+// Records the outcome and latency of a JSON-RPC request.
+func observeRequest(method string, start time.Time, err error) {
+	requestCount.WithLabelValues(method).Inc()
+	requestLatency.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		requestErrorCount.WithLabelValues(method, errorCodeLabel(err)).Inc()
+	}
+}
+
+// Returns a low cardinality label describing an error returned by the RPC client.
+func errorCodeLabel(err error) string {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return strconv.Itoa(rpcErr.ErrorCode())
+	}
+	if errors.Is(err, ErrCircuitOpen) {
+		return "circuit_open"
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	var timeoutErr interface{ Timeout() bool }
+	if errors.As(err, &timeoutErr) && timeoutErr.Timeout() {
+		return "timeout"
+	}
+	return "transport"
+}
+
+func updateActiveEndpointMetric(endpoints []*endpointConn, activeIdx int) {
+	for i, e := range endpoints {
+		val := float64(0)
+		if i == activeIdx {
+			val = 1
+		}
+		activeEndpointGauge.WithLabelValues(redactURL(e.url)).Set(val)
+	}
+}
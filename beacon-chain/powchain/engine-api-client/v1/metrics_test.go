@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+type timeoutError struct{}
+
+func (*timeoutError) Error() string   { return "i/o timeout" }
+func (*timeoutError) Timeout() bool   { return true }
+func (*timeoutError) Temporary() bool { return true }
+
+func Test_errorCodeLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "json-rpc error", err: &customError{code: -32601}, want: "-32601"},
+		{name: "context deadline", err: errors.Wrap(context.DeadlineExceeded, "foo"), want: "timeout"},
+		{name: "net timeout", err: &net.OpError{Op: "read", Err: &timeoutError{}}, want: "timeout"},
+		{name: "transport", err: errors.New("connection refused"), want: "transport"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, errorCodeLabel(tt.err))
+		})
+	}
+}
+
+func TestClient_RecordsRequestMetrics(t *testing.T) {
+	server := newTestIPCServer(t)
+	defer server.Stop()
+	rpcClient := rpc.DialInProc(server)
+	defer rpcClient.Close()
+	client := &Client{rpc: rpcClient}
+	ctx := context.Background()
+
+	before := testutil.ToFloat64(requestCount.WithLabelValues(ExecutionBlockByNumberMethod))
+	_, err := client.LatestExecutionBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, before+1, testutil.ToFloat64(requestCount.WithLabelValues(ExecutionBlockByNumberMethod)))
+
+	beforeErrs := testutil.ToFloat64(requestErrorCount.WithLabelValues("eth_unknownMethod", "-32601"))
+	require.NotNil(t, client.call(ctx, nil, "eth_unknownMethod"))
+	require.Equal(t, beforeErrs+1, testutil.ToFloat64(requestErrorCount.WithLabelValues("eth_unknownMethod", "-32601")))
+}
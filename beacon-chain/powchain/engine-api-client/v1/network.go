@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Checks that an execution endpoint is on the chain expected by the beacon node, and warns if it
+// is still syncing. An error is only returned if the endpoint is on another chain: endpoints which
+// cannot be reached are merely logged, as they are health checked again later on.
+func (c *Client) checkNetwork(ctx context.Context, rawURL string, client *rpc.Client) error {
+	if c.cfg.expectedChainID == 0 {
+		return nil
+	}
+	logger := log.WithField("endpoint", redactURL(rawURL))
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	var chainID hexutil.Big
+	if err := client.CallContext(ctx, &chainID, ChainIDMethod); err != nil {
+		logger.WithError(err).Warn("Could not verify the chain ID of execution endpoint")
+		return nil
+	}
+	if got := chainID.ToInt(); !got.IsUint64() || got.Uint64() != c.cfg.expectedChainID {
+		logger.WithFields(logrus.Fields{
+			"expected": c.cfg.expectedChainID,
+			"got":      got,
+		}).Error("Execution endpoint is on a different chain than the beacon node, check the network " +
+			"flags of both the execution node and the beacon node")
+		return errors.Wrapf(
+			ErrChainIDMismatch,
+			"endpoint %s has chain ID %s, expected %d",
+			redactURL(rawURL),
+			got,
+			c.cfg.expectedChainID,
+		)
+	}
+	// eth_syncing returns false when the node is synced, and the sync progress otherwise.
+	var syncing json.RawMessage
+	if err := client.CallContext(ctx, &syncing, SyncingMethod); err != nil {
+		logger.WithError(err).Debug("Could not check the sync status of execution endpoint")
+		return nil
+	}
+	if string(syncing) != "false" {
+		logger.Warn("Execution endpoint is still syncing, block processing will be optimistic until it is synced")
+	}
+	return nil
+}
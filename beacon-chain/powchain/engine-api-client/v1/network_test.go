@@ -0,0 +1,64 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func newNetworkTestServer(t *testing.T, chainID string, syncing interface{}) *httptest.Server {
+	return newJSONRPCServer(t, jsonRPCHandlers{
+		ChainIDMethod: func(json.RawMessage) (interface{}, *jsonError) {
+			return chainID, nil
+		},
+		SyncingMethod: func(json.RawMessage) (interface{}, *jsonError) {
+			return syncing, nil
+		},
+		anyMethod: func(json.RawMessage) (interface{}, *jsonError) {
+			return nil, nil
+		},
+	})
+}
+
+func TestNew_ChecksChainID(t *testing.T) {
+	ctx := context.Background()
+	srv := newNetworkTestServer(t, "0x5", false)
+	defer srv.Close()
+
+	client, err := New(ctx, srv.URL, WithHealthCheckInterval(0), WithExpectedChainID(5))
+	require.NoError(t, err)
+	client.Close()
+
+	_, err = New(ctx, srv.URL, WithHealthCheckInterval(0), WithExpectedChainID(1))
+	require.Equal(t, true, errors.Is(err, ErrChainIDMismatch))
+
+	// Fallback endpoints are checked as well.
+	fallback := newNetworkTestServer(t, "0x1", false)
+	defer fallback.Close()
+	_, err = New(ctx, srv.URL, WithHealthCheckInterval(0), WithExpectedChainID(5), WithFallbackEndpoints([]string{fallback.URL}))
+	require.Equal(t, true, errors.Is(err, ErrChainIDMismatch))
+}
+
+func TestNew_WarnsIfExecutionNodeIsSyncing(t *testing.T) {
+	hook := logTest.NewGlobal()
+	srv := newNetworkTestServer(t, "0x5", map[string]string{"currentBlock": "0x1", "highestBlock": "0x2"})
+	defer srv.Close()
+
+	client, err := New(context.Background(), srv.URL, WithHealthCheckInterval(0), WithExpectedChainID(5))
+	require.NoError(t, err)
+	defer client.Close()
+	require.LogsContain(t, hook, "Execution endpoint is still syncing")
+}
+
+func TestNew_UnreachableEndpointChainIDNotChecked(t *testing.T) {
+	hook := logTest.NewGlobal()
+	client, err := New(context.Background(), "http://127.0.0.1:0", WithHealthCheckInterval(0), WithExpectedChainID(5))
+	require.NoError(t, err)
+	defer client.Close()
+	require.LogsContain(t, hook, "Could not verify the chain ID of execution endpoint")
+}
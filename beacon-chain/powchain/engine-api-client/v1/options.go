@@ -1,7 +1,12 @@
 package v1
 
 import (
+	"crypto/tls"
 	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 )
 
 // Option for configuring the engine API client.
@@ -9,13 +14,46 @@ type Option func(c *Client) error
 
 type config struct {
 	httpClient *http.Client
+	jwtSecret  []byte
+	headers    map[string]string
+	tlsConfig  *tls.Config
+	timeouts   Timeouts
+	// Period between health checks of the execution endpoints, zero disables them.
+	healthCheckInterval time.Duration
+	stateNotifier       statefeed.Notifier
+	// Number of consecutive failed requests tripping the circuit breaker, zero disables it.
+	circuitBreakerThreshold int
+	circuitBreakerCooldown  time.Duration
+	// Number of execution blocks cached by hash, zero disables the cache.
+	blockCacheSize int
+	// Latency above which block processing calls are reported as slow, zero disables the reports.
+	slowCallThreshold time.Duration
+	// Whether to log the requests and responses of engine API calls, and the number
+	// of transactions of payloads included in these logs.
+	debug                bool
+	debugMaxTransactions int
+	// Number of non-critical requests per second sent to the execution node, zero disables the limit.
+	rateLimit int
+	// Chain ID the execution endpoints must be on, zero disables the check.
+	expectedChainID uint64
+	// Endpoint serving read-only eth namespace requests instead of the execution endpoints, if set.
+	ethEndpoint string
+	// Execution endpoints to fail over to, in order of priority,
+	// when the primary endpoint is unavailable.
+	fallbackEndpoints []string
 }
 
 func defaultConfig() *config {
 	return &config{
-		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
-		},
+		// Deadlines are enforced per request, according to the configured timeouts.
+		httpClient:              &http.Client{},
+		timeouts:                DefaultTimeouts(),
+		healthCheckInterval:     DefaultHealthCheckInterval,
+		circuitBreakerThreshold: DefaultCircuitBreakerThreshold,
+		circuitBreakerCooldown:  DefaultCircuitBreakerCooldown,
+		blockCacheSize:          DefaultBlockCacheSize,
+		slowCallThreshold:       DefaultSlowCallThreshold,
+		rateLimit:               DefaultRateLimit,
 	}
 }
 
@@ -27,3 +65,152 @@ func WithHTTPClient(httpClient *http.Client) Option {
 		return nil
 	}
 }
+
+// WithJWTSecret allows setting a JWT secret for authenticating
+// requests to the engine API over HTTP.
+func WithJWTSecret(secret []byte) Option {
+	return func(c *Client) error {
+		if len(secret) == 0 {
+			return errors.New("empty JWT secret")
+		}
+		c.cfg.jwtSecret = secret
+		return nil
+	}
+}
+
+// WithHeaders allows setting custom HTTP headers which will be
+// attached to every request sent to the engine API over HTTP.
+func WithHeaders(headers map[string]string) Option {
+	return func(c *Client) error {
+		c.cfg.headers = headers
+		return nil
+	}
+}
+
+// WithTLSConfig allows setting the TLS configuration used to connect to https and wss
+// endpoints, such as custom root certificate authorities or a client certificate.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Client) error {
+		c.cfg.tlsConfig = cfg
+		return nil
+	}
+}
+
+// WithFallbackEndpoints allows setting execution endpoints which the client fails
+// over to, in order of priority, when the primary endpoint is unavailable.
+func WithFallbackEndpoints(endpoints []string) Option {
+	return func(c *Client) error {
+		c.cfg.fallbackEndpoints = endpoints
+		return nil
+	}
+}
+
+// WithTimeouts allows setting the deadlines enforced for engine API requests, by method.
+func WithTimeouts(timeouts Timeouts) Option {
+	return func(c *Client) error {
+		c.cfg.timeouts = timeouts
+		return nil
+	}
+}
+
+// WithHealthCheckInterval allows setting the period between health checks of the
+// execution endpoints. A zero interval disables the health checks.
+func WithHealthCheckInterval(interval time.Duration) Option {
+	return func(c *Client) error {
+		c.cfg.healthCheckInterval = interval
+		return nil
+	}
+}
+
+// WithStateNotifier allows publishing execution node connectivity changes
+// on the beacon node's state feed.
+func WithStateNotifier(notifier statefeed.Notifier) Option {
+	return func(c *Client) error {
+		c.cfg.stateNotifier = notifier
+		return nil
+	}
+}
+
+// WithCircuitBreaker allows setting the number of consecutive failed requests after which
+// requests to the execution node are short-circuited, and for how long. A zero threshold
+// disables the circuit breaker.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) error {
+		if threshold < 0 {
+			return errors.New("negative circuit breaker threshold")
+		}
+		c.cfg.circuitBreakerThreshold = threshold
+		c.cfg.circuitBreakerCooldown = cooldown
+		return nil
+	}
+}
+
+// WithBlockCacheSize allows setting the number of execution blocks fetched by hash
+// which are cached by the client. A zero size disables the cache.
+func WithBlockCacheSize(size int) Option {
+	return func(c *Client) error {
+		if size < 0 {
+			return errors.New("negative block cache size")
+		}
+		c.cfg.blockCacheSize = size
+		return nil
+	}
+}
+
+// WithDebugLogging enables logging the JSON-RPC request and response of every engine API call,
+// which is useful to diagnose interoperability issues with execution nodes. Transaction lists
+// are truncated to at most maxTransactions entries, a zero value disables the truncation.
+func WithDebugLogging(maxTransactions int) Option {
+	return func(c *Client) error {
+		if maxTransactions < 0 {
+			return errors.New("negative number of transactions to log")
+		}
+		c.cfg.debug = true
+		c.cfg.debugMaxTransactions = maxTransactions
+		return nil
+	}
+}
+
+// WithSlowCallThreshold allows setting the latency above which newPayload and forkchoiceUpdated
+// calls are reported as slow. A zero threshold disables the reports.
+func WithSlowCallThreshold(threshold time.Duration) Option {
+	return func(c *Client) error {
+		if threshold < 0 {
+			return errors.New("negative slow call threshold")
+		}
+		c.cfg.slowCallThreshold = threshold
+		return nil
+	}
+}
+
+// WithExpectedChainID allows setting the chain ID the execution endpoints must be on. Endpoints
+// are checked when the client is created, and an error is returned if any is on another chain.
+func WithExpectedChainID(chainID uint64) Option {
+	return func(c *Client) error {
+		c.cfg.expectedChainID = chainID
+		return nil
+	}
+}
+
+// WithRateLimit allows setting the number of non-critical requests per second, such as execution
+// block and payload body fetches, sent to the execution node. A zero limit disables rate limiting.
+func WithRateLimit(requestsPerSecond int) Option {
+	return func(c *Client) error {
+		if requestsPerSecond < 0 {
+			return errors.New("negative rate limit")
+		}
+		c.cfg.rateLimit = requestsPerSecond
+		return nil
+	}
+}
+
+// WithEthEndpoint allows setting a separate endpoint, such as a hosted provider, serving the
+// read-only eth namespace requests of the client, like execution block lookups, while engine API
+// requests are sent to the authenticated execution endpoint. Neither the JWT secret nor custom
+// headers are sent to this endpoint.
+func WithEthEndpoint(endpoint string) Option {
+	return func(c *Client) error {
+		c.cfg.ethEndpoint = endpoint
+		return nil
+	}
+}
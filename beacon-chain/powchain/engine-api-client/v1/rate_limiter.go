@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimit is the default number of non-critical requests per second sent to the execution node.
+const DefaultRateLimit = 100
+
+// rateLimiter is a token bucket limiting the rate of non-critical requests, such as execution block
+// scans and payload body fetches, so that they cannot starve the latency-critical newPayload and
+// forkchoiceUpdated requests of an execution node shared with other consumers. Bursts of up to one
+// second worth of requests are allowed.
+type rateLimiter struct {
+	rate   float64
+	lock   sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(requestsPerSecond int) *rateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		rate:   float64(requestsPerSecond),
+		tokens: float64(requestsPerSecond),
+		last:   time.Now(),
+	}
+}
+
+// Blocks until n requests may be sent, or the context is done. Batches larger than the
+// bucket wait for a full bucket, and the excess is paid for by subsequent requests.
+func (l *rateLimiter) wait(ctx context.Context, n int) error {
+	if l == nil {
+		return nil
+	}
+	need := float64(n)
+	if need > l.rate {
+		need = l.rate
+	}
+	limited := false
+	for {
+		l.lock.Lock()
+		now := time.Now()
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.rate {
+			l.tokens = l.rate
+		}
+		l.last = now
+		if l.tokens >= need {
+			l.tokens -= float64(n)
+			l.lock.Unlock()
+			return nil
+		}
+		delay := time.Duration((need - l.tokens) / l.rate * float64(time.Second))
+		l.lock.Unlock()
+		if !limited {
+			limited = true
+			rateLimitedRequestCount.Inc()
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Returns true for the methods which are not on the critical path of importing and proposing
+// blocks, and are therefore subject to rate limiting.
+func isRateLimitedMethod(method string) bool {
+	switch method {
+	case ExecutionBlockByHashMethod, ExecutionBlockByNumberMethod, LogsMethod,
+		GetPayloadBodiesByHashMethod, GetPayloadBodiesByRangeMethod:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	ctx := context.Background()
+	l := newRateLimiter(20)
+
+	// A full bucket lets a burst of requests through without delay.
+	start := time.Now()
+	for i := 0; i < 20; i++ {
+		require.NoError(t, l.wait(ctx, 1))
+	}
+	require.Equal(t, true, time.Since(start) < 50*time.Millisecond)
+
+	// Once the bucket is empty, requests wait for tokens to refill.
+	start = time.Now()
+	require.NoError(t, l.wait(ctx, 2))
+	require.Equal(t, true, time.Since(start) >= 80*time.Millisecond)
+
+	// Waiting is aborted when the context is done.
+	l.tokens = 0
+	l.last = time.Now()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, l.wait(ctx, 20), context.DeadlineExceeded)
+
+	require.Equal(t, true, newRateLimiter(0) == nil)
+	var disabled *rateLimiter
+	require.NoError(t, disabled.wait(ctx, 1000))
+}
+
+func TestRateLimiter_LargeBatchWaitsForFullBucket(t *testing.T) {
+	l := newRateLimiter(10)
+	require.NoError(t, l.wait(context.Background(), 50))
+	// The excess of the batch over the bucket size is paid for by subsequent requests.
+	require.Equal(t, true, l.tokens < -39)
+}
+
+func TestIsRateLimitedMethod(t *testing.T) {
+	require.Equal(t, true, isRateLimitedMethod(ExecutionBlockByHashMethod))
+	require.Equal(t, true, isRateLimitedMethod(GetPayloadBodiesByRangeMethod))
+	require.Equal(t, false, isRateLimitedMethod(NewPayloadMethod))
+	require.Equal(t, false, isRateLimitedMethod(ForkchoiceUpdatedMethod))
+}
@@ -0,0 +1,148 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+)
+
+// DefaultHealthCheckInterval is the default period between health checks of the configured execution endpoints.
+const DefaultHealthCheckInterval = 10 * time.Second
+
+// Periodically health checks every configured endpoint until the context is canceled. Unhealthy
+// endpoints are re-dialed, the active endpoint is switched to a healthy one when it becomes
+// unavailable, and changes in connectivity are published on the state feed, if configured.
+// The transition configuration of the active endpoint is checked at a slower pace.
+func (c *Client) superviseConnections(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.healthCheckInterval)
+	defer ticker.Stop()
+	transitionTicker := time.NewTicker(transitionConfigurationInterval)
+	defer transitionTicker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.checkConnections(ctx)
+		case <-transitionTicker.C:
+			c.checkTransitionConfiguration(ctx)
+		case <-ctx.Done():
+			log.Debug("Context closed, exiting execution connection supervisor")
+			return
+		}
+	}
+}
+
+// Health checks all endpoints and updates the connection status of the client.
+func (c *Client) checkConnections(ctx context.Context) {
+	var activeErr error
+	healthyIdx := -1
+	for i := range c.endpoints {
+		err := checkEndpointHealth(c.endpointRPC(i))
+		if err != nil {
+			c.markFailed(i)
+			c.redial(ctx, i)
+		} else if healthyIdx == -1 {
+			healthyIdx = i
+		}
+		if i == c.currentIdx() {
+			activeErr = err
+		}
+	}
+	if activeErr != nil && healthyIdx != -1 {
+		// The active endpoint is down while another one is up, so there is no
+		// need to wait for a request to fail before switching endpoints.
+		c.setActive(healthyIdx)
+		activeErr = nil
+	}
+	if activeErr == nil {
+		// Stop short-circuiting requests as soon as the active endpoint is known to be up.
+		c.recordRequestOutcome(ctx, nil)
+	}
+	c.updateConnectionStatus(activeErr)
+}
+
+// Replaces the connection to an endpoint with a freshly dialed one.
+func (c *Client) redial(ctx context.Context, idx int) {
+	c.lock.RLock()
+	e := c.endpoints[idx]
+	c.lock.RUnlock()
+	client, err := c.dial(ctx, e.url)
+	if err != nil {
+		log.WithError(err).WithField("endpoint", redactURL(e.url)).Debug("Could not re-dial execution endpoint")
+		return
+	}
+	c.lock.Lock()
+	previous := e.rpc
+	e.rpc = client
+	if c.activeIdx == idx {
+		c.rpc = client
+	}
+	c.lock.Unlock()
+	previous.Close()
+}
+
+// Records whether the active endpoint is reachable, logging and publishing an event on the
+// state feed whenever connectivity is lost or regained.
+func (c *Client) updateConnectionStatus(err error) {
+	c.lock.Lock()
+	connected := err == nil
+	if c.connected == connected {
+		c.lock.Unlock()
+		return
+	}
+	c.connected = connected
+	endpoint := redactURL(c.endpoints[c.activeIdx].url)
+	c.lock.Unlock()
+
+	if connected {
+		log.WithField("endpoint", endpoint).Info("Connection to execution node restored")
+		c.refreshCapabilities()
+	} else {
+		log.WithError(err).WithField("endpoint", endpoint).Error("Connection to execution node lost")
+	}
+	connectedGauge.Set(boolToFloat(connected))
+	if c.cfg.stateNotifier == nil {
+		return
+	}
+	c.cfg.stateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.ExecutionConnectionChanged,
+		Data: &statefeed.ExecutionConnectionChangedData{
+			Endpoint:  endpoint,
+			Connected: connected,
+			Error:     err,
+		},
+	})
+}
+
+// ConnectionChecker reports whether the execution node can be reached.
+type ConnectionChecker interface {
+	IsConnected() bool
+}
+
+// IsConnected returns true if the most recent health check of the active execution endpoint succeeded.
+func (c *Client) IsConnected() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.connected
+}
+
+func (c *Client) endpointRPC(idx int) *rpc.Client {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.endpoints[idx].rpc
+}
+
+func (c *Client) currentIdx() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.activeIdx
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
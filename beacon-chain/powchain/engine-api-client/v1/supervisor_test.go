@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/async/event"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+type mockStateNotifier struct {
+	feed *event.Feed
+}
+
+func (m *mockStateNotifier) StateFeed() *event.Feed {
+	return m.feed
+}
+
+func TestClient_SupervisorPublishesConnectivityChanges(t *testing.T) {
+	down := int32(0)
+	srv := newFailoverTestServer(t, "0x1", &down)
+	defer srv.Close()
+
+	notifier := &mockStateNotifier{feed: new(event.Feed)}
+	events := make(chan *feed.Event, 10)
+	sub := notifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := New(ctx, srv.URL, WithHealthCheckInterval(10*time.Millisecond), WithStateNotifier(notifier))
+	require.NoError(t, err)
+	defer client.Close()
+	require.Equal(t, true, client.IsConnected())
+
+	atomic.StoreInt32(&down, 1)
+	ev := receiveConnectionEvent(t, events)
+	require.Equal(t, false, ev.Connected)
+	require.Equal(t, srv.URL, ev.Endpoint)
+	require.NotNil(t, ev.Error)
+	require.Equal(t, false, client.IsConnected())
+
+	atomic.StoreInt32(&down, 0)
+	ev = receiveConnectionEvent(t, events)
+	require.Equal(t, true, ev.Connected)
+	require.Equal(t, true, client.IsConnected())
+}
+
+func TestClient_SupervisorSwitchesToHealthyEndpoint(t *testing.T) {
+	primaryDown := int32(0)
+	primary := newFailoverTestServer(t, "0x1", &primaryDown)
+	defer primary.Close()
+	fallbackDown := int32(0)
+	fallback := newFailoverTestServer(t, "0x1", &fallbackDown)
+	defer fallback.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := New(
+		ctx,
+		primary.URL,
+		WithFallbackEndpoints([]string{fallback.URL}),
+		WithHealthCheckInterval(10*time.Millisecond),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+	require.Equal(t, primary.URL, client.CurrentEndpoint())
+
+	atomic.StoreInt32(&primaryDown, 1)
+	for i := 0; i < 100 && client.CurrentEndpoint() != fallback.URL; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Equal(t, fallback.URL, client.CurrentEndpoint())
+	require.Equal(t, true, client.IsConnected())
+}
+
+func receiveConnectionEvent(t *testing.T, events chan *feed.Event) *statefeed.ExecutionConnectionChangedData {
+	select {
+	case ev := <-events:
+		require.Equal(t, feed.EventType(statefeed.ExecutionConnectionChanged), ev.Type)
+		data, ok := ev.Data.(*statefeed.ExecutionConnectionChangedData)
+		require.Equal(t, true, ok)
+		return data
+	case <-time.After(5 * time.Second):
+		t.Fatal("Did not receive execution connection event")
+	}
+	return nil
+}
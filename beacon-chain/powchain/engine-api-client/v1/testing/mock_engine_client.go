@@ -0,0 +1,258 @@
+// Package testing provides a configurable mock of the engine API client, for
+// unit tests of services interacting with an execution node.
+package testing
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+)
+
+var _ = engine.EngineCaller(&EngineClient{})
+
+// EngineClient is a mock implementation of the engine API client. Every call returns the
+// response programmed in the corresponding field, or an error if one is set for its method.
+// Calls are keyed by the JSON-RPC method they correspond to, such as engine.NewPayloadMethod.
+type EngineClient struct {
+	// Delay is waited before every call returns, or until the context of the call is done.
+	Delay time.Duration
+	// Err is returned by every call, unless an error is set for its method in ErrByMethod.
+	Err         error
+	ErrByMethod map[string]error
+	// PayloadStatus is returned by the newPayload calls. A VALID status is returned if nil.
+	PayloadStatus *pb.PayloadStatus
+	// ForkchoiceUpdatedResp is returned by the forkchoiceUpdated calls. A VALID status
+	// without a payload ID is returned if nil.
+	ForkchoiceUpdatedResp *engine.ForkchoiceUpdatedResponse
+	ExecutionPayload      *pb.ExecutionPayload
+	ExecutionPayloadV2    *pb.ExecutionPayloadCapellaWithValue
+	ExecutionPayloadV3    *pb.ExecutionPayloadDenebWithValueAndBlobsBundle
+	// PayloadBodiesByHash and PayloadBodiesByNumber are the payload bodies known to the
+	// mock, a nil body is returned for any other block.
+	PayloadBodiesByHash   map[common.Hash]*pb.ExecutionPayloadBodyV1
+	PayloadBodiesByNumber map[uint64]*pb.ExecutionPayloadBodyV1
+	LatestBlock           *pb.ExecutionBlock
+	// BlocksByHash are the execution blocks known to the mock, an error is returned when
+	// requesting any other block.
+	BlocksByHash map[common.Hash]*pb.ExecutionBlock
+	// BlocksByNumber are the blocks of the canonical execution chain. If nil, they are
+	// looked up by number in BlocksByHash.
+	BlocksByNumber map[uint64]*pb.ExecutionBlock
+
+	lock  sync.Mutex
+	calls map[string]int
+}
+
+// Calls returns the number of times the given JSON-RPC method was called.
+func (e *EngineClient) Calls(method string) int {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.calls[method]
+}
+
+// Records a call of the given method, waits for the configured delay and
+// returns the error the call must fail with, if any.
+func (e *EngineClient) handle(ctx context.Context, method string) error {
+	e.lock.Lock()
+	if e.calls == nil {
+		e.calls = make(map[string]int)
+	}
+	e.calls[method]++
+	e.lock.Unlock()
+	if e.Delay > 0 {
+		timer := time.NewTimer(e.Delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if err, ok := e.ErrByMethod[method]; ok {
+		return err
+	}
+	return e.Err
+}
+
+func (e *EngineClient) payloadStatus() *pb.PayloadStatus {
+	if e.PayloadStatus == nil {
+		return &pb.PayloadStatus{Status: pb.PayloadStatus_VALID}
+	}
+	return e.PayloadStatus
+}
+
+func (e *EngineClient) forkchoiceUpdatedResp() *engine.ForkchoiceUpdatedResponse {
+	if e.ForkchoiceUpdatedResp == nil {
+		return &engine.ForkchoiceUpdatedResponse{Status: &pb.PayloadStatus{Status: pb.PayloadStatus_VALID}}
+	}
+	return e.ForkchoiceUpdatedResp
+}
+
+// NewPayload --
+func (e *EngineClient) NewPayload(ctx context.Context, _ *pb.ExecutionPayload) (*pb.PayloadStatus, error) {
+	if err := e.handle(ctx, engine.NewPayloadMethod); err != nil {
+		return nil, err
+	}
+	return e.payloadStatus(), nil
+}
+
+// ForkchoiceUpdated --
+func (e *EngineClient) ForkchoiceUpdated(
+	ctx context.Context, _ *pb.ForkchoiceState, _ *pb.PayloadAttributes,
+) (*engine.ForkchoiceUpdatedResponse, error) {
+	if err := e.handle(ctx, engine.ForkchoiceUpdatedMethod); err != nil {
+		return nil, err
+	}
+	return e.forkchoiceUpdatedResp(), nil
+}
+
+// GetPayload --
+func (e *EngineClient) GetPayload(ctx context.Context, _ [8]byte) (*pb.ExecutionPayload, error) {
+	if err := e.handle(ctx, engine.GetPayloadMethod); err != nil {
+		return nil, err
+	}
+	return e.ExecutionPayload, nil
+}
+
+// NewPayloadV2 --
+func (e *EngineClient) NewPayloadV2(ctx context.Context, _ *pb.ExecutionPayloadCapella) (*pb.PayloadStatus, error) {
+	if err := e.handle(ctx, engine.NewPayloadMethodV2); err != nil {
+		return nil, err
+	}
+	return e.payloadStatus(), nil
+}
+
+// ForkchoiceUpdatedV2 --
+func (e *EngineClient) ForkchoiceUpdatedV2(
+	ctx context.Context, _ *pb.ForkchoiceState, _ *pb.PayloadAttributesV2,
+) (*engine.ForkchoiceUpdatedResponse, error) {
+	if err := e.handle(ctx, engine.ForkchoiceUpdatedMethodV2); err != nil {
+		return nil, err
+	}
+	return e.forkchoiceUpdatedResp(), nil
+}
+
+// GetPayloadV2 --
+func (e *EngineClient) GetPayloadV2(ctx context.Context, _ [8]byte) (*pb.ExecutionPayloadCapellaWithValue, error) {
+	if err := e.handle(ctx, engine.GetPayloadMethodV2); err != nil {
+		return nil, err
+	}
+	return e.ExecutionPayloadV2, nil
+}
+
+// NewPayloadV3 --
+func (e *EngineClient) NewPayloadV3(
+	ctx context.Context, _ *pb.ExecutionPayloadDeneb, _ []common.Hash, _ common.Hash,
+) (*pb.PayloadStatus, error) {
+	if err := e.handle(ctx, engine.NewPayloadMethodV3); err != nil {
+		return nil, err
+	}
+	return e.payloadStatus(), nil
+}
+
+// GetPayloadV3 --
+func (e *EngineClient) GetPayloadV3(ctx context.Context, _ [8]byte) (*pb.ExecutionPayloadDenebWithValueAndBlobsBundle, error) {
+	if err := e.handle(ctx, engine.GetPayloadMethodV3); err != nil {
+		return nil, err
+	}
+	return e.ExecutionPayloadV3, nil
+}
+
+// NewPayloadForSlot behaves as NewPayloadV2, regardless of the slot.
+func (e *EngineClient) NewPayloadForSlot(
+	ctx context.Context, _ types.Slot, payload *pb.ExecutionPayloadCapella,
+) (*pb.PayloadStatus, error) {
+	return e.NewPayloadV2(ctx, payload)
+}
+
+// ForkchoiceUpdatedForSlot behaves as ForkchoiceUpdatedV2, regardless of the slot.
+func (e *EngineClient) ForkchoiceUpdatedForSlot(
+	ctx context.Context, _ types.Slot, state *pb.ForkchoiceState, attrs *pb.PayloadAttributesV2,
+) (*engine.ForkchoiceUpdatedResponse, error) {
+	return e.ForkchoiceUpdatedV2(ctx, state, attrs)
+}
+
+// GetPayloadForSlot behaves as GetPayloadV2, regardless of the slot.
+func (e *EngineClient) GetPayloadForSlot(
+	ctx context.Context, _ types.Slot, payloadId [8]byte,
+) (*pb.ExecutionPayloadCapella, error) {
+	resp, err := e.GetPayloadV2(ctx, payloadId)
+	if err != nil || resp == nil {
+		return nil, err
+	}
+	return resp.Payload, nil
+}
+
+// GetPayloadBodiesByHash --
+func (e *EngineClient) GetPayloadBodiesByHash(ctx context.Context, hashes []common.Hash) ([]*pb.ExecutionPayloadBodyV1, error) {
+	if err := e.handle(ctx, engine.GetPayloadBodiesByHashMethod); err != nil {
+		return nil, err
+	}
+	bodies := make([]*pb.ExecutionPayloadBodyV1, len(hashes))
+	for i, h := range hashes {
+		bodies[i] = e.PayloadBodiesByHash[h]
+	}
+	return bodies, nil
+}
+
+// GetPayloadBodiesByRange --
+func (e *EngineClient) GetPayloadBodiesByRange(ctx context.Context, start, count uint64) ([]*pb.ExecutionPayloadBodyV1, error) {
+	if err := e.handle(ctx, engine.GetPayloadBodiesByRangeMethod); err != nil {
+		return nil, err
+	}
+	bodies := make([]*pb.ExecutionPayloadBodyV1, count)
+	for i := uint64(0); i < count; i++ {
+		bodies[i] = e.PayloadBodiesByNumber[start+i]
+	}
+	return bodies, nil
+}
+
+// LatestExecutionBlock --
+func (e *EngineClient) LatestExecutionBlock(ctx context.Context) (*pb.ExecutionBlock, error) {
+	if err := e.handle(ctx, engine.ExecutionBlockByNumberMethod); err != nil {
+		return nil, err
+	}
+	return e.LatestBlock, nil
+}
+
+// ExecutionBlockByHash --
+func (e *EngineClient) ExecutionBlockByHash(ctx context.Context, hash common.Hash) (*pb.ExecutionBlock, error) {
+	if err := e.handle(ctx, engine.ExecutionBlockByHashMethod); err != nil {
+		return nil, err
+	}
+	blk, ok := e.BlocksByHash[hash]
+	if !ok {
+		return nil, errors.Errorf("unknown execution block %#x", hash)
+	}
+	return blk, nil
+}
+
+// ExecutionBlocksByNumbers --
+func (e *EngineClient) ExecutionBlocksByNumbers(ctx context.Context, numbers []uint64) ([]*pb.ExecutionBlock, error) {
+	if err := e.handle(ctx, engine.ExecutionBlockByNumberMethod); err != nil {
+		return nil, err
+	}
+	byNumber := e.BlocksByNumber
+	if byNumber == nil {
+		byNumber = make(map[uint64]*pb.ExecutionBlock, len(e.BlocksByHash))
+		for _, blk := range e.BlocksByHash {
+			byNumber[new(big.Int).SetBytes(blk.Number).Uint64()] = blk
+		}
+	}
+	blks := make([]*pb.ExecutionBlock, len(numbers))
+	for i, number := range numbers {
+		blk, ok := byNumber[number]
+		if !ok {
+			return nil, errors.Errorf("unknown execution block %d", number)
+		}
+		blks[i] = blk
+	}
+	return blks, nil
+}
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Timeouts defines the deadlines enforced by the client for engine API
+// requests, by method. A zero duration disables the deadline for a method.
+type Timeouts struct {
+	NewPayload        time.Duration
+	ForkchoiceUpdated time.Duration
+	GetPayload        time.Duration
+	// Default applies to all other methods, such as the eth_ block getters.
+	Default time.Duration
+}
+
+// DefaultTimeouts returns the request deadlines recommended by the engine API specification
+// in https://github.com/ethereum/execution-apis/blob/main/src/engine/specification.md.
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		NewPayload:        8 * time.Second,
+		ForkchoiceUpdated: 8 * time.Second,
+		GetPayload:        time.Second,
+		Default:           DefaultTimeout,
+	}
+}
+
+func (t Timeouts) forMethod(method string) time.Duration {
+	switch method {
+	case NewPayloadMethod, NewPayloadMethodV2, NewPayloadMethodV3:
+		return t.NewPayload
+	case ForkchoiceUpdatedMethod, ForkchoiceUpdatedMethodV2:
+		return t.ForkchoiceUpdated
+	case GetPayloadMethod, GetPayloadMethodV2, GetPayloadMethodV3:
+		return t.GetPayload
+	default:
+		return t.Default
+	}
+}
+
+// TimeoutError is returned when an engine API request does not
+// complete within the deadline configured for its method.
+type TimeoutError struct {
+	Method  string
+	Timeout time.Duration
+}
+
+// Error satisfies the error interface.
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s request did not complete within %s", e.Method, e.Timeout)
+}
+
+// Is allows matching a TimeoutError against ErrTimeout, as well as ErrTransport, with errors.Is.
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout || target == ErrTransport
+}
+
+// Sends a request to a single endpoint, enforcing the deadline configured for the method.
+// A request exceeding the deadline fails with a TimeoutError, unless the caller's own
+// context expired first, in which case the context error is returned as is.
+func (c *Client) withTimeout(
+	ctx context.Context, method string, client *rpc.Client, request func(context.Context, *rpc.Client) error,
+) error {
+	var timeout time.Duration
+	if c.cfg != nil {
+		timeout = c.cfg.timeouts.forMethod(method)
+	}
+	if timeout <= 0 {
+		return request(ctx, client)
+	}
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := request(callCtx, client)
+	if err != nil && ctx.Err() == nil && callCtx.Err() == context.DeadlineExceeded {
+		return &TimeoutError{Method: method, Timeout: timeout}
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestClient_EnforcesPerMethodTimeouts(t *testing.T) {
+	fix := fixtures()
+	fcuResp, ok := fix["ForkchoiceUpdatedResponse"].(*ForkchoiceUpdatedResponse)
+	require.Equal(t, true, ok)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every request is answered slowly.
+		time.Sleep(100 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  fcuResp,
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	client, err := New(ctx, srv.URL, WithTimeouts(Timeouts{
+		NewPayload:        10 * time.Millisecond,
+		ForkchoiceUpdated: 5 * time.Second,
+	}))
+	require.NoError(t, err)
+
+	_, err = client.NewPayload(ctx, &pb.ExecutionPayload{})
+	require.ErrorIs(t, err, ErrTimeout)
+	timeoutErr := &TimeoutError{}
+	require.Equal(t, true, errors.As(err, &timeoutErr))
+	require.Equal(t, NewPayloadMethod, timeoutErr.Method)
+	require.Equal(t, 10*time.Millisecond, timeoutErr.Timeout)
+
+	resp, err := client.ForkchoiceUpdated(ctx, &pb.ForkchoiceState{}, &pb.PayloadAttributes{})
+	require.NoError(t, err)
+	require.DeepEqual(t, fcuResp.Status, resp.Status)
+}
+
+func TestClient_CallerDeadlineIsNotATimeoutError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	client, err := New(context.Background(), srv.URL)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.NewPayload(ctx, &pb.ExecutionPayload{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, false, errors.Is(err, ErrTimeout))
+}
+
+func TestTimeouts_forMethod(t *testing.T) {
+	timeouts := DefaultTimeouts()
+	require.Equal(t, 8*time.Second, timeouts.forMethod(NewPayloadMethod))
+	require.Equal(t, 8*time.Second, timeouts.forMethod(ForkchoiceUpdatedMethod))
+	require.Equal(t, time.Second, timeouts.forMethod(GetPayloadMethod))
+	require.Equal(t, DefaultTimeout, timeouts.forMethod(ExecutionBlockByHashMethod))
+}
@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	"go.opencensus.io/trace"
+)
+
+// Starts a tracing span for an engine API call, as a child of any span of the given context.
+func startSpan(ctx context.Context, name, method string) (context.Context, *trace.Span) {
+	ctx, span := trace.StartSpan(ctx, "powchain.engine-api-client."+name)
+	span.AddAttributes(trace.StringAttribute("method", method))
+	return ctx, span
+}
+
+// Annotates a span with the block hash and number of the execution payload it concerns.
+func annotatePayload(span *trace.Span, blockHash []byte, blockNumber uint64) {
+	span.AddAttributes(
+		trace.StringAttribute("blockHash", fmt.Sprintf("%#x", blockHash)),
+		trace.Int64Attribute("blockNumber", int64(blockNumber)),
+	)
+}
+
+// Annotates a span with the payload status returned by the execution node, if any.
+func annotatePayloadStatus(span *trace.Span, status *pb.PayloadStatus) {
+	if status == nil {
+		return
+	}
+	span.AddAttributes(trace.StringAttribute("status", status.Status.String()))
+	if len(status.LatestValidHash) > 0 {
+		span.AddAttributes(trace.StringAttribute("latestValidHash", fmt.Sprintf("%#x", status.LatestValidHash)))
+	}
+}
+
+// Annotates a span with the forkchoice state sent to the execution node, and whether
+// the execution node is requested to build a payload.
+func annotateForkchoiceState(span *trace.Span, state *pb.ForkchoiceState, hasAttributes bool) {
+	if state != nil {
+		span.AddAttributes(
+			trace.StringAttribute("headBlockHash", fmt.Sprintf("%#x", state.HeadBlockHash)),
+			trace.StringAttribute("finalizedBlockHash", fmt.Sprintf("%#x", state.FinalizedBlockHash)),
+		)
+	}
+	span.AddAttributes(trace.BoolAttribute("hasPayloadAttributes", hasAttributes))
+}
+
+// Annotates a span with the hash and number of an execution block received from the execution node.
+func annotateExecutionBlock(span *trace.Span, blk *pb.ExecutionBlock) {
+	span.AddAttributes(
+		trace.StringAttribute("blockHash", fmt.Sprintf("%#x", blk.Hash)),
+		trace.Int64Attribute("blockNumber", new(big.Int).SetBytes(blk.Number).Int64()),
+	)
+}
@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"go.opencensus.io/trace"
+)
+
+type spanRecorder struct {
+	lock  sync.Mutex
+	spans []*trace.SpanData
+}
+
+func (r *spanRecorder) ExportSpan(s *trace.SpanData) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.spans = append(r.spans, s)
+}
+
+func (r *spanRecorder) span(name string) *trace.SpanData {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, s := range r.spans {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func TestClient_TracesEngineCalls(t *testing.T) {
+	recorder := &spanRecorder{}
+	trace.RegisterExporter(recorder)
+	defer trace.UnregisterExporter(recorder)
+
+	server := newTestIPCServer(t)
+	defer server.Stop()
+	rpcClient := rpc.DialInProc(server)
+	defer rpcClient.Close()
+	client := &Client{}
+	client.rpc = rpcClient
+
+	ctx, parent := trace.StartSpan(context.Background(), "blockChain.onBlock", trace.WithSampler(trace.AlwaysSample()))
+	payload, ok := fixtures()["ExecutionPayload"].(*pb.ExecutionPayload)
+	require.Equal(t, true, ok)
+	status, err := client.NewPayload(ctx, payload)
+	require.NoError(t, err)
+	parent.End()
+
+	s := recorder.span("powchain.engine-api-client.NewPayload")
+	require.NotNil(t, s)
+	require.Equal(t, parent.SpanContext().SpanID, s.ParentSpanID)
+	require.Equal(t, NewPayloadMethod, s.Attributes["method"])
+	require.Equal(t, fmt.Sprintf("%#x", payload.BlockHash), s.Attributes["blockHash"])
+	require.Equal(t, int64(payload.BlockNumber), s.Attributes["blockNumber"])
+	require.Equal(t, status.Status.String(), s.Attributes["status"])
+	require.Equal(t, int32(trace.StatusCodeOK), s.Status.Code)
+}
+
+func TestClient_NilPayload(t *testing.T) {
+	client := &Client{}
+	ctx := context.Background()
+	_, err := client.NewPayload(ctx, nil)
+	require.ErrorIs(t, err, ErrNilPayload)
+	_, err = client.NewPayloadV2(ctx, nil)
+	require.ErrorIs(t, err, ErrNilPayload)
+	_, err = client.NewPayloadV3(ctx, nil, nil, common.Hash{})
+	require.ErrorIs(t, err, ErrNilPayload)
+}
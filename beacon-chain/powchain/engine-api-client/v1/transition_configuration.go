@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+)
+
+// Period between exchanges of the transition configuration with the execution node,
+// as recommended by the engine API specification.
+var transitionConfigurationInterval = 60 * time.Second
+
+// TransitionConfiguration is the merge transition configuration exchanged with the execution
+// node, which must match between the beacon node and the execution node.
+type TransitionConfiguration struct {
+	TerminalTotalDifficulty *hexutil.Big   `json:"terminalTotalDifficulty"`
+	TerminalBlockHash       common.Hash    `json:"terminalBlockHash"`
+	TerminalBlockNumber     hexutil.Uint64 `json:"terminalBlockNumber"`
+}
+
+// Returns the transition configuration of the beacon node.
+func localTransitionConfiguration() (*TransitionConfiguration, error) {
+	ttd, ok := new(big.Int).SetString(params.BeaconConfig().TerminalTotalDifficulty, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid terminal total difficulty %q", params.BeaconConfig().TerminalTotalDifficulty)
+	}
+	return &TransitionConfiguration{
+		TerminalTotalDifficulty: (*hexutil.Big)(ttd),
+		TerminalBlockHash:       params.BeaconConfig().TerminalBlockHash,
+	}, nil
+}
+
+// ExchangeTransitionConfiguration sends the transition configuration of the beacon node to the
+// execution node, and returns an error wrapping ErrTransitionConfigurationMismatch if the terminal
+// total difficulty or terminal block hash of the execution node differ.
+func (c *Client) ExchangeTransitionConfiguration(ctx context.Context) error {
+	local, err := localTransitionConfiguration()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	// The exchange bypasses the circuit breaker and failover, as it is repeated periodically.
+	remote := &TransitionConfiguration{}
+	if err := handleRPCError(c.activeRPC().CallContext(ctx, remote, ExchangeTransitionConfigurationMethod, local)); err != nil {
+		return err
+	}
+	if remote.TerminalTotalDifficulty == nil || remote.TerminalTotalDifficulty.ToInt().Cmp(local.TerminalTotalDifficulty.ToInt()) != 0 {
+		return errors.Wrapf(
+			ErrTransitionConfigurationMismatch,
+			"terminal total difficulty %s, expected %s", remote.TerminalTotalDifficulty, local.TerminalTotalDifficulty,
+		)
+	}
+	if remote.TerminalBlockHash != local.TerminalBlockHash {
+		return errors.Wrapf(
+			ErrTransitionConfigurationMismatch,
+			"terminal block hash %s, expected %s", remote.TerminalBlockHash, local.TerminalBlockHash,
+		)
+	}
+	return nil
+}
+
+// Checks the transition configuration of the active endpoint, logging and publishing an event
+// on the state feed whenever it starts or stops matching the configuration of the beacon node.
+// Execution nodes which are unreachable or do not implement the exchange are skipped.
+func (c *Client) checkTransitionConfiguration(ctx context.Context) {
+	err := c.ExchangeTransitionConfiguration(ctx)
+	if err != nil && !errors.Is(err, ErrTransitionConfigurationMismatch) {
+		log.WithError(err).Debug("Could not exchange transition configuration with execution node")
+		return
+	}
+	mismatch := err != nil
+	c.lock.Lock()
+	if c.transitionMismatch == mismatch {
+		c.lock.Unlock()
+		return
+	}
+	c.transitionMismatch = mismatch
+	endpoint := redactURL(c.endpoints[c.activeIdx].url)
+	c.lock.Unlock()
+
+	if mismatch {
+		log.WithError(err).WithField("endpoint", endpoint).Error(
+			"Execution node transition configuration does not match, check the terminal total difficulty of both nodes",
+		)
+	} else {
+		log.WithField("endpoint", endpoint).Info("Execution node transition configuration matches")
+	}
+	transitionMismatchGauge.Set(boolToFloat(mismatch))
+	if c.cfg.stateNotifier == nil {
+		return
+	}
+	c.cfg.stateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.TransitionConfigurationChanged,
+		Data: &statefeed.TransitionConfigurationChangedData{
+			Endpoint: endpoint,
+			Matching: !mismatch,
+			Error:    err,
+		},
+	})
+}
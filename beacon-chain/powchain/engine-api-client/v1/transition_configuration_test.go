@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/async/event"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+// Returns a server responding to transition configuration exchanges with the configuration
+// stored in cfg, or a method not found error if it is nil.
+func newTransitionConfigurationTestServer(t *testing.T, cfg *atomic.Value) *httptest.Server {
+	return newJSONRPCServer(t, jsonRPCHandlers{
+		ExchangeTransitionConfigurationMethod: func(json.RawMessage) (interface{}, *jsonError) {
+			remote, ok := cfg.Load().(*TransitionConfiguration)
+			if !ok || remote == nil {
+				return nil, errJSONMethodNotFound
+			}
+			return remote, nil
+		},
+	})
+}
+
+func testTransitionConfiguration(ttd int64, hash common.Hash) *TransitionConfiguration {
+	return &TransitionConfiguration{
+		TerminalTotalDifficulty: (*hexutil.Big)(big.NewInt(ttd)),
+		TerminalBlockHash:       hash,
+	}
+}
+
+func TestClient_ExchangeTransitionConfiguration(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.TerminalTotalDifficulty = "100"
+	cfg.TerminalBlockHash = common.Hash{}
+	params.OverrideBeaconConfig(cfg)
+
+	remote := &atomic.Value{}
+	srv := newTransitionConfigurationTestServer(t, remote)
+	defer srv.Close()
+	client, err := New(context.Background(), srv.URL, WithHealthCheckInterval(0))
+	require.NoError(t, err)
+	defer client.Close()
+
+	tests := []struct {
+		name    string
+		remote  *TransitionConfiguration
+		wantErr error
+	}{
+		{
+			name:   "matching",
+			remote: testTransitionConfiguration(100, common.Hash{}),
+		},
+		{
+			name:    "terminal total difficulty mismatch",
+			remote:  testTransitionConfiguration(101, common.Hash{}),
+			wantErr: ErrTransitionConfigurationMismatch,
+		},
+		{
+			name:    "terminal block hash mismatch",
+			remote:  testTransitionConfiguration(100, common.HexToHash("0x01")),
+			wantErr: ErrTransitionConfigurationMismatch,
+		},
+		{
+			name:    "not implemented",
+			wantErr: ErrMethodNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remote.Store(tt.remote)
+			err := client.ExchangeTransitionConfiguration(context.Background())
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, true, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestClient_SupervisorPublishesTransitionConfigurationChanges(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.TerminalTotalDifficulty = "100"
+	cfg.TerminalBlockHash = common.Hash{}
+	params.OverrideBeaconConfig(cfg)
+	defer func(interval time.Duration) {
+		transitionConfigurationInterval = interval
+	}(transitionConfigurationInterval)
+	transitionConfigurationInterval = 10 * time.Millisecond
+
+	remote := &atomic.Value{}
+	remote.Store(testTransitionConfiguration(101, common.Hash{}))
+	srv := newTransitionConfigurationTestServer(t, remote)
+	defer srv.Close()
+
+	notifier := &mockStateNotifier{feed: new(event.Feed)}
+	events := make(chan *feed.Event, 10)
+	sub := notifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := New(ctx, srv.URL, WithHealthCheckInterval(time.Hour), WithStateNotifier(notifier))
+	require.NoError(t, err)
+	defer client.Close()
+
+	ev := receiveTransitionConfigurationEvent(t, events)
+	require.Equal(t, false, ev.Matching)
+	require.Equal(t, srv.URL, ev.Endpoint)
+	require.Equal(t, true, errors.Is(ev.Error, ErrTransitionConfigurationMismatch))
+
+	remote.Store(testTransitionConfiguration(100, common.Hash{}))
+	ev = receiveTransitionConfigurationEvent(t, events)
+	require.Equal(t, true, ev.Matching)
+	require.NoError(t, ev.Error)
+}
+
+func receiveTransitionConfigurationEvent(t *testing.T, events chan *feed.Event) *statefeed.TransitionConfigurationChangedData {
+	select {
+	case ev := <-events:
+		require.Equal(t, feed.EventType(statefeed.TransitionConfigurationChanged), ev.Type)
+		data, ok := ev.Data.(*statefeed.TransitionConfigurationChangedData)
+		require.Equal(t, true, ok)
+		return data
+	case <-time.After(5 * time.Second):
+		t.Fatal("Did not receive transition configuration event")
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/config/params"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+// Returns true if the engine API methods introduced by the Capella fork must be used
+// for a payload of the given slot.
+func isCapellaSlot(slot types.Slot) bool {
+	return slots.ToEpoch(slot) >= params.BeaconConfig().CapellaForkEpoch
+}
+
+// NewPayloadForSlot sends the payload of a block at the given slot to the execution node,
+// using the engine_newPayload version of the fork active at that slot. Payloads of slots
+// before the Capella fork are sent in the Bellatrix format, and must not contain withdrawals.
+func (c *Client) NewPayloadForSlot(
+	ctx context.Context, slot types.Slot, payload *pb.ExecutionPayloadCapella,
+) (*pb.PayloadStatus, error) {
+	if payload == nil {
+		return nil, ErrNilPayload
+	}
+	if isCapellaSlot(slot) {
+		return c.NewPayloadV2(ctx, payload)
+	}
+	if len(payload.Withdrawals) != 0 {
+		return nil, errors.Errorf("payload for pre-Capella slot %d contains withdrawals", slot)
+	}
+	return c.NewPayload(ctx, payload.ToV1())
+}
+
+// ForkchoiceUpdatedForSlot updates the forkchoice state of the execution node, using the
+// engine_forkchoiceUpdated version of the fork active at the given slot. The attributes,
+// which may be nil, are those of a payload to be built for that slot.
+func (c *Client) ForkchoiceUpdatedForSlot(
+	ctx context.Context, slot types.Slot, state *pb.ForkchoiceState, attrs *pb.PayloadAttributesV2,
+) (*ForkchoiceUpdatedResponse, error) {
+	if isCapellaSlot(slot) {
+		return c.ForkchoiceUpdatedV2(ctx, state, attrs)
+	}
+	if attrs == nil {
+		return c.ForkchoiceUpdated(ctx, state, nil)
+	}
+	if len(attrs.Withdrawals) != 0 {
+		return nil, errors.Errorf("payload attributes for pre-Capella slot %d contain withdrawals", slot)
+	}
+	return c.ForkchoiceUpdated(ctx, state, attrs.ToV1())
+}
+
+// GetPayloadForSlot retrieves the payload built for the given slot from the execution node,
+// using the engine_getPayload version of the fork active at that slot.
+func (c *Client) GetPayloadForSlot(
+	ctx context.Context, slot types.Slot, payloadId [8]byte,
+) (*pb.ExecutionPayloadCapella, error) {
+	if isCapellaSlot(slot) {
+		resp, err := c.GetPayloadV2(ctx, payloadId)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Payload, nil
+	}
+	payload, err := c.GetPayload(ctx, payloadId)
+	if err != nil {
+		return nil, err
+	}
+	return pb.ExecutionPayloadCapellaFromV1(payload), nil
+}
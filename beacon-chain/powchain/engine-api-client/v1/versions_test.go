@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prysmaticlabs/prysm/config/params"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestClient_DispatchesByForkOfSlot(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.CapellaForkEpoch = 10
+	params.OverrideBeaconConfig(cfg)
+	capellaSlot := params.BeaconConfig().SlotsPerEpoch * 10
+
+	server := newTestIPCServer(t)
+	defer server.Stop()
+	rpcClient := rpc.DialInProc(server)
+	defer rpcClient.Close()
+	client := &Client{}
+	client.rpc = rpcClient
+	ctx := context.Background()
+	fix := fixtures()
+	capella, ok := fix["ExecutionPayloadCapella"].(*pb.ExecutionPayloadCapellaWithValue)
+	require.Equal(t, true, ok)
+	bellatrix, ok := fix["ExecutionPayload"].(*pb.ExecutionPayload)
+	require.Equal(t, true, ok)
+
+	// Asserts that calling f sends a single request for the given method.
+	requireCalls := func(t *testing.T, method string, f func() error) {
+		before := testutil.ToFloat64(requestCount.WithLabelValues(method))
+		require.NoError(t, f())
+		require.Equal(t, before+1, testutil.ToFloat64(requestCount.WithLabelValues(method)))
+	}
+
+	t.Run("new payload", func(t *testing.T) {
+		requireCalls(t, NewPayloadMethod, func() error {
+			_, err := client.NewPayloadForSlot(ctx, capellaSlot-1, pb.ExecutionPayloadCapellaFromV1(bellatrix))
+			return err
+		})
+		requireCalls(t, NewPayloadMethodV2, func() error {
+			_, err := client.NewPayloadForSlot(ctx, capellaSlot, capella.Payload)
+			return err
+		})
+		_, err := client.NewPayloadForSlot(ctx, capellaSlot-1, capella.Payload)
+		require.ErrorContains(t, "contains withdrawals", err)
+		_, err = client.NewPayloadForSlot(ctx, capellaSlot-1, nil)
+		require.ErrorIs(t, err, ErrNilPayload)
+		_, err = client.NewPayloadForSlot(ctx, capellaSlot, nil)
+		require.ErrorIs(t, err, ErrNilPayload)
+	})
+	t.Run("forkchoice updated", func(t *testing.T) {
+		requireCalls(t, ForkchoiceUpdatedMethod, func() error {
+			_, err := client.ForkchoiceUpdatedForSlot(ctx, capellaSlot-1, &pb.ForkchoiceState{}, nil)
+			return err
+		})
+		requireCalls(t, ForkchoiceUpdatedMethod, func() error {
+			_, err := client.ForkchoiceUpdatedForSlot(ctx, capellaSlot-1, &pb.ForkchoiceState{}, &pb.PayloadAttributesV2{})
+			return err
+		})
+		requireCalls(t, ForkchoiceUpdatedMethodV2, func() error {
+			_, err := client.ForkchoiceUpdatedForSlot(ctx, capellaSlot, &pb.ForkchoiceState{}, &pb.PayloadAttributesV2{
+				Withdrawals: capella.Payload.Withdrawals,
+			})
+			return err
+		})
+		_, err := client.ForkchoiceUpdatedForSlot(ctx, capellaSlot-1, &pb.ForkchoiceState{}, &pb.PayloadAttributesV2{
+			Withdrawals: capella.Payload.Withdrawals,
+		})
+		require.ErrorContains(t, "contain withdrawals", err)
+	})
+	t.Run("get payload", func(t *testing.T) {
+		var payload *pb.ExecutionPayloadCapella
+		requireCalls(t, GetPayloadMethod, func() error {
+			var err error
+			payload, err = client.GetPayloadForSlot(ctx, capellaSlot-1, [8]byte{1})
+			return err
+		})
+		require.DeepEqual(t, pb.ExecutionPayloadCapellaFromV1(bellatrix), payload)
+		requireCalls(t, GetPayloadMethodV2, func() error {
+			var err error
+			payload, err = client.GetPayloadForSlot(ctx, capellaSlot, [8]byte{1})
+			return err
+		})
+		require.DeepEqual(t, capella.Payload, payload)
+	})
+}
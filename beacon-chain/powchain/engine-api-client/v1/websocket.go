@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsHandshakeTimeout = 10 * time.Second
+	wsBufferSize       = 1024
+)
+
+// Dials an engine API endpoint over a websocket connection. The underlying RPC client
+// transparently re-establishes the connection, performing a new handshake, when a request
+// is sent over a broken connection, and keeps idle connections alive with periodic pings.
+func (c *Client) dialWebsocket(ctx context.Context, rawURL string) (*rpc.Client, error) {
+	dialer := websocket.Dialer{
+		HandshakeTimeout: wsHandshakeTimeout,
+		ReadBufferSize:   wsBufferSize,
+		WriteBufferSize:  wsBufferSize,
+		TLSClientConfig:  c.cfg.tlsConfig,
+		// The RPC client only forwards basic auth credentials from the URL during the
+		// websocket handshake. The proxy hook is the one place the handshake request is
+		// exposed, so we use it to attach custom and JWT authentication headers to every
+		// handshake, including the ones performed when reconnecting.
+		Proxy: func(req *http.Request) (*url.URL, error) {
+			for k, v := range c.cfg.headers {
+				req.Header.Set(k, v)
+			}
+			if len(c.cfg.jwtSecret) > 0 {
+				authHeader, err := jwtAuthorizationHeader(c.cfg.jwtSecret)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set("Authorization", authHeader)
+			}
+			return http.ProxyFromEnvironment(req)
+		},
+	}
+	return rpc.DialWebsocketWithDialer(ctx, rawURL, "", dialer)
+}
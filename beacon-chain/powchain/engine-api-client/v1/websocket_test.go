@@ -0,0 +1,64 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestClient_Websocket(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	server := newTestIPCServer(t)
+	handshakes := int32(0)
+	var wsHandler atomic.Value
+	wsHandler.Store(server.WebsocketHandler([]string{"*"}))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every handshake must carry the custom headers and a valid JWT.
+		require.Equal(t, "bar", r.Header.Get("X-Foo"))
+		token, err := jwt.Parse(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), func(token *jwt.Token) (interface{}, error) {
+			return secret, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, true, token.Valid)
+		atomic.AddInt32(&handshakes, 1)
+		wsHandler.Load().(http.Handler).ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	client, err := New(
+		ctx,
+		"ws"+strings.TrimPrefix(srv.URL, "http"),
+		WithJWTSecret(secret),
+		WithHeaders(map[string]string{"X-Foo": "bar"}),
+	)
+	require.NoError(t, err)
+	want, ok := fixtures()["ExecutionBlock"].(*pb.ExecutionBlock)
+	require.Equal(t, true, ok)
+	resp, err := client.LatestExecutionBlock(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, want, resp)
+	require.Equal(t, int32(1), atomic.LoadInt32(&handshakes))
+
+	// Dropping the connection leads to a new handshake on a subsequent request.
+	server.Stop()
+	server = newTestIPCServer(t)
+	defer server.Stop()
+	wsHandler.Store(server.WebsocketHandler([]string{"*"}))
+	for i := 0; i < 5; i++ {
+		resp, err = client.LatestExecutionBlock(ctx)
+		if err == nil {
+			break
+		}
+	}
+	require.NoError(t, err)
+	require.DeepEqual(t, want, resp)
+	require.Equal(t, int32(2), atomic.LoadInt32(&handshakes))
+}
@@ -0,0 +1,188 @@
+package powchain
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Maximum number of eth_getLogs requests in flight while processing past deposit logs.
+	maxConcurrentLogRequests = 4
+	// Number of logs in a single eth_getLogs response above which the block range of the following
+	// requests is shrunk, keeping responses well below the size limits of providers.
+	logResponseSizeTarget = 1000
+	// Number of consecutive failed eth_getLogs requests after which processing past logs is aborted.
+	maxLogRequestRetries = 10
+)
+
+// Time waited before retrying eth_getLogs requests rejected by a rate limiting provider.
+var logRequestBackoff = time.Second
+
+// Error messages returned by providers refusing eth_getLogs requests over too many blocks or
+// with too many results.
+var logRangeTooLargeMessages = []string{
+	"query returned more than",
+	"response size exceeded",
+	"response size should not greater than",
+	"block range is too wide",
+	"block range too large",
+	"exceed maximum block range",
+	"query timeout exceeded",
+}
+
+// Error messages returned by rate limiting providers.
+var rateLimitedMessages = []string{
+	"429",
+	"too many requests",
+	"rate limit",
+	"limit exceeded",
+}
+
+func tooMuchDataRequestedError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return containsAny(err, logRangeTooLargeMessages)
+}
+
+func rateLimitedError(err error) bool {
+	return containsAny(err, rateLimitedMessages)
+}
+
+func containsAny(err error, messages []string) bool {
+	msg := strings.ToLower(err.Error())
+	for _, m := range messages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// logRange is a range of blocks whose deposit logs are requested in a single eth_getLogs call.
+type logRange struct {
+	start uint64
+	end   uint64
+	logs  []gethTypes.Log
+	err   error
+}
+
+// logBatcher adapts the block range and the number of concurrent eth_getLogs requests to the
+// provider. The range is shrunk multiplicatively when requests are refused as too large, time out
+// or return many logs, and grown additively while responses stay small. Rate limited requests
+// reduce the number of concurrent requests instead, which recovers one request at a time.
+type logBatcher struct {
+	size           uint64
+	maxSize        uint64
+	additiveFactor uint64
+	concurrency    int
+}
+
+func newLogBatcher(maxSize uint64) *logBatcher {
+	if maxSize == 0 {
+		maxSize = 1
+	}
+	additiveFactor := uint64(float64(maxSize) * additiveFactorMultiplier)
+	if additiveFactor == 0 {
+		additiveFactor = 1
+	}
+	return &logBatcher{
+		size:           maxSize,
+		maxSize:        maxSize,
+		additiveFactor: additiveFactor,
+		concurrency:    maxConcurrentLogRequests,
+	}
+}
+
+// ranges splits the blocks from start to end, inclusive, into consecutive ranges of the current
+// size, as many as requests may currently be in flight.
+func (b *logBatcher) ranges(start, end uint64) []*logRange {
+	ranges := make([]*logRange, 0, b.concurrency)
+	for len(ranges) < b.concurrency && start <= end {
+		rangeEnd := start + b.size - 1
+		if rangeEnd > end || rangeEnd < start {
+			rangeEnd = end
+		}
+		ranges = append(ranges, &logRange{start: start, end: rangeEnd})
+		if rangeEnd == end {
+			break
+		}
+		start = rangeEnd + 1
+	}
+	return ranges
+}
+
+// onResponse adapts the range size to the number of logs returned by a successful request.
+func (b *logBatcher) onResponse(numLogs int) {
+	if numLogs > logResponseSizeTarget {
+		b.shrink()
+		return
+	}
+	b.size += b.additiveFactor
+	if b.size > b.maxSize {
+		b.size = b.maxSize
+	}
+	if b.concurrency < maxConcurrentLogRequests {
+		b.concurrency++
+	}
+}
+
+// onError adapts the range size or the number of concurrent requests to a failed request, and
+// returns whether the request may be retried.
+func (b *logBatcher) onError(err error) bool {
+	switch {
+	case rateLimitedError(err):
+		b.concurrency /= multiplicativeDecreaseDivisor
+		if b.concurrency < 1 {
+			b.concurrency = 1
+		}
+		return true
+	case tooMuchDataRequestedError(err):
+		if b.size == 1 {
+			return false
+		}
+		b.shrink()
+		return true
+	default:
+		return false
+	}
+}
+
+func (b *logBatcher) shrink() {
+	b.size /= multiplicativeDecreaseDivisor
+	if b.size == 0 {
+		b.size = 1
+	}
+}
+
+// Requests the deposit logs of all ranges concurrently.
+func (s *Service) filterLogRanges(ctx context.Context, ranges []*logRange) {
+	var wg sync.WaitGroup
+	for _, r := range ranges {
+		wg.Add(1)
+		go func(r *logRange) {
+			defer wg.Done()
+			r.logs, r.err = s.logFilterer().FilterLogs(ctx, ethereum.FilterQuery{
+				Addresses: []common.Address{
+					s.cfg.depositContractAddr,
+				},
+				FromBlock: new(big.Int).SetUint64(r.start),
+				ToBlock:   new(big.Int).SetUint64(r.end),
+			})
+		}(r)
+	}
+	wg.Wait()
+}
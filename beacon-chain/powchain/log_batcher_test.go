@@ -0,0 +1,181 @@
+package powchain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	contracts "github.com/prysmaticlabs/prysm/contracts/deposit"
+	"github.com/prysmaticlabs/prysm/contracts/deposit/mock"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+// limitingLogger refuses log requests over more than maxRange blocks and rate limits every
+// rateLimitEvery-th request, like public execution node providers do.
+type limitingLogger struct {
+	goodLogger
+	maxRange       uint64
+	rateLimitEvery int
+
+	lock     sync.Mutex
+	requests int
+	refused  int
+}
+
+func (l *limitingLogger) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]gethTypes.Log, error) {
+	l.lock.Lock()
+	l.requests++
+	rateLimited := l.rateLimitEvery > 0 && l.requests%l.rateLimitEvery == 0
+	if rateLimited || q.ToBlock.Uint64()-q.FromBlock.Uint64() >= l.maxRange {
+		l.refused++
+	}
+	l.lock.Unlock()
+	if rateLimited {
+		return nil, errors.New("429 Too Many Requests")
+	}
+	if q.ToBlock.Uint64()-q.FromBlock.Uint64() >= l.maxRange {
+		return nil, errors.New("query returned more than 10000 results")
+	}
+	return l.goodLogger.FilterLogs(ctx, q)
+}
+
+func TestLogBatcher_Ranges(t *testing.T) {
+	b := newLogBatcher(10)
+	ranges := b.ranges(5, 100)
+	require.Equal(t, maxConcurrentLogRequests, len(ranges))
+	next := uint64(5)
+	for _, r := range ranges {
+		assert.Equal(t, next, r.start)
+		assert.Equal(t, next+9, r.end)
+		next = r.end + 1
+	}
+
+	ranges = b.ranges(5, 17)
+	require.Equal(t, 2, len(ranges))
+	assert.Equal(t, uint64(15), ranges[1].start)
+	assert.Equal(t, uint64(17), ranges[1].end)
+
+	ranges = b.ranges(17, 17)
+	require.Equal(t, 1, len(ranges))
+	assert.Equal(t, uint64(17), ranges[0].end)
+}
+
+func TestLogBatcher_AdaptsToResponses(t *testing.T) {
+	b := newLogBatcher(100)
+	assert.Equal(t, true, b.onError(errors.New("query returned more than 10000 results")))
+	assert.Equal(t, uint64(50), b.size)
+	assert.Equal(t, true, b.onError(context.DeadlineExceeded))
+	assert.Equal(t, uint64(25), b.size)
+
+	b.onResponse(logResponseSizeTarget + 1)
+	assert.Equal(t, uint64(12), b.size)
+	b.onResponse(10)
+	assert.Equal(t, uint64(22), b.size)
+	for i := 0; i < 20; i++ {
+		b.onResponse(10)
+	}
+	assert.Equal(t, uint64(100), b.size)
+
+	assert.Equal(t, true, b.onError(errors.New("429 Too Many Requests")))
+	assert.Equal(t, maxConcurrentLogRequests/2, b.concurrency)
+	assert.Equal(t, uint64(100), b.size)
+	b.onResponse(10)
+	assert.Equal(t, maxConcurrentLogRequests/2+1, b.concurrency)
+
+	assert.Equal(t, false, b.onError(errors.New("execution reverted")))
+
+	b = newLogBatcher(1)
+	assert.Equal(t, false, b.onError(errors.New("query returned more than 10000 results")))
+}
+
+func TestProcessPastLogs_AdaptsToLimitingProvider(t *testing.T) {
+	logRequestBackoff = time.Millisecond
+	defer func() {
+		logRequestBackoff = time.Second
+	}()
+	testAcc, err := mock.Setup()
+	require.NoError(t, err, "Unable to set up simulated backend")
+	kvStore := testDB.SetupDB(t)
+	depositCache, err := depositcache.New()
+	require.NoError(t, err)
+
+	web3Service, err := NewService(context.Background(),
+		WithHttpEndpoints([]string{endpoint}),
+		WithDepositContractAddress(testAcc.ContractAddr),
+		WithDatabase(kvStore),
+		WithDepositCache(depositCache),
+		WithEth1HeaderRequestLimit(64),
+	)
+	require.NoError(t, err, "unable to setup web3 ETH1.0 chain service")
+	web3Service = setDefaultMocks(web3Service)
+	web3Service.depositContractCaller, err = contracts.NewDepositContractCaller(testAcc.ContractAddr, testAcc.Backend)
+	require.NoError(t, err)
+	web3Service.rpcClient = &mockPOW.RPCClient{Backend: testAcc.Backend}
+	logger := &limitingLogger{
+		goodLogger:     goodLogger{backend: testAcc.Backend},
+		maxRange:       16,
+		rateLimitEvery: 7,
+	}
+	web3Service.httpLogger = logger
+	web3Service.eth1DataFetcher = &goodFetcher{backend: testAcc.Backend}
+	params.SetupTestConfigCleanup(t)
+	bConfig := params.MinimalSpecConfig()
+	bConfig.SecondsPerETH1Block = 10
+	params.OverrideBeaconConfig(bConfig)
+	nConfig := params.BeaconNetworkConfig()
+	nConfig.ContractDeploymentBlock = 0
+	params.OverrideBeaconNetworkConfig(nConfig)
+
+	testAcc.Backend.Commit()
+
+	numOfDeposits := 20
+	deposits, _, err := util.DeterministicDepositsAndKeys(uint64(numOfDeposits))
+	require.NoError(t, err)
+	_, depositRoots, err := util.DeterministicDepositTrie(len(deposits))
+	require.NoError(t, err)
+	for i := 0; i < numOfDeposits; i++ {
+		data := deposits[i].Data
+		testAcc.TxOpts.Value = mock.Amount32Eth()
+		testAcc.TxOpts.GasLimit = 1000000
+		_, err = testAcc.Contract.Deposit(testAcc.TxOpts, data.PublicKey, data.WithdrawalCredentials, data.Signature, depositRoots[i])
+		require.NoError(t, err, "Could not deposit to deposit contract")
+		// Spread the deposits over many blocks.
+		for j := 0; j < 10; j++ {
+			testAcc.Backend.Commit()
+		}
+	}
+	for i := uint64(0); i < params.BeaconConfig().Eth1FollowDistance; i++ {
+		testAcc.Backend.Commit()
+	}
+	web3Service.latestEth1Data.BlockHeight = testAcc.Backend.Blockchain().CurrentBlock().NumberU64()
+	web3Service.latestEth1Data.BlockTime = testAcc.Backend.Blockchain().CurrentBlock().Time()
+
+	require.NoError(t, web3Service.processPastLogs(context.Background()))
+	assert.Equal(t, numOfDeposits, web3Service.depositTrie.NumOfItems())
+	assert.Equal(t, int64(numOfDeposits-1), web3Service.lastReceivedMerkleIndex)
+	assert.Equal(t, true, logger.refused > 0, "Expected the provider to refuse requests")
+}
+
+func TestProcessPastLogs_FailsOnUnrecoverableError(t *testing.T) {
+	testAcc, err := mock.Setup()
+	require.NoError(t, err, "Unable to set up simulated backend")
+	web3Service := newPowchainService(t, testAcc, testDB.SetupDB(t))
+	web3Service.httpLogger = &limitingLogger{}
+	params.SetupTestConfigCleanup(t)
+	nConfig := params.BeaconNetworkConfig()
+	nConfig.ContractDeploymentBlock = 0
+	params.OverrideBeaconNetworkConfig(nConfig)
+	web3Service.latestEth1Data.BlockHeight = params.BeaconConfig().Eth1FollowDistance + 100
+
+	require.ErrorContains(t, "could not request deposit logs", web3Service.processPastLogs(context.Background()))
+}
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/pkg/errors"
@@ -33,15 +32,9 @@ var (
 const eth1DataSavingInterval = 1000
 const maxTolerableDifference = 50
 const defaultEth1HeaderReqLimit = uint64(1000)
-const depositlogRequestLimit = 10000
 const additiveFactorMultiplier = 0.10
 const multiplicativeDecreaseDivisor = 2
 
-func tooMuchDataRequestedError(err error) bool {
-	// this error is only infura specific (other providers might have different error messages)
-	return err.Error() == "query returned more than 10000 results"
-}
-
 // Eth2GenesisPowchainInfo retrieves the genesis time and eth1 block number of the beacon chain
 // from the deposit contract.
 func (s *Service) Eth2GenesisPowchainInfo() (uint64, *big.Int) {
@@ -57,7 +50,7 @@ func (s *Service) ProcessETH1Block(ctx context.Context, blkNum *big.Int) error {
 		FromBlock: blkNum,
 		ToBlock:   blkNum,
 	}
-	logs, err := s.httpLogger.FilterLogs(ctx, query)
+	logs, err := s.logFilterer().FilterLogs(ctx, query)
 	if err != nil {
 		return err
 	}
@@ -263,11 +256,6 @@ func (s *Service) processPastLogs(ctx context.Context) error {
 	}
 	// To store all blocks.
 	headersMap := make(map[uint64]*gethTypes.Header)
-	rawLogCount, err := s.depositContractCaller.GetDepositCount(&bind.CallOpts{})
-	if err != nil {
-		return err
-	}
-	logCount := binary.LittleEndian.Uint64(rawLogCount)
 
 	// Batch request the desired headers and store them in a
 	// map for quick access.
@@ -288,77 +276,67 @@ func (s *Service) processPastLogs(ctx context.Context) error {
 		return err
 	}
 
-	batchSize := s.cfg.eth1HeaderReqLimit
-	additiveFactor := uint64(float64(batchSize) * additiveFactorMultiplier)
-
+	batcher := newLogBatcher(s.cfg.eth1HeaderReqLimit)
+	retries := 0
 	for currentBlockNum < latestFollowHeight {
-		start := currentBlockNum
-		end := currentBlockNum + batchSize
-		// Appropriately bound the request, as we do not
+		// Appropriately bound the requests, as we do not
 		// want request blocks beyond the current follow distance.
-		if end > latestFollowHeight {
-			end = latestFollowHeight
-		}
-		query := ethereum.FilterQuery{
-			Addresses: []common.Address{
-				s.cfg.depositContractAddr,
-			},
-			FromBlock: big.NewInt(int64(start)),
-			ToBlock:   big.NewInt(int64(end)),
-		}
-		remainingLogs := logCount - uint64(s.lastReceivedMerkleIndex+1)
-		// only change the end block if the remaining logs are below the required log limit.
-		// reset our query and end block in this case.
-		withinLimit := remainingLogs < depositlogRequestLimit
-		aboveFollowHeight := end >= latestFollowHeight
-		if withinLimit && aboveFollowHeight {
-			query.ToBlock = big.NewInt(int64(latestFollowHeight))
-			end = latestFollowHeight
-		}
-		logs, err := s.httpLogger.FilterLogs(ctx, query)
-		if err != nil {
-			if tooMuchDataRequestedError(err) {
-				if batchSize == 0 {
-					return errors.New("batch size is zero")
-				}
-
-				// multiplicative decrease
-				batchSize /= multiplicativeDecreaseDivisor
-				continue
+		ranges := batcher.ranges(currentBlockNum, latestFollowHeight)
+		s.filterLogRanges(ctx, ranges)
+
+		// Logs are processed strictly in order, so only the ranges
+		// preceding the first failed request are processed and the
+		// remaining blocks are requested again.
+		var requestErr error
+		for _, r := range ranges {
+			if r.err != nil {
+				requestErr = r.err
+				break
 			}
-			return err
-		}
-		// Only request headers before chainstart to correctly determine
-		// genesis.
-		if !s.chainStartData.Chainstarted {
-			if err := requestHeaders(start, end); err != nil {
-				return err
+			batcher.onResponse(len(r.logs))
+			// Only request headers before chainstart to correctly determine
+			// genesis.
+			if !s.chainStartData.Chainstarted {
+				if err := requestHeaders(r.start, r.end); err != nil {
+					return err
+				}
 			}
-		}
 
-		for _, filterLog := range logs {
-			if filterLog.BlockNumber > currentBlockNum {
-				if err := s.checkHeaderRange(ctx, currentBlockNum, filterLog.BlockNumber-1, headersMap, requestHeaders); err != nil {
+			for _, filterLog := range r.logs {
+				if filterLog.BlockNumber > currentBlockNum {
+					if err := s.checkHeaderRange(ctx, currentBlockNum, filterLog.BlockNumber-1, headersMap, requestHeaders); err != nil {
+						return err
+					}
+					// set new block number after checking for chainstart for previous block.
+					s.latestEth1Data.LastRequestedBlock = currentBlockNum
+					currentBlockNum = filterLog.BlockNumber
+				}
+				if err := s.ProcessLog(ctx, filterLog); err != nil {
 					return err
 				}
-				// set new block number after checking for chainstart for previous block.
-				s.latestEth1Data.LastRequestedBlock = currentBlockNum
-				currentBlockNum = filterLog.BlockNumber
 			}
-			if err := s.ProcessLog(ctx, filterLog); err != nil {
+			if err := s.checkHeaderRange(ctx, currentBlockNum, r.end, headersMap, requestHeaders); err != nil {
 				return err
 			}
+			currentBlockNum = r.end
+			retries = 0
 		}
-		if err := s.checkHeaderRange(ctx, currentBlockNum, end, headersMap, requestHeaders); err != nil {
-			return err
+		if requestErr == nil {
+			continue
 		}
-		currentBlockNum = end
-
-		if batchSize < s.cfg.eth1HeaderReqLimit {
-			// update the batchSize with additive increase
-			batchSize += additiveFactor
-			if batchSize > s.cfg.eth1HeaderReqLimit {
-				batchSize = s.cfg.eth1HeaderReqLimit
+		retries++
+		if !batcher.onError(requestErr) || retries > maxLogRequestRetries {
+			return errors.Wrapf(requestErr, "could not request deposit logs from block %d", currentBlockNum)
+		}
+		log.WithError(requestErr).WithFields(logrus.Fields{
+			"blockRange":  batcher.size,
+			"concurrency": batcher.concurrency,
+		}).Debug("Deposit log request failed, retrying")
+		if rateLimitedError(requestErr) {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(logRequestBackoff):
 			}
 		}
 	}
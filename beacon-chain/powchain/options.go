@@ -1,6 +1,9 @@
 package powchain
 
 import (
+	"crypto/tls"
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
@@ -40,6 +43,78 @@ func WithExecutionEndpoint(endpoint string) Option {
 	}
 }
 
+// WithExecutionFallbackEndpoints for execution node JSON-RPC endpoints to fail over to,
+// in order of priority, when the primary execution endpoint is unavailable.
+func WithExecutionFallbackEndpoints(endpoints []string) Option {
+	return func(s *Service) error {
+		s.cfg.executionFallbackEndpoints = dedupEndpoints(endpoints)
+		return nil
+	}
+}
+
+// WithExecutionEthEndpoint for a separate JSON-RPC endpoint, such as a hosted provider, serving
+// the read-only eth namespace requests of the engine API client.
+func WithExecutionEthEndpoint(endpoint string) Option {
+	return func(s *Service) error {
+		s.cfg.executionEthEndpoint = endpoint
+		return nil
+	}
+}
+
+// WithExecutionEndpointJWTSecret for the execution node JSON-RPC endpoint.
+func WithExecutionEndpointJWTSecret(secret []byte) Option {
+	return func(s *Service) error {
+		s.cfg.executionEndpointJWTSecret = secret
+		return nil
+	}
+}
+
+// WithExecutionEndpointHeaders for custom HTTP headers attached to every request sent to the
+// execution node JSON-RPC endpoint and to the eth1 endpoints.
+func WithExecutionEndpointHeaders(headers map[string]string) Option {
+	return func(s *Service) error {
+		s.cfg.executionEndpointHeaders = headers
+		return nil
+	}
+}
+
+// WithExecutionEndpointTLSConfig for connecting to the execution node JSON-RPC endpoints over TLS,
+// such as custom root certificate authorities or a client certificate.
+func WithExecutionEndpointTLSConfig(cfg *tls.Config) Option {
+	return func(s *Service) error {
+		s.cfg.executionEndpointTLSConfig = cfg
+		return nil
+	}
+}
+
+// WithEngineAPIDebugLogging logs the requests and responses of every engine API call, with
+// the transaction lists of payloads truncated to at most maxTransactions entries.
+func WithEngineAPIDebugLogging(maxTransactions int) Option {
+	return func(s *Service) error {
+		s.cfg.engineAPIDebug = true
+		s.cfg.engineAPIDebugMaxTxs = maxTransactions
+		return nil
+	}
+}
+
+// WithEngineAPISlowCallThreshold for the latency above which newPayload and forkchoiceUpdated
+// calls to the execution node are reported as slow. A zero threshold disables the reports.
+func WithEngineAPISlowCallThreshold(threshold time.Duration) Option {
+	return func(s *Service) error {
+		s.cfg.engineAPISlowThreshold = threshold
+		return nil
+	}
+}
+
+// WithEngineAPIRateLimit for the number of non-critical requests per second, such as execution
+// block scans and payload body fetches, sent to the execution node. A zero limit disables it.
+func WithEngineAPIRateLimit(requestsPerSecond int) Option {
+	return func(s *Service) error {
+		s.cfg.engineAPIRateLimit = requestsPerSecond
+		return nil
+	}
+}
+
 // WithDepositContractAddress for the deposit contract.
 func WithDepositContractAddress(addr common.Address) Option {
 	return func(s *Service) error {
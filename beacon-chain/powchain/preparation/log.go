@@ -0,0 +1,5 @@
+package preparation
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "payload-preparation")
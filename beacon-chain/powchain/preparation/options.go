@@ -0,0 +1,85 @@
+package preparation
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+)
+
+// Option for configuring the payload preparation service.
+type Option func(s *Service) error
+
+// WithEngineCaller for sending forkchoice updates to the execution node.
+func WithEngineCaller(caller engine.EngineCaller) Option {
+	return func(s *Service) error {
+		s.cfg.engine = caller
+		return nil
+	}
+}
+
+// WithChainInfoFetcher for retrieving the head of the beacon chain and the genesis time.
+func WithChainInfoFetcher(chain blockchain.ChainInfoFetcher) Option {
+	return func(s *Service) error {
+		s.cfg.chain = chain
+		return nil
+	}
+}
+
+// WithDatabase for retrieving the finalized block.
+func WithDatabase(database db.ReadOnlyDatabase) Option {
+	return func(s *Service) error {
+		s.cfg.db = database
+		return nil
+	}
+}
+
+// WithPayloadIDCache for storing the IDs of the payloads built by the execution node.
+func WithPayloadIDCache(c *cache.PayloadIDCache) Option {
+	return func(s *Service) error {
+		s.cfg.payloadIDs = c
+		return nil
+	}
+}
+
+// WithTrackedValidators for the validators whose proposals are prepared.
+func WithTrackedValidators(indices []types.ValidatorIndex) Option {
+	return func(s *Service) error {
+		s.cfg.tracked = make(map[types.ValidatorIndex]bool, len(indices))
+		for _, idx := range indices {
+			s.cfg.tracked[idx] = true
+		}
+		return nil
+	}
+}
+
+// WithFeeRecipient for the address the transaction fees of prepared payloads are paid to.
+func WithFeeRecipient(addr common.Address) Option {
+	return func(s *Service) error {
+		s.cfg.feeRecipient = addr
+		return nil
+	}
+}
+
+// WithLeadTime for how long before the start of a proposal slot the execution node is requested
+// to build its payload.
+func WithLeadTime(lead time.Duration) Option {
+	return func(s *Service) error {
+		s.cfg.leadTime = lead
+		return nil
+	}
+}
+
+// WithStateNotifier allows publishing the attributes of prepared payloads on the beacon node's
+// state feed, for external block builders.
+func WithStateNotifier(notifier statefeed.Notifier) Option {
+	return func(s *Service) error {
+		s.cfg.stateNotifier = notifier
+		return nil
+	}
+}
@@ -0,0 +1,413 @@
+// Package preparation defines a service which requests the execution node to start building the
+// payload of an upcoming proposal of a tracked validator ahead of time, by sending a forkchoice
+// update with payload attributes, so the execution node has more time to build a valuable payload.
+package preparation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/crypto/hash"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// Period between checks of whether the genesis time is known, before the chain has started.
+var genesisPollInterval = time.Second
+
+type config struct {
+	engine        engine.EngineCaller
+	chain         blockchain.ChainInfoFetcher
+	db            db.ReadOnlyDatabase
+	payloadIDs    *cache.PayloadIDCache
+	stateNotifier statefeed.Notifier
+	tracked       map[types.ValidatorIndex]bool
+	feeRecipient  common.Address
+	leadTime      time.Duration
+}
+
+// PayloadPreparer requests the execution node to build the payload of a proposal.
+type PayloadPreparer interface {
+	PreparePayload(ctx context.Context, slot types.Slot, parentRoot [32]byte, parentState state.BeaconState) (cache.PayloadID, error)
+}
+
+// Service sends a forkchoice update with payload attributes to the execution node ahead of
+// every slot in which a tracked validator is due to propose, and stores the returned payload ID.
+// On every head update, the payload of a tracked validator proposing at the next slot is prepared
+// again on top of the new head. Proposals of untracked validators are only prepared on demand
+// through PreparePayload, and the attributes of prepared payloads are published on the state feed.
+type Service struct {
+	cfg    *config
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewService sets up a new payload preparation service. By default payloads are prepared
+// one slot ahead of their proposal.
+func NewService(ctx context.Context, opts ...Option) (*Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		cfg: &config{
+			leadTime: time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second,
+		},
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	switch {
+	case s.cfg.engine == nil:
+		cancel()
+		return nil, errors.New("no engine API client provided")
+	case s.cfg.chain == nil:
+		cancel()
+		return nil, errors.New("no chain info fetcher provided")
+	case s.cfg.db == nil:
+		cancel()
+		return nil, errors.New("no database provided")
+	case s.cfg.payloadIDs == nil:
+		cancel()
+		return nil, errors.New("no payload ID cache provided")
+	}
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	if s.cfg.leadTime <= 0 || s.cfg.leadTime > slotDuration {
+		cancel()
+		return nil, errors.Errorf("lead time must be positive and at most %v", slotDuration)
+	}
+	return s, nil
+}
+
+// Start preparing payloads in the background.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop the service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the service.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	genesis, err := s.waitForGenesis()
+	if err != nil {
+		return
+	}
+	headUpdated := make(chan struct{}, 1)
+	if s.cfg.stateNotifier != nil {
+		go s.watchHeadUpdates(headUpdated)
+	}
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	// Ticks of slot n happen the lead time before the start of slot n+1.
+	offset := time.Duration(secondsPerSlot)*time.Second - s.cfg.leadTime
+	ticker := slots.NewSlotTickerWithOffset(genesis, offset, secondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case slot := <-ticker.C():
+			if err := s.prepare(s.ctx, slot+1); err != nil {
+				log.WithError(err).WithField("slot", slot+1).Warn("Could not prepare execution payload")
+			}
+		case <-headUpdated:
+			slot := slots.CurrentSlot(uint64(genesis.Unix())) + 1
+			if err := s.prepare(s.ctx, slot); err != nil {
+				log.WithError(err).WithField("slot", slot).Warn("Could not prepare execution payload")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting payload preparation")
+			return
+		}
+	}
+}
+
+// Signals head updates of the chain on the given channel, without waiting for them to be handled,
+// as handling them sends events on the same state feed. Head updates which happen while one is
+// pending are merged into it.
+func (s *Service) watchHeadUpdates(headUpdated chan<- struct{}) {
+	events := make(chan *feed.Event, 1)
+	sub := s.cfg.stateNotifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case ev := <-events:
+			if ev.Type != statefeed.NewHead {
+				continue
+			}
+			select {
+			case headUpdated <- struct{}{}:
+			default:
+			}
+		case <-sub.Err():
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// Blocks until the genesis time of the beacon chain is known.
+func (s *Service) waitForGenesis() (time.Time, error) {
+	for {
+		if genesis := s.cfg.chain.GenesisTime(); !genesis.IsZero() {
+			return genesis, nil
+		}
+		select {
+		case <-time.After(genesisPollInterval):
+		case <-s.ctx.Done():
+			return time.Time{}, s.ctx.Err()
+		}
+	}
+}
+
+// PreparePayload sends a forkchoice update with the attributes of the payload to propose at the
+// given slot on top of the given parent block, whoever that slot's proposer is, and returns the ID
+// of the payload the execution node starts building. The ID is cached under the parent root, so
+// later proposals on the same parent reuse it.
+func (s *Service) PreparePayload(
+	ctx context.Context, slot types.Slot, parentRoot [32]byte, parentState state.BeaconState,
+) (cache.PayloadID, error) {
+	req, err := s.payloadRequest(ctx, slot, parentRoot, parentState, false /* trackedOnly */)
+	if err != nil {
+		return cache.PayloadID{}, err
+	}
+	if req == nil {
+		return cache.PayloadID{}, errors.Errorf("no execution payload to build for slot %d", slot)
+	}
+	id, err := s.sendPayloadRequest(ctx, req)
+	if err != nil {
+		return cache.PayloadID{}, err
+	}
+	return *id, nil
+}
+
+// Prepares the payload to propose at the given slot on top of the current head, if that slot's
+// proposer is a tracked validator.
+func (s *Service) prepare(ctx context.Context, slot types.Slot) error {
+	if len(s.cfg.tracked) == 0 {
+		return nil
+	}
+	headRoot, err := s.cfg.chain.HeadRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head root")
+	}
+	st, err := s.cfg.chain.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	req, err := s.payloadRequest(ctx, slot, bytesutil.ToBytes32(headRoot), st, true /* trackedOnly */)
+	if err != nil || req == nil {
+		return err
+	}
+	_, err = s.sendPayloadRequest(ctx, req)
+	return err
+}
+
+// The attributes of the payload to propose at a slot on top of a parent block.
+type payloadRequest struct {
+	version    int
+	slot       types.Slot
+	proposer   types.ValidatorIndex
+	parentRoot [32]byte
+	header     *ethpb.ExecutionPayloadHeader
+	fcs        *pb.ForkchoiceState
+	attrs      *pb.PayloadAttributesV2
+}
+
+// Returns the attributes of the payload to propose at the given slot on top of the given parent
+// block and its state, or nil when no payload is to be built, such as before the merge or, if
+// trackedOnly is set, when the slot's proposer is not a tracked validator.
+func (s *Service) payloadRequest(
+	ctx context.Context, slot types.Slot, parentRoot [32]byte, st state.BeaconState, trackedOnly bool,
+) (*payloadRequest, error) {
+	if st == nil || st.IsNil() || st.Version() < version.Bellatrix || st.Slot() >= slot {
+		return nil, nil
+	}
+	complete, err := blocks.MergeTransitionComplete(st)
+	if err != nil {
+		return nil, err
+	}
+	// Payloads of the merge transition block are built on the terminal proof-of-work block instead.
+	if !complete {
+		return nil, nil
+	}
+	header, err := st.LatestExecutionPayloadHeader()
+	if err != nil {
+		return nil, err
+	}
+	var proposer types.ValidatorIndex
+	if slots.ToEpoch(slot) == slots.ToEpoch(st.Slot()) {
+		// The proposer and the randao mix of a slot in the epoch of the state are known without
+		// advancing the state.
+		proposer, err = proposerIndexAtSlot(ctx, st, slot)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute proposer index")
+		}
+		if trackedOnly && !s.cfg.tracked[proposer] {
+			return nil, nil
+		}
+	} else {
+		// The proposers of the next epoch depend on its epoch processing, so the state is advanced
+		// first. The caller only asks for tracked proposals when there are tracked validators.
+		st, err = transition.ProcessSlots(ctx, st.Copy(), slot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not process slots up to %d", slot)
+		}
+		proposer, err = helpers.BeaconProposerIndex(ctx, st)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute proposer index")
+		}
+		if trackedOnly && !s.cfg.tracked[proposer] {
+			return nil, nil
+		}
+	}
+	random, err := helpers.RandaoMix(st, slots.ToEpoch(slot))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get randao mix")
+	}
+	finalizedHash, err := s.finalizedBlockHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The states of this chain do not process withdrawals, so that none are due in its payloads.
+	return &payloadRequest{
+		version:    st.Version(),
+		slot:       slot,
+		proposer:   proposer,
+		parentRoot: parentRoot,
+		header:     header,
+		fcs: &pb.ForkchoiceState{
+			HeadBlockHash:      header.BlockHash,
+			SafeBlockHash:      header.BlockHash,
+			FinalizedBlockHash: finalizedHash,
+		},
+		attrs: &pb.PayloadAttributesV2{
+			Timestamp:             uint64(s.cfg.chain.GenesisTime().Unix()) + uint64(slot)*params.BeaconConfig().SecondsPerSlot,
+			PrevRandao:            random,
+			SuggestedFeeRecipient: s.cfg.feeRecipient.Bytes(),
+			Withdrawals:           []*pb.Withdrawal{},
+		},
+	}, nil
+}
+
+// Returns the proposer of a slot in the epoch of the given state, as helpers.BeaconProposerIndex
+// would once the state is advanced to that slot.
+func proposerIndexAtSlot(ctx context.Context, st state.ReadOnlyBeaconState, slot types.Slot) (types.ValidatorIndex, error) {
+	epoch := slots.ToEpoch(slot)
+	seed, err := helpers.Seed(st, epoch, params.BeaconConfig().DomainBeaconProposer)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not generate seed")
+	}
+	seedWithSlot := hash.Hash(append(seed[:], bytesutil.Bytes8(uint64(slot))...))
+	indices, err := helpers.ActiveValidatorIndices(ctx, st, epoch)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get active indices")
+	}
+	return helpers.ComputeProposerIndex(st, indices, seedWithSlot)
+}
+
+// Sends the forkchoice update of the payload request to the execution node, caches the ID of the
+// payload it starts building and publishes the attributes of that payload.
+func (s *Service) sendPayloadRequest(ctx context.Context, req *payloadRequest) (*cache.PayloadID, error) {
+	resp, err := s.cfg.engine.ForkchoiceUpdatedForSlot(ctx, req.slot, req.fcs, req.attrs)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not send forkchoice update")
+	}
+	if resp.PayloadId == nil {
+		return nil, errors.Errorf("execution node did not start building a payload, status %v", resp.Status.GetStatus())
+	}
+	id := cache.PayloadID(*resp.PayloadId)
+	s.cfg.payloadIDs.SetPayloadID(req.slot, req.parentRoot, s.cfg.feeRecipient, id)
+	s.notifyPayloadAttributes(req)
+	log.WithFields(logrus.Fields{
+		"slot":          req.slot,
+		"proposerIndex": req.proposer,
+		"payloadID":     fmt.Sprintf("%#x", id[:]),
+	}).Debug("Prepared execution payload for upcoming proposal")
+	return &id, nil
+}
+
+// Publishes the attributes of the payload to propose at the slot of the request on the state feed.
+func (s *Service) notifyPayloadAttributes(req *payloadRequest) {
+	if s.cfg.stateNotifier == nil {
+		return
+	}
+	withdrawals := make([]*ethpbv1.EventPayloadAttributes_Withdrawal, len(req.attrs.Withdrawals))
+	for i, w := range req.attrs.Withdrawals {
+		withdrawals[i] = &ethpbv1.EventPayloadAttributes_Withdrawal{
+			Index:          w.Index,
+			ValidatorIndex: w.ValidatorIndex,
+			Address:        w.Address,
+			Amount:         w.Amount,
+		}
+	}
+	s.cfg.stateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.PayloadAttributes,
+		Data: &ethpbv1.EventPayloadAttributes{
+			Version: version.String(req.version),
+			Data: &ethpbv1.EventPayloadAttributes_Data{
+				ProposerIndex:     req.proposer,
+				ProposalSlot:      req.slot,
+				ParentBlockNumber: req.header.BlockNumber,
+				ParentBlockRoot:   req.parentRoot[:],
+				ParentBlockHash:   req.header.BlockHash,
+				PayloadAttributes: &ethpbv1.EventPayloadAttributes_PayloadAttributes{
+					Timestamp:             req.attrs.Timestamp,
+					PrevRandao:            req.attrs.PrevRandao,
+					SuggestedFeeRecipient: req.attrs.SuggestedFeeRecipient,
+					Withdrawals:           withdrawals,
+				},
+			},
+		},
+	})
+}
+
+// Returns the execution block hash of the finalized beacon block, or the zero hash if
+// the finalized block predates the merge.
+func (s *Service) finalizedBlockHash(ctx context.Context) ([]byte, error) {
+	zeroHash := params.BeaconConfig().ZeroHash[:]
+	cp := s.cfg.chain.FinalizedCheckpt()
+	if cp == nil {
+		return zeroHash, nil
+	}
+	blk, err := s.cfg.db.Block(ctx, bytesutil.ToBytes32(cp.Root))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized block")
+	}
+	if blk == nil || blk.IsNil() || blk.Version() < version.Bellatrix {
+		return zeroHash, nil
+	}
+	payload, err := blk.Block().Body().ExecutionPayload()
+	if err != nil {
+		return nil, err
+	}
+	return payload.BlockHash, nil
+}
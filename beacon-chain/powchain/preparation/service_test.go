@@ -0,0 +1,266 @@
+package preparation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	types "github.com/prysmaticlabs/eth2-types"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+	mockEngine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+// Returns a genesis head state, after the merge transition if mergeComplete is set, and the
+// proposer of the slot following it.
+func headState(t *testing.T, mergeComplete bool) (state.BeaconState, types.ValidatorIndex) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateBellatrix(t, 64)
+	if mergeComplete {
+		header, err := st.LatestExecutionPayloadHeader()
+		require.NoError(t, err)
+		header.BlockHash = bytesutil.PadTo([]byte("head"), 32)
+		require.NoError(t, st.SetLatestExecutionPayloadHeader(header))
+	}
+	next, err := transition.ProcessSlots(ctx, st.Copy(), st.Slot()+1)
+	require.NoError(t, err)
+	proposer, err := helpers.BeaconProposerIndex(ctx, next)
+	require.NoError(t, err)
+	return st, proposer
+}
+
+func newTestService(
+	t *testing.T, st state.BeaconState, client *mockEngine.EngineClient, tracked ...types.ValidatorIndex,
+) (*Service, *cache.PayloadIDCache) {
+	payloadIDs := cache.NewPayloadIDCache()
+	chain := &mock.ChainService{State: st, Root: []byte("root"), Genesis: time.Now()}
+	s, err := NewService(
+		context.Background(),
+		WithEngineCaller(client),
+		WithChainInfoFetcher(chain),
+		WithDatabase(dbtest.SetupDB(t)),
+		WithPayloadIDCache(payloadIDs),
+		WithTrackedValidators(tracked),
+		WithFeeRecipient(common.HexToAddress("0x01")),
+		WithStateNotifier(chain.StateNotifier()),
+	)
+	require.NoError(t, err)
+	return s, payloadIDs
+}
+
+func TestService_PrepareTrackedProposal(t *testing.T) {
+	st, proposer := headState(t, true)
+	id := pb.PayloadIDBytes{1, 2, 3}
+	client := &mockEngine.EngineClient{
+		ForkchoiceUpdatedResp: &engine.ForkchoiceUpdatedResponse{
+			Status:    &pb.PayloadStatus{Status: pb.PayloadStatus_VALID},
+			PayloadId: &id,
+		},
+	}
+	s, payloadIDs := newTestService(t, st, client, proposer)
+	events := make(chan *feed.Event, 1)
+	sub := s.cfg.stateNotifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+	require.NoError(t, s.prepare(context.Background(), 1))
+	require.Equal(t, 1, client.Calls(engine.ForkchoiceUpdatedMethodV2))
+
+	cached, ok := payloadIDs.PayloadID(1, bytesutil.ToBytes32([]byte("root")), common.HexToAddress("0x01"))
+	require.Equal(t, true, ok)
+	require.Equal(t, cache.PayloadID(id), cached)
+
+	ev := <-events
+	require.Equal(t, feed.EventType(statefeed.PayloadAttributes), ev.Type)
+	attrs, ok := ev.Data.(*ethpbv1.EventPayloadAttributes)
+	require.Equal(t, true, ok)
+	require.Equal(t, "bellatrix", attrs.Version)
+	require.Equal(t, proposer, attrs.Data.ProposerIndex)
+	require.Equal(t, types.Slot(1), attrs.Data.ProposalSlot)
+	require.DeepEqual(t, bytesutil.PadTo([]byte("root"), 32), attrs.Data.ParentBlockRoot)
+	require.DeepEqual(t, bytesutil.PadTo([]byte("head"), 32), attrs.Data.ParentBlockHash)
+	require.DeepEqual(t, common.HexToAddress("0x01").Bytes(), attrs.Data.PayloadAttributes.SuggestedFeeRecipient)
+	require.Equal(t, 0, len(attrs.Data.PayloadAttributes.Withdrawals))
+}
+
+func TestService_SkipsUntrackedProposal(t *testing.T) {
+	st, proposer := headState(t, true)
+	client := &mockEngine.EngineClient{}
+	s, _ := newTestService(t, st, client, proposer+1)
+	events := make(chan *feed.Event, 1)
+	sub := s.cfg.stateNotifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+	require.NoError(t, s.prepare(context.Background(), 1))
+	require.Equal(t, 0, client.Calls(engine.ForkchoiceUpdatedMethodV2))
+	require.Equal(t, 0, len(events), "Payload attributes published for an untracked proposer")
+}
+
+func TestProposerIndexAtSlot(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateBellatrix(t, 64)
+	for slot := types.Slot(1); slot < params.BeaconConfig().SlotsPerEpoch; slot++ {
+		proposer, err := proposerIndexAtSlot(ctx, st, slot)
+		require.NoError(t, err)
+		next, err := transition.ProcessSlots(ctx, st.Copy(), slot)
+		require.NoError(t, err)
+		want, err := helpers.BeaconProposerIndex(ctx, next)
+		require.NoError(t, err)
+		require.Equal(t, want, proposer, "Wrong proposer at slot %d", slot)
+	}
+}
+
+func TestService_PrepareTrackedProposalOfNextEpoch(t *testing.T) {
+	ctx := context.Background()
+	st, _ := headState(t, true)
+	slot := params.BeaconConfig().SlotsPerEpoch
+	next, err := transition.ProcessSlots(ctx, st.Copy(), slot)
+	require.NoError(t, err)
+	proposer, err := helpers.BeaconProposerIndex(ctx, next)
+	require.NoError(t, err)
+	id := pb.PayloadIDBytes{1, 2, 3}
+	client := &mockEngine.EngineClient{
+		ForkchoiceUpdatedResp: &engine.ForkchoiceUpdatedResponse{
+			Status:    &pb.PayloadStatus{Status: pb.PayloadStatus_VALID},
+			PayloadId: &id,
+		},
+	}
+	s, payloadIDs := newTestService(t, st, client, proposer)
+	require.NoError(t, s.prepare(ctx, slot))
+	require.Equal(t, 1, client.Calls(engine.ForkchoiceUpdatedMethodV2))
+	_, ok := payloadIDs.PayloadID(slot, bytesutil.ToBytes32([]byte("root")), common.HexToAddress("0x01"))
+	require.Equal(t, true, ok)
+}
+
+func TestService_PreparePayloadOfUntrackedProposal(t *testing.T) {
+	st, proposer := headState(t, true)
+	id := pb.PayloadIDBytes{1, 2, 3}
+	client := &mockEngine.EngineClient{
+		ForkchoiceUpdatedResp: &engine.ForkchoiceUpdatedResponse{
+			Status:    &pb.PayloadStatus{Status: pb.PayloadStatus_VALID},
+			PayloadId: &id,
+		},
+	}
+	s, payloadIDs := newTestService(t, st, client, proposer+1)
+	prepared, err := s.PreparePayload(context.Background(), 1, bytesutil.ToBytes32([]byte("root")), st)
+	require.NoError(t, err)
+	require.Equal(t, cache.PayloadID(id), prepared)
+	require.Equal(t, 1, client.Calls(engine.ForkchoiceUpdatedMethodV2))
+
+	cached, ok := payloadIDs.PayloadID(1, bytesutil.ToBytes32([]byte("root")), common.HexToAddress("0x01"))
+	require.Equal(t, true, ok)
+	require.Equal(t, cache.PayloadID(id), cached)
+}
+
+func TestService_PreparePayloadOnParent(t *testing.T) {
+	st, proposer := headState(t, true)
+	parent := st.Copy()
+	header, err := parent.LatestExecutionPayloadHeader()
+	require.NoError(t, err)
+	header.BlockHash = bytesutil.PadTo([]byte("parent"), 32)
+	require.NoError(t, parent.SetLatestExecutionPayloadHeader(header))
+	id := pb.PayloadIDBytes{1, 2, 3}
+	client := &mockEngine.EngineClient{
+		ForkchoiceUpdatedResp: &engine.ForkchoiceUpdatedResponse{
+			Status:    &pb.PayloadStatus{Status: pb.PayloadStatus_VALID},
+			PayloadId: &id,
+		},
+	}
+	s, payloadIDs := newTestService(t, st, client, proposer)
+	events := make(chan *feed.Event, 1)
+	sub := s.cfg.stateNotifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+	parentRoot := bytesutil.ToBytes32([]byte("parent"))
+	_, err = s.PreparePayload(context.Background(), 1, parentRoot, parent)
+	require.NoError(t, err)
+
+	_, ok := payloadIDs.PayloadID(1, bytesutil.ToBytes32([]byte("root")), common.HexToAddress("0x01"))
+	require.Equal(t, false, ok, "Payload cached under the head root instead of the parent root")
+	cached, ok := payloadIDs.PayloadID(1, parentRoot, common.HexToAddress("0x01"))
+	require.Equal(t, true, ok)
+	require.Equal(t, cache.PayloadID(id), cached)
+	attrs, ok := (<-events).Data.(*ethpbv1.EventPayloadAttributes)
+	require.Equal(t, true, ok)
+	require.DeepEqual(t, parentRoot[:], attrs.Data.ParentBlockRoot)
+	require.DeepEqual(t, bytesutil.PadTo([]byte("parent"), 32), attrs.Data.ParentBlockHash)
+}
+
+func TestService_PreparePayloadBeforeMerge(t *testing.T) {
+	st, proposer := headState(t, false)
+	client := &mockEngine.EngineClient{}
+	s, _ := newTestService(t, st, client, proposer)
+	_, err := s.PreparePayload(context.Background(), 1, bytesutil.ToBytes32([]byte("root")), st)
+	require.ErrorContains(t, "no execution payload to build", err)
+	require.Equal(t, 0, client.Calls(engine.ForkchoiceUpdatedMethodV2))
+}
+
+func TestService_SkipsBeforeMerge(t *testing.T) {
+	st, proposer := headState(t, false)
+	client := &mockEngine.EngineClient{}
+	s, _ := newTestService(t, st, client, proposer)
+	require.NoError(t, s.prepare(context.Background(), 1))
+	require.Equal(t, 0, client.Calls(engine.ForkchoiceUpdatedMethodV2))
+}
+
+func TestService_NoPayloadID(t *testing.T) {
+	st, proposer := headState(t, true)
+	client := &mockEngine.EngineClient{
+		ForkchoiceUpdatedResp: &engine.ForkchoiceUpdatedResponse{
+			Status: &pb.PayloadStatus{Status: pb.PayloadStatus_SYNCING},
+		},
+	}
+	s, payloadIDs := newTestService(t, st, client, proposer)
+	events := make(chan *feed.Event, 1)
+	sub := s.cfg.stateNotifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+	require.ErrorContains(t, "did not start building a payload", s.prepare(context.Background(), 1))
+	_, ok := payloadIDs.PayloadID(1, bytesutil.ToBytes32([]byte("root")), common.HexToAddress("0x01"))
+	require.Equal(t, false, ok)
+	require.Equal(t, 0, len(events), "Payload attributes published without a payload being built")
+}
+
+func TestService_NotifyPayloadAttributesWithdrawals(t *testing.T) {
+	st, proposer := headState(t, true)
+	s, _ := newTestService(t, st, &mockEngine.EngineClient{}, proposer)
+	events := make(chan *feed.Event, 1)
+	sub := s.cfg.stateNotifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+	req, err := s.payloadRequest(context.Background(), 1, bytesutil.ToBytes32([]byte("root")), st, false /* trackedOnly */)
+	require.NoError(t, err)
+	withdrawal := &pb.Withdrawal{Index: 1, ValidatorIndex: 2, Address: bytesutil.PadTo([]byte{3}, 20), Amount: 4}
+	req.attrs.Withdrawals = []*pb.Withdrawal{withdrawal}
+	s.notifyPayloadAttributes(req)
+
+	attrs, ok := (<-events).Data.(*ethpbv1.EventPayloadAttributes)
+	require.Equal(t, true, ok)
+	require.Equal(t, 1, len(attrs.Data.PayloadAttributes.Withdrawals))
+	require.DeepEqual(t, &ethpbv1.EventPayloadAttributes_Withdrawal{
+		Index:          1,
+		ValidatorIndex: 2,
+		Address:        withdrawal.Address,
+		Amount:         4,
+	}, attrs.Data.PayloadAttributes.Withdrawals[0])
+}
+
+func TestNewService_InvalidLeadTime(t *testing.T) {
+	_, err := NewService(
+		context.Background(),
+		WithEngineCaller(&mockEngine.EngineClient{}),
+		WithChainInfoFetcher(&mock.ChainService{}),
+		WithDatabase(dbtest.SetupDB(t)),
+		WithPayloadIDCache(cache.NewPayloadIDCache()),
+		WithLeadTime(0),
+	)
+	require.ErrorContains(t, "lead time must be positive", err)
+}
@@ -5,6 +5,7 @@ package powchain
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -128,17 +129,26 @@ type RPCClient interface {
 
 // config defines a config struct for dependencies into the service.
 type config struct {
-	depositContractAddr     common.Address
-	beaconDB                db.HeadAccessDatabase
-	depositCache            *depositcache.DepositCache
-	stateNotifier           statefeed.Notifier
-	stateGen                *stategen.State
-	eth1HeaderReqLimit      uint64
-	beaconNodeStatsUpdater  BeaconNodeStatsUpdater
-	httpEndpoints           []network.Endpoint
-	executionEndpoint       string
-	currHttpEndpoint        network.Endpoint
-	finalizedStateAtStartup state.BeaconState
+	depositContractAddr        common.Address
+	beaconDB                   db.HeadAccessDatabase
+	depositCache               *depositcache.DepositCache
+	stateNotifier              statefeed.Notifier
+	stateGen                   *stategen.State
+	eth1HeaderReqLimit         uint64
+	beaconNodeStatsUpdater     BeaconNodeStatsUpdater
+	httpEndpoints              []network.Endpoint
+	executionEndpoint          string
+	executionEndpointJWTSecret []byte
+	executionEndpointHeaders   map[string]string
+	executionFallbackEndpoints []string
+	executionEthEndpoint       string
+	executionEndpointTLSConfig *tls.Config
+	engineAPIDebug             bool
+	engineAPIDebugMaxTxs       int
+	engineAPISlowThreshold     time.Duration
+	engineAPIRateLimit         int
+	currHttpEndpoint           network.Endpoint
+	finalizedStateAtStartup    state.BeaconState
 }
 
 // Service fetches important information about the canonical
@@ -165,6 +175,8 @@ type Service struct {
 	depositTrie             *trie.SparseMerkleTrie
 	chainStartData          *ethpb.ChainStartData
 	lastReceivedMerkleIndex int64 // Keeps track of the last received index to prevent log spam.
+	lastSnapshotRoot        [32]byte
+	endpointHealth          *endpointHealth
 	runError                error
 	preGenesisState         state.BeaconState
 }
@@ -189,6 +201,8 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		cfg: &config{
 			beaconNodeStatsUpdater: &NopBeaconNodeStatsUpdater{},
 			eth1HeaderReqLimit:     defaultEth1HeaderReqLimit,
+			engineAPISlowThreshold: engine.DefaultSlowCallThreshold,
+			engineAPIRateLimit:     engine.DefaultRateLimit,
 		},
 		latestEth1Data: &ethpb.LatestETH1Data{
 			BlockHeight:        0,
@@ -196,8 +210,9 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 			BlockHash:          []byte{},
 			LastRequestedBlock: 0,
 		},
-		headerCache: newHeaderCache(),
-		depositTrie: depositTrie,
+		headerCache:    newHeaderCache(),
+		endpointHealth: newEndpointHealth(),
+		depositTrie:    depositTrie,
 		chainStartData: &ethpb.ChainStartData{
 			Eth1Data:           &ethpb.Eth1Data{},
 			ChainstartDeposits: make([]*ethpb.Deposit, 0),
@@ -268,6 +283,9 @@ func (s *Service) Stop() error {
 		defer s.cancel()
 	}
 	s.closeClients()
+	if s.engineAPIClient != nil {
+		s.engineAPIClient.Close()
+	}
 	return nil
 }
 
@@ -455,6 +473,10 @@ func (s *Service) dialETH1Nodes(endpoint network.Endpoint) (*ethclient.Client, *
 	if err != nil {
 		return nil, nil, err
 	}
+	for key, value := range s.cfg.executionEndpointHeaders {
+		httpRPCClient.SetHeader(key, value)
+	}
+	// Credentials of the endpoint itself take precedence over a custom authorization header.
 	if endpoint.Auth.Method != authorization.None {
 		header, err := endpoint.Auth.ToHeaderValue()
 		if err != nil {
@@ -512,6 +534,20 @@ func (s *Service) initializeConnection(
 	s.rpcClient = rpcClient
 }
 
+// logFilterer fetches the logs matching a filter query.
+type logFilterer interface {
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]gethTypes.Log, error)
+}
+
+// Returns the filterer used to fetch deposit logs. Log queries are offloaded to the dedicated
+// eth endpoint of the engine API client when one is configured.
+func (s *Service) logFilterer() logFilterer {
+	if s.engineAPIClient != nil && s.cfg.executionEthEndpoint != "" {
+		return s.engineAPIClient
+	}
+	return s.httpLogger
+}
+
 // closes down our active eth1 clients.
 func (s *Service) closeClients() {
 	gethClient, ok := s.rpcClient.(*gethRPC.Client)
@@ -774,6 +810,7 @@ func (s *Service) handleETH1FollowDistance() {
 	}
 	if err := s.requestBatchedHeadersAndLogs(ctx); err != nil {
 		s.runError = err
+		s.endpointHealth.recordFailure(s.cfg.currHttpEndpoint)
 		log.Error(err)
 		return
 	}
@@ -861,17 +898,23 @@ func (s *Service) run(done <-chan struct{}) {
 			head, err := s.eth1DataFetcher.HeaderByNumber(s.ctx, nil)
 			if err != nil {
 				log.WithError(err).Debug("Could not fetch latest eth1 header")
+				s.endpointHealth.recordFailure(s.cfg.currHttpEndpoint)
 				s.retryETH1Node(err)
 				continue
 			}
 			if eth1HeadIsBehind(head.Time) {
 				log.WithError(errFarBehind).Debug("Could not get an up to date eth1 header")
+				s.endpointHealth.recordFailure(s.cfg.currHttpEndpoint)
 				s.retryETH1Node(errFarBehind)
 				continue
 			}
+			s.endpointHealth.recordSuccess(s.cfg.currHttpEndpoint, head.Number.Uint64())
 			s.processBlockHeader(head)
 			s.handleETH1FollowDistance()
-			s.checkDefaultEndpoint()
+			if err := s.updateDepositSnapshot(s.ctx); err != nil {
+				log.WithError(err).Debug("Could not update deposit snapshot")
+			}
+			s.checkEndpointHealth()
 		case <-chainstartTicker.C:
 			if s.chainStartData.Chainstarted {
 				chainstartTicker.Stop()
@@ -958,42 +1001,85 @@ func (s *Service) determineEarliestVotingBlock(ctx context.Context, followBlock
 	return hdr.Number.Uint64(), nil
 }
 
-// This performs a health check on our primary endpoint, and if it
-// is ready to serve we connect to it again. This method is only
-// relevant if we are on our backup endpoint.
-func (s *Service) checkDefaultEndpoint() {
-	primaryEndpoint := s.cfg.httpEndpoints[0]
-	// Return early if we are running on our primary
-	// endpoint.
-	if s.cfg.currHttpEndpoint.Equals(primaryEndpoint) {
+// This probes the heads of the endpoints not currently in use, and switches to the preferred
+// endpoint if it differs from the current one: the primary endpoint as soon as it is healthy
+// again, or any healthier endpoint if the current one has become unhealthy.
+func (s *Service) checkEndpointHealth() {
+	if len(s.cfg.httpEndpoints) <= 1 {
 		return
 	}
+	now := prysmTime.Now()
+	for _, endpoint := range s.cfg.httpEndpoints {
+		if endpoint.Equals(s.cfg.currHttpEndpoint) || !s.endpointHealth.markProbed(endpoint, now) {
+			continue
+		}
+		s.probeEndpoint(endpoint)
+	}
 
-	httpClient, rpcClient, err := s.dialETH1Nodes(primaryEndpoint)
-	if err != nil {
-		log.Debugf("Primary endpoint not ready: %v", err)
+	preferred := s.endpointHealth.preferred(s.cfg.httpEndpoints)
+	if preferred.Equals(s.cfg.currHttpEndpoint) {
 		return
 	}
-	log.Info("Primary endpoint ready again, switching back to it")
-	// Close the clients and let our main connection routine
-	// properly connect with it.
-	httpClient.Close()
-	rpcClient.Close()
+	if s.endpointHealth.healthy(s.cfg.currHttpEndpoint) && !s.precedesCurrentEndpoint(preferred) {
+		return
+	}
+	if !s.endpointHealth.healthy(preferred) && s.endpointHealth.score(preferred) >= s.endpointHealth.score(s.cfg.currHttpEndpoint) {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"from": logs.MaskCredentialsLogging(s.cfg.currHttpEndpoint.Url),
+		"to":   logs.MaskCredentialsLogging(preferred.Url),
+	}).Info("Switching to healthier eth1 endpoint")
 	// Close current active clients.
 	s.closeClients()
 
-	// Switch back to primary endpoint and try connecting
-	// to it again.
-	s.updateCurrHttpEndpoint(primaryEndpoint)
+	// Switch to the preferred endpoint and let our main
+	// connection routine properly connect with it.
+	s.updateCurrHttpEndpoint(preferred)
 	s.retryETH1Node(nil)
 }
 
-// This is an inefficient way to search for the next endpoint, but given N is expected to be
-// small ( < 25), it is fine to search this way.
+// Requests the head of the endpoint and records the outcome in its health.
+func (s *Service) probeEndpoint(endpoint network.Endpoint) {
+	httpClient, rpcClient, err := s.dialETH1Nodes(endpoint)
+	if err != nil {
+		log.WithError(err).Debugf("Eth1 endpoint %s not ready", logs.MaskCredentialsLogging(endpoint.Url))
+		s.endpointHealth.recordFailure(endpoint)
+		return
+	}
+	defer func() {
+		httpClient.Close()
+		rpcClient.Close()
+	}()
+	head, err := httpClient.HeaderByNumber(s.ctx, nil)
+	if err != nil || eth1HeadIsBehind(head.Time) {
+		s.endpointHealth.recordFailure(endpoint)
+		return
+	}
+	s.endpointHealth.recordSuccess(endpoint, head.Number.Uint64())
+}
+
+func (s *Service) precedesCurrentEndpoint(endpoint network.Endpoint) bool {
+	for _, ep := range s.cfg.httpEndpoints {
+		if ep.Equals(endpoint) {
+			return true
+		}
+		if ep.Equals(s.cfg.currHttpEndpoint) {
+			return false
+		}
+	}
+	return false
+}
+
+// Records a failure of the current endpoint and switches to the next healthy endpoint in the
+// configured order, or to the best scoring endpoint if none of the others is healthy. This is an
+// inefficient way to search for the next endpoint, but given N is expected to be small ( < 25),
+// it is fine to search this way.
 func (s *Service) fallbackToNextEndpoint() {
 	currEndpoint := s.cfg.currHttpEndpoint
 	currIndex := 0
 	totalEndpoints := len(s.cfg.httpEndpoints)
+	s.endpointHealth.recordFailure(currEndpoint)
 
 	for i, endpoint := range s.cfg.httpEndpoints {
 		if endpoint.Equals(currEndpoint) {
@@ -1001,9 +1087,18 @@ func (s *Service) fallbackToNextEndpoint() {
 			break
 		}
 	}
-	nextIndex := currIndex + 1
-	if nextIndex >= totalEndpoints {
-		nextIndex = 0
+	nextIndex := currIndex
+	bestScore := 0.0
+	for i := 1; i < totalEndpoints; i++ {
+		index := (currIndex + i) % totalEndpoints
+		score := s.endpointHealth.score(s.cfg.httpEndpoints[index])
+		if score < 1 {
+			nextIndex = index
+			break
+		}
+		if nextIndex == currIndex || score < bestScore {
+			nextIndex, bestScore = index, score
+		}
 	}
 	s.updateCurrHttpEndpoint(s.cfg.httpEndpoints[nextIndex])
 	if nextIndex != currIndex {
@@ -1031,6 +1126,15 @@ func (s *Service) initializeEth1Data(ctx context.Context, eth1DataInDB *ethpb.ET
 	s.latestEth1Data = eth1DataInDB.CurrentEth1Data
 	numOfItems := s.depositTrie.NumOfItems()
 	s.lastReceivedMerkleIndex = int64(numOfItems - 1)
+	snapshot, err := s.cfg.beaconDB.DepositSnapshot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve deposit snapshot")
+	}
+	if snapshot != nil {
+		if err := s.initializeFromDepositSnapshot(ctx, snapshot); err != nil {
+			return errors.Wrap(err, "could not initialize from deposit snapshot")
+		}
+	}
 	if err := s.initDepositCaches(ctx, eth1DataInDB.DepositContainers); err != nil {
 		return errors.Wrap(err, "could not initialize caches")
 	}
@@ -1039,7 +1143,7 @@ func (s *Service) initializeEth1Data(ctx context.Context, eth1DataInDB *ethpb.ET
 
 // validates that all deposit containers are valid and have their relevant indices
 // in order.
-func validateDepositContainers(ctrs []*ethpb.DepositContainer) bool {
+func validateDepositContainers(ctrs []*ethpb.DepositContainer, snapshotCount uint64) bool {
 	ctrLen := len(ctrs)
 	// Exit for empty containers.
 	if ctrLen == 0 {
@@ -1049,7 +1153,11 @@ func validateDepositContainers(ctrs []*ethpb.DepositContainer) bool {
 	sort.Slice(ctrs, func(i, j int) bool {
 		return ctrs[i].Index < ctrs[j].Index
 	})
+	// Containers processed on top of a deposit snapshot start after the deposits of the snapshot.
 	startIndex := int64(0)
+	if ctrs[0].Index <= int64(snapshotCount) {
+		startIndex = ctrs[0].Index
+	}
 	for _, c := range ctrs {
 		if c.Index != startIndex {
 			log.Info("Recovering missing deposit containers, node is re-requesting missing deposit data")
@@ -1075,7 +1183,15 @@ func (s *Service) ensureValidPowchainData(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "unable to retrieve eth1 data")
 	}
-	if eth1Data == nil || !eth1Data.ChainstartData.Chainstarted || !validateDepositContainers(eth1Data.DepositContainers) {
+	snapshot, err := s.cfg.beaconDB.DepositSnapshot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to retrieve deposit snapshot")
+	}
+	snapshotCount := uint64(0)
+	if snapshot != nil {
+		snapshotCount = snapshot.DepositCount
+	}
+	if eth1Data == nil || !eth1Data.ChainstartData.Chainstarted || !validateDepositContainers(eth1Data.DepositContainers, snapshotCount) {
 		pbState, err := v1.ProtobufBeaconState(s.preGenesisState.InnerStateUnsafe())
 		if err != nil {
 			return err
@@ -1104,7 +1220,34 @@ func (s *Service) initializeEngineAPIClient(ctx context.Context) error {
 	if s.cfg.executionEndpoint == "" {
 		return nil
 	}
-	client, err := engine.New(ctx, s.cfg.executionEndpoint)
+	opts := []engine.Option{
+		// Refuse execution nodes on another chain than the one of the beacon node.
+		engine.WithExpectedChainID(params.BeaconConfig().DepositChainID),
+	}
+	if len(s.cfg.executionEndpointJWTSecret) > 0 {
+		opts = append(opts, engine.WithJWTSecret(s.cfg.executionEndpointJWTSecret))
+	}
+	if len(s.cfg.executionEndpointHeaders) > 0 {
+		opts = append(opts, engine.WithHeaders(s.cfg.executionEndpointHeaders))
+	}
+	if len(s.cfg.executionFallbackEndpoints) > 0 {
+		opts = append(opts, engine.WithFallbackEndpoints(s.cfg.executionFallbackEndpoints))
+	}
+	if s.cfg.executionEthEndpoint != "" {
+		opts = append(opts, engine.WithEthEndpoint(s.cfg.executionEthEndpoint))
+	}
+	if s.cfg.executionEndpointTLSConfig != nil {
+		opts = append(opts, engine.WithTLSConfig(s.cfg.executionEndpointTLSConfig))
+	}
+	opts = append(opts, engine.WithSlowCallThreshold(s.cfg.engineAPISlowThreshold))
+	opts = append(opts, engine.WithRateLimit(s.cfg.engineAPIRateLimit))
+	if s.cfg.engineAPIDebug {
+		opts = append(opts, engine.WithDebugLogging(s.cfg.engineAPIDebugMaxTxs))
+	}
+	if s.cfg.stateNotifier != nil {
+		opts = append(opts, engine.WithStateNotifier(s.cfg.stateNotifier))
+	}
+	client, err := engine.New(ctx, s.cfg.executionEndpoint, opts...)
 	if err != nil {
 		return err
 	}
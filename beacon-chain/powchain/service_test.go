@@ -3,9 +3,12 @@ package powchain
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"sync"
 	"testing"
@@ -26,6 +29,7 @@ import (
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/monitoring/clientstats"
 	"github.com/prysmaticlabs/prysm/network"
+	"github.com/prysmaticlabs/prysm/network/authorization"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
@@ -761,9 +765,10 @@ func TestService_EnsureValidPowchainData(t *testing.T) {
 
 func TestService_ValidateDepositContainers(t *testing.T) {
 	var tt = []struct {
-		name        string
-		ctrsFunc    func() []*ethpb.DepositContainer
-		expectedRes bool
+		name          string
+		ctrsFunc      func() []*ethpb.DepositContainer
+		snapshotCount uint64
+		expectedRes   bool
 	}{
 		{
 			name: "zero containers",
@@ -808,10 +813,34 @@ func TestService_ValidateDepositContainers(t *testing.T) {
 			},
 			expectedRes: false,
 		},
+		{
+			name: "containers following a deposit snapshot",
+			ctrsFunc: func() []*ethpb.DepositContainer {
+				ctrs := make([]*ethpb.DepositContainer, 0)
+				for i := 5; i < 10; i++ {
+					ctrs = append(ctrs, &ethpb.DepositContainer{Index: int64(i), Eth1BlockHeight: uint64(i + 10)})
+				}
+				return ctrs
+			},
+			snapshotCount: 6,
+			expectedRes:   true,
+		},
+		{
+			name: "containers missing after a deposit snapshot",
+			ctrsFunc: func() []*ethpb.DepositContainer {
+				ctrs := make([]*ethpb.DepositContainer, 0)
+				for i := 5; i < 10; i++ {
+					ctrs = append(ctrs, &ethpb.DepositContainer{Index: int64(i), Eth1BlockHeight: uint64(i + 10)})
+				}
+				return ctrs
+			},
+			snapshotCount: 4,
+			expectedRes:   false,
+		},
 	}
 
 	for _, test := range tt {
-		assert.Equal(t, test.expectedRes, validateDepositContainers(test.ctrsFunc()))
+		assert.Equal(t, test.expectedRes, validateDepositContainers(test.ctrsFunc(), test.snapshotCount), test.name)
 	}
 }
 
@@ -849,3 +878,55 @@ func TestETH1Endpoints(t *testing.T) {
 	// Check endpoints are all present.
 	assert.DeepSSZEqual(t, endpoints, s1.ETH1Endpoints(), "Unexpected http endpoint slice")
 }
+
+func TestDialETH1Nodes_ExecutionHeaders(t *testing.T) {
+	var routes, auths []string
+	var lock sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		routes = append(routes, r.Header.Get("X-Route"))
+		auths = append(auths, r.Header.Get("Authorization"))
+		lock.Unlock()
+		req := struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Method {
+		case "eth_syncing":
+			resp["result"] = false
+		case "eth_chainId":
+			resp["result"] = fmt.Sprintf("%#x", params.BeaconConfig().DepositChainID)
+		case "net_version":
+			resp["result"] = fmt.Sprintf("%d", params.BeaconConfig().DepositNetworkID)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	s := &Service{
+		ctx: context.Background(),
+		cfg: &config{executionEndpointHeaders: map[string]string{
+			"X-Route":       "node-1",
+			"Authorization": "Bearer custom",
+		}},
+	}
+	httpClient, rpcClient, err := s.dialETH1Nodes(network.Endpoint{
+		Url:  srv.URL,
+		Auth: network.AuthorizationData{Method: authorization.Bearer, Value: "endpoint"},
+	})
+	require.NoError(t, err)
+	defer httpClient.Close()
+	defer rpcClient.Close()
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Equal(t, 3, len(routes))
+	for i := range routes {
+		assert.Equal(t, "node-1", routes[i])
+		// The credentials of the endpoint take precedence over the custom header.
+		assert.Equal(t, "Bearer endpoint", auths[i])
+	}
+}
@@ -0,0 +1,208 @@
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/config/params"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+)
+
+var (
+	// Maximum number of blocks walked back from the execution head in a single search, beyond
+	// which the terminal block is searched by block number instead.
+	maxWalkDepth = 1024
+	// Maximum number of ancestors fetched within a single batch request during a search.
+	maxWalkBatchSize = 64
+)
+
+// Block is the terminal proof-of-work block, the last block of the execution chain
+// produced by proof-of-work, whose child is the first block of the merged chain.
+type Block struct {
+	Hash            common.Hash
+	ParentHash      common.Hash
+	Number          uint64
+	TotalDifficulty *big.Int
+	Timestamp       uint64
+}
+
+func newBlock(blk *pb.ExecutionBlock) *Block {
+	return &Block{
+		Hash:            common.BytesToHash(blk.Hash),
+		ParentHash:      common.BytesToHash(blk.ParentHash),
+		Number:          new(big.Int).SetBytes(blk.Number).Uint64(),
+		TotalDifficulty: new(big.Int).SetBytes(blk.TotalDifficulty),
+		Timestamp:       blk.Timestamp,
+	}
+}
+
+// Returns the configured terminal total difficulty.
+func terminalTotalDifficulty() (*big.Int, error) {
+	ttd, ok := new(big.Int).SetString(params.BeaconConfig().TerminalTotalDifficulty, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid terminal total difficulty %q", params.BeaconConfig().TerminalTotalDifficulty)
+	}
+	return ttd, nil
+}
+
+// Searches the terminal block of the canonical execution chain. If a terminal block hash is
+// configured, the block with that hash is the terminal block from the terminal block hash
+// activation epoch onwards, and no terminal block is found before. Otherwise, the execution chain
+// is walked back from its head to the first block reaching the terminal total difficulty, or
+// searched by block number when the terminal block is too far below the head, such as when the
+// node is started long after the merge. Returns nil if the terminal block has not been produced yet, along with the hash of
+// the execution head the terminal block was found from, if any.
+//
+// Spec code:
+// def get_pow_block_at_terminal_total_difficulty(pow_chain: Dict[Hash32, PowBlock]) -> Optional[PowBlock]:
+//     # `pow_chain` abstractly represents all blocks in the PoW chain
+//     for block in pow_chain.values():
+//         block_reached_ttd = block.total_difficulty >= TERMINAL_TOTAL_DIFFICULTY
+//         if block_reached_ttd:
+//             # If genesis block, no parent exists so reaching TTD alone qualifies as valid terminal block
+//             if block.parent_hash == Hash32():
+//                 return block
+//             parent = pow_chain[block.parent_hash]
+//             parent_reached_ttd = parent.total_difficulty >= TERMINAL_TOTAL_DIFFICULTY
+//             if not parent_reached_ttd:
+//                 return block
+//
+//     return None
+func (s *Service) findTerminalBlock(ctx context.Context) (*Block, common.Hash, error) {
+	if hash := params.BeaconConfig().TerminalBlockHash; hash != (common.Hash{}) {
+		if !s.terminalBlockHashActivated() {
+			return nil, common.Hash{}, nil
+		}
+		blk, err := s.cfg.engine.ExecutionBlockByHash(ctx, hash)
+		if err != nil {
+			return nil, common.Hash{}, errors.Wrapf(err, "could not fetch terminal block %#x", hash)
+		}
+		if len(blk.Hash) == 0 {
+			// The execution node does not know the block yet.
+			return nil, common.Hash{}, nil
+		}
+		return newBlock(blk), common.Hash{}, nil
+	}
+	ttd, err := terminalTotalDifficulty()
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	blk, err := s.cfg.engine.LatestExecutionBlock(ctx)
+	if err != nil {
+		return nil, common.Hash{}, errors.Wrap(err, "could not fetch latest execution block")
+	}
+	head := common.BytesToHash(blk.Hash)
+	if new(big.Int).SetBytes(blk.TotalDifficulty).Cmp(ttd) < 0 {
+		return nil, head, nil
+	}
+	var ancestors []*pb.ExecutionBlock
+	batchSize := 1
+	for i := 0; i < maxWalkDepth; i++ {
+		hash := common.BytesToHash(blk.Hash)
+		// Stop at the head of the previous search or any other known terminal block,
+		// so only the blocks produced since the previous search are walked.
+		if terminal, ok := s.knownTerminalBlock(hash); ok {
+			return terminal, head, nil
+		}
+		parentHash := common.BytesToHash(blk.ParentHash)
+		if parentHash == (common.Hash{}) {
+			terminal := newBlock(blk)
+			s.candidates.Add(terminal.Hash, terminal)
+			return terminal, head, nil
+		}
+		if len(ancestors) == 0 {
+			// The batches grow as the walk goes on, so few blocks are fetched when only
+			// the blocks produced since the previous search are walked.
+			ancestors, err = s.fetchAncestors(ctx, blk, batchSize)
+			if err != nil {
+				return nil, common.Hash{}, err
+			}
+			if batchSize < maxWalkBatchSize {
+				batchSize *= 2
+			}
+		}
+		parent := ancestors[0]
+		ancestors = ancestors[1:]
+		if new(big.Int).SetBytes(parent.TotalDifficulty).Cmp(ttd) < 0 {
+			terminal := newBlock(blk)
+			s.candidates.Add(terminal.Hash, terminal)
+			return terminal, head, nil
+		}
+		blk = parent
+	}
+	terminal, err := s.findTerminalBlockByNumber(ctx, ttd, blk)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	s.candidates.Add(terminal.Hash, terminal)
+	return terminal, head, nil
+}
+
+// Searches the first block reaching the terminal total difficulty among the canonical ancestors
+// of the given block, which reached it. As the total difficulty only grows along the chain, the
+// blocks are searched by number, in a number of requests logarithmic in the height of the chain.
+func (s *Service) findTerminalBlockByNumber(ctx context.Context, ttd *big.Int, blk *pb.ExecutionBlock) (*Block, error) {
+	terminal := blk
+	low, high := uint64(0), new(big.Int).SetBytes(blk.Number).Uint64()
+	for low < high {
+		number := low + (high-low)/2
+		blks, err := s.cfg.engine.ExecutionBlocksByNumbers(ctx, []uint64{number})
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not fetch execution block %d", number)
+		}
+		if len(blks) != 1 || len(blks[0].Hash) == 0 {
+			return nil, errors.Errorf("execution block %d not found", number)
+		}
+		if new(big.Int).SetBytes(blks[0].TotalDifficulty).Cmp(ttd) < 0 {
+			low = number + 1
+		} else {
+			high = number
+			terminal = blks[0]
+		}
+	}
+	return newBlock(terminal), nil
+}
+
+// Fetches up to count ancestors of the given block in a single batch request, from its parent
+// downwards. As the blocks are fetched by number, only those forming a chain with the given
+// block are returned. If the execution chain was reorganized since the block was fetched and
+// none of them does, its parent is fetched by hash instead.
+func (s *Service) fetchAncestors(ctx context.Context, blk *pb.ExecutionBlock, count int) ([]*pb.ExecutionBlock, error) {
+	number := new(big.Int).SetBytes(blk.Number).Uint64()
+	if uint64(count) > number {
+		count = int(number)
+	}
+	numbers := make([]uint64, count)
+	for i := range numbers {
+		numbers[i] = number - uint64(i) - 1
+	}
+	blks, err := s.cfg.engine.ExecutionBlocksByNumbers(ctx, numbers)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not fetch execution blocks below %d", number)
+	}
+	ancestors := make([]*pb.ExecutionBlock, 0, len(blks))
+	parentHash := blk.ParentHash
+	for _, b := range blks {
+		if !bytes.Equal(b.Hash, parentHash) {
+			break
+		}
+		ancestors = append(ancestors, b)
+		parentHash = b.ParentHash
+	}
+	if len(ancestors) > 0 {
+		return ancestors, nil
+	}
+	parent, err := s.cfg.engine.ExecutionBlockByHash(ctx, common.BytesToHash(blk.ParentHash))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not fetch execution block %#x", blk.ParentHash)
+	}
+	// The total difficulty of a missing parent is unknown, it must not be taken as below the
+	// terminal total difficulty.
+	if parent == nil || !bytes.Equal(parent.Hash, blk.ParentHash) {
+		return nil, errors.Errorf("execution block %#x not found", blk.ParentHash)
+	}
+	return []*pb.ExecutionBlock{parent}, nil
+}
@@ -0,0 +1,180 @@
+package terminal
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	types "github.com/prysmaticlabs/eth2-types"
+	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+	mockEngine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1/testing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	pb "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+// Builds an execution chain with the given total difficulties, from genesis to head.
+func testChain(totalDifficulties ...int64) ([]*pb.ExecutionBlock, map[common.Hash]*pb.ExecutionBlock) {
+	blks := make([]*pb.ExecutionBlock, len(totalDifficulties))
+	byHash := make(map[common.Hash]*pb.ExecutionBlock)
+	parent := common.Hash{}
+	for i, td := range totalDifficulties {
+		hash := common.BigToHash(big.NewInt(int64(i + 1)))
+		blks[i] = &pb.ExecutionBlock{
+			Number:          big.NewInt(int64(i)).Bytes(),
+			Hash:            hash.Bytes(),
+			ParentHash:      parent.Bytes(),
+			TotalDifficulty: big.NewInt(td).Bytes(),
+		}
+		byHash[hash] = blks[i]
+		parent = hash
+	}
+	return blks, byHash
+}
+
+func setTerminalTotalDifficulty(t *testing.T, ttd string) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	cfg.TerminalTotalDifficulty = ttd
+	params.OverrideBeaconConfig(cfg)
+}
+
+func TestService_findTerminalBlock(t *testing.T) {
+	setTerminalTotalDifficulty(t, "10")
+	ctx := context.Background()
+
+	t.Run("terminal total difficulty not reached", func(t *testing.T) {
+		blks, byHash := testChain(1, 5, 9)
+		client := &mockEngine.EngineClient{LatestBlock: blks[2], BlocksByHash: byHash}
+		s, err := NewService(ctx, WithEngineCaller(client))
+		require.NoError(t, err)
+		terminal, _, err := s.findTerminalBlock(ctx)
+		require.NoError(t, err)
+		require.Equal(t, true, terminal == nil)
+	})
+	t.Run("walks back to the terminal block", func(t *testing.T) {
+		blks, byHash := testChain(1, 5, 10, 10, 10, 10)
+		client := &mockEngine.EngineClient{LatestBlock: blks[4], BlocksByHash: byHash}
+		s, err := NewService(ctx, WithEngineCaller(client))
+		require.NoError(t, err)
+		s.search()
+		terminal, ok := s.TerminalBlock()
+		require.Equal(t, true, ok)
+		require.Equal(t, common.BytesToHash(blks[2].Hash), terminal.Hash)
+		require.Equal(t, uint64(2), terminal.Number)
+		require.Equal(t, int64(10), terminal.TotalDifficulty.Int64())
+		// The head and two batches of ancestors are fetched by number.
+		require.Equal(t, 3, client.Calls(engine.ExecutionBlockByNumberMethod))
+		require.Equal(t, 0, client.Calls(engine.ExecutionBlockByHashMethod))
+
+		// Only the blocks produced since the previous search are walked.
+		client.LatestBlock = blks[5]
+		s.search()
+		terminal, ok = s.TerminalBlock()
+		require.Equal(t, true, ok)
+		require.Equal(t, common.BytesToHash(blks[2].Hash), terminal.Hash)
+		require.Equal(t, 5, client.Calls(engine.ExecutionBlockByNumberMethod))
+		require.NoError(t, s.Status())
+	})
+	t.Run("execution chain reorganized during the search", func(t *testing.T) {
+		blks, byHash := testChain(1, 5, 10, 10)
+		byNumber := make(map[uint64]*pb.ExecutionBlock)
+		for i, blk := range blks {
+			byNumber[uint64(i)] = blk
+		}
+		// The head of the search is on a fork, whose blocks are not canonical anymore.
+		fork2 := &pb.ExecutionBlock{
+			Number:          big.NewInt(2).Bytes(),
+			Hash:            common.BigToHash(big.NewInt(100)).Bytes(),
+			ParentHash:      blks[1].Hash,
+			TotalDifficulty: big.NewInt(10).Bytes(),
+		}
+		fork3 := &pb.ExecutionBlock{
+			Number:          big.NewInt(3).Bytes(),
+			Hash:            common.BigToHash(big.NewInt(101)).Bytes(),
+			ParentHash:      fork2.Hash,
+			TotalDifficulty: big.NewInt(10).Bytes(),
+		}
+		byHash[common.BytesToHash(fork2.Hash)] = fork2
+		byHash[common.BytesToHash(fork3.Hash)] = fork3
+		client := &mockEngine.EngineClient{LatestBlock: fork3, BlocksByHash: byHash, BlocksByNumber: byNumber}
+		s, err := NewService(ctx, WithEngineCaller(client))
+		require.NoError(t, err)
+		terminal, _, err := s.findTerminalBlock(ctx)
+		require.NoError(t, err)
+		require.Equal(t, common.BytesToHash(fork2.Hash), terminal.Hash)
+		require.Equal(t, 1, client.Calls(engine.ExecutionBlockByHashMethod))
+	})
+	t.Run("genesis block reached terminal total difficulty", func(t *testing.T) {
+		blks, byHash := testChain(10, 10)
+		client := &mockEngine.EngineClient{LatestBlock: blks[1], BlocksByHash: byHash}
+		s, err := NewService(ctx, WithEngineCaller(client))
+		require.NoError(t, err)
+		terminal, _, err := s.findTerminalBlock(ctx)
+		require.NoError(t, err)
+		require.Equal(t, common.BytesToHash(blks[0].Hash), terminal.Hash)
+	})
+	t.Run("searches by number beyond the walk depth", func(t *testing.T) {
+		defer func(depth int) { maxWalkDepth = depth }(maxWalkDepth)
+		maxWalkDepth = 2
+		blks, byHash := testChain(1, 5, 9, 10, 10, 10, 10, 10, 10)
+		client := &mockEngine.EngineClient{LatestBlock: blks[8], BlocksByHash: byHash}
+		s, err := NewService(ctx, WithEngineCaller(client))
+		require.NoError(t, err)
+		s.search()
+		require.NoError(t, s.Status())
+		terminal, ok := s.TerminalBlock()
+		require.Equal(t, true, ok)
+		require.Equal(t, common.BytesToHash(blks[3].Hash), terminal.Hash)
+		require.Equal(t, uint64(3), terminal.Number)
+	})
+	t.Run("missing parent", func(t *testing.T) {
+		blks, byHash := testChain(1, 10, 10)
+		// The parent of the head is neither found by number nor by hash.
+		byNumber := map[uint64]*pb.ExecutionBlock{1: {}}
+		byHash[common.BytesToHash(blks[1].Hash)] = &pb.ExecutionBlock{}
+		client := &mockEngine.EngineClient{LatestBlock: blks[2], BlocksByHash: byHash, BlocksByNumber: byNumber}
+		s, err := NewService(ctx, WithEngineCaller(client))
+		require.NoError(t, err)
+		s.search()
+		_, ok := s.TerminalBlock()
+		require.Equal(t, false, ok)
+		require.ErrorContains(t, "not found", s.Status())
+	})
+}
+
+func TestService_findTerminalBlock_HashOverride(t *testing.T) {
+	setTerminalTotalDifficulty(t, "10")
+	blks, byHash := testChain(1, 2, 3)
+	cfg := params.BeaconConfig()
+	cfg.TerminalBlockHash = common.BytesToHash(blks[1].Hash)
+	cfg.TerminalBlockHashActivationEpoch = 2
+	params.OverrideBeaconConfig(cfg)
+
+	client := &mockEngine.EngineClient{LatestBlock: blks[2], BlocksByHash: byHash}
+	slot := types.Slot(0)
+	chain := &mockChain.ChainService{Genesis: time.Now(), Slot: &slot}
+	s, err := NewService(context.Background(), WithEngineCaller(client), WithTimeFetcher(chain))
+	require.NoError(t, err)
+
+	// The terminal block hash does not apply before its activation epoch.
+	terminal, _, err := s.findTerminalBlock(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, true, terminal == nil)
+	require.Equal(t, 0, client.Calls(engine.ExecutionBlockByHashMethod))
+
+	slot = params.BeaconConfig().SlotsPerEpoch.Mul(2)
+	terminal, _, err = s.findTerminalBlock(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, common.BytesToHash(blks[1].Hash), terminal.Hash)
+	require.Equal(t, 0, client.Calls(engine.ExecutionBlockByNumberMethod))
+}
+
+func TestNewService_InvalidTerminalTotalDifficulty(t *testing.T) {
+	setTerminalTotalDifficulty(t, "foo")
+	_, err := NewService(context.Background(), WithEngineCaller(&mockEngine.EngineClient{}))
+	require.ErrorContains(t, "invalid terminal total difficulty", err)
+}
@@ -0,0 +1,39 @@
+package terminal
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type terminalBlockJSON struct {
+	Hash            string `json:"hash"`
+	ParentHash      string `json:"parent_hash"`
+	Number          uint64 `json:"number"`
+	TotalDifficulty string `json:"total_difficulty"`
+	Timestamp       uint64 `json:"timestamp"`
+}
+
+// TerminalBlockHandler is a handler to serve the /merge/terminal-block page in metrics, which
+// reports the terminal proof-of-work block found for merge readiness checks. A not found status
+// is returned until the terminal block is found.
+func (s *Service) TerminalBlockHandler(w http.ResponseWriter, _ *http.Request) {
+	blk, ok := s.TerminalBlock()
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		if _, err := w.Write([]byte("Terminal block not found yet")); err != nil {
+			log.WithError(err).Error("Failed to render terminal block page")
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(terminalBlockJSON{
+		Hash:            blk.Hash.Hex(),
+		ParentHash:      blk.ParentHash.Hex(),
+		Number:          blk.Number,
+		TotalDifficulty: blk.TotalDifficulty.String(),
+		Timestamp:       blk.Timestamp,
+	}); err != nil {
+		log.WithError(err).Error("Failed to render terminal block page")
+	}
+}
@@ -0,0 +1,34 @@
+package terminal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mockEngine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1/testing"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestService_TerminalBlockHandler(t *testing.T) {
+	setTerminalTotalDifficulty(t, "10")
+	blks, byHash := testChain(1, 10)
+	client := &mockEngine.EngineClient{LatestBlock: blks[0], BlocksByHash: byHash}
+	s, err := NewService(context.Background(), WithEngineCaller(client))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	s.TerminalBlockHandler(rr, httptest.NewRequest(http.MethodGet, "/merge/terminal-block", nil))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+
+	client.LatestBlock = blks[1]
+	s.search()
+	rr = httptest.NewRecorder()
+	s.TerminalBlockHandler(rr, httptest.NewRequest(http.MethodGet, "/merge/terminal-block", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	res := &terminalBlockJSON{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), res))
+	require.Equal(t, uint64(1), res.Number)
+	require.Equal(t, "10", res.TotalDifficulty)
+}
@@ -0,0 +1,5 @@
+package terminal
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "terminal-block")
@@ -0,0 +1,54 @@
+package terminal
+
+import (
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+)
+
+// Option for configuring the terminal block service.
+type Option func(s *Service) error
+
+// WithEngineCaller for fetching execution blocks from the execution node.
+func WithEngineCaller(caller engine.EngineCaller) Option {
+	return func(s *Service) error {
+		s.cfg.engine = caller
+		return nil
+	}
+}
+
+// WithHeadFetcher for the head state, the completion of the merge transition in which stops the
+// terminal block search.
+func WithHeadFetcher(fetcher blockchain.HeadFetcher) Option {
+	return func(s *Service) error {
+		s.cfg.headFetcher = fetcher
+		return nil
+	}
+}
+
+// WithPollInterval for the period between searches of the terminal block.
+func WithPollInterval(interval time.Duration) Option {
+	return func(s *Service) error {
+		s.cfg.pollInterval = interval
+		return nil
+	}
+}
+
+// WithStateNotifier for publishing the terminal block on the beacon node's state feed once found.
+func WithStateNotifier(notifier statefeed.Notifier) Option {
+	return func(s *Service) error {
+		s.cfg.stateNotifier = notifier
+		return nil
+	}
+}
+
+// WithTimeFetcher for the current epoch, which the terminal block hash only applies from
+// once its activation epoch is reached.
+func WithTimeFetcher(fetcher blockchain.TimeFetcher) Option {
+	return func(s *Service) error {
+		s.cfg.timeFetcher = fetcher
+		return nil
+	}
+}
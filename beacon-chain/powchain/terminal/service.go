@@ -0,0 +1,220 @@
+// Package terminal defines a service which searches the terminal proof-of-work block of the
+// execution chain, the last block produced before the merge, using the engine API client.
+package terminal
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// Number of terminal block candidates, found on competing execution forks, which are cached.
+const maxCandidates = 16
+
+type config struct {
+	engine        engine.EngineCaller
+	headFetcher   blockchain.HeadFetcher
+	pollInterval  time.Duration
+	stateNotifier statefeed.Notifier
+	timeFetcher   blockchain.TimeFetcher
+}
+
+// Service periodically searches the terminal block of the canonical execution chain until it
+// is found, and keeps track of it afterwards in case the execution chain is reorganized, until
+// the merge transition is complete.
+type Service struct {
+	cfg        *config
+	ctx        context.Context
+	cancel     context.CancelFunc
+	candidates *lru.Cache
+	lock       sync.RWMutex
+	terminal   *Block
+	lastHead   common.Hash
+	searchErr  error
+}
+
+// NewService sets up a new terminal block service.
+func NewService(ctx context.Context, opts ...Option) (*Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		cfg: &config{
+			pollInterval: time.Duration(params.BeaconConfig().SecondsPerETH1Block) * time.Second,
+		},
+		ctx:        ctx,
+		cancel:     cancel,
+		candidates: lruwrpr.New(maxCandidates),
+	}
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	if s.cfg.engine == nil {
+		cancel()
+		return nil, errors.New("no engine API client provided")
+	}
+	if s.cfg.pollInterval <= 0 {
+		cancel()
+		return nil, errors.New("poll interval must be positive")
+	}
+	if _, err := terminalTotalDifficulty(); err != nil {
+		cancel()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Start searching the terminal block in the background.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop the service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status returns the error of the most recent terminal block search, if it failed.
+func (s *Service) Status() error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.searchErr
+}
+
+// TerminalBlock returns the terminal block of the canonical execution chain, and
+// false if it has not been found yet.
+func (s *Service) TerminalBlock() (*Block, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.terminal == nil {
+		return nil, false
+	}
+	blk := *s.terminal
+	return &blk, true
+}
+
+func (s *Service) run() {
+	ticker := time.NewTicker(s.cfg.pollInterval)
+	defer ticker.Stop()
+	for {
+		// The search runs at least once after the merge, so a node started after the merge
+		// still finds the terminal block.
+		s.search()
+		if s.Status() == nil && s.mergeComplete() {
+			log.Info("Merge transition complete, stopping terminal proof-of-work block search")
+			return
+		}
+		select {
+		case <-ticker.C:
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting terminal block search")
+			return
+		}
+	}
+}
+
+func (s *Service) search() {
+	terminal, head, err := s.findTerminalBlock(s.ctx)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.searchErr = err
+	if err != nil {
+		log.WithError(err).Warn("Could not search terminal proof-of-work block")
+		return
+	}
+	if terminal == nil {
+		return
+	}
+	s.lastHead = head
+	if s.terminal != nil && s.terminal.Hash == terminal.Hash {
+		return
+	}
+	fields := logrus.Fields{
+		"hash":            terminal.Hash.Hex(),
+		"number":          terminal.Number,
+		"totalDifficulty": terminal.TotalDifficulty.String(),
+	}
+	if s.terminal == nil {
+		log.WithFields(fields).Info("Found terminal proof-of-work block")
+	} else {
+		log.WithFields(fields).WithField("previous", s.terminal.Hash.Hex()).Warn(
+			"Terminal proof-of-work block changed after an execution chain reorganization",
+		)
+	}
+	s.terminal = terminal
+	if s.cfg.stateNotifier != nil {
+		s.cfg.stateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.TerminalBlockReached,
+			Data: &statefeed.TerminalBlockReachedData{
+				Hash:            terminal.Hash,
+				Number:          terminal.Number,
+				TotalDifficulty: new(big.Int).Set(terminal.TotalDifficulty),
+			},
+		})
+	}
+}
+
+// Returns true once the merge transition is complete in the head state, after which the execution
+// chain is only extended by proof-of-stake blocks and the terminal block is not searched anymore.
+func (s *Service) mergeComplete() bool {
+	if s.cfg.headFetcher == nil {
+		return false
+	}
+	st, err := s.cfg.headFetcher.HeadState(s.ctx)
+	if err != nil || st == nil || st.IsNil() || st.Version() < version.Bellatrix {
+		return false
+	}
+	complete, err := blocks.MergeTransitionComplete(st)
+	if err != nil {
+		log.WithError(err).Debug("Could not check if the merge transition is complete")
+		return false
+	}
+	return complete
+}
+
+// Returns true once the current epoch reaches the activation epoch of the configured terminal
+// block hash, before which the terminal block hash does not apply.
+func (s *Service) terminalBlockHashActivated() bool {
+	activationEpoch := params.BeaconConfig().TerminalBlockHashActivationEpoch
+	if activationEpoch == 0 {
+		return true
+	}
+	if s.cfg.timeFetcher == nil || s.cfg.timeFetcher.GenesisTime().IsZero() {
+		return false
+	}
+	return slots.ToEpoch(s.cfg.timeFetcher.CurrentSlot()) >= activationEpoch
+}
+
+// Returns the terminal block known to be an ancestor of the execution block with the
+// given hash, or to be that block itself.
+func (s *Service) knownTerminalBlock(hash common.Hash) (*Block, bool) {
+	s.lock.RLock()
+	if s.terminal != nil && hash == s.lastHead {
+		defer s.lock.RUnlock()
+		return s.terminal, true
+	}
+	s.lock.RUnlock()
+	item, ok := s.candidates.Get(hash)
+	if !ok {
+		return nil, false
+	}
+	blk, ok := item.(*Block)
+	return blk, ok
+}
@@ -0,0 +1,111 @@
+package terminal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/async/event"
+	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	mockEngine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1/testing"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+type mockStateNotifier struct {
+	feed *event.Feed
+}
+
+func (m *mockStateNotifier) StateFeed() *event.Feed {
+	return m.feed
+}
+
+func TestService_PublishesTerminalBlock(t *testing.T) {
+	setTerminalTotalDifficulty(t, "10")
+	blks, byHash := testChain(1, 10, 10)
+	client := &mockEngine.EngineClient{LatestBlock: blks[0], BlocksByHash: byHash}
+	notifier := &mockStateNotifier{feed: new(event.Feed)}
+	events := make(chan *feed.Event, 10)
+	sub := notifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+	s, err := NewService(context.Background(), WithEngineCaller(client), WithStateNotifier(notifier))
+	require.NoError(t, err)
+
+	// No event is published until the terminal block is found.
+	s.search()
+	require.Equal(t, 0, len(events))
+
+	client.LatestBlock = blks[1]
+	s.search()
+	require.Equal(t, 1, len(events))
+	ev := <-events
+	require.Equal(t, feed.EventType(statefeed.TerminalBlockReached), ev.Type)
+	data, ok := ev.Data.(*statefeed.TerminalBlockReachedData)
+	require.Equal(t, true, ok)
+	require.DeepEqual(t, common.BytesToHash(blks[1].Hash), common.Hash(data.Hash))
+	require.Equal(t, uint64(1), data.Number)
+	require.Equal(t, int64(10), data.TotalDifficulty.Int64())
+
+	// Descendants of the terminal block do not change it.
+	client.LatestBlock = blks[2]
+	s.search()
+	require.Equal(t, 0, len(events))
+}
+
+func TestService_StopsOnceMergeComplete(t *testing.T) {
+	setTerminalTotalDifficulty(t, "10")
+	blks, byHash := testChain(1, 10, 10)
+	// Runs the search in the background, returning a channel closed once it stops.
+	run := func(t *testing.T, mergeComplete bool) (*Service, chan struct{}) {
+		st, _ := util.DeterministicGenesisStateBellatrix(t, 8)
+		if mergeComplete {
+			header, err := st.LatestExecutionPayloadHeader()
+			require.NoError(t, err)
+			header.BlockHash = bytesutil.PadTo([]byte("head"), 32)
+			require.NoError(t, st.SetLatestExecutionPayloadHeader(header))
+		}
+		client := &mockEngine.EngineClient{LatestBlock: blks[2], BlocksByHash: byHash}
+		s, err := NewService(
+			context.Background(),
+			WithEngineCaller(client),
+			WithHeadFetcher(&mockChain.ChainService{State: st}),
+			WithPollInterval(time.Millisecond),
+		)
+		require.NoError(t, err)
+		done := make(chan struct{})
+		go func() {
+			s.run()
+			close(done)
+		}()
+		return s, done
+	}
+
+	t.Run("before the merge transition", func(t *testing.T) {
+		s, done := run(t, false)
+		time.Sleep(20 * time.Millisecond)
+		select {
+		case <-done:
+			t.Fatal("Terminal block search stopped before the merge transition")
+		default:
+		}
+		require.NoError(t, s.Stop())
+		<-done
+	})
+	t.Run("after the merge transition", func(t *testing.T) {
+		s, done := run(t, true)
+		defer func() { require.NoError(t, s.Stop()) }()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Terminal block search did not stop after the merge transition")
+		}
+		// The terminal block is still searched once.
+		terminal, ok := s.TerminalBlock()
+		require.Equal(t, true, ok)
+		require.Equal(t, common.BytesToHash(blks[1].Hash), terminal.Hash)
+	})
+}
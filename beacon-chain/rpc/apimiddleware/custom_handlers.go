@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"strconv"
 	"strings"
@@ -101,6 +102,17 @@ func handleGetSSZ(
 		apimiddleware.WriteError(w, errJson, nil)
 		return true
 	}
+	if apimiddleware.HandleConditionalGet(req, grpcResponse, responseSsz, w) {
+		if errJson := apimiddleware.Cleanup(grpcResponse.Body); errJson != nil {
+			apimiddleware.WriteError(w, errJson, nil)
+		}
+		return true
+	}
+	responseSsz, errJson = apimiddleware.CompressResponseBody(req, responseSsz, w)
+	if errJson != nil {
+		apimiddleware.WriteError(w, errJson, nil)
+		return true
+	}
 	if errJson := writeSSZResponseHeaderAndBody(grpcResponse, w, responseSsz, respVersion, config.fileName); errJson != nil {
 		apimiddleware.WriteError(w, errJson, nil)
 		return true
@@ -113,17 +125,39 @@ func handleGetSSZ(
 	return true
 }
 
+// sszRequested returns true if the media ranges of the request's Accept headers, weighted by their
+// quality values, prefer an SSZ response to a JSON one. SSZ must be listed explicitly, and is
+// preferred when both are equally acceptable.
 func sszRequested(req *http.Request) bool {
-	accept, ok := req.Header["Accept"]
-	if !ok {
+	qualities := make(map[string]float64)
+	for _, header := range req.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(header, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err != nil {
+				continue
+			}
+			quality := 1.0
+			if q, ok := params["q"]; ok {
+				if quality, err = strconv.ParseFloat(q, 64); err != nil {
+					continue
+				}
+			}
+			if existing, ok := qualities[mediaType]; !ok || quality > existing {
+				qualities[mediaType] = quality
+			}
+		}
+	}
+	sszQuality, ok := qualities["application/octet-stream"]
+	if !ok || sszQuality <= 0 {
 		return false
 	}
-	for _, v := range accept {
-		if v == "application/octet-stream" {
-			return true
+	// The most specific media range matching JSON determines its quality.
+	for _, mediaType := range []string{"application/json", "application/*", "*/*"} {
+		if jsonQuality, ok := qualities[mediaType]; ok {
+			return sszQuality >= jsonQuality
 		}
 	}
-	return false
+	return true
 }
 
 func prepareSSZRequestForProxying(
@@ -187,6 +221,10 @@ func writeSSZResponseHeaderAndBody(grpcResp *http.Response, w http.ResponseWrite
 
 func handleEvents(m *apimiddleware.ApiProxyMiddleware, _ apimiddleware.Endpoint, w http.ResponseWriter, req *http.Request) (handled bool) {
 	sseClient := sse.NewClient("http://" + m.GatewayAddress + "/internal" + req.URL.RequestURI())
+	// The gateway authenticates the internal request with the header set on the original request.
+	if secret := req.Header.Get(apimiddleware.InternalAuthHeader); secret != "" {
+		sseClient.Headers[apimiddleware.InternalAuthHeader] = secret
+	}
 	eventChan := make(chan *sse.Event)
 
 	// We use grpc-gateway as the server side of events, not the sse library.
@@ -245,6 +283,14 @@ func receiveEvents(eventChan <-chan *sse.Event, w http.ResponseWriter, req *http
 				data = &eventChainReorgJson{}
 			case events.SyncCommitteeContributionTopic:
 				data = &signedContributionAndProofJson{}
+			case events.PayloadAttributesTopic:
+				data = &eventPayloadAttributesJson{}
+			case events.ExecutionConnectionTopic:
+				data = &eventExecutionConnectionJson{}
+			case events.TerminalBlockTopic:
+				data = &eventTerminalBlockJson{}
+			case events.TransitionConfigurationTopic:
+				data = &eventTransitionConfigurationJson{}
 			case "error":
 				data = &eventErrorJson{}
 			default:
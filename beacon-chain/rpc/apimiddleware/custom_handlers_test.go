@@ -1,15 +1,21 @@
 package apimiddleware
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/api/gateway"
 	"github.com/prysmaticlabs/prysm/api/gateway/apimiddleware"
 	"github.com/prysmaticlabs/prysm/api/grpc"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/eth/events"
@@ -58,6 +64,48 @@ func TestSSZRequested(t *testing.T) {
 		result := sszRequested(request)
 		assert.Equal(t, false, result)
 	})
+
+	t.Run("comma_separated_content_types", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example", nil)
+		request.Header["Accept"] = []string{"application/json, application/octet-stream"}
+		result := sszRequested(request)
+		assert.Equal(t, true, result)
+	})
+
+	t.Run("ssz_preferred", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example", nil)
+		request.Header["Accept"] = []string{"application/octet-stream;q=1.0,application/json;q=0.9"}
+		result := sszRequested(request)
+		assert.Equal(t, true, result)
+	})
+
+	t.Run("json_preferred", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example", nil)
+		request.Header["Accept"] = []string{"application/octet-stream;q=0.5,application/json"}
+		result := sszRequested(request)
+		assert.Equal(t, false, result)
+	})
+
+	t.Run("ssz_not_acceptable", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example", nil)
+		request.Header["Accept"] = []string{"application/octet-stream;q=0"}
+		result := sszRequested(request)
+		assert.Equal(t, false, result)
+	})
+
+	t.Run("wildcard", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example", nil)
+		request.Header["Accept"] = []string{"*/*"}
+		result := sszRequested(request)
+		assert.Equal(t, false, result)
+	})
+
+	t.Run("ssz_preferred_over_wildcard", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example", nil)
+		request.Header["Accept"] = []string{"application/octet-stream, */*;q=0.1"}
+		result := sszRequested(request)
+		assert.Equal(t, true, result)
+	})
 }
 
 func TestPrepareSSZRequestForProxying(t *testing.T) {
@@ -171,6 +219,69 @@ func TestWriteSSZResponseHeaderAndBody(t *testing.T) {
 	})
 }
 
+func TestHandleEvents_GatewayAuth(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	gatewayAddress := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	authPath := filepath.Join(t.TempDir(), "auth.yaml")
+	require.NoError(t, ioutil.WriteFile(authPath, []byte(`
+tokens:
+  - name: test
+    token: test-token
+`), 0600))
+
+	m := &apimiddleware.ApiProxyMiddleware{GatewayAddress: gatewayAddress}
+	router := mux.NewRouter()
+	router.HandleFunc("/internal/eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, err := w.Write([]byte("event: finalized_checkpoint\ndata: {\"block\":\"Zm9v\",\"state\":\"Zm9v\",\"epoch\":\"1\"}\n\n"))
+		require.NoError(t, err)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	router.HandleFunc("/eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		handleEvents(m, apimiddleware.Endpoint{}, w, r)
+	})
+	g, err := gateway.New(
+		context.Background(),
+		gateway.WithGatewayAddr(gatewayAddress),
+		gateway.WithRemoteAddr("127.0.0.1:1"),
+		gateway.WithRouter(router),
+		gateway.WithAuthConfig(authPath),
+	)
+	require.NoError(t, err)
+	g.Start()
+	defer func() {
+		require.NoError(t, g.Stop())
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	url := "http://" + gatewayAddress + "/eth/v1/events?topics=finalized_checkpoint"
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token")
+	// The gateway starts listening in the background.
+	var resp *http.Response
+	for i := 0; i < 100; i++ {
+		if resp, err = http.DefaultClient.Do(req); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "event: finalized_checkpoint\n", line)
+}
+
 func TestReceiveEvents(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	ch := make(chan *sse.Event)
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/gogo/protobuf/types"
+	eth2types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/api/gateway/apimiddleware"
 	"github.com/prysmaticlabs/prysm/config/params"
 	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
@@ -434,6 +435,75 @@ func TestPreparePublishedBlock(t *testing.T) {
 	})
 }
 
+func TestSetInitialPublishBlindedBlockPostRequest(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	cfg.AltairForkEpoch = 1
+	cfg.BellatrixForkEpoch = 2
+	params.OverrideBeaconConfig(cfg)
+
+	tests := []struct {
+		name     string
+		epoch    eth2types.Epoch
+		expected interface{}
+	}{
+		{name: "Phase 0", epoch: 0, expected: signedBeaconBlockContainerJson{}},
+		{name: "Altair", epoch: 1, expected: signedBeaconBlockAltairContainerJson{}},
+		{name: "Bellatrix", epoch: 2, expected: signedBlindedBeaconBlockBellatrixContainerJson{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := &apimiddleware.Endpoint{}
+			slot, err := slots.EpochStart(tt.epoch)
+			require.NoError(t, err)
+			body := bytes.NewBufferString(`{"message":{"slot":"` + strconv.FormatUint(uint64(slot), 10) + `"}}`)
+			request := httptest.NewRequest("POST", "http://foo.example", body)
+			runDefault, errJson := setInitialPublishBlindedBlockPostRequest(endpoint, nil, request)
+			require.Equal(t, true, errJson == nil)
+			assert.Equal(t, apimiddleware.RunDefault(true), runDefault)
+			assert.Equal(t, reflect.TypeOf(tt.expected).Name(), reflect.Indirect(reflect.ValueOf(endpoint.PostRequest)).Type().Name())
+		})
+	}
+}
+
+func TestPreparePublishedBlindedBlock(t *testing.T) {
+	t.Run("Altair", func(t *testing.T) {
+		endpoint := &apimiddleware.Endpoint{
+			PostRequest: &signedBeaconBlockAltairContainerJson{
+				Message: &beaconBlockAltairJson{
+					Body: &beaconBlockBodyAltairJson{},
+				},
+			},
+		}
+		errJson := preparePublishedBlindedBlock(endpoint, nil, nil)
+		require.Equal(t, true, errJson == nil)
+		_, ok := endpoint.PostRequest.(*altairPublishBlockRequestJson)
+		assert.Equal(t, true, ok)
+	})
+
+	t.Run("Bellatrix", func(t *testing.T) {
+		endpoint := &apimiddleware.Endpoint{
+			PostRequest: &signedBlindedBeaconBlockBellatrixContainerJson{
+				Message: &blindedBeaconBlockBellatrixJson{
+					Body: &blindedBeaconBlockBodyBellatrixJson{},
+				},
+				Signature: "sig",
+			},
+		}
+		errJson := preparePublishedBlindedBlock(endpoint, nil, nil)
+		require.Equal(t, true, errJson == nil)
+		req, ok := endpoint.PostRequest.(*bellatrixPublishBlindedBlockRequestJson)
+		require.Equal(t, true, ok)
+		require.NotNil(t, req.BellatrixBlock)
+		assert.Equal(t, "sig", req.Signature)
+	})
+
+	t.Run("unsupported block type", func(t *testing.T) {
+		errJson := preparePublishedBlindedBlock(&apimiddleware.Endpoint{}, nil, nil)
+		assert.Equal(t, true, strings.Contains(errJson.Msg(), "unsupported block type"))
+	})
+}
+
 func TestPrepareValidatorAggregates(t *testing.T) {
 	body := &tempSyncCommitteesResponseJson{
 		Data: &tempSyncCommitteeValidatorsJson{
@@ -543,6 +613,56 @@ func TestSerializeV2Block(t *testing.T) {
 	})
 }
 
+func TestSerializeBlindedBlock(t *testing.T) {
+	t.Run("Bellatrix", func(t *testing.T) {
+		response := &blindedBlockResponseJson{
+			Version: ethpbv2.Version_BELLATRIX.String(),
+			Data: &signedBlindedBeaconBlockContainerJson{
+				BellatrixBlock: &blindedBeaconBlockBellatrixJson{
+					Slot:          "1",
+					ProposerIndex: "1",
+					ParentRoot:    "root",
+					StateRoot:     "root",
+					Body: &blindedBeaconBlockBodyBellatrixJson{
+						ExecutionPayloadHeader: &executionPayloadHeaderJson{TransactionsRoot: "root"},
+					},
+				},
+				Signature: "sig",
+			},
+		}
+		runDefault, j, errJson := serializeBlindedBlock(response)
+		require.Equal(t, nil, errJson)
+		require.Equal(t, apimiddleware.RunDefault(false), runDefault)
+		require.NotNil(t, j)
+		resp := &bellatrixBlindedBlockResponseJson{}
+		require.NoError(t, json.Unmarshal(j, resp))
+		require.NotNil(t, resp.Data)
+		require.NotNil(t, resp.Data.Message)
+		beaconBlock := resp.Data.Message
+		assert.Equal(t, "1", beaconBlock.Slot)
+		assert.Equal(t, "sig", resp.Data.Signature)
+		require.NotNil(t, beaconBlock.Body)
+		require.NotNil(t, beaconBlock.Body.ExecutionPayloadHeader)
+		assert.Equal(t, "root", beaconBlock.Body.ExecutionPayloadHeader.TransactionsRoot)
+	})
+
+	t.Run("incorrect response type", func(t *testing.T) {
+		runDefault, j, errJson := serializeBlindedBlock(&types.Empty{})
+		require.Equal(t, apimiddleware.RunDefault(false), runDefault)
+		require.Equal(t, 0, len(j))
+		require.NotNil(t, errJson)
+		assert.Equal(t, true, strings.Contains(errJson.Msg(), "container is not of the correct type"))
+	})
+
+	t.Run("unsupported block version", func(t *testing.T) {
+		runDefault, j, errJson := serializeBlindedBlock(&blindedBlockResponseJson{Version: "unsupported"})
+		require.Equal(t, apimiddleware.RunDefault(false), runDefault)
+		require.Equal(t, 0, len(j))
+		require.NotNil(t, errJson)
+		assert.Equal(t, true, strings.Contains(errJson.Msg(), "unsupported block version"))
+	})
+}
+
 func TestSerializeV2State(t *testing.T) {
 	t.Run("Phase 0", func(t *testing.T) {
 		response := &beaconStateV2ResponseJson{
@@ -675,3 +795,44 @@ func TestSerializeProduceV2Block(t *testing.T) {
 		assert.Equal(t, true, strings.Contains(errJson.Msg(), "unsupported block version"))
 	})
 }
+
+func TestSerializeLightClientUpdates(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		response := &lightClientUpdatesByRangeResponseJson{
+			Updates: []*lightClientUpdateWithVersionJson{
+				{
+					Version: "altair",
+					Data: &lightClientUpdateJson{
+						AttestedHeader: &beaconBlockHeaderJson{Slot: "1"},
+						SignatureSlot:  "2",
+					},
+				},
+			},
+		}
+		runDefault, j, errJson := serializeLightClientUpdates(response)
+		require.Equal(t, nil, errJson)
+		require.Equal(t, apimiddleware.RunDefault(false), runDefault)
+		var resp []*lightClientUpdateWithVersionJson
+		require.NoError(t, json.Unmarshal(j, &resp))
+		require.Equal(t, 1, len(resp))
+		assert.Equal(t, "altair", resp[0].Version)
+		require.NotNil(t, resp[0].Data)
+		assert.Equal(t, "1", resp[0].Data.AttestedHeader.Slot)
+		assert.Equal(t, "2", resp[0].Data.SignatureSlot)
+	})
+
+	t.Run("no updates", func(t *testing.T) {
+		runDefault, j, errJson := serializeLightClientUpdates(&lightClientUpdatesByRangeResponseJson{})
+		require.Equal(t, nil, errJson)
+		require.Equal(t, apimiddleware.RunDefault(false), runDefault)
+		assert.Equal(t, "[]", string(j))
+	})
+
+	t.Run("incorrect response type", func(t *testing.T) {
+		runDefault, j, errJson := serializeLightClientUpdates(&types.Empty{})
+		require.Equal(t, apimiddleware.RunDefault(false), runDefault)
+		assert.Equal(t, 0, len(j))
+		require.NotNil(t, errJson)
+		assert.Equal(t, true, strings.Contains(errJson.Msg(), "container is not of the correct type"))
+	})
+}
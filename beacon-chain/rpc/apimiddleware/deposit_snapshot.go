@@ -0,0 +1,75 @@
+package apimiddleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/api/gateway/apimiddleware"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+)
+
+// DepositSnapshotPath is the API path serving the finalized deposit tree snapshot.
+const DepositSnapshotPath = "/eth/v1/beacon/deposit_snapshot"
+
+// DepositSnapshotHandler serves the finalized deposit tree snapshot of EIP-4881 stored in the database,
+// which lets checkpoint synced nodes process deposits without the execution log history preceding it.
+// The snapshot is SSZ encoded if requested with the application/octet-stream Accept header.
+func DepositSnapshotHandler(beaconDB db.ReadOnlyDatabase) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		snapshot, err := beaconDB.DepositSnapshot(req.Context())
+		if err != nil {
+			apimiddleware.WriteError(w, apimiddleware.InternalServerErrorWithMessage(err, "could not retrieve deposit snapshot"), nil)
+			return
+		}
+		if snapshot == nil {
+			apimiddleware.WriteError(w, &apimiddleware.DefaultErrorJson{
+				Message: "no finalized deposit snapshot available",
+				Code:    http.StatusNotFound,
+			}, nil)
+			return
+		}
+
+		if sszRequested(req) {
+			enc, err := snapshot.MarshalSSZ()
+			if err != nil {
+				apimiddleware.WriteError(w, apimiddleware.InternalServerErrorWithMessage(err, "could not encode deposit snapshot"), nil)
+				return
+			}
+			w.Header().Set("Content-Length", strconv.Itoa(len(enc)))
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Content-Disposition", "attachment; filename=deposit_snapshot.ssz")
+			w.WriteHeader(http.StatusOK)
+			if _, err := w.Write(enc); err != nil {
+				log.WithError(err).Error("Could not write deposit snapshot response")
+			}
+			return
+		}
+
+		finalized := make([]string, len(snapshot.Finalized))
+		for i, node := range snapshot.Finalized {
+			finalized[i] = hexutil.Encode(node[:])
+		}
+		resp := &depositSnapshotResponseJson{
+			Data: &depositSnapshotJson{
+				Finalized:            finalized,
+				DepositRoot:          hexutil.Encode(snapshot.DepositRoot[:]),
+				DepositCount:         strconv.FormatUint(snapshot.DepositCount, 10),
+				ExecutionBlockHash:   hexutil.Encode(snapshot.ExecutionBlockHash[:]),
+				ExecutionBlockHeight: strconv.FormatUint(snapshot.ExecutionBlockHeight, 10),
+			},
+		}
+		enc, err := json.Marshal(resp)
+		if err != nil {
+			apimiddleware.WriteError(w, apimiddleware.InternalServerErrorWithMessage(err, "could not marshal deposit snapshot"), nil)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(enc)))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(enc); err != nil {
+			log.WithError(err).Error("Could not write deposit snapshot response")
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package apimiddleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/container/trie"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestDepositSnapshotHandler(t *testing.T) {
+	beaconDB := dbTest.SetupDB(t)
+	handler := DepositSnapshotHandler(beaconDB)
+
+	t.Run("no snapshot", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		handler(writer, httptest.NewRequest("GET", "http://foo.example"+DepositSnapshotPath, nil))
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+
+	snapshot := &trie.DepositTreeSnapshot{
+		Finalized:            [][32]byte{{'a'}, {'b'}},
+		DepositRoot:          [32]byte{'c'},
+		DepositCount:         3,
+		ExecutionBlockHash:   [32]byte{'d'},
+		ExecutionBlockHeight: 1000,
+	}
+	require.NoError(t, beaconDB.SaveDepositSnapshot(context.Background(), snapshot))
+
+	t.Run("json", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		handler(writer, httptest.NewRequest("GET", "http://foo.example"+DepositSnapshotPath, nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &depositSnapshotResponseJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+		assert.DeepEqual(t, []string{hexutil.Encode(snapshot.Finalized[0][:]), hexutil.Encode(snapshot.Finalized[1][:])}, resp.Data.Finalized)
+		assert.Equal(t, hexutil.Encode(snapshot.DepositRoot[:]), resp.Data.DepositRoot)
+		assert.Equal(t, "3", resp.Data.DepositCount)
+		assert.Equal(t, hexutil.Encode(snapshot.ExecutionBlockHash[:]), resp.Data.ExecutionBlockHash)
+		assert.Equal(t, "1000", resp.Data.ExecutionBlockHeight)
+	})
+
+	t.Run("ssz", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "http://foo.example"+DepositSnapshotPath, nil)
+		req.Header.Set("Accept", "application/octet-stream")
+		handler(writer, req)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "application/octet-stream", writer.Header().Get("Content-Type"))
+		decoded := &trie.DepositTreeSnapshot{}
+		require.NoError(t, decoded.UnmarshalSSZ(writer.Body.Bytes()))
+		assert.DeepEqual(t, snapshot, decoded)
+	})
+}
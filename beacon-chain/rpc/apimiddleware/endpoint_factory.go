@@ -32,6 +32,14 @@ func (_ *BeaconEndpointFactory) Paths() []string {
 		"/eth/v2/beacon/blocks/{block_id}",
 		"/eth/v1/beacon/blocks/{block_id}/root",
 		"/eth/v1/beacon/blocks/{block_id}/attestations",
+		"/eth/v1/beacon/blinded_blocks",
+		"/eth/v1/beacon/blinded_blocks/{block_id}",
+		"/eth/v1/beacon/light_client/bootstrap/{block_root}",
+		"/eth/v1/beacon/light_client/updates",
+		"/eth/v1/beacon/light_client/finality_update",
+		"/eth/v1/beacon/light_client/optimistic_update",
+		"/eth/v1/beacon/rewards/blocks/{block_id}",
+		"/eth/v1/beacon/rewards/attestations/{epoch}",
 		"/eth/v1/beacon/pool/attestations",
 		"/eth/v1/beacon/pool/attester_slashings",
 		"/eth/v1/beacon/pool/proposer_slashings",
@@ -47,6 +55,7 @@ func (_ *BeaconEndpointFactory) Paths() []string {
 		"/eth/v1/debug/beacon/states/{state_id}",
 		"/eth/v2/debug/beacon/states/{state_id}",
 		"/eth/v1/debug/beacon/heads",
+		"/eth/v1/debug/fork_choice",
 		"/eth/v1/config/fork_schedule",
 		"/eth/v1/config/deposit_contract",
 		"/eth/v1/config/spec",
@@ -63,6 +72,7 @@ func (_ *BeaconEndpointFactory) Paths() []string {
 		"/eth/v1/validator/aggregate_and_proofs",
 		"/eth/v1/validator/sync_committee_contribution",
 		"/eth/v1/validator/contribution_and_proofs",
+		"/eth/v1/validator/liveness/{epoch}",
 	}
 }
 
@@ -79,7 +89,7 @@ func (_ *BeaconEndpointFactory) Create(path string) (*apimiddleware.Endpoint, er
 	case "/eth/v1/beacon/states/{state_id}/finality_checkpoints":
 		endpoint.GetResponse = &stateFinalityCheckpointResponseJson{}
 	case "/eth/v1/beacon/states/{state_id}/validators":
-		endpoint.RequestQueryParams = []apimiddleware.QueryParam{{Name: "id", Hex: true}, {Name: "status", Enum: true}}
+		endpoint.RequestQueryParams = []apimiddleware.QueryParam{{Name: "id", Hex: true}, {Name: "status", Enum: true}, {Name: "index_range"}, {Name: "page_size"}, {Name: "page_token"}}
 		endpoint.GetResponse = &stateValidatorsResponseJson{}
 	case "/eth/v1/beacon/states/{state_id}/validators/{validator_id}":
 		endpoint.GetResponse = &stateValidatorResponseJson{}
@@ -101,6 +111,7 @@ func (_ *BeaconEndpointFactory) Create(path string) (*apimiddleware.Endpoint, er
 	case "/eth/v1/beacon/headers/{block_id}":
 		endpoint.GetResponse = &blockHeaderResponseJson{}
 	case "/eth/v1/beacon/blocks":
+		endpoint.RequestQueryParams = []apimiddleware.QueryParam{{Name: "broadcast_validation", Enum: true}}
 		endpoint.Hooks = apimiddleware.HookCollection{
 			OnPreDeserializeRequestBodyIntoContainer:  setInitialPublishBlockPostRequest,
 			OnPostDeserializeRequestBodyIntoContainer: preparePublishedBlock,
@@ -118,6 +129,38 @@ func (_ *BeaconEndpointFactory) Create(path string) (*apimiddleware.Endpoint, er
 		endpoint.GetResponse = &blockRootResponseJson{}
 	case "/eth/v1/beacon/blocks/{block_id}/attestations":
 		endpoint.GetResponse = &blockAttestationsResponseJson{}
+	case "/eth/v1/beacon/blinded_blocks":
+		endpoint.RequestQueryParams = []apimiddleware.QueryParam{{Name: "broadcast_validation", Enum: true}}
+		endpoint.Hooks = apimiddleware.HookCollection{
+			OnPreDeserializeRequestBodyIntoContainer:  setInitialPublishBlindedBlockPostRequest,
+			OnPostDeserializeRequestBodyIntoContainer: preparePublishedBlindedBlock,
+		}
+	case "/eth/v1/beacon/blinded_blocks/{block_id}":
+		endpoint.GetResponse = &blindedBlockResponseJson{}
+		endpoint.Hooks = apimiddleware.HookCollection{
+			OnPreSerializeMiddlewareResponseIntoJson: serializeBlindedBlock,
+		}
+	case "/eth/v1/beacon/light_client/bootstrap/{block_root}":
+		endpoint.GetResponse = &lightClientBootstrapResponseJson{}
+	case "/eth/v1/beacon/light_client/updates":
+		endpoint.RequestQueryParams = []apimiddleware.QueryParam{{Name: "start_period"}, {Name: "count"}}
+		endpoint.GetResponse = &lightClientUpdatesByRangeResponseJson{}
+		endpoint.Hooks = apimiddleware.HookCollection{
+			OnPreSerializeMiddlewareResponseIntoJson: serializeLightClientUpdates,
+		}
+	case "/eth/v1/beacon/light_client/finality_update":
+		endpoint.GetResponse = &lightClientFinalityUpdateResponseJson{}
+	case "/eth/v1/beacon/light_client/optimistic_update":
+		endpoint.GetResponse = &lightClientOptimisticUpdateResponseJson{}
+	case "/eth/v1/beacon/rewards/blocks/{block_id}":
+		endpoint.GetResponse = &blockRewardsResponseJson{}
+	case "/eth/v1/beacon/rewards/attestations/{epoch}":
+		endpoint.PostRequest = &dutiesRequestJson{}
+		endpoint.PostResponse = &attestationRewardsResponseJson{}
+		endpoint.RequestURLLiterals = []string{"epoch"}
+		endpoint.Hooks = apimiddleware.HookCollection{
+			OnPreDeserializeRequestBodyIntoContainer: wrapValidatorIndicesArray,
+		}
 	case "/eth/v1/beacon/pool/attestations":
 		endpoint.RequestQueryParams = []apimiddleware.QueryParam{{Name: "slot"}, {Name: "committee_index"}}
 		endpoint.GetResponse = &attestationsPoolResponseJson{}
@@ -156,7 +199,7 @@ func (_ *BeaconEndpointFactory) Create(path string) (*apimiddleware.Endpoint, er
 	case "/eth/v1/node/syncing":
 		endpoint.GetResponse = &syncingResponseJson{}
 	case "/eth/v1/node/health":
-		// Use default endpoint
+		endpoint.GetResponse = &healthResponseJson{}
 	case "/eth/v1/debug/beacon/states/{state_id}":
 		endpoint.GetResponse = &beaconStateResponseJson{}
 		endpoint.CustomHandlers = []apimiddleware.CustomHandler{handleGetBeaconStateSSZ}
@@ -168,6 +211,8 @@ func (_ *BeaconEndpointFactory) Create(path string) (*apimiddleware.Endpoint, er
 		endpoint.CustomHandlers = []apimiddleware.CustomHandler{handleGetBeaconStateSSZV2}
 	case "/eth/v1/debug/beacon/heads":
 		endpoint.GetResponse = &forkChoiceHeadsResponseJson{}
+	case "/eth/v1/debug/fork_choice":
+		endpoint.GetResponse = &forkChoiceResponseJson{}
 	case "/eth/v1/config/fork_schedule":
 		endpoint.GetResponse = &forkScheduleResponseJson{}
 	case "/eth/v1/config/deposit_contract":
@@ -238,6 +283,13 @@ func (_ *BeaconEndpointFactory) Create(path string) (*apimiddleware.Endpoint, er
 		endpoint.Hooks = apimiddleware.HookCollection{
 			OnPreDeserializeRequestBodyIntoContainer: wrapSignedContributionAndProofsArray,
 		}
+	case "/eth/v1/validator/liveness/{epoch}":
+		endpoint.PostRequest = &dutiesRequestJson{}
+		endpoint.PostResponse = &livenessResponseJson{}
+		endpoint.RequestURLLiterals = []string{"epoch"}
+		endpoint.Hooks = apimiddleware.HookCollection{
+			OnPreDeserializeRequestBodyIntoContainer: wrapValidatorIndicesArray,
+		}
 	default:
 		return nil, errors.New("invalid path")
 	}
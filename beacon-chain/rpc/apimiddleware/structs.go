@@ -48,7 +48,8 @@ type stateFinalityCheckpointResponse_StateFinalityCheckpointJson struct {
 
 // stateValidatorResponseJson is used in /beacon/states/{state_id}/validators API endpoint.
 type stateValidatorsResponseJson struct {
-	Data []*validatorContainerJson `json:"data"`
+	Data          []*validatorContainerJson `json:"data"`
+	NextPageToken string                    `json:"next_page_token"`
 }
 
 // stateValidatorResponseJson is used in /beacon/states/{state_id}/validators/{validator_id} API endpoint.
@@ -92,6 +93,12 @@ type blockV2ResponseJson struct {
 	Data    *signedBeaconBlockContainerV2Json `json:"data"`
 }
 
+// blindedBlockResponseJson is used in /beacon/blinded_blocks/{block_id} API endpoint.
+type blindedBlockResponseJson struct {
+	Version string                                 `json:"version" enum:"true"`
+	Data    *signedBlindedBeaconBlockContainerJson `json:"data"`
+}
+
 // blockRootResponseJson is used in /beacon/blocks/{block_id}/root API endpoint.
 type blockRootResponseJson struct {
 	Data *blockRootContainerJson `json:"data"`
@@ -102,6 +109,39 @@ type blockAttestationsResponseJson struct {
 	Data []*attestationJson `json:"data"`
 }
 
+// lightClientBootstrapResponseJson is used in /beacon/light_client/bootstrap/{block_root} API endpoint.
+type lightClientBootstrapResponseJson struct {
+	Version string                    `json:"version" enum:"true"`
+	Data    *lightClientBootstrapJson `json:"data"`
+}
+
+// lightClientUpdatesByRangeResponseJson is used in /beacon/light_client/updates API endpoint.
+type lightClientUpdatesByRangeResponseJson struct {
+	Updates []*lightClientUpdateWithVersionJson `json:"updates"`
+}
+
+// lightClientFinalityUpdateResponseJson is used in /beacon/light_client/finality_update API endpoint.
+type lightClientFinalityUpdateResponseJson struct {
+	Version string                         `json:"version" enum:"true"`
+	Data    *lightClientFinalityUpdateJson `json:"data"`
+}
+
+// lightClientOptimisticUpdateResponseJson is used in /beacon/light_client/optimistic_update API endpoint.
+type lightClientOptimisticUpdateResponseJson struct {
+	Version string                           `json:"version" enum:"true"`
+	Data    *lightClientOptimisticUpdateJson `json:"data"`
+}
+
+// blockRewardsResponseJson is used in /beacon/rewards/blocks/{block_id} API endpoint.
+type blockRewardsResponseJson struct {
+	Data *blockRewardsJson `json:"data"`
+}
+
+// attestationRewardsResponseJson is used in /beacon/rewards/attestations/{epoch} API endpoint.
+type attestationRewardsResponseJson struct {
+	Data *attestationRewardsJson `json:"data"`
+}
+
 // attestationsPoolResponseJson is used in /beacon/pool/attestations GET API endpoint.
 type attestationsPoolResponseJson struct {
 	Data []*attestationJson `json:"data"`
@@ -170,6 +210,11 @@ type syncingResponseJson struct {
 	Data *syncInfoJson `json:"data"`
 }
 
+// healthResponseJson is used in /node/health API endpoint.
+type healthResponseJson struct {
+	Data *healthInfoJson `json:"data"`
+}
+
 // beaconStateResponseJson is used in /debug/beacon/states/{state_id} API endpoint.
 type beaconStateResponseJson struct {
 	Data *beaconStateJson `json:"data"`
@@ -186,6 +231,11 @@ type forkChoiceHeadsResponseJson struct {
 	Data []*forkChoiceHeadJson `json:"data"`
 }
 
+// forkChoiceResponseJson is used in /debug/fork_choice API endpoint.
+type forkChoiceResponseJson struct {
+	Data *forkChoiceDumpJson `json:"data"`
+}
+
 // forkScheduleResponseJson is used in /config/fork_schedule API endpoint.
 type forkScheduleResponseJson struct {
 	Data []*forkJson `json:"data"`
@@ -196,12 +246,18 @@ type depositContractResponseJson struct {
 	Data *depositContractJson `json:"data"`
 }
 
+// depositSnapshotResponseJson is used in /beacon/deposit_snapshot API endpoint.
+type depositSnapshotResponseJson struct {
+	Data *depositSnapshotJson `json:"data"`
+}
+
 // specResponseJson is used in /config/spec API endpoint.
 type specResponseJson struct {
 	Data interface{} `json:"data"`
 }
 
-// dutiesRequestJson is used in several duties-related API endpoints.
+// dutiesRequestJson is used in several duties-related API endpoints, as well as in /validator/liveness/{epoch}
+// and /beacon/rewards/attestations/{epoch} API endpoints.
 type dutiesRequestJson struct {
 	Index []string `json:"index"`
 }
@@ -223,6 +279,11 @@ type syncCommitteeDutiesResponseJson struct {
 	Data []*syncCommitteeDuty `json:"data"`
 }
 
+// livenessResponseJson is used in /validator/liveness/{epoch} API endpoint.
+type livenessResponseJson struct {
+	Data []*livenessJson `json:"data"`
+}
+
 // produceBlockResponseJson is used in /validator/blocks/{slot} API endpoint.
 type produceBlockResponseJson struct {
 	Data *beaconBlockJson `json:"data"`
@@ -335,6 +396,13 @@ type beaconBlockContainerV2Json struct {
 	BellatrixBlock *beaconBlockBellatrixJson `json:"bellatrix_block"`
 }
 
+type signedBlindedBeaconBlockContainerJson struct {
+	Phase0Block    *beaconBlockJson                 `json:"phase0_block"`
+	AltairBlock    *beaconBlockAltairJson           `json:"altair_block"`
+	BellatrixBlock *blindedBeaconBlockBellatrixJson `json:"bellatrix_block"`
+	Signature      string                           `json:"signature" hex:"true"`
+}
+
 type signedBeaconBlockAltairContainerJson struct {
 	Message   *beaconBlockAltairJson `json:"message"`
 	Signature string                 `json:"signature" hex:"true"`
@@ -345,6 +413,11 @@ type signedBeaconBlockBellatrixContainerJson struct {
 	Signature string                    `json:"signature" hex:"true"`
 }
 
+type signedBlindedBeaconBlockBellatrixContainerJson struct {
+	Message   *blindedBeaconBlockBellatrixJson `json:"message"`
+	Signature string                           `json:"signature" hex:"true"`
+}
+
 type beaconBlockAltairJson struct {
 	Slot          string                     `json:"slot"`
 	ProposerIndex string                     `json:"proposer_index"`
@@ -361,6 +434,14 @@ type beaconBlockBellatrixJson struct {
 	Body          *beaconBlockBodyBellatrixJson `json:"body"`
 }
 
+type blindedBeaconBlockBellatrixJson struct {
+	Slot          string                               `json:"slot"`
+	ProposerIndex string                               `json:"proposer_index"`
+	ParentRoot    string                               `json:"parent_root" hex:"true"`
+	StateRoot     string                               `json:"state_root" hex:"true"`
+	Body          *blindedBeaconBlockBodyBellatrixJson `json:"body"`
+}
+
 type beaconBlockBodyAltairJson struct {
 	RandaoReveal      string                     `json:"randao_reveal" hex:"true"`
 	Eth1Data          *eth1DataJson              `json:"eth1_data"`
@@ -403,6 +484,36 @@ type executionPayloadJson struct {
 	Transactions  []string `json:"transactions" hex:"true"`
 }
 
+type blindedBeaconBlockBodyBellatrixJson struct {
+	RandaoReveal           string                      `json:"randao_reveal" hex:"true"`
+	Eth1Data               *eth1DataJson               `json:"eth1_data"`
+	Graffiti               string                      `json:"graffiti" hex:"true"`
+	ProposerSlashings      []*proposerSlashingJson     `json:"proposer_slashings"`
+	AttesterSlashings      []*attesterSlashingJson     `json:"attester_slashings"`
+	Attestations           []*attestationJson          `json:"attestations"`
+	Deposits               []*depositJson              `json:"deposits"`
+	VoluntaryExits         []*signedVoluntaryExitJson  `json:"voluntary_exits"`
+	SyncAggregate          *syncAggregateJson          `json:"sync_aggregate"`
+	ExecutionPayloadHeader *executionPayloadHeaderJson `json:"execution_payload_header"`
+}
+
+type executionPayloadHeaderJson struct {
+	ParentHash       string `json:"parent_hash" hex:"true"`
+	FeeRecipient     string `json:"fee_recipient" hex:"true"`
+	StateRoot        string `json:"state_root" hex:"true"`
+	ReceiptRoot      string `json:"receipt_root" hex:"true"`
+	LogsBloom        string `json:"logs_bloom" hex:"true"`
+	Random           string `json:"random" hex:"true"`
+	BlockNumber      string `json:"block_number"`
+	GasLimit         string `json:"gas_limit"`
+	GasUsed          string `json:"gas_used"`
+	TimeStamp        string `json:"timestamp"`
+	ExtraData        string `json:"extra_data" hex:"true"`
+	BaseFeePerGas    string `json:"base_fee_per_gas" hex:"true"`
+	BlockHash        string `json:"block_hash" hex:"true"`
+	TransactionsRoot string `json:"transactions_root" hex:"true"`
+}
+
 type syncAggregateJson struct {
 	SyncCommitteeBits      string `json:"sync_committee_bits" hex:"true"`
 	SyncCommitteeSignature string `json:"sync_committee_signature" hex:"true"`
@@ -618,6 +729,71 @@ type syncCommitteeJson struct {
 	AggregatePubkey string   `json:"aggregate_pubkey" hex:"true"`
 }
 
+type blockRewardsJson struct {
+	ProposerIndex     string `json:"proposer_index"`
+	Total             string `json:"total"`
+	Attestations      string `json:"attestations"`
+	SyncAggregate     string `json:"sync_aggregate"`
+	ProposerSlashings string `json:"proposer_slashings"`
+	AttesterSlashings string `json:"attester_slashings"`
+}
+
+type attestationRewardsJson struct {
+	IdealRewards []*idealAttestationRewardJson `json:"ideal_rewards"`
+	TotalRewards []*totalAttestationRewardJson `json:"total_rewards"`
+}
+
+type idealAttestationRewardJson struct {
+	EffectiveBalance string `json:"effective_balance"`
+	Head             string `json:"head"`
+	Target           string `json:"target"`
+	Source           string `json:"source"`
+	Inactivity       string `json:"inactivity"`
+}
+
+type totalAttestationRewardJson struct {
+	ValidatorIndex string `json:"validator_index"`
+	Head           string `json:"head"`
+	Target         string `json:"target"`
+	Source         string `json:"source"`
+	Inactivity     string `json:"inactivity"`
+}
+
+type lightClientBootstrapJson struct {
+	Header                     *beaconBlockHeaderJson `json:"header"`
+	CurrentSyncCommittee       *syncCommitteeJson     `json:"current_sync_committee"`
+	CurrentSyncCommitteeBranch []string               `json:"current_sync_committee_branch" hex:"true"`
+}
+
+type lightClientUpdateWithVersionJson struct {
+	Version string                 `json:"version" enum:"true"`
+	Data    *lightClientUpdateJson `json:"data"`
+}
+
+type lightClientUpdateJson struct {
+	AttestedHeader          *beaconBlockHeaderJson `json:"attested_header"`
+	NextSyncCommittee       *syncCommitteeJson     `json:"next_sync_committee"`
+	NextSyncCommitteeBranch []string               `json:"next_sync_committee_branch" hex:"true"`
+	FinalizedHeader         *beaconBlockHeaderJson `json:"finalized_header"`
+	FinalityBranch          []string               `json:"finality_branch" hex:"true"`
+	SyncAggregate           *syncAggregateJson     `json:"sync_aggregate"`
+	SignatureSlot           string                 `json:"signature_slot"`
+}
+
+type lightClientFinalityUpdateJson struct {
+	AttestedHeader  *beaconBlockHeaderJson `json:"attested_header"`
+	FinalizedHeader *beaconBlockHeaderJson `json:"finalized_header"`
+	FinalityBranch  []string               `json:"finality_branch" hex:"true"`
+	SyncAggregate   *syncAggregateJson     `json:"sync_aggregate"`
+	SignatureSlot   string                 `json:"signature_slot"`
+}
+
+type lightClientOptimisticUpdateJson struct {
+	AttestedHeader *beaconBlockHeaderJson `json:"attested_header"`
+	SyncAggregate  *syncAggregateJson     `json:"sync_aggregate"`
+	SignatureSlot  string                 `json:"signature_slot"`
+}
+
 type syncCommitteeValidatorsJson struct {
 	Validators          []string   `json:"validators"`
 	ValidatorAggregates [][]string `json:"validator_aggregates"`
@@ -635,17 +811,52 @@ type forkChoiceHeadJson struct {
 	Slot string `json:"slot"`
 }
 
+type forkChoiceDumpJson struct {
+	JustifiedCheckpoint *checkpointJson       `json:"justified_checkpoint"`
+	FinalizedCheckpoint *checkpointJson       `json:"finalized_checkpoint"`
+	HeadRoot            string                `json:"head_root" hex:"true"`
+	ProposerBoostRoot   string                `json:"proposer_boost_root" hex:"true"`
+	Nodes               []*forkChoiceNodeJson `json:"nodes"`
+}
+
+type forkChoiceNodeJson struct {
+	Slot           string `json:"slot"`
+	Root           string `json:"root" hex:"true"`
+	ParentRoot     string `json:"parent_root" hex:"true"`
+	JustifiedEpoch string `json:"justified_epoch"`
+	FinalizedEpoch string `json:"finalized_epoch"`
+	Weight         string `json:"weight"`
+	Optimistic     bool   `json:"optimistic"`
+}
+
 type depositContractJson struct {
 	ChainId string `json:"chain_id"`
 	Address string `json:"address"`
 }
 
+type depositSnapshotJson struct {
+	Finalized            []string `json:"finalized" hex:"true"`
+	DepositRoot          string   `json:"deposit_root" hex:"true"`
+	DepositCount         string   `json:"deposit_count"`
+	ExecutionBlockHash   string   `json:"execution_block_hash" hex:"true"`
+	ExecutionBlockHeight string   `json:"execution_block_height"`
+}
+
 type syncInfoJson struct {
 	HeadSlot     string `json:"head_slot"`
 	SyncDistance string `json:"sync_distance"`
 	IsSyncing    bool   `json:"is_syncing"`
 }
 
+type healthInfoJson struct {
+	Stage         string `json:"stage" enum:"true"`
+	SyncDistance  string `json:"sync_distance"`
+	IsSyncing     bool   `json:"is_syncing"`
+	IsOptimistic  bool   `json:"is_optimistic"`
+	ElOffline     bool   `json:"el_offline"`
+	IsBackfilling bool   `json:"is_backfilling"`
+}
+
 type attesterDutyJson struct {
 	Pubkey                  string `json:"pubkey" hex:"true"`
 	ValidatorIndex          string `json:"validator_index"`
@@ -668,6 +879,11 @@ type syncCommitteeDuty struct {
 	ValidatorSyncCommitteeIndices []string `json:"validator_sync_committee_indices"`
 }
 
+type livenessJson struct {
+	Index  string `json:"index"`
+	IsLive bool   `json:"is_live"`
+}
+
 type signedAggregateAttestationAndProofJson struct {
 	Message   *aggregateAttestationAndProofJson `json:"message"`
 	Signature string                            `json:"signature" hex:"true"`
@@ -791,13 +1007,60 @@ type eventFinalizedCheckpointJson struct {
 }
 
 type eventChainReorgJson struct {
-	Slot         string `json:"slot"`
-	Depth        string `json:"depth"`
-	OldHeadBlock string `json:"old_head_block" hex:"true"`
-	NewHeadBlock string `json:"old_head_state" hex:"true"`
-	OldHeadState string `json:"new_head_block" hex:"true"`
-	NewHeadState string `json:"new_head_state" hex:"true"`
-	Epoch        string `json:"epoch"`
+	Slot                string `json:"slot"`
+	Depth               string `json:"depth"`
+	OldHeadBlock        string `json:"old_head_block" hex:"true"`
+	NewHeadBlock        string `json:"new_head_block" hex:"true"`
+	OldHeadState        string `json:"old_head_state" hex:"true"`
+	NewHeadState        string `json:"new_head_state" hex:"true"`
+	Epoch               string `json:"epoch"`
+	CommonAncestorBlock string `json:"common_ancestor_block" hex:"true"`
+}
+
+type eventPayloadAttributesJson struct {
+	Version string                          `json:"version"`
+	Data    *eventPayloadAttributesDataJson `json:"data"`
+}
+
+type eventPayloadAttributesDataJson struct {
+	ProposerIndex     string                 `json:"proposer_index"`
+	ProposalSlot      string                 `json:"proposal_slot"`
+	ParentBlockNumber string                 `json:"parent_block_number"`
+	ParentBlockRoot   string                 `json:"parent_block_root" hex:"true"`
+	ParentBlockHash   string                 `json:"parent_block_hash" hex:"true"`
+	PayloadAttributes *payloadAttributesJson `json:"payload_attributes"`
+}
+
+type payloadAttributesJson struct {
+	Timestamp             string            `json:"timestamp"`
+	PrevRandao            string            `json:"prev_randao" hex:"true"`
+	SuggestedFeeRecipient string            `json:"suggested_fee_recipient" hex:"true"`
+	Withdrawals           []*withdrawalJson `json:"withdrawals"`
+}
+
+type withdrawalJson struct {
+	Index          string `json:"index"`
+	ValidatorIndex string `json:"validator_index"`
+	Address        string `json:"address" hex:"true"`
+	Amount         string `json:"amount"`
+}
+
+type eventExecutionConnectionJson struct {
+	Endpoint  string `json:"endpoint"`
+	Connected bool   `json:"connected"`
+	Error     string `json:"error"`
+}
+
+type eventTerminalBlockJson struct {
+	Hash            string `json:"hash" hex:"true"`
+	Number          string `json:"number"`
+	TotalDifficulty string `json:"total_difficulty"`
+}
+
+type eventTransitionConfigurationJson struct {
+	Endpoint string `json:"endpoint"`
+	Matching bool   `json:"matching"`
+	Error    string `json:"error"`
 }
 
 // ---------------
@@ -0,0 +1,116 @@
+package blockfetcher
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+)
+
+// BlockIdParseError represents an error scenario where a block ID could not be parsed.
+type BlockIdParseError struct {
+	message string
+}
+
+// NewBlockIdParseError creates a new error instance.
+func NewBlockIdParseError(reason error) BlockIdParseError {
+	return BlockIdParseError{
+		message: errors.Wrapf(reason, "could not parse block ID").Error(),
+	}
+}
+
+// Error returns the underlying error message.
+func (e *BlockIdParseError) Error() string {
+	return e.message
+}
+
+// Fetcher is responsible for retrieving blocks by their identifier.
+type Fetcher interface {
+	Block(ctx context.Context, blockId []byte) (block.SignedBeaconBlock, error)
+}
+
+// BlockProvider is a real implementation of Fetcher.
+type BlockProvider struct {
+	BeaconDB         db.ReadOnlyDatabase
+	ChainInfoFetcher blockchain.ChainInfoFetcher
+}
+
+// Block returns the block for a given identifier, or nil if there is no such block. The identifier can be one of:
+//  - "head" (canonical head in node's view)
+//  - "genesis"
+//  - "finalized"
+//  - <slot>
+//  - <block root>
+func (p *BlockProvider) Block(ctx context.Context, blockId []byte) (block.SignedBeaconBlock, error) {
+	var err error
+	var blk block.SignedBeaconBlock
+	switch string(blockId) {
+	case "head":
+		blk, err = p.ChainInfoFetcher.HeadBlock(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not retrieve head block")
+		}
+	case "finalized":
+		finalized := p.ChainInfoFetcher.FinalizedCheckpt()
+		finalizedRoot := bytesutil.ToBytes32(finalized.Root)
+		blk, err = p.BeaconDB.Block(ctx, finalizedRoot)
+		if err != nil {
+			return nil, errors.New("could not get finalized block from db")
+		}
+	case "genesis":
+		blk, err = p.BeaconDB.GenesisBlock(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not retrieve blocks for genesis slot")
+		}
+	default:
+		if len(blockId) == 32 {
+			blk, err = p.BeaconDB.Block(ctx, bytesutil.ToBytes32(blockId))
+			if err != nil {
+				return nil, errors.Wrap(err, "could not retrieve block")
+			}
+		} else {
+			slot, err := strconv.ParseUint(string(blockId), 10, 64)
+			if err != nil {
+				e := NewBlockIdParseError(err)
+				return nil, &e
+			}
+			return p.blockBySlot(ctx, types.Slot(slot))
+		}
+	}
+	return blk, nil
+}
+
+// blockBySlot returns the canonical block of the slot, or its first block if none of them is canonical.
+func (p *BlockProvider) blockBySlot(ctx context.Context, slot types.Slot) (block.SignedBeaconBlock, error) {
+	_, blks, err := p.BeaconDB.BlocksBySlot(ctx, slot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not retrieve blocks for slot %d", slot)
+	}
+	_, roots, err := p.BeaconDB.BlockRootsBySlot(ctx, slot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not retrieve block roots for slot %d", slot)
+	}
+
+	numBlks := len(blks)
+	if numBlks == 0 {
+		return nil, nil
+	}
+	if numBlks == 1 {
+		return blks[0], nil
+	}
+	for i, b := range blks {
+		canonical, err := p.ChainInfoFetcher.IsCanonical(ctx, roots[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "could not determine if block root is canonical")
+		}
+		if canonical {
+			return b, nil
+		}
+	}
+	return blks[0], nil
+}
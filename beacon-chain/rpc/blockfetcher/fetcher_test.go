@@ -0,0 +1,81 @@
+package blockfetcher
+
+import (
+	"context"
+	"testing"
+
+	chainMock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestGetBlock(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	genesis := util.NewBeaconBlock()
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(genesis)))
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	// Two blocks at slot 2, of which only the second one is canonical.
+	var blks []*ethpb.SignedBeaconBlock
+	var roots [][32]byte
+	for i := 0; i < 2; i++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = 2
+		b.Block.ParentRoot = genesisRoot[:]
+		b.Block.Body.Graffiti = bytesutil.PadTo([]byte{byte(i)}, 32)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(b)))
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		blks = append(blks, b)
+		roots = append(roots, root)
+	}
+	head := wrapper.WrappedPhase0SignedBeaconBlock(blks[1])
+	p := &BlockProvider{
+		BeaconDB: beaconDB,
+		ChainInfoFetcher: &chainMock.ChainService{
+			Block:               head,
+			CanonicalRoots:      map[[32]byte]bool{roots[1]: true},
+			FinalizedCheckPoint: &ethpb.Checkpoint{Root: genesisRoot[:]},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		blockId []byte
+		want    *ethpb.SignedBeaconBlock
+	}{
+		{name: "head", blockId: []byte("head"), want: blks[1]},
+		{name: "genesis", blockId: []byte("genesis"), want: genesis},
+		{name: "finalized", blockId: []byte("finalized"), want: genesis},
+		{name: "root", blockId: roots[0][:], want: blks[0]},
+		{name: "canonical block of slot", blockId: []byte("2"), want: blks[1]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blk, err := p.Block(ctx, tt.blockId)
+			require.NoError(t, err)
+			pb, err := blk.PbPhase0Block()
+			require.NoError(t, err)
+			assert.DeepEqual(t, tt.want, pb)
+		})
+	}
+	t.Run("empty slot", func(t *testing.T) {
+		blk, err := p.Block(ctx, []byte("3"))
+		require.NoError(t, err)
+		assert.Equal(t, true, blk == nil)
+	})
+	t.Run("invalid ID", func(t *testing.T) {
+		_, err := p.Block(ctx, []byte("foo"))
+		_, ok := err.(*BlockIdParseError)
+		assert.Equal(t, true, ok)
+	})
+}
@@ -0,0 +1,192 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	coreblocks "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/encoding/ssz"
+	enginev1 "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/proto/migration"
+	ethpbalpha "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetBlindedBlock retrieves the blinded block for given block ID. The execution payload of Bellatrix blocks is
+// replaced by its header, while blocks of earlier forks are returned unchanged.
+func (bs *Server) GetBlindedBlock(ctx context.Context, req *ethpbv2.BlockRequestV2) (*ethpbv2.BlindedBlockResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon.GetBlindedBlock")
+	defer span.End()
+
+	blk, err := bs.blockFromBlockID(ctx, req.BlockId)
+	err = handleGetBlockError(blk, err)
+	if err != nil {
+		return nil, err
+	}
+	if err := bs.setOptimisticHeader(ctx, blk); err != nil {
+		return nil, err
+	}
+
+	switch blk.Version() {
+	case version.Phase0:
+		v1Blk, err := migration.SignedBeaconBlock(blk)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get signed beacon block: %v", err)
+		}
+		return &ethpbv2.BlindedBlockResponse{
+			Version: ethpbv2.Version_PHASE0,
+			Data: &ethpbv2.SignedBlindedBeaconBlockContainer{
+				Message:   &ethpbv2.SignedBlindedBeaconBlockContainer_Phase0Block{Phase0Block: v1Blk.Block},
+				Signature: v1Blk.Signature,
+			},
+		}, nil
+	case version.Altair:
+		altairBlk, err := blk.PbAltairBlock()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get Altair block: %v", err)
+		}
+		v2Blk, err := migration.V1Alpha1BeaconBlockAltairToV2(altairBlk.Block)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get signed beacon block: %v", err)
+		}
+		return &ethpbv2.BlindedBlockResponse{
+			Version: ethpbv2.Version_ALTAIR,
+			Data: &ethpbv2.SignedBlindedBeaconBlockContainer{
+				Message:   &ethpbv2.SignedBlindedBeaconBlockContainer_AltairBlock{AltairBlock: v2Blk},
+				Signature: blk.Signature(),
+			},
+		}, nil
+	case version.Bellatrix:
+		bellatrixBlk, err := blk.PbBellatrixBlock()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get Bellatrix block: %v", err)
+		}
+		v2Blk, err := migration.V1Alpha1BeaconBlockBellatrixToV2Blinded(bellatrixBlk.Block)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get blinded beacon block: %v", err)
+		}
+		return &ethpbv2.BlindedBlockResponse{
+			Version: ethpbv2.Version_BELLATRIX,
+			Data: &ethpbv2.SignedBlindedBeaconBlockContainer{
+				Message:   &ethpbv2.SignedBlindedBeaconBlockContainer_BellatrixBlock{BellatrixBlock: v2Blk},
+				Signature: blk.Signature(),
+			},
+		}, nil
+	default:
+		return nil, status.Errorf(codes.Internal, "Unsupported block version %s", version.String(blk.Version()))
+	}
+}
+
+// SubmitBlindedBlock instructs the beacon node to broadcast a newly signed blinded beacon block to the beacon
+// network. Blinded Bellatrix blocks are unblinded with the execution payload known to the execution node, which
+// must be available locally. Blocks of earlier forks are not blinded and are submitted as regular blocks.
+func (bs *Server) SubmitBlindedBlock(ctx context.Context, req *ethpbv2.SubmitBlindedBlockRequest) (*emptypb.Empty, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon.SubmitBlindedBlock")
+	defer span.End()
+
+	if req.Block == nil {
+		return nil, status.Error(codes.InvalidArgument, "No block provided")
+	}
+	switch blk := req.Block.Message.(type) {
+	case *ethpbv2.SignedBlindedBeaconBlockContainer_Phase0Block:
+		return bs.SubmitBlock(ctx, &ethpbv2.SubmitBlockRequest{
+			Block: &ethpbv2.SignedBeaconBlockContainerV2{
+				Message:   &ethpbv2.SignedBeaconBlockContainerV2_Phase0Block{Phase0Block: blk.Phase0Block},
+				Signature: req.Block.Signature,
+			},
+			BroadcastValidation: req.BroadcastValidation,
+		})
+	case *ethpbv2.SignedBlindedBeaconBlockContainer_AltairBlock:
+		return bs.SubmitBlock(ctx, &ethpbv2.SubmitBlockRequest{
+			Block: &ethpbv2.SignedBeaconBlockContainerV2{
+				Message:   &ethpbv2.SignedBeaconBlockContainerV2_AltairBlock{AltairBlock: blk.AltairBlock},
+				Signature: req.Block.Signature,
+			},
+			BroadcastValidation: req.BroadcastValidation,
+		})
+	case *ethpbv2.SignedBlindedBeaconBlockContainer_BellatrixBlock:
+		if err := bs.submitBlindedBellatrixBlock(ctx, blk.BellatrixBlock, req.Block.Signature, req.BroadcastValidation); err != nil {
+			return nil, err
+		}
+		return &emptypb.Empty{}, nil
+	default:
+		return nil, status.Error(codes.InvalidArgument, "Unsupported block type")
+	}
+}
+
+func (bs *Server) submitBlindedBellatrixBlock(
+	ctx context.Context,
+	blindedBlk *ethpbv2.BlindedBeaconBlockBellatrix,
+	signature []byte,
+	level ethpbv2.BroadcastValidation,
+) error {
+	if blindedBlk.Body == nil || blindedBlk.Body.ExecutionPayloadHeader == nil {
+		return status.Error(codes.InvalidArgument, "No execution payload header provided")
+	}
+	header, err := migration.V2ExecutionPayloadHeaderToV1Alpha1(blindedBlk.Body.ExecutionPayloadHeader)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Could not convert execution payload header: %v", err)
+	}
+	payload, err := bs.payloadFromHeader(ctx, header)
+	if err != nil {
+		return err
+	}
+	v1alpha1Blk, err := migration.BlindedBellatrixToV1Alpha1SignedBlock(blindedBlk, signature, payload)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Could not unblind block: %v", err)
+	}
+	wrappedBellatrixBlk, err := wrapper.WrappedBellatrixSignedBeaconBlock(v1alpha1Blk)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Could not prepare Bellatrix block: %v", err)
+	}
+
+	root, err := v1alpha1Blk.Block.HashTreeRoot()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Could not tree hash block: %v", err)
+	}
+
+	return bs.proposeBlock(ctx, wrappedBellatrixBlk, root, level)
+}
+
+// payloadFromHeader reconstructs the execution payload committed to by the header. The transactions of the payload
+// are retrieved from the execution node, unless the payload has no transactions.
+func (bs *Server) payloadFromHeader(ctx context.Context, header *ethpbalpha.ExecutionPayloadHeader) (*enginev1.ExecutionPayload, error) {
+	emptyTxRoot, err := ssz.TransactionsRoot([][]byte{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute transactions root: %v", err)
+	}
+	if bytes.Equal(header.TransactionsRoot, emptyTxRoot[:]) {
+		payload, err := coreblocks.PayloadFromHeaderAndBody(header, &enginev1.ExecutionPayloadBodyV1{})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not reconstruct execution payload: %v", err)
+		}
+		return payload, nil
+	}
+
+	if bs.PayloadBodiesFetcher == nil {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"Execution payload %#x is not available locally: no execution node is configured",
+			header.BlockHash,
+		)
+	}
+	bodies, err := bs.PayloadBodiesFetcher.GetPayloadBodiesByHash(ctx, []common.Hash{common.BytesToHash(header.BlockHash)})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get execution payload body: %v", err)
+	}
+	if len(bodies) != 1 || bodies[0] == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Execution payload %#x is not available locally", header.BlockHash)
+	}
+	payload, err := coreblocks.PayloadFromHeaderAndBody(header, bodies[0])
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Execution payload does not match header: %v", err)
+	}
+	return payload, nil
+}
@@ -0,0 +1,195 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+	mockengine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1/testing"
+	mockstategen "github.com/prysmaticlabs/prysm/beacon-chain/state/stategen/mock"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/encoding/ssz"
+	enginev1 "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/proto/migration"
+	ethpbalpha "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestServer_GetBlindedBlock(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Bellatrix", func(t *testing.T) {
+		beaconDB := dbTest.SetupDB(t)
+		b := util.NewBeaconBlockBellatrix()
+		b.Block.Slot = 1
+		b.Block.Body.ExecutionPayload.BlockNumber = 2
+		b.Block.Body.ExecutionPayload.Transactions = [][]byte{[]byte("transaction")}
+		wsb, err := wrapper.WrappedBellatrixSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wsb))
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		bs := &Server{
+			BeaconDB:         beaconDB,
+			ChainInfoFetcher: &mock.ChainService{},
+		}
+
+		resp, err := bs.GetBlindedBlock(ctx, &ethpbv2.BlockRequestV2{BlockId: root[:]})
+		require.NoError(t, err)
+		assert.Equal(t, ethpbv2.Version_BELLATRIX, resp.Version)
+		blindedBlk, ok := resp.Data.Message.(*ethpbv2.SignedBlindedBeaconBlockContainer_BellatrixBlock)
+		require.Equal(t, true, ok)
+		assert.DeepEqual(t, b.Signature, resp.Data.Signature)
+		header := blindedBlk.BellatrixBlock.Body.ExecutionPayloadHeader
+		require.NotNil(t, header)
+		assert.Equal(t, uint64(2), header.BlockNumber)
+		txRoot, err := ssz.TransactionsRoot(b.Block.Body.ExecutionPayload.Transactions)
+		require.NoError(t, err)
+		assert.DeepEqual(t, txRoot[:], header.TransactionsRoot)
+	})
+	t.Run("Altair", func(t *testing.T) {
+		beaconDB := dbTest.SetupDB(t)
+		b := util.NewBeaconBlockAltair()
+		b.Block.Slot = 1
+		wsb, err := wrapper.WrappedAltairSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wsb))
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		bs := &Server{
+			BeaconDB:         beaconDB,
+			ChainInfoFetcher: &mock.ChainService{},
+		}
+
+		resp, err := bs.GetBlindedBlock(ctx, &ethpbv2.BlockRequestV2{BlockId: root[:]})
+		require.NoError(t, err)
+		assert.Equal(t, ethpbv2.Version_ALTAIR, resp.Version)
+		altairBlk, ok := resp.Data.Message.(*ethpbv2.SignedBlindedBeaconBlockContainer_AltairBlock)
+		require.Equal(t, true, ok)
+		v2Blk, err := migration.V1Alpha1BeaconBlockAltairToV2(b.Block)
+		require.NoError(t, err)
+		assert.DeepEqual(t, v2Blk, altairBlk.AltairBlock)
+	})
+	t.Run("unknown block", func(t *testing.T) {
+		bs := &Server{BeaconDB: dbTest.SetupDB(t)}
+		_, err := bs.GetBlindedBlock(ctx, &ethpbv2.BlockRequestV2{BlockId: make([]byte, 32)})
+		assert.ErrorContains(t, "Could not find requested block", err)
+	})
+}
+
+func TestServer_SubmitBlindedBlock(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	genesis := util.NewBeaconBlock()
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(genesis)))
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	beaconState, keys := util.DeterministicGenesisStateBellatrix(t, 1)
+	stateGen := mockstategen.NewMockService()
+	newServer := func(fetcher *mockengine.EngineClient) (*Server, *mock.ChainService) {
+		stateGen.AddStateForRoot(beaconState.Copy(), genesisRoot)
+		c := &mock.ChainService{
+			Root:                genesisRoot[:],
+			State:               beaconState.Copy(),
+			FinalizedCheckPoint: &ethpbalpha.Checkpoint{},
+		}
+		bs := &Server{
+			BeaconDB:           beaconDB,
+			BlockReceiver:      c,
+			ChainInfoFetcher:   c,
+			GenesisTimeFetcher: c,
+			BlockNotifier:      c.BlockNotifier(),
+			Broadcaster:        mockp2p.NewTestP2P(t),
+			StateGenService:    stateGen,
+		}
+		if fetcher != nil {
+			bs.PayloadBodiesFetcher = fetcher
+		}
+		return bs, c
+	}
+	newBlock := func(txs [][]byte) *ethpbalpha.SignedBeaconBlockBellatrix {
+		b := util.NewBeaconBlockBellatrix()
+		b.Block.Slot = 1
+		b.Block.ParentRoot = genesisRoot[:]
+		b.Block.Body.ExecutionPayload.BlockHash = bytesutil.PadTo([]byte("hash"), 32)
+		b.Block.Body.ExecutionPayload.Transactions = txs
+		b.Signature, err = signing.ComputeDomainAndSign(beaconState, 0, b.Block, params.BeaconConfig().DomainBeaconProposer, keys[0])
+		require.NoError(t, err)
+		return b
+	}
+	submitReq := func(b *ethpbalpha.SignedBeaconBlockBellatrix) *ethpbv2.SubmitBlindedBlockRequest {
+		blindedBlk, err := migration.V1Alpha1BeaconBlockBellatrixToV2Blinded(b.Block)
+		require.NoError(t, err)
+		return &ethpbv2.SubmitBlindedBlockRequest{
+			Block: &ethpbv2.SignedBlindedBeaconBlockContainer{
+				Message:   &ethpbv2.SignedBlindedBeaconBlockContainer_BellatrixBlock{BellatrixBlock: blindedBlk},
+				Signature: b.Signature,
+			},
+		}
+	}
+	txs := [][]byte{[]byte("transaction1"), []byte("transaction2")}
+	blockHash := common.BytesToHash(bytesutil.PadTo([]byte("hash"), 32))
+
+	t.Run("OK", func(t *testing.T) {
+		fetcher := &mockengine.EngineClient{
+			PayloadBodiesByHash: map[common.Hash]*enginev1.ExecutionPayloadBodyV1{
+				blockHash: {Transactions: txs},
+			},
+		}
+		bs, c := newServer(fetcher)
+		b := newBlock(txs)
+		_, err := bs.SubmitBlindedBlock(ctx, submitReq(b))
+		require.NoError(t, err)
+		assert.Equal(t, true, bs.Broadcaster.(*mockp2p.TestP2P).BroadcastCalled)
+		assert.Equal(t, 1, fetcher.Calls(engine.GetPayloadBodiesByHashMethod))
+		// The received block is the full block the blinded block commits to.
+		require.Equal(t, 1, len(c.BlocksReceived))
+		received, err := c.BlocksReceived[0].PbBellatrixBlock()
+		require.NoError(t, err)
+		assert.DeepEqual(t, b, received)
+	})
+	t.Run("no transactions", func(t *testing.T) {
+		bs, _ := newServer(nil)
+		_, err := bs.SubmitBlindedBlock(ctx, submitReq(newBlock(nil)))
+		require.NoError(t, err)
+		assert.Equal(t, true, bs.Broadcaster.(*mockp2p.TestP2P).BroadcastCalled)
+	})
+	t.Run("no execution node", func(t *testing.T) {
+		bs, _ := newServer(nil)
+		_, err := bs.SubmitBlindedBlock(ctx, submitReq(newBlock(txs)))
+		assert.ErrorContains(t, "no execution node is configured", err)
+		assert.Equal(t, false, bs.Broadcaster.(*mockp2p.TestP2P).BroadcastCalled)
+	})
+	t.Run("payload not available", func(t *testing.T) {
+		bs, _ := newServer(&mockengine.EngineClient{})
+		_, err := bs.SubmitBlindedBlock(ctx, submitReq(newBlock(txs)))
+		assert.ErrorContains(t, "is not available locally", err)
+		assert.Equal(t, false, bs.Broadcaster.(*mockp2p.TestP2P).BroadcastCalled)
+	})
+	t.Run("payload does not match header", func(t *testing.T) {
+		fetcher := &mockengine.EngineClient{
+			PayloadBodiesByHash: map[common.Hash]*enginev1.ExecutionPayloadBodyV1{
+				blockHash: {Transactions: txs[:1]},
+			},
+		}
+		bs, _ := newServer(fetcher)
+		_, err := bs.SubmitBlindedBlock(ctx, submitReq(newBlock(txs)))
+		assert.ErrorContains(t, "Execution payload does not match header", err)
+		assert.Equal(t, false, bs.Broadcaster.(*mockp2p.TestP2P).BroadcastCalled)
+	})
+	t.Run("no block", func(t *testing.T) {
+		bs, _ := newServer(nil)
+		_, err := bs.SubmitBlindedBlock(ctx, &ethpbv2.SubmitBlindedBlockRequest{})
+		assert.ErrorContains(t, "No block provided", err)
+	})
+}
@@ -7,10 +7,15 @@ import (
 
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
+	grpcutil "github.com/prysmaticlabs/prysm/api/grpc"
+	coreblocks "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/blockfetcher"
+	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
@@ -18,29 +23,14 @@ import (
 	ethpbalpha "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-// blockIdParseError represents an error scenario where a block ID could not be parsed.
-type blockIdParseError struct {
-	message string
-}
-
-// newBlockIdParseError creates a new error instance.
-func newBlockIdParseError(reason error) blockIdParseError {
-	return blockIdParseError{
-		message: errors.Wrapf(reason, "could not parse block ID").Error(),
-	}
-}
-
-// Error returns the underlying error message.
-func (e *blockIdParseError) Error() string {
-	return e.message
-}
-
 // GetBlockHeader retrieves block header for given block id.
 func (bs *Server) GetBlockHeader(ctx context.Context, req *ethpbv1.BlockRequest) (*ethpbv1.BlockHeaderResponse, error) {
 	ctx, span := trace.StartSpan(ctx, "beacon.GetBlockHeader")
@@ -68,6 +58,9 @@ func (bs *Server) GetBlockHeader(ctx context.Context, req *ethpbv1.BlockRequest)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not determine if block root is canonical: %v", err)
 	}
+	if err := bs.setOptimisticHeader(ctx, blk); err != nil {
+		return nil, err
+	}
 
 	return &ethpbv1.BlockHeaderResponse{
 		Data: &ethpbv1.BlockHeaderContainer{
@@ -141,18 +134,20 @@ func (bs *Server) ListBlockHeaders(ctx context.Context, req *ethpbv1.BlockHeader
 }
 
 // SubmitBlock instructs the beacon node to broadcast a newly signed beacon block to the beacon network, to be
-// included in the beacon chain. The beacon node is not required to validate the signed BeaconBlock, and a successful
-// response (20X) only indicates that the broadcast has been successful. The beacon node is expected to integrate the
-// new block into its state, and therefore validate the block internally, however blocks which fail the validation are
-// still broadcast but a different status code is returned (202).
-func (bs *Server) SubmitBlock(ctx context.Context, req *ethpbv2.SignedBeaconBlockContainerV2) (*emptypb.Empty, error) {
+// included in the beacon chain. The block is broadcast only if it passes the requested level of validation, otherwise
+// it is rejected without being broadcast. The beacon node then integrates the broadcast block into its state, and an
+// error is returned if this fails.
+func (bs *Server) SubmitBlock(ctx context.Context, req *ethpbv2.SubmitBlockRequest) (*emptypb.Empty, error) {
 	ctx, span := trace.StartSpan(ctx, "beacon.SubmitBlock")
 	defer span.End()
 
-	phase0BlkContainer, ok := req.Message.(*ethpbv2.SignedBeaconBlockContainerV2_Phase0Block)
+	if req.Block == nil {
+		return nil, status.Error(codes.InvalidArgument, "No block provided")
+	}
+	phase0BlkContainer, ok := req.Block.Message.(*ethpbv2.SignedBeaconBlockContainerV2_Phase0Block)
 	if ok {
 		phase0Blk := phase0BlkContainer.Phase0Block
-		v1alpha1Blk, err := migration.V1ToV1Alpha1SignedBlock(&ethpbv1.SignedBeaconBlock{Block: phase0Blk, Signature: req.Signature})
+		v1alpha1Blk, err := migration.V1ToV1Alpha1SignedBlock(&ethpbv1.SignedBeaconBlock{Block: phase0Blk, Signature: req.Block.Signature})
 		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "Could not convert block to v1 block")
 		}
@@ -163,31 +158,16 @@ func (bs *Server) SubmitBlock(ctx context.Context, req *ethpbv2.SignedBeaconBloc
 			return nil, status.Errorf(codes.InvalidArgument, "Could not tree hash block: %v", err)
 		}
 
-		// Do not block proposal critical path with debug logging or block feed updates.
-		defer func() {
-			log.WithField("blockRoot", fmt.Sprintf("%#x", bytesutil.Trunc(root[:]))).Debugf(
-				"Block proposal received via RPC")
-			bs.BlockNotifier.BlockFeed().Send(&feed.Event{
-				Type: blockfeed.ReceivedBlock,
-				Data: &blockfeed.ReceivedBlockData{SignedBlock: wrappedPhase0Blk},
-			})
-		}()
-
-		// Broadcast the new block to the network.
-		if err := bs.Broadcaster.Broadcast(ctx, wrappedPhase0Blk.Proto()); err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not broadcast block: %v", err)
-		}
-
-		if err := bs.BlockReceiver.ReceiveBlock(ctx, wrappedPhase0Blk, root); err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not process beacon block: %v", err)
+		if err := bs.proposeBlock(ctx, wrappedPhase0Blk, root, req.BroadcastValidation); err != nil {
+			return nil, err
 		}
 	} else {
-		altairBlkContainer, ok := req.Message.(*ethpbv2.SignedBeaconBlockContainerV2_AltairBlock)
+		altairBlkContainer, ok := req.Block.Message.(*ethpbv2.SignedBeaconBlockContainerV2_AltairBlock)
 		if !ok {
 			return nil, status.Errorf(codes.InvalidArgument, "Could not get Altair block from request")
 		}
 		altairBlk := altairBlkContainer.AltairBlock
-		v1alpha1Blk, err := migration.AltairToV1Alpha1SignedBlock(&ethpbv2.SignedBeaconBlockAltair{Message: altairBlk, Signature: req.Signature})
+		v1alpha1Blk, err := migration.AltairToV1Alpha1SignedBlock(&ethpbv2.SignedBeaconBlockAltair{Message: altairBlk, Signature: req.Block.Signature})
 		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "Could not convert block to v1 block")
 		}
@@ -201,29 +181,109 @@ func (bs *Server) SubmitBlock(ctx context.Context, req *ethpbv2.SignedBeaconBloc
 			return nil, status.Errorf(codes.InvalidArgument, "Could not tree hash block: %v", err)
 		}
 
-		// Do not block proposal critical path with debug logging or block feed updates.
-		defer func() {
-			log.WithField("blockRoot", fmt.Sprintf("%#x", bytesutil.Trunc(root[:]))).Debugf(
-				"Block proposal received via RPC")
-			bs.BlockNotifier.BlockFeed().Send(&feed.Event{
-				Type: blockfeed.ReceivedBlock,
-				Data: &blockfeed.ReceivedBlockData{SignedBlock: wrappedAltairBlk},
-			})
-		}()
-
-		// Broadcast the new block to the network.
-		if err := bs.Broadcaster.Broadcast(ctx, wrappedAltairBlk.Proto()); err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not broadcast block: %v", err)
-		}
-
-		if err := bs.BlockReceiver.ReceiveBlock(ctx, wrappedAltairBlk, root); err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not process beacon block: %v", err)
+		if err := bs.proposeBlock(ctx, wrappedAltairBlk, root, req.BroadcastValidation); err != nil {
+			return nil, err
 		}
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
+// proposeBlock validates the block at the requested level, broadcasts it to the network and processes it.
+func (bs *Server) proposeBlock(
+	ctx context.Context,
+	blk block.SignedBeaconBlock,
+	root [32]byte,
+	level ethpbv2.BroadcastValidation,
+) error {
+	if err := bs.validateBroadcast(ctx, blk, root, level); err != nil {
+		return err
+	}
+
+	// Do not block proposal critical path with debug logging or block feed updates.
+	defer func() {
+		log.WithField("blockRoot", fmt.Sprintf("%#x", bytesutil.Trunc(root[:]))).Debugf(
+			"Block proposal received via RPC")
+		bs.BlockNotifier.BlockFeed().Send(&feed.Event{
+			Type: blockfeed.ReceivedBlock,
+			Data: &blockfeed.ReceivedBlockData{SignedBlock: blk},
+		})
+	}()
+
+	// Broadcast the new block to the network.
+	if err := bs.Broadcaster.Broadcast(ctx, blk.Proto()); err != nil {
+		return status.Errorf(codes.Internal, "Could not broadcast block: %v", err)
+	}
+
+	if err := bs.BlockReceiver.ReceiveBlock(ctx, blk, root); err != nil {
+		return status.Errorf(codes.Internal, "Could not process beacon block: %v", err)
+	}
+	return nil
+}
+
+// validateBroadcast performs the requested level of validation on a submitted block, which must pass it to be
+// broadcast. Gossip validation only performs the checks of gossiped blocks, including the proposer signature, consensus
+// validation also runs the state transition of the block, and equivocation validation also rejects the block if
+// another block of the same proposer is known for the slot.
+func (bs *Server) validateBroadcast(
+	ctx context.Context,
+	blk block.SignedBeaconBlock,
+	root [32]byte,
+	level ethpbv2.BroadcastValidation,
+) error {
+	b := blk.Block()
+	genesisTime := uint64(bs.GenesisTimeFetcher.GenesisTime().Unix())
+	if err := slots.VerifyTime(genesisTime, b.Slot(), params.BeaconNetworkConfig().MaximumGossipClockDisparity); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid block slot: %v", err)
+	}
+	finalizedSlot, err := slots.EpochStart(bs.ChainInfoFetcher.FinalizedCheckpt().Epoch)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not get finalized slot: %v", err)
+	}
+	if b.Slot() <= finalizedSlot {
+		return status.Errorf(codes.InvalidArgument, "Block slot %d is not later than the finalized slot %d", b.Slot(), finalizedSlot)
+	}
+	parentRoot := bytesutil.ToBytes32(b.ParentRoot())
+	if !bs.BeaconDB.HasBlock(ctx, parentRoot) {
+		return status.Errorf(codes.InvalidArgument, "Unknown parent block %#x", parentRoot)
+	}
+	parentState, err := bs.StateGenService.StateByRoot(ctx, parentRoot)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not get parent state: %v", err)
+	}
+	if err := coreblocks.VerifyBlockSignatureUsingCurrentFork(parentState, blk); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid proposer signature: %v", err)
+	}
+	if level == ethpbv2.BroadcastValidation_GOSSIP {
+		return nil
+	}
+
+	if _, err := transition.ExecuteStateTransition(ctx, parentState, blk); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Block failed consensus validation: %v", err)
+	}
+	if level == ethpbv2.BroadcastValidation_CONSENSUS {
+		return nil
+	}
+
+	_, slotBlks, err := bs.BeaconDB.BlocksBySlot(ctx, b.Slot())
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not get blocks at slot %d: %v", b.Slot(), err)
+	}
+	for _, other := range slotBlks {
+		if other.Block().ProposerIndex() != b.ProposerIndex() {
+			continue
+		}
+		otherRoot, err := other.Block().HashTreeRoot()
+		if err != nil {
+			return status.Errorf(codes.Internal, "Could not hash block: %v", err)
+		}
+		if otherRoot != root {
+			return status.Errorf(codes.InvalidArgument, "Block equivocates with block %#x of the same proposer", otherRoot)
+		}
+	}
+	return nil
+}
+
 // GetBlock retrieves block details for given block ID.
 func (bs *Server) GetBlock(ctx context.Context, req *ethpbv1.BlockRequest) (*ethpbv1.BlockResponse, error) {
 	ctx, span := trace.StartSpan(ctx, "beacon.GetBlock")
@@ -279,6 +339,9 @@ func (bs *Server) GetBlockV2(ctx context.Context, req *ethpbv2.BlockRequestV2) (
 	if err != nil {
 		return nil, err
 	}
+	if err := bs.setOptimisticHeader(ctx, blk); err != nil {
+		return nil, err
+	}
 	if phase0Blk != nil {
 		v1Blk, err := migration.SignedBeaconBlock(blk)
 		if err != nil {
@@ -501,72 +564,12 @@ func (bs *Server) blocksFromId(ctx context.Context, blockId []byte) (
 }
 
 func (bs *Server) blockFromBlockID(ctx context.Context, blockId []byte) (block.SignedBeaconBlock, error) {
-	var err error
-	var blk block.SignedBeaconBlock
-	switch string(blockId) {
-	case "head":
-		blk, err = bs.ChainInfoFetcher.HeadBlock(ctx)
-		if err != nil {
-			return nil, errors.Wrap(err, "could not retrieve head block")
-		}
-	case "finalized":
-		finalized := bs.ChainInfoFetcher.FinalizedCheckpt()
-		finalizedRoot := bytesutil.ToBytes32(finalized.Root)
-		blk, err = bs.BeaconDB.Block(ctx, finalizedRoot)
-		if err != nil {
-			return nil, errors.New("could not get finalized block from db")
-		}
-	case "genesis":
-		blk, err = bs.BeaconDB.GenesisBlock(ctx)
-		if err != nil {
-			return nil, errors.Wrap(err, "could not retrieve blocks for genesis slot")
-		}
-	default:
-		if len(blockId) == 32 {
-			blk, err = bs.BeaconDB.Block(ctx, bytesutil.ToBytes32(blockId))
-			if err != nil {
-				return nil, errors.Wrap(err, "could not retrieve block")
-			}
-		} else {
-			slot, err := strconv.ParseUint(string(blockId), 10, 64)
-			if err != nil {
-				e := newBlockIdParseError(err)
-				return nil, &e
-			}
-			_, blks, err := bs.BeaconDB.BlocksBySlot(ctx, types.Slot(slot))
-			if err != nil {
-				return nil, errors.Wrapf(err, "could not retrieve blocks for slot %d", slot)
-			}
-			_, roots, err := bs.BeaconDB.BlockRootsBySlot(ctx, types.Slot(slot))
-			if err != nil {
-				return nil, errors.Wrapf(err, "could not retrieve block roots for slot %d", slot)
-			}
-
-			numBlks := len(blks)
-			if numBlks == 0 {
-				return nil, nil
-			}
-			blk = blks[0]
-			if numBlks == 1 {
-				break
-			}
-			for i, b := range blks {
-				canonical, err := bs.ChainInfoFetcher.IsCanonical(ctx, roots[i])
-				if err != nil {
-					return nil, status.Errorf(codes.Internal, "Could not determine if block root is canonical: %v", err)
-				}
-				if canonical {
-					blk = b
-					break
-				}
-			}
-		}
-	}
-	return blk, nil
+	fetcher := &blockfetcher.BlockProvider{BeaconDB: bs.BeaconDB, ChainInfoFetcher: bs.ChainInfoFetcher}
+	return fetcher.Block(ctx, blockId)
 }
 
 func handleGetBlockError(blk block.SignedBeaconBlock, err error) error {
-	if invalidBlockIdErr, ok := err.(*blockIdParseError); ok {
+	if invalidBlockIdErr, ok := err.(*blockfetcher.BlockIdParseError); ok {
 		return status.Errorf(codes.InvalidArgument, "Invalid block ID: %v", invalidBlockIdErr)
 	}
 	if err != nil {
@@ -577,3 +580,26 @@ func handleGetBlockError(blk block.SignedBeaconBlock, err error) error {
 	}
 	return nil
 }
+
+// setOptimisticHeader flags the response as served from an optimistically imported block, whose
+// execution payload was not yet validated by the execution node.
+func (bs *Server) setOptimisticHeader(ctx context.Context, blk block.SignedBeaconBlock) error {
+	if blk.Version() < version.Bellatrix {
+		return nil
+	}
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not hash block: %v", err)
+	}
+	optimistic, err := bs.ChainInfoFetcher.IsOptimisticForRoot(ctx, root, blk.Block().Slot())
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not check if block is optimistic: %v", err)
+	}
+	if !optimistic {
+		return nil
+	}
+	if err := grpcutil.AppendExecutionOptimisticHeader(ctx); err != nil {
+		return status.Errorf(codes.Internal, "Could not set execution optimistic header: %v", err)
+	}
+	return nil
+}
@@ -7,9 +7,13 @@ import (
 
 	types "github.com/prysmaticlabs/eth2-types"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	mockstategen "github.com/prysmaticlabs/prysm/beacon-chain/state/stategen/mock"
+	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
@@ -341,30 +345,36 @@ func TestServer_ProposeBlock_OK(t *testing.T) {
 		require.NoError(t, beaconDB.SaveBlock(context.Background(), wrapper.WrappedPhase0SignedBeaconBlock(genesis)), "Could not save genesis block")
 
 		numDeposits := uint64(64)
-		beaconState, _ := util.DeterministicGenesisState(t, numDeposits)
-		bsRoot, err := beaconState.HashTreeRoot(ctx)
-		require.NoError(t, err)
+		beaconState, keys := util.DeterministicGenesisState(t, numDeposits)
 		genesisRoot, err := genesis.Block.HashTreeRoot()
 		require.NoError(t, err)
 		require.NoError(t, beaconDB.SaveState(ctx, beaconState, genesisRoot), "Could not save genesis state")
+		stateGen := mockstategen.NewMockService()
+		stateGen.AddStateForRoot(beaconState, genesisRoot)
 
-		c := &mock.ChainService{Root: bsRoot[:], State: beaconState}
+		c := &mock.ChainService{Root: genesisRoot[:], State: beaconState, FinalizedCheckPoint: &ethpbalpha.Checkpoint{}}
 		beaconChainServer := &Server{
-			BeaconDB:         beaconDB,
-			BlockReceiver:    c,
-			ChainInfoFetcher: c,
-			BlockNotifier:    c.BlockNotifier(),
-			Broadcaster:      mockp2p.NewTestP2P(t),
+			BeaconDB:           beaconDB,
+			BlockReceiver:      c,
+			ChainInfoFetcher:   c,
+			GenesisTimeFetcher: c,
+			BlockNotifier:      c.BlockNotifier(),
+			Broadcaster:        mockp2p.NewTestP2P(t),
+			StateGenService:    stateGen,
 		}
 		req := util.NewBeaconBlock()
 		req.Block.Slot = 5
-		req.Block.ParentRoot = bsRoot[:]
+		req.Block.ParentRoot = genesisRoot[:]
+		req.Signature, err = signing.ComputeDomainAndSign(beaconState, 0, req.Block, params.BeaconConfig().DomainBeaconProposer, keys[0])
+		require.NoError(t, err)
 		v1Block, err := migration.V1Alpha1ToV1SignedBlock(req)
 		require.NoError(t, err)
 		require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(req)))
-		blockReq := &ethpbv2.SignedBeaconBlockContainerV2{
-			Message:   &ethpbv2.SignedBeaconBlockContainerV2_Phase0Block{Phase0Block: v1Block.Block},
-			Signature: v1Block.Signature,
+		blockReq := &ethpbv2.SubmitBlockRequest{
+			Block: &ethpbv2.SignedBeaconBlockContainerV2{
+				Message:   &ethpbv2.SignedBeaconBlockContainerV2_Phase0Block{Phase0Block: v1Block.Block},
+				Signature: v1Block.Signature,
+			},
 		}
 		_, err = beaconChainServer.SubmitBlock(context.Background(), blockReq)
 		assert.NoError(t, err, "Could not propose block correctly")
@@ -380,38 +390,130 @@ func TestServer_ProposeBlock_OK(t *testing.T) {
 		require.NoError(t, beaconDB.SaveBlock(context.Background(), wrapped), "Could not save genesis block")
 
 		numDeposits := uint64(64)
-		beaconState, _ := util.DeterministicGenesisState(t, numDeposits)
-		bsRoot, err := beaconState.HashTreeRoot(ctx)
-		require.NoError(t, err)
+		beaconState, keys := util.DeterministicGenesisState(t, numDeposits)
 		genesisRoot, err := genesis.Block.HashTreeRoot()
 		require.NoError(t, err)
 		require.NoError(t, beaconDB.SaveState(ctx, beaconState, genesisRoot), "Could not save genesis state")
+		stateGen := mockstategen.NewMockService()
+		stateGen.AddStateForRoot(beaconState, genesisRoot)
 
-		c := &mock.ChainService{Root: bsRoot[:], State: beaconState}
+		c := &mock.ChainService{Root: genesisRoot[:], State: beaconState, FinalizedCheckPoint: &ethpbalpha.Checkpoint{}}
 		beaconChainServer := &Server{
-			BeaconDB:         beaconDB,
-			BlockReceiver:    c,
-			ChainInfoFetcher: c,
-			BlockNotifier:    c.BlockNotifier(),
-			Broadcaster:      mockp2p.NewTestP2P(t),
+			BeaconDB:           beaconDB,
+			BlockReceiver:      c,
+			ChainInfoFetcher:   c,
+			GenesisTimeFetcher: c,
+			BlockNotifier:      c.BlockNotifier(),
+			Broadcaster:        mockp2p.NewTestP2P(t),
+			StateGenService:    stateGen,
 		}
 		req := util.NewBeaconBlockAltair()
 		req.Block.Slot = 5
-		req.Block.ParentRoot = bsRoot[:]
+		req.Block.ParentRoot = genesisRoot[:]
+		req.Signature, err = signing.ComputeDomainAndSign(beaconState, 0, req.Block, params.BeaconConfig().DomainBeaconProposer, keys[0])
+		require.NoError(t, err)
 		v2Block, err := migration.V1Alpha1BeaconBlockAltairToV2(req.Block)
 		require.NoError(t, err)
 		wrapped, err = wrapper.WrappedAltairSignedBeaconBlock(req)
 		require.NoError(t, err)
 		require.NoError(t, beaconDB.SaveBlock(ctx, wrapped))
-		blockReq := &ethpbv2.SignedBeaconBlockContainerV2{
-			Message:   &ethpbv2.SignedBeaconBlockContainerV2_AltairBlock{AltairBlock: v2Block},
-			Signature: req.Signature,
+		blockReq := &ethpbv2.SubmitBlockRequest{
+			Block: &ethpbv2.SignedBeaconBlockContainerV2{
+				Message:   &ethpbv2.SignedBeaconBlockContainerV2_AltairBlock{AltairBlock: v2Block},
+				Signature: req.Signature,
+			},
 		}
 		_, err = beaconChainServer.SubmitBlock(context.Background(), blockReq)
 		assert.NoError(t, err, "Could not propose block correctly")
 	})
 }
 
+func TestServer_SubmitBlock_BroadcastValidation(t *testing.T) {
+	beaconDB := dbTest.SetupDB(t)
+	ctx := context.Background()
+
+	beaconState, keys := util.DeterministicGenesisState(t, 64)
+	stateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(stateRoot[:])
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(genesis)))
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	stateGen := mockstategen.NewMockService()
+	newServer := func(finalizedEpoch types.Epoch) *Server {
+		stateGen.AddStateForRoot(beaconState.Copy(), genesisRoot)
+		c := &mock.ChainService{
+			Root:                genesisRoot[:],
+			State:               beaconState.Copy(),
+			FinalizedCheckPoint: &ethpbalpha.Checkpoint{Epoch: finalizedEpoch},
+		}
+		return &Server{
+			BeaconDB:           beaconDB,
+			BlockReceiver:      c,
+			ChainInfoFetcher:   c,
+			GenesisTimeFetcher: c,
+			BlockNotifier:      c.BlockNotifier(),
+			Broadcaster:        mockp2p.NewTestP2P(t),
+			StateGenService:    stateGen,
+		}
+	}
+	blk, err := util.GenerateFullBlock(beaconState.Copy(), keys, util.DefaultBlockGenConfig(), 1)
+	require.NoError(t, err)
+	submitReq := func(b *ethpbalpha.SignedBeaconBlock, level ethpbv2.BroadcastValidation) *ethpbv2.SubmitBlockRequest {
+		v1Block, err := migration.V1Alpha1ToV1SignedBlock(b)
+		require.NoError(t, err)
+		return &ethpbv2.SubmitBlockRequest{
+			Block: &ethpbv2.SignedBeaconBlockContainerV2{
+				Message:   &ethpbv2.SignedBeaconBlockContainerV2_Phase0Block{Phase0Block: v1Block.Block},
+				Signature: v1Block.Signature,
+			},
+			BroadcastValidation: level,
+		}
+	}
+
+	t.Run("unknown parent", func(t *testing.T) {
+		b := ethpbalpha.CopySignedBeaconBlock(blk)
+		b.Block.ParentRoot = bytesutil.PadTo([]byte{'a'}, 32)
+		_, err := newServer(0).SubmitBlock(ctx, submitReq(b, ethpbv2.BroadcastValidation_GOSSIP))
+		assert.ErrorContains(t, "Unknown parent block", err)
+	})
+	t.Run("finalized slot", func(t *testing.T) {
+		_, err := newServer(1).SubmitBlock(ctx, submitReq(blk, ethpbv2.BroadcastValidation_GOSSIP))
+		assert.ErrorContains(t, "is not later than the finalized slot", err)
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		b := ethpbalpha.CopySignedBeaconBlock(blk)
+		b.Block.Body.Graffiti = bytesutil.PadTo([]byte{'b'}, 32)
+		_, err := newServer(0).SubmitBlock(ctx, submitReq(b, ethpbv2.BroadcastValidation_GOSSIP))
+		assert.ErrorContains(t, "Invalid proposer signature", err)
+	})
+	t.Run("invalid consensus", func(t *testing.T) {
+		b := ethpbalpha.CopySignedBeaconBlock(blk)
+		b.Block.StateRoot = bytesutil.PadTo([]byte{'a'}, 32)
+		b.Signature, err = signing.ComputeDomainAndSign(beaconState, 0, b.Block, params.BeaconConfig().DomainBeaconProposer, keys[b.Block.ProposerIndex])
+		require.NoError(t, err)
+		// The block passes gossip validation, which does not run the state transition.
+		_, err := newServer(0).SubmitBlock(ctx, submitReq(b, ethpbv2.BroadcastValidation_GOSSIP))
+		require.NoError(t, err)
+		_, err = newServer(0).SubmitBlock(ctx, submitReq(b, ethpbv2.BroadcastValidation_CONSENSUS))
+		assert.ErrorContains(t, "Block failed consensus validation", err)
+	})
+	t.Run("consensus", func(t *testing.T) {
+		_, err := newServer(0).SubmitBlock(ctx, submitReq(blk, ethpbv2.BroadcastValidation_CONSENSUS))
+		require.NoError(t, err)
+	})
+	t.Run("equivocation", func(t *testing.T) {
+		other := ethpbalpha.CopySignedBeaconBlock(blk)
+		other.Block.Body.Graffiti = bytesutil.PadTo([]byte{'a'}, 32)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(other)))
+
+		_, err := newServer(0).SubmitBlock(ctx, submitReq(blk, ethpbv2.BroadcastValidation_CONSENSUS))
+		require.NoError(t, err)
+		_, err = newServer(0).SubmitBlock(ctx, submitReq(blk, ethpbv2.BroadcastValidation_CONSENSUS_AND_EQUIVOCATION))
+		assert.ErrorContains(t, "Block equivocates with block", err)
+	})
+}
+
 func TestServer_GetBlock(t *testing.T) {
 	beaconDB := dbTest.SetupDB(t)
 	ctx := context.Background()
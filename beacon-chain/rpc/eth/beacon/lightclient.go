@@ -0,0 +1,140 @@
+package beacon
+
+import (
+	"context"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/proto/migration"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// maxRequestLightClientUpdates is the maximum number of light client updates returned by a single request.
+const maxRequestLightClientUpdates = 128
+
+// GetLightClientBootstrap retrieves the light client bootstrap data for the given block root, containing the
+// current sync committee of the block's post state along with its Merkle proof.
+func (bs *Server) GetLightClientBootstrap(ctx context.Context, req *ethpbv2.LightClientBootstrapRequest) (*ethpbv2.LightClientBootstrapResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon.GetLightClientBootstrap")
+	defer span.End()
+
+	if len(req.BlockRoot) != fieldparams.RootLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Block root must be %d bytes long", fieldparams.RootLength)
+	}
+	root := bytesutil.ToBytes32(req.BlockRoot)
+	blk, err := bs.BeaconDB.Block(ctx, root)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get block: %v", err)
+	}
+	if err := helpers.BeaconBlockIsNil(blk); err != nil {
+		return nil, status.Errorf(codes.NotFound, "Could not find block: %v", err)
+	}
+	if blk.Version() == version.Phase0 {
+		return nil, status.Error(codes.NotFound, "Light client bootstrap is not available for phase 0 blocks")
+	}
+	st, err := bs.StateGenService.StateByRoot(ctx, root)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Could not get state of block: %v", err)
+	}
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get current sync committee: %v", err)
+	}
+	branch, err := st.CurrentSyncCommitteeProof(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get current sync committee proof: %v", err)
+	}
+	header, err := migration.BlockIfaceToV1BlockHeader(blk)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get block header: %v", err)
+	}
+
+	return &ethpbv2.LightClientBootstrapResponse{
+		Version: lightClientVersion(header.Message.Slot),
+		Data: &ethpbv2.LightClientBootstrap{
+			Header: header.Message,
+			CurrentSyncCommittee: &ethpbv2.SyncCommittee{
+				Pubkeys:         committee.Pubkeys,
+				AggregatePubkey: committee.AggregatePubkey,
+			},
+			CurrentSyncCommitteeBranch: branch,
+		},
+	}, nil
+}
+
+// GetLightClientUpdatesByRange retrieves the best light client updates of consecutive sync committee periods,
+// starting at the requested period. At most 128 updates are returned, and fewer if an update is missing.
+func (bs *Server) GetLightClientUpdatesByRange(ctx context.Context, req *ethpbv2.LightClientUpdatesByRangeRequest) (*ethpbv2.LightClientUpdatesByRangeResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon.GetLightClientUpdatesByRange")
+	defer span.End()
+
+	if req.Count == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Count must be greater than 0")
+	}
+	count := req.Count
+	if count > maxRequestLightClientUpdates {
+		count = maxRequestLightClientUpdates
+	}
+	updates, err := bs.BeaconDB.LightClientUpdates(ctx, req.StartPeriod, req.StartPeriod+count-1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get light client updates: %v", err)
+	}
+
+	resp := &ethpbv2.LightClientUpdatesByRangeResponse{
+		Updates: make([]*ethpbv2.LightClientUpdateWithVersion, len(updates)),
+	}
+	for i, u := range updates {
+		resp.Updates[i] = &ethpbv2.LightClientUpdateWithVersion{
+			Version: lightClientVersion(u.AttestedHeader.Slot),
+			Data:    u,
+		}
+	}
+	return resp, nil
+}
+
+// GetLightClientFinalityUpdate retrieves the latest light client finality update produced by the node.
+func (bs *Server) GetLightClientFinalityUpdate(ctx context.Context, _ *emptypb.Empty) (*ethpbv2.LightClientFinalityUpdateResponse, error) {
+	_, span := trace.StartSpan(ctx, "beacon.GetLightClientFinalityUpdate")
+	defer span.End()
+
+	update := bs.LightClientFetcher.LightClientFinalityUpdate()
+	if update == nil {
+		return nil, status.Error(codes.NotFound, "No light client finality update is available")
+	}
+	return &ethpbv2.LightClientFinalityUpdateResponse{
+		Version: lightClientVersion(update.AttestedHeader.Slot),
+		Data:    update,
+	}, nil
+}
+
+// GetLightClientOptimisticUpdate retrieves the latest light client optimistic update produced by the node.
+func (bs *Server) GetLightClientOptimisticUpdate(ctx context.Context, _ *emptypb.Empty) (*ethpbv2.LightClientOptimisticUpdateResponse, error) {
+	_, span := trace.StartSpan(ctx, "beacon.GetLightClientOptimisticUpdate")
+	defer span.End()
+
+	update := bs.LightClientFetcher.LightClientOptimisticUpdate()
+	if update == nil {
+		return nil, status.Error(codes.NotFound, "No light client optimistic update is available")
+	}
+	return &ethpbv2.LightClientOptimisticUpdateResponse{
+		Version: lightClientVersion(update.AttestedHeader.Slot),
+		Data:    update,
+	}, nil
+}
+
+// lightClientVersion returns the fork version of light client data attested at the given slot.
+func lightClientVersion(slot types.Slot) ethpbv2.Version {
+	if slots.ToEpoch(slot) >= params.BeaconConfig().BellatrixForkEpoch {
+		return ethpbv2.Version_BELLATRIX
+	}
+	return ethpbv2.Version_ALTAIR
+}
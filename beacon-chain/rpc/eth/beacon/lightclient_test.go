@@ -0,0 +1,130 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestGetLightClientBootstrap(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	bs := &Server{
+		BeaconDB:        beaconDB,
+		StateGenService: stategen.New(beaconDB),
+	}
+
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, st.SetSlot(10))
+	b := util.NewBeaconBlockAltair()
+	b.Block.Slot = 10
+	wsb, err := wrapper.WrappedAltairSignedBeaconBlock(b)
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wsb))
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, st, root))
+
+	t.Run("OK", func(t *testing.T) {
+		resp, err := bs.GetLightClientBootstrap(ctx, &ethpbv2.LightClientBootstrapRequest{BlockRoot: root[:]})
+		require.NoError(t, err)
+		assert.Equal(t, ethpbv2.Version_ALTAIR, resp.Version)
+		assert.Equal(t, types.Slot(10), resp.Data.Header.Slot)
+		committee, err := st.CurrentSyncCommittee()
+		require.NoError(t, err)
+		assert.DeepEqual(t, committee.Pubkeys, resp.Data.CurrentSyncCommittee.Pubkeys)
+		branch, err := st.CurrentSyncCommitteeProof(ctx)
+		require.NoError(t, err)
+		assert.DeepEqual(t, branch, resp.Data.CurrentSyncCommitteeBranch)
+	})
+	t.Run("invalid root", func(t *testing.T) {
+		_, err := bs.GetLightClientBootstrap(ctx, &ethpbv2.LightClientBootstrapRequest{BlockRoot: []byte("foo")})
+		assert.ErrorContains(t, "Block root must be 32 bytes long", err)
+	})
+	t.Run("unknown block", func(t *testing.T) {
+		_, err := bs.GetLightClientBootstrap(ctx, &ethpbv2.LightClientBootstrapRequest{BlockRoot: make([]byte, 32)})
+		assert.ErrorContains(t, "Could not find block", err)
+	})
+	t.Run("phase 0 block", func(t *testing.T) {
+		b := util.NewBeaconBlock()
+		require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(b)))
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		_, err = bs.GetLightClientBootstrap(ctx, &ethpbv2.LightClientBootstrapRequest{BlockRoot: root[:]})
+		assert.ErrorContains(t, "Light client bootstrap is not available for phase 0 blocks", err)
+	})
+}
+
+func TestGetLightClientUpdatesByRange(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	bs := &Server{BeaconDB: beaconDB}
+
+	for period := uint64(0); period < 3; period++ {
+		require.NoError(t, beaconDB.SaveLightClientUpdate(ctx, period, &ethpbv2.LightClientUpdate{
+			AttestedHeader: &ethpbv1.BeaconBlockHeader{Slot: types.Slot(period * 8192)},
+		}))
+	}
+
+	resp, err := bs.GetLightClientUpdatesByRange(ctx, &ethpbv2.LightClientUpdatesByRangeRequest{StartPeriod: 1, Count: 5})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(resp.Updates))
+	assert.Equal(t, types.Slot(8192), resp.Updates[0].Data.AttestedHeader.Slot)
+	assert.Equal(t, types.Slot(16384), resp.Updates[1].Data.AttestedHeader.Slot)
+	assert.Equal(t, ethpbv2.Version_ALTAIR, resp.Updates[0].Version)
+
+	resp, err = bs.GetLightClientUpdatesByRange(ctx, &ethpbv2.LightClientUpdatesByRangeRequest{StartPeriod: 3, Count: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(resp.Updates))
+
+	_, err = bs.GetLightClientUpdatesByRange(ctx, &ethpbv2.LightClientUpdatesByRangeRequest{StartPeriod: 0, Count: 0})
+	assert.ErrorContains(t, "Count must be greater than 0", err)
+}
+
+func TestGetLightClientFinalityUpdate(t *testing.T) {
+	ctx := context.Background()
+	chain := &mock.ChainService{}
+	bs := &Server{LightClientFetcher: chain}
+
+	_, err := bs.GetLightClientFinalityUpdate(ctx, &emptypb.Empty{})
+	assert.ErrorContains(t, "No light client finality update is available", err)
+
+	chain.FinalityUpdate = &ethpbv2.LightClientFinalityUpdate{
+		AttestedHeader:  &ethpbv1.BeaconBlockHeader{Slot: 100},
+		FinalizedHeader: &ethpbv1.BeaconBlockHeader{Slot: 32},
+		SignatureSlot:   101,
+	}
+	resp, err := bs.GetLightClientFinalityUpdate(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, ethpbv2.Version_ALTAIR, resp.Version)
+	assert.DeepEqual(t, chain.FinalityUpdate, resp.Data)
+}
+
+func TestGetLightClientOptimisticUpdate(t *testing.T) {
+	ctx := context.Background()
+	chain := &mock.ChainService{}
+	bs := &Server{LightClientFetcher: chain}
+
+	_, err := bs.GetLightClientOptimisticUpdate(ctx, &emptypb.Empty{})
+	assert.ErrorContains(t, "No light client optimistic update is available", err)
+
+	chain.OptimisticUpdate = &ethpbv2.LightClientOptimisticUpdate{
+		AttestedHeader: &ethpbv1.BeaconBlockHeader{Slot: 100},
+		SignatureSlot:  101,
+	}
+	resp, err := bs.GetLightClientOptimisticUpdate(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, ethpbv2.Version_ALTAIR, resp.Version)
+	assert.DeepEqual(t, chain.OptimisticUpdate, resp.Data)
+}
@@ -0,0 +1,221 @@
+package beacon
+
+import (
+	"context"
+	"sort"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	coreblocks "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/validators"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetBlockRewards retrieves the rewards received by the proposer of the requested block, broken down by the
+// block's operations. The rewards are computed by applying the block's operations to its parent state.
+func (bs *Server) GetBlockRewards(ctx context.Context, req *ethpbv2.BlockRewardsRequest) (*ethpbv2.BlockRewardsResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon.GetBlockRewards")
+	defer span.End()
+
+	blk, err := bs.blockFromBlockID(ctx, req.BlockId)
+	err = handleGetBlockError(blk, err)
+	if err != nil {
+		return nil, err
+	}
+	if blk.Version() == version.Phase0 {
+		return nil, status.Error(codes.InvalidArgument, "Block rewards are not supported for phase 0 blocks")
+	}
+	if err := bs.setOptimisticHeader(ctx, blk); err != nil {
+		return nil, err
+	}
+
+	b := blk.Block()
+	st, err := bs.StateGenService.StateByRoot(ctx, bytesutil.ToBytes32(b.ParentRoot()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get parent state: %v", err)
+	}
+	st, err = transition.ProcessSlots(ctx, st, b.Slot())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process slots: %v", err)
+	}
+
+	proposerIndex := b.ProposerIndex()
+	initBalance, err := st.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get proposer balance: %v", err)
+	}
+	st, err = coreblocks.ProcessProposerSlashings(ctx, st, b.Body().ProposerSlashings(), validators.SlashValidator)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process proposer slashings: %v", err)
+	}
+	proposerSlashingsBalance, err := st.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get proposer balance: %v", err)
+	}
+	st, err = coreblocks.ProcessAttesterSlashings(ctx, st, b.Body().AttesterSlashings(), validators.SlashValidator)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process attester slashings: %v", err)
+	}
+	attesterSlashingsBalance, err := st.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get proposer balance: %v", err)
+	}
+	st, err = altair.ProcessAttestationsNoVerifySignature(ctx, st, blk)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process attestations: %v", err)
+	}
+	attestationsBalance, err := st.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get proposer balance: %v", err)
+	}
+
+	// The proposer may also be part of the sync committee, so its sync reward is computed rather than
+	// read from its balance.
+	syncAggregate, err := b.Body().SyncAggregate()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get sync aggregate: %v", err)
+	}
+	_, votedIndices, _, err := altair.FilterSyncCommitteeVotes(st, syncAggregate)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get sync committee votes: %v", err)
+	}
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get total active balance: %v", err)
+	}
+	proposerReward, _, err := altair.SyncRewards(activeBalance)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get sync rewards: %v", err)
+	}
+
+	rewards := &ethpbv2.BlockRewards{
+		ProposerIndex:     proposerIndex,
+		ProposerSlashings: balanceIncrease(initBalance, proposerSlashingsBalance),
+		AttesterSlashings: balanceIncrease(proposerSlashingsBalance, attesterSlashingsBalance),
+		Attestations:      balanceIncrease(attesterSlashingsBalance, attestationsBalance),
+		SyncAggregate:     proposerReward * uint64(len(votedIndices)),
+	}
+	rewards.Total = rewards.ProposerSlashings + rewards.AttesterSlashings + rewards.Attestations + rewards.SyncAggregate
+	return &ethpbv2.BlockRewardsResponse{Data: rewards}, nil
+}
+
+// balanceIncrease returns the amount by which the balance increased, or zero when it decreased, as the proposer is
+// penalized when it is slashed in its own block.
+func balanceIncrease(before, after uint64) uint64 {
+	if after < before {
+		return 0
+	}
+	return after - before
+}
+
+// GetAttestationRewards retrieves the attestation rewards of the requested validators for the given epoch, broken
+// down by voting duty, along with the rewards of a validator attesting perfectly for each of their effective
+// balances. The rewards of an epoch are applied at the end of the following epoch, so the requested epoch must be
+// at least two epochs old.
+func (bs *Server) GetAttestationRewards(ctx context.Context, req *ethpbv2.AttestationRewardsRequest) (*ethpbv2.AttestationRewardsResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon.GetAttestationRewards")
+	defer span.End()
+
+	if req.Epoch < params.BeaconConfig().AltairForkEpoch {
+		return nil, status.Error(codes.InvalidArgument, "Attestation rewards are not supported for phase 0 epochs")
+	}
+	currentEpoch := slots.ToEpoch(bs.GenesisTimeFetcher.CurrentSlot())
+	if req.Epoch+1 >= currentEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Attestation rewards are not available yet for epoch %d", req.Epoch)
+	}
+
+	// The rewards of the requested epoch are computed from the state just before the following epoch transition.
+	endSlot, err := slots.EpochEnd(req.Epoch + 1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get end slot of epoch: %v", err)
+	}
+	st, err := bs.StateGenService.StateBySlot(ctx, endSlot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get state: %v", err)
+	}
+	vals, bal, err := altair.InitializePrecomputeValidators(ctx, st)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not initialize precompute validators: %v", err)
+	}
+	vals, bal, err = altair.ProcessEpochParticipation(ctx, st, bal, vals)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process epoch participation: %v", err)
+	}
+	st, err = precompute.ProcessJustificationAndFinalizationPreCompute(st, bal)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process justification: %v", err)
+	}
+	st, vals, err = altair.ProcessInactivityScores(ctx, st, vals)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process inactivity scores: %v", err)
+	}
+
+	indices := req.Index
+	if len(indices) == 0 {
+		indices = make([]types.ValidatorIndex, len(vals))
+		for i := range indices {
+			indices[i] = types.ValidatorIndex(i)
+		}
+	}
+	requested := make([]*precompute.Validator, len(indices))
+	effectiveBalances := make(map[uint64]bool)
+	for i, index := range indices {
+		if uint64(index) >= uint64(len(vals)) {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid validator index %d", index)
+		}
+		requested[i] = vals[index]
+		effectiveBalances[vals[index].CurrentEpochEffectiveBalance] = true
+	}
+	ideal := make([]*precompute.Validator, 0, len(effectiveBalances))
+	for b := range effectiveBalances {
+		ideal = append(ideal, &precompute.Validator{
+			IsActivePrevEpoch:            true,
+			CurrentEpochEffectiveBalance: b,
+			IsPrevEpochSourceAttester:    true,
+			IsPrevEpochTargetAttester:    true,
+			IsPrevEpochHeadAttester:      true,
+		})
+	}
+	sort.Slice(ideal, func(i, j int) bool {
+		return ideal[i].CurrentEpochEffectiveBalance < ideal[j].CurrentEpochEffectiveBalance
+	})
+
+	details, err := altair.AttestationsDeltaDetails(st, bal, append(requested, ideal...))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute attestation rewards: %v", err)
+	}
+	rewards := &ethpbv2.AttestationRewards{
+		IdealRewards: make([]*ethpbv2.IdealAttestationReward, len(ideal)),
+		TotalRewards: make([]*ethpbv2.TotalAttestationReward, len(requested)),
+	}
+	for i, index := range indices {
+		d := details[i]
+		rewards.TotalRewards[i] = &ethpbv2.TotalAttestationReward{
+			ValidatorIndex: index,
+			Head:           int64(d.HeadReward),
+			Target:         int64(d.TargetReward) - int64(d.TargetPenalty),
+			Source:         int64(d.SourceReward) - int64(d.SourcePenalty),
+			Inactivity:     -int64(d.InactivityPenalty),
+		}
+	}
+	for i, v := range ideal {
+		d := details[len(requested)+i]
+		rewards.IdealRewards[i] = &ethpbv2.IdealAttestationReward{
+			EffectiveBalance: v.CurrentEpochEffectiveBalance,
+			Head:             int64(d.HeadReward),
+			Target:           int64(d.TargetReward),
+			Source:           int64(d.SourceReward),
+		}
+	}
+	return &ethpbv2.AttestationRewardsResponse{Data: rewards}, nil
+}
@@ -0,0 +1,179 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	mockstategen "github.com/prysmaticlabs/prysm/beacon-chain/state/stategen/mock"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestGetBlockRewards(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	stateGen := mockstategen.NewMockService()
+	bs := &Server{
+		BeaconDB:        beaconDB,
+		StateGenService: stateGen,
+	}
+
+	st, keys := util.DeterministicGenesisStateAltair(t, 64)
+	c, err := altair.NextSyncCommittee(ctx, st)
+	require.NoError(t, err)
+	require.NoError(t, st.SetCurrentSyncCommittee(c))
+	b, err := util.GenerateFullBlockAltair(st, keys, &util.BlockGenConfig{NumAttestations: 1}, 1)
+	require.NoError(t, err)
+	// Slash validators other than the proposer, with the proposer as whistleblower.
+	var slashed []types.ValidatorIndex
+	for i := types.ValidatorIndex(0); len(slashed) < 2; i++ {
+		if i != b.Block.ProposerIndex {
+			slashed = append(slashed, i)
+		}
+	}
+	proposerSlashing, err := util.GenerateProposerSlashingForValidator(st, keys[slashed[0]], slashed[0])
+	require.NoError(t, err)
+	attesterSlashing, err := util.GenerateAttesterSlashingForValidator(st, keys[slashed[1]], slashed[1])
+	require.NoError(t, err)
+	b.Block.Body.ProposerSlashings = []*ethpb.ProposerSlashing{proposerSlashing}
+	b.Block.Body.AttesterSlashings = []*ethpb.AttesterSlashing{attesterSlashing}
+	wsb, err := wrapper.WrappedAltairSignedBeaconBlock(b)
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wsb))
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	stateGen.AddStateForRoot(st, bytesutil.ToBytes32(b.Block.ParentRoot))
+
+	t.Run("OK", func(t *testing.T) {
+		resp, err := bs.GetBlockRewards(ctx, &ethpbv2.BlockRewardsRequest{BlockId: root[:]})
+		require.NoError(t, err)
+		whistleblowerReward := params.BeaconConfig().MaxEffectiveBalance / params.BeaconConfig().WhistleBlowerRewardQuotient
+		activeBalance, err := helpers.TotalActiveBalance(st)
+		require.NoError(t, err)
+		proposerReward, _, err := altair.SyncRewards(activeBalance)
+		require.NoError(t, err)
+		syncReward := proposerReward * bitfield.Bitvector512(b.Block.Body.SyncAggregate.SyncCommitteeBits).Count()
+
+		assert.Equal(t, b.Block.ProposerIndex, resp.Data.ProposerIndex)
+		assert.Equal(t, whistleblowerReward, resp.Data.ProposerSlashings)
+		assert.Equal(t, whistleblowerReward, resp.Data.AttesterSlashings)
+		assert.Equal(t, true, resp.Data.Attestations > 0)
+		assert.Equal(t, syncReward, resp.Data.SyncAggregate)
+		assert.Equal(t, resp.Data.ProposerSlashings+resp.Data.AttesterSlashings+resp.Data.Attestations+resp.Data.SyncAggregate, resp.Data.Total)
+	})
+	t.Run("proposer slashed in its own block", func(t *testing.T) {
+		st, keys := util.DeterministicGenesisStateAltair(t, 64)
+		c, err := altair.NextSyncCommittee(ctx, st)
+		require.NoError(t, err)
+		require.NoError(t, st.SetCurrentSyncCommittee(c))
+		b, err := util.GenerateFullBlockAltair(st, keys, util.DefaultBlockGenConfig(), 1)
+		require.NoError(t, err)
+		proposerSlashing, err := util.GenerateProposerSlashingForValidator(st, keys[b.Block.ProposerIndex], b.Block.ProposerIndex)
+		require.NoError(t, err)
+		b.Block.Body.ProposerSlashings = []*ethpb.ProposerSlashing{proposerSlashing}
+		b.Block.Slot = 3
+		wsb, err := wrapper.WrappedAltairSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wsb))
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		stateGen.AddStateForRoot(st, bytesutil.ToBytes32(b.Block.ParentRoot))
+
+		resp, err := bs.GetBlockRewards(ctx, &ethpbv2.BlockRewardsRequest{BlockId: root[:]})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), resp.Data.ProposerSlashings)
+		assert.Equal(t, resp.Data.ProposerSlashings+resp.Data.AttesterSlashings+resp.Data.Attestations+resp.Data.SyncAggregate, resp.Data.Total)
+	})
+	t.Run("phase 0 block", func(t *testing.T) {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = 2
+		require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(b)))
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		_, err = bs.GetBlockRewards(ctx, &ethpbv2.BlockRewardsRequest{BlockId: root[:]})
+		assert.ErrorContains(t, "Block rewards are not supported for phase 0 blocks", err)
+	})
+	t.Run("unknown block", func(t *testing.T) {
+		_, err := bs.GetBlockRewards(ctx, &ethpbv2.BlockRewardsRequest{BlockId: make([]byte, 32)})
+		assert.ErrorContains(t, "Could not find requested block", err)
+	})
+}
+
+func TestGetAttestationRewards(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	stateGen := mockstategen.NewMockService()
+	currentSlot := params.BeaconConfig().SlotsPerEpoch * 3
+	bs := &Server{
+		StateGenService:    stateGen,
+		GenesisTimeFetcher: &mock.ChainService{Slot: &currentSlot},
+	}
+
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	endSlot := params.BeaconConfig().SlotsPerEpoch*2 - 1
+	require.NoError(t, st.SetSlot(endSlot))
+	// Validator 0 attested perfectly in epoch 0, validator 1 did not attest.
+	participation := make([]byte, st.NumValidators())
+	participation[0] = 0b111
+	require.NoError(t, st.SetPreviousParticipationBits(participation))
+	stateGen.AddStateForSlot(st, endSlot)
+
+	t.Run("OK", func(t *testing.T) {
+		resp, err := bs.GetAttestationRewards(ctx, &ethpbv2.AttestationRewardsRequest{Epoch: 0, Index: []types.ValidatorIndex{0, 1}})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(resp.Data.TotalRewards))
+		perfect, absent := resp.Data.TotalRewards[0], resp.Data.TotalRewards[1]
+		assert.Equal(t, types.ValidatorIndex(0), perfect.ValidatorIndex)
+		assert.Equal(t, true, perfect.Head > 0)
+		assert.Equal(t, true, perfect.Target > 0)
+		assert.Equal(t, true, perfect.Source > 0)
+		assert.Equal(t, int64(0), perfect.Inactivity)
+		assert.Equal(t, types.ValidatorIndex(1), absent.ValidatorIndex)
+		assert.Equal(t, int64(0), absent.Head)
+		assert.Equal(t, true, absent.Target < 0)
+		assert.Equal(t, true, absent.Source < 0)
+
+		require.Equal(t, 1, len(resp.Data.IdealRewards))
+		ideal := resp.Data.IdealRewards[0]
+		assert.Equal(t, params.BeaconConfig().MaxEffectiveBalance, ideal.EffectiveBalance)
+		assert.Equal(t, perfect.Head, ideal.Head)
+		assert.Equal(t, perfect.Target, ideal.Target)
+		assert.Equal(t, perfect.Source, ideal.Source)
+	})
+	t.Run("all validators", func(t *testing.T) {
+		resp, err := bs.GetAttestationRewards(ctx, &ethpbv2.AttestationRewardsRequest{Epoch: 0})
+		require.NoError(t, err)
+		assert.Equal(t, st.NumValidators(), len(resp.Data.TotalRewards))
+	})
+	t.Run("invalid index", func(t *testing.T) {
+		_, err := bs.GetAttestationRewards(ctx, &ethpbv2.AttestationRewardsRequest{Epoch: 0, Index: []types.ValidatorIndex{64}})
+		assert.ErrorContains(t, "Invalid validator index 64", err)
+	})
+	t.Run("epoch not finished", func(t *testing.T) {
+		_, err := bs.GetAttestationRewards(ctx, &ethpbv2.AttestationRewardsRequest{Epoch: 2})
+		assert.ErrorContains(t, "Attestation rewards are not available yet for epoch 2", err)
+	})
+	t.Run("phase 0 epoch", func(t *testing.T) {
+		cfg := params.BeaconConfig()
+		cfg.AltairForkEpoch = 1
+		params.OverrideBeaconConfig(cfg)
+		_, err := bs.GetAttestationRewards(ctx, &ethpbv2.AttestationRewardsRequest{Epoch: 0})
+		assert.ErrorContains(t, "Attestation rewards are not supported for phase 0 epochs", err)
+	})
+}
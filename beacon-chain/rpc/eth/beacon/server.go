@@ -33,5 +33,7 @@ type Server struct {
 	StateGenService         stategen.StateManager
 	StateFetcher            statefetcher.Fetcher
 	HeadFetcher             blockchain.HeadFetcher
+	LightClientFetcher      blockchain.LightClientFetcher
+	PayloadBodiesFetcher    db.PayloadBodiesFetcher
 	V1Alpha1ValidatorServer *v1alpha1validator.Server
 }
@@ -2,14 +2,18 @@ package beacon
 
 import (
 	"context"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/api/pagination"
 	corehelpers "github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/eth/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	"github.com/prysmaticlabs/prysm/cmd"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1"
@@ -49,7 +53,7 @@ func (bs *Server) GetValidator(ctx context.Context, req *ethpb.StateValidatorReq
 	if len(req.ValidatorId) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "Validator ID is required")
 	}
-	valContainer, err := valContainersByRequestIds(st, [][]byte{req.ValidatorId})
+	valContainer, err := bs.valContainersByRequestIds(ctx, st, [][]byte{req.ValidatorId})
 	if err != nil {
 		return nil, handleValContainerErr(err)
 	}
@@ -59,26 +63,25 @@ func (bs *Server) GetValidator(ctx context.Context, req *ethpb.StateValidatorReq
 	return &ethpb.StateValidatorResponse{Data: valContainer[0]}, nil
 }
 
-// ListValidators returns filterable list of validators with their balance, status and index.
+// ListValidators returns filterable list of validators with their balance, status and index. Validators can be
+// filtered by ID, status and index range. When a page size is requested, the next_page_token of the response
+// continues the listing where the page ended.
 func (bs *Server) ListValidators(ctx context.Context, req *ethpb.StateValidatorsRequest) (*ethpb.StateValidatorsResponse, error) {
 	ctx, span := trace.StartSpan(ctx, "beacon.ListValidators")
 	defer span.End()
 
-	st, err := bs.StateFetcher.State(ctx, req.StateId)
-	if err != nil {
-		return nil, helpers.PrepareStateFetchGRPCError(err)
+	if int(req.PageSize) > cmd.Get().MaxRPCPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "Requested page size %d can not be greater than max size %d",
+			req.PageSize, cmd.Get().MaxRPCPageSize)
 	}
-
-	valContainers, err := valContainersByRequestIds(st, req.Id)
+	cursor, err := pagination.DecodeCursor(req.PageToken)
 	if err != nil {
-		return nil, handleValContainerErr(err)
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid page token: %v", err)
 	}
-
-	// Exit early if no matching validators we found or we don't want to further filter validators by status.
-	if len(valContainers) == 0 || len(req.Status) == 0 {
-		return &ethpb.StateValidatorsResponse{Data: valContainers}, nil
+	ranges, err := parseIndexRanges(req.IndexRange)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid index ranges: %v", err)
 	}
-
 	filterStatus := make(map[ethpb.ValidatorStatus]bool, len(req.Status))
 	const lastValidStatusValue = ethpb.ValidatorStatus(12)
 	for _, ss := range req.Status {
@@ -87,26 +90,98 @@ func (bs *Server) ListValidators(ctx context.Context, req *ethpb.StateValidators
 		}
 		filterStatus[ss] = true
 	}
+
+	st, err := bs.StateFetcher.State(ctx, req.StateId)
+	if err != nil {
+		return nil, helpers.PrepareStateFetchGRPCError(err)
+	}
+
 	epoch := slots.ToEpoch(st.Slot())
-	filteredVals := make([]*ethpb.ValidatorContainer, 0, len(valContainers))
-	for _, vc := range valContainers {
-		readOnlyVal, err := v1.NewValidator(migration.V1ValidatorToV1Alpha1(vc.Validator))
+	valContainers := make([]*ethpb.ValidatorContainer, 0)
+	var nextPageToken string
+	// visit adds the validator to the page if it matches the status filter. It reports whether the page is already
+	// full, in which case the next page starts from the passed in cursor position.
+	visit := func(idx types.ValidatorIndex, position uint64) (bool, error) {
+		readOnlyVal, err := st.ValidatorAtIndexReadOnly(idx)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not convert validator: %v", err)
+			return false, status.Errorf(codes.Internal, "Could not get validator: %v", err)
 		}
-		valStatus, err := helpers.ValidatorStatus(readOnlyVal, epoch)
+		valSubStatus, err := helpers.ValidatorSubStatus(readOnlyVal, epoch)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not get validator status: %v", err)
+			return false, status.Errorf(codes.Internal, "Could not get validator sub status: %v", err)
 		}
-		valSubStatus, err := helpers.ValidatorSubStatus(readOnlyVal, epoch)
+		if len(filterStatus) > 0 {
+			valStatus, err := helpers.ValidatorStatus(readOnlyVal, epoch)
+			if err != nil {
+				return false, status.Errorf(codes.Internal, "Could not get validator status: %v", err)
+			}
+			if !filterStatus[valStatus] && !filterStatus[valSubStatus] {
+				return false, nil
+			}
+		}
+		if req.PageSize > 0 && uint64(len(valContainers)) == req.PageSize {
+			nextPageToken = pagination.EncodeCursor(position)
+			return true, nil
+		}
+		vc, err := valContainerAtIndex(st, idx, valSubStatus)
+		if err != nil {
+			return false, handleValContainerErr(err)
+		}
+		valContainers = append(valContainers, vc)
+		return false, nil
+	}
+
+	if len(req.Id) > 0 {
+		// Validators requested by ID are listed in the order of the request, and the cursor is the position
+		// in the list of requested validators.
+		indices, err := validatorIndicesByRequestIds(st, req.Id)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not get validator sub status: %v", err)
+			return nil, handleValContainerErr(err)
+		}
+		for i := cursor; i < uint64(len(indices)); i++ {
+			if len(ranges) > 0 && !indexInRanges(indices[i], ranges) {
+				continue
+			}
+			full, err := visit(indices[i], i)
+			if err != nil {
+				return nil, err
+			}
+			if full {
+				break
+			}
+		}
+		return &ethpb.StateValidatorsResponse{Data: valContainers, NextPageToken: nextPageToken}, nil
+	}
+
+	// Otherwise, validators are listed in the order of their indexes, and the cursor is the index of the validator
+	// the listing continues from.
+	numVals := types.ValidatorIndex(st.NumValidators())
+	if numVals == 0 {
+		return &ethpb.StateValidatorsResponse{Data: valContainers}, nil
+	}
+	spans := []indexRange{{start: 0, end: numVals - 1}}
+	if len(ranges) > 0 {
+		spans = mergeIndexRanges(ranges)
+	}
+	for _, r := range spans {
+		if r.start < types.ValidatorIndex(cursor) {
+			r.start = types.ValidatorIndex(cursor)
+		}
+		if r.end >= numVals {
+			r.end = numVals - 1
+		}
+		full := false
+		for idx := r.start; idx <= r.end && !full; idx++ {
+			full, err = visit(idx, uint64(idx))
+			if err != nil {
+				return nil, err
+			}
 		}
-		if filterStatus[valStatus] || filterStatus[valSubStatus] {
-			filteredVals = append(filteredVals, vc)
+		if full {
+			break
 		}
 	}
-	return &ethpb.StateValidatorsResponse{Data: filteredVals}, nil
+	return &ethpb.StateValidatorsResponse{Data: valContainers, NextPageToken: nextPageToken}, nil
 }
 
 // ListValidatorBalances returns a filterable list of validator balances.
@@ -119,7 +194,7 @@ func (bs *Server) ListValidatorBalances(ctx context.Context, req *ethpb.Validato
 		return nil, helpers.PrepareStateFetchGRPCError(err)
 	}
 
-	valContainers, err := valContainersByRequestIds(st, req.Id)
+	valContainers, err := bs.valContainersByRequestIds(ctx, st, req.Id)
 	if err != nil {
 		return nil, handleValContainerErr(err)
 	}
@@ -188,75 +263,145 @@ func (bs *Server) ListCommittees(ctx context.Context, req *ethpb.StateCommittees
 
 // This function returns the validator object based on the passed in ID. The validator ID could be its public key,
 // or its index.
-func valContainersByRequestIds(state state.BeaconState, validatorIds [][]byte) ([]*ethpb.ValidatorContainer, error) {
-	epoch := slots.ToEpoch(state.Slot())
-	var valContainers []*ethpb.ValidatorContainer
-	allBalances := state.Balances()
+func (bs *Server) valContainersByRequestIds(ctx context.Context, state state.BeaconState, validatorIds [][]byte) ([]*ethpb.ValidatorContainer, error) {
+	var indices []types.ValidatorIndex
 	if len(validatorIds) == 0 {
-		allValidators := state.Validators()
-		valContainers = make([]*ethpb.ValidatorContainer, len(allValidators))
-		for i, validator := range allValidators {
-			readOnlyVal, err := v1.NewValidator(validator)
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "Could not convert validator: %v", err)
-			}
-			subStatus, err := helpers.ValidatorSubStatus(readOnlyVal, epoch)
-			if err != nil {
-				return nil, errors.Wrap(err, "could not get validator sub status")
-			}
-			valContainers[i] = &ethpb.ValidatorContainer{
-				Index:     types.ValidatorIndex(i),
-				Balance:   allBalances[i],
-				Status:    subStatus,
-				Validator: migration.V1Alpha1ValidatorToV1(validator),
-			}
+		indices = make([]types.ValidatorIndex, state.NumValidators())
+		for i := range indices {
+			indices[i] = types.ValidatorIndex(i)
 		}
 	} else {
-		valContainers = make([]*ethpb.ValidatorContainer, 0, len(validatorIds))
-		for _, validatorId := range validatorIds {
-			var valIndex types.ValidatorIndex
-			if len(validatorId) == params.BeaconConfig().BLSPubkeyLength {
-				var ok bool
-				valIndex, ok = state.ValidatorIndexByPubkey(bytesutil.ToBytes48(validatorId))
-				if !ok {
-					// Ignore well-formed yet unknown public keys.
-					continue
-				}
-			} else {
-				index, err := strconv.ParseUint(string(validatorId), 10, 64)
-				if err != nil {
-					e := newInvalidValidatorIdError(validatorId, err)
-					return nil, &e
-				}
-				valIndex = types.ValidatorIndex(index)
-			}
-			validator, err := state.ValidatorAtIndex(valIndex)
-			if _, ok := err.(*v1.ValidatorIndexOutOfRangeError); ok {
-				// Ignore well-formed yet unknown indexes.
+		var err error
+		indices, err = validatorIndicesByRequestIds(state, validatorIds)
+		if err != nil {
+			return nil, err
+		}
+	}
+	epoch := slots.ToEpoch(state.Slot())
+	valContainers := make([]*ethpb.ValidatorContainer, len(indices))
+	for i, valIndex := range indices {
+		readOnlyVal, err := state.ValidatorAtIndexReadOnly(valIndex)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get validator")
+		}
+		subStatus, err := helpers.ValidatorSubStatus(readOnlyVal, epoch)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get validator sub status")
+		}
+		valContainers[i], err = valContainerAtIndex(state, valIndex, subStatus)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return valContainers, nil
+}
+
+// validatorIndicesByRequestIds resolves the passed in validator IDs, which could be public keys or indexes, to the
+// indexes of validators in the state. Well-formed IDs of validators which are not in the state are ignored.
+func validatorIndicesByRequestIds(state state.BeaconState, validatorIds [][]byte) ([]types.ValidatorIndex, error) {
+	valIndices := make([]types.ValidatorIndex, 0, len(validatorIds))
+	for _, validatorId := range validatorIds {
+		var valIndex types.ValidatorIndex
+		if len(validatorId) == params.BeaconConfig().BLSPubkeyLength {
+			var ok bool
+			valIndex, ok = state.ValidatorIndexByPubkey(bytesutil.ToBytes48(validatorId))
+			if !ok {
+				// Ignore well-formed yet unknown public keys.
 				continue
 			}
+		} else {
+			index, err := strconv.ParseUint(string(validatorId), 10, 64)
 			if err != nil {
-				return nil, errors.Wrap(err, "could not get validator")
-			}
-			v1Validator := migration.V1Alpha1ValidatorToV1(validator)
-			readOnlyVal, err := v1.NewValidator(validator)
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "Could not convert validator: %v", err)
-			}
-			subStatus, err := helpers.ValidatorSubStatus(readOnlyVal, epoch)
-			if err != nil {
-				return nil, errors.Wrap(err, "could not get validator sub status")
+				e := newInvalidValidatorIdError(validatorId, err)
+				return nil, &e
 			}
-			valContainers = append(valContainers, &ethpb.ValidatorContainer{
-				Index:     valIndex,
-				Balance:   allBalances[valIndex],
-				Status:    subStatus,
-				Validator: v1Validator,
-			})
+			valIndex = types.ValidatorIndex(index)
+		}
+		if uint64(valIndex) >= uint64(state.NumValidators()) {
+			// Ignore well-formed yet unknown indexes.
+			continue
 		}
+		valIndices = append(valIndices, valIndex)
 	}
+	return valIndices, nil
+}
 
-	return valContainers, nil
+func valContainerAtIndex(state state.BeaconState, valIndex types.ValidatorIndex, subStatus ethpb.ValidatorStatus) (*ethpb.ValidatorContainer, error) {
+	validator, err := state.ValidatorAtIndex(valIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validator")
+	}
+	balance, err := state.BalanceAtIndex(valIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validator balance")
+	}
+	return &ethpb.ValidatorContainer{
+		Index:     valIndex,
+		Balance:   balance,
+		Status:    subStatus,
+		Validator: migration.V1Alpha1ValidatorToV1(validator),
+	}, nil
+}
+
+// indexRange is a range of validator indexes with both ends inclusive.
+type indexRange struct {
+	start types.ValidatorIndex
+	end   types.ValidatorIndex
+}
+
+// parseIndexRanges parses index ranges in the "<start>-<end>" format.
+func parseIndexRanges(ranges []string) ([]indexRange, error) {
+	parsed := make([]indexRange, 0, len(ranges))
+	for _, r := range ranges {
+		parts := strings.Split(r, "-")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("could not parse index range %q", r)
+		}
+		start, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("could not parse index range %q", r)
+		}
+		end, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil || end < start {
+			return nil, errors.Errorf("could not parse index range %q", r)
+		}
+		parsed = append(parsed, indexRange{start: types.ValidatorIndex(start), end: types.ValidatorIndex(end)})
+	}
+	return parsed, nil
+}
+
+func indexInRanges(idx types.ValidatorIndex, ranges []indexRange) bool {
+	for _, r := range ranges {
+		if idx >= r.start && idx <= r.end {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeIndexRanges sorts the ranges and merges the overlapping and adjacent ones, so that every index is visited once
+// and in order when walking the ranges.
+func mergeIndexRanges(ranges []indexRange) []indexRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+	sorted := make([]indexRange, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].start < sorted[j].start
+	})
+	merged := []indexRange{sorted[0]}
+	for _, r := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if r.start <= last.end || r.start == last.end+1 {
+			if r.end > last.end {
+				last.end = r.end
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
 }
 
 func handleValContainerErr(err error) error {
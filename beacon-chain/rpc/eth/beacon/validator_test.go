@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/testutil"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	"github.com/prysmaticlabs/prysm/cmd"
 	"github.com/prysmaticlabs/prysm/config/params"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	"github.com/prysmaticlabs/prysm/proto/migration"
@@ -434,6 +435,101 @@ func TestListValidators_Status(t *testing.T) {
 		}
 	})
 }
+func TestListValidators_Pagination(t *testing.T) {
+	ctx := context.Background()
+
+	var st state.BeaconState
+	st, _ = util.DeterministicGenesisState(t, 64)
+	s := Server{
+		StateFetcher: &testutil.MockFetcher{
+			BeaconState: st,
+		},
+	}
+	indices := func(resp *ethpb.StateValidatorsResponse) []types.ValidatorIndex {
+		res := make([]types.ValidatorIndex, len(resp.Data))
+		for i, val := range resp.Data {
+			res[i] = val.Index
+		}
+		return res
+	}
+
+	t.Run("All validators", func(t *testing.T) {
+		var listed []types.ValidatorIndex
+		req := &ethpb.StateValidatorsRequest{StateId: []byte("head"), PageSize: 30}
+		for pages := 0; ; pages++ {
+			require.Equal(t, true, pages < 3)
+			resp, err := s.ListValidators(ctx, req)
+			require.NoError(t, err)
+			listed = append(listed, indices(resp)...)
+			if resp.NextPageToken == "" {
+				assert.Equal(t, 4, len(resp.Data))
+				break
+			}
+			assert.Equal(t, 30, len(resp.Data))
+			req.PageToken = resp.NextPageToken
+		}
+		require.Equal(t, 64, len(listed))
+		for i, idx := range listed {
+			assert.Equal(t, types.ValidatorIndex(i), idx)
+		}
+	})
+
+	t.Run("Index ranges", func(t *testing.T) {
+		resp, err := s.ListValidators(ctx, &ethpb.StateValidatorsRequest{
+			StateId:    []byte("head"),
+			IndexRange: []string{"40-41", "10-12", "11-15", "60-1000"},
+		})
+		require.NoError(t, err)
+		assert.DeepEqual(t, []types.ValidatorIndex{10, 11, 12, 13, 14, 15, 40, 41, 60, 61, 62, 63}, indices(resp))
+		assert.Equal(t, "", resp.NextPageToken)
+
+		resp, err = s.ListValidators(ctx, &ethpb.StateValidatorsRequest{
+			StateId:    []byte("head"),
+			IndexRange: []string{"40-41", "10-12"},
+			PageSize:   4,
+		})
+		require.NoError(t, err)
+		assert.DeepEqual(t, []types.ValidatorIndex{10, 11, 12, 40}, indices(resp))
+		resp, err = s.ListValidators(ctx, &ethpb.StateValidatorsRequest{
+			StateId:    []byte("head"),
+			IndexRange: []string{"40-41", "10-12"},
+			PageSize:   4,
+			PageToken:  resp.NextPageToken,
+		})
+		require.NoError(t, err)
+		assert.DeepEqual(t, []types.ValidatorIndex{41}, indices(resp))
+		assert.Equal(t, "", resp.NextPageToken)
+	})
+
+	t.Run("Validators by ID", func(t *testing.T) {
+		req := &ethpb.StateValidatorsRequest{
+			StateId:    []byte("head"),
+			Id:         [][]byte{[]byte("20"), []byte("5"), []byte("30"), []byte("7")},
+			IndexRange: []string{"0-25"},
+			PageSize:   2,
+		}
+		resp, err := s.ListValidators(ctx, req)
+		require.NoError(t, err)
+		assert.DeepEqual(t, []types.ValidatorIndex{20, 5}, indices(resp))
+		req.PageToken = resp.NextPageToken
+		resp, err = s.ListValidators(ctx, req)
+		require.NoError(t, err)
+		assert.DeepEqual(t, []types.ValidatorIndex{7}, indices(resp))
+		assert.Equal(t, "", resp.NextPageToken)
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		_, err := s.ListValidators(ctx, &ethpb.StateValidatorsRequest{StateId: []byte("head"), PageToken: "foo"})
+		assert.ErrorContains(t, "Invalid page token", err)
+		_, err = s.ListValidators(ctx, &ethpb.StateValidatorsRequest{StateId: []byte("head"), IndexRange: []string{"5-3"}})
+		assert.ErrorContains(t, "could not parse index range \"5-3\"", err)
+		_, err = s.ListValidators(ctx, &ethpb.StateValidatorsRequest{StateId: []byte("head"), IndexRange: []string{"5"}})
+		assert.ErrorContains(t, "could not parse index range \"5\"", err)
+		_, err = s.ListValidators(ctx, &ethpb.StateValidatorsRequest{StateId: []byte("head"), PageSize: uint64(cmd.Get().MaxRPCPageSize + 1)})
+		assert.ErrorContains(t, "can not be greater than max size", err)
+	})
+}
+
 func TestListValidatorBalances(t *testing.T) {
 	ctx := context.Background()
 
@@ -133,3 +133,16 @@ func (ds *Server) ListForkChoiceHeads(ctx context.Context, _ *emptypb.Empty) (*e
 
 	return resp, nil
 }
+
+// GetForkChoice returns a dump of the fork choice store: every node with its weight and optimistic
+// status, along with the current head and the justified and finalized checkpoints.
+func (ds *Server) GetForkChoice(ctx context.Context, _ *emptypb.Empty) (*ethpbv1.ForkChoiceResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "debug.GetForkChoice")
+	defer span.End()
+
+	dump, err := ds.HeadFetcher.ForkChoiceDump(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not dump fork choice store: %v", err)
+	}
+	return &ethpbv1.ForkChoiceResponse{Data: dump}, nil
+}
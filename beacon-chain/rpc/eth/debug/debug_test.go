@@ -10,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	ethpbalpha "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
@@ -154,3 +155,30 @@ func TestListForkChoiceHeads(t *testing.T) {
 		assert.Equal(t, true, found, "Expected head not found")
 	}
 }
+
+func TestGetForkChoice(t *testing.T) {
+	ctx := context.Background()
+
+	headRoot := bytesutil.PadTo([]byte("head"), 32)
+	nodes := []*ethpbv1.ForkChoiceNode{
+		{Slot: 0, Root: bytesutil.PadTo([]byte("foo"), 32), ParentRoot: make([]byte, 32), Weight: 10},
+		{Slot: 1, Root: headRoot, ParentRoot: bytesutil.PadTo([]byte("foo"), 32), Weight: 5, Optimistic: true},
+	}
+	server := &Server{
+		HeadFetcher: &blockchainmock.ChainService{
+			Root:                       headRoot,
+			CurrentJustifiedCheckPoint: &ethpbalpha.Checkpoint{Epoch: 2, Root: bytesutil.PadTo([]byte("justified"), 32)},
+			FinalizedCheckPoint:        &ethpbalpha.Checkpoint{Epoch: 1, Root: bytesutil.PadTo([]byte("finalized"), 32)},
+			ForkChoiceNodes:            nodes,
+		},
+	}
+	resp, err := server.GetForkChoice(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, headRoot, resp.Data.HeadRoot)
+	assert.Equal(t, types.Epoch(2), resp.Data.JustifiedCheckpoint.Epoch)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte("justified"), 32), resp.Data.JustifiedCheckpoint.Root)
+	assert.Equal(t, types.Epoch(1), resp.Data.FinalizedCheckpoint.Epoch)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte("finalized"), 32), resp.Data.FinalizedCheckpoint.Root)
+	require.Equal(t, 2, len(resp.Data.Nodes))
+	assert.DeepEqual(t, nodes[1], resp.Data.Nodes[1])
+}
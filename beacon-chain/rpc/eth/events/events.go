@@ -33,6 +33,18 @@ const (
 	ChainReorgTopic = "chain_reorg"
 	// SyncCommitteeContributionTopic represents a new sync committee contribution event topic.
 	SyncCommitteeContributionTopic = "contribution_and_proof"
+	// PayloadAttributesTopic represents a new payload attributes event topic, sent on every head update
+	// and when the execution node starts building the payload of an upcoming proposal.
+	PayloadAttributesTopic = "payload_attributes"
+	// ExecutionConnectionTopic represents an event topic for the beacon node losing or regaining
+	// connectivity to its execution node.
+	ExecutionConnectionTopic = "execution_connection"
+	// TerminalBlockTopic represents an event topic for the terminal proof-of-work block being found,
+	// or changing after an execution chain reorganization.
+	TerminalBlockTopic = "terminal_block"
+	// TransitionConfigurationTopic represents an event topic for the merge transition configuration
+	// of the execution node starting or stopping to match the configuration of the beacon node.
+	TransitionConfigurationTopic = "transition_configuration"
 )
 
 var casesHandled = map[string]bool{
@@ -43,6 +55,10 @@ var casesHandled = map[string]bool{
 	FinalizedCheckpointTopic:       true,
 	ChainReorgTopic:                true,
 	SyncCommitteeContributionTopic: true,
+	PayloadAttributesTopic:         true,
+	ExecutionConnectionTopic:       true,
+	TerminalBlockTopic:             true,
+	TransitionConfigurationTopic:   true,
 }
 
 // StreamEvents allows requesting all events from a set of topics defined in the Ethereum consensus API standard.
@@ -213,6 +229,63 @@ func handleStateEvents(
 			return nil
 		}
 		return streamData(stream, ChainReorgTopic, reorg)
+	case statefeed.PayloadAttributes:
+		if _, ok := requestedTopics[PayloadAttributesTopic]; !ok {
+			return nil
+		}
+		attributes, ok := event.Data.(*ethpb.EventPayloadAttributes)
+		if !ok {
+			return nil
+		}
+		return streamData(stream, PayloadAttributesTopic, attributes)
+	case statefeed.ExecutionConnectionChanged:
+		if _, ok := requestedTopics[ExecutionConnectionTopic]; !ok {
+			return nil
+		}
+		connection, ok := event.Data.(*statefeed.ExecutionConnectionChangedData)
+		if !ok {
+			return nil
+		}
+		eventConnection := &ethpb.EventExecutionConnection{
+			Endpoint:  connection.Endpoint,
+			Connected: connection.Connected,
+		}
+		if connection.Error != nil {
+			eventConnection.Error = connection.Error.Error()
+		}
+		return streamData(stream, ExecutionConnectionTopic, eventConnection)
+	case statefeed.TerminalBlockReached:
+		if _, ok := requestedTopics[TerminalBlockTopic]; !ok {
+			return nil
+		}
+		terminal, ok := event.Data.(*statefeed.TerminalBlockReachedData)
+		if !ok {
+			return nil
+		}
+		eventTerminal := &ethpb.EventTerminalBlock{
+			Hash:   terminal.Hash[:],
+			Number: terminal.Number,
+		}
+		if terminal.TotalDifficulty != nil {
+			eventTerminal.TotalDifficulty = terminal.TotalDifficulty.String()
+		}
+		return streamData(stream, TerminalBlockTopic, eventTerminal)
+	case statefeed.TransitionConfigurationChanged:
+		if _, ok := requestedTopics[TransitionConfigurationTopic]; !ok {
+			return nil
+		}
+		configuration, ok := event.Data.(*statefeed.TransitionConfigurationChangedData)
+		if !ok {
+			return nil
+		}
+		eventConfiguration := &ethpb.EventTransitionConfiguration{
+			Endpoint: configuration.Endpoint,
+			Matching: configuration.Matching,
+		}
+		if configuration.Error != nil {
+			eventConfiguration.Error = configuration.Error.Error()
+		}
+		return streamData(stream, TransitionConfigurationTopic, eventConfiguration)
 	default:
 		return nil
 	}
@@ -2,6 +2,8 @@ package events
 
 import (
 	"context"
+	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -13,6 +15,7 @@ import (
 	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	"github.com/prysmaticlabs/prysm/proto/migration"
 	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -329,6 +332,148 @@ func TestStreamEvents_StateEvents(t *testing.T) {
 			feed: srv.StateNotifier.StateFeed(),
 		})
 	})
+	t.Run(PayloadAttributesTopic, func(t *testing.T) {
+		ctx := context.Background()
+		srv, ctrl, mockStream := setupServer(ctx, t)
+		defer ctrl.Finish()
+
+		wantedAttributes := &ethpb.EventPayloadAttributes{
+			Version: "bellatrix",
+			Data: &ethpb.EventPayloadAttributes_Data{
+				ProposerIndex:     1,
+				ProposalSlot:      8,
+				ParentBlockNumber: 2,
+				ParentBlockRoot:   make([]byte, 32),
+				ParentBlockHash:   make([]byte, 32),
+				PayloadAttributes: &ethpb.EventPayloadAttributes_PayloadAttributes{
+					Timestamp:             3,
+					PrevRandao:            make([]byte, 32),
+					SuggestedFeeRecipient: make([]byte, 20),
+				},
+			},
+		}
+		genericResponse, err := anypb.New(wantedAttributes)
+		require.NoError(t, err)
+		wantedMessage := &gateway.EventSource{
+			Event: PayloadAttributesTopic,
+			Data:  genericResponse,
+		}
+
+		assertFeedSendAndReceive(ctx, &assertFeedArgs{
+			t:             t,
+			srv:           srv,
+			topics:        []string{PayloadAttributesTopic},
+			stream:        mockStream,
+			shouldReceive: wantedMessage,
+			itemToSend: &feed.Event{
+				Type: statefeed.PayloadAttributes,
+				Data: wantedAttributes,
+			},
+			feed: srv.StateNotifier.StateFeed(),
+		})
+	})
+	t.Run(ExecutionConnectionTopic, func(t *testing.T) {
+		ctx := context.Background()
+		srv, ctrl, mockStream := setupServer(ctx, t)
+		defer ctrl.Finish()
+
+		genericResponse, err := anypb.New(&ethpb.EventExecutionConnection{
+			Endpoint:  "http://localhost:8551",
+			Connected: false,
+			Error:     "connection refused",
+		})
+		require.NoError(t, err)
+		wantedMessage := &gateway.EventSource{
+			Event: ExecutionConnectionTopic,
+			Data:  genericResponse,
+		}
+
+		assertFeedSendAndReceive(ctx, &assertFeedArgs{
+			t:             t,
+			srv:           srv,
+			topics:        []string{ExecutionConnectionTopic},
+			stream:        mockStream,
+			shouldReceive: wantedMessage,
+			itemToSend: &feed.Event{
+				Type: statefeed.ExecutionConnectionChanged,
+				Data: &statefeed.ExecutionConnectionChangedData{
+					Endpoint:  "http://localhost:8551",
+					Connected: false,
+					Error:     errors.New("connection refused"),
+				},
+			},
+			feed: srv.StateNotifier.StateFeed(),
+		})
+	})
+	t.Run(TerminalBlockTopic, func(t *testing.T) {
+		ctx := context.Background()
+		srv, ctrl, mockStream := setupServer(ctx, t)
+		defer ctrl.Finish()
+
+		hash := bytesutil.ToBytes32([]byte("terminal"))
+		genericResponse, err := anypb.New(&ethpb.EventTerminalBlock{
+			Hash:            hash[:],
+			Number:          100,
+			TotalDifficulty: "58750000000000000000000",
+		})
+		require.NoError(t, err)
+		wantedMessage := &gateway.EventSource{
+			Event: TerminalBlockTopic,
+			Data:  genericResponse,
+		}
+		ttd, ok := new(big.Int).SetString("58750000000000000000000", 10)
+		require.Equal(t, true, ok)
+
+		assertFeedSendAndReceive(ctx, &assertFeedArgs{
+			t:             t,
+			srv:           srv,
+			topics:        []string{TerminalBlockTopic},
+			stream:        mockStream,
+			shouldReceive: wantedMessage,
+			itemToSend: &feed.Event{
+				Type: statefeed.TerminalBlockReached,
+				Data: &statefeed.TerminalBlockReachedData{
+					Hash:            hash,
+					Number:          100,
+					TotalDifficulty: ttd,
+				},
+			},
+			feed: srv.StateNotifier.StateFeed(),
+		})
+	})
+	t.Run(TransitionConfigurationTopic, func(t *testing.T) {
+		ctx := context.Background()
+		srv, ctrl, mockStream := setupServer(ctx, t)
+		defer ctrl.Finish()
+
+		genericResponse, err := anypb.New(&ethpb.EventTransitionConfiguration{
+			Endpoint: "http://localhost:8551",
+			Matching: false,
+			Error:    "terminal total difficulty mismatch",
+		})
+		require.NoError(t, err)
+		wantedMessage := &gateway.EventSource{
+			Event: TransitionConfigurationTopic,
+			Data:  genericResponse,
+		}
+
+		assertFeedSendAndReceive(ctx, &assertFeedArgs{
+			t:             t,
+			srv:           srv,
+			topics:        []string{TransitionConfigurationTopic},
+			stream:        mockStream,
+			shouldReceive: wantedMessage,
+			itemToSend: &feed.Event{
+				Type: statefeed.TransitionConfigurationChanged,
+				Data: &statefeed.TransitionConfigurationChangedData{
+					Endpoint: "http://localhost:8551",
+					Matching: false,
+					Error:    errors.New("terminal total difficulty mismatch"),
+				},
+			},
+			feed: srv.StateNotifier.StateFeed(),
+		})
+	})
 }
 
 func TestStreamEvents_CommaSeparatedTopics(t *testing.T) {
@@ -262,9 +262,19 @@ func (_ *Server) GetVersion(ctx context.Context, _ *emptypb.Empty) (*ethpb.Versi
 // GetSyncStatus requests the beacon node to describe if it's currently syncing or not, and
 // if it is, what block it is up to.
 func (ns *Server) GetSyncStatus(ctx context.Context, _ *emptypb.Empty) (*ethpb.SyncingResponse, error) {
-	_, span := trace.StartSpan(ctx, "node.GetSyncStatus")
+	ctx, span := trace.StartSpan(ctx, "node.GetSyncStatus")
 	defer span.End()
 
+	optimistic, err := ns.HeadFetcher.IsOptimistic(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not check if head is optimistic: %v", err)
+	}
+	if optimistic {
+		if err := grpcutil.AppendExecutionOptimisticHeader(ctx); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not set execution optimistic header: %v", err)
+		}
+	}
+
 	headSlot := ns.HeadFetcher.HeadSlot()
 	return &ethpb.SyncingResponse{
 		Data: &ethpb.SyncInfo{
@@ -276,28 +286,55 @@ func (ns *Server) GetSyncStatus(ctx context.Context, _ *emptypb.Empty) (*ethpb.S
 }
 
 // GetHealth returns node health status in http status codes. Useful for load balancers.
+// The stage of the node is described in the response.
 // Response Usage:
 //    "200":
-//      description: Node is ready
+//      description: Node is ready or backfilling
 //    "206":
-//      description: Node is syncing but can serve incomplete data
+//      description: Node is syncing or optimistic, and can serve incomplete data
 //    "503":
-//      description: Node not initialized or having issues
-func (ns *Server) GetHealth(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+//      description: Node not initialized or having issues, such as its execution client being offline
+func (ns *Server) GetHealth(ctx context.Context, _ *emptypb.Empty) (*ethpb.HealthResponse, error) {
 	ctx, span := trace.StartSpan(ctx, "node.GetHealth")
 	defer span.End()
 
-	if ns.SyncChecker.Synced() {
-		return &emptypb.Empty{}, nil
+	health, err := ns.V1Alpha1Server.GetHealth(ctx, &emptypb.Empty{})
+	if err != nil {
+		// We simply return the error because it's already a gRPC error.
+		return nil, err
+	}
+	var stage ethpb.HealthInfo_Stage
+	httpCode := http.StatusOK
+	switch health.Stage {
+	case eth.NodeHealth_EXECUTION_CLIENT_OFFLINE:
+		stage = ethpb.HealthInfo_EL_OFFLINE
+		httpCode = http.StatusServiceUnavailable
+	case eth.NodeHealth_SYNCING:
+		stage = ethpb.HealthInfo_SYNCING
+		httpCode = http.StatusPartialContent
+	case eth.NodeHealth_OPTIMISTIC:
+		stage = ethpb.HealthInfo_OPTIMISTIC
+		httpCode = http.StatusPartialContent
+	case eth.NodeHealth_BACKFILLING:
+		stage = ethpb.HealthInfo_BACKFILLING
+	default:
+		stage = ethpb.HealthInfo_READY
+	}
+	resp := &ethpb.HealthResponse{
+		Data: &ethpb.HealthInfo{
+			Stage:         stage,
+			SyncDistance:  health.SyncDistance,
+			IsSyncing:     health.Syncing,
+			IsOptimistic:  health.Optimistic,
+			ElOffline:     health.ExecutionClientOffline,
+			IsBackfilling: health.Backfilling,
+		},
 	}
-	if ns.SyncChecker.Syncing() || ns.SyncChecker.Initialized() {
-		if err := grpc.SetHeader(ctx, metadata.Pairs(grpcutil.HttpCodeMetadataKey, strconv.Itoa(http.StatusPartialContent))); err != nil {
-			// We return a positive result because failing to set a non-gRPC related header should not cause the gRPC call to fail.
-			return &emptypb.Empty{}, nil
-		}
-		return &emptypb.Empty{}, nil
+	if httpCode != http.StatusOK {
+		// We ignore the error because failing to set a non-gRPC related header should not cause the gRPC call to fail.
+		_ = grpc.SetHeader(ctx, metadata.Pairs(grpcutil.HttpCodeMetadataKey, strconv.Itoa(httpCode)))
 	}
-	return &emptypb.Empty{}, status.Error(codes.Internal, "Node not initialized or having issues")
+	return resp, nil
 }
 
 func handleEmptyFilters(req *ethpb.PeersRequest) (emptyState, emptyDirection bool) {
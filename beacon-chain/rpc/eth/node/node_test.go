@@ -23,6 +23,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	nodev1alpha1 "github.com/prysmaticlabs/prysm/beacon-chain/rpc/prysm/v1alpha1/node"
 	syncmock "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -40,6 +41,14 @@ type dummyIdentity enode.ID
 func (_ dummyIdentity) Verify(_ *enr.Record, _ []byte) error { return nil }
 func (id dummyIdentity) NodeAddr(_ *enr.Record) []byte       { return id[:] }
 
+type mockConnChecker struct {
+	connected bool
+}
+
+func (m *mockConnChecker) IsConnected() bool {
+	return m.connected
+}
+
 func TestGetVersion(t *testing.T) {
 	semVer := version.SemanticVersion()
 	os := runtime.GOOS
@@ -53,23 +62,67 @@ func TestGetVersion(t *testing.T) {
 }
 
 func TestGetHealth(t *testing.T) {
-	ctx := grpc.NewContextWithServerTransportStream(context.Background(), &grpcruntime.ServerTransportStream{})
+	headState, err := util.NewBeaconState()
+	require.NoError(t, err)
 	checker := &syncmock.Sync{}
+	connChecker := &mockConnChecker{connected: true}
+	headFetcher := &mock.ChainService{State: headState}
+	currentSlot := types.Slot(0)
 	s := &Server{
-		SyncChecker: checker,
+		V1Alpha1Server: &nodev1alpha1.Server{
+			SyncChecker:        checker,
+			HeadFetcher:        headFetcher,
+			GenesisTimeFetcher: &mock.ChainService{Slot: &currentSlot},
+			EngineConnChecker:  connChecker,
+		},
+	}
+	newCtx := func() (context.Context, *grpcruntime.ServerTransportStream) {
+		stream := &grpcruntime.ServerTransportStream{}
+		return grpc.NewContextWithServerTransportStream(context.Background(), stream), stream
+	}
+	httpCode := func(stream *grpcruntime.ServerTransportStream) string {
+		codes := stream.Header()[strings.ToLower(grpcutil.HttpCodeMetadataKey)]
+		if len(codes) == 0 {
+			return strconv.Itoa(http.StatusOK)
+		}
+		return codes[0]
 	}
 
-	_, err := s.GetHealth(ctx, &emptypb.Empty{})
+	ctx, _ := newCtx()
+	_, err = s.GetHealth(ctx, &emptypb.Empty{})
 	require.ErrorContains(t, "Node not initialized or having issues", err)
+
 	checker.IsInitialized = true
-	_, err = s.GetHealth(ctx, &emptypb.Empty{})
+	ctx, stream := newCtx()
+	resp, err := s.GetHealth(ctx, &emptypb.Empty{})
 	require.NoError(t, err)
-	stream, ok := grpc.ServerTransportStreamFromContext(ctx).(*grpcruntime.ServerTransportStream)
-	require.Equal(t, true, ok, "type assertion failed")
-	assert.Equal(t, stream.Header()[strings.ToLower(grpcutil.HttpCodeMetadataKey)][0], strconv.Itoa(http.StatusPartialContent))
+	assert.Equal(t, ethpb.HealthInfo_SYNCING, resp.Data.Stage)
+	assert.Equal(t, true, resp.Data.IsSyncing)
+	assert.Equal(t, strconv.Itoa(http.StatusPartialContent), httpCode(stream))
+
 	checker.IsSynced = true
-	_, err = s.GetHealth(ctx, &emptypb.Empty{})
+	headFetcher.Optimistic = true
+	ctx, stream = newCtx()
+	resp, err = s.GetHealth(ctx, &emptypb.Empty{})
 	require.NoError(t, err)
+	assert.Equal(t, ethpb.HealthInfo_OPTIMISTIC, resp.Data.Stage)
+	assert.Equal(t, true, resp.Data.IsOptimistic)
+	assert.Equal(t, strconv.Itoa(http.StatusPartialContent), httpCode(stream))
+
+	headFetcher.Optimistic = false
+	ctx, stream = newCtx()
+	resp, err = s.GetHealth(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, ethpb.HealthInfo_READY, resp.Data.Stage)
+	assert.Equal(t, strconv.Itoa(http.StatusOK), httpCode(stream))
+
+	connChecker.connected = false
+	ctx, stream = newCtx()
+	resp, err = s.GetHealth(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, ethpb.HealthInfo_EL_OFFLINE, resp.Data.Stage)
+	assert.Equal(t, true, resp.Data.ElOffline)
+	assert.Equal(t, strconv.Itoa(http.StatusServiceUnavailable), httpCode(stream))
 }
 
 func TestGetIdentity(t *testing.T) {
@@ -183,6 +236,27 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, true, resp.Data.IsSyncing)
 }
 
+func TestSyncStatus_Optimistic(t *testing.T) {
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), &grpcruntime.ServerTransportStream{})
+	currentSlot := new(types.Slot)
+	*currentSlot = 110
+	state, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, state.SetSlot(100))
+	chainService := &mock.ChainService{Slot: currentSlot, State: state, Optimistic: true}
+
+	s := &Server{
+		HeadFetcher:        chainService,
+		GenesisTimeFetcher: chainService,
+		SyncChecker:        &syncmock.Sync{},
+	}
+	_, err = s.GetSyncStatus(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	stream, ok := grpc.ServerTransportStreamFromContext(ctx).(*grpcruntime.ServerTransportStream)
+	require.Equal(t, true, ok, "type assertion failed")
+	assert.Equal(t, "true", stream.Header()[strings.ToLower(grpcutil.ExecutionOptimisticMetadataKey)][0])
+}
+
 func TestGetPeer(t *testing.T) {
 	const rawId = "16Uiu2HAkvyYtoQXZNTsthjgLHjEnv7kvwzEmjvsJjWXpbhtqpSUN"
 	ctx := context.Background()
@@ -7,6 +7,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	nodev1alpha1 "github.com/prysmaticlabs/prysm/beacon-chain/rpc/prysm/v1alpha1/node"
 	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	"google.golang.org/grpc"
 )
@@ -23,4 +24,5 @@ type Server struct {
 	MetadataProvider   p2p.MetadataProvider
 	GenesisTimeFetcher blockchain.TimeFetcher
 	HeadFetcher        blockchain.HeadFetcher
+	V1Alpha1Server     *nodev1alpha1.Server
 }
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
@@ -22,4 +23,5 @@ type Server struct {
 	StateFetcher      statefetcher.Fetcher
 	SyncCommitteePool synccommittee.Pool
 	V1Alpha1Server    *v1alpha1validator.Server
+	LivenessCache     *cache.LivenessCache
 }
@@ -138,9 +138,9 @@ func (vs *Server) GetProposerDuties(ctx context.Context, req *ethpbv1.ProposerDu
 		return nil, status.Errorf(codes.Internal, "Could not advance state to requested epoch start slot: %v", err)
 	}
 
-	_, proposals, err := helpers.CommitteeAssignments(ctx, s, req.Epoch)
+	proposals, err := helpers.ProposerAssignments(ctx, s, req.Epoch)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not compute committee assignments: %v", err)
+		return nil, status.Errorf(codes.Internal, "Could not compute proposer assignments: %v", err)
 	}
 
 	duties := make([]*ethpbv1.ProposerDuty, 0)
@@ -460,6 +460,7 @@ func (vs *Server) SubmitBeaconCommitteeSubscription(ctx context.Context, req *et
 		cache.SubnetIDs.AddAttesterSubnetID(sub.Slot, subnet)
 		if sub.IsAggregator {
 			cache.SubnetIDs.AddAggregatorSubnetID(sub.Slot, subnet)
+			vs.PeerManager.FindAggregatorSubnetPeers(subnet, sub.Slot)
 		}
 	}
 
@@ -609,40 +610,52 @@ func (vs *Server) SubmitContributionAndProofs(ctx context.Context, req *ethpbv2.
 	return &empty.Empty{}, nil
 }
 
+// GetLiveness requests the beacon node to indicate whether the given validators were seen proposing a block
+// or attesting in the given epoch. Only the current and previous epochs can be queried.
+func (vs *Server) GetLiveness(ctx context.Context, req *ethpbv2.GetLivenessRequest) (*ethpbv2.GetLivenessResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "validator.GetLiveness")
+	defer span.End()
+
+	currentEpoch := slots.ToEpoch(vs.TimeFetcher.CurrentSlot())
+	if req.Epoch > currentEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Request epoch %d can not be greater than current epoch %d", req.Epoch, currentEpoch)
+	}
+	if req.Epoch+1 < currentEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Request epoch %d can not be older than previous epoch %d", req.Epoch, currentEpoch-1)
+	}
+
+	s, err := vs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	numVals := types.ValidatorIndex(s.NumValidators())
+	data := make([]*ethpbv2.GetLivenessResponse_Liveness, len(req.Index))
+	for i, index := range req.Index {
+		if index >= numVals {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid validator index %d", index)
+		}
+		data[i] = &ethpbv2.GetLivenessResponse_Liveness{
+			Index:  index,
+			IsLive: vs.LivenessCache.IsLive(req.Epoch, index),
+		}
+	}
+	return &ethpbv2.GetLivenessResponse{Data: data}, nil
+}
+
 // attestationDependentRoot is get_block_root_at_slot(state, compute_start_slot_at_epoch(epoch - 1) - 1)
 // or the genesis block root in the case of underflow.
 func attestationDependentRoot(s state.BeaconState, epoch types.Epoch) ([]byte, error) {
-	var dependentRootSlot types.Slot
-	if epoch <= 1 {
-		dependentRootSlot = 0
-	} else {
-		prevEpochStartSlot, err := slots.EpochStart(epoch.Sub(1))
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not obtain epoch's start slot: %v", err)
-		}
-		dependentRootSlot = prevEpochStartSlot.Sub(1)
-	}
-	root, err := helpers.BlockRootAtSlot(s, dependentRootSlot)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not get block root")
+	var previousEpoch types.Epoch
+	if epoch > 0 {
+		previousEpoch = epoch - 1
 	}
-	return root, nil
+	return proposalDependentRoot(s, previousEpoch)
 }
 
 // proposalDependentRoot is get_block_root_at_slot(state, compute_start_slot_at_epoch(epoch) - 1)
 // or the genesis block root in the case of underflow.
 func proposalDependentRoot(s state.BeaconState, epoch types.Epoch) ([]byte, error) {
-	var dependentRootSlot types.Slot
-	if epoch == 0 {
-		dependentRootSlot = 0
-	} else {
-		epochStartSlot, err := slots.EpochStart(epoch)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not obtain epoch's start slot: %v", err)
-		}
-		dependentRootSlot = epochStartSlot.Sub(1)
-	}
-	root, err := helpers.BlockRootAtSlot(s, dependentRootSlot)
+	root, err := helpers.DutyDependentRoot(s, epoch)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get block root")
 	}
@@ -1135,6 +1135,7 @@ func TestSubmitBeaconCommitteeSubscription(t *testing.T) {
 		HeadFetcher:    chain,
 		TimeFetcher:    chain,
 		SyncChecker:    &mockSync.Sync{IsSyncing: false},
+		PeerManager:    &p2pmock.MockPeerManager{},
 		V1Alpha1Server: &v1alpha1validator.Server{},
 	}
 
@@ -1276,6 +1277,7 @@ func TestSubmitSyncCommitteeSubscription(t *testing.T) {
 		HeadFetcher:    chain,
 		TimeFetcher:    chain,
 		SyncChecker:    &mockSync.Sync{IsSyncing: false},
+		PeerManager:    &p2pmock.MockPeerManager{},
 		V1Alpha1Server: &v1alpha1validator.Server{},
 	}
 
@@ -1835,3 +1837,46 @@ func TestSubmitContributionAndProofs(t *testing.T) {
 		require.DeepEqual(t, expectedContributions, savedMsgs)
 	})
 }
+
+func TestGetLiveness(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisState(t, 4)
+	slot := params.BeaconConfig().SlotsPerEpoch * 2
+	chain := &mockChain.ChainService{State: st, Slot: &slot}
+	livenessCache := cache.NewLivenessCache()
+	livenessCache.MarkLive(1, []uint64{0, 2})
+	livenessCache.MarkLive(2, []uint64{1})
+	vs := &Server{
+		HeadFetcher:   chain,
+		TimeFetcher:   chain,
+		LivenessCache: livenessCache,
+	}
+
+	t.Run("Previous epoch", func(t *testing.T) {
+		resp, err := vs.GetLiveness(ctx, &ethpbv2.GetLivenessRequest{Epoch: 1, Index: []types.ValidatorIndex{0, 1, 2}})
+		require.NoError(t, err)
+		require.Equal(t, 3, len(resp.Data))
+		assert.DeepEqual(t, &ethpbv2.GetLivenessResponse_Liveness{Index: 0, IsLive: true}, resp.Data[0])
+		assert.DeepEqual(t, &ethpbv2.GetLivenessResponse_Liveness{Index: 1, IsLive: false}, resp.Data[1])
+		assert.DeepEqual(t, &ethpbv2.GetLivenessResponse_Liveness{Index: 2, IsLive: true}, resp.Data[2])
+	})
+	t.Run("Current epoch", func(t *testing.T) {
+		resp, err := vs.GetLiveness(ctx, &ethpbv2.GetLivenessRequest{Epoch: 2, Index: []types.ValidatorIndex{0, 1}})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, false, resp.Data[0].IsLive)
+		assert.Equal(t, true, resp.Data[1].IsLive)
+	})
+	t.Run("Future epoch", func(t *testing.T) {
+		_, err := vs.GetLiveness(ctx, &ethpbv2.GetLivenessRequest{Epoch: 3, Index: []types.ValidatorIndex{0}})
+		assert.ErrorContains(t, "Request epoch 3 can not be greater than current epoch 2", err)
+	})
+	t.Run("Old epoch", func(t *testing.T) {
+		_, err := vs.GetLiveness(ctx, &ethpbv2.GetLivenessRequest{Epoch: 0, Index: []types.ValidatorIndex{0}})
+		assert.ErrorContains(t, "Request epoch 0 can not be older than previous epoch 1", err)
+	})
+	t.Run("Invalid index", func(t *testing.T) {
+		_, err := vs.GetLiveness(ctx, &ethpbv2.GetLivenessRequest{Epoch: 2, Index: []types.ValidatorIndex{0, 4}})
+		assert.ErrorContains(t, "Invalid validator index 4", err)
+	})
+}
@@ -0,0 +1,47 @@
+package graphql
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxQueryDepth is the maximum nesting depth of the fields of a query.
+	maxQueryDepth = 5
+	// maxQueryParallelism is the maximum number of fields of a query which are resolved concurrently.
+	maxQueryParallelism = 4
+	// maxQueryBytes is the maximum size of a request, which also bounds the number of aliased fields of a query.
+	maxQueryBytes = 1 << 16
+	// maxQueryCost is the maximum cost of a query, as the sum of the costs of the blocks and states it looks up and
+	// of the validators it lists.
+	maxQueryCost = 100000
+	// stateCost is the cost of looking up a state, which may have to be replayed.
+	stateCost = 10000
+	// blockCost is the cost of looking up a block.
+	blockCost = 100
+	// validatorCost is the cost of listing a validator, either on its own or as a committee member.
+	validatorCost = 1
+)
+
+type queryBudgetKey struct{}
+
+// withQueryBudget returns a copy of the context in which the resolvers can spend up to the maximum query cost.
+func withQueryBudget(ctx context.Context) context.Context {
+	remaining := int64(maxQueryCost)
+	return context.WithValue(ctx, queryBudgetKey{}, &remaining)
+}
+
+// spend deducts the cost from the budget of the query, and fails once the budget is exceeded. The cost of queries
+// executed without a budget is not limited.
+func spend(ctx context.Context, cost int) error {
+	remaining, ok := ctx.Value(queryBudgetKey{}).(*int64)
+	if !ok {
+		return nil
+	}
+	if atomic.AddInt64(remaining, -int64(cost)) < 0 {
+		return errors.Errorf("query exceeds the maximum cost of %d", maxQueryCost)
+	}
+	return nil
+}
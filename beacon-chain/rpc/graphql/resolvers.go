@@ -0,0 +1,446 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	corehelpers "github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+type queryResolver struct {
+	s *Server
+}
+
+// Block resolves the block with the given ID, or nil if there is no such block.
+func (r *queryResolver) Block(ctx context.Context, args struct{ ID *string }) (*blockResolver, error) {
+	if err := spend(ctx, blockCost); err != nil {
+		return nil, err
+	}
+	blockId, err := parseID(args.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid block ID")
+	}
+	blk, err := r.s.BlockFetcher.Block(ctx, blockId)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block")
+	}
+	if blk == nil || blk.IsNil() {
+		return nil, nil
+	}
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash block")
+	}
+	return &blockResolver{s: r.s, blk: blk, root: root}, nil
+}
+
+// State resolves the state with the given ID.
+func (r *queryResolver) State(ctx context.Context, args struct{ ID *string }) (*stateResolver, error) {
+	if err := spend(ctx, stateCost); err != nil {
+		return nil, err
+	}
+	stateId, err := parseID(args.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid state ID")
+	}
+	st, err := r.s.StateFetcher.State(ctx, stateId)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get state")
+	}
+	return &stateResolver{st: st, epoch: slots.ToEpoch(st.Slot())}, nil
+}
+
+// parseID converts a block or state ID, which is the head by default, to the form taken by the block and state
+// fetchers, in which roots are raw bytes rather than 0x-prefixed hex strings.
+func parseID(id *string) ([]byte, error) {
+	if id == nil {
+		return []byte("head"), nil
+	}
+	if !strings.HasPrefix(*id, "0x") {
+		return []byte(*id), nil
+	}
+	root, err := hexutil.Decode(*id)
+	if err != nil || len(root) != fieldparams.RootLength {
+		return nil, errors.Errorf("%q is not a valid root", *id)
+	}
+	return root, nil
+}
+
+type blockResolver struct {
+	s    *Server
+	blk  block.SignedBeaconBlock
+	root [32]byte
+}
+
+func (r *blockResolver) Root() string {
+	return hexutil.Encode(r.root[:])
+}
+
+func (r *blockResolver) Slot() string {
+	return formatUint(uint64(r.blk.Block().Slot()))
+}
+
+func (r *blockResolver) ProposerIndex() string {
+	return formatUint(uint64(r.blk.Block().ProposerIndex()))
+}
+
+func (r *blockResolver) ParentRoot() string {
+	return hexutil.Encode(r.blk.Block().ParentRoot())
+}
+
+func (r *blockResolver) StateRoot() string {
+	return hexutil.Encode(r.blk.Block().StateRoot())
+}
+
+func (r *blockResolver) Signature() string {
+	return hexutil.Encode(r.blk.Signature())
+}
+
+func (r *blockResolver) RandaoReveal() string {
+	return hexutil.Encode(r.blk.Block().Body().RandaoReveal())
+}
+
+func (r *blockResolver) Graffiti() string {
+	return hexutil.Encode(r.blk.Block().Body().Graffiti())
+}
+
+func (r *blockResolver) Attestations() []*attestationResolver {
+	atts := r.blk.Block().Body().Attestations()
+	resolvers := make([]*attestationResolver, len(atts))
+	for i, att := range atts {
+		resolvers[i] = &attestationResolver{att: att}
+	}
+	return resolvers
+}
+
+func (r *blockResolver) ExecutionOptimistic(ctx context.Context) (bool, error) {
+	if r.blk.Version() < version.Bellatrix {
+		return false, nil
+	}
+	optimistic, err := r.s.ChainInfoFetcher.IsOptimisticForRoot(ctx, r.root, r.blk.Block().Slot())
+	if err != nil {
+		return false, errors.Wrap(err, "could not check if block is optimistic")
+	}
+	return optimistic, nil
+}
+
+type attestationResolver struct {
+	att *ethpb.Attestation
+}
+
+func (r *attestationResolver) AggregationBits() string {
+	return hexutil.Encode(r.att.AggregationBits)
+}
+
+func (r *attestationResolver) Slot() string {
+	return formatUint(uint64(r.att.Data.Slot))
+}
+
+func (r *attestationResolver) Index() string {
+	return formatUint(uint64(r.att.Data.CommitteeIndex))
+}
+
+func (r *attestationResolver) BeaconBlockRoot() string {
+	return hexutil.Encode(r.att.Data.BeaconBlockRoot)
+}
+
+func (r *attestationResolver) Source() *checkpointResolver {
+	return &checkpointResolver{cp: r.att.Data.Source}
+}
+
+func (r *attestationResolver) Target() *checkpointResolver {
+	return &checkpointResolver{cp: r.att.Data.Target}
+}
+
+func (r *attestationResolver) Signature() string {
+	return hexutil.Encode(r.att.Signature)
+}
+
+type checkpointResolver struct {
+	cp *ethpb.Checkpoint
+}
+
+func (r *checkpointResolver) Epoch() string {
+	return formatUint(uint64(r.cp.Epoch))
+}
+
+func (r *checkpointResolver) Root() string {
+	return hexutil.Encode(r.cp.Root)
+}
+
+type stateResolver struct {
+	st    state.BeaconState
+	epoch types.Epoch
+}
+
+func (r *stateResolver) Slot() string {
+	return formatUint(uint64(r.st.Slot()))
+}
+
+// Validator resolves the validator with the given index or public key, or nil if there is no such validator.
+func (r *stateResolver) Validator(ctx context.Context, args struct{ ID string }) (*validatorResolver, error) {
+	if err := spend(ctx, validatorCost); err != nil {
+		return nil, err
+	}
+	idx, ok, err := r.validatorIndex(args.ID)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return r.validatorAtIndex(idx)
+}
+
+// Validators resolves the validators with the given indexes or public keys, filtered by status.
+func (r *stateResolver) Validators(ctx context.Context, args struct {
+	IDs      *[]string
+	Statuses *[]string
+}) ([]*validatorResolver, error) {
+	var indices []types.ValidatorIndex
+	if args.IDs == nil {
+		indices = make([]types.ValidatorIndex, r.st.NumValidators())
+		for i := range indices {
+			indices[i] = types.ValidatorIndex(i)
+		}
+	} else {
+		indices = make([]types.ValidatorIndex, 0, len(*args.IDs))
+		for _, id := range *args.IDs {
+			idx, ok, err := r.validatorIndex(id)
+			if err != nil {
+				return nil, err
+			}
+			// Ignore well-formed yet unknown validators.
+			if ok {
+				indices = append(indices, idx)
+			}
+		}
+	}
+	var filterStatus map[ethpbv1.ValidatorStatus]bool
+	if args.Statuses != nil {
+		filterStatus = make(map[ethpbv1.ValidatorStatus]bool, len(*args.Statuses))
+		for _, s := range *args.Statuses {
+			status, ok := ethpbv1.ValidatorStatus_value[strings.ToUpper(s)]
+			if !ok {
+				return nil, errors.Errorf("invalid validator status %q", s)
+			}
+			filterStatus[ethpbv1.ValidatorStatus(status)] = true
+		}
+	}
+
+	resolvers := make([]*validatorResolver, 0, len(indices))
+	for _, idx := range indices {
+		v, err := r.validatorAtIndex(idx)
+		if err != nil {
+			return nil, err
+		}
+		if filterStatus != nil {
+			status, err := helpers.ValidatorStatus(v.val, r.epoch)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get validator status")
+			}
+			subStatus, err := helpers.ValidatorSubStatus(v.val, r.epoch)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get validator sub status")
+			}
+			if !filterStatus[status] && !filterStatus[subStatus] {
+				continue
+			}
+		}
+		if err := spend(ctx, validatorCost); err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, v)
+	}
+	return resolvers, nil
+}
+
+// Committees resolves the committees of the given epoch, optionally filtered by slot and committee index.
+func (r *stateResolver) Committees(ctx context.Context, args struct {
+	Epoch *string
+	Slot  *string
+	Index *string
+}) ([]*committeeResolver, error) {
+	epoch := r.epoch
+	if args.Epoch != nil {
+		e, err := strconv.ParseUint(*args.Epoch, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid epoch %q", *args.Epoch)
+		}
+		epoch = types.Epoch(e)
+	}
+	var filterSlot *types.Slot
+	if args.Slot != nil {
+		s, err := strconv.ParseUint(*args.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid slot %q", *args.Slot)
+		}
+		slot := types.Slot(s)
+		filterSlot = &slot
+	}
+	var filterIndex *types.CommitteeIndex
+	if args.Index != nil {
+		i, err := strconv.ParseUint(*args.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid committee index %q", *args.Index)
+		}
+		index := types.CommitteeIndex(i)
+		filterIndex = &index
+	}
+
+	activeCount, err := corehelpers.ActiveValidatorCount(ctx, r.st, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get active validator count")
+	}
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid epoch")
+	}
+	endSlot, err := slots.EpochEnd(epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid epoch")
+	}
+	committeesPerSlot := corehelpers.SlotCommitteeCount(activeCount)
+	resolvers := make([]*committeeResolver, 0)
+	for slot := startSlot; slot <= endSlot; slot++ {
+		if filterSlot != nil && slot != *filterSlot {
+			continue
+		}
+		for index := types.CommitteeIndex(0); index < types.CommitteeIndex(committeesPerSlot); index++ {
+			if filterIndex != nil && index != *filterIndex {
+				continue
+			}
+			committee, err := corehelpers.BeaconCommitteeFromState(ctx, r.st, slot, index)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get committee")
+			}
+			if err := spend(ctx, len(committee)*validatorCost); err != nil {
+				return nil, err
+			}
+			resolvers = append(resolvers, &committeeResolver{index: index, slot: slot, validators: committee})
+		}
+	}
+	return resolvers, nil
+}
+
+// validatorIndex returns the index of the validator with the given index or public key, and false if the
+// state has no such validator.
+func (r *stateResolver) validatorIndex(id string) (types.ValidatorIndex, bool, error) {
+	if strings.HasPrefix(id, "0x") {
+		pubkey, err := hexutil.Decode(id)
+		if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+			return 0, false, errors.Errorf("invalid validator ID %q", id)
+		}
+		idx, ok := r.st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+		return idx, ok, nil
+	}
+	idx, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, false, errors.Errorf("invalid validator ID %q", id)
+	}
+	return types.ValidatorIndex(idx), idx < uint64(r.st.NumValidators()), nil
+}
+
+func (r *stateResolver) validatorAtIndex(idx types.ValidatorIndex) (*validatorResolver, error) {
+	val, err := r.st.ValidatorAtIndexReadOnly(idx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validator")
+	}
+	return &validatorResolver{st: r.st, epoch: r.epoch, index: idx, val: val}, nil
+}
+
+type validatorResolver struct {
+	st    state.BeaconState
+	epoch types.Epoch
+	index types.ValidatorIndex
+	val   state.ReadOnlyValidator
+}
+
+func (r *validatorResolver) Index() string {
+	return formatUint(uint64(r.index))
+}
+
+func (r *validatorResolver) Balance() (string, error) {
+	balance, err := r.st.BalanceAtIndex(r.index)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get validator balance")
+	}
+	return formatUint(balance), nil
+}
+
+func (r *validatorResolver) Status() (string, error) {
+	status, err := helpers.ValidatorSubStatus(r.val, r.epoch)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get validator sub status")
+	}
+	return strings.ToLower(status.String()), nil
+}
+
+func (r *validatorResolver) Pubkey() string {
+	pubkey := r.val.PublicKey()
+	return hexutil.Encode(pubkey[:])
+}
+
+func (r *validatorResolver) WithdrawalCredentials() string {
+	return hexutil.Encode(r.val.WithdrawalCredentials())
+}
+
+func (r *validatorResolver) EffectiveBalance() string {
+	return formatUint(r.val.EffectiveBalance())
+}
+
+func (r *validatorResolver) Slashed() bool {
+	return r.val.Slashed()
+}
+
+func (r *validatorResolver) ActivationEligibilityEpoch() string {
+	return formatUint(uint64(r.val.ActivationEligibilityEpoch()))
+}
+
+func (r *validatorResolver) ActivationEpoch() string {
+	return formatUint(uint64(r.val.ActivationEpoch()))
+}
+
+func (r *validatorResolver) ExitEpoch() string {
+	return formatUint(uint64(r.val.ExitEpoch()))
+}
+
+func (r *validatorResolver) WithdrawableEpoch() string {
+	return formatUint(uint64(r.val.WithdrawableEpoch()))
+}
+
+type committeeResolver struct {
+	index      types.CommitteeIndex
+	slot       types.Slot
+	validators []types.ValidatorIndex
+}
+
+func (r *committeeResolver) Index() string {
+	return formatUint(uint64(r.index))
+}
+
+func (r *committeeResolver) Slot() string {
+	return formatUint(uint64(r.slot))
+}
+
+func (r *committeeResolver) Validators() []string {
+	validators := make([]string, len(r.validators))
+	for i, v := range r.validators {
+		validators[i] = formatUint(uint64(v))
+	}
+	return validators
+}
+
+func formatUint(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
@@ -0,0 +1,185 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	types "github.com/prysmaticlabs/eth2-types"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/blockfetcher"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestBlock(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 3
+	b.Block.ProposerIndex = 5
+	b.Block.Body.Graffiti = bytesutil.PadTo([]byte("graffiti"), 32)
+	att := util.HydrateAttestation(&ethpb.Attestation{
+		AggregationBits: []byte{0b11},
+		Data:            &ethpb.AttestationData{Slot: 2, CommitteeIndex: 1},
+	})
+	b.Block.Body.Attestations = []*ethpb.Attestation{att}
+	wsb := wrapper.WrappedPhase0SignedBeaconBlock(b)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wsb))
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	chain := &mock.ChainService{Block: wsb}
+	s := &Server{
+		ChainInfoFetcher: chain,
+		BlockFetcher:     &blockfetcher.BlockProvider{BeaconDB: beaconDB, ChainInfoFetcher: chain},
+	}
+	schema, err := s.schema()
+	require.NoError(t, err)
+
+	for _, id := range []string{"head", "3", hexutil.Encode(root[:])} {
+		t.Run(id, func(t *testing.T) {
+			resp := schema.Exec(ctx, `query($id: String!) {
+				block(id: $id) { root slot proposerIndex graffiti attestations { aggregationBits slot index } }
+			}`, "", map[string]interface{}{"id": id})
+			require.Equal(t, 0, len(resp.Errors))
+			var data struct {
+				Block struct {
+					Root          string
+					Slot          string
+					ProposerIndex string
+					Graffiti      string
+					Attestations  []map[string]string
+				}
+			}
+			require.NoError(t, json.Unmarshal(resp.Data, &data))
+			assert.Equal(t, hexutil.Encode(root[:]), data.Block.Root)
+			assert.Equal(t, "3", data.Block.Slot)
+			assert.Equal(t, "5", data.Block.ProposerIndex)
+			assert.Equal(t, hexutil.Encode(b.Block.Body.Graffiti), data.Block.Graffiti)
+			require.Equal(t, 1, len(data.Block.Attestations))
+			assert.DeepEqual(t, map[string]string{"aggregationBits": "0x03", "slot": "2", "index": "1"}, data.Block.Attestations[0])
+		})
+	}
+	t.Run("unknown block", func(t *testing.T) {
+		resp := schema.Exec(ctx, `{ block(id: "4") { root } }`, "", nil)
+		require.Equal(t, 0, len(resp.Errors))
+		assert.Equal(t, `{"block":null}`, string(resp.Data))
+	})
+	t.Run("invalid ID", func(t *testing.T) {
+		resp := schema.Exec(ctx, `{ block(id: "foo") { root } }`, "", nil)
+		require.Equal(t, 1, len(resp.Errors))
+		assert.ErrorContains(t, "could not parse block ID", resp.Errors[0])
+
+		resp = schema.Exec(ctx, `{ block(id: "0x01") { root } }`, "", nil)
+		require.Equal(t, 1, len(resp.Errors))
+		assert.ErrorContains(t, `invalid block ID: "0x01" is not a valid root`, resp.Errors[0])
+	})
+}
+
+func TestState(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisState(t, 64)
+	// Validator 1 has exited.
+	val, err := st.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	val.ExitEpoch = 0
+	val.WithdrawableEpoch = params.BeaconConfig().FarFutureEpoch
+	require.NoError(t, st.UpdateValidatorAtIndex(1, val))
+	s := &Server{StateFetcher: &testutil.MockFetcher{BeaconState: st}}
+	schema, err := s.schema()
+	require.NoError(t, err)
+	pubkey := st.PubkeyAtIndex(2)
+
+	t.Run("validator", func(t *testing.T) {
+		resp := schema.Exec(ctx, `query($id: String!) { state { validator(id: $id) { index balance status } } }`, "",
+			map[string]interface{}{"id": hexutil.Encode(pubkey[:])})
+		require.Equal(t, 0, len(resp.Errors))
+		assert.Equal(t, `{"state":{"validator":{"index":"2","balance":"32000000000","status":"active_ongoing"}}}`, string(resp.Data))
+	})
+	t.Run("unknown validator", func(t *testing.T) {
+		resp := schema.Exec(ctx, `{ state { validator(id: "64") { index } } }`, "", nil)
+		require.Equal(t, 0, len(resp.Errors))
+		assert.Equal(t, `{"state":{"validator":null}}`, string(resp.Data))
+	})
+	t.Run("validators", func(t *testing.T) {
+		resp := schema.Exec(ctx, `{ state { validators(ids: ["3", "1", "0", "100"]) { index } } }`, "", nil)
+		require.Equal(t, 0, len(resp.Errors))
+		assert.Equal(t, `{"state":{"validators":[{"index":"3"},{"index":"1"},{"index":"0"}]}}`, string(resp.Data))
+
+		resp = schema.Exec(ctx, `{ state { validators(statuses: ["exited"]) { index status } } }`, "", nil)
+		require.Equal(t, 0, len(resp.Errors))
+		assert.Equal(t, `{"state":{"validators":[{"index":"1","status":"exited_unslashed"}]}}`, string(resp.Data))
+
+		resp = schema.Exec(ctx, `{ state { validators(statuses: ["foo"]) { index } } }`, "", nil)
+		require.Equal(t, 1, len(resp.Errors))
+		assert.ErrorContains(t, `invalid validator status "foo"`, resp.Errors[0])
+	})
+	t.Run("committees", func(t *testing.T) {
+		resp := schema.Exec(ctx, `{ state { committees { slot index validators } } }`, "", nil)
+		require.Equal(t, 0, len(resp.Errors))
+		var data struct {
+			State struct {
+				Committees []struct {
+					Slot       string
+					Index      string
+					Validators []string
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &data))
+		require.Equal(t, int(params.BeaconConfig().SlotsPerEpoch), len(data.State.Committees))
+		numValidators := 0
+		for _, c := range data.State.Committees {
+			numValidators += len(c.Validators)
+		}
+		assert.Equal(t, 63 /* all active validators */, numValidators)
+
+		resp = schema.Exec(ctx, `{ state { committees(slot: "1") { slot index } } }`, "", nil)
+		require.Equal(t, 0, len(resp.Errors))
+		assert.Equal(t, `{"state":{"committees":[{"slot":"1","index":"0"}]}}`, string(resp.Data))
+	})
+}
+
+func TestHandler(t *testing.T) {
+	st, _ := util.DeterministicGenesisState(t, 4)
+	require.NoError(t, st.SetSlot(types.Slot(7)))
+	s := &Server{StateFetcher: &testutil.MockFetcher{BeaconState: st}}
+	handler, err := s.Handler()
+	require.NoError(t, err)
+
+	body := strings.NewReader(`{"query": "{ state(id: \"head\") { slot } }"}`)
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, httptest.NewRequest(http.MethodPost, "http://foo.example"+Path, body))
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, true, bytes.Contains(writer.Body.Bytes(), []byte(`{"data":{"state":{"slot":"7"}}}`)))
+}
+
+func TestHandler_QueryCost(t *testing.T) {
+	st, _ := util.DeterministicGenesisState(t, 4)
+	s := &Server{StateFetcher: &testutil.MockFetcher{BeaconState: st}}
+	handler, err := s.Handler()
+	require.NoError(t, err)
+
+	var fields []string
+	for i := 0; i <= maxQueryCost/stateCost; i++ {
+		fields = append(fields, fmt.Sprintf(`s%d: state(id: \"%d\") { slot }`, i, i))
+	}
+	body := strings.NewReader(fmt.Sprintf(`{"query": "{ %s }"}`, strings.Join(fields, " ")))
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, httptest.NewRequest(http.MethodPost, "http://foo.example"+Path, body))
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, true, bytes.Contains(writer.Body.Bytes(), []byte("query exceeds the maximum cost")))
+}
@@ -0,0 +1,78 @@
+package graphql
+
+// beaconSchema is the read-only GraphQL schema of beacon data. Blocks and states are identified as in the
+// Beacon API, by "head", "genesis", "finalized", a slot or a 0x-prefixed root, and states can also be
+// identified by "justified". 64-bit integers are represented by decimal strings and byte arrays by
+// 0x-prefixed hex strings, like in the JSON responses of the Beacon API.
+const beaconSchema = `
+schema {
+    query: Query
+}
+
+type Query {
+    # The block with the given ID, or null if there is no such block. The ID defaults to "head".
+    block(id: String): Block
+    # The state with the given ID. The ID defaults to "head".
+    state(id: String): State!
+}
+
+type Block {
+    root: String!
+    slot: String!
+    proposerIndex: String!
+    parentRoot: String!
+    stateRoot: String!
+    signature: String!
+    randaoReveal: String!
+    graffiti: String!
+    attestations: [Attestation!]!
+    # Whether the block was imported optimistically, without its execution payload being validated.
+    executionOptimistic: Boolean!
+}
+
+type Attestation {
+    aggregationBits: String!
+    slot: String!
+    index: String!
+    beaconBlockRoot: String!
+    source: Checkpoint!
+    target: Checkpoint!
+    signature: String!
+}
+
+type Checkpoint {
+    epoch: String!
+    root: String!
+}
+
+type State {
+    slot: String!
+    # The validator with the given index or public key, or null if there is no such validator.
+    validator(id: String!): Validator
+    # The validators with the given indexes or public keys, or all validators if no IDs are given.
+    # Unknown validators are ignored. Validators can be filtered by status, such as "active" or "active_ongoing".
+    validators(ids: [String!], statuses: [String!]): [Validator!]!
+    # The committees of the given epoch, which defaults to the epoch of the state.
+    committees(epoch: String, slot: String, index: String): [Committee!]!
+}
+
+type Validator {
+    index: String!
+    balance: String!
+    status: String!
+    pubkey: String!
+    withdrawalCredentials: String!
+    effectiveBalance: String!
+    slashed: Boolean!
+    activationEligibilityEpoch: String!
+    activationEpoch: String!
+    exitEpoch: String!
+    withdrawableEpoch: String!
+}
+
+type Committee {
+    index: String!
+    slot: String!
+    validators: [String!]!
+}
+`
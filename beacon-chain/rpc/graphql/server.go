@@ -0,0 +1,54 @@
+// Package graphql serves a read-only GraphQL API over beacon blocks and states, which lets clients
+// such as block explorers query the fields they need instead of downloading full Beacon API objects.
+package graphql
+
+import (
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/blockfetcher"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/statefetcher"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+)
+
+// Path is the path the GraphQL API is served at by the gRPC gateway.
+const Path = "/graphql"
+
+// Server resolves GraphQL queries with the block and state fetchers of the beacon node.
+type Server struct {
+	ChainInfoFetcher blockchain.ChainInfoFetcher
+	BlockFetcher     blockfetcher.Fetcher
+	StateFetcher     statefetcher.Fetcher
+}
+
+// Handler returns the HTTP handler of the GraphQL API, which executes the queries sent in POST requests.
+func (s *Server) Handler() (http.Handler, error) {
+	schema, err := s.schema()
+	if err != nil {
+		return nil, err
+	}
+	handler := &relay.Handler{Schema: schema}
+	// Deep state replays of the queries are bounded, so that they do not hold up the processing of blocks, and
+	// the size and cost of each query are limited.
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxQueryBytes)
+		ctx := withQueryBudget(stategen.WithBoundedDeepReplays(r.Context()))
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	}), nil
+}
+
+func (s *Server) schema() (*graphql.Schema, error) {
+	schema, err := graphql.ParseSchema(
+		beaconSchema,
+		&queryResolver{s: s},
+		graphql.MaxDepth(maxQueryDepth),
+		graphql.MaxParallelism(maxQueryParallelism),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse GraphQL schema")
+	}
+	return schema, nil
+}
@@ -6,11 +6,16 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	pbrpc "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // GetProtoArrayForkChoice returns proto array fork choice store.
 func (ds *Server) GetProtoArrayForkChoice(_ context.Context, _ *empty.Empty) (*pbrpc.ProtoArrayForkChoiceResponse, error) {
 	store := ds.HeadFetcher.ProtoArrayStore()
+	if store == nil {
+		return nil, status.Error(codes.Unimplemented, "The fork choice store is not a proto array store")
+	}
 
 	nodes := store.Nodes()
 	returnedNodes := make([]*pbrpc.ProtoArrayNode, len(nodes))
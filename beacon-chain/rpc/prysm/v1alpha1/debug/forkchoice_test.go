@@ -20,3 +20,9 @@ func TestServer_GetForkChoice(t *testing.T) {
 	assert.Equal(t, store.JustifiedEpoch(), res.JustifiedEpoch, "Did not get wanted justified epoch")
 	assert.Equal(t, store.FinalizedEpoch(), res.FinalizedEpoch, "Did not get wanted finalized epoch")
 }
+
+func TestServer_GetForkChoice_NotProtoArray(t *testing.T) {
+	bs := &Server{HeadFetcher: &mock.ChainService{}}
+	_, err := bs.GetProtoArrayForkChoice(context.Background(), &empty.Empty{})
+	require.ErrorContains(t, "not a proto array store", err)
+}
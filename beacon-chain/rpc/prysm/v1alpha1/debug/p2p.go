@@ -3,6 +3,7 @@ package debug
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -35,6 +36,27 @@ func (ds *Server) ListPeers(_ context.Context, _ *empty.Empty) (*ethpb.DebugPeer
 	return &ethpb.DebugPeerResponses{Responses: responses}, nil
 }
 
+// GetENR returns the enr of the host node, with its entries decoded.
+func (ds *Server) GetENR(_ context.Context, _ *empty.Empty) (*ethpb.DecodedENR, error) {
+	return decodedENR(ds.PeerManager.ENR())
+}
+
+// GetPeerENR returns the enr of the peer defined by the provided peer id, with its entries decoded.
+func (ds *Server) GetPeerENR(_ context.Context, peerReq *ethpb.PeerRequest) (*ethpb.DecodedENR, error) {
+	pid, err := peer.Decode(peerReq.PeerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to parse provided peer id: %v", err)
+	}
+	record, err := ds.PeersFetcher.Peers().ENR(pid)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Requested peer does not exist: %v", err)
+	}
+	if record == nil {
+		return nil, status.Error(codes.NotFound, "No enr is known for the requested peer")
+	}
+	return decodedENR(record)
+}
+
 func (ds *Server) getPeer(pid peer.ID) (*ethpb.DebugPeerResponse, error) {
 	peers := ds.PeersFetcher.Peers()
 	peerStore := ds.PeerManager.Host().Peerstore()
@@ -136,6 +158,18 @@ func (ds *Server) getPeer(pid peer.ID) (*ethpb.DebugPeerResponse, error) {
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "Requested peer does not exist: %v", err)
 	}
+	received, sent, err := peers.RequestCounts(pid)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Requested peer does not exist: %v", err)
+	}
+	lastSeen, err := peers.LastSeen(pid)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Requested peer does not exist: %v", err)
+	}
+	lastSeenTime := uint64(0)
+	if !lastSeen.IsZero() {
+		lastSeenTime = uint64(lastSeen.Unix())
+	}
 	scoreInfo := &ethpb.ScoreInfo{
 		OverallScore:       float32(peers.Scorers().Score(pid)),
 		ProcessedBlocks:    peers.Scorers().BlockProviderScorer().ProcessedBlocks(pid),
@@ -155,6 +189,9 @@ func (ds *Server) getPeer(pid peer.ID) (*ethpb.DebugPeerResponse, error) {
 		PeerStatus:         pStatus,
 		LastUpdated:        unixTime,
 		ScoreInfo:          scoreInfo,
+		RequestsReceived:   received,
+		RequestsSent:       sent,
+		LastSeen:           lastSeenTime,
 	}, nil
 }
 
@@ -164,3 +201,32 @@ func errorToString(err error) string {
 	}
 	return err.Error()
 }
+
+func decodedENR(record *enr.Record) (*ethpb.DecodedENR, error) {
+	info, err := p2p.DecodeENR(record)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to decode enr: %v", err)
+	}
+	res := &ethpb.DecodedENR{
+		Enr:             info.Record,
+		Seq:             info.Seq,
+		NodeId:          info.NodeID,
+		Tcp:             uint32(info.TCP),
+		Udp:             uint32(info.UDP),
+		Tcp6:            uint32(info.TCP6),
+		Udp6:            uint32(info.UDP6),
+		ForkDigest:      info.ForkDigest,
+		NextForkVersion: info.NextForkVersion,
+		NextForkEpoch:   info.NextForkEpoch,
+		AttnetSubnets:   info.AttSubnets,
+		SyncnetSubnets:  info.SyncSubnets,
+		Entries:         info.Entries,
+	}
+	if info.IP != nil {
+		res.Ip = info.IP.String()
+	}
+	if info.IPv6 != nil {
+		res.Ip6 = info.IPv6.String()
+	}
+	return res, nil
+}
@@ -2,9 +2,17 @@ package debug
 
 import (
 	"context"
+	"crypto/rand"
+	"net"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/golang/protobuf/ptypes/empty"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
 	mockP2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
@@ -19,6 +27,9 @@ func TestDebugServer_GetPeer(t *testing.T) {
 		PeerManager:  &mockP2p.MockPeerManager{BHost: mP2P.BHost},
 	}
 	firstPeer := peersProvider.Peers().All()[0]
+	peersProvider.Peers().IncrementRequestsReceived(firstPeer)
+	peersProvider.Peers().IncrementRequestsSent(firstPeer)
+	peersProvider.Peers().IncrementRequestsSent(firstPeer)
 
 	res, err := ds.GetPeer(context.Background(), &ethpb.PeerRequest{PeerId: firstPeer.String()})
 	require.NoError(t, err)
@@ -26,6 +37,9 @@ func TestDebugServer_GetPeer(t *testing.T) {
 
 	assert.Equal(t, int(ethpb.PeerDirection_INBOUND), int(res.Direction), "Expected 1st peer to be an inbound connection")
 	assert.Equal(t, ethpb.ConnectionState_CONNECTED, res.ConnectionState, "Expected peer to be connected")
+	assert.Equal(t, uint64(1), res.RequestsReceived)
+	assert.Equal(t, uint64(2), res.RequestsSent)
+	assert.NotEqual(t, uint64(0), res.LastSeen)
 }
 
 func TestDebugServer_ListPeers(t *testing.T) {
@@ -57,3 +71,55 @@ func TestDebugServer_ListPeers(t *testing.T) {
 		t.Errorf("Expected 2nd peer to have a multiaddress, instead they have no addresses")
 	}
 }
+
+func TestDebugServer_GetENR(t *testing.T) {
+	ds := &Server{
+		PeerManager: &mockP2p.MockPeerManager{Enr: testENR(t, enr.WithEntry("client", []byte("prysm")))},
+	}
+	res, err := ds.GetENR(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", res.Ip)
+	assert.Equal(t, uint32(13000), res.Tcp)
+	assert.DeepEqual(t, map[string][]byte{"client": []byte("prysm")}, res.Entries)
+}
+
+func TestDebugServer_GetPeerENR(t *testing.T) {
+	peersProvider := &mockP2p.MockPeersProvider{}
+	ds := &Server{PeersFetcher: peersProvider}
+
+	pid := testPeerID(t)
+	record := testENR(t)
+	peersProvider.Peers().Add(record, pid, nil, network.DirOutbound)
+	res, err := ds.GetPeerENR(context.Background(), &ethpb.PeerRequest{PeerId: pid.String()})
+	require.NoError(t, err)
+	node, err := enode.New(enode.ValidSchemes, record)
+	require.NoError(t, err)
+	assert.Equal(t, node.String(), res.Enr)
+
+	pid = testPeerID(t)
+	peersProvider.Peers().Add(nil, pid, nil, network.DirInbound)
+	_, err = ds.GetPeerENR(context.Background(), &ethpb.PeerRequest{PeerId: pid.String()})
+	assert.ErrorContains(t, "No enr is known for the requested peer", err)
+}
+
+func testENR(t *testing.T, entries ...enr.Entry) *enr.Record {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	localNode := enode.NewLocalNode(db, key)
+	localNode.Set(enr.IP(net.ParseIP("10.0.0.1")))
+	localNode.Set(enr.TCP(13000))
+	for _, entry := range entries {
+		localNode.Set(entry)
+	}
+	return localNode.Node().Record()
+}
+
+func testPeerID(t *testing.T) peer.ID {
+	priv, _, err := libp2pcrypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPrivateKey(priv)
+	require.NoError(t, err)
+	return pid
+}
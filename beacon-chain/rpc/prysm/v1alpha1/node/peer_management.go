@@ -0,0 +1,188 @@
+package node
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errPeerManagementDisabled is returned by the peer management endpoints when they are not enabled.
+var errPeerManagementDisabled = status.Error(codes.PermissionDenied, "Peer management endpoints are not enabled")
+
+// ListStaticPeers lists the static peers of the node, which the node keeps reconnecting to.
+func (ns *Server) ListStaticPeers(_ context.Context, _ *empty.Empty) (*ethpb.StaticPeers, error) {
+	if !ns.EnablePeerManagement {
+		return nil, errPeerManagementDisabled
+	}
+	infos := ns.StaticPeerManager.StaticPeers()
+	res := make([]*ethpb.StaticPeer, len(infos))
+	for i, info := range infos {
+		res[i] = staticPeer(info)
+	}
+	return &ethpb.StaticPeers{Peers: res}, nil
+}
+
+// AddStaticPeer adds a static peer from the provided multiaddr or enr.
+func (ns *Server) AddStaticPeer(_ context.Context, req *ethpb.StaticPeerRequest) (*ethpb.StaticPeer, error) {
+	if !ns.EnablePeerManagement {
+		return nil, errPeerManagementDisabled
+	}
+	if req.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "Address of the peer is required")
+	}
+	info, err := ns.StaticPeerManager.AddStaticPeer(req.Address)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Could not add static peer: %v", err)
+	}
+	return staticPeer(info), nil
+}
+
+// RemoveStaticPeer removes the static peer defined by the provided peer id.
+func (ns *Server) RemoveStaticPeer(_ context.Context, req *ethpb.PeerRequest) (*empty.Empty, error) {
+	if !ns.EnablePeerManagement {
+		return nil, errPeerManagementDisabled
+	}
+	pid, err := peer.Decode(req.PeerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to parse provided peer id: %v", err)
+	}
+	if !ns.StaticPeerManager.RemoveStaticPeer(pid) {
+		return nil, status.Error(codes.NotFound, "Requested peer is not a static peer")
+	}
+	return &empty.Empty{}, nil
+}
+
+// ListTrustedPeers lists the trusted peers of the node, which are exempt from peer scoring and pruning.
+func (ns *Server) ListTrustedPeers(_ context.Context, _ *empty.Empty) (*ethpb.TrustedPeers, error) {
+	if !ns.EnablePeerManagement {
+		return nil, errPeerManagementDisabled
+	}
+	pids := ns.StaticPeerManager.TrustedPeers()
+	res := make([]string, len(pids))
+	for i, pid := range pids {
+		res[i] = pid.String()
+	}
+	return &ethpb.TrustedPeers{PeerIds: res}, nil
+}
+
+// AddTrustedPeer marks the peer defined by the provided peer id as trusted.
+func (ns *Server) AddTrustedPeer(_ context.Context, req *ethpb.PeerRequest) (*empty.Empty, error) {
+	if !ns.EnablePeerManagement {
+		return nil, errPeerManagementDisabled
+	}
+	pid, err := peer.Decode(req.PeerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to parse provided peer id: %v", err)
+	}
+	ns.StaticPeerManager.AddTrustedPeer(pid)
+	return &empty.Empty{}, nil
+}
+
+// RemoveTrustedPeer removes the trusted mark of the peer defined by the provided peer id.
+func (ns *Server) RemoveTrustedPeer(_ context.Context, req *ethpb.PeerRequest) (*empty.Empty, error) {
+	if !ns.EnablePeerManagement {
+		return nil, errPeerManagementDisabled
+	}
+	pid, err := peer.Decode(req.PeerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to parse provided peer id: %v", err)
+	}
+	ns.StaticPeerManager.RemoveTrustedPeer(pid)
+	return &empty.Empty{}, nil
+}
+
+// ListBannedPeers lists the banned peers of the node.
+func (ns *Server) ListBannedPeers(_ context.Context, _ *empty.Empty) (*ethpb.BannedPeers, error) {
+	if !ns.EnablePeerManagement {
+		return nil, errPeerManagementDisabled
+	}
+	pids := ns.BanManager.BannedPeers()
+	res := make([]string, len(pids))
+	for i, pid := range pids {
+		res[i] = pid.String()
+	}
+	return &ethpb.BannedPeers{PeerIds: res}, nil
+}
+
+// BanPeer bans the peer defined by the provided peer id. The node disconnects from a peer when
+// it is banned.
+func (ns *Server) BanPeer(_ context.Context, req *ethpb.PeerRequest) (*empty.Empty, error) {
+	if !ns.EnablePeerManagement {
+		return nil, errPeerManagementDisabled
+	}
+	pid, err := peer.Decode(req.PeerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to parse provided peer id: %v", err)
+	}
+	if err := ns.BanManager.BanPeer(pid); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not ban peer: %v", err)
+	}
+	return &empty.Empty{}, nil
+}
+
+// UnbanPeer lifts the ban of the peer defined by the provided peer id.
+func (ns *Server) UnbanPeer(_ context.Context, req *ethpb.PeerRequest) (*empty.Empty, error) {
+	if !ns.EnablePeerManagement {
+		return nil, errPeerManagementDisabled
+	}
+	pid, err := peer.Decode(req.PeerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to parse provided peer id: %v", err)
+	}
+	ok, err := ns.BanManager.UnbanPeer(pid)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not unban peer: %v", err)
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, "Requested peer is not banned")
+	}
+	return &empty.Empty{}, nil
+}
+
+// ListBannedNetworks lists the banned ip ranges of the node.
+func (ns *Server) ListBannedNetworks(_ context.Context, _ *empty.Empty) (*ethpb.BannedNetworks, error) {
+	if !ns.EnablePeerManagement {
+		return nil, errPeerManagementDisabled
+	}
+	return &ethpb.BannedNetworks{Cidrs: ns.BanManager.BannedNetworks()}, nil
+}
+
+// BanNetwork bans the provided ip range, and returns it in its canonical form. The node
+// disconnects from the peers within a range when it is banned.
+func (ns *Server) BanNetwork(_ context.Context, req *ethpb.Network) (*ethpb.Network, error) {
+	if !ns.EnablePeerManagement {
+		return nil, errPeerManagementDisabled
+	}
+	cidr, err := ns.BanManager.BanNetwork(req.Cidr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Could not ban network: %v", err)
+	}
+	return &ethpb.Network{Cidr: cidr}, nil
+}
+
+// UnbanNetwork lifts the ban of the provided ip range.
+func (ns *Server) UnbanNetwork(_ context.Context, req *ethpb.Network) (*empty.Empty, error) {
+	if !ns.EnablePeerManagement {
+		return nil, errPeerManagementDisabled
+	}
+	ok, err := ns.BanManager.UnbanNetwork(req.Cidr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Could not unban network: %v", err)
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, "Requested network is not banned")
+	}
+	return &empty.Empty{}, nil
+}
+
+func staticPeer(info peer.AddrInfo) *ethpb.StaticPeer {
+	addrs := make([]string, len(info.Addrs))
+	for i, addr := range info.Addrs {
+		addrs[i] = addr.String()
+	}
+	return &ethpb.StaticPeer{PeerId: info.ID.String(), Addresses: addrs}
+}
@@ -0,0 +1,241 @@
+package node
+
+import (
+	"context"
+	"crypto/rand"
+	"net"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type mockStaticPeerManager struct {
+	static  map[peer.ID]peer.AddrInfo
+	trusted map[peer.ID]bool
+}
+
+func (m *mockStaticPeerManager) AddStaticPeer(addr string) (peer.AddrInfo, error) {
+	a, err := ma.NewMultiaddr(addr)
+	if err != nil {
+		return peer.AddrInfo{}, err
+	}
+	info, err := peer.AddrInfoFromP2pAddr(a)
+	if err != nil {
+		return peer.AddrInfo{}, err
+	}
+	m.static[info.ID] = *info
+	return *info, nil
+}
+
+func (m *mockStaticPeerManager) RemoveStaticPeer(pid peer.ID) bool {
+	_, ok := m.static[pid]
+	delete(m.static, pid)
+	return ok
+}
+
+func (m *mockStaticPeerManager) StaticPeers() []peer.AddrInfo {
+	infos := make([]peer.AddrInfo, 0, len(m.static))
+	for _, info := range m.static {
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+func (m *mockStaticPeerManager) AddTrustedPeer(pid peer.ID) {
+	m.trusted[pid] = true
+}
+
+func (m *mockStaticPeerManager) RemoveTrustedPeer(pid peer.ID) {
+	delete(m.trusted, pid)
+}
+
+func (m *mockStaticPeerManager) TrustedPeers() []peer.ID {
+	pids := make([]peer.ID, 0, len(m.trusted))
+	for pid := range m.trusted {
+		pids = append(pids, pid)
+	}
+	return pids
+}
+
+type mockBanManager struct {
+	peers    map[peer.ID]bool
+	networks map[string]bool
+}
+
+func (m *mockBanManager) BanPeer(pid peer.ID) error {
+	m.peers[pid] = true
+	return nil
+}
+
+func (m *mockBanManager) UnbanPeer(pid peer.ID) (bool, error) {
+	ok := m.peers[pid]
+	delete(m.peers, pid)
+	return ok, nil
+}
+
+func (m *mockBanManager) BannedPeers() []peer.ID {
+	pids := make([]peer.ID, 0, len(m.peers))
+	for pid := range m.peers {
+		pids = append(pids, pid)
+	}
+	return pids
+}
+
+func (m *mockBanManager) BanNetwork(cidr string) (string, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+	m.networks[ipNet.String()] = true
+	return ipNet.String(), nil
+}
+
+func (m *mockBanManager) UnbanNetwork(cidr string) (bool, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false, err
+	}
+	ok := m.networks[ipNet.String()]
+	delete(m.networks, ipNet.String())
+	return ok, nil
+}
+
+func (m *mockBanManager) BannedNetworks() []string {
+	cidrs := make([]string, 0, len(m.networks))
+	for cidr := range m.networks {
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs
+}
+
+func TestServer_StaticPeers(t *testing.T) {
+	ctx := context.Background()
+	pm := &mockStaticPeerManager{static: make(map[peer.ID]peer.AddrInfo), trusted: make(map[peer.ID]bool)}
+	ns := &Server{StaticPeerManager: pm, EnablePeerManagement: true}
+	pid := testPeerID(t)
+
+	_, err := ns.AddStaticPeer(ctx, &ethpb.StaticPeerRequest{Address: "/ip4/127.0.0.1/tcp/13000"})
+	assert.ErrorContains(t, "Could not add static peer", err)
+
+	res, err := ns.AddStaticPeer(ctx, &ethpb.StaticPeerRequest{Address: "/ip4/127.0.0.1/tcp/13000/p2p/" + pid.String()})
+	require.NoError(t, err)
+	assert.Equal(t, pid.String(), res.PeerId)
+	assert.DeepEqual(t, []string{"/ip4/127.0.0.1/tcp/13000"}, res.Addresses)
+
+	peers, err := ns.ListStaticPeers(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(peers.Peers))
+	assert.Equal(t, pid.String(), peers.Peers[0].PeerId)
+
+	_, err = ns.RemoveStaticPeer(ctx, &ethpb.PeerRequest{PeerId: pid.String()})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(pm.static))
+	_, err = ns.RemoveStaticPeer(ctx, &ethpb.PeerRequest{PeerId: pid.String()})
+	assert.ErrorContains(t, "Requested peer is not a static peer", err)
+}
+
+func TestServer_TrustedPeers(t *testing.T) {
+	ctx := context.Background()
+	pm := &mockStaticPeerManager{static: make(map[peer.ID]peer.AddrInfo), trusted: make(map[peer.ID]bool)}
+	ns := &Server{StaticPeerManager: pm, EnablePeerManagement: true}
+	pid := testPeerID(t)
+
+	_, err := ns.AddTrustedPeer(ctx, &ethpb.PeerRequest{PeerId: "foo"})
+	assert.ErrorContains(t, "Unable to parse provided peer id", err)
+
+	_, err = ns.AddTrustedPeer(ctx, &ethpb.PeerRequest{PeerId: pid.String()})
+	require.NoError(t, err)
+	assert.Equal(t, true, pm.trusted[pid])
+
+	peers, err := ns.ListTrustedPeers(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{pid.String()}, peers.PeerIds)
+
+	_, err = ns.RemoveTrustedPeer(ctx, &ethpb.PeerRequest{PeerId: pid.String()})
+	require.NoError(t, err)
+	assert.Equal(t, false, pm.trusted[pid])
+}
+
+func TestServer_BannedPeers(t *testing.T) {
+	ctx := context.Background()
+	bm := &mockBanManager{peers: make(map[peer.ID]bool), networks: make(map[string]bool)}
+	ns := &Server{BanManager: bm, EnablePeerManagement: true}
+	pid := testPeerID(t)
+
+	_, err := ns.BanPeer(ctx, &ethpb.PeerRequest{PeerId: "foo"})
+	assert.ErrorContains(t, "Unable to parse provided peer id", err)
+
+	_, err = ns.BanPeer(ctx, &ethpb.PeerRequest{PeerId: pid.String()})
+	require.NoError(t, err)
+	assert.Equal(t, true, bm.peers[pid])
+
+	peers, err := ns.ListBannedPeers(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{pid.String()}, peers.PeerIds)
+
+	_, err = ns.UnbanPeer(ctx, &ethpb.PeerRequest{PeerId: pid.String()})
+	require.NoError(t, err)
+	assert.Equal(t, false, bm.peers[pid])
+	_, err = ns.UnbanPeer(ctx, &ethpb.PeerRequest{PeerId: pid.String()})
+	assert.ErrorContains(t, "Requested peer is not banned", err)
+}
+
+func TestServer_BannedNetworks(t *testing.T) {
+	ctx := context.Background()
+	bm := &mockBanManager{peers: make(map[peer.ID]bool), networks: make(map[string]bool)}
+	ns := &Server{BanManager: bm, EnablePeerManagement: true}
+
+	_, err := ns.BanNetwork(ctx, &ethpb.Network{Cidr: "10.0.0.1"})
+	assert.ErrorContains(t, "Could not ban network", err)
+
+	res, err := ns.BanNetwork(ctx, &ethpb.Network{Cidr: "10.0.0.1/8"})
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/8", res.Cidr)
+
+	networks, err := ns.ListBannedNetworks(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{"10.0.0.0/8"}, networks.Cidrs)
+
+	_, err = ns.UnbanNetwork(ctx, &ethpb.Network{Cidr: "10.0.0.0/8"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(bm.networks))
+	_, err = ns.UnbanNetwork(ctx, &ethpb.Network{Cidr: "10.0.0.0/8"})
+	assert.ErrorContains(t, "Requested network is not banned", err)
+}
+
+func TestServer_PeerManagementDisabled(t *testing.T) {
+	ctx := context.Background()
+	pm := &mockStaticPeerManager{static: make(map[peer.ID]peer.AddrInfo), trusted: make(map[peer.ID]bool)}
+	bm := &mockBanManager{peers: make(map[peer.ID]bool), networks: make(map[string]bool)}
+	ns := &Server{StaticPeerManager: pm, BanManager: bm}
+	pid := testPeerID(t)
+
+	_, err := ns.AddStaticPeer(ctx, &ethpb.StaticPeerRequest{Address: "/ip4/127.0.0.1/tcp/13000/p2p/" + pid.String()})
+	assert.ErrorContains(t, "Peer management endpoints are not enabled", err)
+	_, err = ns.AddTrustedPeer(ctx, &ethpb.PeerRequest{PeerId: pid.String()})
+	assert.ErrorContains(t, "Peer management endpoints are not enabled", err)
+	_, err = ns.ListStaticPeers(ctx, &emptypb.Empty{})
+	assert.ErrorContains(t, "Peer management endpoints are not enabled", err)
+	_, err = ns.BanPeer(ctx, &ethpb.PeerRequest{PeerId: pid.String()})
+	assert.ErrorContains(t, "Peer management endpoints are not enabled", err)
+	_, err = ns.BanNetwork(ctx, &ethpb.Network{Cidr: "10.0.0.0/8"})
+	assert.ErrorContains(t, "Peer management endpoints are not enabled", err)
+	assert.Equal(t, 0, len(pm.static))
+	assert.Equal(t, 0, len(pm.trusted))
+	assert.Equal(t, 0, len(bm.peers))
+	assert.Equal(t, 0, len(bm.networks))
+}
+
+func testPeerID(t *testing.T) peer.ID {
+	priv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPrivateKey(priv)
+	require.NoError(t, err)
+	return pid
+}
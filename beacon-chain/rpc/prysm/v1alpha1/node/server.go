@@ -17,7 +17,9 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/backfill"
 	"github.com/prysmaticlabs/prysm/io/logs"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/runtime/version"
@@ -38,9 +40,16 @@ type Server struct {
 	BeaconDB             db.ReadOnlyDatabase
 	PeersFetcher         p2p.PeersProvider
 	PeerManager          p2p.PeerManager
+	StaticPeerManager    p2p.StaticPeerManager
+	BanManager           p2p.BanManager
+	EnablePeerManagement bool
 	GenesisTimeFetcher   blockchain.TimeFetcher
 	GenesisFetcher       blockchain.GenesisFetcher
 	POWChainInfoFetcher  powchain.ChainInfoFetcher
+	HeadFetcher          blockchain.HeadFetcher
+	BackfillChecker      backfill.Checker
+	EngineLatencyFetcher engine.LatencyFetcher
+	EngineConnChecker    engine.ConnectionChecker
 	BeaconMonitoringHost string
 	BeaconMonitoringPort int
 }
@@ -241,6 +250,64 @@ func (ns *Server) GetETH1ConnectionStatus(ctx context.Context, _ *empty.Empty) (
 	}, nil
 }
 
+// GetHealth reports the stage the node is in, from the most severe of the conditions
+// which affect the data it serves: an offline execution client, syncing, an optimistic
+// head or backfilling.
+func (ns *Server) GetHealth(ctx context.Context, _ *empty.Empty) (*ethpb.NodeHealth, error) {
+	if !ns.SyncChecker.Synced() && !ns.SyncChecker.Syncing() && !ns.SyncChecker.Initialized() {
+		return nil, status.Error(codes.Unavailable, "Node not initialized or having issues")
+	}
+	optimistic, err := ns.HeadFetcher.IsOptimistic(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not check if head is optimistic: %v", err)
+	}
+	health := &ethpb.NodeHealth{
+		Syncing:    !ns.SyncChecker.Synced(),
+		Optimistic: optimistic,
+		// The execution client is only reported offline when one is configured.
+		ExecutionClientOffline: ns.EngineConnChecker != nil && !ns.EngineConnChecker.IsConnected(),
+		Backfilling:            ns.BackfillChecker != nil && ns.BackfillChecker.Backfilling(),
+		EngineLatencies:        ns.engineLatencies(),
+	}
+	if headSlot, currentSlot := ns.HeadFetcher.HeadSlot(), ns.GenesisTimeFetcher.CurrentSlot(); currentSlot > headSlot {
+		health.SyncDistance = currentSlot - headSlot
+	}
+	switch {
+	case health.ExecutionClientOffline:
+		health.Stage = ethpb.NodeHealth_EXECUTION_CLIENT_OFFLINE
+	case health.Syncing:
+		health.Stage = ethpb.NodeHealth_SYNCING
+	case health.Optimistic:
+		health.Stage = ethpb.NodeHealth_OPTIMISTIC
+	case health.Backfilling:
+		health.Stage = ethpb.NodeHealth_BACKFILLING
+	default:
+		health.Stage = ethpb.NodeHealth_READY
+	}
+	return health, nil
+}
+
+// Returns the latency of the most recent calls of every engine API method, sorted by method.
+func (ns *Server) engineLatencies() []*ethpb.EngineMethodLatency {
+	if ns.EngineLatencyFetcher == nil {
+		return nil
+	}
+	stats := ns.EngineLatencyFetcher.LatencyStats()
+	res := make([]*ethpb.EngineMethodLatency, 0, len(stats))
+	for method, s := range stats {
+		res = append(res, &ethpb.EngineMethodLatency{
+			Method:    method,
+			Samples:   uint64(s.Samples),
+			P95Micros: uint64(s.P95.Microseconds()),
+			MaxMicros: uint64(s.Max.Microseconds()),
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Method < res[j].Method
+	})
+	return res
+}
+
 // StreamBeaconLogs from the beacon node via a gRPC server-side stream.
 func (ns *Server) StreamBeaconLogs(_ *empty.Empty, stream ethpb.Health_StreamBeaconLogsServer) error {
 	ch := make(chan []byte, ns.StreamLogsBufferSize)
@@ -9,10 +9,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	types "github.com/prysmaticlabs/eth2-types"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	mockP2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/testutil"
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
@@ -174,3 +176,100 @@ func TestNodeServer_GetETH1ConnectionStatus(t *testing.T) {
 	assert.DeepSSZEqual(t, eps, res.Addresses)
 	assert.DeepSSZEqual(t, errStrs, res.ConnectionErrors)
 }
+
+type mockBackfillChecker struct {
+	backfilling bool
+}
+
+func (m *mockBackfillChecker) Backfilling() bool {
+	return m.backfilling
+}
+
+type mockConnChecker struct {
+	connected bool
+}
+
+func (m *mockConnChecker) IsConnected() bool {
+	return m.connected
+}
+
+type mockLatencyFetcher struct {
+	stats map[string]engine.LatencyStats
+}
+
+func (m *mockLatencyFetcher) LatencyStats() map[string]engine.LatencyStats {
+	return m.stats
+}
+
+func TestNodeServer_GetHealth(t *testing.T) {
+	headState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, headState.SetSlot(10))
+	currentSlot := types.Slot(12)
+	tests := []struct {
+		name         string
+		synced       bool
+		optimistic   bool
+		disconnected bool
+		backfilling  bool
+		want         ethpb.NodeHealth_Stage
+	}{
+		{name: "ready", synced: true, want: ethpb.NodeHealth_READY},
+		{name: "backfilling", synced: true, backfilling: true, want: ethpb.NodeHealth_BACKFILLING},
+		{name: "optimistic", synced: true, optimistic: true, backfilling: true, want: ethpb.NodeHealth_OPTIMISTIC},
+		{name: "syncing", optimistic: true, want: ethpb.NodeHealth_SYNCING},
+		{name: "execution client offline", disconnected: true, want: ethpb.NodeHealth_EXECUTION_CLIENT_OFFLINE},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := &Server{
+				SyncChecker:        &mockSync.Sync{IsSynced: tt.synced, IsInitialized: true},
+				HeadFetcher:        &mock.ChainService{State: headState, Optimistic: tt.optimistic},
+				GenesisTimeFetcher: &mock.ChainService{Slot: &currentSlot},
+				EngineConnChecker:  &mockConnChecker{connected: !tt.disconnected},
+				BackfillChecker:    &mockBackfillChecker{backfilling: tt.backfilling},
+			}
+			res, err := ns.GetHealth(context.Background(), &emptypb.Empty{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, res.Stage)
+			assert.Equal(t, !tt.synced, res.Syncing)
+			assert.Equal(t, tt.optimistic, res.Optimistic)
+			assert.Equal(t, tt.disconnected, res.ExecutionClientOffline)
+			assert.Equal(t, tt.backfilling, res.Backfilling)
+			assert.Equal(t, types.Slot(2), res.SyncDistance)
+		})
+	}
+
+	t.Run("not initialized", func(t *testing.T) {
+		ns := &Server{SyncChecker: &mockSync.Sync{}}
+		_, err := ns.GetHealth(context.Background(), &emptypb.Empty{})
+		assert.ErrorContains(t, "Node not initialized or having issues", err)
+	})
+	t.Run("no execution client", func(t *testing.T) {
+		ns := &Server{
+			SyncChecker:        &mockSync.Sync{IsSynced: true},
+			HeadFetcher:        &mock.ChainService{State: headState},
+			GenesisTimeFetcher: &mock.ChainService{Slot: &currentSlot},
+		}
+		res, err := ns.GetHealth(context.Background(), &emptypb.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, ethpb.NodeHealth_READY, res.Stage)
+	})
+	t.Run("engine latencies", func(t *testing.T) {
+		ns := &Server{
+			SyncChecker:        &mockSync.Sync{IsSynced: true},
+			HeadFetcher:        &mock.ChainService{State: headState},
+			GenesisTimeFetcher: &mock.ChainService{Slot: &currentSlot},
+			EngineLatencyFetcher: &mockLatencyFetcher{stats: map[string]engine.LatencyStats{
+				engine.NewPayloadMethod:        {Samples: 20, P95: 1500 * time.Millisecond, Max: 3 * time.Second},
+				engine.ForkchoiceUpdatedMethod: {Samples: 10, P95: 40 * time.Microsecond, Max: time.Millisecond},
+			}},
+		}
+		res, err := ns.GetHealth(context.Background(), &emptypb.Empty{})
+		require.NoError(t, err)
+		require.DeepEqual(t, []*ethpb.EngineMethodLatency{
+			{Method: engine.ForkchoiceUpdatedMethod, Samples: 10, P95Micros: 40, MaxMicros: 1000},
+			{Method: engine.NewPayloadMethod, Samples: 20, P95Micros: 1500000, MaxMicros: 3000000},
+		}, res.EngineLatencies)
+	})
+}
@@ -133,6 +133,11 @@ func (vs *Server) duties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.
 			return nil, status.Errorf(codes.Internal, "Could not process slots up to %d: %v", epochStartSlot, err)
 		}
 	}
+	// The dependent roots are computed first, as computing the assignments moves the slot of the state.
+	previousDependentRoot, currentDependentRoot, err := vs.dutyDependentRoots(ctx, s, req.Epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute duty dependent roots: %v", err)
+	}
 	committeeAssignments, proposerIndexToSlots, err := helpers.CommitteeAssignments(ctx, s, req.Epoch)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not compute committee assignments: %v", err)
@@ -225,12 +230,42 @@ func (vs *Server) duties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.
 	}
 
 	return &ethpb.DutiesResponse{
-		Duties:             validatorAssignments,
-		CurrentEpochDuties: validatorAssignments,
-		NextEpochDuties:    nextValidatorAssignments,
+		Duties:                    validatorAssignments,
+		CurrentEpochDuties:        validatorAssignments,
+		NextEpochDuties:           nextValidatorAssignments,
+		PreviousDutyDependentRoot: previousDependentRoot,
+		CurrentDutyDependentRoot:  currentDependentRoot,
 	}, nil
 }
 
+// dutyDependentRoots returns the roots of the blocks the duties of the given epoch depend on: the
+// previous duty dependent root for the attester duties of the epoch, and the current duty dependent
+// root for its proposer duties and the attester duties of the next epoch. The state must be at or
+// after the start slot of the epoch.
+func (vs *Server) dutyDependentRoots(ctx context.Context, s beaconState.BeaconState, epoch types.Epoch) ([]byte, []byte, error) {
+	if s.Slot() == 0 {
+		// The state has no block roots yet, and the head is the genesis block all duties depend on.
+		root, err := vs.HeadFetcher.HeadRoot(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		return root, root, nil
+	}
+	var previousEpoch types.Epoch
+	if epoch > 0 {
+		previousEpoch = epoch - 1
+	}
+	previousRoot, err := helpers.DutyDependentRoot(s, previousEpoch)
+	if err != nil {
+		return nil, nil, err
+	}
+	currentRoot, err := helpers.DutyDependentRoot(s, epoch)
+	if err != nil {
+		return nil, nil, err
+	}
+	return previousRoot, currentRoot, nil
+}
+
 // AssignValidatorToSubnet checks the status and pubkey of a particular validator
 // to discern whether persistent subnets need to be registered for them.
 func (vs *Server) AssignValidatorToSubnet(pubkey []byte, status ethpb.ValidatorStatus) {
@@ -350,6 +350,50 @@ func TestGetDuties_MultipleKeys_OK(t *testing.T) {
 	assert.Equal(t, types.Slot(4), res.CurrentEpochDuties[1].AttesterSlot)
 }
 
+func TestGetDuties_DependentRoots(t *testing.T) {
+	deposits, _, err := util.DeterministicDepositsAndKeys(64)
+	require.NoError(t, err)
+	eth1Data, err := util.DeterministicEth1Data(len(deposits))
+	require.NoError(t, err)
+	bs, err := transition.GenesisBeaconState(context.Background(), deposits, 0, eth1Data)
+	require.NoError(t, err, "Could not setup genesis bs")
+	genesisRoot, err := util.NewBeaconBlock().Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	chain := &mockChain.ChainService{
+		State: bs, Root: genesisRoot[:], Genesis: time.Now(),
+	}
+	vs := &Server{
+		HeadFetcher: chain,
+		TimeFetcher: chain,
+		SyncChecker: &mockSync.Sync{IsSyncing: false},
+	}
+	req := &ethpb.DutiesRequest{
+		PublicKeys: [][]byte{deposits[0].Data.PublicKey},
+	}
+
+	t.Run("genesis", func(t *testing.T) {
+		res, err := vs.GetDuties(context.Background(), req)
+		require.NoError(t, err)
+		assert.DeepEqual(t, genesisRoot[:], res.PreviousDutyDependentRoot)
+		assert.DeepEqual(t, genesisRoot[:], res.CurrentDutyDependentRoot)
+	})
+	t.Run("epoch 2", func(t *testing.T) {
+		slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+		require.NoError(t, bs.SetSlot(2*slotsPerEpoch+3))
+		for i := uint64(0); i < uint64(2*slotsPerEpoch+3); i++ {
+			require.NoError(t, bs.UpdateBlockRootAtIndex(i, bytesutil.ToBytes32([]byte{byte(i)})))
+		}
+		currentSlot := 2*slotsPerEpoch + 3
+		chain.Slot = &currentSlot
+		req.Epoch = 2
+		res, err := vs.GetDuties(context.Background(), req)
+		require.NoError(t, err)
+		assert.DeepEqual(t, bytesutil.PadTo([]byte{byte(slotsPerEpoch - 1)}, 32), res.PreviousDutyDependentRoot)
+		assert.DeepEqual(t, bytesutil.PadTo([]byte{byte(2*slotsPerEpoch - 1)}, 32), res.CurrentDutyDependentRoot)
+	})
+}
+
 func TestGetDuties_SyncNotReady(t *testing.T) {
 	vs := &Server{
 		SyncChecker: &mockSync.Sync{IsSyncing: true},
@@ -379,6 +423,9 @@ func TestStreamDuties_OK(t *testing.T) {
 	require.NoError(t, err, "Could not setup genesis bs")
 	genesisRoot, err := genesis.Block.HashTreeRoot()
 	require.NoError(t, err, "Could not get signing root")
+	// Computing duties moves the slot of the mocked head state, after which
+	// the genesis root is read from the block roots like on a real chain.
+	require.NoError(t, bs.UpdateBlockRootAtIndex(0, genesisRoot))
 
 	pubKeys := make([][]byte, len(deposits))
 	indices := make([]uint64, len(deposits))
@@ -436,6 +483,9 @@ func TestStreamDuties_OK_ChainReorg(t *testing.T) {
 	require.NoError(t, err, "Could not setup genesis bs")
 	genesisRoot, err := genesis.Block.HashTreeRoot()
 	require.NoError(t, err, "Could not get signing root")
+	// Computing duties moves the slot of the mocked head state, after which
+	// the genesis root is read from the block roots like on a real chain.
+	require.NoError(t, bs.UpdateBlockRootAtIndex(0, genesisRoot))
 
 	pubKeys := make([][]byte, len(deposits))
 	indices := make([]uint64, len(deposits))
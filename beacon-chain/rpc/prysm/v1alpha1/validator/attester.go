@@ -41,6 +41,9 @@ func (vs *Server) GetAttestationData(ctx context.Context, req *ethpb.Attestation
 		params.BeaconNetworkConfig().MaximumGossipClockDisparity); err != nil {
 		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid request: %v", err))
 	}
+	if err := vs.checkOptimistic(ctx); err != nil {
+		return nil, err
+	}
 
 	res, err := vs.AttestationCache.Get(ctx, req)
 	if err != nil {
@@ -230,6 +233,7 @@ func (vs *Server) SubscribeCommitteeSubnets(ctx context.Context, req *ethpb.Comm
 		cache.SubnetIDs.AddAttesterSubnetID(req.Slots[i], subnet)
 		if req.IsAggregator[i] {
 			cache.SubnetIDs.AddAggregatorSubnetID(req.Slots[i], subnet)
+			vs.PeerManager.FindAggregatorSubnetPeers(subnet, req.Slots[i])
 		}
 	}
 
@@ -1,15 +1,22 @@
 package validator
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition/interop"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	enginev1 "github.com/prysmaticlabs/prysm/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/runtime/version"
 )
 
 func (vs *Server) getBellatrixBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (*ethpb.BeaconBlockBellatrix, error) {
@@ -18,6 +25,11 @@ func (vs *Server) getBellatrixBeaconBlock(ctx context.Context, req *ethpb.BlockR
 		return nil, err
 	}
 
+	payload, err := vs.getExecutionPayload(ctx, req.Slot, bytesutil.ToBytes32(altairBlk.ParentRoot))
+	if err != nil {
+		return nil, err
+	}
+
 	blk := &ethpb.BeaconBlockBellatrix{
 		Slot:          altairBlk.Slot,
 		ProposerIndex: altairBlk.ProposerIndex,
@@ -33,16 +45,7 @@ func (vs *Server) getBellatrixBeaconBlock(ctx context.Context, req *ethpb.BlockR
 			Deposits:          altairBlk.Body.Deposits,
 			VoluntaryExits:    altairBlk.Body.VoluntaryExits,
 			SyncAggregate:     altairBlk.Body.SyncAggregate,
-			ExecutionPayload: &enginev1.ExecutionPayload{
-				ParentHash:    make([]byte, fieldparams.RootLength),
-				FeeRecipient:  make([]byte, fieldparams.FeeRecipientLength),
-				StateRoot:     make([]byte, fieldparams.RootLength),
-				ReceiptsRoot:  make([]byte, fieldparams.RootLength),
-				LogsBloom:     make([]byte, fieldparams.LogsBloomLength),
-				Random:        make([]byte, fieldparams.RootLength),
-				BaseFeePerGas: make([]byte, fieldparams.RootLength),
-				BlockHash:     make([]byte, fieldparams.RootLength),
-			}, // TODO(9853) Insert real execution payload.
+			ExecutionPayload:  payload,
 		},
 	}
 	// Compute state root with the newly constructed block.
@@ -60,3 +63,79 @@ func (vs *Server) getBellatrixBeaconBlock(ctx context.Context, req *ethpb.BlockR
 	blk.StateRoot = stateRoot
 	return blk, nil
 }
+
+// Returns the execution payload of the block proposed at the given slot on top of the given parent
+// block. The payload is the one the execution node started building when the payload attributes of
+// the proposal were sent, retrieved with the payload ID cached at that time. An empty payload is
+// proposed until the merge transition is complete.
+func (vs *Server) getExecutionPayload(ctx context.Context, slot types.Slot, parentRoot [32]byte) (*enginev1.ExecutionPayload, error) {
+	st, err := vs.parentState(ctx, parentRoot)
+	if err != nil {
+		return nil, err
+	}
+	if st.Version() < version.Bellatrix {
+		return emptyPayload(), nil
+	}
+	complete, err := blocks.MergeTransitionComplete(st)
+	if err != nil {
+		return nil, err
+	}
+	if !complete {
+		return emptyPayload(), nil
+	}
+	if vs.ExecutionEngineCaller == nil || vs.PayloadIDCache == nil {
+		return nil, errors.New("no execution node to build the execution payload")
+	}
+	feeRecipient := params.BeaconConfig().FeeRecipient
+	id, ok := vs.PayloadIDCache.PayloadID(slot, parentRoot, feeRecipient)
+	if !ok {
+		// The payload was not prepared ahead of the proposal, so request it now. Its ID is cached,
+		// so further proposals for the slot reuse it.
+		if vs.PayloadPreparer == nil {
+			return nil, errors.Errorf("no execution payload is being built for slot %d on top of %#x", slot, parentRoot)
+		}
+		id, err = vs.PayloadPreparer.PreparePayload(ctx, slot, parentRoot, st)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not prepare execution payload")
+		}
+	}
+	payload, err := vs.ExecutionEngineCaller.GetPayload(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get execution payload")
+	}
+	return payload, nil
+}
+
+// Returns the state of the parent block of a proposal, which is the head state unless the proposal
+// reorgs the head out.
+func (vs *Server) parentState(ctx context.Context, parentRoot [32]byte) (state.BeaconState, error) {
+	headRoot, err := vs.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head root")
+	}
+	if bytes.Equal(headRoot, parentRoot[:]) {
+		st, err := vs.HeadFetcher.HeadState(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get head state")
+		}
+		return st, nil
+	}
+	st, err := vs.StateGen.StateByRoot(ctx, parentRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get parent state")
+	}
+	return st, nil
+}
+
+func emptyPayload() *enginev1.ExecutionPayload {
+	return &enginev1.ExecutionPayload{
+		ParentHash:    make([]byte, fieldparams.RootLength),
+		FeeRecipient:  make([]byte, fieldparams.FeeRecipientLength),
+		StateRoot:     make([]byte, fieldparams.RootLength),
+		ReceiptsRoot:  make([]byte, fieldparams.RootLength),
+		LogsBloom:     make([]byte, fieldparams.LogsBloomLength),
+		Random:        make([]byte, fieldparams.RootLength),
+		BaseFeePerGas: make([]byte, fieldparams.RootLength),
+		BlockHash:     make([]byte, fieldparams.RootLength),
+	}
+}
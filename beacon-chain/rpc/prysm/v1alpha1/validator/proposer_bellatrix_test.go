@@ -0,0 +1,253 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+	mockEngine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1/testing"
+	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	mockstategen "github.com/prysmaticlabs/prysm/beacon-chain/state/stategen/mock"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+// Returns a Bellatrix head state, after the merge transition if mergeComplete is set.
+func bellatrixHeadState(t *testing.T, mergeComplete bool) state.BeaconState {
+	st, _ := util.DeterministicGenesisStateBellatrix(t, 64)
+	if mergeComplete {
+		header, err := st.LatestExecutionPayloadHeader()
+		require.NoError(t, err)
+		header.BlockHash = bytesutil.PadTo([]byte("head"), 32)
+		require.NoError(t, st.SetLatestExecutionPayloadHeader(header))
+	}
+	return st
+}
+
+// Prepares payloads with a fixed ID, caching it like the payload preparation service.
+type mockPayloadPreparer struct {
+	payloadIDs  *cache.PayloadIDCache
+	calls       int
+	parentState state.BeaconState
+}
+
+func (m *mockPayloadPreparer) PreparePayload(
+	_ context.Context, slot types.Slot, parentRoot [32]byte, parentState state.BeaconState,
+) (cache.PayloadID, error) {
+	m.calls++
+	m.parentState = parentState
+	id := cache.PayloadID{2}
+	m.payloadIDs.SetPayloadID(slot, parentRoot, params.BeaconConfig().FeeRecipient, id)
+	return id, nil
+}
+
+func TestServer_getExecutionPayload(t *testing.T) {
+	ctx := context.Background()
+	parentRoot := bytesutil.ToBytes32([]byte("parent"))
+	built := &enginev1.ExecutionPayload{BlockHash: bytesutil.PadTo([]byte("built"), 32)}
+
+	t.Run("empty payload before the merge", func(t *testing.T) {
+		client := &mockEngine.EngineClient{ExecutionPayload: built}
+		vs := &Server{
+			HeadFetcher:           &mock.ChainService{State: bellatrixHeadState(t, false), Root: parentRoot[:]},
+			ExecutionEngineCaller: client,
+			PayloadIDCache:        cache.NewPayloadIDCache(),
+		}
+		payload, err := vs.getExecutionPayload(ctx, 1, parentRoot)
+		require.NoError(t, err)
+		require.DeepEqual(t, emptyPayload(), payload)
+		require.Equal(t, 0, client.Calls(engine.GetPayloadMethod))
+	})
+	t.Run("payload retrieved with the cached payload ID", func(t *testing.T) {
+		client := &mockEngine.EngineClient{ExecutionPayload: built}
+		payloadIDs := cache.NewPayloadIDCache()
+		payloadIDs.SetPayloadID(1, parentRoot, params.BeaconConfig().FeeRecipient, cache.PayloadID{1})
+		vs := &Server{
+			HeadFetcher:           &mock.ChainService{State: bellatrixHeadState(t, true), Root: parentRoot[:]},
+			ExecutionEngineCaller: client,
+			PayloadIDCache:        payloadIDs,
+		}
+		payload, err := vs.getExecutionPayload(ctx, 1, parentRoot)
+		require.NoError(t, err)
+		require.DeepEqual(t, built, payload)
+		require.Equal(t, 1, client.Calls(engine.GetPayloadMethod))
+		require.Equal(t, 0, client.Calls(engine.ForkchoiceUpdatedMethod))
+	})
+	t.Run("payload prepared on a cache miss and reused", func(t *testing.T) {
+		client := &mockEngine.EngineClient{ExecutionPayload: built}
+		payloadIDs := cache.NewPayloadIDCache()
+		preparer := &mockPayloadPreparer{payloadIDs: payloadIDs}
+		vs := &Server{
+			HeadFetcher:           &mock.ChainService{State: bellatrixHeadState(t, true), Root: parentRoot[:]},
+			ExecutionEngineCaller: client,
+			PayloadIDCache:        payloadIDs,
+			PayloadPreparer:       preparer,
+		}
+		payload, err := vs.getExecutionPayload(ctx, 1, parentRoot)
+		require.NoError(t, err)
+		require.DeepEqual(t, built, payload)
+		require.Equal(t, 1, preparer.calls)
+
+		_, err = vs.getExecutionPayload(ctx, 1, parentRoot)
+		require.NoError(t, err)
+		require.Equal(t, 1, preparer.calls)
+		require.Equal(t, 2, client.Calls(engine.GetPayloadMethod))
+	})
+	t.Run("payload prepared on the parent of a reorged head", func(t *testing.T) {
+		client := &mockEngine.EngineClient{ExecutionPayload: built}
+		payloadIDs := cache.NewPayloadIDCache()
+		preparer := &mockPayloadPreparer{payloadIDs: payloadIDs}
+		parent := bellatrixHeadState(t, true)
+		header, err := parent.LatestExecutionPayloadHeader()
+		require.NoError(t, err)
+		header.BlockHash = bytesutil.PadTo([]byte("parent"), 32)
+		require.NoError(t, parent.SetLatestExecutionPayloadHeader(header))
+		stateGen := mockstategen.NewMockService()
+		stateGen.StatesByRoot[parentRoot] = parent
+		vs := &Server{
+			HeadFetcher:           &mock.ChainService{State: bellatrixHeadState(t, true), Root: []byte("head")},
+			StateGen:              stateGen,
+			ExecutionEngineCaller: client,
+			PayloadIDCache:        payloadIDs,
+			PayloadPreparer:       preparer,
+		}
+		_, err = vs.getExecutionPayload(ctx, 1, parentRoot)
+		require.NoError(t, err)
+		require.Equal(t, 1, preparer.calls)
+		preparedHeader, err := preparer.parentState.LatestExecutionPayloadHeader()
+		require.NoError(t, err)
+		require.DeepEqual(t, header.BlockHash, preparedHeader.BlockHash)
+		_, ok := payloadIDs.PayloadID(1, parentRoot, params.BeaconConfig().FeeRecipient)
+		require.Equal(t, true, ok)
+	})
+	t.Run("no payload prepared", func(t *testing.T) {
+		vs := &Server{
+			HeadFetcher:           &mock.ChainService{State: bellatrixHeadState(t, true), Root: parentRoot[:]},
+			ExecutionEngineCaller: &mockEngine.EngineClient{ExecutionPayload: built},
+			PayloadIDCache:        cache.NewPayloadIDCache(),
+		}
+		_, err := vs.getExecutionPayload(ctx, 1, parentRoot)
+		require.ErrorContains(t, "no execution payload is being built", err)
+	})
+	t.Run("no execution node", func(t *testing.T) {
+		vs := &Server{HeadFetcher: &mock.ChainService{State: bellatrixHeadState(t, true), Root: parentRoot[:]}}
+		_, err := vs.getExecutionPayload(ctx, 1, parentRoot)
+		require.ErrorContains(t, "no execution node", err)
+	})
+}
+
+func TestProposer_GetBeaconBlock_ReorgAfterMerge(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	ctx := context.Background()
+	resetCfg := features.InitWithReset(&features.Flags{EnableReorgLateBlocks: true})
+	defer resetCfg()
+	params.SetupTestConfigCleanup(t)
+	cfg := params.MainnetConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	// The parent of the late head block, after the merge.
+	parentState, privKeys := util.DeterministicGenesisStateBellatrix(t, 64)
+	syncCommittee, err := altair.NextSyncCommittee(ctx, parentState)
+	require.NoError(t, err)
+	require.NoError(t, parentState.SetCurrentSyncCommittee(syncCommittee))
+	header, err := parentState.LatestExecutionPayloadHeader()
+	require.NoError(t, err)
+	header.BlockHash = bytesutil.PadTo([]byte("parent"), 32)
+	require.NoError(t, parentState.SetLatestExecutionPayloadHeader(header))
+	stateRoot, err := parentState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	parentBlk := util.NewBeaconBlockBellatrix()
+	parentBlk.Block.StateRoot = stateRoot[:]
+	wsb, err := wrapper.WrappedBellatrixSignedBeaconBlock(parentBlk)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, wsb))
+	parentRoot, err := parentBlk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, parentState, parentRoot))
+
+	headState := parentState.Copy()
+	headHeader, err := headState.LatestExecutionPayloadHeader()
+	require.NoError(t, err)
+	headHeader.BlockHash = bytesutil.PadTo([]byte("head"), 32)
+	require.NoError(t, headState.SetLatestExecutionPayloadHeader(headHeader))
+
+	random, err := helpers.RandaoMix(parentState, 0)
+	require.NoError(t, err)
+	built := &enginev1.ExecutionPayload{
+		ParentHash:    header.BlockHash,
+		FeeRecipient:  make([]byte, fieldparams.FeeRecipientLength),
+		StateRoot:     make([]byte, fieldparams.RootLength),
+		ReceiptsRoot:  make([]byte, fieldparams.RootLength),
+		LogsBloom:     make([]byte, fieldparams.LogsBloomLength),
+		Random:        random,
+		BaseFeePerGas: make([]byte, fieldparams.RootLength),
+		BlockHash:     bytesutil.PadTo([]byte("built"), 32),
+		Timestamp:     parentState.GenesisTime() + params.BeaconConfig().SecondsPerSlot,
+	}
+	payloadIDs := cache.NewPayloadIDCache()
+	preparer := &mockPayloadPreparer{payloadIDs: payloadIDs}
+	proposerServer := &Server{
+		HeadFetcher: &mock.ChainService{
+			State:            headState,
+			Root:             bytesutil.PadTo([]byte("head"), 32),
+			ProposerHeadRoot: parentRoot[:],
+		},
+		SyncChecker:           &mockSync.Sync{IsSyncing: false},
+		BlockReceiver:         &mock.ChainService{},
+		ChainStartFetcher:     &mockPOW.POWChain{},
+		Eth1InfoFetcher:       &mockPOW.POWChain{},
+		Eth1BlockFetcher:      &mockPOW.POWChain{},
+		MockEth1Votes:         true,
+		AttPool:               attestations.NewPool(),
+		SlashingsPool:         slashings.NewPool(),
+		ExitPool:              voluntaryexits.NewPool(),
+		StateGen:              stategen.New(db),
+		SyncCommitteePool:     synccommittee.NewStore(),
+		ExecutionEngineCaller: &mockEngine.EngineClient{ExecutionPayload: built},
+		PayloadIDCache:        payloadIDs,
+		PayloadPreparer:       preparer,
+	}
+
+	randaoReveal, err := util.RandaoReveal(parentState, 0, privKeys)
+	require.NoError(t, err)
+	block, err := proposerServer.GetBeaconBlock(ctx, &ethpb.BlockRequest{
+		Slot:         1,
+		RandaoReveal: randaoReveal,
+		Graffiti:     make([]byte, 32),
+	})
+	require.NoError(t, err)
+	bellatrixBlk, ok := block.GetBlock().(*ethpb.GenericBeaconBlock_Bellatrix)
+	require.Equal(t, true, ok)
+	require.DeepEqual(t, parentRoot[:], bellatrixBlk.Bellatrix.ParentRoot)
+	require.DeepEqual(t, built, bellatrixBlk.Bellatrix.Body.ExecutionPayload)
+
+	// The payload was built on top of the execution block of the parent, not the one of the head.
+	require.Equal(t, 1, preparer.calls)
+	preparedHeader, err := preparer.parentState.LatestExecutionPayloadHeader()
+	require.NoError(t, err)
+	require.DeepEqual(t, header.BlockHash, preparedHeader.BlockHash)
+	_, ok = payloadIDs.PayloadID(1, parentRoot, params.BeaconConfig().FeeRecipient)
+	require.Equal(t, true, ok)
+}
@@ -45,6 +45,31 @@ func (vs *Server) eth1DataMajorityVote(ctx context.Context, beaconState state.Be
 	}
 	eth1DataNotification = false
 
+	headEth1Data := vs.HeadFetcher.HeadETH1Data()
+	if vs.Eth1DataVoteCache != nil {
+		if vote, ok := vs.Eth1DataVoteCache.Vote(votingPeriodStartTime, headEth1Data); ok {
+			return vote, nil
+		}
+	}
+	vote, err := vs.computeEth1DataMajorityVote(ctx, votingPeriodStartTime, headEth1Data)
+	if err != nil {
+		log.WithError(err).Error("Could not determine eth1 data majority vote")
+		return vs.randomETH1DataVote(ctx)
+	}
+	if vs.Eth1DataVoteCache != nil {
+		vs.Eth1DataVoteCache.SetVote(votingPeriodStartTime, headEth1Data, vote)
+	}
+	return vote, nil
+}
+
+// computeEth1DataMajorityVote computes the eth1data vote for the voting period starting at the given time,
+// as described in eth1DataMajorityVote. The vote only depends on the voting period and on the eth1data of
+// the head state, so it is shared by all proposals of the period.
+func (vs *Server) computeEth1DataMajorityVote(
+	ctx context.Context,
+	votingPeriodStartTime uint64,
+	headEth1Data *ethpb.Eth1Data,
+) (*ethpb.Eth1Data, error) {
 	eth1FollowDistance := params.BeaconConfig().Eth1FollowDistance
 	earliestValidTime := votingPeriodStartTime - 2*params.BeaconConfig().SecondsPerETH1Block*eth1FollowDistance
 	latestValidTime := votingPeriodStartTime - params.BeaconConfig().SecondsPerETH1Block*eth1FollowDistance
@@ -52,18 +77,16 @@ func (vs *Server) eth1DataMajorityVote(ctx context.Context, beaconState state.Be
 	if !features.Get().EnableGetBlockOptimizations {
 		_, err := vs.Eth1BlockFetcher.BlockByTimestamp(ctx, earliestValidTime)
 		if err != nil {
-			log.WithError(err).Error("Could not get last block by earliest valid time")
-			return vs.randomETH1DataVote(ctx)
+			return nil, errors.Wrap(err, "could not get last block by earliest valid time")
 		}
 	}
 
 	lastBlockByLatestValidTime, err := vs.Eth1BlockFetcher.BlockByTimestamp(ctx, latestValidTime)
 	if err != nil {
-		log.WithError(err).Error("Could not get last block by latest valid time")
-		return vs.randomETH1DataVote(ctx)
+		return nil, errors.Wrap(err, "could not get last block by latest valid time")
 	}
 	if lastBlockByLatestValidTime.Time < earliestValidTime {
-		return vs.HeadFetcher.HeadETH1Data(), nil
+		return headEth1Data, nil
 	}
 
 	lastBlockDepositCount, lastBlockDepositRoot := vs.DepositFetcher.DepositsNumberAndRootAtHeight(ctx, lastBlockByLatestValidTime.Number)
@@ -71,11 +94,10 @@ func (vs *Server) eth1DataMajorityVote(ctx context.Context, beaconState state.Be
 		return vs.ChainStartFetcher.ChainStartEth1Data(), nil
 	}
 
-	if lastBlockDepositCount >= vs.HeadFetcher.HeadETH1Data().DepositCount {
+	if lastBlockDepositCount >= headEth1Data.DepositCount {
 		hash, err := vs.Eth1BlockFetcher.BlockHashByHeight(ctx, lastBlockByLatestValidTime.Number)
 		if err != nil {
-			log.WithError(err).Error("Could not get hash of last block by latest valid time")
-			return vs.randomETH1DataVote(ctx)
+			return nil, errors.Wrap(err, "could not get hash of last block by latest valid time")
 		}
 		return &ethpb.Eth1Data{
 			BlockHash:    hash.Bytes(),
@@ -83,7 +105,7 @@ func (vs *Server) eth1DataMajorityVote(ctx context.Context, beaconState state.Be
 			DepositRoot:  lastBlockDepositRoot[:],
 		}, nil
 	}
-	return vs.HeadFetcher.HeadETH1Data(), nil
+	return headEth1Data, nil
 }
 
 func (vs *Server) slotStartTime(slot types.Slot) uint64 {
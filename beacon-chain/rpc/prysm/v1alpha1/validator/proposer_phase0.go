@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
@@ -10,10 +11,12 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition/interop"
 	v "github.com/prysmaticlabs/prysm/beacon-chain/core/validators"
+	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
 
@@ -92,6 +95,26 @@ func (vs *Server) buildPhase0BlockData(ctx context.Context, req *ethpb.BlockRequ
 		return nil, fmt.Errorf("could not get head state %v", err)
 	}
 
+	// A late and weak head block is reorged out by building on its parent instead.
+	if features.Get().EnableReorgLateBlocks {
+		proposerHead, err := vs.HeadFetcher.ProposerHead(ctx, req.Slot)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve proposer head: %v", err)
+		}
+		if !bytes.Equal(proposerHead, parentRoot) {
+			head, err = vs.StateGen.StateByRoot(ctx, bytesutil.ToBytes32(proposerHead))
+			if err != nil {
+				return nil, fmt.Errorf("could not get proposer head state %v", err)
+			}
+			log.WithFields(logrus.Fields{
+				"slot":       req.Slot,
+				"headRoot":   fmt.Sprintf("%#x", bytesutil.Trunc(parentRoot)),
+				"parentRoot": fmt.Sprintf("%#x", bytesutil.Trunc(proposerHead)),
+			}).Info("Reorging late head block")
+			parentRoot = proposerHead
+		}
+	}
+
 	head, err = transition.ProcessSlotsUsingNextSlotCache(ctx, head, parentRoot, req.Slot)
 	if err != nil {
 		return nil, fmt.Errorf("could not advance slots to calculate proposer index: %v", err)
@@ -8,6 +8,7 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/go-bitfield"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	b "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
@@ -1821,6 +1822,53 @@ func TestProposer_Eth1Data_MajorityVote(t *testing.T) {
 		expectedHash := []byte("eth1data")
 		assert.DeepEqual(t, expectedHash, hash)
 	})
+
+	t.Run("cached vote - reuse vote within voting period", func(t *testing.T) {
+		p := mockPOW.NewPOWChain().
+			InsertBlock(50, earliestValidTime, []byte("earliest")).
+			InsertBlock(100, latestValidTime, []byte("latest"))
+
+		beaconState, err := v1.InitializeFromProto(&ethpb.BeaconState{
+			Slot:          slot,
+			Eth1DataVotes: []*ethpb.Eth1Data{}})
+		require.NoError(t, err)
+
+		chainService := &mock.ChainService{ETH1Data: &ethpb.Eth1Data{DepositCount: 1}}
+		ps := &Server{
+			ChainStartFetcher: p,
+			Eth1InfoFetcher:   p,
+			Eth1BlockFetcher:  p,
+			BlockFetcher:      p,
+			DepositFetcher:    depositCache,
+			HeadFetcher:       chainService,
+			Eth1DataVoteCache: cache.NewEth1DataVoteCache(),
+		}
+
+		ctx := context.Background()
+		majorityVoteEth1Data, err := ps.eth1DataMajorityVote(ctx, beaconState)
+		require.NoError(t, err)
+		expectedHash := make([]byte, 32)
+		copy(expectedHash, "latest")
+		assert.DeepEqual(t, expectedHash, majorityVoteEth1Data.BlockHash)
+
+		// The eth1 chain changing within the voting period does not change the vote.
+		other := mockPOW.NewPOWChain().
+			InsertBlock(50, earliestValidTime, []byte("earliest")).
+			InsertBlock(100, latestValidTime, []byte("other"))
+		ps.Eth1BlockFetcher = other
+		require.NoError(t, beaconState.SetSlot(slot+1))
+		majorityVoteEth1Data, err = ps.eth1DataMajorityVote(ctx, beaconState)
+		require.NoError(t, err)
+		assert.DeepEqual(t, expectedHash, majorityVoteEth1Data.BlockHash)
+
+		// The vote is computed again once the eth1 data of the head state changes.
+		chainService.ETH1Data = &ethpb.Eth1Data{DepositCount: 1, BlockHash: []byte("head")}
+		majorityVoteEth1Data, err = ps.eth1DataMajorityVote(ctx, beaconState)
+		require.NoError(t, err)
+		expectedHash = make([]byte, 32)
+		copy(expectedHash, "other")
+		assert.DeepEqual(t, expectedHash, majorityVoteEth1Data.BlockHash)
+	})
 }
 
 func TestProposer_FilterAttestation(t *testing.T) {
@@ -2357,6 +2405,23 @@ func TestProposer_GetBeaconBlock_BellatrixEpoch(t *testing.T) {
 	assert.DeepEqual(t, req.Graffiti, bellatrixBlk.Bellatrix.Body.Graffiti, "Expected block to have correct Graffiti")
 }
 
+func TestProposer_GetBeaconBlock_BellatrixEpochOptimistic(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.MainnetConfig().Copy()
+	cfg.BellatrixForkEpoch = 2
+	cfg.AltairForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	bellatrixSlot, err := slots.EpochStart(params.BeaconConfig().BellatrixForkEpoch)
+	require.NoError(t, err)
+	proposerServer := &Server{
+		HeadFetcher: &mock.ChainService{Optimistic: true},
+		SyncChecker: &mockSync.Sync{IsSyncing: false},
+	}
+	_, err = proposerServer.GetBeaconBlock(context.Background(), &ethpb.BlockRequest{Slot: bellatrixSlot + 1})
+	assert.ErrorContains(t, "head block is optimistic", err)
+}
+
 func TestProposer_GetSyncAggregate_OK(t *testing.T) {
 	proposerServer := &Server{
 		SyncChecker:       &mockSync.Sync{IsSyncing: false},
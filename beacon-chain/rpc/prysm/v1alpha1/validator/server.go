@@ -16,14 +16,18 @@ import (
 	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/powchain/preparation"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/network/forks"
@@ -40,6 +44,7 @@ import (
 type Server struct {
 	Ctx                    context.Context
 	AttestationCache       *cache.AttestationCache
+	Eth1DataVoteCache      *cache.Eth1DataVoteCache
 	HeadFetcher            blockchain.HeadFetcher
 	ForkFetcher            blockchain.ForkFetcher
 	FinalizationFetcher    blockchain.FinalizationFetcher
@@ -52,6 +57,7 @@ type Server struct {
 	StateNotifier          statefeed.Notifier
 	BlockNotifier          blockfeed.Notifier
 	P2P                    p2p.Broadcaster
+	PeerManager            p2p.PeerManager
 	AttPool                attestations.Pool
 	SlashingsPool          slashings.PoolManager
 	ExitPool               voluntaryexits.PoolManager
@@ -62,6 +68,10 @@ type Server struct {
 	PendingDepositsFetcher depositcache.PendingDepositsFetcher
 	OperationNotifier      opfeed.Notifier
 	StateGen               stategen.StateManager
+	BeaconDB               db.ReadOnlyDatabase
+	ExecutionEngineCaller  engine.EngineCaller
+	PayloadIDCache         *cache.PayloadIDCache
+	PayloadPreparer        preparation.PayloadPreparer
 }
 
 // WaitForActivation checks if a validator public key exists in the active validator registry of the current
@@ -109,11 +119,20 @@ func (vs *Server) WaitForActivation(req *ethpb.ValidatorActivationRequest, strea
 
 // ValidatorIndex is called by a validator to get its index location in the beacon state.
 func (vs *Server) ValidatorIndex(ctx context.Context, req *ethpb.ValidatorIndexRequest) (*ethpb.ValidatorIndexResponse, error) {
+	// The indices of finalized validators are looked up in the database, without copying the head state.
+	pubKey := bytesutil.ToBytes48(req.PublicKey)
+	indices, err := vs.BeaconDB.ValidatorIndices(ctx, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not look up validator index: %v", err)
+	}
+	if index, ok := indices[pubKey]; ok {
+		return &ethpb.ValidatorIndexResponse{Index: index}, nil
+	}
 	st, err := vs.HeadFetcher.HeadState(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not determine head state: %v", err)
 	}
-	index, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(req.PublicKey))
+	index, ok := st.ValidatorIndexByPubkey(pubKey)
 	if !ok {
 		return nil, status.Errorf(codes.Internal, "Could not find validator index for public key %#x not found", req.PublicKey)
 	}
@@ -196,3 +215,16 @@ func (vs *Server) WaitForChainStart(_ *emptypb.Empty, stream ethpb.BeaconNodeVal
 		}
 	}
 }
+
+// checkOptimistic returns an error if the head block was imported optimistically, while the
+// execution node is syncing, as validators must neither attest to nor build on such blocks.
+func (vs *Server) checkOptimistic(ctx context.Context) error {
+	optimistic, err := vs.HeadFetcher.IsOptimistic(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not determine if the head is optimistic: %v", err)
+	}
+	if optimistic {
+		return status.Error(codes.Unavailable, "The execution node is syncing, head block is optimistic")
+	}
+	return nil
+}
@@ -7,12 +7,14 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/async/event"
 	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
 	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
 	"github.com/prysmaticlabs/prysm/config/params"
@@ -39,6 +41,7 @@ func TestValidatorIndex_OK(t *testing.T) {
 
 	Server := &Server{
 		HeadFetcher: &mockChain.ChainService{State: st},
+		BeaconDB:    dbutil.SetupDB(t),
 	}
 
 	req := &ethpb.ValidatorIndexRequest{
@@ -48,6 +51,24 @@ func TestValidatorIndex_OK(t *testing.T) {
 	assert.NoError(t, err, "Could not get validator index")
 }
 
+func TestValidatorIndex_Finalized(t *testing.T) {
+	ctx := context.Background()
+	finalized, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, finalized.SetValidators([]*ethpb.Validator{{PublicKey: pubKey(1)}, {PublicKey: pubKey(2)}}))
+	beaconDB := dbutil.SetupDB(t)
+	require.NoError(t, beaconDB.SaveValidatorIndices(ctx, finalized))
+
+	// The head state is not read for finalized validators.
+	Server := &Server{
+		HeadFetcher: &mockChain.ChainService{},
+		BeaconDB:    beaconDB,
+	}
+	res, err := Server.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey(2)})
+	require.NoError(t, err)
+	assert.Equal(t, types.ValidatorIndex(1), res.Index)
+}
+
 func TestWaitForActivation_ContextClosed(t *testing.T) {
 	beaconState, err := v1.InitializeFromProto(&ethpb.BeaconState{
 		Slot:       0,
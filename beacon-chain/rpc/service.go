@@ -8,11 +8,13 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"time"
 
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	grpcutil "github.com/prysmaticlabs/prysm/api/grpc"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
@@ -26,6 +28,8 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	engine "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/powchain/preparation"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/eth/beacon"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/eth/debug"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/eth/events"
@@ -39,6 +43,7 @@ import (
 	slasherservice "github.com/prysmaticlabs/prysm/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	chainSync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/backfill"
 	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/io/logs"
@@ -49,11 +54,18 @@ import (
 	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/reflection"
 )
 
-const attestationBufferSize = 100
+const (
+	attestationBufferSize = 100
+	// healthStatusInterval is how often the status reported by the gRPC health service is refreshed.
+	healthStatusInterval = 2 * time.Second
+)
 
 // Service defining an RPC server for a beacon node.
 type Service struct {
@@ -62,6 +74,7 @@ type Service struct {
 	cancel               context.CancelFunc
 	listener             net.Listener
 	grpcServer           *grpc.Server
+	healthServer         *health.Server
 	incomingAttestation  chan *ethpbv1alpha1.Attestation
 	credentialError      error
 	connectedRPCClients  map[net.Addr]bool
@@ -74,6 +87,7 @@ type Config struct {
 	Port                    string
 	CertFlag                string
 	KeyFlag                 string
+	ClientCAFlag            string
 	BeaconMonitoringHost    string
 	BeaconMonitoringPort    int
 	BeaconDB                db.HeadAccessDatabase
@@ -82,14 +96,17 @@ type Config struct {
 	CanonicalFetcher        blockchain.CanonicalFetcher
 	ForkFetcher             blockchain.ForkFetcher
 	FinalizationFetcher     blockchain.FinalizationFetcher
+	LightClientFetcher      blockchain.LightClientFetcher
 	AttestationReceiver     blockchain.AttestationReceiver
 	BlockReceiver           blockchain.BlockReceiver
 	POWChainService         powchain.Chain
 	ChainStartFetcher       powchain.ChainStartFetcher
 	POWChainInfoFetcher     powchain.ChainInfoFetcher
+	PayloadBodiesFetcher    db.PayloadBodiesFetcher
 	GenesisTimeFetcher      blockchain.TimeFetcher
 	GenesisFetcher          blockchain.GenesisFetcher
 	EnableDebugRPCEndpoints bool
+	EnablePeerManagement    bool
 	MockEth1Votes           bool
 	AttestationsPool        attestations.Pool
 	ExitPool                voluntaryexits.PoolManager
@@ -97,9 +114,12 @@ type Config struct {
 	SlashingChecker         slasherservice.SlashingChecker
 	SyncCommitteeObjectPool synccommittee.Pool
 	SyncService             chainSync.Checker
+	BackfillChecker         backfill.Checker
 	Broadcaster             p2p.Broadcaster
 	PeersFetcher            p2p.PeersProvider
 	PeerManager             p2p.PeerManager
+	StaticPeerManager       p2p.StaticPeerManager
+	BanManager              p2p.BanManager
 	MetadataProvider        p2p.MetadataProvider
 	DepositFetcher          depositcache.DepositFetcher
 	PendingDepositFetcher   depositcache.PendingDepositsFetcher
@@ -107,7 +127,17 @@ type Config struct {
 	BlockNotifier           blockfeed.Notifier
 	OperationNotifier       opfeed.Notifier
 	StateGen                *stategen.State
+	LivenessCache           *cache.LivenessCache
+	ExecutionEngineCaller   engine.EngineCaller
+	EngineLatencyFetcher    engine.LatencyFetcher
+	EngineConnChecker       engine.ConnectionChecker
+	PayloadIDCache          *cache.PayloadIDCache
+	PayloadPreparer         preparation.PayloadPreparer
 	MaxMsgSize              int
+	MaxSendMsgSize          int
+	KeepaliveTime           time.Duration
+	KeepaliveTimeout        time.Duration
+	KeepaliveMinTime        time.Duration
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -142,6 +172,7 @@ func (s *Service) Start() {
 			grpc_prometheus.StreamServerInterceptor,
 			grpc_opentracing.StreamServerInterceptor(),
 			s.validatorStreamConnectionInterceptor,
+			boundedDeepReplaysStreamInterceptor,
 		)),
 		grpc.UnaryInterceptor(middleware.ChainUnaryServer(
 			recovery.UnaryServerInterceptor(
@@ -150,16 +181,39 @@ func (s *Service) Start() {
 			grpc_prometheus.UnaryServerInterceptor,
 			grpc_opentracing.UnaryServerInterceptor(),
 			s.validatorUnaryConnectionInterceptor,
+			boundedDeepReplaysUnaryInterceptor,
 		)),
 		grpc.MaxRecvMsgSize(s.cfg.MaxMsgSize),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    s.cfg.KeepaliveTime,
+			Timeout: s.cfg.KeepaliveTimeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             s.cfg.KeepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+	}
+	if s.cfg.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(s.cfg.MaxSendMsgSize))
 	}
 	grpc_prometheus.EnableHandlingTimeHistogram()
 	if s.cfg.CertFlag != "" && s.cfg.KeyFlag != "" {
-		creds, err := credentials.NewServerTLSFromFile(s.cfg.CertFlag, s.cfg.KeyFlag)
+		var creds credentials.TransportCredentials
+		var err error
+		if s.cfg.ClientCAFlag != "" {
+			creds, err = grpcutil.NewServerMutualTLSFromFile(s.cfg.CertFlag, s.cfg.KeyFlag, s.cfg.ClientCAFlag)
+		} else {
+			creds, err = credentials.NewServerTLSFromFile(s.cfg.CertFlag, s.cfg.KeyFlag)
+		}
 		if err != nil {
 			log.WithError(err).Fatal("Could not load TLS keys")
 		}
 		opts = append(opts, grpc.Creds(creds))
+		if s.cfg.ClientCAFlag != "" {
+			log.WithField("client-ca-path", s.cfg.ClientCAFlag).Info("Requiring gRPC clients to present a trusted certificate")
+		}
+	} else if s.cfg.ClientCAFlag != "" {
+		log.Fatal("A TLS certificate and key are required to verify the certificates of gRPC clients")
 	} else {
 		log.Warn("You are using an insecure gRPC server. If you are running your beacon node and " +
 			"validator on the same machines, you can ignore this message. If you want to know " +
@@ -170,6 +224,7 @@ func (s *Service) Start() {
 	validatorServer := &validatorv1alpha1.Server{
 		Ctx:                    s.ctx,
 		AttestationCache:       cache.NewAttestationCache(),
+		Eth1DataVoteCache:      cache.NewEth1DataVoteCache(),
 		AttPool:                s.cfg.AttestationsPool,
 		ExitPool:               s.cfg.ExitPool,
 		HeadFetcher:            s.cfg.HeadFetcher,
@@ -185,6 +240,7 @@ func (s *Service) Start() {
 		BlockNotifier:          s.cfg.BlockNotifier,
 		OperationNotifier:      s.cfg.OperationNotifier,
 		P2P:                    s.cfg.Broadcaster,
+		PeerManager:            s.cfg.PeerManager,
 		BlockReceiver:          s.cfg.BlockReceiver,
 		MockEth1Votes:          s.cfg.MockEth1Votes,
 		Eth1BlockFetcher:       s.cfg.POWChainService,
@@ -192,6 +248,10 @@ func (s *Service) Start() {
 		SlashingsPool:          s.cfg.SlashingsPool,
 		StateGen:               s.cfg.StateGen,
 		SyncCommitteePool:      s.cfg.SyncCommitteeObjectPool,
+		BeaconDB:               s.cfg.BeaconDB,
+		ExecutionEngineCaller:  s.cfg.ExecutionEngineCaller,
+		PayloadIDCache:         s.cfg.PayloadIDCache,
+		PayloadPreparer:        s.cfg.PayloadPreparer,
 	}
 	validatorServerV1 := &validator.Server{
 		HeadFetcher:      s.cfg.HeadFetcher,
@@ -208,6 +268,7 @@ func (s *Service) Start() {
 			StateGenService:    s.cfg.StateGen,
 		},
 		SyncCommitteePool: s.cfg.SyncCommitteeObjectPool,
+		LivenessCache:     s.cfg.LivenessCache,
 	}
 
 	nodeServer := &nodev1alpha1.Server{
@@ -219,8 +280,15 @@ func (s *Service) Start() {
 		GenesisTimeFetcher:   s.cfg.GenesisTimeFetcher,
 		PeersFetcher:         s.cfg.PeersFetcher,
 		PeerManager:          s.cfg.PeerManager,
+		StaticPeerManager:    s.cfg.StaticPeerManager,
+		BanManager:           s.cfg.BanManager,
+		EnablePeerManagement: s.cfg.EnablePeerManagement,
 		GenesisFetcher:       s.cfg.GenesisFetcher,
 		POWChainInfoFetcher:  s.cfg.POWChainInfoFetcher,
+		HeadFetcher:          s.cfg.HeadFetcher,
+		BackfillChecker:      s.cfg.BackfillChecker,
+		EngineLatencyFetcher: s.cfg.EngineLatencyFetcher,
+		EngineConnChecker:    s.cfg.EngineConnChecker,
 		BeaconMonitoringHost: s.cfg.BeaconMonitoringHost,
 		BeaconMonitoringPort: s.cfg.BeaconMonitoringPort,
 	}
@@ -233,6 +301,7 @@ func (s *Service) Start() {
 		PeerManager:        s.cfg.PeerManager,
 		MetadataProvider:   s.cfg.MetadataProvider,
 		HeadFetcher:        s.cfg.HeadFetcher,
+		V1Alpha1Server:     nodeServer,
 	}
 
 	beaconChainServer := &beaconv1alpha1.Server{
@@ -274,6 +343,8 @@ func (s *Service) Start() {
 			StateGenService:    s.cfg.StateGen,
 		},
 		HeadFetcher:             s.cfg.HeadFetcher,
+		LightClientFetcher:      s.cfg.LightClientFetcher,
+		PayloadBodiesFetcher:    s.cfg.PayloadBodiesFetcher,
 		VoluntaryExitsPool:      s.cfg.ExitPool,
 		V1Alpha1ValidatorServer: validatorServer,
 	}
@@ -315,6 +386,10 @@ func (s *Service) Start() {
 	ethpbservice.RegisterBeaconValidatorServer(s.grpcServer, validatorServerV1)
 	// Register reflection service on gRPC server.
 	reflection.Register(s.grpcServer)
+	// Register the health service last, so that it reports the status of all the services above.
+	s.healthServer = grpcutil.NewHealthServer(s.grpcServer)
+	s.updateHealthStatus()
+	go s.healthStatusLoop()
 
 	go func() {
 		if s.listener != nil {
@@ -328,6 +403,9 @@ func (s *Service) Start() {
 // Stop the service.
 func (s *Service) Stop() error {
 	s.cancel()
+	if s.healthServer != nil {
+		s.healthServer.Shutdown()
+	}
 	if s.listener != nil {
 		s.grpcServer.GracefulStop()
 		log.Debug("Initiated graceful stop of gRPC server")
@@ -346,6 +424,31 @@ func (s *Service) Status() error {
 	return nil
 }
 
+// healthStatusLoop periodically refreshes the status reported by the gRPC health service until the
+// service is stopped.
+func (s *Service) healthStatusLoop() {
+	ticker := time.NewTicker(healthStatusInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.updateHealthStatus()
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// updateHealthStatus reports the overall status of the server as not serving to health checks while
+// the node is syncing or the server is misconfigured, as the node cannot serve reliable data then.
+func (s *Service) updateHealthStatus() {
+	status := healthpb.HealthCheckResponse_SERVING
+	if err := s.Status(); err != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	s.healthServer.SetServingStatus("", status)
+}
+
 // Stream interceptor for new validator client connections to the beacon node.
 func (s *Service) validatorStreamConnectionInterceptor(
 	srv interface{},
@@ -368,6 +471,30 @@ func (s *Service) validatorUnaryConnectionInterceptor(
 	return handler(ctx, req)
 }
 
+// Stream interceptor bounding the deep state replays of the requests, so that they do not hold up
+// the processing of blocks.
+func boundedDeepReplaysStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	wrapped := middleware.WrapServerStream(ss)
+	wrapped.WrappedContext = stategen.WithBoundedDeepReplays(ss.Context())
+	return handler(srv, wrapped)
+}
+
+// Unary interceptor bounding the deep state replays of the requests, so that they do not hold up
+// the processing of blocks.
+func boundedDeepReplaysUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	return handler(stategen.WithBoundedDeepReplays(ctx), req)
+}
+
 func (s *Service) logNewClientConnection(ctx context.Context) {
 	if features.Get().DisableGRPCConnectionLogs {
 		return
@@ -14,6 +14,8 @@ import (
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/sirupsen/logrus"
 	logTest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func init() {
@@ -73,3 +75,25 @@ func TestRPC_InsecureEndpoint(t *testing.T) {
 	require.LogsContain(t, hook, "You are using an insecure gRPC server")
 	assert.NoError(t, rpcService.Stop())
 }
+
+func TestUpdateHealthStatus(t *testing.T) {
+	syncService := &mockSync.Sync{IsSyncing: true}
+	s := &Service{
+		cfg:          &Config{SyncService: syncService},
+		healthServer: health.NewServer(),
+	}
+	check := func() healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := s.healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		return resp.Status
+	}
+
+	s.updateHealthStatus()
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check())
+	syncService.IsSyncing = false
+	s.updateHealthStatus()
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check())
+	s.credentialError = errors.New("credentialError")
+	s.updateHealthStatus()
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check())
+}
@@ -105,7 +105,7 @@ func InitializeFromProtoUnsafe(st *ethpb.BeaconStateAltair) (*BeaconState, error
 		dirtyFields:           make(map[types.FieldIndex]bool, fieldCount),
 		dirtyIndices:          make(map[types.FieldIndex][]uint64, fieldCount),
 		stateFieldLeaves:      make(map[types.FieldIndex]*fieldtrie.FieldTrie, fieldCount),
-		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 11),
+		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 13),
 		rebuildTrie:           make(map[types.FieldIndex]bool, fieldCount),
 		valMapHandler:         stateutil.NewValMapHandler(st.Validators),
 	}
@@ -133,6 +133,8 @@ func InitializeFromProtoUnsafe(st *ethpb.BeaconStateAltair) (*BeaconState, error
 	b.sharedFieldReferences[balances] = stateutil.NewRef(1)
 	b.sharedFieldReferences[inactivityScores] = stateutil.NewRef(1) // New in Altair.
 	b.sharedFieldReferences[historicalRoots] = stateutil.NewRef(1)
+	b.sharedFieldReferences[currentSyncCommittee] = stateutil.NewRef(1) // New in Altair.
+	b.sharedFieldReferences[nextSyncCommittee] = stateutil.NewRef(1)    // New in Altair.
 
 	state.StateCount.Inc()
 	return b, nil
@@ -174,13 +176,15 @@ func (b *BeaconState) Copy() state.BeaconState {
 		previousJustifiedCheckpoint: b.previousJustifiedCheckpointVal(),
 		currentJustifiedCheckpoint:  b.currentJustifiedCheckpointVal(),
 		finalizedCheckpoint:         b.finalizedCheckpointVal(),
-		currentSyncCommittee:        b.currentSyncCommitteeVal(),
-		nextSyncCommittee:           b.nextSyncCommitteeVal(),
+
+		// Shared by reference, see stateutil.Reference.
+		currentSyncCommittee: b.currentSyncCommittee,
+		nextSyncCommittee:    b.nextSyncCommittee,
 
 		dirtyFields:           make(map[types.FieldIndex]bool, fieldCount),
 		dirtyIndices:          make(map[types.FieldIndex][]uint64, fieldCount),
 		rebuildTrie:           make(map[types.FieldIndex]bool, fieldCount),
-		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 11),
+		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 13),
 		stateFieldLeaves:      make(map[types.FieldIndex]*fieldtrie.FieldTrie, fieldCount),
 
 		// Share the reference to validator index map.
@@ -1,12 +1,18 @@
 package v3
 
-import ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+import (
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+)
 
 // SetLatestExecutionPayloadHeader for the beacon state.
 func (b *BeaconState) SetLatestExecutionPayloadHeader(val *ethpb.ExecutionPayloadHeader) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
+	b.sharedFieldReferences[latestExecutionPayloadHeader].MinusRef()
+	b.sharedFieldReferences[latestExecutionPayloadHeader] = stateutil.NewRef(1)
+
 	b.latestExecutionPayloadHeader = val
 	b.markFieldAsDirty(latestExecutionPayloadHeader)
 	return nil
@@ -1,6 +1,7 @@
 package v3
 
 import (
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 )
 
@@ -9,6 +10,9 @@ func (b *BeaconState) SetCurrentSyncCommittee(val *ethpb.SyncCommittee) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
+	b.sharedFieldReferences[currentSyncCommittee].MinusRef()
+	b.sharedFieldReferences[currentSyncCommittee] = stateutil.NewRef(1)
+
 	b.currentSyncCommittee = val
 	b.markFieldAsDirty(currentSyncCommittee)
 	return nil
@@ -19,6 +23,9 @@ func (b *BeaconState) SetNextSyncCommittee(val *ethpb.SyncCommittee) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
+	b.sharedFieldReferences[nextSyncCommittee].MinusRef()
+	b.sharedFieldReferences[nextSyncCommittee] = stateutil.NewRef(1)
+
 	b.nextSyncCommittee = val
 	b.markFieldAsDirty(nextSyncCommittee)
 	return nil
@@ -84,7 +84,7 @@ func InitializeFromProtoUnsafe(st *ethpb.BeaconStateBellatrix) (state.BeaconStat
 		dirtyFields:           make(map[types.FieldIndex]bool, fieldCount),
 		dirtyIndices:          make(map[types.FieldIndex][]uint64, fieldCount),
 		stateFieldLeaves:      make(map[types.FieldIndex]*fieldtrie.FieldTrie, fieldCount),
-		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 11),
+		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 14),
 		rebuildTrie:           make(map[types.FieldIndex]bool, fieldCount),
 		valMapHandler:         stateutil.NewValMapHandler(st.Validators),
 	}
@@ -112,6 +112,8 @@ func InitializeFromProtoUnsafe(st *ethpb.BeaconStateBellatrix) (state.BeaconStat
 	b.sharedFieldReferences[balances] = stateutil.NewRef(1)
 	b.sharedFieldReferences[inactivityScores] = stateutil.NewRef(1) // New in Altair.
 	b.sharedFieldReferences[historicalRoots] = stateutil.NewRef(1)
+	b.sharedFieldReferences[currentSyncCommittee] = stateutil.NewRef(1)         // New in Altair.
+	b.sharedFieldReferences[nextSyncCommittee] = stateutil.NewRef(1)            // New in Altair.
 	b.sharedFieldReferences[latestExecutionPayloadHeader] = stateutil.NewRef(1) // New in Bellatrix.
 	state.StateCount.Inc()
 	return b, nil
@@ -145,22 +147,24 @@ func (b *BeaconState) Copy() state.BeaconState {
 		inactivityScores:           b.inactivityScores,
 
 		// Everything else, too small to be concerned about, constant size.
-		genesisValidatorsRoot:        b.genesisValidatorsRoot,
-		fork:                         b.forkVal(),
-		latestBlockHeader:            b.latestBlockHeaderVal(),
-		eth1Data:                     b.eth1DataVal(),
-		justificationBits:            b.justificationBitsVal(),
-		previousJustifiedCheckpoint:  b.previousJustifiedCheckpointVal(),
-		currentJustifiedCheckpoint:   b.currentJustifiedCheckpointVal(),
-		finalizedCheckpoint:          b.finalizedCheckpointVal(),
-		currentSyncCommittee:         b.currentSyncCommitteeVal(),
-		nextSyncCommittee:            b.nextSyncCommitteeVal(),
-		latestExecutionPayloadHeader: b.latestExecutionPayloadHeaderVal(),
+		genesisValidatorsRoot:       b.genesisValidatorsRoot,
+		fork:                        b.forkVal(),
+		latestBlockHeader:           b.latestBlockHeaderVal(),
+		eth1Data:                    b.eth1DataVal(),
+		justificationBits:           b.justificationBitsVal(),
+		previousJustifiedCheckpoint: b.previousJustifiedCheckpointVal(),
+		currentJustifiedCheckpoint:  b.currentJustifiedCheckpointVal(),
+		finalizedCheckpoint:         b.finalizedCheckpointVal(),
+
+		// Shared by reference, see stateutil.Reference.
+		currentSyncCommittee:         b.currentSyncCommittee,
+		nextSyncCommittee:            b.nextSyncCommittee,
+		latestExecutionPayloadHeader: b.latestExecutionPayloadHeader,
 
 		dirtyFields:           make(map[types.FieldIndex]bool, fieldCount),
 		dirtyIndices:          make(map[types.FieldIndex][]uint64, fieldCount),
 		rebuildTrie:           make(map[types.FieldIndex]bool, fieldCount),
-		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 11),
+		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 14),
 		stateFieldLeaves:      make(map[types.FieldIndex]*fieldtrie.FieldTrie, fieldCount),
 
 		// Copy on write validator index map.
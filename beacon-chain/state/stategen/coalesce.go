@@ -0,0 +1,48 @@
+package stategen
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+// coalescedReplay regenerates the state of the given block root, sharing a single replay between
+// the callers requesting the same state concurrently. Each caller receives its own copy of a shared
+// state, as states are mutated by their users.
+func (s *State) coalescedReplay(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+	// Bounded replays are not shared with the other callers, which would wait on the bound otherwise.
+	key := string(blockRoot[:])
+	if hasBoundedDeepReplays(ctx) {
+		key = "bounded-" + key
+	}
+	ch := s.replayGroup.DoChan(key, func() (interface{}, error) {
+		return s.replayStateByRoot(ctx, blockRoot)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			// The replay was run with the context of another caller, which was canceled
+			// in the meantime. The replay is retried for this caller.
+			if res.Shared && isContextError(res.Err) && ctx.Err() == nil {
+				return s.replayStateByRoot(ctx, blockRoot)
+			}
+			return nil, res.Err
+		}
+		st, ok := res.Val.(state.BeaconState)
+		if !ok {
+			return nil, errors.New("replayed state is not a beacon state")
+		}
+		if res.Shared {
+			replayCoalescedCount.Inc()
+			return st.Copy(), nil
+		}
+		return st, nil
+	}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
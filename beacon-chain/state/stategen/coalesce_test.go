@@ -0,0 +1,63 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestCoalescedReplay_SharesInFlightReplay(t *testing.T) {
+	s := New(testDB.SetupDB(t))
+	root := [32]byte{'a'}
+	replayed, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, replayed.SetSlot(10))
+
+	// A replay of the state is already in flight for another caller.
+	release := make(chan struct{})
+	leader := s.replayGroup.DoChan(string(root[:]), func() (interface{}, error) {
+		<-release
+		return replayed, nil
+	})
+
+	type result struct {
+		st  state.BeaconState
+		err error
+	}
+	results := make(chan result, 1)
+	go func() {
+		st, err := s.coalescedReplay(context.Background(), root)
+		results <- result{st, err}
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	require.NoError(t, (<-leader).Err)
+	res := <-results
+	require.NoError(t, res.err)
+	assert.Equal(t, replayed.Slot(), res.st.Slot())
+	// The shared state is copied for the caller.
+	assert.NotEqual(t, replayed, res.st)
+}
+
+func TestCoalescedReplay_CanceledContext(t *testing.T) {
+	s := New(testDB.SetupDB(t))
+	root := [32]byte{'b'}
+	release := make(chan struct{})
+	defer close(release)
+	s.replayGroup.DoChan(string(root[:]), func() (interface{}, error) {
+		<-release
+		return nil, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := s.coalescedReplay(ctx, root)
+	require.ErrorIs(t, err, context.Canceled)
+}
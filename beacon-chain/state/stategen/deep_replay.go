@@ -0,0 +1,70 @@
+package stategen
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// deepReplayThreshold is the number of blocks above which a replay is considered deep. Deep
+// replays happen when a reorg reaches past the hot state cache and the closest saved ancestor
+// state is far behind.
+const deepReplayThreshold = 64
+
+// maxConcurrentDeepReplays is the number of deep replays requested through the API which may run at
+// the same time. API replays beyond this limit wait for a running one to finish, so that regenerating
+// the states of old forks does not starve the processing of the canonical chain of CPU and memory.
+const maxConcurrentDeepReplays = 1
+
+// replayProgressInterval is the number of replayed blocks, or processed slots, between progress
+// logs of a deep replay.
+const replayProgressInterval = 128
+
+type boundedDeepReplaysKey struct{}
+
+// WithBoundedDeepReplays returns a context whose deep replays wait for the deep replays requested
+// with other such contexts to finish. It is used for the API requests, while the deep replays needed
+// to process blocks are never held up.
+func WithBoundedDeepReplays(ctx context.Context) context.Context {
+	return context.WithValue(ctx, boundedDeepReplaysKey{}, true)
+}
+
+func hasBoundedDeepReplays(ctx context.Context) bool {
+	bounded, ok := ctx.Value(boundedDeepReplaysKey{}).(bool)
+	return ok && bounded
+}
+
+// acquireDeepReplay waits for a deep replay slot if the context has bounded deep replays. The
+// returned function must be called once the replay is done.
+func (s *State) acquireDeepReplay(ctx context.Context, numBlocks int) (func(), error) {
+	deepReplayCount.Inc()
+	if s.deepReplayLimiter == nil || !hasBoundedDeepReplays(ctx) {
+		return func() {}, nil
+	}
+	select {
+	case s.deepReplayLimiter <- struct{}{}:
+	default:
+		log.WithField("blocks", numBlocks).Info("Waiting for a running state regeneration to finish")
+		deepReplayWaitingGauge.Inc()
+		select {
+		case s.deepReplayLimiter <- struct{}{}:
+			deepReplayWaitingGauge.Dec()
+		case <-ctx.Done():
+			deepReplayWaitingGauge.Dec()
+			return nil, ctx.Err()
+		}
+	}
+	return func() { <-s.deepReplayLimiter }, nil
+}
+
+// logReplayProgress logs the progress of a deep replay every replayProgressInterval blocks. It also
+// logs the progress of the processing of long runs of slots, such as the skipped slots up to a slot
+// queried through the API, every replayProgressInterval slots.
+func logReplayProgress(replayed, total int, fields logrus.Fields) {
+	if total <= deepReplayThreshold || replayed == 0 || replayed%replayProgressInterval != 0 {
+		return
+	}
+	fields["replayed"] = replayed
+	fields["total"] = total
+	log.WithFields(fields).Info("Regenerating state")
+}
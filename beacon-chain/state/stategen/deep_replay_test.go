@@ -0,0 +1,59 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/sirupsen/logrus"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestAcquireDeepReplay_Bounded(t *testing.T) {
+	s := New(testDB.SetupDB(t))
+	bounded := WithBoundedDeepReplays(context.Background())
+
+	release, err := s.acquireDeepReplay(bounded, deepReplayThreshold+1)
+	require.NoError(t, err)
+
+	// A second bounded deep replay waits for the running one.
+	ctx, cancel := context.WithTimeout(bounded, 10*time.Millisecond)
+	defer cancel()
+	_, err = s.acquireDeepReplay(ctx, deepReplayThreshold+1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Deep replays without a bound, such as the ones processing blocks, do not wait.
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	unboundedRelease, err := s.acquireDeepReplay(ctx, deepReplayThreshold+1)
+	require.NoError(t, err)
+	unboundedRelease()
+
+	release()
+	release, err = s.acquireDeepReplay(bounded, deepReplayThreshold+1)
+	require.NoError(t, err)
+	release()
+}
+
+func TestAcquireDeepReplay_NoLimiter(t *testing.T) {
+	s := &State{}
+	release, err := s.acquireDeepReplay(WithBoundedDeepReplays(context.Background()), deepReplayThreshold+1)
+	require.NoError(t, err)
+	release()
+}
+
+func TestLogReplayProgress(t *testing.T) {
+	hook := logTest.NewGlobal()
+
+	// Shallow replays are not logged.
+	logReplayProgress(replayProgressInterval, deepReplayThreshold, logrus.Fields{})
+	require.LogsDoNotContain(t, hook, "Regenerating state")
+
+	logReplayProgress(replayProgressInterval-1, 2*replayProgressInterval, logrus.Fields{})
+	require.LogsDoNotContain(t, hook, "Regenerating state")
+
+	logReplayProgress(replayProgressInterval, 2*replayProgressInterval, logrus.Fields{})
+	require.LogsContain(t, hook, "Regenerating state")
+}
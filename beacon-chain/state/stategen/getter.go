@@ -177,6 +177,15 @@ func (s *State) loadStateByRoot(ctx context.Context, blockRoot [32]byte) (state.
 		return s.beaconDB.State(ctx, blockRoot)
 	}
 
+	// Concurrent requests for the same state share a single replay.
+	return s.coalescedReplay(ctx, blockRoot)
+}
+
+// This replays blocks on top of the last available ancestor state up to the requested block root.
+func (s *State) replayStateByRoot(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "stateGen.replayStateByRoot")
+	defer span.End()
+
 	summary, err := s.stateSummary(ctx, blockRoot)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get state summary")
@@ -205,6 +214,15 @@ func (s *State) loadStateByRoot(ctx context.Context, blockRoot [32]byte) (state.
 
 	replayBlockCount.Observe(float64(len(blks)))
 
+	// A deep replay requested through the API is bounded so that it does not hold up the processing of blocks.
+	if len(blks) > deepReplayThreshold {
+		release, err := s.acquireDeepReplay(ctx, len(blks))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not wait for deep replay")
+		}
+		defer release()
+	}
+
 	return s.ReplayBlocks(ctx, startState, blks, targetSlot)
 }
 
@@ -230,6 +248,7 @@ func (s *State) loadStateBySlot(ctx context.Context, slot types.Slot) (state.Bea
 	}
 
 	if lastValidSlot < slot {
+		replaySlotCount.Observe(float64(slot - lastValidSlot))
 		replayStartState, err = processSlotsStateGen(ctx, replayStartState, slot)
 		if err != nil {
 			return nil, err
@@ -10,9 +10,10 @@ import (
 	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
 )
 
+// DefaultHotStateCacheSize is the default max number of hot states this can cache.
+const DefaultHotStateCacheSize = 32
+
 var (
-	// hotStateCacheSize defines the max number of hot state this can cache.
-	hotStateCacheSize = 32
 	// Metrics
 	hotStateCacheHit = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "hot_state_cache_hit",
@@ -30,10 +31,10 @@ type hotStateCache struct {
 	lock  sync.RWMutex
 }
 
-// newHotStateCache initializes the map and underlying cache.
-func newHotStateCache() *hotStateCache {
+// newHotStateCache initializes the map and underlying cache of the given size.
+func newHotStateCache(size int) *hotStateCache {
 	return &hotStateCache{
-		cache: lruwrpr.New(hotStateCacheSize),
+		cache: lruwrpr.New(size),
 	}
 }
 
@@ -13,4 +13,29 @@ var (
 			Buckets: []float64{64, 256, 1024, 2048, 4096},
 		},
 	)
+	replaySlotCount = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "replay_slots_count",
+			Help:    "The number of slots processed, with or without blocks, to generate a state",
+			Buckets: []float64{32, 128, 512, 2048, 8192},
+		},
+	)
+	deepReplayCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "state_gen_deep_replays_total",
+			Help: "The number of state regenerations replaying more blocks than the deep replay threshold",
+		},
+	)
+	deepReplayWaitingGauge = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "state_gen_deep_replays_waiting",
+			Help: "The number of deep state regenerations waiting for a running one to finish",
+		},
+	)
+	replayCoalescedCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "state_gen_coalesced_replays_total",
+			Help: "The number of state regenerations served by a replay shared with a concurrent request",
+		},
+	)
 )
@@ -5,12 +5,19 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
 
+// Number of archived points in between two archived states saved in full when state diffs are enabled.
+const snapshotArchivedPoints = 32
+
 // MigrateToCold advances the finalized info in between the cold and hot state sections.
 // It moves the recent finalized states from the hot section to the cold section and
 // only preserve the ones that's on archived point.
@@ -95,7 +102,7 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 				continue
 			}
 
-			if err := s.beaconDB.SaveState(ctx, aState, aRoot); err != nil {
+			if err := s.saveArchivedState(ctx, aState, aRoot); err != nil {
 				return err
 			}
 			log.WithFields(
@@ -117,3 +124,87 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 
 	return nil
 }
+
+// This saves the state of an archived point. When state diffs are enabled, an archived state is
+// only saved in full every snapshotArchivedPoints archived points, the others are saved as diffs
+// against the last full snapshot. The first archived state after a restart is saved in full.
+func (s *State) saveArchivedState(ctx context.Context, st state.BeaconState, root [32]byte) error {
+	if !features.Get().EnableStateDiffs {
+		return s.beaconDB.SaveState(ctx, st, root)
+	}
+
+	s.lastSnapshot.lock.Lock()
+	defer s.lastSnapshot.lock.Unlock()
+	if s.lastSnapshot.root == params.BeaconConfig().ZeroHash ||
+		st.Slot() < s.lastSnapshot.slot ||
+		st.Slot()-s.lastSnapshot.slot >= s.slotsPerArchivedPoint*snapshotArchivedPoints {
+		if err := s.beaconDB.SaveState(ctx, st, root); err != nil {
+			return err
+		}
+		s.lastSnapshot.slot = st.Slot()
+		s.lastSnapshot.root = root
+		return nil
+	}
+	return s.beaconDB.SaveStateDiff(ctx, st, root, s.lastSnapshot.root)
+}
+
+// This saves the archived states required by the current archived point interval when it differs
+// from the interval the archived states in the DB were saved with, so that changing the interval
+// does not leave gaps in the cold section. The states of the previous interval which are no longer
+// on an archived point are removed by the dirty state clean up. The interval is only recorded once
+// every archived state below the finalized slot is saved, an interrupted run resumes on next start.
+func (s *State) respaceArchivedPoints(ctx context.Context, fSlot types.Slot) error {
+	ctx, span := trace.StartSpan(ctx, "stateGen.respaceArchivedPoints")
+	defer span.End()
+
+	saved, err := s.beaconDB.SlotsPerArchivedPoint(ctx)
+	if err != nil {
+		return err
+	}
+	if saved == s.slotsPerArchivedPoint {
+		return nil
+	}
+	// Nodes which never recorded an interval are assumed to have saved their archived
+	// states with the current one.
+	if saved != 0 {
+		log.WithFields(logrus.Fields{
+			"previousSlotsPerArchivedPoint": saved,
+			"slotsPerArchivedPoint":         s.slotsPerArchivedPoint,
+		}).Info("Archived point interval changed, saving the states of the new archived points")
+		count := 0
+		for slot := s.slotsPerArchivedPoint; slot < fSlot; slot += s.slotsPerArchivedPoint {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// The archived state of a slot is the state of the highest block at or below it.
+			blks, err := s.beaconDB.HighestSlotBlocksBelow(ctx, slot+1)
+			if err != nil {
+				return err
+			}
+			if len(blks) != 1 {
+				return errUnknownBlock
+			}
+			root, err := blks[0].Block().HashTreeRoot()
+			if err != nil {
+				return err
+			}
+			if s.beaconDB.HasState(ctx, root) {
+				continue
+			}
+			st, err := s.StateByRoot(ctx, root)
+			if err != nil {
+				return errors.Wrapf(err, "could not generate archived state at slot %d", slot)
+			}
+			if err := s.saveArchivedState(ctx, st, root); err != nil {
+				return err
+			}
+			count++
+			log.WithFields(logrus.Fields{
+				"slot": slot,
+				"root": hex.EncodeToString(bytesutil.Trunc(root[:])),
+			}).Debug("Saved state of new archived point in DB")
+		}
+		log.WithField("count", count).Info("Saved the states of the new archived points")
+	}
+	return s.beaconDB.SaveSlotsPerArchivedPoint(ctx, s.slotsPerArchivedPoint)
+}
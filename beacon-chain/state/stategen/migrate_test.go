@@ -7,6 +7,7 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/config/features"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
 	"github.com/prysmaticlabs/prysm/testing/assert"
@@ -135,3 +136,89 @@ func TestMigrateToCold_StateExistsInDB(t *testing.T) {
 	assert.DeepEqual(t, [][32]byte{{1}, {2}, {3}, {4}}, service.saveHotStateDB.savedStateRoots)
 	assert.LogsDoNotContain(t, hook, "Saved state in DB")
 }
+
+func TestSaveArchivedState_StateDiffs(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableStateDiffs: true})
+	defer resetCfg()
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service := New(beaconDB)
+	service.slotsPerArchivedPoint = 1
+	beaconState, _ := util.DeterministicGenesisState(t, 32)
+
+	// A full snapshot is saved every snapshotArchivedPoints archived points, with diffs in between.
+	snapshots := map[types.Slot]bool{1: true, 2: false, snapshotArchivedPoints: false, snapshotArchivedPoints + 1: true, snapshotArchivedPoints + 2: false}
+	for _, slot := range []types.Slot{1, 2, snapshotArchivedPoints, snapshotArchivedPoints + 1, snapshotArchivedPoints + 2} {
+		st := beaconState.Copy()
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, st.UpdateBalancesAtIndex(1, uint64(slot)))
+		root := [32]byte{byte(slot)}
+		require.NoError(t, service.saveArchivedState(ctx, st, root))
+		if snapshots[slot] {
+			assert.Equal(t, root, service.lastSnapshot.root, "state at slot %d is not a snapshot", slot)
+			assert.Equal(t, slot, service.lastSnapshot.slot)
+		} else {
+			assert.NotEqual(t, root, service.lastSnapshot.root, "state at slot %d is a snapshot", slot)
+		}
+
+		saved, err := beaconDB.State(ctx, root)
+		require.NoError(t, err)
+		assert.DeepSSZEqual(t, st.InnerStateUnsafe(), saved.InnerStateUnsafe())
+	}
+}
+
+func TestRespaceArchivedPoints_RecordsInterval(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	service := New(beaconDB)
+	service.slotsPerArchivedPoint = 2048
+	require.NoError(t, service.respaceArchivedPoints(ctx, 10000))
+	slots, err := beaconDB.SlotsPerArchivedPoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(2048), slots)
+}
+
+func TestRespaceArchivedPoints_SavesNewArchivedPoints(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	service := New(beaconDB)
+	service.slotsPerArchivedPoint = 2
+	beaconState, pks := util.DeterministicGenesisState(t, 32)
+	genesisStateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(genesisStateRoot[:])
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(genesis)))
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, beaconState, gRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+
+	roots := make([][32]byte, 0, 2)
+	for _, slot := range []types.Slot{1, 3} {
+		b, err := util.GenerateFullBlock(beaconState, pks, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		r, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(b)))
+		require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: r[:]}))
+		roots = append(roots, r)
+	}
+	require.NoError(t, beaconDB.SaveSlotsPerArchivedPoint(ctx, 4))
+
+	require.NoError(t, service.respaceArchivedPoints(ctx, 5))
+	for i, r := range roots {
+		require.Equal(t, true, beaconDB.HasState(ctx, r), "Did not save archived state %d", i)
+	}
+	slots, err := beaconDB.SlotsPerArchivedPoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(2), slots)
+	require.LogsContain(t, hook, "Archived point interval changed")
+
+	// Nothing is left to do once the interval is recorded.
+	hook.Reset()
+	require.NoError(t, service.respaceArchivedPoints(ctx, 5))
+	require.LogsDoNotContain(t, hook, "Archived point interval changed")
+}
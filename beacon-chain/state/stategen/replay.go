@@ -3,6 +3,7 @@ package stategen
 import (
 	"context"
 	"fmt"
+	"runtime"
 	"time"
 
 	"github.com/pkg/errors"
@@ -38,9 +39,13 @@ func (_ *State) ReplayBlocks(
 		"endSlot":   targetSlot,
 		"diff":      targetSlot - state.Slot(),
 	}).Debug("Replaying state")
+	if targetSlot > state.Slot() {
+		replaySlotCount.Observe(float64(targetSlot - state.Slot()))
+	}
 	// The input block list is sorted in decreasing slots order.
 	if len(signed) > 0 {
 		for i := len(signed) - 1; i >= 0; i-- {
+			logReplayProgress(len(signed)-1-i, len(signed), logrus.Fields{"slot": state.Slot(), "targetSlot": targetSlot})
 			if ctx.Err() != nil {
 				return nil, ctx.Err()
 			}
@@ -174,6 +179,10 @@ func executeStateTransitionStateGen(
 	return altair.ProcessSyncAggregate(ctx, state, sa)
 }
 
+// replayEpochWorkers is the number of parallel workers processing the validators at the epoch
+// boundaries of a replay.
+var replayEpochWorkers = runtime.GOMAXPROCS(0)
+
 // processSlotsStateGen to process old slots for state gen usages.
 // There's no skip slot cache involved given state gen only works with already stored block and state in DB.
 // WARNING: This method should not be used for future slot.
@@ -194,7 +203,12 @@ func processSlotsStateGen(ctx context.Context, state state.BeaconState, slot typ
 	}
 
 	var err error
+	startSlot := state.Slot()
 	for state.Slot() < slot {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		logReplayProgress(int(state.Slot()-startSlot), int(slot-startSlot), logrus.Fields{"slot": state.Slot(), "targetSlot": slot})
 		state, err = transition.ProcessSlot(ctx, state)
 		if err != nil {
 			return nil, errors.Wrap(err, "could not process slot")
@@ -207,7 +221,7 @@ func processSlotsStateGen(ctx context.Context, state state.BeaconState, slot typ
 					return nil, errors.Wrap(err, "could not process epoch with optimizations")
 				}
 			case version.Altair, version.Bellatrix:
-				state, err = altair.ProcessEpoch(ctx, state)
+				state, err = altair.ProcessEpochParallel(ctx, state, replayEpochWorkers)
 				if err != nil {
 					return nil, errors.Wrap(err, "could not process epoch with optimization")
 				}
@@ -17,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
+	logTest "github.com/sirupsen/logrus/hooks/test"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -168,6 +169,23 @@ func TestReplayBlocks_ThroughBellatrixForkBoundary(t *testing.T) {
 	assert.Equal(t, version.Bellatrix, newState.Version())
 }
 
+func TestProcessSlotsStateGen_LogsProgress(t *testing.T) {
+	hook := logTest.NewGlobal()
+	beaconState, _ := util.DeterministicGenesisStateAltair(t, 64)
+
+	// Skipped slots up to a slot queried through the API.
+	targetSlot := types.Slot(2 * replayProgressInterval)
+	newState, err := processSlotsStateGen(context.Background(), beaconState, targetSlot)
+	require.NoError(t, err)
+	assert.Equal(t, targetSlot, newState.Slot())
+	require.LogsContain(t, hook, "Regenerating state")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = processSlotsStateGen(ctx, newState, targetSlot+1)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func TestLoadBlocks_FirstBranch(t *testing.T) {
 	beaconDB := testDB.SetupDB(t)
 	ctx := context.Background()
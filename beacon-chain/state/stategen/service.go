@@ -16,9 +16,12 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
 	"go.opencensus.io/trace"
+	"golang.org/x/sync/singleflight"
 )
 
-var defaultHotStateDBInterval types.Slot = 128
+// DefaultHotStateDBInterval is the default number of slots between the hot states saved to the DB
+// during long periods of non-finality.
+const DefaultHotStateDBInterval types.Slot = 128
 
 // StateManager represents a management object that handles the internal
 // logic of maintaining both hot and cold states in DB.
@@ -49,6 +52,9 @@ type State struct {
 	finalizedInfo           *finalizedInfo
 	epochBoundaryStateCache *epochBoundaryState
 	saveHotStateDB          *saveHotStateDbConfig
+	deepReplayLimiter       chan struct{}
+	lastSnapshot            *snapshotInfo
+	replayGroup             singleflight.Group
 }
 
 // This tracks the config in the event of long non-finality,
@@ -61,6 +67,14 @@ type saveHotStateDbConfig struct {
 	savedStateRoots [][32]byte
 }
 
+// This tracks the last archived state saved in full, which the archived states following it are
+// saved as diffs against when state diffs are enabled.
+type snapshotInfo struct {
+	slot types.Slot
+	root [32]byte
+	lock sync.Mutex
+}
+
 // This tracks the finalized point. It's also the point where slot and the block root of
 // cold and hot sections of the DB splits.
 type finalizedInfo struct {
@@ -70,18 +84,42 @@ type finalizedInfo struct {
 	lock  sync.RWMutex
 }
 
+// StateGenOption is a functional option for the state management object.
+type StateGenOption func(*State)
+
+// WithHotStateCacheSize sets the number of hot states kept in memory.
+func WithHotStateCacheSize(size int) StateGenOption {
+	return func(s *State) {
+		s.hotStateCache = newHotStateCache(size)
+	}
+}
+
+// WithHotStateDBInterval sets the number of slots between the hot states saved to the DB during
+// long periods of non-finality.
+func WithHotStateDBInterval(slots types.Slot) StateGenOption {
+	return func(s *State) {
+		s.saveHotStateDB.duration = slots
+	}
+}
+
 // New returns a new state management object.
-func New(beaconDB db.NoHeadAccessDatabase) *State {
-	return &State{
+func New(beaconDB db.NoHeadAccessDatabase, opts ...StateGenOption) *State {
+	s := &State{
 		beaconDB:                beaconDB,
-		hotStateCache:           newHotStateCache(),
+		hotStateCache:           newHotStateCache(DefaultHotStateCacheSize),
 		finalizedInfo:           &finalizedInfo{slot: 0, root: params.BeaconConfig().ZeroHash},
 		slotsPerArchivedPoint:   params.BeaconConfig().SlotsPerArchivedPoint,
 		epochBoundaryStateCache: newBoundaryStateCache(),
 		saveHotStateDB: &saveHotStateDbConfig{
-			duration: defaultHotStateDBInterval,
+			duration: DefaultHotStateDBInterval,
 		},
+		deepReplayLimiter: make(chan struct{}, maxConcurrentDeepReplays),
+		lastSnapshot:      &snapshotInfo{},
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // Resume resumes a new state management object from previously saved finalized check point in DB.
@@ -103,14 +141,20 @@ func (s *State) Resume(ctx context.Context, fState state.BeaconState) (state.Bea
 		return nil, errors.New("finalized state is nil")
 	}
 
+	fSlot := fState.Slot()
+	s.finalizedInfo = &finalizedInfo{slot: fSlot, root: fRoot, state: fState.Copy()}
+
 	go func() {
+		// The states of the previous archived points are only cleaned up once the new ones are saved.
+		if err := s.respaceArchivedPoints(ctx, fSlot); err != nil {
+			log.WithError(err).Error("Could not save the states of the new archived points")
+			return
+		}
 		if err := s.beaconDB.CleanUpDirtyStates(ctx, s.slotsPerArchivedPoint); err != nil {
 			log.WithError(err).Error("Could not clean up dirty states")
 		}
 	}()
 
-	s.finalizedInfo = &finalizedInfo{slot: fState.Slot(), root: fRoot, state: fState.Copy()}
-
 	return fState, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	types "github.com/prysmaticlabs/eth2-types"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/config/params"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -35,3 +36,18 @@ func TestResume(t *testing.T) {
 	assert.Equal(t, service.finalizedInfo.root, root, "Did not get wanted root")
 	assert.NotNil(t, service.finalizedState(), "Wanted a non nil finalized state")
 }
+
+func TestNew_Options(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	service := New(beaconDB)
+	assert.Equal(t, DefaultHotStateDBInterval, service.saveHotStateDB.duration)
+
+	service = New(beaconDB, WithHotStateCacheSize(1), WithHotStateDBInterval(32))
+	assert.Equal(t, types.Slot(32), service.saveHotStateDB.duration)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	service.hotStateCache.put([32]byte{'a'}, st)
+	service.hotStateCache.put([32]byte{'b'}, st)
+	assert.Equal(t, false, service.hotStateCache.has([32]byte{'a'}), "Least recently used state was not evicted")
+	assert.Equal(t, true, service.hotStateCache.has([32]byte{'b'}))
+}
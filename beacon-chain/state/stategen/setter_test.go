@@ -144,7 +144,7 @@ func TestSaveState_CanSaveHotStateToDB(t *testing.T) {
 	service := New(beaconDB)
 	service.EnableSaveHotStateToDB(ctx)
 	beaconState, _ := util.DeterministicGenesisState(t, 32)
-	require.NoError(t, beaconState.SetSlot(defaultHotStateDBInterval))
+	require.NoError(t, beaconState.SetSlot(DefaultHotStateDBInterval))
 
 	r := [32]byte{'A'}
 	require.NoError(t, service.saveStateByRoot(ctx, r, beaconState))
@@ -3,6 +3,8 @@ package stateutil_test
 import (
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/crypto/hash"
 	"github.com/prysmaticlabs/prysm/encoding/ssz"
 	"github.com/prysmaticlabs/prysm/testing/require"
@@ -28,3 +30,24 @@ func BenchmarkMerkleize_Buffered(b *testing.B) {
 		require.NoError(b, err)
 	}
 }
+
+func BenchmarkRecomputeFromLayerVariable(b *testing.B) {
+	roots := make([][32]byte, 100000)
+	for i := range roots {
+		roots[i] = [32]byte{byte(i), byte(i >> 8), byte(i >> 16)}
+	}
+	changedIdx := make([]uint64, 0, len(roots)/10)
+	changedRoots := make([][32]byte, 0, len(roots)/10)
+	for i := 0; i < len(roots); i += 10 {
+		changedIdx = append(changedIdx, uint64(i))
+		changedRoots = append(changedRoots, [32]byte{'A', byte(i), byte(i >> 8)})
+	}
+	layers := stateutil.ReturnTrieLayerVariable(roots, params.BeaconConfig().ValidatorRegistryLimit)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, err := stateutil.RecomputeFromLayerVariable(changedRoots, changedIdx, layers)
+		require.NoError(b, err)
+	}
+}
@@ -0,0 +1,51 @@
+package stateutil
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// chunkPools hold the chunk slices into which lists of basic types, such as the
+// balances, the inactivity scores and the participation bits, are packed before
+// being merkleized. These lists are as long as the validator registry and are
+// hashed on every state root computation, so their chunks are reused instead of
+// being allocated each time. The slices are pooled by size class, the capacities
+// of the slices in the pool at index i being 2^i, so that the short lists do not
+// take the slices of the long ones and the long lists do not drop the short ones.
+// The slices of state copies are not pooled, as a copied state is released by the
+// garbage collector and never gives its slices back.
+var chunkPools [bits.UintSize]sync.Pool
+
+// chunkSizeClass returns the index of the pool holding the slices of at least n chunks.
+func chunkSizeClass(n int) int {
+	return bits.Len(uint(n - 1))
+}
+
+// getChunks returns a zeroed slice of n chunks from the pool of its size class.
+func getChunks(n int) *[][32]byte {
+	if n == 0 {
+		return &[][32]byte{}
+	}
+	class := chunkSizeClass(n)
+	if v := chunkPools[class].Get(); v != nil {
+		if chunks, ok := v.(*[][32]byte); ok {
+			*chunks = (*chunks)[:n]
+			for i := range *chunks {
+				(*chunks)[i] = [32]byte{}
+			}
+			return chunks
+		}
+	}
+	chunks := make([][32]byte, n, 1<<class)
+	return &chunks
+}
+
+// putChunks returns a slice of chunks to the pool of its size class. The chunks
+// must not be used after they are returned.
+func putChunks(chunks *[][32]byte) {
+	c := cap(*chunks)
+	if c == 0 || c&(c-1) != 0 {
+		return
+	}
+	chunkPools[chunkSizeClass(c)].Put(chunks)
+}
@@ -0,0 +1,38 @@
+package stateutil
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+)
+
+func TestGetChunks_SizeClasses(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 5, 8, 9, 1000} {
+		chunks := getChunks(n)
+		for i := range *chunks {
+			(*chunks)[i] = [32]byte{1}
+		}
+		putChunks(chunks)
+	}
+	tests := []struct {
+		n   int
+		cap int
+	}{
+		{n: 0, cap: 0},
+		{n: 1, cap: 1},
+		{n: 3, cap: 4},
+		{n: 4, cap: 4},
+		{n: 5, cap: 8},
+		{n: 9, cap: 16},
+		{n: 1000, cap: 1024},
+	}
+	for _, tt := range tests {
+		chunks := getChunks(tt.n)
+		assert.Equal(t, tt.n, len(*chunks))
+		assert.Equal(t, tt.cap, cap(*chunks), "wrong capacity for %d chunks", tt.n)
+		for i := range *chunks {
+			assert.Equal(t, [32]byte{}, (*chunks)[i], "chunk %d of %d is not zeroed", i, tt.n)
+		}
+		putChunks(chunks)
+	}
+}
@@ -13,10 +13,9 @@ import (
 // participation roots.
 func ParticipationBitsRoot(bits []byte) ([32]byte, error) {
 	hasher := hash.CustomSHA256Hasher()
-	chunkedRoots, err := packParticipationBits(bits)
-	if err != nil {
-		return [32]byte{}, err
-	}
+	chunks := packParticipationBits(bits)
+	defer putChunks(chunks)
+	chunkedRoots := *chunks
 
 	limit := (uint64(fieldparams.ValidatorRegistryLimit + 31)) / 32
 	if limit == 0 {
@@ -27,7 +26,7 @@ func ParticipationBitsRoot(bits []byte) ([32]byte, error) {
 		}
 	}
 
-	bytesRoot, err := ssz.BitwiseMerkleize(hasher, chunkedRoots, uint64(len(chunkedRoots)), limit)
+	bytesRoot, err := ssz.BitwiseMerkleizeArrays(hasher, chunkedRoots, uint64(len(chunkedRoots)), limit)
 	if err != nil {
 		return [32]byte{}, errors.Wrap(err, "could not compute merkleization")
 	}
@@ -37,33 +36,13 @@ func ParticipationBitsRoot(bits []byte) ([32]byte, error) {
 	return ssz.MixInLength(bytesRoot, bytesRootBufRoot), nil
 }
 
-// packParticipationBits into chunks. It'll pad the last chunk with zero bytes if
-// it does not have length bytes per chunk.
-func packParticipationBits(bytes []byte) ([][]byte, error) {
-	numItems := len(bytes)
-	var chunks [][]byte
-	for i := 0; i < numItems; i += 32 {
-		j := i + 32
-		// We create our upper bound index of the chunk, if it is greater than numItems,
-		// we set it as numItems itself.
-		if j > numItems {
-			j = numItems
-		}
-		// We create chunks from the list of items based on the
-		// indices determined above.
-		chunks = append(chunks, bytes[i:j])
-	}
-
-	if len(chunks) == 0 {
-		return chunks, nil
-	}
-
-	// Right-pad the last chunk with zero bytes if it does not
-	// have length bytes.
-	lastChunk := chunks[len(chunks)-1]
-	for len(lastChunk) < 32 {
-		lastChunk = append(lastChunk, 0)
+// packParticipationBits into pooled chunks, which have to be returned to the pool
+// once used. The last chunk is padded with zero bytes if it does not have length
+// bytes per chunk.
+func packParticipationBits(bytes []byte) *[][32]byte {
+	chunks := getChunks((len(bytes) + 31) / 32)
+	for i := range *chunks {
+		copy((*chunks)[i][:], bytes[i*32:])
 	}
-	chunks[len(chunks)-1] = lastChunk
-	return chunks, nil
+	return chunks
 }
@@ -0,0 +1,75 @@
+package stateutil_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/crypto/hash"
+	"github.com/prysmaticlabs/prysm/encoding/ssz"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestParticipationBitsRoot(t *testing.T) {
+	limit := (uint64(fieldparams.ValidatorRegistryLimit) + 31) / 32
+	for _, n := range []int{0, 1, 31, 32, 33, 100, 1000} {
+		bits := make([]byte, n)
+		for i := range bits {
+			bits[i] = byte(i%7 + 1)
+		}
+		var chunks [][]byte
+		for i := 0; i < n; i += 32 {
+			chunk := make([]byte, 32)
+			copy(chunk, bits[i:])
+			chunks = append(chunks, chunk)
+		}
+		root, err := ssz.BitwiseMerkleize(hash.CustomSHA256Hasher(), chunks, uint64(len(chunks)), limit)
+		require.NoError(t, err)
+		lengthRoot := make([]byte, 32)
+		binary.LittleEndian.PutUint64(lengthRoot, uint64(n))
+		want := ssz.MixInLength(root, lengthRoot)
+
+		// Twice, so that the second computation reuses pooled chunks.
+		for i := 0; i < 2; i++ {
+			got, err := stateutil.ParticipationBitsRoot(bits)
+			require.NoError(t, err)
+			assert.Equal(t, want, got, "wrong root for %d participation bits", n)
+		}
+	}
+}
+
+func BenchmarkParticipationBitsRoot(b *testing.B) {
+	bits := make([]byte, 100000)
+	for i := range bits {
+		bits[i] = byte(i % 8)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, err := stateutil.ParticipationBitsRoot(bits)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParticipationBitsRoot_WithBalances(b *testing.B) {
+	bits := make([]byte, 100000)
+	balances := make([]uint64, 100000)
+	for i := range bits {
+		bits[i] = byte(i % 8)
+		balances[i] = uint64(i)
+	}
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := stateutil.ParticipationBitsRoot(bits); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := stateutil.Uint64ListRootWithRegistryLimit(balances); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -6,7 +6,9 @@ import "sync"
 // copy-on-write for shared fields or may modify a field in place when it holds the only reference
 // to the field value. References are tracked in a map of fieldIndex -> *reference. Whenever a state
 // releases their reference to the field value, they must decrement the refs. Likewise whenever a
-// copy is performed then the state must increment the refs counter.
+// copy is performed then the state must increment the refs counter. Fields whose setters replace the
+// value as a whole rather than mutate it, such as the sync committees and the execution payload header,
+// need no copy at all: copies share the value and a setter only swaps in a new reference.
 type Reference struct {
 	refs uint
 	lock sync.RWMutex
@@ -3,6 +3,7 @@ package stateutil
 import (
 	"bytes"
 	"encoding/binary"
+	"sort"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/container/trie"
@@ -118,21 +119,59 @@ func RecomputeFromLayer(changedLeaves [][32]byte, changedIdx []uint64, layer [][
 }
 
 // RecomputeFromLayerVariable recomputes specific branches of a variable sized trie depending on the provided changed indexes.
+// The branches are recomputed a layer at a time, so that a parent shared by several changed
+// nodes is only hashed once.
 func RecomputeFromLayerVariable(changedLeaves [][32]byte, changedIdx []uint64, layer [][]*[32]byte) ([32]byte, [][]*[32]byte, error) {
 	hasher := hash.CustomSHA256Hasher()
 	if len(changedIdx) == 0 {
 		return *layer[0][0], layer, nil
 	}
-	root := *layer[len(layer)-1][0]
-	var err error
-
+	if len(changedLeaves) != len(changedIdx) {
+		return [32]byte{}, nil, errors.Errorf("wanted %d changed leaves, got %d", len(changedIdx), len(changedLeaves))
+	}
+	dirtyIdx := make([]uint64, 0, len(changedIdx))
 	for i, idx := range changedIdx {
-		root, layer, err = recomputeRootFromLayerVariable(int(idx), changedLeaves[i], layer, hasher)
-		if err != nil {
-			return [32]byte{}, nil, err
+		// Missing leaves are assumed to be zerohashes, following the
+		// structure of a sparse merkle trie.
+		for idx >= uint64(len(layer[0])) {
+			zerohash := trie.ZeroHashes[0]
+			layer[0] = append(layer[0], &zerohash)
 		}
+		item := changedLeaves[i]
+		layer[0][idx] = &item
+		dirtyIdx = append(dirtyIdx, idx)
 	}
-	return root, layer, nil
+
+	buffer := make([]byte, 64)
+	for i := 0; i < len(layer)-1; i++ {
+		parentIdx := make([]uint64, 0, len(dirtyIdx))
+		for _, idx := range dirtyIdx {
+			parentIdx = append(parentIdx, idx/2)
+		}
+		// Nodes appended to the current layer also require new parents.
+		parentLength := (len(layer[i]) + 1) / 2
+		for j := len(layer[i+1]); j < parentLength; j++ {
+			layer[i+1] = append(layer[i+1], nil)
+			parentIdx = append(parentIdx, uint64(j))
+		}
+		parentIdx = sortedUniqueIndices(parentIdx)
+
+		for _, idx := range parentIdx {
+			left := layer[i][2*idx]
+			right := trie.ZeroHashes[i]
+			if 2*idx+1 < uint64(len(layer[i])) {
+				right = *layer[i][2*idx+1]
+			}
+			copy(buffer[:32], left[:])
+			copy(buffer[32:], right[:])
+			// The layers are shared between copies of a trie, so
+			// we replace nodes instead of mutating them.
+			parent := hasher(buffer)
+			layer[i+1][idx] = &parent
+		}
+		dirtyIdx = parentIdx
+	}
+	return *layer[len(layer)-1][0], layer, nil
 }
 
 // this method assumes that the provided trie already has all its elements included
@@ -178,47 +217,30 @@ func recomputeRootFromLayer(idx int, layers [][]*[32]byte, chunks []*[32]byte,
 	return root, layers, nil
 }
 
-// this method assumes that the base branch does not consist of all leaves of the
-// trie. Instead missing leaves are assumed to be zerohashes, following the structure
-// of a sparse merkle trie.
-func recomputeRootFromLayerVariable(idx int, item [32]byte, layers [][]*[32]byte,
-	hasher func([]byte) [32]byte) ([32]byte, [][]*[32]byte, error) {
-	for idx >= len(layers[0]) {
-		zerohash := trie.ZeroHashes[0]
-		layers[0] = append(layers[0], &zerohash)
+// sortedUniqueIndices sorts the provided indices in place and removes duplicates.
+func sortedUniqueIndices(indices []uint64) []uint64 {
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+	unique := indices[:0]
+	for i, idx := range indices {
+		if i > 0 && indices[i-1] == idx {
+			continue
+		}
+		unique = append(unique, idx)
 	}
-	layers[0][idx] = &item
-
-	currentIndex := idx
-	root := item
-	for i := 0; i < len(layers)-1; i++ {
-		isLeft := currentIndex%2 == 0
-		neighborIdx := currentIndex ^ 1
+	return unique
+}
 
-		neighbor := [32]byte{}
-		if neighborIdx >= len(layers[i]) {
-			neighbor = trie.ZeroHashes[i]
-		} else {
-			neighbor = *layers[i][neighborIdx]
-		}
-		if isLeft {
-			parentHash := hasher(append(root[:], neighbor[:]...))
-			root = parentHash
-		} else {
-			parentHash := hasher(append(neighbor[:], root[:]...))
-			root = parentHash
-		}
-		parentIdx := currentIndex / 2
-		if len(layers[i+1]) == 0 || parentIdx >= len(layers[i+1]) {
-			newItem := root
-			layers[i+1] = append(layers[i+1], &newItem)
-		} else {
-			newItem := root
-			layers[i+1][parentIdx] = &newItem
-		}
-		currentIndex = parentIdx
+// ValidatorsDirtyIndicesLimit returns the number of dirty indices beyond which the validator
+// registry trie is rebuilt rather than recomputed from its layers, given the limit of the other
+// fields. Validator leaves are expensive to hash, so the registry trie is only rebuilt once there
+// are more dirty indices than validators.
+func ValidatorsDirtyIndicesLimit(numValidators, limit int) int {
+	if numValidators > limit {
+		return numValidators
 	}
-	return root, layers, nil
+	return limit
 }
 
 // AddInMixin describes a method from which a lenth mixin is added to the
@@ -112,6 +112,45 @@ func TestRecomputeFromLayer_VariableSizedArray(t *testing.T) {
 	assert.Equal(t, expectedRoot, root)
 }
 
+func TestRecomputeFromLayer_VariableSizedArray_ManyChanges(t *testing.T) {
+	limit := params.BeaconConfig().ValidatorRegistryLimit
+	roots := make([][32]byte, 1000)
+	for i := range roots {
+		roots[i] = [32]byte{byte(i), byte(i >> 8)}
+	}
+	layers := stateutil.ReturnTrieLayerVariable(roots, limit)
+
+	// Update every third leaf, including the last one, and append new leaves.
+	var changedIdx []uint64
+	var changedRoots [][32]byte
+	for i := 0; i < 1003; i += 3 {
+		changedIdx = append(changedIdx, uint64(i))
+		changedRoots = append(changedRoots, [32]byte{'A', byte(i), byte(i >> 8)})
+	}
+	updated := append(roots, [32]byte{}, [32]byte{})
+	updated = append(updated, [32]byte{})
+	for i, idx := range changedIdx {
+		updated[idx] = changedRoots[i]
+	}
+	wantLayers := stateutil.ReturnTrieLayerVariable(updated, limit)
+
+	root, layers, err := stateutil.RecomputeFromLayerVariable(changedRoots, changedIdx, layers)
+	require.NoError(t, err)
+	assert.Equal(t, *wantLayers[len(wantLayers)-1][0], root)
+	require.Equal(t, len(wantLayers), len(layers))
+	for i := range wantLayers {
+		require.Equal(t, len(wantLayers[i]), len(layers[i]))
+		for j := range wantLayers[i] {
+			assert.Equal(t, *wantLayers[i][j], *layers[i][j])
+		}
+	}
+}
+
+func TestValidatorsDirtyIndicesLimit(t *testing.T) {
+	assert.Equal(t, 8000, stateutil.ValidatorsDirtyIndicesLimit(100, 8000))
+	assert.Equal(t, 500000, stateutil.ValidatorsDirtyIndicesLimit(500000, 8000))
+}
+
 func TestMerkleizeTrieLeaves_BadHashLayer(t *testing.T) {
 	hashLayer := make([][32]byte, 12)
 	layers := make([][][32]byte, 20)
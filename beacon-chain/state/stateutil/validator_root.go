@@ -58,15 +58,16 @@ func ValidatorRootWithHasher(hasher ssz.HashFn, validator *ethpb.Validator) ([32
 // a list of uint64 and mixed with registry limit.
 func Uint64ListRootWithRegistryLimit(balances []uint64) ([32]byte, error) {
 	hasher := hash.CustomSHA256Hasher()
-	balancesMarshaling := make([][]byte, 0, len(balances))
-	for i := 0; i < len(balances); i++ {
-		balanceBuf := make([]byte, 8)
-		binary.LittleEndian.PutUint64(balanceBuf, balances[i])
-		balancesMarshaling = append(balancesMarshaling, balanceBuf)
+	// The items are packed four to a chunk, with a single zero chunk for an empty list.
+	numChunks := (len(balances) + 3) / 4
+	if numChunks == 0 {
+		numChunks = 1
 	}
-	balancesChunks, err := ssz.Pack(balancesMarshaling)
-	if err != nil {
-		return [32]byte{}, errors.Wrap(err, "could not pack balances into chunks")
+	chunks := getChunks(numChunks)
+	defer putChunks(chunks)
+	balancesChunks := *chunks
+	for i := 0; i < len(balances); i++ {
+		binary.LittleEndian.PutUint64(balancesChunks[i/4][(i%4)*8:], balances[i])
 	}
 	maxBalCap := uint64(fieldparams.ValidatorRegistryLimit)
 	elemSize := uint64(8)
@@ -78,7 +79,7 @@ func Uint64ListRootWithRegistryLimit(balances []uint64) ([32]byte, error) {
 			balLimit = uint64(len(balances))
 		}
 	}
-	balancesRootsRoot, err := ssz.BitwiseMerkleize(hasher, balancesChunks, uint64(len(balancesChunks)), balLimit)
+	balancesRootsRoot, err := ssz.BitwiseMerkleizeArrays(hasher, balancesChunks, uint64(len(balancesChunks)), balLimit)
 	if err != nil {
 		return [32]byte{}, errors.Wrap(err, "could not compute balances merkleization")
 	}
@@ -1,9 +1,15 @@
 package stateutil_test
 
 import (
+	"encoding/binary"
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/crypto/hash"
+	"github.com/prysmaticlabs/prysm/encoding/ssz"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
 )
 
 func BenchmarkUint64ListRootWithRegistryLimit(b *testing.B) {
@@ -12,6 +18,7 @@ func BenchmarkUint64ListRootWithRegistryLimit(b *testing.B) {
 		balances[i] = uint64(i)
 	}
 	b.Run("100k balances", func(b *testing.B) {
+		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
 			_, err := stateutil.Uint64ListRootWithRegistryLimit(balances)
 			if err != nil {
@@ -20,3 +27,30 @@ func BenchmarkUint64ListRootWithRegistryLimit(b *testing.B) {
 		}
 	})
 }
+
+func TestUint64ListRootWithRegistryLimit(t *testing.T) {
+	limit := (uint64(fieldparams.ValidatorRegistryLimit)*8 + 31) / 32
+	for _, n := range []int{0, 1, 3, 4, 5, 100, 1001} {
+		list := make([]uint64, n)
+		items := make([][]byte, n)
+		for i := range list {
+			list[i] = uint64(i) * 1e9
+			items[i] = make([]byte, 8)
+			binary.LittleEndian.PutUint64(items[i], list[i])
+		}
+		chunks, err := ssz.Pack(items)
+		require.NoError(t, err)
+		root, err := ssz.BitwiseMerkleize(hash.CustomSHA256Hasher(), chunks, uint64(len(chunks)), limit)
+		require.NoError(t, err)
+		lengthRoot := make([]byte, 32)
+		binary.LittleEndian.PutUint64(lengthRoot, uint64(n))
+		want := ssz.MixInLength(root, lengthRoot)
+
+		// Twice, so that the second computation reuses pooled chunks.
+		for i := 0; i < 2; i++ {
+			got, err := stateutil.Uint64ListRootWithRegistryLimit(list)
+			require.NoError(t, err)
+			assert.Equal(t, want, got, "wrong root for %d items", n)
+		}
+	}
+}
@@ -68,9 +68,16 @@ func TestAppendBeyondIndicesLimit(t *testing.T) {
 	assert.Equal(t, false, st.rebuildTrie[validators])
 	assert.NotEqual(t, len(st.dirtyIndices[validators]), 0)
 
+	// The validator trie is only rebuilt once there are more dirty indices than validators.
 	for i := 0; i < indicesLimit; i++ {
 		assert.NoError(t, st.AppendValidator(&ethpb.Validator{}))
 	}
+	assert.Equal(t, false, st.rebuildTrie[validators])
+	assert.Equal(t, indicesLimit+10, len(st.dirtyIndices[validators]))
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, st.UpdateValidatorAtIndex(0, &ethpb.Validator{}))
+	}
 	assert.Equal(t, true, st.rebuildTrie[validators])
 	assert.Equal(t, len(st.dirtyIndices[validators]), 0)
 }
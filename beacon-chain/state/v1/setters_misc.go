@@ -178,10 +178,19 @@ func (b *BeaconState) addDirtyIndices(index stateTypes.FieldIndex, indices []uin
 		return
 	}
 	totalIndicesLen := len(b.dirtyIndices[index]) + len(indices)
-	if totalIndicesLen > indicesLimit {
+	if totalIndicesLen > b.dirtyIndicesLimit(index) {
 		b.rebuildTrie[index] = true
 		b.dirtyIndices[index] = []uint64{}
 	} else {
 		b.dirtyIndices[index] = append(b.dirtyIndices[index], indices...)
 	}
 }
+
+// dirtyIndicesLimit returns the number of dirty indices of a field beyond which its
+// trie is rebuilt, see stateutil.ValidatorsDirtyIndicesLimit for the validator registry.
+func (b *BeaconState) dirtyIndicesLimit(index stateTypes.FieldIndex) int {
+	if index == validators {
+		return stateutil.ValidatorsDirtyIndicesLimit(len(b.state.Validators), indicesLimit)
+	}
+	return indicesLimit
+}
@@ -77,7 +77,7 @@ func InitializeFromProtoUnsafe(st *ethpb.BeaconStateAltair) (state.BeaconStateAl
 		dirtyFields:           make(map[types.FieldIndex]bool, fieldCount),
 		dirtyIndices:          make(map[types.FieldIndex][]uint64, fieldCount),
 		stateFieldLeaves:      make(map[types.FieldIndex]*fieldtrie.FieldTrie, fieldCount),
-		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 11),
+		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 13),
 		rebuildTrie:           make(map[types.FieldIndex]bool, fieldCount),
 		valMapHandler:         stateutil.NewValMapHandler(st.Validators),
 	}
@@ -105,6 +105,8 @@ func InitializeFromProtoUnsafe(st *ethpb.BeaconStateAltair) (state.BeaconStateAl
 	b.sharedFieldReferences[balances] = stateutil.NewRef(1)
 	b.sharedFieldReferences[inactivityScores] = stateutil.NewRef(1) // New in Altair.
 	b.sharedFieldReferences[historicalRoots] = stateutil.NewRef(1)
+	b.sharedFieldReferences[currentSyncCommittee] = stateutil.NewRef(1) // New in Altair.
+	b.sharedFieldReferences[nextSyncCommittee] = stateutil.NewRef(1)    // New in Altair.
 
 	state.StateCount.Inc()
 	return b, nil
@@ -151,13 +153,15 @@ func (b *BeaconState) Copy() state.BeaconState {
 			CurrentJustifiedCheckpoint:  b.currentJustifiedCheckpoint(),
 			FinalizedCheckpoint:         b.finalizedCheckpoint(),
 			GenesisValidatorsRoot:       b.genesisValidatorsRoot(),
-			CurrentSyncCommittee:        b.currentSyncCommittee(),
-			NextSyncCommittee:           b.nextSyncCommittee(),
+
+			// Shared by reference, see stateutil.Reference.
+			CurrentSyncCommittee: b.state.CurrentSyncCommittee,
+			NextSyncCommittee:    b.state.NextSyncCommittee,
 		},
 		dirtyFields:           make(map[types.FieldIndex]bool, fieldCount),
 		dirtyIndices:          make(map[types.FieldIndex][]uint64, fieldCount),
 		rebuildTrie:           make(map[types.FieldIndex]bool, fieldCount),
-		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 11),
+		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 13),
 		stateFieldLeaves:      make(map[types.FieldIndex]*fieldtrie.FieldTrie, fieldCount),
 
 		// Share the reference to validator index map.
@@ -169,3 +169,37 @@ func TestInitializeFromProtoUnsafe(t *testing.T) {
 	}
 	_ = initTests
 }
+
+func TestBeaconState_Copy_SharesSyncCommittees(t *testing.T) {
+	committee := &ethpb.SyncCommittee{
+		Pubkeys:         [][]byte{bytesutil.PadTo([]byte{'a'}, fieldparams.BLSPubkeyLength)},
+		AggregatePubkey: bytesutil.PadTo([]byte{'b'}, fieldparams.BLSPubkeyLength),
+	}
+	st, err := InitializeFromProto(&ethpb.BeaconStateAltair{CurrentSyncCommittee: committee, NextSyncCommittee: committee})
+	require.NoError(t, err)
+	a, ok := st.(*BeaconState)
+	require.Equal(t, true, ok)
+	b, ok := a.Copy().(*BeaconState)
+	require.Equal(t, true, ok)
+
+	// The committees are shared until one of the states replaces them.
+	assert.Equal(t, a.state.CurrentSyncCommittee, b.state.CurrentSyncCommittee)
+	assert.Equal(t, a.state.NextSyncCommittee, b.state.NextSyncCommittee)
+	assert.Equal(t, uint(2), a.sharedFieldReferences[currentSyncCommittee].Refs())
+	assert.Equal(t, uint(2), a.sharedFieldReferences[nextSyncCommittee].Refs())
+
+	updated := &ethpb.SyncCommittee{
+		Pubkeys:         [][]byte{bytesutil.PadTo([]byte{'c'}, fieldparams.BLSPubkeyLength)},
+		AggregatePubkey: bytesutil.PadTo([]byte{'d'}, fieldparams.BLSPubkeyLength),
+	}
+	require.NoError(t, b.SetCurrentSyncCommittee(updated))
+	assert.Equal(t, uint(1), a.sharedFieldReferences[currentSyncCommittee].Refs())
+	assert.Equal(t, uint(1), b.sharedFieldReferences[currentSyncCommittee].Refs())
+	assert.Equal(t, uint(2), a.sharedFieldReferences[nextSyncCommittee].Refs())
+	got, err := a.CurrentSyncCommittee()
+	require.NoError(t, err)
+	assert.DeepEqual(t, committee, got)
+	got, err = b.CurrentSyncCommittee()
+	require.NoError(t, err)
+	assert.DeepEqual(t, updated, got)
+}
@@ -1,6 +1,7 @@
 package v3
 
 import (
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 )
 
@@ -12,6 +13,9 @@ func (b *BeaconState) SetCurrentSyncCommittee(val *ethpb.SyncCommittee) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
+	b.sharedFieldReferences[currentSyncCommittee].MinusRef()
+	b.sharedFieldReferences[currentSyncCommittee] = stateutil.NewRef(1)
+
 	b.state.CurrentSyncCommittee = val
 	b.markFieldAsDirty(currentSyncCommittee)
 	return nil
@@ -25,6 +29,9 @@ func (b *BeaconState) SetNextSyncCommittee(val *ethpb.SyncCommittee) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
+	b.sharedFieldReferences[nextSyncCommittee].MinusRef()
+	b.sharedFieldReferences[nextSyncCommittee] = stateutil.NewRef(1)
+
 	b.state.NextSyncCommittee = val
 	b.markFieldAsDirty(nextSyncCommittee)
 	return nil
@@ -48,7 +48,7 @@ func InitializeFromProtoUnsafe(st *ethpb.BeaconStateBellatrix) (state.BeaconStat
 		dirtyFields:           make(map[types.FieldIndex]bool, fieldCount),
 		dirtyIndices:          make(map[types.FieldIndex][]uint64, fieldCount),
 		stateFieldLeaves:      make(map[types.FieldIndex]*fieldtrie.FieldTrie, fieldCount),
-		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 11),
+		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 14),
 		rebuildTrie:           make(map[types.FieldIndex]bool, fieldCount),
 		valMapHandler:         stateutil.NewValMapHandler(st.Validators),
 	}
@@ -76,6 +76,8 @@ func InitializeFromProtoUnsafe(st *ethpb.BeaconStateBellatrix) (state.BeaconStat
 	b.sharedFieldReferences[balances] = stateutil.NewRef(1)
 	b.sharedFieldReferences[inactivityScores] = stateutil.NewRef(1) // New in Altair.
 	b.sharedFieldReferences[historicalRoots] = stateutil.NewRef(1)
+	b.sharedFieldReferences[currentSyncCommittee] = stateutil.NewRef(1)         // New in Altair.
+	b.sharedFieldReferences[nextSyncCommittee] = stateutil.NewRef(1)            // New in Altair.
 	b.sharedFieldReferences[latestExecutionPayloadHeader] = stateutil.NewRef(1) // New in Bellatrix.
 	state.StateCount.Inc()
 	return b, nil
@@ -114,22 +116,24 @@ func (b *BeaconState) Copy() state.BeaconState {
 			InactivityScores:           b.state.InactivityScores,
 
 			// Everything else, too small to be concerned about, constant size.
-			Fork:                         b.fork(),
-			LatestBlockHeader:            b.latestBlockHeader(),
-			Eth1Data:                     b.eth1Data(),
-			JustificationBits:            b.justificationBits(),
-			PreviousJustifiedCheckpoint:  b.previousJustifiedCheckpoint(),
-			CurrentJustifiedCheckpoint:   b.currentJustifiedCheckpoint(),
-			FinalizedCheckpoint:          b.finalizedCheckpoint(),
-			GenesisValidatorsRoot:        b.genesisValidatorsRoot(),
-			CurrentSyncCommittee:         b.currentSyncCommittee(),
-			NextSyncCommittee:            b.nextSyncCommittee(),
-			LatestExecutionPayloadHeader: b.latestExecutionPayloadHeader(),
+			Fork:                        b.fork(),
+			LatestBlockHeader:           b.latestBlockHeader(),
+			Eth1Data:                    b.eth1Data(),
+			JustificationBits:           b.justificationBits(),
+			PreviousJustifiedCheckpoint: b.previousJustifiedCheckpoint(),
+			CurrentJustifiedCheckpoint:  b.currentJustifiedCheckpoint(),
+			FinalizedCheckpoint:         b.finalizedCheckpoint(),
+			GenesisValidatorsRoot:       b.genesisValidatorsRoot(),
+
+			// Shared by reference, see stateutil.Reference.
+			CurrentSyncCommittee:         b.state.CurrentSyncCommittee,
+			NextSyncCommittee:            b.state.NextSyncCommittee,
+			LatestExecutionPayloadHeader: b.state.LatestExecutionPayloadHeader,
 		},
 		dirtyFields:           make(map[types.FieldIndex]bool, fieldCount),
 		dirtyIndices:          make(map[types.FieldIndex][]uint64, fieldCount),
 		rebuildTrie:           make(map[types.FieldIndex]bool, fieldCount),
-		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 11),
+		sharedFieldReferences: make(map[types.FieldIndex]*stateutil.Reference, 14),
 		stateFieldLeaves:      make(map[types.FieldIndex]*fieldtrie.FieldTrie, fieldCount),
 
 		// Copy on write validator index map.
@@ -168,3 +168,40 @@ func TestInitializeFromProtoUnsafe(t *testing.T) {
 	}
 	_ = initTests
 }
+
+func TestBeaconState_Copy_SharesPayloadHeader(t *testing.T) {
+	header := &ethpb.ExecutionPayloadHeader{
+		ParentHash:       make([]byte, 32),
+		FeeRecipient:     make([]byte, 20),
+		StateRoot:        make([]byte, 32),
+		ReceiptRoot:      make([]byte, 32),
+		LogsBloom:        make([]byte, 256),
+		Random:           make([]byte, 32),
+		BaseFeePerGas:    make([]byte, 32),
+		BlockHash:        bytesutil.PadTo([]byte{'a'}, 32),
+		TransactionsRoot: make([]byte, 32),
+	}
+	st, err := InitializeFromProto(&ethpb.BeaconStateBellatrix{LatestExecutionPayloadHeader: header})
+	require.NoError(t, err)
+	a, ok := st.(*BeaconState)
+	require.Equal(t, true, ok)
+	b, ok := a.Copy().(*BeaconState)
+	require.Equal(t, true, ok)
+
+	// The header is shared until one of the states replaces it.
+	assert.Equal(t, a.state.LatestExecutionPayloadHeader, b.state.LatestExecutionPayloadHeader)
+	assert.Equal(t, a.state.CurrentSyncCommittee, b.state.CurrentSyncCommittee)
+	assert.Equal(t, uint(2), a.sharedFieldReferences[latestExecutionPayloadHeader].Refs())
+
+	updated := ethpb.CopyExecutionPayloadHeader(header)
+	updated.BlockHash = bytesutil.PadTo([]byte{'b'}, 32)
+	require.NoError(t, b.SetLatestExecutionPayloadHeader(updated))
+	assert.Equal(t, uint(1), a.sharedFieldReferences[latestExecutionPayloadHeader].Refs())
+	assert.Equal(t, uint(1), b.sharedFieldReferences[latestExecutionPayloadHeader].Refs())
+	got, err := a.LatestExecutionPayloadHeader()
+	require.NoError(t, err)
+	assert.DeepEqual(t, header, got)
+	got, err = b.LatestExecutionPayloadHeader()
+	require.NoError(t, err)
+	assert.DeepEqual(t, updated, got)
+}
@@ -0,0 +1,21 @@
+package backfill
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	backfillLowestSlot = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "backfill_lowest_slot",
+		Help: "Slot of the lowest block filled in below the checkpoint sync origin",
+	})
+	backfillBlocksCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backfill_blocks_count",
+		Help: "Number of blocks fetched from peers and saved by backfill",
+	})
+	backfillBatchFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backfill_batch_failures_count",
+		Help: "Number of batches of blocks which could not be fetched or verified",
+	})
+)
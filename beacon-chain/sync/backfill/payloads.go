@@ -0,0 +1,98 @@
+package backfill
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+var errPayloadUnavailable = errors.New("execution payload is not available from the execution node")
+
+// checkPayloadBodies checks that the execution node can reconstruct the execution payloads of the
+// blocks of a batch, sorted by increasing slot, by fetching the bodies of their consecutive
+// payloads at once. Blocks saved blinded are read back with these bodies, so the transactions
+// downloaded from peers are dropped on the assumption that the execution node serves them.
+func (s *Service) checkPayloadBodies(ctx context.Context, blks []block.SignedBeaconBlock) error {
+	var numbers []uint64
+	var transactions [][][]byte
+	for _, blk := range blks {
+		if blk.Version() != version.Bellatrix {
+			continue
+		}
+		payload, err := blk.Block().Body().ExecutionPayload()
+		if err != nil {
+			return err
+		}
+		// Blocks before the merge have an empty payload.
+		if payload == nil || payload.BlockNumber == 0 {
+			continue
+		}
+		numbers = append(numbers, payload.BlockNumber)
+		transactions = append(transactions, payload.Transactions)
+	}
+	if len(numbers) == 0 {
+		return nil
+	}
+	start, count := numbers[0], uint64(len(numbers))
+	if numbers[len(numbers)-1] != start+count-1 {
+		return errors.Errorf("execution payloads %d to %d are not consecutive", start, numbers[len(numbers)-1])
+	}
+	bodies, err := s.cfg.PayloadBodies.GetPayloadBodiesByRange(ctx, start, count)
+	if err != nil {
+		return errors.Wrap(err, "could not get execution payload bodies")
+	}
+	for i, number := range numbers {
+		// Payloads without transactions are saved in full.
+		if len(transactions[i]) == 0 {
+			continue
+		}
+		if i >= len(bodies) || bodies[i] == nil {
+			return errors.Wrapf(errPayloadUnavailable, "execution block %d", number)
+		}
+		if !equalTransactions(bodies[i].Transactions, transactions[i]) {
+			return errors.Errorf("transactions of execution block %d do not match the backfilled block", number)
+		}
+	}
+	return nil
+}
+
+// waitForPayloadBodies waits until the execution node can reconstruct the execution payloads of the
+// blocks of a batch, such as while it is syncing, so that they do not have to be fetched again from
+// peers.
+func (s *Service) waitForPayloadBodies(blks []block.SignedBeaconBlock) error {
+	for {
+		err := s.checkPayloadBodies(s.ctx, blks)
+		if err == nil {
+			return nil
+		}
+		if s.ctx.Err() != nil {
+			return s.ctx.Err()
+		}
+		log.WithError(err).WithFields(logrus.Fields{
+			"startSlot": blks[0].Block().Slot(),
+			"endSlot":   blks[len(blks)-1].Block().Slot(),
+		}).Warn("Execution node cannot reconstruct the payloads of backfilled blocks, waiting before saving them")
+		select {
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+func equalTransactions(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,267 @@
+// Package backfill fills in the history of the chain below the origin block of a beacon node
+// started from a checkpoint, so that blocks older than the checkpoint can still be served to
+// peers and over the APIs.
+package backfill
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/async/abool"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/runtime"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var _ runtime.Service = (*Service)(nil)
+
+// Number of slots of blocks requested from a peer at once.
+const batchSize = types.Slot(64)
+
+var (
+	// Interval at which suitable peers are looked up when there are none.
+	peerPollingInterval = 5 * time.Second
+	// Time to wait before retrying a batch which could not be fetched or verified.
+	retryInterval = time.Second
+)
+
+// blocksFetcher requests a range of blocks from a peer.
+type blocksFetcher func(ctx context.Context, pid peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]block.SignedBeaconBlock, error)
+
+// PayloadBodiesFetcher fetches the execution payload bodies of a range of execution blocks.
+type PayloadBodiesFetcher interface {
+	GetPayloadBodiesByRange(ctx context.Context, start, count uint64) ([]*enginev1.ExecutionPayloadBodyV1, error)
+}
+
+// Checker reports whether blocks are being backfilled.
+type Checker interface {
+	Backfilling() bool
+}
+
+// Config to set up the backfill service.
+type Config struct {
+	P2P   p2p.P2P
+	DB    db.NoHeadAccessDatabase
+	Chain blockchain.ChainInfoFetcher
+	// Number of epochs of blocks, counted back from the current epoch, to fill in.
+	// Zero fills in the history back to genesis.
+	RetentionEpochs types.Epoch
+	// Fetcher of the execution payload bodies, set when blocks are saved blinded, to check that the
+	// execution node can reconstruct the payloads of the backfilled blocks before they are saved.
+	PayloadBodies PayloadBodiesFetcher
+}
+
+// Service fetches the blocks below the origin block from peers, backwards from the lowest
+// block known to the node, until it reaches genesis or the configured retention point.
+type Service struct {
+	cfg         *Config
+	ctx         context.Context
+	cancel      context.CancelFunc
+	fetchBlocks blocksFetcher
+	backfilling *abool.AtomicBool
+}
+
+// NewService configures the backfill service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		cfg:         cfg,
+		ctx:         ctx,
+		cancel:      cancel,
+		backfilling: abool.New(),
+	}
+	s.fetchBlocks = func(ctx context.Context, pid peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]block.SignedBeaconBlock, error) {
+		return prysmsync.SendBeaconBlocksByRangeRequest(ctx, s.cfg.Chain, s.cfg.P2P, pid, req, nil)
+	}
+	return s
+}
+
+// Start the backfill service.
+func (s *Service) Start() {
+	if err := s.backfill(); err != nil {
+		if errors.Is(s.ctx.Err(), context.Canceled) {
+			return
+		}
+		log.WithError(err).Error("Could not backfill blocks")
+	}
+}
+
+// Stop the backfill service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Backfilling returns true while the blocks below the origin block are being fetched.
+func (s *Service) Backfilling() bool {
+	return s.backfilling.IsSet()
+}
+
+// Status of the backfill service.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) backfill() error {
+	originRoot, err := s.cfg.DB.OriginBlockRoot(s.ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		log.Debug("Node was not started from a checkpoint, nothing to backfill")
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not get origin block root")
+	}
+	originState, err := s.cfg.DB.State(s.ctx, originRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not get origin state")
+	}
+	if originState == nil || originState.IsNil() {
+		return errors.New("origin state not found")
+	}
+
+	// Resume from the lowest block filled in so far.
+	lowestRoot, err := s.cfg.DB.BackfillBlockRoot(s.ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		lowestRoot = originRoot
+	} else if err != nil {
+		return errors.Wrap(err, "could not get backfill block root")
+	}
+	lowest, err := s.cfg.DB.Block(s.ctx, lowestRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not get lowest backfilled block")
+	}
+	if lowest == nil || lowest.IsNil() {
+		return errors.Errorf("lowest backfilled block %#x not found", lowestRoot)
+	}
+
+	target := s.targetSlot(originState)
+	originEpoch := slots.ToEpoch(originState.Slot())
+	log.WithFields(logrus.Fields{
+		"lowestSlot": lowest.Block().Slot(),
+		"targetSlot": target,
+	}).Info("Backfilling blocks below checkpoint sync origin")
+	s.backfilling.Set()
+	defer s.backfilling.UnSet()
+
+	// Blocks below cursor have not been requested yet. The cursor may move below the lowest
+	// block over empty ranges, which are only confirmed by the next block linking to the chain.
+	cursor := lowest.Block().Slot()
+	attempt := 0
+	for {
+		parentRoot := bytesutil.ToBytes32(lowest.Block().ParentRoot())
+		if parentRoot == params.BeaconConfig().ZeroHash || s.cfg.DB.HasBlock(s.ctx, parentRoot) || cursor <= target {
+			break
+		}
+		start := target
+		if cursor > target+batchSize {
+			start = cursor - batchSize
+		}
+		pids, err := s.waitForPeers(originEpoch)
+		if err != nil {
+			return err
+		}
+		pid := pids[attempt%len(pids)]
+		blks, err := s.fetchBlocks(s.ctx, pid, &ethpb.BeaconBlocksByRangeRequest{
+			StartSlot: start,
+			Count:     uint64(cursor - start),
+			Step:      1,
+		})
+		if err == nil {
+			err = verifyBatch(originState, blks, parentRoot)
+		}
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return s.ctx.Err()
+			}
+			backfillBatchFailures.Inc()
+			s.cfg.P2P.Peers().Scorers().BadResponsesScorer().Increment(pid)
+			log.WithError(err).WithFields(logrus.Fields{
+				"peer":      pid,
+				"startSlot": start,
+			}).Debug("Could not backfill batch")
+			// A peer may have withheld the blocks of the empty ranges skipped so far,
+			// so start over from the lowest block known to be part of the chain.
+			cursor = lowest.Block().Slot()
+			attempt++
+			select {
+			case <-s.ctx.Done():
+				return s.ctx.Err()
+			case <-time.After(retryInterval):
+			}
+			continue
+		}
+		if len(blks) > 0 {
+			if s.cfg.PayloadBodies != nil {
+				if err := s.waitForPayloadBodies(blks); err != nil {
+					return err
+				}
+			}
+			if err := s.saveBatch(blks); err != nil {
+				return err
+			}
+			lowest = blks[0]
+		}
+		cursor = start
+	}
+	log.WithField("lowestSlot", lowest.Block().Slot()).Info("Backfill complete")
+	return nil
+}
+
+func (s *Service) saveBatch(blks []block.SignedBeaconBlock) error {
+	if err := s.cfg.DB.SaveBlocks(s.ctx, blks); err != nil {
+		return errors.Wrap(err, "could not save backfilled blocks")
+	}
+	root, err := blks[0].Block().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute block root")
+	}
+	if err := s.cfg.DB.SaveBackfillBlockRoot(s.ctx, root); err != nil {
+		return errors.Wrap(err, "could not save backfill block root")
+	}
+	backfillBlocksCount.Add(float64(len(blks)))
+	backfillLowestSlot.Set(float64(blks[0].Block().Slot()))
+	return nil
+}
+
+// targetSlot is the lowest slot to fill in, as configured by the retention period.
+func (s *Service) targetSlot(originState state.ReadOnlyBeaconState) types.Slot {
+	if s.cfg.RetentionEpochs == 0 {
+		return 0
+	}
+	currentSlot := slots.CurrentSlot(originState.GenesisTime())
+	retention := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(s.cfg.RetentionEpochs))
+	if currentSlot <= retention {
+		return 0
+	}
+	return currentSlot - retention
+}
+
+// waitForPeers returns the peers able to serve blocks up to the origin epoch,
+// waiting for some to be connected if there are none.
+func (s *Service) waitForPeers(originEpoch types.Epoch) ([]peer.ID, error) {
+	for {
+		_, pids := s.cfg.P2P.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, originEpoch)
+		if len(pids) > 0 {
+			return pids, nil
+		}
+		log.Debug("Waiting for suitable peers to backfill from")
+		select {
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		case <-time.After(peerPollingInterval):
+		}
+	}
+}
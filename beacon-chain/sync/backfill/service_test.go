@@ -0,0 +1,279 @@
+package backfill
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+// testChain builds a chain of signed blocks from genesis up to the given slot, skipping the slots for which skip is true.
+func testChain(t *testing.T, st state.BeaconState, keys []bls.SecretKey, head types.Slot, skip func(types.Slot) bool) []block.SignedBeaconBlock {
+	genesis := util.NewBeaconBlock()
+	chain := []block.SignedBeaconBlock{wrapper.WrappedPhase0SignedBeaconBlock(genesis)}
+	parentRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	for slot := types.Slot(1); slot <= head; slot++ {
+		if skip(slot) && slot != head {
+			continue
+		}
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = slot
+		blk.Block.ProposerIndex = types.ValidatorIndex(uint64(slot) % uint64(len(keys)))
+		blk.Block.ParentRoot = bytesutil.SafeCopyBytes(parentRoot[:])
+		blk.Signature, err = signing.ComputeDomainAndSign(st, slots.ToEpoch(slot), blk.Block, params.BeaconConfig().DomainBeaconProposer, keys[blk.Block.ProposerIndex])
+		require.NoError(t, err)
+		parentRoot, err = blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		chain = append(chain, wrapper.WrappedPhase0SignedBeaconBlock(blk))
+	}
+	return chain
+}
+
+// setupOrigin saves the genesis block and the head of the chain as the checkpoint sync origin.
+func setupOrigin(t *testing.T, beaconDB db.Database, st state.BeaconState, chain []block.SignedBeaconBlock) {
+	ctx := context.Background()
+	genesisRoot, err := chain[0].Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, chain[0]))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+	origin := chain[len(chain)-1]
+	require.NoError(t, st.SetSlot(origin.Block().Slot()))
+	sb, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	bb, err := origin.MarshalSSZ()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveOrigin(ctx, bytes.NewReader(sb), bytes.NewReader(bb)))
+}
+
+// servePeer serves the blocks of the chain, tampering with the responses of the first failures requests.
+func servePeer(chain []block.SignedBeaconBlock, failures int) blocksFetcher {
+	return func(_ context.Context, _ peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]block.SignedBeaconBlock, error) {
+		var blks []block.SignedBeaconBlock
+		for _, blk := range chain {
+			if blk.Block().Slot() >= req.StartSlot && blk.Block().Slot() < req.StartSlot.Add(req.Count) {
+				blks = append(blks, blk)
+			}
+		}
+		if failures > 0 && len(blks) > 0 {
+			failures--
+			tampered := blks[len(blks)-1].Copy().Proto().(*ethpb.SignedBeaconBlock)
+			tampered.Block.StateRoot = make([]byte, 32)
+			tampered.Block.StateRoot[0] = 'a'
+			blks[len(blks)-1] = wrapper.WrappedPhase0SignedBeaconBlock(tampered)
+		}
+		return blks, nil
+	}
+}
+
+func addPeer(t *testing.T, p *p2ptest.TestP2P, finalizedEpoch types.Epoch) {
+	pid := peer.ID("peer")
+	p.Peers().Add(new(enr.Record), pid, nil, network.DirOutbound)
+	p.Peers().SetConnectionState(pid, peers.PeerConnected)
+	p.Peers().SetChainState(pid, &ethpb.Status{
+		FinalizedEpoch: finalizedEpoch,
+	})
+}
+
+func TestService_Backfill(t *testing.T) {
+	ctx := context.Background()
+	st, keys := util.DeterministicGenesisState(t, 64)
+	head := 3*batchSize + 5
+	chain := testChain(t, st.Copy(), keys, head, func(slot types.Slot) bool {
+		return slot%7 == 0 || (slot > batchSize && slot < 2*batchSize)
+	})
+	beaconDB := dbtest.SetupDB(t)
+	setupOrigin(t, beaconDB, st, chain)
+
+	p := p2ptest.NewTestP2P(t)
+	addPeer(t, p, slots.ToEpoch(head))
+	s := NewService(ctx, &Config{P2P: p, DB: beaconDB})
+	fetchBlocks := servePeer(chain, 0)
+	s.fetchBlocks = func(ctx context.Context, pid peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]block.SignedBeaconBlock, error) {
+		assert.Equal(t, true, s.Backfilling())
+		return fetchBlocks(ctx, pid, req)
+	}
+	require.NoError(t, s.backfill())
+	assert.Equal(t, false, s.Backfilling())
+
+	for _, blk := range chain {
+		root, err := blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, true, beaconDB.HasBlock(ctx, root), "Block at slot %d was not backfilled", blk.Block().Slot())
+	}
+	lowestRoot, err := chain[0].Block().HashTreeRoot()
+	require.NoError(t, err)
+	backfillRoot, err := beaconDB.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, lowestRoot, backfillRoot)
+}
+
+func TestService_Backfill_RetriesInvalidBatches(t *testing.T) {
+	ctx := context.Background()
+	retryInterval = 0
+	st, keys := util.DeterministicGenesisState(t, 64)
+	head := 2*batchSize + 5
+	chain := testChain(t, st.Copy(), keys, head, func(types.Slot) bool { return false })
+	beaconDB := dbtest.SetupDB(t)
+	setupOrigin(t, beaconDB, st, chain)
+
+	p := p2ptest.NewTestP2P(t)
+	addPeer(t, p, slots.ToEpoch(head))
+	s := NewService(ctx, &Config{P2P: p, DB: beaconDB})
+	s.fetchBlocks = servePeer(chain, 2)
+	require.NoError(t, s.backfill())
+
+	for _, blk := range chain {
+		root, err := blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, true, beaconDB.HasBlock(ctx, root), "Block at slot %d was not backfilled", blk.Block().Slot())
+	}
+}
+
+func TestService_Backfill_Retention(t *testing.T) {
+	ctx := context.Background()
+	st, keys := util.DeterministicGenesisState(t, 64)
+	head := 4 * params.BeaconConfig().SlotsPerEpoch
+	chain := testChain(t, st.Copy(), keys, head, func(types.Slot) bool { return false })
+	// Start the chain so that the head slot is the current slot.
+	genesisTime := time.Now().Add(-time.Duration(uint64(head)*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	require.NoError(t, st.SetGenesisTime(uint64(genesisTime.Unix())))
+	beaconDB := dbtest.SetupDB(t)
+	setupOrigin(t, beaconDB, st, chain)
+
+	p := p2ptest.NewTestP2P(t)
+	addPeer(t, p, slots.ToEpoch(head))
+	s := NewService(ctx, &Config{P2P: p, DB: beaconDB, RetentionEpochs: 2})
+	s.fetchBlocks = servePeer(chain, 0)
+	require.NoError(t, s.backfill())
+
+	target := head - 2*params.BeaconConfig().SlotsPerEpoch
+	for _, blk := range chain[1:] {
+		root, err := blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, blk.Block().Slot() >= target, beaconDB.HasBlock(ctx, root), "Unexpected backfill of block at slot %d", blk.Block().Slot())
+	}
+}
+
+func TestService_Backfill_NoOrigin(t *testing.T) {
+	s := NewService(context.Background(), &Config{P2P: p2ptest.NewTestP2P(t), DB: dbtest.SetupDB(t)})
+	s.fetchBlocks = func(context.Context, peer.ID, *ethpb.BeaconBlocksByRangeRequest) ([]block.SignedBeaconBlock, error) {
+		t.Fatal("Unexpected blocks request")
+		return nil, nil
+	}
+	require.NoError(t, s.backfill())
+}
+
+func TestVerifyBatch(t *testing.T) {
+	st, keys := util.DeterministicGenesisState(t, 64)
+	chain := testChain(t, st.Copy(), keys, 10, func(types.Slot) bool { return false })
+	parentRoot := bytesutil.ToBytes32(chain[10].Block().ParentRoot())
+
+	require.NoError(t, verifyBatch(st, chain[:10], parentRoot))
+	require.ErrorIs(t, verifyBatch(st, chain[:9], parentRoot), errUnknownParent)
+	require.ErrorIs(t, verifyBatch(st, append(chain[:5:5], chain[6:10]...), parentRoot), errUnknownParent)
+
+	// A block signed by another proposer.
+	forged := chain[9].Copy().Proto().(*ethpb.SignedBeaconBlock)
+	forged.Block.ProposerIndex++
+	forgedRoot, err := forged.Block.HashTreeRoot()
+	require.NoError(t, err)
+	err = verifyBatch(st, []block.SignedBeaconBlock{wrapper.WrappedPhase0SignedBeaconBlock(forged)}, forgedRoot)
+	require.ErrorContains(t, "could not verify signature", err)
+}
+
+type mockPayloadBodies struct {
+	bodies   []*enginev1.ExecutionPayloadBodyV1
+	requests []uint64
+	// Number of requests answered without the bodies, as while the execution node syncs.
+	unavailable int
+}
+
+func (m *mockPayloadBodies) GetPayloadBodiesByRange(_ context.Context, start, count uint64) ([]*enginev1.ExecutionPayloadBodyV1, error) {
+	m.requests = append(m.requests, start, count)
+	if m.unavailable > 0 {
+		m.unavailable--
+		return make([]*enginev1.ExecutionPayloadBodyV1, count), nil
+	}
+	return m.bodies, nil
+}
+
+// payloadChain returns bellatrix blocks with the given transactions in their consecutive payloads,
+// after a block from before the merge.
+func payloadChain(t *testing.T, firstNumber uint64, transactions ...[][]byte) []block.SignedBeaconBlock {
+	preMerge, err := wrapper.WrappedBellatrixSignedBeaconBlock(util.NewBeaconBlockBellatrix())
+	require.NoError(t, err)
+	blks := []block.SignedBeaconBlock{preMerge}
+	for i, txs := range transactions {
+		b := util.NewBeaconBlockBellatrix()
+		b.Block.Body.ExecutionPayload.BlockNumber = firstNumber + uint64(i)
+		b.Block.Body.ExecutionPayload.Transactions = txs
+		blk, err := wrapper.WrappedBellatrixSignedBeaconBlock(b)
+		require.NoError(t, err)
+		blks = append(blks, blk)
+	}
+	return blks
+}
+
+func TestService_CheckPayloadBodies(t *testing.T) {
+	ctx := context.Background()
+	txs := [][]byte{{'a'}, {'b'}}
+	blks := payloadChain(t, 10, txs, nil, txs)
+
+	fetcher := &mockPayloadBodies{bodies: []*enginev1.ExecutionPayloadBodyV1{{Transactions: txs}, nil, {Transactions: txs}}}
+	s := NewService(ctx, &Config{PayloadBodies: fetcher})
+	require.NoError(t, s.checkPayloadBodies(ctx, blks))
+	// The bodies of the payloads are fetched at once, skipping the block before the merge.
+	assert.DeepEqual(t, []uint64{10, 3}, fetcher.requests)
+
+	// The payloads without transactions are not reconstructed.
+	fetcher.bodies = []*enginev1.ExecutionPayloadBodyV1{{Transactions: txs}, nil, nil}
+	require.ErrorIs(t, s.checkPayloadBodies(ctx, blks), errPayloadUnavailable)
+	fetcher.bodies = fetcher.bodies[:1]
+	require.ErrorIs(t, s.checkPayloadBodies(ctx, blks), errPayloadUnavailable)
+
+	fetcher.bodies = []*enginev1.ExecutionPayloadBodyV1{{Transactions: txs}, nil, {Transactions: txs[:1]}}
+	require.ErrorContains(t, "do not match", s.checkPayloadBodies(ctx, blks))
+
+	require.NoError(t, s.checkPayloadBodies(ctx, blks[:1]))
+	require.ErrorContains(t, "not consecutive", s.checkPayloadBodies(ctx, append(blks[:2:2], blks[3])))
+}
+
+func TestService_WaitForPayloadBodies(t *testing.T) {
+	retryInterval = 0
+	txs := [][]byte{{'a'}}
+	blks := payloadChain(t, 10, txs)
+	fetcher := &mockPayloadBodies{
+		bodies:      []*enginev1.ExecutionPayloadBodyV1{{Transactions: txs}},
+		unavailable: 2,
+	}
+	s := NewService(context.Background(), &Config{PayloadBodies: fetcher})
+	require.NoError(t, s.waitForPayloadBodies(blks))
+	assert.Equal(t, 6, len(fetcher.requests))
+
+	fetcher.unavailable = 1
+	require.NoError(t, s.Stop())
+	require.ErrorIs(t, s.waitForPayloadBodies(blks), context.Canceled)
+}
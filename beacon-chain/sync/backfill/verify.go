@@ -0,0 +1,41 @@
+package backfill
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+)
+
+var errUnknownParent = errors.New("block does not extend the known chain")
+
+// verifyBatch checks that the blocks of a batch, sorted by increasing slot, form a chain whose
+// highest block is the parent of the lowest block known to the node. Every block is then part of
+// the history of the trusted origin block. The proposer signatures are verified against the
+// validator registry of the origin state, which knows every earlier proposer as validators are
+// never removed from the registry.
+func verifyBatch(originState state.ReadOnlyBeaconState, blks []block.SignedBeaconBlock, parentRoot [32]byte) error {
+	for i := len(blks) - 1; i >= 0; i-- {
+		blk := blks[i]
+		if err := helpers.BeaconBlockIsNil(blk); err != nil {
+			return err
+		}
+		root, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not compute block root")
+		}
+		if root != parentRoot {
+			return errors.Wrapf(errUnknownParent, "block at slot %d has root %#x, expected %#x", blk.Block().Slot(), root, parentRoot)
+		}
+		// The genesis block is not signed.
+		if blk.Block().Slot() > 0 {
+			if err := blocks.VerifyBlockSignatureUsingCurrentFork(originState, blk); err != nil {
+				return errors.Wrapf(err, "could not verify signature of block at slot %d", blk.Block().Slot())
+			}
+		}
+		parentRoot = bytesutil.ToBytes32(blk.Block().ParentRoot())
+	}
+	return nil
+}
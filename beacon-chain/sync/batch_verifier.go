@@ -18,6 +18,9 @@ const verifierLimit = 50
 type signatureVerifier struct {
 	set     *bls.SignatureBatch
 	resChan chan error
+	// Verifies the set along with the pending ones right away, instead of waiting for the
+	// batch to fill up or for the next verification interval.
+	flush bool
 }
 
 // A routine that runs in the background to perform batch
@@ -36,7 +39,7 @@ func (s *Service) verifierRoutine() {
 			return
 		case sig := <-s.signatureChan:
 			verifierBatch = append(verifierBatch, sig)
-			if len(verifierBatch) >= verifierLimit {
+			if sig.flush || len(verifierBatch) >= verifierLimit {
 				verifyBatch(verifierBatch)
 				verifierBatch = []*signatureVerifier{}
 			}
@@ -50,11 +53,18 @@ func (s *Service) verifierRoutine() {
 }
 
 func (s *Service) validateWithBatchVerifier(ctx context.Context, message string, set *bls.SignatureBatch) (pubsub.ValidationResult, error) {
+	return s.verifyWithBatchVerifier(ctx, message, set, false /* flush */)
+}
+
+// Verifies the signature set along with the other signatures received over gossip, and on its own if
+// the batch fails. With flush set, the batch is verified as soon as the set is added to it, for messages
+// which cannot afford to wait for the batch, such as blocks.
+func (s *Service) verifyWithBatchVerifier(ctx context.Context, message string, set *bls.SignatureBatch, flush bool) (pubsub.ValidationResult, error) {
 	_, span := trace.StartSpan(ctx, "sync.validateWithBatchVerifier")
 	defer span.End()
 
 	resChan := make(chan error)
-	verificationSet := &signatureVerifier{set: set.Copy(), resChan: resChan}
+	verificationSet := &signatureVerifier{set: set.Copy(), resChan: resChan, flush: flush}
 	s.signatureChan <- verificationSet
 
 	resErr := <-resChan
@@ -88,6 +98,7 @@ func verifyBatch(verifierBatch []*signatureVerifier) {
 	for i := 1; i < len(verifierBatch); i++ {
 		aggSet = aggSet.Join(verifierBatch[i].set)
 	}
+	signatureBatchSizeHistogram.Observe(float64(len(aggSet.Signatures)))
 	verified, err := aggSet.Verify()
 	switch {
 	case err != nil:
@@ -95,6 +106,9 @@ func verifyBatch(verifierBatch []*signatureVerifier) {
 	case !verified:
 		verificationErr = errors.New("batch signature verification failed")
 	}
+	if verificationErr != nil {
+		signatureBatchFailureCounter.Inc()
+	}
 	for i := 0; i < len(verifierBatch); i++ {
 		verifierBatch[i].resChan <- verificationErr
 	}
@@ -1,12 +1,31 @@
 package sync
 
 import (
+	"bytes"
 	"context"
+	"reflect"
 	"testing"
+	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	gcache "github.com/patrickmn/go-cache"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
 	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
 )
 
@@ -82,3 +101,134 @@ func TestValidateWithBatchVerifier(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyWithBatchVerifier_Flush(t *testing.T) {
+	_, keys, err := util.DeterministicDepositsAndKeys(2)
+	require.NoError(t, err)
+	validSet := &bls.SignatureBatch{
+		Messages:   [][32]byte{{}},
+		PublicKeys: []bls.PublicKey{keys[0].PublicKey()},
+		Signatures: [][]byte{keys[0].Sign(make([]byte, 32)).Marshal()},
+	}
+	invalidSet := &bls.SignatureBatch{
+		Messages:   [][32]byte{{}},
+		PublicKeys: []bls.PublicKey{keys[0].PublicKey()},
+		Signatures: [][]byte{keys[1].Sign(make([]byte, 32)).Marshal()},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc := &Service{
+		ctx:           ctx,
+		cancel:        cancel,
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
+	}
+	go svc.verifierRoutine()
+
+	// The pending set is verified in the same batch as the flushed one, so its result is already
+	// available. Its invalid signature fails the batch, and the flushed set is then verified on its own.
+	pendingChan := make(chan error, 1)
+	svc.signatureChan <- &signatureVerifier{set: invalidSet.Copy(), resChan: pendingChan}
+	res, err := svc.verifyWithBatchVerifier(ctx, "block", validSet, true /* flush */)
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationAccept, res)
+	select {
+	case err := <-pendingChan:
+		assert.NotNil(t, err, "Pending invalid set was not rejected")
+	default:
+		t.Fatal("Pending set was not verified along with the flushed set")
+	}
+}
+
+func TestValidateBeaconBlockPubSub_BatchVerification(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableBatchVerification: true,
+	})
+	defer resetCfg()
+
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{name: "valid signature", valid: true},
+		{name: "invalid signature", valid: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := dbtest.SetupDB(t)
+			p := p2ptest.NewTestP2P(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			beaconState, privKeys := util.DeterministicGenesisState(t, 100)
+			parentBlock := util.NewBeaconBlock()
+			require.NoError(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(parentBlock)))
+			bRoot, err := parentBlock.Block.HashTreeRoot()
+			require.NoError(t, err)
+			require.NoError(t, db.SaveState(ctx, beaconState, bRoot))
+			require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Root: bRoot[:]}))
+			copied := beaconState.Copy()
+			require.NoError(t, copied.SetSlot(1))
+			proposerIdx, err := helpers.BeaconProposerIndex(ctx, copied)
+			require.NoError(t, err)
+			msg := util.NewBeaconBlock()
+			msg.Block.ParentRoot = bRoot[:]
+			msg.Block.Slot = 1
+			msg.Block.ProposerIndex = proposerIdx
+			signer := privKeys[proposerIdx]
+			if !tt.valid {
+				signer = privKeys[(proposerIdx+1)%100]
+			}
+			msg.Signature, err = signing.ComputeDomainAndSign(beaconState, 0, msg.Block, params.BeaconConfig().DomainBeaconProposer, signer)
+			require.NoError(t, err)
+
+			chainService := &mock.ChainService{Genesis: time.Unix(time.Now().Unix()-int64(params.BeaconConfig().SecondsPerSlot), 0),
+				State: beaconState,
+				FinalizedCheckPoint: &ethpb.Checkpoint{
+					Epoch: 0,
+					Root:  make([]byte, 32),
+				},
+			}
+			r := &Service{
+				ctx: ctx,
+				cfg: &config{
+					beaconDB:      db,
+					p2p:           p,
+					initialSync:   &mockSync.Sync{IsSyncing: false},
+					chain:         chainService,
+					blockNotifier: chainService.BlockNotifier(),
+					stateGen:      stategen.New(db),
+				},
+				seenBlockCache:      lruwrpr.New(10),
+				badBlockCache:       lruwrpr.New(10),
+				slotToPendingBlocks: gcache.New(time.Second, 2*time.Second),
+				seenPendingBlocks:   make(map[[32]byte]bool),
+				signatureChan:       make(chan *signatureVerifier, verifierLimit),
+			}
+			go r.verifierRoutine()
+
+			buf := new(bytes.Buffer)
+			_, err = p.Encoding().EncodeGossip(buf, msg)
+			require.NoError(t, err)
+			topic := p2p.GossipTypeMapping[reflect.TypeOf(msg)]
+			digest, err := r.currentForkDigest()
+			assert.NoError(t, err)
+			topic = r.addDigestToTopic(topic, digest)
+			m := &pubsub.Message{
+				Message: &pubsubpb.Message{
+					Data:  buf.Bytes(),
+					Topic: &topic,
+				},
+			}
+			res, err := r.validateBeaconBlockPubSub(ctx, "", m)
+			if tt.valid {
+				assert.NoError(t, err)
+				assert.Equal(t, pubsub.ValidationAccept, res)
+				return
+			}
+			assert.NotNil(t, err)
+			assert.Equal(t, pubsub.ValidationReject, res)
+			root, err := msg.Block.HashTreeRoot()
+			require.NoError(t, err)
+			assert.Equal(t, true, r.hasBadBlock(root), "Block with invalid signature was not marked as bad")
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"go.opencensus.io/trace"
+)
+
+// deferredAttLimit is the number of attestations verified together by the deferred verifier.
+const deferredAttLimit = 256
+
+// deferredAttVerificationInterval is the longest an attestation waits for its deferred verification.
+const deferredAttVerificationInterval = 100 * time.Millisecond
+
+// deferredAttestation is an unaggregated attestation received on gossip whose signature has not
+// been verified yet, along with the channel its validation result is sent to.
+type deferredAttestation struct {
+	att    *eth.Attestation
+	set    *bls.SignatureBatch
+	result chan pubsub.ValidationResult
+}
+
+// lazyAttestationVerification returns true if the signatures of gossip attestations are verified
+// by the deferred verifier instead of during gossip validation.
+func (s *Service) lazyAttestationVerification() bool {
+	return features.Get().EnableLazyAttestationVerification && s.deferredAttChan != nil
+}
+
+// deferAttestationVerification queues the signature of an attestation for deferred verification,
+// and waits for the result of the batch it is verified in. Pubsub marks the message as seen before
+// validating it, so the attestation is accepted or rejected here rather than broadcast again later.
+func (s *Service) deferAttestationVerification(ctx context.Context, a *eth.Attestation, bs state.ReadOnlyBeaconState) (pubsub.ValidationResult, error) {
+	ctx, span := trace.StartSpan(ctx, "sync.deferAttestationVerification")
+	defer span.End()
+
+	set, err := blocks.AttestationSignatureBatch(ctx, bs, []*eth.Attestation{a})
+	if err != nil {
+		return pubsub.ValidationReject, err
+	}
+	d := &deferredAttestation{att: a, set: set, result: make(chan pubsub.ValidationResult, 1)}
+	select {
+	case s.deferredAttChan <- d:
+		deferredAttVerificationCounter.Inc()
+	case <-ctx.Done():
+		return pubsub.ValidationIgnore, ctx.Err()
+	}
+	select {
+	case res := <-d.result:
+		if res != pubsub.ValidationAccept {
+			return res, errors.New("attestation signature verification failed")
+		}
+		return res, nil
+	case <-ctx.Done():
+		return pubsub.ValidationIgnore, ctx.Err()
+	}
+}
+
+// A routine that runs in the background to verify the signatures of the attestations received
+// on gossip in batches.
+func (s *Service) deferredAttVerifierRoutine() {
+	batch := make([]*deferredAttestation, 0, deferredAttLimit)
+	ticker := time.NewTicker(deferredAttVerificationInterval)
+	for {
+		select {
+		case <-s.ctx.Done():
+			ticker.Stop()
+			return
+		case d := <-s.deferredAttChan:
+			batch = append(batch, d)
+			if len(batch) >= deferredAttLimit {
+				verifyDeferredAttestations(batch)
+				batch = make([]*deferredAttestation, 0, deferredAttLimit)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				verifyDeferredAttestations(batch)
+				batch = make([]*deferredAttestation, 0, deferredAttLimit)
+			}
+		}
+	}
+}
+
+// verifyDeferredAttestations verifies the signatures of a batch of attestations at once, falling
+// back to verifying them individually if the batch fails, and sends each attestation its result.
+func verifyDeferredAttestations(batch []*deferredAttestation) {
+	aggSet := batch[0].set.Copy()
+	for i := 1; i < len(batch); i++ {
+		aggSet = aggSet.Join(batch[i].set)
+	}
+	signatureBatchSizeHistogram.Observe(float64(len(aggSet.Signatures)))
+	verified, err := aggSet.Verify()
+	batchValid := err == nil && verified
+	if !batchValid {
+		signatureBatchFailureCounter.Inc()
+	}
+
+	for _, d := range batch {
+		if !batchValid {
+			verified, err := d.set.Verify()
+			if err != nil || !verified {
+				deferredAttVerificationFailureCounter.Inc()
+				log.WithError(err).WithField("slot", d.att.Data.Slot).Debug("Deferred attestation signature verification failed")
+				d.result <- pubsub.ValidationReject
+				continue
+			}
+		}
+		d.result <- pubsub.ValidationAccept
+	}
+}
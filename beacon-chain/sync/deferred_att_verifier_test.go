@@ -0,0 +1,149 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestVerifyDeferredAttestations(t *testing.T) {
+	helpers.ClearCache()
+	st, keys := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, st.SetSlot(1))
+
+	valid := signedDeferredAttestation(t, st, keys, 0, true)
+	invalid := signedDeferredAttestation(t, st, keys, 1, false)
+
+	// The batch fails, so each attestation is verified individually.
+	verifyDeferredAttestations([]*deferredAttestation{valid, invalid})
+	assert.Equal(t, pubsub.ValidationAccept, <-valid.result)
+	assert.Equal(t, pubsub.ValidationReject, <-invalid.result)
+}
+
+func TestService_DeferAttestationVerification(t *testing.T) {
+	helpers.ClearCache()
+	st, keys := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, st.SetSlot(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &Service{
+		ctx:             ctx,
+		cfg:             &config{p2p: p2ptest.NewTestP2P(t)},
+		deferredAttChan: make(chan *deferredAttestation, deferredAttLimit),
+	}
+	assert.Equal(t, false, s.lazyAttestationVerification())
+	resetCfg := features.InitWithReset(&features.Flags{EnableLazyAttestationVerification: true})
+	defer resetCfg()
+	require.Equal(t, true, s.lazyAttestationVerification())
+	go s.deferredAttVerifierRoutine()
+
+	valid := signedDeferredAttestation(t, st, keys, 0, true)
+	res, err := s.deferAttestationVerification(ctx, valid.att, st)
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationAccept, res)
+
+	invalid := signedDeferredAttestation(t, st, keys, 1, false)
+	res, err = s.deferAttestationVerification(ctx, invalid.att, st)
+	require.ErrorContains(t, "signature verification failed", err)
+	assert.Equal(t, pubsub.ValidationReject, res)
+}
+
+func TestService_DeferAttestationVerification_RelaysToPeers(t *testing.T) {
+	helpers.ClearCache()
+	st, keys := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, st.SetSlot(1))
+	resetCfg := features.InitWithReset(&features.Flags{EnableLazyAttestationVerification: true})
+	defer resetCfg()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// The relay only knows the sender and the receiver, which are not connected to each other.
+	sender := p2ptest.NewTestP2P(t)
+	relay := p2ptest.NewTestP2P(t)
+	receiver := p2ptest.NewTestP2P(t)
+	sender.Connect(relay)
+	relay.Connect(receiver)
+	s := &Service{
+		ctx:             ctx,
+		cfg:             &config{p2p: relay},
+		deferredAttChan: make(chan *deferredAttestation, deferredAttLimit),
+	}
+	go s.deferredAttVerifierRoutine()
+
+	topic := "/eth2/deferred_attestation_test"
+	require.NoError(t, relay.PubSub().RegisterTopicValidator(topic, func(ctx context.Context, _ peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+		att := &ethpb.Attestation{}
+		if err := att.UnmarshalSSZ(msg.Data); err != nil {
+			return pubsub.ValidationReject
+		}
+		res, err := s.deferAttestationVerification(ctx, att, st)
+		if err != nil {
+			t.Log(err)
+		}
+		return res
+	}))
+	_, err := relay.SubscribeToTopic(topic)
+	require.NoError(t, err)
+	sub, err := receiver.SubscribeToTopic(topic)
+	require.NoError(t, err)
+	// Wait for the subscriptions to propagate.
+	time.Sleep(100 * time.Millisecond)
+
+	att := signedDeferredAttestation(t, st, keys, 0, true).att
+	enc, err := att.MarshalSSZ()
+	require.NoError(t, err)
+	require.NoError(t, sender.PublishToTopic(ctx, topic, enc))
+
+	msg, err := sub.Next(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, enc, msg.Data)
+	assert.Equal(t, relay.PeerID(), msg.ReceivedFrom)
+}
+
+func signedDeferredAttestation(t *testing.T, st state.BeaconState, keys []bls.SecretKey, blockRootByte byte, valid bool) *deferredAttestation {
+	ctx := context.Background()
+	att := &ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0b101},
+		Data: &ethpb.AttestationData{
+			Slot:            1,
+			CommitteeIndex:  0,
+			BeaconBlockRoot: make([]byte, fieldparams.RootLength),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, fieldparams.RootLength)},
+			Target:          &ethpb.Checkpoint{Root: make([]byte, fieldparams.RootLength)},
+		},
+	}
+	// Distinguish the attestations by the block they vote for.
+	att.Data.BeaconBlockRoot[0] = blockRootByte
+	com, err := helpers.BeaconCommitteeFromState(ctx, st, att.Data.Slot, att.Data.CommitteeIndex)
+	require.NoError(t, err)
+	domain, err := signing.Domain(st.Fork(), att.Data.Target.Epoch, params.BeaconConfig().DomainBeaconAttester, st.GenesisValidatorsRoot())
+	require.NoError(t, err)
+	root, err := signing.ComputeSigningRoot(att.Data, domain)
+	require.NoError(t, err)
+	// An invalid attestation claims the second committee member but is signed by the first.
+	if !valid {
+		att.AggregationBits = bitfield.Bitlist{0b110}
+	}
+	att.Signature = keys[com[0]].Sign(root[:]).Marshal()
+	set, err := blocks.AttestationSignatureBatch(ctx, st, []*ethpb.Attestation{att})
+	require.NoError(t, err)
+	return &deferredAttestation{att: att, set: set, result: make(chan pubsub.ValidationResult, 1)}
+}
@@ -0,0 +1,39 @@
+package sync
+
+import (
+	"sync"
+)
+
+// inFlightRoots tracks the block roots requested from peers, for which requests have not completed
+// yet, so that the pending blocks and pending attestations queues do not request the same blocks twice.
+type inFlightRoots struct {
+	lock  sync.Mutex
+	roots map[[32]byte]bool
+}
+
+// claim marks the given roots as in flight, and returns the roots which were not in flight already.
+func (f *inFlightRoots) claim(roots [][32]byte) [][32]byte {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.roots == nil {
+		f.roots = make(map[[32]byte]bool)
+	}
+	claimed := make([][32]byte, 0, len(roots))
+	for _, r := range roots {
+		if f.roots[r] {
+			continue
+		}
+		f.roots[r] = true
+		claimed = append(claimed, r)
+	}
+	return claimed
+}
+
+// release the given roots, once the requests for them have completed.
+func (f *inFlightRoots) release(roots [][32]byte) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, r := range roots {
+		delete(f.roots, r)
+	}
+}
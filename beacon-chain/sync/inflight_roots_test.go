@@ -0,0 +1,62 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/network"
+	gcache "github.com/patrickmn/go-cache"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/crypto/rand"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestInFlightRoots_ClaimRelease(t *testing.T) {
+	var f inFlightRoots
+	a, b, c := [32]byte{'a'}, [32]byte{'b'}, [32]byte{'c'}
+
+	assert.DeepEqual(t, [][32]byte{a, b}, f.claim([][32]byte{a, b}))
+	assert.DeepEqual(t, [][32]byte{c}, f.claim([][32]byte{a, b, c}))
+	assert.Equal(t, 0, len(f.claim([][32]byte{b})))
+
+	f.release([][32]byte{a, b})
+	assert.DeepEqual(t, [][32]byte{a}, f.claim([][32]byte{a, c}))
+}
+
+func TestService_BatchRootRequest_SkipsInFlightRoots(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	r := &Service{
+		cfg: &config{
+			p2p:      p1,
+			beaconDB: dbtest.SetupDB(t),
+			chain: &mock.ChainService{
+				FinalizedCheckPoint: &ethpb.Checkpoint{
+					Epoch: 1,
+					Root:  make([]byte, 32),
+				},
+			},
+		},
+		slotToPendingBlocks: gcache.New(time.Second, 2*time.Second),
+		seenPendingBlocks:   make(map[[32]byte]bool),
+	}
+	// A peer which can't be dialed, all requests to it fail.
+	pid := p2ptest.NewTestP2P(t).PeerID()
+	p1.Peers().Add(new(enr.Record), pid, nil, network.DirOutbound)
+	p1.Peers().SetConnectionState(pid, peers.PeerConnected)
+	p1.Peers().SetChainState(pid, &ethpb.Status{FinalizedEpoch: 2})
+
+	inFlight := [][32]byte{{'a'}, {'b'}}
+	require.Equal(t, 2, len(r.inFlightRoots.claim(inFlight)))
+	require.NoError(t, r.sendBatchRootRequest(context.Background(), [][32]byte{{'a'}, {'b'}, {'c'}}, rand.NewGenerator()))
+
+	// The roots requested by another caller are still in flight, the ones requested by this call are not.
+	assert.Equal(t, 0, len(r.inFlightRoots.claim(inFlight)))
+	assert.Equal(t, 1, len(r.inFlightRoots.claim([][32]byte{{'c'}})))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/prysmaticlabs/prysm/crypto/rand"
 	p2ppb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	prysmTime "github.com/prysmaticlabs/prysm/time"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
@@ -52,6 +53,7 @@ var (
 	errBlockAlreadyProcessed = errors.New("block is already processed")
 	errParentDoesNotExist    = errors.New("beacon node doesn't have a parent in db with root")
 	errNoPeersWithAltBlocks  = errors.New("no peers with alternative blocks found")
+	errNoGoodBlocksInBatch   = errors.New("no good blocks in batch")
 )
 
 // blocksFetcherConfig is a config to setup the block fetcher.
@@ -288,11 +290,24 @@ func (f *blocksFetcher) fetchBlocksFromPeer(
 		Count:     count,
 		Step:      1,
 	}
+	scorer := f.p2p.Peers().Scorers().BlockProviderScorer()
 	for i := 0; i < len(peers); i++ {
-		if blocks, err := f.requestBlocks(ctx, req, peers[i]); err == nil {
-			f.p2p.Peers().Scorers().BlockProviderScorer().Touch(peers[i])
-			return blocks, peers[i], err
+		requestStart := prysmTime.Now()
+		blocks, err := f.requestBlocks(ctx, req, peers[i])
+		if err != nil {
+			if errors.Is(err, prysmsync.ErrInvalidFetchedData) {
+				scorer.IncrementInvalidBatches(peers[i])
+			}
+			continue
+		}
+		scorer.Touch(peers[i])
+		// Peers only respond with no blocks for ranges of skipped slots, which are rare.
+		if len(blocks) == 0 {
+			scorer.IncrementEmptyResponses(peers[i])
+		} else {
+			scorer.UpdateThroughput(peers[i], len(blocks), time.Since(requestStart))
 		}
+		return blocks, peers[i], nil
 	}
 	return nil, "", errNoPeersAvailable
 }
@@ -0,0 +1,225 @@
+package initialsync
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/network/forks"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+const (
+	// Number of workers preparing fetched batches for execution.
+	prepareWorkers = 4
+	// Number of fetched batches which may be prepared ahead of the batch being executed.
+	maxPreparedBatches = 2 * prepareWorkers
+)
+
+var errInvalidProposerSignature = errors.New("invalid proposer signature in batch")
+
+// preparedBatch is a batch of fetched blocks along with their roots, ready to be executed.
+type preparedBatch struct {
+	pid    peer.ID
+	blocks []block.SignedBeaconBlock
+	roots  [][32]byte
+	// Whether the proposer signature of each block was pre-verified, so it is not verified again.
+	proposerVerified []bool
+	err              error
+}
+
+type prepareJob struct {
+	data   *blocksQueueFetchedData
+	result chan *preparedBatch
+}
+
+// processPipelined runs the fetched batches through a pipeline. While a batch is executed, a pool
+// of workers prepares the next batches: it computes their block roots and pre-verifies their proposer
+// signatures. Downloading from peers, signature verification and the state transition overlap, and
+// batches with invalid proposer signatures are dropped before running the state transition on them.
+// Batches are executed in the order they were fetched.
+func (s *Service) processPipelined(ctx context.Context, genesis time.Time, fetched <-chan *blocksQueueFetchedData) {
+	jobs := make(chan prepareJob)
+	pending := make(chan chan *preparedBatch, maxPreparedBatches)
+	var wg sync.WaitGroup
+	wg.Add(prepareWorkers)
+	for i := 0; i < prepareWorkers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				batch := s.prepareBatch(ctx, job.data)
+				s.savePendingBatch(ctx, batch)
+				job.result <- batch
+			}
+		}()
+	}
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+		for data := range fetched {
+			result := make(chan *preparedBatch, 1)
+			pending <- result
+			jobs <- prepareJob{data: data, result: result}
+		}
+	}()
+
+	for result := range pending {
+		s.processPreparedBatch(ctx, genesis, s.cfg.Chain.HeadSlot(), <-result)
+	}
+	wg.Wait()
+}
+
+// prepareBatch computes the block roots of a fetched batch and pre-verifies its proposer signatures.
+func (s *Service) prepareBatch(ctx context.Context, data *blocksQueueFetchedData) *preparedBatch {
+	batch := &preparedBatch{
+		pid:    data.pid,
+		blocks: data.blocks,
+		roots:  make([][32]byte, len(data.blocks)),
+	}
+	for i, blk := range data.blocks {
+		root, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			batch.err = err
+			return batch
+		}
+		batch.roots[i] = root
+	}
+	batch.proposerVerified, batch.err = s.preVerifyProposerSignatures(ctx, batch.blocks, batch.roots)
+	return batch
+}
+
+// preVerifyProposerSignatures verifies the proposer signatures of the blocks as a single signature batch,
+// using the validator registry of the head state, and returns which blocks had their proposer signature
+// verified. The signatures of the blocks whose proposer is not in that registry yet are only verified
+// by the state transition.
+func (s *Service) preVerifyProposerSignatures(
+	ctx context.Context, blks []block.SignedBeaconBlock, roots [][32]byte,
+) ([]bool, error) {
+	genesisValidatorsRoot := s.cfg.Chain.GenesisValidatorsRoot()
+	set := bls.NewSet()
+	verified := make([]bool, len(blks))
+	for i, blk := range blks {
+		b := blk.Block()
+		// The genesis block is not signed.
+		if b.Slot() == 0 {
+			continue
+		}
+		pubkey, err := s.cfg.Chain.HeadValidatorIndexToPublicKey(ctx, b.ProposerIndex())
+		if err != nil || pubkey == [fieldparams.BLSPubkeyLength]byte{} {
+			continue
+		}
+		epoch := slots.ToEpoch(b.Slot())
+		fork, err := forks.Fork(epoch)
+		if err != nil {
+			return nil, err
+		}
+		domain, err := signing.Domain(fork, epoch, params.BeaconConfig().DomainBeaconProposer, genesisValidatorsRoot[:])
+		if err != nil {
+			return nil, err
+		}
+		root := roots[i]
+		blockSet, err := signing.BlockSignatureBatch(pubkey[:], blk.Signature(), domain, func() ([32]byte, error) {
+			return root, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		set.Join(blockSet)
+		verified[i] = true
+	}
+	if len(set.Signatures) == 0 {
+		return verified, nil
+	}
+	valid, err := set.Verify()
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, errInvalidProposerSignature
+	}
+	return verified, nil
+}
+
+// savePendingBatch saves the blocks of a prepared batch until it is executed, so that they are not
+// requested again if the node is restarted in the meantime.
+func (s *Service) savePendingBatch(ctx context.Context, batch *preparedBatch) {
+	if batch.err != nil || len(batch.blocks) == 0 {
+		return
+	}
+	if err := s.cfg.DB.SaveInitialSyncPendingBlocks(ctx, batch.blocks); err != nil {
+		log.WithError(err).Debug("Could not save pending blocks")
+	}
+}
+
+// processSavedPendingBlocks executes the blocks which were fetched and saved, but not executed yet,
+// before the node was restarted.
+func (s *Service) processSavedPendingBlocks(ctx context.Context, genesis time.Time) {
+	blks, err := s.cfg.DB.InitialSyncPendingBlocks(ctx)
+	if err != nil {
+		log.WithError(err).Debug("Could not read pending blocks")
+		return
+	}
+	if len(blks) == 0 {
+		return
+	}
+	log.WithField("blocks", len(blks)).Info("Processing blocks fetched before the restart")
+	batch := s.prepareBatch(ctx, &blocksQueueFetchedData{blocks: blks})
+	s.processPreparedBatch(ctx, genesis, s.cfg.Chain.HeadSlot(), batch)
+}
+
+// processPreparedBatch executes a prepared batch, and deletes its saved pending blocks.
+func (s *Service) processPreparedBatch(ctx context.Context, genesis time.Time, startSlot types.Slot, batch *preparedBatch) {
+	defer s.updatePeerScorerStats(batch.pid, startSlot)
+	defer s.deletePendingBatch(ctx, batch)
+
+	scorers := s.cfg.P2P.Peers().Scorers()
+	if batch.err != nil {
+		if batch.pid != "" {
+			scorers.BadResponsesScorer().Increment(batch.pid)
+			scorers.BlockProviderScorer().IncrementInvalidBatches(batch.pid)
+		}
+		log.WithError(batch.err).Warn("Batch is not processed")
+		return
+	}
+	// Use Batch Block Verify to process and verify batches directly. Blocks already processed are
+	// dropped from the front of the batch, so the pre-verified flags are aligned from its end.
+	receive := func(ctx context.Context, blks []block.SignedBeaconBlock, roots [][32]byte) error {
+		verified := batch.proposerVerified[len(batch.proposerVerified)-len(blks):]
+		return s.cfg.Chain.ReceiveBlockBatchWithVerifiedProposers(ctx, blks, roots, verified)
+	}
+	if err := s.processBatchedBlocksWithRoots(ctx, genesis, batch.blocks, batch.roots, receive); err != nil {
+		if batch.pid != "" && isInvalidBatchError(ctx, err) {
+			scorers.BlockProviderScorer().IncrementInvalidBatches(batch.pid)
+		}
+		log.WithError(err).Warn("Batch is not processed")
+	}
+}
+
+// deletePendingBatch deletes the saved pending blocks up to the last block of an executed batch. The
+// blocks are kept when the batch is interrupted by a shutdown, to be executed after the restart.
+func (s *Service) deletePendingBatch(ctx context.Context, batch *preparedBatch) {
+	if ctx.Err() != nil || len(batch.blocks) == 0 {
+		return
+	}
+	lastSlot := batch.blocks[len(batch.blocks)-1].Block().Slot()
+	if err := s.cfg.DB.DeleteInitialSyncPendingBlocks(ctx, lastSlot); err != nil {
+		log.WithError(err).Debug("Could not delete pending blocks")
+	}
+}
+
+// isInvalidBatchError returns whether a batch failed processing because of the blocks served by
+// the peer, rather than because its blocks were already processed or its parent is unknown to us.
+func isInvalidBatchError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return !errors.Is(err, errParentDoesNotExist) && !errors.Is(err, errNoGoodBlocksInBatch)
+}
@@ -0,0 +1,232 @@
+package initialsync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	types "github.com/prysmaticlabs/eth2-types"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	p2pt "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/network/forks"
+	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+func TestService_processPipelined(t *testing.T) {
+	beaconDB := dbtest.SetupDB(t)
+	genesisBlk := util.NewBeaconBlock()
+	genesisBlkRoot, err := genesisBlk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(context.Background(), wrapper.WrappedPhase0SignedBeaconBlock(genesisBlk)))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	chain := &mock.ChainService{
+		State: st,
+		Root:  genesisBlkRoot[:],
+		DB:    beaconDB,
+		FinalizedCheckPoint: &eth.Checkpoint{
+			Epoch: 0,
+		},
+	}
+	s := NewService(context.Background(), &Config{
+		P2P:           p2pt.NewTestP2P(t),
+		DB:            beaconDB,
+		Chain:         chain,
+		StateNotifier: &mock.MockStateNotifier{},
+	})
+
+	fetched := make(chan *blocksQueueFetchedData)
+	go func() {
+		defer close(fetched)
+		parentRoot := genesisBlkRoot
+		for i := 0; i < 3*maxPreparedBatches; i++ {
+			data := &blocksQueueFetchedData{pid: peer.ID("a")}
+			for j := 0; j < 4; j++ {
+				blk := util.NewBeaconBlock()
+				blk.Block.Slot = types.Slot(4*i + j + 1)
+				blk.Block.ParentRoot = bytesutil.SafeCopyBytes(parentRoot[:])
+				parentRoot, err = blk.Block.HashTreeRoot()
+				require.NoError(t, err)
+				data.blocks = append(data.blocks, wrapper.WrappedPhase0SignedBeaconBlock(blk))
+			}
+			fetched <- data
+		}
+	}()
+	s.processPipelined(context.Background(), makeGenesisTime(32), fetched)
+
+	require.Equal(t, 12*maxPreparedBatches, len(chain.BlocksReceived))
+	for i, blk := range chain.BlocksReceived {
+		assert.Equal(t, types.Slot(i+1), blk.Block().Slot(), "Batches were not executed in order")
+	}
+	pending, err := beaconDB.InitialSyncPendingBlocks(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(pending), "Executed batches were not deleted from the pending blocks")
+}
+
+func TestService_processSavedPendingBlocks(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	genesisBlk := util.NewBeaconBlock()
+	genesisBlkRoot, err := genesisBlk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(genesisBlk)))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	chain := &mock.ChainService{
+		State: st,
+		Root:  genesisBlkRoot[:],
+		DB:    beaconDB,
+		FinalizedCheckPoint: &eth.Checkpoint{
+			Epoch: 0,
+		},
+	}
+	s := NewService(ctx, &Config{
+		P2P:           p2pt.NewTestP2P(t),
+		DB:            beaconDB,
+		Chain:         chain,
+		StateNotifier: &mock.MockStateNotifier{},
+	})
+
+	// Nothing is executed without saved pending blocks.
+	s.processSavedPendingBlocks(ctx, makeGenesisTime(32))
+	assert.Equal(t, 0, len(chain.BlocksReceived))
+
+	// Blocks fetched before a restart.
+	var blks []block.SignedBeaconBlock
+	parentRoot := genesisBlkRoot
+	for i := types.Slot(1); i <= 8; i++ {
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = i
+		blk.Block.ParentRoot = bytesutil.SafeCopyBytes(parentRoot[:])
+		parentRoot, err = blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		blks = append(blks, wrapper.WrappedPhase0SignedBeaconBlock(blk))
+	}
+	require.NoError(t, beaconDB.SaveInitialSyncPendingBlocks(ctx, blks[4:]))
+	require.NoError(t, beaconDB.SaveInitialSyncPendingBlocks(ctx, blks[:4]))
+
+	s.processSavedPendingBlocks(ctx, makeGenesisTime(32))
+	require.Equal(t, len(blks), len(chain.BlocksReceived))
+	for i, blk := range chain.BlocksReceived {
+		assert.Equal(t, types.Slot(i+1), blk.Block().Slot())
+	}
+	pending, err := beaconDB.InitialSyncPendingBlocks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(pending))
+}
+
+func TestService_preVerifyProposerSignatures(t *testing.T) {
+	key, err := bls.RandKey()
+	require.NoError(t, err)
+	var pubkey [48]byte
+	copy(pubkey[:], key.PublicKey().Marshal())
+	chain := &mock.ChainService{PublicKey: pubkey, ValidatorsRoot: [32]byte{'a'}}
+	s := NewService(context.Background(), &Config{
+		P2P:           p2pt.NewTestP2P(t),
+		Chain:         chain,
+		StateNotifier: &mock.MockStateNotifier{},
+	})
+
+	var blks []block.SignedBeaconBlock
+	var roots [][32]byte
+	for i := types.Slot(0); i < 3; i++ {
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = i * params.BeaconConfig().SlotsPerEpoch
+		epoch := slots.ToEpoch(blk.Block.Slot)
+		fork, err := forks.Fork(epoch)
+		require.NoError(t, err)
+		domain, err := signing.Domain(fork, epoch, params.BeaconConfig().DomainBeaconProposer, chain.ValidatorsRoot[:])
+		require.NoError(t, err)
+		signingRoot, err := signing.ComputeSigningRoot(blk.Block, domain)
+		require.NoError(t, err)
+		if i > 0 {
+			blk.Signature = key.Sign(signingRoot[:]).Marshal()
+		}
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		blks = append(blks, wrapper.WrappedPhase0SignedBeaconBlock(blk))
+		roots = append(roots, root)
+	}
+	verified, err := s.preVerifyProposerSignatures(context.Background(), blks, roots)
+	require.NoError(t, err)
+	// The genesis block is not signed.
+	assert.DeepEqual(t, []bool{false, true, true}, verified)
+
+	// A block carrying the signature of another block.
+	forged := blks[1].Copy().Proto().(*eth.SignedBeaconBlock)
+	forged.Signature = blks[2].Signature()
+	blks[1] = wrapper.WrappedPhase0SignedBeaconBlock(forged)
+	_, err = s.preVerifyProposerSignatures(context.Background(), blks, roots)
+	require.ErrorIs(t, err, errInvalidProposerSignature)
+	assert.Equal(t, true, s.prepareBatch(context.Background(), &blocksQueueFetchedData{blocks: blks}).err != nil)
+
+	// Proposers missing from the head state are left to the state transition.
+	chain.PublicKey = [48]byte{}
+	verified, err = s.preVerifyProposerSignatures(context.Background(), blks, roots)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []bool{false, false, false}, verified)
+}
+
+func TestService_processPreparedBatch_InvalidBatch(t *testing.T) {
+	beaconDB := dbtest.SetupDB(t)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	chain := &mock.ChainService{
+		State: st,
+		DB:    beaconDB,
+		FinalizedCheckPoint: &eth.Checkpoint{
+			Epoch: 0,
+		},
+	}
+	p := p2pt.NewTestP2P(t)
+	s := NewService(context.Background(), &Config{
+		P2P:           p,
+		DB:            beaconDB,
+		Chain:         chain,
+		StateNotifier: &mock.MockStateNotifier{},
+	})
+	scorer := p.Peers().Scorers().BlockProviderScorer()
+
+	s.processPreparedBatch(context.Background(), makeGenesisTime(32), 0, &preparedBatch{pid: "a", err: errInvalidProposerSignature})
+	assert.Equal(t, 1, scorer.InvalidBatches("a"))
+
+	// Blocks not linked to one another.
+	genesisBlk := util.NewBeaconBlock()
+	genesisBlkRoot, err := genesisBlk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(context.Background(), wrapper.WrappedPhase0SignedBeaconBlock(genesisBlk)))
+	var blks []block.SignedBeaconBlock
+	var roots [][32]byte
+	for i := types.Slot(1); i <= 2; i++ {
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = i
+		blk.Block.ParentRoot = genesisBlkRoot[:]
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		blks = append(blks, wrapper.WrappedPhase0SignedBeaconBlock(blk))
+		roots = append(roots, root)
+	}
+	s.processPreparedBatch(context.Background(), makeGenesisTime(32), 0, &preparedBatch{pid: "b", blocks: blks, roots: roots})
+	assert.Equal(t, 1, scorer.InvalidBatches("b"))
+
+	// Unknown parents are not blamed on the peer.
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 1
+	blk.Block.ParentRoot = bytesutil.PadTo([]byte{'x'}, 32)
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	batch := &preparedBatch{pid: "c", blocks: []block.SignedBeaconBlock{wrapper.WrappedPhase0SignedBeaconBlock(blk)}, roots: [][32]byte{root}}
+	s.processPreparedBatch(context.Background(), makeGenesisTime(32), 0, batch)
+	assert.Equal(t, 0, scorer.InvalidBatches("c"))
+}
@@ -45,6 +45,9 @@ func (s *Service) roundRobinSync(genesis time.Time) error {
 
 	s.counter = ratecounter.NewRateCounter(counterSeconds * time.Second)
 
+	// Blocks fetched before a restart are not requested again.
+	s.processSavedPendingBlocks(ctx, genesis)
+
 	// Step 1 - Sync to end of finalized epoch.
 	if err := s.syncToFinalizedEpoch(ctx, genesis); err != nil {
 		return err
@@ -82,9 +85,7 @@ func (s *Service) syncToFinalizedEpoch(ctx context.Context, genesis time.Time) e
 		return err
 	}
 
-	for data := range queue.fetchedData {
-		s.processFetchedData(ctx, genesis, s.cfg.Chain.HeadSlot(), data)
-	}
+	s.processPipelined(ctx, genesis, queue.fetchedData)
 
 	log.WithFields(logrus.Fields{
 		"syncedSlot":  s.cfg.Chain.HeadSlot(),
@@ -124,17 +125,6 @@ func (s *Service) syncToNonFinalizedEpoch(ctx context.Context, genesis time.Time
 	return nil
 }
 
-// processFetchedData processes data received from queue.
-func (s *Service) processFetchedData(
-	ctx context.Context, genesis time.Time, startSlot types.Slot, data *blocksQueueFetchedData) {
-	defer s.updatePeerScorerStats(data.pid, startSlot)
-
-	// Use Batch Block Verify to process and verify batches directly.
-	if err := s.processBatchedBlocks(ctx, genesis, data.blocks, s.cfg.Chain.ReceiveBlockBatch); err != nil {
-		log.WithError(err).Warn("Batch is not processed")
-	}
-}
-
 // processFetchedData processes data received from queue.
 func (s *Service) processFetchedDataRegSync(
 	ctx context.Context, genesis time.Time, startSlot types.Slot, data *blocksQueueFetchedData) {
@@ -241,44 +231,44 @@ func (s *Service) processBlock(
 
 func (s *Service) processBatchedBlocks(ctx context.Context, genesis time.Time,
 	blks []block.SignedBeaconBlock, bFunc batchBlockReceiverFn) error {
+	blockRoots := make([][32]byte, len(blks))
+	for i, b := range blks {
+		blkRoot, err := b.Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		blockRoots[i] = blkRoot
+	}
+	return s.processBatchedBlocksWithRoots(ctx, genesis, blks, blockRoots, bFunc)
+}
+
+// processBatchedBlocksWithRoots processes a batch of blocks whose roots are already known.
+func (s *Service) processBatchedBlocksWithRoots(ctx context.Context, genesis time.Time,
+	blks []block.SignedBeaconBlock, blockRoots [][32]byte, bFunc batchBlockReceiverFn) error {
 	if len(blks) == 0 {
 		return errors.New("0 blocks provided into method")
 	}
 	firstBlock := blks[0]
-	blkRoot, err := firstBlock.Block().HashTreeRoot()
-	if err != nil {
-		return err
-	}
 	headSlot := s.cfg.Chain.HeadSlot()
-	for headSlot >= firstBlock.Block().Slot() && s.isProcessedBlock(ctx, firstBlock, blkRoot) {
+	for headSlot >= firstBlock.Block().Slot() && s.isProcessedBlock(ctx, firstBlock, blockRoots[0]) {
 		if len(blks) == 1 {
-			return errors.New("no good blocks in batch")
+			return errNoGoodBlocksInBatch
 		}
 		blks = blks[1:]
+		blockRoots = blockRoots[1:]
 		firstBlock = blks[0]
-		blkRoot, err = firstBlock.Block().HashTreeRoot()
-		if err != nil {
-			return err
-		}
 	}
-	s.logBatchSyncStatus(genesis, blks, blkRoot)
+	s.logBatchSyncStatus(genesis, blks, blockRoots[0])
 	parentRoot := bytesutil.ToBytes32(firstBlock.Block().ParentRoot())
 	if !s.cfg.DB.HasBlock(ctx, parentRoot) && !s.cfg.Chain.HasInitSyncBlock(parentRoot) {
 		return fmt.Errorf("%w: %#x", errParentDoesNotExist, firstBlock.Block().ParentRoot())
 	}
-	blockRoots := make([][32]byte, len(blks))
-	blockRoots[0] = blkRoot
 	for i := 1; i < len(blks); i++ {
 		b := blks[i]
 		if !bytes.Equal(b.Block().ParentRoot(), blockRoots[i-1][:]) {
 			return fmt.Errorf("expected linear block list with parent root of %#x but received %#x",
 				blockRoots[i-1][:], b.Block().ParentRoot())
 		}
-		blkRoot, err := b.Block().HashTreeRoot()
-		if err != nil {
-			return err
-		}
-		blockRoots[i] = blkRoot
 	}
 	return bFunc(ctx, blks, blockRoots)
 }
@@ -4,14 +4,18 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
+	"github.com/golang/snappy"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/config/params"
 	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	prysmTime "github.com/prysmaticlabs/prysm/time"
 	"github.com/prysmaticlabs/prysm/time/slots"
 )
 
@@ -63,6 +67,45 @@ var (
 		},
 	)
 
+	signatureBatchSizeHistogram = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "gossip_signature_batch_size",
+			Help:    "Number of signatures verified together by the gossip batch verifier.",
+			Buckets: []float64{1, 2, 4, 8, 16, 32, 64, 128, 256},
+		},
+	)
+	signatureBatchFailureCounter = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "gossip_signature_batch_failures_total",
+			Help: "Count of signature batches which failed verification, falling back to individual verification.",
+		},
+	)
+	deferredAttVerificationCounter = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "gossip_attestation_deferred_verifications_total",
+			Help: "Count of gossip attestations queued for deferred signature verification.",
+		},
+	)
+	deferredAttVerificationFailureCounter = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "gossip_attestation_deferred_verification_failures_total",
+			Help: "Count of gossip attestations rejected by the deferred signature verification.",
+		},
+	)
+	rpcThrottledRequestsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rpc_throttled_requests_total",
+			Help: "Count of req/resp requests rejected by the rate limiter, per topic.",
+		},
+		[]string{"topic"},
+	)
+	validationQueueGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "gossip_validation_queue_size",
+			Help: "Number of gossip messages waiting for a validation slot, by priority.",
+		},
+		[]string{"priority"},
+	)
 	arrivalBlockPropagationHistogram = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "block_arrival_latency_milliseconds",
@@ -70,6 +113,30 @@ var (
 			Buckets: []float64{250, 500, 1000, 1500, 2000, 4000, 8000, 16000},
 		},
 	)
+	receivedMessageSizeHistogram = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "p2p_message_received_size_bytes",
+			Help:    "The size of the received gossip messages, before and after their decompression.",
+			Buckets: prometheus.ExponentialBuckets(64, 4, 9),
+		},
+		[]string{"topic", "encoding"},
+	)
+	gossipArrivalLatencyHistogram = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "gossip_message_arrival_latency_milliseconds",
+			Help:    "Time between the start of the slot of a gossip message and its arrival, per topic.",
+			Buckets: []float64{100, 250, 500, 1000, 2000, 3000, 4000, 6000, 8000, 12000, 16000},
+		},
+		[]string{"topic"},
+	)
+	gossipValidationLatencyHistogram = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "gossip_message_validation_latency_milliseconds",
+			Help:    "Time between the start of the slot of a gossip message and its acceptance by validation, per topic.",
+			Buckets: []float64{100, 250, 500, 1000, 2000, 3000, 4000, 6000, 8000, 12000, 16000},
+		},
+		[]string{"topic"},
+	)
 )
 
 func (s *Service) updateMetrics() {
@@ -129,3 +196,31 @@ func (s *Service) collectMetricForSubnet(topic string, digest [4]byte, index uin
 	formattedTopic := fmt.Sprintf(topic, digest, index)
 	topicPeerCount.WithLabelValues(formattedTopic).Set(float64(len(s.cfg.p2p.PubSub().ListPeers(formattedTopic))))
 }
+
+// captureGossipSizeMetrics records the size of a received gossip message, read from its snappy header
+// so that the message does not have to be decompressed.
+func captureGossipSizeMetrics(topic string, data []byte) {
+	receivedMessageSizeHistogram.WithLabelValues(topic, "compressed").Observe(float64(len(data)))
+	if size, err := snappy.DecodedLen(data); err == nil {
+		receivedMessageSizeHistogram.WithLabelValues(topic, "uncompressed").Observe(float64(size))
+	}
+}
+
+// captureGossipLatencyMetrics records the time elapsed between the start of the slot of an accepted
+// gossip message and its arrival, and between the start of the slot and the end of its validation.
+// Messages arriving before the start of their slot are recorded with no latency.
+func captureGossipLatencyMetrics(topic string, genesisTime time.Time, slot types.Slot, receivedTime time.Time) {
+	startTime, err := slots.ToTime(uint64(genesisTime.Unix()), slot)
+	if err != nil {
+		return
+	}
+	gossipArrivalLatencyHistogram.WithLabelValues(topic).Observe(sinceSlotStartMillis(startTime, receivedTime))
+	gossipValidationLatencyHistogram.WithLabelValues(topic).Observe(sinceSlotStartMillis(startTime, prysmTime.Now()))
+}
+
+func sinceSlotStartMillis(startTime, t time.Time) float64 {
+	if t.Before(startTime) {
+		return 0
+	}
+	return float64(t.Sub(startTime).Milliseconds())
+}
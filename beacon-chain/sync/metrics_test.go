@@ -0,0 +1,38 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+)
+
+func TestSinceSlotStartMillis(t *testing.T) {
+	start := time.Unix(1000, 0)
+	assert.Equal(t, float64(1500), sinceSlotStartMillis(start, start.Add(1500*time.Millisecond)))
+	assert.Equal(t, float64(0), sinceSlotStartMillis(start, start.Add(-time.Second)))
+}
+
+func TestCaptureGossipLatencyMetrics(t *testing.T) {
+	topic := "/test/gossip_latency"
+	genesis := time.Now().Add(-10 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	arrivals := testutil.CollectAndCount(gossipArrivalLatencyHistogram)
+	validations := testutil.CollectAndCount(gossipValidationLatencyHistogram)
+
+	captureGossipLatencyMetrics(topic, genesis, 9, time.Now())
+	assert.Equal(t, arrivals+1, testutil.CollectAndCount(gossipArrivalLatencyHistogram))
+	assert.Equal(t, validations+1, testutil.CollectAndCount(gossipValidationLatencyHistogram))
+}
+
+func TestCaptureGossipSizeMetrics(t *testing.T) {
+	topic := "/test/gossip_size"
+	sizes := testutil.CollectAndCount(receivedMessageSizeHistogram)
+	captureGossipSizeMetrics(topic, []byte{0xff})
+	// The uncompressed size is not recorded for invalid snappy data.
+	assert.Equal(t, sizes+1, testutil.CollectAndCount(receivedMessageSizeHistogram))
+	captureGossipSizeMetrics(topic, snappy.Encode(nil, make([]byte, 1024)))
+	assert.Equal(t, sizes+2, testutil.CollectAndCount(receivedMessageSizeHistogram))
+}
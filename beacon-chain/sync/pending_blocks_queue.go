@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/async"
@@ -171,7 +172,7 @@ func (s *Service) processPendingBlocks(ctx context.Context) error {
 				continue
 			}
 
-			s.setSeenBlockIndexSlot(b.Block().Slot(), b.Block().ProposerIndex())
+			s.setSeenBlock(b)
 
 			// Broadcasting the block again once a node is able to process it.
 			if err := s.cfg.p2p.Broadcast(ctx, b.Proto()); err != nil {
@@ -209,36 +210,64 @@ func (s *Service) sendBatchRootRequest(ctx context.Context, roots [][32]byte, ra
 	if len(bestPeers) == 0 {
 		return nil
 	}
-	roots = s.dedupRoots(roots)
-	// Randomly choose a peer to query from our best peers. If that peer cannot return
-	// all the requested blocks, we randomly select another peer.
-	pid := bestPeers[randGen.Int()%len(bestPeers)]
-	for i := 0; i < numOfTries; i++ {
-		req := p2ptypes.BeaconBlockByRootsReq(roots)
-		if len(roots) > int(params.BeaconNetworkConfig().MaxRequestBlocks) {
-			req = roots[:params.BeaconNetworkConfig().MaxRequestBlocks]
-		}
-		if err := s.sendRecentBeaconBlocksRequest(ctx, &req, pid); err != nil {
-			tracing.AnnotateError(span, err)
-			log.Debugf("Could not send recent block request: %v", err)
+	// Roots which are already being requested are not requested again.
+	roots = s.inFlightRoots.claim(s.dedupRoots(roots))
+	if len(roots) == 0 {
+		return nil
+	}
+	defer s.inFlightRoots.release(roots)
+
+	span.AddAttributes(trace.Int64Attribute("numRoots", int64(len(roots))))
+	// Spread the roots across our best peers. The roots which could not be
+	// returned are requested again from another set of peers.
+	for i := 0; i < numOfTries && len(roots) > 0; i++ {
+		s.requestRootsFromPeers(ctx, roots, bestPeers, randGen)
+		roots = s.missingPendingRoots(roots)
+	}
+	return nil
+}
+
+// requestRootsFromPeers splits the roots into blocks by root requests, sent concurrently to randomly
+// chosen peers. Roots beyond what the peers may be requested at once are left out.
+func (s *Service) requestRootsFromPeers(ctx context.Context, roots [][32]byte, pids []peer.ID, randGen *rand.Rand) {
+	pids = append([]peer.ID{}, pids...)
+	randGen.Shuffle(len(pids), func(i, j int) {
+		pids[i], pids[j] = pids[j], pids[i]
+	})
+	size := (len(roots) + len(pids) - 1) / len(pids)
+	if maxRequest := int(params.BeaconNetworkConfig().MaxRequestBlocks); size > maxRequest {
+		size = maxRequest
+	}
+
+	var wg sync.WaitGroup
+	for i, j := 0, 0; i < len(roots) && j < len(pids); i, j = i+size, j+1 {
+		end := i + size
+		if end > len(roots) {
+			end = len(roots)
 		}
-		newRoots := make([][32]byte, 0, len(roots))
-		s.pendingQueueLock.RLock()
-		for _, rt := range roots {
-			if !s.seenPendingBlocks[rt] {
-				newRoots = append(newRoots, rt)
+		req := p2ptypes.BeaconBlockByRootsReq(roots[i:end])
+		wg.Add(1)
+		go func(pid peer.ID) {
+			defer wg.Done()
+			if err := s.sendRecentBeaconBlocksRequest(ctx, &req, pid); err != nil {
+				log.WithError(err).WithField("peer", pid).Debug("Could not send recent block request")
 			}
+		}(pids[j])
+	}
+	wg.Wait()
+}
+
+// missingPendingRoots returns the roots whose blocks are not in the pending queue.
+func (s *Service) missingPendingRoots(roots [][32]byte) [][32]byte {
+	s.pendingQueueLock.RLock()
+	defer s.pendingQueueLock.RUnlock()
+	missing := make([][32]byte, 0, len(roots))
+	for _, rt := range roots {
+		if !s.seenPendingBlocks[rt] {
+			missing = append(missing, rt)
 		}
-		s.pendingQueueLock.RUnlock()
-		if len(newRoots) == 0 {
-			break
-		}
-		// Choosing a new peer with the leftover set of
-		// roots to request.
-		roots = newRoots
-		pid = bestPeers[randGen.Int()%len(bestPeers)]
 	}
-	return nil
+	return missing
 }
 
 func (s *Service) sortedPendingSlots() []types.Slot {
@@ -2,6 +2,7 @@ package sync
 
 import (
 	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/kevinms/leakybucket-go"
@@ -39,14 +40,14 @@ func newRateLimiter(p2pProvider p2p.P2P) *limiter {
 	// Set topic map for all rpc topics.
 	topicMap := make(map[string]*leakybucket.Collector, len(p2p.RPCTopicMappings))
 	// Goodbye Message
-	topicMap[addEncoding(p2p.RPCGoodByeTopicV1)] = leakybucket.NewCollector(1, 1, false /* deleteEmptyBuckets */)
+	topicMap[addEncoding(p2p.RPCGoodByeTopicV1)] = newTopicCollector(p2p.GoodbyeMessageName, 1, 1)
 	// MetadataV0 Message
-	topicMap[addEncoding(p2p.RPCMetaDataTopicV1)] = leakybucket.NewCollector(1, defaultBurstLimit, false /* deleteEmptyBuckets */)
-	topicMap[addEncoding(p2p.RPCMetaDataTopicV2)] = leakybucket.NewCollector(1, defaultBurstLimit, false /* deleteEmptyBuckets */)
+	topicMap[addEncoding(p2p.RPCMetaDataTopicV1)] = newTopicCollector(p2p.MetadataMessageName, 1, defaultBurstLimit)
+	topicMap[addEncoding(p2p.RPCMetaDataTopicV2)] = newTopicCollector(p2p.MetadataMessageName, 1, defaultBurstLimit)
 	// Ping Message
-	topicMap[addEncoding(p2p.RPCPingTopicV1)] = leakybucket.NewCollector(1, defaultBurstLimit, false /* deleteEmptyBuckets */)
+	topicMap[addEncoding(p2p.RPCPingTopicV1)] = newTopicCollector(p2p.PingMessageName, 1, defaultBurstLimit)
 	// Status Message
-	topicMap[addEncoding(p2p.RPCStatusTopicV1)] = leakybucket.NewCollector(1, defaultBurstLimit, false /* deleteEmptyBuckets */)
+	topicMap[addEncoding(p2p.RPCStatusTopicV1)] = newTopicCollector(p2p.StatusMessageName, 1, defaultBurstLimit)
 
 	// Use a single collector for block requests
 	blockCollector := leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksBurst, false /* deleteEmptyBuckets */)
@@ -62,11 +63,28 @@ func newRateLimiter(p2pProvider p2p.P2P) *limiter {
 	topicMap[addEncoding(p2p.RPCBlocksByRangeTopicV2)] = blockCollectorV2
 
 	// General topic for all rpc requests.
-	topicMap[rpcLimiterTopic] = leakybucket.NewCollector(5, defaultBurstLimit*2, false /* deleteEmptyBuckets */)
+	allowedRequestsPerSecond := float64(flags.DefaultRPCRateLimit)
+	if flags.Get().RPCRateLimit > 0 {
+		allowedRequestsPerSecond = float64(flags.Get().RPCRateLimit)
+	}
+	allowedRequestsBurst := int64(flags.DefaultRPCRateLimitBurst)
+	if flags.Get().RPCRateLimitBurst > 0 {
+		allowedRequestsBurst = int64(flags.Get().RPCRateLimitBurst)
+	}
+	topicMap[rpcLimiterTopic] = leakybucket.NewCollector(allowedRequestsPerSecond, allowedRequestsBurst, false /* deleteEmptyBuckets */)
 
 	return &limiter{limiterMap: topicMap, p2p: p2pProvider}
 }
 
+// newTopicCollector returns a collector for the provided message name, using the rate limit configured
+// for the topic if there is one and the provided default otherwise.
+func newTopicCollector(messageName string, perSecond float64, burst int64) *leakybucket.Collector {
+	if limit, ok := flags.Get().RPCTopicRateLimits[strings.TrimPrefix(messageName, "/")]; ok {
+		perSecond, burst = limit.PerSecond, limit.Burst
+	}
+	return leakybucket.NewCollector(perSecond, burst, false /* deleteEmptyBuckets */)
+}
+
 // Returns the current topic collector for the provided topic.
 func (l *limiter) topicCollector(topic string) (*leakybucket.Collector, error) {
 	l.RLock()
@@ -92,6 +110,7 @@ func (l *limiter) validateRequest(stream network.Stream, amt uint64) error {
 		amt = 1
 	}
 	if amt > uint64(remaining) {
+		rpcThrottledRequestsCounter.WithLabelValues(topic).Inc()
 		l.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
 		writeErrorResponseToStream(responseCodeInvalidRequest, p2ptypes.ErrRateLimited.Error(), stream, l.p2p)
 		return p2ptypes.ErrRateLimited
@@ -115,6 +134,7 @@ func (l *limiter) validateRawRpcRequest(stream network.Stream) error {
 	// Treat each request as a minimum of 1.
 	amt := int64(1)
 	if amt > remaining {
+		rpcThrottledRequestsCounter.WithLabelValues(string(stream.Protocol())).Inc()
 		l.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
 		writeErrorResponseToStream(responseCodeInvalidRequest, p2ptypes.ErrRateLimited.Error(), stream, l.p2p)
 		return p2ptypes.ErrRateLimited
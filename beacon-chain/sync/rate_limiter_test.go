@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	p2ptypes "github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
@@ -21,6 +22,36 @@ func TestNewRateLimiter(t *testing.T) {
 	assert.Equal(t, len(rlimiter.limiterMap), 10, "correct number of topics not registered")
 }
 
+func TestNewRateLimiter_ConfiguredLimits(t *testing.T) {
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{
+		BlockBatchLimit:            64,
+		BlockBatchLimitBurstFactor: 10,
+		RPCRateLimit:               20,
+		RPCRateLimitBurst:          40,
+		RPCTopicRateLimits: map[string]flags.RateLimit{
+			"status": {PerSecond: 2, Burst: 16},
+		},
+	})
+	defer flags.Init(resetFlags)
+
+	p := mockp2p.NewTestP2P(t)
+	rlimiter := newRateLimiter(p)
+
+	collector := rlimiter.limiterMap[rpcLimiterTopic]
+	assert.Equal(t, float64(20), collector.Rate())
+	assert.Equal(t, int64(40), collector.Capacity())
+
+	collector = rlimiter.limiterMap[p2p.RPCStatusTopicV1+p.Encoding().ProtocolSuffix()]
+	assert.Equal(t, float64(2), collector.Rate())
+	assert.Equal(t, int64(16), collector.Capacity())
+
+	// Topics without a configured limit keep their defaults.
+	collector = rlimiter.limiterMap[p2p.RPCPingTopicV1+p.Encoding().ProtocolSuffix()]
+	assert.Equal(t, float64(1), collector.Rate())
+	assert.Equal(t, int64(defaultBurstLimit), collector.Capacity())
+}
+
 func TestNewRateLimiter_FreeCorrectly(t *testing.T) {
 	rlimiter := newRateLimiter(mockp2p.NewTestP2P(t))
 	rlimiter.free()
@@ -33,6 +33,7 @@ import (
 	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/config/params"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/runtime"
 	prysmTime "github.com/prysmaticlabs/prysm/time"
@@ -95,6 +96,7 @@ type blockchainService interface {
 	blockchain.TimeFetcher
 	blockchain.GenesisFetcher
 	blockchain.CanonicalFetcher
+	blockchain.LightClientFetcher
 }
 
 // Service is responsible for handling all run time p2p related operations as the
@@ -122,15 +124,23 @@ type Service struct {
 	seenExitCache                    *lru.Cache
 	seenProposerSlashingLock         sync.RWMutex
 	seenProposerSlashingCache        *lru.Cache
+	seenProposerEquivocationLock     sync.RWMutex
+	seenProposerEquivocationCache    *lru.Cache
 	seenAttesterSlashingLock         sync.RWMutex
 	seenAttesterSlashingCache        map[uint64]bool
 	seenSyncMessageLock              sync.RWMutex
 	seenSyncMessageCache             *lru.Cache
 	seenSyncContributionLock         sync.RWMutex
 	seenSyncContributionCache        *lru.Cache
+	lightClientLock                  sync.Mutex
+	lastFinalityUpdate               *ethpbv2.LightClientFinalityUpdate
+	lastOptimisticUpdate             *ethpbv2.LightClientOptimisticUpdate
 	badBlockCache                    *lru.Cache
 	badBlockLock                     sync.RWMutex
 	signatureChan                    chan *signatureVerifier
+	deferredAttChan                  chan *deferredAttestation
+	validationQueue                  *validationQueue
+	inFlightRoots                    inFlightRoots
 }
 
 // NewService initializes new regular sync service.
@@ -146,6 +156,8 @@ func NewService(ctx context.Context, opts ...Option) *Service {
 		seenPendingBlocks:    make(map[[32]byte]bool),
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
 		signatureChan:        make(chan *signatureVerifier, verifierLimit),
+		deferredAttChan:      make(chan *deferredAttestation, deferredAttLimit),
+		validationQueue:      newValidationQueue(maxConcurrentValidations),
 	}
 	for _, opt := range opts {
 		if err := opt(r); err != nil {
@@ -157,6 +169,7 @@ func NewService(ctx context.Context, opts ...Option) *Service {
 
 	go r.registerHandlers()
 	go r.verifierRoutine()
+	go r.deferredAttVerifierRoutine()
 
 	return r
 }
@@ -223,6 +236,7 @@ func (s *Service) initCaches() {
 	s.seenExitCache = lruwrpr.New(seenExitSize)
 	s.seenAttesterSlashingCache = make(map[uint64]bool)
 	s.seenProposerSlashingCache = lruwrpr.New(seenProposerSlashingSize)
+	s.seenProposerEquivocationCache = lruwrpr.New(seenBlockSize)
 	s.badBlockCache = lruwrpr.New(badBlockSize)
 }
 
@@ -16,6 +16,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/container/slice"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
@@ -117,6 +118,20 @@ func (s *Service) registerSubscribers(epoch types.Epoch, digest [4]byte) {
 				digest,
 			)
 		}
+		if features.Get().EnableLightClient {
+			s.subscribe(
+				p2p.LightClientFinalityUpdateTopicFormat,
+				s.validateLightClientFinalityUpdate,
+				s.lightClientUpdateSubscriber,
+				digest,
+			)
+			s.subscribe(
+				p2p.LightClientOptimisticUpdateTopicFormat,
+				s.validateLightClientOptimisticUpdate,
+				s.lightClientUpdateSubscriber,
+				digest,
+			)
+		}
 	}
 }
 
@@ -226,6 +241,7 @@ func (s *Service) subscribeWithBase(topic string, validator wrappedVal, handle s
 // Wrap the pubsub validator with a metric monitoring function. This function increments the
 // appropriate counter if the particular message fails to validate.
 func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, pubsub.ValidatorEx) {
+	priority := topicPriority(topic)
 	return topic, func(ctx context.Context, pid peer.ID, msg *pubsub.Message) (res pubsub.ValidationResult) {
 		defer messagehandler.HandlePanic(ctx, msg)
 		res = pubsub.ValidationIgnore // Default: ignore any message that panics.
@@ -236,6 +252,7 @@ func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, p
 			messageFailedValidationCounter.WithLabelValues(topic).Inc()
 			return pubsub.ValidationReject
 		}
+		captureGossipSizeMetrics(topic, msg.Data)
 		// Ignore any messages received before chainstart.
 		if s.chainStarted.IsNotSet() {
 			messageIgnoredValidationCounter.WithLabelValues(topic).Inc()
@@ -255,6 +272,13 @@ func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, p
 			log.WithField("topic", topic).Debugf("Received message from outdated fork digest %#x", retDigest)
 			return pubsub.ValidationIgnore
 		}
+		if s.validationQueue != nil {
+			if err := s.validationQueue.acquire(ctx, priority); err != nil {
+				messageIgnoredValidationCounter.WithLabelValues(topic).Inc()
+				return pubsub.ValidationIgnore
+			}
+			defer s.validationQueue.release()
+		}
 		b, err := v(ctx, pid, msg)
 		if b == pubsub.ValidationReject {
 			log.WithError(err).WithFields(logrus.Fields{
@@ -659,7 +683,7 @@ func (_ *Service) retrieveActiveSyncSubnets(currEpoch types.Epoch) []uint64 {
 }
 
 // filters out required peers for the node to function, not
-// pruning peers who are in our attestation subnets.
+// pruning peers who are in our attestation and sync committee subnets.
 func (s *Service) filterNeededPeers(pids []peer.ID) []peer.ID {
 	// Exit early if nothing to filter.
 	if len(pids) == 0 {
@@ -694,6 +718,16 @@ func (s *Service) filterNeededPeers(pids []peer.ID) []peer.ID {
 			peerMap[p] = true
 		}
 	}
+	// Peers advertise their sync committee subnets in their metadata.
+	for _, sub := range s.retrieveActiveSyncSubnets(slots.ToEpoch(currSlot)) {
+		peers := s.cfg.p2p.Peers().SubscribedToSyncSubnet(sub)
+		if len(peers) > flags.Get().MinimumPeersPerSubnet {
+			peers = peers[:flags.Get().MinimumPeersPerSubnet]
+		}
+		for _, p := range peers {
+			peerMap[p] = true
+		}
+	}
 
 	// Clear out necessary peers from the peers to prune.
 	newPeers := make([]peer.ID, 0, len(pids))
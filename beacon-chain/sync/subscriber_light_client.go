@@ -0,0 +1,13 @@
+package sync
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// lightClientUpdateSubscriber is a no-op, as the node creates its own light client updates and only relays the
+// validated updates of its peers.
+func (s *Service) lightClientUpdateSubscriber(_ context.Context, _ proto.Message) error {
+	return nil
+}
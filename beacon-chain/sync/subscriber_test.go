@@ -8,10 +8,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
 	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/async/abool"
 	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
@@ -20,6 +22,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
 	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
@@ -29,6 +32,7 @@ import (
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/network/forks"
 	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
@@ -503,6 +507,45 @@ func TestFilterSubnetPeers(t *testing.T) {
 	cancel()
 }
 
+func TestFilterNeededPeers_SyncSubnets(t *testing.T) {
+	gFlags := new(flags.GlobalFlags)
+	gFlags.MinimumPeersPerSubnet = 4
+	flags.Init(gFlags)
+	// Reset config.
+	defer flags.Init(new(flags.GlobalFlags))
+	p := p2ptest.NewTestP2P(t)
+	currSlot := types.Slot(100)
+	r := Service{
+		ctx: context.Background(),
+		cfg: &config{
+			chain: &mockChain.ChainService{
+				Genesis:        time.Now(),
+				ValidatorsRoot: [32]byte{'A'},
+				Slot:           &currSlot,
+			},
+			p2p: p,
+		},
+		chainStarted: abool.New(),
+		subHandler:   newSubTopicHandler(),
+	}
+	// Empty cache at the end of the test.
+	defer cache.SyncSubnetIDs.EmptyAllCaches()
+	cache.SyncSubnetIDs.AddSyncCommitteeSubnets([]byte("pubkey"), slots.ToEpoch(currSlot), []uint64{1}, 10*time.Second)
+
+	syncPeer, otherPeer := createPeer(t).PeerID(), createPeer(t).PeerID()
+	for i, pid := range []peer.ID{syncPeer, otherPeer} {
+		p.Peers().Add(nil, pid, nil, network.DirInbound)
+		p.Peers().SetConnectionState(pid, peers.PeerConnected)
+		syncnets := bitfield.Bitvector4{byte(0x00)}
+		syncnets.SetBitAt(uint64(1+i), true)
+		p.Peers().SetMetadata(pid, wrapper.WrappedMetadataV1(&pb.MetaDataV1{
+			Attnets:  bitfield.NewBitvector64(),
+			Syncnets: syncnets,
+		}))
+	}
+	assert.DeepEqual(t, []peer.ID{otherPeer}, r.filterNeededPeers([]peer.ID{syncPeer, otherPeer}))
+}
+
 func TestSubscribeWithSyncSubnets_StaticOK(t *testing.T) {
 	p := p2ptest.NewTestP2P(t)
 	ctx, cancel := context.WithCancel(context.Background())
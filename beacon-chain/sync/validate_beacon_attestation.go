@@ -154,7 +154,17 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 		return validationRes, err
 	}
 
-	validationRes, err = s.validateUnaggregatedAttWithState(ctx, att, preState)
+	if s.lazyAttestationVerification() {
+		// Only check the committee and aggregation bits here, the signature is verified in a batch by
+		// the deferred verifier.
+		validationRes, err = s.validateUnaggregatedAttBits(ctx, att, preState)
+		if validationRes != pubsub.ValidationAccept {
+			return validationRes, err
+		}
+		validationRes, err = s.deferAttestationVerification(ctx, att, preState)
+	} else {
+		validationRes, err = s.validateUnaggregatedAttWithState(ctx, att, preState)
+	}
 	if validationRes != pubsub.ValidationAccept {
 		return validationRes, err
 	}
@@ -200,6 +210,30 @@ func (s *Service) validateUnaggregatedAttWithState(ctx context.Context, a *eth.A
 	ctx, span := trace.StartSpan(ctx, "sync.validateUnaggregatedAttWithState")
 	defer span.End()
 
+	if validationRes, err := s.validateUnaggregatedAttBits(ctx, a, bs); validationRes != pubsub.ValidationAccept {
+		return validationRes, err
+	}
+
+	if features.Get().EnableBatchVerification {
+		set, err := blocks.AttestationSignatureBatch(ctx, bs, []*eth.Attestation{a})
+		if err != nil {
+			tracing.AnnotateError(span, err)
+			return pubsub.ValidationReject, err
+		}
+		return s.validateWithBatchVerifier(ctx, "attestation", set)
+	}
+	if err := blocks.VerifyAttestationSignature(ctx, bs, a); err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	return pubsub.ValidationAccept, nil
+}
+
+// This validates the aggregation bits of a beacon unaggregated attestation against its committee in the given state.
+func (_ *Service) validateUnaggregatedAttBits(ctx context.Context, a *eth.Attestation, bs state.ReadOnlyBeaconState) (pubsub.ValidationResult, error) {
+	ctx, span := trace.StartSpan(ctx, "sync.validateUnaggregatedAttBits")
+	defer span.End()
+
 	committee, err := helpers.BeaconCommitteeFromState(ctx, bs, a.Data.Slot, a.Data.CommitteeIndex)
 	if err != nil {
 		tracing.AnnotateError(span, err)
@@ -217,19 +251,6 @@ func (s *Service) validateUnaggregatedAttWithState(ctx context.Context, a *eth.A
 	if a.AggregationBits.Count() != 1 || a.AggregationBits.BitIndices()[0] >= len(committee) {
 		return pubsub.ValidationReject, errors.New("attestation bitfield is invalid")
 	}
-
-	if features.Get().EnableBatchVerification {
-		set, err := blocks.AttestationSignatureBatch(ctx, bs, []*eth.Attestation{a})
-		if err != nil {
-			tracing.AnnotateError(span, err)
-			return pubsub.ValidationReject, err
-		}
-		return s.validateWithBatchVerifier(ctx, "attestation", set)
-	}
-	if err := blocks.VerifyAttestationSignature(ctx, bs, a); err != nil {
-		tracing.AnnotateError(span, err)
-		return pubsub.ValidationReject, err
-	}
 	return pubsub.ValidationAccept, nil
 }
 
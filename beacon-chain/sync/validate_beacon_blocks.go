@@ -13,18 +13,23 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
+	"github.com/prysmaticlabs/prysm/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
 	"github.com/prysmaticlabs/prysm/runtime/version"
 	prysmTime "github.com/prysmaticlabs/prysm/time"
 	"github.com/prysmaticlabs/prysm/time/slots"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
 )
 
 // validateBeaconBlockPubSub checks that the incoming block has a valid BLS signature.
@@ -85,9 +90,11 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 		}()
 	}
 
-	// Verify the block is the first block received for the proposer for the slot.
+	// Verify the block is the first block received for the proposer for the slot. A conflicting
+	// block is still ignored rather than processed as a fork candidate, but it is kept as evidence
+	// of the proposer equivocation.
 	if s.hasSeenBlockIndexSlot(blk.Block().Slot(), blk.Block().ProposerIndex()) {
-		return pubsub.ValidationIgnore, nil
+		return s.handleProposerEquivocation(ctx, blk)
 	}
 
 	blockRoot, err := blk.Block().HashTreeRoot()
@@ -178,6 +185,7 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 		"blockSlot":          blk.Block().Slot(),
 		"sinceSlotStartTime": receivedTime.Sub(startTime),
 	}).Debug("Received block")
+	captureGossipLatencyMetrics(p2p.GossipBlockMessage, s.cfg.chain.GenesisTime(), blk.Block().Slot(), receivedTime)
 	return pubsub.ValidationAccept, nil
 }
 
@@ -202,7 +210,7 @@ func (s *Service) validateBeaconBlock(ctx context.Context, blk block.SignedBeaco
 		return err
 	}
 
-	if err := blocks.VerifyBlockSignatureUsingCurrentFork(parentState, blk); err != nil {
+	if err := s.verifyBlockSignature(ctx, parentState, blk); err != nil {
 		s.setBadBlock(ctx, blockRoot)
 		return err
 	}
@@ -269,17 +277,130 @@ func validateBellatrixBeaconBlock(parentState state.BeaconState, blk block.Beaco
 func (s *Service) hasSeenBlockIndexSlot(slot types.Slot, proposerIdx types.ValidatorIndex) bool {
 	s.seenBlockLock.RLock()
 	defer s.seenBlockLock.RUnlock()
-	b := append(bytesutil.Bytes32(uint64(slot)), bytesutil.Bytes32(uint64(proposerIdx))...)
-	_, seen := s.seenBlockCache.Get(string(b))
+	_, seen := s.seenBlockCache.Get(seenBlockKey(slot, proposerIdx))
 	return seen
 }
 
-// Set block proposer index and slot as seen for incoming blocks.
-func (s *Service) setSeenBlockIndexSlot(slot types.Slot, proposerIdx types.ValidatorIndex) {
+// Returns the signed header of the first block seen for the proposer for the slot, if any.
+func (s *Service) seenBlockHeader(slot types.Slot, proposerIdx types.ValidatorIndex) *ethpb.SignedBeaconBlockHeader {
+	s.seenBlockLock.RLock()
+	defer s.seenBlockLock.RUnlock()
+	v, seen := s.seenBlockCache.Get(seenBlockKey(slot, proposerIdx))
+	if !seen {
+		return nil
+	}
+	header, ok := v.(*ethpb.SignedBeaconBlockHeader)
+	if !ok {
+		return nil
+	}
+	return header
+}
+
+// Set block proposer index and slot as seen for incoming blocks. The signed header of the block
+// is kept to detect a later equivocation of its proposer.
+func (s *Service) setSeenBlock(blk block.SignedBeaconBlock) {
+	header, err := block.SignedBeaconBlockHeaderFromBlockInterface(blk)
+	if err != nil {
+		log.WithError(err).WithField("blockSlot", blk.Block().Slot()).Debug("Could not extract block header")
+	}
 	s.seenBlockLock.Lock()
 	defer s.seenBlockLock.Unlock()
-	b := append(bytesutil.Bytes32(uint64(slot)), bytesutil.Bytes32(uint64(proposerIdx))...)
-	s.seenBlockCache.Add(string(b), true)
+	s.seenBlockCache.Add(seenBlockKey(blk.Block().Slot(), blk.Block().ProposerIndex()), header)
+}
+
+func seenBlockKey(slot types.Slot, proposerIdx types.ValidatorIndex) string {
+	return string(append(bytesutil.Bytes32(uint64(slot)), bytesutil.Bytes32(uint64(proposerIdx))...))
+}
+
+// handleProposerEquivocation checks whether the incoming block conflicts with the block first seen
+// for the same proposer and slot. Only the signature of the incoming block is verified, at most once
+// per proposer and slot. If it is valid, fork choice is told not to treat either block as timely,
+// and the resulting proposer slashing is inserted into the slashing pool in the background.
+func (s *Service) handleProposerEquivocation(ctx context.Context, blk block.SignedBeaconBlock) (pubsub.ValidationResult, error) {
+	ctx, span := trace.StartSpan(ctx, "sync.handleProposerEquivocation")
+	defer span.End()
+
+	seenHeader := s.seenBlockHeader(blk.Block().Slot(), blk.Block().ProposerIndex())
+	if seenHeader == nil {
+		return pubsub.ValidationIgnore, nil
+	}
+	header, err := block.SignedBeaconBlockHeaderFromBlockInterface(blk)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	if proto.Equal(seenHeader.Header, header.Header) || s.hasSeenProposerSlashingIndex(header.Header.ProposerIndex) {
+		return pubsub.ValidationIgnore, nil
+	}
+	if s.hasSeenProposerEquivocation(header.Header.Slot, header.Header.ProposerIndex) {
+		return pubsub.ValidationIgnore, nil
+	}
+	s.setSeenProposerEquivocation(header.Header.Slot, header.Header.ProposerIndex)
+
+	pubkey, err := s.cfg.chain.HeadValidatorIndexToPublicKey(ctx, header.Header.ProposerIndex)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	fork, err := forks.Fork(slots.ToEpoch(header.Header.Slot))
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	gvr := s.cfg.chain.GenesisValidatorsRoot()
+	domain, err := signing.Domain(fork, slots.ToEpoch(header.Header.Slot), params.BeaconConfig().DomainBeaconProposer, gvr[:])
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	if err := signing.VerifyBlockHeaderSigningRoot(header.Header, pubkey[:], header.Signature, domain); err != nil {
+		return pubsub.ValidationReject, errors.Wrap(err, "invalid proposer equivocation")
+	}
+	log.WithFields(logrus.Fields{
+		"slot":          header.Header.Slot,
+		"proposerIndex": header.Header.ProposerIndex,
+	}).Warn("Detected proposer equivocation")
+
+	slashing := &ethpb.ProposerSlashing{
+		Header_1: seenHeader,
+		Header_2: header,
+	}
+	go s.receiveProposerEquivocation(slashing)
+	return pubsub.ValidationIgnore, nil
+}
+
+// receiveProposerEquivocation applies a proposer equivocation to fork choice and inserts the
+// proposer slashing into the slashing pool. This is done in the background to keep gossip
+// validation fast.
+func (s *Service) receiveProposerEquivocation(slashing *ethpb.ProposerSlashing) {
+	ctx, span := trace.StartSpan(s.ctx, "sync.receiveProposerEquivocation")
+	defer span.End()
+
+	if err := s.cfg.chain.ReceiveProposerEquivocation(ctx, slashing); err != nil {
+		log.WithError(err).Error("Could not apply proposer equivocation to fork choice")
+		return
+	}
+	headState, err := s.cfg.chain.HeadState(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get head state")
+		return
+	}
+	if err := s.cfg.slashingPool.InsertProposerSlashing(ctx, headState, slashing); err != nil {
+		log.WithError(err).Debug("Could not insert proposer slashing into pool")
+		return
+	}
+	s.setProposerSlashingIndexSeen(slashing.Header_1.Header.ProposerIndex)
+}
+
+// Returns true if a conflicting block was already checked for the proposer for the slot.
+func (s *Service) hasSeenProposerEquivocation(slot types.Slot, proposerIdx types.ValidatorIndex) bool {
+	s.seenProposerEquivocationLock.RLock()
+	defer s.seenProposerEquivocationLock.RUnlock()
+	_, seen := s.seenProposerEquivocationCache.Get(seenBlockKey(slot, proposerIdx))
+	return seen
+}
+
+// Set proposer index and slot as seen for conflicting blocks, whether or not their signature is valid.
+func (s *Service) setSeenProposerEquivocation(slot types.Slot, proposerIdx types.ValidatorIndex) {
+	s.seenProposerEquivocationLock.Lock()
+	defer s.seenProposerEquivocationLock.Unlock()
+	s.seenProposerEquivocationCache.Add(seenBlockKey(slot, proposerIdx), true)
 }
 
 // Returns true if the block is marked as a bad block.
@@ -325,3 +446,18 @@ func isBlockQueueable(genesisTime uint64, slot types.Slot, receivedTime time.Tim
 	currentTimeWithDisparity := receivedTime.Add(params.BeaconNetworkConfig().MaximumGossipClockDisparity)
 	return currentTimeWithDisparity.Unix() < slotTime.Unix()
 }
+
+// verifyBlockSignature verifies the proposer signature of a block. With batch verification enabled, the
+// signature is verified right away along with the other pending signatures received over gossip, so the
+// block does not wait for the batch to fill up.
+func (s *Service) verifyBlockSignature(ctx context.Context, parentState state.ReadOnlyBeaconState, blk block.SignedBeaconBlock) error {
+	if !features.Get().EnableBatchVerification {
+		return blocks.VerifyBlockSignatureUsingCurrentFork(parentState, blk)
+	}
+	set, err := blocks.BlockSignatureBatchUsingCurrentFork(parentState, blk)
+	if err != nil {
+		return err
+	}
+	_, err = s.verifyWithBatchVerifier(ctx, "block", set, true /* flush */)
+	return err
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
@@ -716,7 +717,7 @@ func TestValidateBeaconBlockPubSub_SeenProposerSlot(t *testing.T) {
 			Topic: &topic,
 		},
 	}
-	r.setSeenBlockIndexSlot(msg.Block.Slot, msg.Block.ProposerIndex)
+	r.setSeenBlock(wrapper.WrappedPhase0SignedBeaconBlock(msg))
 	time.Sleep(10 * time.Millisecond) // Wait for cached value to pass through buffers.
 	res, err := r.validateBeaconBlockPubSub(ctx, "", m)
 	_ = err
@@ -724,6 +725,179 @@ func TestValidateBeaconBlockPubSub_SeenProposerSlot(t *testing.T) {
 	assert.Equal(t, false, result)
 }
 
+func TestValidateBeaconBlockPubSub_ProposerEquivocation(t *testing.T) {
+	db := dbtest.SetupDB(t)
+	p := p2ptest.NewTestP2P(t)
+	ctx := context.Background()
+	beaconState, privKeys := util.DeterministicGenesisState(t, 100)
+	parentBlock := util.NewBeaconBlock()
+	require.NoError(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(parentBlock)))
+	bRoot, err := parentBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, beaconState, bRoot))
+	proposerIdx, err := helpers.BeaconProposerIndex(ctx, beaconState)
+	require.NoError(t, err)
+
+	newBlock := func(graffiti string) *ethpb.SignedBeaconBlock {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = 1
+		b.Block.ProposerIndex = proposerIdx
+		b.Block.ParentRoot = bRoot[:]
+		b.Block.Body.Graffiti = bytesutil.PadTo([]byte(graffiti), 32)
+		b.Signature, err = signing.ComputeDomainAndSign(beaconState, 0, b.Block, params.BeaconConfig().DomainBeaconProposer, privKeys[proposerIdx])
+		require.NoError(t, err)
+		return b
+	}
+	first := newBlock("first")
+	msg := newBlock("second")
+
+	chainService := &mock.ChainService{Genesis: time.Unix(time.Now().Unix()-int64(params.BeaconConfig().SecondsPerSlot), 0),
+		State: beaconState,
+		FinalizedCheckPoint: &ethpb.Checkpoint{
+			Epoch: 0,
+			Root:  make([]byte, 32),
+		},
+		PublicKey:      bytesutil.ToBytes48(beaconState.Validators()[proposerIdx].PublicKey),
+		ValidatorsRoot: bytesutil.ToBytes32(beaconState.GenesisValidatorsRoot()),
+	}
+	r := &Service{
+		ctx: ctx,
+		cfg: &config{
+			beaconDB:      db,
+			p2p:           p,
+			initialSync:   &mockSync.Sync{IsSyncing: false},
+			chain:         chainService,
+			blockNotifier: chainService.BlockNotifier(),
+			slashingPool:  slashings.NewPool(),
+		},
+		seenBlockCache:                lruwrpr.New(10),
+		seenProposerSlashingCache:     lruwrpr.New(10),
+		seenProposerEquivocationCache: lruwrpr.New(10),
+		badBlockCache:                 lruwrpr.New(10),
+		slotToPendingBlocks:           gcache.New(time.Second, 2*time.Second),
+		seenPendingBlocks:             make(map[[32]byte]bool),
+	}
+	digest, err := r.currentForkDigest()
+	require.NoError(t, err)
+	gossipMsg := func(b *ethpb.SignedBeaconBlock) *pubsub.Message {
+		buf := new(bytes.Buffer)
+		_, err := p.Encoding().EncodeGossip(buf, b)
+		require.NoError(t, err)
+		topic := r.addDigestToTopic(p2p.GossipTypeMapping[reflect.TypeOf(b)], digest)
+		return &pubsub.Message{
+			Message: &pubsubpb.Message{
+				Data:  buf.Bytes(),
+				Topic: &topic,
+			},
+		}
+	}
+	m := gossipMsg(msg)
+	r.setSeenBlock(wrapper.WrappedPhase0SignedBeaconBlock(first))
+	time.Sleep(10 * time.Millisecond) // Wait for cached value to pass through buffers.
+	res, err := r.validateBeaconBlockPubSub(ctx, "", m)
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+
+	// The proposer slashing is handled in the background.
+	for i := 0; i < 100 && !r.hasSeenProposerSlashingIndex(proposerIdx); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Equal(t, true, r.hasSeenProposerSlashingIndex(proposerIdx))
+	require.Equal(t, 1, len(chainService.ProposerEquivocations))
+	ps := r.cfg.slashingPool.PendingProposerSlashings(ctx, beaconState, true /*noLimit*/)
+	require.Equal(t, 1, len(ps))
+	assert.Equal(t, proposerIdx, ps[0].Header_1.Header.ProposerIndex)
+
+	// The same equivocation is only reported once.
+	res, err = r.validateBeaconBlockPubSub(ctx, "", m)
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+	require.Equal(t, 1, len(chainService.ProposerEquivocations))
+}
+
+func TestValidateBeaconBlockPubSub_ProposerEquivocation_InvalidSignature(t *testing.T) {
+	db := dbtest.SetupDB(t)
+	p := p2ptest.NewTestP2P(t)
+	ctx := context.Background()
+	beaconState, privKeys := util.DeterministicGenesisState(t, 100)
+	parentBlock := util.NewBeaconBlock()
+	require.NoError(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(parentBlock)))
+	bRoot, err := parentBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, beaconState, bRoot))
+	proposerIdx, err := helpers.BeaconProposerIndex(ctx, beaconState)
+	require.NoError(t, err)
+
+	newBlock := func(graffiti string) *ethpb.SignedBeaconBlock {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = 1
+		b.Block.ProposerIndex = proposerIdx
+		b.Block.ParentRoot = bRoot[:]
+		b.Block.Body.Graffiti = bytesutil.PadTo([]byte(graffiti), 32)
+		b.Signature, err = signing.ComputeDomainAndSign(beaconState, 0, b.Block, params.BeaconConfig().DomainBeaconProposer, privKeys[proposerIdx])
+		require.NoError(t, err)
+		return b
+	}
+	first := newBlock("first")
+	// The forged block carries the signature of the first block.
+	forged := newBlock("forged")
+	forged.Signature = first.Signature
+	valid := newBlock("valid")
+
+	chainService := &mock.ChainService{Genesis: time.Unix(time.Now().Unix()-int64(params.BeaconConfig().SecondsPerSlot), 0),
+		State: beaconState,
+		FinalizedCheckPoint: &ethpb.Checkpoint{
+			Epoch: 0,
+			Root:  make([]byte, 32),
+		},
+		PublicKey:      bytesutil.ToBytes48(beaconState.Validators()[proposerIdx].PublicKey),
+		ValidatorsRoot: bytesutil.ToBytes32(beaconState.GenesisValidatorsRoot()),
+	}
+	r := &Service{
+		ctx: ctx,
+		cfg: &config{
+			beaconDB:      db,
+			p2p:           p,
+			initialSync:   &mockSync.Sync{IsSyncing: false},
+			chain:         chainService,
+			blockNotifier: chainService.BlockNotifier(),
+			slashingPool:  slashings.NewPool(),
+		},
+		seenBlockCache:                lruwrpr.New(10),
+		seenProposerSlashingCache:     lruwrpr.New(10),
+		seenProposerEquivocationCache: lruwrpr.New(10),
+		badBlockCache:                 lruwrpr.New(10),
+		slotToPendingBlocks:           gcache.New(time.Second, 2*time.Second),
+		seenPendingBlocks:             make(map[[32]byte]bool),
+	}
+	digest, err := r.currentForkDigest()
+	require.NoError(t, err)
+	gossipMsg := func(b *ethpb.SignedBeaconBlock) *pubsub.Message {
+		buf := new(bytes.Buffer)
+		_, err := p.Encoding().EncodeGossip(buf, b)
+		require.NoError(t, err)
+		topic := r.addDigestToTopic(p2p.GossipTypeMapping[reflect.TypeOf(b)], digest)
+		return &pubsub.Message{
+			Message: &pubsubpb.Message{
+				Data:  buf.Bytes(),
+				Topic: &topic,
+			},
+		}
+	}
+	r.setSeenBlock(wrapper.WrappedPhase0SignedBeaconBlock(first))
+	time.Sleep(10 * time.Millisecond) // Wait for cached value to pass through buffers.
+	res, err := r.validateBeaconBlockPubSub(ctx, "", gossipMsg(forged))
+	require.ErrorContains(t, "invalid proposer equivocation", err)
+	assert.Equal(t, pubsub.ValidationReject, res)
+
+	// Conflicting blocks are only verified once per proposer and slot.
+	res, err = r.validateBeaconBlockPubSub(ctx, "", gossipMsg(valid))
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, false, r.hasSeenProposerSlashingIndex(proposerIdx))
+}
+
 func TestValidateBeaconBlockPubSub_FilterByFinalizedEpoch(t *testing.T) {
 	hook := logTest.NewGlobal()
 	db := dbtest.SetupDB(t)
@@ -0,0 +1,158 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/monitoring/tracing"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	prysmTime "github.com/prysmaticlabs/prysm/time"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// Clients who receive a light client finality update on this topic forward it only when it is newer than the
+// previously forwarded ones and matches the update computed locally.
+//
+// Spec pseudocode definition:
+//   [IGNORE] The finalized_header.slot is greater than that of all previously forwarded finality_updates, or it
+//   matches the highest previously forwarded slot and also has a sync_aggregate indicating supermajority (> 2/3)
+//   sync committee participation while the previously forwarded finality_update for that slot did not indicate
+//   supermajority.
+//   [IGNORE] The finality_update is received after the block at signature_slot was given enough time to propagate
+//   through the network -- i.e. validate that one-third of finality_update.signature_slot has transpired
+//   (SECONDS_PER_SLOT / INTERVALS_PER_SLOT seconds after the start of the slot, with a
+//   MAXIMUM_GOSSIP_CLOCK_DISPARITY allowance).
+//   [IGNORE] The received finality_update matches the locally computed one exactly.
+func (s *Service) validateLightClientFinalityUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+
+	// The node cannot compute the updates to compare against while syncing.
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	_, span := trace.StartSpan(ctx, "sync.validateLightClientFinalityUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+
+	update, ok := m.(*ethpbv2.LightClientFinalityUpdate)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	if update.FinalizedHeader == nil || update.SyncAggregate == nil {
+		return pubsub.ValidationReject, errNilMessage
+	}
+
+	s.lightClientLock.Lock()
+	defer s.lightClientLock.Unlock()
+
+	if last := s.lastFinalityUpdate; last != nil {
+		slot, lastSlot := update.FinalizedHeader.Slot, last.FinalizedHeader.Slot
+		if slot < lastSlot {
+			return pubsub.ValidationIgnore, nil
+		}
+		if slot == lastSlot && (!hasSupermajority(update.SyncAggregate) || hasSupermajority(last.SyncAggregate)) {
+			return pubsub.ValidationIgnore, nil
+		}
+	}
+	if !s.lightClientUpdatePropagated(update.SignatureSlot) {
+		return pubsub.ValidationIgnore, nil
+	}
+	if !proto.Equal(update, s.cfg.chain.LightClientFinalityUpdate()) {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	s.lastFinalityUpdate = update
+	msg.ValidatorData = update
+	return pubsub.ValidationAccept, nil
+}
+
+// Clients who receive a light client optimistic update on this topic forward it only when it is newer than the
+// previously forwarded ones and matches the update computed locally.
+//
+// Spec pseudocode definition:
+//   [IGNORE] The attested_header.slot is greater than that of all previously forwarded optimistic_updates.
+//   [IGNORE] The optimistic_update is received after the block at signature_slot was given enough time to
+//   propagate through the network -- i.e. validate that one-third of optimistic_update.signature_slot has
+//   transpired (SECONDS_PER_SLOT / INTERVALS_PER_SLOT seconds after the start of the slot, with a
+//   MAXIMUM_GOSSIP_CLOCK_DISPARITY allowance).
+//   [IGNORE] The received optimistic_update matches the locally computed one exactly.
+func (s *Service) validateLightClientOptimisticUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+
+	// The node cannot compute the updates to compare against while syncing.
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	_, span := trace.StartSpan(ctx, "sync.validateLightClientOptimisticUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+
+	update, ok := m.(*ethpbv2.LightClientOptimisticUpdate)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	if update.AttestedHeader == nil || update.SyncAggregate == nil {
+		return pubsub.ValidationReject, errNilMessage
+	}
+
+	s.lightClientLock.Lock()
+	defer s.lightClientLock.Unlock()
+
+	if last := s.lastOptimisticUpdate; last != nil && update.AttestedHeader.Slot <= last.AttestedHeader.Slot {
+		return pubsub.ValidationIgnore, nil
+	}
+	if !s.lightClientUpdatePropagated(update.SignatureSlot) {
+		return pubsub.ValidationIgnore, nil
+	}
+	if !proto.Equal(update, s.cfg.chain.LightClientOptimisticUpdate()) {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	s.lastOptimisticUpdate = update
+	msg.ValidatorData = update
+	return pubsub.ValidationAccept, nil
+}
+
+// lightClientUpdatePropagated returns true once the first interval of the signature slot has passed, allowing for the
+// maximum gossip clock disparity.
+func (s *Service) lightClientUpdatePropagated(signatureSlot types.Slot) bool {
+	slotStart, err := slots.ToTime(uint64(s.cfg.chain.GenesisTime().Unix()), signatureSlot)
+	if err != nil {
+		return false
+	}
+	interval := params.BeaconConfig().SecondsPerSlot / params.BeaconConfig().IntervalsPerSlot
+	due := slotStart.Add(time.Duration(interval) * time.Second)
+	return !prysmTime.Now().Add(params.BeaconNetworkConfig().MaximumGossipClockDisparity).Before(due)
+}
+
+// hasSupermajority returns true when at least two thirds of the sync committee took part in the sync aggregate.
+func hasSupermajority(syncAggregate *ethpbv1.SyncAggregate) bool {
+	return syncAggregate.SyncCommitteeBits.Count()*3 >= syncAggregate.SyncCommitteeBits.Len()*2
+}
@@ -0,0 +1,170 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	ssz "github.com/ferranbt/fastssz"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func lightClientHeader(slot types.Slot) *ethpbv1.BeaconBlockHeader {
+	return &ethpbv1.BeaconBlockHeader{
+		Slot:       slot,
+		ParentRoot: make([]byte, 32),
+		StateRoot:  make([]byte, 32),
+		BodyRoot:   make([]byte, 32),
+	}
+}
+
+func lightClientSyncAggregate(participants uint64) *ethpbv1.SyncAggregate {
+	bits := bitfield.NewBitvector512()
+	for i := uint64(0); i < participants; i++ {
+		bits.SetBitAt(i, true)
+	}
+	return &ethpbv1.SyncAggregate{
+		SyncCommitteeBits:      bits,
+		SyncCommitteeSignature: make([]byte, 96),
+	}
+}
+
+func lightClientFinalityUpdate(finalizedSlot, signatureSlot types.Slot, participants uint64) *ethpbv2.LightClientFinalityUpdate {
+	branch := make([][]byte, 6)
+	for i := range branch {
+		branch[i] = make([]byte, 32)
+	}
+	return &ethpbv2.LightClientFinalityUpdate{
+		AttestedHeader:  lightClientHeader(signatureSlot - 1),
+		FinalizedHeader: lightClientHeader(finalizedSlot),
+		FinalityBranch:  branch,
+		SyncAggregate:   lightClientSyncAggregate(participants),
+		SignatureSlot:   signatureSlot,
+	}
+}
+
+func lightClientOptimisticUpdate(attestedSlot, signatureSlot types.Slot) *ethpbv2.LightClientOptimisticUpdate {
+	return &ethpbv2.LightClientOptimisticUpdate{
+		AttestedHeader: lightClientHeader(attestedSlot),
+		SyncAggregate:  lightClientSyncAggregate(512),
+		SignatureSlot:  signatureSlot,
+	}
+}
+
+func lightClientPubsubMessage(t *testing.T, s *Service, p *p2ptest.TestP2P, update ssz.Marshaler) *pubsub.Message {
+	buf := new(bytes.Buffer)
+	_, err := p.Encoding().EncodeGossip(buf, update)
+	require.NoError(t, err)
+	topic := p2p.GossipTypeMapping[reflect.TypeOf(update)]
+	d, err := s.currentForkDigest()
+	require.NoError(t, err)
+	topic = s.addDigestToTopic(topic, d)
+	return &pubsub.Message{
+		Message: &pubsubpb.Message{
+			Data:  buf.Bytes(),
+			Topic: &topic,
+		},
+	}
+}
+
+func TestValidateLightClientFinalityUpdate(t *testing.T) {
+	ctx := context.Background()
+	p := p2ptest.NewTestP2P(t)
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	chain := &mock.ChainService{Genesis: time.Now().Add(-10 * slotDuration)}
+	s := &Service{
+		cfg: &config{
+			p2p:         p,
+			chain:       chain,
+			initialSync: &mockSync.Sync{IsSyncing: false},
+		},
+	}
+
+	// The update does not match the locally computed one.
+	update := lightClientFinalityUpdate(4, 8, 300)
+	res, err := s.validateLightClientFinalityUpdate(ctx, "", lightClientPubsubMessage(t, s, p, update))
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+
+	chain.FinalityUpdate = update
+	m := lightClientPubsubMessage(t, s, p, update)
+	res, err = s.validateLightClientFinalityUpdate(ctx, "", m)
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationAccept, res)
+	assert.NotNil(t, m.ValidatorData, "Decoded message was not set on the message validator data")
+
+	// The update was already forwarded.
+	res, err = s.validateLightClientFinalityUpdate(ctx, "", lightClientPubsubMessage(t, s, p, update))
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+
+	// The same finalized header is forwarded again once signed by a supermajority.
+	update = lightClientFinalityUpdate(4, 9, 400)
+	chain.FinalityUpdate = update
+	res, err = s.validateLightClientFinalityUpdate(ctx, "", lightClientPubsubMessage(t, s, p, update))
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationAccept, res)
+
+	// The signature slot has only just started.
+	update = lightClientFinalityUpdate(5, 10, 400)
+	chain.FinalityUpdate = update
+	res, err = s.validateLightClientFinalityUpdate(ctx, "", lightClientPubsubMessage(t, s, p, update))
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+}
+
+func TestValidateLightClientOptimisticUpdate(t *testing.T) {
+	ctx := context.Background()
+	p := p2ptest.NewTestP2P(t)
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	chain := &mock.ChainService{Genesis: time.Now().Add(-10 * slotDuration)}
+	s := &Service{
+		cfg: &config{
+			p2p:         p,
+			chain:       chain,
+			initialSync: &mockSync.Sync{IsSyncing: false},
+		},
+	}
+
+	// The update does not match the locally computed one.
+	update := lightClientOptimisticUpdate(8, 9)
+	res, err := s.validateLightClientOptimisticUpdate(ctx, "", lightClientPubsubMessage(t, s, p, update))
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+
+	chain.OptimisticUpdate = update
+	m := lightClientPubsubMessage(t, s, p, update)
+	res, err = s.validateLightClientOptimisticUpdate(ctx, "", m)
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationAccept, res)
+	assert.NotNil(t, m.ValidatorData, "Decoded message was not set on the message validator data")
+
+	// The attested header is not newer than the forwarded one.
+	update = lightClientOptimisticUpdate(8, 9)
+	update.SyncAggregate = lightClientSyncAggregate(400)
+	chain.OptimisticUpdate = update
+	res, err = s.validateLightClientOptimisticUpdate(ctx, "", lightClientPubsubMessage(t, s, p, update))
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+
+	// The signature slot has only just started.
+	update = lightClientOptimisticUpdate(9, 10)
+	chain.OptimisticUpdate = update
+	res, err = s.validateLightClientOptimisticUpdate(ctx, "", lightClientPubsubMessage(t, s, p, update))
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+}
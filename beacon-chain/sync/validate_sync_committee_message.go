@@ -20,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	prysmTime "github.com/prysmaticlabs/prysm/time"
 	"go.opencensus.io/trace"
 )
 
@@ -46,6 +47,7 @@ import (
 func (s *Service) validateSyncCommitteeMessage(
 	ctx context.Context, pid peer.ID, msg *pubsub.Message,
 ) (pubsub.ValidationResult, error) {
+	receivedTime := prysmTime.Now()
 	ctx, span := trace.StartSpan(ctx, "sync.validateSyncCommitteeMessage")
 	defer span.End()
 
@@ -99,6 +101,7 @@ func (s *Service) validateSyncCommitteeMessage(
 	s.markSyncCommitteeMessagesSeen(committeeIndices, m)
 
 	msg.ValidatorData = m
+	captureGossipLatencyMetrics(p2p.GossipSyncCommitteeMessage, s.cfg.chain.GenesisTime(), m.Slot, receivedTime)
 	return pubsub.ValidationAccept, nil
 }
 
@@ -0,0 +1,112 @@
+package sync
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+)
+
+// maxConcurrentValidations is the number of gossip messages which may be validated at the same time.
+// Validations beyond this limit wait in the validation queue.
+const maxConcurrentValidations = 256
+
+// validationPriority is the priority of a gossip message in the validation queue.
+type validationPriority int
+
+const (
+	// Unaggregated attestations, sync committee messages, exits and slashings.
+	priorityLow validationPriority = iota
+	// Aggregates and sync committee contributions.
+	priorityMedium
+	// Blocks.
+	priorityHigh
+	numPriorities
+)
+
+var priorityNames = [numPriorities]string{"low", "medium", "high"}
+
+// validationQueue bounds the number of gossip messages validated concurrently. When no validation
+// slot is free, messages wait for one and are served in order of priority, so that incoming blocks
+// and aggregates are validated before the flood of unaggregated attestations.
+type validationQueue struct {
+	lock    sync.Mutex
+	free    int
+	waiting [numPriorities][]chan struct{}
+}
+
+func newValidationQueue(slots int) *validationQueue {
+	return &validationQueue{free: slots}
+}
+
+// acquire waits for a validation slot, which must be released once the validation is done.
+func (q *validationQueue) acquire(ctx context.Context, priority validationPriority) error {
+	q.lock.Lock()
+	if q.free > 0 {
+		q.free--
+		q.lock.Unlock()
+		return nil
+	}
+	ready := make(chan struct{})
+	q.waiting[priority] = append(q.waiting[priority], ready)
+	validationQueueGauge.WithLabelValues(priorityNames[priority]).Inc()
+	q.lock.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		q.lock.Lock()
+		defer q.lock.Unlock()
+		for i, c := range q.waiting[priority] {
+			if c == ready {
+				q.waiting[priority] = append(q.waiting[priority][:i], q.waiting[priority][i+1:]...)
+				validationQueueGauge.WithLabelValues(priorityNames[priority]).Dec()
+				return ctx.Err()
+			}
+		}
+		// The slot was handed over concurrently, pass it on.
+		q.releaseNoLock()
+		return ctx.Err()
+	}
+}
+
+// release a validation slot, handing it over to the waiting message of the highest priority.
+func (q *validationQueue) release() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.releaseNoLock()
+}
+
+// releaseNoLock is a lock-free version of release.
+func (q *validationQueue) releaseNoLock() {
+	for p := numPriorities - 1; p >= priorityLow; p-- {
+		if len(q.waiting[p]) == 0 {
+			continue
+		}
+		ready := q.waiting[p][0]
+		q.waiting[p] = q.waiting[p][1:]
+		validationQueueGauge.WithLabelValues(priorityNames[p]).Dec()
+		close(ready)
+		return
+	}
+	q.free++
+}
+
+// topicPriority returns the validation priority of the messages of a gossip topic, which is of the
+// form /eth2/<digest>/<message name>/<encoding>.
+func topicPriority(topic string) validationPriority {
+	parts := strings.Split(topic, "/")
+	if len(parts) < 4 {
+		return priorityLow
+	}
+	switch parts[3] {
+	case p2p.GossipBlockMessage:
+		return priorityHigh
+	case p2p.GossipAggregateAndProofMessage, p2p.GossipContributionAndProofMessage:
+		return priorityMedium
+	default:
+		return priorityLow
+	}
+}
@@ -0,0 +1,88 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestValidationQueue_PriorityOrder(t *testing.T) {
+	q := newValidationQueue(1)
+	ctx := context.Background()
+	require.NoError(t, q.acquire(ctx, priorityLow))
+
+	served := make(chan validationPriority, 3)
+	wait := func(p validationPriority) {
+		go func() {
+			if err := q.acquire(ctx, p); err == nil {
+				served <- p
+			}
+		}()
+		// Wait for the message to be queued.
+		require.NoError(t, waitFor(func() bool {
+			q.lock.Lock()
+			defer q.lock.Unlock()
+			return len(q.waiting[p]) == 1
+		}))
+	}
+	wait(priorityLow)
+	wait(priorityMedium)
+	wait(priorityHigh)
+
+	for _, want := range []validationPriority{priorityHigh, priorityMedium, priorityLow} {
+		q.release()
+		assert.Equal(t, want, <-served)
+	}
+	q.release()
+	assert.Equal(t, 1, q.free)
+}
+
+func TestValidationQueue_ContextCancelled(t *testing.T) {
+	q := newValidationQueue(1)
+	require.NoError(t, q.acquire(context.Background(), priorityHigh))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, q.acquire(ctx, priorityLow), context.DeadlineExceeded)
+	assert.Equal(t, 0, len(q.waiting[priorityLow]))
+
+	q.release()
+	assert.Equal(t, 1, q.free)
+}
+
+func TestTopicPriority(t *testing.T) {
+	digest := [4]byte{'a', 'b', 'c', 'd'}
+	suffix := "/ssz_snappy"
+	tests := []struct {
+		topic string
+		want  validationPriority
+	}{
+		{topic: fmt.Sprintf(p2p.BlockSubnetTopicFormat, digest) + suffix, want: priorityHigh},
+		{topic: fmt.Sprintf(p2p.AggregateAndProofSubnetTopicFormat, digest) + suffix, want: priorityMedium},
+		{topic: fmt.Sprintf(p2p.SyncContributionAndProofSubnetTopicFormat, digest) + suffix, want: priorityMedium},
+		{topic: fmt.Sprintf(p2p.AttestationSubnetTopicFormat, digest, 1) + suffix, want: priorityLow},
+		{topic: fmt.Sprintf(p2p.SyncCommitteeSubnetTopicFormat, digest, 1) + suffix, want: priorityLow},
+		{topic: fmt.Sprintf(p2p.ExitSubnetTopicFormat, digest) + suffix, want: priorityLow},
+		{topic: "invalid", want: priorityLow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			assert.Equal(t, tt.want, topicPriority(tt.topic))
+		})
+	}
+}
+
+func waitFor(cond func() bool) error {
+	for i := 0; i < 100; i++ {
+		if cond() {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return fmt.Errorf("condition not met")
+}
@@ -19,6 +19,7 @@ func FlagOptions(c *cli.Context) ([]blockchain.Option, error) {
 	opts := []blockchain.Option{
 		blockchain.WithMaxGoroutines(maxRoutines),
 		blockchain.WithWeakSubjectivityCheckpoint(wsCheckpt),
+		blockchain.WithCheckpointStateCacheSize(c.Int(flags.CheckpointStateCacheSize.Name)),
 	}
 	return opts, nil
 }
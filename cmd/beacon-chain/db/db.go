@@ -3,6 +3,7 @@ package db
 import (
 	beacondb "github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/runtime/tos"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
@@ -31,5 +32,94 @@ var Commands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name:        "migrate-backend",
+			Description: `converts the database of the data directory to the key-value backend set with --db-backend`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.DBBackendFlag,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := beacondb.MigrateBackend(cliCtx); err != nil {
+					log.Fatalf("Could not migrate database: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "migrations",
+			Description: `lists the schema migrations of the database and whether they are applied`,
+			Flags:       cmd.WrapFlags(append([]cli.Flag{cmd.DataDirFlag, cmd.DBBackendFlag, cmd.DBReadOnlyFlag}, features.BeaconChainFlags...)),
+			Before:      tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := beacondb.ListMigrations(cliCtx); err != nil {
+					log.Fatalf("Could not list migrations: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "migrate",
+			Description: `applies the pending schema migrations of the database, or reports them with --dry-run`,
+			Flags:       cmd.WrapFlags(append([]cli.Flag{cmd.DataDirFlag, cmd.DBBackendFlag, cmd.DBMigrationDryRunFlag}, features.BeaconChainFlags...)),
+			Before:      tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := beacondb.ApplyMigrations(cliCtx); err != nil {
+					log.Fatalf("Could not migrate database: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "rollback-migrations",
+			Description: `rolls back the schema migrations of the database above --target-version, to downgrade the beacon node`,
+			Flags:       cmd.WrapFlags(append([]cli.Flag{cmd.DataDirFlag, cmd.DBBackendFlag, cmd.DBMigrationTargetVersionFlag}, features.BeaconChainFlags...)),
+			Before:      tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := beacondb.RollbackMigrations(cliCtx); err != nil {
+					log.Fatalf("Could not roll back migrations: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name: "verify",
+			Description: `verifies the integrity of the database, checking the parents of the blocks and the states of ` +
+				`the checkpoints, and reports the orphaned entries, which --repair deletes`,
+			Flags:  cmd.WrapFlags(append([]cli.Flag{cmd.DataDirFlag, cmd.DBBackendFlag, cmd.DBReadOnlyFlag, cmd.DBRepairFlag}, features.BeaconChainFlags...)),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := beacondb.VerifyDatabase(cliCtx); err != nil {
+					log.Fatalf("Could not verify database: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "export-era",
+			Description: `exports the finalized history of the database to era files, one per era of SLOTS_PER_HISTORICAL_ROOT slots`,
+			Flags:       cmd.WrapFlags(append([]cli.Flag{cmd.EraFromFlag, cmd.EraToFlag, cmd.DBReadOnlyFlag}, eraFlags...)),
+			Before:      tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := exportEra(cliCtx); err != nil {
+					log.Fatalf("Could not export era files: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name: "import-era",
+			Description: `imports the blocks of era files below the origin of a database initialized from a checkpoint, ` +
+				`instead of backfilling them from peers`,
+			Flags:  cmd.WrapFlags(eraFlags),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := importEra(cliCtx); err != nil {
+					log.Fatalf("Could not import era files: %v", err)
+				}
+				return nil
+			},
+		},
 	},
 }
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/era"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/urfave/cli/v2"
+)
+
+var eraFlags = []cli.Flag{
+	cmd.DataDirFlag,
+	cmd.DBBackendFlag,
+	cmd.ChainConfigFileFlag,
+	cmd.EraDirFlag,
+	features.PraterTestnet,
+	features.Mainnet,
+}
+
+// openDB configures the network of the beacon node and opens its database.
+func openDB(cliCtx *cli.Context) (*kv.Store, string, error) {
+	features.ConfigureBeaconChain(cliCtx)
+	if cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		params.LoadChainConfigFile(cliCtx.String(cmd.ChainConfigFileFlag.Name))
+	}
+	eraDir := cliCtx.String(cmd.EraDirFlag.Name)
+	if eraDir == "" {
+		return nil, "", errors.Errorf("--%s is required", cmd.EraDirFlag.Name)
+	}
+	kind, err := backend.ParseKind(cliCtx.String(cmd.DBBackendFlag.Name))
+	if err != nil {
+		return nil, "", err
+	}
+	dir := path.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	store, err := kv.NewKVStore(cliCtx.Context, dir, &kv.Config{Backend: kind, ReadOnly: cliCtx.Bool(cmd.DBReadOnlyFlag.Name)})
+	if err != nil {
+		return nil, "", errors.Wrap(err, "could not open database")
+	}
+	return store, eraDir, nil
+}
+
+// exportEra writes the finalized history of the database to era files.
+func exportEra(cliCtx *cli.Context) error {
+	store, eraDir, err := openDB(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	paths, err := era.Export(cliCtx.Context, store, eraDir, cliCtx.Uint64(cmd.EraFromFlag.Name), cliCtx.Uint64(cmd.EraToFlag.Name))
+	if err != nil {
+		return err
+	}
+	log.WithField("files", len(paths)).Info("Exported era files")
+	return nil
+}
+
+// importEra fills in the history of a database initialized from a checkpoint from era files.
+func importEra(cliCtx *cli.Context) error {
+	store, eraDir, err := openDB(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	count, err := era.Import(cliCtx.Context, store, eraDir)
+	if err != nil {
+		return err
+	}
+	log.WithField("blocks", count).Info("Imported era files")
+	return nil
+}
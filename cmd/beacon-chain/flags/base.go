@@ -5,11 +5,20 @@ package flags
 import (
 	"encoding/hex"
 	"strings"
+	"time"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	enginev1 "github.com/prysmaticlabs/prysm/beacon-chain/powchain/engine-api-client/v1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/urfave/cli/v2"
 )
 
+// DefaultGRPCKeepaliveMinTime is the default minimum duration gRPC clients must wait between keepalive pings.
+// The RPC service package depends on this package, so it is defined here rather than next to the server options.
+const DefaultGRPCKeepaliveMinTime = 5 * time.Minute
+
 var (
 	// HTTPWeb3ProviderFlag provides an HTTP access endpoint to an ETH 1.0 RPC.
 	HTTPWeb3ProviderFlag = &cli.StringFlag{
@@ -20,13 +29,89 @@ var (
 	// ExecutionProvider provides an HTTP or IPC access endpoint to an ETH execution node.
 	ExecutionProviderFlag = &cli.StringFlag{
 		Name:  "execution-provider",
-		Usage: "An http endpoint for an Ethereum execution node",
+		Usage: "An http(s), ws(s) or IPC endpoint for an Ethereum execution node",
+		Value: "",
+	}
+	// ExecutionJWTSecretFlag provides a path to a file containing a hex-encoded string representing a 32 byte secret
+	// used to authenticate with an execution node via HTTP. This is required if using an HTTP connection, otherwise all requests
+	// to execution nodes for consensus-related calls will fail. This is not required if using an IPC connection.
+	ExecutionJWTSecretFlag = &cli.StringFlag{
+		Name: "jwt-secret",
+		Usage: "REQUIRED if connecting to an execution node via HTTP. Provides a path to a file containing " +
+			"a hex-encoded string representing a 32 byte secret used for authentication with an execution node via " +
+			"HTTP. If this is not set, all requests to execution nodes via HTTP for consensus-related calls will fail, which " +
+			"will prevent your validators from performing their duties. " +
+			"This is not required if using an IPC connection.",
 		Value: "",
 	}
+	// ExecutionHeadersFlag provides custom HTTP headers attached to every request sent to the execution node
+	// and the eth1 endpoints.
+	ExecutionHeadersFlag = &cli.StringFlag{
+		Name: "execution-headers",
+		Usage: "A comma separated list of key=value HTTP headers attached to every request sent to the execution " +
+			"node and the eth1 endpoints, such as bearer tokens or routing headers required by an API gateway in front of it, " +
+			"e.g. --execution-headers=\"Authorization=Bearer xxx,X-Route=node-1\"",
+	}
+	// FallbackExecutionProviderFlag provides fallback endpoints to ETH execution nodes.
+	FallbackExecutionProviderFlag = &cli.StringSliceFlag{
+		Name:  "fallback-execution-provider",
+		Usage: "An http or IPC endpoint for an Ethereum execution node to fail over to when the primary execution provider is unavailable, this flag may be used multiple times.",
+	}
+	// ExecutionEthProviderFlag provides an endpoint serving read-only eth namespace requests instead of the execution node.
+	ExecutionEthProviderFlag = &cli.StringFlag{
+		Name: "execution-eth-provider",
+		Usage: "An http(s), ws(s) or IPC endpoint, such as a hosted provider, serving the read-only eth_ requests " +
+			"otherwise sent to --execution-provider, like execution block lookups. Engine API requests are always " +
+			"sent to --execution-provider, and the JWT secret is never sent to this endpoint",
+	}
+	// ExecutionTLSCACertFlag provides a path to a PEM encoded certificate authority bundle used to verify
+	// the certificates of https and wss execution endpoints.
+	ExecutionTLSCACertFlag = &cli.StringFlag{
+		Name: "execution-tls-ca-cert",
+		Usage: "Path to a PEM encoded certificate authority bundle used to verify the certificates of " +
+			"https and wss execution endpoints, in addition to the system certificate authorities",
+	}
+	// ExecutionTLSClientCertFlag provides a path to a PEM encoded client certificate presented to execution endpoints.
+	ExecutionTLSClientCertFlag = &cli.StringFlag{
+		Name: "execution-tls-client-cert",
+		Usage: "Path to a PEM encoded client certificate presented to https and wss execution endpoints " +
+			"requiring mutual TLS authentication. Requires --execution-tls-client-key",
+	}
+	// ExecutionTLSClientKeyFlag provides a path to the PEM encoded private key of the execution client certificate.
+	ExecutionTLSClientKeyFlag = &cli.StringFlag{
+		Name:  "execution-tls-client-key",
+		Usage: "Path to the PEM encoded private key of the client certificate set with --execution-tls-client-cert",
+	}
+	// EngineAPISlowCallThresholdFlag specifies the latency above which engine API calls are reported as slow.
+	EngineAPISlowCallThresholdFlag = &cli.DurationFlag{
+		Name: "engine-api-slow-call-threshold",
+		Usage: "Latency above which newPayload and forkchoiceUpdated calls to the execution node are logged " +
+			"as slow, as they delay the import and proposal of blocks",
+		Value: enginev1.DefaultSlowCallThreshold,
+	}
+	// EngineAPIRateLimitFlag specifies the number of non-critical engine API requests per second.
+	EngineAPIRateLimitFlag = &cli.IntFlag{
+		Name: "engine-api-rate-limit",
+		Usage: "Maximum number of non-critical requests per second, such as execution block and payload body " +
+			"fetches, sent to the execution node so they do not delay block processing. 0 disables the limit",
+		Value: enginev1.DefaultRateLimit,
+	}
+	// EngineAPIDebugFlag enables logging the requests and responses of engine API calls.
+	EngineAPIDebugFlag = &cli.BoolFlag{
+		Name: "engine-api-debug",
+		Usage: "Logs the JSON-RPC request and response of every engine API call to the execution node, " +
+			"to help diagnose interoperability issues. Not recommended outside of test networks due to the log volume",
+	}
+	// EngineAPIDebugMaxTransactionsFlag specifies the number of payload transactions included in engine API debug logs.
+	EngineAPIDebugMaxTransactionsFlag = &cli.IntFlag{
+		Name:  "engine-api-debug-max-txs",
+		Usage: "Maximum number of transactions of a payload logged with --engine-api-debug, the rest are omitted. 0 logs all transactions",
+		Value: enginev1.DefaultDebugMaxTransactions,
+	}
 	// FallbackWeb3ProviderFlag provides a fallback endpoint to an ETH 1.0 RPC.
 	FallbackWeb3ProviderFlag = &cli.StringSliceFlag{
 		Name:  "fallback-web3provider",
-		Usage: "A mainchain web3 provider string http endpoint. This is our fallback web3 provider, this flag may be used multiple times.",
+		Usage: "A mainchain web3 provider string http endpoint. This is our fallback web3 provider, this flag may be used multiple times. Providers are scored on their error rate and head lag, and the beacon node rotates away from unhealthy ones automatically.",
 	}
 	// DepositContractFlag defines a flag for the deposit contract address.
 	DepositContractFlag = &cli.StringFlag{
@@ -62,6 +147,21 @@ var (
 		Name:  "tls-key",
 		Usage: "Key for secure gRPC. Pass this and the tls-cert flag in order to use gRPC securely.",
 	}
+	// ClientCAFlag defines a flag for the certificate authority of the gRPC clients.
+	ClientCAFlag = &cli.StringFlag{
+		Name: "tls-client-ca",
+		Usage: "Certificate authority bundle used to verify the certificates of gRPC clients. Requires the tls-cert and " +
+			"tls-key flags, and rejects clients without a certificate signed by one of these authorities. The gRPC gateway " +
+			"presents the grpc-gateway-tls-client-cert certificate to the gRPC server, or the tls-cert certificate when it is " +
+			"not set, so that certificate must be signed by one of these authorities and allow client authentication.",
+	}
+	// GRPCKeepaliveMinTimeFlag defines the minimum interval between the keepalive pings of a gRPC client.
+	GRPCKeepaliveMinTimeFlag = &cli.DurationFlag{
+		Name: "grpc-keepalive-min-time",
+		Usage: "Minimum duration gRPC clients must wait between keepalive pings. Connections of clients pinging more " +
+			"often are closed, so this must not be greater than the grpc-keepalive-time of the clients",
+		Value: DefaultGRPCKeepaliveMinTime,
+	}
 	// HTTPModules define the set of enabled HTTP APIs.
 	HTTPModules = &cli.StringFlag{
 		Name:  "http-modules",
@@ -92,6 +192,29 @@ var (
 			"(browser enforced). This flag has no effect if not used with --grpc-gateway-port.",
 		Value: "http://localhost:4200,http://localhost:7500,http://127.0.0.1:4200,http://127.0.0.1:7500,http://0.0.0.0:4200,http://0.0.0.0:7500,http://localhost:3000,http://0.0.0.0:3000,http://127.0.0.1:3000",
 	}
+	// GRPCGatewayAuthConfig specifies the file listing the bearer tokens accepted by the gRPC gateway.
+	GRPCGatewayAuthConfig = &cli.StringFlag{
+		Name: "grpc-gateway-auth-config",
+		Usage: "Path to a YAML file listing the bearer tokens accepted by the gRPC gateway, along with their rate limits " +
+			"and the routes they can access. Requests without a valid token are rejected when set.",
+	}
+	// GRPCGatewayClientCertFlag defines a flag for the certificate the gRPC gateway presents to the gRPC server.
+	GRPCGatewayClientCertFlag = &cli.StringFlag{
+		Name: "grpc-gateway-tls-client-cert",
+		Usage: "Certificate the gRPC gateway presents to the gRPC server when the tls-client-ca flag is set. It must carry " +
+			"the clientAuth extended key usage. Defaults to the tls-cert certificate.",
+	}
+	// GRPCGatewayClientKeyFlag defines a flag for the key of the gRPC gateway client certificate.
+	GRPCGatewayClientKeyFlag = &cli.StringFlag{
+		Name:  "grpc-gateway-tls-client-key",
+		Usage: "Key of the grpc-gateway-tls-client-cert certificate. Defaults to the tls-key key.",
+	}
+	// EnableGraphQL serves a read-only GraphQL API of beacon data on the gRPC gateway.
+	EnableGraphQL = &cli.BoolFlag{
+		Name: "enable-graphql",
+		Usage: "Serves a read-only GraphQL API of blocks, validators, committees and attestations at /graphql " +
+			"on the gRPC gateway, letting clients query the fields they need.",
+	}
 	// MinSyncPeers specifies the required number of successful peer handshakes in order
 	// to start syncing with external peers.
 	MinSyncPeers = &cli.IntFlag{
@@ -127,8 +250,9 @@ var (
 	// SlotsPerArchivedPoint specifies the number of slots between the archived points, to save beacon state in the cold
 	// section of beaconDB.
 	SlotsPerArchivedPoint = &cli.IntFlag{
-		Name:  "slots-per-archive-point",
-		Usage: "The slot durations of when an archived state gets saved in the beaconDB.",
+		Name: "slots-per-archive-point",
+		Usage: "The slot durations of when an archived state gets saved in the beaconDB. Changing it re-spaces " +
+			"the archived states already saved on the next start, which may take a while on archival nodes.",
 		Value: 2048,
 	}
 	// DisableDiscv5 disables running discv5.
@@ -136,6 +260,12 @@ var (
 		Name:  "disable-discv5",
 		Usage: "Does not run the discoveryV5 dht.",
 	}
+	// GossipScoringConfig specifies the file overriding the gossipsub peer scoring parameters.
+	GossipScoringConfig = &cli.StringFlag{
+		Name: "gossip-scoring-config",
+		Usage: "Path to a YAML file overriding the gossipsub peer scoring weights, thresholds and decays. " +
+			"Parameters not set in the file keep their default value.",
+	}
 	// BlockBatchLimit specifies the requested block batch size.
 	BlockBatchLimit = &cli.IntFlag{
 		Name:  "block-batch-limit",
@@ -148,6 +278,25 @@ var (
 		Usage: "The factor by which block batch limit may increase on burst.",
 		Value: 10,
 	}
+	// RPCRateLimit specifies the number of req/resp requests per second a peer may make.
+	RPCRateLimit = &cli.IntFlag{
+		Name:  "rpc-rate-limit",
+		Usage: "The number of req/resp requests per second a single peer may make across all topics.",
+		Value: DefaultRPCRateLimit,
+	}
+	// RPCRateLimitBurst specifies the number of req/resp requests a peer may make on burst.
+	RPCRateLimitBurst = &cli.IntFlag{
+		Name:  "rpc-rate-limit-burst",
+		Usage: "The number of req/resp requests a single peer may make on burst across all topics.",
+		Value: DefaultRPCRateLimitBurst,
+	}
+	// RPCTopicRateLimits specifies the rate limits of individual req/resp topics.
+	RPCTopicRateLimits = &cli.StringSliceFlag{
+		Name: "rpc-topic-rate-limit",
+		Usage: "Rate limit of a req/resp topic for a single peer, of the form <topic>=<requests per second>:<burst>. " +
+			"Supported topics are goodbye, metadata, ping and status. Block requests are limited by --block-batch-limit. " +
+			"May be used multiple times, e.g. --rpc-topic-rate-limit=status=2:10 --rpc-topic-rate-limit=ping=1:5",
+	}
 	// DisableSync disables a node from syncing at start-up. Instead the node enters regular sync
 	// immediately.
 	DisableSync = &cli.BoolFlag{
@@ -159,6 +308,11 @@ var (
 		Name:  "enable-debug-rpc-endpoints",
 		Usage: "Enables the debug rpc service, containing utility endpoints such as /eth/v1alpha1/beacon/state.",
 	}
+	// EnablePeerManagementRPCEndpoints enables the rpc endpoints managing the static, trusted and banned peers.
+	EnablePeerManagementRPCEndpoints = &cli.BoolFlag{
+		Name:  "enable-peer-management-rpc-endpoints",
+		Usage: "Enables the rpc endpoints which manage the static, trusted and banned peers of the node at runtime, such as /eth/v1alpha1/node/static_peers.",
+	}
 	// SubscribeToAllSubnets defines a flag to specify whether to subscribe to all possible attestation/sync subnets or not.
 	SubscribeToAllSubnets = &cli.BoolFlag{
 		Name:  "subscribe-all-subnets",
@@ -186,6 +340,12 @@ var (
 			"If such a sync is not possible, the node will treat it a critical and irrecoverable failure",
 		Value: "",
 	}
+	// IgnoreWeakSubjectivityPeriod allows the node to start from a finalized state older than the weak subjectivity period.
+	IgnoreWeakSubjectivityPeriod = &cli.BoolFlag{
+		Name: "ignore-weak-subjectivity-period",
+		Usage: "Allows the node to start syncing from a finalized state which is older than the weak subjectivity period, " +
+			"without a more recent --weak-subjectivity-checkpoint to sync through. Such a chain may be the result of a long range attack",
+	}
 	// Eth1HeaderReqLimit defines a flag to set the maximum number of headers that a deposit log query can fetch. If none is set, 1000 will be the limit.
 	Eth1HeaderReqLimit = &cli.Uint64Flag{
 		Name:  "eth1-header-req-limit",
@@ -198,6 +358,87 @@ var (
 		Usage: "Load a genesis state from ssz file. Testnet genesis files can be found in the " +
 			"eth2-clients/eth2-testnets repository on github.",
 	}
+	// CheckpointSyncURLFlag defines a flag for the URL of a trusted beacon node to checkpoint sync from.
+	CheckpointSyncURLFlag = &cli.StringFlag{
+		Name: "checkpoint-sync-url",
+		Usage: "URL of a trusted beacon node serving the Beacon API. On an empty database, the finalized " +
+			"state and block of that node are downloaded at startup and the beacon node syncs from them " +
+			"instead of from genesis. The genesis state must still be known, see --genesis-state.",
+	}
+	// BackfillRetentionEpochsFlag defines a flag for how far back the history below a checkpoint sync origin is filled in.
+	BackfillRetentionEpochsFlag = &cli.Uint64Flag{
+		Name: "backfill-retention-epochs",
+		Usage: "Number of epochs of blocks, counted back from the current epoch, to fill in from peers in the " +
+			"background after starting from a checkpoint. The default of 0 fills in the history back to genesis.",
+	}
+	// PruneRetentionEpochsFlag defines a flag for how many finalized epochs of blocks and states are kept in the database.
+	PruneRetentionEpochsFlag = &cli.Uint64Flag{
+		Name: "prune-retention-epochs",
+		Usage: "Number of finalized epochs of blocks and states, counted back from the finalized checkpoint, to keep in " +
+			"the database. Older data is pruned once per epoch, except for the first finalized block and state of " +
+			"each era of SLOTS_PER_HISTORICAL_ROOT slots. The default of 0 disables pruning.",
+	}
+	// PruneDryRunFlag defines a flag to only report what pruning would delete.
+	PruneDryRunFlag = &cli.BoolFlag{
+		Name:  "prune-dry-run",
+		Usage: "Log the number of objects and bytes pruning would delete from the database without deleting them.",
+	}
+	// DBCompactionIntervalFlag defines a flag for the period of the scheduled database compactions.
+	DBCompactionIntervalFlag = &cli.DurationFlag{
+		Name: "db-compaction-interval",
+		Usage: "Period at which the database is compacted to reclaim the disk space of deleted data, when at least " +
+			"--db-compaction-min-free-ratio of it is free. A bolt database is compacted into a copy, which needs " +
+			"free disk space for the size of its data. The default of 0 disables scheduled compactions.",
+	}
+	// EnableDBCompactionWebhookFlag defines a flag to trigger database compactions via an HTTP webhook.
+	EnableDBCompactionWebhookFlag = &cli.BoolFlag{
+		Name: "enable-db-compaction-webhook",
+		Usage: "Serve an HTTP handler to compact the database with a POST request. The handler is served on the " +
+			"monitoring port at path /db/compact.",
+	}
+	// DBCompactionMinFreeRatioFlag defines a flag for the ratio of free space triggering a scheduled compaction.
+	DBCompactionMinFreeRatioFlag = &cli.Float64Flag{
+		Name:  "db-compaction-min-free-ratio",
+		Usage: "Minimum ratio of free space to the size of the database for a scheduled compaction to run.",
+		Value: kv.DefaultCompactionMinFreeRatio,
+	}
+	// BlindedBlockStorageFlag defines a flag to save finalized blocks without their execution payload transactions.
+	BlindedBlockStorageFlag = &cli.BoolFlag{
+		Name: "blinded-block-storage",
+		Usage: "Save finalized post-merge blocks without the transactions of their execution payload, which are " +
+			"fetched back from the execution node with engine_getPayloadBodiesByHashV1 when the blocks are read. " +
+			"This greatly reduces the growth of the database of non-archival nodes. The blocks are read without " +
+			"their transactions while the execution node cannot provide them.",
+	}
+	// CheckpointStateCacheSize defines a flag for the number of checkpoint states cached.
+	CheckpointStateCacheSize = &cli.IntFlag{
+		Name: "checkpoint-state-cache-size",
+		Usage: "Maximum number of checkpoint states, used to verify attestations, kept in memory. The least " +
+			"recently used state is evicted when full. A larger cache avoids regenerating states when many " +
+			"forks are seen, at the cost of the memory of a beacon state per entry.",
+		Value: cache.DefaultCheckpointStateCacheSize,
+	}
+	// HotStateCacheSize defines a flag for the number of hot states cached.
+	HotStateCacheSize = &cli.IntFlag{
+		Name: "hot-state-cache-size",
+		Usage: "Maximum number of states above the finalized checkpoint kept in memory. Raising it lets more " +
+			"API queries and forks be served without replaying blocks, but every cached state uses memory.",
+		Value: stategen.DefaultHotStateCacheSize,
+	}
+	// SaveHotStateInterval defines a flag for the number of slots between the hot states saved to the database.
+	SaveHotStateInterval = &cli.Uint64Flag{
+		Name: "save-hot-state-interval",
+		Usage: "Number of slots between the states saved to the database during long periods of non-finality. " +
+			"A shorter interval bounds the blocks replayed to regenerate a state, at the cost of disk space.",
+		Value: uint64(stategen.DefaultHotStateDBInterval),
+	}
+	// VerifyDBFlag defines a flag to verify the integrity of the database at startup.
+	VerifyDBFlag = &cli.BoolFlag{
+		Name: "verify-db",
+		Usage: "Verify the integrity of the database at startup, logging the blocks whose parent is missing and the " +
+			"checkpoint states which do not match their block, and deleting orphaned states, state summaries and " +
+			"index entries. This walks every block of the database, which delays the startup of archival nodes.",
+	}
 	// MinPeersPerSubnet defines a flag to set the minimum number of peers that a node will attempt to peer with for a subnet.
 	MinPeersPerSubnet = &cli.Uint64Flag{
 		Name:  "minimum-peers-per-subnet",
@@ -210,4 +451,33 @@ var (
 		Usage: "Post bellatrix, this address will receive the transaction fees produced by any blocks from this node. Default to junk whilst bellatrix is in development state.",
 		Value: hex.EncodeToString([]byte("0x0000000000000000000000000000000000000001")),
 	}
+	// TerminalTotalDifficultyOverride specifies the terminal total difficulty of the merge, overriding the network configuration.
+	TerminalTotalDifficultyOverride = &cli.StringFlag{
+		Name: "terminal-total-difficulty-override",
+		Usage: "Sets the total difficulty, as a decimal number, which the terminal proof-of-work block must reach, " +
+			"overriding the value of the network configuration. Only use this if instructed to during an emergency",
+	}
+	// TerminalBlockHashOverride specifies the hash of the terminal proof-of-work block, overriding the network configuration.
+	TerminalBlockHashOverride = &cli.StringFlag{
+		Name: "terminal-block-hash-override",
+		Usage: "Sets the hash of the terminal proof-of-work block, overriding the terminal total difficulty. " +
+			"Must be set along with --terminal-block-hash-epoch-override. Only use this if instructed to during an emergency",
+	}
+	// TerminalBlockHashActivationEpochOverride specifies the epoch from which the terminal block hash override applies.
+	TerminalBlockHashActivationEpochOverride = &cli.Uint64Flag{
+		Name:  "terminal-block-hash-epoch-override",
+		Usage: "Sets the epoch from which the terminal block hash set with --terminal-block-hash-override applies",
+	}
+	// PayloadPreparationLeadTimeFlag specifies how long before a proposal the execution node starts building its payload.
+	PayloadPreparationLeadTimeFlag = &cli.DurationFlag{
+		Name: "payload-preparation-lead-time",
+		Usage: "How long before the start of a slot in which a validator set with --payload-preparation-indices proposes, " +
+			"the execution node is requested to start building the payload. At most one slot, which is the default",
+	}
+	// PayloadPreparationIndicesFlag specifies the validators whose proposals are prepared ahead of time.
+	PayloadPreparationIndicesFlag = &cli.IntSliceFlag{
+		Name: "payload-preparation-indices",
+		Usage: "List of validator indices whose proposals the execution node is requested to start building ahead of " +
+			"time, paying the fees to the fee recipient of the node. Proposals of other validators are prepared on demand",
+	}
 )
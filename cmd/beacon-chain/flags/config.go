@@ -1,10 +1,21 @@
 package flags
 
 import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/cmd"
 	"github.com/urfave/cli/v2"
 )
 
+const (
+	// DefaultRPCRateLimit is the default number of req/resp requests per second a peer may make.
+	DefaultRPCRateLimit = 5
+	// DefaultRPCRateLimitBurst is the default number of req/resp requests a peer may make on burst.
+	DefaultRPCRateLimitBurst = 10
+)
+
 // GlobalFlags specifies all the global flags for the
 // beacon node.
 type GlobalFlags struct {
@@ -16,8 +27,20 @@ type GlobalFlags struct {
 	MinimumPeersPerSubnet      int
 	BlockBatchLimit            int
 	BlockBatchLimitBurstFactor int
+	RPCRateLimit               int
+	RPCRateLimitBurst          int
+	RPCTopicRateLimits         map[string]RateLimit
 }
 
+// RateLimit is the rate limit of a req/resp topic for a single peer.
+type RateLimit struct {
+	PerSecond float64
+	Burst     int64
+}
+
+// rateLimitedTopics are the req/resp topics whose rate limit may be set with the rpc-topic-rate-limit flag.
+var rateLimitedTopics = []string{"goodbye", "metadata", "ping", "status"}
+
 var globalConfig *GlobalFlags
 
 // Get retrieves the global config.
@@ -35,7 +58,7 @@ func Init(c *GlobalFlags) {
 
 // ConfigureGlobalFlags initializes the global config.
 // based on the provided cli context.
-func ConfigureGlobalFlags(ctx *cli.Context) {
+func ConfigureGlobalFlags(ctx *cli.Context) error {
 	cfg := &GlobalFlags{}
 	if ctx.Bool(HeadSync.Name) {
 		log.Warn("Using Head Sync flag, it starts syncing from last saved head.")
@@ -52,10 +75,56 @@ func ConfigureGlobalFlags(ctx *cli.Context) {
 	cfg.DisableDiscv5 = ctx.Bool(DisableDiscv5.Name)
 	cfg.BlockBatchLimit = ctx.Int(BlockBatchLimit.Name)
 	cfg.BlockBatchLimitBurstFactor = ctx.Int(BlockBatchLimitBurstFactor.Name)
+	cfg.RPCRateLimit = ctx.Int(RPCRateLimit.Name)
+	cfg.RPCRateLimitBurst = ctx.Int(RPCRateLimitBurst.Name)
+	topicLimits, err := parseTopicRateLimits(ctx.StringSlice(RPCTopicRateLimits.Name))
+	if err != nil {
+		return err
+	}
+	cfg.RPCTopicRateLimits = topicLimits
 	cfg.MinimumPeersPerSubnet = ctx.Int(MinPeersPerSubnet.Name)
 	configureMinimumPeers(ctx, cfg)
 
 	Init(cfg)
+	return nil
+}
+
+// parseTopicRateLimits parses topic rate limits of the form <topic>=<requests per second>:<burst>.
+func parseTopicRateLimits(values []string) (map[string]RateLimit, error) {
+	limits := make(map[string]RateLimit, len(values))
+	for _, v := range values {
+		parts := strings.Split(v, "=")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid topic rate limit %q, expected <topic>=<requests per second>:<burst>", v)
+		}
+		topic := parts[0]
+		if !isRateLimitedTopic(topic) {
+			return nil, errors.Errorf("invalid topic rate limit %q, topic must be one of %s", v, strings.Join(rateLimitedTopics, ", "))
+		}
+		quota := strings.Split(parts[1], ":")
+		if len(quota) != 2 {
+			return nil, errors.Errorf("invalid topic rate limit %q, expected <topic>=<requests per second>:<burst>", v)
+		}
+		perSecond, err := strconv.ParseFloat(quota[0], 64)
+		if err != nil || perSecond <= 0 {
+			return nil, errors.Errorf("invalid topic rate limit %q, requests per second must be a positive number", v)
+		}
+		burst, err := strconv.ParseInt(quota[1], 10, 64)
+		if err != nil || burst <= 0 {
+			return nil, errors.Errorf("invalid topic rate limit %q, burst must be a positive integer", v)
+		}
+		limits[topic] = RateLimit{PerSecond: perSecond, Burst: burst}
+	}
+	return limits, nil
+}
+
+func isRateLimitedTopic(topic string) bool {
+	for _, t := range rateLimitedTopics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
 }
 
 func configureMinimumPeers(ctx *cli.Context, cfg *GlobalFlags) {
@@ -0,0 +1,67 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestParseTopicRateLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]RateLimit
+		wantErr string
+	}{
+		{
+			name:   "no limits",
+			values: nil,
+			want:   map[string]RateLimit{},
+		},
+		{
+			name:   "multiple topics",
+			values: []string{"status=2:10", "ping=0.5:3"},
+			want: map[string]RateLimit{
+				"status": {PerSecond: 2, Burst: 10},
+				"ping":   {PerSecond: 0.5, Burst: 3},
+			},
+		},
+		{
+			name:    "unknown topic",
+			values:  []string{"beacon_blocks_by_range=2:10"},
+			wantErr: "topic must be one of",
+		},
+		{
+			name:    "missing burst",
+			values:  []string{"status=2"},
+			wantErr: "expected <topic>=<requests per second>:<burst>",
+		},
+		{
+			name:    "missing quota",
+			values:  []string{"status"},
+			wantErr: "expected <topic>=<requests per second>:<burst>",
+		},
+		{
+			name:    "zero rate",
+			values:  []string{"status=0:10"},
+			wantErr: "requests per second must be a positive number",
+		},
+		{
+			name:    "invalid burst",
+			values:  []string{"status=1:1.5"},
+			wantErr: "burst must be a positive integer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTopicRateLimits(tt.values)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.DeepEqual(t, tt.want, got)
+		})
+	}
+}
@@ -34,39 +34,79 @@ var appFlags = []cli.Flag{
 	flags.DepositContractFlag,
 	flags.HTTPWeb3ProviderFlag,
 	flags.ExecutionProviderFlag,
+	flags.ExecutionJWTSecretFlag,
+	flags.ExecutionHeadersFlag,
+	flags.FallbackExecutionProviderFlag,
+	flags.ExecutionEthProviderFlag,
+	flags.ExecutionTLSCACertFlag,
+	flags.ExecutionTLSClientCertFlag,
+	flags.ExecutionTLSClientKeyFlag,
+	flags.EngineAPISlowCallThresholdFlag,
+	flags.EngineAPIRateLimitFlag,
+	flags.EngineAPIDebugFlag,
+	flags.EngineAPIDebugMaxTransactionsFlag,
 	flags.FallbackWeb3ProviderFlag,
 	flags.RPCHost,
 	flags.RPCPort,
 	flags.CertFlag,
 	flags.KeyFlag,
+	flags.ClientCAFlag,
+	flags.GRPCKeepaliveMinTimeFlag,
 	flags.HTTPModules,
 	flags.DisableGRPCGateway,
 	flags.GRPCGatewayHost,
 	flags.GRPCGatewayPort,
 	flags.GPRCGatewayCorsDomain,
+	flags.GRPCGatewayAuthConfig,
+	flags.GRPCGatewayClientCertFlag,
+	flags.GRPCGatewayClientKeyFlag,
+	flags.EnableGraphQL,
 	flags.MinSyncPeers,
 	flags.ContractDeploymentBlock,
 	flags.SetGCPercent,
 	flags.HeadSync,
 	flags.DisableSync,
 	flags.DisableDiscv5,
+	flags.GossipScoringConfig,
 	flags.BlockBatchLimit,
 	flags.BlockBatchLimitBurstFactor,
+	flags.RPCRateLimit,
+	flags.RPCRateLimitBurst,
+	flags.RPCTopicRateLimits,
 	flags.InteropMockEth1DataVotesFlag,
 	flags.InteropGenesisStateFlag,
 	flags.InteropNumValidatorsFlag,
 	flags.InteropGenesisTimeFlag,
 	flags.SlotsPerArchivedPoint,
 	flags.EnableDebugRPCEndpoints,
+	flags.EnablePeerManagementRPCEndpoints,
 	flags.SubscribeToAllSubnets,
 	flags.HistoricalSlasherNode,
 	flags.ChainID,
 	flags.NetworkID,
 	flags.WeakSubjectivityCheckpt,
+	flags.IgnoreWeakSubjectivityPeriod,
 	flags.Eth1HeaderReqLimit,
 	flags.GenesisStatePath,
+	flags.CheckpointSyncURLFlag,
+	flags.BackfillRetentionEpochsFlag,
+	flags.PruneRetentionEpochsFlag,
+	flags.PruneDryRunFlag,
+	flags.DBCompactionIntervalFlag,
+	flags.DBCompactionMinFreeRatioFlag,
+	flags.EnableDBCompactionWebhookFlag,
+	flags.BlindedBlockStorageFlag,
+	flags.VerifyDBFlag,
+	flags.CheckpointStateCacheSize,
+	flags.HotStateCacheSize,
+	flags.SaveHotStateInterval,
 	flags.MinPeersPerSubnet,
 	flags.FeeRecipient,
+	flags.TerminalTotalDifficultyOverride,
+	flags.TerminalBlockHashOverride,
+	flags.TerminalBlockHashActivationEpochOverride,
+	flags.PayloadPreparationLeadTimeFlag,
+	flags.PayloadPreparationIndicesFlag,
 	cmd.EnableBackupWebhookFlag,
 	cmd.BackupWebhookOutputDir,
 	cmd.MinimalConfigFlag,
@@ -81,7 +121,19 @@ var appFlags = []cli.Flag{
 	cmd.P2PIP,
 	cmd.P2PHost,
 	cmd.P2PHostDNS,
+	cmd.P2PENREntries,
+	cmd.P2PIPv6,
+	cmd.P2PHostIPv6,
+	cmd.P2PPreferredAddressFamily,
 	cmd.P2PMaxPeers,
+	cmd.P2PMaxConnsPerPeer,
+	cmd.P2PMaxStreamsPerPeer,
+	cmd.P2PMaxStreamsPerProtocol,
+	cmd.P2PMaxMemoryPerPeer,
+	cmd.P2PMaxMemoryPerProtocol,
+	cmd.P2PMaxMessageSize,
+	cmd.P2PInboundRatio,
+	cmd.P2PNoInbound,
 	cmd.P2PPrivKey,
 	cmd.P2PMetadata,
 	cmd.P2PAllowList,
@@ -112,10 +164,14 @@ var appFlags = []cli.Flag{
 	cmd.ConfigFileFlag,
 	cmd.ChainConfigFileFlag,
 	cmd.GrpcMaxCallRecvMsgSizeFlag,
+	cmd.GrpcMaxCallSendMsgSizeFlag,
+	cmd.GrpcKeepaliveTimeFlag,
+	cmd.GrpcKeepaliveTimeoutFlag,
 	cmd.AcceptTosFlag,
 	cmd.RestoreSourceFileFlag,
 	cmd.RestoreTargetDirFlag,
 	cmd.BoltMMapInitialSizeFlag,
+	cmd.DBBackendFlag,
 	cmd.ValidatorMonitorIndicesFlag,
 }
 
@@ -1,8 +1,15 @@
 package powchaincmd
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/io/file"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
@@ -20,9 +27,151 @@ func FlagOptions(c *cli.Context) ([]powchain.Option, error) {
 	if executionEndpoint != "" {
 		opts = append(opts, powchain.WithExecutionEndpoint(executionEndpoint))
 	}
+	if fallbacks := c.StringSlice(flags.FallbackExecutionProviderFlag.Name); len(fallbacks) > 0 {
+		opts = append(opts, powchain.WithExecutionFallbackEndpoints(fallbacks))
+	}
+	if ethEndpoint := c.String(flags.ExecutionEthProviderFlag.Name); ethEndpoint != "" {
+		opts = append(opts, powchain.WithExecutionEthEndpoint(ethEndpoint))
+	}
+	jwtSecret, err := parseJWTSecretFromFile(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read JWT secret file for authenticating execution API")
+	}
+	if len(jwtSecret) > 0 {
+		opts = append(opts, powchain.WithExecutionEndpointJWTSecret(jwtSecret))
+	}
+	headers, err := parseExecutionHeaders(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse custom HTTP headers for the execution endpoint")
+	}
+	if len(headers) > 0 {
+		opts = append(opts, powchain.WithExecutionEndpointHeaders(headers))
+	}
+	tlsConfig, err := parseExecutionTLSConfig(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load TLS configuration for connecting to execution endpoints")
+	}
+	if tlsConfig != nil {
+		opts = append(opts, powchain.WithExecutionEndpointTLSConfig(tlsConfig))
+	}
+	if c.IsSet(flags.EngineAPISlowCallThresholdFlag.Name) {
+		opts = append(opts, powchain.WithEngineAPISlowCallThreshold(c.Duration(flags.EngineAPISlowCallThresholdFlag.Name)))
+	}
+	if c.IsSet(flags.EngineAPIRateLimitFlag.Name) {
+		rateLimit := c.Int(flags.EngineAPIRateLimitFlag.Name)
+		if rateLimit < 0 {
+			return nil, errors.Errorf("--%s must not be negative", flags.EngineAPIRateLimitFlag.Name)
+		}
+		opts = append(opts, powchain.WithEngineAPIRateLimit(rateLimit))
+	}
+	if c.Bool(flags.EngineAPIDebugFlag.Name) {
+		maxTxs := c.Int(flags.EngineAPIDebugMaxTransactionsFlag.Name)
+		if maxTxs < 0 {
+			return nil, errors.Errorf("--%s must not be negative", flags.EngineAPIDebugMaxTransactionsFlag.Name)
+		}
+		opts = append(opts, powchain.WithEngineAPIDebugLogging(maxTxs))
+	}
 	return opts, nil
 }
 
+// Parses the --execution-headers flag, a comma separated list of key=value pairs, into a map of
+// HTTP header names to values. Returns nil if the flag is not set.
+func parseExecutionHeaders(c *cli.Context) (map[string]string, error) {
+	flagValue := strings.TrimSpace(c.String(flags.ExecutionHeadersFlag.Name))
+	if flagValue == "" {
+		return nil, nil
+	}
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(flagValue, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		key := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || key == "" {
+			return nil, errors.Errorf("header %q is not in the key=value format", pair)
+		}
+		headers[key] = strings.TrimSpace(kv[1])
+	}
+	return headers, nil
+}
+
+// Builds the TLS configuration used to connect to https and wss execution endpoints from the
+// --execution-tls-* flags. A custom certificate authority bundle is trusted in addition to the
+// system certificate authorities, and a client certificate is presented for mutual TLS if set.
+// Returns nil if none of the flags are set.
+func parseExecutionTLSConfig(c *cli.Context) (*tls.Config, error) {
+	caCertFile := c.String(flags.ExecutionTLSCACertFlag.Name)
+	clientCertFile := c.String(flags.ExecutionTLSClientCertFlag.Name)
+	clientKeyFile := c.String(flags.ExecutionTLSClientKeyFlag.Name)
+	if caCertFile == "" && clientCertFile == "" && clientKeyFile == "" {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caCertFile != "" {
+		caCert, err := file.ReadFileAsBytes(caCertFile)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			log.WithError(err).Warn("Could not load system certificate authorities")
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.Errorf("no valid certificates found in %s", caCertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if (clientCertFile == "") != (clientKeyFile == "") {
+		return nil, errors.Errorf(
+			"--%s and --%s must be set together",
+			flags.ExecutionTLSClientCertFlag.Name,
+			flags.ExecutionTLSClientKeyFlag.Name,
+		)
+	}
+	if clientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not load client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// Parses a JWT secret from a file path. This secret is required when connecting to execution nodes
+// over HTTP, and must be the same one used in Prysm and the execution node server Prysm is connecting to.
+// The engine API specification here https://github.com/ethereum/execution-apis/blob/main/src/engine/authentication.md
+// Explains how we should validate this secret and the format of the file a user can specify.
+//
+// The secret must be stored as a hex-encoded string within a file in the filesystem.
+// If the --jwt-secret flag is provided to Prysm, but the file cannot be read, or does not contain a hex-encoded
+// key of exactly 256 bits, the client should treat this as an error and abort the startup.
+func parseJWTSecretFromFile(c *cli.Context) ([]byte, error) {
+	jwtSecretFile := c.String(flags.ExecutionJWTSecretFlag.Name)
+	if jwtSecretFile == "" {
+		return nil, nil
+	}
+	enc, err := file.ReadFileAsBytes(jwtSecretFile)
+	if err != nil {
+		return nil, err
+	}
+	strData := strings.TrimSpace(string(enc))
+	if len(strData) == 0 {
+		return nil, errors.Errorf("provided JWT secret in file %s cannot be empty", jwtSecretFile)
+	}
+	secret, err := hex.DecodeString(strings.TrimPrefix(strData, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) != 32 {
+		return nil, errors.Errorf("provided JWT secret in file %s should be exactly 32 bytes, got %d", jwtSecretFile, len(secret))
+	}
+	return secret, nil
+}
+
 func parsePowchainEndpoints(c *cli.Context) []string {
 	if c.String(flags.HTTPWeb3ProviderFlag.Name) == "" && len(c.StringSlice(flags.FallbackWeb3ProviderFlag.Name)) == 0 {
 		log.Error(
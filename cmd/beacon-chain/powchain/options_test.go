@@ -1,10 +1,23 @@
 package powchaincmd
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/io/file"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	logTest "github.com/sirupsen/logrus/hooks/test"
@@ -38,3 +51,173 @@ func TestPowchainPreregistration_EmptyWeb3Provider(t *testing.T) {
 	parsePowchainEndpoints(ctx)
 	assert.LogsContain(t, hook, "No ETH1 node specified to run with the beacon node")
 }
+
+func Test_parseJWTSecretFromFile(t *testing.T) {
+	t.Run("no flag value specified leads to nil secret", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.String(flags.ExecutionJWTSecretFlag.Name, "", "")
+		ctx := cli.NewContext(&app, set, nil)
+		got, err := parseJWTSecretFromFile(ctx)
+		require.NoError(t, err)
+		require.DeepEqual(t, []byte(nil), got)
+	})
+	t.Run("flag specified but no file found", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.String(flags.ExecutionJWTSecretFlag.Name, "/tmp/askdjkajsd", "")
+		ctx := cli.NewContext(&app, set, nil)
+		_, err := parseJWTSecretFromFile(ctx)
+		require.ErrorContains(t, "no such file", err)
+	})
+	t.Run("empty string in file", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		fullPath := filepath.Join(os.TempDir(), "foohex")
+		require.NoError(t, file.WriteFile(fullPath, []byte{}))
+		t.Cleanup(func() {
+			if err := os.RemoveAll(fullPath); err != nil {
+				t.Fatalf("Could not delete temp dir: %v", err)
+			}
+		})
+		set.String(flags.ExecutionJWTSecretFlag.Name, fullPath, "")
+		ctx := cli.NewContext(&app, set, nil)
+		_, err := parseJWTSecretFromFile(ctx)
+		require.ErrorContains(t, "cannot be empty", err)
+	})
+	t.Run("less than 32 bytes", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		fullPath := filepath.Join(os.TempDir(), "foohex")
+		secret := bytesutil.PadTo([]byte("foo"), 31)
+		hexData := fmt.Sprintf("%#x", secret)
+		require.NoError(t, file.WriteFile(fullPath, []byte(hexData)))
+		t.Cleanup(func() {
+			if err := os.RemoveAll(fullPath); err != nil {
+				t.Fatalf("Could not delete temp dir: %v", err)
+			}
+		})
+		set.String(flags.ExecutionJWTSecretFlag.Name, fullPath, "")
+		ctx := cli.NewContext(&app, set, nil)
+		_, err := parseJWTSecretFromFile(ctx)
+		require.ErrorContains(t, "should be exactly 32 bytes", err)
+	})
+	t.Run("bad data", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		fullPath := filepath.Join(os.TempDir(), "foohex")
+		secret := []byte("foo")
+		require.NoError(t, file.WriteFile(fullPath, secret))
+		t.Cleanup(func() {
+			if err := os.RemoveAll(fullPath); err != nil {
+				t.Fatalf("Could not delete temp dir: %v", err)
+			}
+		})
+		set.String(flags.ExecutionJWTSecretFlag.Name, fullPath, "")
+		ctx := cli.NewContext(&app, set, nil)
+		_, err := parseJWTSecretFromFile(ctx)
+		require.ErrorContains(t, "invalid byte", err)
+	})
+	t.Run("correct format", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		fullPath := filepath.Join(os.TempDir(), "foohex")
+		secret := bytesutil.PadTo([]byte("foo"), 32)
+		hexData := fmt.Sprintf("%#x", secret)
+		require.NoError(t, file.WriteFile(fullPath, []byte(hexData)))
+		t.Cleanup(func() {
+			if err := os.RemoveAll(fullPath); err != nil {
+				t.Fatalf("Could not delete temp dir: %v", err)
+			}
+		})
+		set.String(flags.ExecutionJWTSecretFlag.Name, fullPath, "")
+		ctx := cli.NewContext(&app, set, nil)
+		got, err := parseJWTSecretFromFile(ctx)
+		require.NoError(t, err)
+		require.DeepEqual(t, secret, got)
+	})
+}
+
+func Test_parseExecutionTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSignedCert(t, dir)
+	newContext := func(caCert, clientCert, clientKey string) *cli.Context {
+		set := flag.NewFlagSet("test", 0)
+		set.String(flags.ExecutionTLSCACertFlag.Name, caCert, "")
+		set.String(flags.ExecutionTLSClientCertFlag.Name, clientCert, "")
+		set.String(flags.ExecutionTLSClientKeyFlag.Name, clientKey, "")
+		return cli.NewContext(&cli.App{}, set, nil)
+	}
+
+	t.Run("no flags set", func(t *testing.T) {
+		cfg, err := parseExecutionTLSConfig(newContext("", "", ""))
+		require.NoError(t, err)
+		require.Equal(t, true, cfg == nil)
+	})
+	t.Run("invalid CA certificate", func(t *testing.T) {
+		invalid := filepath.Join(dir, "invalid.pem")
+		require.NoError(t, file.WriteFile(invalid, []byte("foo")))
+		_, err := parseExecutionTLSConfig(newContext(invalid, "", ""))
+		require.ErrorContains(t, "no valid certificates found", err)
+	})
+	t.Run("client certificate without key", func(t *testing.T) {
+		_, err := parseExecutionTLSConfig(newContext("", certFile, ""))
+		require.ErrorContains(t, "must be set together", err)
+	})
+	t.Run("CA certificate and client certificate", func(t *testing.T) {
+		cfg, err := parseExecutionTLSConfig(newContext(certFile, certFile, keyFile))
+		require.NoError(t, err)
+		require.NotNil(t, cfg.RootCAs)
+		require.Equal(t, 1, len(cfg.Certificates))
+	})
+}
+
+func Test_parseExecutionHeaders(t *testing.T) {
+	newContext := func(value string) *cli.Context {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.String(flags.ExecutionHeadersFlag.Name, value, "")
+		return cli.NewContext(&app, set, nil)
+	}
+	t.Run("not set", func(t *testing.T) {
+		headers, err := parseExecutionHeaders(newContext(""))
+		require.NoError(t, err)
+		require.Equal(t, 0, len(headers))
+	})
+	t.Run("multiple headers", func(t *testing.T) {
+		headers, err := parseExecutionHeaders(newContext("Authorization=Bearer abc=, X-Route=node-1,"))
+		require.NoError(t, err)
+		require.DeepEqual(t, map[string]string{"Authorization": "Bearer abc=", "X-Route": "node-1"}, headers)
+	})
+	t.Run("missing value", func(t *testing.T) {
+		_, err := parseExecutionHeaders(newContext("Authorization"))
+		require.ErrorContains(t, "not in the key=value format", err)
+	})
+	t.Run("missing key", func(t *testing.T) {
+		_, err := parseExecutionHeaders(newContext("=value"))
+		require.ErrorContains(t, "not in the key=value format", err)
+	})
+}
+
+// Writes a self-signed certificate and its private key as PEM files to the given directory.
+func writeSelfSignedCert(t *testing.T, dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, file.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+	require.NoError(t, file.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})))
+	return certFile, keyFile
+}
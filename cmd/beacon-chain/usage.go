@@ -69,10 +69,14 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.ConfigFileFlag,
 			cmd.ChainConfigFileFlag,
 			cmd.GrpcMaxCallRecvMsgSizeFlag,
+			cmd.GrpcMaxCallSendMsgSizeFlag,
+			cmd.GrpcKeepaliveTimeFlag,
+			cmd.GrpcKeepaliveTimeoutFlag,
 			cmd.AcceptTosFlag,
 			cmd.RestoreSourceFileFlag,
 			cmd.RestoreTargetDirFlag,
 			cmd.BoltMMapInitialSizeFlag,
+			cmd.DBBackendFlag,
 			cmd.ValidatorMonitorIndicesFlag,
 		},
 	},
@@ -100,29 +104,64 @@ var appHelpFlagGroups = []flagGroup{
 			flags.RPCPort,
 			flags.CertFlag,
 			flags.KeyFlag,
+			flags.ClientCAFlag,
+			flags.GRPCKeepaliveMinTimeFlag,
 			flags.HTTPModules,
 			flags.DisableGRPCGateway,
 			flags.GRPCGatewayHost,
 			flags.GRPCGatewayPort,
 			flags.GPRCGatewayCorsDomain,
+			flags.GRPCGatewayAuthConfig,
+			flags.GRPCGatewayClientCertFlag,
+			flags.GRPCGatewayClientKeyFlag,
+			flags.EnableGraphQL,
 			flags.HTTPWeb3ProviderFlag,
 			flags.ExecutionProviderFlag,
+			flags.ExecutionJWTSecretFlag,
+			flags.ExecutionHeadersFlag,
+			flags.FallbackExecutionProviderFlag,
+			flags.ExecutionEthProviderFlag,
+			flags.ExecutionTLSCACertFlag,
+			flags.ExecutionTLSClientCertFlag,
+			flags.ExecutionTLSClientKeyFlag,
+			flags.EngineAPISlowCallThresholdFlag,
+			flags.EngineAPIRateLimitFlag,
+			flags.EngineAPIDebugFlag,
+			flags.EngineAPIDebugMaxTransactionsFlag,
 			flags.FallbackWeb3ProviderFlag,
 			flags.SetGCPercent,
 			flags.HeadSync,
 			flags.DisableSync,
 			flags.SlotsPerArchivedPoint,
 			flags.DisableDiscv5,
+			flags.GossipScoringConfig,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
+			flags.RPCRateLimit,
+			flags.RPCRateLimitBurst,
+			flags.RPCTopicRateLimits,
 			flags.EnableDebugRPCEndpoints,
+			flags.EnablePeerManagementRPCEndpoints,
 			flags.SubscribeToAllSubnets,
 			flags.HistoricalSlasherNode,
 			flags.ChainID,
 			flags.NetworkID,
 			flags.WeakSubjectivityCheckpt,
+			flags.IgnoreWeakSubjectivityPeriod,
 			flags.Eth1HeaderReqLimit,
 			flags.GenesisStatePath,
+			flags.CheckpointSyncURLFlag,
+			flags.BackfillRetentionEpochsFlag,
+			flags.PruneRetentionEpochsFlag,
+			flags.PruneDryRunFlag,
+			flags.DBCompactionIntervalFlag,
+			flags.DBCompactionMinFreeRatioFlag,
+			flags.EnableDBCompactionWebhookFlag,
+			flags.BlindedBlockStorageFlag,
+			flags.VerifyDBFlag,
+			flags.CheckpointStateCacheSize,
+			flags.HotStateCacheSize,
+			flags.SaveHotStateInterval,
 			flags.MinPeersPerSubnet,
 		},
 	},
@@ -130,6 +169,11 @@ var appHelpFlagGroups = []flagGroup{
 		Name: "merge",
 		Flags: []cli.Flag{
 			flags.FeeRecipient,
+			flags.TerminalTotalDifficultyOverride,
+			flags.TerminalBlockHashOverride,
+			flags.TerminalBlockHashActivationEpochOverride,
+			flags.PayloadPreparationLeadTimeFlag,
+			flags.PayloadPreparationIndicesFlag,
 		},
 	},
 	{
@@ -138,7 +182,19 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.P2PIP,
 			cmd.P2PHost,
 			cmd.P2PHostDNS,
+			cmd.P2PENREntries,
+			cmd.P2PIPv6,
+			cmd.P2PHostIPv6,
+			cmd.P2PPreferredAddressFamily,
 			cmd.P2PMaxPeers,
+			cmd.P2PMaxConnsPerPeer,
+			cmd.P2PMaxStreamsPerPeer,
+			cmd.P2PMaxStreamsPerProtocol,
+			cmd.P2PMaxMemoryPerPeer,
+			cmd.P2PMaxMemoryPerProtocol,
+			cmd.P2PMaxMessageSize,
+			cmd.P2PInboundRatio,
+			cmd.P2PNoInbound,
 			cmd.P2PPrivKey,
 			cmd.P2PMetadata,
 			cmd.P2PAllowList,
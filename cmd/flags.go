@@ -3,13 +3,37 @@ package cmd
 
 import (
 	"fmt"
+	"math"
 	"strings"
+	"time"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/backend"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/urfave/cli/v2"
 	"github.com/urfave/cli/v2/altsrc"
 )
 
+// The defaults of the p2p flags. The p2p packages depend on this package, so the
+// defaults are defined here rather than next to the settings they configure.
+const (
+	// DefaultP2PInboundRatio is the default proportion of the max peers which can be taken by inbound peers.
+	DefaultP2PInboundRatio = 0.8
+	// DefaultP2PMaxConnsPerPeer is the default max number of connections with a single peer.
+	DefaultP2PMaxConnsPerPeer = 2
+	// DefaultP2PMaxStreamsPerPeer is the default max number of concurrent inbound req/resp streams of a peer.
+	DefaultP2PMaxStreamsPerPeer = 32
+	// DefaultP2PMaxStreamsPerProtocol is the default max number of concurrent inbound req/resp streams of a protocol.
+	DefaultP2PMaxStreamsPerProtocol = 256
+	// DefaultP2PMaxMemoryPerPeer is the default max memory in bytes reserved by the inbound req/resp streams of a peer.
+	DefaultP2PMaxMemoryPerPeer = 64 << 20
+	// DefaultP2PMaxMemoryPerProtocol is the default max memory in bytes reserved by the inbound req/resp streams of a protocol.
+	DefaultP2PMaxMemoryPerProtocol = 1 << 30
+)
+
+// DefaultGrpcKeepaliveTimeout is the default duration to wait for the reply to a gRPC keepalive ping.
+// The RPC packages depend on this package, so it is defined here rather than in the RPC service package.
+const DefaultGrpcKeepaliveTimeout = 20 * time.Second
+
 var (
 	// MinimalConfigFlag declares to use the minimal config for running Ethereum consensus.
 	MinimalConfigFlag = &cli.BoolFlag{
@@ -41,8 +65,10 @@ var (
 	}
 	// EnableBackupWebhookFlag for users to trigger db backups via an HTTP webhook.
 	EnableBackupWebhookFlag = &cli.BoolFlag{
-		Name:  "enable-db-backup-webhook",
-		Usage: "Serve HTTP handler to initiate database backups. The handler is served on the monitoring port at path /db/backup.",
+		Name: "enable-db-backup-webhook",
+		Usage: "Serve HTTP handlers to initiate database backups. The handlers are served on the monitoring port: /db/backup " +
+			"writes a backup to the backup output directory and /db/backup/stream downloads a consistent snapshot of the " +
+			"database, gzip compressed with the gzip query parameter.",
 	}
 	// BackupWebhookOutputDir to customize the output directory for db backups.
 	BackupWebhookOutputDir = &cli.StringFlag{
@@ -131,12 +157,37 @@ var (
 		Usage: "The IP address advertised by libp2p. This may be used to advertise an external IP.",
 		Value: "",
 	}
+	// P2PIPv6 defines the local IPv6 address to be used by libp2p.
+	P2PIPv6 = &cli.StringFlag{
+		Name: "p2p-local-ipv6",
+		Usage: "The local IPv6 address to listen for incoming data, in addition to the IPv4 address. " +
+			"Setting it makes the node listen on both address families.",
+		Value: "",
+	}
+	// P2PHostIPv6 defines the host IPv6 address to be used by libp2p.
+	P2PHostIPv6 = &cli.StringFlag{
+		Name:  "p2p-host-ipv6",
+		Usage: "The IPv6 address advertised by libp2p, in addition to the IPv4 address. This may be used to advertise an external IPv6 address.",
+		Value: "",
+	}
+	// P2PPreferredAddressFamily defines the address family used to dial peers advertising both families.
+	P2PPreferredAddressFamily = &cli.StringFlag{
+		Name:  "p2p-preferred-address-family",
+		Usage: "The address family (ipv4 or ipv6) used to dial peers which advertise both IPv4 and IPv6 addresses.",
+		Value: "ipv4",
+	}
 	// P2PHostDNS defines the host DNS to be used by libp2p.
 	P2PHostDNS = &cli.StringFlag{
 		Name:  "p2p-host-dns",
 		Usage: "The DNS address advertised by libp2p. This may be used to advertise an external DNS.",
 		Value: "",
 	}
+	// P2PENREntries defines custom key/value entries added to the node's ENR.
+	P2PENREntries = &cli.StringSliceFlag{
+		Name: "p2p-enr-entry",
+		Usage: "A custom key=value entry to add to the node's ENR. Values starting with 0x are decoded as hex. " +
+			"Multiple entries can be added by repeating the flag.",
+	}
 	// P2PPrivKey defines a flag to specify the location of the private key file for libp2p.
 	P2PPrivKey = &cli.StringFlag{
 		Name:  "p2p-priv-key",
@@ -155,6 +206,58 @@ var (
 		Usage: "The max number of p2p peers to maintain.",
 		Value: 45,
 	}
+	// P2PMaxConnsPerPeer defines the max number of connections with a single peer.
+	P2PMaxConnsPerPeer = &cli.IntFlag{
+		Name:  "p2p-max-conns-per-peer",
+		Usage: "The max number of connections with a single peer. 0 means unlimited.",
+		Value: DefaultP2PMaxConnsPerPeer,
+	}
+	// P2PMaxStreamsPerPeer defines the max number of concurrent inbound req/resp streams of a single peer.
+	P2PMaxStreamsPerPeer = &cli.IntFlag{
+		Name:  "p2p-max-streams-per-peer",
+		Usage: "The max number of concurrent inbound req/resp streams of a single peer. Streams above it are reset. 0 means unlimited.",
+		Value: DefaultP2PMaxStreamsPerPeer,
+	}
+	// P2PMaxStreamsPerProtocol defines the max number of concurrent inbound req/resp streams of a protocol.
+	P2PMaxStreamsPerProtocol = &cli.IntFlag{
+		Name:  "p2p-max-streams-per-protocol",
+		Usage: "The max number of concurrent inbound req/resp streams of a protocol, across all peers. Streams above it are reset. 0 means unlimited.",
+		Value: DefaultP2PMaxStreamsPerProtocol,
+	}
+	// P2PMaxMemoryPerPeer defines the max memory reserved by the inbound req/resp streams of a single peer.
+	P2PMaxMemoryPerPeer = &cli.Uint64Flag{
+		Name: "p2p-max-memory-per-peer",
+		Usage: "The max memory in bytes reserved by the concurrent inbound req/resp streams of a single peer, each stream " +
+			"reserving the max size of a req/resp message. Streams above it are reset. 0 means unlimited.",
+		Value: DefaultP2PMaxMemoryPerPeer,
+	}
+	// P2PMaxMemoryPerProtocol defines the max memory reserved by the inbound req/resp streams of a protocol.
+	P2PMaxMemoryPerProtocol = &cli.Uint64Flag{
+		Name: "p2p-max-memory-per-protocol",
+		Usage: "The max memory in bytes reserved by the concurrent inbound req/resp streams of a protocol, across all " +
+			"peers, each stream reserving the max size of a req/resp message. Streams above it are reset. 0 means unlimited.",
+		Value: DefaultP2PMaxMemoryPerProtocol,
+	}
+	// P2PMaxMessageSize defines the max size of gossip and req/resp messages.
+	P2PMaxMessageSize = &cli.Uint64Flag{
+		Name: "p2p-max-message-size",
+		Usage: "The max size in bytes of uncompressed gossip and req/resp messages, messages above it are rejected. " +
+			"It can only lower the limits of the network. 0 means the limits of the network.",
+	}
+	// P2PInboundRatio defines the proportion of the max peers which can be taken by inbound peers.
+	P2PInboundRatio = &cli.Float64Flag{
+		Name: "p2p-inbound-ratio",
+		Usage: "The proportion of the max number of p2p peers which can be taken by inbound peers, " +
+			"the rest being kept for the peers dialed by the node. Must be between 0 and 1, 0 disallowing inbound peers " +
+			"like --p2p-no-inbound.",
+		Value: DefaultP2PInboundRatio,
+	}
+	// P2PNoInbound defines a flag to disallow inbound connections.
+	P2PNoInbound = &cli.BoolFlag{
+		Name: "p2p-no-inbound",
+		Usage: "Disallow inbound connections, the node only connects to the peers it dials. " +
+			"Useful for nodes placed behind sentry nodes.",
+	}
 	// P2PAllowList defines a CIDR subnet to exclusively allow connections.
 	P2PAllowList = &cli.StringFlag{
 		Name: "p2p-allowlist",
@@ -219,6 +322,24 @@ var (
 		Usage: "Integer to define max recieve message call size (default: 4194304 (for 4MB))",
 		Value: 1 << 22,
 	}
+	// GrpcMaxCallSendMsgSizeFlag defines the max send message size for GRPC
+	GrpcMaxCallSendMsgSizeFlag = &cli.IntFlag{
+		Name:  "grpc-max-send-msg-size",
+		Usage: "Integer to define max send message size, such as the size of large state responses (default: 2147483647)",
+		Value: math.MaxInt32,
+	}
+	// GrpcKeepaliveTimeFlag defines how often idle GRPC connections are pinged.
+	GrpcKeepaliveTimeFlag = &cli.DurationFlag{
+		Name: "grpc-keepalive-time",
+		Usage: "Duration after which an idle gRPC connection is pinged to check that it is still alive. " +
+			"The gRPC defaults are used if 0, which are 2 hours for servers and no pings for clients",
+	}
+	// GrpcKeepaliveTimeoutFlag defines how long to wait for the reply to a GRPC keepalive ping.
+	GrpcKeepaliveTimeoutFlag = &cli.DurationFlag{
+		Name:  "grpc-keepalive-timeout",
+		Usage: "Duration to wait for the reply to a gRPC keepalive ping before closing the connection",
+		Value: DefaultGrpcKeepaliveTimeout,
+	}
 	// AcceptTosFlag specifies user acceptance of ToS for non-interactive environments.
 	AcceptTosFlag = &cli.BoolFlag{
 		Name:  "accept-terms-of-use",
@@ -249,6 +370,51 @@ var (
 		Usage: "Specifies the size in bytes of bolt db's mmap syscall allocation",
 		Value: 536870912, // 512 Mb as a default value.
 	}
+	// DBBackendFlag specifies the key-value backend of the beacon node database.
+	DBBackendFlag = &cli.StringFlag{
+		Name: "db-backend",
+		Usage: "Key-value backend of the beacon node database, bolt or pebble. Pebble is better suited to " +
+			"archival nodes. An existing database can be converted with the db migrate-backend command.",
+		Value: string(backend.Bolt),
+	}
+	// DBReadOnlyFlag opens the beacon node database read-only in the db commands.
+	DBReadOnlyFlag = &cli.BoolFlag{
+		Name: "db-read-only",
+		Usage: "Open the beacon node database read-only, failing all writes to it, to run against a copy of a " +
+			"data directory without risking to corrupt it. The database must be up to date with the migrations " +
+			"of this version. Only the db commands which do not write to the database accept it, a running " +
+			"beacon node writes the blocks and states it syncs.",
+	}
+	// DBMigrationDryRunFlag runs the pending database migrations without applying them.
+	DBMigrationDryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Run the pending database migrations in transactions which are rolled back, reporting the writes they would make",
+	}
+	// DBMigrationTargetVersionFlag specifies the schema version the database migrations are rolled back to.
+	DBMigrationTargetVersionFlag = &cli.Uint64Flag{
+		Name:  "target-version",
+		Usage: "Schema version to roll the database migrations back to",
+	}
+	// EraDirFlag specifies the directory of the era files exported or imported.
+	EraDirFlag = &cli.StringFlag{
+		Name:  "era-dir",
+		Usage: "Directory of the era files holding the finalized history of the chain",
+	}
+	// EraFromFlag specifies the first era exported.
+	EraFromFlag = &cli.Uint64Flag{
+		Name:  "era-from",
+		Usage: "First era to export, the default being genesis",
+	}
+	// EraToFlag specifies the last era exported.
+	EraToFlag = &cli.Uint64Flag{
+		Name:  "era-to",
+		Usage: "Last era to export, the default being the last finalized era",
+	}
+	// DBRepairFlag deletes the orphaned entries found when verifying the integrity of the database.
+	DBRepairFlag = &cli.BoolFlag{
+		Name:  "repair",
+		Usage: "Delete the orphaned states, state summaries and index entries found in the database",
+	}
 )
 
 // LoadFlagsFromConfig sets flags values from config file if ConfigFileFlag is set.
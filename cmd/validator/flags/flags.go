@@ -43,6 +43,17 @@ var (
 		Name:  "tls-cert",
 		Usage: "Certificate for secure gRPC. Pass this and the tls-key flag in order to use gRPC securely.",
 	}
+	// ClientCertFlag defines a flag for the TLS certificate presented to the beacon node.
+	ClientCertFlag = &cli.StringFlag{
+		Name: "tls-client-cert",
+		Usage: "Certificate presented to the beacon node for mutual TLS, when the beacon node verifies the " +
+			"certificates of its gRPC clients. Pass this and the tls-client-key flag.",
+	}
+	// ClientKeyFlag defines a flag for the key of the TLS certificate presented to the beacon node.
+	ClientKeyFlag = &cli.StringFlag{
+		Name:  "tls-client-key",
+		Usage: "Key of the certificate presented to the beacon node for mutual TLS. Pass this and the tls-client-cert flag.",
+	}
 	// EnableRPCFlag enables controlling the validator client via gRPC (without web UI).
 	EnableRPCFlag = &cli.BoolFlag{
 		Name:  "rpc",
@@ -50,6 +50,8 @@ var appFlags = []cli.Flag{
 	flags.BeaconRPCProviderFlag,
 	flags.BeaconRPCGatewayProviderFlag,
 	flags.CertFlag,
+	flags.ClientCertFlag,
+	flags.ClientKeyFlag,
 	flags.GraffitiFlag,
 	flags.DisablePenaltyRewardLogFlag,
 	flags.InteropStartIndex,
@@ -95,6 +97,9 @@ var appFlags = []cli.Flag{
 	cmd.ConfigFileFlag,
 	cmd.ChainConfigFileFlag,
 	cmd.GrpcMaxCallRecvMsgSizeFlag,
+	cmd.GrpcMaxCallSendMsgSizeFlag,
+	cmd.GrpcKeepaliveTimeFlag,
+	cmd.GrpcKeepaliveTimeoutFlag,
 	cmd.BoltMMapInitialSizeFlag,
 	debug.PProfFlag,
 	debug.PProfAddrFlag,
@@ -64,6 +64,9 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.ConfigFileFlag,
 			cmd.ChainConfigFileFlag,
 			cmd.GrpcMaxCallRecvMsgSizeFlag,
+			cmd.GrpcMaxCallSendMsgSizeFlag,
+			cmd.GrpcKeepaliveTimeFlag,
+			cmd.GrpcKeepaliveTimeoutFlag,
 			cmd.AcceptTosFlag,
 			cmd.BoltMMapInitialSizeFlag,
 		},
@@ -87,6 +90,8 @@ var appHelpFlagGroups = []flagGroup{
 			flags.BeaconRPCProviderFlag,
 			flags.BeaconRPCGatewayProviderFlag,
 			flags.CertFlag,
+			flags.ClientCertFlag,
+			flags.ClientKeyFlag,
 			flags.EnableWebFlag,
 			flags.DisablePenaltyRewardLogFlag,
 			flags.GraffitiFlag,
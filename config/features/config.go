@@ -53,6 +53,12 @@ type Flags struct {
 	EnableGetBlockOptimizations         bool // EnableGetBlockOptimizations optimizes some elements of the GetBlock() function.
 	EnableBatchVerification             bool // EnableBatchVerification enables batch signature verification on gossip messages.
 	EnableBalanceTrieComputation        bool // EnableBalanceTrieComputation enables our beacon state to use balance tries for hash tree root operations.
+	EnableLazyAttestationVerification   bool // EnableLazyAttestationVerification defers the signature verification of gossip attestations to a batching worker.
+	EnableStateDiffs                    bool // EnableStateDiffs saves archived states as diffs against periodic full snapshots.
+	EnableForkChoiceDoublyLinkedTree    bool // EnableForkChoiceDoublyLinkedTree uses the doubly linked tree fork choice store instead of proto array.
+	EnableReorgLateBlocks               bool // EnableReorgLateBlocks makes proposers build on the parent of a late and weak head block, reorging it out.
+	EnableHeadInvariantChecks           bool // EnableHeadInvariantChecks re-validates chain invariants after every head update and snapshots violations.
+	EnableLightClient                   bool // EnableLightClient serves and gossips the light client data produced from imported blocks.
 	// Logging related toggles.
 	DisableGRPCConnectionLogs bool // Disables logging when a new grpc client has connected.
 
@@ -225,6 +231,30 @@ func ConfigureBeaconChain(ctx *cli.Context) {
 		logEnabled(enableNativeState)
 		cfg.EnableNativeState = true
 	}
+	if ctx.Bool(enableLazyAttestationVerification.Name) {
+		logEnabled(enableLazyAttestationVerification)
+		cfg.EnableLazyAttestationVerification = true
+	}
+	if ctx.Bool(enableStateDiffs.Name) {
+		logEnabled(enableStateDiffs)
+		cfg.EnableStateDiffs = true
+	}
+	if ctx.Bool(enableForkChoiceDoublyLinkedTree.Name) {
+		logEnabled(enableForkChoiceDoublyLinkedTree)
+		cfg.EnableForkChoiceDoublyLinkedTree = true
+	}
+	if ctx.Bool(enableReorgLateBlocks.Name) {
+		logEnabled(enableReorgLateBlocks)
+		cfg.EnableReorgLateBlocks = true
+	}
+	if ctx.Bool(enableHeadInvariantChecks.Name) {
+		logEnabled(enableHeadInvariantChecks)
+		cfg.EnableHeadInvariantChecks = true
+	}
+	if ctx.Bool(enableLightClient.Name) {
+		logEnabled(enableLightClient)
+		cfg.EnableLightClient = true
+	}
 	Init(cfg)
 }
 
@@ -134,6 +134,35 @@ var (
 		Name:  "enable-native-state",
 		Usage: "Enables representing the beacon state as a pure Go struct.",
 	}
+	enableLazyAttestationVerification = &cli.BoolFlag{
+		Name: "enable-lazy-attestation-verification",
+		Usage: "Verifies the signatures of unaggregated gossip attestations in batches, so that fewer signature " +
+			"verifications run on large nodes. Gossip validation waits for the batch an attestation is verified in.",
+	}
+	enableStateDiffs = &cli.BoolFlag{
+		Name: "enable-state-diffs",
+		Usage: "Saves most archived states as diffs against periodic full snapshots of the state, which " +
+			"greatly reduces the disk usage of archival nodes at the cost of slower historical state queries.",
+	}
+	enableForkChoiceDoublyLinkedTree = &cli.BoolFlag{
+		Name:  "enable-forkchoice-doubly-linked-tree",
+		Usage: "Enables the experimental fork choice store implemented as a doubly linked tree of blocks instead of proto array.",
+	}
+	enableReorgLateBlocks = &cli.BoolFlag{
+		Name: "enable-reorg-late-blocks",
+		Usage: "When proposing, builds on the parent of the head block instead if the head arrived after the " +
+			"attestation deadline and has few votes, reorging the late block out.",
+	}
+	enableHeadInvariantChecks = &cli.BoolFlag{
+		Name: "enable-head-invariant-checks",
+		Usage: "Debug mode that re-validates justification, finalization and fork choice weight invariants after " +
+			"every head update, writing a diagnostic snapshot to the temp directory when one is violated. Meant for devnets and soak tests.",
+	}
+	enableLightClient = &cli.BoolFlag{
+		Name: "enable-lightclient",
+		Usage: "Computes the light client updates of imported blocks, stores the best update of each sync committee " +
+			"period, gossips the latest finality and optimistic updates and serves them over the beacon API.",
+	}
 )
 
 // devModeFlags holds list of flags that are set when development mode is on.
@@ -183,6 +212,12 @@ var BeaconChainFlags = append(deprecatedFlags, []cli.Flag{
 	disableBatchGossipVerification,
 	disableBalanceTrieComputation,
 	enableNativeState,
+	enableLazyAttestationVerification,
+	enableStateDiffs,
+	enableForkChoiceDoublyLinkedTree,
+	enableReorgLateBlocks,
+	enableHeadInvariantChecks,
+	enableLightClient,
 }...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
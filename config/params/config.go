@@ -65,8 +65,11 @@ type BeaconChainConfig struct {
 	SecondsPerETH1Block              uint64      `yaml:"SECONDS_PER_ETH1_BLOCK" spec:"true"`              // SecondsPerETH1Block is the approximate time for a single eth1 block to be produced.
 
 	// Fork choice algorithm constants.
-	ProposerScoreBoost uint64 `yaml:"PROPOSER_SCORE_BOOST" spec:"true"` // ProposerScoreBoost defines a value that is a % of the committee weight for fork-choice boosting.
-	IntervalsPerSlot   uint64 `yaml:"INTERVALS_PER_SLOT" spec:"true"`   // IntervalsPerSlot defines the number of fork choice intervals in a slot defined in the fork choice spec.
+	ProposerScoreBoost              uint64      `yaml:"PROPOSER_SCORE_BOOST" spec:"true"`    // ProposerScoreBoost defines a value that is a % of the committee weight for fork-choice boosting.
+	IntervalsPerSlot                uint64      `yaml:"INTERVALS_PER_SLOT" spec:"true"`      // IntervalsPerSlot defines the number of fork choice intervals in a slot defined in the fork choice spec.
+	ReorgWeightThreshold            uint64      `yaml:"REORG_WEIGHT_THRESHOLD"`              // ReorgWeightThreshold defines a value that is a % of the committee weight under which a late head block is weak enough to be reorged by the next proposer.
+	ReorgParentWeightThreshold      uint64      `yaml:"REORG_PARENT_WEIGHT_THRESHOLD"`       // ReorgParentWeightThreshold defines a value that is a % of the committee weight the parent of a late head block must exceed for the next proposer to build on it.
+	ReorgMaxEpochsSinceFinalization types.Epoch `yaml:"REORG_MAX_EPOCHS_SINCE_FINALIZATION"` // ReorgMaxEpochsSinceFinalization defines the maximum number of epochs since finalization for the next proposer to reorg a late head block.
 
 	// Ethereum PoW parameters.
 	DepositChainID         uint64 `yaml:"DEPOSIT_CHAIN_ID" spec:"true"`         // DepositChainID of the eth1 network. This used for replay protection.
@@ -140,6 +143,8 @@ type BeaconChainConfig struct {
 	AltairForkEpoch      types.Epoch             `yaml:"ALTAIR_FORK_EPOCH" spec:"true"`      // AltairForkEpoch is used to represent the assigned fork epoch for altair.
 	BellatrixForkVersion []byte                  `yaml:"BELLATRIX_FORK_VERSION" spec:"true"` // BellatrixForkVersion is used to represent the fork version for bellatrix.
 	BellatrixForkEpoch   types.Epoch             `yaml:"BELLATRIX_FORK_EPOCH" spec:"true"`   // BellatrixForkEpoch is used to represent the assigned fork epoch for bellatrix.
+	CapellaForkVersion   []byte                  `yaml:"CAPELLA_FORK_VERSION" spec:"true"`   // CapellaForkVersion is used to represent the fork version for capella.
+	CapellaForkEpoch     types.Epoch             `yaml:"CAPELLA_FORK_EPOCH" spec:"true"`     // CapellaForkEpoch is used to represent the assigned fork epoch for capella.
 	ShardingForkVersion  []byte                  `yaml:"SHARDING_FORK_VERSION" spec:"true"`  // ShardingForkVersion is used to represent the fork version for sharding.
 	ShardingForkEpoch    types.Epoch             `yaml:"SHARDING_FORK_EPOCH" spec:"true"`    // ShardingForkEpoch is used to represent the assigned fork epoch for sharding.
 	ForkVersionSchedule  map[[4]byte]types.Epoch // Schedule of fork epochs by version.
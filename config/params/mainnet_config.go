@@ -115,8 +115,11 @@ var mainnetBeaconConfig = &BeaconChainConfig{
 	SafeSlotsToUpdateJustified:       8,
 
 	// Fork choice algorithm constants.
-	ProposerScoreBoost: 70,
-	IntervalsPerSlot:   3,
+	ProposerScoreBoost:              70,
+	IntervalsPerSlot:                3,
+	ReorgWeightThreshold:            20,
+	ReorgParentWeightThreshold:      160,
+	ReorgMaxEpochsSinceFinalization: 2,
 
 	// Ethereum PoW parameters.
 	DepositChainID:         1, // Chain ID of eth1 mainnet.
@@ -201,6 +204,8 @@ var mainnetBeaconConfig = &BeaconChainConfig{
 	AltairForkEpoch:      mainnetAltairForkEpoch,
 	BellatrixForkVersion: []byte{2, 0, 0, 0},
 	BellatrixForkEpoch:   math.MaxUint64,
+	CapellaForkVersion:   []byte{4, 0, 0, 0},
+	CapellaForkEpoch:     math.MaxUint64,
 	ShardingForkVersion:  []byte{3, 0, 0, 0},
 	ShardingForkEpoch:    math.MaxUint64,
 	ForkVersionSchedule: map[[4]byte]types.Epoch{
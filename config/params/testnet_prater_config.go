@@ -43,6 +43,7 @@ func PraterConfig() *BeaconChainConfig {
 	cfg.AltairForkVersion = []byte{0x1, 0x0, 0x10, 0x20}
 	cfg.ShardingForkVersion = []byte{0x3, 0x0, 0x10, 0x20}
 	cfg.BellatrixForkVersion = []byte{0x2, 0x0, 0x10, 0x20}
+	cfg.CapellaForkVersion = []byte{0x4, 0x0, 0x10, 0x20}
 	cfg.TerminalTotalDifficulty = "4294967296"
 	cfg.DepositContractAddress = "0xff50ed3d0ec03aC01D4C79aAd74928BFF48a7b2b"
 	return cfg
@@ -32,6 +32,8 @@ func PyrmontConfig() *BeaconChainConfig {
 	cfg.AltairForkEpoch = 61650
 	cfg.BellatrixForkVersion = []byte{0x02, 0x00, 0x20, 0x09}
 	cfg.BellatrixForkEpoch = math.MaxUint64
+	cfg.CapellaForkVersion = []byte{0x04, 0x00, 0x20, 0x09}
+	cfg.CapellaForkEpoch = math.MaxUint64
 	cfg.ShardingForkVersion = []byte{0x03, 0x00, 0x20, 0x09}
 	cfg.ShardingForkEpoch = math.MaxUint64
 	cfg.SecondsPerETH1Block = 14
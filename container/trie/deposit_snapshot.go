@@ -0,0 +1,179 @@
+package trie
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/crypto/hash"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	protodb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+)
+
+// DepositTreeSnapshot is the minimal representation of the deposit tree after its first DepositCount
+// leaves have been finalized, as defined in EIP-4881. Finalized contains the roots of the largest
+// complete subtrees covering the finalized deposits, from the left-most to the right-most one, which
+// are enough to keep appending deposits to the tree and prove them.
+type DepositTreeSnapshot struct {
+	Finalized            [][32]byte
+	DepositRoot          [32]byte
+	DepositCount         uint64
+	ExecutionBlockHash   [32]byte
+	ExecutionBlockHeight uint64
+}
+
+// Snapshot of the first count items of the trie, taken at the given execution block.
+func (m *SparseMerkleTrie) Snapshot(count uint64, blockHash [32]byte, blockHeight uint64) (*DepositTreeSnapshot, error) {
+	if count > uint64(m.NumOfItems()) {
+		return nil, fmt.Errorf("cannot snapshot %d items of a trie containing %d items", count, m.NumOfItems())
+	}
+	finalized := make([][32]byte, 0, m.depth)
+	for i := int(m.depth) - 1; i >= 0; i-- {
+		if (count>>uint(i))&1 == 0 {
+			continue
+		}
+		var node [32]byte
+		copy(node[:], m.branches[i][(count>>uint(i))-1])
+		finalized = append(finalized, node)
+	}
+	snapshot := &DepositTreeSnapshot{
+		Finalized:            finalized,
+		DepositCount:         count,
+		ExecutionBlockHash:   blockHash,
+		ExecutionBlockHeight: blockHeight,
+	}
+	snapshot.DepositRoot = snapshot.CalculateRoot(uint64(m.depth))
+	return snapshot, nil
+}
+
+// CalculateRoot of the deposit tree of the given depth described by the snapshot, including the
+// mixed in deposit count as done by the deposit contract.
+//  Spec Definition:
+//   def calculate_root(self) -> Hash32:
+//       size = self.deposit_count
+//       index = len(self.finalized)
+//       root = zero_hashes[0]
+//       for level in range(0, DEPOSIT_CONTRACT_DEPTH):
+//           if (size & 1) == 1:
+//               index -= 1
+//               root = sha256(self.finalized[index] + root)
+//           else:
+//               root = sha256(root + zero_hashes[level])
+//           size >>= 1
+//       return sha256(root + to_le_bytes(self.deposit_count))
+func (s *DepositTreeSnapshot) CalculateRoot(depth uint64) [32]byte {
+	size := s.DepositCount
+	index := len(s.Finalized)
+	root := ZeroHashes[0]
+	for i := uint64(0); i < depth; i++ {
+		if size&1 == 1 && index > 0 {
+			index--
+			root = hash.Hash(append(s.Finalized[index][:], root[:]...))
+		} else {
+			root = hash.Hash(append(root[:], ZeroHashes[i][:]...))
+		}
+		size >>= 1
+	}
+	enc := [32]byte{}
+	binary.LittleEndian.PutUint64(enc[:], s.DepositCount)
+	return hash.Hash(append(root[:], enc[:]...))
+}
+
+// TrieFromSnapshot rebuilds a trie of the given depth from a deposit tree snapshot. New items can be
+// inserted and proven from index snapshot.DepositCount on, the finalized items themselves are not
+// recoverable and are replaced by placeholders.
+func TrieFromSnapshot(snapshot *DepositTreeSnapshot, depth uint64) (*SparseMerkleTrie, error) {
+	if snapshot == nil {
+		return nil, errors.New("nil deposit tree snapshot")
+	}
+	count := snapshot.DepositCount
+	if depth < 64 && count >= 1<<depth {
+		return nil, fmt.Errorf("deposit count %d exceeds the capacity of a trie of depth %d", count, depth)
+	}
+	if root := snapshot.CalculateRoot(depth); root != snapshot.DepositRoot {
+		return nil, fmt.Errorf("snapshot deposit root %#x does not match the finalized nodes root %#x", snapshot.DepositRoot, root)
+	}
+	if count == 0 {
+		return NewTrie(depth)
+	}
+	// Finalized nodes are listed from the highest to the lowest level they are located at.
+	finalized := make(map[uint64][32]byte, len(snapshot.Finalized))
+	index := 0
+	for i := int(depth) - 1; i >= 0; i-- {
+		if (count>>uint(i))&1 == 0 {
+			continue
+		}
+		if index >= len(snapshot.Finalized) {
+			return nil, errors.New("not enough finalized nodes in deposit tree snapshot")
+		}
+		finalized[uint64(i)] = snapshot.Finalized[index]
+		index++
+	}
+	if index != len(snapshot.Finalized) {
+		return nil, errors.New("too many finalized nodes in deposit tree snapshot")
+	}
+
+	layers := make([][][]byte, depth+1)
+	for i := uint64(0); i <= depth; i++ {
+		size := (count + (1 << i) - 1) >> i
+		complete := count >> i
+		layers[i] = make([][]byte, size)
+		for j := uint64(0); j < size; j++ {
+			switch {
+			case j+1 == complete && (count>>i)&1 == 1:
+				node := finalized[i]
+				layers[i][j] = node[:]
+			case j < complete:
+				// Nodes within a finalized subtree are never needed to insert or prove new items.
+				layers[i][j] = ZeroHashes[i][:]
+			default:
+				left := layers[i-1][2*j]
+				right := ZeroHashes[i-1][:]
+				if 2*j+1 < uint64(len(layers[i-1])) {
+					right = layers[i-1][2*j+1]
+				}
+				node := hash.Hash(append(append(make([]byte, 0, 64), left...), right...))
+				layers[i][j] = node[:]
+			}
+		}
+	}
+	items := make([][]byte, count)
+	copy(items, layers[0])
+	return &SparseMerkleTrie{
+		depth:         uint(depth),
+		branches:      layers,
+		originalItems: items,
+	}, nil
+}
+
+// MarshalSSZ encodes the snapshot as the DepositTreeSnapshot SSZ container of EIP-4881.
+func (s *DepositTreeSnapshot) MarshalSSZ() ([]byte, error) {
+	finalized := make([][]byte, len(s.Finalized))
+	for i := range s.Finalized {
+		finalized[i] = s.Finalized[i][:]
+	}
+	return (&protodb.DepositSnapshot{
+		Finalized:            finalized,
+		DepositRoot:          s.DepositRoot[:],
+		DepositCount:         s.DepositCount,
+		ExecutionBlockHash:   s.ExecutionBlockHash[:],
+		ExecutionBlockHeight: s.ExecutionBlockHeight,
+	}).MarshalSSZ()
+}
+
+// UnmarshalSSZ decodes a snapshot from the DepositTreeSnapshot SSZ container of EIP-4881.
+func (s *DepositTreeSnapshot) UnmarshalSSZ(enc []byte) error {
+	pb := &protodb.DepositSnapshot{}
+	if err := pb.UnmarshalSSZ(enc); err != nil {
+		return fmt.Errorf("could not decode deposit tree snapshot: %w", err)
+	}
+	s.Finalized = make([][32]byte, len(pb.Finalized))
+	for i := range pb.Finalized {
+		s.Finalized[i] = bytesutil.ToBytes32(pb.Finalized[i])
+	}
+	s.DepositRoot = bytesutil.ToBytes32(pb.DepositRoot)
+	s.DepositCount = pb.DepositCount
+	s.ExecutionBlockHash = bytesutil.ToBytes32(pb.ExecutionBlockHash)
+	s.ExecutionBlockHeight = pb.ExecutionBlockHeight
+	return nil
+}
@@ -0,0 +1,96 @@
+package trie_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/container/trie"
+	"github.com/prysmaticlabs/prysm/crypto/hash"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestDepositTreeSnapshot_RebuildTrie(t *testing.T) {
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	items := make([][]byte, 37)
+	for i := range items {
+		h := hash.Hash([]byte{byte(i)})
+		items[i] = h[:]
+	}
+	full, err := trie.GenerateTrieFromItems(items, depth)
+	require.NoError(t, err)
+
+	for _, count := range []int{0, 1, 2, 5, 16, 21, 36} {
+		partial, err := trie.GenerateTrieFromItems(items[:count], depth)
+		if count == 0 {
+			partial, err = trie.NewTrie(depth)
+		}
+		require.NoError(t, err)
+		snapshot, err := full.Snapshot(uint64(count), [32]byte{'a'}, 100)
+		require.NoError(t, err)
+		assert.Equal(t, partial.HashTreeRoot(), snapshot.DepositRoot, "count %d", count)
+
+		rebuilt, err := trie.TrieFromSnapshot(snapshot, depth)
+		require.NoError(t, err)
+		assert.Equal(t, count, rebuilt.NumOfItems())
+		assert.Equal(t, snapshot.DepositRoot, rebuilt.HashTreeRoot(), "count %d", count)
+		for i := count; i < len(items); i++ {
+			require.NoError(t, rebuilt.Insert(items[i], i))
+			require.NoError(t, partial.Insert(items[i], i))
+			assert.Equal(t, partial.HashTreeRoot(), rebuilt.HashTreeRoot(), "count %d, index %d", count, i)
+		}
+		for i := count; i < len(items); i++ {
+			want, err := full.MerkleProof(i)
+			require.NoError(t, err)
+			got, err := rebuilt.MerkleProof(i)
+			require.NoError(t, err)
+			assert.DeepEqual(t, want, got, "count %d, index %d", count, i)
+		}
+		// Snapshots of a rebuilt trie only depend on the finalized nodes.
+		later, err := rebuilt.Snapshot(uint64(len(items)), [32]byte{'b'}, 200)
+		require.NoError(t, err)
+		assert.Equal(t, full.HashTreeRoot(), later.DepositRoot)
+	}
+}
+
+func TestDepositTreeSnapshot_Errors(t *testing.T) {
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	items := [][]byte{[]byte("A"), []byte("BB"), []byte("CCC")}
+	m, err := trie.GenerateTrieFromItems(items, depth)
+	require.NoError(t, err)
+
+	_, err = m.Snapshot(4, [32]byte{}, 0)
+	require.ErrorContains(t, "cannot snapshot 4 items", err)
+
+	snapshot, err := m.Snapshot(3, [32]byte{}, 0)
+	require.NoError(t, err)
+	snapshot.DepositRoot = [32]byte{'a'}
+	_, err = trie.TrieFromSnapshot(snapshot, depth)
+	require.ErrorContains(t, "does not match", err)
+}
+
+func TestDepositTreeSnapshot_SSZ(t *testing.T) {
+	snapshot := &trie.DepositTreeSnapshot{
+		Finalized:            [][32]byte{{'a'}, {'b'}},
+		DepositRoot:          [32]byte{'c'},
+		DepositCount:         3,
+		ExecutionBlockHash:   [32]byte{'d'},
+		ExecutionBlockHeight: 1000,
+	}
+	enc, err := snapshot.MarshalSSZ()
+	require.NoError(t, err)
+	assert.Equal(t, 84+2*32, len(enc))
+	decoded := &trie.DepositTreeSnapshot{}
+	require.NoError(t, decoded.UnmarshalSSZ(enc))
+	assert.DeepEqual(t, snapshot, decoded)
+
+	require.ErrorContains(t, "incorrect size", decoded.UnmarshalSSZ(enc[:80]))
+	require.ErrorContains(t, "could not decode deposit tree snapshot", decoded.UnmarshalSSZ(enc[:100]))
+
+	// Snapshots of the deposit tree have at most one finalized node per level.
+	snapshot.Finalized = make([][32]byte, 33)
+	_, err = snapshot.MarshalSSZ()
+	require.ErrorContains(t, "list length is higher than max value", err)
+	tooLong := append(enc[:84:84], make([]byte, 33*32)...)
+	require.ErrorContains(t, "could not decode deposit tree snapshot", decoded.UnmarshalSSZ(tooLong))
+}
@@ -0,0 +1,118 @@
+package detect
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	v2 "github.com/prysmaticlabs/prysm/beacon-chain/state/v2"
+	v3 "github.com/prysmaticlabs/prysm/beacon-chain/state/v3"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+)
+
+// Offsets of the fields of SSZ encoded values used to detect their fork: the current version of the
+// fork of a BeaconState, after its genesis time, genesis validators root, slot and previous version,
+// and the slot of the message of a SignedBeaconBlock, after the message offset and the signature.
+const (
+	stateForkVersionOffset = 8 + 32 + 8 + 4
+	blockSlotOffset        = 4 + 96
+)
+
+// ErrForkNotFound is returned when the fork version of a value does not match any fork of the
+// beacon chain config.
+var ErrForkNotFound = errors.New("no fork in the beacon chain config matches the fork version")
+
+// VersionedUnmarshaler unmarshals SSZ encoded values of the fork they were detected to be from.
+type VersionedUnmarshaler struct {
+	Config  *params.BeaconChainConfig
+	Fork    int
+	Version [4]byte
+}
+
+// FromState detects the fork of an SSZ encoded BeaconState from the current version of its fork field.
+func FromState(marshaled []byte) (*VersionedUnmarshaler, error) {
+	if len(marshaled) < stateForkVersionOffset+4 {
+		return nil, fmt.Errorf("encoded state is %d bytes, too short to contain a fork version", len(marshaled))
+	}
+	return FromForkVersion(bytesutil.ToBytes4(marshaled[stateForkVersionOffset : stateForkVersionOffset+4]))
+}
+
+// FromForkVersion maps a fork version to the fork of the current beacon chain config.
+func FromForkVersion(cv [4]byte) (*VersionedUnmarshaler, error) {
+	cfg := params.BeaconConfig()
+	var fork int
+	switch cv {
+	case bytesutil.ToBytes4(cfg.GenesisForkVersion):
+		fork = version.Phase0
+	case bytesutil.ToBytes4(cfg.AltairForkVersion):
+		fork = version.Altair
+	case bytesutil.ToBytes4(cfg.BellatrixForkVersion):
+		fork = version.Bellatrix
+	default:
+		return nil, errors.Wrapf(ErrForkNotFound, "version=%#x", cv)
+	}
+	return &VersionedUnmarshaler{
+		Config:  cfg,
+		Fork:    fork,
+		Version: cv,
+	}, nil
+}
+
+// UnmarshalBeaconState unmarshals an SSZ encoded BeaconState of the detected fork.
+func (cf *VersionedUnmarshaler) UnmarshalBeaconState(marshaled []byte) (state.BeaconState, error) {
+	switch cf.Fork {
+	case version.Phase0:
+		st := &ethpb.BeaconState{}
+		if err := st.UnmarshalSSZ(marshaled); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal phase0 state")
+		}
+		return v1.InitializeFromProtoUnsafe(st)
+	case version.Altair:
+		st := &ethpb.BeaconStateAltair{}
+		if err := st.UnmarshalSSZ(marshaled); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal altair state")
+		}
+		return v2.InitializeFromProtoUnsafe(st)
+	case version.Bellatrix:
+		st := &ethpb.BeaconStateBellatrix{}
+		if err := st.UnmarshalSSZ(marshaled); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal bellatrix state")
+		}
+		return v3.InitializeFromProtoUnsafe(st)
+	default:
+		return nil, fmt.Errorf("unable to unmarshal state of unsupported fork %s", version.String(cf.Fork))
+	}
+}
+
+// UnmarshalBeaconBlock unmarshals an SSZ encoded SignedBeaconBlock. As blocks prior to a fork may still be
+// referenced by states of the fork, the fork of the block is detected from its slot instead.
+func (cf *VersionedUnmarshaler) UnmarshalBeaconBlock(marshaled []byte) (block.SignedBeaconBlock, error) {
+	if len(marshaled) < blockSlotOffset+8 {
+		return nil, fmt.Errorf("encoded block is %d bytes, too short to contain a slot", len(marshaled))
+	}
+	slot := types.Slot(binary.LittleEndian.Uint64(marshaled[blockSlotOffset : blockSlotOffset+8]))
+	epoch := types.Epoch(slot / cf.Config.SlotsPerEpoch)
+	var blk interface {
+		UnmarshalSSZ([]byte) error
+	}
+	switch {
+	case epoch >= cf.Config.BellatrixForkEpoch:
+		blk = &ethpb.SignedBeaconBlockBellatrix{}
+	case epoch >= cf.Config.AltairForkEpoch:
+		blk = &ethpb.SignedBeaconBlockAltair{}
+	default:
+		blk = &ethpb.SignedBeaconBlock{}
+	}
+	if err := blk.UnmarshalSSZ(marshaled); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal block at slot %d", slot)
+	}
+	return wrapper.WrappedSignedBeaconBlock(blk)
+}
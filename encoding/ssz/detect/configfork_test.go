@@ -0,0 +1,105 @@
+package detect
+
+import (
+	"context"
+
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestFromState(t *testing.T) {
+	cfg := params.BeaconConfig()
+	phase0, _ := util.DeterministicGenesisState(t, 8)
+	altair, _ := util.DeterministicGenesisStateAltair(t, 8)
+	bellatrix, _ := util.DeterministicGenesisStateBellatrix(t, 8)
+
+	tests := []struct {
+		name    string
+		st      state.BeaconState
+		version []byte
+		fork    int
+	}{
+		{name: "phase0", st: phase0, version: cfg.GenesisForkVersion, fork: version.Phase0},
+		{name: "altair", st: altair, version: cfg.AltairForkVersion, fork: version.Altair},
+		{name: "bellatrix", st: bellatrix, version: cfg.BellatrixForkVersion, fork: version.Bellatrix},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.st.SetFork(&ethpb.Fork{
+				PreviousVersion: tt.version,
+				CurrentVersion:  tt.version,
+			}))
+			require.NoError(t, tt.st.SetSlot(10))
+			marshaled, err := tt.st.MarshalSSZ()
+			require.NoError(t, err)
+
+			cf, err := FromState(marshaled)
+			require.NoError(t, err)
+			assert.Equal(t, tt.fork, cf.Fork)
+			st, err := cf.UnmarshalBeaconState(marshaled)
+			require.NoError(t, err)
+			assert.Equal(t, tt.fork, st.Version())
+			wantRoot, err := tt.st.HashTreeRoot(context.Background())
+			require.NoError(t, err)
+			gotRoot, err := st.HashTreeRoot(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, wantRoot, gotRoot)
+		})
+	}
+
+	_, err := FromState([]byte{1, 2, 3})
+	require.ErrorContains(t, "too short", err)
+	require.NoError(t, phase0.SetFork(&ethpb.Fork{
+		PreviousVersion: []byte{0xff, 0xff, 0xff, 0xff},
+		CurrentVersion:  []byte{0xff, 0xff, 0xff, 0xff},
+	}))
+	marshaled, err := phase0.MarshalSSZ()
+	require.NoError(t, err)
+	_, err = FromState(marshaled)
+	require.ErrorIs(t, err, ErrForkNotFound)
+}
+
+func TestUnmarshalBeaconBlock(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 1
+	cfg.BellatrixForkEpoch = 2
+	params.OverrideBeaconConfig(cfg)
+	cf, err := FromForkVersion([4]byte{})
+	require.NoError(t, err)
+
+	phase0 := util.NewBeaconBlock()
+	phase0.Block.Slot = 1
+	altair := util.NewBeaconBlockAltair()
+	altair.Block.Slot = cfg.SlotsPerEpoch
+	bellatrix := util.NewBeaconBlockBellatrix()
+	bellatrix.Block.Slot = 2 * cfg.SlotsPerEpoch
+
+	tests := []struct {
+		name string
+		blk  interface {
+			MarshalSSZ() ([]byte, error)
+		}
+		fork int
+	}{
+		{name: "phase0", blk: phase0, fork: version.Phase0},
+		{name: "altair", blk: altair, fork: version.Altair},
+		{name: "bellatrix", blk: bellatrix, fork: version.Bellatrix},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			marshaled, err := tt.blk.MarshalSSZ()
+			require.NoError(t, err)
+			blk, err := cf.UnmarshalBeaconBlock(marshaled)
+			require.NoError(t, err)
+			assert.Equal(t, tt.fork, blk.Version())
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package backup
 
 import (
+	"compress/gzip"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -13,6 +16,11 @@ type BackupExporter interface {
 	Backup(ctx context.Context, outputPath string, permissionOverride bool) error
 }
 
+// BackupStreamer defines a method to write a consistent snapshot of a database.
+type BackupStreamer interface {
+	BackupTo(ctx context.Context, w io.Writer) (int64, error)
+}
+
 // BackupHandler for accepting requests to initiate a new database backup.
 func BackupHandler(bk BackupExporter, outputDir string) func(http.ResponseWriter, *http.Request) {
 	log := logrus.WithField("prefix", "db")
@@ -34,3 +42,63 @@ func BackupHandler(bk BackupExporter, outputDir string) func(http.ResponseWriter
 		}
 	}
 }
+
+// StreamBackupHandler for accepting requests to download a consistent snapshot of the database.
+// The snapshot is streamed in the response as a bolt database file named after the given prefix,
+// gzip compressed if the gzip query parameter is set.
+func StreamBackupHandler(bs BackupStreamer, namePrefix string) func(http.ResponseWriter, *http.Request) {
+	log := logrus.WithField("prefix", "db")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		log.Debug("Streaming database backup from HTTP webhook")
+
+		_, compress := r.URL.Query()["gzip"]
+		filename := fmt.Sprintf("%s_%d.backup", namePrefix, time.Now().Unix())
+		sent := &countingWriter{w: w}
+		var out io.Writer = sent
+		var gz *gzip.Writer
+		if compress {
+			filename += ".gz"
+			gz = gzip.NewWriter(sent)
+			out = gz
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+		start := time.Now()
+		n, err := bs.BackupTo(r.Context(), out)
+		if err == nil && gz != nil {
+			err = gz.Close()
+		}
+		if err != nil {
+			log.WithError(err).Error("Failed to stream backup")
+			if sent.n == 0 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			// The status cannot be changed once the snapshot started streaming. The connection is
+			// aborted so that the client does not get a truncated snapshot which looks complete.
+			panic(http.ErrAbortHandler)
+		}
+		log.WithFields(logrus.Fields{
+			"bytes":    n,
+			"duration": time.Since(start),
+		}).Info("Streamed database backup")
+	}
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
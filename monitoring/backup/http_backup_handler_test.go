@@ -0,0 +1,88 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+type mockStreamer struct {
+	data []byte
+	err  error
+}
+
+func (m *mockStreamer) BackupTo(_ context.Context, w io.Writer) (int64, error) {
+	if len(m.data) == 0 {
+		return 0, m.err
+	}
+	n, err := w.Write(m.data)
+	if err != nil {
+		return int64(n), err
+	}
+	return int64(n), m.err
+}
+
+func TestStreamBackupHandler(t *testing.T) {
+	data := bytes.Repeat([]byte("snapshot"), 100)
+	handler := StreamBackupHandler(&mockStreamer{data: data}, "prysm_beacondb")
+
+	t.Run("raw", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/db/backup/stream", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.DeepEqual(t, data, w.Body.Bytes())
+		disposition := w.Header().Get("Content-Disposition")
+		assert.Equal(t, true, strings.HasPrefix(disposition, `attachment; filename="prysm_beacondb_`))
+		assert.Equal(t, true, strings.HasSuffix(disposition, `.backup"`))
+	})
+	t.Run("gzip", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/db/backup/stream?gzip", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, true, strings.HasSuffix(w.Header().Get("Content-Disposition"), `.backup.gz"`))
+		r, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+		decompressed, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		assert.DeepEqual(t, data, decompressed)
+	})
+	t.Run("method not allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodPost, "/db/backup/stream", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+	t.Run("failure", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		StreamBackupHandler(&mockStreamer{err: errors.New("bad")}, "prysm_beacondb")(w, httptest.NewRequest(http.MethodGet, "/db/backup/stream?gzip", nil))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, 0, w.Body.Len())
+	})
+	t.Run("failure while streaming", func(t *testing.T) {
+		// The gzip stream is flushed to the response past its buffer size.
+		data := make([]byte, 1<<20)
+		_, err := rand.Read(data)
+		require.NoError(t, err)
+		for _, target := range []string{"/db/backup/stream", "/db/backup/stream?gzip"} {
+			w := httptest.NewRecorder()
+			h := StreamBackupHandler(&mockStreamer{data: data, err: errors.New("bad")}, "prysm_beacondb")
+			func() {
+				defer func() {
+					assert.Equal(t, http.ErrAbortHandler, recover())
+				}()
+				h(w, httptest.NewRequest(http.MethodGet, target, nil))
+			}()
+			assert.NotEqual(t, 0, w.Body.Len())
+		}
+	})
+}
@@ -0,0 +1,239 @@
+package enginev1
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	types "github.com/prysmaticlabs/eth2-types"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+)
+
+// ExecutionPayloadCapellaFromV1 converts a Bellatrix execution payload to the Capella
+// format, without any withdrawals.
+func ExecutionPayloadCapellaFromV1(p *ExecutionPayload) *ExecutionPayloadCapella {
+	return &ExecutionPayloadCapella{
+		ParentHash:    p.ParentHash,
+		FeeRecipient:  p.FeeRecipient,
+		StateRoot:     p.StateRoot,
+		ReceiptsRoot:  p.ReceiptsRoot,
+		LogsBloom:     p.LogsBloom,
+		PrevRandao:    p.Random,
+		BlockNumber:   p.BlockNumber,
+		GasLimit:      p.GasLimit,
+		GasUsed:       p.GasUsed,
+		Timestamp:     p.Timestamp,
+		ExtraData:     p.ExtraData,
+		BaseFeePerGas: p.BaseFeePerGas,
+		BlockHash:     p.BlockHash,
+		Transactions:  p.Transactions,
+	}
+}
+
+// ToV1 converts the payload to the Bellatrix format, dropping its withdrawals.
+func (p *ExecutionPayloadCapella) ToV1() *ExecutionPayload {
+	return &ExecutionPayload{
+		ParentHash:    p.ParentHash,
+		FeeRecipient:  p.FeeRecipient,
+		StateRoot:     p.StateRoot,
+		ReceiptsRoot:  p.ReceiptsRoot,
+		LogsBloom:     p.LogsBloom,
+		Random:        p.PrevRandao,
+		BlockNumber:   p.BlockNumber,
+		GasLimit:      p.GasLimit,
+		GasUsed:       p.GasUsed,
+		Timestamp:     p.Timestamp,
+		ExtraData:     p.ExtraData,
+		BaseFeePerGas: p.BaseFeePerGas,
+		BlockHash:     p.BlockHash,
+		Transactions:  p.Transactions,
+	}
+}
+
+// ToV1 converts the attributes to the Bellatrix format, dropping their withdrawals.
+func (p *PayloadAttributesV2) ToV1() *PayloadAttributes {
+	return &PayloadAttributes{
+		Timestamp:             p.Timestamp,
+		Random:                p.PrevRandao,
+		SuggestedFeeRecipient: p.SuggestedFeeRecipient,
+	}
+}
+
+type withdrawalJSON struct {
+	Index          hexutil.Uint64 `json:"index"`
+	ValidatorIndex hexutil.Uint64 `json:"validatorIndex"`
+	Address        hexutil.Bytes  `json:"address"`
+	Amount         hexutil.Uint64 `json:"amount"`
+}
+
+// MarshalJSON --
+func (w *Withdrawal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(withdrawalJSON{
+		Index:          hexutil.Uint64(w.Index),
+		ValidatorIndex: hexutil.Uint64(w.ValidatorIndex),
+		Address:        w.Address,
+		Amount:         hexutil.Uint64(w.Amount),
+	})
+}
+
+// UnmarshalJSON --
+func (w *Withdrawal) UnmarshalJSON(enc []byte) error {
+	dec := withdrawalJSON{}
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		return err
+	}
+	*w = Withdrawal{}
+	w.Index = uint64(dec.Index)
+	w.ValidatorIndex = types.ValidatorIndex(dec.ValidatorIndex)
+	w.Address = bytesutil.PadTo(dec.Address, fieldparams.FeeRecipientLength)
+	w.Amount = uint64(dec.Amount)
+	return nil
+}
+
+type executionPayloadCapellaJSON struct {
+	ParentHash    hexutil.Bytes   `json:"parentHash"`
+	FeeRecipient  hexutil.Bytes   `json:"feeRecipient"`
+	StateRoot     hexutil.Bytes   `json:"stateRoot"`
+	ReceiptsRoot  hexutil.Bytes   `json:"receiptsRoot"`
+	LogsBloom     hexutil.Bytes   `json:"logsBloom"`
+	PrevRandao    hexutil.Bytes   `json:"prevRandao"`
+	BlockNumber   hexutil.Uint64  `json:"blockNumber"`
+	GasLimit      hexutil.Uint64  `json:"gasLimit"`
+	GasUsed       hexutil.Uint64  `json:"gasUsed"`
+	Timestamp     hexutil.Uint64  `json:"timestamp"`
+	ExtraData     hexutil.Bytes   `json:"extraData"`
+	BaseFeePerGas string          `json:"baseFeePerGas"`
+	BlockHash     hexutil.Bytes   `json:"blockHash"`
+	Transactions  []hexutil.Bytes `json:"transactions"`
+	Withdrawals   []*Withdrawal   `json:"withdrawals"`
+}
+
+// MarshalJSON --
+func (e *ExecutionPayloadCapella) MarshalJSON() ([]byte, error) {
+	transactions := make([]hexutil.Bytes, len(e.Transactions))
+	for i, tx := range e.Transactions {
+		transactions[i] = tx
+	}
+	withdrawals := e.Withdrawals
+	if withdrawals == nil {
+		withdrawals = make([]*Withdrawal, 0)
+	}
+	baseFee := new(big.Int).SetBytes(e.BaseFeePerGas)
+	baseFeeHex := hexutil.EncodeBig(baseFee)
+	return json.Marshal(executionPayloadCapellaJSON{
+		ParentHash:    e.ParentHash,
+		FeeRecipient:  e.FeeRecipient,
+		StateRoot:     e.StateRoot,
+		ReceiptsRoot:  e.ReceiptsRoot,
+		LogsBloom:     e.LogsBloom,
+		PrevRandao:    e.PrevRandao,
+		BlockNumber:   hexutil.Uint64(e.BlockNumber),
+		GasLimit:      hexutil.Uint64(e.GasLimit),
+		GasUsed:       hexutil.Uint64(e.GasUsed),
+		Timestamp:     hexutil.Uint64(e.Timestamp),
+		ExtraData:     e.ExtraData,
+		BaseFeePerGas: baseFeeHex,
+		BlockHash:     e.BlockHash,
+		Transactions:  transactions,
+		Withdrawals:   withdrawals,
+	})
+}
+
+// UnmarshalJSON --
+func (e *ExecutionPayloadCapella) UnmarshalJSON(enc []byte) error {
+	dec := executionPayloadCapellaJSON{}
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		return err
+	}
+	*e = ExecutionPayloadCapella{}
+	e.ParentHash = bytesutil.PadTo(dec.ParentHash, fieldparams.RootLength)
+	e.FeeRecipient = bytesutil.PadTo(dec.FeeRecipient, fieldparams.FeeRecipientLength)
+	e.StateRoot = bytesutil.PadTo(dec.StateRoot, fieldparams.RootLength)
+	e.ReceiptsRoot = bytesutil.PadTo(dec.ReceiptsRoot, fieldparams.RootLength)
+	e.LogsBloom = bytesutil.PadTo(dec.LogsBloom, fieldparams.LogsBloomLength)
+	e.PrevRandao = bytesutil.PadTo(dec.PrevRandao, fieldparams.RootLength)
+	e.BlockNumber = uint64(dec.BlockNumber)
+	e.GasLimit = uint64(dec.GasLimit)
+	e.GasUsed = uint64(dec.GasUsed)
+	e.Timestamp = uint64(dec.Timestamp)
+	e.ExtraData = dec.ExtraData
+	baseFee, err := hexutil.DecodeBig(dec.BaseFeePerGas)
+	if err != nil {
+		return err
+	}
+	e.BaseFeePerGas = bytesutil.PadTo(baseFee.Bytes(), fieldparams.RootLength)
+	e.BlockHash = bytesutil.PadTo(dec.BlockHash, fieldparams.RootLength)
+	transactions := make([][]byte, len(dec.Transactions))
+	for i, tx := range dec.Transactions {
+		transactions[i] = tx
+	}
+	e.Transactions = transactions
+	e.Withdrawals = dec.Withdrawals
+	if e.Withdrawals == nil {
+		e.Withdrawals = make([]*Withdrawal, 0)
+	}
+	return nil
+}
+
+type executionPayloadCapellaWithValueJSON struct {
+	ExecutionPayload *ExecutionPayloadCapella `json:"executionPayload"`
+	BlockValue       *hexutil.Big             `json:"blockValue"`
+}
+
+// MarshalJSON --
+func (e *ExecutionPayloadCapellaWithValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(executionPayloadCapellaWithValueJSON{
+		ExecutionPayload: e.Payload,
+		BlockValue:       (*hexutil.Big)(new(big.Int).SetBytes(e.BlockValue)),
+	})
+}
+
+// UnmarshalJSON --
+func (e *ExecutionPayloadCapellaWithValue) UnmarshalJSON(enc []byte) error {
+	dec := executionPayloadCapellaWithValueJSON{}
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		return err
+	}
+	*e = ExecutionPayloadCapellaWithValue{}
+	e.Payload = dec.ExecutionPayload
+	if dec.BlockValue != nil {
+		e.BlockValue = dec.BlockValue.ToInt().Bytes()
+	}
+	return nil
+}
+
+type payloadAttributesV2JSON struct {
+	Timestamp             hexutil.Uint64 `json:"timestamp"`
+	PrevRandao            hexutil.Bytes  `json:"prevRandao"`
+	SuggestedFeeRecipient hexutil.Bytes  `json:"suggestedFeeRecipient"`
+	Withdrawals           []*Withdrawal  `json:"withdrawals"`
+}
+
+// MarshalJSON --
+func (p *PayloadAttributesV2) MarshalJSON() ([]byte, error) {
+	withdrawals := p.Withdrawals
+	if withdrawals == nil {
+		withdrawals = make([]*Withdrawal, 0)
+	}
+	return json.Marshal(payloadAttributesV2JSON{
+		Timestamp:             hexutil.Uint64(p.Timestamp),
+		PrevRandao:            p.PrevRandao,
+		SuggestedFeeRecipient: p.SuggestedFeeRecipient,
+		Withdrawals:           withdrawals,
+	})
+}
+
+// UnmarshalJSON --
+func (p *PayloadAttributesV2) UnmarshalJSON(enc []byte) error {
+	dec := payloadAttributesV2JSON{}
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		return err
+	}
+	*p = PayloadAttributesV2{}
+	p.Timestamp = uint64(dec.Timestamp)
+	p.PrevRandao = dec.PrevRandao
+	p.SuggestedFeeRecipient = dec.SuggestedFeeRecipient
+	p.Withdrawals = dec.Withdrawals
+	return nil
+}
@@ -0,0 +1,181 @@
+package enginev1
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+)
+
+type executionPayloadDenebJSON struct {
+	ParentHash    hexutil.Bytes   `json:"parentHash"`
+	FeeRecipient  hexutil.Bytes   `json:"feeRecipient"`
+	StateRoot     hexutil.Bytes   `json:"stateRoot"`
+	ReceiptsRoot  hexutil.Bytes   `json:"receiptsRoot"`
+	LogsBloom     hexutil.Bytes   `json:"logsBloom"`
+	PrevRandao    hexutil.Bytes   `json:"prevRandao"`
+	BlockNumber   hexutil.Uint64  `json:"blockNumber"`
+	GasLimit      hexutil.Uint64  `json:"gasLimit"`
+	GasUsed       hexutil.Uint64  `json:"gasUsed"`
+	Timestamp     hexutil.Uint64  `json:"timestamp"`
+	ExtraData     hexutil.Bytes   `json:"extraData"`
+	BaseFeePerGas string          `json:"baseFeePerGas"`
+	BlockHash     hexutil.Bytes   `json:"blockHash"`
+	Transactions  []hexutil.Bytes `json:"transactions"`
+	Withdrawals   []*Withdrawal   `json:"withdrawals"`
+	BlobGasUsed   hexutil.Uint64  `json:"blobGasUsed"`
+	ExcessBlobGas hexutil.Uint64  `json:"excessBlobGas"`
+}
+
+// MarshalJSON --
+func (e *ExecutionPayloadDeneb) MarshalJSON() ([]byte, error) {
+	transactions := make([]hexutil.Bytes, len(e.Transactions))
+	for i, tx := range e.Transactions {
+		transactions[i] = tx
+	}
+	withdrawals := e.Withdrawals
+	if withdrawals == nil {
+		withdrawals = make([]*Withdrawal, 0)
+	}
+	baseFee := new(big.Int).SetBytes(e.BaseFeePerGas)
+	baseFeeHex := hexutil.EncodeBig(baseFee)
+	return json.Marshal(executionPayloadDenebJSON{
+		ParentHash:    e.ParentHash,
+		FeeRecipient:  e.FeeRecipient,
+		StateRoot:     e.StateRoot,
+		ReceiptsRoot:  e.ReceiptsRoot,
+		LogsBloom:     e.LogsBloom,
+		PrevRandao:    e.PrevRandao,
+		BlockNumber:   hexutil.Uint64(e.BlockNumber),
+		GasLimit:      hexutil.Uint64(e.GasLimit),
+		GasUsed:       hexutil.Uint64(e.GasUsed),
+		Timestamp:     hexutil.Uint64(e.Timestamp),
+		ExtraData:     e.ExtraData,
+		BaseFeePerGas: baseFeeHex,
+		BlockHash:     e.BlockHash,
+		Transactions:  transactions,
+		Withdrawals:   withdrawals,
+		BlobGasUsed:   hexutil.Uint64(e.BlobGasUsed),
+		ExcessBlobGas: hexutil.Uint64(e.ExcessBlobGas),
+	})
+}
+
+// UnmarshalJSON --
+func (e *ExecutionPayloadDeneb) UnmarshalJSON(enc []byte) error {
+	dec := executionPayloadDenebJSON{}
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		return err
+	}
+	*e = ExecutionPayloadDeneb{}
+	e.ParentHash = bytesutil.PadTo(dec.ParentHash, fieldparams.RootLength)
+	e.FeeRecipient = bytesutil.PadTo(dec.FeeRecipient, fieldparams.FeeRecipientLength)
+	e.StateRoot = bytesutil.PadTo(dec.StateRoot, fieldparams.RootLength)
+	e.ReceiptsRoot = bytesutil.PadTo(dec.ReceiptsRoot, fieldparams.RootLength)
+	e.LogsBloom = bytesutil.PadTo(dec.LogsBloom, fieldparams.LogsBloomLength)
+	e.PrevRandao = bytesutil.PadTo(dec.PrevRandao, fieldparams.RootLength)
+	e.BlockNumber = uint64(dec.BlockNumber)
+	e.GasLimit = uint64(dec.GasLimit)
+	e.GasUsed = uint64(dec.GasUsed)
+	e.Timestamp = uint64(dec.Timestamp)
+	e.ExtraData = dec.ExtraData
+	baseFee, err := hexutil.DecodeBig(dec.BaseFeePerGas)
+	if err != nil {
+		return err
+	}
+	e.BaseFeePerGas = bytesutil.PadTo(baseFee.Bytes(), fieldparams.RootLength)
+	e.BlockHash = bytesutil.PadTo(dec.BlockHash, fieldparams.RootLength)
+	transactions := make([][]byte, len(dec.Transactions))
+	for i, tx := range dec.Transactions {
+		transactions[i] = tx
+	}
+	e.Transactions = transactions
+	e.Withdrawals = dec.Withdrawals
+	if e.Withdrawals == nil {
+		e.Withdrawals = make([]*Withdrawal, 0)
+	}
+	e.BlobGasUsed = uint64(dec.BlobGasUsed)
+	e.ExcessBlobGas = uint64(dec.ExcessBlobGas)
+	return nil
+}
+
+type blobsBundleJSON struct {
+	Commitments []hexutil.Bytes `json:"commitments"`
+	Proofs      []hexutil.Bytes `json:"proofs"`
+	Blobs       []hexutil.Bytes `json:"blobs"`
+}
+
+// MarshalJSON --
+func (b *BlobsBundle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(blobsBundleJSON{
+		Commitments: toHexBytesList(b.KzgCommitments),
+		Proofs:      toHexBytesList(b.Proofs),
+		Blobs:       toHexBytesList(b.Blobs),
+	})
+}
+
+// UnmarshalJSON --
+func (b *BlobsBundle) UnmarshalJSON(enc []byte) error {
+	dec := blobsBundleJSON{}
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		return err
+	}
+	*b = BlobsBundle{}
+	b.KzgCommitments = fromHexBytesList(dec.Commitments)
+	b.Proofs = fromHexBytesList(dec.Proofs)
+	b.Blobs = fromHexBytesList(dec.Blobs)
+	return nil
+}
+
+type executionPayloadDenebWithValueAndBlobsBundleJSON struct {
+	ExecutionPayload      *ExecutionPayloadDeneb `json:"executionPayload"`
+	BlockValue            *hexutil.Big           `json:"blockValue"`
+	BlobsBundle           *BlobsBundle           `json:"blobsBundle"`
+	ShouldOverrideBuilder bool                   `json:"shouldOverrideBuilder"`
+}
+
+// MarshalJSON --
+func (e *ExecutionPayloadDenebWithValueAndBlobsBundle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(executionPayloadDenebWithValueAndBlobsBundleJSON{
+		ExecutionPayload:      e.Payload,
+		BlockValue:            (*hexutil.Big)(new(big.Int).SetBytes(e.BlockValue)),
+		BlobsBundle:           e.BlobsBundle,
+		ShouldOverrideBuilder: e.ShouldOverrideBuilder,
+	})
+}
+
+// UnmarshalJSON --
+func (e *ExecutionPayloadDenebWithValueAndBlobsBundle) UnmarshalJSON(enc []byte) error {
+	dec := executionPayloadDenebWithValueAndBlobsBundleJSON{}
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		return err
+	}
+	*e = ExecutionPayloadDenebWithValueAndBlobsBundle{}
+	e.Payload = dec.ExecutionPayload
+	if dec.BlockValue != nil {
+		e.BlockValue = dec.BlockValue.ToInt().Bytes()
+	}
+	e.BlobsBundle = dec.BlobsBundle
+	if e.BlobsBundle == nil {
+		e.BlobsBundle = &BlobsBundle{}
+	}
+	e.ShouldOverrideBuilder = dec.ShouldOverrideBuilder
+	return nil
+}
+
+func toHexBytesList(items [][]byte) []hexutil.Bytes {
+	res := make([]hexutil.Bytes, len(items))
+	for i, item := range items {
+		res[i] = item
+	}
+	return res
+}
+
+func fromHexBytesList(items []hexutil.Bytes) [][]byte {
+	res := make([][]byte, len(items))
+	for i, item := range items {
+		res[i] = item
+	}
+	return res
+}
@@ -3,6 +3,7 @@ package enginev1_test
 import (
 	"encoding/json"
 	"math/big"
+	"strings"
 	"testing"
 
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
@@ -43,6 +44,16 @@ func TestJsonMarshalUnmarshal(t *testing.T) {
 		require.DeepEqual(t, hash, payloadPb.LatestValidHash)
 		require.DeepEqual(t, "failed validation", payloadPb.ValidationError)
 	})
+	t.Run("execution payload body", func(t *testing.T) {
+		jsonPayload := &enginev1.ExecutionPayloadBodyV1{
+			Transactions: [][]byte{[]byte("hi"), []byte("there")},
+		}
+		enc, err := json.Marshal(jsonPayload)
+		require.NoError(t, err)
+		payloadPb := &enginev1.ExecutionPayloadBodyV1{}
+		require.NoError(t, json.Unmarshal(enc, payloadPb))
+		require.DeepEqual(t, jsonPayload.Transactions, payloadPb.Transactions)
+	})
 	t.Run("forkchoice state", func(t *testing.T) {
 		head := bytesutil.PadTo([]byte("head"), fieldparams.RootLength)
 		safe := bytesutil.PadTo([]byte("safe"), fieldparams.RootLength)
@@ -105,6 +116,110 @@ func TestJsonMarshalUnmarshal(t *testing.T) {
 		require.DeepEqual(t, hash, payloadPb.BlockHash)
 		require.DeepEqual(t, [][]byte{[]byte("hi")}, payloadPb.Transactions)
 	})
+	t.Run("payload attributes v2", func(t *testing.T) {
+		random := bytesutil.PadTo([]byte("random"), fieldparams.RootLength)
+		feeRecipient := bytesutil.PadTo([]byte("feeRecipient"), fieldparams.FeeRecipientLength)
+		withdrawals := []*enginev1.Withdrawal{{
+			Index:          1,
+			ValidatorIndex: 2,
+			Address:        feeRecipient,
+			Amount:         3,
+		}}
+		jsonPayload := &enginev1.PayloadAttributesV2{
+			Timestamp:             1,
+			PrevRandao:            random,
+			SuggestedFeeRecipient: feeRecipient,
+			Withdrawals:           withdrawals,
+		}
+		enc, err := json.Marshal(jsonPayload)
+		require.NoError(t, err)
+		payloadPb := &enginev1.PayloadAttributesV2{}
+		require.NoError(t, json.Unmarshal(enc, payloadPb))
+		require.DeepEqual(t, jsonPayload, payloadPb)
+	})
+	t.Run("execution payload capella", func(t *testing.T) {
+		baseFeePerGas := big.NewInt(6)
+		root := bytesutil.PadTo([]byte("root"), fieldparams.RootLength)
+		feeRecipient := bytesutil.PadTo([]byte("feeRecipient"), fieldparams.FeeRecipientLength)
+		jsonPayload := &enginev1.ExecutionPayloadCapellaWithValue{
+			Payload: &enginev1.ExecutionPayloadCapella{
+				ParentHash:    root,
+				FeeRecipient:  feeRecipient,
+				StateRoot:     root,
+				ReceiptsRoot:  root,
+				LogsBloom:     bytesutil.PadTo([]byte("logs"), fieldparams.LogsBloomLength),
+				PrevRandao:    root,
+				BlockNumber:   1,
+				GasLimit:      2,
+				GasUsed:       3,
+				Timestamp:     4,
+				ExtraData:     []byte("extra"),
+				BaseFeePerGas: bytesutil.PadTo(baseFeePerGas.Bytes(), fieldparams.RootLength),
+				BlockHash:     root,
+				Transactions:  [][]byte{[]byte("hi")},
+				Withdrawals: []*enginev1.Withdrawal{{
+					Index:          1,
+					ValidatorIndex: 2,
+					Address:        feeRecipient,
+					Amount:         3,
+				}},
+			},
+			BlockValue: big.NewInt(100).Bytes(),
+		}
+		enc, err := json.Marshal(jsonPayload)
+		require.NoError(t, err)
+		payloadPb := &enginev1.ExecutionPayloadCapellaWithValue{}
+		require.NoError(t, json.Unmarshal(enc, payloadPb))
+		require.DeepEqual(t, jsonPayload.Payload, payloadPb.Payload)
+		require.DeepEqual(t, jsonPayload.BlockValue, payloadPb.BlockValue)
+
+		// Payloads without withdrawals are encoded with an empty list, as required by the specification.
+		jsonPayload.Payload.Withdrawals = nil
+		enc, err = json.Marshal(jsonPayload.Payload)
+		require.NoError(t, err)
+		require.Equal(t, true, strings.Contains(string(enc), `"withdrawals":[]`))
+	})
+	t.Run("execution payload deneb with blobs bundle", func(t *testing.T) {
+		baseFeePerGas := big.NewInt(6)
+		root := bytesutil.PadTo([]byte("root"), fieldparams.RootLength)
+		feeRecipient := bytesutil.PadTo([]byte("feeRecipient"), fieldparams.FeeRecipientLength)
+		jsonPayload := &enginev1.ExecutionPayloadDenebWithValueAndBlobsBundle{
+			Payload: &enginev1.ExecutionPayloadDeneb{
+				ParentHash:    root,
+				FeeRecipient:  feeRecipient,
+				StateRoot:     root,
+				ReceiptsRoot:  root,
+				LogsBloom:     bytesutil.PadTo([]byte("logs"), fieldparams.LogsBloomLength),
+				PrevRandao:    root,
+				BlockNumber:   1,
+				GasLimit:      2,
+				GasUsed:       3,
+				Timestamp:     4,
+				ExtraData:     []byte("extra"),
+				BaseFeePerGas: bytesutil.PadTo(baseFeePerGas.Bytes(), fieldparams.RootLength),
+				BlockHash:     root,
+				Transactions:  [][]byte{[]byte("hi")},
+				Withdrawals:   []*enginev1.Withdrawal{},
+				BlobGasUsed:   5,
+				ExcessBlobGas: 6,
+			},
+			BlockValue: big.NewInt(100).Bytes(),
+			BlobsBundle: &enginev1.BlobsBundle{
+				KzgCommitments: [][]byte{[]byte("commitment")},
+				Proofs:         [][]byte{[]byte("proof")},
+				Blobs:          [][]byte{[]byte("blob")},
+			},
+			ShouldOverrideBuilder: true,
+		}
+		enc, err := json.Marshal(jsonPayload)
+		require.NoError(t, err)
+		payloadPb := &enginev1.ExecutionPayloadDenebWithValueAndBlobsBundle{}
+		require.NoError(t, json.Unmarshal(enc, payloadPb))
+		require.DeepEqual(t, jsonPayload.Payload, payloadPb.Payload)
+		require.DeepEqual(t, jsonPayload.BlobsBundle, payloadPb.BlobsBundle)
+		require.DeepEqual(t, jsonPayload.BlockValue, payloadPb.BlockValue)
+		require.Equal(t, true, payloadPb.ShouldOverrideBuilder)
+	})
 	t.Run("execution block", func(t *testing.T) {
 		jsonPayload := &enginev1.ExecutionBlock{
 			Number:           []byte("100"),
@@ -0,0 +1,49 @@
+package enginev1
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ExecutionPayloadBodyV1 contains the parts of an execution payload which are not
+// committed to in full by an execution payload header, as returned by the
+// engine_getPayloadBodiesByHashV1 and engine_getPayloadBodiesByRangeV1 methods.
+type ExecutionPayloadBodyV1 struct {
+	Transactions [][]byte
+	// Withdrawals of the payload, nil for payloads built before the Capella fork.
+	Withdrawals []*Withdrawal
+}
+
+type executionPayloadBodyJSON struct {
+	Transactions []hexutil.Bytes `json:"transactions"`
+	Withdrawals  []*Withdrawal   `json:"withdrawals"`
+}
+
+// MarshalJSON --
+func (b *ExecutionPayloadBodyV1) MarshalJSON() ([]byte, error) {
+	transactions := make([]hexutil.Bytes, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		transactions[i] = tx
+	}
+	return json.Marshal(executionPayloadBodyJSON{
+		Transactions: transactions,
+		Withdrawals:  b.Withdrawals,
+	})
+}
+
+// UnmarshalJSON --
+func (b *ExecutionPayloadBodyV1) UnmarshalJSON(enc []byte) error {
+	dec := executionPayloadBodyJSON{}
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		return err
+	}
+	*b = ExecutionPayloadBodyV1{}
+	transactions := make([][]byte, len(dec.Transactions))
+	for i, tx := range dec.Transactions {
+		transactions[i] = tx
+	}
+	b.Transactions = transactions
+	b.Withdrawals = dec.Withdrawals
+	return nil
+}
@@ -128,6 +128,61 @@ func (ConnectionState) EnumDescriptor() ([]byte, []int) {
 	return file_proto_eth_v1_node_proto_rawDescGZIP(), []int{1}
 }
 
+type HealthInfo_Stage int32
+
+const (
+	HealthInfo_READY       HealthInfo_Stage = 0
+	HealthInfo_BACKFILLING HealthInfo_Stage = 1
+	HealthInfo_OPTIMISTIC  HealthInfo_Stage = 2
+	HealthInfo_SYNCING     HealthInfo_Stage = 3
+	HealthInfo_EL_OFFLINE  HealthInfo_Stage = 4
+)
+
+// Enum value maps for HealthInfo_Stage.
+var (
+	HealthInfo_Stage_name = map[int32]string{
+		0: "READY",
+		1: "BACKFILLING",
+		2: "OPTIMISTIC",
+		3: "SYNCING",
+		4: "EL_OFFLINE",
+	}
+	HealthInfo_Stage_value = map[string]int32{
+		"READY":       0,
+		"BACKFILLING": 1,
+		"OPTIMISTIC":  2,
+		"SYNCING":     3,
+		"EL_OFFLINE":  4,
+	}
+)
+
+func (x HealthInfo_Stage) Enum() *HealthInfo_Stage {
+	p := new(HealthInfo_Stage)
+	*p = x
+	return p
+}
+
+func (x HealthInfo_Stage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HealthInfo_Stage) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_eth_v1_node_proto_enumTypes[2].Descriptor()
+}
+
+func (HealthInfo_Stage) Type() protoreflect.EnumType {
+	return &file_proto_eth_v1_node_proto_enumTypes[2]
+}
+
+func (x HealthInfo_Stage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HealthInfo_Stage.Descriptor instead.
+func (HealthInfo_Stage) EnumDescriptor() ([]byte, []int) {
+	return file_proto_eth_v1_node_proto_rawDescGZIP(), []int{14, 0}
+}
+
 type IdentityResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -843,6 +898,140 @@ func (x *SyncInfo) GetIsSyncing() bool {
 	return false
 }
 
+type HealthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data *HealthInfo `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_eth_v1_node_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eth_v1_node_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_proto_eth_v1_node_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *HealthResponse) GetData() *HealthInfo {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type HealthInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage         HealthInfo_Stage                         `protobuf:"varint,1,opt,name=stage,proto3,enum=ethereum.eth.v1.HealthInfo_Stage" json:"stage,omitempty"`
+	SyncDistance  github_com_prysmaticlabs_eth2_types.Slot `protobuf:"varint,2,opt,name=sync_distance,json=syncDistance,proto3" json:"sync_distance,omitempty" cast-type:"github.com/prysmaticlabs/eth2-types.Slot"`
+	IsSyncing     bool                                     `protobuf:"varint,3,opt,name=is_syncing,json=isSyncing,proto3" json:"is_syncing,omitempty"`
+	IsOptimistic  bool                                     `protobuf:"varint,4,opt,name=is_optimistic,json=isOptimistic,proto3" json:"is_optimistic,omitempty"`
+	ElOffline     bool                                     `protobuf:"varint,5,opt,name=el_offline,json=elOffline,proto3" json:"el_offline,omitempty"`
+	IsBackfilling bool                                     `protobuf:"varint,6,opt,name=is_backfilling,json=isBackfilling,proto3" json:"is_backfilling,omitempty"`
+}
+
+func (x *HealthInfo) Reset() {
+	*x = HealthInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_eth_v1_node_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HealthInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthInfo) ProtoMessage() {}
+
+func (x *HealthInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eth_v1_node_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthInfo.ProtoReflect.Descriptor instead.
+func (*HealthInfo) Descriptor() ([]byte, []int) {
+	return file_proto_eth_v1_node_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HealthInfo) GetStage() HealthInfo_Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return HealthInfo_READY
+}
+
+func (x *HealthInfo) GetSyncDistance() github_com_prysmaticlabs_eth2_types.Slot {
+	if x != nil {
+		return x.SyncDistance
+	}
+	return github_com_prysmaticlabs_eth2_types.Slot(0)
+}
+
+func (x *HealthInfo) GetIsSyncing() bool {
+	if x != nil {
+		return x.IsSyncing
+	}
+	return false
+}
+
+func (x *HealthInfo) GetIsOptimistic() bool {
+	if x != nil {
+		return x.IsOptimistic
+	}
+	return false
+}
+
+func (x *HealthInfo) GetElOffline() bool {
+	if x != nil {
+		return x.ElOffline
+	}
+	return false
+}
+
+func (x *HealthInfo) GetIsBackfilling() bool {
+	if x != nil {
+		return x.IsBackfilling
+	}
+	return false
+}
+
 type PeerResponse_Meta struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -854,7 +1043,7 @@ type PeerResponse_Meta struct {
 func (x *PeerResponse_Meta) Reset() {
 	*x = PeerResponse_Meta{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_eth_v1_node_proto_msgTypes[13]
+		mi := &file_proto_eth_v1_node_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -867,7 +1056,7 @@ func (x *PeerResponse_Meta) String() string {
 func (*PeerResponse_Meta) ProtoMessage() {}
 
 func (x *PeerResponse_Meta) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eth_v1_node_proto_msgTypes[13]
+	mi := &file_proto_eth_v1_node_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -904,7 +1093,7 @@ type PeerCountResponse_PeerCount struct {
 func (x *PeerCountResponse_PeerCount) Reset() {
 	*x = PeerCountResponse_PeerCount{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_eth_v1_node_proto_msgTypes[14]
+		mi := &file_proto_eth_v1_node_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -917,7 +1106,7 @@ func (x *PeerCountResponse_PeerCount) String() string {
 func (*PeerCountResponse_PeerCount) ProtoMessage() {}
 
 func (x *PeerCountResponse_PeerCount) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eth_v1_node_proto_msgTypes[14]
+	mi := &file_proto_eth_v1_node_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1070,24 +1259,51 @@ var file_proto_eth_v1_node_proto_rawDesc = []byte{
 	0x74, 0x68, 0x32, 0x2d, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x0c,
 	0x73, 0x79, 0x6e, 0x63, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
 	0x69, 0x73, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x09, 0x69, 0x73, 0x53, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x2a, 0x2a, 0x0a, 0x0d, 0x50,
-	0x65, 0x65, 0x72, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07,
-	0x49, 0x4e, 0x42, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x4f, 0x55, 0x54,
-	0x42, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x01, 0x2a, 0x55, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x49,
-	0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a,
-	0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09,
-	0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x44,
-	0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x42, 0x79,
-	0x0a, 0x13, 0x6f, 0x72, 0x67, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x65,
-	0x74, 0x68, 0x2e, 0x76, 0x31, 0x42, 0x0f, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x4e, 0x6f, 0x64,
-	0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x72, 0x79, 0x73, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x6c, 0x61,
-	0x62, 0x73, 0x2f, 0x70, 0x72, 0x79, 0x73, 0x6d, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65,
-	0x74, 0x68, 0x2f, 0x76, 0x31, 0xaa, 0x02, 0x0f, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d,
-	0x2e, 0x45, 0x74, 0x68, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x0f, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65,
-	0x75, 0x6d, 0x5c, 0x45, 0x74, 0x68, 0x5c, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x52, 0x09, 0x69, 0x73, 0x53, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x22, 0x41, 0x0a, 0x0e, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x74,
+	0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x65, 0x74, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xf4,
+	0x02, 0x0a, 0x0a, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x37, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x65,
+	0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x65, 0x74, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x51, 0x0a, 0x0d, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x64,
+	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x42, 0x2c, 0x82,
+	0xb5, 0x18, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x72,
+	0x79, 0x73, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x74, 0x68, 0x32,
+	0x2d, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x0c, 0x73, 0x79, 0x6e,
+	0x63, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f,
+	0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69,
+	0x73, 0x53, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x73, 0x5f, 0x6f,
+	0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x74, 0x69, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x69, 0x73, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x74, 0x69, 0x63, 0x12, 0x1d, 0x0a,
+	0x0a, 0x65, 0x6c, 0x5f, 0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x65, 0x6c, 0x4f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x25, 0x0a, 0x0e,
+	0x69, 0x73, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x73, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c,
+	0x69, 0x6e, 0x67, 0x22, 0x50, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x09, 0x0a, 0x05,
+	0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x41, 0x43, 0x4b, 0x46,
+	0x49, 0x4c, 0x4c, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x50, 0x54, 0x49,
+	0x4d, 0x49, 0x53, 0x54, 0x49, 0x43, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x59, 0x4e, 0x43,
+	0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x4c, 0x5f, 0x4f, 0x46, 0x46, 0x4c,
+	0x49, 0x4e, 0x45, 0x10, 0x04, 0x2a, 0x2a, 0x0a, 0x0d, 0x50, 0x65, 0x65, 0x72, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x42, 0x4f, 0x55, 0x4e,
+	0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x4f, 0x55, 0x54, 0x42, 0x4f, 0x55, 0x4e, 0x44, 0x10,
+	0x01, 0x2a, 0x55, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45,
+	0x43, 0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43,
+	0x54, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43,
+	0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e,
+	0x45, 0x43, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x42, 0x79, 0x0a, 0x13, 0x6f, 0x72, 0x67, 0x2e,
+	0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x65, 0x74, 0x68, 0x2e, 0x76, 0x31, 0x42,
+	0x0f, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x50, 0x01, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70,
+	0x72, 0x79, 0x73, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x70, 0x72, 0x79,
+	0x73, 0x6d, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x74, 0x68, 0x2f, 0x76, 0x31, 0xaa,
+	0x02, 0x0f, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x45, 0x74, 0x68, 0x2e, 0x56,
+	0x31, 0xca, 0x02, 0x0f, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x5c, 0x45, 0x74, 0x68,
+	0x5c, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1102,45 +1318,50 @@ func file_proto_eth_v1_node_proto_rawDescGZIP() []byte {
 	return file_proto_eth_v1_node_proto_rawDescData
 }
 
-var file_proto_eth_v1_node_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_eth_v1_node_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_proto_eth_v1_node_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_eth_v1_node_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_proto_eth_v1_node_proto_goTypes = []interface{}{
 	(PeerDirection)(0),                  // 0: ethereum.eth.v1.PeerDirection
 	(ConnectionState)(0),                // 1: ethereum.eth.v1.ConnectionState
-	(*IdentityResponse)(nil),            // 2: ethereum.eth.v1.IdentityResponse
-	(*Identity)(nil),                    // 3: ethereum.eth.v1.Identity
-	(*Metadata)(nil),                    // 4: ethereum.eth.v1.Metadata
-	(*PeerRequest)(nil),                 // 5: ethereum.eth.v1.PeerRequest
-	(*PeersRequest)(nil),                // 6: ethereum.eth.v1.PeersRequest
-	(*PeerResponse)(nil),                // 7: ethereum.eth.v1.PeerResponse
-	(*PeersResponse)(nil),               // 8: ethereum.eth.v1.PeersResponse
-	(*PeerCountResponse)(nil),           // 9: ethereum.eth.v1.PeerCountResponse
-	(*Peer)(nil),                        // 10: ethereum.eth.v1.Peer
-	(*VersionResponse)(nil),             // 11: ethereum.eth.v1.VersionResponse
-	(*Version)(nil),                     // 12: ethereum.eth.v1.Version
-	(*SyncingResponse)(nil),             // 13: ethereum.eth.v1.SyncingResponse
-	(*SyncInfo)(nil),                    // 14: ethereum.eth.v1.SyncInfo
-	(*PeerResponse_Meta)(nil),           // 15: ethereum.eth.v1.PeerResponse.Meta
-	(*PeerCountResponse_PeerCount)(nil), // 16: ethereum.eth.v1.PeerCountResponse.PeerCount
+	(HealthInfo_Stage)(0),               // 2: ethereum.eth.v1.HealthInfo.Stage
+	(*IdentityResponse)(nil),            // 3: ethereum.eth.v1.IdentityResponse
+	(*Identity)(nil),                    // 4: ethereum.eth.v1.Identity
+	(*Metadata)(nil),                    // 5: ethereum.eth.v1.Metadata
+	(*PeerRequest)(nil),                 // 6: ethereum.eth.v1.PeerRequest
+	(*PeersRequest)(nil),                // 7: ethereum.eth.v1.PeersRequest
+	(*PeerResponse)(nil),                // 8: ethereum.eth.v1.PeerResponse
+	(*PeersResponse)(nil),               // 9: ethereum.eth.v1.PeersResponse
+	(*PeerCountResponse)(nil),           // 10: ethereum.eth.v1.PeerCountResponse
+	(*Peer)(nil),                        // 11: ethereum.eth.v1.Peer
+	(*VersionResponse)(nil),             // 12: ethereum.eth.v1.VersionResponse
+	(*Version)(nil),                     // 13: ethereum.eth.v1.Version
+	(*SyncingResponse)(nil),             // 14: ethereum.eth.v1.SyncingResponse
+	(*SyncInfo)(nil),                    // 15: ethereum.eth.v1.SyncInfo
+	(*HealthResponse)(nil),              // 16: ethereum.eth.v1.HealthResponse
+	(*HealthInfo)(nil),                  // 17: ethereum.eth.v1.HealthInfo
+	(*PeerResponse_Meta)(nil),           // 18: ethereum.eth.v1.PeerResponse.Meta
+	(*PeerCountResponse_PeerCount)(nil), // 19: ethereum.eth.v1.PeerCountResponse.PeerCount
 }
 var file_proto_eth_v1_node_proto_depIdxs = []int32{
-	3,  // 0: ethereum.eth.v1.IdentityResponse.data:type_name -> ethereum.eth.v1.Identity
-	4,  // 1: ethereum.eth.v1.Identity.metadata:type_name -> ethereum.eth.v1.Metadata
+	4,  // 0: ethereum.eth.v1.IdentityResponse.data:type_name -> ethereum.eth.v1.Identity
+	5,  // 1: ethereum.eth.v1.Identity.metadata:type_name -> ethereum.eth.v1.Metadata
 	1,  // 2: ethereum.eth.v1.PeersRequest.state:type_name -> ethereum.eth.v1.ConnectionState
 	0,  // 3: ethereum.eth.v1.PeersRequest.direction:type_name -> ethereum.eth.v1.PeerDirection
-	10, // 4: ethereum.eth.v1.PeerResponse.data:type_name -> ethereum.eth.v1.Peer
-	15, // 5: ethereum.eth.v1.PeerResponse.meta:type_name -> ethereum.eth.v1.PeerResponse.Meta
-	10, // 6: ethereum.eth.v1.PeersResponse.data:type_name -> ethereum.eth.v1.Peer
-	16, // 7: ethereum.eth.v1.PeerCountResponse.data:type_name -> ethereum.eth.v1.PeerCountResponse.PeerCount
+	11, // 4: ethereum.eth.v1.PeerResponse.data:type_name -> ethereum.eth.v1.Peer
+	18, // 5: ethereum.eth.v1.PeerResponse.meta:type_name -> ethereum.eth.v1.PeerResponse.Meta
+	11, // 6: ethereum.eth.v1.PeersResponse.data:type_name -> ethereum.eth.v1.Peer
+	19, // 7: ethereum.eth.v1.PeerCountResponse.data:type_name -> ethereum.eth.v1.PeerCountResponse.PeerCount
 	1,  // 8: ethereum.eth.v1.Peer.state:type_name -> ethereum.eth.v1.ConnectionState
 	0,  // 9: ethereum.eth.v1.Peer.direction:type_name -> ethereum.eth.v1.PeerDirection
-	12, // 10: ethereum.eth.v1.VersionResponse.data:type_name -> ethereum.eth.v1.Version
-	14, // 11: ethereum.eth.v1.SyncingResponse.data:type_name -> ethereum.eth.v1.SyncInfo
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	13, // 10: ethereum.eth.v1.VersionResponse.data:type_name -> ethereum.eth.v1.Version
+	15, // 11: ethereum.eth.v1.SyncingResponse.data:type_name -> ethereum.eth.v1.SyncInfo
+	17, // 12: ethereum.eth.v1.HealthResponse.data:type_name -> ethereum.eth.v1.HealthInfo
+	2,  // 13: ethereum.eth.v1.HealthInfo.stage:type_name -> ethereum.eth.v1.HealthInfo.Stage
+	14, // [14:14] is the sub-list for method output_type
+	14, // [14:14] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_proto_eth_v1_node_proto_init() }
@@ -1306,7 +1527,7 @@ func file_proto_eth_v1_node_proto_init() {
 			}
 		}
 		file_proto_eth_v1_node_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PeerResponse_Meta); i {
+			switch v := v.(*HealthResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1318,6 +1539,30 @@ func file_proto_eth_v1_node_proto_init() {
 			}
 		}
 		file_proto_eth_v1_node_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HealthInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_eth_v1_node_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerResponse_Meta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_eth_v1_node_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PeerCountResponse_PeerCount); i {
 			case 0:
 				return &v.state
@@ -1335,8 +1580,8 @@ func file_proto_eth_v1_node_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_eth_v1_node_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   15,
+			NumEnums:      3,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
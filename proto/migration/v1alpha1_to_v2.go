@@ -4,6 +4,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/encoding/ssz"
+	enginev1 "github.com/prysmaticlabs/prysm/proto/engine/v1"
 	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
 	ethpbalpha "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -50,6 +52,66 @@ func V1Alpha1BeaconBlockBellatrixToV2(v1alpha1Block *ethpbalpha.BeaconBlockBella
 	return v2Block, nil
 }
 
+// V1Alpha1BeaconBlockBellatrixToV2Blinded converts a v1alpha1 Bellatrix beacon block to a v2
+// blinded Bellatrix block, replacing the execution payload with its header.
+func V1Alpha1BeaconBlockBellatrixToV2Blinded(v1alpha1Block *ethpbalpha.BeaconBlockBellatrix) (*ethpbv2.BlindedBeaconBlockBellatrix, error) {
+	marshaledBlk, err := proto.Marshal(v1alpha1Block)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal block")
+	}
+	// The fields of the execution payload match the fields of its header, except for the
+	// transactions which are replaced by their root below.
+	v2Block := &ethpbv2.BlindedBeaconBlockBellatrix{}
+	if err := proto.Unmarshal(marshaledBlk, v2Block); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal block")
+	}
+	if payload := v1alpha1Block.GetBody().GetExecutionPayload(); payload != nil {
+		txRoot, err := ssz.TransactionsRoot(payload.Transactions)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute transactions root")
+		}
+		v2Block.Body.ExecutionPayloadHeader.TransactionsRoot = txRoot[:]
+	}
+	return v2Block, nil
+}
+
+// BlindedBellatrixToV1Alpha1SignedBlock converts a v2 blinded Bellatrix block to a v1alpha1 signed
+// Bellatrix block, with the given execution payload in place of the execution payload header.
+// The payload is expected to match the header.
+func BlindedBellatrixToV1Alpha1SignedBlock(
+	blindedBlk *ethpbv2.BlindedBeaconBlockBellatrix,
+	signature []byte,
+	payload *enginev1.ExecutionPayload,
+) (*ethpbalpha.SignedBeaconBlockBellatrix, error) {
+	marshaledBlk, err := proto.Marshal(blindedBlk)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal block")
+	}
+	v1alpha1Block := &ethpbalpha.BeaconBlockBellatrix{}
+	if err := proto.Unmarshal(marshaledBlk, v1alpha1Block); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal block")
+	}
+	if v1alpha1Block.Body == nil {
+		return nil, errors.New("nil block body")
+	}
+	v1alpha1Block.Body.ExecutionPayload = payload
+	return &ethpbalpha.SignedBeaconBlockBellatrix{Block: v1alpha1Block, Signature: bytesutil.SafeCopyBytes(signature)}, nil
+}
+
+// V2ExecutionPayloadHeaderToV1Alpha1 converts a v2 execution payload header to a v1alpha1 execution
+// payload header.
+func V2ExecutionPayloadHeaderToV1Alpha1(v2Header *ethpbv2.ExecutionPayloadHeader) (*ethpbalpha.ExecutionPayloadHeader, error) {
+	marshaledHeader, err := proto.Marshal(v2Header)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal execution payload header")
+	}
+	v1alpha1Header := &ethpbalpha.ExecutionPayloadHeader{}
+	if err := proto.Unmarshal(marshaledHeader, v1alpha1Header); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal execution payload header")
+	}
+	return v1alpha1Header, nil
+}
+
 func BeaconStateAltairToV2(altairState state.BeaconStateAltair) (*ethpbv2.BeaconStateV2, error) {
 	sourceFork := altairState.Fork()
 	sourceLatestBlockHeader := altairState.LatestBlockHeader()
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/encoding/ssz"
 	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
 	ethpbalpha "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -126,3 +127,45 @@ func Test_V1Alpha1BeaconBlockBellatrixToV2(t *testing.T) {
 	require.NoError(t, err)
 	assert.DeepEqual(t, alphaRoot, v2Root)
 }
+
+func Test_V1Alpha1BeaconBlockBellatrixToV2Blinded(t *testing.T) {
+	alphaBlock := util.HydrateSignedBeaconBlockBellatrix(&ethpbalpha.SignedBeaconBlockBellatrix{})
+	alphaBlock.Block.Slot = slot
+	alphaBlock.Block.ProposerIndex = validatorIndex
+	alphaBlock.Block.ParentRoot = parentRoot
+	alphaBlock.Block.StateRoot = stateRoot
+	alphaBlock.Block.Body.RandaoReveal = randaoReveal
+	alphaBlock.Block.Body.ExecutionPayload.BlockNumber = 10
+	alphaBlock.Block.Body.ExecutionPayload.BlockHash = blockHash
+	alphaBlock.Block.Body.ExecutionPayload.Transactions = [][]byte{[]byte("transaction1"), []byte("transaction2")}
+	alphaBlock.Signature = signature
+
+	blindedBlock, err := V1Alpha1BeaconBlockBellatrixToV2Blinded(alphaBlock.Block)
+	require.NoError(t, err)
+	assert.Equal(t, slot, blindedBlock.Slot)
+	assert.DeepEqual(t, randaoReveal, blindedBlock.Body.RandaoReveal)
+	header := blindedBlock.Body.ExecutionPayloadHeader
+	require.NotNil(t, header)
+	assert.Equal(t, uint64(10), header.BlockNumber)
+	assert.DeepEqual(t, blockHash, header.BlockHash)
+	txRoot, err := ssz.TransactionsRoot(alphaBlock.Block.Body.ExecutionPayload.Transactions)
+	require.NoError(t, err)
+	assert.DeepEqual(t, txRoot[:], header.TransactionsRoot)
+
+	t.Run("unblind", func(t *testing.T) {
+		unblindedBlock, err := BlindedBellatrixToV1Alpha1SignedBlock(blindedBlock, signature, alphaBlock.Block.Body.ExecutionPayload)
+		require.NoError(t, err)
+		alphaRoot, err := alphaBlock.HashTreeRoot()
+		require.NoError(t, err)
+		unblindedRoot, err := unblindedBlock.HashTreeRoot()
+		require.NoError(t, err)
+		assert.DeepEqual(t, alphaRoot, unblindedRoot)
+	})
+	t.Run("header", func(t *testing.T) {
+		alphaHeader, err := V2ExecutionPayloadHeaderToV1Alpha1(header)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), alphaHeader.BlockNumber)
+		assert.DeepEqual(t, blockHash, alphaHeader.BlockHash)
+		assert.DeepEqual(t, txRoot[:], alphaHeader.TransactionsRoot)
+	})
+}
@@ -1,5 +1,5 @@
 // Code generated by fastssz. DO NOT EDIT.
-// Hash: 17c106ab7e1185d9b8f54624184d025eadbdf770c6ffc969a5ca7464e25f115c
+// Hash: 7d40bfe7a535011cfb999bf4ff6f56df597f11c7814435a2c2971ea6664568d9
 package eth
 
 import (
@@ -7758,6 +7758,173 @@ func (m *MetaDataV1) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	return
 }
 
+// MarshalSSZ ssz marshals the DepositSnapshot object
+func (d *DepositSnapshot) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(d)
+}
+
+// MarshalSSZTo ssz marshals the DepositSnapshot object to a target array
+func (d *DepositSnapshot) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(84)
+
+	// Offset (0) 'Finalized'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(d.Finalized) * 32
+
+	// Field (1) 'DepositRoot'
+	if len(d.DepositRoot) != 32 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, d.DepositRoot...)
+
+	// Field (2) 'DepositCount'
+	dst = ssz.MarshalUint64(dst, d.DepositCount)
+
+	// Field (3) 'ExecutionBlockHash'
+	if len(d.ExecutionBlockHash) != 32 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, d.ExecutionBlockHash...)
+
+	// Field (4) 'ExecutionBlockHeight'
+	dst = ssz.MarshalUint64(dst, d.ExecutionBlockHeight)
+
+	// Field (0) 'Finalized'
+	if len(d.Finalized) > 32 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(d.Finalized); ii++ {
+		if len(d.Finalized[ii]) != 32 {
+			err = ssz.ErrBytesLength
+			return
+		}
+		dst = append(dst, d.Finalized[ii]...)
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the DepositSnapshot object
+func (d *DepositSnapshot) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 84 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'Finalized'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 84 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (1) 'DepositRoot'
+	if cap(d.DepositRoot) == 0 {
+		d.DepositRoot = make([]byte, 0, len(buf[4:36]))
+	}
+	d.DepositRoot = append(d.DepositRoot, buf[4:36]...)
+
+	// Field (2) 'DepositCount'
+	d.DepositCount = ssz.UnmarshallUint64(buf[36:44])
+
+	// Field (3) 'ExecutionBlockHash'
+	if cap(d.ExecutionBlockHash) == 0 {
+		d.ExecutionBlockHash = make([]byte, 0, len(buf[44:76]))
+	}
+	d.ExecutionBlockHash = append(d.ExecutionBlockHash, buf[44:76]...)
+
+	// Field (4) 'ExecutionBlockHeight'
+	d.ExecutionBlockHeight = ssz.UnmarshallUint64(buf[76:84])
+
+	// Field (0) 'Finalized'
+	{
+		buf = tail[o0:]
+		num, err := ssz.DivideInt2(len(buf), 32, 32)
+		if err != nil {
+			return err
+		}
+		d.Finalized = make([][]byte, num)
+		for ii := 0; ii < num; ii++ {
+			if cap(d.Finalized[ii]) == 0 {
+				d.Finalized[ii] = make([]byte, 0, len(buf[ii*32:(ii+1)*32]))
+			}
+			d.Finalized[ii] = append(d.Finalized[ii], buf[ii*32:(ii+1)*32]...)
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the DepositSnapshot object
+func (d *DepositSnapshot) SizeSSZ() (size int) {
+	size = 84
+
+	// Field (0) 'Finalized'
+	size += len(d.Finalized) * 32
+
+	return
+}
+
+// HashTreeRoot ssz hashes the DepositSnapshot object
+func (d *DepositSnapshot) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(d)
+}
+
+// HashTreeRootWith ssz hashes the DepositSnapshot object with a hasher
+func (d *DepositSnapshot) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Finalized'
+	{
+		if len(d.Finalized) > 32 {
+			err = ssz.ErrListTooBig
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range d.Finalized {
+			if len(i) != 32 {
+				err = ssz.ErrBytesLength
+				return
+			}
+			hh.Append(i)
+		}
+		numItems := uint64(len(d.Finalized))
+		hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(32, numItems, 32))
+	}
+
+	// Field (1) 'DepositRoot'
+	if len(d.DepositRoot) != 32 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	hh.PutBytes(d.DepositRoot)
+
+	// Field (2) 'DepositCount'
+	hh.PutUint64(d.DepositCount)
+
+	// Field (3) 'ExecutionBlockHash'
+	if len(d.ExecutionBlockHash) != 32 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	hh.PutBytes(d.ExecutionBlockHash)
+
+	// Field (4) 'ExecutionBlockHeight'
+	hh.PutUint64(d.ExecutionBlockHeight)
+
+	hh.Merkleize(indx)
+	return
+}
+
 // MarshalSSZ ssz marshals the SyncCommitteeMessage object
 func (s *SyncCommitteeMessage) MarshalSSZ() ([]byte, error) {
 	return ssz.MarshalSSZ(s)
@@ -37,6 +37,86 @@ func (m *MockNodeClient) EXPECT() *MockNodeClientMockRecorder {
 	return m.recorder
 }
 
+// AddStaticPeer mocks base method
+func (m *MockNodeClient) AddStaticPeer(arg0 context.Context, arg1 *eth.StaticPeerRequest, arg2 ...grpc.CallOption) (*eth.StaticPeer, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddStaticPeer", varargs...)
+	ret0, _ := ret[0].(*eth.StaticPeer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddStaticPeer indicates an expected call of AddStaticPeer
+func (mr *MockNodeClientMockRecorder) AddStaticPeer(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStaticPeer", reflect.TypeOf((*MockNodeClient)(nil).AddStaticPeer), varargs...)
+}
+
+// AddTrustedPeer mocks base method
+func (m *MockNodeClient) AddTrustedPeer(arg0 context.Context, arg1 *eth.PeerRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddTrustedPeer", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTrustedPeer indicates an expected call of AddTrustedPeer
+func (mr *MockNodeClientMockRecorder) AddTrustedPeer(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrustedPeer", reflect.TypeOf((*MockNodeClient)(nil).AddTrustedPeer), varargs...)
+}
+
+// BanNetwork mocks base method
+func (m *MockNodeClient) BanNetwork(arg0 context.Context, arg1 *eth.Network, arg2 ...grpc.CallOption) (*eth.Network, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BanNetwork", varargs...)
+	ret0, _ := ret[0].(*eth.Network)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanNetwork indicates an expected call of BanNetwork
+func (mr *MockNodeClientMockRecorder) BanNetwork(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanNetwork", reflect.TypeOf((*MockNodeClient)(nil).BanNetwork), varargs...)
+}
+
+// BanPeer mocks base method
+func (m *MockNodeClient) BanPeer(arg0 context.Context, arg1 *eth.PeerRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BanPeer", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanPeer indicates an expected call of BanPeer
+func (mr *MockNodeClientMockRecorder) BanPeer(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanPeer", reflect.TypeOf((*MockNodeClient)(nil).BanPeer), varargs...)
+}
+
 // GetGenesis mocks base method
 func (m *MockNodeClient) GetGenesis(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.Genesis, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +137,26 @@ func (mr *MockNodeClientMockRecorder) GetGenesis(arg0, arg1 interface{}, arg2 ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenesis", reflect.TypeOf((*MockNodeClient)(nil).GetGenesis), varargs...)
 }
 
+// GetHealth mocks base method
+func (m *MockNodeClient) GetHealth(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.NodeHealth, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHealth", varargs...)
+	ret0, _ := ret[0].(*eth.NodeHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealth indicates an expected call of GetHealth
+func (mr *MockNodeClientMockRecorder) GetHealth(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealth", reflect.TypeOf((*MockNodeClient)(nil).GetHealth), varargs...)
+}
+
 // GetHost mocks base method
 func (m *MockNodeClient) GetHost(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.HostData, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +237,46 @@ func (mr *MockNodeClientMockRecorder) GetVersion(arg0, arg1 interface{}, arg2 ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockNodeClient)(nil).GetVersion), varargs...)
 }
 
+// ListBannedNetworks mocks base method
+func (m *MockNodeClient) ListBannedNetworks(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.BannedNetworks, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListBannedNetworks", varargs...)
+	ret0, _ := ret[0].(*eth.BannedNetworks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBannedNetworks indicates an expected call of ListBannedNetworks
+func (mr *MockNodeClientMockRecorder) ListBannedNetworks(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBannedNetworks", reflect.TypeOf((*MockNodeClient)(nil).ListBannedNetworks), varargs...)
+}
+
+// ListBannedPeers mocks base method
+func (m *MockNodeClient) ListBannedPeers(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.BannedPeers, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListBannedPeers", varargs...)
+	ret0, _ := ret[0].(*eth.BannedPeers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBannedPeers indicates an expected call of ListBannedPeers
+func (mr *MockNodeClientMockRecorder) ListBannedPeers(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBannedPeers", reflect.TypeOf((*MockNodeClient)(nil).ListBannedPeers), varargs...)
+}
+
 // ListImplementedServices mocks base method
 func (m *MockNodeClient) ListImplementedServices(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.ImplementedServices, error) {
 	m.ctrl.T.Helper()
@@ -196,3 +336,123 @@ func (mr *MockNodeClientMockRecorder) GetETH1ConnectionStatus(arg0, arg1 interfa
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetETH1ConnectionStatus", reflect.TypeOf((*MockNodeClient)(nil).GetETH1ConnectionStatus), varargs...)
 }
+
+// ListStaticPeers mocks base method
+func (m *MockNodeClient) ListStaticPeers(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.StaticPeers, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListStaticPeers", varargs...)
+	ret0, _ := ret[0].(*eth.StaticPeers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStaticPeers indicates an expected call of ListStaticPeers
+func (mr *MockNodeClientMockRecorder) ListStaticPeers(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStaticPeers", reflect.TypeOf((*MockNodeClient)(nil).ListStaticPeers), varargs...)
+}
+
+// ListTrustedPeers mocks base method
+func (m *MockNodeClient) ListTrustedPeers(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*eth.TrustedPeers, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTrustedPeers", varargs...)
+	ret0, _ := ret[0].(*eth.TrustedPeers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrustedPeers indicates an expected call of ListTrustedPeers
+func (mr *MockNodeClientMockRecorder) ListTrustedPeers(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrustedPeers", reflect.TypeOf((*MockNodeClient)(nil).ListTrustedPeers), varargs...)
+}
+
+// RemoveStaticPeer mocks base method
+func (m *MockNodeClient) RemoveStaticPeer(arg0 context.Context, arg1 *eth.PeerRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveStaticPeer", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveStaticPeer indicates an expected call of RemoveStaticPeer
+func (mr *MockNodeClientMockRecorder) RemoveStaticPeer(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveStaticPeer", reflect.TypeOf((*MockNodeClient)(nil).RemoveStaticPeer), varargs...)
+}
+
+// RemoveTrustedPeer mocks base method
+func (m *MockNodeClient) RemoveTrustedPeer(arg0 context.Context, arg1 *eth.PeerRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveTrustedPeer", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTrustedPeer indicates an expected call of RemoveTrustedPeer
+func (mr *MockNodeClientMockRecorder) RemoveTrustedPeer(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrustedPeer", reflect.TypeOf((*MockNodeClient)(nil).RemoveTrustedPeer), varargs...)
+}
+
+// UnbanNetwork mocks base method
+func (m *MockNodeClient) UnbanNetwork(arg0 context.Context, arg1 *eth.Network, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnbanNetwork", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnbanNetwork indicates an expected call of UnbanNetwork
+func (mr *MockNodeClientMockRecorder) UnbanNetwork(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanNetwork", reflect.TypeOf((*MockNodeClient)(nil).UnbanNetwork), varargs...)
+}
+
+// UnbanPeer mocks base method
+func (m *MockNodeClient) UnbanPeer(arg0 context.Context, arg1 *eth.PeerRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnbanPeer", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnbanPeer indicates an expected call of UnbanPeer
+func (mr *MockNodeClientMockRecorder) UnbanPeer(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanPeer", reflect.TypeOf((*MockNodeClient)(nil).UnbanPeer), varargs...)
+}
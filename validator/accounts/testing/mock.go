@@ -8,6 +8,7 @@ import (
 	"time"
 
 	types "github.com/prysmaticlabs/eth2-types"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	"github.com/prysmaticlabs/prysm/validator/accounts/iface"
 	iface2 "github.com/prysmaticlabs/prysm/validator/client/iface"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
@@ -171,6 +172,14 @@ func (_ MockValidator) ReceiveBlocks(_ context.Context, _ chan<- error) {
 	panic("implement me")
 }
 
+func (_ MockValidator) ReceiveHeadEvents(_ context.Context, _ chan<- *ethpbv1.EventHead, _ chan<- error) {
+	panic("implement me")
+}
+
+func (_ MockValidator) HandleHeadEvent(_ context.Context, _ *ethpbv1.EventHead) error {
+	panic("implement me")
+}
+
 func (_ MockValidator) HandleKeyReload(_ context.Context, _ [][48]byte) (bool, error) {
 	panic("implement me")
 }
@@ -7,6 +7,7 @@ import (
 
 	types "github.com/prysmaticlabs/eth2-types"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 )
 
@@ -55,6 +56,8 @@ type Validator interface {
 	AllValidatorsAreExited(ctx context.Context) (bool, error)
 	Keymanager() (keymanager.IKeymanager, error)
 	ReceiveBlocks(ctx context.Context, connectionErrorChannel chan<- error)
+	ReceiveHeadEvents(ctx context.Context, headChannel chan<- *ethpbv1.EventHead, connectionErrorChannel chan<- error)
+	HandleHeadEvent(ctx context.Context, head *ethpbv1.EventHead) error
 	HandleKeyReload(ctx context.Context, newKeys [][fieldparams.BLSPubkeyLength]byte) (bool, error)
 	CheckDoppelGanger(ctx context.Context) error
 }
@@ -48,14 +48,15 @@ func (v *validator) LogNextDutyTimeLeft(slot types.Slot) error {
 	if !v.logDutyCountDown {
 		return nil
 	}
-	if v.duties == nil {
+	duties := v.currentDuties()
+	if duties == nil {
 		return nil
 	}
 
 	var nextDutySlot types.Slot
 	attestingCounts := make(map[types.Slot]uint64)
 	proposingCounts := make(map[types.Slot]uint64)
-	for _, duty := range v.duties.CurrentEpochDuties {
+	for _, duty := range duties.CurrentEpochDuties {
 		attestingCounts[duty.AttesterSlot]++
 
 		if duty.AttesterSlot > slot && (nextDutySlot > duty.AttesterSlot || nextDutySlot == 0) {
@@ -11,6 +11,7 @@ import (
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	"github.com/prysmaticlabs/prysm/time/slots"
 	"github.com/prysmaticlabs/prysm/validator/client/iface"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/remote"
@@ -104,6 +105,9 @@ func run(ctx context.Context, v iface.Validator) {
 
 	connectionErrorChannel := make(chan error, 1)
 	go v.ReceiveBlocks(ctx, connectionErrorChannel)
+	headChannel := make(chan *ethpbv1.EventHead, 1)
+	headErrorChannel := make(chan error, 1)
+	go v.ReceiveHeadEvents(ctx, headChannel, headErrorChannel)
 	if err := v.UpdateDuties(ctx, headSlot); err != nil {
 		handleAssignmentError(err, headSlot)
 	}
@@ -132,6 +136,17 @@ func run(ctx context.Context, v iface.Validator) {
 				go v.ReceiveBlocks(ctx, connectionErrorChannel)
 				continue
 			}
+		case headError := <-headErrorChannel:
+			if headError != nil {
+				log.WithError(headError).Warn("head event stream interrupted")
+				go v.ReceiveHeadEvents(ctx, headChannel, headErrorChannel)
+				continue
+			}
+		case head := <-headChannel:
+			// Duties that depend on blocks which are no longer canonical are requested again.
+			if err := v.HandleHeadEvent(ctx, head); err != nil {
+				log.WithError(err).Error("Could not update duties after head event")
+			}
 		case newKeys := <-accountsChangedChan:
 			anyActive, err := v.HandleKeyReload(ctx, newKeys)
 			if err != nil {
@@ -54,6 +54,7 @@ func TestRetry_On_ConnectionError(t *testing.T) {
 	assert.Equal(t, retry*2, v.WaitForActivationCalled, "Expected WaitForActivation() to be called")
 	assert.Equal(t, retry, v.CanonicalHeadSlotCalled, "Expected WaitForActivation() to be called")
 	assert.Equal(t, retry, v.ReceiveBlocksCalled, "Expected WaitForActivation() to be called")
+	assert.Equal(t, retry, v.ReceiveHeadEventsCalled, "Expected ReceiveHeadEvents() to be called")
 }
 
 func TestCancelledContext_WaitsForActivation(t *testing.T) {
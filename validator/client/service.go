@@ -17,6 +17,7 @@ import (
 	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
+	ethpbservice "github.com/prysmaticlabs/prysm/proto/eth/service"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
 	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
@@ -29,6 +30,7 @@ import (
 	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -56,12 +58,17 @@ type ValidatorService struct {
 	grpcRetryDelay        time.Duration
 	grpcRetries           uint
 	maxCallRecvMsgSize    int
+	maxCallSendMsgSize    int
+	keepaliveTime         time.Duration
+	keepaliveTimeout      time.Duration
 	cancel                context.CancelFunc
 	walletInitializedFeed *event.Feed
 	wallet                *wallet.Wallet
 	graffitiStruct        *graffiti.Graffiti
 	dataDir               string
 	withCert              string
+	withClientCert        string
+	withClientKey         string
 	endpoint              string
 	ctx                   context.Context
 	validator             iface.Validator
@@ -82,11 +89,16 @@ type Config struct {
 	WalletInitializedFeed      *event.Feed
 	GrpcRetriesFlag            uint
 	GrpcMaxCallRecvMsgSizeFlag int
+	GrpcMaxCallSendMsgSizeFlag int
 	GrpcRetryDelay             time.Duration
+	GrpcKeepaliveTime          time.Duration
+	GrpcKeepaliveTimeout       time.Duration
 	GraffitiStruct             *graffiti.Graffiti
 	Validator                  iface.Validator
 	ValDB                      db.Database
 	CertFlag                   string
+	ClientCertFlag             string
+	ClientKeyFlag              string
 	DataDir                    string
 	GrpcHeadersFlag            string
 	GraffitiFlag               string
@@ -103,11 +115,16 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		cancel:                cancel,
 		endpoint:              cfg.Endpoint,
 		withCert:              cfg.CertFlag,
+		withClientCert:        cfg.ClientCertFlag,
+		withClientKey:         cfg.ClientKeyFlag,
 		dataDir:               cfg.DataDir,
 		graffiti:              []byte(cfg.GraffitiFlag),
 		logValidatorBalances:  cfg.LogValidatorBalances,
 		emitAccountMetrics:    cfg.EmitAccountMetrics,
 		maxCallRecvMsgSize:    cfg.GrpcMaxCallRecvMsgSizeFlag,
+		maxCallSendMsgSize:    cfg.GrpcMaxCallSendMsgSizeFlag,
+		keepaliveTime:         cfg.GrpcKeepaliveTime,
+		keepaliveTimeout:      cfg.GrpcKeepaliveTimeout,
 		grpcRetries:           cfg.GrpcRetriesFlag,
 		grpcRetryDelay:        cfg.GrpcRetryDelay,
 		grpcHeaders:           strings.Split(cfg.GrpcHeadersFlag, ","),
@@ -129,8 +146,11 @@ func (v *ValidatorService) Start() {
 	dialOpts := ConstructDialOptions(
 		v.maxCallRecvMsgSize,
 		v.withCert,
+		v.withClientCert,
+		v.withClientKey,
 		v.grpcRetries,
 		v.grpcRetryDelay,
+		ConnectionDialOptions(v.maxCallSendMsgSize, v.keepaliveTime, v.keepaliveTimeout)...,
 	)
 	if dialOpts == nil {
 		return
@@ -143,7 +163,7 @@ func (v *ValidatorService) Start() {
 		log.Errorf("Could not dial endpoint: %s, %v", v.endpoint, err)
 		return
 	}
-	if v.withCert != "" {
+	if v.withCert != "" || v.withClientCert != "" {
 		log.Info("Established secure gRPC connection")
 	}
 
@@ -179,6 +199,7 @@ func (v *ValidatorService) Start() {
 		db:                             v.db,
 		validatorClient:                ethpb.NewBeaconNodeValidatorClient(v.conn),
 		beaconClient:                   ethpb.NewBeaconChainClient(v.conn),
+		eventsClient:                   ethpbservice.NewEventsClient(v.conn),
 		slashingProtectionClient:       ethpb.NewSlasherClient(v.conn),
 		node:                           ethpb.NewNodeClient(v.conn),
 		graffiti:                       v.graffiti,
@@ -243,16 +264,30 @@ func (v *ValidatorService) Keymanager() (keymanager.IKeymanager, error) {
 	return v.validator.Keymanager()
 }
 
-// ConstructDialOptions constructs a list of grpc dial options
+// ConstructDialOptions constructs a list of grpc dial options. The client certificate, if any,
+// is presented to the beacon node for mutual TLS.
 func ConstructDialOptions(
 	maxCallRecvMsgSize int,
 	withCert string,
+	withClientCert string,
+	withClientKey string,
 	grpcRetries uint,
 	grpcRetryDelay time.Duration,
 	extraOpts ...grpc.DialOption,
 ) []grpc.DialOption {
 	var transportSecurity grpc.DialOption
-	if withCert != "" {
+	if (withClientCert == "") != (withClientKey == "") {
+		log.Error("A client certificate and its key must be provided together")
+		return nil
+	}
+	if withClientCert != "" {
+		creds, err := grpcutil.NewClientMutualTLSFromFile(withCert, withClientCert, withClientKey)
+		if err != nil {
+			log.Errorf("Could not get valid credentials: %v", err)
+			return nil
+		}
+		transportSecurity = grpc.WithTransportCredentials(creds)
+	} else if withCert != "" {
 		creds, err := credentials.NewClientTLSFromFile(withCert, "")
 		if err != nil {
 			log.Errorf("Could not get valid credentials: %v", err)
@@ -297,6 +332,23 @@ func ConstructDialOptions(
 	return dialOpts
 }
 
+// ConnectionDialOptions constructs the grpc dial options for the maximum size of sent messages and
+// the keepalive pings of the connection. Zero values leave the gRPC defaults in place.
+func ConnectionDialOptions(maxCallSendMsgSize int, keepaliveTime, keepaliveTimeout time.Duration) []grpc.DialOption {
+	var dialOpts []grpc.DialOption
+	if maxCallSendMsgSize > 0 {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(maxCallSendMsgSize)))
+	}
+	if keepaliveTime > 0 {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                keepaliveTime,
+			Timeout:             keepaliveTimeout,
+			PermitWithoutStream: true,
+		}))
+	}
+	return dialOpts
+}
+
 // Syncing returns whether or not the beacon node is currently synchronizing the chain.
 func (v *ValidatorService) Syncing(ctx context.Context) (bool, error) {
 	nc := ethpb.NewNodeClient(v.conn)
@@ -104,3 +104,16 @@ func TestStart_GrpcHeaders(t *testing.T) {
 		}
 	}
 }
+
+func TestConstructDialOptions_ClientCertWithoutKey(t *testing.T) {
+	hook := logTest.NewGlobal()
+	dialOpts := ConstructDialOptions(0, "", "client.crt", "", 0, 0)
+	assert.Equal(t, 0, len(dialOpts))
+	require.LogsContain(t, hook, "A client certificate and its key must be provided together")
+}
+
+func TestConnectionDialOptions(t *testing.T) {
+	assert.Equal(t, 0, len(ConnectionDialOptions(0, 0, time.Second)))
+	assert.Equal(t, 1, len(ConnectionDialOptions(1<<30, 0, time.Second)))
+	assert.Equal(t, 2, len(ConnectionDialOptions(1<<30, time.Minute, time.Second)))
+}
@@ -7,6 +7,7 @@ import (
 
 	types "github.com/prysmaticlabs/eth2-types"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	prysmTime "github.com/prysmaticlabs/prysm/time"
 	"github.com/prysmaticlabs/prysm/validator/client/iface"
@@ -31,11 +32,13 @@ type FakeValidator struct {
 	DeleteProtectionCalled            bool
 	SlotDeadlineCalled                bool
 	HandleKeyReloadCalled             bool
+	HandleHeadEventCalled             bool
 	WaitForChainStartCalled           int
 	WaitForSyncCalled                 int
 	WaitForActivationCalled           int
 	CanonicalHeadSlotCalled           int
 	ReceiveBlocksCalled               int
+	ReceiveHeadEventsCalled           int
 	RetryTillSuccess                  int
 	ProposeBlockArg1                  uint64
 	AttestToBlockHeadArg1             uint64
@@ -232,6 +235,20 @@ func (fv *FakeValidator) ReceiveBlocks(_ context.Context, connectionErrorChannel
 	}
 }
 
+// ReceiveHeadEvents for mocking
+func (fv *FakeValidator) ReceiveHeadEvents(_ context.Context, _ chan<- *ethpbv1.EventHead, connectionErrorChannel chan<- error) {
+	fv.ReceiveHeadEventsCalled++
+	if fv.RetryTillSuccess > fv.ReceiveHeadEventsCalled {
+		connectionErrorChannel <- iface.ErrConnectionIssue
+	}
+}
+
+// HandleHeadEvent for mocking
+func (fv *FakeValidator) HandleHeadEvent(_ context.Context, _ *ethpbv1.EventHead) error {
+	fv.HandleHeadEventCalled = true
+	return nil
+}
+
 // HandleKeyReload for mocking
 func (fv *FakeValidator) HandleKeyReload(_ context.Context, newKeys [][fieldparams.BLSPubkeyLength]byte) (anyActive bool, err error) {
 	fv.HandleKeyReloadCalled = true
@@ -25,6 +25,8 @@ import (
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/crypto/hash"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbservice "github.com/prysmaticlabs/prysm/proto/eth/service"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
@@ -50,6 +52,9 @@ var (
 	keyRefetchPeriod = 30 * time.Second
 )
 
+// headEventTopic is the topic of the events sent by the beacon node on a new chain head.
+const headEventTopic = "head"
+
 var (
 	msgCouldNotFetchKeys = "could not fetch validating keys"
 	msgNoKeysFetched     = "No validating keys fetched. Trying again"
@@ -65,11 +70,13 @@ type validator struct {
 	aggregatedSlotCommitteeIDCacheLock sync.Mutex
 	prevBalanceLock                    sync.RWMutex
 	slashableKeysLock                  sync.RWMutex
+	dutiesLock                         sync.RWMutex
 	eipImportBlacklistedPublicKeys     map[[fieldparams.BLSPubkeyLength]byte]bool
 	walletInitializedFeed              *event.Feed
 	attLogs                            map[[32]byte]*attSubmitted
 	startBalances                      map[[fieldparams.BLSPubkeyLength]byte]uint64
 	duties                             *ethpb.DutiesResponse
+	dutiesEpoch                        types.Epoch
 	prevBalance                        map[[fieldparams.BLSPubkeyLength]byte]uint64
 	graffitiOrderedIndex               uint64
 	aggregatedSlotCommitteeIDCache     *lru.Cache
@@ -84,6 +91,7 @@ type validator struct {
 	slashingProtectionClient           ethpb.SlasherClient
 	db                                 vdb.Database
 	beaconClient                       ethpb.BeaconChainClient
+	eventsClient                       ethpbservice.EventsClient
 	keyManager                         keymanager.IKeymanager
 	ticker                             slots.Ticker
 	validatorClient                    ethpb.BeaconNodeValidatorClient
@@ -357,6 +365,44 @@ func (v *validator) ReceiveBlocks(ctx context.Context, connectionErrorChannel ch
 	}
 }
 
+// ReceiveHeadEvents starts a gRPC client stream listener to obtain head events
+// from the beacon node. Upon receiving a head event, the service sends it to the
+// head channel so that the duty dependent roots can be checked.
+func (v *validator) ReceiveHeadEvents(ctx context.Context, headChannel chan<- *ethpbv1.EventHead, connectionErrorChannel chan<- error) {
+	stream, err := v.eventsClient.StreamEvents(ctx, &ethpbv1.StreamEventsRequest{Topics: []string{headEventTopic}})
+	if err != nil {
+		log.WithError(err).Error("Failed to retrieve head events stream, " + iface.ErrConnectionIssue.Error())
+		connectionErrorChannel <- errors.Wrap(iface.ErrConnectionIssue, err.Error())
+		return
+	}
+
+	for {
+		if ctx.Err() == context.Canceled {
+			log.WithError(ctx.Err()).Error("Context canceled - shutting down head events receiver")
+			return
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			log.WithError(err).Error("Could not receive head events from beacon node, " + iface.ErrConnectionIssue.Error())
+			connectionErrorChannel <- errors.Wrap(iface.ErrConnectionIssue, err.Error())
+			return
+		}
+		if res == nil || res.Event != headEventTopic || res.Data == nil {
+			continue
+		}
+		head := &ethpbv1.EventHead{}
+		if err := res.Data.UnmarshalTo(head); err != nil {
+			log.WithError(err).Error("Failed to unmarshal head event")
+			continue
+		}
+		select {
+		case headChannel <- head:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func (v *validator) checkAndLogValidatorStatus(statuses []*validatorStatus) bool {
 	nonexistentIndex := types.ValidatorIndex(^uint64(0))
 	var validatorActivated bool
@@ -540,7 +586,7 @@ func retrieveLatestRecord(recs []*kv.AttestationRecord) *kv.AttestationRecord {
 // list of upcoming assignments needs to be updated. For example, at the
 // beginning of a new epoch.
 func (v *validator) UpdateDuties(ctx context.Context, slot types.Slot) error {
-	if slot%params.BeaconConfig().SlotsPerEpoch != 0 && v.duties != nil {
+	if slot%params.BeaconConfig().SlotsPerEpoch != 0 && v.currentDuties() != nil {
 		// Do nothing if not epoch start AND assignments already exist.
 		return nil
 	}
@@ -582,13 +628,18 @@ func (v *validator) UpdateDuties(ctx context.Context, slot types.Slot) error {
 	// If duties is nil it means we have had no prior duties and just started up.
 	resp, err := v.validatorClient.GetDuties(ctx, req)
 	if err != nil {
+		v.dutiesLock.Lock()
 		v.duties = nil // Clear assignments so we know to retry the request.
+		v.dutiesLock.Unlock()
 		log.Error(err)
 		return err
 	}
 
+	v.dutiesLock.Lock()
 	v.duties = resp
-	v.logDuties(slot, v.duties.CurrentEpochDuties)
+	v.dutiesEpoch = req.Epoch
+	v.dutiesLock.Unlock()
+	v.logDuties(slot, resp.CurrentEpochDuties)
 
 	// Non-blocking call for beacon node to start subscriptions for aggregators.
 	go func() {
@@ -600,6 +651,57 @@ func (v *validator) UpdateDuties(ctx context.Context, slot types.Slot) error {
 	return nil
 }
 
+// HandleHeadEvent compares the duty dependent roots of a new chain head with the
+// ones of the current duties. If they differ, a reorg changed the blocks the duties
+// were computed from, so the duties of the epoch of the head are requested again and
+// replace the current ones.
+func (v *validator) HandleHeadEvent(ctx context.Context, head *ethpbv1.EventHead) error {
+	if head == nil {
+		return nil
+	}
+	v.dutiesLock.RLock()
+	duties, dutiesEpoch := v.duties, v.dutiesEpoch
+	v.dutiesLock.RUnlock()
+	if duties == nil {
+		return nil
+	}
+	headEpoch := slots.ToEpoch(head.Slot)
+	var changed bool
+	switch headEpoch {
+	case dutiesEpoch:
+		changed = !bytes.Equal(head.PreviousDutyDependentRoot, duties.PreviousDutyDependentRoot) ||
+			!bytes.Equal(head.CurrentDutyDependentRoot, duties.CurrentDutyDependentRoot)
+	case dutiesEpoch + 1:
+		// The previous dependent root of the head is the dependent root of the duties of the next epoch.
+		changed = !bytes.Equal(head.PreviousDutyDependentRoot, duties.CurrentDutyDependentRoot)
+	default:
+		// Duties of other epochs are updated at the start of the epoch anyway.
+		return nil
+	}
+	if !changed {
+		return nil
+	}
+	log.WithFields(logrus.Fields{
+		"slot":                      head.Slot,
+		"previousDutyDependentRoot": fmt.Sprintf("%#x", bytesutil.Trunc(head.PreviousDutyDependentRoot)),
+		"currentDutyDependentRoot":  fmt.Sprintf("%#x", bytesutil.Trunc(head.CurrentDutyDependentRoot)),
+	}).Info("Duty dependent root changed, requesting duties again")
+	epochStart, err := slots.EpochStart(headEpoch)
+	if err != nil {
+		return err
+	}
+	// Duties are always requested at the start of an epoch.
+	return v.UpdateDuties(ctx, epochStart)
+}
+
+// currentDuties returns the latest duties of the validator, which are replaced as a whole
+// and never modified in place.
+func (v *validator) currentDuties() *ethpb.DutiesResponse {
+	v.dutiesLock.RLock()
+	defer v.dutiesLock.RUnlock()
+	return v.duties
+}
+
 // subscribeToSubnets iterates through each validator duty, signs each slot, and asks beacon node
 // to eagerly subscribe to subnets so that the aggregator has attestations to aggregate.
 func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesResponse) error {
@@ -671,7 +773,11 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 // validator assignments are unknown. Otherwise returns a valid ValidatorRole map.
 func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[fieldparams.BLSPubkeyLength]byte][]iface.ValidatorRole, error) {
 	rolesAt := make(map[[fieldparams.BLSPubkeyLength]byte][]iface.ValidatorRole)
-	for validator, duty := range v.duties.Duties {
+	duties := v.currentDuties()
+	if duties == nil {
+		return rolesAt, nil
+	}
+	for validator, duty := range duties.Duties {
 		var roles []iface.ValidatorRole
 
 		if duty == nil {
@@ -703,7 +809,7 @@ func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[fieldpar
 		// the validator checks whether it's in the sync committee of following epoch.
 		inSyncCommittee := false
 		if slots.IsEpochEnd(slot) {
-			if v.duties.NextEpochDuties[validator].IsSyncCommittee {
+			if duties.NextEpochDuties[validator].IsSyncCommittee {
 				roles = append(roles, iface.RoleSyncCommittee)
 				inSyncCommittee = true
 			}
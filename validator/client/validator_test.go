@@ -12,6 +12,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/mock/gomock"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/proto/gateway"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"github.com/prysmaticlabs/prysm/config/features"
@@ -19,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/crypto/bls"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/testing/assert"
@@ -34,6 +36,7 @@ import (
 	"github.com/sirupsen/logrus"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -1034,6 +1037,121 @@ func TestService_ReceiveBlocks_SetHighest(t *testing.T) {
 	require.Equal(t, slot, v.highestValidSlot)
 }
 
+func TestService_ReceiveHeadEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock2.NewMockEventsClient(ctrl)
+	v := validator{
+		eventsClient: client,
+	}
+	stream := mock2.NewMockEvents_StreamEventsClient(ctrl)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client.EXPECT().StreamEvents(
+		gomock.Any(),
+		&ethpbv1.StreamEventsRequest{Topics: []string{"head"}},
+	).Return(stream, nil)
+	head := &ethpbv1.EventHead{Slot: 100, PreviousDutyDependentRoot: []byte{'a'}, CurrentDutyDependentRoot: []byte{'b'}}
+	data, err := anypb.New(head)
+	require.NoError(t, err)
+	stream.EXPECT().Recv().Return(&gateway.EventSource{Event: "head", Data: data}, nil)
+	stream.EXPECT().Recv().Return(nil, errors.New("stream closed"))
+	headChannel := make(chan *ethpbv1.EventHead, 1)
+	connectionErrorChannel := make(chan error, 1)
+	v.ReceiveHeadEvents(ctx, headChannel, connectionErrorChannel)
+	assert.DeepEqual(t, head, <-headChannel)
+	assert.ErrorContains(t, iface.ErrConnectionIssue.Error(), <-connectionErrorChannel)
+}
+
+func TestHandleHeadEvent(t *testing.T) {
+	previousRoot := bytesutil.PadTo([]byte("previous"), 32)
+	currentRoot := bytesutil.PadTo([]byte("current"), 32)
+	otherRoot := bytesutil.PadTo([]byte("other"), 32)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	tests := []struct {
+		name        string
+		head        *ethpbv1.EventHead
+		wantEpoch   types.Epoch
+		wantRefetch bool
+	}{
+		{
+			name:        "same epoch, same roots",
+			head:        &ethpbv1.EventHead{Slot: 2*slotsPerEpoch + 1, PreviousDutyDependentRoot: previousRoot, CurrentDutyDependentRoot: currentRoot},
+			wantRefetch: false,
+		},
+		{
+			name:        "same epoch, previous root changed",
+			head:        &ethpbv1.EventHead{Slot: 2*slotsPerEpoch + 1, PreviousDutyDependentRoot: otherRoot, CurrentDutyDependentRoot: currentRoot},
+			wantEpoch:   2,
+			wantRefetch: true,
+		},
+		{
+			name:        "same epoch, current root changed",
+			head:        &ethpbv1.EventHead{Slot: 2*slotsPerEpoch + 1, PreviousDutyDependentRoot: previousRoot, CurrentDutyDependentRoot: otherRoot},
+			wantEpoch:   2,
+			wantRefetch: true,
+		},
+		{
+			name:        "next epoch, same root",
+			head:        &ethpbv1.EventHead{Slot: 3 * slotsPerEpoch, PreviousDutyDependentRoot: currentRoot, CurrentDutyDependentRoot: otherRoot},
+			wantRefetch: false,
+		},
+		{
+			name:        "next epoch, root changed",
+			head:        &ethpbv1.EventHead{Slot: 3 * slotsPerEpoch, PreviousDutyDependentRoot: otherRoot, CurrentDutyDependentRoot: otherRoot},
+			wantEpoch:   3,
+			wantRefetch: true,
+		},
+		{
+			name:        "other epoch",
+			head:        &ethpbv1.EventHead{Slot: 5 * slotsPerEpoch, PreviousDutyDependentRoot: otherRoot, CurrentDutyDependentRoot: otherRoot},
+			wantRefetch: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+			privKey, err := bls.RandKey()
+			require.NoError(t, err)
+			pubKey := [fieldparams.BLSPubkeyLength]byte{}
+			copy(pubKey[:], privKey.PublicKey().Marshal())
+			duties := &ethpb.DutiesResponse{
+				PreviousDutyDependentRoot: previousRoot,
+				CurrentDutyDependentRoot:  currentRoot,
+			}
+			v := validator{
+				validatorClient: client,
+				keyManager: &mockKeymanager{
+					keysMap: map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey{pubKey: privKey},
+				},
+				duties:      duties,
+				dutiesEpoch: 2,
+			}
+			refetched := &ethpb.DutiesResponse{
+				PreviousDutyDependentRoot: tt.head.PreviousDutyDependentRoot,
+				CurrentDutyDependentRoot:  tt.head.CurrentDutyDependentRoot,
+			}
+			if tt.wantRefetch {
+				client.EXPECT().GetDuties(
+					gomock.Any(),
+					&ethpb.DutiesRequest{Epoch: tt.wantEpoch, PublicKeys: [][]byte{pubKey[:]}},
+				).Return(refetched, nil)
+				client.EXPECT().SubscribeCommitteeSubnets(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+			}
+			require.NoError(t, v.HandleHeadEvent(context.Background(), tt.head))
+			if tt.wantRefetch {
+				assert.Equal(t, refetched, v.currentDuties())
+				assert.Equal(t, tt.wantEpoch, v.dutiesEpoch)
+			} else {
+				assert.Equal(t, duties, v.currentDuties())
+				assert.Equal(t, types.Epoch(2), v.dutiesEpoch)
+			}
+		})
+	}
+}
+
 type doppelGangerRequestMatcher struct {
 	req *ethpb.DoppelGangerRequest
 }
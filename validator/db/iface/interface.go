@@ -19,6 +19,7 @@ var _ = ValidatorDB(&kv.Store{})
 type ValidatorDB interface {
 	io.Closer
 	backup.BackupExporter
+	backup.BackupStreamer
 	DatabasePath() string
 	ClearDB() error
 	RunUpMigrations(ctx context.Context) error
@@ -3,6 +3,9 @@ package kv
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"path"
 	"time"
 
@@ -80,3 +83,35 @@ func createNestedBuckets(srcBucket, dstBucket *bolt.Bucket, fn func(k, v []byte)
 		return fn(k, v)
 	}
 }
+
+// BackupTo writes a consistent snapshot of the database to the given writer, as a bolt database
+// file, and returns the number of bytes written. Writes to the database are not blocked meanwhile.
+func (s *Store) BackupTo(ctx context.Context, w io.Writer) (int64, error) {
+	_, span := trace.StartSpan(ctx, "ValidatorDB.BackupTo")
+	defer span.End()
+
+	// The snapshot is written to a temporary file first, so that the read transaction is not held
+	// for as long as the writer takes to consume it, which would block the growth of the database file.
+	f, err := ioutil.TempFile(s.databasePath, "backup-*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close temporary backup file")
+		}
+		if err := os.Remove(f.Name()); err != nil {
+			log.WithError(err).Error("Could not remove temporary backup file")
+		}
+	}()
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		_, err := tx.WriteTo(f)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return io.Copy(w, f)
+}
@@ -353,6 +353,10 @@ func (c *ValidatorClient) registerPrometheusService(cliCtx *cli.Context) error {
 				Path:    "/db/backup",
 				Handler: backup.BackupHandler(c.db, cliCtx.String(cmd.BackupWebhookOutputDir.Name)),
 			},
+			prometheus.Handler{
+				Path:    "/db/backup/stream",
+				Handler: backup.StreamBackupHandler(c.db, "prysm_validatordb"),
+			},
 		)
 	}
 	service := prometheus.NewService(
@@ -403,10 +407,15 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		LogValidatorBalances:       logValidatorBalances,
 		EmitAccountMetrics:         emitAccountMetrics,
 		CertFlag:                   cert,
+		ClientCertFlag:             c.cliCtx.String(flags.ClientCertFlag.Name),
+		ClientKeyFlag:              c.cliCtx.String(flags.ClientKeyFlag.Name),
 		GraffitiFlag:               g.ParseHexGraffiti(graffiti),
 		GrpcMaxCallRecvMsgSizeFlag: maxCallRecvMsgSize,
+		GrpcMaxCallSendMsgSizeFlag: c.cliCtx.Int(cmd.GrpcMaxCallSendMsgSizeFlag.Name),
 		GrpcRetriesFlag:            grpcRetries,
 		GrpcRetryDelay:             grpcRetryDelay,
+		GrpcKeepaliveTime:          c.cliCtx.Duration(cmd.GrpcKeepaliveTimeFlag.Name),
+		GrpcKeepaliveTimeout:       c.cliCtx.Duration(cmd.GrpcKeepaliveTimeoutFlag.Name),
 		GrpcHeadersFlag:            c.cliCtx.String(flags.GrpcHeadersFlag.Name),
 		ValDB:                      c.db,
 		UseWeb:                     c.cliCtx.Bool(flags.EnableWebFlag.Name),
@@ -494,10 +503,15 @@ func (c *ValidatorClient) registerRPCService(cliCtx *cli.Context) error {
 		ValidatorMonitoringPort:  validatorMonitoringPort,
 		BeaconClientEndpoint:     beaconClientEndpoint,
 		ClientMaxCallRecvMsgSize: maxCallRecvMsgSize,
+		ClientMaxCallSendMsgSize: c.cliCtx.Int(cmd.GrpcMaxCallSendMsgSizeFlag.Name),
 		ClientGrpcRetries:        grpcRetries,
 		ClientGrpcRetryDelay:     grpcRetryDelay,
 		ClientGrpcHeaders:        strings.Split(grpcHeaders, ","),
 		ClientWithCert:           clientCert,
+		ClientWithClientCert:     c.cliCtx.String(flags.ClientCertFlag.Name),
+		ClientWithClientKey:      c.cliCtx.String(flags.ClientKeyFlag.Name),
+		ClientKeepaliveTime:      c.cliCtx.Duration(cmd.GrpcKeepaliveTimeFlag.Name),
+		ClientKeepaliveTimeout:   c.cliCtx.Duration(cmd.GrpcKeepaliveTimeoutFlag.Name),
 	})
 	return c.services.RegisterService(server)
 }
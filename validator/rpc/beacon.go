@@ -25,12 +25,15 @@ func (s *Server) registerBeaconClient() error {
 		grpc_prometheus.StreamClientInterceptor,
 		grpc_retry.StreamClientInterceptor(),
 	))
+	extraOpts := client.ConnectionDialOptions(s.clientMaxCallSendMsgSize, s.clientKeepaliveTime, s.clientKeepaliveTimeout)
 	dialOpts := client.ConstructDialOptions(
 		s.clientMaxCallRecvMsgSize,
 		s.clientWithCert,
+		s.clientWithClientCert,
+		s.clientWithClientKey,
 		s.clientGrpcRetries,
 		s.clientGrpcRetryDelay,
-		streamInterceptor,
+		append(extraOpts, streamInterceptor)...,
 	)
 	if dialOpts == nil {
 		return errors.New("no dial options for beacon chain gRPC client")
@@ -42,7 +45,7 @@ func (s *Server) registerBeaconClient() error {
 	if err != nil {
 		return errors.Wrapf(err, "could not dial endpoint: %s", s.beaconClientEndpoint)
 	}
-	if s.clientWithCert != "" {
+	if s.clientWithCert != "" || s.clientWithClientCert != "" {
 		log.Info("Established secure gRPC connection")
 	}
 	s.beaconChainClient = ethpb.NewBeaconChainClient(conn)
@@ -8,11 +8,17 @@ import (
 	"github.com/golang-jwt/jwt"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+// healthCheckMethodPrefix is the prefix of the methods of the grpc.health.v1 health service, which load
+// balancers and probes call without an auth token.
+var healthCheckMethodPrefix = "/" + healthpb.Health_ServiceDesc.ServiceName + "/"
+
 // JWTInterceptor is a gRPC unary interceptor to authorize incoming requests.
+// Health checks are not authorized, as they do not expose any sensitive data.
 func (s *Server) JWTInterceptor() grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -20,8 +26,10 @@ func (s *Server) JWTInterceptor() grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		if err := s.authorize(ctx); err != nil {
-			return nil, err
+		if !strings.HasPrefix(info.FullMethod, healthCheckMethodPrefix) {
+			if err := s.authorize(ctx); err != nil {
+				return nil, err
+			}
 		}
 		h, err := handler(ctx, req)
 		log.Debugf("Request - Method: %s, Error: %v\n", info.FullMethod, err)
@@ -67,3 +67,19 @@ func TestServer_JWTInterceptor_InvalidSigningType(t *testing.T) {
 	_, err := ss.validateJWT(token)
 	require.ErrorContains(t, "unexpected JWT signing method", err)
 }
+
+func TestServer_JWTInterceptor_HealthCheck(t *testing.T) {
+	s := Server{
+		jwtSecret: []byte("testKey"),
+	}
+	interceptor := s.JWTInterceptor()
+
+	unaryHandler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), map[string][]string{})
+	_, err := interceptor(ctx, "xyz", &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, unaryHandler)
+	require.NoError(t, err)
+	_, err = interceptor(ctx, "xyz", &grpc.UnaryServerInfo{FullMethod: "/ethereum.validator.accounts.v2.Health/GetVersion"}, unaryHandler)
+	require.ErrorContains(t, "Authorization token could not be found", err)
+}
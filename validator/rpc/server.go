@@ -11,6 +11,7 @@ import (
 	recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	grpcutil "github.com/prysmaticlabs/prysm/api/grpc"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"github.com/prysmaticlabs/prysm/io/logs"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
@@ -24,6 +25,7 @@ import (
 	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -35,10 +37,15 @@ type Config struct {
 	ValidatorMonitoringPort  int
 	BeaconClientEndpoint     string
 	ClientMaxCallRecvMsgSize int
+	ClientMaxCallSendMsgSize int
 	ClientGrpcRetries        uint
 	ClientGrpcRetryDelay     time.Duration
 	ClientGrpcHeaders        []string
 	ClientWithCert           string
+	ClientWithClientCert     string
+	ClientWithClientKey      string
+	ClientKeepaliveTime      time.Duration
+	ClientKeepaliveTimeout   time.Duration
 	Host                     string
 	Port                     string
 	CertFlag                 string
@@ -66,10 +73,15 @@ type Server struct {
 	cancel                    context.CancelFunc
 	beaconClientEndpoint      string
 	clientMaxCallRecvMsgSize  int
+	clientMaxCallSendMsgSize  int
 	clientGrpcRetries         uint
 	clientGrpcRetryDelay      time.Duration
 	clientGrpcHeaders         []string
 	clientWithCert            string
+	clientWithClientCert      string
+	clientWithClientKey       string
+	clientKeepaliveTime       time.Duration
+	clientKeepaliveTimeout    time.Duration
 	host                      string
 	port                      string
 	listener                  net.Listener
@@ -77,6 +89,7 @@ type Server struct {
 	withKey                   string
 	credentialError           error
 	grpcServer                *grpc.Server
+	healthServer              *health.Server
 	jwtSecret                 []byte
 	validatorService          *client.ValidatorService
 	syncChecker               client.SyncChecker
@@ -106,10 +119,15 @@ func NewServer(ctx context.Context, cfg *Config) *Server {
 		withKey:                  cfg.KeyFlag,
 		beaconClientEndpoint:     cfg.BeaconClientEndpoint,
 		clientMaxCallRecvMsgSize: cfg.ClientMaxCallRecvMsgSize,
+		clientMaxCallSendMsgSize: cfg.ClientMaxCallSendMsgSize,
 		clientGrpcRetries:        cfg.ClientGrpcRetries,
 		clientGrpcRetryDelay:     cfg.ClientGrpcRetryDelay,
 		clientGrpcHeaders:        cfg.ClientGrpcHeaders,
 		clientWithCert:           cfg.ClientWithCert,
+		clientWithClientCert:     cfg.ClientWithClientCert,
+		clientWithClientKey:      cfg.ClientWithClientKey,
+		clientKeepaliveTime:      cfg.ClientKeepaliveTime,
+		clientKeepaliveTimeout:   cfg.ClientKeepaliveTimeout,
 		valDB:                    cfg.ValDB,
 		validatorService:         cfg.ValidatorService,
 		syncChecker:              cfg.SyncChecker,
@@ -178,6 +196,8 @@ func (s *Server) Start() {
 	validatorpb.RegisterAccountsServer(s.grpcServer, s)
 	ethpbservice.RegisterKeyManagementServer(s.grpcServer, s)
 	validatorpb.RegisterSlashingProtectionServer(s.grpcServer, s)
+	// Register the grpc.health.v1 health service last, so that it reports the status of all the services above.
+	s.healthServer = grpcutil.NewHealthServer(s.grpcServer)
 
 	go func() {
 		if s.listener != nil {
@@ -203,6 +223,9 @@ func (s *Server) Start() {
 // Stop the gRPC server.
 func (s *Server) Stop() error {
 	s.cancel()
+	if s.healthServer != nil {
+		s.healthServer.Shutdown()
+	}
 	if s.listener != nil {
 		s.grpcServer.GracefulStop()
 		log.Debug("Initiated graceful stop of server")